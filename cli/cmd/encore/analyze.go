@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/pkg/svcadvisor"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Commands to analyze your app",
+}
+
+var (
+	analyzeWindow time.Duration
+	analyzeOpts   svcadvisor.Options
+	analyzeFormat = cmdutil.Oneof{
+		Value:     "markdown",
+		Allowed:   []string{"markdown", "json"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Output format",
+	}
+)
+
+var analyzeServicesCmd = &cobra.Command{
+	Use:   "services",
+	Short: "Suggests improvements to how your app is split into services",
+	Long: `Analyzes how your app is split into services and reports service
+boundaries that may be drawn poorly:
+
+  - cycles: services that depend on each other in a cycle
+  - god services: services with many more endpoints than the rest of the app,
+    or that both call and are called by most other services
+  - chatty pairs: services that call each other many times per request
+
+The analysis combines the API calls found in the source code with the call
+frequencies observed in the traces recorded while running the app with
+'encore run'. Each finding lists the evidence backing it, with links to the
+endpoint definitions and the ids of example traces.`,
+	Args: cobra.NoArgs,
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		md, usage, from, to := analyzeInputs()
+		report := &servicesReport{
+			From:     from,
+			To:       to,
+			Traces:   usage.Traces,
+			Findings: svcadvisor.Analyze(md, serviceUsage(usage), analyzeOpts),
+		}
+		if report.Findings == nil {
+			report.Findings = []svcadvisor.Finding{}
+		}
+		if analyzeFormat.Value == "json" {
+			writeJSON(report)
+		} else {
+			report.writeMarkdown(os.Stdout)
+		}
+	},
+}
+
+var analyzeAllow []string
+
+var analyzeUnusedCmd = &cobra.Command{
+	Use:   "unused",
+	Short: "Reports endpoints that nothing calls",
+	Long: `Reports the endpoints in your app that are not called by any other
+code in the app, not invoked by a cron job, and that have not been called from
+outside the app, such as through a generated client, according to the traces
+recorded while running the app with 'encore run'.
+
+Endpoints that are only meant to be called by external systems, such as webhooks,
+can be excluded with '--allow', given either a service name or a "service.Endpoint"
+pattern where '*' matches any sequence of characters (e.g. "billing.Webhook*").
+
+Exits with status 1 if any unused endpoints were found.`,
+	Args: cobra.NoArgs,
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		md, usage, from, to := analyzeInputs()
+		report := &unusedReport{
+			From:      from,
+			To:        to,
+			Traces:    usage.Traces,
+			Endpoints: svcadvisor.UnusedEndpoints(md, serviceUsage(usage), analyzeAllow),
+		}
+		if report.Endpoints == nil {
+			report.Endpoints = []svcadvisor.UnusedEndpoint{}
+		}
+		if analyzeFormat.Value == "json" {
+			writeJSON(report)
+		} else {
+			report.writeMarkdown(os.Stdout)
+		}
+		if len(report.Endpoints) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// analyzeInputs returns the app metadata and the usage observed
+// in the traces recorded within the analysis window.
+func analyzeInputs() (md *meta.Data, usage *daemonpb.UsageReportResponse, from, to time.Time) {
+	appRoot, workingDir := determineAppRoot()
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	metaResp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot:    appRoot,
+		WorkingDir: workingDir,
+		Environ:    os.Environ(),
+		Format:     daemonpb.DumpMetaRequest_FORMAT_PROTO,
+	})
+	if err != nil {
+		fatal(err)
+	}
+	md = &meta.Data{}
+	if err := proto.Unmarshal(metaResp.Meta, md); err != nil {
+		fatal("parse app metadata: ", err)
+	}
+
+	to = time.Now()
+	from = to.Add(-analyzeWindow)
+	usage, err = daemon.UsageReport(ctx, &daemonpb.UsageReportRequest{
+		AppRoot: appRoot,
+		From:    timestamppb.New(from),
+		To:      timestamppb.New(to),
+	})
+	if err != nil {
+		fatal("compute usage report: ", err)
+	}
+	return md, usage, from, to
+}
+
+func writeJSON(v any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fatal(err)
+	}
+}
+
+// serviceUsage converts the usage report into the observed usage
+// of the services, for use by the service advisor.
+func serviceUsage(resp *daemonpb.UsageReportResponse) svcadvisor.Usage {
+	usage := svcadvisor.Usage{
+		Requests: make(map[string]int64),
+		External: make(map[string]int64),
+	}
+	for _, u := range resp.Usages {
+		usage.Requests[u.Service] += u.Requests
+		if u.CallerKind == daemonpb.UsageReportResponse_CALLER_EXTERNAL {
+			usage.External[u.Service+"."+u.Endpoint] += u.Requests
+		} else {
+			usage.Calls = append(usage.Calls, svcadvisor.Call{
+				Caller:         u.Caller,
+				Service:        u.Service,
+				Endpoint:       u.Endpoint,
+				Count:          u.Requests,
+				ExampleTraceID: u.ExampleTraceId,
+			})
+		}
+	}
+	return usage
+}
+
+type servicesReport struct {
+	From     time.Time            `json:"from"`
+	To       time.Time            `json:"to"`
+	Traces   int32                `json:"traces"` // number of traces the call frequencies were computed from
+	Findings []svcadvisor.Finding `json:"findings"`
+}
+
+func (r *servicesReport) writeMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "# Service boundary report\n\n")
+	fmt.Fprintf(w, "Call frequencies from %s to %s (%d traces)\n\n", r.From.Format(time.RFC3339), r.To.Format(time.RFC3339), r.Traces)
+	if len(r.Findings) == 0 {
+		fmt.Fprintf(w, "No issues found.\n")
+		return
+	}
+
+	for _, f := range r.Findings {
+		fmt.Fprintf(w, "## %s: %s\n\n", f.Kind, strings.Join(f.Services, ", "))
+		fmt.Fprintf(w, "%s %s\n\n", f.Summary, f.Suggestion)
+		fmt.Fprintf(w, "Evidence:\n\n")
+		for _, ev := range f.Evidence {
+			fmt.Fprintf(w, "- %s", ev.Desc)
+			if ev.Location != "" {
+				fmt.Fprintf(w, " ([%s](%s))", ev.Location, locationLink(ev.Location))
+			}
+			if ev.TraceID != "" {
+				fmt.Fprintf(w, ", e.g. in trace `%s`", ev.TraceID)
+			}
+			fmt.Fprintf(w, "\n")
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+type unusedReport struct {
+	From      time.Time                   `json:"from"`
+	To        time.Time                   `json:"to"`
+	Traces    int32                       `json:"traces"` // number of traces external calls were computed from
+	Endpoints []svcadvisor.UnusedEndpoint `json:"endpoints"`
+}
+
+func (r *unusedReport) writeMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "# Unused endpoints\n\n")
+	fmt.Fprintf(w, "External calls from %s to %s (%d traces)\n\n", r.From.Format(time.RFC3339), r.To.Format(time.RFC3339), r.Traces)
+	if len(r.Endpoints) == 0 {
+		fmt.Fprintf(w, "No unused endpoints found.\n")
+		return
+	}
+
+	fmt.Fprintf(w, "| Endpoint | Access | Defined at |\n")
+	fmt.Fprintf(w, "|---|---|---|\n")
+	for _, ep := range r.Endpoints {
+		loc := ep.Location
+		if loc != "" {
+			loc = fmt.Sprintf("[%s](%s)", loc, locationLink(loc))
+		}
+		fmt.Fprintf(w, "| %s.%s | %s | %s |\n", ep.Service, ep.Endpoint, ep.Access, loc)
+	}
+}
+
+// locationLink converts a "path/to/file.go:line" location
+// into a relative markdown link to the line.
+func locationLink(loc string) string {
+	if i := strings.LastIndexByte(loc, ':'); i >= 0 {
+		return loc[:i] + "#L" + loc[i+1:]
+	}
+	return loc
+}
+
+func init() {
+	rootCmd.AddCommand(analyzeCmd)
+
+	for _, cmd := range []*cobra.Command{analyzeServicesCmd, analyzeUnusedCmd} {
+		cmd.Flags().DurationVar(&analyzeWindow, "window", 7*24*time.Hour, "The time window of traces to analyze, ending now")
+		analyzeFormat.AddFlag(cmd)
+	}
+	analyzeServicesCmd.Flags().Float64Var(&analyzeOpts.ChattyCallsPerRequest, "chatty-calls", 3, "The number of calls per request above which two services are considered chatty")
+	analyzeServicesCmd.Flags().IntVar(&analyzeOpts.GodServiceMinEndpoints, "god-endpoints", 20, "The number of endpoints from which a service is considered a god service")
+	analyzeCmd.AddCommand(analyzeServicesCmd)
+
+	analyzeUnusedCmd.Flags().StringSliceVar(&analyzeAllow, "allow", nil, "Services or endpoints to never report, such as external-only APIs (e.g. \"webhooks,billing.Stripe*\")")
+	analyzeCmd.AddCommand(analyzeUnusedCmd)
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/pkg/apichangelog"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "Commands to inspect your app's API",
+}
+
+var (
+	changelogSince          string
+	changelogUntil          string
+	changelogFailOnBreaking bool
+	changelogFormat         = cmdutil.Oneof{
+		Value:     "markdown",
+		Allowed:   []string{"markdown", "json"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Output format",
+	}
+)
+
+var apiChangelogCmd = &cobra.Command{
+	Use:   "changelog --since <ref>",
+	Short: "Describes the API changes made since a git revision",
+	Long: `Parses your app both at the given git revision and as it is now,
+and describes the changes made to its API in between, for use in release notes:
+
+  - endpoints that were added or removed
+  - changes to the method, path, access and streaming of endpoints
+  - fields added to, removed from or changed in request and response schemas
+  - Pub/Sub topics that were added or removed, and changes to their messages
+
+Each change is marked as breaking if it may break existing callers, such as
+removing an endpoint or response field, or adding a required request field.
+
+By default the app is compared with the current working tree, including any
+uncommitted changes. Use '--until' to compare with another revision instead.`,
+	Args: cobra.NoArgs,
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		appRoot, workingDir := determineAppRoot()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		daemon := setupDaemon(ctx)
+		old := parseRevision(ctx, daemon, appRoot, workingDir, changelogSince)
+		new := parseRevision(ctx, daemon, appRoot, workingDir, changelogUntil)
+
+		report := &changelogReport{
+			Since:     changelogSince,
+			Until:     changelogUntil,
+			Changelog: apichangelog.Compare(old, new),
+		}
+		if changelogFormat.Value == "json" {
+			writeJSON(report)
+		} else {
+			report.writeMarkdown(os.Stdout)
+		}
+		if changelogFailOnBreaking && report.Changelog.Breaking() {
+			os.Exit(1)
+		}
+	},
+}
+
+var apiDocsPrivate bool
+
+var apiDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Prints a reference of your app's endpoints",
+	Long: `Parses your app and prints a Markdown reference of its public and
+authenticated endpoints: how they're called, their documentation,
+their versions and whether they're deprecated.
+
+Use '--private' to include private endpoints.`,
+	Args: cobra.NoArgs,
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		appRoot, workingDir := determineAppRoot()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		daemon := setupDaemon(ctx)
+		md := parseRevision(ctx, daemon, appRoot, workingDir, "")
+		writeAPIDocs(os.Stdout, md, apiDocsPrivate)
+	},
+}
+
+// writeAPIDocs writes a Markdown reference of the endpoints in md,
+// excluding private endpoints unless private is true.
+func writeAPIDocs(w io.Writer, md *meta.Data, private bool) {
+	// The default version of a versioned endpoint is the oldest one.
+	defaultVersions := make(map[string]int32)
+	for _, svc := range md.Svcs {
+		for _, rpc := range svc.Rpcs {
+			if rpc.Version == 0 {
+				continue
+			}
+			path := docsPath(rpc.UnversionedPath)
+			if v, ok := defaultVersions[path]; !ok || rpc.Version < v {
+				defaultVersions[path] = rpc.Version
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "# API reference\n")
+	for _, svc := range md.Svcs {
+		rpcs := slices.DeleteFunc(slices.Clone(svc.Rpcs), func(rpc *meta.RPC) bool {
+			return rpc.AccessType == meta.RPC_PRIVATE && !private
+		})
+		if len(rpcs) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n## %s\n", svc.Name)
+		for _, rpc := range rpcs {
+			title := svc.Name + "." + rpc.Name
+			if rpc.Deprecated != nil {
+				title += " (deprecated)"
+			}
+			fmt.Fprintf(w, "\n### %s\n\n", title)
+
+			methods := slices.Clone(rpc.HttpMethods)
+			slices.Sort(methods)
+			fmt.Fprintf(w, "`%s %s` (%s)\n", strings.Join(methods, ", "), docsPath(rpc.Path), accessName(rpc.AccessType))
+
+			if rpc.Version > 0 {
+				path := docsPath(rpc.UnversionedPath)
+				fmt.Fprintf(w, "\nVersion %d of `%s`, also served on it with the `API-Version: %d` header", rpc.Version, path, rpc.Version)
+				if defaultVersions[path] == rpc.Version {
+					fmt.Fprintf(w, " or without an `API-Version` header")
+				}
+				fmt.Fprintf(w, ".\n")
+			}
+			if rpc.Deprecated != nil {
+				fmt.Fprintf(w, "\n> **Deprecated:** %s\n", *rpc.Deprecated)
+			}
+			if doc := docsText(rpc.GetDoc()); doc != "" {
+				fmt.Fprintf(w, "\n%s\n", doc)
+			}
+		}
+	}
+}
+
+// docsText returns the doc comment of an endpoint without
+// its deprecation notice, which is written separately.
+func docsText(doc string) string {
+	paras := strings.Split(strings.TrimSpace(doc), "\n\n")
+	paras = slices.DeleteFunc(paras, func(para string) bool {
+		return strings.HasPrefix(strings.TrimSpace(para), "Deprecated: ")
+	})
+	return strings.TrimSpace(strings.Join(paras, "\n\n"))
+}
+
+func docsPath(path *meta.Path) string {
+	var b strings.Builder
+	for _, s := range path.GetSegments() {
+		b.WriteByte('/')
+		switch s.Type {
+		case meta.PathSegment_PARAM:
+			b.WriteByte(':')
+		case meta.PathSegment_WILDCARD:
+			b.WriteByte('*')
+		case meta.PathSegment_FALLBACK:
+			b.WriteByte('!')
+		}
+		b.WriteString(s.Value)
+	}
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
+
+func accessName(access meta.RPC_AccessType) string {
+	switch access {
+	case meta.RPC_PUBLIC:
+		return "public"
+	case meta.RPC_AUTH:
+		return "auth"
+	default:
+		return "private"
+	}
+}
+
+// parseRevision parses the app at the given git revision,
+// or the current working tree if revision is empty.
+func parseRevision(ctx context.Context, daemon daemonpb.DaemonClient, appRoot, workingDir, revision string) *meta.Data {
+	resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot:    appRoot,
+		WorkingDir: workingDir,
+		Environ:    os.Environ(),
+		Format:     daemonpb.DumpMetaRequest_FORMAT_PROTO,
+		Revision:   revision,
+	})
+	if err != nil {
+		if revision != "" {
+			fatalf("parse app at revision %s: %v", revision, err)
+		}
+		fatal(err)
+	}
+	md := &meta.Data{}
+	if err := proto.Unmarshal(resp.Meta, md); err != nil {
+		fatal("parse app metadata: ", err)
+	}
+	return md
+}
+
+type changelogReport struct {
+	Since string `json:"since"`
+	Until string `json:"until,omitempty"` // empty for the working tree
+	*apichangelog.Changelog
+}
+
+func (r *changelogReport) writeMarkdown(w io.Writer) {
+	until := r.Until
+	if until == "" {
+		until = "working tree"
+	}
+	fmt.Fprintf(w, "# API changes\n\n")
+	fmt.Fprintf(w, "Changes from %s to %s\n\n", r.Since, until)
+	if r.Empty() {
+		fmt.Fprintf(w, "No API changes.\n")
+		return
+	}
+
+	var breaking []string
+	for _, e := range r.Endpoints {
+		name := e.Service + "." + e.Endpoint
+		switch e.Kind {
+		case apichangelog.Removed:
+			breaking = append(breaking, fmt.Sprintf("Removed endpoint `%s`", name))
+		case apichangelog.Changed:
+			for _, d := range e.Details {
+				if d.Breaking {
+					breaking = append(breaking, fmt.Sprintf("`%s`: %s", name, d.Desc))
+				}
+			}
+		}
+	}
+	for _, t := range r.Topics {
+		switch t.Kind {
+		case apichangelog.Removed:
+			breaking = append(breaking, fmt.Sprintf("Removed topic `%s`", t.Topic))
+		case apichangelog.Changed:
+			for _, d := range t.Details {
+				if d.Breaking {
+					breaking = append(breaking, fmt.Sprintf("Topic `%s`: %s", t.Topic, d.Desc))
+				}
+			}
+		}
+	}
+	if len(breaking) > 0 {
+		fmt.Fprintf(w, "## Breaking changes\n\n")
+		for _, b := range breaking {
+			fmt.Fprintf(w, "- %s\n", b)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	if len(r.Endpoints) > 0 {
+		fmt.Fprintf(w, "## Endpoints\n\n")
+		for _, kind := range []apichangelog.Kind{apichangelog.Added, apichangelog.Removed, apichangelog.Changed} {
+			first := true
+			for _, e := range r.Endpoints {
+				if e.Kind != kind {
+					continue
+				}
+				if first {
+					fmt.Fprintf(w, "### %s\n\n", kindTitle(kind))
+					first = false
+				}
+				fmt.Fprintf(w, "- `%s.%s` (`%s %s`, %s)\n", e.Service, e.Endpoint, e.Method, e.Path, e.Access)
+				writeDetails(w, e.Details)
+			}
+			if !first {
+				fmt.Fprintf(w, "\n")
+			}
+		}
+	}
+
+	if len(r.Topics) > 0 {
+		fmt.Fprintf(w, "## Pub/Sub topics\n\n")
+		for _, kind := range []apichangelog.Kind{apichangelog.Added, apichangelog.Removed, apichangelog.Changed} {
+			first := true
+			for _, t := range r.Topics {
+				if t.Kind != kind {
+					continue
+				}
+				if first {
+					fmt.Fprintf(w, "### %s\n\n", kindTitle(kind))
+					first = false
+				}
+				fmt.Fprintf(w, "- `%s`\n", t.Topic)
+				writeDetails(w, t.Details)
+			}
+			if !first {
+				fmt.Fprintf(w, "\n")
+			}
+		}
+	}
+}
+
+func writeDetails(w io.Writer, details []apichangelog.Detail) {
+	for _, d := range details {
+		if d.Breaking {
+			fmt.Fprintf(w, "  - %s (breaking)\n", d.Desc)
+		} else {
+			fmt.Fprintf(w, "  - %s\n", d.Desc)
+		}
+	}
+}
+
+func kindTitle(kind apichangelog.Kind) string {
+	switch kind {
+	case apichangelog.Added:
+		return "Added"
+	case apichangelog.Removed:
+		return "Removed"
+	default:
+		return "Changed"
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+
+	apiChangelogCmd.Flags().StringVar(&changelogSince, "since", "", "The git revision to describe changes since, such as a tag (required)")
+	apiChangelogCmd.Flags().StringVar(&changelogUntil, "until", "", "The git revision to describe changes until (defaults to the working tree)")
+	apiChangelogCmd.Flags().BoolVar(&changelogFailOnBreaking, "fail-on-breaking", false, "Exit with status 1 if there are breaking changes")
+	_ = apiChangelogCmd.MarkFlagRequired("since")
+	changelogFormat.AddFlag(apiChangelogCmd)
+	apiCmd.AddCommand(apiChangelogCmd)
+
+	apiDocsCmd.Flags().BoolVar(&apiDocsPrivate, "private", false, "Include private endpoints")
+	apiCmd.AddCommand(apiDocsCmd)
+}
@@ -2,8 +2,10 @@ package cmdutil
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,8 +15,12 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 
+	"encr.dev/cli/daemon/team"
+	"encr.dev/internal/env"
 	"encr.dev/internal/version"
 	"encr.dev/pkg/xos"
 	daemonpb "encr.dev/proto/encore/daemon"
@@ -40,7 +46,18 @@ func IsDaemonRunning(ctx context.Context) bool {
 
 // ConnectDaemon returns a client connection to the Encore daemon.
 // By default, it will start the daemon if it is not already running.
+//
+// If ENCORE_DAEMON_ADDR is set it instead connects to the remote
+// daemon running in shared mode at that address.
 func ConnectDaemon(ctx context.Context) daemonpb.DaemonClient {
+	if addr, ok := env.EncoreDaemonAddr().Get(); ok {
+		cc, err := dialRemoteDaemon(ctx, addr)
+		if err != nil {
+			Fatal("dialing remote daemon: ", err)
+		}
+		return daemonpb.NewDaemonClient(cc)
+	}
+
 	socketPath, err := daemonSockPath()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "fatal: ", err)
@@ -165,11 +182,67 @@ func dialDaemon(ctx context.Context, socketPath string) (*grpc.ClientConn, error
 	)
 }
 
+// dialRemoteDaemon dials a remote daemon running in shared mode.
+// The address is a URL with the scheme "https", or "http" for connecting without TLS.
+func dialRemoteDaemon(ctx context.Context, addr string) (*grpc.ClientConn, error) {
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid daemon address %q: must be a URL like https://host:port", addr)
+	}
+
+	var creds credentials.TransportCredentials
+	switch u.Scheme {
+	case "https":
+		creds = credentials.NewTLS(&tls.Config{})
+	case "http":
+		creds = insecure.NewCredentials()
+	default:
+		return nil, fmt.Errorf("invalid daemon address %q: unsupported scheme %q", addr, u.Scheme)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	auth := remoteDaemonAuth{
+		token:     env.EncoreDaemonToken(),
+		workspace: env.EncoreDaemonWorkspace().GetOrElse(""),
+		secure:    u.Scheme == "https",
+	}
+	return grpc.DialContext(ctx, u.Host,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(auth),
+		grpc.WithBlock(),
+		grpc.WithUnaryInterceptor(errInterceptor),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(16*1024*1024)),
+	)
+}
+
+// remoteDaemonAuth provides the credentials for connecting to a remote daemon.
+type remoteDaemonAuth struct {
+	token     string
+	workspace string
+	secure    bool
+}
+
+func (a remoteDaemonAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	md := map[string]string{"authorization": "Bearer " + a.token}
+	if a.workspace != "" {
+		if abs, err := filepath.Abs(a.workspace); err == nil {
+			md[team.WorkspaceHeader] = abs
+		}
+	}
+	return md, nil
+}
+
+func (a remoteDaemonAuth) RequireTransportSecurity() bool { return a.secure }
+
 func errInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	err := invoker(ctx, method, req, reply, cc, opts...)
 	if err != nil {
 		if st, ok := status.FromError(err); ok {
 			if st.Code() == codes.Unauthenticated {
+				if env.EncoreDaemonAddr().Present() {
+					Fatal("remote daemon: ", st.Message())
+				}
 				Fatal("not logged in: run 'encore auth login' first")
 			}
 			for _, detail := range st.Details() {
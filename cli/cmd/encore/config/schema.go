@@ -0,0 +1,149 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/pkg/configschema"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+var (
+	schemaService string
+	schemaOutput  string
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [--service=<name>] [--output=<file>]",
+	Short: "Outputs a JSON Schema of the app's service config",
+	Long: `Outputs a JSON Schema describing the config each service loads with config.Load,
+derived from the service's config types.
+
+The schema describes a JSON object with a property for each service that loads
+config, holding the service's config values. Use --service to output the schema
+of a single service's config instead.
+
+Editors supporting JSON Schema can use it to check config files as they are
+written, and ` + bt("encore config validate") + ` uses it to check them before deploying.`,
+	Args: cobra.NoArgs,
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		s := loadSchema()
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			cmdutil.Fatal(err)
+		}
+		data = append(data, '\n')
+
+		if schemaOutput == "" {
+			_, _ = os.Stdout.Write(data)
+		} else if err := os.WriteFile(schemaOutput, data, 0644); err != nil {
+			cmdutil.Fatal(err)
+		}
+	},
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate <file> [--service=<name>]",
+	Short: "Validates a config file against the app's config schema",
+	Long: `Validates a JSON or YAML file holding config values for the app's services
+against the schema of the services' config types, as output by ` + bt("encore config schema") + `.
+
+The file must hold an object with a property for each service, holding the
+service's config values. Use --service to validate a file holding the config
+values of a single service instead.
+
+Unknown fields, which the services would otherwise silently ignore, missing
+required fields and values of the wrong type are reported, and the command
+exits with status 1 if there are any.`,
+	Args: cobra.ExactArgs(1),
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		file := args[0]
+		data, err := os.ReadFile(file)
+		if err != nil {
+			cmdutil.Fatal(err)
+		}
+		if ext := strings.ToLower(filepath.Ext(file)); ext == ".yaml" || ext == ".yml" {
+			if data, err = yaml.YAMLToJSON(data); err != nil {
+				cmdutil.Fatalf("parse %s: %v", file, err)
+			}
+		}
+
+		errs, err := loadSchema().Validate(data)
+		if err != nil {
+			cmdutil.Fatalf("parse %s: %v", file, err)
+		}
+		if len(errs) == 0 {
+			fmt.Println(cmdutil.SuccessStyle.Render(fmt.Sprintf("%s matches the config schema.", file)))
+			return
+		}
+		for _, e := range errs {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", file, e.Error())
+		}
+		fmt.Fprintln(os.Stderr, cmdutil.ErrorStyle.Render(fmt.Sprintf("\nfound %d config %s", len(errs), plural(len(errs), "error"))))
+		os.Exit(1)
+	},
+}
+
+// loadSchema parses the app and returns the schema of its service config,
+// or of the service given with --service.
+func loadSchema() *configschema.Schema {
+	appRoot, relPath := cmdutil.AppRoot()
+	ctx := context.Background()
+	daemon := cmdutil.ConnectDaemon(ctx)
+	resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot:    appRoot,
+		WorkingDir: relPath,
+		Environ:    os.Environ(),
+		Format:     daemonpb.DumpMetaRequest_FORMAT_PROTO,
+	})
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	md := &meta.Data{}
+	if err := proto.Unmarshal(resp.Meta, md); err != nil {
+		cmdutil.Fatal("parse app metadata: ", err)
+	}
+
+	s := configschema.Generate(md)
+	if schemaService == "" {
+		return s
+	}
+	if svc := s.Service(schemaService); svc != nil {
+		return svc
+	}
+	if slices.ContainsFunc(md.Svcs, func(svc *meta.Service) bool { return svc.Name == schemaService }) {
+		cmdutil.Fatalf("service %q doesn't load any config", schemaService)
+	}
+	cmdutil.Fatalf("unknown service %q", schemaService)
+	return nil
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{schemaCmd, validateCmd} {
+		cmd.Flags().StringVar(&schemaService, "service", "", "only use the config of the given service")
+		configCmd.AddCommand(cmd)
+	}
+	schemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "write the schema to the given file instead of stdout")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -13,15 +14,21 @@ import (
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
-var daemonizeForeground bool
+var (
+	daemonizeForeground bool
+	daemonOpts          daemonpkg.Options
+)
 
 var daemonCmd = &cobra.Command{
 	Use:   "daemon",
 	Short: "Starts the encore daemon",
 	Run: func(cc *cobra.Command, args []string) {
 		if daemonizeForeground {
-			daemonpkg.Main()
+			daemonpkg.Main(daemonOpts)
 		} else {
+			if daemonOpts.SharedListenAddr != "" {
+				fatal("--shared requires running the daemon in the foreground (--foreground)")
+			}
 			if err := cmdutil.StartDaemonInBackground(context.Background()); err != nil {
 				fatal(err)
 			}
@@ -33,7 +40,12 @@ var daemonCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(daemonCmd)
 	daemonCmd.Flags().BoolVarP(&daemonizeForeground, "foreground", "f", false, "Start the daemon in the foreground")
+	daemonCmd.Flags().StringVar(&daemonOpts.SharedListenAddr, "shared", "", "Run in shared mode, serving remote connections on the given address (e.g. 0.0.0.0:9100)")
+	daemonCmd.Flags().StringVar(&daemonOpts.UsersFile, "users", "", "Path to the users file for shared mode")
+	daemonCmd.Flags().StringVar(&daemonOpts.TLSCertFile, "tls-cert", "", "TLS certificate file for shared mode")
+	daemonCmd.Flags().StringVar(&daemonOpts.TLSKeyFile, "tls-key", "", "TLS key file for shared mode")
 	daemonCmd.AddCommand(daemonEnvCmd)
+	daemonCmd.AddCommand(daemonURLsCmd)
 }
 
 func setupDaemon(ctx context.Context) daemonpb.DaemonClient {
@@ -50,3 +62,35 @@ var daemonEnvCmd = &cobra.Command{
 		}
 	},
 }
+
+var daemonURLsCmd = &cobra.Command{
+	Use:   "urls",
+	Short: "Prints the local URLs of the running app as JSON",
+	Long: `Prints the addresses of the running app's API gateway, services and
+local infrastructure resources as JSON.
+
+To keep the addresses stable across runs, configure fixed ports
+in the "local" section of encore.app.`,
+	Args: cobra.NoArgs,
+	Run: func(cc *cobra.Command, args []string) {
+		appRoot, _ := determineAppRoot()
+		ctx := context.Background()
+		daemon := setupDaemon(ctx)
+		resp, err := daemon.URLRegistry(ctx, &daemonpb.URLRegistryRequest{AppRoot: appRoot})
+		if err != nil {
+			fatal(err)
+		}
+
+		out, err := json.MarshalIndent(struct {
+			AppID     string            `json:"app_id"`
+			BaseURL   string            `json:"base_url"`
+			Services  map[string]string `json:"services"`
+			Gateways  map[string]string `json:"gateways"`
+			Resources map[string]string `json:"resources"`
+		}{resp.AppId, resp.BaseUrl, resp.Services, resp.Gateways, resp.Resources}, "", "  ")
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(string(out))
+	},
+}
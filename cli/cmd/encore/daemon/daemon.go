@@ -13,6 +13,7 @@ import (
 	"net/http/pprof"
 	"net/netip"
 	"os"
+	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"strconv"
@@ -32,10 +33,12 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 
 	"encr.dev/cli/daemon"
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/containers"
 	"encr.dev/cli/daemon/dash"
 	"encr.dev/cli/daemon/engine"
 	"encr.dev/cli/daemon/engine/trace2"
@@ -48,6 +51,8 @@ import (
 	"encr.dev/cli/daemon/sqldb"
 	"encr.dev/cli/daemon/sqldb/docker"
 	"encr.dev/cli/daemon/sqldb/external"
+	"encr.dev/cli/daemon/sqldb/native"
+	"encr.dev/cli/daemon/team"
 	"encr.dev/internal/conf"
 	"encr.dev/internal/env"
 	"encr.dev/pkg/eerror"
@@ -57,19 +62,36 @@ import (
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
+// Options configures the daemon.
+type Options struct {
+	// SharedListenAddr, if set, runs the daemon in shared mode, serving
+	// remote CLI connections on the given address in addition to the
+	// local daemon socket.
+	SharedListenAddr string
+
+	// UsersFile is the path to the JSON file configuring the
+	// users allowed to connect in shared mode.
+	UsersFile string
+
+	// TLSCertFile and TLSKeyFile configure TLS for the shared listener.
+	// If unset, remote connections are served without TLS.
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
 // Main runs the daemon.
-func Main() {
+func Main(opts Options) {
 	watcher.BumpRLimitSoftToHardLimit()
 
 	if err := redirectLogOutput(); err != nil {
 		log.Error().Err(err).Msg("could not setup daemon log file, skipping")
 	}
-	if err := runMain(); err != nil {
+	if err := runMain(opts); err != nil {
 		log.Fatal().Err(err).Msg("daemon failed")
 	}
 }
 
-func runMain() (err error) {
+func runMain(opts Options) (err error) {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT)
 	defer cancel()
 
@@ -83,6 +105,9 @@ func runMain() (err error) {
 	defer d.closeAll()
 
 	d.init(ctx)
+	if opts.SharedListenAddr != "" {
+		d.initShared(opts)
+	}
 	d.serve()
 
 	select {
@@ -102,6 +127,7 @@ type Daemon struct {
 	Debug         *retryingTCPListener
 	ObjectStorage *retryingTCPListener
 	MCP           *retryingTCPListener
+	Shared        net.Listener // nil unless running in shared mode
 	EncoreDB      *sql.DB
 
 	Apps          *apps.Manager
@@ -113,9 +139,14 @@ type Daemon struct {
 	MCPMgr        *mcp.Manager
 	PublicBuckets *objects.PublicBucketServer
 	Trace         trace2.Store
+	Recorder      *trace2.Recorder
 	Server        *daemon.Server
+	TeamAuth      *team.Authenticator
 	dev           bool // whether we're in development mode
 
+	// sharedCreds are the transport credentials for the shared listener.
+	sharedCreds credentials.TransportCredentials
+
 	// exit is a channel that shuts down the daemon when sent on.
 	// A nil error indicates graceful exit.
 	exit chan<- error
@@ -139,7 +170,7 @@ func (d *Daemon) init(ctx context.Context) {
 
 	// If ENCORE_SQLDB_HOST is set, use the external cluster instead of
 	// creating our own docker container cluster.
-	var sqldbDriver sqldb.Driver = &docker.Driver{}
+	sqldbDriver := d.localSQLDBDriver()
 	if host := os.Getenv("ENCORE_SQLDB_HOST"); host != "" {
 		sqldbDriver = &external.Driver{
 			Host:              host,
@@ -159,6 +190,8 @@ func (d *Daemon) init(ctx context.Context) {
 	traceStore := sqlite.New(d.EncoreDB)
 	go traceStore.CleanEvery(ctx, 1*time.Minute, 500, 100, 10000)
 	d.Trace = traceStore
+	d.Recorder = trace2.NewRecorder(d.Trace)
+	d.closeOnExit(d.Recorder)
 
 	d.RunMgr = &run.Manager{
 		RuntimePort:   d.Runtime.Port(),
@@ -183,11 +216,89 @@ func (d *Daemon) init(ctx context.Context) {
 	d.NS.RegisterDeletionHandler(d.RunMgr)
 	d.NS.RegisterDeletionHandler(d.ObjectsMgr)
 
-	d.Server = daemon.New(d.Apps, d.RunMgr, d.ClusterMgr, d.Secret, d.NS, d.MCPMgr)
+	d.Server = daemon.New(d.Apps, d.RunMgr, d.ClusterMgr, d.Secret, d.NS, d.MCPMgr, d.Trace)
+}
+
+// localSQLDBDriver returns the driver for running local SQL databases.
+//
+// ENCORE_SQLDB_DRIVER selects between running PostgreSQL in a container ("docker")
+// or using a locally installed PostgreSQL ("native"). If it's not set the native
+// driver is used when no container runtime is installed but PostgreSQL is.
+// The other local infrastructure always runs in-process, so it needs no such choice.
+func (d *Daemon) localSQLDBDriver() sqldb.Driver {
+	driver := os.Getenv("ENCORE_SQLDB_DRIVER")
+	binDir, hasPostgres := native.FindBinDir()
+	if driver == "" {
+		driver = "docker"
+		rt := containers.MustDetect()
+		if _, err := exec.LookPath(rt.Name()); err != nil && hasPostgres {
+			driver = "native"
+		}
+	}
+
+	switch driver {
+	case "docker":
+		rt, err := containers.Detect()
+		if err != nil {
+			fatal(err)
+		}
+		log.Info().Str("runtime", rt.Name()).Str("host", rt.HostAddress()).Msg("using container postgres cluster")
+		return &docker.Driver{Runtime: rt}
+	case "native":
+		dataDir, err := conf.DataDir()
+		if err != nil {
+			fatalf("unable to determine data directory: %v", err)
+		}
+		log.Info().Str("bin_dir", binDir).Msg("using native postgres cluster")
+		return &native.Driver{
+			BinDir:  binDir,
+			DataDir: filepath.Join(dataDir, "postgres"),
+		}
+	default:
+		fatalf("unknown ENCORE_SQLDB_DRIVER %q: must be \"docker\" or \"native\"", driver)
+		return nil
+	}
+}
+
+// initShared sets up serving remote connections in shared mode.
+func (d *Daemon) initShared(opts Options) {
+	if opts.UsersFile == "" {
+		fatalf("shared mode requires a users file")
+	}
+	cfg, err := team.LoadConfig(opts.UsersFile)
+	if err != nil {
+		fatal(err)
+	}
+	d.TeamAuth = team.NewAuthenticator(cfg, func(appID string) (string, error) {
+		app, err := d.Apps.FindLatestByPlatformOrLocalID(appID)
+		if err != nil {
+			return "", err
+		}
+		return app.Root(), nil
+	})
+
+	if opts.TLSCertFile != "" || opts.TLSKeyFile != "" {
+		d.sharedCreds, err = credentials.NewServerTLSFromFile(opts.TLSCertFile, opts.TLSKeyFile)
+		if err != nil {
+			fatalf("unable to load tls certificate: %v", err)
+		}
+	} else {
+		log.Warn().Msg("serving shared daemon without TLS; make sure the network is trusted")
+	}
+
+	ln, err := net.Listen("tcp", opts.SharedListenAddr)
+	if err != nil {
+		fatalf("unable to listen on %s: %v", opts.SharedListenAddr, err)
+	}
+	d.closeOnExit(ln)
+	d.Shared = ln
 }
 
 func (d *Daemon) serve() {
 	go d.serveDaemon()
+	if d.Shared != nil {
+		go d.serveShared()
+	}
 	go d.serveRuntime()
 	go d.serveDBProxy()
 	go d.serveDash()
@@ -259,10 +370,23 @@ func (d *Daemon) serveDaemon() {
 	d.exit <- srv.Serve(d.Daemon)
 }
 
+func (d *Daemon) serveShared() {
+	log.Info().Stringer("addr", d.Shared.Addr()).Msg("serving shared daemon")
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(d.TeamAuth.UnaryInterceptor(), ErrInterceptor),
+		grpc.StreamInterceptor(d.TeamAuth.StreamInterceptor()),
+	}
+	if d.sharedCreds != nil {
+		opts = append(opts, grpc.Creds(d.sharedCreds))
+	}
+	srv := grpc.NewServer(opts...)
+	daemonpb.RegisterDaemonServer(srv, d.Server)
+	d.exit <- srv.Serve(d.Shared)
+}
+
 func (d *Daemon) serveRuntime() {
 	log.Info().Stringer("addr", d.Runtime.Addr()).Msg("serving runtime")
-	rec := trace2.NewRecorder(d.Trace)
-	srv := engine.NewServer(d.RunMgr, rec)
+	srv := engine.NewServer(d.RunMgr, d.Apps, d.Recorder)
 	d.exit <- http.Serve(d.Runtime, srv)
 }
 
@@ -444,9 +568,11 @@ func (d *Daemon) closeOnExit(c io.Closer) {
 	d.close = append(d.close, c)
 }
 
+// closeAll closes the things to close in reverse order, so that things
+// are closed before what they depend on, like the trace recorder before the database.
 func (d *Daemon) closeAll() {
-	for _, c := range d.close {
-		_ = c.Close()
+	for i := len(d.close) - 1; i >= 0; i-- {
+		_ = d.close[i].Close()
 	}
 }
 
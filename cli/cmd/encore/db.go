@@ -3,20 +3,29 @@ package main
 import (
 	"context"
 	"fmt"
+	"go/parser"
+	"go/token"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/logrusorgru/aurora/v3"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/daemon/containers"
 	"encr.dev/cli/daemon/sqldb/docker"
+	"encr.dev/cli/daemon/sqldb/native"
+	"encr.dev/pkg/idents"
+	"encr.dev/pkg/pgschema"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
@@ -81,6 +90,40 @@ var dbResetCmd = &cobra.Command{
 	},
 }
 
+var seedForce bool
+
+var dbSeedCmd = &cobra.Command{
+	Use:   "seed [database-names...]",
+	Short: "Applies the seed data for the given databases, or all databases if none are given",
+	Long: `Applies the seed data for the given databases.
+
+Seed data is defined by a "seed" directory in the database's package,
+containing SQL files that are applied in lexical order after migrations,
+and optionally a Go program (package main) that is run after the SQL files.
+
+Each SQL seed file is only applied once, unless --force is given.
+Seeds are also applied automatically by 'encore run' and 'encore test'.`,
+
+	Run: func(command *cobra.Command, args []string) {
+		appRoot, relPath := determineAppRoot()
+		ctx := context.Background()
+		daemon := setupDaemon(ctx)
+		stream, err := daemon.DBSeed(ctx, &daemonpb.DBSeedRequest{
+			AppRoot:       appRoot,
+			WorkingDir:    relPath,
+			DatabaseNames: args,
+			ClusterType:   dbClusterType(),
+			Namespace:     nonZeroPtr(nsName),
+			Force:         seedForce,
+			Environ:       os.Environ(),
+		})
+		if err != nil {
+			fatal("seed databases: ", err)
+		}
+		os.Exit(cmdutil.StreamCommandOutput(stream, nil))
+	},
+}
+
 var dbEnv string
 
 var dbShellCmd = &cobra.Command{
@@ -143,23 +186,36 @@ when using tools like Prisma.
 		}
 
 		// If we have the psql binary, use that.
-		// Otherwise fall back to docker.
+		// Otherwise fall back to running it in a container.
 		var cmd *exec.Cmd
-		if p, err := exec.LookPath("psql"); err == nil {
+		if p, ok := native.FindBinary("psql"); ok {
 			cmd = exec.Command(p, resp.Dsn)
 		} else {
-			fmt.Fprintln(os.Stderr, "encore: no 'psql' executable found in $PATH; using docker to run 'psql' instead.\n\nNote: install psql to hide this message.")
+			rt, err := containers.Detect()
+			if err != nil {
+				fatal(err)
+			}
+			fmt.Fprintf(os.Stderr, "encore: no 'psql' executable found in $PATH; using %s to run 'psql' instead.\n\nNote: install psql to hide this message.\n", rt.Name())
 			dsn := resp.Dsn
 
 			if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
 				// Docker for {Mac, Windows}'s networking setup requires
-				// using "host.docker.internal" instead of "localhost"
+				// using "host.docker.internal" instead of "localhost",
+				// and Podman uses "host.containers.internal".
+				hostname := "host.docker.internal"
+				if rt.Name() == "podman" {
+					hostname = "host.containers.internal"
+				}
 				for _, rep := range []string{"localhost", "127.0.0.1"} {
-					dsn = strings.Replace(dsn, rep, "host.docker.internal", -1)
+					dsn = strings.Replace(dsn, rep, hostname, -1)
 				}
 			}
 
-			cmd = exec.Command("docker", "run", "-it", "--rm", "--network=host", docker.Image, "psql", dsn)
+			image := docker.Image
+			if rt.Name() == "podman" {
+				image = "docker.io/" + image
+			}
+			cmd = rt.Command(context.Background(), "run", "-it", "--rm", "--network=host", image, "psql", dsn)
 		}
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -291,6 +347,225 @@ when using tools like Prisma.
 	},
 }
 
+var (
+	migratePlan       bool
+	migrateNoValidate bool
+)
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate [<database-names...>] --plan [--env=<name>] [--test|--shadow]",
+	Short: "Shows the pending database migrations without applying them",
+	Long: `Shows which pending migrations would run against an environment,
+without applying anything. Defaults to all databases in the local environment.
+
+The pending migrations are validated against the live schema by applying
+them in a transaction that is always rolled back. Use --no-validate to skip this.
+
+Any drift between the applied migrations and the migrations in the
+application is reported, and results in a non-zero exit code.
+
+--test and --shadow imply --env=local.
+`,
+
+	DisableFlagsInUseLine: true,
+	Run: func(command *cobra.Command, args []string) {
+		if !migratePlan {
+			fatal("migrations are applied automatically by 'encore run' and when deploying; use --plan to preview pending migrations")
+		}
+
+		appRoot, _ := determineAppRoot()
+		ctx := context.Background()
+		daemon := setupDaemon(ctx)
+
+		if testDB || shadowDB {
+			dbEnv = "local"
+		}
+
+		resp, err := daemon.DBMigratePlan(ctx, &daemonpb.DBMigratePlanRequest{
+			AppRoot:       appRoot,
+			EnvName:       dbEnv,
+			DatabaseNames: args,
+			ClusterType:   dbClusterType(),
+			Namespace:     nonZeroPtr(nsName),
+			Validate:      !migrateNoValidate,
+		})
+		if err != nil {
+			st, ok := status.FromError(err)
+			if ok && st.Code() == codes.NotFound {
+				fatalf("no such database found: %s", strings.Join(args, ", "))
+			}
+			fatal("plan migrations: ", err)
+		}
+
+		failed := false
+		for i, db := range resp.Databases {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(aurora.Bold("Database " + db.DbName))
+			if !db.Exists {
+				fmt.Println(aurora.Gray(12, "  database does not exist yet; all migrations will be applied"))
+			}
+
+			if len(db.Pending) == 0 {
+				fmt.Println("  no pending migrations")
+			}
+			for _, m := range db.Pending {
+				fmt.Printf("  %s\n", m.Filename)
+				for _, stmt := range m.DestructiveStatements {
+					fmt.Printf("    %s %s\n", aurora.Yellow("destructive:"), stmt)
+				}
+				switch {
+				case m.ValidationError != nil:
+					failed = true
+					fmt.Printf("    %s %s\n", aurora.Red("validation failed:"), *m.ValidationError)
+				case m.Validated:
+					fmt.Printf("    %s\n", aurora.Green("validated"))
+				}
+			}
+
+			if len(db.Drift) > 0 {
+				failed = true
+				fmt.Println(aurora.Red("  drift detected:"))
+				for _, d := range db.Drift {
+					fmt.Printf("    - %s\n", d)
+				}
+			}
+		}
+
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+var (
+	importSchemaDBName      string
+	importSchemaDir         string
+	importSchemaMarkApplied bool
+)
+
+var dbImportSchemaCmd = &cobra.Command{
+	Use:   "import-schema <connection-uri> [--name=<db-name>] [--dir=<dir>] [--mark-applied]",
+	Short: "Creates an initial migration from the schema of an existing database",
+	Long: `Introspects the schema of an existing Postgres database and writes it as the
+initial migration of an Encore database, for moving a running system onto
+Encore-managed migrations. Only the schema is imported, not the data.
+
+The migration is written to the "migrations" directory of --dir (defaults to
+the current directory), together with a db.go file declaring the database.
+The database name defaults to the name of the introspected database.
+
+The existing database already has the schema, so the initial migration must be
+marked as applied in it before Encore manages its migrations. Use --mark-applied
+to do so after importing the schema.`,
+	Args: cobra.ExactArgs(1),
+
+	DisableFlagsInUseLine: true,
+	Run: func(command *cobra.Command, args []string) {
+		cfg, err := pgx.ParseConfig(args[0])
+		if err != nil {
+			fatal("invalid connection uri: ", err)
+		}
+		dbName := importSchemaDBName
+		if dbName == "" {
+			dbName = idents.Convert(cfg.Database, idents.SnakeCase)
+		}
+		if !dbNameRe.MatchString(dbName) || len(dbName) > 63 {
+			fatalf("invalid database name %q: must be snake_case; use --name to set the name", dbName)
+		}
+
+		dir, err := filepath.Abs(importSchemaDir)
+		if err != nil {
+			fatal(err)
+		}
+		migrationsDir := filepath.Join(dir, "migrations")
+		if files, _ := filepath.Glob(filepath.Join(migrationsDir, "*.sql")); len(files) > 0 {
+			fatalf("%s already contains migrations", migrationsDir)
+		}
+
+		ctx := context.Background()
+		conn, err := pgx.ConnectConfig(ctx, cfg)
+		if err != nil {
+			fatal("connect to database: ", err)
+		}
+		defer func() { _ = conn.Close(ctx) }()
+
+		schema, err := pgschema.Introspect(ctx, conn)
+		if err != nil {
+			fatal("introspect schema: ", err)
+		}
+
+		header := fmt.Sprintf("-- Initial schema of the %s database, imported from %s:%d/%s\n-- by 'encore db import-schema'.\n\n",
+			dbName, cfg.Host, cfg.Port, cfg.Database)
+		migration := filepath.Join(migrationsDir, "1_initial_schema.up.sql")
+		if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+			fatal(err)
+		}
+		if err := os.WriteFile(migration, []byte(header+schema.SQL()), 0644); err != nil {
+			fatal(err)
+		}
+		fmt.Printf("Wrote %s (%d tables, %d views).\n", relToCwd(migration), len(schema.Tables), len(schema.Views))
+
+		decl := fmt.Sprintf("var DB = sqldb.NewDatabase(%q, sqldb.DatabaseConfig{\n\tMigrations: \"./migrations\",\n})\n", dbName)
+		dbFile := filepath.Join(dir, "db.go")
+		if _, err := os.Stat(dbFile); err == nil {
+			fmt.Printf("\n%s already exists; declare the database in the package yourself:\n\n%s", relToCwd(dbFile), decl)
+		} else {
+			src := fmt.Sprintf("package %s\n\nimport \"encore.dev/storage/sqldb\"\n\n%s", goPackageName(dir), decl)
+			if err := os.WriteFile(dbFile, []byte(src), 0644); err != nil {
+				fatal(err)
+			}
+			fmt.Printf("Wrote %s.\n", relToCwd(dbFile))
+		}
+
+		if len(schema.Warnings) > 0 {
+			fmt.Println(aurora.Yellow("\nThe following objects were not imported and must be migrated by hand:"))
+			for _, w := range schema.Warnings {
+				fmt.Printf("  - %s\n", w)
+			}
+		}
+
+		markApplied := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL);\n"+
+			"INSERT INTO %[1]s (version, dirty) VALUES (1, false) ON CONFLICT (version) DO NOTHING;", pgschema.MigrationsTable)
+		if !importSchemaMarkApplied {
+			fmt.Printf("\nBefore Encore manages the existing database, mark the initial migration as\n"+
+				"applied in it, or rerun with --mark-applied:\n\n%s\n", markApplied)
+			return
+		}
+		if _, err := conn.Exec(ctx, markApplied); err != nil {
+			fatal("mark migration as applied: ", err)
+		}
+		fmt.Println(aurora.Green("\nMarked the initial migration as applied in the existing database."))
+	},
+}
+
+// dbNameRe matches valid Encore database names.
+var dbNameRe = regexp.MustCompile(`^[a-z]([_a-z0-9]*[a-z0-9])?$`)
+
+// goPackageName returns the name of the Go package in dir,
+// or a package name derived from the directory name if there is none.
+func goPackageName(dir string) string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly)
+		if err == nil && !strings.HasSuffix(f.Name.Name, "_test") {
+			return f.Name.Name
+		}
+	}
+	return strings.ReplaceAll(idents.Convert(filepath.Base(dir), idents.SnakeCase), "_", "")
+}
+
+// relToCwd returns path relative to the working directory, if possible.
+func relToCwd(path string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
+
 func init() {
 	rootCmd.AddCommand(dbCmd)
 
@@ -300,6 +575,11 @@ func init() {
 	dbResetCmd.Flags().BoolVar(&shadowDB, "shadow", false, "Reset databases in the shadow cluster instead")
 	dbCmd.AddCommand(dbResetCmd)
 
+	dbSeedCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+	dbSeedCmd.Flags().BoolVar(&seedForce, "force", false, "Apply all seed files, including previously applied ones")
+	dbSeedCmd.Flags().BoolVarP(&testDB, "test", "t", false, "Seed databases in the test cluster instead")
+	dbCmd.AddCommand(dbSeedCmd)
+
 	dbShellCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
 	dbShellCmd.Flags().StringVarP(&dbEnv, "env", "e", "local", "Environment name to connect to (such as \"prod\")")
 	dbShellCmd.Flags().BoolVarP(&testDB, "test", "t", false, "Connect to the integration test database (implies --env=local)")
@@ -330,6 +610,19 @@ func init() {
 	dbConnURICmd.Flags().BoolVar(&superuser, "superuser", false, "Connect as a superuser")
 	dbConnURICmd.MarkFlagsMutuallyExclusive("write", "admin", "superuser")
 	dbCmd.AddCommand(dbConnURICmd)
+
+	dbMigrateCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+	dbMigrateCmd.Flags().StringVarP(&dbEnv, "env", "e", "local", "Environment name to plan migrations for (such as \"prod\")")
+	dbMigrateCmd.Flags().BoolVarP(&testDB, "test", "t", false, "Plan migrations for the integration test database (implies --env=local)")
+	dbMigrateCmd.Flags().BoolVar(&shadowDB, "shadow", false, "Plan migrations for the shadow database (implies --env=local)")
+	dbMigrateCmd.Flags().BoolVar(&migratePlan, "plan", false, "Show the pending migrations without applying them")
+	dbMigrateCmd.Flags().BoolVar(&migrateNoValidate, "no-validate", false, "Skip validating pending migrations against the live schema")
+	dbCmd.AddCommand(dbMigrateCmd)
+
+	dbImportSchemaCmd.Flags().StringVar(&importSchemaDBName, "name", "", "Name of the Encore database (defaults to the name of the introspected database)")
+	dbImportSchemaCmd.Flags().StringVar(&importSchemaDir, "dir", ".", "Directory of the package declaring the database")
+	dbImportSchemaCmd.Flags().BoolVar(&importSchemaMarkApplied, "mark-applied", false, "Mark the initial migration as applied in the introspected database")
+	dbCmd.AddCommand(dbImportSchemaCmd)
 }
 
 func dbClusterType() daemonpb.DBClusterType {
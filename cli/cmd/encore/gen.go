@@ -5,15 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
 	"encr.dev/cli/internal/manifest"
+	"encr.dev/pkg/alertgen"
 	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/clientgen"
+	"encr.dev/pkg/dashgen"
 	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 func init() {
@@ -50,6 +56,7 @@ Supported language codes are:
   javascript: A JavaScript client using the Fetch API
   go: A Go client using net/http"
   openapi: An OpenAPI specification (EXPERIMENTAL)
+  proto: A protobuf definition of the endpoints exposed over gRPC
 
 By default all services with a non-private API endpoint are included.
 To further narrow down the services to generate, use the '--services' flag.
@@ -109,7 +116,7 @@ To further narrow down the services to generate, use the '--services' flag.
 				// Validate the user input for the language
 				l, err := clientgen.GetLang(lang)
 				if err != nil {
-					fatal(fmt.Sprintf("%s: supported languages are `typescript`, `javascript`, `go` and `openapi`", err))
+					fatal(fmt.Sprintf("%s: supported languages are `typescript`, `javascript`, `go`, `openapi` and `proto`", err))
 				}
 				lang = string(l)
 			}
@@ -177,19 +184,168 @@ which may require the user-facing wrapper code to be manually generated.`,
 		},
 	}
 
+	var (
+		alertsFormat = cmdutil.Oneof{
+			Value:     "prometheus",
+			Allowed:   []string{"prometheus", "grafana"},
+			Flag:      "format",
+			FlagShort: "f",
+			Desc:      "Output format",
+		}
+		alertsDatasource string
+		alertsFolder     string
+	)
+
+	genAlertsCmd := &cobra.Command{
+		Use:   "alerts [--format=prometheus|grafana] [--output=<file>]",
+		Short: "Generates alerting rules from the alert rules declared in your app",
+		Long: `Generates alerting rules from the alert rules declared in your app
+using the encore.dev/alerts package.
+
+Supported formats are:
+  prometheus: A Prometheus alerting rules file
+  grafana: A Grafana alert rule provisioning file, querying the Prometheus
+           data source given by '--datasource'`,
+		Args: cobra.NoArgs,
+
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if alertsFormat.Value == "grafana" && alertsDatasource == "" {
+				fatal("specify the UID of the Prometheus data source to query with --datasource.")
+			}
+
+			appRoot, workingDir := determineAppRoot()
+			ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+			defer cancel()
+
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+				AppRoot:    appRoot,
+				WorkingDir: workingDir,
+				Environ:    os.Environ(),
+				Format:     daemonpb.DumpMetaRequest_FORMAT_PROTO,
+			})
+			if err != nil {
+				fatal(err)
+			}
+			var md meta.Data
+			if err := proto.Unmarshal(resp.Meta, &md); err != nil {
+				fatal("parse app metadata: ", err)
+			}
+
+			var out []byte
+			if alertsFormat.Value == "grafana" {
+				out, err = alertgen.GenGrafana(&md, alertgen.GrafanaOptions{
+					DatasourceUID: alertsDatasource,
+					Folder:        alertsFolder,
+				})
+			} else {
+				out, err = alertgen.GenPrometheus(&md)
+			}
+			if err != nil {
+				fatal(err)
+			}
+
+			if output == "" {
+				_, _ = os.Stdout.Write(out)
+			} else if err := os.WriteFile(output, out, 0644); err != nil {
+				fatal(err)
+			}
+		},
+	}
+
+	var (
+		dashboardsDatasource string
+		dashboardsOutput     string
+		dashboardsServices   []string
+	)
+
+	genDashboardsCmd := &cobra.Command{
+		Use:   "dashboards [--datasource=<uid>] [--output=<dir>] [--services=foo,bar]",
+		Short: "Generates Grafana dashboards for the services in your app",
+		Long: `Generates a Grafana dashboard for each service in your app.
+
+The dashboards chart the metrics reported by the Encore runtime: API request
+rates, error rates and latencies, database connection pools, Pub/Sub
+subscription lag, and cache hit rates. They query a Prometheus data source,
+selected with a dashboard variable that defaults to '--datasource'.
+
+Each dashboard is written to <output>/<service>.json.`,
+		Args: cobra.NoArgs,
+
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, workingDir := determineAppRoot()
+			ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+			defer cancel()
+
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+				AppRoot:    appRoot,
+				WorkingDir: workingDir,
+				Environ:    os.Environ(),
+				Format:     daemonpb.DumpMetaRequest_FORMAT_PROTO,
+			})
+			if err != nil {
+				fatal(err)
+			}
+			var md meta.Data
+			if err := proto.Unmarshal(resp.Meta, &md); err != nil {
+				fatal("parse app metadata: ", err)
+			}
+
+			dashboards, err := dashgen.Gen(&md, dashgen.Options{DatasourceUID: dashboardsDatasource})
+			if err != nil {
+				fatal(err)
+			}
+			if len(dashboardsServices) > 0 {
+				dashboards = slices.DeleteFunc(dashboards, func(d dashgen.Dashboard) bool {
+					return !slices.Contains(dashboardsServices, d.Service)
+				})
+			}
+			if len(dashboards) == 0 {
+				fatal("no services to generate dashboards for.")
+			}
+
+			if err := os.MkdirAll(dashboardsOutput, 0755); err != nil {
+				fatal(err)
+			}
+			for _, d := range dashboards {
+				path := filepath.Join(dashboardsOutput, d.Service+".json")
+				if err := os.WriteFile(path, d.JSON, 0644); err != nil {
+					fatal(err)
+				}
+				fmt.Fprintf(os.Stderr, "wrote %s\n", path)
+			}
+		},
+	}
+
 	genCmd.AddCommand(genClientCmd)
 	genCmd.AddCommand(genWrappersCmd)
+	genCmd.AddCommand(genAlertsCmd)
+	genCmd.AddCommand(genDashboardsCmd)
+
+	genDashboardsCmd.Flags().StringVar(&dashboardsDatasource, "datasource", "", "The UID of the Prometheus data source to select by default")
+	genDashboardsCmd.Flags().StringVarP(&dashboardsOutput, "output", "o", "dashboards", "The directory to write the generated dashboards to")
+	genDashboardsCmd.Flags().StringSliceVarP(&dashboardsServices, "services", "s", nil, "The names of the services to generate dashboards for (defaults to all services)")
+	_ = genDashboardsCmd.MarkFlagDirname("output")
+
+	alertsFormat.AddFlag(genAlertsCmd)
+	genAlertsCmd.Flags().StringVarP(&output, "output", "o", "", "The filename to write the generated rules to")
+	genAlertsCmd.Flags().StringVar(&alertsDatasource, "datasource", "", "The UID of the Grafana data source to query (for --format=grafana)")
+	genAlertsCmd.Flags().StringVar(&alertsFolder, "folder", "Encore", "The Grafana folder to provision the rules in (for --format=grafana)")
 
-	genClientCmd.Flags().StringVarP(&lang, "lang", "l", "", "The language to generate code for (\"typescript\", \"javascript\", \"go\", and \"openapi\" are supported)")
+	genClientCmd.Flags().StringVarP(&lang, "lang", "l", "", "The language to generate code for (\"typescript\", \"javascript\", \"go\", \"openapi\", and \"proto\" are supported)")
 	_ = genClientCmd.RegisterFlagCompletionFunc("lang", cmdutil.AutoCompleteFromStaticList(
 		"typescript\tA TypeScript client using the in-browser Fetch API",
 		"javascript\tA JavaScript client using the in-browser Fetch API",
 		"go\tA Go client using net/http",
 		"openapi\tAn OpenAPI specification",
+		"proto\tA protobuf definition of the endpoints exposed over gRPC",
 	))
 
 	genClientCmd.Flags().StringVarP(&output, "output", "o", "", "The filename to write the generated client code to")
-	_ = genClientCmd.MarkFlagFilename("output", "go", "ts", "tsx", "js", "jsx")
+	_ = genClientCmd.MarkFlagFilename("output", "go", "ts", "tsx", "js", "jsx", "proto")
 
 	genClientCmd.Flags().StringVarP(&envName, "env", "e", "local", "The environment to fetch the API for (defaults to the local environment)")
 	_ = genClientCmd.RegisterFlagCompletionFunc("env", cmdutil.AutoCompleteEnvSlug)
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/httpimport"
+)
+
+var (
+	importDryRun bool
+	importForce  bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [dir]",
+	Short: "Generates Encore endpoints for an existing net/http, chi or gin service",
+	Long: `Analyzes the existing Go HTTP service in dir (defaults to the current directory)
+and generates Encore endpoints for the routes it registers with net/http, chi or gin.
+
+Each package registering routes becomes an Encore service, with its endpoints
+generated to ` + httpimport.GeneratedFile + ` in the package's directory.
+Routes registered in a main package are generated to an "api" service next to it.
+
+Handlers that decode and encode named JSON types become typed endpoints, and other
+handler functions are wrapped as raw endpoints. Handlers that can't be wrapped
+automatically, such as function literals and methods, get a raw endpoint marked
+with a TODO to wire up by hand. Middleware isn't carried over and is reported as
+a warning, so it can be reimplemented with Encore middleware.`,
+	Args: cobra.MaximumNArgs(1),
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		runImport(dir)
+	},
+}
+
+func runImport(dir string) {
+	pkgs, err := httpimport.Analyze(dir)
+	if err != nil {
+		fatal("analyze: ", err)
+	} else if len(pkgs) == 0 {
+		fatalf("no routes registered with net/http, chi or gin found in %s", dir)
+	}
+
+	// Check for existing files before writing anything.
+	if !importDryRun && !importForce {
+		for _, pkg := range pkgs {
+			path := filepath.Join(dir, filepath.FromSlash(pkg.ServiceDir), httpimport.GeneratedFile)
+			if _, err := os.Stat(path); err == nil {
+				fatalf("%s already exists; rerun with --force to overwrite it", path)
+			}
+		}
+	}
+
+	counts := make(map[httpimport.Conversion]int)
+	for _, pkg := range pkgs {
+		src, err := pkg.Generate()
+		if err != nil {
+			fatalf("generate endpoints for %s: %v", pkg.Dir, err)
+		}
+		path := filepath.Join(filepath.FromSlash(pkg.ServiceDir), httpimport.GeneratedFile)
+		if !importDryRun {
+			out := filepath.Join(dir, path)
+			if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+				fatal(err)
+			} else if err := os.WriteFile(out, src, 0644); err != nil {
+				fatal(err)
+			}
+		}
+
+		fmt.Printf("%s %s\n", cmdutil.InputStyle.Render("service "+pkg.ServiceName()),
+			cmdutil.DescStyle.Render("("+path+")"))
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, r := range pkg.Routes {
+			counts[r.Conversion]++
+			method := r.Method
+			if method == "" {
+				method = "*"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", method, r.Path, r.Endpoint, r.Conversion, r.Reason)
+		}
+		_ = tw.Flush()
+		for _, w := range pkg.Warnings {
+			fmt.Printf("  %s %s\n", cmdutil.ErrorStyle.Render("warning:"), w)
+		}
+		fmt.Println()
+	}
+
+	verb := "Generated"
+	if importDryRun {
+		verb = "Would generate"
+	}
+	fmt.Printf("%s %d typed and %d raw endpoints, and %d endpoints to wire up by hand (marked TODO).\n",
+		verb, counts[httpimport.Typed], counts[httpimport.Raw], counts[httpimport.Manual])
+	if importDryRun {
+		return
+	}
+
+	fmt.Println("\nNext steps:")
+	if _, err := os.Stat(filepath.Join(dir, appfile.Name)); errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("  - run %s to create an Encore app\n", cmdutil.InputStyle.Render("encore app init"))
+	}
+	fmt.Printf("  - run %s to validate the generated endpoints\n", cmdutil.InputStyle.Render("encore check"))
+	fmt.Printf("  - run %s and remove the old server once everything works\n", cmdutil.InputStyle.Render("encore run"))
+}
+
+func init() {
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Report the endpoints that would be generated without writing any files")
+	importCmd.Flags().BoolVar(&importForce, "force", false, "Overwrite previously generated files")
+	rootCmd.AddCommand(importCmd)
+}
@@ -17,6 +17,7 @@ import (
 	_ "encr.dev/cli/cmd/encore/k8s"
 	_ "encr.dev/cli/cmd/encore/namespace"
 	_ "encr.dev/cli/cmd/encore/secrets"
+	_ "encr.dev/cli/cmd/encore/tour"
 )
 
 // for backwards compatibility, for now
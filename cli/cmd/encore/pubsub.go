@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var pubsubCmd = &cobra.Command{
+	Use:   "pubsub",
+	Short: "PubSub management commands",
+}
+
+var (
+	replayTopic        string
+	replayFrom         string
+	replayTo           string
+	replaySubscription string
+	replayDryRun       bool
+)
+
+var pubsubReplayCmd = &cobra.Command{
+	Use:   "replay --topic=<name> --from=<time>",
+	Short: "Replays the messages published to a topic of the running app",
+	Long: `Re-publishes the messages published to a topic of the running app,
+for example to reprocess messages after fixing a bug in a subscriber.
+
+Messages published while the app is running with 'encore run' are stored
+by the Encore daemon, up to the 10000 most recent messages per topic.
+Replayed messages are marked as replayed in traces.
+
+--from and --to accept either an RFC 3339 timestamp (such as "2024-01-02T15:04:05Z")
+or a duration relative to now (such as "1h30m" for 90 minutes ago).
+Use --subscription to only have the given subscription process the replayed messages.`,
+	Args: cobra.NoArgs,
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		appRoot, _ := determineAppRoot()
+		from, err := parseReplayTime(replayFrom)
+		if err != nil {
+			fatal("invalid --from: ", err)
+		}
+		req := &daemonpb.PubSubReplayRequest{
+			AppRoot:      appRoot,
+			Topic:        replayTopic,
+			From:         timestamppb.New(from),
+			Subscription: nonZeroPtr(replaySubscription),
+			DryRun:       replayDryRun,
+		}
+		if replayTo != "" {
+			to, err := parseReplayTime(replayTo)
+			if err != nil {
+				fatal("invalid --to: ", err)
+			}
+			req.To = timestamppb.New(to)
+		}
+
+		ctx := context.Background()
+		daemon := setupDaemon(ctx)
+		resp, err := daemon.PubSubReplay(ctx, req)
+		if err != nil {
+			fatal("replay messages: ", err)
+		}
+
+		if replayDryRun {
+			fmt.Printf("Would replay %d message(s) to topic %s.\n", resp.Replayed, replayTopic)
+		} else {
+			fmt.Printf("Replayed %d message(s) to topic %s.\n", resp.Replayed, replayTopic)
+		}
+	},
+}
+
+// parseReplayTime parses an RFC 3339 timestamp or a duration relative to now.
+func parseReplayTime(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func init() {
+	rootCmd.AddCommand(pubsubCmd)
+
+	pubsubReplayCmd.Flags().StringVar(&replayTopic, "topic", "", "Name of the topic to replay messages for")
+	pubsubReplayCmd.Flags().StringVar(&replayFrom, "from", "", "Replay messages published at or after this time")
+	pubsubReplayCmd.Flags().StringVar(&replayTo, "to", "", "Replay messages published before this time (defaults to now)")
+	pubsubReplayCmd.Flags().StringVar(&replaySubscription, "subscription", "", "Only process the replayed messages with this subscription")
+	pubsubReplayCmd.Flags().BoolVar(&replayDryRun, "dry-run", false, "Report the number of messages to replay without replaying them")
+	_ = pubsubReplayCmd.MarkFlagRequired("topic")
+	_ = pubsubReplayCmd.MarkFlagRequired("from")
+	pubsubCmd.AddCommand(pubsubReplayCmd)
+}
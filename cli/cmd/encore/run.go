@@ -50,11 +50,13 @@ var (
 		Desc:      "Whether to open the local development dashboard in the browser on startup",
 		TypeDesc:  "string",
 	}
+	runTunnel    bool
+	tunnelServer string
 )
 
 func init() {
 	runCmd := &cobra.Command{
-		Use:   "run [--debug] [--watch=true] [--level=TRACE] [--port=4000] [--listen=<listen-addr>]",
+		Use:   "run [--debug] [--watch=true] [--level=TRACE] [--port=4000] [--listen=<listen-addr>] [--tunnel]",
 		Short: "Runs your application",
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -79,6 +81,8 @@ func init() {
 	runCmd.Flags().BoolVar(&color, "color", isTerm, "Whether to display colorized output")
 	runCmd.Flags().BoolVar(&noColor, "no-color", false, "Equivalent to --color=false")
 	runCmd.Flags().MarkHidden("no-color")
+	runCmd.Flags().BoolVar(&runTunnel, "tunnel", false, "Expose the app through a public URL, for receiving webhooks")
+	runCmd.Flags().StringVar(&tunnelServer, "tunnel-server", os.Getenv("ENCORE_TUNNEL_SERVER"), "URL of a self-hosted tunnel server to use with --tunnel")
 	logLevel.AddFlag(runCmd)
 	debug.AddFlag(runCmd)
 	browser.AddFlag(runCmd)
@@ -134,6 +138,10 @@ func runApp(appRoot, wd string) {
 		Namespace:  nonZeroPtr(nsName),
 		Browser:    browserMode,
 		LogLevel:   nonZeroPtr(logLevel.Value),
+
+		Tunnel:       runTunnel,
+		TunnelServer: nonZeroPtr(tunnelServer),
+		TunnelToken:  nonZeroPtr(os.Getenv("ENCORE_TUNNEL_TOKEN")),
 	})
 	if err != nil {
 		fatal(err)
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/pkg/appfile"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var sloCmd = &cobra.Command{
+	Use:   "slo",
+	Short: "Service level objective commands",
+}
+
+var (
+	sloWindow time.Duration
+	sloFormat = cmdutil.Oneof{
+		Value:     "markdown",
+		Allowed:   []string{"markdown", "json"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Output format",
+	}
+)
+
+var sloReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Reports endpoint availability and latency against the app's SLOs",
+	Long: `Reports the availability and latency percentiles of each endpoint,
+computed from the traces recorded while running the app with 'encore run',
+and compares them against the targets declared in the "slo" section of encore.app:
+
+	"slo": {
+		"availability": 99.9,
+		"latency_ms": {"p99": 500},
+		"endpoints": {
+			"checkout.Pay": {"availability": 99.95, "latency_ms": {"p95": 800}}
+		}
+	}
+
+Only requests made to the app from the outside are included.`,
+	Args: cobra.NoArgs,
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		appRoot, _ := determineAppRoot()
+		appFile, err := appfile.ParseFile(filepath.Join(appRoot, appfile.Name))
+		if err != nil {
+			fatal(err)
+		}
+
+		to := time.Now()
+		from := to.Add(-sloWindow)
+
+		ctx := context.Background()
+		daemon := setupDaemon(ctx)
+		resp, err := daemon.SLOReport(ctx, &daemonpb.SLOReportRequest{
+			AppRoot: appRoot,
+			From:    timestamppb.New(from),
+			To:      timestamppb.New(to),
+		})
+		if err != nil {
+			fatal("compute slo report: ", err)
+		}
+
+		report := buildSLOReport(appFile.SLO, from, to, resp)
+		if sloFormat.Value == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				fatal(err)
+			}
+		} else {
+			report.writeMarkdown(os.Stdout)
+		}
+	},
+}
+
+type sloReport struct {
+	From      time.Time           `json:"from"`
+	To        time.Time           `json:"to"`
+	Requests  int64               `json:"requests"`  // number of requests considered
+	Truncated bool                `json:"truncated"` // whether older requests were left out
+	Endpoints []sloEndpointReport `json:"endpoints"`
+}
+
+type sloEndpointReport struct {
+	Service      string             `json:"service"`
+	Endpoint     string             `json:"endpoint"`
+	Requests     int64              `json:"requests"`
+	Errors       int64              `json:"errors"`
+	Availability float64            `json:"availability"` // percentage of successful requests
+	LatencyMs    map[string]float64 `json:"latency_ms"`
+	Targets      appfile.SLOTarget  `json:"targets"`
+	Met          bool               `json:"met"`
+	Violations   []string           `json:"violations,omitempty"`
+}
+
+func buildSLOReport(slo *appfile.SLO, from, to time.Time, resp *daemonpb.SLOReportResponse) *sloReport {
+	report := &sloReport{
+		From:      from,
+		To:        to,
+		Requests:  resp.Requests,
+		Truncated: resp.Truncated,
+		Endpoints: []sloEndpointReport{},
+	}
+	for _, ep := range resp.Endpoints {
+		r := sloEndpointReport{
+			Service:  ep.Service,
+			Endpoint: ep.Endpoint,
+			Requests: ep.Requests,
+			Errors:   ep.Errors,
+			LatencyMs: map[string]float64{
+				"p50": nanosToMs(ep.P50Nanos),
+				"p90": nanosToMs(ep.P90Nanos),
+				"p95": nanosToMs(ep.P95Nanos),
+				"p99": nanosToMs(ep.P99Nanos),
+			},
+			Targets: slo.For(ep.Service, ep.Endpoint),
+		}
+		if ep.Requests > 0 {
+			r.Availability = 100 * float64(ep.Requests-ep.Errors) / float64(ep.Requests)
+		}
+
+		if t := r.Targets.Availability; t > 0 && r.Availability < t {
+			r.Violations = append(r.Violations, fmt.Sprintf("availability %.3f%% is below the %.3f%% target", r.Availability, t))
+		}
+		for _, p := range appfile.SLOPercentiles {
+			if t, ok := r.Targets.LatencyMs[p]; ok && r.LatencyMs[p] > t {
+				r.Violations = append(r.Violations, fmt.Sprintf("%s latency %.1fms is above the %.1fms target", p, r.LatencyMs[p], t))
+			}
+		}
+		r.Met = len(r.Violations) == 0
+		report.Endpoints = append(report.Endpoints, r)
+	}
+	return report
+}
+
+func (r *sloReport) writeMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "# SLO report\n\n")
+	fmt.Fprintf(w, "%s to %s, %d requests\n\n", r.From.Format(time.RFC3339), r.To.Format(time.RFC3339), r.Requests)
+	if r.Truncated {
+		fmt.Fprintf(w, "**Note:** the period contains more requests than can be considered; "+
+			"only the most recent %d are included. Use a shorter --window for a complete report.\n\n", r.Requests)
+	}
+	if len(r.Endpoints) == 0 {
+		fmt.Fprintf(w, "No requests were recorded in this period.\n")
+		return
+	}
+
+	fmt.Fprintf(w, "| Endpoint | Requests | Availability | p50 | p90 | p95 | p99 | Status |\n")
+	fmt.Fprintf(w, "|---|---:|---:|---:|---:|---:|---:|---|\n")
+	met := 0
+	for _, ep := range r.Endpoints {
+		cells := []string{
+			ep.Service + "." + ep.Endpoint,
+			fmt.Sprint(ep.Requests),
+			withTarget(fmt.Sprintf("%.3f%%", ep.Availability), ep.Targets.Availability > 0, fmt.Sprintf("%.3f%%", ep.Targets.Availability)),
+		}
+		for _, p := range appfile.SLOPercentiles {
+			t, ok := ep.Targets.LatencyMs[p]
+			cells = append(cells, withTarget(fmt.Sprintf("%.1fms", ep.LatencyMs[p]), ok, fmt.Sprintf("%.1fms", t)))
+		}
+		if ep.Met {
+			met++
+			cells = append(cells, "met")
+		} else {
+			cells = append(cells, "missed")
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	}
+
+	fmt.Fprintf(w, "\n%d of %d endpoints met their objectives.\n", met, len(r.Endpoints))
+	for _, ep := range r.Endpoints {
+		for _, v := range ep.Violations {
+			fmt.Fprintf(w, "- %s.%s: %s\n", ep.Service, ep.Endpoint, v)
+		}
+	}
+}
+
+// withTarget formats a value together with its target, if any.
+func withTarget(val string, hasTarget bool, target string) string {
+	if !hasTarget {
+		return val
+	}
+	return val + " (target " + target + ")"
+}
+
+func nanosToMs(nanos uint64) float64 {
+	return float64(nanos) / float64(time.Millisecond)
+}
+
+func init() {
+	rootCmd.AddCommand(sloCmd)
+
+	sloReportCmd.Flags().DurationVar(&sloWindow, "window", 7*24*time.Hour, "The time window to report on, ending now")
+	sloFormat.AddFlag(sloReportCmd)
+	sloCmd.AddCommand(sloReportCmd)
+}
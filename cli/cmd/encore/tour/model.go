@@ -0,0 +1,283 @@
+package tour
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(cmdutil.CodeBlue))
+	faintStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// stepResult is the result of running a step of the tour.
+type stepResult struct {
+	changes []fileChange
+	calls   []callResult
+	traces  []*daemonpb.ListTracesResponse_Trace
+	run     *appRun
+}
+
+type stepDoneMsg struct{ res *stepResult }
+
+type stepFailedMsg struct{ err error }
+
+// model is the bubbletea model of the tour.
+type model struct {
+	ctx     context.Context
+	daemon  daemonpb.DaemonClient
+	appRoot string
+
+	step    int // index into steps of the current step
+	running bool
+	done    bool // whether all steps have completed
+	spinner spinner.Model
+
+	res *stepResult
+	err error
+
+	// run is the currently running app, if any.
+	// It's kept running between steps so the user can explore
+	// the traces in the development dashboard.
+	run *appRun
+}
+
+func newModel(ctx context.Context, daemon daemonpb.DaemonClient, appRoot string) model {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = cmdutil.InputStyle
+	return model{
+		ctx:     ctx,
+		daemon:  daemon,
+		appRoot: appRoot,
+		spinner: sp,
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			if m.running {
+				return m, nil
+			} else if m.done {
+				return m, tea.Quit
+			}
+			if m.res != nil {
+				if m.step == len(steps)-1 {
+					m.done = true
+					return m, nil
+				}
+				m.step++
+			}
+			m.running = true
+			m.res, m.err = nil, nil
+			return m, tea.Batch(m.runStep(m.step, m.run), m.spinner.Tick)
+		case tea.KeyRunes:
+			if string(msg.Runes) == "q" && !m.running {
+				return m, tea.Quit
+			}
+		}
+
+	case stepDoneMsg:
+		m.running = false
+		m.res = msg.res
+		m.run = msg.res.run
+		return m, nil
+
+	case stepFailedMsg:
+		m.running = false
+		m.err = msg.err
+		m.run = nil
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.running {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// runStep runs the step with index idx, stopping the previous run first.
+func (m model) runStep(idx int, prev *appRun) tea.Cmd {
+	ctx, daemon, appRoot := m.ctx, m.daemon, m.appRoot
+	return func() tea.Msg {
+		if prev != nil {
+			prev.Stop()
+		}
+
+		s := steps[idx]
+		res := &stepResult{}
+		var err error
+		if res.changes, err = s.write(appRoot); err != nil {
+			return stepFailedMsg{fmt.Errorf("could not write files: %v", err)}
+		}
+
+		if res.run, err = startApp(ctx, daemon, appRoot); err != nil {
+			return stepFailedMsg{err}
+		}
+
+		from := time.Now()
+		for _, c := range s.Calls {
+			cr, err := res.run.Call(ctx, c)
+			if err != nil {
+				res.run.Stop()
+				return stepFailedMsg{fmt.Errorf("%s %s failed: %v", c.Method, c.Path, err)}
+			}
+			res.calls = append(res.calls, cr)
+		}
+
+		if res.traces, err = waitForTraces(ctx, daemon, appRoot, from, s.Spans); err != nil {
+			res.run.Stop()
+			return stepFailedMsg{fmt.Errorf("could not list traces: %v", err)}
+		}
+		return stepDoneMsg{res}
+	}
+}
+
+func (m model) View() string {
+	var b strings.Builder
+	b.WriteString("\n")
+
+	if m.done {
+		m.viewSummary(&b)
+		return cmdutil.DocStyle.Render(b.String())
+	}
+
+	if m.res == nil && m.err == nil && !m.running {
+		b.WriteString(titleStyle.Render("Welcome to the Encore tour!") + "\n\n")
+		fmt.Fprintf(&b, "The tour builds an app in %s, one feature at a time.\n", cmdutil.InputStyle.Render(m.appRoot))
+		b.WriteString("After each step it runs the app, calls its API and shows the resulting traces.\n\n")
+		b.WriteString(faintStyle.Render("Press enter to start, or q to quit.") + "\n")
+		return cmdutil.DocStyle.Render(b.String())
+	}
+
+	s := steps[m.step]
+	fmt.Fprintf(&b, "%s\n\n", titleStyle.Render(fmt.Sprintf("Step %d/%d: %s", m.step+1, len(steps), s.Title)))
+	b.WriteString(cmdutil.DescStyle.Render(s.Desc) + "\n\n")
+
+	switch {
+	case m.running:
+		fmt.Fprintf(&b, "%s Building and running the app...\n", m.spinner.View())
+
+	case m.err != nil:
+		b.WriteString(cmdutil.ErrorStyle.Render("Step failed: "+m.err.Error()) + "\n\n")
+		b.WriteString(faintStyle.Render("Press enter to retry, or q to quit.") + "\n")
+
+	case m.res != nil:
+		m.viewResult(&b, m.res)
+		if m.step == len(steps)-1 {
+			b.WriteString(faintStyle.Render("Press enter to finish the tour, or q to quit.") + "\n")
+		} else {
+			b.WriteString(faintStyle.Render("Press enter to continue, or q to quit.") + "\n")
+		}
+	}
+	return cmdutil.DocStyle.Render(b.String())
+}
+
+func (m model) viewResult(b *strings.Builder, res *stepResult) {
+	b.WriteString("Files:\n")
+	for _, c := range res.changes {
+		if c.Created {
+			fmt.Fprintf(b, "  %s %s\n", cmdutil.SuccessStyle.Render("+"), c.Path)
+		} else {
+			fmt.Fprintf(b, "  %s %s\n", cmdutil.InputStyle.Render("~"), c.Path)
+		}
+	}
+
+	b.WriteString("\nAPI calls:\n")
+	for _, c := range res.calls {
+		status := cmdutil.SuccessStyle.Render(fmt.Sprint(c.Status))
+		if c.Status >= 400 {
+			status = cmdutil.ErrorStyle.Render(fmt.Sprint(c.Status))
+		}
+		fmt.Fprintf(b, "  %s %s %s %s\n", c.Method, c.Path, status, faintStyle.Render(c.Body))
+	}
+
+	b.WriteString("\nTraces:\n")
+	if len(res.traces) == 0 {
+		b.WriteString(faintStyle.Render("  No traces recorded.") + "\n")
+	}
+	for _, tr := range res.traces {
+		for _, sp := range tr.Spans {
+			indent := strings.Repeat("  ", int(sp.Depth)+1)
+			name := fmt.Sprintf("%s.%s", sp.Service, sp.Name)
+			if sp.IsError {
+				name = cmdutil.ErrorStyle.Render(name)
+			} else {
+				name = cmdutil.InputStyle.Render(name)
+			}
+			dur := time.Duration(sp.DurationNanos).Round(10 * time.Microsecond)
+			fmt.Fprintf(b, "%s%s %s %s\n", indent, spanKind(sp.Kind), name, faintStyle.Render(dur.String()))
+			for _, op := range sp.Operations {
+				fmt.Fprintf(b, "%s  %s %s\n", indent, faintStyle.Render(operationKind(op.Kind)), op.Desc)
+			}
+		}
+	}
+
+	if res.run != nil && res.run.dashURL != "" {
+		fmt.Fprintf(b, "\nExplore the traces in the development dashboard: %s\n", cmdutil.InputStyle.Render(res.run.dashURL))
+	}
+	b.WriteString("\n")
+}
+
+func (m model) viewSummary(b *strings.Builder) {
+	b.WriteString(titleStyle.Render("You've completed the tour!") + "\n\n")
+	fmt.Fprintf(b, "Your app in %s now has:\n", cmdutil.InputStyle.Render(m.appRoot))
+	for _, s := range steps {
+		fmt.Fprintf(b, "  %s %s\n", cmdutil.SuccessStyle.Render("✔"), s.Title)
+	}
+	b.WriteString("\nTo keep building it, run:\n\n")
+	fmt.Fprintf(b, "  cd %s\n  encore run\n\n", m.appRoot)
+	fmt.Fprintf(b, "Learn more at %s\n\n", cmdutil.InputStyle.Render("https://encore.dev/docs/go"))
+	b.WriteString(faintStyle.Render("Press enter to exit.") + "\n")
+}
+
+func spanKind(k daemonpb.ListTracesResponse_Span_Kind) string {
+	switch k {
+	case daemonpb.ListTracesResponse_Span_AUTH:
+		return "auth"
+	case daemonpb.ListTracesResponse_Span_PUBSUB_MESSAGE:
+		return "message"
+	case daemonpb.ListTracesResponse_Span_TEST:
+		return "test"
+	default:
+		return "request"
+	}
+}
+
+func operationKind(k daemonpb.ListTracesResponse_Operation_Kind) string {
+	switch k {
+	case daemonpb.ListTracesResponse_Operation_RPC_CALL:
+		return "call"
+	case daemonpb.ListTracesResponse_Operation_DB_QUERY:
+		return "query"
+	case daemonpb.ListTracesResponse_Operation_PUBSUB_PUBLISH:
+		return "publish"
+	case daemonpb.ListTracesResponse_Operation_HTTP_CALL:
+		return "http"
+	case daemonpb.ListTracesResponse_Operation_CACHE_CALL:
+		return "cache"
+	default:
+		return "log"
+	}
+}
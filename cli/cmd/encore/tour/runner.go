@@ -0,0 +1,237 @@
+package tour
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encr.dev/pkg/errlist"
+	"encr.dev/pkg/xos"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// encoreAppFile is the encore.app file of the tour app,
+// which isn't linked to the Encore Platform.
+const encoreAppFile = `{
+	// The app is not currently linked to the encore.dev platform.
+	// Use "encore app link" to link it.
+	"id": "",
+}
+`
+
+// scaffold creates an empty Go app at appRoot.
+func scaffold(appRoot string) error {
+	if _, err := os.Stat(appRoot); err == nil {
+		return fmt.Errorf("directory %s already exists", appRoot)
+	}
+	if err := os.MkdirAll(appRoot, 0755); err != nil {
+		return err
+	}
+	files := map[string]string{
+		"encore.app": encoreAppFile,
+		"go.mod":     "module encore.app\n",
+		".gitignore": "/.encore\n",
+	}
+	for name, data := range files {
+		if err := xos.WriteFile(filepath.Join(appRoot, name), []byte(data), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appRun is a running instance of the tour app, started by the daemon.
+type appRun struct {
+	addr    string // the address the app listens on
+	dashURL string // the URL of the app in the development dashboard, if known
+
+	cancel context.CancelFunc
+	exited chan struct{} // closed when the run has exited
+
+	mu     sync.Mutex
+	output bytes.Buffer
+}
+
+var (
+	ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+	dashURLRe  = regexp.MustCompile(`Development Dashboard URL:\s*(\S+)`)
+)
+
+// startApp asks the daemon to run the app at appRoot,
+// and waits for the app to be ready to serve requests.
+func startApp(ctx context.Context, daemon daemonpb.DaemonClient, appRoot string) (*appRun, error) {
+	addr, err := freeLocalAddr()
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	stream, err := daemon.Run(runCtx, &daemonpb.RunRequest{
+		AppRoot:    appRoot,
+		ListenAddr: addr,
+		Environ:    os.Environ(),
+		Browser:    daemonpb.RunRequest_BROWSER_NEVER,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	run := &appRun{addr: addr, cancel: cancel, exited: make(chan struct{})}
+	go run.readOutput(stream)
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-run.exited:
+			return nil, fmt.Errorf("the app failed to start:\n\n%s", run.Output())
+		case <-ctx.Done():
+			run.Stop()
+			return nil, ctx.Err()
+		case <-ticker.C:
+			if run.healthy(ctx) {
+				if m := dashURLRe.FindStringSubmatch(run.Output()); m != nil {
+					run.dashURL = m[1]
+				}
+				return run, nil
+			}
+		}
+	}
+}
+
+func (r *appRun) readOutput(stream daemonpb.Daemon_RunClient) {
+	defer close(r.exited)
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return
+		}
+		r.mu.Lock()
+		switch m := msg.Msg.(type) {
+		case *daemonpb.CommandMessage_Output:
+			r.output.Write(m.Output.Stdout)
+			r.output.Write(m.Output.Stderr)
+		case *daemonpb.CommandMessage_Errors:
+			errList := errlist.New(nil)
+			if err := json.Unmarshal(m.Errors.Errinsrc, &errList); err == nil {
+				r.output.WriteString(errList.Error())
+			}
+		}
+		r.mu.Unlock()
+		if _, ok := msg.Msg.(*daemonpb.CommandMessage_Exit); ok {
+			return
+		}
+	}
+}
+
+// Output returns the output of the run so far, without ANSI escape codes.
+func (r *appRun) Output() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.TrimSpace(ansiEscape.ReplaceAllString(r.output.String(), ""))
+}
+
+// Stop stops the app and waits for it to exit.
+func (r *appRun) Stop() {
+	r.cancel()
+	<-r.exited
+}
+
+func (r *appRun) healthy(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://"+r.addr+"/__encore/healthz", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// callResult is the result of an API call to the tour app.
+type callResult struct {
+	call
+	Status int
+	Body   string
+}
+
+// Call makes the API call c to the app.
+func (r *appRun) Call(ctx context.Context, c call) (callResult, error) {
+	req, err := http.NewRequestWithContext(ctx, c.Method, "http://"+r.addr+c.Path, nil)
+	if err != nil {
+		return callResult{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return callResult{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return callResult{}, err
+	}
+
+	// Compact the response body to fit on a single line.
+	var buf bytes.Buffer
+	if json.Compact(&buf, body) == nil {
+		body = buf.Bytes()
+	}
+	return callResult{call: c, Status: resp.StatusCode, Body: string(body)}, nil
+}
+
+// waitForTraces lists the traces of the app at appRoot that started after
+// from, waiting until they contain at least the given number of spans.
+// If they don't within a few seconds, the traces recorded so far are returned.
+func waitForTraces(ctx context.Context, daemon daemonpb.DaemonClient, appRoot string, from time.Time, spans int) ([]*daemonpb.ListTracesResponse_Trace, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	for {
+		resp, err := daemon.ListTraces(ctx, &daemonpb.ListTracesRequest{
+			AppRoot: appRoot,
+			From:    timestamppb.New(from),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		n := 0
+		for _, tr := range resp.Traces {
+			n += len(tr.Spans)
+		}
+		if n >= spans {
+			return resp.Traces, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return resp.Traces, nil
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+func freeLocalAddr() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	return addr, nil
+}
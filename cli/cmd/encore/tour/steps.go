@@ -0,0 +1,109 @@
+package tour
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"encr.dev/pkg/xos"
+)
+
+//go:embed steps
+var stepFiles embed.FS
+
+// step is a step of the tour, which adds a feature to the tour app
+// and then calls the app to show the feature in action.
+type step struct {
+	// Dir is the directory in stepFiles containing the files the step writes,
+	// relative to the app root and with a ".tmpl" suffix.
+	Dir   string
+	Title string
+	// Desc describes what the step adds and how it works.
+	Desc string
+	// Calls are the API calls to make once the app is running.
+	Calls []call
+	// Spans is the number of spans the calls result in, such as
+	// requests and Pub/Sub messages, to know when they're all traced.
+	Spans int
+}
+
+// call is an API call to the tour app.
+type call struct {
+	Method string
+	Path   string
+}
+
+var steps = []step{
+	{
+		Dir:   "1-endpoint",
+		Title: "Define an API endpoint",
+		Desc: `Endpoints are regular Go functions annotated with //encore:api.
+Encore parses the function signature to generate the HTTP handler,
+request validation and API documentation, and traces every request.`,
+		Calls: []call{{"GET", "/hello/Ada"}},
+		Spans: 1,
+	},
+	{
+		Dir:   "2-database",
+		Title: "Add a database",
+		Desc: `Databases are declared with sqldb.NewDatabase. When running locally
+Encore provisions a PostgreSQL database using Docker and runs the
+migrations for you. Every query shows up in the request's trace.`,
+		Calls: []call{{"GET", "/hello/Ada"}, {"GET", "/hello/Ada"}},
+		Spans: 2,
+	},
+	{
+		Dir:   "3-pubsub",
+		Title: "Publish events with Pub/Sub",
+		Desc: `Topics and subscriptions are declared with pubsub.NewTopic and
+pubsub.NewSubscription. The new welcome service subscribes to the
+greetings published by the hello service, and handles them asynchronously.`,
+		Calls: []call{{"GET", "/hello/Grace"}},
+		Spans: 2,
+	},
+	{
+		Dir:   "4-cron",
+		Title: "Schedule a cron job",
+		Desc: `Cron jobs are declared with cron.NewJob and call an endpoint on a
+schedule. They don't run on a schedule locally, so the tour calls the
+private Report endpoint directly, just like the cron job would.`,
+		Calls: []call{{"POST", "/hello.Report"}},
+		Spans: 1,
+	},
+}
+
+// fileChange describes a file written by a step.
+type fileChange struct {
+	Path    string // relative to the app root, using forward slashes
+	Created bool   // whether the file was created, as opposed to updated
+}
+
+// write writes the files of the step to the app at appRoot.
+func (s step) write(appRoot string) ([]fileChange, error) {
+	var changes []fileChange
+	root := path.Join("steps", s.Dir)
+	err := fs.WalkDir(stepFiles, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := stepFiles.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		rel := strings.TrimSuffix(strings.TrimPrefix(p, root+"/"), ".tmpl")
+		dst := filepath.Join(appRoot, filepath.FromSlash(rel))
+		_, statErr := os.Stat(dst)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		} else if err := xos.WriteFile(dst, data, 0644); err != nil {
+			return err
+		}
+		changes = append(changes, fileChange{Path: rel, Created: statErr != nil})
+		return nil
+	})
+	return changes, err
+}
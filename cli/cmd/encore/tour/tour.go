@@ -0,0 +1,66 @@
+// Package tour implements the "encore tour" command, an interactive
+// walkthrough that builds and runs a sample app one feature at a time.
+package tour
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/cmd/encore/root"
+)
+
+func init() {
+	tourCmd := &cobra.Command{
+		Use:   "tour [dir]",
+		Short: "Take an interactive tour of Encore by building a sample app",
+		Long: `Take an interactive tour of Encore by building a sample app.
+
+The tour creates a new app in the given directory (defaults to "encore-tour")
+and adds an API endpoint, a database, Pub/Sub and a cron job, one step at a time.
+After each step it runs the app, calls its API and shows the resulting traces.`,
+		Args: cobra.MaximumNArgs(1),
+
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			dir := "encore-tour"
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			runTour(dir)
+		},
+	}
+	root.Cmd.AddCommand(tourCmd)
+}
+
+func runTour(dir string) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		cmdutil.Fatal("encore tour must be run in an interactive terminal")
+	}
+
+	appRoot, err := filepath.Abs(dir)
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	if err := scaffold(appRoot); err != nil {
+		cmdutil.Fatalf("could not create app: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	daemon := cmdutil.ConnectDaemon(ctx)
+
+	result, err := tea.NewProgram(newModel(ctx, daemon, appRoot)).Run()
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	if m := result.(model); m.run != nil {
+		m.run.Stop()
+	}
+}
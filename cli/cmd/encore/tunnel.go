@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"encr.dev/pkg/tunnel"
+)
+
+var tunnelServerCmd = &cobra.Command{
+	Use:   "tunnel-server --public-url=<url> [--listen=<addr>]",
+	Short: "Runs a self-hosted tunnel server for use with 'encore run --tunnel'",
+	Long: `Runs a self-hosted tunnel server for use with 'encore run --tunnel'.
+
+The server should be exposed to the internet over HTTPS, for example behind a
+load balancer, with --public-url set to the URL it's reachable on.
+
+If the ENCORE_TUNNEL_TOKEN environment variable is set, clients must provide the
+same token (by setting ENCORE_TUNNEL_TOKEN when running 'encore run') to connect.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		listenAddr, _ := cmd.Flags().GetString("listen")
+		publicURL, _ := cmd.Flags().GetString("public-url")
+		if publicURL == "" {
+			fatal("--public-url is required")
+		}
+
+		srv := &tunnel.Server{
+			PublicURL: publicURL,
+			Token:     os.Getenv("ENCORE_TUNNEL_TOKEN"),
+			Log:       log.Logger,
+		}
+		fmt.Fprintf(os.Stderr, "tunnel server listening on %s\n", listenAddr)
+		if err := http.ListenAndServe(listenAddr, srv); err != nil {
+			fatal(err)
+		}
+	},
+}
+
+func init() {
+	tunnelServerCmd.Flags().String("listen", ":8080", "Address to listen on")
+	tunnelServerCmd.Flags().String("public-url", "", "Public URL the tunnel server is reachable on")
+	alphaCmd.AddCommand(tunnelServerCmd)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Endpoint usage commands",
+}
+
+var (
+	usageWindow   time.Duration
+	usageEndpoint string
+	usageFormat   = cmdutil.Oneof{
+		Value:     "markdown",
+		Allowed:   []string{"markdown", "json"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Output format",
+	}
+)
+
+var usageReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Reports which callers call which endpoints",
+	Long: `Reports, for each endpoint, which callers called it and how often,
+computed from the traces recorded while running the app with 'encore run'.
+
+Requests made by other services are attributed to the calling service.
+Requests made to the app from the outside are attributed to the user id
+returned by the app's auth handler, such as the id of an API key,
+or reported as anonymous if the request was not authenticated.`,
+	Args: cobra.NoArgs,
+
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		appRoot, _ := determineAppRoot()
+
+		to := time.Now()
+		from := to.Add(-usageWindow)
+
+		ctx := context.Background()
+		daemon := setupDaemon(ctx)
+		resp, err := daemon.UsageReport(ctx, &daemonpb.UsageReportRequest{
+			AppRoot: appRoot,
+			From:    timestamppb.New(from),
+			To:      timestamppb.New(to),
+		})
+		if err != nil {
+			fatal("compute usage report: ", err)
+		}
+
+		report := buildUsageReport(from, to, usageEndpoint, resp)
+		if usageFormat.Value == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				fatal(err)
+			}
+		} else {
+			report.writeMarkdown(os.Stdout)
+		}
+	},
+}
+
+type usageReport struct {
+	From   time.Time    `json:"from"`
+	To     time.Time    `json:"to"`
+	Traces int32        `json:"traces"` // number of traces the report was computed from
+	Usages []usageEntry `json:"usages"`
+}
+
+type usageEntry struct {
+	Service    string    `json:"service"`
+	Endpoint   string    `json:"endpoint"`
+	CallerKind string    `json:"caller_kind"` // "external" or "service"
+	Caller     string    `json:"caller"`      // service name or user id; empty for anonymous requests
+	Requests   int64     `json:"requests"`
+	Errors     int64     `json:"errors"`
+	LastCalled time.Time `json:"last_called"`
+}
+
+// buildUsageReport builds the report from the daemon response.
+// If endpoint is non-empty, only the usages of that endpoint
+// (in "service.Endpoint" form) or service (in "service" form) are included.
+func buildUsageReport(from, to time.Time, endpoint string, resp *daemonpb.UsageReportResponse) *usageReport {
+	report := &usageReport{From: from, To: to, Traces: resp.Traces, Usages: []usageEntry{}}
+	for _, u := range resp.Usages {
+		if endpoint != "" && endpoint != u.Service && endpoint != u.Service+"."+u.Endpoint {
+			continue
+		}
+
+		kind := "external"
+		if u.CallerKind == daemonpb.UsageReportResponse_CALLER_SERVICE {
+			kind = "service"
+		}
+		report.Usages = append(report.Usages, usageEntry{
+			Service:    u.Service,
+			Endpoint:   u.Endpoint,
+			CallerKind: kind,
+			Caller:     u.Caller,
+			Requests:   u.Requests,
+			Errors:     u.Errors,
+			LastCalled: u.LastCalled.AsTime(),
+		})
+	}
+	return report
+}
+
+func (r *usageReport) writeMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "# Usage report\n\n")
+	fmt.Fprintf(w, "%s to %s (%d traces)\n\n", r.From.Format(time.RFC3339), r.To.Format(time.RFC3339), r.Traces)
+	if len(r.Usages) == 0 {
+		fmt.Fprintf(w, "No requests were recorded in this period.\n")
+		return
+	}
+
+	fmt.Fprintf(w, "| Endpoint | Caller | Requests | Errors | Last called |\n")
+	fmt.Fprintf(w, "|---|---|---:|---:|---|\n")
+	for _, u := range r.Usages {
+		fmt.Fprintf(w, "| %s.%s | %s | %d | %d | %s |\n",
+			u.Service, u.Endpoint, u.callerDesc(), u.Requests, u.Errors, u.LastCalled.Format(time.RFC3339))
+	}
+}
+
+// callerDesc describes the caller for human consumption.
+func (u *usageEntry) callerDesc() string {
+	switch {
+	case u.CallerKind == "service":
+		return "service " + u.Caller
+	case u.Caller == "":
+		return "external (anonymous)"
+	default:
+		return "external user " + u.Caller
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(usageCmd)
+
+	usageReportCmd.Flags().DurationVar(&usageWindow, "window", 7*24*time.Hour, "The time window to report on, ending now")
+	usageReportCmd.Flags().StringVar(&usageEndpoint, "endpoint", "", "Only report on the given service or endpoint (e.g. \"svc\" or \"svc.Endpoint\")")
+	usageFormat.AddFlag(usageReportCmd)
+	usageCmd.AddCommand(usageReportCmd)
+}
@@ -395,6 +395,15 @@ func (i *Instance) BuildSettings() (appfile.Build, error) {
 	return appFile.Build, nil
 }
 
+// LocalSettings returns the settings for running the app locally.
+func (i *Instance) LocalSettings() (appfile.Local, error) {
+	appFile, err := appfile.ParseFile(filepath.Join(i.root, appfile.Name))
+	if err != nil {
+		return appfile.Local{}, err
+	}
+	return appFile.Local, nil
+}
+
 // GlobalCORS returns the CORS configuration for the app which
 // will be applied against all API gateways into the app
 func (i *Instance) GlobalCORS() (appfile.CORS, error) {
@@ -0,0 +1,182 @@
+// Package containers abstracts the container runtime used to run
+// local infrastructure, like Docker (including Colima and remote
+// Docker hosts) and Podman.
+package containers
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Runtime is a container runtime with a Docker-compatible CLI.
+type Runtime interface {
+	// Name is the name of the runtime, like "docker" or "podman".
+	Name() string
+
+	// Command returns a command that runs the runtime's CLI with the given arguments.
+	Command(ctx context.Context, args ...string) *exec.Cmd
+
+	// CheckHealth reports an error describing why the runtime
+	// can't be used, or nil if it is ready to run containers.
+	CheckHealth(ctx context.Context) error
+
+	// HostAddress is the address where ports published by containers can be reached.
+	// It's "127.0.0.1" unless the runtime runs containers on a remote host.
+	HostAddress() string
+}
+
+// Detect returns the container runtime to use.
+//
+// ENCORE_CONTAINER_RUNTIME selects the runtime ("docker" or "podman").
+// If it's not set Docker is used if installed, and otherwise Podman.
+// Remote hosts are configured the same way as for the runtime's own CLI,
+// using DOCKER_HOST (for example "ssh://user@devbox") or docker contexts for Docker,
+// and CONTAINER_HOST for Podman.
+func Detect() (Runtime, error) {
+	name := os.Getenv("ENCORE_CONTAINER_RUNTIME")
+	if name == "" {
+		name = "docker"
+		if _, err := exec.LookPath("docker"); err != nil {
+			if _, err := exec.LookPath("podman"); err == nil {
+				name = "podman"
+			}
+		}
+	}
+
+	switch name {
+	case "docker":
+		return &cliRuntime{name: "docker", host: os.Getenv("DOCKER_HOST")}, nil
+	case "podman":
+		return &cliRuntime{name: "podman", host: os.Getenv("CONTAINER_HOST")}, nil
+	default:
+		return nil, errors.Newf("unknown ENCORE_CONTAINER_RUNTIME %q: must be \"docker\" or \"podman\"", name)
+	}
+}
+
+// MustDetect is like Detect but falls back to Docker if the runtime is misconfigured.
+// The misconfiguration is then reported when checking the runtime's health.
+func MustDetect() Runtime {
+	rt, err := Detect()
+	if err != nil {
+		return &cliRuntime{name: "docker", host: os.Getenv("DOCKER_HOST"), err: err}
+	}
+	return rt
+}
+
+// cliRuntime is a Runtime using the CLI of Docker or Podman.
+type cliRuntime struct {
+	name string // name of the runtime, which is also the name of its binary
+	host string // the remote host to connect to, if any
+	err  error  // configuration error, if any
+}
+
+var _ Runtime = (*cliRuntime)(nil)
+
+func (r *cliRuntime) Name() string { return r.name }
+
+func (r *cliRuntime) Command(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, r.name, args...)
+}
+
+func (r *cliRuntime) HostAddress() string {
+	return hostAddress(r.host)
+}
+
+func (r *cliRuntime) CheckHealth(ctx context.Context) error {
+	if r.err != nil {
+		return r.err
+	}
+	if _, err := exec.LookPath(r.name); err != nil {
+		return errors.Newf("This application requires a container runtime to run since it uses an SQL database, "+
+			"but %s was not found in your PATH. Install Docker or Podman first, "+
+			"or set ENCORE_CONTAINER_RUNTIME to select the runtime to use.", r.name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	out, err := r.Command(ctx, "info").CombinedOutput()
+	if err == nil {
+		return nil
+	} else if ctx.Err() != nil {
+		return errors.Newf("Timed out connecting to %s%s. Make sure it is running and reachable.", r.name, r.hostDesc())
+	}
+	return r.classifyError(out)
+}
+
+// classifyError turns the output of a failed "info" command into a user-facing error.
+func (r *cliRuntime) classifyError(out []byte) error {
+	msg := string(bytes.TrimSpace(out))
+	lower := strings.ToLower(msg)
+
+	switch {
+	case strings.HasPrefix(r.host, "ssh://") && containsAny(lower,
+		"permission denied", "host key verification failed", "could not resolve hostname", "ssh:"):
+		return errors.Newf("Could not connect to the %s host %s over SSH: %s", r.name, r.host, msg)
+
+	case r.host != "" && !isLocalHost(r.host):
+		return errors.Newf("Could not connect to the remote %s host %s. Make sure it is running and reachable: %s", r.name, r.host, msg)
+
+	case r.name == "podman":
+		return errors.Newf("Podman is not running. Start it first (for example with `podman machine start`): %s", msg)
+
+	case containsAny(lower, "cannot connect to the docker daemon", "is the docker daemon running", "error during connect"):
+		return errors.New("The docker daemon is not running. Start it first " +
+			"(for example by starting Docker Desktop, or with `colima start`).")
+
+	default:
+		return errors.Newf("%s is not usable: %s", r.name, msg)
+	}
+}
+
+func (r *cliRuntime) hostDesc() string {
+	if r.host == "" || isLocalHost(r.host) {
+		return ""
+	}
+	return " at " + r.host
+}
+
+// hostAddress returns the address where published ports can be reached
+// for the given container host, as given by DOCKER_HOST or CONTAINER_HOST.
+func hostAddress(host string) string {
+	if host == "" || isLocalHost(host) {
+		return "127.0.0.1"
+	}
+	u, err := url.Parse(host)
+	if err != nil || u.Hostname() == "" {
+		return "127.0.0.1"
+	}
+	return u.Hostname()
+}
+
+// isLocalHost reports whether the container host is on the local machine.
+func isLocalHost(host string) bool {
+	u, err := url.Parse(host)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "unix", "npipe":
+		return true
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		return ip.IsLoopback()
+	}
+	return u.Hostname() == "localhost"
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package containers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHostAddress(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"", "127.0.0.1"},
+		{"unix:///var/run/docker.sock", "127.0.0.1"},
+		{"npipe:////./pipe/docker_engine", "127.0.0.1"},
+		{"tcp://127.0.0.1:2375", "127.0.0.1"},
+		{"tcp://localhost:2375", "127.0.0.1"},
+		{"tcp://10.0.0.5:2376", "10.0.0.5"},
+		{"ssh://dev@devbox.internal", "devbox.internal"},
+		{"ssh://dev@devbox.internal:2222", "devbox.internal"},
+	}
+	for _, tt := range tests {
+		if got := hostAddress(tt.host); got != tt.want {
+			t.Errorf("hostAddress(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		rt   *cliRuntime
+		out  string
+		want string
+	}{
+		{
+			name: "docker not running",
+			rt:   &cliRuntime{name: "docker"},
+			out:  "Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?",
+			want: "The docker daemon is not running",
+		},
+		{
+			name: "podman not running",
+			rt:   &cliRuntime{name: "podman"},
+			out:  "Cannot connect to Podman.",
+			want: "podman machine start",
+		},
+		{
+			name: "ssh auth failure",
+			rt:   &cliRuntime{name: "docker", host: "ssh://dev@devbox"},
+			out:  "error during connect: ssh: dev@devbox: Permission denied (publickey).",
+			want: "over SSH",
+		},
+		{
+			name: "remote host unreachable",
+			rt:   &cliRuntime{name: "docker", host: "tcp://10.0.0.5:2376"},
+			out:  "error during connect: dial tcp 10.0.0.5:2376: connect: connection refused",
+			want: "remote docker host tcp://10.0.0.5:2376",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rt.classifyError([]byte(tt.out))
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
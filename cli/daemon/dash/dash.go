@@ -29,6 +29,7 @@ import (
 	"encr.dev/parser/encoding"
 	"encr.dev/pkg/editors"
 	"encr.dev/pkg/errlist"
+	"encr.dev/pkg/fns"
 	"encr.dev/pkg/jsonext"
 	tracepb2 "encr.dev/proto/encore/engine/trace2"
 	meta "encr.dev/proto/encore/parser/meta/v1"
@@ -267,6 +268,33 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 		}
 		return reply(ctx, events, err)
 
+	case "traces/force-token":
+		telemetry.Send("traces.force-token")
+		var params struct {
+			AppID      string
+			TTLSeconds int // if 0 defaults to an hour
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		r := h.run.FindRunByAppID(params.AppID)
+		if r == nil {
+			return reply(ctx, nil, fmt.Errorf("the app is not running"))
+		}
+		ttl := time.Duration(params.TTLSeconds) * time.Second
+		if ttl <= 0 {
+			ttl = time.Hour
+		}
+		token, expires, err := r.ForceTraceToken(ttl)
+		if err != nil {
+			return reply(ctx, nil, err)
+		}
+		return reply(ctx, map[string]any{
+			"token":      token,
+			"expires_at": expires,
+			"header":     "X-Encore-Force-Trace",
+		}, nil)
+
 	case "status":
 		var params struct {
 			AppID string
@@ -327,6 +355,151 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 		status := buildDbMigrationStatus(ctx, appMeta, cluster)
 
 		return reply(ctx, status, nil)
+	case "infra/resource-usage":
+		var params struct {
+			AppID string
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+
+		app, err := h.apps.FindLatestByPlatformOrLocalID(params.AppID)
+		if err != nil {
+			return reply(ctx, nil, err)
+		}
+		namespace, err := h.GetNamespace(ctx, params.AppID)
+		if err != nil {
+			return reply(ctx, nil, err)
+		}
+
+		usage := []infraResourceUsage{}
+		cluster, ok := h.run.ClusterMgr.Get(sqldb.GetClusterID(app, sqldb.Run, namespace))
+		if ok {
+			res, err := cluster.ResourceUsage(ctx)
+			if err == nil {
+				usage = append(usage, infraResourceUsage{
+					Name:             "sqldb",
+					CPUPercent:       res.CPUPercent,
+					MemoryBytes:      res.MemoryBytes,
+					MemoryLimitBytes: res.MemoryLimitBytes,
+				})
+			} else if !errors.Is(err, sqldb.ErrUnsupported) {
+				log.Error().Err(err).Msg("dash: could not get database resource usage")
+			}
+		}
+		return reply(ctx, usage, nil)
+
+	case "pubsub/topics":
+		var params struct {
+			AppID string
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		topics, err := h.PubSubTopics(params.AppID)
+		return reply(ctx, topics, err)
+
+	case "pubsub/messages":
+		var p PubSubMessagesRequest
+		if err := unmarshal(&p); err != nil {
+			return reply(ctx, nil, err)
+		}
+		msgs, err := h.PubSubMessages(p)
+		return reply(ctx, msgs, err)
+
+	case "pubsub/publish":
+		telemetry.Send("pubsub.publish")
+		var p PubSubPublishRequest
+		if err := unmarshal(&p); err != nil {
+			return reply(ctx, nil, err)
+		}
+		msgID, err := h.PubSubPublish(p)
+		if err != nil {
+			return reply(ctx, nil, err)
+		}
+		return reply(ctx, map[string]string{"messageID": msgID}, nil)
+
+	case "monitor/checks":
+		var params struct {
+			AppID string
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		r := h.run.FindRunByAppID(params.AppID)
+		if r == nil {
+			return reply(ctx, nil, fmt.Errorf("the app is not running"))
+		}
+		return reply(ctx, r.MonitorChecks(), nil)
+
+	case "config/effective":
+		var params struct {
+			AppID string
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		r := h.run.FindRunByAppID(params.AppID)
+		if r == nil {
+			return reply(ctx, nil, fmt.Errorf("the app is not running"))
+		}
+		return reply(ctx, r.EffectiveConfig(), nil)
+
+	case "flags/list":
+		var params struct {
+			AppID string
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		r := h.run.FindRunByAppID(params.AppID)
+		if r == nil {
+			return reply(ctx, nil, fmt.Errorf("the app is not running"))
+		}
+		return reply(ctx, r.FeatureFlags(), nil)
+
+	case "flags/set":
+		var params struct {
+			AppID   string
+			Name    string
+			Enabled *bool // nil to remove the override
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		r := h.run.FindRunByAppID(params.AppID)
+		if r == nil {
+			return reply(ctx, nil, fmt.Errorf("the app is not running"))
+		}
+		if err := r.SetFeatureFlagOverride(params.Name, params.Enabled); err != nil {
+			return reply(ctx, nil, err)
+		}
+		return reply(ctx, r.FeatureFlags(), nil)
+
+	case "tests/runs":
+		var params struct {
+			AppID string
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		runs := fns.Map(h.run.ListTestRuns(params.AppID), (*run.TestRun).Summary)
+		return reply(ctx, runs, nil)
+
+	case "tests/get":
+		var params struct {
+			AppID string
+			RunID string
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		tr := h.run.FindTestRun(params.AppID, params.RunID)
+		if tr == nil {
+			return reply(ctx, nil, fmt.Errorf("test run not found"))
+		}
+		return reply(ctx, testRunDetails{TestRunSummary: tr.Summary(), Tests: tr.Results()}, nil)
+
 	case "api-call":
 		telemetry.Send("api.call")
 		var params run.ApiCallParams
@@ -601,6 +774,10 @@ func (h *handler) listenNotify(ctx context.Context, ch <-chan *notification) {
 
 func (s *Server) listenTraces() {
 	for sp := range s.traceCh {
+		if sp.TestTrace {
+			s.linkTestTrace(sp)
+		}
+
 		// Only marshal the trace if someone's listening.
 		s.mu.Lock()
 		hasClients := len(s.clients) > 0
@@ -723,6 +900,48 @@ func (s *Server) OnError(r *run.Run, err *errlist.List) {
 	})
 }
 
+var _ run.TestListener = (*Server)(nil)
+
+// OnTestRunStart notifies active websocket clients about the started test run.
+func (s *Server) OnTestRunStart(tr *run.TestRun) {
+	s.notify(&notification{
+		Method: "test/run-start",
+		Params: tr.Summary(),
+	})
+}
+
+// OnTestResult notifies active websocket clients about the updated result of a test.
+func (s *Server) OnTestResult(tr *run.TestRun, res run.TestResult) {
+	s.notify(&notification{
+		Method: "test/result",
+		Params: map[string]any{
+			"appID":  tr.AppID,
+			"runID":  tr.ID,
+			"result": res,
+		},
+	})
+}
+
+// OnTestRunEnd notifies active websocket clients about the ended test run.
+// As notifications are dropped for slow clients, clients should fetch
+// the results of the tests with "tests/get" once the run has ended.
+func (s *Server) OnTestRunEnd(tr *run.TestRun) {
+	s.notify(&notification{
+		Method: "test/run-end",
+		Params: tr.Summary(),
+	})
+}
+
+// linkTestTrace links the trace of a test to its result in the app's latest test run.
+func (s *Server) linkTestTrace(sp trace2.NewSpanEvent) {
+	if sp.Span.EndpointName == nil {
+		return
+	}
+	if runs := s.run.ListTestRuns(sp.AppID); len(runs) > 0 {
+		runs[0].LinkTrace(*sp.Span.EndpointName, sp.Span.TraceId, sp.Span.SpanId)
+	}
+}
+
 func (s *Server) onOutput(r *run.Run, out []byte) {
 	// Copy to a new slice since we cannot retain it after the call ends, and notify is async.
 	out2 := make([]byte, len(out))
@@ -766,6 +985,19 @@ type appStatus struct {
 	CompileError string                `json:"compileError,omitempty"`
 }
 
+// testRunDetails is a test run with the results of its tests.
+type testRunDetails struct {
+	run.TestRunSummary
+	Tests []run.TestResult `json:"tests"`
+}
+
+type infraResourceUsage struct {
+	Name             string  `json:"name"`
+	CPUPercent       float64 `json:"cpuPercent"`
+	MemoryBytes      uint64  `json:"memoryBytes"`
+	MemoryLimitBytes uint64  `json:"memoryLimitBytes"`
+}
+
 type dbMigrationHistory struct {
 	DatabaseName string        `json:"databaseName"`
 	Migrations   []dbMigration `json:"migrations"`
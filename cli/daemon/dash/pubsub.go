@@ -0,0 +1,217 @@
+package dash
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nsqio/nsq/nsqd"
+
+	"encr.dev/cli/daemon/pubsub"
+	"encr.dev/pkg/fns"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// pubsubTopic describes a topic of a running app and the state of its subscriptions.
+type pubsubTopic struct {
+	Name          string               `json:"name"`
+	Published     uint64               `json:"published"` // number of messages published since the app started
+	Subscriptions []pubsubSubscription `json:"subscriptions"`
+}
+
+type pubsubSubscription struct {
+	Name    string `json:"name"`
+	Service string `json:"service"`
+
+	Pending   int64  `json:"pending"`   // messages waiting to be delivered
+	InFlight  int    `json:"inFlight"`  // messages being processed
+	Deferred  int    `json:"deferred"`  // messages waiting to be retried or for their delivery delay
+	Delivered uint64 `json:"delivered"` // number of messages delivered, including retries
+	Retried   uint64 `json:"retried"`   // number of messages retried
+
+	// DeadLetterTopic is the subscription's dead-letter topic, if any,
+	// and DeadLettered the number of messages published to it.
+	DeadLetterTopic string `json:"deadLetterTopic,omitempty"`
+	DeadLettered    uint64 `json:"deadLettered"`
+}
+
+// pubsubMessage is a message published to a topic.
+type pubsubMessage struct {
+	ID          string            `json:"id"`
+	PublishTime time.Time         `json:"publishTime"`
+	Attributes  map[string]string `json:"attributes"`
+	Data        json.RawMessage   `json:"data"`
+}
+
+type PubSubMessagesRequest struct {
+	AppID string `json:"appID"`
+	Topic string `json:"topic"`
+}
+
+type PubSubPublishRequest struct {
+	AppID string          `json:"appID"`
+	Topic string          `json:"topic"`
+	Data  json.RawMessage `json:"data"`
+
+	// Attributes are additional message attributes. Attributes for fields
+	// tagged with `pubsub-attr` are derived from Data.
+	Attributes map[string]string `json:"attributes"`
+}
+
+// pubsubDaemon returns the metadata and pubsub daemon of the running app.
+func (h *handler) pubsubDaemon(appID string) (*meta.Data, *pubsub.NSQDaemon, error) {
+	r := h.run.FindRunByAppID(appID)
+	if r == nil || r.ProcGroup() == nil {
+		return nil, nil, fmt.Errorf("the app is not running")
+	}
+	nsq := r.ResourceManager.GetPubSub()
+	if nsq == nil {
+		return nil, nil, fmt.Errorf("the app has no pubsub topics")
+	}
+	return r.ProcGroup().Meta, nsq, nil
+}
+
+// PubSubTopics lists the topics of the running app and the state of their subscriptions.
+func (h *handler) PubSubTopics(appID string) ([]pubsubTopic, error) {
+	md, nsq, err := h.pubsubDaemon(appID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := nsq.Stats()
+	if err != nil {
+		return nil, err
+	}
+	topicStats := make(map[string]nsqd.TopicStats, len(stats.Topics))
+	for _, t := range stats.Topics {
+		topicStats[t.TopicName] = t
+	}
+
+	topics := make([]pubsubTopic, 0, len(md.PubsubTopics))
+	for _, t := range md.PubsubTopics {
+		ts := topicStats[t.Name]
+		topic := pubsubTopic{
+			Name:          t.Name,
+			Published:     ts.MessageCount,
+			Subscriptions: make([]pubsubSubscription, 0, len(t.Subscriptions)),
+		}
+		for _, s := range t.Subscriptions {
+			sub := pubsubSubscription{Name: s.Name, Service: s.ServiceName}
+			if cs, ok := fns.Find(ts.Channels, func(c nsqd.ChannelStats) bool { return c.ChannelName == s.Name }); ok {
+				sub.Pending = cs.Depth
+				sub.InFlight = cs.InFlightCount
+				sub.Deferred = cs.DeferredCount
+				sub.Delivered = cs.MessageCount
+				sub.Retried = cs.RequeueCount
+			}
+			if dl := s.DeadLetterPolicy; dl != nil && dl.TopicName != "" {
+				sub.DeadLetterTopic = dl.TopicName
+				sub.DeadLettered = topicStats[dl.TopicName].MessageCount
+			}
+			topic.Subscriptions = append(topic.Subscriptions, sub)
+		}
+		topics = append(topics, topic)
+	}
+	return topics, nil
+}
+
+// PubSubMessages returns the most recent messages published to a topic of the running app,
+// newest first. For dead-letter topics these are the dead-lettered messages.
+func (h *handler) PubSubMessages(req PubSubMessagesRequest) ([]pubsubMessage, error) {
+	md, nsq, err := h.pubsubDaemon(req.AppID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fns.Find(md.PubsubTopics, func(t *meta.PubSubTopic) bool { return t.Name == req.Topic }); !ok {
+		return nil, fmt.Errorf("topic %q not found", req.Topic)
+	}
+
+	const maxMessages = 100
+	stored := nsq.Messages(req.Topic, time.Time{}, time.Time{})
+	msgs := make([]pubsubMessage, 0, min(len(stored), maxMessages))
+	for i := len(stored) - 1; i >= 0 && len(msgs) < maxMessages; i-- {
+		m := stored[i]
+		msgs = append(msgs, pubsubMessage{
+			ID:          m.ID,
+			PublishTime: m.PublishTime,
+			Attributes:  m.Attributes,
+			Data:        m.Data,
+		})
+	}
+	return msgs, nil
+}
+
+// PubSubPublish publishes a test message to a topic of the running app.
+func (h *handler) PubSubPublish(req PubSubPublishRequest) (msgID string, err error) {
+	md, nsq, err := h.pubsubDaemon(req.AppID)
+	if err != nil {
+		return "", err
+	}
+	topic, ok := fns.Find(md.PubsubTopics, func(t *meta.PubSubTopic) bool { return t.Name == req.Topic })
+	if !ok {
+		return "", fmt.Errorf("topic %q not found", req.Topic)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(req.Data, &fields); err != nil {
+		return "", fmt.Errorf("message must be a JSON object: %v", err)
+	}
+
+	attrs := make(map[string]string, len(req.Attributes))
+	for k, v := range req.Attributes {
+		attrs[k] = v
+	}
+	messageAttributes(md, topic.MessageType, fields, attrs)
+
+	var data bytes.Buffer
+	if err := json.Compact(&data, req.Data); err != nil {
+		return "", err
+	}
+	return nsq.Publish(topic.Name, attrs, data.Bytes())
+}
+
+// messageAttributes adds the attributes for the fields of the message type
+// tagged with `pubsub-attr` to attrs, using the field values in the JSON message.
+func messageAttributes(md *meta.Data, typ *schema.Type, fields map[string]json.RawMessage, attrs map[string]string) {
+	for {
+		switch t := typ.GetTyp().(type) {
+		case *schema.Type_Pointer:
+			typ = t.Pointer.Base
+			continue
+		case *schema.Type_Named:
+			if int(t.Named.Id) >= len(md.Decls) {
+				return
+			}
+			typ = md.Decls[t.Named.Id].Type
+			continue
+		case *schema.Type_Struct:
+			for _, f := range t.Struct.Fields {
+				tag, ok := fns.Find(f.Tags, func(t *schema.Tag) bool { return t.Key == "pubsub-attr" })
+				if !ok {
+					continue
+				}
+				name := f.Name
+				if f.JsonName != "" {
+					name = f.JsonName
+				}
+				val, ok := fields[name]
+				if !ok || string(val) == "null" {
+					continue
+				}
+				attrs[tag.Name] = attributeValue(val)
+			}
+		}
+		return
+	}
+}
+
+// attributeValue formats a JSON value as a message attribute,
+// matching how the runtime formats attribute fields.
+func attributeValue(val json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(val, &s); err == nil {
+		return s
+	}
+	return string(val)
+}
@@ -0,0 +1,56 @@
+package dash
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func TestMessageAttributes(t *testing.T) {
+	attrField := func(name, jsonName, attr string) *schema.Field {
+		return &schema.Field{
+			Name:     name,
+			JsonName: jsonName,
+			Tags:     []*schema.Tag{{Key: "pubsub-attr", Name: attr}},
+		}
+	}
+	md := &meta.Data{
+		Decls: []*schema.Decl{{
+			Id:   0,
+			Name: "OrderEvent",
+			Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{
+				Fields: []*schema.Field{
+					{Name: "ID", JsonName: "id"},
+					attrField("Region", "region", "region"),
+					attrField("Priority", "", "priority"),
+					attrField("Express", "express", "express"),
+					attrField("Note", "note", "note"),
+				},
+			}}},
+		}},
+	}
+	msgType := &schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{
+		Base: &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: 0}}},
+	}}}
+
+	var fields map[string]json.RawMessage
+	data := `{"id": 1, "region": "eu \"west\"", "Priority": 2, "express": true, "note": null}`
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	attrs := map[string]string{"source": "dashboard"}
+	messageAttributes(md, msgType, fields, attrs)
+	want := map[string]string{
+		"source":   "dashboard",
+		"region":   `eu "west"`,
+		"priority": "2",
+		"express":  "true",
+	}
+	if !reflect.DeepEqual(attrs, want) {
+		t.Errorf("messageAttributes() = %v, want %v", attrs, want)
+	}
+}
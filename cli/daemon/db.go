@@ -2,15 +2,19 @@ package daemon
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"net"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"encr.dev/cli/daemon/sqldb"
 	"encr.dev/cli/internal/platform"
@@ -20,6 +24,7 @@ import (
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/pgproxy"
 	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 func toRoleType(role daemonpb.DBRole) sqldb.RoleType {
@@ -381,3 +386,130 @@ func getClusterType(req interface{ GetClusterType() daemonpb.DBClusterType }) sq
 		return sqldb.Run
 	}
 }
+
+// DBMigratePlan reports the pending migrations for the given databases
+// and detects drift, without applying anything.
+func (s *Server) DBMigratePlan(ctx context.Context, req *daemonpb.DBMigratePlanRequest) (*daemonpb.DBMigratePlanResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	expSet, err := app.Experiments(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the app to figure out what migrations there are.
+	bld := builderimpl.Resolve(app.Lang(), expSet)
+	defer fns.CloseIgnore(bld)
+	parse, err := bld.Parse(ctx, builder.ParseParams{
+		Build:       builder.DefaultBuildInfo(),
+		App:         app,
+		Experiments: expSet,
+		WorkingDir:  ".",
+		ParseTests:  false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dbs := parse.Meta.SqlDatabases
+	if len(req.DatabaseNames) > 0 {
+		dbs = fns.Filter(dbs, func(db *meta.SQLDatabase) bool {
+			return slices.Contains(req.DatabaseNames, db.Name)
+		})
+		if len(dbs) != len(req.DatabaseNames) {
+			return nil, errDatabaseNotFound
+		}
+	}
+
+	var planDB func(ctx context.Context, db *meta.SQLDatabase) (*sqldb.MigrationPlan, error)
+	if req.EnvName == "local" {
+		clusterNS, err := s.namespaceOrActive(ctx, app, req.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		clusterType := getClusterType(req)
+		clusterID := sqldb.GetClusterID(app, clusterType, clusterNS)
+		cluster := s.cm.Create(ctx, &sqldb.CreateParams{
+			ClusterID: clusterID,
+			Memfs:     clusterType.Memfs(),
+		})
+		if _, err := cluster.Start(ctx, nil); err != nil {
+			return nil, err
+		}
+		planDB = func(ctx context.Context, db *meta.SQLDatabase) (*sqldb.MigrationPlan, error) {
+			if cluster.IsExternalDB(db.Name) {
+				return nil, fmt.Errorf("database %s is external", db.Name)
+			}
+			return cluster.PlanMigrations(ctx, req.AppRoot, db, req.Validate)
+		}
+	} else {
+		appID, err := appfile.Slug(req.AppRoot)
+		if err != nil {
+			return nil, err
+		} else if appID == "" {
+			return nil, errNotLinked
+		}
+
+		planDB = func(ctx context.Context, db *meta.SQLDatabase) (*sqldb.MigrationPlan, error) {
+			// Validating migrations requires running DDL statements in the dry run.
+			role := sqldb.RoleRead
+			if req.Validate {
+				role = sqldb.RoleAdmin
+			}
+			port, passwd, err := sqldb.OneshotProxy(appID, req.EnvName, role)
+			if err != nil {
+				return nil, err
+			}
+			dsn := fmt.Sprintf("postgresql://encore:%s@127.0.0.1:%d/%s?sslmode=disable", passwd, port, db.Name)
+			pool, err := sql.Open("pgx", dsn)
+			if err != nil {
+				return nil, err
+			}
+			defer fns.CloseIgnore(pool)
+			conn, err := pool.Conn(ctx)
+			if err != nil {
+				return nil, err
+			}
+			defer fns.CloseIgnore(conn)
+
+			var reader sqldb.MigrationReader
+			if db.MigrationRelPath != nil {
+				reader = sqldb.NewOsMigrationReader(filepath.Join(req.AppRoot, *db.MigrationRelPath))
+			}
+			return sqldb.PlanMigrations(ctx, conn, reader, db, req.Validate)
+		}
+	}
+
+	resp := &daemonpb.DBMigratePlanResponse{}
+	for _, db := range dbs {
+		plan, err := planDB(ctx, db)
+		if err != nil {
+			return nil, fmt.Errorf("plan migrations for database %s: %v", db.Name, err)
+		}
+
+		dbPlan := &daemonpb.DBMigrationPlan{
+			DbName: db.Name,
+			Exists: plan.Exists,
+			Drift:  plan.Drift,
+		}
+		for _, m := range plan.Pending {
+			pm := &daemonpb.PendingMigration{
+				Number:                m.Number,
+				Filename:              m.Filename,
+				Description:           m.Description,
+				DestructiveStatements: m.Destructive,
+				Validated:             m.Validated,
+			}
+			if m.ValidationErr != nil {
+				pm.ValidationError = proto.String(m.ValidationErr.Error())
+			}
+			dbPlan.Pending = append(dbPlan.Pending, pm)
+		}
+		resp.Databases = append(resp.Databases, dbPlan)
+	}
+	return resp, nil
+}
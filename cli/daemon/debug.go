@@ -3,13 +3,20 @@ package daemon
 import (
 	"bytes"
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"runtime"
 
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+
 	"github.com/golang/protobuf/jsonpb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"encr.dev/cli/daemon/apps"
 	"encr.dev/internal/version"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/builder/builderimpl"
@@ -24,6 +31,17 @@ func (s *Server) DumpMeta(ctx context.Context, req *daemonpb.DumpMetaRequest) (*
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if req.Revision != "" {
+		root, cleanup, err := checkoutRevision(ctx, app.Root(), req.Revision)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		defer cleanup()
+		// Parse the checked out revision as the same app,
+		// without tracking it since it's removed afterwards.
+		app = apps.NewInstance(root, app.LocalID(), app.PlatformID())
+	}
+
 	expSet, err := app.Experiments(req.Environ)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -81,3 +99,52 @@ func (s *Server) DumpMeta(ctx context.Context, req *daemonpb.DumpMetaRequest) (*
 
 	return &daemonpb.DumpMetaResponse{Meta: out}, nil
 }
+
+// checkoutRevision checks out the given git revision of the repository
+// containing appRoot into a temporary directory, using a git worktree.
+// It returns the app root within the checkout, and a function that
+// removes the checkout.
+func checkoutRevision(ctx context.Context, appRoot, revision string) (root string, cleanup func(), err error) {
+	git := func(dir string, args ...string) (string, error) {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return "", errors.Newf("git %s: %s", args[0], bytes.TrimSpace(out))
+		}
+		return string(bytes.TrimSpace(out)), nil
+	}
+
+	repoRoot, err := git(appRoot, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, err
+	}
+	commit, err := git(repoRoot, "rev-parse", "--verify", "--end-of-options", revision+"^{commit}")
+	if err != nil {
+		return "", nil, errors.Newf("unknown revision %q", revision)
+	}
+	relRoot, err := filepath.Rel(repoRoot, appRoot)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "compute app root")
+	}
+
+	dir, err := os.MkdirTemp("", "encore-revision")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "create temp dir")
+	}
+	if _, err := git(repoRoot, "worktree", "add", "--detach", "--quiet", dir, commit); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", nil, err
+	}
+
+	cleanup = func() {
+		// Use a new context since the request may have been canceled.
+		cmd := exec.Command("git", "worktree", "remove", "--force", dir)
+		cmd.Dir = repoRoot
+		if out, err := cmd.CombinedOutput(); err != nil {
+			log.Error().Err(err).Str("dir", dir).Bytes("output", out).Msg("unable to remove git worktree")
+		}
+		_ = os.RemoveAll(dir)
+	}
+	return filepath.Join(dir, relRoot), cleanup, nil
+}
@@ -9,17 +9,19 @@ import (
 	"github.com/cockroachdb/errors"
 
 	tracemodel "encore.dev/appruntime/exported/trace2"
+	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/engine/trace2"
 	"encr.dev/cli/daemon/run"
 )
 
 type server struct {
 	runMgr *run.Manager
+	apps   *apps.Manager
 	rec    *trace2.Recorder
 }
 
-func NewServer(runMgr *run.Manager, rec *trace2.Recorder) http.Handler {
-	s := &server{runMgr: runMgr, rec: rec}
+func NewServer(runMgr *run.Manager, apps *apps.Manager, rec *trace2.Recorder) http.Handler {
+	s := &server{runMgr: runMgr, apps: apps, rec: rec}
 	return s
 }
 
@@ -63,6 +65,9 @@ func (s *server) parseTraceData(req *http.Request) (d trace2.RecordData, err err
 			return d, errors.New("missing X-Encore-App-ID header")
 		}
 		d.Meta = &trace2.Meta{AppID: appID}
+		if app, err := s.apps.FindLatestByPlatformOrLocalID(appID); err == nil {
+			d.Sampling = samplingPolicy(app.Root(), "test")
+		}
 	} else {
 		if pid == "" {
 			return d, errors.New("missing X-Encore-Env-ID header")
@@ -72,6 +77,7 @@ func (s *server) parseTraceData(req *http.Request) (d trace2.RecordData, err err
 			return d, errors.Newf("process %q is not running", pid)
 		}
 		d.Meta = &trace2.Meta{AppID: proc.Run.App.PlatformOrLocalID()}
+		d.Sampling = samplingPolicy(proc.Run.App.Root(), "local")
 	}
 
 	// Parse time anchor
@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/internal/userconfig"
+)
+
+// samplingPolicy returns the tail-based sampling policy of an app's environment,
+// "local" or "test", as configured with "encore config trace.<env>.*".
+// It returns nil if all the environment's traces are kept.
+func samplingPolicy(appRoot, env string) *trace2.SamplingPolicy {
+	cfg, err := userconfig.ForApp(appRoot).Get()
+	if err != nil {
+		log.Warn().Err(err).Msg("unable to read user config, keeping all traces")
+		return nil
+	}
+	get := func(key string) string {
+		v, _ := cfg.GetByKey("trace." + env + "." + key)
+		s, _ := v.Val.(string)
+		return strings.TrimSpace(s)
+	}
+
+	rate := get("sample_rate")
+	if rate == "" {
+		return nil
+	}
+	p := &trace2.SamplingPolicy{}
+	if p.Rate, err = strconv.ParseFloat(rate, 64); err != nil || p.Rate < 0 || p.Rate > 1 {
+		log.Warn().Str("value", rate).Msgf("invalid trace.%s.sample_rate, keeping all traces", env)
+		return nil
+	}
+
+	if threshold := get("latency_threshold"); threshold != "" {
+		if p.LatencyThreshold, err = time.ParseDuration(threshold); err != nil {
+			log.Warn().Str("value", threshold).Msgf("invalid trace.%s.latency_threshold, ignoring it", env)
+		}
+	}
+	for _, ep := range strings.Split(get("endpoints"), ",") {
+		if ep = strings.TrimSpace(ep); ep != "" {
+			p.Endpoints = append(p.Endpoints, ep)
+		}
+	}
+	return p
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -15,11 +16,52 @@ import (
 )
 
 type Recorder struct {
-	s Store
+	s       Store
+	sampler *tailSampler
+
+	closeOnce sync.Once
+	quit      chan struct{} // closed by Close
+	done      chan struct{} // closed when sweepLoop returns
 }
 
+// NewRecorder returns a Recorder writing traces to s.
+// It must be closed with Close to write the traces still pending a sampling decision.
 func NewRecorder(s Store) *Recorder {
-	return &Recorder{s}
+	h := &Recorder{
+		s:       s,
+		sampler: newTailSampler(s),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go h.sweepLoop()
+	return h
+}
+
+// sweepLoop periodically sweeps the traces pending a sampling decision,
+// so that traces whose root span never ends are sampled even when no more events arrive.
+func (h *Recorder) sweepLoop() {
+	defer close(h.done)
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			h.sampler.tick()
+		case <-h.quit:
+			return
+		}
+	}
+}
+
+// Close stops sweeping pending traces, and makes the sampling decision
+// of the traces still pending, writing the ones to keep.
+func (h *Recorder) Close() error {
+	h.closeOnce.Do(func() {
+		close(h.quit)
+		<-h.done
+		h.sampler.flush()
+	})
+	return nil
 }
 
 type RecordData struct {
@@ -27,6 +69,10 @@ type RecordData struct {
 	TraceVersion trace2.Version
 	Buf          *bufio.Reader
 	Anchor       trace2.TimeAnchor
+
+	// Sampling is the tail-based sampling policy of the environment the trace is from.
+	// If nil, all traces are kept.
+	Sampling *SamplingPolicy
 }
 
 func (h *Recorder) RecordTrace(data RecordData) error {
@@ -88,6 +134,11 @@ func (h *Recorder) RecordTrace(data RecordData) error {
 				flushWrites()
 				return nil
 			}
+			if data.Sampling != nil {
+				// The sampler writes the events once the trace has been sampled.
+				h.sampler.add(data.Meta, data.Sampling, ev)
+				continue
+			}
 			debounce.Reset(500 * time.Millisecond)
 			pendingWrites = append(pendingWrites, ev)
 
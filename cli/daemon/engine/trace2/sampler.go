@@ -0,0 +1,216 @@
+package trace2
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/pkg/option"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// SamplingPolicy configures the tail-based sampling of an environment's traces.
+//
+// Sampling decisions are made once a trace's root span has ended, so that all spans
+// of a trace are either kept or dropped together.
+type SamplingPolicy struct {
+	// Rate is the fraction of traces to keep, between [0, 1],
+	// out of the traces that aren't always kept according to the fields below.
+	Rate float64
+
+	// LatencyThreshold, if non-zero, is the duration at or above which
+	// a span causes its trace to always be kept.
+	LatencyThreshold time.Duration
+
+	// Endpoints are the endpoints whose traces are always kept,
+	// as "service.Endpoint", or "service" for all the endpoints of a service.
+	Endpoints []string
+}
+
+// flagged reports whether the endpoint is one whose traces are always kept.
+func (p *SamplingPolicy) flagged(service, endpoint string) bool {
+	return slices.Contains(p.Endpoints, service) || slices.Contains(p.Endpoints, service+"."+endpoint)
+}
+
+const (
+	// maxPendingAge is how long the events of a trace are buffered waiting for
+	// its root span to end, before a sampling decision is made regardless.
+	maxPendingAge = 1 * time.Minute
+
+	// decisionTTL is how long sampling decisions are remembered,
+	// so that events of a trace arriving late follow the decision.
+	decisionTTL = 5 * time.Minute
+
+	// sweepInterval is how often expired traces and decisions are swept, by the Recorder.
+	sweepInterval = 10 * time.Second
+)
+
+type traceKey struct {
+	appID     string
+	high, low uint64
+}
+
+// pendingTrace is a trace whose sampling decision hasn't been made yet.
+type pendingTrace struct {
+	meta    *Meta
+	policy  *SamplingPolicy
+	events  []*tracepb2.TraceEvent
+	created time.Time
+
+	root        option.Option[uint64] // the span id of the root span, if seen
+	interesting bool                  // whether the trace must be kept
+}
+
+type decision struct {
+	keep    bool
+	expires time.Time
+}
+
+// tailSampler buffers the events of traces until their root span ends,
+// and then writes or drops them according to their environment's SamplingPolicy.
+type tailSampler struct {
+	s Store
+
+	// now and sample are overridden in tests.
+	now    func() time.Time
+	sample func(rate float64) bool
+
+	mu      sync.Mutex
+	pending map[traceKey]*pendingTrace
+	decided map[traceKey]decision
+}
+
+func newTailSampler(s Store) *tailSampler {
+	return &tailSampler{
+		s:      s,
+		now:    time.Now,
+		sample: func(rate float64) bool { return rand.Float64() < rate },
+
+		pending: make(map[traceKey]*pendingTrace),
+		decided: make(map[traceKey]decision),
+	}
+}
+
+// add adds a trace event to be sampled according to policy.
+func (ts *tailSampler) add(meta *Meta, policy *SamplingPolicy, ev *tracepb2.TraceEvent) {
+	key := traceKey{appID: meta.AppID, high: ev.TraceId.GetHigh(), low: ev.TraceId.GetLow()}
+
+	ts.mu.Lock()
+	now := ts.now()
+	var write []*pendingTrace
+	if d, ok := ts.decided[key]; ok {
+		if d.keep {
+			write = append(write, &pendingTrace{meta: meta, events: []*tracepb2.TraceEvent{ev}})
+		}
+	} else {
+		pt := ts.pending[key]
+		if pt == nil {
+			pt = &pendingTrace{meta: meta, policy: policy, created: now}
+			ts.pending[key] = pt
+		}
+		pt.observe(ev)
+
+		if root, ok := pt.root.Get(); ok && root == ev.SpanId && ev.GetSpanEnd() != nil {
+			if ts.decide(key, pt, now) {
+				write = append(write, pt)
+			}
+		}
+	}
+	ts.mu.Unlock()
+
+	for _, pt := range write {
+		ts.write(pt)
+	}
+}
+
+// observe adds ev to the trace, and records whether it makes the trace interesting.
+func (pt *pendingTrace) observe(ev *tracepb2.TraceEvent) {
+	pt.events = append(pt.events, ev)
+
+	if start := ev.GetSpanStart(); start != nil {
+		if start.ParentSpanId == nil {
+			pt.root = option.Some(ev.SpanId)
+		}
+		if req := start.GetRequest(); req != nil && pt.policy.flagged(req.ServiceName, req.EndpointName) {
+			pt.interesting = true
+		} else if auth := start.GetAuth(); auth != nil && pt.policy.flagged(auth.ServiceName, auth.EndpointName) {
+			pt.interesting = true
+		}
+	} else if end := ev.GetSpanEnd(); end != nil {
+		if end.Error != nil || end.PanicStack != nil {
+			pt.interesting = true
+		}
+		if t := pt.policy.LatencyThreshold; t > 0 && time.Duration(end.DurationNanos) >= t {
+			pt.interesting = true
+		}
+	}
+}
+
+// decide makes the sampling decision of a pending trace, and reports whether to keep it.
+// It must be called with ts.mu held.
+func (ts *tailSampler) decide(key traceKey, pt *pendingTrace, now time.Time) bool {
+	keep := pt.interesting || ts.sample(pt.policy.Rate)
+	delete(ts.pending, key)
+	ts.decided[key] = decision{keep: keep, expires: now.Add(decisionTTL)}
+	return keep
+}
+
+// tick sweeps the traces that have been pending for too long and the expired decisions,
+// writing the swept traces to keep. It's called periodically, so that traces are
+// swept even when no more events arrive.
+func (ts *tailSampler) tick() {
+	ts.mu.Lock()
+	write := ts.sweep(ts.now())
+	ts.mu.Unlock()
+
+	for _, pt := range write {
+		ts.write(pt)
+	}
+}
+
+// flush makes the sampling decision of all pending traces,
+// regardless of how long they've been pending, and writes the traces to keep.
+func (ts *tailSampler) flush() {
+	ts.mu.Lock()
+	now := ts.now()
+	var write []*pendingTrace
+	for key, pt := range ts.pending {
+		if ts.decide(key, pt, now) {
+			write = append(write, pt)
+		}
+	}
+	ts.mu.Unlock()
+
+	for _, pt := range write {
+		ts.write(pt)
+	}
+}
+
+// sweep makes the sampling decision of traces that have been pending for too long,
+// and forgets expired decisions. It reports the traces to keep.
+// It must be called with ts.mu held.
+func (ts *tailSampler) sweep(now time.Time) (keep []*pendingTrace) {
+	for key, pt := range ts.pending {
+		if now.Sub(pt.created) >= maxPendingAge && ts.decide(key, pt, now) {
+			keep = append(keep, pt)
+		}
+	}
+	for key, d := range ts.decided {
+		if now.After(d.expires) {
+			delete(ts.decided, key)
+		}
+	}
+	return keep
+}
+
+func (ts *tailSampler) write(pt *pendingTrace) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ts.s.WriteEvents(ctx, pt.meta, pt.events); err != nil {
+		log.Error().Err(err).Msg("unable to write trace events")
+	}
+}
@@ -0,0 +1,191 @@
+package trace2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// memStore is a Store that records the written events.
+type memStore struct {
+	Store
+	written []*tracepb2.TraceEvent
+}
+
+func (s *memStore) WriteEvents(ctx context.Context, meta *Meta, events []*tracepb2.TraceEvent) error {
+	s.written = append(s.written, events...)
+	return nil
+}
+
+func spanStart(traceID, spanID uint64, parent *uint64, service, endpoint string) *tracepb2.TraceEvent {
+	return &tracepb2.TraceEvent{
+		TraceId: &tracepb2.TraceID{Low: traceID},
+		SpanId:  spanID,
+		Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+			ParentSpanId: parent,
+			Data: &tracepb2.SpanStart_Request{Request: &tracepb2.RequestSpanStart{
+				ServiceName:  service,
+				EndpointName: endpoint,
+			}},
+		}},
+	}
+}
+
+func spanEnd(traceID, spanID uint64, dur time.Duration, err *tracepb2.Error) *tracepb2.TraceEvent {
+	return &tracepb2.TraceEvent{
+		TraceId: &tracepb2.TraceID{Low: traceID},
+		SpanId:  spanID,
+		Event: &tracepb2.TraceEvent_SpanEnd{SpanEnd: &tracepb2.SpanEnd{
+			DurationNanos: uint64(dur),
+			Error:         err,
+			Data:          &tracepb2.SpanEnd_Request{Request: &tracepb2.RequestSpanEnd{}},
+		}},
+	}
+}
+
+// trace returns the events of a trace with a root span calling svc.Child,
+// where the child span takes childDur and fails with childErr.
+func trace(traceID uint64, childDur time.Duration, childErr *tracepb2.Error) []*tracepb2.TraceEvent {
+	root := uint64(1)
+	return []*tracepb2.TraceEvent{
+		spanStart(traceID, 1, nil, "svc", "Root"),
+		spanStart(traceID, 2, &root, "svc", "Child"),
+		spanEnd(traceID, 2, childDur, childErr),
+		spanEnd(traceID, 1, childDur+time.Millisecond, nil),
+	}
+}
+
+func TestTailSampler(t *testing.T) {
+	policy := &SamplingPolicy{
+		Rate:             0.5,
+		LatencyThreshold: time.Second,
+		Endpoints:        []string{"flagged.Endpoint"},
+	}
+	tests := []struct {
+		name   string
+		events []*tracepb2.TraceEvent
+		sample bool
+		want   bool
+	}{
+		{name: "sampled", events: trace(1, time.Millisecond, nil), sample: true, want: true},
+		{name: "not_sampled", events: trace(1, time.Millisecond, nil), sample: false, want: false},
+		{name: "error", events: trace(1, time.Millisecond, &tracepb2.Error{Msg: "boom"}), want: true},
+		{name: "slow", events: trace(1, 2*time.Second, nil), want: true},
+		{
+			name: "flagged_endpoint",
+			events: []*tracepb2.TraceEvent{
+				spanStart(1, 1, nil, "flagged", "Endpoint"),
+				spanEnd(1, 1, time.Millisecond, nil),
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			store := &memStore{}
+			ts := newTailSampler(store)
+			var sampled []float64
+			ts.sample = func(rate float64) bool {
+				sampled = append(sampled, rate)
+				return tt.sample
+			}
+
+			meta := &Meta{AppID: "app"}
+			for i, ev := range tt.events {
+				ts.add(meta, policy, ev)
+				if i < len(tt.events)-1 {
+					c.Assert(store.written, qt.HasLen, 0, qt.Commentf("written before the root span ended"))
+				}
+			}
+			if tt.want {
+				c.Assert(store.written, qt.CmpEquals(protocmp.Transform()), tt.events)
+			} else {
+				c.Assert(store.written, qt.HasLen, 0)
+			}
+
+			// Traces that must be kept aren't subject to the sampling rate.
+			if tt.sample || !tt.want {
+				c.Assert(sampled, qt.DeepEquals, []float64{0.5})
+			} else {
+				c.Assert(sampled, qt.HasLen, 0)
+			}
+
+			// Events arriving after the decision follow it.
+			store.written = nil
+			late := spanEnd(1, 3, time.Millisecond, &tracepb2.Error{Msg: "late"})
+			ts.add(meta, policy, late)
+			if tt.want {
+				c.Assert(store.written, qt.CmpEquals(protocmp.Transform()), []*tracepb2.TraceEvent{late})
+			} else {
+				c.Assert(store.written, qt.HasLen, 0)
+			}
+		})
+	}
+}
+
+func TestTailSampler_Expiry(t *testing.T) {
+	c := qt.New(t)
+	store := &memStore{}
+	ts := newTailSampler(store)
+	ts.sample = func(float64) bool { return false }
+	now := time.Now()
+	ts.now = func() time.Time { return now }
+
+	// A trace whose root span never ends, with a failing child span.
+	meta := &Meta{AppID: "app"}
+	policy := &SamplingPolicy{Rate: 0}
+	events := trace(1, time.Millisecond, &tracepb2.Error{Msg: "boom"})[:3]
+	for _, ev := range events {
+		ts.add(meta, policy, ev)
+	}
+	c.Assert(store.written, qt.HasLen, 0)
+
+	// The trace is sampled when it has been pending for too long,
+	// even if no more events arrive.
+	now = now.Add(maxPendingAge / 2)
+	ts.tick()
+	c.Assert(store.written, qt.HasLen, 0)
+	ts.add(meta, policy, spanStart(2, 1, nil, "svc", "Other"))
+	now = now.Add(maxPendingAge / 2)
+	ts.tick()
+	c.Assert(store.written, qt.CmpEquals(protocmp.Transform()), events)
+	c.Assert(ts.pending, qt.HasLen, 1) // the other trace
+
+	// Decisions are forgotten after a while.
+	ts.add(meta, policy, spanEnd(2, 1, time.Millisecond, nil))
+	c.Assert(ts.decided, qt.HasLen, 2)
+	now = now.Add(decisionTTL + time.Second)
+	ts.tick()
+	c.Assert(ts.decided, qt.HasLen, 0)
+	c.Assert(ts.pending, qt.HasLen, 0)
+}
+
+func TestRecorder_Close(t *testing.T) {
+	c := qt.New(t)
+	store := &memStore{}
+	rec := NewRecorder(store)
+	rec.sampler.sample = func(float64) bool { return false }
+
+	// A failing trace whose root span hasn't ended, and a trace that isn't kept.
+	meta := &Meta{AppID: "app"}
+	policy := &SamplingPolicy{Rate: 0}
+	failing := trace(1, time.Millisecond, &tracepb2.Error{Msg: "boom"})[:3]
+	for _, ev := range failing {
+		rec.sampler.add(meta, policy, ev)
+	}
+	rec.sampler.add(meta, policy, spanStart(2, 1, nil, "svc", "Other"))
+	c.Assert(store.written, qt.HasLen, 0)
+
+	// Closing the recorder samples the pending traces.
+	c.Assert(rec.Close(), qt.IsNil)
+	c.Assert(store.written, qt.CmpEquals(protocmp.Transform()), failing)
+	c.Assert(rec.sampler.pending, qt.HasLen, 0)
+	c.Assert(rec.Close(), qt.IsNil)
+}
@@ -43,6 +43,15 @@ func (s *Store) List(ctx context.Context, q *trace2.Query, iter trace2.ListEntry
 		}
 	}
 
+	if !q.StartTime.IsZero() {
+		args = append(args, q.StartTime.UnixNano())
+		extraWhereClause += " AND started_at >= $" + strconv.Itoa(len(args))
+	}
+	if !q.EndTime.IsZero() {
+		args = append(args, q.EndTime.UnixNano())
+		extraWhereClause += " AND started_at < $" + strconv.Itoa(len(args))
+	}
+
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT
 		    trace_id, span_id, started_at, span_type, is_root, service_name, endpoint_name,
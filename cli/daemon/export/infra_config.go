@@ -166,7 +166,7 @@ func buildAndValidateInfraConfig(params EmbeddedInfraConfigParams) (*infra.Infra
 	slices.Sort(secrets)
 	secrets = slices.Compact(secrets)
 	var ok bool
-	if infraCfg.Secrets.EnvRef == nil {
+	if infraCfg.Secrets.EnvRef == nil && infraCfg.Secrets.Provider == nil {
 		for secret := range infraCfg.Secrets.SecretsMap {
 			secrets, ok = fns.Delete(secrets, secret)
 			if !ok {
@@ -213,12 +213,25 @@ func buildAndValidateInfraConfig(params EmbeddedInfraConfigParams) (*infra.Infra
 		})
 	})
 
+	for name := range infraCfg.Memcached {
+		if _, ok := infraCfg.Redis[name]; ok {
+			path := infra.JSONPath("memcached").Append(infra.JSONPath(name))
+			validationErrors[path] = errors.New("Cache cluster is configured as both Redis and Memcached")
+		}
+	}
+
 	for name := range infraCfg.Redis {
 		caches, ok = fns.Delete(caches, name)
 		if !ok {
 			delete(infraCfg.Redis, name)
 		}
 	}
+	for name := range infraCfg.Memcached {
+		caches, ok = fns.Delete(caches, name)
+		if !ok {
+			delete(infraCfg.Memcached, name)
+		}
+	}
 
 	if len(caches) > 0 {
 		missing["Redis"] = caches
@@ -264,6 +277,16 @@ func buildAndValidateInfraConfig(params EmbeddedInfraConfigParams) (*infra.Infra
 		})
 	})
 
+	// Subscriptions with a dead-letter topic need access to it, as providers
+	// without native dead-lettering (like NSQ) forward messages to it from the service.
+	for _, topic := range md.PubsubTopics {
+		for _, sub := range topic.Subscriptions {
+			if dl := sub.DeadLetterPolicy; dl != nil && slices.Contains(services, sub.ServiceName) && !slices.Contains(topics, dl.TopicName) {
+				topics = append(topics, dl.TopicName)
+			}
+		}
+	}
+
 	for i, pubsub := range infraCfg.PubSub {
 		for topicName, topic := range pubsub.GetTopics() {
 			i := slices.Index(topics, topicName)
@@ -299,6 +322,15 @@ func buildAndValidateInfraConfig(params EmbeddedInfraConfigParams) (*infra.Infra
 					validationErrors[path] = errors.New("Bucket is public but no public base URL is set")
 					return nil, "", configError(missing, validationErrors)
 				}
+				if metaBkt.ClientSideEncryption && infraCfg.KMSKey == "" {
+					path := infra.JSONPath("buckets").Append(infra.JSONPath(name)).Append("kms_key")
+					validationErrors[path] = errors.New("Bucket uses client-side encryption but no KMS key is set")
+					return nil, "", configError(missing, validationErrors)
+				} else if !metaBkt.ClientSideEncryption && infraCfg.KMSKey != "" {
+					path := infra.JSONPath("buckets").Append(infra.JSONPath(name)).Append("kms_key")
+					validationErrors[path] = errors.New("Bucket has a KMS key set but does not use client-side encryption")
+					return nil, "", configError(missing, validationErrors)
+				}
 			}
 
 			buckets, ok = fns.Delete(buckets, name)
@@ -311,6 +343,11 @@ func buildAndValidateInfraConfig(params EmbeddedInfraConfigParams) (*infra.Infra
 		missing["Buckets"] = buckets
 	}
 
+	applyResourceTags(md, &infraCfg)
+	maps.Copy(validationErrors, validateResourceRegions(md, &infraCfg))
+	maps.Copy(validationErrors, validateMemcachedKeyspaces(md, &infraCfg, services))
+	maps.Copy(validationErrors, applyBucketLifecycles(md, &infraCfg))
+
 	// Copy CORS config
 	cors := infra.CORS(params.GlobalCORS)
 	infraCfg.CORS = &cors
@@ -335,6 +372,221 @@ func buildAndValidateInfraConfig(params EmbeddedInfraConfigParams) (*infra.Infra
 	return &infraCfg, resp.String(), nil
 }
 
+// applyResourceTags adds the tags declared for resources in the application
+// to their infra configuration, so they can be applied when provisioning.
+func applyResourceTags(md *meta.Data, cfg *infra.InfraConfig) {
+	dbTags := make(map[string]map[string]string)
+	for _, db := range md.SqlDatabases {
+		dbTags[db.Name] = db.Tags
+	}
+	for _, srv := range cfg.SQLServers {
+		for name, db := range srv.Databases {
+			db.Tags = mergeTags(dbTags[name], db.Tags)
+		}
+	}
+
+	for _, cluster := range md.CacheClusters {
+		if redis, ok := cfg.Redis[cluster.Name]; ok {
+			redis.Tags = mergeTags(cluster.Tags, redis.Tags)
+		}
+	}
+
+	topicTags := make(map[string]map[string]string)
+	for _, topic := range md.PubsubTopics {
+		topicTags[topic.Name] = topic.Tags
+	}
+	for _, pubsub := range cfg.PubSub {
+		for name, topic := range pubsub.GetTopics() {
+			topic.SetTags(mergeTags(topicTags[name], topic.GetTags()))
+		}
+	}
+
+	bucketTags := make(map[string]map[string]string)
+	for _, bkt := range md.Buckets {
+		bucketTags[bkt.Name] = bkt.Tags
+	}
+	for _, storage := range cfg.ObjectStorage {
+		for name, bkt := range storage.GetBuckets() {
+			bkt.Tags = mergeTags(bucketTags[name], bkt.Tags)
+		}
+	}
+}
+
+// memcachedKeyspaceKinds are the kinds of keyspaces that can be
+// backed by Memcached, which only supports basic key-value operations.
+var memcachedKeyspaceKinds = []meta.CacheCluster_Keyspace_Kind{
+	meta.CacheCluster_Keyspace_STRING,
+	meta.CacheCluster_Keyspace_INT,
+	meta.CacheCluster_Keyspace_FLOAT,
+	meta.CacheCluster_Keyspace_STRUCT,
+}
+
+// validateMemcachedKeyspaces reports cache clusters configured to use Memcached
+// where the hosted services use keyspaces requiring operations Memcached doesn't support.
+func validateMemcachedKeyspaces(md *meta.Data, cfg *infra.InfraConfig, services []string) map[infra.JSONPath]error {
+	errs := make(map[infra.JSONPath]error)
+	for _, cluster := range md.CacheClusters {
+		if _, ok := cfg.Memcached[cluster.Name]; !ok {
+			continue
+		}
+		var unsupported []string
+		for _, ks := range cluster.Keyspaces {
+			if slices.Contains(services, ks.Service) && !slices.Contains(memcachedKeyspaceKinds, ks.Kind) {
+				kind := strings.ReplaceAll(strings.ToLower(ks.Kind.String()), "_", " ")
+				unsupported = append(unsupported, fmt.Sprintf("%s keyspace in service %s", kind, ks.Service))
+			}
+		}
+		if len(unsupported) > 0 {
+			path := infra.JSONPath("memcached").Append(infra.JSONPath(cluster.Name))
+			errs[path] = errors.Newf("Cache cluster %s is not supported by Memcached, which only supports "+
+				"string, int, float and struct keyspaces; found %s", cluster.Name, strings.Join(unsupported, ", "))
+		}
+	}
+	return errs
+}
+
+// validateResourceRegions reports resources that are restricted to certain
+// regions but are configured to be provisioned elsewhere, or in no known region.
+// Resources without their own region fall back to the environment's region.
+func validateResourceRegions(md *meta.Data, cfg *infra.InfraConfig) map[infra.JSONPath]error {
+	errs := make(map[infra.JSONPath]error)
+	check := func(path infra.JSONPath, kind, name string, allowed []string, region string) {
+		if len(allowed) == 0 {
+			return
+		}
+		if region == "" {
+			errs[path] = errors.Newf("%s %s is restricted to regions [%s] but no region is configured",
+				kind, name, strings.Join(allowed, ", "))
+		} else if !regionAllowed(allowed, region) {
+			errs[path] = errors.Newf("%s %s is restricted to regions [%s] but is configured in region %s",
+				kind, name, strings.Join(allowed, ", "), region)
+		}
+	}
+	orEnv := func(region string) string {
+		if region != "" {
+			return region
+		}
+		return cfg.Metadata.Region
+	}
+
+	dbRegions := make(map[string][]string)
+	for _, db := range md.SqlDatabases {
+		dbRegions[db.Name] = db.Regions
+	}
+	for i, srv := range cfg.SQLServers {
+		for name := range srv.Databases {
+			path := infra.JSONPath(fmt.Sprintf("sql_servers[%d].databases", i)).Append(infra.JSONPath(name))
+			check(path, "Database", name, dbRegions[name], orEnv(srv.Region))
+		}
+	}
+
+	bucketRegions := make(map[string][]string)
+	for _, bkt := range md.Buckets {
+		bucketRegions[bkt.Name] = bkt.Regions
+	}
+	for i, storage := range cfg.ObjectStorage {
+		var region string
+		switch {
+		case storage.S3 != nil:
+			region = storage.S3.Region
+		case storage.GCS != nil:
+			region = storage.GCS.Region
+		}
+		for name := range storage.GetBuckets() {
+			path := infra.JSONPath(fmt.Sprintf("object_storage[%d].buckets", i)).Append(infra.JSONPath(name))
+			check(path, "Bucket", name, bucketRegions[name], orEnv(region))
+		}
+	}
+
+	topicRegions := make(map[string][]string)
+	for _, topic := range md.PubsubTopics {
+		topicRegions[topic.Name] = topic.Regions
+	}
+	for i, pubsub := range cfg.PubSub {
+		for name, topic := range pubsub.GetTopics() {
+			var region string
+			if aws, ok := topic.(*infra.AWSTopic); ok {
+				// ARNs have the form arn:aws:sns:<region>:<account>:<name>.
+				if parts := strings.Split(aws.ARN, ":"); len(parts) > 3 {
+					region = parts[3]
+				}
+			}
+			path := infra.JSONPath(fmt.Sprintf("pubsub[%d].topics", i)).Append(infra.JSONPath(name))
+			check(path, "Topic", name, topicRegions[name], orEnv(region))
+		}
+	}
+	return errs
+}
+
+// regionAllowed reports whether region matches any of the allowed regions.
+// An allowed region ending in "*" matches any region with that prefix.
+func regionAllowed(allowed []string, region string) bool {
+	for _, a := range allowed {
+		if prefix, ok := strings.CutSuffix(a, "*"); ok {
+			if strings.HasPrefix(region, prefix) {
+				return true
+			}
+		} else if a == region {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBucketLifecycles sets the lifecycle rules declared for buckets in the
+// application on their infra configuration, so they can be applied when provisioning.
+// It reports lifecycles the configured storage provider can't apply, and ones
+// configured differently from the application, since the code is the source of truth.
+func applyBucketLifecycles(md *meta.Data, cfg *infra.InfraConfig) map[infra.JSONPath]error {
+	errs := make(map[infra.JSONPath]error)
+	lifecycles := make(map[string]*meta.Bucket_Lifecycle)
+	for _, bkt := range md.Buckets {
+		lifecycles[bkt.Name] = bkt.Lifecycle
+	}
+
+	for i, storage := range cfg.ObjectStorage {
+		for name, bkt := range storage.GetBuckets() {
+			path := infra.JSONPath(fmt.Sprintf("object_storage[%d].buckets", i)).Append(infra.JSONPath(name)).Append("lifecycle")
+			lc := lifecycles[name]
+			if lc == nil {
+				if bkt.Lifecycle != nil {
+					errs[path] = errors.Newf("Bucket %s has a lifecycle configured, but none is declared in the application", name)
+				}
+				continue
+			}
+
+			declared := &infra.BucketLifecycle{
+				ExpireAfterDays:      int(lc.ExpireAfterDays),
+				ColdStorageAfterDays: int(lc.ColdStorageAfterDays),
+			}
+			if bkt.Lifecycle != nil && *bkt.Lifecycle != *declared {
+				errs[path] = errors.Newf("Bucket %s has a lifecycle configured that differs from the one declared in the application", name)
+				continue
+			}
+
+			// Providers other than AWS that implement the S3 API
+			// generally don't support storage class transitions.
+			if storage.S3 != nil && storage.S3.Endpoint != "" && declared.ColdStorageAfterDays > 0 {
+				errs[path] = errors.Newf("Bucket %s moves objects to cold storage, which is not supported by custom S3 providers", name)
+				continue
+			}
+			bkt.Lifecycle = declared
+		}
+	}
+	return errs
+}
+
+// mergeTags returns the declared tags overridden by the configured tags.
+func mergeTags(declared, configured map[string]string) map[string]string {
+	if len(declared) == 0 {
+		return configured
+	}
+	merged := make(map[string]string, len(declared)+len(configured))
+	maps.Copy(merged, declared)
+	maps.Copy(merged, configured)
+	return merged
+}
+
 func formatCronJobInstructions(services []string, md *meta.Data) (string, error) {
 	if len(md.CronJobs) == 0 {
 		return "", nil
@@ -119,6 +119,19 @@ func (m *Manager) getPubSub(ctx context.Context, request mcp.CallToolRequest) (*
 				subscriptionInfo["retry_policy"] = retryPolicy
 			}
 
+			// Add dead-letter policy if available
+			if dl := subscription.DeadLetterPolicy; dl != nil {
+				subscriptionInfo["dead_letter_policy"] = map[string]interface{}{
+					"topic":          dl.TopicName,
+					"max_deliveries": dl.MaxDeliveries,
+				}
+			}
+
+			// Add the attribute filter if available
+			if subscription.Filter != "" {
+				subscriptionInfo["filter"] = subscription.Filter
+			}
+
 			subscriptions = append(subscriptions, subscriptionInfo)
 		}
 
@@ -144,6 +157,9 @@ func (m *Manager) getPubSub(ctx context.Context, request mcp.CallToolRequest) (*
 		if topic.OrderingKey != "" {
 			topicInfo["ordering_key"] = topic.OrderingKey
 		}
+		if topic.Ordered {
+			topicInfo["ordered"] = true
+		}
 
 		// Add message type if available
 		if topic.MessageType != nil {
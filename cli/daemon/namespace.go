@@ -2,11 +2,15 @@ package daemon
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/team"
 	"encr.dev/pkg/fns"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
@@ -16,11 +20,11 @@ func (s *Server) CreateNamespace(ctx context.Context, req *daemonpb.CreateNamesp
 	if err != nil {
 		return nil, err
 	}
-	ns, err := s.ns.Create(ctx, app, namespace.Name(req.Name))
+	ns, err := s.ns.Create(ctx, app, scopedNamespace(ctx, req.Name))
 	if err != nil {
 		return nil, err
 	}
-	return ns.ToProto(), nil
+	return s.namespaceProto(ctx, ns), nil
 }
 
 func (s *Server) ListNamespaces(ctx context.Context, req *daemonpb.ListNamespacesRequest) (*daemonpb.ListNamespacesResponse, error) {
@@ -32,7 +36,24 @@ func (s *Server) ListNamespaces(ctx context.Context, req *daemonpb.ListNamespace
 	if err != nil {
 		return nil, err
 	}
-	protos := fns.Map(nss, (*namespace.Namespace).ToProto)
+
+	// In shared mode, only list the user's own namespaces.
+	if u, ok := team.UserFromContext(ctx); ok {
+		nss = slices.DeleteFunc(nss, func(ns *namespace.Namespace) bool {
+			return !strings.HasPrefix(string(ns.Name), u.NamespacePrefix())
+		})
+		if len(nss) == 0 {
+			ns, err := s.namespaceOrActive(ctx, app, nil)
+			if err != nil {
+				return nil, err
+			}
+			nss = []*namespace.Namespace{ns}
+		}
+	}
+
+	protos := fns.Map(nss, func(ns *namespace.Namespace) *daemonpb.Namespace {
+		return s.namespaceProto(ctx, ns)
+	})
 	return &daemonpb.ListNamespacesResponse{Namespaces: protos}, nil
 }
 
@@ -41,7 +62,11 @@ func (s *Server) DeleteNamespace(ctx context.Context, req *daemonpb.DeleteNamesp
 	if err != nil {
 		return nil, err
 	}
-	if err := s.ns.Delete(ctx, app, namespace.Name(req.Name)); err != nil {
+	name := scopedNamespace(ctx, req.Name)
+	if u, ok := team.UserFromContext(ctx); ok && s.activeUserNamespace(app, u) == name {
+		return nil, namespace.ErrActive
+	}
+	if err := s.ns.Delete(ctx, app, name); err != nil {
 		return nil, err
 	}
 	return &empty.Empty{}, nil
@@ -53,14 +78,27 @@ func (s *Server) SwitchNamespace(ctx context.Context, req *daemonpb.SwitchNamesp
 		return nil, err
 	}
 
+	name := scopedNamespace(ctx, req.Name)
 	if req.Create {
-		_, err := s.ns.Create(ctx, app, namespace.Name(req.Name))
+		_, err := s.ns.Create(ctx, app, name)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	ns, err := s.ns.Switch(ctx, app, namespace.Name(req.Name))
+	// In shared mode, each user has their own active namespace.
+	if u, ok := team.UserFromContext(ctx); ok {
+		ns, err := s.ns.GetByName(ctx, app, name)
+		if err != nil {
+			return nil, err
+		}
+		s.userNSMu.Lock()
+		s.userNS[userNSKey{appID: app.PlatformOrLocalID(), user: u.Name}] = ns.Name
+		s.userNSMu.Unlock()
+		return s.namespaceProto(ctx, ns), nil
+	}
+
+	ns, err := s.ns.Switch(ctx, app, name)
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +106,56 @@ func (s *Server) SwitchNamespace(ctx context.Context, req *daemonpb.SwitchNamesp
 }
 
 func (s *Server) namespaceOrActive(ctx context.Context, app *apps.Instance, ns *string) (*namespace.Namespace, error) {
+	if u, ok := team.UserFromContext(ctx); ok {
+		name := s.activeUserNamespace(app, u)
+		if ns != nil {
+			name = namespace.Name(u.Namespace(*ns))
+		}
+		res, err := s.ns.GetByName(ctx, app, name)
+		if errors.Is(err, namespace.ErrNotFound) && name == namespace.Name(u.Namespace("default")) {
+			res, err = s.ns.Create(ctx, app, name)
+		}
+		return res, err
+	}
+
 	if ns == nil {
 		return s.ns.GetActive(ctx, app)
 	}
 	return s.ns.GetByName(ctx, app, namespace.Name(*ns))
 }
+
+// userNSKey is the key for tracking the active namespace of a user in shared mode.
+type userNSKey struct {
+	appID string
+	user  string
+}
+
+// activeUserNamespace returns the name of the user's active namespace for the app.
+func (s *Server) activeUserNamespace(app *apps.Instance, u *team.User) namespace.Name {
+	s.userNSMu.Lock()
+	defer s.userNSMu.Unlock()
+	if name, ok := s.userNS[userNSKey{appID: app.PlatformOrLocalID(), user: u.Name}]; ok {
+		return name
+	}
+	return namespace.Name(u.Namespace("default"))
+}
+
+// scopedNamespace returns the namespace name to use for the given name.
+// In shared mode namespaces are scoped to the authenticated user.
+func scopedNamespace(ctx context.Context, name string) namespace.Name {
+	if u, ok := team.UserFromContext(ctx); ok {
+		return namespace.Name(u.Namespace(name))
+	}
+	return namespace.Name(name)
+}
+
+// namespaceProto converts ns to its protobuf representation,
+// as seen by the authenticated user in shared mode.
+func (s *Server) namespaceProto(ctx context.Context, ns *namespace.Namespace) *daemonpb.Namespace {
+	res := ns.ToProto()
+	if u, ok := team.UserFromContext(ctx); ok {
+		res.Name = strings.TrimPrefix(res.Name, u.NamespacePrefix())
+		res.Active = s.activeUserNamespace(ns.App, u) == ns.Name
+	}
+	return res
+}
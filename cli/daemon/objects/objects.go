@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/pkg/emulators/storage/gcsemu"
@@ -27,6 +28,9 @@ type Server struct {
 	ln        net.Listener
 	srv       *http.Server
 	inMemory  bool
+
+	// Port is the port to listen on. If zero a free port is used.
+	Port int
 }
 
 func NewInMemoryServer(public *PublicBucketServer) *Server {
@@ -65,7 +69,7 @@ func (s *Server) Start() error {
 			s.public.Register(s.id, s.store)
 		}
 		mux := http.NewServeMux()
-		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		ln, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(s.Port))
 		if err != nil {
 			return errors.Wrap(err, "listen tcp")
 		}
@@ -0,0 +1,65 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/pkg/fns"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// PubSubReplay re-publishes the messages stored for a topic of the running app.
+//
+// Messages published to the local NSQ daemon are recorded while the app is running,
+// and replayed messages are marked as such so that the runtime can record the
+// replay in traces and limit processing to the requested subscription.
+func (s *Server) PubSubReplay(ctx context.Context, req *daemonpb.PubSubReplayRequest) (*daemonpb.PubSubReplayResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	r := s.mgr.FindRunByAppID(app.PlatformOrLocalID())
+	if r == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the app is not running")
+	}
+
+	pg := r.ProcGroup()
+	if pg == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the app is not running")
+	}
+	topic, ok := fns.Find(pg.Meta.PubsubTopics, func(t *meta.PubSubTopic) bool { return t.Name == req.Topic })
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "topic %q not found", req.Topic)
+	}
+	if sub := req.GetSubscription(); sub != "" {
+		if _, ok := fns.Find(topic.Subscriptions, func(s *meta.PubSubTopic_Subscription) bool { return s.Name == sub }); !ok {
+			return nil, status.Errorf(codes.NotFound, "subscription %q not found for topic %q", sub, req.Topic)
+		}
+	}
+
+	nsqd := r.ResourceManager.GetPubSub()
+	if nsqd == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the pubsub daemon is not running")
+	}
+
+	var from, to time.Time
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+
+	msgs := nsqd.Messages(topic.Name, from, to)
+	if !req.DryRun {
+		if err := nsqd.Replay(topic.Name, req.GetSubscription(), msgs); err != nil {
+			return nil, status.Errorf(codes.Internal, "replay messages: %v", err)
+		}
+	}
+	return &daemonpb.PubSubReplayResponse{Replayed: int32(len(msgs))}, nil
+}
@@ -2,6 +2,7 @@ package pubsub
 
 import (
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -15,8 +16,13 @@ import (
 type NSQDaemon struct {
 	nsqd      *nsqd.NSQD
 	startOnce syncutil.Once
+	store     messageStore
 
 	Opts *nsqd.Options
+
+	// Port is the TCP port to listen on when Opts is nil.
+	// If zero a free port is used.
+	Port int
 }
 
 func (n *NSQDaemon) Stats() (*nsqd.Stats, error) {
@@ -58,7 +64,7 @@ func (n *NSQDaemon) Start() error {
 
 			// Take the default address options and scope down to localhost (to prevent firewall warnings / permission requests)
 			// then set the port to 0 to allow any port to be used which is free
-			n.Opts.TCPAddress = "127.0.0.1:0"
+			n.Opts.TCPAddress = "127.0.0.1:" + strconv.Itoa(n.Port)
 			n.Opts.HTTPAddress = "127.0.0.1:0"
 			n.Opts.HTTPSAddress = "127.0.0.1:0"
 			n.Opts.MaxMsgSize = 10 * 1024 * 1024 // 10MB
@@ -80,6 +86,7 @@ func (n *NSQDaemon) Start() error {
 }
 
 func (n *NSQDaemon) Stop() {
+	n.store.stop()
 	if n.nsqd != nil {
 		n.nsqd.Exit()
 	}
@@ -0,0 +1,34 @@
+package pubsub
+
+import (
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nsqio/go-nsq"
+	"github.com/rs/xid"
+)
+
+// Publish publishes a message with the given attributes and JSON data to the topic,
+// the same way the runtime does. It returns the id of the published message.
+func (n *NSQDaemon) Publish(topic string, attrs map[string]string, data json.RawMessage) (string, error) {
+	if n.nsqd == nil {
+		return "", errors.New("nsqd not started")
+	}
+
+	msgID := xid.New().String()
+	body, err := json.Marshal(&messageWrapper{ID: msgID, Attributes: attrs, Data: data})
+	if err != nil {
+		return "", errors.Wrap(err, "marshal message")
+	}
+
+	p, err := nsq.NewProducer(n.Addr(), nsq.NewConfig())
+	if err != nil {
+		return "", err
+	}
+	defer p.Stop()
+	p.SetLogger(&logAdapter{"nsq producer"}, nsq.LogLevelWarning)
+	if err := p.Publish(topic, body); err != nil {
+		return "", err
+	}
+	return msgID, nil
+}
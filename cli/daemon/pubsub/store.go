@@ -0,0 +1,180 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nsqio/go-nsq"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// replayAttribute is the message attribute marking a message as replayed.
+// Its value is the name of the subscription the message is replayed to,
+// or "*" for all subscriptions. It must be synchronized with the
+// pubsub/types.go file in the runtime.
+const replayAttribute = "encore_replay"
+
+// recordChannel is the NSQ channel used to record the messages published to each topic.
+// It's ephemeral so that nsqd doesn't keep messages for it when the daemon isn't recording.
+const recordChannel = "encore-replay-store#ephemeral"
+
+// maxStoredMessages is the maximum number of messages stored per topic.
+// Once reached, the oldest messages are discarded.
+const maxStoredMessages = 10000
+
+// messageWrapper is the data structure for an NSQ message.
+// It must be synchronized with the nsq/topic.go file in the runtime.
+type messageWrapper struct {
+	ID         string
+	Attributes map[string]string
+	Data       json.RawMessage
+}
+
+// StoredMessage is a message published to a topic, stored for later replay.
+type StoredMessage struct {
+	ID          string
+	PublishTime time.Time
+	Attributes  map[string]string
+	Data        json.RawMessage
+}
+
+// messageStore records the messages published to the topics of an NSQ daemon.
+type messageStore struct {
+	mu        sync.Mutex
+	consumers map[string]*nsq.Consumer   // by topic name
+	messages  map[string][]StoredMessage // by topic name, in publish order
+}
+
+// Record starts recording the messages published to the app's topics,
+// so they can be replayed with Replay. It's a no-op for topics that
+// are already being recorded.
+func (n *NSQDaemon) Record(md *meta.Data) error {
+	if n.nsqd == nil {
+		return errors.New("nsqd not started")
+	}
+
+	s := &n.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.consumers == nil {
+		s.consumers = make(map[string]*nsq.Consumer)
+		s.messages = make(map[string][]StoredMessage)
+	}
+
+	for _, topic := range md.PubsubTopics {
+		if _, ok := s.consumers[topic.Name]; ok {
+			continue
+		}
+
+		cfg := nsq.NewConfig()
+		cfg.MaxInFlight = 100
+		consumer, err := nsq.NewConsumer(topic.Name, recordChannel, cfg)
+		if err != nil {
+			return errors.Wrapf(err, "record topic %s", topic.Name)
+		}
+		consumer.SetLogger(&logAdapter{"nsq recorder"}, nsq.LogLevelWarning)
+		consumer.AddHandler(nsq.HandlerFunc(func(m *nsq.Message) error {
+			s.add(topic.Name, m)
+			return nil
+		}))
+		if err := consumer.ConnectToNSQD(n.Addr()); err != nil {
+			consumer.Stop()
+			return errors.Wrapf(err, "record topic %s", topic.Name)
+		}
+		s.consumers[topic.Name] = consumer
+	}
+	return nil
+}
+
+// add stores a message published to the given topic.
+func (s *messageStore) add(topic string, m *nsq.Message) {
+	var msg messageWrapper
+	if err := json.Unmarshal(m.Body, &msg); err != nil {
+		return
+	} else if _, replayed := msg.Attributes[replayAttribute]; replayed {
+		// Don't store replayed messages a second time.
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msgs := append(s.messages[topic], StoredMessage{
+		ID:          msg.ID,
+		PublishTime: time.Unix(0, m.Timestamp),
+		Attributes:  msg.Attributes,
+		Data:        msg.Data,
+	})
+	if len(msgs) > maxStoredMessages {
+		msgs = msgs[len(msgs)-maxStoredMessages:]
+	}
+	s.messages[topic] = msgs
+}
+
+// Messages returns the stored messages published to the given topic
+// within the time range [from, to). A zero to means no upper bound.
+func (n *NSQDaemon) Messages(topic string, from, to time.Time) []StoredMessage {
+	s := &n.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var msgs []StoredMessage
+	for _, msg := range s.messages[topic] {
+		if msg.PublishTime.Before(from) || (!to.IsZero() && !msg.PublishTime.Before(to)) {
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// Replay re-publishes the given messages to the topic, marked as replayed.
+// If subscription is non-empty only that subscription processes the
+// replayed messages; other subscriptions acknowledge them without processing them.
+func (n *NSQDaemon) Replay(topic, subscription string, msgs []StoredMessage) error {
+	if n.nsqd == nil {
+		return errors.New("nsqd not started")
+	} else if len(msgs) == 0 {
+		return nil
+	}
+
+	target := subscription
+	if target == "" {
+		target = "*"
+	}
+
+	bodies := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		attrs := make(map[string]string, len(msg.Attributes)+1)
+		for k, v := range msg.Attributes {
+			attrs[k] = v
+		}
+		attrs[replayAttribute] = target
+
+		body, err := json.Marshal(&messageWrapper{ID: msg.ID, Attributes: attrs, Data: msg.Data})
+		if err != nil {
+			return fmt.Errorf("marshal message %s: %v", msg.ID, err)
+		}
+		bodies[i] = body
+	}
+
+	p, err := nsq.NewProducer(n.Addr(), nsq.NewConfig())
+	if err != nil {
+		return err
+	}
+	defer p.Stop()
+	p.SetLogger(&logAdapter{"nsq producer"}, nsq.LogLevelWarning)
+	return p.MultiPublish(topic, bodies)
+}
+
+// stop stops recording messages.
+func (s *messageStore) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.consumers {
+		c.Stop()
+	}
+}
@@ -0,0 +1,91 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/nsqio/go-nsq"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	c := qt.New(t)
+	n := &NSQDaemon{}
+	c.Assert(n.Start(), qt.IsNil)
+	defer n.Stop()
+
+	md := &meta.Data{PubsubTopics: []*meta.PubSubTopic{{Name: "orders"}}}
+	c.Assert(n.Record(md), qt.IsNil)
+
+	p, err := nsq.NewProducer(n.Addr(), nsq.NewConfig())
+	c.Assert(err, qt.IsNil)
+	defer p.Stop()
+	body, _ := json.Marshal(&messageWrapper{ID: "msg1", Attributes: map[string]string{"region": "eu"}, Data: json.RawMessage(`{"id":1}`)})
+	c.Assert(p.Publish("orders", body), qt.IsNil)
+
+	var msgs []StoredMessage
+	waitFor(c, func() bool {
+		msgs = n.Messages("orders", time.Now().Add(-time.Minute), time.Time{})
+		return len(msgs) == 1
+	})
+	c.Assert(msgs[0].ID, qt.Equals, "msg1")
+	c.Assert(msgs[0].Attributes, qt.DeepEquals, map[string]string{"region": "eu"})
+	c.Assert(string(msgs[0].Data), qt.Equals, `{"id":1}`)
+	c.Assert(n.Messages("orders", time.Now().Add(time.Minute), time.Time{}), qt.HasLen, 0)
+
+	// Consume the replayed messages on a separate channel.
+	replayed := make(chan *messageWrapper, 1)
+	consumer, err := nsq.NewConsumer("orders", "test#ephemeral", nsq.NewConfig())
+	c.Assert(err, qt.IsNil)
+	defer consumer.Stop()
+	consumer.AddHandler(nsq.HandlerFunc(func(m *nsq.Message) error {
+		var msg messageWrapper
+		if err := json.Unmarshal(m.Body, &msg); err == nil {
+			replayed <- &msg
+		}
+		return nil
+	}))
+	c.Assert(consumer.ConnectToNSQD(n.Addr()), qt.IsNil)
+
+	c.Assert(n.Replay("orders", "process-order", msgs), qt.IsNil)
+	select {
+	case msg := <-replayed:
+		c.Assert(msg.ID, qt.Equals, "msg1")
+		c.Assert(msg.Attributes, qt.DeepEquals, map[string]string{"region": "eu", replayAttribute: "process-order"})
+	case <-time.After(5 * time.Second):
+		c.Fatal("timed out waiting for replayed message")
+	}
+
+	// Replayed messages aren't stored again.
+	time.Sleep(100 * time.Millisecond)
+	c.Assert(n.Messages("orders", time.Time{}, time.Time{}), qt.HasLen, 1)
+
+	// Messages published by the daemon are delivered and stored like any other.
+	id, err := n.Publish("orders", map[string]string{"region": "us"}, json.RawMessage(`{"id":2}`))
+	c.Assert(err, qt.IsNil)
+	select {
+	case msg := <-replayed:
+		c.Assert(msg.ID, qt.Equals, id)
+		c.Assert(msg.Attributes, qt.DeepEquals, map[string]string{"region": "us"})
+		c.Assert(string(msg.Data), qt.Equals, `{"id":2}`)
+	case <-time.After(5 * time.Second):
+		c.Fatal("timed out waiting for published message")
+	}
+	waitFor(c, func() bool {
+		return len(n.Messages("orders", time.Time{}, time.Time{})) == 2
+	})
+}
+
+func waitFor(c *qt.C, cond func() bool) {
+	c.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			c.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
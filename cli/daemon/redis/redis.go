@@ -2,6 +2,7 @@ package redis
 
 import (
 	mathrand "math/rand" // nosemgrep
+	"strconv"
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
@@ -17,6 +18,9 @@ type Server struct {
 	cleanup   *time.Ticker
 	quit      chan struct{}
 	addr      string
+
+	// Port is the port to listen on. If zero a free port is used.
+	Port int
 }
 
 const tickInterval = 1 * time.Second
@@ -30,7 +34,7 @@ func New() *Server {
 
 func (s *Server) Start() error {
 	return s.startOnce.Do(func() error {
-		if err := s.mini.Start(); err != nil {
+		if err := s.mini.StartAddr("127.0.0.1:" + strconv.Itoa(s.Port)); err != nil {
 			return errors.Wrap(err, "failed to start redis server")
 		}
 		s.addr = s.mini.Addr()
@@ -210,6 +210,13 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	for db, connStr := range externalDBs {
 		_, _ = fmt.Fprintf(stderr, "     %s: %s\n", db, aurora.Cyan(connStr))
 	}
+	if req.Tunnel {
+		if tunnelURL, err := s.startTunnel(ctx, req, runInstance, stderr); err != nil {
+			_, _ = fmt.Fprintf(stderr, "  Tunnel URL:                 %s\n", aurora.Red(fmt.Sprintf("failed to start tunnel: %v", err)))
+		} else {
+			_, _ = fmt.Fprintf(stderr, "  Tunnel URL:                 %s\n", aurora.Cyan(tunnelURL))
+		}
+	}
 	if req.DebugMode == daemonpb.RunRequest_DEBUG_ENABLED {
 		// Print the pid for debugging. Currently we only support this if we have a default gateway.
 		if gw, ok := runInstance.ProcGroup().Gateways["api-gateway"]; ok {
@@ -0,0 +1,39 @@
+package run
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"encr.dev/pkg/cueutil"
+)
+
+// ServiceConfig is the effective config of a service.
+type ServiceConfig struct {
+	Service string          `json:"service"`
+	Config  json.RawMessage `json:"config"`
+	// Layers are the layers of config files merged into the config,
+	// in the order they were merged.
+	Layers []cueutil.Layer `json:"layers"`
+}
+
+// EffectiveConfig returns the effective config of the services
+// of the running app that load config, sorted by service name.
+// It returns nil if the app hasn't started yet.
+func (r *Run) EffectiveConfig() []ServiceConfig {
+	p := r.ProcGroup()
+	if p == nil {
+		return nil
+	}
+
+	configs := make([]ServiceConfig, 0, len(p.ConfigGen.SvcConfigs))
+	for svc, cfg := range p.ConfigGen.SvcConfigs {
+		configs = append(configs, ServiceConfig{
+			Service: svc,
+			Config:  json.RawMessage(cfg),
+			Layers:  p.configLayers[svc],
+		})
+	}
+	slices.SortFunc(configs, func(a, b ServiceConfig) int { return strings.Compare(a.Service, b.Service) })
+	return configs
+}
@@ -0,0 +1,108 @@
+package run
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/xos"
+)
+
+// flagOverridesPathEnvVar is the environment variable telling the app
+// where to read feature flag overrides from.
+const flagOverridesPathEnvVar = "ENCORE_FLAG_OVERRIDES_PATH"
+
+// FeatureFlagStatus is the status of a feature flag of a running app.
+type FeatureFlagStatus struct {
+	Name       string            `json:"name"`
+	Doc        string            `json:"doc"`
+	Rollout    int               `json:"rollout"`
+	UserIDs    []string          `json:"userIds"`
+	Attributes map[string]string `json:"attributes"`
+
+	// Override is whether the flag has been forcibly enabled or disabled
+	// for all users, or nil if its targeting rules apply.
+	Override *bool `json:"override"`
+}
+
+// flagOverrides are the feature flag overrides of a running app.
+// They are kept across live reloads, and written to a file the app
+// periodically reads them from.
+type flagOverrides struct {
+	mu        sync.Mutex
+	path      string // the file the overrides are written to, if any
+	overrides map[string]bool
+}
+
+// write writes the overrides to the given path, and keeps
+// writing them there whenever they change.
+func (f *flagOverrides) write(path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.path = path
+	return f.writeLocked()
+}
+
+func (f *flagOverrides) writeLocked() error {
+	if f.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(f.overrides)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal feature flag overrides")
+	}
+	if err := xos.WriteFile(f.path, data, 0644); err != nil {
+		return errors.Wrap(err, "failed to write feature flag overrides")
+	}
+	return nil
+}
+
+// FeatureFlags reports the feature flags of the running app
+// and their overrides, sorted by name.
+// It returns nil if the app hasn't started yet.
+func (r *Run) FeatureFlags() []FeatureFlagStatus {
+	p := r.ProcGroup()
+	if p == nil || p.Meta == nil {
+		return nil
+	}
+
+	r.flags.mu.Lock()
+	defer r.flags.mu.Unlock()
+	statuses := make([]FeatureFlagStatus, 0, len(p.Meta.FeatureFlags))
+	for _, f := range p.Meta.FeatureFlags {
+		st := FeatureFlagStatus{
+			Name:       f.Name,
+			Doc:        f.GetDoc(),
+			Rollout:    int(f.RolloutPercent),
+			UserIDs:    f.UserIds,
+			Attributes: f.Attributes,
+		}
+		if enabled, ok := r.flags.overrides[f.Name]; ok {
+			st.Override = &enabled
+		}
+		statuses = append(statuses, st)
+	}
+	slices.SortFunc(statuses, func(a, b FeatureFlagStatus) int { return strings.Compare(a.Name, b.Name) })
+	return statuses
+}
+
+// SetFeatureFlagOverride forcibly enables or disables the given feature flag
+// for all users of the running app. A nil enabled removes the override,
+// so that the flag's targeting rules apply again.
+// The app picks up the change within a second.
+func (r *Run) SetFeatureFlagOverride(name string, enabled *bool) error {
+	r.flags.mu.Lock()
+	defer r.flags.mu.Unlock()
+	if enabled == nil {
+		delete(r.flags.overrides, name)
+	} else {
+		if r.flags.overrides == nil {
+			r.flags.overrides = make(map[string]bool)
+		}
+		r.flags.overrides[name] = *enabled
+	}
+	return r.flags.writeLocked()
+}
@@ -98,8 +98,19 @@ func (rm *ResourceManager) StartRequiredServices(a *optracker.AsyncBuildJobs, md
 		a.Go("Creating PostgreSQL database cluster", true, 300*time.Millisecond, rm.StartSQLCluster(a, md))
 	}
 
-	if pubsub.IsUsed(md) && rm.GetPubSub() == nil {
-		a.Go("Starting PubSub daemon", true, 250*time.Millisecond, rm.StartPubSub)
+	if pubsub.IsUsed(md) {
+		if nsqd := rm.GetPubSub(); nsqd == nil {
+			a.Go("Starting PubSub daemon", true, 250*time.Millisecond, func(ctx context.Context) error {
+				if err := rm.StartPubSub(ctx); err != nil {
+					return err
+				}
+				rm.recordPubSub(rm.GetPubSub(), md)
+				return nil
+			})
+		} else {
+			// Record any topics added since the daemon was started.
+			rm.recordPubSub(nsqd, md)
+		}
 	}
 
 	if redis.IsUsed(md) && rm.GetRedis() == nil {
@@ -111,9 +122,23 @@ func (rm *ResourceManager) StartRequiredServices(a *optracker.AsyncBuildJobs, md
 	}
 }
 
+// resourcePort returns the fixed port configured for the given
+// resource type in the app's local settings, or 0 if there is none.
+func (rm *ResourceManager) resourcePort(typ string) int {
+	if rm.forTests {
+		// Tests run concurrently with "encore run", so never use fixed ports.
+		return 0
+	}
+	local, err := rm.app.LocalSettings()
+	if err != nil {
+		return 0
+	}
+	return local.ResourcePorts[typ]
+}
+
 // StartPubSub starts a PubSub daemon.
 func (rm *ResourceManager) StartPubSub(ctx context.Context) error {
-	nsqd := &pubsub.NSQDaemon{}
+	nsqd := &pubsub.NSQDaemon{Port: rm.resourcePort("pubsub")}
 	err := nsqd.Start()
 	if err != nil {
 		return err
@@ -136,9 +161,21 @@ func (rm *ResourceManager) GetPubSub() *pubsub.NSQDaemon {
 	return nil
 }
 
+// recordPubSub records the messages published to the app's topics
+// so they can be replayed with "encore pubsub replay".
+func (rm *ResourceManager) recordPubSub(nsqd *pubsub.NSQDaemon, md *meta.Data) {
+	if rm.forTests {
+		return
+	}
+	if err := nsqd.Record(md); err != nil {
+		rm.log.Warn().Err(err).Msg("unable to record pubsub messages for replay")
+	}
+}
+
 // StartRedis starts a Redis server.
 func (rm *ResourceManager) StartRedis(ctx context.Context) error {
 	srv := redis.New()
+	srv.Port = rm.resourcePort("redis")
 	err := srv.Start()
 	if err != nil {
 		return err
@@ -178,6 +215,7 @@ func (rm *ResourceManager) StartObjects(md *meta.Data) func(context.Context) err
 				return err
 			}
 			srv = objects.NewDirServer(rm.publicBuckets, rm.ns.ID, baseDir)
+			srv.Port = rm.resourcePort("objects")
 		}
 
 		if err := srv.Initialize(md); err != nil {
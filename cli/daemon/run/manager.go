@@ -32,7 +32,8 @@ type Manager struct {
 
 	listeners []EventListener
 	mu        sync.Mutex
-	runs      map[string]*Run // id -> run
+	runs      map[string]*Run       // id -> run
+	testRuns  map[string][]*TestRun // app id -> recent test runs, oldest first
 }
 
 // EventListener is the interface for listening to events
@@ -100,6 +101,8 @@ func (mgr *Manager) ListRuns() []*Run {
 
 // AddListener adds an event listener to mgr.
 // It must be called before starting the first run.
+//
+// Listeners that implement TestListener are notified about test runs as well.
 func (mgr *Manager) AddListener(ln EventListener) {
 	mgr.listeners = append(mgr.listeners, ln)
 }
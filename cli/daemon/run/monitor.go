@@ -0,0 +1,160 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/probe"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// MonitorCheckStatus is the status of a synthetic monitoring check
+// of a running app.
+type MonitorCheckStatus struct {
+	Name     string        `json:"name"`
+	Title    string        `json:"title"`
+	Method   string        `json:"method"`
+	Path     string        `json:"path"`
+	Interval time.Duration `json:"interval"`
+
+	// Last is the result of the most recent run of the check,
+	// or nil if it has not yet run.
+	Last *probe.Result `json:"last"`
+
+	// ConsecutiveFailures is the number of consecutive failed runs.
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+}
+
+// monitors runs the synthetic monitoring checks of a running app
+// against its local API gateway.
+type monitors struct {
+	mu       sync.Mutex
+	statuses map[string]*MonitorCheckStatus
+	nextRun  map[string]time.Time
+	running  map[string]bool
+}
+
+// runMonitors runs the checks declared by the app until ctx is canceled.
+// The checks are read from the current proc's metadata on every tick,
+// so that checks added or changed while live reloading are picked up.
+func (r *Run) runMonitors(ctx context.Context) {
+	client := &http.Client{
+		// Report redirects as-is rather than following them.
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			p := r.ProcGroup()
+			if p == nil || p.Meta == nil {
+				continue
+			}
+			for _, c := range r.monitors.due(p.Meta.MonitorChecks, now) {
+				go r.runMonitorCheck(ctx, client, c)
+			}
+		}
+	}
+}
+
+func (r *Run) runMonitorCheck(ctx context.Context, client *http.Client, c *meta.MonitorCheck) {
+	spec := probe.Spec{
+		Method:       c.Method,
+		URL:          "http://" + r.ListenAddr + c.Path,
+		Timeout:      time.Duration(c.TimeoutMillis) * time.Millisecond,
+		ExpectStatus: int(c.ExpectStatus),
+	}
+	if c.ExpectBodyContains != nil {
+		spec.ExpectBodyContains = *c.ExpectBodyContains
+	}
+	if c.MaxLatencyMillis != nil {
+		spec.MaxLatency = time.Duration(*c.MaxLatencyMillis) * time.Millisecond
+	}
+
+	res := probe.Run(ctx, client, spec)
+	if ctx.Err() != nil {
+		return
+	}
+	if !res.OK {
+		r.log.Warn().Str("check", c.Name).Str("error", res.Err).Msg("monitor check failed")
+	}
+	r.monitors.record(c.Name, res)
+}
+
+// due reports the checks that are due to run at the given time,
+// and marks them as running.
+func (m *monitors) due(checks []*meta.MonitorCheck, now time.Time) []*meta.MonitorCheck {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.statuses == nil {
+		m.statuses = make(map[string]*MonitorCheckStatus)
+		m.nextRun = make(map[string]time.Time)
+		m.running = make(map[string]bool)
+	}
+
+	var due []*meta.MonitorCheck
+	seen := make(map[string]bool, len(checks))
+	for _, c := range checks {
+		seen[c.Name] = true
+		interval := time.Duration(c.IntervalSeconds) * time.Second
+
+		st, ok := m.statuses[c.Name]
+		if !ok {
+			st = &MonitorCheckStatus{Name: c.Name}
+			m.statuses[c.Name] = st
+		}
+		st.Title, st.Method, st.Path, st.Interval = c.Title, c.Method, c.Path, interval
+
+		if m.running[c.Name] || now.Before(m.nextRun[c.Name]) {
+			continue
+		}
+		m.running[c.Name] = true
+		m.nextRun[c.Name] = now.Add(interval)
+		due = append(due, c)
+	}
+
+	// Forget about checks that have been removed.
+	for name := range m.statuses {
+		if !seen[name] {
+			delete(m.statuses, name)
+			delete(m.nextRun, name)
+		}
+	}
+	return due
+}
+
+func (m *monitors) record(name string, res probe.Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.running, name)
+	st, ok := m.statuses[name]
+	if !ok {
+		return
+	}
+	st.Last = &res
+	if res.OK {
+		st.ConsecutiveFailures = 0
+	} else {
+		st.ConsecutiveFailures++
+	}
+}
+
+// MonitorChecks reports the status of the app's synthetic monitoring checks,
+// sorted by name.
+func (r *Run) MonitorChecks() []MonitorCheckStatus {
+	r.monitors.mu.Lock()
+	defer r.monitors.mu.Unlock()
+	statuses := make([]MonitorCheckStatus, 0, len(r.monitors.statuses))
+	for _, st := range r.monitors.statuses {
+		statuses = append(statuses, *st)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -26,6 +26,7 @@ import (
 	"encr.dev/cli/daemon/internal/sym"
 	"encr.dev/internal/lookpath"
 	"encr.dev/pkg/builder"
+	"encr.dev/pkg/cueutil"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/noopgateway"
 	"encr.dev/pkg/noopgwdesc"
@@ -42,6 +43,8 @@ type procGroupOptions struct {
 	Logger      RunLogger
 	WorkingDir  string
 	ConfigGen   *RuntimeConfigGenerator
+	// ConfigLayers are the config layers of each service's config.
+	ConfigLayers map[string][]cueutil.Layer
 }
 
 func newProcGroup(opts procGroupOptions) *ProcGroup {
@@ -56,10 +59,11 @@ func newProcGroup(opts procGroupOptions) *ProcGroup {
 		log:         opts.Run.log.With().Str("proc_id", opts.ProcID).Logger(),
 		ConfigGen:   opts.ConfigGen,
 
-		symParsed: make(chan struct{}),
-		Services:  make(map[string]*Proc),
-		Gateways:  make(map[string]*Proc),
-		authKey:   opts.AuthKey,
+		configLayers: opts.ConfigLayers,
+		symParsed:    make(chan struct{}),
+		Services:     make(map[string]*Proc),
+		Gateways:     make(map[string]*Proc),
+		authKey:      opts.AuthKey,
 	}
 
 	p.procCond.L = &p.procMu
@@ -81,6 +85,8 @@ type ProcGroup struct {
 
 	ConfigGen *RuntimeConfigGenerator // generates runtime configuration
 
+	configLayers map[string][]cueutil.Layer // the config layers of each service
+
 	procMu       sync.Mutex // protects both allProcesses and runningProcs
 	procCond     sync.Cond  // used to signal a change in runningProcs
 	allProcesses []*Proc    // all processes in the group
@@ -98,6 +104,15 @@ type ProcGroup struct {
 	sym       *sym.Table
 	symErr    error
 	symParsed chan struct{} // closed when sym and symErr are set
+
+	// replaced is set when the group is closed ahead of a reload,
+	// to signal that its exit does not mean the run has stopped.
+	replaced atomic.Bool
+
+	// reloadableSecrets is whether the app reads its secrets from
+	// ConfigGen.SecretsPath, and therefore picks up rotated secrets
+	// without being restarted.
+	reloadableSecrets bool
 }
 
 func (pg *ProcGroup) ProxyReq(w http.ResponseWriter, req *http.Request) {
@@ -468,6 +483,11 @@ func (p *Proc) Close() {
 	}
 }
 
+// ListenAddr reports the address the process listens on.
+func (p *Proc) ListenAddr() netip.AddrPort {
+	return p.listenAddr
+}
+
 // ProxyReq proxies the request to the Encore app.
 func (p *Proc) ProxyReq(w http.ResponseWriter, req *http.Request) {
 	p.httpProxy.ServeHTTP(w, req)
@@ -65,6 +65,9 @@ type Run struct {
 	Params  *StartParams
 	secrets *secret.LoadResult
 
+	monitors monitors      // synthetic monitoring checks
+	flags    flagOverrides // feature flag overrides set from the dashboard
+
 	ctx     context.Context // ctx is closed when the run is to exit
 	proc    atomic.Value    // current process
 	exited  chan struct{}   // exit is closed when the run has fully exited
@@ -272,6 +275,22 @@ func (r *Run) Reload() error {
 	return nil
 }
 
+// ReloadSecrets fetches the app's current secrets, including any local
+// overrides, and hands them to the running app without restarting it.
+// It reports false if the running app doesn't support reloading secrets,
+// in which case it must be reloaded to use the new values.
+func (r *Run) ReloadSecrets(ctx context.Context) (ok bool, err error) {
+	p := r.ProcGroup()
+	if p == nil || !p.reloadableSecrets {
+		return false, nil
+	}
+	data, err := r.secrets.Get(ctx, p.Experiments)
+	if err != nil {
+		return false, err
+	}
+	return true, p.ConfigGen.WriteSecrets(data.Values)
+}
+
 // start starts the application and serves requests over HTTP using ln.
 func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 	defer func() {
@@ -315,6 +334,21 @@ func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 		_ = srv.Close()
 	}()
 
+	go r.runMonitors(r.ctx)
+
+	// Hand secrets rotated on the platform to the running app.
+	if r.Mgr.Secret != nil && r.App.PlatformID() != "" {
+		unsubscribe := r.Mgr.Secret.Subscribe(r.App.PlatformID(), func() {
+			if _, err := r.ReloadSecrets(r.ctx); err != nil {
+				r.log.Error().Err(err).Msg("could not reload secrets")
+			}
+		})
+		go func() {
+			<-r.exited
+			unsubscribe()
+		}()
+	}
+
 	// Monitor the running proc and Close the app when it exits.
 	go func() {
 		for {
@@ -323,6 +357,14 @@ func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 			// p exited, but it could have been a reload.
 			// Check to make sure p is still the active proc.
 			p2 := r.proc.Load().(*ProcGroup)
+			if p2 == p && p.replaced.Load() {
+				// p was closed ahead of a reload; wait for the new proc.
+				select {
+				case <-r.ctx.Done():
+				case <-time.After(100 * time.Millisecond):
+					continue
+				}
+			}
 			if p2 == p {
 				// We're done.
 				for _, ln := range r.Mgr.listeners {
@@ -462,6 +504,17 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		return err
 	}
 
+	// If the app uses fixed local ports the previous process must be
+	// closed before starting the new one, or the ports would conflict.
+	if isReload {
+		if local, err := r.App.LocalSettings(); err == nil && local.HasFixedProcessPorts() {
+			if prev, ok := r.proc.Load().(*ProcGroup); ok && prev != nil {
+				prev.replaced.Store(true)
+				prev.Close()
+			}
+		}
+	}
+
 	startOp := tracker.Add("Starting Encore application", start)
 	newProcess, err := r.StartProcGroup(&StartProcGroupParams{
 		Ctx:            ctx,
@@ -470,6 +523,7 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		Logger:         r.Mgr,
 		Secrets:        secrets,
 		ServiceConfigs: svcCfg.Configs,
+		ConfigLayers:   svcCfg.ConfigLayers,
 		Environ:        r.Params.Environ,
 		WorkingDir:     r.Params.WorkingDir,
 		IsReload:       isReload,
@@ -518,6 +572,7 @@ type StartProcGroupParams struct {
 	Meta           *meta.Data
 	Secrets        map[string]string
 	ServiceConfigs map[string]string
+	ConfigLayers   map[string][]cueutil.Layer
 	Logger         RunLogger
 	Environ        []string
 	WorkingDir     string
@@ -552,6 +607,7 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 
 	var runtimeConfigPath option.Option[string]
 	var metaPath option.Option[string]
+	var secretsPath option.Option[string]
 
 	if r.TempDir != "" {
 		if r.Builder.UseNewRuntimeConfig() {
@@ -563,6 +619,13 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 		if r.Builder.NeedsMeta() {
 			metaPath = option.Some(filepath.Join(r.TempDir, "meta.pb"))
 		}
+		secretsPath = option.Some(filepath.Join(r.TempDir, "secrets"))
+
+		flagsPath := filepath.Join(r.TempDir, "flag_overrides.json")
+		if err := r.flags.write(flagsPath); err != nil {
+			return nil, err
+		}
+		userEnv = append(userEnv, flagOverridesPathEnvVar+"="+flagsPath)
 	}
 
 	authKey := genAuthKey()
@@ -585,13 +648,15 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 			IncludeMeta:       r.Builder.NeedsMeta(),
 			MetaPath:          metaPath,
 			RuntimeConfigPath: runtimeConfigPath,
+			SecretsPath:       secretsPath,
 			LogLevel:          r.Params.LogLevel,
 		},
-		Experiments: params.Experiments,
-		Meta:        params.Meta,
-		Ctx:         params.Ctx,
-		WorkingDir:  params.WorkingDir,
-		Logger:      params.Logger,
+		Experiments:  params.Experiments,
+		Meta:         params.Meta,
+		ConfigLayers: params.ConfigLayers,
+		Ctx:          params.Ctx,
+		WorkingDir:   params.WorkingDir,
+		Logger:       params.Logger,
 	})
 
 	if isSingleProc(params.Outputs) {
@@ -616,6 +681,7 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 		if err := p.NewAllInOneProc(cmd, conf.ListenAddr, env); err != nil {
 			return nil, err
 		}
+		p.reloadableSecrets = secretsPath.Present() && !entrypoint.UseRuntimeConfigV2
 	} else {
 		var (
 			svcConfs map[string]*ProcConfig
@@ -29,6 +29,7 @@ import (
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/rtconfgen"
 	"encr.dev/pkg/svcproxy"
+	"encr.dev/pkg/xos"
 	meta "encr.dev/proto/encore/parser/meta/v1"
 	runtimev1 "encr.dev/proto/encore/runtime/v1"
 )
@@ -37,6 +38,7 @@ const (
 	runtimeCfgEnvVar     = "ENCORE_RUNTIME_CONFIG"
 	runtimeCfgPathEnvVar = "ENCORE_RUNTIME_CONFIG_PATH"
 	appSecretsEnvVar     = "ENCORE_APP_SECRETS"
+	appSecretsPathEnvVar = "ENCORE_APP_SECRETS_PATH"
 	serviceCfgEnvPrefix  = "ENCORE_CFG_"
 	listenEnvVar         = "ENCORE_LISTEN_ADDR"
 	metaEnvVar           = "ENCORE_APP_META"
@@ -54,6 +56,7 @@ type RuntimeConfigGenerator struct {
 		GlobalCORS() (appfile.CORS, error)
 		AppFile() (*appfile.File, error)
 		BuildSettings() (appfile.Build, error)
+		LocalSettings() (appfile.Local, error)
 	}
 
 	// The infra manager to use
@@ -86,6 +89,10 @@ type RuntimeConfigGenerator struct {
 	// If set, write the runtime config to the given path
 	// instead of including it as an environment variable.
 	RuntimeConfigPath option.Option[string]
+	// If set, write the secrets to the given path instead of including
+	// them as an environment variable, so they can be rotated with
+	// WriteSecrets while the app is running.
+	SecretsPath option.Option[string]
 
 	// Minimum log level, if any.
 	LogLevel option.Option[string]
@@ -141,12 +148,19 @@ func (g *RuntimeConfigGenerator) initialize() error {
 			if val, err := strconv.ParseFloat(os.Getenv("ENCORE_TRACE_SAMPLING_RATE"), 64); err == nil {
 				sampleRate = min(max(val, 0), 1)
 			}
+			timeAnchor := runtimev1.TracingProvider_EncoreTracingProvider_TIME_ANCHOR_SEND
+			if os.Getenv("ENCORE_TRACE_TIME_ANCHOR") == "boot" {
+				timeAnchor = runtimev1.TracingProvider_EncoreTracingProvider_TIME_ANCHOR_BOOT
+			}
+			memStats, _ := strconv.ParseBool(os.Getenv("ENCORE_TRACE_MEMSTATS"))
 			g.conf.TracingProvider(&runtimev1.TracingProvider{
 				Rid: newRid(),
 				Provider: &runtimev1.TracingProvider_Encore{
 					Encore: &runtimev1.TracingProvider_EncoreTracingProvider{
 						TraceEndpoint: traceEndpoint,
 						SamplingRate:  &sampleRate,
+						TimeAnchor:    timeAnchor,
+						MemStats:      memStats,
 					},
 				},
 			})
@@ -463,11 +477,17 @@ func (g *RuntimeConfigGenerator) initialize() error {
 					u := publicBaseURL + "/" + bkt.Name
 					publicURL = &u
 				}
+				// Locally, encrypted buckets use a development key.
+				var encryption *runtimev1.BucketEncryption
+				if bkt.ClientSideEncryption {
+					encryption = &runtimev1.BucketEncryption{}
+				}
 				cluster.Bucket(&runtimev1.Bucket{
 					Rid:           bktRid,
 					EncoreName:    bkt.Name,
 					CloudName:     bkt.Name,
 					PublicBaseUrl: publicURL,
+					Encryption:    encryption,
 				})
 			}
 		}
@@ -497,6 +517,11 @@ func (g *RuntimeConfigGenerator) ProcPerService(proxy *svcproxy.SvcProxy) (servi
 		return nil, nil, err
 	}
 
+	local, err := g.app.LocalSettings()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read local settings")
+	}
+
 	services = make(map[string]*ProcConfig)
 	gateways = make(map[string]*ProcConfig)
 
@@ -506,7 +531,7 @@ func (g *RuntimeConfigGenerator) ProcPerService(proxy *svcproxy.SvcProxy) (servi
 
 	svcListenAddr := make(map[string]netip.AddrPort)
 	for _, svc := range g.md.Svcs {
-		listenAddr, err := freeLocalhostAddress()
+		listenAddr, err := listenAddress(local.ServicePorts[svc.Name])
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "failed to find free localhost address")
 		}
@@ -555,7 +580,7 @@ func (g *RuntimeConfigGenerator) ProcPerService(proxy *svcproxy.SvcProxy) (servi
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "failed to generate runtime config")
 		}
-		listenAddr, err := freeLocalhostAddress()
+		listenAddr, err := listenAddress(local.GatewayPorts[gw.EncoreName])
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "failed to find free localhost address")
 		}
@@ -601,6 +626,12 @@ func (g *RuntimeConfigGenerator) AllInOneProc(useRuntimeConfigV2 bool) (*ProcCon
 	extraEnv := configEnvs
 	if !useRuntimeConfigV2 {
 		secretsEnv := fmt.Sprintf("%s=%s", appSecretsEnvVar, encodeSecretsEnv(g.DefinedSecrets))
+		if secretsPath, ok := g.SecretsPath.Get(); ok {
+			if err := g.WriteSecrets(g.DefinedSecrets); err != nil {
+				return nil, err
+			}
+			secretsEnv = fmt.Sprintf("%s=%s", appSecretsPathEnvVar, secretsPath)
+		}
 		extraEnv = append([]string{secretsEnv}, configEnvs...)
 	}
 
@@ -620,6 +651,11 @@ func (g *RuntimeConfigGenerator) ProcPerServiceWithNewRuntimeConfig(proxy *svcpr
 		return nil, nil, nil, errors.New("service configs not yet supported")
 	}
 
+	local, err := g.app.LocalSettings()
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to read local settings")
+	}
+
 	services = make(map[string]*ProcConfig)
 	gateways = make(map[string]*ProcConfig)
 
@@ -631,7 +667,7 @@ func (g *RuntimeConfigGenerator) ProcPerServiceWithNewRuntimeConfig(proxy *svcpr
 	var svcNames []string
 	for _, svc := range g.md.Svcs {
 		svcNames = append(svcNames, svc.Name)
-		listenAddr, err := freeLocalhostAddress()
+		listenAddr, err := listenAddress(local.ServicePorts[svc.Name])
 		if err != nil {
 			return nil, nil, nil, errors.Wrap(err, "failed to find free localhost address")
 		}
@@ -669,7 +705,7 @@ func (g *RuntimeConfigGenerator) ProcPerServiceWithNewRuntimeConfig(proxy *svcpr
 
 	// Set up the gateways.
 	for _, gw := range g.md.Gateways {
-		listenAddr, err := freeLocalhostAddress()
+		listenAddr, err := listenAddress(local.GatewayPorts[gw.EncoreName])
 		if err != nil {
 			return nil, nil, nil, errors.Wrap(err, "failed to find free localhost address")
 		}
@@ -867,6 +903,20 @@ func (g *RuntimeConfigGenerator) writeMetadata() ([]string, error) {
 	return []string{fmt.Sprintf("%s=%s", metaEnvVar, metaEnvStr)}, nil
 }
 
+// WriteSecrets writes the given secret values to SecretsPath, if set.
+// The file is replaced atomically, and running apps pick up the new
+// values the next time they check for rotated secrets.
+func (g *RuntimeConfigGenerator) WriteSecrets(secrets map[string]string) error {
+	secretsPath, ok := g.SecretsPath.Get()
+	if !ok {
+		return nil
+	}
+	if err := xos.WriteFile(secretsPath, []byte(encodeSecretsEnv(secrets)), 0600); err != nil {
+		return errors.Wrap(err, "failed to write secrets")
+	}
+	return nil
+}
+
 func (g *RuntimeConfigGenerator) MissingSecrets() []string {
 	var missing []string
 	for _, pkg := range g.md.Pkgs {
@@ -929,6 +979,15 @@ func secretsUsedByServices(md *meta.Data, svcNames ...string) (secretNames map[s
 }
 
 // freeLocalhostAddress returns the first free port number on the system.
+// listenAddress returns the localhost address for a process to listen on.
+// If port is non-zero that port is used, and otherwise a free port is allocated.
+func listenAddress(port int) (netip.AddrPort, error) {
+	if port != 0 {
+		return netip.AddrPortFrom(netip.AddrFrom4([4]byte{127, 0, 0, 1}), uint16(port)), nil
+	}
+	return freeLocalhostAddress()
+}
+
 func freeLocalhostAddress() (netip.AddrPort, error) {
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
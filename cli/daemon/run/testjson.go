@@ -0,0 +1,172 @@
+package run
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// testEvent is an event reported by "go test -json".
+// See "go doc cmd/test2json" for a description of the fields.
+type testEvent struct {
+	Time    time.Time
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64 // seconds
+	Output  string
+}
+
+// testJSONWriter decodes the output of "go test -json", recording the events
+// in a test run and writing the output the way "go test" writes it.
+type testJSONWriter struct {
+	run *TestRun
+	out io.Writer
+
+	// raw is whether the JSON events are written as-is,
+	// because "go test" was invoked with -json by the user.
+	raw bool
+	// verbose is whether the output of all tests is written,
+	// as by "go test -v", and not only the output of failed tests.
+	verbose bool
+
+	buf     []byte               // incomplete line
+	pending map[testKey][]string // output of running tests, written if they fail
+}
+
+// newTestJSONWriter returns a testJSONWriter recording events in tr and writing output to out,
+// and the args to invoke "go test" with, given the args it would otherwise be invoked with.
+func newTestJSONWriter(tr *TestRun, out io.Writer, args []string) (*testJSONWriter, []string) {
+	w := &testJSONWriter{
+		run:     tr,
+		out:     out,
+		raw:     hasTestFlag(args, "json"),
+		verbose: hasTestFlag(args, "v"),
+		pending: make(map[testKey][]string),
+	}
+	if !w.raw {
+		args = append([]string{"-json"}, args...)
+	}
+	return w, args
+}
+
+func (w *testJSONWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	start := 0
+	for {
+		i := bytes.IndexByte(w.buf[start:], '\n')
+		if i < 0 {
+			break
+		}
+		w.writeLine(w.buf[start : start+i+1])
+		start += i + 1
+	}
+	w.buf = append(w.buf[:0], w.buf[start:]...)
+	return len(p), nil
+}
+
+// Flush writes any incomplete line left when "go test" exits.
+func (w *testJSONWriter) Flush() {
+	if len(w.buf) > 0 {
+		_, _ = w.out.Write(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *testJSONWriter) writeLine(line []byte) {
+	var ev testEvent
+	if !bytes.HasPrefix(line, []byte("{")) || json.Unmarshal(line, &ev) != nil {
+		// Not an event, such as output of the go command itself.
+		_, _ = w.out.Write(line)
+		return
+	}
+
+	w.run.handleEvent(&ev)
+	if w.raw {
+		_, _ = w.out.Write(line)
+		return
+	}
+	w.render(&ev)
+}
+
+// render writes the output of an event the way "go test" writes it without -json.
+func (w *testJSONWriter) render(ev *testEvent) {
+	key := testKey{ev.Package, ev.Test}
+	switch {
+	case ev.Action == "build-output":
+		w.write(ev.Output)
+
+	case ev.Action == "output" && (w.verbose || ev.Test == ""):
+		// Without -v, "go test" only reports the outcome of packages that pass.
+		if w.verbose || ev.Output != "PASS\n" {
+			w.write(ev.Output)
+		}
+
+	case w.verbose || ev.Test == "":
+		// Nothing to buffer.
+
+	case ev.Action == "run":
+		w.pending[key] = nil
+
+	case ev.Action == "output":
+		if !isTestFramingLine(ev.Output) {
+			w.pending[key] = append(w.pending[key], ev.Output)
+		}
+
+	case testEventStatus[ev.Action] != "":
+		out := w.pending[key]
+		delete(w.pending, key)
+		if ev.Action != "fail" {
+			return
+		}
+		// Write the output of failed subtests together with their parent's.
+		if i := strings.LastIndexByte(ev.Test, '/'); i >= 0 {
+			parent := testKey{ev.Package, ev.Test[:i]}
+			if parentOut, ok := w.pending[parent]; ok {
+				w.pending[parent] = append(parentOut, out...)
+				return
+			}
+		}
+		w.write(out...)
+	}
+}
+
+func (w *testJSONWriter) write(out ...string) {
+	for _, s := range out {
+		_, _ = io.WriteString(w.out, s)
+	}
+}
+
+// isTestFramingLine reports whether line is one of the lines "go test -v" writes
+// when tests start, pause and continue, which aren't written without -v.
+func isTestFramingLine(line string) bool {
+	for _, prefix := range []string{"=== RUN", "=== PAUSE", "=== CONT", "=== NAME"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTestFlag reports whether the boolean flag with the given name
+// is set in the args for "go test".
+func hasTestFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == "-args" || arg == "--args" {
+			// The remaining args are for the test binary.
+			return false
+		}
+		flag, ok := strings.CutPrefix(arg, "-")
+		if !ok {
+			continue
+		}
+		flag = strings.TrimPrefix(flag, "-")
+		flag = strings.TrimPrefix(flag, "test.")
+		if flag == name || flag == name+"=true" {
+			return true
+		}
+	}
+	return false
+}
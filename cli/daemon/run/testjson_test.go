@@ -0,0 +1,159 @@
+package run
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// goTestJSON is the output of "go test -json" for a package with a passing
+// test, and a failing test with a passing and a failing subtest.
+var goTestJSON = []testEvent{
+	{Action: "start", Package: "app/svc"},
+	{Action: "run", Package: "app/svc", Test: "TestPass"},
+	{Action: "output", Package: "app/svc", Test: "TestPass", Output: "=== RUN   TestPass\n"},
+	{Action: "output", Package: "app/svc", Test: "TestPass", Output: "    svc_test.go:10: logged\n"},
+	{Action: "output", Package: "app/svc", Test: "TestPass", Output: "--- PASS: TestPass (0.00s)\n"},
+	{Action: "pass", Package: "app/svc", Test: "TestPass", Elapsed: 0.5},
+	{Action: "run", Package: "app/svc", Test: "TestFail"},
+	{Action: "output", Package: "app/svc", Test: "TestFail", Output: "=== RUN   TestFail\n"},
+	{Action: "run", Package: "app/svc", Test: "TestFail/ok"},
+	{Action: "output", Package: "app/svc", Test: "TestFail/ok", Output: "=== RUN   TestFail/ok\n"},
+	{Action: "output", Package: "app/svc", Test: "TestFail/ok", Output: "    --- PASS: TestFail/ok (0.00s)\n"},
+	{Action: "pass", Package: "app/svc", Test: "TestFail/ok"},
+	{Action: "run", Package: "app/svc", Test: "TestFail/bad"},
+	{Action: "output", Package: "app/svc", Test: "TestFail/bad", Output: "=== RUN   TestFail/bad\n"},
+	{Action: "output", Package: "app/svc", Test: "TestFail/bad", Output: "    svc_test.go:20: got 1, want 2\n"},
+	{Action: "output", Package: "app/svc", Test: "TestFail/bad", Output: "    --- FAIL: TestFail/bad (0.00s)\n"},
+	{Action: "fail", Package: "app/svc", Test: "TestFail/bad"},
+	{Action: "output", Package: "app/svc", Test: "TestFail", Output: "--- FAIL: TestFail (0.00s)\n"},
+	{Action: "fail", Package: "app/svc", Test: "TestFail"},
+	{Action: "output", Package: "app/svc", Output: "FAIL\n"},
+	{Action: "output", Package: "app/svc", Output: "FAIL\tapp/svc\t0.01s\n"},
+	{Action: "fail", Package: "app/svc", Elapsed: 0.01},
+}
+
+func writeGoTestJSON(t *testing.T, w *testJSONWriter) {
+	t.Helper()
+	var b strings.Builder
+	for _, ev := range goTestJSON {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	b.WriteString("go: some message\n")
+
+	// Write the output in small chunks to exercise line buffering.
+	out := b.String()
+	for len(out) > 0 {
+		n := min(len(out), 7)
+		if _, err := w.Write([]byte(out[:n])); err != nil {
+			t.Fatal(err)
+		}
+		out = out[n:]
+	}
+	w.Flush()
+}
+
+func TestTestJSONWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantArgs []string
+		wantOut  string
+	}{
+		{
+			name:     "quiet",
+			args:     []string{"./..."},
+			wantArgs: []string{"-json", "./..."},
+			wantOut: "    svc_test.go:20: got 1, want 2\n" +
+				"    --- FAIL: TestFail/bad (0.00s)\n" +
+				"--- FAIL: TestFail (0.00s)\n" +
+				"FAIL\n" +
+				"FAIL\tapp/svc\t0.01s\n" +
+				"go: some message\n",
+		},
+		{
+			name:     "verbose",
+			args:     []string{"-v", "./svc"},
+			wantArgs: []string{"-json", "-v", "./svc"},
+		},
+		{
+			name:     "json",
+			args:     []string{"-json", "./...", "-args", "-v"},
+			wantArgs: []string{"-json", "./...", "-args", "-v"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			tr := (&Manager{}).beginTestRun("app", tt.args)
+			w, args := newTestJSONWriter(tr, &out, tt.args)
+			if strings.Join(args, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("got args %q, want %q", args, tt.wantArgs)
+			}
+			writeGoTestJSON(t, w)
+
+			switch tt.name {
+			case "verbose":
+				for _, ev := range goTestJSON {
+					if !strings.Contains(out.String(), ev.Output) {
+						t.Errorf("output is missing %q", ev.Output)
+					}
+				}
+			case "json":
+				if got := strings.Count(out.String(), "\n"); got != len(goTestJSON)+1 {
+					t.Errorf("got %d lines of output, want %d", got, len(goTestJSON)+1)
+				}
+			default:
+				if got := out.String(); got != tt.wantOut {
+					t.Errorf("got output:\n%s\nwant:\n%s", got, tt.wantOut)
+				}
+			}
+
+			results := tr.Results()
+			want := map[string]TestStatus{
+				"TestPass":     TestPassed,
+				"TestFail":     TestFailed,
+				"TestFail/ok":  TestPassed,
+				"TestFail/bad": TestFailed,
+			}
+			if len(results) != len(want) {
+				t.Fatalf("got %d results, want %d", len(results), len(want))
+			}
+			for _, res := range results {
+				if res.Status != want[res.Name] {
+					t.Errorf("got status %s for %s, want %s", res.Status, res.Name, want[res.Name])
+				}
+			}
+			if results[0].DurationNanos != 5e8 || !strings.Contains(results[0].Output, "logged") {
+				t.Errorf("got result %+v for TestPass, want it to have taken 0.5s and logged", results[0])
+			}
+		})
+	}
+}
+
+func TestTestRun_LinkTrace(t *testing.T) {
+	tr := (&Manager{}).beginTestRun("app", nil)
+	tr.handleEvent(&testEvent{Action: "run", Package: "app/a", Test: "TestFoo"})
+	tr.handleEvent(&testEvent{Action: "run", Package: "app/b", Test: "TestFoo"})
+
+	if !tr.LinkTrace("TestFoo", "trace1", "span1") || !tr.LinkTrace("TestFoo", "trace2", "span2") {
+		t.Fatal("could not link traces")
+	}
+	if tr.LinkTrace("TestFoo", "trace3", "span3") {
+		t.Error("linked a trace to a test that already has one")
+	}
+	if res := tr.Results(); res[0].TraceID != "trace1" || res[1].TraceID != "trace2" {
+		t.Errorf("got results %+v, want them linked to trace1 and trace2", res)
+	}
+
+	tr.end(errors.New("interrupted"))
+	if s := tr.Summary(); s.Status != TestFailed || s.Failed != 2 || s.Error != "interrupted" {
+		t.Errorf("got summary %+v, want a failed run with 2 interrupted tests", s)
+	}
+}
@@ -0,0 +1,298 @@
+package run
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// maxTestRunHistory is the number of test runs kept per app.
+const maxTestRunHistory = 20
+
+// maxTestOutput is the maximum number of bytes of output kept per test.
+const maxTestOutput = 64 << 10
+
+// TestListener is implemented by event listeners that also
+// want to be notified about test runs. See AddListener.
+type TestListener interface {
+	// OnTestRunStart is called when a test run starts.
+	OnTestRunStart(tr *TestRun)
+	// OnTestResult is called when the status of a test changes,
+	// or when it's linked to its trace.
+	OnTestResult(tr *TestRun, res TestResult)
+	// OnTestRunEnd is called when a test run ends.
+	OnTestRunEnd(tr *TestRun)
+}
+
+// TestStatus is the status of a test run or a single test.
+type TestStatus string
+
+const (
+	TestRunning TestStatus = "running"
+	TestPassed  TestStatus = "passed"
+	TestFailed  TestStatus = "failed"
+	TestSkipped TestStatus = "skipped"
+)
+
+// testEventStatus maps the actions of "go test -json" events
+// reporting the outcome of a test to the test's status.
+var testEventStatus = map[string]TestStatus{
+	"pass": TestPassed,
+	"fail": TestFailed,
+	"skip": TestSkipped,
+}
+
+// TestRun is a run of an app's tests, started by "encore test".
+type TestRun struct {
+	ID      string
+	AppID   string
+	Args    []string
+	Started time.Time
+
+	mgr *Manager
+
+	mu      sync.Mutex
+	ended   time.Time
+	status  TestStatus
+	tests   []*TestResult // in the order the tests started
+	byTest  map[testKey]*TestResult
+	errText string
+}
+
+type testKey struct {
+	pkg, name string
+}
+
+// TestResult is the result of a single test, including subtests, in a test run.
+type TestResult struct {
+	Package string     `json:"package"`
+	Name    string     `json:"name"`
+	Status  TestStatus `json:"status"`
+	Started time.Time  `json:"started"`
+
+	// DurationNanos is how long the test took, once it has completed.
+	DurationNanos int64 `json:"durationNanos"`
+
+	// Output is the output of the test, such as what it logged and why it failed.
+	// Only the first maxTestOutput bytes are kept.
+	Output string `json:"output"`
+
+	// TraceID and SpanID identify the span of the test in its trace,
+	// which includes the API calls and other operations made by the test.
+	// They're empty until the trace has been received.
+	TraceID string `json:"traceID,omitempty"`
+	SpanID  string `json:"spanID,omitempty"`
+}
+
+// TestRunSummary summarizes a test run, for display purposes.
+type TestRunSummary struct {
+	ID            string     `json:"id"`
+	AppID         string     `json:"appID"`
+	Args          []string   `json:"args"`
+	Status        TestStatus `json:"status"`
+	Started       time.Time  `json:"started"`
+	DurationNanos int64      `json:"durationNanos"` // set once the run has ended
+	Error         string     `json:"error,omitempty"`
+
+	// Counts of the tests by status.
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Running int `json:"running"`
+}
+
+// beginTestRun records the start of a test run of the given app,
+// and notifies the listeners about it.
+func (mgr *Manager) beginTestRun(appID string, args []string) *TestRun {
+	tr := &TestRun{
+		ID:      xid.New().String(),
+		AppID:   appID,
+		Args:    args,
+		Started: time.Now(),
+		mgr:     mgr,
+		status:  TestRunning,
+		byTest:  make(map[testKey]*TestResult),
+	}
+
+	mgr.mu.Lock()
+	if mgr.testRuns == nil {
+		mgr.testRuns = make(map[string][]*TestRun)
+	}
+	runs := append(mgr.testRuns[appID], tr)
+	if len(runs) > maxTestRunHistory {
+		runs = slices.Delete(runs, 0, len(runs)-maxTestRunHistory)
+	}
+	mgr.testRuns[appID] = runs
+	mgr.mu.Unlock()
+
+	mgr.forEachTestListener(func(ln TestListener) { ln.OnTestRunStart(tr) })
+	return tr
+}
+
+// ListTestRuns returns the recent test runs of the given app, most recent first.
+func (mgr *Manager) ListTestRuns(appID string) []*TestRun {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	runs := slices.Clone(mgr.testRuns[appID])
+	slices.Reverse(runs)
+	return runs
+}
+
+// FindTestRun finds the test run of the given app with the given id.
+// It reports nil if no such test run was found.
+func (mgr *Manager) FindTestRun(appID, runID string) *TestRun {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	for _, tr := range mgr.testRuns[appID] {
+		if tr.ID == runID {
+			return tr
+		}
+	}
+	return nil
+}
+
+func (mgr *Manager) forEachTestListener(fn func(ln TestListener)) {
+	for _, ln := range mgr.listeners {
+		if tl, ok := ln.(TestListener); ok {
+			fn(tl)
+		}
+	}
+}
+
+// Summary summarizes the test run.
+func (tr *TestRun) Summary() TestRunSummary {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	s := TestRunSummary{
+		ID:      tr.ID,
+		AppID:   tr.AppID,
+		Args:    tr.Args,
+		Status:  tr.status,
+		Started: tr.Started,
+		Error:   tr.errText,
+	}
+	if !tr.ended.IsZero() {
+		s.DurationNanos = tr.ended.Sub(tr.Started).Nanoseconds()
+	}
+	for _, res := range tr.tests {
+		switch res.Status {
+		case TestPassed:
+			s.Passed++
+		case TestFailed:
+			s.Failed++
+		case TestSkipped:
+			s.Skipped++
+		case TestRunning:
+			s.Running++
+		}
+	}
+	return s
+}
+
+// Results returns the results of the tests in the run so far,
+// in the order the tests started.
+func (tr *TestRun) Results() []TestResult {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	results := make([]TestResult, len(tr.tests))
+	for i, res := range tr.tests {
+		results[i] = *res
+	}
+	return results
+}
+
+// LinkTrace links the trace of a test to its result, given the name of the test
+// and the ids of its span. It reports whether a test without a trace was found.
+func (tr *TestRun) LinkTrace(testName, traceID, spanID string) bool {
+	tr.mu.Lock()
+	var linked *TestResult
+	for _, res := range tr.tests {
+		if res.Name == testName && res.TraceID == "" {
+			res.TraceID, res.SpanID = traceID, spanID
+			linked = res
+			break
+		}
+	}
+	tr.mu.Unlock()
+
+	if linked == nil {
+		return false
+	}
+	tr.notifyResult(linked)
+	return true
+}
+
+// end records the end of the test run with the error returned
+// by the test command, and notifies the listeners about it.
+func (tr *TestRun) end(err error) {
+	tr.mu.Lock()
+	tr.ended = time.Now()
+	if err != nil {
+		tr.status = TestFailed
+		tr.errText = err.Error()
+	} else {
+		tr.status = TestPassed
+	}
+	// Tests still running when the run ends were interrupted.
+	for _, res := range tr.tests {
+		if res.Status == TestRunning {
+			res.Status = TestFailed
+		}
+	}
+	tr.mu.Unlock()
+
+	tr.mgr.forEachTestListener(func(ln TestListener) { ln.OnTestRunEnd(tr) })
+}
+
+// handleEvent updates the run based on an event reported by "go test -json".
+func (tr *TestRun) handleEvent(ev *testEvent) {
+	if ev.Test == "" {
+		return
+	}
+
+	tr.mu.Lock()
+	key := testKey{ev.Package, ev.Test}
+	res, ok := tr.byTest[key]
+	if !ok {
+		if ev.Action != "run" {
+			tr.mu.Unlock()
+			return
+		}
+		res = &TestResult{Package: ev.Package, Name: ev.Test, Status: TestRunning, Started: ev.Time}
+		tr.byTest[key] = res
+		tr.tests = append(tr.tests, res)
+	}
+
+	notify := !ok
+	switch ev.Action {
+	case "output":
+		if n := maxTestOutput - len(res.Output); n > 0 {
+			out := ev.Output
+			if len(out) > n {
+				out = out[:n]
+			}
+			res.Output += out
+		}
+	default:
+		if status, ok := testEventStatus[ev.Action]; ok {
+			res.Status = status
+			res.DurationNanos = int64(ev.Elapsed * float64(time.Second))
+			notify = true
+		}
+	}
+	tr.mu.Unlock()
+
+	if notify {
+		tr.notifyResult(res)
+	}
+}
+
+func (tr *TestRun) notifyResult(res *TestResult) {
+	tr.mu.Lock()
+	snapshot := *res
+	tr.mu.Unlock()
+	tr.mgr.forEachTestListener(func(ln TestListener) { ln.OnTestResult(tr, snapshot) })
+}
@@ -18,6 +18,7 @@ import (
 	"encr.dev/cli/daemon/secret"
 	"encr.dev/internal/optracker"
 	"encr.dev/internal/version"
+	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/builder/builderimpl"
 	"encr.dev/pkg/cueutil"
@@ -37,7 +38,13 @@ type TestParams struct {
 }
 
 // Test runs the tests.
+//
+// The run is recorded as a TestRun, and for Go apps the
+// result of each test is recorded as the tests complete.
 func (mgr *Manager) Test(ctx context.Context, params TestParams) (err error) {
+	tr := mgr.beginTestRun(params.App.PlatformOrLocalID(), params.Args)
+	defer func() { tr.end(err) }()
+
 	expSet, err := params.App.Experiments(params.Environ)
 	if err != nil {
 		return err
@@ -45,7 +52,19 @@ func (mgr *Manager) Test(ctx context.Context, params TestParams) (err error) {
 	bld := builderimpl.Resolve(params.App.Lang(), expSet)
 	defer fns.CloseIgnore(bld)
 
-	spec, err := mgr.testSpec(ctx, bld, expSet, params.TestSpecParams)
+	// Run "go test" with -json to follow the progress of the tests.
+	specParams, stdout := params.TestSpecParams, params.Stdout
+	if params.App.Lang() == appfile.LangGo {
+		w, args := newTestJSONWriter(tr, params.Stdout, params.Args)
+		defer w.Flush()
+		stdout = w
+
+		withJSON := *params.TestSpecParams
+		withJSON.Args = args
+		specParams = &withJSON
+	}
+
+	spec, err := mgr.testSpec(ctx, bld, expSet, specParams)
 	if err != nil {
 		return err
 	}
@@ -54,7 +73,7 @@ func (mgr *Manager) Test(ctx context.Context, params TestParams) (err error) {
 	return bld.RunTests(ctx, builder.RunTestsParams{
 		Spec:       spec,
 		WorkingDir: workingDir,
-		Stdout:     params.Stdout,
+		Stdout:     stdout,
 		Stderr:     params.Stderr,
 	})
 }
@@ -0,0 +1,22 @@
+package run
+
+import (
+	"errors"
+	"time"
+
+	"encore.dev/appruntime/shared/platform"
+)
+
+// ForceTraceToken returns a token that forces requests to the running app to be traced,
+// regardless of the trace sampling rate, when passed in the X-Encore-Force-Trace header.
+//
+// The token is valid for ttl, which is capped at platform.ForceTraceMaxTTL,
+// or until the app is restarted, for example after a code change.
+func (r *Run) ForceTraceToken(ttl time.Duration) (token string, expires time.Time, err error) {
+	p := r.ProcGroup()
+	if p == nil {
+		return "", time.Time{}, errors.New("the app is not running")
+	}
+	expires = time.Now().Add(min(ttl, platform.ForceTraceMaxTTL))
+	return platform.SignForceTraceToken(p.authKey, expires), expires, nil
+}
@@ -0,0 +1,39 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/platform"
+)
+
+func TestRun_ForceTraceToken(t *testing.T) {
+	r := &Run{}
+	if _, _, err := r.ForceTraceToken(time.Hour); err == nil {
+		t.Fatal("got nil error for a run that hasn't started, want non-nil")
+	}
+
+	key := genAuthKey()
+	r.StoreProc(&ProcGroup{authKey: key})
+	token, expires, err := r.ForceTraceToken(7 * 24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limit := time.Now().Add(platform.ForceTraceMaxTTL); expires.After(limit) {
+		t.Errorf("got expiry %v, want at most %v", expires, limit)
+	}
+
+	// The app's processes are configured with the process group's auth key,
+	// so they accept the token.
+	pc := platform.NewClient(&config.Static{}, &config.Runtime{AuthKeys: []config.EncoreAuthKey{key}})
+	if !pc.ValidateForceTraceToken(token) {
+		t.Error("got invalid token, want valid")
+	}
+
+	// Processes of other runs don't.
+	other := platform.NewClient(&config.Static{}, &config.Runtime{AuthKeys: []config.EncoreAuthKey{genAuthKey()}})
+	if other.ValidateForceTraceToken(token) {
+		t.Error("got valid token for another run, want invalid")
+	}
+}
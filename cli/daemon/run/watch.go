@@ -16,6 +16,18 @@ func (mgr *Manager) watch(run *Run) error {
 			return
 		}
 
+		// Changes to the local secret overrides don't require recompiling
+		// if the running app can reload its secrets.
+		if onlySecretsChanged(event) {
+			if ok, err := run.ReloadSecrets(run.ctx); err != nil {
+				mgr.RunStderr(run, []byte(err.Error()+"\n"))
+				return
+			} else if ok {
+				mgr.RunStdout(run, []byte("Secrets changed, reloaded secrets.\n"))
+				return
+			}
+		}
+
 		mgr.RunStdout(run, []byte("Changes detected, recompiling...\n"))
 		if err := run.Reload(); err != nil {
 			if errList := AsErrorList(err); errList != nil {
@@ -54,6 +66,17 @@ func IgnoreEvents(events []watcher.Event) bool {
 	return true
 }
 
+// onlySecretsChanged reports whether all the events that aren't
+// ignored are on the local secret overrides file.
+func onlySecretsChanged(events []watcher.Event) bool {
+	for _, event := range events {
+		if !ignoreEvent(event) && filepath.Base(event.Path) != ".secrets.local.cue" {
+			return false
+		}
+	}
+	return true
+}
+
 func ignoreEvent(ev watcher.Event) bool {
 	filename := filepath.Base(ev.Path)
 	if strings.HasPrefix(strings.ToLower(filename), "encore.gen.") {
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
 	"sync"
@@ -28,7 +29,10 @@ import (
 
 // New returns a new manager.
 func New() *Manager {
-	return &Manager{cache: make(map[string]*Data)}
+	return &Manager{
+		cache:       make(map[string]*Data),
+		subscribers: make(map[*subscriber]bool),
+	}
 }
 
 // Manager manages the secrets cache for running Encore apps.
@@ -36,8 +40,14 @@ type Manager struct {
 	group    singleflight.Group
 	pollOnce sync.Once
 
-	mu    sync.Mutex
-	cache map[string]*Data
+	mu          sync.Mutex
+	cache       map[string]*Data
+	subscribers map[*subscriber]bool
+}
+
+type subscriber struct {
+	appSlug string
+	fn      func()
 }
 
 // Data is a snapshot of an Encore app's development secret values.
@@ -151,6 +161,32 @@ func (mgr *Manager) UpdateKey(appSlug, key, value string) {
 		if err := mgr.writeToDisk(appSlug, data); err != nil {
 			log.Error().Err(err).Msg("failed to write secrets to disk cache")
 		}
+		mgr.notify(appSlug)
+	}
+}
+
+// Subscribe registers fn to be called whenever the secrets for appSlug change,
+// either because they were updated or because a poll picked up new values.
+// The returned function unsubscribes.
+func (mgr *Manager) Subscribe(appSlug string, fn func()) (unsubscribe func()) {
+	sub := &subscriber{appSlug: appSlug, fn: fn}
+	mgr.mu.Lock()
+	mgr.subscribers[sub] = true
+	mgr.mu.Unlock()
+	return func() {
+		mgr.mu.Lock()
+		delete(mgr.subscribers, sub)
+		mgr.mu.Unlock()
+	}
+}
+
+// notify calls the subscribers for appSlug in the background.
+// mu must be held when calling it.
+func (mgr *Manager) notify(appSlug string) {
+	for sub := range mgr.subscribers {
+		if sub.appSlug == appSlug {
+			go sub.fn()
+		}
 	}
 }
 
@@ -171,7 +207,11 @@ func (mgr *Manager) fetch(appSlug string, poll bool) <-chan singleflight.Result
 
 		// Update our caches
 		mgr.mu.Lock()
+		prev, hadPrev := mgr.cache[appSlug]
 		mgr.cache[appSlug] = data
+		if hadPrev && !maps.Equal(prev.Values, data.Values) {
+			mgr.notify(appSlug)
+		}
 		mgr.mu.Unlock()
 		if err := mgr.writeToDisk(appSlug, data); err != nil {
 			log.Error().Err(err).Msg("failed to write secrets to disk cache")
@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/mod/modfile"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/internal/optracker"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/builder"
+	"encr.dev/pkg/builder/builderimpl"
+	"encr.dev/pkg/fns"
+	"encr.dev/pkg/paths"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// DBSeed applies the seed data for the given databases.
+func (s *Server) DBSeed(req *daemonpb.DBSeedRequest, stream daemonpb.Daemon_DBSeedServer) error {
+	ctx := stream.Context()
+	slog := &streamLog{stream: stream, buffered: false}
+	stderr := slog.Stderr(false)
+	sendErr := func(err error) {
+		if list := run.AsErrorList(err); list != nil {
+			_ = list.SendToStream(stream)
+		} else {
+			_, _ = fmt.Fprintln(stderr, err.Error())
+		}
+		streamExit(stream, 1)
+	}
+
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+
+	expSet, err := app.Experiments(req.Environ)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+
+	// Parse the app to figure out what seeds there are.
+	bld := builderimpl.Resolve(app.Lang(), expSet)
+	defer fns.CloseIgnore(bld)
+	parse, err := bld.Parse(ctx, builder.ParseParams{
+		Build:       builder.DefaultBuildInfo(),
+		App:         app,
+		Experiments: expSet,
+		WorkingDir:  ".",
+		ParseTests:  false,
+	})
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+
+	dbs := parse.Meta.SqlDatabases
+	if len(req.DatabaseNames) > 0 {
+		dbs = fns.Filter(dbs, func(db *meta.SQLDatabase) bool {
+			return slices.Contains(req.DatabaseNames, db.Name)
+		})
+		if len(dbs) != len(req.DatabaseNames) {
+			sendErr(errDatabaseNotFound)
+			return nil
+		}
+	}
+	dbs = fns.Filter(dbs, func(db *meta.SQLDatabase) bool { return db.SeedRelPath != nil })
+	if len(dbs) == 0 {
+		_, _ = fmt.Fprintln(stderr, "No databases with seed data found.")
+		streamExit(stream, 0)
+		return nil
+	}
+
+	ns, err := s.namespaceOrActive(ctx, app, req.Namespace)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+
+	clusterType := getClusterType(req)
+	clusterID := sqldb.GetClusterID(app, clusterType, ns)
+	cluster, ok := s.cm.Get(clusterID)
+	if !ok {
+		cluster = s.cm.Create(ctx, &sqldb.CreateParams{
+			ClusterID: clusterID,
+			Memfs:     clusterType.Memfs(),
+		})
+	}
+	if _, err := cluster.Start(ctx, nil); err != nil {
+		sendErr(err)
+		return nil
+	}
+
+	// Apply the SQL seeds first, so seed programs can build on them.
+	for _, db := range dbs {
+		if len(db.SeedFiles) == 0 {
+			continue
+		}
+		if err := cluster.Seed(ctx, req.AppRoot, db, req.Force); err != nil {
+			sendErr(fmt.Errorf("seed database %s: %v", db.Name, err))
+			return nil
+		}
+		_, _ = fmt.Fprintf(stderr, "Seeded database %s (%d seed files).\n", db.Name, len(db.SeedFiles))
+	}
+
+	for _, db := range dbs {
+		if !db.SeedProgram {
+			continue
+		} else if clusterType != sqldb.Run || app.Lang() != appfile.LangGo {
+			_, _ = fmt.Fprintf(stderr, "Skipping seed program for database %s: only supported for the local development database.\n", db.Name)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(stderr, "Running seed program for database %s...\n", db.Name)
+		if err := s.runSeedProgram(req, stream, app, ns, db); err != nil {
+			sendErr(fmt.Errorf("seed database %s: %v", db.Name, err))
+			return nil
+		}
+	}
+
+	streamExit(stream, 0)
+	return nil
+}
+
+// runSeedProgram runs the Go seed program for the given database.
+func (s *Server) runSeedProgram(req *daemonpb.DBSeedRequest, stream daemonpb.Daemon_DBSeedServer, app *apps.Instance, ns *namespace.Namespace, db *meta.SQLDatabase) error {
+	modPath := filepath.Join(app.Root(), "go.mod")
+	modData, err := os.ReadFile(modPath)
+	if err != nil {
+		return err
+	}
+	mod, err := modfile.Parse(modPath, modData, nil)
+	if err != nil {
+		return err
+	}
+
+	slog := &streamLog{stream: stream, buffered: false}
+	ops := optracker.New(slog.Stderr(false), stream)
+	defer ops.AllDone()
+
+	return s.mgr.ExecScript(stream.Context(), run.ExecScriptParams{
+		App:        app,
+		NS:         ns,
+		WorkingDir: req.WorkingDir,
+		Environ:    req.Environ,
+		MainPkg:    paths.Pkg(mod.Module.Mod.Path).JoinSlash(paths.RelSlash(*db.SeedRelPath)),
+		Stdout:     slog.Stdout(false),
+		Stderr:     slog.Stderr(false),
+		OpTracker:  ops,
+	})
+}
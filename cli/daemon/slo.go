@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	daemonpb "encr.dev/proto/encore/daemon"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// maxSLOSpans is the maximum number of traces considered by SLOReport.
+// If the window contains more, only the most recent are considered
+// and the report is marked as truncated.
+const maxSLOSpans = 100000
+
+// SLOReport computes per-endpoint availability and latency from the traces
+// recorded for the app within the requested time window.
+//
+// Only requests made to the app from the outside are considered,
+// as the trace store only indexes root spans.
+func (s *Server) SLOReport(ctx context.Context, req *daemonpb.SLOReportRequest) (*daemonpb.SLOReportResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// List one more span than we consider to detect whether the window
+	// contained more requests than the report covers.
+	q := &trace2.Query{
+		AppID:      app.PlatformOrLocalID(),
+		TestFilter: new(bool),
+		Limit:      maxSLOSpans + 1,
+	}
+	if req.From != nil {
+		q.StartTime = req.From.AsTime()
+	}
+	if req.To != nil {
+		q.EndTime = req.To.AsTime()
+	}
+
+	agg := newSLOAggregator()
+	listed := 0
+	truncated := false
+	err = s.tr.List(ctx, q, func(span *tracepb2.SpanSummary) bool {
+		if listed++; listed > maxSLOSpans {
+			truncated = true
+			return false
+		}
+		agg.add(span)
+		return true
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list traces: %v", err)
+	}
+
+	resp := agg.result()
+	resp.Truncated = truncated
+	return resp, nil
+}
+
+type sloEndpointKey struct{ svc, ep string }
+
+// sloAggregator aggregates request spans into per-endpoint SLO stats.
+type sloAggregator struct {
+	requests  int64
+	durations map[sloEndpointKey][]uint64
+	stats     map[sloEndpointKey]*daemonpb.SLOReportResponse_EndpointStats
+}
+
+func newSLOAggregator() *sloAggregator {
+	return &sloAggregator{
+		durations: make(map[sloEndpointKey][]uint64),
+		stats:     make(map[sloEndpointKey]*daemonpb.SLOReportResponse_EndpointStats),
+	}
+}
+
+// add records the span, ignoring spans that aren't API requests.
+func (a *sloAggregator) add(span *tracepb2.SpanSummary) {
+	if span.Type != tracepb2.SpanSummary_REQUEST || span.EndpointName == nil {
+		return
+	}
+	key := sloEndpointKey{span.ServiceName, *span.EndpointName}
+	st, ok := a.stats[key]
+	if !ok {
+		st = &daemonpb.SLOReportResponse_EndpointStats{Service: key.svc, Endpoint: key.ep}
+		a.stats[key] = st
+	}
+	st.Requests++
+	if span.IsError {
+		st.Errors++
+	}
+	a.durations[key] = append(a.durations[key], span.DurationNanos)
+	a.requests++
+}
+
+// result computes the report from the recorded spans.
+func (a *sloAggregator) result() *daemonpb.SLOReportResponse {
+	resp := &daemonpb.SLOReportResponse{Requests: a.requests}
+	for key, st := range a.stats {
+		durs := a.durations[key]
+		slices.Sort(durs)
+		st.P50Nanos = percentile(durs, 50)
+		st.P90Nanos = percentile(durs, 90)
+		st.P95Nanos = percentile(durs, 95)
+		st.P99Nanos = percentile(durs, 99)
+		resp.Endpoints = append(resp.Endpoints, st)
+	}
+	slices.SortFunc(resp.Endpoints, func(a, b *daemonpb.SLOReportResponse_EndpointStats) int {
+		return cmp.Or(cmp.Compare(a.Service, b.Service), cmp.Compare(a.Endpoint, b.Endpoint))
+	})
+	return resp
+}
+
+// percentile returns the p-th percentile of the sorted values,
+// using the nearest-rank method.
+func percentile(sorted []uint64, p float64) uint64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
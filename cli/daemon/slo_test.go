@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		sorted []uint64
+		p      float64
+		want   uint64
+	}{
+		{sorted: nil, p: 50, want: 0},
+		{sorted: []uint64{7}, p: 50, want: 7},
+		{sorted: []uint64{7}, p: 99, want: 7},
+		{sorted: []uint64{1, 2, 3, 4}, p: 50, want: 2},
+		{sorted: []uint64{1, 2, 3, 4}, p: 51, want: 3},
+		{sorted: []uint64{1, 2, 3, 4}, p: 99, want: 4},
+		{sorted: []uint64{1, 2, 3, 4}, p: 0, want: 1},
+		{sorted: []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 90, want: 9},
+		{sorted: []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 95, want: 10},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v, %v) = %d, want %d", tt.sorted, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestSLOAggregator(t *testing.T) {
+	span := func(typ tracepb2.SpanSummary_SpanType, svc, ep string, durMs uint64, isErr bool) *tracepb2.SpanSummary {
+		s := &tracepb2.SpanSummary{
+			Type:          typ,
+			ServiceName:   svc,
+			DurationNanos: durMs * 1e6,
+			IsError:       isErr,
+		}
+		if ep != "" {
+			s.EndpointName = &ep
+		}
+		return s
+	}
+	req := tracepb2.SpanSummary_REQUEST
+
+	tests := []struct {
+		name  string
+		spans []*tracepb2.SpanSummary
+		want  *daemonpb.SLOReportResponse
+	}{
+		{
+			name: "empty",
+			want: &daemonpb.SLOReportResponse{},
+		},
+		{
+			name: "per_endpoint",
+			spans: []*tracepb2.SpanSummary{
+				span(req, "svc", "B", 30, false),
+				span(req, "svc", "A", 10, false),
+				span(req, "svc", "B", 10, true),
+				span(req, "svc", "B", 20, false),
+				span(req, "other", "A", 5, true),
+			},
+			want: &daemonpb.SLOReportResponse{
+				Requests: 5,
+				Endpoints: []*daemonpb.SLOReportResponse_EndpointStats{
+					{Service: "other", Endpoint: "A", Requests: 1, Errors: 1, P50Nanos: 5e6, P90Nanos: 5e6, P95Nanos: 5e6, P99Nanos: 5e6},
+					{Service: "svc", Endpoint: "A", Requests: 1, P50Nanos: 10e6, P90Nanos: 10e6, P95Nanos: 10e6, P99Nanos: 10e6},
+					{Service: "svc", Endpoint: "B", Requests: 3, Errors: 1, P50Nanos: 20e6, P90Nanos: 30e6, P95Nanos: 30e6, P99Nanos: 30e6},
+				},
+			},
+		},
+		{
+			name: "ignores_non_requests",
+			spans: []*tracepb2.SpanSummary{
+				span(req, "svc", "A", 10, false),
+				span(tracepb2.SpanSummary_PUBSUB_MESSAGE, "svc", "", 100, true),
+				span(req, "svc", "", 100, true),
+			},
+			want: &daemonpb.SLOReportResponse{
+				Requests: 1,
+				Endpoints: []*daemonpb.SLOReportResponse_EndpointStats{
+					{Service: "svc", Endpoint: "A", Requests: 1, P50Nanos: 10e6, P90Nanos: 10e6, P95Nanos: 10e6, P99Nanos: 10e6},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			agg := newSLOAggregator()
+			for _, s := range tt.spans {
+				agg.add(s)
+			}
+			c.Assert(agg.result(), qt.CmpEquals(protocmp.Transform()), tt.want)
+		})
+	}
+}
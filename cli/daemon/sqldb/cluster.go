@@ -338,6 +338,12 @@ func (c *Cluster) updateStatusFromDriver(ctx context.Context) (*ClusterStatus, e
 	return st, err
 }
 
+// ResourceUsage reports the cluster's current resource usage.
+// It reports ErrUnsupported if the driver doesn't support it.
+func (c *Cluster) ResourceUsage(ctx context.Context) (*ResourceUsage, error) {
+	return c.driver.ResourceUsage(ctx, c.ID)
+}
+
 // pollStatus polls the driver for status changes.
 func (c *Cluster) pollStatus() {
 	ch := time.NewTicker(10 * time.Second)
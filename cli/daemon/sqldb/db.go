@@ -105,6 +105,10 @@ func (db *DB) Setup(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabas
 				if migrate || recreate {
 					return fmt.Errorf("migrate db %s: %v", cloudName, err)
 				}
+			} else if _, err := db.doSeed(ctx, cloudName, appRoot, dbMeta, false); err != nil {
+				if migrate || recreate {
+					return fmt.Errorf("seed db %s: %v", cloudName, err)
+				}
 			}
 		}
 		return nil
@@ -274,20 +278,7 @@ func (db *DB) doMigrate(ctx context.Context, cloudName, appRoot string, dbMeta *
 		}
 	}()
 
-	info, err := db.Cluster.Info(ctx)
-	if err != nil {
-		return err
-	} else if info.Status != Running {
-		return errors.New("cluster not running")
-	}
-
-	admin, ok := info.Encore.First(RoleAdmin, RoleSuperuser)
-	if !ok {
-		return errors.New("unable to find superuser or admin roles")
-	}
-	uri := info.ConnURI(cloudName, admin)
-	db.log.Debug().Str("uri", uri).Msg("running migrations")
-	pool, err := sql.Open("pgx", uri)
+	pool, err := db.openAdmin(ctx, cloudName)
 	if err != nil {
 		return err
 	}
@@ -313,6 +304,25 @@ func (db *DB) doMigrate(ctx context.Context, cloudName, appRoot string, dbMeta *
 	return nil
 }
 
+// openAdmin opens a connection pool to the database with the given cloud name,
+// using the cluster's admin role.
+func (db *DB) openAdmin(ctx context.Context, cloudName string) (*sql.DB, error) {
+	info, err := db.Cluster.Info(ctx)
+	if err != nil {
+		return nil, err
+	} else if info.Status != Running {
+		return nil, errors.New("cluster not running")
+	}
+
+	admin, ok := info.Encore.First(RoleAdmin, RoleSuperuser)
+	if !ok {
+		return nil, errors.New("unable to find superuser or admin roles")
+	}
+	uri := info.ConnURI(cloudName, admin)
+	db.log.Debug().Str("uri", uri).Msg("connecting as admin")
+	return sql.Open("pgx", uri)
+}
+
 func (db *DB) ListAppliedMigrations(ctx context.Context) (map[uint64]bool, error) {
 	conn, err := db.connectToDB(ctx)
 	if err != nil {
@@ -6,6 +6,8 @@ import (
 
 	qt "github.com/frankban/quicktest"
 	_ "github.com/golang-migrate/migrate/v4/source/file" // for running migrations from the filesystem
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 func TestFindClosestVersion(t *testing.T) {
@@ -71,3 +73,88 @@ func TestFindClosestVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeMigrationPlan(t *testing.T) {
+	c := qt.New(t)
+	migrations := []*meta.DBMigration{{Number: 1}, {Number: 2}, {Number: 3}}
+	testCases := map[string]struct {
+		applied     map[uint64]bool
+		allowNonSeq bool
+		pending     []uint64
+		drift       int
+	}{
+		"fresh": {
+			applied: map[uint64]bool{},
+			pending: []uint64{1, 2, 3},
+		},
+		"sequential": {
+			applied: map[uint64]bool{2: false},
+			pending: []uint64{3},
+		},
+		"sequential_dirty": {
+			applied: map[uint64]bool{2: true},
+			pending: []uint64{2, 3},
+			drift:   1,
+		},
+		"up_to_date": {
+			applied: map[uint64]bool{3: false},
+		},
+		"removed": {
+			applied: map[uint64]bool{4: false},
+			drift:   1,
+		},
+		"non_sequential": {
+			applied:     map[uint64]bool{1: false, 3: false},
+			allowNonSeq: true,
+			pending:     []uint64{2},
+		},
+		"non_sequential_removed": {
+			applied:     map[uint64]bool{1: false, 5: false},
+			allowNonSeq: true,
+			pending:     []uint64{2, 3},
+			drift:       1,
+		},
+	}
+
+	for name, tc := range testCases {
+		c.Run(name, func(c *qt.C) {
+			pending, drift := computeMigrationPlan(migrations, tc.applied, tc.allowNonSeq)
+			var got []uint64
+			for _, m := range pending {
+				got = append(got, m.Number)
+			}
+			c.Assert(got, qt.DeepEquals, tc.pending)
+			c.Assert(drift, qt.HasLen, tc.drift)
+		})
+	}
+}
+
+func TestDestructiveStatements(t *testing.T) {
+	c := qt.New(t)
+	sql := `
+CREATE TABLE foo (id INT);
+-- DROP TABLE commented;
+DROP TABLE bar;
+ALTER TABLE foo ADD COLUMN name TEXT;
+ALTER TABLE foo
+    DROP COLUMN old;
+ALTER TABLE foo ALTER COLUMN id TYPE BIGINT;
+TRUNCATE baz;
+DELETE FROM qux WHERE id = 1;
+`
+	c.Assert(destructiveStatements(sql), qt.DeepEquals, []string{
+		"DROP TABLE bar",
+		"ALTER TABLE foo DROP COLUMN old",
+		"ALTER TABLE foo ALTER COLUMN id TYPE BIGINT",
+		"TRUNCATE baz",
+		"DELETE FROM qux WHERE id = 1",
+	})
+}
+
+func TestPendingSeeds(t *testing.T) {
+	c := qt.New(t)
+	files := []string{"2_orders.sql", "1_users.sql", "3_items.sql"}
+	c.Assert(pendingSeeds(files, nil), qt.DeepEquals, []string{"1_users.sql", "2_orders.sql", "3_items.sql"})
+	c.Assert(pendingSeeds(files, map[string]bool{"1_users.sql": true, "3_items.sql": true}), qt.DeepEquals, []string{"2_orders.sql"})
+	c.Assert(files, qt.DeepEquals, []string{"2_orders.sql", "1_users.sql", "3_items.sql"})
+}
@@ -6,21 +6,35 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 
+	"encr.dev/cli/daemon/containers"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/internal/userconfig"
 	"encr.dev/pkg/idents"
 )
 
-type Driver struct{}
+// Driver runs database clusters as containers, using Docker or another container runtime.
+type Driver struct {
+	// Runtime is the container runtime to use.
+	// If nil, it's detected using containers.Detect.
+	Runtime containers.Runtime
+
+	once sync.Once
+	rt   containers.Runtime
+}
 
 var _ sqldb.Driver = (*Driver)(nil)
 
@@ -36,12 +50,12 @@ func (d *Driver) CreateCluster(ctx context.Context, p *sqldb.CreateParams, log z
 	{
 		checkExistsCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
-		if ok, err := ImageExists(checkExistsCtx); err != nil {
+		if ok, err := d.imageExists(checkExistsCtx); err != nil {
 			return nil, errors.Wrap(err, "check docker image")
 		} else if !ok {
 			log.Debug().Msg("PostgreSQL image does not exist, pulling")
 			pullOp := p.Tracker.Add("Pulling PostgreSQL docker image", time.Now())
-			if err := PullImage(context.Background()); err != nil {
+			if err := d.pullImage(context.Background()); err != nil {
 				log.Error().Err(err).Msg("failed to pull PostgreSQL image")
 				p.Tracker.Fail(pullOp, err)
 				return nil, errors.Wrap(err, "pull docker image")
@@ -122,7 +136,16 @@ func (d *Driver) CreateCluster(ctx context.Context, p *sqldb.CreateParams, log z
 	case sqldb.Stopped:
 		log.Debug().Msg("cluster stopped, restarting")
 
-		if out, err := exec.CommandContext(ctx, "docker", "start", existingContainerName).CombinedOutput(); err != nil {
+		// Apply the resource limits in case they have changed since the container was created.
+		if limits := resourceLimitArgs(cid); len(limits) > 0 {
+			args := append([]string{"update"}, limits...)
+			args = append(args, existingContainerName)
+			if out, err := d.runtime().Command(ctx, args...).CombinedOutput(); err != nil {
+				return nil, errors.Wrapf(err, "could not update sqldb container resource limits: %s", out)
+			}
+		}
+
+		if out, err := d.runtime().Command(ctx, "start", existingContainerName).CombinedOutput(); err != nil {
 			return nil, errors.Wrapf(err, "could not start sqldb container: %s", string(out))
 		}
 		return waitForPort()
@@ -140,10 +163,11 @@ func (d *Driver) CreateCluster(ctx context.Context, p *sqldb.CreateParams, log z
 			"-e", "PGDATA=" + defaultDataDir,
 			"--name", cnames[0],
 		}
+		args = append(args, resourceLimitArgs(cid)...)
 		if p.Memfs {
 			args = append(args,
 				"--mount", "type=tmpfs,destination="+defaultDataDir,
-				Image,
+				d.image(),
 				"-c", "fsync=off",
 			)
 		} else {
@@ -153,12 +177,12 @@ func (d *Driver) CreateCluster(ctx context.Context, p *sqldb.CreateParams, log z
 			}
 			args = append(args,
 				"-v", fmt.Sprintf("%s:%s", volumeName, defaultDataDir),
-				Image)
+				d.image())
 		}
 
-		cmd := exec.CommandContext(ctx, "docker", args...)
+		cmd := d.runtime().Command(ctx, args...)
 		if out, err := cmd.CombinedOutput(); err != nil {
-			return nil, errors.Wrapf(err, "could not start sql database as docker container: %s", out)
+			return nil, errors.Wrapf(err, "could not start sql database as %s container: %s", d.runtime().Name(), out)
 		}
 
 		log.Debug().Msg("cluster created")
@@ -175,12 +199,18 @@ func (d *Driver) ClusterStatus(ctx context.Context, id sqldb.ClusterID) (*sqldb.
 }
 
 func (d *Driver) CheckRequirements(ctx context.Context) error {
-	if _, err := exec.LookPath("docker"); err != nil {
-		return errors.New("This application requires docker to run since it uses an SQL database. Install docker first.")
-	} else if !isDockerRunning(ctx) {
-		return errors.New("The docker daemon is not running. Start it first.")
-	}
-	return nil
+	return d.runtime().CheckHealth(ctx)
+}
+
+// runtime returns the container runtime to use.
+func (d *Driver) runtime() containers.Runtime {
+	d.once.Do(func() {
+		d.rt = d.Runtime
+		if d.rt == nil {
+			d.rt = containers.MustDetect()
+		}
+	})
+	return d.rt
 }
 
 // clusterStatus reports both the standard ClusterStatus but also the container name we actually resolved to.
@@ -191,9 +221,9 @@ func (d *Driver) clusterStatus(ctx context.Context, id sqldb.ClusterID) (status
 	cnames := containerNames(id)
 	for _, cname := range cnames {
 		var err error
-		out, err := exec.CommandContext(ctx, "docker", "container", "inspect", cname).CombinedOutput()
+		out, err := d.runtime().Command(ctx, "container", "inspect", cname).CombinedOutput()
 		if errors.Is(err, exec.ErrNotFound) {
-			return nil, "", errors.New("docker not found: is it installed and in your PATH?")
+			return nil, "", errors.Newf("%s not found: is it installed and in your PATH?", d.runtime().Name())
 		} else if err != nil {
 			// Docker returns a non-zero exit code if the container does not exist.
 			// Try to tell this apart from an error by parsing the output.
@@ -204,7 +234,7 @@ func (d *Driver) clusterStatus(ctx context.Context, id sqldb.ClusterID) (status
 			if bytes.Contains(out, []byte("no such container")) {
 				continue
 			}
-			return nil, "", errors.Wrapf(err, "docker container inspect failed: %s", out)
+			return nil, "", errors.Wrapf(err, "%s container inspect failed: %s", d.runtime().Name(), out)
 		} else {
 			// Found our container; use it.
 			output, containerName = out, cname
@@ -254,11 +284,12 @@ func (d *Driver) clusterStatus(ctx context.Context, id sqldb.ClusterID) (status
 
 				// Podman can keep HostIP empty or 0.0.0.0.
 				// https://github.com/containers/podman/issues/17780
-				if hostIP == "" || hostIP == "0.0.0.0" {
-					hostIP = "127.0.0.1"
+				// Containers running on remote hosts are reached through the host's address.
+				if hostIP == "" || hostIP == "0.0.0.0" || d.runtime().HostAddress() != "127.0.0.1" {
+					hostIP = d.runtime().HostAddress()
 				}
 
-				status.Config.Host = hostIP + ":" + ports[0].HostPort
+				status.Config.Host = net.JoinHostPort(hostIP, ports[0].HostPort)
 			}
 
 			// Read the Postgres config from the docker container's environment.
@@ -282,9 +313,9 @@ func (d *Driver) clusterStatus(ctx context.Context, id sqldb.ClusterID) (status
 }
 
 func (d *Driver) CanDestroyCluster(ctx context.Context, id sqldb.ClusterID) error {
-	// Check that we can communicate with Docker.
-	if !isDockerRunning(ctx) {
-		return errors.New("cannot delete sql database: docker is not running")
+	// Check that we can communicate with the container runtime.
+	if err := d.runtime().CheckHealth(ctx); err != nil {
+		return errors.Wrap(err, "cannot delete sql database")
 	}
 	return nil
 }
@@ -292,9 +323,9 @@ func (d *Driver) CanDestroyCluster(ctx context.Context, id sqldb.ClusterID) erro
 func (d *Driver) DestroyCluster(ctx context.Context, id sqldb.ClusterID) error {
 	cnames := containerNames(id)
 	for _, cname := range cnames {
-		out, err := exec.CommandContext(ctx, "docker", "rm", "-f", cname).CombinedOutput()
+		out, err := d.runtime().Command(ctx, "rm", "-f", cname).CombinedOutput()
 		if err != nil {
-			if bytes.Contains(out, []byte("No such container")) {
+			if bytes.Contains(bytes.ToLower(out), []byte("no such container")) {
 				continue
 			}
 			return errors.Wrapf(err, "could not delete cluster: %s", out)
@@ -306,7 +337,7 @@ func (d *Driver) DestroyCluster(ctx context.Context, id sqldb.ClusterID) error {
 func (d *Driver) DestroyNamespaceData(ctx context.Context, ns *namespace.Namespace) error {
 	candidates := clusterVolumeNames(ns)
 	for _, c := range candidates {
-		if err := exec.CommandContext(ctx, "docker", "volume", "rm", "-f", c).Run(); err != nil {
+		if err := d.runtime().Command(ctx, "volume", "rm", "-f", c).Run(); err != nil {
 			if strings.Contains(strings.ToLower(err.Error()), "no such volume") {
 				continue
 			}
@@ -317,13 +348,125 @@ func (d *Driver) DestroyNamespaceData(ctx context.Context, ns *namespace.Namespa
 }
 
 func (d *Driver) createVolumeIfNeeded(ctx context.Context, name string) error {
-	if err := exec.CommandContext(ctx, "docker", "volume", "inspect", name).Run(); err == nil {
+	if err := d.runtime().Command(ctx, "volume", "inspect", name).Run(); err == nil {
 		return nil
 	}
-	out, err := exec.CommandContext(ctx, "docker", "volume", "create", name).CombinedOutput()
+	out, err := d.runtime().Command(ctx, "volume", "create", name).CombinedOutput()
 	return errors.Wrapf(err, "create volume %s: %s", name, out)
 }
 
+func (d *Driver) ResourceUsage(ctx context.Context, id sqldb.ClusterID) (*sqldb.ResourceUsage, error) {
+	status, containerName, err := d.clusterStatus(ctx, id)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if status.Status != sqldb.Running {
+		return nil, errors.New("cluster is not running")
+	}
+
+	// Use a template rather than JSON output since the JSON fields differ between Docker and Podman.
+	out, err := d.runtime().Command(ctx, "stats", "--no-stream", "--format", "{{.CPUPerc}}\t{{.MemUsage}}", containerName).CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s stats failed: %s", d.runtime().Name(), out)
+	}
+	return parseStats(string(out))
+}
+
+// parseStats parses the output of "stats --format '{{.CPUPerc}}\t{{.MemUsage}}'",
+// like "1.25%\t20.5MiB / 1GiB".
+func parseStats(out string) (*sqldb.ResourceUsage, error) {
+	cpu, mem, ok := strings.Cut(strings.TrimSpace(out), "\t")
+	if !ok {
+		return nil, errors.Newf("unexpected stats output %q", out)
+	}
+
+	cpuPercent, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(cpu), "%"), 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse cpu usage %q", cpu)
+	}
+
+	used, limit, ok := strings.Cut(mem, "/")
+	if !ok {
+		return nil, errors.Newf("unexpected memory usage %q", mem)
+	}
+	usedBytes, err := parseSize(used)
+	if err != nil {
+		return nil, err
+	}
+	limitBytes, err := parseSize(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqldb.ResourceUsage{
+		CPUPercent:       cpuPercent,
+		MemoryBytes:      usedBytes,
+		MemoryLimitBytes: limitBytes,
+	}, nil
+}
+
+// parseSize parses a human-readable size as reported by the container runtime,
+// like "20.5MiB" (Docker) or "20.5MB" (Podman).
+func parseSize(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	idx := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if idx == -1 {
+		idx = len(s)
+	}
+
+	num, err := strconv.ParseFloat(s[:idx], 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse size %q", s)
+	}
+
+	var mult float64
+	switch strings.ToLower(strings.TrimSpace(s[idx:])) {
+	case "", "b":
+		mult = 1
+	case "kib":
+		mult = 1 << 10
+	case "mib":
+		mult = 1 << 20
+	case "gib":
+		mult = 1 << 30
+	case "tib":
+		mult = 1 << 40
+	case "kb":
+		mult = 1e3
+	case "mb":
+		mult = 1e6
+	case "gb":
+		mult = 1e9
+	case "tb":
+		mult = 1e12
+	default:
+		return 0, errors.Newf("unknown size unit in %q", s)
+	}
+	return uint64(num * mult), nil
+}
+
+// resourceLimitArgs returns the container runtime arguments for
+// the resource limits configured for the cluster's app.
+func resourceLimitArgs(id sqldb.ClusterID) []string {
+	cfg, err := userconfig.ForApp(id.NS.App.Root()).Get()
+	if err != nil {
+		log.Warn().Err(err).Msg("unable to read config, not applying container resource limits")
+		return nil
+	}
+
+	var args []string
+	if cfg.InfraCPUs != "" {
+		args = append(args, "--cpus", cfg.InfraCPUs)
+	}
+	if cfg.InfraMemory != "" {
+		// Set the swap limit to the same value to disable swapping,
+		// which would otherwise slow down the host as much as the memory usage itself.
+		args = append(args, "--memory", cfg.InfraMemory, "--memory-swap", cfg.InfraMemory)
+	}
+	return args
+}
+
 func (d *Driver) Meta() sqldb.DriverMeta {
 	return sqldb.DriverMeta{ClusterIsolation: true}
 }
@@ -358,9 +501,9 @@ func containerNames(id sqldb.ClusterID) []string {
 	return names
 }
 
-// ImageExists reports whether the docker image exists.
-func ImageExists(ctx context.Context) (ok bool, err error) {
-	out, err := exec.CommandContext(ctx, "docker", "image", "inspect", Image).CombinedOutput()
+// imageExists reports whether the docker image exists.
+func (d *Driver) imageExists(ctx context.Context) (ok bool, err error) {
+	out, err := d.runtime().Command(ctx, "image", "inspect", d.image()).CombinedOutput()
 	switch {
 	case err == nil:
 		return true, nil
@@ -370,13 +513,13 @@ func ImageExists(ctx context.Context) (ok bool, err error) {
 	case bytes.Contains(out, []byte("failed to find image")):
 		return false, nil
 	default:
-		return false, errors.WithStack(errors.Wrapf(err, "docker image inspect failed: %s", Image))
+		return false, errors.WithStack(errors.Wrapf(err, "%s image inspect failed: %s", d.runtime().Name(), Image))
 	}
 }
 
-// PullImage pulls the image.
-func PullImage(ctx context.Context) error {
-	cmd := exec.CommandContext(ctx, "docker", "pull", Image)
+// pullImage pulls the image.
+func (d *Driver) pullImage(ctx context.Context) error {
+	cmd := d.runtime().Command(ctx, "pull", d.image())
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
@@ -384,9 +527,13 @@ func PullImage(ctx context.Context) error {
 
 const Image = "encoredotdev/postgres:15"
 
-func isDockerRunning(ctx context.Context) bool {
-	err := exec.CommandContext(ctx, "docker", "info").Run()
-	return err == nil
+// image returns the name of the image to use with the container runtime.
+func (d *Driver) image() string {
+	// Podman doesn't necessarily resolve short image names to Docker Hub.
+	if d.runtime().Name() == "podman" {
+		return "docker.io/" + Image
+	}
+	return Image
 }
 
 // clusterVolumeNames reports the candidate names for the docker volume.
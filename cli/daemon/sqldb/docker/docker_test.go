@@ -0,0 +1,39 @@
+package docker
+
+import (
+	"testing"
+
+	"encr.dev/cli/daemon/sqldb"
+)
+
+func TestParseStats(t *testing.T) {
+	tests := []struct {
+		out  string
+		want sqldb.ResourceUsage
+	}{
+		{
+			out:  "1.25%\t20MiB / 1GiB\n",
+			want: sqldb.ResourceUsage{CPUPercent: 1.25, MemoryBytes: 20 << 20, MemoryLimitBytes: 1 << 30},
+		},
+		{
+			out:  "150.00%\t512kB / 2GB",
+			want: sqldb.ResourceUsage{CPUPercent: 150, MemoryBytes: 512e3, MemoryLimitBytes: 2e9},
+		},
+		{
+			out:  "0.00%\t0B / 7.5GiB",
+			want: sqldb.ResourceUsage{CPUPercent: 0, MemoryBytes: 0, MemoryLimitBytes: 7.5 * (1 << 30)},
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseStats(tt.out)
+		if err != nil {
+			t.Errorf("parseStats(%q): %v", tt.out, err)
+		} else if *got != tt.want {
+			t.Errorf("parseStats(%q) = %+v, want %+v", tt.out, *got, tt.want)
+		}
+	}
+
+	if _, err := parseStats("garbage"); err == nil {
+		t.Errorf("parseStats(%q): want error", "garbage")
+	}
+}
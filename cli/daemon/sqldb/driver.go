@@ -34,6 +34,10 @@ type Driver interface {
 	// ClusterStatus reports the current status of a cluster.
 	ClusterStatus(ctx context.Context, id ClusterID) (*ClusterStatus, error)
 
+	// ResourceUsage reports the current resource usage of a running cluster.
+	// If a Driver doesn't support reporting resource usage it reports ErrUnsupported.
+	ResourceUsage(ctx context.Context, id ClusterID) (*ResourceUsage, error)
+
 	// CheckRequirements checks whether all the requirements are met
 	// to use the driver.
 	CheckRequirements(ctx context.Context) error
@@ -102,6 +106,20 @@ const (
 	NotFound Status = "notfound"
 )
 
+// ResourceUsage describes the resources used by a cluster.
+type ResourceUsage struct {
+	// CPUPercent is the CPU usage as a percentage of a single CPU.
+	// It can exceed 100 when the cluster uses multiple CPUs.
+	CPUPercent float64
+
+	// MemoryBytes is the amount of memory used, in bytes.
+	MemoryBytes uint64
+
+	// MemoryLimitBytes is the memory limit of the cluster, in bytes.
+	// If the cluster has no limit it's the total memory available to it.
+	MemoryLimitBytes uint64
+}
+
 // ClusterStatus represents the status of a database cluster.
 type ClusterStatus struct {
 	// Status is the status of the underlying container.
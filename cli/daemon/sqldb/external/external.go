@@ -56,6 +56,10 @@ func (d *Driver) CheckRequirements(ctx context.Context) error {
 	return nil
 }
 
+func (d *Driver) ResourceUsage(ctx context.Context, id sqldb.ClusterID) (*sqldb.ResourceUsage, error) {
+	return nil, sqldb.ErrUnsupported
+}
+
 func (d *Driver) Meta() sqldb.DriverMeta {
 	return sqldb.DriverMeta{ClusterIsolation: false}
 }
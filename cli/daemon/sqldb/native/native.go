@@ -0,0 +1,369 @@
+// Package native implements a cluster driver that runs PostgreSQL as a local process,
+// using an existing PostgreSQL installation. It's used on machines where Docker isn't available.
+//
+// SQL databases are the only local infrastructure that needs a driver like this:
+// caches, Pub/Sub and object storage always run in-process in the daemon.
+package native
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/sqldb"
+)
+
+const (
+	DefaultSuperuserUsername = "postgres"
+	DefaultSuperuserPassword = "postgres"
+	DefaultRootDatabase      = "postgres"
+)
+
+// Driver runs a single PostgreSQL server shared by all clusters,
+// with databases prefixed by the cluster id to keep them apart.
+//
+// The server keeps running when the daemon exits, like the Docker containers
+// used by the docker driver, and is reused when the daemon starts again.
+type Driver struct {
+	// BinDir is the directory containing the PostgreSQL binaries (initdb and pg_ctl).
+	BinDir string
+
+	// DataDir is the directory to store the database data in.
+	DataDir string
+
+	mu sync.Mutex // held while starting the server
+}
+
+var _ sqldb.Driver = (*Driver)(nil)
+
+// FindBinDir finds the directory containing the PostgreSQL binaries.
+// It uses ENCORE_POSTGRES_BIN if set, and otherwise looks up initdb in $PATH.
+func FindBinDir() (dir string, ok bool) {
+	if dir := os.Getenv("ENCORE_POSTGRES_BIN"); dir != "" {
+		return dir, true
+	}
+	if p, err := exec.LookPath("initdb"); err == nil {
+		return filepath.Dir(p), true
+	}
+	return "", false
+}
+
+// FindBinary finds the given PostgreSQL binary, like "psql".
+func FindBinary(name string) (path string, ok bool) {
+	if dir, ok := FindBinDir(); ok {
+		if p, err := exec.LookPath(filepath.Join(dir, name)); err == nil {
+			return p, true
+		}
+	}
+	if p, err := exec.LookPath(name); err == nil {
+		return p, true
+	}
+	return "", false
+}
+
+func (d *Driver) CreateCluster(ctx context.Context, p *sqldb.CreateParams, log zerolog.Logger) (*sqldb.ClusterStatus, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	status, err := d.ClusterStatus(ctx, p.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch status.Status {
+	case sqldb.Running:
+		log.Debug().Str("hostport", status.Config.Host).Msg("cluster already running")
+		return status, nil
+
+	case sqldb.NotFound:
+		log.Debug().Str("data_dir", d.DataDir).Msg("cluster not found, initializing data directory")
+		initOp := p.Tracker.Add("Initializing PostgreSQL data directory", time.Now())
+		if err := d.initDataDir(ctx); err != nil {
+			p.Tracker.Fail(initOp, err)
+			return nil, errors.Wrap(err, "initialize data directory")
+		}
+		p.Tracker.Done(initOp, 0)
+	}
+
+	log.Debug().Msg("starting cluster")
+	if err := d.start(ctx); err != nil {
+		return nil, errors.Wrap(err, "start postgres")
+	}
+
+	status, err = d.ClusterStatus(ctx, p.ClusterID)
+	if err != nil {
+		return nil, err
+	} else if status.Status != sqldb.Running {
+		return nil, errors.New("postgres did not start")
+	}
+
+	// Make sure we can connect.
+	uri := status.ConnURI(status.Config.RootDatabase, status.Config.Superuser)
+	connCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	conn, err := pgx.Connect(connCtx, uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "database did not come up")
+	}
+	_ = conn.Close(ctx)
+
+	log.Debug().Str("hostport", status.Config.Host).Msg("cluster started")
+	return status, nil
+}
+
+func (d *Driver) ClusterStatus(ctx context.Context, id sqldb.ClusterID) (*sqldb.ClusterStatus, error) {
+	if _, err := os.Stat(filepath.Join(d.DataDir, "PG_VERSION")); errors.Is(err, os.ErrNotExist) {
+		return &sqldb.ClusterStatus{Status: sqldb.NotFound}, nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	status := &sqldb.ClusterStatus{Status: sqldb.Stopped, Config: &sqldb.ConnConfig{
+		Superuser: sqldb.Role{
+			Type:     sqldb.RoleSuperuser,
+			Username: DefaultSuperuserUsername,
+			Password: DefaultSuperuserPassword,
+		},
+		RootDatabase: DefaultRootDatabase,
+	}}
+
+	// The server is running if its pid file exists and it accepts connections on the port in it.
+	data, err := os.ReadFile(filepath.Join(d.DataDir, "postmaster.pid"))
+	if errors.Is(err, os.ErrNotExist) {
+		return status, nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	port, ok := parsePostmasterPort(data)
+	if !ok {
+		return status, nil
+	}
+	hostPort := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	if conn, err := net.DialTimeout("tcp", hostPort, time.Second); err == nil {
+		_ = conn.Close()
+		status.Status = sqldb.Running
+		status.Config.Host = hostPort
+	}
+	return status, nil
+}
+
+func (d *Driver) CheckRequirements(ctx context.Context) error {
+	if _, err := exec.LookPath(d.bin("pg_ctl")); err != nil {
+		return errors.Newf("This application requires PostgreSQL since it uses an SQL database, "+
+			"but no PostgreSQL installation was found in %s. Install PostgreSQL or set ENCORE_POSTGRES_BIN first.", d.BinDir)
+	}
+	return nil
+}
+
+func (d *Driver) CanDestroyCluster(ctx context.Context, id sqldb.ClusterID) error {
+	return nil
+}
+
+func (d *Driver) DestroyCluster(ctx context.Context, id sqldb.ClusterID) error {
+	return d.dropDatabases(ctx, id.NS, id.Type)
+}
+
+func (d *Driver) DestroyNamespaceData(ctx context.Context, ns *namespace.Namespace) error {
+	return d.dropDatabases(ctx, ns, sqldb.Run, sqldb.Test, sqldb.Shadow)
+}
+
+func (d *Driver) ResourceUsage(ctx context.Context, id sqldb.ClusterID) (*sqldb.ResourceUsage, error) {
+	return nil, sqldb.ErrUnsupported
+}
+
+func (d *Driver) Meta() sqldb.DriverMeta {
+	return sqldb.DriverMeta{ClusterIsolation: false}
+}
+
+// initDataDir initializes a new PostgreSQL data directory.
+func (d *Driver) initDataDir(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(d.DataDir), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// initdb reads the superuser password from a file.
+	pwFile, err := os.CreateTemp("", "encore-pgpass")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = os.Remove(pwFile.Name()) }()
+	if _, err := pwFile.WriteString(DefaultSuperuserPassword); err != nil {
+		_ = pwFile.Close()
+		return errors.WithStack(err)
+	} else if err := pwFile.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	out, err := exec.CommandContext(ctx, d.bin("initdb"),
+		"-D", d.DataDir,
+		"-U", DefaultSuperuserUsername,
+		"--pwfile="+pwFile.Name(),
+		"--auth=scram-sha-256",
+		"--encoding=UTF8",
+		"--locale=C",
+	).CombinedOutput()
+	if err != nil {
+		// Remove the partially initialized data directory so we try again next time.
+		_ = os.RemoveAll(d.DataDir)
+		return errors.Wrapf(err, "initdb failed: %s", out)
+	}
+
+	// Only listen on localhost, and not on a unix socket since
+	// the default socket directory may not be writable.
+	conf := "\n# Added by Encore\nlisten_addresses = '127.0.0.1'\n"
+	if runtime.GOOS != "windows" {
+		conf += "unix_socket_directories = ''\n"
+	}
+	f, err := os.OpenFile(filepath.Join(d.DataDir, "postgresql.conf"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := f.WriteString(conf); err != nil {
+		_ = f.Close()
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(f.Close())
+}
+
+// start starts the PostgreSQL server on a free port and waits for it to accept connections.
+func (d *Driver) start(ctx context.Context) error {
+	port, err := freePort()
+	if err != nil {
+		return errors.Wrap(err, "find free port")
+	}
+
+	// Start the server using pg_ctl so it runs in the background,
+	// and on Windows drops administrative privileges as required by postgres.
+	cmd := exec.CommandContext(ctx, d.bin("pg_ctl"),
+		"start",
+		"-D", d.DataDir,
+		"-l", filepath.Join(d.DataDir, "encore-postgres.log"),
+		"-o", fmt.Sprintf("-p %d", port),
+		"-w", "-t", "60",
+	)
+	// Don't let the server inherit the daemon's stdout and stderr.
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "pg_ctl start failed: %s", out.String())
+	}
+	return nil
+}
+
+// Stop stops the PostgreSQL server, if it's running.
+func (d *Driver) Stop(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	status, err := d.ClusterStatus(ctx, sqldb.ClusterID{})
+	if err != nil {
+		return err
+	} else if status.Status != sqldb.Running {
+		return nil
+	}
+
+	out, err := exec.CommandContext(ctx, d.bin("pg_ctl"),
+		"stop",
+		"-D", d.DataDir,
+		"-m", "fast",
+		"-w", "-t", "60",
+	).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "pg_ctl stop failed: %s", out)
+	}
+	return nil
+}
+
+// dropDatabases drops the databases belonging to the given namespace's clusters of the given types.
+func (d *Driver) dropDatabases(ctx context.Context, ns *namespace.Namespace, types ...sqldb.ClusterType) error {
+	status, err := d.ClusterStatus(ctx, sqldb.ClusterID{NS: ns})
+	if err != nil {
+		return err
+	} else if status.Status != sqldb.Running {
+		// Nothing to drop if the server isn't running; the databases
+		// are dropped the next time the namespace is deleted or reset.
+		return nil
+	}
+
+	conn, err := pgx.Connect(ctx, status.ConnURI(status.Config.RootDatabase, status.Config.Superuser))
+	if err != nil {
+		return errors.Wrap(err, "connect to postgres")
+	}
+	defer func() { _ = conn.Close(ctx) }()
+
+	rows, err := conn.Query(ctx, "SELECT datname FROM pg_database WHERE NOT datistemplate")
+	if err != nil {
+		return errors.Wrap(err, "list databases")
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return errors.Wrap(err, "list databases")
+	}
+
+	for _, name := range names {
+		for _, typ := range types {
+			if strings.HasSuffix(name, databaseSuffix(ns, typ)) {
+				_, err := conn.Exec(ctx, "DROP DATABASE "+pgx.Identifier{name}.Sanitize()+" WITH (FORCE)")
+				if err != nil {
+					return errors.Wrapf(err, "drop database %s", name)
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// databaseSuffix returns the suffix of the database names for a cluster,
+// matching the database names computed by (*sqldb.Cluster).initDB
+// for drivers without cluster isolation.
+func databaseSuffix(ns *namespace.Namespace, typ sqldb.ClusterType) string {
+	suffix := fmt.Sprintf("-%s-%s", ns.App.PlatformOrLocalID(), typ)
+	if ns.Name != "default" {
+		suffix += "-" + string(ns.ID)
+	}
+	return suffix
+}
+
+func (d *Driver) bin(name string) string {
+	return filepath.Join(d.BinDir, name)
+}
+
+// parsePostmasterPort parses the port from the contents of a postmaster.pid file,
+// which has the port on its fourth line.
+func parsePostmasterPort(data []byte) (port int, ok bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		if line == 4 {
+			port, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+			return port, err == nil && port > 0
+		}
+	}
+	return 0, false
+}
+
+// freePort returns a free TCP port on localhost.
+func freePort() (int, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = ln.Close() }()
+	return ln.Addr().(*net.TCPAddr).Port, nil
+}
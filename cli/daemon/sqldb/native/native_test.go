@@ -0,0 +1,149 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"encr.dev/cli/daemon/sqldb"
+)
+
+func TestParsePostmasterPort(t *testing.T) {
+	tests := []struct {
+		data   string
+		want   int
+		wantOK bool
+	}{
+		{data: "1234\n/data\n1700000000\n5433\n\n127.0.0.1\n", want: 5433, wantOK: true},
+		{data: "1234\r\n/data\r\n1700000000\r\n5432\r\n", want: 5432, wantOK: true},
+		{data: "1234\n/data\n1700000000\n", wantOK: false},
+		{data: "1234\n/data\n1700000000\nnope\n", wantOK: false},
+		{data: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := parsePostmasterPort([]byte(tt.data))
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("parsePostmasterPort(%q) = %d, %v, want %d, %v", tt.data, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestClusterStatus(t *testing.T) {
+	ctx := context.Background()
+	d := &Driver{DataDir: filepath.Join(t.TempDir(), "postgres")}
+	status := func() sqldb.Status {
+		t.Helper()
+		s, err := d.ClusterStatus(ctx, sqldb.ClusterID{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s.Status
+	}
+	writeFile := func(name, data string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(d.DataDir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := status(); got != sqldb.NotFound {
+		t.Fatalf("got status %s without a data directory, want %s", got, sqldb.NotFound)
+	}
+
+	if err := os.MkdirAll(d.DataDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile("PG_VERSION", "16\n")
+	if got := status(); got != sqldb.Stopped {
+		t.Fatalf("got status %s without a pid file, want %s", got, sqldb.Stopped)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	writeFile("postmaster.pid", fmt.Sprintf("1234\n%s\n1700000000\n%d\n", d.DataDir, port))
+
+	s, err := d.ClusterStatus(ctx, sqldb.ClusterID{})
+	if err != nil {
+		t.Fatal(err)
+	} else if s.Status != sqldb.Running {
+		t.Fatalf("got status %s with a server listening, want %s", s.Status, sqldb.Running)
+	} else if want := ln.Addr().String(); s.Config.Host != want {
+		t.Errorf("got host %q, want %q", s.Config.Host, want)
+	}
+
+	// A stale pid file left behind by a server that's no longer running.
+	_ = ln.Close()
+	if got := status(); got != sqldb.Stopped {
+		t.Fatalf("got status %s with a stale pid file, want %s", got, sqldb.Stopped)
+	}
+}
+
+func TestDriver_StartStop(t *testing.T) {
+	binDir, ok := FindBinDir()
+	if !ok {
+		t.Skip("PostgreSQL is not installed")
+	} else if runtime.GOOS != "windows" && os.Geteuid() == 0 {
+		t.Skip("PostgreSQL cannot run as root")
+	}
+
+	ctx := context.Background()
+	d := &Driver{BinDir: binDir, DataDir: filepath.Join(t.TempDir(), "postgres")}
+	if err := d.CheckRequirements(ctx); err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { _ = d.Stop(context.Background()) })
+
+	start := func() *sqldb.ClusterStatus {
+		t.Helper()
+		status, err := d.CreateCluster(ctx, &sqldb.CreateParams{}, zerolog.Nop())
+		if err != nil {
+			t.Fatal(err)
+		} else if status.Status != sqldb.Running {
+			t.Fatalf("got status %s, want %s", status.Status, sqldb.Running)
+		}
+
+		conn, err := pgx.Connect(ctx, status.ConnURI(status.Config.RootDatabase, status.Config.Superuser))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = conn.Close(ctx) }()
+		var one int
+		if err := conn.QueryRow(ctx, "SELECT 1").Scan(&one); err != nil || one != 1 {
+			t.Fatalf("got %d, %v, want 1", one, err)
+		}
+		return status
+	}
+
+	first := start()
+
+	// Creating the cluster again reuses the running server.
+	if again := start(); again.Config.Host != first.Config.Host {
+		t.Errorf("got host %q, want the running server at %q", again.Config.Host, first.Config.Host)
+	}
+
+	if err := d.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	status, err := d.ClusterStatus(ctx, sqldb.ClusterID{})
+	if err != nil {
+		t.Fatal(err)
+	} else if status.Status != sqldb.Stopped {
+		t.Fatalf("got status %s after stopping, want %s", status.Status, sqldb.Stopped)
+	}
+	if err := d.Stop(ctx); err != nil {
+		t.Errorf("stopping a stopped server: %v", err)
+	}
+
+	// The existing data directory is reused when starting again.
+	start()
+}
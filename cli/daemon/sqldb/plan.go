@@ -0,0 +1,273 @@
+package sqldb
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgx/v5"
+
+	"encr.dev/pkg/fns"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// MigrationPlan describes the migrations that would be applied to a database,
+// without applying them.
+type MigrationPlan struct {
+	// Exists reports whether the database exists.
+	// If it does not, all migrations are pending.
+	Exists bool
+
+	// Pending are the migrations that would be applied, in order.
+	Pending []*PlannedMigration
+
+	// Drift describes ways in which the applied migrations
+	// differ from the migrations in the application.
+	Drift []string
+}
+
+// PlannedMigration is a migration that is pending to be applied.
+type PlannedMigration struct {
+	*meta.DBMigration
+
+	// Destructive lists the destructive statements in the migration, if any.
+	Destructive []string
+
+	// Validated reports whether the migration was applied in a dry run.
+	Validated bool
+
+	// ValidationErr is the error reported when applying the migration
+	// in a dry run, if any.
+	ValidationErr error
+}
+
+// PlanMigrations computes the migration plan for the database that conn is connected to.
+//
+// If validate is true the pending migrations are applied inside a transaction
+// that is always rolled back, to validate them against the live schema.
+func PlanMigrations(ctx context.Context, conn *sql.Conn, reader MigrationReader, dbMeta *meta.SQLDatabase, validate bool) (*MigrationPlan, error) {
+	applied, err := LoadAppliedVersions(ctx, conn, "public", "schema_migrations")
+	if err != nil {
+		return nil, errors.Wrap(err, "load applied migrations")
+	}
+
+	pending, drift := computeMigrationPlan(dbMeta.Migrations, applied, dbMeta.AllowNonSequentialMigrations)
+	plan := &MigrationPlan{Exists: true, Drift: drift}
+	for _, m := range pending {
+		pm, err := readPlannedMigration(reader, m)
+		if err != nil {
+			return nil, err
+		}
+		plan.Pending = append(plan.Pending, pm)
+	}
+
+	if validate && len(plan.Pending) > 0 {
+		if err := validateMigrations(ctx, conn, reader, plan.Pending); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// PlanAllMigrations computes the migration plan for a database that does not exist yet,
+// where all migrations are pending.
+func PlanAllMigrations(reader MigrationReader, dbMeta *meta.SQLDatabase) (*MigrationPlan, error) {
+	plan := &MigrationPlan{Exists: false}
+	for _, m := range dbMeta.Migrations {
+		pm, err := readPlannedMigration(reader, m)
+		if err != nil {
+			return nil, err
+		}
+		plan.Pending = append(plan.Pending, pm)
+	}
+	return plan, nil
+}
+
+// computeMigrationPlan determines which migrations are pending given the
+// applied versions, as recorded in the schema_migrations table, and describes
+// any drift between the applied versions and the migrations.
+//
+// For sequential migrations the schema_migrations table only records the
+// current version, and only migrations with a higher number are applied.
+func computeMigrationPlan(migrations []*meta.DBMigration, applied map[uint64]bool, allowNonSeq bool) (pending []*meta.DBMigration, drift []string) {
+	migrations = slices.Clone(migrations)
+	slices.SortFunc(migrations, func(a, b *meta.DBMigration) int {
+		return cmp.Compare(a.Number, b.Number)
+	})
+	known := make(map[uint64]bool, len(migrations))
+	for _, m := range migrations {
+		known[m.Number] = true
+	}
+
+	versions := fns.MapKeys(applied)
+	slices.Sort(versions)
+	for _, v := range versions {
+		if applied[v] {
+			drift = append(drift, fmt.Sprintf("migration %d is marked dirty: it failed to apply and will be retried", v))
+		}
+		if !known[v] {
+			drift = append(drift, fmt.Sprintf("migration %d has been applied but no longer exists in the application", v))
+		}
+	}
+
+	if allowNonSeq {
+		for _, m := range migrations {
+			if _, ok := applied[m.Number]; !ok {
+				pending = append(pending, m)
+			}
+		}
+		return pending, drift
+	}
+
+	// Sequential migrations: everything after the current version is pending.
+	var curr uint64
+	hasCurr := len(versions) > 0
+	if hasCurr {
+		curr = versions[len(versions)-1]
+	}
+	for _, m := range migrations {
+		switch {
+		case !hasCurr || m.Number > curr:
+			pending = append(pending, m)
+		case m.Number == curr && applied[curr]:
+			// The current version is dirty, so it will be re-applied.
+			pending = append(pending, m)
+		}
+	}
+	return pending, drift
+}
+
+// destructiveStmt matches SQL statements that may result in data loss.
+var destructiveStmt = regexp.MustCompile(`(?is)^(DROP|TRUNCATE|DELETE\s+FROM|ALTER\s+TABLE\s.*\s(DROP|ALTER\s+COLUMN\s+\S+\s+(SET\s+DATA\s+)?TYPE))\s`)
+
+// txControlStmt matches statements that manage transactions,
+// which would interfere with the dry run transaction.
+var txControlStmt = regexp.MustCompile(`(?im)^\s*(BEGIN|COMMIT|END|ROLLBACK|START\s+TRANSACTION)\s*(TRANSACTION\s*|WORK\s*)?;`)
+
+// sqlLineComment matches single-line SQL comments.
+var sqlLineComment = regexp.MustCompile(`--[^\n]*`)
+
+func readPlannedMigration(reader MigrationReader, m *meta.DBMigration) (*PlannedMigration, error) {
+	data, err := readMigration(reader, m)
+	if err != nil {
+		return nil, err
+	}
+
+	pm := &PlannedMigration{DBMigration: m}
+	pm.Destructive = destructiveStatements(data)
+	return pm, nil
+}
+
+// destructiveStatements returns the statements in the given SQL that may result in data loss,
+// with whitespace normalized. The statements are split naively on semicolons.
+func destructiveStatements(sql string) []string {
+	var stmts []string
+	for _, stmt := range strings.Split(sqlLineComment.ReplaceAllString(sql, ""), ";") {
+		stmt = strings.Join(strings.Fields(stmt), " ")
+		if destructiveStmt.MatchString(stmt + " ") {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+func readMigration(reader MigrationReader, m *meta.DBMigration) (string, error) {
+	r, err := reader.Read(m)
+	if err != nil {
+		return "", errors.Wrapf(err, "read migration %s", m.Filename)
+	}
+	defer fns.CloseIgnore(r)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", errors.Wrapf(err, "read migration %s", m.Filename)
+	}
+	return string(data), nil
+}
+
+// validateMigrations applies the given migrations in order inside a transaction
+// that is always rolled back, recording the outcome on each migration.
+// Validation stops at the first migration that fails to apply.
+func validateMigrations(ctx context.Context, conn *sql.Conn, reader MigrationReader, pending []*PlannedMigration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin dry run transaction")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, m := range pending {
+		data, err := readMigration(reader, m.DBMigration)
+		if err != nil {
+			return err
+		}
+		if txControlStmt.MatchString(data) {
+			m.ValidationErr = errors.New("migration manages its own transaction and cannot be validated in a dry run")
+			break
+		}
+
+		m.Validated = true
+		if _, err := tx.ExecContext(ctx, data); err != nil {
+			m.ValidationErr = err
+			break
+		}
+	}
+	return nil
+}
+
+// PlanMigrations computes the migration plan for the given database in the cluster,
+// without applying any migrations.
+func (c *Cluster) PlanMigrations(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabase, validate bool) (*MigrationPlan, error) {
+	var reader MigrationReader
+	if dbMeta.MigrationRelPath != nil {
+		reader = NewOsMigrationReader(filepath.Join(appRoot, *dbMeta.MigrationRelPath))
+	}
+
+	c.mu.Lock()
+	db, ok := c.dbs[dbMeta.Name]
+	if !ok {
+		db = c.initDB(dbMeta.Name)
+	}
+	c.mu.Unlock()
+
+	// Check if the database exists, without creating it.
+	adm, err := db.connectSuperuser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var dummy int
+	err = adm.QueryRow(ctx, "SELECT 1 FROM pg_database WHERE datname = $1", db.ApplicationCloudName()).Scan(&dummy)
+	_ = adm.Close(context.Background())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return PlanAllMigrations(reader, dbMeta)
+	} else if err != nil {
+		return nil, err
+	}
+
+	info, err := c.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	admin, ok := info.Encore.First(RoleAdmin, RoleSuperuser)
+	if !ok {
+		return nil, errors.New("unable to find superuser or admin roles")
+	}
+
+	pool, err := sql.Open("pgx", info.ConnURI(db.ApplicationCloudName(), admin))
+	if err != nil {
+		return nil, err
+	}
+	defer fns.CloseIgnore(pool)
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to postgres")
+	}
+	defer fns.CloseIgnore(conn)
+
+	return PlanMigrations(ctx, conn, reader, dbMeta, validate)
+}
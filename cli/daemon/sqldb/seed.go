@@ -0,0 +1,127 @@
+package sqldb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/fns"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// seedsTable is the table tracking which seed files have been applied to a database.
+const seedsTable = "encore_seeds"
+
+// Seed sets up and migrates the database and applies its SQL seed files
+// that have not yet been applied. If force is true all seed files are
+// applied again, including previously applied ones.
+func (c *Cluster) Seed(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabase, force bool) error {
+	if c.IsExternalDB(dbMeta.Name) {
+		return errors.Newf("cannot seed %q: seeding external databases is disabled", dbMeta.Name)
+	}
+
+	c.mu.Lock()
+	db, ok := c.dbs[dbMeta.Name]
+	if !ok {
+		db = c.initDB(dbMeta.Name)
+	}
+	c.mu.Unlock()
+
+	// Setting up the database applies any pending seeds.
+	if err := db.Setup(ctx, appRoot, dbMeta, true, false); err != nil {
+		return err
+	}
+	if force {
+		_, err := db.doSeed(ctx, db.ApplicationCloudName(), appRoot, dbMeta, true)
+		return err
+	}
+	return nil
+}
+
+func (db *DB) doSeed(ctx context.Context, cloudName, appRoot string, dbMeta *meta.SQLDatabase, force bool) (applied []string, err error) {
+	if db.Cluster.ID.Type == Shadow {
+		return nil, nil
+	} else if dbMeta.SeedRelPath == nil || len(dbMeta.SeedFiles) == 0 {
+		return nil, nil
+	}
+
+	pool, err := db.openAdmin(ctx, cloudName)
+	if err != nil {
+		return nil, err
+	}
+	defer fns.CloseIgnore(pool)
+
+	_, err = pool.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS `+seedsTable+` (
+			filename TEXT PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL
+		)
+	`)
+	if err != nil {
+		return nil, errors.Wrap(err, "create seeds table")
+	}
+
+	done := make(map[string]bool)
+	if !force {
+		rows, err := pool.QueryContext(ctx, "SELECT filename FROM "+seedsTable)
+		if err != nil {
+			return nil, errors.Wrap(err, "list applied seeds")
+		}
+		defer fns.CloseIgnore(rows)
+		for rows.Next() {
+			var filename string
+			if err := rows.Scan(&filename); err != nil {
+				return nil, errors.Wrap(err, "list applied seeds")
+			}
+			done[filename] = true
+		}
+		if err := rows.Err(); err != nil {
+			return nil, errors.Wrap(err, "list applied seeds")
+		}
+	}
+
+	dir := filepath.Join(appRoot, filepath.FromSlash(*dbMeta.SeedRelPath))
+	for _, filename := range pendingSeeds(dbMeta.SeedFiles, done) {
+		data, err := os.ReadFile(filepath.Join(dir, filename))
+		if err != nil {
+			return applied, errors.Wrapf(err, "read seed file %s", filename)
+		}
+
+		db.log.Debug().Str("seed", filename).Msg("applying seed file")
+		tx, err := pool.BeginTx(ctx, nil)
+		if err != nil {
+			return applied, err
+		}
+		_, err = tx.ExecContext(ctx, string(data))
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO `+seedsTable+` (filename, applied_at) VALUES ($1, $2)
+				ON CONFLICT (filename) DO UPDATE SET applied_at = EXCLUDED.applied_at
+			`, filename, time.Now())
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return applied, errors.Wrapf(err, "apply seed file %s", filename)
+		}
+		if err := tx.Commit(); err != nil {
+			return applied, errors.Wrapf(err, "apply seed file %s", filename)
+		}
+		applied = append(applied, filename)
+	}
+
+	if len(applied) > 0 {
+		db.log.Info().Strs("seeds", applied).Msg("applied seed files")
+	}
+	return applied, nil
+}
+
+// pendingSeeds returns the seed files that have not yet been applied, in order.
+func pendingSeeds(files []string, applied map[string]bool) []string {
+	pending := slices.DeleteFunc(slices.Clone(files), func(f string) bool { return applied[f] })
+	slices.Sort(pending)
+	return pending
+}
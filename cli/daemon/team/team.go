@@ -0,0 +1,318 @@
+// Package team implements the daemon's shared mode, where a single
+// long-running daemon on a shared server is used remotely by multiple
+// developers. Each developer authenticates with their own access token,
+// gets their own namespaces, and shares the daemon's infrastructure.
+package team
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// WorkspaceHeader is the gRPC metadata key the CLI uses to report the
+// local directory that corresponds to the user's workspace on the server.
+const WorkspaceHeader = "encore-workspace"
+
+// Config is the shared mode configuration.
+type Config struct {
+	Users []UserConfig `json:"users"`
+}
+
+// UserConfig configures a single developer.
+type UserConfig struct {
+	// Name is the name of the user. It must consist of
+	// lowercase letters, digits and dashes.
+	Name string `json:"name"`
+
+	// TokenSHA256 is the hex-encoded SHA-256 hash of the user's access token.
+	TokenSHA256 string `json:"token_sha256"`
+
+	// Workspace is the directory on the server containing the user's
+	// app checkouts. If set, the user can only use apps within it.
+	Workspace string `json:"workspace,omitempty"`
+}
+
+var userNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// LoadConfig loads and validates the shared mode configuration
+// from the JSON file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read users file: %v", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse users file: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid users file: %v", err)
+	}
+	return &cfg, nil
+}
+
+// Validate reports an error if the configuration is invalid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Users) == 0 {
+		return fmt.Errorf("no users configured")
+	}
+	seen := make(map[string]bool)
+	for _, u := range cfg.Users {
+		if !userNameRe.MatchString(u.Name) {
+			return fmt.Errorf("invalid user name %q: must consist of lowercase letters, digits and dashes", u.Name)
+		} else if seen[u.Name] {
+			return fmt.Errorf("duplicate user %q", u.Name)
+		}
+		seen[u.Name] = true
+
+		if b, err := hex.DecodeString(u.TokenSHA256); err != nil || len(b) != sha256.Size {
+			return fmt.Errorf("user %q: token_sha256 must be a hex-encoded SHA-256 hash", u.Name)
+		}
+		if u.Workspace != "" && !filepath.IsAbs(u.Workspace) {
+			return fmt.Errorf("user %q: workspace must be an absolute path", u.Name)
+		}
+	}
+	return nil
+}
+
+// User is an authenticated user.
+type User struct {
+	Name      string
+	Workspace string // empty if the user is not restricted to a workspace
+
+	tokenHash []byte
+}
+
+// NamespacePrefix is the prefix of the names of the user's namespaces.
+func (u *User) NamespacePrefix() string {
+	return u.Name + "/"
+}
+
+// Namespace returns the name of the user's namespace with the given name.
+func (u *User) Namespace(name string) string {
+	return u.NamespacePrefix() + name
+}
+
+// ResolveAppRoot resolves the app root reported by the user's CLI
+// to a path on the server. If clientWorkspace is non-empty, appRoot is
+// mapped from it to the user's workspace on the server.
+//
+// It reports an error if the user is restricted to a workspace
+// and the app root is outside of it.
+func (u *User) ResolveAppRoot(appRoot, clientWorkspace string) (string, error) {
+	if u.Workspace == "" || appRoot == "" {
+		return appRoot, nil
+	}
+
+	if clientWorkspace != "" {
+		// The client may be running on a different OS, so compare slash-separated paths.
+		rel, ok := relPath(toSlash(clientWorkspace), toSlash(appRoot))
+		if !ok {
+			return "", status.Errorf(codes.PermissionDenied, "app root %s is not within the workspace %s", appRoot, clientWorkspace)
+		}
+		appRoot = filepath.Join(u.Workspace, filepath.FromSlash(rel))
+	}
+
+	if _, ok := relPath(filepath.ToSlash(u.Workspace), filepath.ToSlash(filepath.Clean(appRoot))); !ok {
+		return "", status.Errorf(codes.PermissionDenied, "app root %s is not within your workspace", appRoot)
+	}
+	return appRoot, nil
+}
+
+// relPath reports the path of target relative to base,
+// if target is base or a path within it.
+func relPath(base, target string) (string, bool) {
+	base = strings.TrimSuffix(base, "/")
+	if target == base {
+		return ".", true
+	}
+	rel, ok := strings.CutPrefix(target, base+"/")
+	if !ok || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", false
+	}
+	return rel, true
+}
+
+func toSlash(path string) string {
+	return strings.ReplaceAll(path, `\`, "/")
+}
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx with the authenticated user attached.
+func WithUser(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, userKey{}, u)
+}
+
+// UserFromContext returns the authenticated user attached to ctx, if any.
+// Requests made through the local daemon socket have no user.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	u, ok := ctx.Value(userKey{}).(*User)
+	return u, ok
+}
+
+// AppRootFunc returns the root on the server of the app with the given id.
+type AppRootFunc func(appID string) (string, error)
+
+// Authenticator authenticates requests to the daemon in shared mode.
+type Authenticator struct {
+	users   []*User
+	appRoot AppRootFunc
+}
+
+// NewAuthenticator creates a new Authenticator for the users in cfg.
+// It uses appRoot to authorize requests that refer to apps by id.
+func NewAuthenticator(cfg *Config, appRoot AppRootFunc) *Authenticator {
+	a := &Authenticator{appRoot: appRoot}
+	for _, u := range cfg.Users {
+		hash, _ := hex.DecodeString(u.TokenSHA256)
+		a.users = append(a.users, &User{Name: u.Name, Workspace: u.Workspace, tokenHash: hash})
+	}
+	return a
+}
+
+// Authenticate returns the user with the given access token.
+func (a *Authenticator) Authenticate(token string) (*User, bool) {
+	if token == "" {
+		return nil, false
+	}
+	hash := sha256.Sum256([]byte(token))
+	for _, u := range a.users {
+		if subtle.ConstantTimeCompare(hash[:], u.tokenHash) == 1 {
+			return u, true
+		}
+	}
+	return nil, false
+}
+
+// authenticate authenticates the request in ctx, returning a context
+// with the user attached along with the client's workspace directory.
+func (a *Authenticator) authenticate(ctx context.Context) (context.Context, string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	if vals := md.Get("authorization"); len(vals) > 0 {
+		token, _ = strings.CutPrefix(vals[0], "Bearer ")
+	}
+	u, ok := a.Authenticate(token)
+	if !ok {
+		return nil, "", status.Error(codes.Unauthenticated, "invalid daemon access token")
+	}
+
+	var clientWorkspace string
+	if vals := md.Get(WorkspaceHeader); len(vals) > 0 {
+		clientWorkspace = vals[0]
+	}
+	return WithUser(ctx, u), clientWorkspace, nil
+}
+
+// UnaryInterceptor returns a gRPC interceptor that authenticates
+// unary requests and resolves the app root they refer to.
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, clientWorkspace, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		u, _ := UserFromContext(ctx)
+		if err := a.resolveRequest(u, req, clientWorkspace); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a gRPC interceptor that authenticates
+// streaming requests and resolves the app roots they refer to.
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, clientWorkspace, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		u, _ := UserFromContext(ctx)
+		return handler(srv, &userStream{ServerStream: ss, ctx: ctx, auth: a, user: u, clientWorkspace: clientWorkspace})
+	}
+}
+
+type userStream struct {
+	grpc.ServerStream
+	ctx             context.Context
+	auth            *Authenticator
+	user            *User
+	clientWorkspace string
+}
+
+func (s *userStream) Context() context.Context { return s.ctx }
+
+func (s *userStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.auth.resolveRequest(s.user, m, s.clientWorkspace)
+}
+
+// resolveRequest resolves the "app_root" field of the request message, if any,
+// to the corresponding path on the server, and authorizes the app referred
+// to by its "app_id" field, if any.
+func (a *Authenticator) resolveRequest(u *User, req any, clientWorkspace string) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	r := msg.ProtoReflect()
+
+	if fd := stringField(r, "app_id"); fd != nil {
+		if err := a.authorizeAppID(u, r.Get(fd).String()); err != nil {
+			return err
+		}
+	}
+
+	if fd := stringField(r, "app_root"); fd != nil {
+		root, err := u.ResolveAppRoot(r.Get(fd).String(), clientWorkspace)
+		if err != nil {
+			return err
+		}
+		r.Set(fd, protoreflect.ValueOfString(root))
+	}
+	return nil
+}
+
+// authorizeAppID reports an error if the user is restricted to a workspace
+// and the app with the given id is not within it. Apps the daemon doesn't
+// know about are rejected, as they can't be attributed to a workspace.
+func (a *Authenticator) authorizeAppID(u *User, appID string) error {
+	if u.Workspace == "" || appID == "" {
+		return nil
+	}
+	if a.appRoot != nil {
+		if root, err := a.appRoot(appID); err == nil && root != "" {
+			_, err := u.ResolveAppRoot(root, "")
+			return err
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "app %s is not within your workspace", appID)
+}
+
+// stringField returns the singular string field of r with the given name, if any.
+func stringField(r protoreflect.Message, name protoreflect.Name) protoreflect.FieldDescriptor {
+	fd := r.Descriptor().Fields().ByName(name)
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return nil
+	}
+	return fd
+}
@@ -0,0 +1,164 @@
+package team
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"valid", `{"users": [{"name": "alice", "token_sha256": "` + hashToken("a") + `", "workspace": "/srv/alice"}]}`, false},
+		{"no users", `{"users": []}`, true},
+		{"invalid name", `{"users": [{"name": "Alice", "token_sha256": "` + hashToken("a") + `"}]}`, true},
+		{"duplicate", `{"users": [{"name": "alice", "token_sha256": "` + hashToken("a") + `"}, {"name": "alice", "token_sha256": "` + hashToken("b") + `"}]}`, true},
+		{"invalid hash", `{"users": [{"name": "alice", "token_sha256": "secret"}]}`, true},
+		{"relative workspace", `{"users": [{"name": "alice", "token_sha256": "` + hashToken("a") + `", "workspace": "srv/alice"}]}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "users.json")
+			if err := os.WriteFile(path, []byte(tt.data), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got err %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	auth := NewAuthenticator(&Config{Users: []UserConfig{
+		{Name: "alice", TokenSHA256: hashToken("alice-token")},
+		{Name: "bob", TokenSHA256: hashToken("bob-token")},
+	}}, nil)
+
+	if u, ok := auth.Authenticate("bob-token"); !ok || u.Name != "bob" {
+		t.Errorf("got %v, %v, want bob", u, ok)
+	}
+	for _, token := range []string{"", "carol-token", hashToken("alice-token")} {
+		if u, ok := auth.Authenticate(token); ok {
+			t.Errorf("Authenticate(%q) = %v, want no user", token, u.Name)
+		}
+	}
+}
+
+func TestResolveAppRoot(t *testing.T) {
+	u := &User{Name: "alice", Workspace: "/srv/alice"}
+	tests := []struct {
+		appRoot, clientWorkspace string
+		want                     string
+		wantErr                  bool
+	}{
+		{appRoot: "/srv/alice/app", want: "/srv/alice/app"},
+		{appRoot: "/srv/bob/app", wantErr: true},
+		{appRoot: "/srv/alice/../bob/app", wantErr: true},
+		{appRoot: "/Users/alice/src/app", clientWorkspace: "/Users/alice/src", want: "/srv/alice/app"},
+		{appRoot: `C:\src\app`, clientWorkspace: `C:\src`, want: "/srv/alice/app"},
+		{appRoot: "/Users/alice/other/app", clientWorkspace: "/Users/alice/src", wantErr: true},
+		{appRoot: "/Users/alice/src/../../bob", clientWorkspace: "/Users/alice/src", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := u.ResolveAppRoot(tt.appRoot, tt.clientWorkspace)
+		if tt.wantErr {
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("ResolveAppRoot(%q, %q) = %q, %v, want PermissionDenied", tt.appRoot, tt.clientWorkspace, got, err)
+			}
+		} else if err != nil || got != tt.want {
+			t.Errorf("ResolveAppRoot(%q, %q) = %q, %v, want %q", tt.appRoot, tt.clientWorkspace, got, err, tt.want)
+		}
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	auth := NewAuthenticator(&Config{Users: []UserConfig{
+		{Name: "alice", TokenSHA256: hashToken("alice-token"), Workspace: "/srv/alice"},
+	}}, nil)
+	intercept := auth.UnaryInterceptor()
+
+	var gotUser *User
+	handler := func(ctx context.Context, req any) (any, error) {
+		gotUser, _ = UserFromContext(ctx)
+		return req, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"authorization", "Bearer alice-token",
+		WorkspaceHeader, "/home/alice/src",
+	))
+	req := &daemonpb.CheckRequest{AppRoot: "/home/alice/src/app"}
+	if _, err := intercept(ctx, req, nil, handler); err != nil {
+		t.Fatal(err)
+	}
+	if gotUser == nil || gotUser.Name != "alice" {
+		t.Errorf("got user %v, want alice", gotUser)
+	}
+	if req.AppRoot != "/srv/alice/app" {
+		t.Errorf("got app root %q, want %q", req.AppRoot, "/srv/alice/app")
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong"))
+	if _, err := intercept(ctx, req, nil, handler); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("got err %v, want Unauthenticated", err)
+	}
+}
+
+func TestUnaryInterceptor_AppID(t *testing.T) {
+	roots := map[string]string{
+		"alice-app": "/srv/alice/app",
+		"bob-app":   "/srv/bob/app",
+	}
+	auth := NewAuthenticator(&Config{Users: []UserConfig{
+		{Name: "alice", TokenSHA256: hashToken("alice-token"), Workspace: "/srv/alice"},
+		{Name: "admin", TokenSHA256: hashToken("admin-token")},
+	}}, func(appID string) (string, error) {
+		if root, ok := roots[appID]; ok {
+			return root, nil
+		}
+		return "", errors.New("app not found")
+	})
+	intercept := auth.UnaryInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) { return req, nil }
+
+	tests := []struct {
+		token string
+		appID string
+		want  codes.Code
+	}{
+		{token: "alice-token", appID: "alice-app", want: codes.OK},
+		{token: "alice-token", appID: "bob-app", want: codes.PermissionDenied},
+		{token: "alice-token", appID: "unknown", want: codes.PermissionDenied},
+		{token: "admin-token", appID: "bob-app", want: codes.OK},
+		{token: "admin-token", appID: "unknown", want: codes.OK},
+	}
+	for _, tt := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"authorization", "Bearer "+tt.token,
+		))
+		req := &daemonpb.GenClientRequest{AppId: tt.appID, Lang: "typescript"}
+		if _, err := intercept(ctx, req, nil, handler); status.Code(err) != tt.want {
+			t.Errorf("%s: GenClient(%s): got err %v, want %v", tt.token, tt.appID, err, tt.want)
+		}
+	}
+}
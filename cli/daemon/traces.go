@@ -0,0 +1,222 @@
+package daemon
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	daemonpb "encr.dev/proto/encore/daemon"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// defaultListTraces is the number of traces listed by ListTraces
+// if the request doesn't specify a limit.
+const defaultListTraces = 20
+
+// ListTraces lists the most recent traces recorded for the app,
+// describing the spans of each trace and the operations they made.
+func (s *Server) ListTraces(ctx context.Context, req *daemonpb.ListTracesRequest) (*daemonpb.ListTracesResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultListTraces
+	}
+
+	appID := app.PlatformOrLocalID()
+	q := &trace2.Query{
+		AppID:      appID,
+		TestFilter: new(bool),
+		Limit:      limit * 10, // spans, not traces
+	}
+	if req.From != nil {
+		q.StartTime = req.From.AsTime()
+	}
+
+	// Collect the trace ids first; the store doesn't support
+	// reading traces while listing them.
+	var traceIDs []string
+	seen := make(map[string]bool)
+	err = s.tr.List(ctx, q, func(span *tracepb2.SpanSummary) bool {
+		if !seen[span.TraceId] {
+			seen[span.TraceId] = true
+			traceIDs = append(traceIDs, span.TraceId)
+		}
+		return len(traceIDs) < limit
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list traces: %v", err)
+	}
+
+	traces := make([]*daemonpb.ListTracesResponse_Trace, 0, len(traceIDs))
+	for _, traceID := range traceIDs {
+		var events []*tracepb2.TraceEvent
+		err := s.tr.Get(ctx, appID, traceID, func(ev *tracepb2.TraceEvent) bool {
+			events = append(events, ev)
+			return true
+		})
+		if errors.Is(err, trace2.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, status.Errorf(codes.Internal, "get trace %s: %v", traceID, err)
+		}
+		if tr := describeTrace(traceID, events); tr != nil {
+			traces = append(traces, tr)
+		}
+	}
+
+	slices.SortFunc(traces, func(a, b *daemonpb.ListTracesResponse_Trace) int {
+		return a.StartedAt.AsTime().Compare(b.StartedAt.AsTime())
+	})
+	return &daemonpb.ListTracesResponse{Traces: traces}, nil
+}
+
+// traceSpan describes a span within a single trace.
+type traceSpan struct {
+	id       uint64
+	parentID *uint64
+	span     *daemonpb.ListTracesResponse_Span
+	started  *timestamppb.Timestamp
+	children []*traceSpan
+}
+
+// describeTrace describes the trace with the given id from its events,
+// which may be provided in any order. It reports nil if the trace has no spans.
+func describeTrace(traceID string, events []*tracepb2.TraceEvent) *daemonpb.ListTracesResponse_Trace {
+	// Sort the events so operations are described in order.
+	events = slices.Clone(events)
+	slices.SortStableFunc(events, func(a, b *tracepb2.TraceEvent) int {
+		return cmp.Compare(a.EventId, b.EventId)
+	})
+
+	spans := make(map[uint64]*traceSpan)
+	span := func(id uint64) *traceSpan {
+		sp, ok := spans[id]
+		if !ok {
+			sp = &traceSpan{id: id, span: &daemonpb.ListTracesResponse_Span{}}
+			spans[id] = sp
+		}
+		return sp
+	}
+
+	for _, ev := range events {
+		switch e := ev.Event.(type) {
+		case *tracepb2.TraceEvent_SpanStart:
+			sp := span(ev.SpanId)
+			sp.parentID = e.SpanStart.ParentSpanId
+			sp.started = ev.EventTime
+			switch data := e.SpanStart.Data.(type) {
+			case *tracepb2.SpanStart_Request:
+				sp.span.Kind = daemonpb.ListTracesResponse_Span_REQUEST
+				sp.span.Service = data.Request.ServiceName
+				sp.span.Name = data.Request.EndpointName
+			case *tracepb2.SpanStart_Auth:
+				sp.span.Kind = daemonpb.ListTracesResponse_Span_AUTH
+				sp.span.Service = data.Auth.ServiceName
+				sp.span.Name = data.Auth.EndpointName
+			case *tracepb2.SpanStart_PubsubMessage:
+				sp.span.Kind = daemonpb.ListTracesResponse_Span_PUBSUB_MESSAGE
+				sp.span.Service = data.PubsubMessage.ServiceName
+				sp.span.Name = data.PubsubMessage.TopicName + "/" + data.PubsubMessage.SubscriptionName
+			case *tracepb2.SpanStart_Test:
+				sp.span.Kind = daemonpb.ListTracesResponse_Span_TEST
+				sp.span.Service = data.Test.ServiceName
+				sp.span.Name = data.Test.TestName
+			}
+
+		case *tracepb2.TraceEvent_SpanEnd:
+			sp := span(ev.SpanId)
+			sp.span.DurationNanos = e.SpanEnd.DurationNanos
+			sp.span.IsError = e.SpanEnd.Error != nil
+
+		case *tracepb2.TraceEvent_SpanEvent:
+			if op := describeOperation(e.SpanEvent); op != nil {
+				sp := span(ev.SpanId)
+				sp.span.Operations = append(sp.span.Operations, op)
+			}
+		}
+	}
+
+	// Build the span tree, ordering siblings by when they started.
+	var roots []*traceSpan
+	for _, sp := range spans {
+		if sp.started == nil {
+			// We only have events for spans we know started.
+			continue
+		}
+		if sp.parentID != nil {
+			if parent, ok := spans[*sp.parentID]; ok && parent.started != nil {
+				parent.children = append(parent.children, sp)
+				continue
+			}
+		}
+		roots = append(roots, sp)
+	}
+	if len(roots) == 0 {
+		return nil
+	}
+	byStart := func(a, b *traceSpan) int {
+		return cmp.Or(
+			a.started.AsTime().Compare(b.started.AsTime()),
+			cmp.Compare(a.id, b.id),
+		)
+	}
+	slices.SortFunc(roots, byStart)
+
+	tr := &daemonpb.ListTracesResponse_Trace{
+		TraceId:   traceID,
+		StartedAt: roots[0].started,
+	}
+	var walk func(sp *traceSpan, depth int32)
+	walk = func(sp *traceSpan, depth int32) {
+		sp.span.Depth = depth
+		tr.Spans = append(tr.Spans, sp.span)
+		slices.SortFunc(sp.children, byStart)
+		for _, child := range sp.children {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+	return tr
+}
+
+// describeOperation describes the operation started by ev,
+// or reports nil if ev doesn't start an operation.
+func describeOperation(ev *tracepb2.SpanEvent) *daemonpb.ListTracesResponse_Operation {
+	op := func(kind daemonpb.ListTracesResponse_Operation_Kind, desc string) *daemonpb.ListTracesResponse_Operation {
+		return &daemonpb.ListTracesResponse_Operation{Kind: kind, Desc: desc}
+	}
+
+	switch data := ev.Data.(type) {
+	case *tracepb2.SpanEvent_RpcCallStart:
+		return op(daemonpb.ListTracesResponse_Operation_RPC_CALL,
+			data.RpcCallStart.TargetServiceName+"."+data.RpcCallStart.TargetEndpointName)
+	case *tracepb2.SpanEvent_DbQueryStart:
+		return op(daemonpb.ListTracesResponse_Operation_DB_QUERY,
+			strings.Join(strings.Fields(data.DbQueryStart.Query), " "))
+	case *tracepb2.SpanEvent_PubsubPublishStart:
+		return op(daemonpb.ListTracesResponse_Operation_PUBSUB_PUBLISH, data.PubsubPublishStart.Topic)
+	case *tracepb2.SpanEvent_HttpCallStart:
+		return op(daemonpb.ListTracesResponse_Operation_HTTP_CALL,
+			data.HttpCallStart.Method+" "+data.HttpCallStart.Url)
+	case *tracepb2.SpanEvent_CacheCallStart:
+		return op(daemonpb.ListTracesResponse_Operation_CACHE_CALL,
+			fmt.Sprintf("%s %s", data.CacheCallStart.Operation, strings.Join(data.CacheCallStart.Keys, " ")))
+	case *tracepb2.SpanEvent_LogMessage:
+		return op(daemonpb.ListTracesResponse_Operation_LOG, data.LogMessage.Msg)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/logrusorgru/aurora/v3"
+
+	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/internal/platform"
+	"encr.dev/pkg/tunnel"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// startTunnel exposes the run through a public URL using a tunnel,
+// and keeps the tunnel connected until the run exits.
+// It returns the public URL of the tunnel.
+func (s *Server) startTunnel(ctx context.Context, req *daemonpb.RunRequest, r *run.Run, stderr io.Writer) (string, error) {
+	dial := func(ctx context.Context, tunnelID, reconnectSecret string) (*tunnel.Client, error) {
+		if req.TunnelServer != nil && *req.TunnelServer != "" {
+			return tunnel.Dial(ctx, *req.TunnelServer, req.GetTunnelToken(), tunnelID, reconnectSecret)
+		}
+
+		appSlug := r.App.PlatformID()
+		if appSlug == "" {
+			return nil, errors.New("the app is not linked with the Encore Platform; " +
+				"link it with 'encore app link' or specify a self-hosted tunnel server with --tunnel-server")
+		}
+		ws, err := platform.AppTunnel(ctx, appSlug, tunnelID)
+		if err != nil {
+			return nil, err
+		}
+		return tunnel.NewClient(ws)
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	client, err := dial(dialCtx, "", "")
+	cancel()
+	if err != nil {
+		return "", err
+	}
+
+	// Requests made to the local development server are authenticated as coming
+	// from the Encore Platform, which grants access to private endpoints.
+	// Requests from the tunnel come from the internet and must not be.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req.Header.Set(run.TestHeaderDisablePlatformAuth, "1")
+		r.ServeHTTP(w, req)
+	})
+
+	ctx, cancel = context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-r.Done():
+			cancel()
+		}
+	}()
+
+	go func() {
+		defer cancel()
+		for {
+			err := client.Serve(ctx, handler)
+			if ctx.Err() != nil {
+				return
+			}
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Yellow("Tunnel disconnected (%v), reconnecting..."), err))
+
+			// Reconnect, reusing the same tunnel id to keep the public URL.
+			prevURL := client.PublicURL
+			eb := backoff.NewExponentialBackOff()
+			eb.MaxElapsedTime = 0 // retry until the run exits
+			b := backoff.WithContext(eb, ctx)
+			err = backoff.Retry(func() error {
+				c, err := dial(ctx, client.ID, client.ReconnectSecret)
+				if err == nil {
+					client = c
+				}
+				return err
+			}, b)
+			if err != nil {
+				return
+			}
+
+			if client.PublicURL != prevURL {
+				_, _ = fmt.Fprintf(stderr, "Tunnel reconnected with a new URL: %s\n", aurora.Cyan(client.PublicURL))
+			} else {
+				_, _ = fmt.Fprintln(stderr, "Tunnel reconnected.")
+			}
+		}
+	}()
+
+	return client.PublicURL, nil
+}
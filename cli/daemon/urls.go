@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// URLRegistry reports the local addresses of the running app's
+// services, gateways and infrastructure resources.
+func (s *Server) URLRegistry(ctx context.Context, req *daemonpb.URLRegistryRequest) (*daemonpb.URLRegistryResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	local, err := app.LocalSettings()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	r := s.mgr.FindRunByAppID(app.PlatformOrLocalID())
+	if r == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the app is not running")
+	}
+
+	// withHost replaces the host in addr with the configured hostname, if any.
+	withHost := func(addr string) string {
+		if local.Hostname == "" {
+			return addr
+		}
+		if _, port, err := net.SplitHostPort(addr); err == nil {
+			return net.JoinHostPort(local.Hostname, port)
+		}
+		return addr
+	}
+
+	resp := &daemonpb.URLRegistryResponse{
+		AppId:     app.PlatformOrLocalID(),
+		BaseUrl:   "http://" + withHost(r.ListenAddr),
+		Services:  make(map[string]string),
+		Gateways:  make(map[string]string),
+		Resources: make(map[string]string),
+	}
+
+	if pg := r.ProcGroup(); pg != nil {
+		for name, p := range pg.Services {
+			resp.Services[name] = "http://" + withHost(p.ListenAddr().String())
+		}
+		for name, p := range pg.Gateways {
+			resp.Gateways[name] = "http://" + withHost(p.ListenAddr().String())
+		}
+	}
+
+	rm := r.ResourceManager
+	if cluster := rm.GetSQLCluster(); cluster != nil {
+		resp.Resources["sqldb"] = withHost("localhost:" + strconv.Itoa(s.mgr.DBProxyPort))
+	}
+	if srv := rm.GetRedis(); srv != nil {
+		resp.Resources["redis"] = withHost(srv.Addr())
+	}
+	if nsq := rm.GetPubSub(); nsq != nil {
+		resp.Resources["pubsub"] = withHost(nsq.Addr())
+	}
+	if srv := rm.GetObjects(); srv != nil {
+		endpoint := srv.Endpoint()
+		if u, err := url.Parse(endpoint); err == nil {
+			u.Host = withHost(u.Host)
+			endpoint = u.String()
+		}
+		resp.Resources["objects"] = endpoint
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,193 @@
+package daemon
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	daemonpb "encr.dev/proto/encore/daemon"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// maxUsageTraces is the maximum number of traces considered by UsageReport.
+// It's lower than maxSLOSpans since every trace's events must be read.
+const maxUsageTraces = 10000
+
+// UsageReport aggregates which callers call which endpoints,
+// from the traces recorded for the app within the requested time window.
+//
+// Requests made to the app from the outside are attributed to the
+// user id returned by the auth handler, if any. Requests made by other
+// services are attributed to the calling service.
+func (s *Server) UsageReport(ctx context.Context, req *daemonpb.UsageReportRequest) (*daemonpb.UsageReportResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	appID := app.PlatformOrLocalID()
+	q := &trace2.Query{
+		AppID:      appID,
+		TestFilter: new(bool),
+		Limit:      maxUsageTraces,
+	}
+	if req.From != nil {
+		q.StartTime = req.From.AsTime()
+	}
+	if req.To != nil {
+		q.EndTime = req.To.AsTime()
+	}
+
+	// Collect the trace ids first; the store doesn't support
+	// reading traces while listing them.
+	var traceIDs []string
+	seen := make(map[string]bool)
+	err = s.tr.List(ctx, q, func(span *tracepb2.SpanSummary) bool {
+		if !seen[span.TraceId] {
+			seen[span.TraceId] = true
+			traceIDs = append(traceIDs, span.TraceId)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list traces: %v", err)
+	}
+
+	agg := newUsageAggregator()
+	for _, traceID := range traceIDs {
+		var events []*tracepb2.TraceEvent
+		err := s.tr.Get(ctx, appID, traceID, func(ev *tracepb2.TraceEvent) bool {
+			events = append(events, ev)
+			return true
+		})
+		if errors.Is(err, trace2.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, status.Errorf(codes.Internal, "get trace %s: %v", traceID, err)
+		}
+		agg.addTrace(traceID, events)
+	}
+
+	return &daemonpb.UsageReportResponse{
+		Usages: agg.usages(),
+		Traces: int32(len(traceIDs)),
+	}, nil
+}
+
+type usageKey struct {
+	svc, ep    string
+	callerKind daemonpb.UsageReportResponse_CallerKind
+	caller     string
+}
+
+// usageAggregator aggregates endpoint usage across traces.
+type usageAggregator struct {
+	stats map[usageKey]*daemonpb.UsageReportResponse_EndpointUsage
+}
+
+func newUsageAggregator() *usageAggregator {
+	return &usageAggregator{stats: make(map[usageKey]*daemonpb.UsageReportResponse_EndpointUsage)}
+}
+
+// usageSpan describes a span within a single trace.
+type usageSpan struct {
+	service   string
+	endpoint  string // empty for non-request spans
+	uid       string
+	parentID  *uint64
+	startedAt time.Time
+	isError   bool
+}
+
+// addTrace records the requests made within the trace with the given id.
+// The events may be provided in any order.
+func (a *usageAggregator) addTrace(traceID string, events []*tracepb2.TraceEvent) {
+	spans := make(map[uint64]*usageSpan)
+	span := func(id uint64) *usageSpan {
+		sp, ok := spans[id]
+		if !ok {
+			sp = &usageSpan{}
+			spans[id] = sp
+		}
+		return sp
+	}
+
+	for _, ev := range events {
+		switch e := ev.Event.(type) {
+		case *tracepb2.TraceEvent_SpanStart:
+			sp := span(ev.SpanId)
+			sp.parentID = e.SpanStart.ParentSpanId
+			sp.startedAt = ev.EventTime.AsTime()
+			switch data := e.SpanStart.Data.(type) {
+			case *tracepb2.SpanStart_Request:
+				sp.service = data.Request.ServiceName
+				sp.endpoint = data.Request.EndpointName
+				sp.uid = data.Request.GetUid()
+			case *tracepb2.SpanStart_PubsubMessage:
+				sp.service = data.PubsubMessage.ServiceName
+			case *tracepb2.SpanStart_Auth:
+				sp.service = data.Auth.ServiceName
+			}
+		case *tracepb2.TraceEvent_SpanEnd:
+			span(ev.SpanId).isError = e.SpanEnd.Error != nil
+		}
+	}
+
+	for _, sp := range spans {
+		if sp.endpoint == "" {
+			continue
+		}
+
+		key := usageKey{svc: sp.service, ep: sp.endpoint, callerKind: daemonpb.UsageReportResponse_CALLER_EXTERNAL, caller: sp.uid}
+		if sp.parentID != nil {
+			if parent, ok := spans[*sp.parentID]; ok && parent.service != "" {
+				key.callerKind = daemonpb.UsageReportResponse_CALLER_SERVICE
+				key.caller = parent.service
+			}
+		}
+
+		st, ok := a.stats[key]
+		if !ok {
+			st = &daemonpb.UsageReportResponse_EndpointUsage{
+				Service:    key.svc,
+				Endpoint:   key.ep,
+				CallerKind: key.callerKind,
+				Caller:     key.caller,
+			}
+			a.stats[key] = st
+		}
+		st.Requests++
+		if sp.isError {
+			st.Errors++
+		}
+		if st.LastCalled == nil || sp.startedAt.After(st.LastCalled.AsTime()) {
+			st.LastCalled = timestamppb.New(sp.startedAt)
+			st.ExampleTraceId = traceID
+		}
+	}
+}
+
+// usages returns the aggregated usages, sorted by service,
+// endpoint, caller kind and caller.
+func (a *usageAggregator) usages() []*daemonpb.UsageReportResponse_EndpointUsage {
+	usages := make([]*daemonpb.UsageReportResponse_EndpointUsage, 0, len(a.stats))
+	for _, st := range a.stats {
+		usages = append(usages, st)
+	}
+	slices.SortFunc(usages, func(a, b *daemonpb.UsageReportResponse_EndpointUsage) int {
+		return cmp.Or(
+			cmp.Compare(a.Service, b.Service),
+			cmp.Compare(a.Endpoint, b.Endpoint),
+			cmp.Compare(a.CallerKind, b.CallerKind),
+			cmp.Compare(a.Caller, b.Caller),
+		)
+	})
+	return usages
+}
@@ -0,0 +1,204 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+func TestUsageAggregator(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(sec int) *timestamppb.Timestamp {
+		return timestamppb.New(base.Add(time.Duration(sec) * time.Second))
+	}
+	spanStart := func(spanID, parentID uint64, sec int, start *tracepb2.SpanStart) *tracepb2.TraceEvent {
+		if parentID != 0 {
+			start.ParentSpanId = &parentID
+		}
+		return &tracepb2.TraceEvent{
+			SpanId:    spanID,
+			EventTime: at(sec),
+			Event:     &tracepb2.TraceEvent_SpanStart{SpanStart: start},
+		}
+	}
+	// request returns a request span start event. The span is a root span if parentID is 0.
+	request := func(spanID, parentID uint64, sec int, svc, ep, uid string) *tracepb2.TraceEvent {
+		req := &tracepb2.RequestSpanStart{ServiceName: svc, EndpointName: ep}
+		if uid != "" {
+			req.Uid = &uid
+		}
+		return spanStart(spanID, parentID, sec, &tracepb2.SpanStart{
+			Data: &tracepb2.SpanStart_Request{Request: req},
+		})
+	}
+	message := func(spanID, parentID uint64, sec int, svc string) *tracepb2.TraceEvent {
+		return spanStart(spanID, parentID, sec, &tracepb2.SpanStart{
+			Data: &tracepb2.SpanStart_PubsubMessage{PubsubMessage: &tracepb2.PubsubMessageSpanStart{ServiceName: svc}},
+		})
+	}
+	auth := func(spanID, parentID uint64, sec int, svc string) *tracepb2.TraceEvent {
+		return spanStart(spanID, parentID, sec, &tracepb2.SpanStart{
+			Data: &tracepb2.SpanStart_Auth{Auth: &tracepb2.AuthSpanStart{ServiceName: svc}},
+		})
+	}
+	end := func(spanID uint64, sec int, isErr bool) *tracepb2.TraceEvent {
+		e := &tracepb2.SpanEnd{}
+		if isErr {
+			e.Error = &tracepb2.Error{Msg: "boom"}
+		}
+		return &tracepb2.TraceEvent{
+			SpanId:    spanID,
+			EventTime: at(sec),
+			Event:     &tracepb2.TraceEvent_SpanEnd{SpanEnd: e},
+		}
+	}
+
+	type trace struct {
+		id     string
+		events []*tracepb2.TraceEvent
+	}
+	external := daemonpb.UsageReportResponse_CALLER_EXTERNAL
+	service := daemonpb.UsageReportResponse_CALLER_SERVICE
+
+	tests := []struct {
+		name   string
+		traces []trace
+		want   []*daemonpb.UsageReportResponse_EndpointUsage
+	}{
+		{
+			name: "empty",
+			want: []*daemonpb.UsageReportResponse_EndpointUsage{},
+		},
+		{
+			name: "external_callers",
+			traces: []trace{
+				{id: "t1", events: []*tracepb2.TraceEvent{request(1, 0, 1, "svc", "A", "alice"), end(1, 2, false)}},
+				{id: "t2", events: []*tracepb2.TraceEvent{request(1, 0, 3, "svc", "A", ""), end(1, 4, true)}},
+				{id: "t3", events: []*tracepb2.TraceEvent{request(1, 0, 5, "svc", "A", "bob")}},
+			},
+			want: []*daemonpb.UsageReportResponse_EndpointUsage{
+				{Service: "svc", Endpoint: "A", CallerKind: external, Caller: "", Requests: 1, Errors: 1, LastCalled: at(3), ExampleTraceId: "t2"},
+				{Service: "svc", Endpoint: "A", CallerKind: external, Caller: "alice", Requests: 1, LastCalled: at(1), ExampleTraceId: "t1"},
+				{Service: "svc", Endpoint: "A", CallerKind: external, Caller: "bob", Requests: 1, LastCalled: at(5), ExampleTraceId: "t3"},
+			},
+		},
+		{
+			// A service call made on behalf of a user is attributed
+			// to the calling service, not to the user.
+			name: "service_caller",
+			traces: []trace{
+				{id: "t1", events: []*tracepb2.TraceEvent{
+					request(1, 0, 1, "gateway", "Entry", "alice"),
+					request(2, 1, 2, "users", "Get", "alice"),
+					request(3, 2, 3, "db", "Query", "alice"),
+					end(3, 4, true),
+					end(2, 5, true),
+					end(1, 6, false),
+				}},
+			},
+			want: []*daemonpb.UsageReportResponse_EndpointUsage{
+				{Service: "db", Endpoint: "Query", CallerKind: service, Caller: "users", Requests: 1, Errors: 1, LastCalled: at(3), ExampleTraceId: "t1"},
+				{Service: "gateway", Endpoint: "Entry", CallerKind: external, Caller: "alice", Requests: 1, LastCalled: at(1), ExampleTraceId: "t1"},
+				{Service: "users", Endpoint: "Get", CallerKind: service, Caller: "gateway", Requests: 1, Errors: 1, LastCalled: at(2), ExampleTraceId: "t1"},
+			},
+		},
+		{
+			name: "non_request_parents",
+			traces: []trace{
+				{id: "t1", events: []*tracepb2.TraceEvent{
+					message(1, 0, 1, "worker"),
+					request(2, 1, 2, "email", "Send", ""),
+				}},
+				{id: "t2", events: []*tracepb2.TraceEvent{
+					auth(1, 0, 1, "auth"),
+					request(2, 1, 2, "users", "Lookup", ""),
+				}},
+			},
+			want: []*daemonpb.UsageReportResponse_EndpointUsage{
+				{Service: "email", Endpoint: "Send", CallerKind: service, Caller: "worker", Requests: 1, LastCalled: at(2), ExampleTraceId: "t1"},
+				{Service: "users", Endpoint: "Lookup", CallerKind: service, Caller: "auth", Requests: 1, LastCalled: at(2), ExampleTraceId: "t2"},
+			},
+		},
+		{
+			// A parent span that isn't part of the trace, such as the
+			// publishing request of a pubsub message, isn't a known caller.
+			name: "unknown_parent",
+			traces: []trace{
+				{id: "t1", events: []*tracepb2.TraceEvent{request(2, 1, 1, "svc", "A", "alice")}},
+			},
+			want: []*daemonpb.UsageReportResponse_EndpointUsage{
+				{Service: "svc", Endpoint: "A", CallerKind: external, Caller: "alice", Requests: 1, LastCalled: at(1), ExampleTraceId: "t1"},
+			},
+		},
+		{
+			name: "out_of_order",
+			traces: []trace{
+				{id: "t1", events: []*tracepb2.TraceEvent{
+					end(2, 3, true),
+					request(2, 1, 2, "users", "Get", ""),
+					end(1, 4, false),
+					request(1, 0, 1, "gateway", "Entry", ""),
+				}},
+			},
+			want: []*daemonpb.UsageReportResponse_EndpointUsage{
+				{Service: "gateway", Endpoint: "Entry", CallerKind: external, Requests: 1, LastCalled: at(1), ExampleTraceId: "t1"},
+				{Service: "users", Endpoint: "Get", CallerKind: service, Caller: "gateway", Requests: 1, Errors: 1, LastCalled: at(2), ExampleTraceId: "t1"},
+			},
+		},
+		{
+			// A span is counted once, however many events it has.
+			name: "duplicate_events",
+			traces: []trace{
+				{id: "t1", events: []*tracepb2.TraceEvent{
+					request(1, 0, 1, "svc", "A", "alice"),
+					request(1, 0, 1, "svc", "A", "alice"),
+					end(1, 2, false),
+					end(1, 2, false),
+					end(2, 3, false), // no matching start
+				}},
+			},
+			want: []*daemonpb.UsageReportResponse_EndpointUsage{
+				{Service: "svc", Endpoint: "A", CallerKind: external, Caller: "alice", Requests: 1, LastCalled: at(1), ExampleTraceId: "t1"},
+			},
+		},
+		{
+			name: "across_traces",
+			traces: []trace{
+				{id: "t1", events: []*tracepb2.TraceEvent{
+					request(1, 0, 5, "gateway", "Entry", ""),
+					request(2, 1, 6, "users", "Get", ""),
+					request(3, 1, 7, "users", "Get", ""),
+					end(3, 8, true),
+				}},
+				{id: "t2", events: []*tracepb2.TraceEvent{
+					request(1, 0, 9, "gateway", "Entry", ""),
+					request(2, 1, 10, "users", "Get", ""),
+				}},
+				{id: "t3", events: []*tracepb2.TraceEvent{
+					request(1, 0, 1, "gateway", "Entry", ""),
+					request(2, 1, 2, "users", "Get", ""),
+				}},
+			},
+			want: []*daemonpb.UsageReportResponse_EndpointUsage{
+				{Service: "gateway", Endpoint: "Entry", CallerKind: external, Requests: 3, LastCalled: at(9), ExampleTraceId: "t2"},
+				{Service: "users", Endpoint: "Get", CallerKind: service, Caller: "gateway", Requests: 4, Errors: 1, LastCalled: at(10), ExampleTraceId: "t2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			agg := newUsageAggregator()
+			for _, tr := range tt.traces {
+				agg.addTrace(tr.id, tr.events)
+			}
+			c.Assert(agg.usages(), qt.CmpEquals(protocmp.Transform()), tt.want)
+		})
+	}
+}
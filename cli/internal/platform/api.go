@@ -152,6 +152,16 @@ func EnvLogs(ctx context.Context, appSlug, envSlug string) (*websocket.Conn, err
 	return wsDial(ctx, path, true, nil)
 }
 
+// AppTunnel connects to the tunnel service for exposing the local app through a public URL.
+// If tunnelID is non-empty the tunnel with that id is reused.
+func AppTunnel(ctx context.Context, appSlug, tunnelID string) (*websocket.Conn, error) {
+	path := escapef("/apps/%s/tunnel", appSlug)
+	if tunnelID != "" {
+		path += "?id=" + url.QueryEscape(tunnelID)
+	}
+	return wsDial(ctx, path, true, nil)
+}
+
 func KubernetesClusters(ctx context.Context, appSlug string, envName string) (string, string, []KubeCtlConfig, error) {
 	type K8SClusterConfigs struct {
 		AppSlug  string          `json:"app"`
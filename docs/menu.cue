@@ -336,6 +336,11 @@
 				text: "Secrets"
 				path: "/go/primitives/secrets"
 				file: "go/primitives/secrets"
+			}, {
+				kind: "basic"
+				text: "Feature Flags"
+				path: "/go/primitives/feature-flags"
+				file: "go/primitives/feature-flags"
 			}, {
 				kind: "basic"
 				text: "Code Snippets"
@@ -460,6 +465,16 @@
 				text: "Metrics"
 				path: "/go/observability/metrics"
 				file: "go/observability/metrics"
+			}, {
+				kind: "basic"
+				text: "Synthetic Monitoring"
+				path: "/go/observability/synthetic-monitoring"
+				file: "go/observability/synthetic-monitoring"
+			}, {
+				kind: "basic"
+				text: "Alert Rules"
+				path: "/go/observability/alerts"
+				file: "go/observability/alerts"
 			}]
 		},
 		{
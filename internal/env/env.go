@@ -117,6 +117,33 @@ func EncoreObjectStorageListAddr() option.Option[string] {
 	return option.None[string]()
 }
 
+// EncoreDaemonAddr reports the address of a remote daemon running
+// in shared mode, to connect to instead of the local daemon.
+// It is set with ENCORE_DAEMON_ADDR, as an "https://host:port" URL
+// (or "http://host:port" for connecting without TLS).
+func EncoreDaemonAddr() option.Option[string] {
+	if p := os.Getenv("ENCORE_DAEMON_ADDR"); p != "" {
+		return option.Some(p)
+	}
+	return option.None[string]()
+}
+
+// EncoreDaemonToken reports the access token to use when
+// connecting to a remote daemon. It is set with ENCORE_DAEMON_TOKEN.
+func EncoreDaemonToken() string {
+	return os.Getenv("ENCORE_DAEMON_TOKEN")
+}
+
+// EncoreDaemonWorkspace reports the local directory that corresponds
+// to the user's workspace on a remote daemon, if any.
+// It is set with ENCORE_DAEMON_WORKSPACE.
+func EncoreDaemonWorkspace() option.Option[string] {
+	if p := os.Getenv("ENCORE_DAEMON_WORKSPACE"); p != "" {
+		return option.Some(p)
+	}
+	return option.None[string]()
+}
+
 func encoreGoRoot() string {
 	if p := os.Getenv("ENCORE_GOROOT"); p != "" {
 		return p
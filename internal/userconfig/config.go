@@ -9,4 +9,40 @@ type Config struct {
 	// Always choose this tool when creating an app or when initializing llm tools
 	// for an existing app, unless overriden via --llm-rules flag on command line.
 	LLMRules string `koanf:"llm_rules" oneof:",cursor,claudcode,vscode,agentsmd,zed" default:""`
+
+	// The number of CPUs local infrastructure containers (like the PostgreSQL database)
+	// may use, passed to the container runtime as "--cpus". For example "1.5".
+	// If empty the containers are not limited.
+	InfraCPUs string `koanf:"infra.cpus" default:""`
+
+	// The maximum amount of memory local infrastructure containers (like the PostgreSQL database)
+	// may use, passed to the container runtime as "--memory". For example "512m" or "2g".
+	// If empty the containers are not limited.
+	InfraMemory string `koanf:"infra.memory" default:""`
+
+	// The fraction of traces from "encore run" to keep, between 0 and 1, for example "0.1".
+	// Traces with errors, slow traces and traces of the endpoints in trace.local.endpoints
+	// are always kept. If empty all traces are kept.
+	TraceLocalSampleRate string `koanf:"trace.local.sample_rate" default:""`
+
+	// The span duration at or above which traces from "encore run" are always kept
+	// when sampling traces, for example "500ms".
+	TraceLocalLatencyThreshold string `koanf:"trace.local.latency_threshold" default:""`
+
+	// Comma-separated endpoints (like "service.Endpoint") or services whose traces
+	// from "encore run" are always kept when sampling traces.
+	TraceLocalEndpoints string `koanf:"trace.local.endpoints" default:""`
+
+	// The fraction of traces from "encore test" to keep, between 0 and 1, for example "0.1".
+	// Traces with errors, slow traces and traces of the endpoints in trace.test.endpoints
+	// are always kept. If empty all traces are kept.
+	TraceTestSampleRate string `koanf:"trace.test.sample_rate" default:""`
+
+	// The span duration at or above which traces from "encore test" are always kept
+	// when sampling traces, for example "500ms".
+	TraceTestLatencyThreshold string `koanf:"trace.test.latency_threshold" default:""`
+
+	// Comma-separated endpoints (like "service.Endpoint") or services whose traces
+	// from "encore test" are always kept when sampling traces.
+	TraceTestEndpoints string `koanf:"trace.test.endpoints" default:""`
 }
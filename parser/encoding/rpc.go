@@ -193,6 +193,25 @@ func (e *RequestEncoding) ParameterEncodingMapByName() map[string][]*ParameterEn
 	return toEncodingMultiMap(nameKey, e.HeaderParameters, e.QueryParameters, e.BodyParameters, e.CookieParameters)
 }
 
+// Multipart reports whether the request contains files,
+// in which case it's sent as multipart/form-data instead of JSON.
+func (e *RequestEncoding) Multipart() bool {
+	return slices.ContainsFunc(e.BodyParameters, func(p *ParameterEncoding) bool {
+		return IsFileType(p.Type)
+	})
+}
+
+// IsFileType reports whether typ is a file or a list of files.
+func IsFileType(typ *schema.Type) bool {
+	if list := typ.GetList(); list != nil {
+		typ = list.Elem
+	}
+	if ptr := typ.GetPointer(); ptr != nil {
+		typ = ptr.Base
+	}
+	return typ.GetBuiltin() == schema.Builtin_FILE
+}
+
 // ParameterEncoding expresses how a parameter should be encoded on the wire
 type ParameterEncoding struct {
 	// The location specific name of the parameter (e.g. cheeseEater, cheese-eater, X-Cheese-Eater)
@@ -213,6 +232,10 @@ type ParameterEncoding struct {
 	WireFormat string `json:"wire_format"`
 	// Optional indicates whether the field is optional.
 	Optional bool `json:"optional"`
+	// StringEncoded indicates whether the field is encoded as a JSON string.
+	StringEncoded bool `json:"string_encoded"`
+	// MaxSize is the maximum decoded size in bytes of a []byte field, or 0 if unlimited.
+	MaxSize uint64 `json:"max_size"`
 }
 
 type Options struct {
@@ -722,6 +745,9 @@ func describeParam(lang meta.Lang, encodingHints *encodingHints, field *schema.F
 		RawTag:     field.RawTag,
 		Optional:   field.Optional,
 		WireFormat: name,
+
+		StringEncoded: field.StringEncoded,
+		MaxSize:       field.GetMaxSize(),
 	}
 
 	var usedOverrideTag string
@@ -0,0 +1,303 @@
+// Package alertgen generates alerting configuration from the alert rules
+// declared in an application, as Prometheus alerting rules or Grafana
+// alert provisioning files.
+//
+// The generated rules query the metrics reported by the Encore runtime:
+// e_requests_total and e_request_duration_seconds_bucket for error rate and
+// latency rules, and e_sys_pubsub_subscription_lag_seconds for queue lag rules.
+package alertgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// GrafanaOptions configures the generated Grafana provisioning file.
+type GrafanaOptions struct {
+	// DatasourceUID is the UID of the Prometheus data source to query.
+	DatasourceUID string
+
+	// Folder is the folder to provision the alert rules in.
+	Folder string
+}
+
+// rule is an alert rule translated to a query and a threshold.
+type rule struct {
+	name      string
+	service   string
+	title     string
+	doc       string
+	query     string  // the PromQL query computing the value to compare
+	threshold float64 // the value above which the alert fires
+	window    time.Duration
+	forDur    time.Duration
+	severity  string
+}
+
+// translate translates the alert rules in md into queries,
+// sorted by service and name.
+func translate(md *meta.Data) ([]rule, error) {
+	rules := make([]rule, 0, len(md.AlertRules))
+	for _, r := range md.AlertRules {
+		window := time.Duration(r.WindowSeconds) * time.Second
+		rng := formatDuration(window)
+
+		out := rule{
+			name:     r.Name,
+			service:  r.Service,
+			title:    r.Title,
+			doc:      strings.TrimSpace(r.GetDoc()),
+			window:   window,
+			forDur:   time.Duration(r.ForSeconds) * time.Second,
+			severity: r.Severity,
+		}
+
+		switch c := r.Condition.(type) {
+		case *meta.AlertRule_ErrorRate_:
+			sel := endpointSelector(r.Service, c.ErrorRate.Endpoint)
+			out.query = fmt.Sprintf(`sum(rate(e_requests_total{%s,code!="ok"}[%s])) / sum(rate(e_requests_total{%s}[%s]))`,
+				sel, rng, sel, rng)
+			out.threshold = c.ErrorRate.Threshold
+		case *meta.AlertRule_Latency_:
+			sel := endpointSelector(r.Service, c.Latency.Endpoint)
+			out.query = fmt.Sprintf(`histogram_quantile(%s, sum by (le) (rate(e_request_duration_seconds_bucket{%s}[%s])))`,
+				formatFloat(c.Latency.Percentile/100), sel, rng)
+			out.threshold = time.Duration(c.Latency.ThresholdMillis * int64(time.Millisecond)).Seconds()
+		case *meta.AlertRule_QueueLag_:
+			out.query = fmt.Sprintf(`max(max_over_time(e_sys_pubsub_subscription_lag_seconds{topic=%s,subscription=%s}[%s]))`,
+				strconv.Quote(c.QueueLag.Topic), strconv.Quote(c.QueueLag.Subscription), rng)
+			out.threshold = time.Duration(c.QueueLag.ThresholdMillis * int64(time.Millisecond)).Seconds()
+		default:
+			return nil, fmt.Errorf("alert rule %q: unknown condition type %T", r.Name, r.Condition)
+		}
+		rules = append(rules, out)
+	}
+
+	slices.SortFunc(rules, func(a, b rule) int {
+		if c := strings.Compare(a.service, b.service); c != 0 {
+			return c
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	return rules, nil
+}
+
+func endpointSelector(service string, endpoint *string) string {
+	sel := "service=" + strconv.Quote(service)
+	if endpoint != nil {
+		sel += ",endpoint=" + strconv.Quote(*endpoint)
+	}
+	return sel
+}
+
+func (r rule) labels() map[string]string {
+	return map[string]string{
+		"severity": r.severity,
+		"service":  r.service,
+	}
+}
+
+func (r rule) annotations() map[string]string {
+	annotations := map[string]string{"summary": r.title}
+	if r.doc != "" {
+		annotations["description"] = r.doc
+	}
+	return annotations
+}
+
+// groupByService groups the sorted rules by service.
+func groupByService(rules []rule) [][]rule {
+	var groups [][]rule
+	for i, r := range rules {
+		if i == 0 || r.service != rules[i-1].service {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], r)
+	}
+	return groups
+}
+
+// GenPrometheus generates a Prometheus alerting rules file,
+// with one rule group per service.
+func GenPrometheus(md *meta.Data) ([]byte, error) {
+	rules, err := translate(md)
+	if err != nil {
+		return nil, err
+	}
+
+	type promRule struct {
+		Alert       string            `json:"alert"`
+		Expr        string            `json:"expr"`
+		For         string            `json:"for,omitempty"`
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	}
+	type promGroup struct {
+		Name  string     `json:"name"`
+		Rules []promRule `json:"rules"`
+	}
+
+	groups := []promGroup{}
+	for _, svcRules := range groupByService(rules) {
+		g := promGroup{Name: "encore." + svcRules[0].service}
+		for _, r := range svcRules {
+			pr := promRule{
+				Alert:       r.name,
+				Expr:        r.query + " > " + formatFloat(r.threshold),
+				Labels:      r.labels(),
+				Annotations: r.annotations(),
+			}
+			if r.forDur > 0 {
+				pr.For = formatDuration(r.forDur)
+			}
+			g.Rules = append(g.Rules, pr)
+		}
+		groups = append(groups, g)
+	}
+
+	return yaml.Marshal(map[string]any{"groups": groups})
+}
+
+// GenGrafana generates a Grafana alert rule provisioning file,
+// with one rule group per service.
+func GenGrafana(md *meta.Data, opts GrafanaOptions) ([]byte, error) {
+	rules, err := translate(md)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DatasourceUID == "" {
+		return nil, fmt.Errorf("grafana: a data source UID is required")
+	}
+	if opts.Folder == "" {
+		opts.Folder = "Encore"
+	}
+
+	type timeRange struct {
+		From int64 `json:"from"`
+		To   int64 `json:"to"`
+	}
+	type query struct {
+		RefID             string         `json:"refId"`
+		RelativeTimeRange *timeRange     `json:"relativeTimeRange,omitempty"`
+		DatasourceUID     string         `json:"datasourceUid"`
+		Model             map[string]any `json:"model"`
+	}
+	type grafanaRule struct {
+		UID          string            `json:"uid"`
+		Title        string            `json:"title"`
+		Condition    string            `json:"condition"`
+		Data         []query           `json:"data"`
+		For          string            `json:"for"`
+		NoDataState  string            `json:"noDataState"`
+		ExecErrState string            `json:"execErrState"`
+		Labels       map[string]string `json:"labels"`
+		Annotations  map[string]string `json:"annotations"`
+	}
+	type grafanaGroup struct {
+		OrgID    int           `json:"orgId"`
+		Name     string        `json:"name"`
+		Folder   string        `json:"folder"`
+		Interval string        `json:"interval"`
+		Rules    []grafanaRule `json:"rules"`
+	}
+
+	groups := []grafanaGroup{}
+	for _, svcRules := range groupByService(rules) {
+		g := grafanaGroup{
+			OrgID:    1,
+			Name:     svcRules[0].service,
+			Folder:   opts.Folder,
+			Interval: "1m",
+		}
+		for _, r := range svcRules {
+			g.Rules = append(g.Rules, grafanaRule{
+				UID:       grafanaUID(r.name),
+				Title:     r.title,
+				Condition: "B",
+				Data: []query{
+					{
+						RefID:             "A",
+						RelativeTimeRange: &timeRange{From: int64(r.window / time.Second), To: 0},
+						DatasourceUID:     opts.DatasourceUID,
+						Model: map[string]any{
+							"refId":   "A",
+							"expr":    r.query,
+							"instant": true,
+						},
+					},
+					{
+						RefID:         "B",
+						DatasourceUID: "__expr__",
+						Model: map[string]any{
+							"refId":      "B",
+							"type":       "threshold",
+							"expression": "A",
+							"conditions": []any{
+								map[string]any{
+									"evaluator": map[string]any{
+										"type":   "gt",
+										"params": []float64{r.threshold},
+									},
+								},
+							},
+						},
+					},
+				},
+				For:          formatDuration(r.forDur),
+				NoDataState:  "OK",
+				ExecErrState: "Error",
+				Labels:       r.labels(),
+				Annotations:  r.annotations(),
+			})
+		}
+		groups = append(groups, g)
+	}
+
+	return yaml.Marshal(map[string]any{
+		"apiVersion": 1,
+		"groups":     groups,
+	})
+}
+
+// grafanaUID returns the Grafana rule UID for the named rule.
+// Grafana limits UIDs to 40 characters, so long names are
+// truncated and suffixed with a hash to keep them unique.
+func grafanaUID(name string) string {
+	uid := "encore-" + name
+	if len(uid) > 40 {
+		sum := sha256.Sum256([]byte(name))
+		uid = uid[:31] + "-" + hex.EncodeToString(sum[:4])
+	}
+	return uid
+}
+
+// formatDuration formats d as a Prometheus duration, such as "1h30m".
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	for _, u := range []struct {
+		unit time.Duration
+		name string
+	}{{time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}} {
+		if n := d / u.unit; n > 0 {
+			b.WriteString(strconv.FormatInt(int64(n), 10) + u.name)
+			d -= n * u.unit
+		}
+	}
+	return b.String()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
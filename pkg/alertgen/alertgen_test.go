@@ -0,0 +1,110 @@
+package alertgen
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"encr.dev/pkg/golden"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+	os.Exit(m.Run())
+}
+
+func testMeta() *meta.Data {
+	ptr := func(s string) *string { return &s }
+	return &meta.Data{
+		AlertRules: []*meta.AlertRule{
+			{
+				Name:          "slow-checkout",
+				Title:         "Checkout is slow",
+				Service:       "checkout",
+				WindowSeconds: 600,
+				ForSeconds:    300,
+				Severity:      "warning",
+				Condition: &meta.AlertRule_Latency_{Latency: &meta.AlertRule_Latency{
+					Percentile:      95,
+					ThresholdMillis: 750,
+				}},
+			},
+			{
+				Name:          "pay-errors",
+				Title:         "Payments are failing",
+				Doc:           ptr("Payments are failing.\nCheck the payment provider status page.\n"),
+				Service:       "checkout",
+				WindowSeconds: 300,
+				ForSeconds:    0,
+				Severity:      "critical",
+				Condition: &meta.AlertRule_ErrorRate_{ErrorRate: &meta.AlertRule_ErrorRate{
+					Endpoint:  ptr("Pay"),
+					Threshold: 0.05,
+				}},
+			},
+			{
+				Name:          "emails-lagging",
+				Title:         "emails-lagging",
+				Service:       "email",
+				WindowSeconds: 300,
+				ForSeconds:    int64(90 * time.Minute / time.Second),
+				Severity:      "warning",
+				Condition: &meta.AlertRule_QueueLag_{QueueLag: &meta.AlertRule_QueueLag{
+					Topic:           "signups",
+					Subscription:    "send-welcome-email",
+					ThresholdMillis: 120000,
+				}},
+			},
+		},
+	}
+}
+
+func TestGenPrometheus(t *testing.T) {
+	out, err := GenPrometheus(testMeta())
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden.Test(t, string(out))
+}
+
+func TestGenGrafana(t *testing.T) {
+	out, err := GenGrafana(testMeta(), GrafanaOptions{DatasourceUID: "prom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden.Test(t, string(out))
+
+	if _, err := GenGrafana(testMeta(), GrafanaOptions{}); err == nil {
+		t.Fatal("expected an error without a data source UID")
+	}
+}
+
+func TestGrafanaUID(t *testing.T) {
+	short := grafanaUID("pay-errors")
+	if short != "encore-pay-errors" {
+		t.Errorf("got uid %q", short)
+	}
+
+	a := grafanaUID("a-very-long-alert-rule-name-that-exceeds-the-limit-1")
+	b := grafanaUID("a-very-long-alert-rule-name-that-exceeds-the-limit-2")
+	if len(a) != 40 || len(b) != 40 || a == b {
+		t.Errorf("got uids %q and %q, want distinct 40 character uids", a, b)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                                   "0s",
+		30 * time.Second:                    "30s",
+		5 * time.Minute:                     "5m",
+		90 * time.Minute:                    "1h30m",
+		time.Hour + 2*time.Second:           "1h2s",
+		24*time.Hour + 500*time.Millisecond: "24h",
+	}
+	for d, want := range tests {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
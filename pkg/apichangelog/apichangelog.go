@@ -0,0 +1,374 @@
+// Package apichangelog compares the metadata of two revisions of an app
+// and describes the changes made to its API: endpoints that were added or
+// removed, changes to their paths, access and request and response schemas,
+// and changes to Pub/Sub topics and their message schemas.
+//
+// Each change is classified as breaking or not, based on whether existing
+// clients (or, for topics, existing publishers and subscribers) may stop
+// working because of it.
+package apichangelog
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Kind is the kind of a change.
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Changelog describes the API changes between two revisions of an app.
+type Changelog struct {
+	Endpoints []*EndpointChange `json:"endpoints"`
+	Topics    []*TopicChange    `json:"topics"`
+}
+
+// Breaking reports whether the changelog contains any breaking changes.
+func (c *Changelog) Breaking() bool {
+	for _, e := range c.Endpoints {
+		if e.Breaking {
+			return true
+		}
+	}
+	for _, t := range c.Topics {
+		if t.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty reports whether there are no changes.
+func (c *Changelog) Empty() bool {
+	return len(c.Endpoints) == 0 && len(c.Topics) == 0
+}
+
+// EndpointChange describes a change to an endpoint.
+type EndpointChange struct {
+	Kind     Kind   `json:"kind"`
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+
+	// Method and Path describe how the endpoint is called,
+	// such as "GET" and "/users/:id". For removed endpoints
+	// they describe the old revision, otherwise the new one.
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// Access is the endpoint's access type: "public", "auth" or "private".
+	Access string `json:"access"`
+
+	// Breaking reports whether the change may break existing callers.
+	Breaking bool `json:"breaking"`
+
+	// Details describes the individual changes made to a changed endpoint.
+	Details []Detail `json:"details,omitempty"`
+}
+
+// TopicChange describes a change to a Pub/Sub topic.
+type TopicChange struct {
+	Kind  Kind   `json:"kind"`
+	Topic string `json:"topic"`
+
+	// Breaking reports whether the change may break
+	// existing publishers or subscribers.
+	Breaking bool `json:"breaking"`
+
+	// Details describes the individual changes made to a changed topic.
+	Details []Detail `json:"details,omitempty"`
+}
+
+// Detail describes an individual change to an endpoint or topic.
+type Detail struct {
+	Desc     string `json:"desc"`
+	Breaking bool   `json:"breaking"`
+}
+
+// Compare describes the API changes made between the old and new revisions
+// of an app. Changes are sorted by service and endpoint, and by topic name.
+func Compare(old, new *meta.Data) *Changelog {
+	return &Changelog{
+		Endpoints: compareEndpoints(old, new),
+		Topics:    compareTopics(old, new),
+	}
+}
+
+func compareEndpoints(old, new *meta.Data) []*EndpointChange {
+	oldRPCs := rpcsByName(old)
+	newRPCs := rpcsByName(new)
+	changes := []*EndpointChange{}
+
+	for key, rpc := range oldRPCs {
+		if _, ok := newRPCs[key]; !ok {
+			c := endpointChange(Removed, rpc)
+			c.Breaking = true
+			changes = append(changes, c)
+		}
+	}
+
+	for key, rpc := range newRPCs {
+		oldRPC, ok := oldRPCs[key]
+		if !ok {
+			changes = append(changes, endpointChange(Added, rpc))
+			continue
+		}
+
+		var details []Detail
+		add := func(breaking bool, format string, args ...any) {
+			details = append(details, Detail{Desc: fmt.Sprintf(format, args...), Breaking: breaking})
+		}
+
+		if o, n := methods(oldRPC), methods(rpc); o != n {
+			add(true, "method changed from %s to %s", o, n)
+		}
+		if o, n := pathString(oldRPC.Path), pathString(rpc.Path); o != n {
+			add(true, "path changed from %s to %s", o, n)
+		}
+		if o, n := oldRPC.AccessType, rpc.AccessType; o != n {
+			add(accessRank(n) < accessRank(o), "access changed from %s to %s", accessName(o), accessName(n))
+		}
+		if o, n := streaming(oldRPC), streaming(rpc); o != n {
+			add(true, "streaming changed from %s to %s", o, n)
+		}
+		if oldRPC.Deprecated == nil && rpc.Deprecated != nil {
+			add(false, "deprecated: %s", rpc.GetDeprecated())
+		}
+
+		details = append(details, compareFields("request", request,
+			fields(old, oldRPC.RequestSchema), fields(new, rpc.RequestSchema))...)
+		details = append(details, compareFields("response", response,
+			fields(old, oldRPC.ResponseSchema), fields(new, rpc.ResponseSchema))...)
+
+		if len(details) > 0 {
+			c := endpointChange(Changed, rpc)
+			c.Details = details
+			c.Breaking = slices.ContainsFunc(details, func(d Detail) bool { return d.Breaking })
+			changes = append(changes, c)
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b *EndpointChange) int {
+		return cmp.Or(
+			cmp.Compare(a.Service, b.Service),
+			cmp.Compare(a.Endpoint, b.Endpoint),
+		)
+	})
+	return changes
+}
+
+func compareTopics(old, new *meta.Data) []*TopicChange {
+	oldTopics := make(map[string]*meta.PubSubTopic)
+	for _, t := range old.PubsubTopics {
+		oldTopics[t.Name] = t
+	}
+	newTopics := make(map[string]*meta.PubSubTopic)
+	for _, t := range new.PubsubTopics {
+		newTopics[t.Name] = t
+	}
+	changes := []*TopicChange{}
+
+	for name := range oldTopics {
+		if _, ok := newTopics[name]; !ok {
+			changes = append(changes, &TopicChange{Kind: Removed, Topic: name, Breaking: true})
+		}
+	}
+
+	for name, topic := range newTopics {
+		oldTopic, ok := oldTopics[name]
+		if !ok {
+			changes = append(changes, &TopicChange{Kind: Added, Topic: name})
+			continue
+		}
+
+		details := compareFields("message", message,
+			fields(old, oldTopic.MessageType), fields(new, topic.MessageType))
+		if o, n := oldTopic.OrderingKey, topic.OrderingKey; o != n {
+			details = append(details, Detail{
+				Desc:     fmt.Sprintf("ordering key changed from %s to %s", orNone(o), orNone(n)),
+				Breaking: true,
+			})
+		}
+		if len(details) > 0 {
+			changes = append(changes, &TopicChange{
+				Kind:     Changed,
+				Topic:    name,
+				Details:  details,
+				Breaking: slices.ContainsFunc(details, func(d Detail) bool { return d.Breaking }),
+			})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b *TopicChange) int {
+		return cmp.Compare(a.Topic, b.Topic)
+	})
+	return changes
+}
+
+// direction describes which way data flows for a schema,
+// which determines which schema changes are breaking.
+type direction int
+
+const (
+	request  direction = iota // sent by callers
+	response                  // received by callers
+	message                   // both sent by publishers and received by subscribers
+)
+
+// compareFields describes the changes between the old and new fields of a schema.
+func compareFields(what string, dir direction, old, new map[string]field) []Detail {
+	var details []Detail
+	for _, name := range sortedKeys(old) {
+		if _, ok := new[name]; !ok {
+			// Callers may keep sending removed request fields,
+			// which are ignored unless the endpoint uses strict decoding.
+			details = append(details, Detail{
+				Desc:     fmt.Sprintf("removed %s field %s", what, name),
+				Breaking: dir != request,
+			})
+		}
+	}
+
+	for _, name := range sortedKeys(new) {
+		n := new[name]
+		o, ok := old[name]
+		if !ok {
+			details = append(details, Detail{
+				Desc:     fmt.Sprintf("added %s %s field %s (%s)", optionalName(n.Optional), what, name, n.Type),
+				Breaking: dir != response && !n.Optional,
+			})
+			continue
+		}
+
+		if o.Type != n.Type {
+			details = append(details, Detail{
+				Desc:     fmt.Sprintf("%s field %s changed type from %s to %s", what, name, o.Type, n.Type),
+				Breaking: true,
+			})
+		}
+		if o.Optional != n.Optional {
+			// A field becoming required breaks senders,
+			// and a field becoming optional breaks receivers.
+			breaking := (n.Optional && dir != request) || (!n.Optional && dir != response)
+			details = append(details, Detail{
+				Desc:     fmt.Sprintf("%s field %s is now %s", what, name, optionalName(n.Optional)),
+				Breaking: breaking,
+			})
+		}
+	}
+	return details
+}
+
+func endpointChange(kind Kind, rpc *meta.RPC) *EndpointChange {
+	return &EndpointChange{
+		Kind:     kind,
+		Service:  rpc.ServiceName,
+		Endpoint: rpc.Name,
+		Method:   methods(rpc),
+		Path:     pathString(rpc.Path),
+		Access:   accessName(rpc.AccessType),
+	}
+}
+
+func rpcsByName(md *meta.Data) map[string]*meta.RPC {
+	rpcs := make(map[string]*meta.RPC)
+	for _, svc := range md.Svcs {
+		for _, rpc := range svc.Rpcs {
+			rpcs[svc.Name+"."+rpc.Name] = rpc
+		}
+	}
+	return rpcs
+}
+
+func methods(rpc *meta.RPC) string {
+	m := slices.Clone(rpc.HttpMethods)
+	slices.Sort(m)
+	return strings.Join(m, ", ")
+}
+
+func pathString(path *meta.Path) string {
+	var b strings.Builder
+	for _, s := range path.GetSegments() {
+		b.WriteByte('/')
+		switch s.Type {
+		case meta.PathSegment_PARAM:
+			b.WriteByte(':')
+		case meta.PathSegment_WILDCARD:
+			b.WriteByte('*')
+		case meta.PathSegment_FALLBACK:
+			b.WriteByte('!')
+		}
+		b.WriteString(s.Value)
+	}
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
+
+func streaming(rpc *meta.RPC) string {
+	switch {
+	case rpc.StreamingRequest && rpc.StreamingResponse:
+		return "bidirectional"
+	case rpc.StreamingRequest:
+		return "request"
+	case rpc.StreamingResponse:
+		return "response"
+	default:
+		return "none"
+	}
+}
+
+func accessName(access meta.RPC_AccessType) string {
+	switch access {
+	case meta.RPC_PUBLIC:
+		return "public"
+	case meta.RPC_AUTH:
+		return "auth"
+	default:
+		return "private"
+	}
+}
+
+// accessRank ranks access types by how many callers can call the endpoint.
+func accessRank(access meta.RPC_AccessType) int {
+	switch access {
+	case meta.RPC_PUBLIC:
+		return 2
+	case meta.RPC_AUTH:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func optionalName(optional bool) string {
+	if optional {
+		return "optional"
+	}
+	return "required"
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,192 @@
+package apichangelog
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func builtin(b schema.Builtin) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Builtin{Builtin: b}}
+}
+
+func named(id uint32) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: id}}}
+}
+
+func list(elem *schema.Type) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_List{List: &schema.List{Elem: elem}}}
+}
+
+func strct(fields ...*schema.Field) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: fields}}}
+}
+
+func rpc(svc, name, method string, access meta.RPC_AccessType, segments ...string) *meta.RPC {
+	path := &meta.Path{}
+	for _, s := range segments {
+		seg := &meta.PathSegment{Type: meta.PathSegment_LITERAL, Value: s}
+		if s[0] == ':' {
+			seg.Type, seg.Value = meta.PathSegment_PARAM, s[1:]
+		}
+		path.Segments = append(path.Segments, seg)
+	}
+	return &meta.RPC{Name: name, ServiceName: svc, HttpMethods: []string{method}, AccessType: access, Path: path}
+}
+
+func TestCompare_Endpoints(t *testing.T) {
+	c := qt.New(t)
+
+	old := &meta.Data{
+		Decls: []*schema.Decl{
+			{Id: 0, Name: "User", Type: strct(
+				&schema.Field{Name: "ID", JsonName: "id", Typ: builtin(schema.Builtin_INT64)},
+				&schema.Field{Name: "Email", JsonName: "email", Typ: builtin(schema.Builtin_STRING)},
+				&schema.Field{Name: "Nick", JsonName: "nick", Typ: builtin(schema.Builtin_STRING), Optional: true},
+			)},
+			{Id: 1, Name: "CreateParams", Type: strct(
+				&schema.Field{Name: "Email", JsonName: "email", Typ: builtin(schema.Builtin_STRING)},
+			)},
+			{Id: 2, Name: "ListResponse", Type: strct(
+				&schema.Field{Name: "Users", JsonName: "users", Typ: list(named(0))},
+			)},
+		},
+		Svcs: []*meta.Service{{Name: "user", Rpcs: []*meta.RPC{
+			rpc("user", "Get", "GET", meta.RPC_PUBLIC, "user", ":id"),
+			rpc("user", "Create", "POST", meta.RPC_PUBLIC, "user"),
+			rpc("user", "List", "GET", meta.RPC_AUTH, "users"),
+			rpc("user", "Legacy", "GET", meta.RPC_PUBLIC, "legacy"),
+		}}},
+	}
+	old.Svcs[0].Rpcs[0].ResponseSchema = named(0)
+	old.Svcs[0].Rpcs[1].RequestSchema = named(1)
+	old.Svcs[0].Rpcs[2].ResponseSchema = named(2)
+
+	new := &meta.Data{
+		Decls: []*schema.Decl{
+			// The user type is renamed, which is not a wire change,
+			// and the email field is removed.
+			{Id: 0, Name: "Account", Type: strct(
+				&schema.Field{Name: "ID", JsonName: "id", Typ: builtin(schema.Builtin_INT64)},
+				&schema.Field{Name: "Nick", JsonName: "nick", Typ: builtin(schema.Builtin_STRING)},
+			)},
+			{Id: 1, Name: "CreateParams", Type: strct(
+				&schema.Field{Name: "Email", JsonName: "email", Typ: builtin(schema.Builtin_STRING)},
+				&schema.Field{Name: "Name", JsonName: "name", Typ: builtin(schema.Builtin_STRING)},
+				&schema.Field{Name: "Tag", JsonName: "tag", Typ: builtin(schema.Builtin_STRING), Optional: true},
+			)},
+			{Id: 2, Name: "ListResponse", Type: strct(
+				&schema.Field{Name: "Users", JsonName: "users", Typ: list(named(0))},
+			)},
+		},
+		Svcs: []*meta.Service{{Name: "user", Rpcs: []*meta.RPC{
+			rpc("user", "Get", "GET", meta.RPC_PUBLIC, "user", ":id"),
+			rpc("user", "Create", "POST", meta.RPC_PUBLIC, "users"),
+			rpc("user", "List", "GET", meta.RPC_PUBLIC, "users"),
+			rpc("user", "Delete", "DELETE", meta.RPC_PRIVATE, "user", ":id"),
+		}}},
+	}
+	new.Svcs[0].Rpcs[0].ResponseSchema = named(0)
+	new.Svcs[0].Rpcs[1].RequestSchema = named(1)
+	new.Svcs[0].Rpcs[2].ResponseSchema = named(2)
+	notice := "Use Search instead."
+	new.Svcs[0].Rpcs[2].Deprecated = &notice
+
+	got := Compare(old, new)
+	c.Assert(got.Breaking(), qt.IsTrue)
+	c.Assert(got.Topics, qt.HasLen, 0)
+	c.Assert(got.Endpoints, qt.DeepEquals, []*EndpointChange{
+		{
+			Kind: Changed, Service: "user", Endpoint: "Create",
+			Method: "POST", Path: "/users", Access: "public", Breaking: true,
+			Details: []Detail{
+				{Desc: "path changed from /user to /users", Breaking: true},
+				{Desc: "added required request field name (string)", Breaking: true},
+				{Desc: "added optional request field tag (string)", Breaking: false},
+			},
+		},
+		{
+			Kind: Added, Service: "user", Endpoint: "Delete",
+			Method: "DELETE", Path: "/user/:id", Access: "private",
+		},
+		{
+			Kind: Changed, Service: "user", Endpoint: "Get",
+			Method: "GET", Path: "/user/:id", Access: "public", Breaking: true,
+			Details: []Detail{
+				{Desc: "removed response field email", Breaking: true},
+				{Desc: "response field nick is now required", Breaking: false},
+			},
+		},
+		{
+			Kind: Removed, Service: "user", Endpoint: "Legacy",
+			Method: "GET", Path: "/legacy", Access: "public", Breaking: true,
+		},
+		{
+			Kind: Changed, Service: "user", Endpoint: "List",
+			Method: "GET", Path: "/users", Access: "public", Breaking: true,
+			Details: []Detail{
+				{Desc: "access changed from auth to public", Breaking: false},
+				{Desc: "deprecated: Use Search instead.", Breaking: false},
+				{Desc: "removed response field users[].email", Breaking: true},
+				{Desc: "response field users[].nick is now required", Breaking: false},
+			},
+		},
+	})
+}
+
+func TestCompare_Topics(t *testing.T) {
+	c := qt.New(t)
+
+	old := &meta.Data{
+		Decls: []*schema.Decl{
+			{Id: 0, Name: "Event", Type: strct(
+				&schema.Field{Name: "ID", JsonName: "id", Typ: builtin(schema.Builtin_INT64)},
+			)},
+		},
+		PubsubTopics: []*meta.PubSubTopic{
+			{Name: "events", MessageType: named(0)},
+			{Name: "legacy", MessageType: named(0)},
+		},
+	}
+	new := &meta.Data{
+		Decls: []*schema.Decl{
+			{Id: 0, Name: "Event", Type: strct(
+				&schema.Field{Name: "ID", JsonName: "id", Typ: builtin(schema.Builtin_STRING)},
+				&schema.Field{Name: "Source", JsonName: "source", Typ: builtin(schema.Builtin_STRING), Optional: true},
+			)},
+		},
+		PubsubTopics: []*meta.PubSubTopic{
+			{Name: "events", MessageType: named(0)},
+			{Name: "signups", MessageType: named(0)},
+		},
+	}
+
+	got := Compare(old, new)
+	c.Assert(got.Endpoints, qt.HasLen, 0)
+	c.Assert(got.Topics, qt.DeepEquals, []*TopicChange{
+		{
+			Kind: Changed, Topic: "events", Breaking: true,
+			Details: []Detail{
+				{Desc: "message field id changed type from int64 to string", Breaking: true},
+				{Desc: "added optional message field source (string)", Breaking: false},
+			},
+		},
+		{Kind: Removed, Topic: "legacy", Breaking: true},
+		{Kind: Added, Topic: "signups"},
+	})
+}
+
+func TestCompare_NoChanges(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		Svcs: []*meta.Service{{Name: "user", Rpcs: []*meta.RPC{
+			rpc("user", "Get", "GET", meta.RPC_PUBLIC, "user", ":id"),
+		}}},
+	}
+	got := Compare(md, md)
+	c.Assert(got.Empty(), qt.IsTrue)
+	c.Assert(got.Breaking(), qt.IsFalse)
+}
@@ -0,0 +1,192 @@
+package apichangelog
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// field describes a field of a schema, as seen on the wire.
+type field struct {
+	Type     string
+	Optional bool
+}
+
+// fields returns the fields of the struct schema typ, keyed by their
+// wire names. Fields of nested structs are included using dotted names,
+// such as "user.email", with "[]" and "{}" denoting the elements of lists
+// and maps, such as "users[].email".
+//
+// Types are described as they are encoded, so named types are replaced
+// by their underlying types and structs are described as "object".
+// This way changes that don't affect the wire format are not reported.
+func fields(md *meta.Data, typ *schema.Type) map[string]field {
+	f := &flattener{
+		decls:    make(map[uint32]*schema.Decl, len(md.Decls)),
+		visiting: make(map[uint32]bool),
+		fields:   make(map[string]field),
+	}
+	for _, d := range md.Decls {
+		f.decls[d.Id] = d
+	}
+	if typ != nil {
+		f.walk("", typ, nil)
+	}
+	return f.fields
+}
+
+type flattener struct {
+	decls    map[uint32]*schema.Decl
+	visiting map[uint32]bool // decls currently being walked, to handle recursive types
+	fields   map[string]field
+}
+
+// typeArgs maps declaration ids to the type arguments they are instantiated with.
+type typeArgs map[uint32][]*schema.Type
+
+// walk adds the fields of typ to f.fields, if it's a struct (or a list or map of structs).
+func (f *flattener) walk(prefix string, typ *schema.Type, args typeArgs) {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl, ok := f.decls[t.Named.Id]
+		if !ok || f.visiting[decl.Id] {
+			return
+		}
+		f.visiting[decl.Id] = true
+		defer delete(f.visiting, decl.Id)
+		f.walk(prefix, decl.Type, args.with(decl.Id, t.Named.TypeArguments))
+
+	case *schema.Type_Struct:
+		for _, fld := range t.Struct.Fields {
+			name := wireName(fld)
+			if name == "" {
+				continue
+			}
+			name = prefix + name
+
+			_, isOption := fld.Typ.Typ.(*schema.Type_Option)
+			f.fields[name] = field{
+				Type:     f.typeString(fld.Typ, args),
+				Optional: fld.Optional || isOption,
+			}
+			f.walk(name+".", fld.Typ, args)
+		}
+
+	case *schema.Type_List:
+		f.walk(strings.TrimSuffix(prefix, ".")+"[].", t.List.Elem, args)
+	case *schema.Type_Map:
+		f.walk(strings.TrimSuffix(prefix, ".")+"{}.", t.Map.Value, args)
+	case *schema.Type_Pointer:
+		f.walk(prefix, t.Pointer.Base, args)
+	case *schema.Type_Option:
+		f.walk(prefix, t.Option.Value, args)
+	case *schema.Type_Config:
+		f.walk(prefix, t.Config.Elem, args)
+	case *schema.Type_TypeParameter:
+		if arg := args.lookup(t.TypeParameter); arg != nil {
+			f.walk(prefix, arg, args)
+		}
+	}
+}
+
+// typeString describes typ as it's encoded on the wire.
+func (f *flattener) typeString(typ *schema.Type, args typeArgs) string {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl, ok := f.decls[t.Named.Id]
+		if !ok {
+			return "unknown"
+		}
+		if _, isStruct := decl.Type.Typ.(*schema.Type_Struct); isStruct || f.visiting[decl.Id] {
+			return "object"
+		}
+		f.visiting[decl.Id] = true
+		defer delete(f.visiting, decl.Id)
+		return f.typeString(decl.Type, args.with(decl.Id, t.Named.TypeArguments))
+
+	case *schema.Type_Struct:
+		return "object"
+	case *schema.Type_List:
+		return "[]" + f.typeString(t.List.Elem, args)
+	case *schema.Type_Map:
+		return "map[" + f.typeString(t.Map.Key, args) + "]" + f.typeString(t.Map.Value, args)
+	case *schema.Type_Builtin:
+		return strings.ToLower(t.Builtin.String())
+	case *schema.Type_Pointer:
+		return f.typeString(t.Pointer.Base, args)
+	case *schema.Type_Option:
+		return f.typeString(t.Option.Value, args)
+	case *schema.Type_Config:
+		return f.typeString(t.Config.Elem, args)
+
+	case *schema.Type_Union:
+		types := make([]string, len(t.Union.Types))
+		for i, u := range t.Union.Types {
+			types[i] = f.typeString(u, args)
+		}
+		return strings.Join(types, " | ")
+
+	case *schema.Type_Literal:
+		switch v := t.Literal.Value.(type) {
+		case *schema.Literal_Str:
+			return strconv.Quote(v.Str)
+		case *schema.Literal_Boolean:
+			return strconv.FormatBool(v.Boolean)
+		case *schema.Literal_Int:
+			return strconv.FormatInt(v.Int, 10)
+		case *schema.Literal_Float:
+			return strconv.FormatFloat(v.Float, 'g', -1, 64)
+		case *schema.Literal_Null:
+			return "null"
+		}
+
+	case *schema.Type_TypeParameter:
+		if arg := args.lookup(t.TypeParameter); arg != nil {
+			return f.typeString(arg, args)
+		}
+	}
+	return "unknown"
+}
+
+// with returns a copy of args where the type parameters
+// of the given declaration are bound to typeArgs.
+func (args typeArgs) with(declID uint32, typeArgs []*schema.Type) typeArgs {
+	if len(typeArgs) == 0 {
+		return args
+	}
+	res := make(map[uint32][]*schema.Type, len(args)+1)
+	for k, v := range args {
+		res[k] = v
+	}
+	res[declID] = typeArgs
+	return res
+}
+
+func (args typeArgs) lookup(ref *schema.TypeParameterRef) *schema.Type {
+	if a := args[ref.DeclId]; int(ref.ParamIdx) < len(a) {
+		return a[ref.ParamIdx]
+	}
+	return nil
+}
+
+// wireName returns the name of the field on the wire,
+// or "" if the field is not encoded.
+func wireName(f *schema.Field) string {
+	switch w := f.Wire.GetLocation().(type) {
+	case *schema.WireSpec_Header_:
+		return "header " + cmp.Or(w.Header.GetName(), f.Name)
+	case *schema.WireSpec_Query_:
+		return "query " + cmp.Or(w.Query.GetName(), f.Name)
+	case *schema.WireSpec_Cookie_:
+		return "cookie " + cmp.Or(w.Cookie.GetName(), f.Name)
+	case *schema.WireSpec_HttpStatus_:
+		return ""
+	}
+	if f.JsonName == "-" {
+		return ""
+	}
+	return cmp.Or(f.JsonName, f.Name)
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/tailscale/hujson"
 
@@ -64,6 +66,115 @@ type File struct {
 	// LogLevel is the minimum log level for the app.
 	// If empty it defaults to "trace".
 	LogLevel string `json:"log_level,omitempty"`
+
+	// Local contains settings for running the app locally.
+	Local Local `json:"local,omitempty"`
+
+	// SLO declares the service level objectives for the app's endpoints,
+	// as reported by "encore slo report".
+	SLO *SLO `json:"slo,omitempty"`
+}
+
+// SLO declares service level objectives for the app's endpoints.
+// The targets apply to every endpoint unless overridden in Endpoints.
+type SLO struct {
+	SLOTarget
+
+	// Endpoints overrides the targets for individual endpoints,
+	// keyed by "service.Endpoint".
+	Endpoints map[string]SLOTarget `json:"endpoints,omitempty"`
+}
+
+// SLOTarget is a set of service level objectives.
+type SLOTarget struct {
+	// Availability is the minimum percentage of requests that must succeed, such as 99.9.
+	// Zero means no availability target.
+	Availability float64 `json:"availability,omitempty"`
+
+	// LatencyMs is the maximum latency in milliseconds, keyed by
+	// percentile: "p50", "p90", "p95" or "p99".
+	LatencyMs map[string]float64 `json:"latency_ms,omitempty"`
+}
+
+// SLOPercentiles are the latency percentiles SLO targets can be declared for.
+var SLOPercentiles = []string{"p50", "p90", "p95", "p99"}
+
+func (s *SLO) validate() error {
+	if s == nil {
+		return nil
+	}
+	check := func(where string, t SLOTarget) error {
+		if t.Availability < 0 || t.Availability > 100 {
+			return fmt.Errorf("slo%s: availability must be between 0 and 100, got %v", where, t.Availability)
+		}
+		for p := range t.LatencyMs {
+			if !slices.Contains(SLOPercentiles, p) {
+				return fmt.Errorf("slo%s: unknown latency percentile %q (must be one of %v)", where, p, SLOPercentiles)
+			}
+		}
+		return nil
+	}
+
+	if err := check("", s.SLOTarget); err != nil {
+		return err
+	}
+	for name, t := range s.Endpoints {
+		if err := check(fmt.Sprintf(".endpoints[%q]", name), t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// For returns the targets for the given endpoint,
+// with the endpoint's overrides applied to the app-wide targets.
+func (s *SLO) For(service, endpoint string) SLOTarget {
+	if s == nil {
+		return SLOTarget{}
+	}
+
+	t := SLOTarget{
+		Availability: s.Availability,
+		LatencyMs:    maps.Clone(s.LatencyMs),
+	}
+	if o, ok := s.Endpoints[service+"."+endpoint]; ok {
+		if o.Availability != 0 {
+			t.Availability = o.Availability
+		}
+		if len(o.LatencyMs) > 0 && t.LatencyMs == nil {
+			t.LatencyMs = make(map[string]float64, len(o.LatencyMs))
+		}
+		maps.Copy(t.LatencyMs, o.LatencyMs)
+	}
+	return t
+}
+
+// Local contains settings for running the app locally with "encore run".
+type Local struct {
+	// Hostname is the hostname to use in the URLs reported by
+	// "encore daemon urls", for example to reach the app from a
+	// mobile emulator or another machine. If empty it defaults to "localhost".
+	Hostname string `json:"hostname,omitempty"`
+
+	// ServicePorts are fixed ports for services to listen on, keyed by service name.
+	// They only apply when running with a process per service.
+	// Services without a fixed port listen on a random free port.
+	ServicePorts map[string]int `json:"service_ports,omitempty"`
+
+	// GatewayPorts are fixed ports for gateways to listen on, keyed by gateway name.
+	// They only apply when running with a process per service.
+	// Gateways without a fixed port listen on a random free port.
+	GatewayPorts map[string]int `json:"gateway_ports,omitempty"`
+
+	// ResourcePorts are fixed ports for the local infrastructure servers,
+	// keyed by resource type: "redis", "pubsub" or "objects".
+	// Resources without a fixed port listen on a random free port.
+	ResourcePorts map[string]int `json:"resource_ports,omitempty"`
+}
+
+// HasFixedProcessPorts reports whether any service or gateway has a fixed port.
+func (l Local) HasFixedProcessPorts() bool {
+	return len(l.ServicePorts) > 0 || len(l.GatewayPorts) > 0
 }
 
 type Build struct {
@@ -146,6 +257,10 @@ func Parse(data []byte) (*File, error) {
 		return nil, fmt.Errorf("appfile.Parse: invalid lang %q", f.Lang)
 	}
 
+	if err := f.SLO.validate(); err != nil {
+		return nil, fmt.Errorf("appfile.Parse: %v", err)
+	}
+
 	// Parse deprecated fields into the new Build struct.
 	f.Build.CgoEnabled = f.Build.CgoEnabled || f.CgoEnabled
 	if f.Build.Docker.BaseImage == "" {
@@ -0,0 +1,117 @@
+package appfile
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParse_SLO(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: `{"slo": {
+				"availability": 99.9,
+				"latency_ms": {"p99": 500},
+				"endpoints": {"svc.Foo": {"availability": 100, "latency_ms": {"p50": 10}}}
+			}}`,
+		},
+		{
+			name:    "availability_too_high",
+			data:    `{"slo": {"availability": 100.1}}`,
+			wantErr: `slo: availability must be between 0 and 100, got 100.1`,
+		},
+		{
+			name:    "negative_availability",
+			data:    `{"slo": {"endpoints": {"svc.Foo": {"availability": -1}}}}`,
+			wantErr: `slo.endpoints\["svc.Foo"\]: availability must be between 0 and 100, got -1`,
+		},
+		{
+			name:    "unknown_percentile",
+			data:    `{"slo": {"latency_ms": {"p75": 100}}}`,
+			wantErr: `slo: unknown latency percentile "p75" .*`,
+		},
+		{
+			name:    "unknown_endpoint_percentile",
+			data:    `{"slo": {"endpoints": {"svc.Foo": {"latency_ms": {"max": 100}}}}}`,
+			wantErr: `slo.endpoints\["svc.Foo"\]: unknown latency percentile "max" .*`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			_, err := Parse([]byte(tt.data))
+			if tt.wantErr == "" {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, "appfile.Parse: "+tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSLO_For(t *testing.T) {
+	slo := &SLO{
+		SLOTarget: SLOTarget{
+			Availability: 99.9,
+			LatencyMs:    map[string]float64{"p95": 200, "p99": 500},
+		},
+		Endpoints: map[string]SLOTarget{
+			"svc.Latency":      {LatencyMs: map[string]float64{"p99": 1000, "p50": 50}},
+			"svc.Availability": {Availability: 99.99},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		slo      *SLO
+		endpoint string
+		want     SLOTarget
+	}{
+		{
+			name:     "no_slo",
+			endpoint: "Foo",
+			want:     SLOTarget{},
+		},
+		{
+			name:     "app_wide",
+			slo:      slo,
+			endpoint: "Foo",
+			want:     SLOTarget{Availability: 99.9, LatencyMs: map[string]float64{"p95": 200, "p99": 500}},
+		},
+		{
+			name:     "latency_override",
+			slo:      slo,
+			endpoint: "Latency",
+			want:     SLOTarget{Availability: 99.9, LatencyMs: map[string]float64{"p50": 50, "p95": 200, "p99": 1000}},
+		},
+		{
+			name:     "availability_override",
+			slo:      slo,
+			endpoint: "Availability",
+			want:     SLOTarget{Availability: 99.99, LatencyMs: map[string]float64{"p95": 200, "p99": 500}},
+		},
+		{
+			name: "override_without_app_wide_latency",
+			slo: &SLO{Endpoints: map[string]SLOTarget{
+				"svc.Foo": {LatencyMs: map[string]float64{"p90": 100}},
+			}},
+			endpoint: "Foo",
+			want:     SLOTarget{LatencyMs: map[string]float64{"p90": 100}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			c.Assert(tt.slo.For("svc", tt.endpoint), qt.DeepEquals, tt.want)
+		})
+	}
+
+	// Overrides must not leak into the app-wide targets.
+	c := qt.New(t)
+	c.Assert(slo.LatencyMs, qt.DeepEquals, map[string]float64{"p95": 200, "p99": 500})
+}
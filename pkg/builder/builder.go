@@ -25,7 +25,7 @@ import (
 
 var LocalBuildTags = []string{
 	"encore_local",
-	"encore_no_gcp", "encore_no_aws", "encore_no_azure",
+	"encore_no_gcp", "encore_no_aws", "encore_no_azure", "encore_no_kafka",
 	"encore_no_datadog", "encore_no_prometheus",
 }
 
@@ -272,6 +272,10 @@ type ServiceConfigsParams struct {
 type ServiceConfigsResult struct {
 	Configs     map[string]string
 	ConfigFiles fs.FS
+
+	// ConfigLayers are the layers of config files each
+	// service's config was merged from, keyed by service name.
+	ConfigLayers map[string][]cueutil.Layer
 }
 
 type Impl interface {
@@ -10,6 +10,7 @@ import (
 
 	"encr.dev/pkg/clientgen/clientgentypes"
 	"encr.dev/pkg/clientgen/openapi"
+	"encr.dev/pkg/clientgen/protobuf"
 	"encr.dev/pkg/errinsrc/srcerrors"
 	meta "encr.dev/proto/encore/parser/meta/v1"
 )
@@ -24,6 +25,7 @@ const (
 	LangJavascript Lang = "javascript"
 	LangGo         Lang = "go"
 	LangOpenAPI    Lang = "openapi"
+	LangProtobuf   Lang = "proto"
 )
 
 type generator interface {
@@ -44,6 +46,8 @@ func Detect(path string) (lang Lang, ok bool) {
 		return LangJavascript, true
 	case ".go":
 		return LangGo, true
+	case ".proto":
+		return LangProtobuf, true
 	default:
 		return LangUnknown, false
 	}
@@ -80,6 +84,8 @@ func Client(
 		gen = &golang{generatorVersion: goGenLatestVersion}
 	case LangOpenAPI:
 		gen = openapi.New(openapi.LatestVersion)
+	case LangProtobuf:
+		gen = protobuf.New(protobuf.LatestVersion)
 	default:
 		return nil, ErrUnknownLang
 	}
@@ -111,6 +117,8 @@ func GetLang(lang string) (Lang, error) {
 		return LangGo, nil
 	case "openapi", "swagger", "oas":
 		return LangOpenAPI, nil
+	case "proto", "protobuf", "grpc":
+		return LangProtobuf, nil
 	default:
 		return LangUnknown, ErrUnknownLang
 	}
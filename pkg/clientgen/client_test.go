@@ -162,3 +162,154 @@ func TestClientCodeGenerationFromTSApp(t *testing.T) {
 		})
 	}
 }
+
+// TestEventStreamClientGeneration tests the generated clients for endpoints
+// streaming server-sent events. The test apps can't depend on a runtime
+// providing stream.Events, so the endpoint is marked as streaming events
+// after parsing.
+func TestEventStreamClientGeneration(t *testing.T) {
+	c := qt.New(t)
+
+	ar := txtar.Parse([]byte(`-- go.mod --
+module app
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import "context"
+
+type Params struct {
+    Topic string ` + "`query:\"topic\"`" + `
+}
+
+type Tick struct {
+    Count   int    ` + "`json:\"count\"`" + `
+    Message string ` + "`json:\"message\"`" + `
+}
+
+// Ticks streams ticks for a topic.
+//encore:api public method=GET path=/ticks/:name
+func Ticks(ctx context.Context, name string, p *Params) (*Tick, error) {
+    return nil, nil
+}
+`))
+	base := t.TempDir()
+	c.Assert(txtar.Write(ar, base), qt.IsNil)
+
+	res, err := v2builder.New().Parse(context.Background(), builder.ParseParams{
+		Build:      builder.DefaultBuildInfo(),
+		App:        apps.NewInstance(base, "app", ""),
+		WorkingDir: ".",
+	})
+	c.Assert(err, qt.IsNil)
+	res.Meta.Svcs[0].Rpcs[0].ServerSentEvents = true
+
+	for _, name := range []string{"expected_sse_golang.go", "expected_sse_typescript.ts"} {
+		c.Run(name, func(c *qt.C) {
+			language, ok := Detect(name)
+			c.Assert(ok, qt.IsTrue)
+			generatedClient, err := Client(
+				language,
+				"app",
+				res.Meta,
+				clientgentypes.AllServices(res.Meta),
+				clientgentypes.TagSet{},
+				clientgentypes.Options{},
+			)
+			c.Assert(err, qt.IsNil)
+			golden.TestAgainst(c, "goapp/"+name, string(generatedClient))
+		})
+	}
+}
+
+// TestStreamClientGeneration tests the generated clients for streaming endpoints.
+// Like TestEventStreamClientGeneration the endpoints are turned into streams
+// after parsing, as Go apps can't declare streaming endpoints.
+func TestStreamClientGeneration(t *testing.T) {
+	c := qt.New(t)
+
+	ar := txtar.Parse([]byte(`-- go.mod --
+module app
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import "context"
+
+type Handshake struct {
+    Name  string ` + "`query:\"name\"`" + `
+    Token string ` + "`header:\"X-Token\"`" + `
+}
+
+type Message struct {
+    Text string ` + "`json:\"text\"`" + `
+}
+
+type Summary struct {
+    Count int ` + "`json:\"count\"`" + `
+}
+
+// Chat chats in a room.
+//encore:api public method=GET path=/chat/:room
+func Chat(ctx context.Context, room string, p *Handshake) (*Message, error) {
+    return nil, nil
+}
+
+// Feed streams the message feed.
+//encore:api public method=GET path=/feed
+func Feed(ctx context.Context) (*Message, error) {
+    return nil, nil
+}
+
+// Upload uploads messages.
+//encore:api public method=POST path=/upload
+func Upload(ctx context.Context, p *Message) (*Summary, error) {
+    return nil, nil
+}
+`))
+	base := t.TempDir()
+	c.Assert(txtar.Write(ar, base), qt.IsNil)
+
+	res, err := v2builder.New().Parse(context.Background(), builder.ParseParams{
+		Build:      builder.DefaultBuildInfo(),
+		App:        apps.NewInstance(base, "app", ""),
+		WorkingDir: ".",
+	})
+	c.Assert(err, qt.IsNil)
+
+	// Use the request params of Chat as its handshake
+	for _, rpc := range res.Meta.Svcs[0].Rpcs {
+		switch rpc.Name {
+		case "Chat":
+			rpc.StreamingRequest, rpc.StreamingResponse = true, true
+			rpc.HandshakeSchema, rpc.RequestSchema = rpc.RequestSchema, rpc.ResponseSchema
+		case "Feed":
+			rpc.StreamingResponse = true
+		case "Upload":
+			rpc.StreamingRequest = true
+		}
+	}
+
+	for _, name := range []string{"expected_stream_golang.go", "expected_stream_typescript.ts"} {
+		c.Run(name, func(c *qt.C) {
+			language, ok := Detect(name)
+			c.Assert(ok, qt.IsTrue)
+			generatedClient, err := Client(
+				language,
+				"app",
+				res.Meta,
+				clientgentypes.AllServices(res.Meta),
+				clientgentypes.TagSet{},
+				clientgentypes.Options{},
+			)
+			c.Assert(err, qt.IsNil)
+			golden.TestAgainst(c, "goapp/"+name, string(generatedClient))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"unicode"
@@ -46,6 +47,11 @@ type golang struct {
 
 	seenSlicePath   bool
 	seenLiteralNull bool
+	seenEventStream bool
+	seenFile        bool
+	hasStreams      bool // whether any streaming endpoints are included in the client
+
+	errorDefs []*meta.ErrorDefinition // the error definitions to expose as error ids
 }
 
 func GenTypes(md *meta.Data, typs ...*schema.Decl) ([]byte, error) {
@@ -66,6 +72,7 @@ func GenTypes(md *meta.Data, typs ...*schema.Decl) ([]byte, error) {
 func (g *golang) Generate(p clientgentypes.GenerateParams) (err error) {
 	g.md = p.Meta
 	g.enc = gocodegen.NewMarshallingCodeGenerator(gocodegen.UnknownPkgPath, "serde", true)
+	g.errorDefs = errorDefinitions(p.Meta, p.Services)
 
 	namedTypes := getNamedTypes(p.Meta, p.Services)
 
@@ -73,6 +80,18 @@ func (g *golang) Generate(p clientgentypes.GenerateParams) (err error) {
 	file := NewFile("client")
 	file.HeaderComment(doNotEditHeader())
 
+	// Streams need an option and a field in the base client, so work out upfront if there are any
+	for _, service := range p.Meta.Svcs {
+		if !p.Services.Has(service.Name) {
+			continue
+		}
+		for _, rpc := range service.Rpcs {
+			if rpc.AccessType != meta.RPC_PRIVATE && isStreamingRPC(rpc) {
+				g.hasStreams = true
+			}
+		}
+	}
+
 	// Generate the parent Client struct
 	g.generateClient(file, p.AppSlug, p.Services)
 
@@ -254,6 +273,20 @@ Defaults to http.DefaultClient`,
 		},
 	)
 
+	if g.hasStreams {
+		g.generateOptionFunc(
+			file,
+			"StreamOptions",
+			`can be used to configure how streams to streaming API endpoints behave,
+such as how they reconnect when the connection is lost.`,
+			&Statement{Id("opts").Id("StreamOptions")},
+			&Statement{
+				Id("base").Dot("streamOptions").Op("=").Id("opts"),
+				Return(Nil()),
+			},
+		)
+	}
+
 	if g.md.AuthHandler != nil {
 		typ := g.getType(g.md.AuthHandler.Params)
 		rawType := typ
@@ -338,11 +371,6 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 			continue
 		}
 
-		// streaming endpoints not supported yet
-		if rpc.StreamingRequest || rpc.StreamingResponse {
-			continue
-		}
-
 		// Add the documentation for the API to the interface method
 		if rpc.Doc != nil && !g.skipDocs {
 			// Add a newline if this is not the first method
@@ -375,11 +403,6 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 			continue
 		}
 
-		// streaming endpoints not supported yet
-		if rpc.StreamingRequest || rpc.StreamingResponse {
-			continue
-		}
-
 		if rpc.Doc != nil && *rpc.Doc != "" && !g.skipDocs {
 			for _, line := range strings.Split(strings.TrimSpace(*rpc.Doc), "\n") {
 				if line != "" {
@@ -389,6 +412,9 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 		}
 
 		callSite, err := g.rpcCallSite(rpc)
+		if isStreamingRPC(rpc) {
+			callSite, err = g.streamCallSite(rpc)
+		}
 		if err != nil {
 			return errors.Wrapf(err, "rpc: %s", rpc.Name)
 		}
@@ -466,6 +492,11 @@ func (g *golang) rpcParams(rpc *meta.RPC) Code {
 
 	if rpc.Proto == meta.RPC_RAW {
 		params = append(params, Id("request").Op("*").Qual("net/http", "Request"))
+	} else if isStreamingRPC(rpc) {
+		// The messages are sent on the stream, so only the handshake is a parameter
+		if rpc.HandshakeSchema != nil {
+			params = append(params, Id("params").Add(g.getType(rpc.HandshakeSchema)))
+		}
 	} else {
 		if rpc.RequestSchema != nil {
 			params = append(params, Id("params").Add(g.getType(rpc.RequestSchema)))
@@ -505,10 +536,26 @@ func (g *golang) rpcReturnType(rpc *meta.RPC, concreteImpl bool) Code {
 		return Params(Op("*").Qual("net/http", "Response"), Error())
 	}
 
+	if isStreamingRPC(rpc) {
+		stream := Op("*").Add(g.streamType(rpc))
+		if concreteImpl {
+			return Params(Id("resp").Add(stream), Err().Error())
+		}
+		return Params(stream, Error())
+	}
+
 	if rpc.ResponseSchema == nil {
 		return Error()
 	}
 
+	if rpc.ServerSentEvents {
+		stream := Op("*").Id("EventStream").Types(g.getType(rpc.ResponseSchema))
+		if concreteImpl {
+			return Params(Id("resp").Add(stream), Err().Error())
+		}
+		return Params(stream, Error())
+	}
+
 	if concreteImpl {
 		// For the concrete implementation we want the response type to be named so we can
 		// refer to it without having to define a variable.
@@ -581,45 +628,14 @@ func (g *golang) rpcCallSite(rpc *meta.RPC) (code []Code, err error) {
 		}
 
 		enc := g.enc.NewPossibleInstance("reqEncoder")
-
-		// Generate the headers
-		if len(reqEnc.HeaderParameters) > 0 {
-			values := Dict{}
-
-			for _, field := range reqEnc.HeaderParameters {
-				slice, err := enc.ToStringSlice(
-					field.Type,
-					Id("params").Dot(field.SrcName),
-				)
-				if err != nil {
-					return nil, errors.Wrapf(err, "unable to encode header %s", field.SrcName)
-				}
-				values[Lit(field.WireFormat)] = slice
-			}
-
-			headers = Id("headers")
-			enc.Add(Id("headers").Op(":=").Qual("net/http", "Header").Values(values), Line())
+		headers, withQueryString, err = g.encodeHeadersAndQuery(enc, reqEnc)
+		if err != nil {
+			return nil, err
 		}
-
-		// Generate the query string
-		if len(reqEnc.QueryParameters) > 0 {
-			withQueryString = true
-			values := Dict{}
-
-			// Check the request schema for fields we can put in the query string
-			for _, field := range reqEnc.QueryParameters {
-				slice, err := enc.ToStringSlice(
-					field.Type,
-					Id("params").Dot(field.SrcName),
-				)
-				if err != nil {
-					return nil, errors.Wrapf(err, "unable to encode query fields %s", field.SrcName)
-				}
-
-				values[Lit(field.WireFormat)] = slice
+		if reqEnc.Multipart() {
+			if err := g.encodeMultipartForm(enc, reqEnc); err != nil {
+				return nil, err
 			}
-
-			enc.Add(Id("queryString").Op(":=").Qual("net/url", "Values").Values(values), Line())
 		}
 
 		if rpc.ResponseSchema != nil {
@@ -640,7 +656,9 @@ func (g *golang) rpcCallSite(rpc *meta.RPC) (code []Code, err error) {
 		}
 
 		// Generate the body
-		if len(reqEnc.BodyParameters) > 0 {
+		if reqEnc.Multipart() {
+			body = Id("form")
+		} else if len(reqEnc.BodyParameters) > 0 {
 			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
 				// In the simple case we can just encode the params as the body directly
 				body = Id("params")
@@ -667,6 +685,20 @@ func (g *golang) rpcCallSite(rpc *meta.RPC) (code []Code, err error) {
 		}
 	}
 
+	// Server-sent events are received as they're sent, using an EventStream
+	if rpc.ServerSentEvents {
+		g.seenEventStream = true
+		code = append(code, Return(Id("openEventStream").Types(g.getType(rpc.ResponseSchema)).Call(
+			Id("ctx"),
+			Id("c").Dot("base"),
+			Lit(rpcEncoding.DefaultMethod),
+			g.createApiPath(rpc, withQueryString),
+			headers,
+			body,
+		)))
+		return
+	}
+
 	// Make the request
 	resp := Nil()
 	apiCallCode := func() Code {
@@ -792,6 +824,146 @@ func goIdentifier(input string) string {
 	return output
 }
 
+// streamType returns the type of the stream to the streaming endpoint rpc.
+func (g *golang) streamType(rpc *meta.RPC) *Statement {
+	// Messages are empty if the endpoint doesn't declare a type for them
+	message := func(typ *schema.Type) Code {
+		if typ == nil {
+			return Struct()
+		}
+		return g.getType(typ)
+	}
+
+	switch {
+	case rpc.StreamingRequest && rpc.StreamingResponse:
+		return Id("StreamInOut").Types(message(rpc.RequestSchema), message(rpc.ResponseSchema))
+	case rpc.StreamingResponse:
+		return Id("StreamIn").Types(message(rpc.ResponseSchema))
+	default:
+		return Id("StreamOut").Types(message(rpc.RequestSchema), message(rpc.ResponseSchema))
+	}
+}
+
+func (g *golang) streamCallSite(rpc *meta.RPC) (code []Code, err error) {
+	headers := Nil()
+	withQueryString := false
+
+	// The handshake is sent in the headers and query string of the request opening the stream
+	if rpc.HandshakeSchema != nil {
+		encs, err := encoding.DescribeRequest(g.md, rpc.HandshakeSchema, nil, "GET")
+		if err != nil {
+			return nil, errors.Wrapf(err, "stream %s", rpc.Name)
+		}
+		handshakeEnc := encs[0]
+
+		if len(handshakeEnc.HeaderParameters) > 0 || len(handshakeEnc.QueryParameters) > 0 {
+			code = append(code, Comment("Convert our params into the objects we need for the request"))
+		}
+
+		enc := g.enc.NewPossibleInstance("reqEncoder")
+		headers, withQueryString, err = g.encodeHeadersAndQuery(enc, handshakeEnc)
+		if err != nil {
+			return nil, err
+		}
+		code = append(code, enc.Finalize(
+			Id("err").Op("=").Qual("fmt", "Errorf").Call(
+				Lit("unable to marshal parameters: %w"),
+				enc.LastError(),
+			),
+			Return(),
+		)...)
+	}
+
+	code = append(code,
+		List(Id("conn"), Err()).Op(":=").Id("dialStream").Call(
+			Id("ctx"),
+			Id("c").Dot("base"),
+			g.createApiPath(rpc, withQueryString),
+			headers,
+		),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Nil(), Err()),
+		),
+		Return(Op("&").Add(g.streamType(rpc)).Values(Dict{Id("conn"): Id("conn")}), Nil()),
+	)
+	return code, nil
+}
+
+// encodeHeadersAndQuery adds the code encoding the header and query string parameters
+// of the request params to enc, returning the headers to send, if any, and whether
+// there is a query string.
+func (g *golang) encodeHeadersAndQuery(enc *gocodegen.MarshallingCodeWrapper, reqEnc *encoding.RequestEncoding) (headers *Statement, withQueryString bool, err error) {
+	headers = Nil()
+
+	// Generate the headers
+	if len(reqEnc.HeaderParameters) > 0 {
+		values := Dict{}
+
+		for _, field := range reqEnc.HeaderParameters {
+			slice, err := enc.ToStringSlice(
+				field.Type,
+				Id("params").Dot(field.SrcName),
+			)
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "unable to encode header %s", field.SrcName)
+			}
+			values[Lit(field.WireFormat)] = slice
+		}
+
+		headers = Id("headers")
+		enc.Add(Id("headers").Op(":=").Qual("net/http", "Header").Values(values), Line())
+	}
+
+	// Generate the query string
+	if len(reqEnc.QueryParameters) > 0 {
+		withQueryString = true
+		values := Dict{}
+
+		// Check the request schema for fields we can put in the query string
+		for _, field := range reqEnc.QueryParameters {
+			slice, err := enc.ToStringSlice(
+				field.Type,
+				Id("params").Dot(field.SrcName),
+			)
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "unable to encode query fields %s", field.SrcName)
+			}
+
+			values[Lit(field.WireFormat)] = slice
+		}
+
+		enc.Add(Id("queryString").Op(":=").Qual("net/url", "Values").Values(values), Line())
+	}
+
+	return headers, withQueryString, nil
+}
+
+// encodeMultipartForm encodes the body parameters of a request containing files as a multipart form.
+func (g *golang) encodeMultipartForm(enc *gocodegen.MarshallingCodeWrapper, reqEnc *encoding.RequestEncoding) error {
+	g.seenFile = true
+	enc.Add(Id("form").Op(":=").Id("newMultipartForm").Call())
+	for _, field := range reqEnc.BodyParameters {
+		value := Id("params").Dot(field.SrcName)
+		if !encoding.IsFileType(field.Type) {
+			slice, err := enc.ToStringSlice(field.Type, value)
+			if err != nil {
+				return errors.Wrapf(err, "unable to encode form field %s", field.SrcName)
+			}
+			if _, ok := field.Type.Typ.(*schema.Type_Builtin); ok {
+				// Builtins are encoded as the elements of a slice literal
+				slice = Index().String().Add(slice)
+			}
+			enc.Add(Id("form").Dot("addValues").Call(Lit(field.WireFormat), slice))
+		} else if field.Type.GetList() != nil {
+			enc.Add(Id("form").Dot("addFiles").Call(Lit(field.WireFormat), value.Op("...")))
+		} else {
+			enc.Add(Id("form").Dot("addFiles").Call(Lit(field.WireFormat), value))
+		}
+	}
+	enc.Add(Line())
+	return nil
+}
+
 func (g *golang) declToID(decl *schema.Decl) *Statement {
 	if g.skipPkgTypePrefix {
 		return Id(goIdentifier(strings.Title(decl.Name)))
@@ -889,6 +1061,9 @@ func (g *golang) getType(typ *schema.Type) Code {
 		case schema.Builtin_UUID, schema.Builtin_USER_ID, schema.Builtin_DECIMAL:
 			// we don't want to add any custom deps, so these come in as strings
 			return String()
+		case schema.Builtin_FILE:
+			g.seenFile = true
+			return Id("File")
 		default:
 			return Any()
 		}
@@ -926,6 +1101,18 @@ func (g *golang) getType(typ *schema.Type) Code {
 					panic("raw tags failed to parse") // This shouldn't happen at runtime, because the parser should have caught this
 				}
 
+				// Fields tagged with encore:"string" are encoded as JSON strings,
+				// which encoding/json supports with the ",string" option.
+				if field.StringEncoded {
+					if tag, err := tags.Get("json"); err == nil {
+						if !tag.HasOption("string") {
+							tag.Options = append(tag.Options, "string")
+						}
+					} else {
+						_ = tags.Set(&structtag.Tag{Key: "json", Options: []string{"string"}})
+					}
+				}
+
 				tagsForJen := make(map[string]string)
 				for _, tag := range tags.Tags() {
 					tagsForJen[tag.Key] = tag.Value()
@@ -1076,12 +1263,16 @@ func (g *golang) generateAnonStructTypes(fields []*encoding.ParameterEncoding, e
 		if err != nil {
 			return nil, errors.Wrapf(err, "parse tags: %s", field.SrcName)
 		}
+		var options []string
 		if tag, err := tags.Get(encodingTag); err == nil {
-			options := strings.Join(tag.Options, ",")
-			if options != "" {
-				tagValue.WriteRune(',')
-				tagValue.WriteString(options)
-			}
+			options = tag.Options
+		}
+		if field.StringEncoded && encodingTag == "json" && !slices.Contains(options, "string") {
+			options = append(slices.Clone(options), "string")
+		}
+		if len(options) > 0 {
+			tagValue.WriteRune(',')
+			tagValue.WriteString(strings.Join(options, ","))
 		}
 
 		types = append(
@@ -1125,6 +1316,11 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 
 		grp.Id("userAgent").String().
 			Commentf("What user agent we will use in the API requests")
+
+		if g.hasStreams {
+			grp.Id("streamOptions").Id("StreamOptions").
+				Comment("How streams to streaming API endpoints behave")
+		}
 	})
 
 	// Add the Do method for th base client
@@ -1136,10 +1332,17 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 		Params(Id("req").Op("*").Qual("net/http", "Request")).
 		Params(Op("*").Qual("net/http", "Response"), Error()).
 		BlockFunc(func(grp *Group) {
-			grp.Id("req").Dot("Header").Dot("Set").Call(
-				Lit("Content-Type"),
-				Lit("application/json"),
-			)
+			if g.seenFile {
+				// Multipart forms have already set their Content-Type
+				grp.If(Id("req").Dot("Header").Dot("Get").Call(Lit("Content-Type")).Op("==").Lit("")).Block(
+					Id("req").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Lit("application/json")),
+				)
+			} else {
+				grp.Id("req").Dot("Header").Dot("Set").Call(
+					Lit("Content-Type"),
+					Lit("application/json"),
+				)
+			}
 			grp.Id("req").Dot("Header").Dot("Set").Call(
 				Lit("User-Agent"),
 				Id("b").Dot("userAgent"),
@@ -1185,16 +1388,7 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 		Block(
 			Comment("Encode the API body"),
 			Var().Id("bodyReader").Qual("io", "Reader"),
-			If(Id("body").Op("!=").Nil()).Block(
-				List(Id("bodyBytes"), Err()).Op(":=").
-					Qual("encoding/json", "Marshal").
-					Call(Id("body")),
-				If(Err().Op("!=").Nil()).Block(
-					Return(Nil(), Qual("fmt", "Errorf").Call(Lit("marshal request: %w"), Err())),
-				),
-
-				Id("bodyReader").Op("=").Qual("bytes", "NewReader").Call(Id("bodyBytes")),
-			),
+			g.encodeRequestBody(),
 			Line(),
 
 			Comment("Create the request"),
@@ -1206,6 +1400,7 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 			If(Err().Op("!=").Nil()).Block(
 				Return(Nil(), Qual("fmt", "Errorf").Call(Lit("create request: %w"), Err())),
 			),
+			g.setFormContentType(),
 			Line(),
 
 			Comment("Add any headers to the request"),
@@ -1282,17 +1477,128 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 	return nil
 }
 
+// encodeRequestBody returns the code encoding the body of an API call as JSON,
+// or as a multipart form if it's a request containing files.
+func (g *golang) encodeRequestBody() Code {
+	encodeJSON := If(Id("body").Op("!=").Nil()).Block(
+		List(Id("bodyBytes"), Err()).Op(":=").
+			Qual("encoding/json", "Marshal").
+			Call(Id("body")),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Nil(), Qual("fmt", "Errorf").Call(Lit("marshal request: %w"), Err())),
+		),
+
+		Id("bodyReader").Op("=").Qual("bytes", "NewReader").Call(Id("bodyBytes")),
+	)
+	if !g.seenFile {
+		return encodeJSON
+	}
+
+	return If(
+		List(Id("form"), Id("ok")).Op(":=").Id("body").Assert(Op("*").Id("multipartForm")),
+		Id("ok"),
+	).Block(
+		If(Err().Op(":=").Id("form").Dot("close").Call(), Err().Op("!=").Nil()).Block(
+			Return(Nil(), Qual("fmt", "Errorf").Call(Lit("encode form: %w"), Err())),
+		),
+		Id("bodyReader").Op("=").Op("&").Id("form").Dot("buf"),
+	).Else().Add(encodeJSON)
+}
+
+// setFormContentType returns the code setting the Content-Type
+// of API calls sending multipart forms, if there are any.
+func (g *golang) setFormContentType() Code {
+	if !g.seenFile {
+		return Null()
+	}
+	return If(
+		List(Id("form"), Id("ok")).Op(":=").Id("body").Assert(Op("*").Id("multipartForm")),
+		Id("ok"),
+	).Block(
+		Id("req").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Id("form").Dot("writer").Dot("FormDataContentType").Call()),
+	)
+}
+
+// writeMultipartForm writes the File type and the helpers
+// used to send requests containing files.
+func (g *golang) writeMultipartForm(file *File) {
+	file.Line()
+	file.Comment("File is a file sent in a request, as part of a multipart form.")
+	file.Type().Id("File").Struct(
+		Id("Name").String().Comment("the name of the file"),
+		Id("Content").Qual("io", "Reader").Comment("the contents of the file"),
+	)
+
+	file.Line()
+	file.Comment("multipartForm is the body of a request containing files.")
+	file.Type().Id("multipartForm").Struct(
+		Id("buf").Qual("bytes", "Buffer"),
+		Id("writer").Op("*").Qual("mime/multipart", "Writer"),
+		Id("err").Error(),
+	)
+
+	file.Line()
+	file.Func().Id("newMultipartForm").Params().Op("*").Id("multipartForm").Block(
+		Id("f").Op(":=").Op("&").Id("multipartForm").Values(),
+		Id("f").Dot("writer").Op("=").Qual("mime/multipart", "NewWriter").Call(Op("&").Id("f").Dot("buf")),
+		Return(Id("f")),
+	)
+
+	file.Line()
+	file.Comment("addValues adds a form field for each of the given values.")
+	file.Func().Params(Id("f").Op("*").Id("multipartForm")).Id("addValues").Params(
+		Id("name").String(),
+		Id("values").Index().String(),
+	).Block(
+		For(List(Id("_"), Id("v")).Op(":=").Range().Id("values")).Block(
+			If(Id("f").Dot("err").Op("==").Nil()).Block(
+				Id("f").Dot("err").Op("=").Id("f").Dot("writer").Dot("WriteField").Call(Id("name"), Id("v")),
+			),
+		),
+	)
+
+	file.Line()
+	file.Comment("addFiles adds the given files to the form, skipping nil files.")
+	file.Func().Params(Id("f").Op("*").Id("multipartForm")).Id("addFiles").Params(
+		Id("name").String(),
+		Id("files").Op("...").Op("*").Id("File"),
+	).Block(
+		For(List(Id("_"), Id("file")).Op(":=").Range().Id("files")).Block(
+			If(Id("file").Op("==").Nil().Op("||").Id("f").Dot("err").Op("!=").Nil()).Block(
+				Continue(),
+			),
+			Var().Id("part").Qual("io", "Writer"),
+			List(Id("part"), Id("f").Dot("err")).Op("=").Id("f").Dot("writer").Dot("CreateFormFile").Call(Id("name"), Id("file").Dot("Name")),
+			If(Id("f").Dot("err").Op("==").Nil()).Block(
+				List(Id("_"), Id("f").Dot("err")).Op("=").Qual("io", "Copy").Call(Id("part"), Id("file").Dot("Content")),
+			),
+		),
+	)
+
+	file.Line()
+	file.Comment("close finishes the form, returning the first error encountered writing it.")
+	file.Func().Params(Id("f").Op("*").Id("multipartForm")).Id("close").Params().Error().Block(
+		If(Id("f").Dot("err").Op("!=").Nil()).Block(
+			Return(Id("f").Dot("err")),
+		),
+		Return(Id("f").Dot("writer").Dot("Close").Call()),
+	)
+}
+
 func (g *golang) writeErrorType(file *File) {
 	const ErrPrefix = "Err"
 
 	// Create the error type
 	file.Line()
 	file.Comment("APIError is the error type returned by the API")
-	file.Type().Id("APIError").Struct(
-		Id("Code").Id("ErrCode").Tag(map[string]string{"json": "code"}),
-		Id("Message").String().Tag(map[string]string{"json": "message"}),
-		Id("Details").Any().Tag(map[string]string{"json": "details"}),
-	)
+	file.Type().Id("APIError").StructFunc(func(grp *Group) {
+		grp.Id("Code").Id("ErrCode").Tag(map[string]string{"json": "code"})
+		grp.Id("Message").String().Tag(map[string]string{"json": "message"})
+		grp.Id("Details").Any().Tag(map[string]string{"json": "details"})
+		if len(g.errorDefs) > 0 {
+			grp.Id("ErrorID").Id("ErrorID").Tag(map[string]string{"json": "error_id,omitempty"}).Comment("the id of the error definition the error was created from, if any")
+		}
+	})
 	file.Func().Params(Id("e").Op("*").Id("APIError")).Id("Error").Params().String().Block(
 		Return(Qual("fmt", "Sprintf").Call(Lit("%s: %s"), Id("e").Dot("Code"), Id("e").Dot("Message"))),
 	)
@@ -1358,6 +1664,32 @@ func (g *golang) writeErrorType(file *File) {
 
 		Return(Nil()),
 	)
+
+	if len(g.errorDefs) > 0 {
+		g.writeErrorIDs(file)
+	}
+}
+
+// writeErrorIDs writes the ErrorID type and a constant for each error definition.
+func (g *golang) writeErrorIDs(file *File) {
+	file.Line()
+	file.Comment("ErrorID identifies the errors declared by the application with errs.Define.")
+	file.Type().Id("ErrorID").String()
+	file.Line()
+
+	ids := make([]Code, 0, len(g.errorDefs))
+	for i, def := range g.errorDefs {
+		if i > 0 {
+			ids = append(ids, Line())
+		}
+		if doc := def.GetDoc(); doc != "" && !g.skipDocs {
+			for _, line := range strings.Split(strings.TrimSpace(doc), "\n") {
+				ids = append(ids, Comment(line))
+			}
+		}
+		ids = append(ids, Id("ErrorID"+errorIDName(def)).Id("ErrorID").Op("=").Lit(def.Id))
+	}
+	file.Const().Defs(ids...)
 }
 
 func (g *golang) writeExtraHelpers(file *File) {
@@ -1381,6 +1713,824 @@ func (g *golang) writeExtraHelpers(file *File) {
 		file.Comment("null is a helper type to indicate a null value in JSON.")
 		file.Type().Id("null").Op("=").Op("*").Bool()
 	}
+
+	if g.seenEventStream {
+		g.writeEventStream(file)
+	}
+
+	if g.seenFile {
+		g.writeMultipartForm(file)
+	}
+
+	if g.hasStreams {
+		g.writeStreams(file)
+	}
+}
+
+// writeEventStream writes the EventStream type used by endpoints
+// streaming server-sent events.
+func (g *golang) writeEventStream(file *File) {
+	stream := Op("*").Id("EventStream").Types(Id("T"))
+
+	file.Line()
+	file.Comment("EventStream is a stream of server-sent events from an API endpoint.")
+	file.Comment("")
+	file.Comment("If the connection is lost the stream reconnects, resuming after the last")
+	file.Comment("event received.")
+	file.Type().Id("EventStream").Types(Id("T").Any()).Struct(
+		Id("ctx").Qual("context", "Context"),
+		Id("client").Op("*").Id("baseClient"),
+		Id("method").String(),
+		Id("path").String(),
+		Id("headers").Qual("net/http", "Header"),
+		Id("body").Index().Byte(),
+		Id("resp").Op("*").Qual("net/http", "Response"),
+		Id("reader").Op("*").Qual("bufio", "Reader"),
+		Id("lastEventID").String(),
+		Id("retry").Qual("time", "Duration"),
+		Id("err").Error(),
+	)
+
+	file.Line()
+	file.Comment("openEventStream opens a stream of server-sent events from an API endpoint.")
+	file.Func().Id("openEventStream").Types(Id("T").Any()).
+		Params(
+			Id("ctx").Qual("context", "Context"),
+			Id("client").Op("*").Id("baseClient"),
+			Id("method"),
+			Id("path").String(),
+			Id("headers").Qual("net/http", "Header"),
+			Id("body").Any(),
+		).
+		Params(stream.Clone(), Error()).
+		Block(
+			Id("s").Op(":=").Op("&").Id("EventStream").Types(Id("T")).Values(Dict{
+				Id("ctx"):     Id("ctx"),
+				Id("client"):  Id("client"),
+				Id("method"):  Id("method"),
+				Id("path"):    Id("path"),
+				Id("headers"): Id("headers"),
+				Id("retry"):   Lit(3).Op("*").Qual("time", "Second"),
+			}),
+			If(Id("body").Op("!=").Nil()).Block(
+				List(Id("bodyBytes"), Err()).Op(":=").Qual("encoding/json", "Marshal").Call(Id("body")),
+				If(Err().Op("!=").Nil()).Block(
+					Return(Nil(), Qual("fmt", "Errorf").Call(Lit("marshal request: %w"), Err())),
+				),
+				Id("s").Dot("body").Op("=").Id("bodyBytes"),
+			),
+			If(Err().Op(":=").Id("s").Dot("connect").Call(), Err().Op("!=").Nil()).Block(
+				Return(Nil(), Err()),
+			),
+			Return(Id("s"), Nil()),
+		)
+
+	file.Line()
+	file.Comment("connect opens the connection, resuming after the last event received, if any.")
+	file.Func().Params(Id("s").Add(stream.Clone())).Id("connect").Params().Error().Block(
+		Var().Id("bodyReader").Qual("io", "Reader"),
+		If(Id("s").Dot("body").Op("!=").Nil()).Block(
+			Id("bodyReader").Op("=").Qual("bytes", "NewReader").Call(Id("s").Dot("body")),
+		),
+		List(Id("req"), Err()).Op(":=").Qual("net/http", "NewRequestWithContext").Call(
+			Id("s").Dot("ctx"), Id("s").Dot("method"), Id("s").Dot("path"), Id("bodyReader"),
+		),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Qual("fmt", "Errorf").Call(Lit("create request: %w"), Err())),
+		),
+		For(List(Id("header"), Id("values")).Op(":=").Range().Id("s").Dot("headers")).Block(
+			For(List(Id("_"), Id("value")).Op(":=").Range().Id("values")).Block(
+				Id("req").Dot("Header").Dot("Add").Call(Id("header"), Id("value")),
+			),
+		),
+		Id("req").Dot("Header").Dot("Set").Call(Lit("Accept"), Lit("text/event-stream")),
+		If(Id("s").Dot("lastEventID").Op("!=").Lit("")).Block(
+			Id("req").Dot("Header").Dot("Set").Call(Lit("Last-Event-ID"), Id("s").Dot("lastEventID")),
+		),
+		Line(),
+		List(Id("resp"), Err()).Op(":=").Id("s").Dot("client").Dot("Do").Call(Id("req")),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Qual("fmt", "Errorf").Call(Lit("request failed: %w"), Err())),
+		),
+		If(Id("resp").Dot("StatusCode").Op(">=").Lit(400)).Block(
+			Defer().Func().Params().Block(
+				Id("_").Op("=").Id("resp").Dot("Body").Dot("Close").Call(),
+			).Call(),
+			List(Id("body"), Id("_")).Op(":=").Qual("io", "ReadAll").Call(Id("resp").Dot("Body")),
+			Id("apiError").Op(":=").Op("&").Id("APIError").Values(),
+			If(Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(Id("body"), Id("apiError")), Err().Op("!=").Nil()).Block(
+				Return(Op("&").Id("APIError").Values(Dict{
+					Id("Code"):    Id("ErrUnknown"),
+					Id("Message"): Qual("fmt", "Sprintf").Call(Lit("got error response: %s"), String().Call(Id("body"))),
+				})),
+			),
+			Return(Id("apiError")),
+		),
+		Id("s").Dot("resp").Op("=").Id("resp"),
+		Id("s").Dot("reader").Op("=").Qual("bufio", "NewReader").Call(Id("resp").Dot("Body")),
+		Return(Nil()),
+	)
+
+	file.Line()
+	file.Comment("Recv returns the next event from the stream.")
+	file.Comment("It returns io.EOF once the server has ended the stream,")
+	file.Comment("and an *APIError if the server reported an error.")
+	file.Func().Params(Id("s").Add(stream.Clone())).Id("Recv").Params().Params(Id("T"), Error()).Block(
+		Var().Id("event").Id("T"),
+		For(Id("s").Dot("err").Op("==").Nil()).Block(
+			List(Id("name"), Id("data"), Err()).Op(":=").Id("s").Dot("readEvent").Call(),
+			If(Err().Op("!=").Nil()).Block(
+				Comment("The connection was lost; reconnect after the retry delay."),
+				Id("_").Op("=").Id("s").Dot("resp").Dot("Body").Dot("Close").Call(),
+				Select().Block(
+					Case(Op("<-").Id("s").Dot("ctx").Dot("Done").Call()).Block(
+						Id("s").Dot("err").Op("=").Id("s").Dot("ctx").Dot("Err").Call(),
+					),
+					Case(Op("<-").Qual("time", "After").Call(Id("s").Dot("retry"))).Block(
+						Id("s").Dot("err").Op("=").Id("s").Dot("connect").Call(),
+					),
+				),
+				Continue(),
+			),
+			Line(),
+			Switch(Id("name")).Block(
+				Case(Lit("end")).Block(
+					Id("s").Dot("err").Op("=").Qual("io", "EOF"),
+				),
+				Case(Lit("error")).Block(
+					Id("apiError").Op(":=").Op("&").Id("APIError").Values(),
+					If(Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(Id("data"), Id("apiError")), Err().Op("!=").Nil()).Block(
+						Id("apiError").Op("=").Op("&").Id("APIError").Values(Dict{
+							Id("Code"):    Id("ErrUnknown"),
+							Id("Message"): Qual("fmt", "Sprintf").Call(Lit("got error event: %s"), String().Call(Id("data"))),
+						}),
+					),
+					Id("s").Dot("err").Op("=").Id("apiError"),
+				),
+				Default().Block(
+					If(Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(Id("data"), Op("&").Id("event")), Err().Op("!=").Nil()).Block(
+						Return(Id("event"), Qual("fmt", "Errorf").Call(Lit("decode event: %w"), Err())),
+					),
+					Return(Id("event"), Nil()),
+				),
+			),
+		),
+		Id("_").Op("=").Id("s").Dot("resp").Dot("Body").Dot("Close").Call(),
+		Return(Id("event"), Id("s").Dot("err")),
+	)
+
+	file.Line()
+	file.Comment("Close closes the stream.")
+	file.Func().Params(Id("s").Add(stream.Clone())).Id("Close").Params().Error().Block(
+		If(Id("s").Dot("err").Op("==").Nil()).Block(
+			Id("s").Dot("err").Op("=").Qual("io", "EOF"),
+		),
+		Return(Id("s").Dot("resp").Dot("Body").Dot("Close").Call()),
+	)
+
+	file.Line()
+	file.Comment("readEvent reads the next event, skipping comments such as heartbeats.")
+	file.Func().Params(Id("s").Add(stream.Clone())).Id("readEvent").Params().
+		Params(Id("name").String(), Id("data").Index().Byte(), Err().Error()).
+		Block(
+			Id("hasData").Op(":=").False(),
+			For().Block(
+				List(Id("line"), Err()).Op(":=").Id("s").Dot("reader").Dot("ReadString").Call(LitRune('\n')),
+				If(Err().Op("!=").Nil()).Block(
+					Return(Lit(""), Nil(), Err()),
+				),
+				Id("line").Op("=").Qual("strings", "TrimRight").Call(Id("line"), Lit("\r\n")),
+				If(Id("line").Op("==").Lit("")).Block(
+					If(Id("hasData")).Block(
+						Return(Id("name"), Id("data"), Nil()),
+					),
+					Id("name").Op("=").Lit(""),
+					Continue(),
+				),
+				Line(),
+				List(Id("field"), Id("value"), Id("_")).Op(":=").Qual("strings", "Cut").Call(Id("line"), Lit(":")),
+				Id("value").Op("=").Qual("strings", "TrimPrefix").Call(Id("value"), Lit(" ")),
+				Switch(Id("field")).Block(
+					Case(Lit("event")).Block(
+						Id("name").Op("=").Id("value"),
+					),
+					Case(Lit("data")).Block(
+						If(Id("hasData")).Block(
+							Id("data").Op("=").Append(Id("data"), LitRune('\n')),
+						),
+						Id("data").Op("=").Append(Id("data"), Id("value").Op("...")),
+						Id("hasData").Op("=").True(),
+					),
+					Case(Lit("id")).Block(
+						Id("s").Dot("lastEventID").Op("=").Id("value"),
+					),
+					Case(Lit("retry")).Block(
+						If(List(Id("ms"), Err()).Op(":=").Qual("strconv", "Atoi").Call(Id("value")), Err().Op("==").Nil()).Block(
+							Id("s").Dot("retry").Op("=").Qual("time", "Duration").Call(Id("ms")).Op("*").Qual("time", "Millisecond"),
+						),
+					),
+				),
+			),
+		)
+}
+
+// writeStreams writes the stream types used by streaming endpoints, and the
+// WebSocket connection they're sent over.
+func (g *golang) writeStreams(file *File) {
+	conn := Op("*").Id("streamConn")
+	rwc := Qual("io", "ReadWriteCloser")
+
+	// lock and unlock the stream's state
+	lock := func() *Statement { return Id("s").Dot("mu").Dot("Lock").Call() }
+	unlock := func() *Statement { return Id("s").Dot("mu").Dot("Unlock").Call() }
+
+	// waitFor waits for the state to change while cond holds, with the lock held
+	waitFor := func(cond Code) Code {
+		return For(Add(cond)).Block(
+			Id("changed").Op(":=").Id("s").Dot("changed"),
+			unlock(),
+			Op("<-").Id("changed"),
+			lock(),
+		)
+	}
+
+	file.Line()
+	file.Comment("StreamOptions configures how streams to streaming API endpoints behave.")
+	file.Type().Id("StreamOptions").Struct(
+		Comment("DisableReconnect disables reconnecting when the connection is lost."),
+		Comment("By default streams reconnect and resume where they left off."),
+		Id("DisableReconnect").Bool(),
+		Line(),
+		Comment("MaxReconnectAttempts is the number of consecutive reconnection attempts"),
+		Comment("made before the stream fails. It defaults to 10."),
+		Id("MaxReconnectAttempts").Int(),
+		Line(),
+		Comment("SendQueueSize is the number of messages which can be waiting to be sent"),
+		Comment("or acknowledged by the server before Send blocks. It defaults to 64."),
+		Id("SendQueueSize").Int(),
+	)
+
+	// The typed streams, which differ in the methods they expose
+	send := func(stream *Statement) {
+		file.Line()
+		file.Comment("Send sends a message on the stream.")
+		file.Comment("It blocks while the send queue is full, such as while reconnecting.")
+		file.Func().Params(Id("s").Add(stream)).Id("Send").Params(Id("msg").Id("Req")).Error().Block(
+			Return(Id("s").Dot("conn").Dot("send").Call(Id("msg"))),
+		)
+	}
+	recv := func(stream *Statement, name string, doc ...string) {
+		file.Line()
+		for _, line := range doc {
+			file.Comment(line)
+		}
+		file.Func().Params(Id("s").Add(stream)).Id(name).Params().Params(Id("msg").Id("Resp"), Err().Error()).Block(
+			Err().Op("=").Id("s").Dot("conn").Dot("recv").Call(Op("&").Id("msg")),
+			Return(Id("msg"), Err()),
+		)
+	}
+	closer := func(stream *Statement) {
+		file.Line()
+		file.Comment("Close closes the stream.")
+		file.Func().Params(Id("s").Add(stream)).Id("Close").Params().Error().Block(
+			Return(Id("s").Dot("conn").Dot("close").Call()),
+		)
+	}
+	recvDoc := []string{
+		"Recv returns the next message from the stream.",
+		"It returns io.EOF once the server has ended the stream.",
+	}
+
+	inOut := Op("*").Id("StreamInOut").Types(Id("Req"), Id("Resp"))
+	file.Line()
+	file.Comment("StreamInOut is a stream to an API endpoint sending messages of type Req")
+	file.Comment("and receiving messages of type Resp.")
+	file.Type().Id("StreamInOut").Types(Id("Req").Any(), Id("Resp").Any()).Struct(
+		Id("conn").Add(conn.Clone()),
+	)
+	send(inOut.Clone())
+	recv(inOut.Clone(), "Recv", recvDoc...)
+	closer(inOut.Clone())
+
+	in := Op("*").Id("StreamIn").Types(Id("Resp"))
+	file.Line()
+	file.Comment("StreamIn is a stream from an API endpoint receiving messages of type Resp.")
+	file.Type().Id("StreamIn").Types(Id("Resp").Any()).Struct(
+		Id("conn").Add(conn.Clone()),
+	)
+	recv(in.Clone(), "Recv", recvDoc...)
+	closer(in.Clone())
+
+	out := Op("*").Id("StreamOut").Types(Id("Req"), Id("Resp"))
+	file.Line()
+	file.Comment("StreamOut is a stream to an API endpoint sending messages of type Req,")
+	file.Comment("to which the API endpoint responds with a single message of type Resp.")
+	file.Type().Id("StreamOut").Types(Id("Req").Any(), Id("Resp").Any()).Struct(
+		Id("conn").Add(conn.Clone()),
+	)
+	send(out.Clone())
+	recv(out.Clone(), "Response", "Response waits for the response from the API endpoint.")
+	closer(out.Clone())
+
+	file.Line()
+	file.Comment("streamConn is a WebSocket connection to a streaming API endpoint.")
+	file.Comment("")
+	file.Comment("If the connection is lost it reconnects and resumes the stream, receiving")
+	file.Comment("the messages it missed and resending the ones the server didn't receive.")
+	file.Type().Id("streamConn").Struct(
+		Id("ctx").Qual("context", "Context"),
+		Id("client").Op("*").Id("baseClient"),
+		Id("path").String(),
+		Id("headers").Qual("net/http", "Header"),
+		Id("opts").Id("StreamOptions"),
+		Id("stop").Func().Params().Bool(),
+		Line(),
+		Id("writeMu").Qual("sync", "Mutex").Comment("serializes writes to the connection"),
+		Line(),
+		Id("mu").Qual("sync", "Mutex"),
+		Id("changed").Chan().Struct().Comment("closed whenever the state below changes"),
+		Id("conn").Add(rwc.Clone()),
+		Id("resumable").Bool(),
+		Id("ready").Bool(),
+		Id("token").String(),
+		Id("received").Uint64().Comment("the number of messages received from the server"),
+		Id("inbox").Index().Index().Byte().Comment("messages received but not yet returned by recv"),
+		Id("queue").Index().Index().Byte().Comment("messages waiting to be sent or acknowledged"),
+		Id("sent").Int().Comment("the number of queued messages sent on the current connection"),
+		Id("acked").Uint64().Comment("the number of messages acknowledged by the server"),
+		Id("err").Error().Comment("set once the stream has ended"),
+	)
+
+	file.Line()
+	file.Comment("dialStream opens a stream to a streaming API endpoint.")
+	file.Func().Id("dialStream").
+		Params(
+			Id("ctx").Qual("context", "Context"),
+			Id("client").Op("*").Id("baseClient"),
+			Id("path").String(),
+			Id("headers").Qual("net/http", "Header"),
+		).
+		Params(conn.Clone(), Error()).
+		Block(
+			Id("s").Op(":=").Op("&").Id("streamConn").Values(Dict{
+				Id("ctx"):     Id("ctx"),
+				Id("client"):  Id("client"),
+				Id("path"):    Id("path"),
+				Id("headers"): Id("headers"),
+				Id("opts"):    Id("client").Dot("streamOptions"),
+				Id("changed"): Make(Chan().Struct()),
+			}),
+			If(Id("s").Dot("opts").Dot("MaxReconnectAttempts").Op("<=").Lit(0)).Block(
+				Id("s").Dot("opts").Dot("MaxReconnectAttempts").Op("=").Lit(10),
+			),
+			If(Id("s").Dot("opts").Dot("SendQueueSize").Op("<=").Lit(0)).Block(
+				Id("s").Dot("opts").Dot("SendQueueSize").Op("=").Lit(64),
+			),
+			If(Err().Op(":=").Id("s").Dot("connect").Call(), Err().Op("!=").Nil()).Block(
+				Return(Nil(), Err()),
+			),
+			Id("s").Dot("stop").Op("=").Qual("context", "AfterFunc").Call(Id("ctx"), Func().Params().Block(
+				Id("s").Dot("end").Call(Nil(), Id("ctx").Dot("Err").Call()),
+			)),
+			Return(Id("s"), Nil()),
+		)
+
+	file.Line()
+	file.Comment("connect opens a new connection, resuming the stream if it has a resume token.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("connect").Params().Error().Block(
+		Id("key").Op(":=").Make(Index().Byte(), Lit(16)),
+		If(List(Id("_"), Err()).Op(":=").Qual("crypto/rand", "Read").Call(Id("key")), Err().Op("!=").Nil()).Block(
+			Return(Err()),
+		),
+		Id("nonce").Op(":=").Qual("encoding/base64", "StdEncoding").Dot("EncodeToString").Call(Id("key")),
+		Line(),
+		List(Id("req"), Err()).Op(":=").Qual("net/http", "NewRequestWithContext").Call(
+			Id("s").Dot("ctx"), Lit("GET"), Id("s").Dot("path"), Nil(),
+		),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Qual("fmt", "Errorf").Call(Lit("create request: %w"), Err())),
+		),
+		For(List(Id("header"), Id("values")).Op(":=").Range().Id("s").Dot("headers")).Block(
+			For(List(Id("_"), Id("value")).Op(":=").Range().Id("values")).Block(
+				Id("req").Dot("Header").Dot("Add").Call(Id("header"), Id("value")),
+			),
+		),
+		Id("req").Dot("Header").Dot("Set").Call(Lit("Connection"), Lit("Upgrade")),
+		Id("req").Dot("Header").Dot("Set").Call(Lit("Upgrade"), Lit("websocket")),
+		Id("req").Dot("Header").Dot("Set").Call(Lit("Sec-WebSocket-Version"), Lit("13")),
+		Id("req").Dot("Header").Dot("Set").Call(Lit("Sec-WebSocket-Key"), Id("nonce")),
+		Id("req").Dot("Header").Dot("Set").Call(Lit("Sec-WebSocket-Protocol"), Lit("encore-ws-resume, encore-ws")),
+		lock(),
+		If(Id("s").Dot("token").Op("!=").Lit("")).Block(
+			Id("req").Dot("Header").Dot("Set").Call(Lit("X-Encore-Resume-Token"), Id("s").Dot("token")),
+			Id("req").Dot("Header").Dot("Set").Call(
+				Lit("X-Encore-Resume-Received"),
+				Qual("strconv", "FormatUint").Call(Id("s").Dot("received"), Lit(10)),
+			),
+		),
+		unlock(),
+		Line(),
+		List(Id("resp"), Err()).Op(":=").Id("s").Dot("client").Dot("Do").Call(Id("req")),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Qual("fmt", "Errorf").Call(Lit("request failed: %w"), Err())),
+		),
+		If(Id("resp").Dot("StatusCode").Op("!=").Qual("net/http", "StatusSwitchingProtocols")).Block(
+			Defer().Func().Params().Block(
+				Id("_").Op("=").Id("resp").Dot("Body").Dot("Close").Call(),
+			).Call(),
+			List(Id("body"), Id("_")).Op(":=").Qual("io", "ReadAll").Call(Id("resp").Dot("Body")),
+			Id("apiError").Op(":=").Op("&").Id("APIError").Values(),
+			If(Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(Id("body"), Id("apiError")), Err().Op("!=").Nil()).Block(
+				Return(Op("&").Id("APIError").Values(Dict{
+					Id("Code"):    Id("ErrUnknown"),
+					Id("Message"): Qual("fmt", "Sprintf").Call(Lit("got error response: %s"), String().Call(Id("body"))),
+				})),
+			),
+			Return(Id("apiError")),
+		),
+		List(Id("conn"), Id("ok")).Op(":=").Id("resp").Dot("Body").Assert(rwc.Clone()),
+		If(Op("!").Id("ok")).Block(
+			Id("_").Op("=").Id("resp").Dot("Body").Dot("Close").Call(),
+			Return(Qual("errors", "New").Call(Lit("the HTTP client does not support WebSocket connections"))),
+		),
+		Id("accept").Op(":=").Qual("crypto/sha1", "Sum").Call(
+			Index().Byte().Call(Id("nonce").Op("+").Lit("258EAFA5-E914-47DA-95CA-C5AB0DC85B11")),
+		),
+		If(
+			Id("resp").Dot("Header").Dot("Get").Call(Lit("Sec-WebSocket-Accept")).Op("!=").
+				Qual("encoding/base64", "StdEncoding").Dot("EncodeToString").Call(Id("accept").Index(Op(":"))),
+		).Block(
+			Id("_").Op("=").Id("conn").Dot("Close").Call(),
+			Return(Qual("errors", "New").Call(Lit("invalid WebSocket handshake response"))),
+		),
+		Line(),
+		lock(),
+		If(Id("s").Dot("err").Op("!=").Nil()).Block(
+			unlock(),
+			Return(Id("conn").Dot("Close").Call()),
+		),
+		Id("s").Dot("conn").Op("=").Id("conn"),
+		Id("s").Dot("resumable").Op("=").Id("resp").Dot("Header").Dot("Get").Call(Lit("Sec-WebSocket-Protocol")).Op("==").Lit("encore-ws-resume"),
+		Comment("Resumable connections are ready once the server has said how much it received."),
+		Id("s").Dot("ready").Op("=").Op("!").Id("s").Dot("resumable"),
+		Id("s").Dot("sent").Op("=").Lit(0),
+		Id("s").Dot("notify").Call(),
+		unlock(),
+		Line(),
+		Go().Id("s").Dot("read").Call(Id("conn")),
+		Go().Id("s").Dot("flush").Call(),
+		Return(Nil()),
+	)
+
+	file.Line()
+	file.Comment("notify wakes up everyone waiting for the state to change.")
+	file.Comment("It must be called with s.mu held.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("notify").Params().Block(
+		Close(Id("s").Dot("changed")),
+		Id("s").Dot("changed").Op("=").Make(Chan().Struct()),
+	)
+
+	file.Line()
+	file.Comment("read reads messages from the connection until it's closed.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("read").Params(Id("conn").Add(rwc.Clone())).Block(
+		Id("reader").Op(":=").Qual("bufio", "NewReader").Call(Id("conn")),
+		For().Block(
+			List(Id("opcode"), Id("payload"), Err()).Op(":=").Id("readWebSocketFrame").Call(Id("reader")),
+			If(Err().Op("!=").Nil()).Block(
+				Id("s").Dot("lost").Call(Id("conn"), Err()),
+				Return(),
+			),
+			Switch(Id("opcode")).Block(
+				Case(Id("wsText")).Block(
+					lock(),
+					Id("s").Dot("received").Op("++"),
+					Id("s").Dot("inbox").Op("=").Append(Id("s").Dot("inbox"), Id("payload")),
+					Id("s").Dot("notify").Call(),
+					unlock(),
+				),
+				Case(Id("wsBinary")).Block(
+					Comment("Binary messages are sent by the server to acknowledge the messages it received."),
+					Var().Id("control").Struct(
+						Id("Token").String().Tag(map[string]string{"json": "token"}),
+						Id("Received").Uint64().Tag(map[string]string{"json": "received"}),
+					),
+					If(Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(Id("payload"), Op("&").Id("control")), Err().Op("==").Nil()).Block(
+						Id("s").Dot("acknowledge").Call(Id("conn"), Id("control").Dot("Token"), Id("control").Dot("Received")),
+					),
+				),
+				Case(Id("wsClose")).Block(
+					List(Id("code"), Id("reason")).Op(":=").List(Lit(1005), Lit("")),
+					If(Len(Id("payload")).Op(">=").Lit(2)).Block(
+						List(Id("code"), Id("reason")).Op("=").List(
+							Int().Call(Qual("encoding/binary", "BigEndian").Dot("Uint16").Call(Id("payload"))),
+							String().Call(Id("payload").Index(Lit(2), Empty())),
+						),
+						Id("payload").Op("=").Id("payload").Index(Empty(), Lit(2)),
+					),
+					Id("_").Op("=").Id("s").Dot("write").Call(Id("conn"), Id("wsClose"), Id("payload")),
+					Err().Op("=").Qual("io", "EOF"),
+					If(Id("code").Op("!=").Lit(1000).Op("&&").Id("code").Op("!=").Lit(1005)).Block(
+						Err().Op("=").Qual("fmt", "Errorf").Call(Lit("stream closed by server: %s (code %d)"), Id("reason"), Id("code")),
+					),
+					Id("s").Dot("end").Call(Id("conn"), Err()),
+					Return(),
+				),
+				Case(Id("wsPing")).Block(
+					Go().Id("s").Dot("write").Call(Id("conn"), Id("wsPong"), Id("payload")),
+				),
+			),
+		),
+	)
+
+	file.Line()
+	file.Comment("acknowledge handles the server acknowledging the messages it received,")
+	file.Comment("removing them from the send queue.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("acknowledge").
+		Params(Id("conn").Add(rwc.Clone()), Id("token").String(), Id("received").Uint64()).
+		Block(
+			lock(),
+			If(Id("s").Dot("conn").Op("!=").Id("conn")).Block(
+				unlock(),
+				Return(),
+			),
+			Id("s").Dot("token").Op("=").Id("token"),
+			If(
+				Id("n").Op(":=").Int().Call(Id("received").Op("-").Id("s").Dot("acked")),
+				Id("received").Op(">").Id("s").Dot("acked").Op("&&").Id("n").Op("<=").Len(Id("s").Dot("queue")),
+			).Block(
+				Id("s").Dot("queue").Op("=").Id("s").Dot("queue").Index(Id("n"), Empty()),
+				Id("s").Dot("sent").Op("=").Max(Id("s").Dot("sent").Op("-").Id("n"), Lit(0)),
+				Id("s").Dot("acked").Op("=").Id("received"),
+			),
+			Id("s").Dot("ready").Op("=").True(),
+			Id("s").Dot("notify").Call(),
+			unlock(),
+			Go().Id("s").Dot("flush").Call(),
+		)
+
+	file.Line()
+	file.Comment("flush sends the queued messages which haven't been sent on the current connection.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("flush").Params().Block(
+		Id("s").Dot("writeMu").Dot("Lock").Call(),
+		Defer().Id("s").Dot("writeMu").Dot("Unlock").Call(),
+		For().Block(
+			lock(),
+			If(Id("s").Dot("conn").Op("==").Nil().Op("||").Op("!").Id("s").Dot("ready").Op("||").Id("s").Dot("sent").Op(">=").Len(Id("s").Dot("queue"))).Block(
+				unlock(),
+				Return(),
+			),
+			List(Id("conn"), Id("msg")).Op(":=").List(Id("s").Dot("conn"), Id("s").Dot("queue").Index(Id("s").Dot("sent"))),
+			If(Id("s").Dot("resumable")).Block(
+				Id("s").Dot("sent").Op("++"),
+			).Else().Block(
+				Comment("Messages can't be resent without resuming, so there's no need to keep them."),
+				Id("s").Dot("queue").Op("=").Id("s").Dot("queue").Index(Lit(1), Empty()),
+				Id("s").Dot("notify").Call(),
+			),
+			unlock(),
+			Line(),
+			If(Err().Op(":=").Id("writeWebSocketFrame").Call(Id("conn"), Id("wsText"), Id("msg")), Err().Op("!=").Nil()).Block(
+				Id("_").Op("=").Id("conn").Dot("Close").Call(),
+				Return(),
+			),
+		),
+	)
+
+	file.Line()
+	file.Comment("write writes a single frame to the connection.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("write").
+		Params(Id("conn").Qual("io", "Writer"), Id("opcode").Byte(), Id("payload").Index().Byte()).
+		Error().
+		Block(
+			Id("s").Dot("writeMu").Dot("Lock").Call(),
+			Defer().Id("s").Dot("writeMu").Dot("Unlock").Call(),
+			Return(Id("writeWebSocketFrame").Call(Id("conn"), Id("opcode"), Id("payload"))),
+		)
+
+	file.Line()
+	file.Comment("lost handles the connection being lost, reconnecting if the stream can be resumed.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("lost").Params(Id("conn").Add(rwc.Clone()), Err().Error()).Block(
+		Id("_").Op("=").Id("conn").Dot("Close").Call(),
+		lock(),
+		Defer().Add(unlock()),
+		If(Id("s").Dot("conn").Op("!=").Id("conn")).Block(
+			Return(),
+		),
+		Id("s").Dot("conn").Op("=").Nil(),
+		If(Op("!").Id("s").Dot("resumable").Op("||").Id("s").Dot("token").Op("==").Lit("").Op("||").Id("s").Dot("opts").Dot("DisableReconnect")).Block(
+			Id("s").Dot("fail").Call(Qual("fmt", "Errorf").Call(Lit("connection lost: %w"), Err())),
+			Return(),
+		),
+		Id("s").Dot("ready").Op("=").False(),
+		Id("s").Dot("notify").Call(),
+		Go().Id("s").Dot("reconnect").Call(),
+	)
+
+	file.Line()
+	file.Comment("reconnect reconnects to resume the stream, backing off between attempts.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("reconnect").Params().Block(
+		Var().Err().Error(),
+		For(Id("attempt").Op(":=").Lit(0), Id("attempt").Op("<").Id("s").Dot("opts").Dot("MaxReconnectAttempts"), Id("attempt").Op("++")).Block(
+			Id("delay").Op(":=").Min(
+				Lit(250).Op("*").Qual("time", "Millisecond").Op("<<").Id("attempt"),
+				Lit(10).Op("*").Qual("time", "Second"),
+			),
+			Select().Block(
+				Case(Op("<-").Id("s").Dot("ctx").Dot("Done").Call()).Block(
+					Return(),
+				),
+				Case(Op("<-").Qual("time", "After").Call(Id("delay"))),
+			),
+			If(Err().Op("=").Id("s").Dot("connect").Call(), Err().Op("==").Nil()).Block(
+				Return(),
+			),
+		),
+		Id("s").Dot("end").Call(Nil(), Qual("fmt", "Errorf").Call(Lit("unable to reconnect: %w"), Err())),
+	)
+
+	file.Line()
+	file.Comment("end ends the stream with the given error if conn is the current connection,")
+	file.Comment("or regardless if conn is nil.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("end").Params(Id("conn").Add(rwc.Clone()), Err().Error()).Block(
+		lock(),
+		Defer().Add(unlock()),
+		If(Id("conn").Op("!=").Nil().Op("&&").Id("s").Dot("conn").Op("!=").Id("conn")).Block(
+			Return(),
+		),
+		If(Id("s").Dot("conn").Op("!=").Nil()).Block(
+			Id("_").Op("=").Id("s").Dot("conn").Dot("Close").Call(),
+			Id("s").Dot("conn").Op("=").Nil(),
+		),
+		Id("s").Dot("fail").Call(Err()),
+	)
+
+	file.Line()
+	file.Comment("fail records the error ending the stream, unless it has already ended.")
+	file.Comment("It must be called with s.mu held.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("fail").Params(Err().Error()).Block(
+		If(Id("s").Dot("err").Op("==").Nil()).Block(
+			Id("s").Dot("err").Op("=").Err(),
+		),
+		Id("s").Dot("notify").Call(),
+	)
+
+	file.Line()
+	file.Comment("send queues a message to be sent, waiting while the send queue is full.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("send").Params(Id("msg").Any()).Error().Block(
+		List(Id("data"), Err()).Op(":=").Qual("encoding/json", "Marshal").Call(Id("msg")),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Qual("fmt", "Errorf").Call(Lit("marshal message: %w"), Err())),
+		),
+		Line(),
+		lock(),
+		waitFor(Id("s").Dot("err").Op("==").Nil().Op("&&").Len(Id("s").Dot("queue")).Op(">=").Id("s").Dot("opts").Dot("SendQueueSize")),
+		If(Id("s").Dot("err").Op("!=").Nil()).Block(
+			Err().Op(":=").Id("s").Dot("err"),
+			unlock(),
+			Return(Err()),
+		),
+		Id("s").Dot("queue").Op("=").Append(Id("s").Dot("queue"), Id("data")),
+		unlock(),
+		Line(),
+		Id("s").Dot("flush").Call(),
+		Return(Nil()),
+	)
+
+	file.Line()
+	file.Comment("recv waits for the next message and decodes it into msg.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("recv").Params(Id("msg").Any()).Error().Block(
+		lock(),
+		waitFor(Id("s").Dot("err").Op("==").Nil().Op("&&").Len(Id("s").Dot("inbox")).Op("==").Lit(0)),
+		If(Len(Id("s").Dot("inbox")).Op("==").Lit(0)).Block(
+			Err().Op(":=").Id("s").Dot("err"),
+			unlock(),
+			Return(Err()),
+		),
+		Id("data").Op(":=").Id("s").Dot("inbox").Index(Lit(0)),
+		Id("s").Dot("inbox").Op("=").Id("s").Dot("inbox").Index(Lit(1), Empty()),
+		unlock(),
+		Line(),
+		If(Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(Id("data"), Id("msg")), Err().Op("!=").Nil()).Block(
+			Return(Qual("fmt", "Errorf").Call(Lit("decode message: %w"), Err())),
+		),
+		Return(Nil()),
+	)
+
+	file.Line()
+	file.Comment("close closes the stream.")
+	file.Func().Params(Id("s").Add(conn.Clone())).Id("close").Params().Error().Block(
+		Id("s").Dot("stop").Call(),
+		lock(),
+		Id("conn").Op(":=").Id("s").Dot("conn"),
+		Id("s").Dot("conn").Op("=").Nil(),
+		Id("s").Dot("fail").Call(Qual("io", "EOF")),
+		unlock(),
+		Line(),
+		If(Id("conn").Op("==").Nil()).Block(
+			Return(Nil()),
+		),
+		Comment("Tell the server the stream was closed normally (status code 1000)."),
+		Id("_").Op("=").Id("s").Dot("write").Call(Id("conn"), Id("wsClose"), Index().Byte().Values(Op("0x03"), Op("0xE8"))),
+		Return(Id("conn").Dot("Close").Call()),
+	)
+
+	file.Line()
+	file.Comment("The WebSocket opcodes used by streams.")
+	file.Const().Defs(
+		Id("wsText").Op("=").Lit(1),
+		Id("wsBinary").Op("=").Lit(2),
+		Id("wsClose").Op("=").Lit(8),
+		Id("wsPing").Op("=").Lit(9),
+		Id("wsPong").Op("=").Lit(10),
+	)
+
+	file.Line()
+	file.Comment("readWebSocketFrame reads a WebSocket message, joining fragmented messages.")
+	file.Func().Id("readWebSocketFrame").
+		Params(Id("reader").Op("*").Qual("bufio", "Reader")).
+		Params(Id("opcode").Byte(), Id("payload").Index().Byte(), Err().Error()).
+		Block(
+			For().Block(
+				Var().Id("header").Index(Lit(2)).Byte(),
+				If(List(Id("_"), Err()).Op(":=").Qual("io", "ReadFull").Call(Id("reader"), Id("header").Index(Op(":"))), Err().Op("!=").Nil()).Block(
+					Return(Lit(0), Nil(), Err()),
+				),
+				Id("length").Op(":=").Uint64().Call(Id("header").Index(Lit(1)).Op("&").Op("0x7F")),
+				Switch(Id("length")).Block(
+					Case(Lit(126)).Block(
+						Var().Id("ext").Index(Lit(2)).Byte(),
+						If(List(Id("_"), Err()).Op(":=").Qual("io", "ReadFull").Call(Id("reader"), Id("ext").Index(Op(":"))), Err().Op("!=").Nil()).Block(
+							Return(Lit(0), Nil(), Err()),
+						),
+						Id("length").Op("=").Uint64().Call(Qual("encoding/binary", "BigEndian").Dot("Uint16").Call(Id("ext").Index(Op(":")))),
+					),
+					Case(Lit(127)).Block(
+						Var().Id("ext").Index(Lit(8)).Byte(),
+						If(List(Id("_"), Err()).Op(":=").Qual("io", "ReadFull").Call(Id("reader"), Id("ext").Index(Op(":"))), Err().Op("!=").Nil()).Block(
+							Return(Lit(0), Nil(), Err()),
+						),
+						Id("length").Op("=").Qual("encoding/binary", "BigEndian").Dot("Uint64").Call(Id("ext").Index(Op(":"))),
+					),
+				),
+				Var().Id("mask").Index(Lit(4)).Byte(),
+				Id("masked").Op(":=").Id("header").Index(Lit(1)).Op("&").Op("0x80").Op("!=").Lit(0),
+				If(Id("masked")).Block(
+					If(List(Id("_"), Err()).Op(":=").Qual("io", "ReadFull").Call(Id("reader"), Id("mask").Index(Op(":"))), Err().Op("!=").Nil()).Block(
+						Return(Lit(0), Nil(), Err()),
+					),
+				),
+				Id("data").Op(":=").Make(Index().Byte(), Id("length")),
+				If(List(Id("_"), Err()).Op(":=").Qual("io", "ReadFull").Call(Id("reader"), Id("data")), Err().Op("!=").Nil()).Block(
+					Return(Lit(0), Nil(), Err()),
+				),
+				If(Id("masked")).Block(
+					For(Id("i").Op(":=").Range().Id("data")).Block(
+						Id("data").Index(Id("i")).Op("^=").Id("mask").Index(Id("i").Op("%").Lit(4)),
+					),
+				),
+				Line(),
+				Comment("Continuation frames have opcode 0 and continue the message."),
+				If(Id("op").Op(":=").Id("header").Index(Lit(0)).Op("&").Op("0x0F"), Id("op").Op("!=").Lit(0)).Block(
+					Id("opcode").Op("=").Id("op"),
+				),
+				Id("payload").Op("=").Append(Id("payload"), Id("data").Op("...")),
+				If(Id("header").Index(Lit(0)).Op("&").Op("0x80").Op("!=").Lit(0)).Block(
+					Return(Id("opcode"), Id("payload"), Nil()),
+				),
+			),
+		)
+
+	file.Line()
+	file.Comment("writeWebSocketFrame writes a WebSocket frame, masked as required for clients.")
+	file.Func().Id("writeWebSocketFrame").
+		Params(Id("w").Qual("io", "Writer"), Id("opcode").Byte(), Id("payload").Index().Byte()).
+		Error().
+		Block(
+			Id("frame").Op(":=").Index().Byte().Values(Op("0x80").Op("|").Id("opcode"), Op("0x80")),
+			Switch(Id("n").Op(":=").Len(Id("payload")), Empty()).Block(
+				Case(Id("n").Op("<").Lit(126)).Block(
+					Id("frame").Index(Lit(1)).Op("|=").Byte().Call(Id("n")),
+				),
+				Case(Id("n").Op("<=").Op("0xFFFF")).Block(
+					Id("frame").Index(Lit(1)).Op("|=").Lit(126),
+					Id("frame").Op("=").Qual("encoding/binary", "BigEndian").Dot("AppendUint16").Call(Id("frame"), Uint16().Call(Id("n"))),
+				),
+				Default().Block(
+					Id("frame").Index(Lit(1)).Op("|=").Lit(127),
+					Id("frame").Op("=").Qual("encoding/binary", "BigEndian").Dot("AppendUint64").Call(Id("frame"), Uint64().Call(Id("n"))),
+				),
+			),
+			Var().Id("mask").Index(Lit(4)).Byte(),
+			If(List(Id("_"), Err()).Op(":=").Qual("crypto/rand", "Read").Call(Id("mask").Index(Op(":"))), Err().Op("!=").Nil()).Block(
+				Return(Err()),
+			),
+			Id("frame").Op("=").Append(Id("frame"), Id("mask").Index(Op(":")).Op("...")),
+			For(List(Id("i"), Id("b")).Op(":=").Range().Id("payload")).Block(
+				Id("frame").Op("=").Append(Id("frame"), Id("b").Op("^").Id("mask").Index(Id("i").Op("%").Lit(4))),
+			),
+			List(Id("_"), Err()).Op(":=").Id("w").Dot("Write").Call(Id("frame")),
+			Return(Err()),
+		)
 }
 
 func (g *golang) addAuthData(grp *Group) (err error) {
@@ -62,6 +62,8 @@ type javascript struct {
 	seenHeaderResponse bool // true if we've seen a header used in a response object
 	hasAuth            bool // true if we've seen an authentication handler
 	authIsComplexType  bool // true if the auth type is a complex type
+
+	errorDefs []*meta.ErrorDefinition // the error definitions to expose as error ids
 }
 
 func (js *javascript) Version() int {
@@ -75,6 +77,7 @@ func (js *javascript) Generate(p clientgentypes.GenerateParams) (err error) {
 	js.md = p.Meta
 	js.appSlug = p.AppSlug
 	js.typs = getNamedTypes(p.Meta, p.Services)
+	js.errorDefs = errorDefinitions(p.Meta, p.Services)
 
 	if js.md.AuthHandler != nil {
 		if !js.isAuthCookiesOnly() {
@@ -166,6 +169,11 @@ func (js *javascript) writeService(svc *meta.Service, set clientgentypes.Service
 		if rpc.AccessType == meta.RPC_PRIVATE || !tags.IsRPCIncluded(rpc) {
 			continue
 		}
+
+		// server-sent event endpoints not supported yet
+		if rpc.ServerSentEvents {
+			continue
+		}
 		name := js.memberName(rpc.Name)
 		indent()
 		fmt.Fprintf(js, "this.%s = this.%s.bind(this)\n", name, name)
@@ -181,6 +189,11 @@ func (js *javascript) writeService(svc *meta.Service, set clientgentypes.Service
 			continue
 		}
 
+		// server-sent event endpoints not supported yet
+		if rpc.ServerSentEvents {
+			continue
+		}
+
 		js.WriteByte('\n')
 
 		// Doc string
@@ -194,6 +207,12 @@ func (js *javascript) writeService(svc *meta.Service, set clientgentypes.Service
 				js.WriteString(scanner.Text())
 				js.WriteByte('\n')
 			}
+			if rpc.Deprecated != nil {
+				indent()
+				js.WriteString(" * @deprecated ")
+				js.WriteString(*rpc.Deprecated)
+				js.WriteByte('\n')
+			}
 			indent()
 			js.WriteString(" */\n")
 		}
@@ -451,7 +470,15 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 		}
 
 		// Generate the body
-		if len(reqEnc.BodyParameters) > 0 {
+		if reqEnc.Multipart() {
+			// Requests containing files are sent as multipart forms
+			body = "form"
+			w.WriteString("// Construct a multipart form, as the request contains files\nconst form = new FormData()\n")
+			for _, field := range reqEnc.BodyParameters {
+				js.writeFormField(w, field)
+			}
+			w.WriteString("\n")
+		} else if len(reqEnc.BodyParameters) > 0 {
 			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
 				// In the simple case we can just encode the params as the body directly
 				body = "JSON.stringify(params)"
@@ -478,6 +505,10 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 		rpcEncoding.DefaultMethod,
 		rpcPath,
 	)
+	if body == "form" {
+		// Let fetch set the Content-Type, as it includes the form's boundary
+		callAPI = fmt.Sprintf("this.baseClient.callAPI(\"%s\", `%s`", rpcEncoding.DefaultMethod, rpcPath)
+	}
 	if body != "" || headers != "" || query != "" {
 		if body == "" {
 			callAPI += ", undefined"
@@ -1085,6 +1116,23 @@ function mustBeSet(field, value) {
 	}
 }
 
+// writeFormField writes the code appending a body parameter to a multipart form.
+func (js *javascript) writeFormField(w *indentWriter, field *encoding.ParameterEncoding) {
+	ref := js.Dot("params", field.SrcName)
+	if list := field.Type.GetList(); list != nil {
+		val := "v"
+		if !encoding.IsFileType(field.Type) {
+			val = js.convertBuiltinToString(list.Elem.GetBuiltin(), "v", false)
+		}
+		w.WriteStringf("for (const v of %s ?? []) form.append(\"%s\", %s)\n", ref, field.WireFormat, val)
+	} else if encoding.IsFileType(field.Type) {
+		w.WriteStringf("if (%s) form.append(\"%s\", %s)\n", ref, field.WireFormat, ref)
+	} else {
+		w.WriteStringf("if (%s !== undefined) form.append(\"%s\", %s)\n", ref, field.WireFormat,
+			js.convertBuiltinToString(field.Type.GetBuiltin(), ref, false))
+	}
+}
+
 func (js *javascript) convertBuiltinToString(typ schema.Builtin, val string, isOptional bool) string {
 	var code string
 	switch typ {
@@ -1289,7 +1337,16 @@ export class APIError extends Error {
          * The error details
          */
         this.details = response.details
-    }
+`)
+	if len(js.errorDefs) > 0 {
+		w.WriteString(`
+        /**
+         * The id of the error definition the error was created from, if any
+         */
+        this.errorID = response.error_id
+`)
+	}
+	w.WriteString(`    }
 }
 
 /**
@@ -1494,4 +1551,26 @@ export const ErrCode = {
     Unauthenticated: "unauthenticated"
 }
 `)
+
+	if len(js.errorDefs) > 0 {
+		w.WriteString("\n/**\n * ErrorID identifies the errors declared by the application with errs.Define.\n */\n")
+		w.WriteString("export const ErrorID = {\n")
+		w = w.Indent()
+		for i, def := range js.errorDefs {
+			if i > 0 {
+				w.WriteString(",\n\n")
+			}
+			if doc := def.GetDoc(); doc != "" {
+				scanner := bufio.NewScanner(strings.NewReader(doc))
+				w.WriteString("/**\n")
+				for scanner.Scan() {
+					w.WriteString(" * " + scanner.Text() + "\n")
+				}
+				w.WriteString(" */\n")
+			}
+			w.WriteStringf("%s: %s", errorIDName(def), js.Quote(def.Id))
+		}
+		w = w.Dedent()
+		w.WriteString("\n}\n")
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/doc/comment"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -66,6 +67,7 @@ func (g *Generator) Generate(p clientgentypes.GenerateParams) (err error) {
 			}
 		}
 	}
+	g.addErrorDefinitions(p.Services)
 
 	out, err := g.spec.MarshalJSON()
 	if err != nil {
@@ -100,6 +102,35 @@ func (g *Generator) addService(svc *meta.Service, tags clientgentypes.TagSet, op
 	return nil
 }
 
+// addErrorDefinitions documents the ids of the error definitions
+// the included services may return in the APIError response.
+func (g *Generator) addErrorDefinitions(services clientgentypes.ServiceSet) {
+	var (
+		ids  []any
+		desc strings.Builder
+	)
+	desc.WriteString("The id of the error definition the error was created from, if any:\n")
+	for _, def := range g.md.ErrorDefinitions {
+		if def.ServiceName != nil && !services.Has(def.GetServiceName()) {
+			continue
+		}
+		ids = append(ids, def.Id)
+		fmt.Fprintf(&desc, "\n- `%s` (%s): %s", def.Id, def.Code, def.Message)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	errSchema := g.spec.Components.Responses["APIError"].Value.Content["application/json"].Schema.Value
+	errSchema.Properties["error_id"] = &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Description: desc.String(),
+			Type:        openapi3.TypeString,
+			Enum:        ids,
+		},
+	}
+}
+
 func (g *Generator) addRPC(rpc *meta.RPC) error {
 	item := g.getOrCreatePath(rpc)
 
@@ -142,6 +173,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 		Description: desc,
 		OperationID: method + ":" + rpc.ServiceName + "." + rpc.Name,
 		Responses:   make(openapi3.Responses),
+		Deprecated:  rpc.Deprecated != nil,
 	}
 
 	// Add path parameters
@@ -182,7 +214,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 				AllowReserved:   false,
 				Deprecated:      false,
 				Required:        !param.Optional,
-				Schema:          g.schemaType(param.Type),
+				Schema:          g.paramSchema(param),
 				Example:         nil,
 				Examples:        nil,
 				Content:         nil,
@@ -203,7 +235,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 				AllowReserved:   false,
 				Deprecated:      false,
 				Required:        !param.Optional,
-				Schema:          g.schemaType(param.Type),
+				Schema:          g.paramSchema(param),
 				Example:         nil,
 				Examples:        nil,
 				Content:         nil,
@@ -241,7 +273,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 						AllowReserved:   false,
 						Deprecated:      false,
 						Required:        !param.Optional,
-						Schema:          g.schemaType(param.Type),
+						Schema:          g.paramSchema(param),
 						Example:         nil,
 						Examples:        nil,
 						Content:         nil,
@@ -262,6 +294,25 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 		}
 	}
 
+	// Describe the endpoint's request limits
+	limits := make(map[string]any)
+	if rpc.TimeoutMillis != nil {
+		limits["x-encore-timeout"] = (time.Duration(*rpc.TimeoutMillis) * time.Millisecond).String()
+	}
+	if rpc.BodyLimit != nil {
+		limits["x-encore-max-body-size"] = *rpc.BodyLimit
+		if op.RequestBody != nil {
+			op.Responses["413"] = &openapi3.ResponseRef{
+				Value: &openapi3.Response{
+					Description: ptr(fmt.Sprintf("Request body larger than %d bytes", *rpc.BodyLimit)),
+				},
+			}
+		}
+	}
+	if len(limits) > 0 {
+		op.Extensions = limits
+	}
+
 	return op, nil
 }
 
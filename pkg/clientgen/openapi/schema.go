@@ -3,6 +3,7 @@ package openapi
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -22,6 +23,10 @@ func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.C
 	props := make(openapi3.Schemas)
 	for _, p := range params {
 		val := g.schemaType(p.Type)
+		if p.StringEncoded {
+			val = stringEncodedSchema().NewRef()
+		}
+		withMaxSize(val, p.MaxSize)
 		if vv := val.Value; vv != nil {
 			vv.Title, vv.Description = splitDoc(p.Doc)
 		}
@@ -35,8 +40,14 @@ func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.C
 	s.Properties = props
 	s.Required = required
 
+	// Bodies containing files are sent as multipart forms.
+	mediaType := "application/json"
+	if slices.ContainsFunc(params, func(p *encoding.ParameterEncoding) bool { return encoding.IsFileType(p.Type) }) {
+		mediaType = "multipart/form-data"
+	}
+
 	return openapi3.Content{
-		"application/json": &openapi3.MediaType{
+		mediaType: &openapi3.MediaType{
 			Schema:   s.NewRef(),
 			Example:  nil,
 			Examples: nil,
@@ -67,6 +78,10 @@ func (g *Generator) schemaType(typ *schema.Type) *openapi3.SchemaRef {
 			}
 
 			val := g.schemaType(f.Typ)
+			if f.StringEncoded {
+				val = stringEncodedSchema().NewRef()
+			}
+			withMaxSize(val, f.GetMaxSize())
 
 			if vv := val.Value; vv != nil {
 				// Direct schema - can set title and description directly
@@ -218,6 +233,27 @@ func (g *Generator) schemaType(typ *schema.Type) *openapi3.SchemaRef {
 	}
 }
 
+// stringEncodedSchema returns the schema for 64-bit integers
+// that are encoded as JSON strings, using the encore:"string" tag.
+func stringEncodedSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithFormat("int64")
+}
+
+// paramSchema returns the schema for a header or query parameter.
+func (g *Generator) paramSchema(p *encoding.ParameterEncoding) *openapi3.SchemaRef {
+	val := g.schemaType(p.Type)
+	withMaxSize(val, p.MaxSize)
+	return val
+}
+
+// withMaxSize limits the length of a base64-encoded []byte schema
+// to the encoded length of its maximum size, as set with the encore:"maxsize=N" tag.
+func withMaxSize(val *openapi3.SchemaRef, maxSize uint64) {
+	if maxSize > 0 && val.Value != nil {
+		val.Value.WithMaxLength(int64((maxSize + 2) / 3 * 4))
+	}
+}
+
 func (g *Generator) builtinSchemaType(t schema.Builtin) *openapi3.Schema {
 	switch t {
 	case schema.Builtin_BOOL:
@@ -254,6 +290,8 @@ func (g *Generator) builtinSchemaType(t schema.Builtin) *openapi3.Schema {
 		return openapi3.NewStringSchema()
 	case schema.Builtin_DECIMAL:
 		return openapi3.NewStringSchema()
+	case schema.Builtin_FILE:
+		return openapi3.NewStringSchema().WithFormat("binary")
 	default:
 		doBailout(errors.Newf("unknown builtin type %v", t))
 		panic("unreachable")
@@ -380,6 +418,8 @@ func (g *Generator) typeToDefinitionName(typ *schema.Type) string {
 			return "uint"
 		case schema.Builtin_DECIMAL:
 			return "string"
+		case schema.Builtin_FILE:
+			return "file"
 		default:
 			return ""
 		}
@@ -0,0 +1,499 @@
+// Package protobuf describes the endpoints exposed over gRPC as protobuf services.
+package protobuf
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// Register the well-known types the descriptor may depend on,
+	// for validating it using protodesc.
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+
+	"encr.dev/pkg/idents"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// Package is the protobuf package the services are declared in.
+const Package = "encore.app"
+
+// FileName is the name of the file the services are declared in.
+const FileName = "encore/app.proto"
+
+// Descriptor describes the endpoints exposed over gRPC in md
+// as a protobuf file, with one service per Encore service.
+//
+// It returns nil if no endpoints are exposed over gRPC.
+func Descriptor(md *meta.Data) (*descriptorpb.FileDescriptorProto, error) {
+	include := func(*meta.Service, *meta.RPC) bool { return true }
+	f, err := build(md, include)
+	if err != nil || f == nil {
+		return nil, err
+	}
+	return f.desc, nil
+}
+
+// file is a protobuf file being built.
+type file struct {
+	desc *descriptorpb.FileDescriptorProto
+
+	// docs are the doc comments of the services, methods and messages,
+	// keyed by their fully qualified name.
+	docs map[string]string
+}
+
+type builder struct {
+	md   *meta.Data
+	file *file
+
+	declMsgs  map[uint32]string // decl id -> message name
+	names     map[string]bool   // top-level message names in use
+	ambiguous map[string]bool   // message names used by more than one type
+	deps      map[string]bool   // imported files
+}
+
+// message is a message being built.
+type message struct {
+	desc     *descriptorpb.DescriptorProto
+	fullName string
+}
+
+// build describes the endpoints exposed over gRPC in md that include reports true for.
+// It returns nil if there are no such endpoints.
+func build(md *meta.Data, include func(*meta.Service, *meta.RPC) bool) (*file, error) {
+	b := &builder{
+		md: md,
+		file: &file{
+			desc: &descriptorpb.FileDescriptorProto{
+				Name:    proto.String(FileName),
+				Package: proto.String(Package),
+				Syntax:  proto.String("proto3"),
+			},
+			docs: make(map[string]string),
+		},
+		declMsgs: make(map[uint32]string),
+		names:    make(map[string]bool),
+		deps:     make(map[string]bool),
+	}
+	b.ambiguous = ambiguousNames(md)
+
+	for _, svc := range md.Svcs {
+		var methods []*descriptorpb.MethodDescriptorProto
+		for _, rpc := range svc.Rpcs {
+			if !rpc.Grpc || !include(svc, rpc) {
+				continue
+			}
+			m, err := b.method(rpc)
+			if err != nil {
+				return nil, fmt.Errorf("describe endpoint %s.%s: %v", svc.Name, rpc.Name, err)
+			}
+			methods = append(methods, m)
+			b.file.docs[Package+"."+svc.Name+"."+rpc.Name] = rpc.GetDoc()
+		}
+		if len(methods) > 0 {
+			b.file.desc.Service = append(b.file.desc.Service, &descriptorpb.ServiceDescriptorProto{
+				Name:   proto.String(svc.Name),
+				Method: methods,
+			})
+		}
+	}
+	if len(b.file.desc.Service) == 0 {
+		return nil, nil
+	}
+
+	for _, dep := range []string{"google/protobuf/empty.proto", "google/protobuf/struct.proto", "google/protobuf/timestamp.proto"} {
+		if b.deps[dep] {
+			b.file.desc.Dependency = append(b.file.desc.Dependency, dep)
+		}
+	}
+
+	// Make sure the descriptor is valid, such as not having conflicting field names.
+	if _, err := protodesc.NewFile(b.file.desc, protoregistry.GlobalFiles); err != nil {
+		return nil, fmt.Errorf("invalid protobuf descriptor: %v", err)
+	}
+	return b.file, nil
+}
+
+func (b *builder) method(rpc *meta.RPC) (*descriptorpb.MethodDescriptorProto, error) {
+	var params []*meta.PathSegment
+	for _, seg := range rpc.Path.GetSegments() {
+		if seg.Type != meta.PathSegment_LITERAL {
+			params = append(params, seg)
+		}
+	}
+
+	var input string
+	switch req := rpc.RequestSchema; {
+	case len(params) > 0:
+		// Path parameters are not part of the request type, so describe them
+		// along with the request fields in a message of their own.
+		name := b.messageName(rpc.Name+"Request", rpc.ServiceName)
+		msg := b.newMessage(name)
+		for _, p := range params {
+			msg.desc.Field = append(msg.desc.Field, &descriptorpb.FieldDescriptorProto{
+				Name:     proto.String(idents.Convert(p.Value, idents.SnakeCase)),
+				JsonName: proto.String(p.Value),
+				Number:   proto.Int32(int32(len(msg.desc.Field) + 1)),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     pathParamType(p.ValueType).Enum(),
+			})
+		}
+		if req != nil {
+			st, err := b.requestStruct(req)
+			if err != nil {
+				return nil, err
+			}
+			if err := b.addFields(msg, st.Fields); err != nil {
+				return nil, err
+			}
+		}
+		input = msg.fullName
+	case req != nil:
+		st := b.resolve(req)
+		named := st.GetNamed()
+		if named == nil || b.md.Decls[named.Id].Type.GetStruct() == nil {
+			return nil, fmt.Errorf("request type must be a named struct")
+		}
+		name, err := b.declMessage(named)
+		if err != nil {
+			return nil, err
+		}
+		input = name
+	default:
+		input = b.empty()
+	}
+
+	var output string
+	if resp := rpc.ResponseSchema; resp != nil {
+		named := b.resolve(resp).GetNamed()
+		if named == nil || b.md.Decls[named.Id].Type.GetStruct() == nil {
+			return nil, fmt.Errorf("response type must be a named struct")
+		}
+		name, err := b.declMessage(named)
+		if err != nil {
+			return nil, err
+		}
+		output = name
+	} else {
+		output = b.empty()
+	}
+
+	return &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String(rpc.Name),
+		InputType:  proto.String(input),
+		OutputType: proto.String(output),
+	}, nil
+}
+
+// requestStruct returns the struct of the request type.
+func (b *builder) requestStruct(typ *schema.Type) (*schema.Struct, error) {
+	named := b.resolve(typ).GetNamed()
+	if named == nil {
+		return nil, fmt.Errorf("request type must be a named struct")
+	} else if len(named.TypeArguments) > 0 {
+		return nil, fmt.Errorf("generic types are not supported")
+	}
+	st := b.md.Decls[named.Id].Type.GetStruct()
+	if st == nil {
+		return nil, fmt.Errorf("request type must be a named struct")
+	}
+	return st, nil
+}
+
+// empty returns the fully qualified name of the empty message.
+func (b *builder) empty() string {
+	b.deps["google/protobuf/empty.proto"] = true
+	return ".google.protobuf.Empty"
+}
+
+// ambiguousNames returns the message names that would be used by more than one type,
+// considering all struct types and request messages regardless of which endpoints
+// are exposed, so the names don't change as endpoints are exposed over gRPC.
+func ambiguousNames(md *meta.Data) map[string]bool {
+	counts := make(map[string]int)
+	for _, decl := range md.Decls {
+		if decl.Type.GetStruct() != nil {
+			counts[decl.Name]++
+		}
+	}
+	for _, svc := range md.Svcs {
+		for _, rpc := range svc.Rpcs {
+			counts[rpc.Name+"Request"]++
+		}
+	}
+
+	ambiguous := make(map[string]bool)
+	for name, n := range counts {
+		if n > 1 {
+			ambiguous[name] = true
+		}
+	}
+	return ambiguous
+}
+
+// messageName returns a unique name for a top-level message,
+// qualifying ambiguous names with the given package name.
+func (b *builder) messageName(name, pkgName string) string {
+	candidate := name
+	if b.ambiguous[name] || b.names[candidate] {
+		candidate = idents.Convert(pkgName, idents.PascalCase) + name
+	}
+	for i := 2; b.names[candidate]; i++ {
+		candidate = idents.Convert(pkgName, idents.PascalCase) + name + strconv.Itoa(i)
+	}
+	b.names[candidate] = true
+	return candidate
+}
+
+// newMessage adds a new top-level message with the given name.
+func (b *builder) newMessage(name string) message {
+	desc := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	b.file.desc.MessageType = append(b.file.desc.MessageType, desc)
+	return message{desc: desc, fullName: "." + Package + "." + name}
+}
+
+// declMessage returns the fully qualified name of the message
+// describing the given named struct, adding it if necessary.
+func (b *builder) declMessage(named *schema.Named) (string, error) {
+	if len(named.TypeArguments) > 0 {
+		return "", fmt.Errorf("generic types are not supported")
+	}
+	if name, ok := b.declMsgs[named.Id]; ok {
+		return "." + Package + "." + name, nil
+	}
+
+	decl := b.md.Decls[named.Id]
+	name := b.messageName(decl.Name, decl.Loc.GetPkgName())
+	b.declMsgs[named.Id] = name // before adding fields, to support recursive types
+	msg := b.newMessage(name)
+	b.file.docs[msg.fullName[1:]] = decl.Doc
+	if err := b.addFields(msg, decl.Type.GetStruct().Fields); err != nil {
+		return "", fmt.Errorf("%s: %v", decl.Name, err)
+	}
+	return msg.fullName, nil
+}
+
+// resolve resolves named types that aren't structs to the type they represent.
+func (b *builder) resolve(typ *schema.Type) *schema.Type {
+	for {
+		named := typ.GetNamed()
+		if named == nil || len(named.TypeArguments) > 0 {
+			return typ
+		}
+		decl := b.md.Decls[named.Id]
+		if decl.Type.GetStruct() != nil {
+			return typ
+		}
+		typ = decl.Type
+	}
+}
+
+// addFields adds the given struct fields to msg, numbered in declaration order.
+// Fields omitted from JSON, or sent outside the body, are skipped.
+func (b *builder) addFields(msg message, fields []*schema.Field) error {
+	for _, f := range fields {
+		if f.JsonName == "-" || f.Wire != nil {
+			continue
+		}
+		jsonName := f.JsonName
+		if jsonName == "" {
+			jsonName = f.Name
+		}
+
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(idents.Convert(f.Name, idents.SnakeCase)),
+			JsonName: proto.String(jsonName),
+			Number:   proto.Int32(int32(len(msg.desc.Field) + 1)),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+		nullable, err := b.setType(fd, f.Typ, msg, f.Name)
+		if err != nil {
+			return fmt.Errorf("field %s: %v", f.Name, err)
+		}
+
+		// Give optional scalars explicit presence. Messages always have it.
+		if (nullable || f.Optional) && fd.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED &&
+			fd.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			fd.Proto3Optional = proto.Bool(true)
+			fd.OneofIndex = proto.Int32(int32(len(msg.desc.OneofDecl)))
+			msg.desc.OneofDecl = append(msg.desc.OneofDecl, &descriptorpb.OneofDescriptorProto{
+				Name: proto.String("_" + fd.GetName()),
+			})
+		}
+		msg.desc.Field = append(msg.desc.Field, fd)
+	}
+	return nil
+}
+
+// setType sets the type of fd to represent typ, adding any nested messages to parent.
+// It reports whether typ is a pointer or option, meaning the field is nullable.
+func (b *builder) setType(fd *descriptorpb.FieldDescriptorProto, typ *schema.Type, parent message, goName string) (nullable bool, err error) {
+	switch t := b.resolve(typ).Typ.(type) {
+	case *schema.Type_Pointer:
+		_, err := b.setType(fd, t.Pointer.Base, parent, goName)
+		return true, err
+
+	case *schema.Type_Option:
+		_, err := b.setType(fd, t.Option.Value, parent, goName)
+		return true, err
+
+	case *schema.Type_Builtin:
+		return false, b.setBuiltin(fd, t.Builtin)
+
+	case *schema.Type_Named:
+		name, err := b.declMessage(t.Named)
+		if err != nil {
+			return false, err
+		}
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String(name)
+		return false, nil
+
+	case *schema.Type_Struct:
+		name := idents.Convert(goName, idents.PascalCase)
+		nested := message{
+			desc:     &descriptorpb.DescriptorProto{Name: proto.String(name)},
+			fullName: parent.fullName + "." + name,
+		}
+		parent.desc.NestedType = append(parent.desc.NestedType, nested.desc)
+		if err := b.addFields(nested, t.Struct.Fields); err != nil {
+			return false, err
+		}
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String(nested.fullName)
+		return false, nil
+
+	case *schema.Type_List:
+		elem := b.resolve(t.List.Elem)
+		if elem.GetList() != nil || elem.GetMap() != nil {
+			return false, fmt.Errorf("nested lists and lists of maps are not supported")
+		}
+		if _, err := b.setType(fd, elem, parent, goName); err != nil {
+			return false, err
+		}
+		fd.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return false, nil
+
+	case *schema.Type_Map:
+		return false, b.setMap(fd, t.Map, parent, goName)
+
+	default:
+		return false, fmt.Errorf("unsupported type")
+	}
+}
+
+// setMap sets the type of fd to a protobuf map,
+// adding the map entry message to parent.
+func (b *builder) setMap(fd *descriptorpb.FieldDescriptorProto, m *schema.Map, parent message, goName string) error {
+	name := idents.Convert(goName, idents.PascalCase) + "Entry"
+	entry := message{
+		desc: &descriptorpb.DescriptorProto{
+			Name:    proto.String(name),
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		},
+		fullName: parent.fullName + "." + name,
+	}
+
+	key := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("key"),
+		JsonName: proto.String("key"),
+		Number:   proto.Int32(1),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	keyType, ok := b.resolve(m.Key).Typ.(*schema.Type_Builtin)
+	if !ok {
+		return fmt.Errorf("map keys must be strings, integers, or booleans")
+	}
+	if err := b.setBuiltin(key, keyType.Builtin); err != nil {
+		return err
+	}
+	switch key.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+		descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		return fmt.Errorf("map keys must be strings, integers, or booleans")
+	}
+
+	value := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("value"),
+		JsonName: proto.String("value"),
+		Number:   proto.Int32(2),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if v := b.resolve(m.Value); v.GetList() != nil || v.GetMap() != nil {
+		return fmt.Errorf("map values cannot be lists or maps")
+	}
+	if _, err := b.setType(value, m.Value, parent, goName+"Value"); err != nil {
+		return err
+	}
+
+	entry.desc.Field = []*descriptorpb.FieldDescriptorProto{key, value}
+	parent.desc.NestedType = append(parent.desc.NestedType, entry.desc)
+	fd.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	fd.TypeName = proto.String(entry.fullName)
+	return nil
+}
+
+// setBuiltin sets the type of fd to represent the given builtin.
+func (b *builder) setBuiltin(fd *descriptorpb.FieldDescriptorProto, builtin schema.Builtin) error {
+	var typ descriptorpb.FieldDescriptorProto_Type
+	switch builtin {
+	case schema.Builtin_BOOL:
+		typ = descriptorpb.FieldDescriptorProto_TYPE_BOOL
+	case schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32:
+		typ = descriptorpb.FieldDescriptorProto_TYPE_INT32
+	case schema.Builtin_INT64, schema.Builtin_INT:
+		typ = descriptorpb.FieldDescriptorProto_TYPE_INT64
+	case schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32:
+		typ = descriptorpb.FieldDescriptorProto_TYPE_UINT32
+	case schema.Builtin_UINT64, schema.Builtin_UINT:
+		typ = descriptorpb.FieldDescriptorProto_TYPE_UINT64
+	case schema.Builtin_FLOAT32:
+		typ = descriptorpb.FieldDescriptorProto_TYPE_FLOAT
+	case schema.Builtin_FLOAT64:
+		typ = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE
+	case schema.Builtin_STRING, schema.Builtin_UUID, schema.Builtin_USER_ID, schema.Builtin_DECIMAL:
+		typ = descriptorpb.FieldDescriptorProto_TYPE_STRING
+	case schema.Builtin_BYTES, schema.Builtin_FILE:
+		typ = descriptorpb.FieldDescriptorProto_TYPE_BYTES
+	case schema.Builtin_TIME:
+		b.deps["google/protobuf/timestamp.proto"] = true
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String(".google.protobuf.Timestamp")
+		return nil
+	case schema.Builtin_JSON:
+		b.deps["google/protobuf/struct.proto"] = true
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String(".google.protobuf.Value")
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %s", builtin)
+	}
+	fd.Type = typ.Enum()
+	return nil
+}
+
+// pathParamType returns the protobuf type of a path parameter.
+func pathParamType(typ meta.PathSegment_ParamType) descriptorpb.FieldDescriptorProto_Type {
+	switch typ {
+	case meta.PathSegment_BOOL:
+		return descriptorpb.FieldDescriptorProto_TYPE_BOOL
+	case meta.PathSegment_INT8, meta.PathSegment_INT16, meta.PathSegment_INT32:
+		return descriptorpb.FieldDescriptorProto_TYPE_INT32
+	case meta.PathSegment_INT64, meta.PathSegment_INT:
+		return descriptorpb.FieldDescriptorProto_TYPE_INT64
+	case meta.PathSegment_UINT8, meta.PathSegment_UINT16, meta.PathSegment_UINT32:
+		return descriptorpb.FieldDescriptorProto_TYPE_UINT32
+	case meta.PathSegment_UINT64, meta.PathSegment_UINT:
+		return descriptorpb.FieldDescriptorProto_TYPE_UINT64
+	default:
+		return descriptorpb.FieldDescriptorProto_TYPE_STRING
+	}
+}
@@ -0,0 +1,196 @@
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"encr.dev/internal/version"
+	"encr.dev/pkg/clientgen/clientgentypes"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+type GenVersion int
+
+const (
+	// Initial is the originally released protobuf generator.
+	Initial GenVersion = iota
+
+	// Experimental can be used to lock experimental or uncompleted features in the generated code
+	// It should always be the last item in the enum.
+	Experimental
+
+	LatestVersion GenVersion = Experimental - 1
+)
+
+// Generator generates a .proto file describing the endpoints exposed over gRPC,
+// for use with native gRPC clients.
+type Generator struct {
+	ver GenVersion
+}
+
+func New(version GenVersion) *Generator {
+	return &Generator{ver: version}
+}
+
+func (g *Generator) Version() int {
+	return int(g.ver)
+}
+
+func (g *Generator) Generate(p clientgentypes.GenerateParams) error {
+	include := func(svc *meta.Service, rpc *meta.RPC) bool {
+		return p.Services.Has(svc.Name) && p.Tags.IsRPCIncluded(rpc)
+	}
+	f, err := build(p.Meta, include)
+	if err != nil {
+		return err
+	} else if f == nil {
+		return errors.New("no endpoints are exposed over gRPC (use the grpc option in the //encore:api directive to expose them)")
+	}
+
+	w := &printer{file: f}
+	w.printFile()
+	p.Buf.WriteString(w.String())
+	return nil
+}
+
+type printer struct {
+	strings.Builder
+	file   *file
+	indent int
+}
+
+func (w *printer) line(format string, args ...any) {
+	if format == "" {
+		w.WriteByte('\n')
+		return
+	}
+	w.WriteString(strings.Repeat("  ", w.indent))
+	fmt.Fprintf(w, format, args...)
+	w.WriteByte('\n')
+}
+
+func (w *printer) doc(fullName string) {
+	doc := strings.TrimSpace(w.file.docs[fullName])
+	if doc == "" {
+		return
+	}
+	for _, l := range strings.Split(doc, "\n") {
+		w.line("// %s", strings.TrimRight(l, " \t"))
+	}
+}
+
+func (w *printer) printFile() {
+	fd := w.file.desc
+	w.line("// Code generated by the Encore %s client generator. DO NOT EDIT.", version.Version)
+	w.line("")
+	w.line("syntax = %q;", fd.GetSyntax())
+	w.line("")
+	w.line("package %s;", fd.GetPackage())
+
+	if len(fd.Dependency) > 0 {
+		w.line("")
+		for _, dep := range fd.Dependency {
+			w.line("import %q;", dep)
+		}
+	}
+
+	for _, svc := range fd.Service {
+		w.line("")
+		w.line("service %s {", svc.GetName())
+		w.indent++
+		for i, m := range svc.Method {
+			if i > 0 {
+				w.line("")
+			}
+			w.doc(fd.GetPackage() + "." + svc.GetName() + "." + m.GetName())
+			w.line("rpc %s(%s) returns (%s);", m.GetName(), w.typeName(m.GetInputType()), w.typeName(m.GetOutputType()))
+		}
+		w.indent--
+		w.line("}")
+	}
+
+	for _, msg := range fd.MessageType {
+		w.line("")
+		w.printMessage(msg, fd.GetPackage()+"."+msg.GetName())
+	}
+}
+
+func (w *printer) printMessage(msg *descriptorpb.DescriptorProto, fullName string) {
+	w.doc(fullName)
+	w.line("message %s {", msg.GetName())
+	w.indent++
+
+	entries := make(map[string]*descriptorpb.DescriptorProto)
+	for _, nested := range msg.NestedType {
+		if nested.GetOptions().GetMapEntry() {
+			entries["."+fullName+"."+nested.GetName()] = nested
+		}
+	}
+
+	for _, f := range msg.Field {
+		var typ string
+		switch entry := entries[f.GetTypeName()]; {
+		case entry != nil:
+			typ = fmt.Sprintf("map<%s, %s>", w.fieldType(entry.Field[0]), w.fieldType(entry.Field[1]))
+		case f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED:
+			typ = "repeated " + w.fieldType(f)
+		case f.GetProto3Optional():
+			typ = "optional " + w.fieldType(f)
+		default:
+			typ = w.fieldType(f)
+		}
+
+		var opts string
+		if f.GetJsonName() != defaultJSONName(f.GetName()) {
+			opts = fmt.Sprintf(" [json_name = %q]", f.GetJsonName())
+		}
+		w.line("%s %s = %d%s;", typ, f.GetName(), f.GetNumber(), opts)
+	}
+
+	for _, nested := range msg.NestedType {
+		if !nested.GetOptions().GetMapEntry() {
+			w.line("")
+			w.printMessage(nested, fullName+"."+nested.GetName())
+		}
+	}
+
+	w.indent--
+	w.line("}")
+}
+
+func (w *printer) fieldType(f *descriptorpb.FieldDescriptorProto) string {
+	if f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		return w.typeName(f.GetTypeName())
+	}
+	// TYPE_INT64 -> int64, and so on.
+	return strings.ToLower(strings.TrimPrefix(f.GetType().String(), "TYPE_"))
+}
+
+// typeName returns the name to refer to the given fully qualified message by,
+// relative to the file's package when possible.
+func (w *printer) typeName(fullName string) string {
+	name := strings.TrimPrefix(fullName, ".")
+	return strings.TrimPrefix(name, w.file.desc.GetPackage()+".")
+}
+
+// defaultJSONName returns the JSON name protoc assigns a field
+// with the given name, by converting it to lowerCamelCase.
+func defaultJSONName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -93,6 +93,9 @@ export interface ClientOptions {
     /** Default RequestInit to be used for the client */
     requestInit?: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
 
+    /** Options for streams to streaming API endpoints */
+    stream?: StreamOptions
+
     /**
      * Allows you to set the auth token to be used for each request
      * either by passing in a static token string or by passing in a function
@@ -158,6 +161,26 @@ function makeRecord<K extends string | number | symbol, V>(record: Record<K, V |
     return record as Record<K, V>
 }
 
+/**
+ * StreamOptions configures streams to streaming API endpoints.
+ */
+export interface StreamOptions {
+    /**
+     * Whether to reconnect when the connection is lost, resuming the stream
+     * where it left off. Defaults to true.
+     */
+    reconnect?: boolean
+
+    /** The maximum number of consecutive reconnection attempts. Defaults to 10. */
+    maxReconnectAttempts?: number
+
+    /**
+     * The maximum number of messages waiting to be sent or acknowledged by the
+     * server. Sending waits while the queue is full. Defaults to 64.
+     */
+    sendQueueSize?: number
+}
+
 function encodeWebSocketHeaders(headers: Record<string, string>) {
     // url safe, no pad
     const base64encoded = btoa(JSON.stringify(headers))
@@ -167,26 +190,172 @@ function encodeWebSocketHeaders(headers: Record<string, string>) {
     return "encore.dev.headers." + base64encoded;
 }
 
+/**
+ * WebSocketConnection is a connection to a streaming API endpoint.
+ *
+ * If the connection is lost it reconnects and resumes the stream where it left off,
+ * receiving the messages it missed and resending the ones the server didn't receive.
+ */
 class WebSocketConnection {
     public ws: WebSocket;
 
+    private readonly url: string;
+    private readonly headers?: Record<string, string>;
+    private readonly options: StreamOptions;
+
+    private messageHandlers: ((data: string) => void)[] = [];
+    private listeners: ["error" | "close" | "message" | "open", (event: any) => void][] = [];
     private hasUpdateHandlers: (() => void)[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        let protocols = ["encore-ws"];
-        if (headers) {
+    // The token identifying the stream when resuming it, and the number
+    // of messages received from the server.
+    private resumeToken?: string;
+    private received = 0;
+
+    // The messages waiting to be sent or acknowledged by the server, the number
+    // of them sent on the current connection, and the number acknowledged so far.
+    private queue: string[] = [];
+    private sent = 0;
+    private acked = 0;
+
+    private ready = false;
+    private attempts = 0;
+    private done = false;
+
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.url = url;
+        this.headers = headers;
+        this.options = options ?? {};
+        this.ws = this.connect();
+    }
+
+    // closed reports whether the stream has ended.
+    get closed(): boolean {
+        return this.done;
+    }
+
+    private connect(): WebSocket {
+        let protocols = ["encore-ws-resume", "encore-ws"];
+        if (this.headers || this.resumeToken) {
+            const headers = { ...this.headers };
+            if (this.resumeToken) {
+                headers["x-encore-resume-token"] = this.resumeToken;
+                headers["x-encore-resume-received"] = String(this.received);
+            }
             protocols.push(encodeWebSocketHeaders(headers))
         }
 
-        this.ws = new WebSocket(url, protocols)
+        const ws = new WebSocket(this.url, protocols);
+        ws.binaryType = "arraybuffer";
+        this.ready = false;
 
-        this.on("error", () => {
+        ws.addEventListener("open", () => {
+            // Servers resuming streams acknowledge the connection with a control message.
+            if (ws.protocol !== "encore-ws-resume") {
+                this.ready = true;
+                this.flush();
+            }
+        });
+
+        ws.addEventListener("message", (event: MessageEvent) => {
+            if (typeof event.data === "string") {
+                this.received++;
+                for (const handler of this.messageHandlers) {
+                    handler(event.data);
+                }
+            } else {
+                this.handleControl(JSON.parse(new TextDecoder().decode(event.data)));
+            }
             this.resolveHasUpdateHandlers();
         });
 
-        this.on("close", () => {
+        ws.addEventListener("close", (event: CloseEvent) => {
+            this.handleClose(event);
+        });
+
+        ws.addEventListener("error", () => {
             this.resolveHasUpdateHandlers();
         });
+
+        for (const [type, handler] of this.listeners) {
+            ws.addEventListener(type, handler);
+        }
+
+        return ws;
+    }
+
+    private handleControl(msg: { token: string; received: number }) {
+        this.resumeToken = msg.token;
+
+        // Forget the messages the server has received.
+        const acked = msg.received - this.acked;
+        if (acked > 0) {
+            this.queue.splice(0, acked);
+            this.sent = Math.max(0, this.sent - acked);
+            this.acked = msg.received;
+        }
+
+        if (!this.ready) {
+            // We're connected: resend the messages the server didn't receive.
+            this.ready = true;
+            this.attempts = 0;
+            this.sent = 0;
+        }
+        this.flush();
+    }
+
+    private handleClose(event: CloseEvent) {
+        this.ready = false;
+
+        // Only streams whose connection was lost (code 1006) can be resumed.
+        const reconnect = this.options.reconnect ?? true;
+        const maxAttempts = this.options.maxReconnectAttempts ?? 10;
+        if (!this.done && reconnect && this.resumeToken && event.code === 1006 && this.attempts < maxAttempts) {
+            const delay = Math.min(250 * 2 ** this.attempts, 10000);
+            this.attempts++;
+            setTimeout(() => {
+                if (!this.done) {
+                    this.ws = this.connect();
+                }
+            }, delay);
+        } else {
+            this.done = true;
+        }
+
+        this.resolveHasUpdateHandlers();
+    }
+
+    // flush sends the queued messages not yet sent on the current connection.
+    private flush() {
+        if (!this.ready || this.ws.readyState !== WebSocket.OPEN) return;
+
+        while (this.sent < this.queue.length) {
+            this.ws.send(this.queue[this.sent++]);
+        }
+
+        // Without acknowledgements from the server, sent messages are forgotten right away.
+        if (this.ws.protocol !== "encore-ws-resume") {
+            this.queue = [];
+            this.sent = 0;
+        }
+        this.resolveHasUpdateHandlers();
+    }
+
+    async send(data: string) {
+        const size = this.options.sendQueueSize ?? 64;
+        while (this.queue.length >= size && !this.done) {
+            await this.hasUpdate();
+        }
+        if (this.done) {
+            throw new Error("stream is closed");
+        }
+
+        this.queue.push(data);
+        this.flush();
+    }
+
+    onMessage(handler: (data: string) => void) {
+        this.messageHandlers.push(handler);
     }
 
     resolveHasUpdateHandlers() {
@@ -206,15 +375,19 @@ class WebSocketConnection {
     }
 
     on(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+        this.listeners.push([type, handler]);
         this.ws.addEventListener(type, handler);
     }
 
     off(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+        this.listeners = this.listeners.filter(([t, h]) => t !== type || h !== handler);
         this.ws.removeEventListener(type, handler);
     }
 
     close() {
+        this.done = true;
         this.ws.close();
+        this.resolveHasUpdateHandlers();
     }
 }
 
@@ -222,11 +395,10 @@ export class StreamInOut<Request, Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
-        this.socket.on("message", (event: any) => {
-            this.buffer.push(JSON.parse(event.data));
-            this.socket.resolveHasUpdateHandlers();
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.onMessage((data) => {
+            this.buffer.push(JSON.parse(data));
         });
     }
 
@@ -234,15 +406,12 @@ export class StreamInOut<Request, Response> {
         this.socket.close();
     }
 
+    /**
+     * Sends a message on the stream. It waits while the send queue is full,
+     * which is also the case while reconnecting.
+     */
     async send(msg: Request) {
-        if (this.socket.ws.readyState === WebSocket.CONNECTING) {
-            // await that the socket is opened
-            await new Promise((resolve) => {
-                this.socket.ws.addEventListener("open", resolve, { once: true });
-            });
-        }
-
-        return this.socket.ws.send(JSON.stringify(msg));
+        return this.socket.send(JSON.stringify(msg));
     }
 
     async next(): Promise<Response | undefined> {
@@ -255,7 +424,7 @@ export class StreamInOut<Request, Response> {
             if (this.buffer.length > 0) {
                 yield this.buffer.shift() as Response;
             } else {
-                if (this.socket.ws.readyState === WebSocket.CLOSED) return;
+                if (this.socket.closed) return;
                 await this.socket.hasUpdate();
             }
         }
@@ -266,11 +435,10 @@ export class StreamIn<Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
-        this.socket.on("message", (event: any) => {
-            this.buffer.push(JSON.parse(event.data));
-            this.socket.resolveHasUpdateHandlers();
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.onMessage((data) => {
+            this.buffer.push(JSON.parse(data));
         });
     }
 
@@ -288,7 +456,7 @@ export class StreamIn<Response> {
             if (this.buffer.length > 0) {
                 yield this.buffer.shift() as Response;
             } else {
-                if (this.socket.ws.readyState === WebSocket.CLOSED) return;
+                if (this.socket.closed) return;
                 await this.socket.hasUpdate();
             }
         }
@@ -299,13 +467,13 @@ export class StreamOut<Request, Response> {
     public socket: WebSocketConnection;
     private responseValue: Promise<Response>;
 
-    constructor(url: string, headers?: Record<string, string>) {
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
         let responseResolver: (_: any) => void;
         this.responseValue = new Promise((resolve) => responseResolver = resolve);
 
-        this.socket = new WebSocketConnection(url, headers);
-        this.socket.on("message", (event: any) => {
-            responseResolver(JSON.parse(event.data))
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.onMessage((data) => {
+            responseResolver(JSON.parse(data))
         });
     }
 
@@ -317,15 +485,12 @@ export class StreamOut<Request, Response> {
         this.socket.close();
     }
 
+    /**
+     * Sends a message on the stream. It waits while the send queue is full,
+     * which is also the case while reconnecting.
+     */
     async send(msg: Request) {
-        if (this.socket.ws.readyState === WebSocket.CONNECTING) {
-            // await that the socket is opened
-            await new Promise((resolve) => {
-                this.socket.ws.addEventListener("open", resolve, { once: true });
-            });
-        }
-
-        return this.socket.ws.send(JSON.stringify(msg));
+        return this.socket.send(JSON.stringify(msg));
     }
 }
 // CallParameters is the type of the parameters to a method call, but require headers to be a Record type
@@ -353,6 +518,7 @@ class BaseClient {
     readonly fetcher: Fetcher
     readonly headers: Record<string, string>
     readonly requestInit: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+    readonly streamOptions: StreamOptions
     readonly authGenerator?: AuthDataGenerator
 
     constructor(baseURL: string, options: ClientOptions) {
@@ -366,6 +532,7 @@ class BaseClient {
         }
 
         this.requestInit = options.requestInit ?? {};
+        this.streamOptions = options.stream ?? {};
 
         // Setup what fetch function we'll be using in the base client
         if (options.fetcher !== undefined) {
@@ -428,7 +595,7 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamInOut(this.baseURL + path + queryString, headers);
+        return new StreamInOut(this.baseURL + path + queryString, headers, this.streamOptions);
     }
 
     // createStreamIn sets up a stream to a streaming API endpoint.
@@ -449,7 +616,7 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamIn(this.baseURL + path + queryString, headers);
+        return new StreamIn(this.baseURL + path + queryString, headers, this.streamOptions);
     }
 
     // createStreamOut sets up a stream to a streaming API endpoint.
@@ -470,7 +637,7 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamOut(this.baseURL + path + queryString, headers);
+        return new StreamOut(this.baseURL + path + queryString, headers, this.streamOptions);
     }
 
     // callTypedAPI makes an API call, defaulting content type to "application/json"
@@ -0,0 +1,1039 @@
+// Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+// Disable eslint, jshint, and jslint for this file.
+/* eslint-disable */
+/* jshint ignore:start */
+/*jslint-disable*/
+
+/**
+ * BaseURL is the base URL for calling the Encore application's API.
+ */
+export type BaseURL = string
+
+export const Local: BaseURL = "http://localhost:4000"
+
+/**
+ * Environment returns a BaseURL for calling the cloud environment with the given name.
+ */
+export function Environment(name: string): BaseURL {
+    return `https://${name}-app.encr.app`
+}
+
+/**
+ * PreviewEnv returns a BaseURL for calling the preview environment with the given PR number.
+ */
+export function PreviewEnv(pr: number | string): BaseURL {
+    return Environment(`pr${pr}`)
+}
+
+const BROWSER = typeof globalThis === "object" && ("window" in globalThis);
+
+/**
+ * Client is an API client for the app Encore application.
+ */
+export default class Client {
+    public readonly svc: svc.ServiceClient
+    private readonly options: ClientOptions
+    private readonly target: string
+
+
+    /**
+     * Creates a Client for calling the public and authenticated APIs of your Encore application.
+     *
+     * @param target  The target which the client should be configured to use. See Local and Environment for options.
+     * @param options Options for the client
+     */
+    constructor(target: BaseURL, options?: ClientOptions) {
+        this.target = target
+        this.options = options ?? {}
+        const base = new BaseClient(this.target, this.options)
+        this.svc = new svc.ServiceClient(base)
+    }
+
+    /**
+     * Creates a new Encore client with the given client options set.
+     *
+     * @param options Client options to set. They are merged with existing options.
+     **/
+    public with(options: ClientOptions): Client {
+        return new Client(this.target, {
+            ...this.options,
+            ...options,
+        })
+    }
+}
+
+/**
+ * ClientOptions allows you to override any default behaviour within the generated Encore client.
+ */
+export interface ClientOptions {
+    /**
+     * By default the client will use the inbuilt fetch function for making the API requests.
+     * however you can override it with your own implementation here if you want to run custom
+     * code on each API request made or response received.
+     */
+    fetcher?: Fetcher
+
+    /** Default RequestInit to be used for the client */
+    requestInit?: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+
+    /** Options for streams to streaming API endpoints */
+    stream?: StreamOptions
+}
+
+export namespace svc {
+    export interface Params {
+        Topic: string
+    }
+
+    export interface Tick {
+        count: number
+        message: string
+    }
+
+    export class ServiceClient {
+        private baseClient: BaseClient
+
+        constructor(baseClient: BaseClient) {
+            this.baseClient = baseClient
+            this.Ticks = this.Ticks.bind(this)
+        }
+
+        /**
+         * Ticks streams ticks for a topic.
+         */
+        public async Ticks(name: string, params: Params): Promise<EventStream<Tick>> {
+            // Convert our params into the objects we need for the request
+            const query = makeRecord<string, string | string[]>({
+                topic: params.Topic,
+            })
+
+            return await this.baseClient.createEventStream<Tick>("GET", `/ticks/${encodeURIComponent(name)}`, undefined, {query})
+        }
+    }
+}
+
+
+
+function encodeQuery(parts: Record<string, string | string[]>): string {
+    const pairs: string[] = []
+    for (const key in parts) {
+        const val = (Array.isArray(parts[key]) ?  parts[key] : [parts[key]]) as string[]
+        for (const v of val) {
+            pairs.push(`${key}=${encodeURIComponent(v)}`)
+        }
+    }
+    return pairs.join("&")
+}
+
+// makeRecord takes a record and strips any undefined values from it,
+// and returns the same record with a narrower type.
+// @ts-ignore - TS ignore because makeRecord is not always used
+function makeRecord<K extends string | number | symbol, V>(record: Record<K, V | undefined>): Record<K, V> {
+    for (const key in record) {
+        if (record[key] === undefined) {
+            delete record[key]
+        }
+    }
+    return record as Record<K, V>
+}
+
+/**
+ * StreamOptions configures streams to streaming API endpoints.
+ */
+export interface StreamOptions {
+    /**
+     * Whether to reconnect when the connection is lost, resuming the stream
+     * where it left off. Defaults to true.
+     */
+    reconnect?: boolean
+
+    /** The maximum number of consecutive reconnection attempts. Defaults to 10. */
+    maxReconnectAttempts?: number
+
+    /**
+     * The maximum number of messages waiting to be sent or acknowledged by the
+     * server. Sending waits while the queue is full. Defaults to 64.
+     */
+    sendQueueSize?: number
+}
+
+function encodeWebSocketHeaders(headers: Record<string, string>) {
+    // url safe, no pad
+    const base64encoded = btoa(JSON.stringify(headers))
+      .replaceAll("=", "")
+      .replaceAll("+", "-")
+      .replaceAll("/", "_");
+    return "encore.dev.headers." + base64encoded;
+}
+
+/**
+ * WebSocketConnection is a connection to a streaming API endpoint.
+ *
+ * If the connection is lost it reconnects and resumes the stream where it left off,
+ * receiving the messages it missed and resending the ones the server didn't receive.
+ */
+class WebSocketConnection {
+    public ws: WebSocket;
+
+    private readonly url: string;
+    private readonly headers?: Record<string, string>;
+    private readonly options: StreamOptions;
+
+    private messageHandlers: ((data: string) => void)[] = [];
+    private listeners: ["error" | "close" | "message" | "open", (event: any) => void][] = [];
+    private hasUpdateHandlers: (() => void)[] = [];
+
+    // The token identifying the stream when resuming it, and the number
+    // of messages received from the server.
+    private resumeToken?: string;
+    private received = 0;
+
+    // The messages waiting to be sent or acknowledged by the server, the number
+    // of them sent on the current connection, and the number acknowledged so far.
+    private queue: string[] = [];
+    private sent = 0;
+    private acked = 0;
+
+    private ready = false;
+    private attempts = 0;
+    private done = false;
+
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.url = url;
+        this.headers = headers;
+        this.options = options ?? {};
+        this.ws = this.connect();
+    }
+
+    // closed reports whether the stream has ended.
+    get closed(): boolean {
+        return this.done;
+    }
+
+    private connect(): WebSocket {
+        let protocols = ["encore-ws-resume", "encore-ws"];
+        if (this.headers || this.resumeToken) {
+            const headers = { ...this.headers };
+            if (this.resumeToken) {
+                headers["x-encore-resume-token"] = this.resumeToken;
+                headers["x-encore-resume-received"] = String(this.received);
+            }
+            protocols.push(encodeWebSocketHeaders(headers))
+        }
+
+        const ws = new WebSocket(this.url, protocols);
+        ws.binaryType = "arraybuffer";
+        this.ready = false;
+
+        ws.addEventListener("open", () => {
+            // Servers resuming streams acknowledge the connection with a control message.
+            if (ws.protocol !== "encore-ws-resume") {
+                this.ready = true;
+                this.flush();
+            }
+        });
+
+        ws.addEventListener("message", (event: MessageEvent) => {
+            if (typeof event.data === "string") {
+                this.received++;
+                for (const handler of this.messageHandlers) {
+                    handler(event.data);
+                }
+            } else {
+                this.handleControl(JSON.parse(new TextDecoder().decode(event.data)));
+            }
+            this.resolveHasUpdateHandlers();
+        });
+
+        ws.addEventListener("close", (event: CloseEvent) => {
+            this.handleClose(event);
+        });
+
+        ws.addEventListener("error", () => {
+            this.resolveHasUpdateHandlers();
+        });
+
+        for (const [type, handler] of this.listeners) {
+            ws.addEventListener(type, handler);
+        }
+
+        return ws;
+    }
+
+    private handleControl(msg: { token: string; received: number }) {
+        this.resumeToken = msg.token;
+
+        // Forget the messages the server has received.
+        const acked = msg.received - this.acked;
+        if (acked > 0) {
+            this.queue.splice(0, acked);
+            this.sent = Math.max(0, this.sent - acked);
+            this.acked = msg.received;
+        }
+
+        if (!this.ready) {
+            // We're connected: resend the messages the server didn't receive.
+            this.ready = true;
+            this.attempts = 0;
+            this.sent = 0;
+        }
+        this.flush();
+    }
+
+    private handleClose(event: CloseEvent) {
+        this.ready = false;
+
+        // Only streams whose connection was lost (code 1006) can be resumed.
+        const reconnect = this.options.reconnect ?? true;
+        const maxAttempts = this.options.maxReconnectAttempts ?? 10;
+        if (!this.done && reconnect && this.resumeToken && event.code === 1006 && this.attempts < maxAttempts) {
+            const delay = Math.min(250 * 2 ** this.attempts, 10000);
+            this.attempts++;
+            setTimeout(() => {
+                if (!this.done) {
+                    this.ws = this.connect();
+                }
+            }, delay);
+        } else {
+            this.done = true;
+        }
+
+        this.resolveHasUpdateHandlers();
+    }
+
+    // flush sends the queued messages not yet sent on the current connection.
+    private flush() {
+        if (!this.ready || this.ws.readyState !== WebSocket.OPEN) return;
+
+        while (this.sent < this.queue.length) {
+            this.ws.send(this.queue[this.sent++]);
+        }
+
+        // Without acknowledgements from the server, sent messages are forgotten right away.
+        if (this.ws.protocol !== "encore-ws-resume") {
+            this.queue = [];
+            this.sent = 0;
+        }
+        this.resolveHasUpdateHandlers();
+    }
+
+    async send(data: string) {
+        const size = this.options.sendQueueSize ?? 64;
+        while (this.queue.length >= size && !this.done) {
+            await this.hasUpdate();
+        }
+        if (this.done) {
+            throw new Error("stream is closed");
+        }
+
+        this.queue.push(data);
+        this.flush();
+    }
+
+    onMessage(handler: (data: string) => void) {
+        this.messageHandlers.push(handler);
+    }
+
+    resolveHasUpdateHandlers() {
+        const handlers = this.hasUpdateHandlers;
+        this.hasUpdateHandlers = [];
+
+        for (const handler of handlers) {
+            handler()
+        }
+    }
+
+    async hasUpdate() {
+        // await until a new message have been received, or the socket is closed
+        await new Promise((resolve) => {
+            this.hasUpdateHandlers.push(() => resolve(null))
+        });
+    }
+
+    on(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+        this.listeners.push([type, handler]);
+        this.ws.addEventListener(type, handler);
+    }
+
+    off(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+        this.listeners = this.listeners.filter(([t, h]) => t !== type || h !== handler);
+        this.ws.removeEventListener(type, handler);
+    }
+
+    close() {
+        this.done = true;
+        this.ws.close();
+        this.resolveHasUpdateHandlers();
+    }
+}
+
+export class StreamInOut<Request, Response> {
+    public socket: WebSocketConnection;
+    private buffer: Response[] = [];
+
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.onMessage((data) => {
+            this.buffer.push(JSON.parse(data));
+        });
+    }
+
+    close() {
+        this.socket.close();
+    }
+
+    /**
+     * Sends a message on the stream. It waits while the send queue is full,
+     * which is also the case while reconnecting.
+     */
+    async send(msg: Request) {
+        return this.socket.send(JSON.stringify(msg));
+    }
+
+    async next(): Promise<Response | undefined> {
+        for await (const next of this) return next;
+        return undefined;
+    }
+
+    async *[Symbol.asyncIterator](): AsyncGenerator<Response, undefined, void> {
+        while (true) {
+            if (this.buffer.length > 0) {
+                yield this.buffer.shift() as Response;
+            } else {
+                if (this.socket.closed) return;
+                await this.socket.hasUpdate();
+            }
+        }
+    }
+}
+
+export class StreamIn<Response> {
+    public socket: WebSocketConnection;
+    private buffer: Response[] = [];
+
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.onMessage((data) => {
+            this.buffer.push(JSON.parse(data));
+        });
+    }
+
+    close() {
+        this.socket.close();
+    }
+
+    async next(): Promise<Response | undefined> {
+        for await (const next of this) return next;
+        return undefined;
+    }
+
+    async *[Symbol.asyncIterator](): AsyncGenerator<Response, undefined, void> {
+        while (true) {
+            if (this.buffer.length > 0) {
+                yield this.buffer.shift() as Response;
+            } else {
+                if (this.socket.closed) return;
+                await this.socket.hasUpdate();
+            }
+        }
+    }
+}
+
+export class StreamOut<Request, Response> {
+    public socket: WebSocketConnection;
+    private responseValue: Promise<Response>;
+
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        let responseResolver: (_: any) => void;
+        this.responseValue = new Promise((resolve) => responseResolver = resolve);
+
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.onMessage((data) => {
+            responseResolver(JSON.parse(data))
+        });
+    }
+
+    async response(): Promise<Response> {
+        return this.responseValue;
+    }
+
+    close() {
+        this.socket.close();
+    }
+
+    /**
+     * Sends a message on the stream. It waits while the send queue is full,
+     * which is also the case while reconnecting.
+     */
+    async send(msg: Request) {
+        return this.socket.send(JSON.stringify(msg));
+    }
+}
+
+/**
+ * EventStream is a stream of server-sent events from an API endpoint.
+ * Iterate over it with "for await" to receive the events.
+ *
+ * If the connection is lost the stream reconnects, resuming after the last
+ * event received. Iteration ends when the server ends the stream, and throws
+ * an APIError if the server reports an error.
+ */
+export class EventStream<Event> {
+    private readonly controller = new AbortController();
+    private reader?: ReadableStreamDefaultReader<Uint8Array>;
+    private status = 200;
+    private lastEventID = "";
+    private retry = 3000;
+
+    constructor(private readonly open: (lastEventID: string, signal: AbortSignal) => Promise<globalThis.Response>) {}
+
+    // connect opens the connection, resuming after the last event received, if any.
+    async connect() {
+        const resp = await this.open(this.lastEventID, this.controller.signal);
+        if (!resp.body) {
+            throw new APIError(resp.status, { code: ErrCode.Unknown, message: "event stream has no body" });
+        }
+        this.status = resp.status;
+        this.reader = resp.body.getReader();
+    }
+
+    // close closes the stream.
+    close() {
+        this.controller.abort();
+    }
+
+    async *[Symbol.asyncIterator](): AsyncGenerator<Event, undefined, void> {
+        const decoder = new TextDecoder();
+        let buffer = "";
+        while (!this.controller.signal.aborted) {
+            let chunk: ReadableStreamReadResult<Uint8Array>;
+            try {
+                chunk = await this.reader!.read();
+            } catch {
+                chunk = { done: true, value: undefined };
+            }
+            if (this.controller.signal.aborted) {
+                return undefined;
+            }
+
+            if (chunk.done) {
+                // The connection was lost; reconnect after the retry delay.
+                await new Promise((resolve) => setTimeout(resolve, this.retry));
+                if (this.controller.signal.aborted) {
+                    return undefined;
+                }
+                buffer = "";
+                await this.connect();
+                continue;
+            }
+
+            buffer += decoder.decode(chunk.value, { stream: true }).replace(/\r\n?/g, "\n");
+            let end: number;
+            while ((end = buffer.indexOf("\n\n")) >= 0) {
+                const block = buffer.slice(0, end);
+                buffer = buffer.slice(end + 2);
+
+                let event = "message";
+                const data: string[] = [];
+                for (const line of block.split("\n")) {
+                    const idx = line.indexOf(":");
+                    if (idx === 0) {
+                        continue; // a comment, such as a heartbeat
+                    }
+                    const field = idx < 0 ? line : line.slice(0, idx);
+                    let value = idx < 0 ? "" : line.slice(idx + 1);
+                    if (value.startsWith(" ")) {
+                        value = value.slice(1);
+                    }
+                    switch (field) {
+                        case "event": event = value; break;
+                        case "data": data.push(value); break;
+                        case "id": this.lastEventID = value; break;
+                        case "retry": if (/^\d+$/.test(value)) this.retry = parseInt(value, 10); break;
+                    }
+                }
+                if (data.length === 0) {
+                    continue;
+                }
+
+                const payload = JSON.parse(data.join("\n"));
+                if (event === "end") {
+                    this.close();
+                    return undefined;
+                } else if (event === "error") {
+                    this.close();
+                    throw new APIError(this.status, isAPIErrorResponse(payload) ? payload : { code: ErrCode.Unknown, message: JSON.stringify(payload) });
+                }
+                yield payload as Event;
+            }
+        }
+        return undefined;
+    }
+}
+// CallParameters is the type of the parameters to a method call, but require headers to be a Record type
+type CallParameters = Omit<RequestInit, "method" | "body" | "headers"> & {
+    /** Headers to be sent with the request */
+    headers?: Record<string, string>
+
+    /** Query parameters to be sent with the request */
+    query?: Record<string, string | string[]>
+}
+
+
+// A fetcher is the prototype for the inbuilt Fetch function
+export type Fetcher = typeof fetch;
+
+const boundFetch = fetch.bind(this);
+
+class BaseClient {
+    readonly baseURL: string
+    readonly fetcher: Fetcher
+    readonly headers: Record<string, string>
+    readonly requestInit: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+    readonly streamOptions: StreamOptions
+
+    constructor(baseURL: string, options: ClientOptions) {
+        this.baseURL = baseURL
+        this.headers = {}
+
+        // Add User-Agent header if the script is running in the server
+        // because browsers do not allow setting User-Agent headers to requests
+        if (!BROWSER) {
+            this.headers["User-Agent"] = "app-Generated-TS-Client (Encore/v0.0.0-develop)";
+        }
+
+        this.requestInit = options.requestInit ?? {};
+        this.streamOptions = options.stream ?? {};
+
+        // Setup what fetch function we'll be using in the base client
+        if (options.fetcher !== undefined) {
+            this.fetcher = options.fetcher
+        } else {
+            this.fetcher = boundFetch
+        }
+    }
+
+    async getAuthData(): Promise<CallParameters | undefined> {
+        return undefined;
+    }
+
+    // createStreamInOut sets up a stream to a streaming API endpoint.
+    async createStreamInOut<Request, Response>(path: string, params?: CallParameters): Promise<StreamInOut<Request, Response>> {
+        let { query, headers } = params ?? {};
+
+        // Fetch auth data if there is any
+        const authData = await this.getAuthData();
+
+        // If we now have authentication data, add it to the request
+        if (authData) {
+            if (authData.query) {
+                query = {...query, ...authData.query};
+            }
+            if (authData.headers) {
+                headers = {...headers, ...authData.headers};
+            }
+        }
+
+        const queryString = query ? '?' + encodeQuery(query) : ''
+        return new StreamInOut(this.baseURL + path + queryString, headers, this.streamOptions);
+    }
+
+    // createStreamIn sets up a stream to a streaming API endpoint.
+    async createStreamIn<Response>(path: string, params?: CallParameters): Promise<StreamIn<Response>> {
+        let { query, headers } = params ?? {};
+
+        // Fetch auth data if there is any
+        const authData = await this.getAuthData();
+
+        // If we now have authentication data, add it to the request
+        if (authData) {
+            if (authData.query) {
+                query = {...query, ...authData.query};
+            }
+            if (authData.headers) {
+                headers = {...headers, ...authData.headers};
+            }
+        }
+
+        const queryString = query ? '?' + encodeQuery(query) : ''
+        return new StreamIn(this.baseURL + path + queryString, headers, this.streamOptions);
+    }
+
+    // createStreamOut sets up a stream to a streaming API endpoint.
+    async createStreamOut<Request, Response>(path: string, params?: CallParameters): Promise<StreamOut<Request, Response>> {
+        let { query, headers } = params ?? {};
+
+        // Fetch auth data if there is any
+        const authData = await this.getAuthData();
+
+        // If we now have authentication data, add it to the request
+        if (authData) {
+            if (authData.query) {
+                query = {...query, ...authData.query};
+            }
+            if (authData.headers) {
+                headers = {...headers, ...authData.headers};
+            }
+        }
+
+        const queryString = query ? '?' + encodeQuery(query) : ''
+        return new StreamOut(this.baseURL + path + queryString, headers, this.streamOptions);
+    }
+
+    // createEventStream sets up a stream of server-sent events from an API endpoint.
+    async createEventStream<Event>(method: string, path: string, body?: RequestInit["body"], params?: CallParameters): Promise<EventStream<Event>> {
+        const stream = new EventStream<Event>((lastEventID, signal) => this.callTypedAPI(method, path, body, {
+            ...params,
+            signal,
+            headers: {
+                ...params?.headers,
+                "Accept": "text/event-stream",
+                ...(lastEventID ? { "Last-Event-ID": lastEventID } : {}),
+            },
+        }));
+        await stream.connect();
+        return stream;
+    }
+
+    // callTypedAPI makes an API call, defaulting content type to "application/json"
+    public async callTypedAPI(method: string, path: string, body?: RequestInit["body"], params?: CallParameters): Promise<Response> {
+        return this.callAPI(method, path, body, {
+            ...params,
+            headers: { "Content-Type": "application/json", ...params?.headers }
+        });
+    }
+
+    // callAPI is used by each generated API method to actually make the request
+    public async callAPI(method: string, path: string, body?: RequestInit["body"], params?: CallParameters): Promise<Response> {
+        let { query, headers, ...rest } = params ?? {}
+        const init = {
+            ...this.requestInit,
+            ...rest,
+            method,
+            body: body ?? null,
+        }
+
+        // Merge our headers with any predefined headers
+        init.headers = {...this.headers, ...init.headers, ...headers}
+
+        // Fetch auth data if there is any
+        const authData = await this.getAuthData();
+
+        // If we now have authentication data, add it to the request
+        if (authData) {
+            if (authData.query) {
+                query = {...query, ...authData.query};
+            }
+            if (authData.headers) {
+                init.headers = {...init.headers, ...authData.headers};
+            }
+        }
+
+        // Make the actual request
+        const queryString = query ? '?' + encodeQuery(query) : ''
+        const response = await this.fetcher(this.baseURL+path+queryString, init)
+
+        // handle any error responses
+        if (!response.ok) {
+            // try and get the error message from the response body
+            let body: APIErrorResponse = { code: ErrCode.Unknown, message: `request failed: status ${response.status}` }
+
+            // if we can get the structured error we should, otherwise give a best effort
+            try {
+                const text = await response.text()
+
+                try {
+                    const jsonBody = JSON.parse(text)
+                    if (isAPIErrorResponse(jsonBody)) {
+                        body = jsonBody
+                    } else {
+                        body.message += ": " + JSON.stringify(jsonBody)
+                    }
+                } catch {
+                    body.message += ": " + text
+                }
+            } catch (e) {
+                // otherwise we just append the text to the error message
+                body.message += ": " + String(e)
+            }
+
+            throw new APIError(response.status, body)
+        }
+
+        return response
+    }
+}
+
+/**
+ * APIErrorDetails represents the response from an Encore API in the case of an error
+ */
+interface APIErrorResponse {
+    code: ErrCode
+    message: string
+    details?: any
+}
+
+function isAPIErrorResponse(err: any): err is APIErrorResponse {
+    return (
+        err !== undefined && err !== null &&
+        isErrCode(err.code) &&
+        typeof(err.message) === "string" &&
+        (err.details === undefined || err.details === null || typeof(err.details) === "object")
+    )
+}
+
+function isErrCode(code: any): code is ErrCode {
+    return code !== undefined && Object.values(ErrCode).includes(code)
+}
+
+/**
+ * APIError represents a structured error as returned from an Encore application.
+ */
+export class APIError extends Error {
+    /**
+     * The HTTP status code associated with the error.
+     */
+    public readonly status: number
+
+    /**
+     * The Encore error code
+     */
+    public readonly code: ErrCode
+
+    /**
+     * The error details
+     */
+    public readonly details?: any
+
+    constructor(status: number, response: APIErrorResponse) {
+        // extending errors causes issues after you construct them, unless you apply the following fixes
+        super(response.message);
+
+        // set error name as constructor name, make it not enumerable to keep native Error behavior
+        // https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Operators/new.target#new.target_in_constructors
+        Object.defineProperty(this, 'name', {
+            value:        'APIError',
+            enumerable:   false,
+            configurable: true,
+        })
+
+        // fix the prototype chain
+        if ((Object as any).setPrototypeOf == undefined) {
+            (this as any).__proto__ = APIError.prototype
+        } else {
+            Object.setPrototypeOf(this, APIError.prototype);
+        }
+
+        // capture a stack trace
+        if ((Error as any).captureStackTrace !== undefined) {
+            (Error as any).captureStackTrace(this, this.constructor);
+        }
+
+        this.status = status
+        this.code = response.code
+        this.details = response.details
+    }
+}
+
+/**
+ * Typeguard allowing use of an APIError's fields'
+ */
+export function isAPIError(err: any): err is APIError {
+    return err instanceof APIError;
+}
+
+export enum ErrCode {
+    /**
+     * OK indicates the operation was successful.
+     */
+    OK = "ok",
+
+    /**
+     * Canceled indicates the operation was canceled (typically by the caller).
+     *
+     * Encore will generate this error code when cancellation is requested.
+     */
+    Canceled = "canceled",
+
+    /**
+     * Unknown error. An example of where this error may be returned is
+     * if a Status value received from another address space belongs to
+     * an error-space that is not known in this address space. Also
+     * errors raised by APIs that do not return enough error information
+     * may be converted to this error.
+     *
+     * Encore will generate this error code in the above two mentioned cases.
+     */
+    Unknown = "unknown",
+
+    /**
+     * InvalidArgument indicates client specified an invalid argument.
+     * Note that this differs from FailedPrecondition. It indicates arguments
+     * that are problematic regardless of the state of the system
+     * (e.g., a malformed file name).
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    InvalidArgument = "invalid_argument",
+
+    /**
+     * DeadlineExceeded means operation expired before completion.
+     * For operations that change the state of the system, this error may be
+     * returned even if the operation has completed successfully. For
+     * example, a successful response from a server could have been delayed
+     * long enough for the deadline to expire.
+     *
+     * The gRPC framework will generate this error code when the deadline is
+     * exceeded.
+     */
+    DeadlineExceeded = "deadline_exceeded",
+
+    /**
+     * NotFound means some requested entity (e.g., file or directory) was
+     * not found.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    NotFound = "not_found",
+
+    /**
+     * AlreadyExists means an attempt to create an entity failed because one
+     * already exists.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    AlreadyExists = "already_exists",
+
+    /**
+     * PermissionDenied indicates the caller does not have permission to
+     * execute the specified operation. It must not be used for rejections
+     * caused by exhausting some resource (use ResourceExhausted
+     * instead for those errors). It must not be
+     * used if the caller cannot be identified (use Unauthenticated
+     * instead for those errors).
+     *
+     * This error code will not be generated by the gRPC core framework,
+     * but expect authentication middleware to use it.
+     */
+    PermissionDenied = "permission_denied",
+
+    /**
+     * ResourceExhausted indicates some resource has been exhausted, perhaps
+     * a per-user quota, or perhaps the entire file system is out of space.
+     *
+     * This error code will be generated by the gRPC framework in
+     * out-of-memory and server overload situations, or when a message is
+     * larger than the configured maximum size.
+     */
+    ResourceExhausted = "resource_exhausted",
+
+    /**
+     * FailedPrecondition indicates operation was rejected because the
+     * system is not in a state required for the operation's execution.
+     * For example, directory to be deleted may be non-empty, an rmdir
+     * operation is applied to a non-directory, etc.
+     *
+     * A litmus test that may help a service implementor in deciding
+     * between FailedPrecondition, Aborted, and Unavailable:
+     *  (a) Use Unavailable if the client can retry just the failing call.
+     *  (b) Use Aborted if the client should retry at a higher-level
+     *      (e.g., restarting a read-modify-write sequence).
+     *  (c) Use FailedPrecondition if the client should not retry until
+     *      the system state has been explicitly fixed. E.g., if an "rmdir"
+     *      fails because the directory is non-empty, FailedPrecondition
+     *      should be returned since the client should not retry unless
+     *      they have first fixed up the directory by deleting files from it.
+     *  (d) Use FailedPrecondition if the client performs conditional
+     *      REST Get/Update/Delete on a resource and the resource on the
+     *      server does not match the condition. E.g., conflicting
+     *      read-modify-write on the same resource.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    FailedPrecondition = "failed_precondition",
+
+    /**
+     * Aborted indicates the operation was aborted, typically due to a
+     * concurrency issue like sequencer check failures, transaction aborts,
+     * etc.
+     *
+     * See litmus test above for deciding between FailedPrecondition,
+     * Aborted, and Unavailable.
+     */
+    Aborted = "aborted",
+
+    /**
+     * OutOfRange means operation was attempted past the valid range.
+     * E.g., seeking or reading past end of file.
+     *
+     * Unlike InvalidArgument, this error indicates a problem that may
+     * be fixed if the system state changes. For example, a 32-bit file
+     * system will generate InvalidArgument if asked to read at an
+     * offset that is not in the range [0,2^32-1], but it will generate
+     * OutOfRange if asked to read from an offset past the current
+     * file size.
+     *
+     * There is a fair bit of overlap between FailedPrecondition and
+     * OutOfRange. We recommend using OutOfRange (the more specific
+     * error) when it applies so that callers who are iterating through
+     * a space can easily look for an OutOfRange error to detect when
+     * they are done.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    OutOfRange = "out_of_range",
+
+    /**
+     * Unimplemented indicates operation is not implemented or not
+     * supported/enabled in this service.
+     *
+     * This error code will be generated by the gRPC framework. Most
+     * commonly, you will see this error code when a method implementation
+     * is missing on the server. It can also be generated for unknown
+     * compression algorithms or a disagreement as to whether an RPC should
+     * be streaming.
+     */
+    Unimplemented = "unimplemented",
+
+    /**
+     * Internal errors. Means some invariants expected by underlying
+     * system has been broken. If you see one of these errors,
+     * something is very broken.
+     *
+     * This error code will be generated by the gRPC framework in several
+     * internal error conditions.
+     */
+    Internal = "internal",
+
+    /**
+     * Unavailable indicates the service is currently unavailable.
+     * This is a most likely a transient condition and may be corrected
+     * by retrying with a backoff. Note that it is not always safe to retry
+     * non-idempotent operations.
+     *
+     * See litmus test above for deciding between FailedPrecondition,
+     * Aborted, and Unavailable.
+     *
+     * This error code will be generated by the gRPC framework during
+     * abrupt shutdown of a server process or network connection.
+     */
+    Unavailable = "unavailable",
+
+    /**
+     * DataLoss indicates unrecoverable data loss or corruption.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    DataLoss = "data_loss",
+
+    /**
+     * Unauthenticated indicates the request does not have valid
+     * authentication credentials for the operation.
+     *
+     * The gRPC framework will generate this error code when the
+     * authentication metadata is invalid or a Credentials callback fails,
+     * but also expect authentication middleware to generate it.
+     */
+    Unauthenticated = "unauthenticated",
+}
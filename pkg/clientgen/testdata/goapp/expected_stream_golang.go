@@ -0,0 +1,1052 @@
+// Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Client is an API client for the app Encore application.
+type Client struct {
+	Svc SvcClient
+}
+
+// BaseURL is the base URL for calling the Encore application's API.
+type BaseURL string
+
+const Local BaseURL = "http://localhost:4000"
+
+// Environment returns a BaseURL for calling the cloud environment with the given name.
+func Environment(name string) BaseURL {
+	return BaseURL(fmt.Sprintf("https://%s-app.encr.app", name))
+}
+
+// PreviewEnv returns a BaseURL for calling the preview environment with the given PR number.
+func PreviewEnv(pr int) BaseURL {
+	return Environment(fmt.Sprintf("pr%d", pr))
+}
+
+// Option allows you to customise the baseClient used by the Client
+type Option = func(client *baseClient) error
+
+// New returns a Client for calling the public and authenticated APIs of your Encore application.
+// You can customize the behaviour of the client using the given Option functions, such as WithHTTPClient or WithAuthFunc.
+func New(target BaseURL, options ...Option) (*Client, error) {
+	// Parse the base URL where the Encore application is being hosted
+	baseURL, err := url.Parse(string(target))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse base url: %w", err)
+	}
+
+	// Create a client with sensible defaults
+	base := &baseClient{
+		baseURL:    baseURL,
+		httpClient: http.DefaultClient,
+		userAgent:  "app-Generated-Go-Client (Encore/v0.0.0-develop)",
+	}
+
+	// Apply any given options
+	for _, option := range options {
+		if err := option(base); err != nil {
+			return nil, fmt.Errorf("unable to apply client option: %w", err)
+		}
+	}
+
+	return &Client{Svc: &svcClient{base}}, nil
+}
+
+// WithHTTPClient can be used to configure the underlying HTTP client used when making API calls.
+//
+// Defaults to http.DefaultClient
+func WithHTTPClient(client HTTPDoer) Option {
+	return func(base *baseClient) error {
+		base.httpClient = client
+		return nil
+	}
+}
+
+// WithStreamOptions can be used to configure how streams to streaming API endpoints behave,
+// such as how they reconnect when the connection is lost.
+func WithStreamOptions(opts StreamOptions) Option {
+	return func(base *baseClient) error {
+		base.streamOptions = opts
+		return nil
+	}
+}
+
+type SvcHandshake struct {
+	Name  string `query:"name"`
+	Token string `header:"X-Token"`
+}
+
+type SvcMessage struct {
+	Text string `json:"text"`
+}
+
+type SvcSummary struct {
+	Count int `json:"count"`
+}
+
+// SvcClient Provides you access to call public and authenticated APIs on svc. The concrete implementation is svcClient.
+// It is setup as an interface allowing you to use GoMock to create mock implementations during tests.
+type SvcClient interface {
+	// Chat chats in a room.
+	Chat(ctx context.Context, room string, params SvcHandshake) (*StreamInOut[SvcMessage, SvcMessage], error)
+
+	// Feed streams the message feed.
+	Feed(ctx context.Context) (*StreamIn[SvcMessage], error)
+
+	// Upload uploads messages.
+	Upload(ctx context.Context) (*StreamOut[SvcMessage, SvcSummary], error)
+}
+
+type svcClient struct {
+	base *baseClient
+}
+
+var _ SvcClient = (*svcClient)(nil)
+
+// Chat chats in a room.
+func (c *svcClient) Chat(ctx context.Context, room string, params SvcHandshake) (resp *StreamInOut[SvcMessage, SvcMessage], err error) {
+	// Convert our params into the objects we need for the request
+	reqEncoder := &serde{}
+
+	headers := http.Header{"x-token": {reqEncoder.FromString(params.Token)}}
+
+	queryString := url.Values{"name": {reqEncoder.FromString(params.Name)}}
+
+	if reqEncoder.LastError != nil {
+		err = fmt.Errorf("unable to marshal parameters: %w", reqEncoder.LastError)
+		return
+	}
+
+	conn, err := dialStream(ctx, c.base, fmt.Sprintf("/chat/%s?%s", url.PathEscape(room), queryString.Encode()), headers)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamInOut[SvcMessage, SvcMessage]{conn: conn}, nil
+}
+
+// Feed streams the message feed.
+func (c *svcClient) Feed(ctx context.Context) (resp *StreamIn[SvcMessage], err error) {
+	conn, err := dialStream(ctx, c.base, "/feed", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamIn[SvcMessage]{conn: conn}, nil
+}
+
+// Upload uploads messages.
+func (c *svcClient) Upload(ctx context.Context) (resp *StreamOut[SvcMessage, SvcSummary], err error) {
+	conn, err := dialStream(ctx, c.base, "/upload", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamOut[SvcMessage, SvcSummary]{conn: conn}, nil
+}
+
+// HTTPDoer is an interface which can be used to swap out the default
+// HTTP client (http.DefaultClient) with your own custom implementation.
+// This can be used to inject middleware or mock responses during unit tests.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// baseClient holds all the information we need to make requests to an Encore application
+type baseClient struct {
+	httpClient    HTTPDoer      // The HTTP client which will be used for all API requests
+	baseURL       *url.URL      // The base URL which API requests will be made against
+	userAgent     string        // What user agent we will use in the API requests
+	streamOptions StreamOptions // How streams to streaming API endpoints behave
+}
+
+// Do sends the req to the Encore application adding the authorization token as required.
+func (b *baseClient) Do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", b.userAgent)
+
+	// Merge the base URL and the API URL
+	req.URL = b.baseURL.ResolveReference(req.URL)
+	req.Host = req.URL.Host
+
+	// Finally, make the request via the configured HTTP Client
+	return b.httpClient.Do(req)
+}
+
+// callAPI is used by each generated API method to actually make request and decode the responses
+func callAPI(ctx context.Context, client *baseClient, method, path string, headers http.Header, body, resp any) (http.Header, error) {
+	// Encode the API body
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshal request: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	// Create the request
+	req, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	// Add any headers to the request
+	for header, values := range headers {
+		for _, value := range values {
+			req.Header.Add(header, value)
+		}
+	}
+
+	// Make the request via the base client
+	rawResponse, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = rawResponse.Body.Close()
+	}()
+	if rawResponse.StatusCode >= 400 {
+		// Read the full body sent back
+		body, err := io.ReadAll(rawResponse.Body)
+		if err != nil {
+			return nil, &APIError{
+				Code:    ErrUnknown,
+				Message: fmt.Sprintf("got error response without readable body: %s", rawResponse.Status),
+			}
+		}
+
+		// Attempt to decode the error response as a structured APIError
+		apiError := &APIError{}
+		if err := json.Unmarshal(body, apiError); err != nil {
+			// If the error is not a parsable as an APIError, then return an error with the raw body
+			return nil, &APIError{
+				Code:    ErrUnknown,
+				Message: fmt.Sprintf("got error response: %s", string(body)),
+			}
+		}
+		return nil, apiError
+	}
+
+	// Decode the response
+	if resp != nil {
+		if err := json.NewDecoder(rawResponse.Body).Decode(resp); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return rawResponse.Header, nil
+}
+
+// StreamOptions configures how streams to streaming API endpoints behave.
+type StreamOptions struct {
+	// DisableReconnect disables reconnecting when the connection is lost.
+	// By default streams reconnect and resume where they left off.
+	DisableReconnect bool
+
+	// MaxReconnectAttempts is the number of consecutive reconnection attempts
+	// made before the stream fails. It defaults to 10.
+	MaxReconnectAttempts int
+
+	// SendQueueSize is the number of messages which can be waiting to be sent
+	// or acknowledged by the server before Send blocks. It defaults to 64.
+	SendQueueSize int
+}
+
+// StreamInOut is a stream to an API endpoint sending messages of type Req
+// and receiving messages of type Resp.
+type StreamInOut[Req any, Resp any] struct {
+	conn *streamConn
+}
+
+// Send sends a message on the stream.
+// It blocks while the send queue is full, such as while reconnecting.
+func (s *StreamInOut[Req, Resp]) Send(msg Req) error {
+	return s.conn.send(msg)
+}
+
+// Recv returns the next message from the stream.
+// It returns io.EOF once the server has ended the stream.
+func (s *StreamInOut[Req, Resp]) Recv() (msg Resp, err error) {
+	err = s.conn.recv(&msg)
+	return msg, err
+}
+
+// Close closes the stream.
+func (s *StreamInOut[Req, Resp]) Close() error {
+	return s.conn.close()
+}
+
+// StreamIn is a stream from an API endpoint receiving messages of type Resp.
+type StreamIn[Resp any] struct {
+	conn *streamConn
+}
+
+// Recv returns the next message from the stream.
+// It returns io.EOF once the server has ended the stream.
+func (s *StreamIn[Resp]) Recv() (msg Resp, err error) {
+	err = s.conn.recv(&msg)
+	return msg, err
+}
+
+// Close closes the stream.
+func (s *StreamIn[Resp]) Close() error {
+	return s.conn.close()
+}
+
+// StreamOut is a stream to an API endpoint sending messages of type Req,
+// to which the API endpoint responds with a single message of type Resp.
+type StreamOut[Req any, Resp any] struct {
+	conn *streamConn
+}
+
+// Send sends a message on the stream.
+// It blocks while the send queue is full, such as while reconnecting.
+func (s *StreamOut[Req, Resp]) Send(msg Req) error {
+	return s.conn.send(msg)
+}
+
+// Response waits for the response from the API endpoint.
+func (s *StreamOut[Req, Resp]) Response() (msg Resp, err error) {
+	err = s.conn.recv(&msg)
+	return msg, err
+}
+
+// Close closes the stream.
+func (s *StreamOut[Req, Resp]) Close() error {
+	return s.conn.close()
+}
+
+// streamConn is a WebSocket connection to a streaming API endpoint.
+//
+// If the connection is lost it reconnects and resumes the stream, receiving
+// the messages it missed and resending the ones the server didn't receive.
+type streamConn struct {
+	ctx     context.Context
+	client  *baseClient
+	path    string
+	headers http.Header
+	opts    StreamOptions
+	stop    func() bool
+
+	writeMu sync.Mutex // serializes writes to the connection
+
+	mu        sync.Mutex
+	changed   chan struct{} // closed whenever the state below changes
+	conn      io.ReadWriteCloser
+	resumable bool
+	ready     bool
+	token     string
+	received  uint64   // the number of messages received from the server
+	inbox     [][]byte // messages received but not yet returned by recv
+	queue     [][]byte // messages waiting to be sent or acknowledged
+	sent      int      // the number of queued messages sent on the current connection
+	acked     uint64   // the number of messages acknowledged by the server
+	err       error    // set once the stream has ended
+}
+
+// dialStream opens a stream to a streaming API endpoint.
+func dialStream(ctx context.Context, client *baseClient, path string, headers http.Header) (*streamConn, error) {
+	s := &streamConn{
+		changed: make(chan struct{}),
+		client:  client,
+		ctx:     ctx,
+		headers: headers,
+		opts:    client.streamOptions,
+		path:    path,
+	}
+	if s.opts.MaxReconnectAttempts <= 0 {
+		s.opts.MaxReconnectAttempts = 10
+	}
+	if s.opts.SendQueueSize <= 0 {
+		s.opts.SendQueueSize = 64
+	}
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	s.stop = context.AfterFunc(ctx, func() {
+		s.end(nil, ctx.Err())
+	})
+	return s, nil
+}
+
+// connect opens a new connection, resuming the stream if it has a resume token.
+func (s *streamConn) connect() error {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	nonce := base64.StdEncoding.EncodeToString(key)
+
+	req, err := http.NewRequestWithContext(s.ctx, "GET", s.path, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	for header, values := range s.headers {
+		for _, value := range values {
+			req.Header.Add(header, value)
+		}
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", nonce)
+	req.Header.Set("Sec-WebSocket-Protocol", "encore-ws-resume, encore-ws")
+	s.mu.Lock()
+	if s.token != "" {
+		req.Header.Set("X-Encore-Resume-Token", s.token)
+		req.Header.Set("X-Encore-Resume-Received", strconv.FormatUint(s.received, 10))
+	}
+	s.mu.Unlock()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		body, _ := io.ReadAll(resp.Body)
+		apiError := &APIError{}
+		if err := json.Unmarshal(body, apiError); err != nil {
+			return &APIError{
+				Code:    ErrUnknown,
+				Message: fmt.Sprintf("got error response: %s", string(body)),
+			}
+		}
+		return apiError
+	}
+	conn, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		_ = resp.Body.Close()
+		return errors.New("the HTTP client does not support WebSocket connections")
+	}
+	accept := sha1.Sum([]byte(nonce + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(accept[:]) {
+		_ = conn.Close()
+		return errors.New("invalid WebSocket handshake response")
+	}
+
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return conn.Close()
+	}
+	s.conn = conn
+	s.resumable = resp.Header.Get("Sec-WebSocket-Protocol") == "encore-ws-resume"
+	// Resumable connections are ready once the server has said how much it received.
+	s.ready = !s.resumable
+	s.sent = 0
+	s.notify()
+	s.mu.Unlock()
+
+	go s.read(conn)
+	go s.flush()
+	return nil
+}
+
+// notify wakes up everyone waiting for the state to change.
+// It must be called with s.mu held.
+func (s *streamConn) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// read reads messages from the connection until it's closed.
+func (s *streamConn) read(conn io.ReadWriteCloser) {
+	reader := bufio.NewReader(conn)
+	for {
+		opcode, payload, err := readWebSocketFrame(reader)
+		if err != nil {
+			s.lost(conn, err)
+			return
+		}
+		switch opcode {
+		case wsText:
+			s.mu.Lock()
+			s.received++
+			s.inbox = append(s.inbox, payload)
+			s.notify()
+			s.mu.Unlock()
+		case wsBinary:
+			// Binary messages are sent by the server to acknowledge the messages it received.
+			var control struct {
+				Token    string `json:"token"`
+				Received uint64 `json:"received"`
+			}
+			if err := json.Unmarshal(payload, &control); err == nil {
+				s.acknowledge(conn, control.Token, control.Received)
+			}
+		case wsClose:
+			code, reason := 1005, ""
+			if len(payload) >= 2 {
+				code, reason = int(binary.BigEndian.Uint16(payload)), string(payload[2:])
+				payload = payload[:2]
+			}
+			_ = s.write(conn, wsClose, payload)
+			err = io.EOF
+			if code != 1000 && code != 1005 {
+				err = fmt.Errorf("stream closed by server: %s (code %d)", reason, code)
+			}
+			s.end(conn, err)
+			return
+		case wsPing:
+			go s.write(conn, wsPong, payload)
+		}
+	}
+}
+
+// acknowledge handles the server acknowledging the messages it received,
+// removing them from the send queue.
+func (s *streamConn) acknowledge(conn io.ReadWriteCloser, token string, received uint64) {
+	s.mu.Lock()
+	if s.conn != conn {
+		s.mu.Unlock()
+		return
+	}
+	s.token = token
+	if n := int(received - s.acked); received > s.acked && n <= len(s.queue) {
+		s.queue = s.queue[n:]
+		s.sent = max(s.sent-n, 0)
+		s.acked = received
+	}
+	s.ready = true
+	s.notify()
+	s.mu.Unlock()
+	go s.flush()
+}
+
+// flush sends the queued messages which haven't been sent on the current connection.
+func (s *streamConn) flush() {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	for {
+		s.mu.Lock()
+		if s.conn == nil || !s.ready || s.sent >= len(s.queue) {
+			s.mu.Unlock()
+			return
+		}
+		conn, msg := s.conn, s.queue[s.sent]
+		if s.resumable {
+			s.sent++
+		} else {
+			// Messages can't be resent without resuming, so there's no need to keep them.
+			s.queue = s.queue[1:]
+			s.notify()
+		}
+		s.mu.Unlock()
+
+		if err := writeWebSocketFrame(conn, wsText, msg); err != nil {
+			_ = conn.Close()
+			return
+		}
+	}
+}
+
+// write writes a single frame to the connection.
+func (s *streamConn) write(conn io.Writer, opcode byte, payload []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return writeWebSocketFrame(conn, opcode, payload)
+}
+
+// lost handles the connection being lost, reconnecting if the stream can be resumed.
+func (s *streamConn) lost(conn io.ReadWriteCloser, err error) {
+	_ = conn.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != conn {
+		return
+	}
+	s.conn = nil
+	if !s.resumable || s.token == "" || s.opts.DisableReconnect {
+		s.fail(fmt.Errorf("connection lost: %w", err))
+		return
+	}
+	s.ready = false
+	s.notify()
+	go s.reconnect()
+}
+
+// reconnect reconnects to resume the stream, backing off between attempts.
+func (s *streamConn) reconnect() {
+	var err error
+	for attempt := 0; attempt < s.opts.MaxReconnectAttempts; attempt++ {
+		delay := min(250*time.Millisecond<<attempt, 10*time.Second)
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if err = s.connect(); err == nil {
+			return
+		}
+	}
+	s.end(nil, fmt.Errorf("unable to reconnect: %w", err))
+}
+
+// end ends the stream with the given error if conn is the current connection,
+// or regardless if conn is nil.
+func (s *streamConn) end(conn io.ReadWriteCloser, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if conn != nil && s.conn != conn {
+		return
+	}
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	s.fail(err)
+}
+
+// fail records the error ending the stream, unless it has already ended.
+// It must be called with s.mu held.
+func (s *streamConn) fail(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+	s.notify()
+}
+
+// send queues a message to be sent, waiting while the send queue is full.
+func (s *streamConn) send(msg any) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+
+	s.mu.Lock()
+	for s.err == nil && len(s.queue) >= s.opts.SendQueueSize {
+		changed := s.changed
+		s.mu.Unlock()
+		<-changed
+		s.mu.Lock()
+	}
+	if s.err != nil {
+		err := s.err
+		s.mu.Unlock()
+		return err
+	}
+	s.queue = append(s.queue, data)
+	s.mu.Unlock()
+
+	s.flush()
+	return nil
+}
+
+// recv waits for the next message and decodes it into msg.
+func (s *streamConn) recv(msg any) error {
+	s.mu.Lock()
+	for s.err == nil && len(s.inbox) == 0 {
+		changed := s.changed
+		s.mu.Unlock()
+		<-changed
+		s.mu.Lock()
+	}
+	if len(s.inbox) == 0 {
+		err := s.err
+		s.mu.Unlock()
+		return err
+	}
+	data := s.inbox[0]
+	s.inbox = s.inbox[1:]
+	s.mu.Unlock()
+
+	if err := json.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("decode message: %w", err)
+	}
+	return nil
+}
+
+// close closes the stream.
+func (s *streamConn) close() error {
+	s.stop()
+	s.mu.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.fail(io.EOF)
+	s.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	// Tell the server the stream was closed normally (status code 1000).
+	_ = s.write(conn, wsClose, []byte{0x03, 0xE8})
+	return conn.Close()
+}
+
+// The WebSocket opcodes used by streams.
+const (
+	wsText   = 1
+	wsBinary = 2
+	wsClose  = 8
+	wsPing   = 9
+	wsPong   = 10
+)
+
+// readWebSocketFrame reads a WebSocket message, joining fragmented messages.
+func readWebSocketFrame(reader *bufio.Reader) (opcode byte, payload []byte, err error) {
+	for {
+		var header [2]byte
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			return 0, nil, err
+		}
+		length := uint64(header[1] & 0x7F)
+		switch length {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(reader, ext[:]); err != nil {
+				return 0, nil, err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(reader, ext[:]); err != nil {
+				return 0, nil, err
+			}
+			length = binary.BigEndian.Uint64(ext[:])
+		}
+		var mask [4]byte
+		masked := header[1]&0x80 != 0
+		if masked {
+			if _, err := io.ReadFull(reader, mask[:]); err != nil {
+				return 0, nil, err
+			}
+		}
+		data := make([]byte, length)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return 0, nil, err
+		}
+		if masked {
+			for i := range data {
+				data[i] ^= mask[i%4]
+			}
+		}
+
+		// Continuation frames have opcode 0 and continue the message.
+		if op := header[0] & 0x0F; op != 0 {
+			opcode = op
+		}
+		payload = append(payload, data...)
+		if header[0]&0x80 != 0 {
+			return opcode, payload, nil
+		}
+	}
+}
+
+// writeWebSocketFrame writes a WebSocket frame, masked as required for clients.
+func writeWebSocketFrame(w io.Writer, opcode byte, payload []byte) error {
+	frame := []byte{0x80 | opcode, 0x80}
+	switch n := len(payload); {
+	case n < 126:
+		frame[1] |= byte(n)
+	case n <= 0xFFFF:
+		frame[1] |= 126
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame[1] |= 127
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := w.Write(frame)
+	return err
+}
+
+// APIError is the error type returned by the API
+type APIError struct {
+	Code    ErrCode `json:"code"`
+	Message string  `json:"message"`
+	Details any     `json:"details"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+type ErrCode int
+
+const (
+	// ErrOK indicates the operation was successful.
+	ErrOK ErrCode = 0
+
+	// ErrCanceled indicates the operation was canceled (typically by the caller).
+	//
+	// Encore will generate this error code when cancellation is requested.
+	ErrCanceled ErrCode = 1
+
+	// ErrUnknown error. An example of where this error may be returned is
+	// if a Status value received from another address space belongs to
+	// an error-space that is not known in this address space. Also
+	// errors raised by APIs that do not return enough error information
+	// may be converted to this error.
+	//
+	// Encore will generate this error code in the above two mentioned cases.
+	ErrUnknown ErrCode = 2
+
+	// ErrInvalidArgument indicates client specified an invalid argument.
+	// Note that this differs from FailedPrecondition. It indicates arguments
+	// that are problematic regardless of the state of the system
+	// (e.g., a malformed file name).
+	//
+	// This error code will not be generated by the gRPC framework.
+	ErrInvalidArgument ErrCode = 3
+
+	// ErrDeadlineExceeded means operation expired before completion.
+	// For operations that change the state of the system, this error may be
+	// returned even if the operation has completed successfully. For
+	// example, a successful response from a server could have been delayed
+	// long enough for the deadline to expire.
+	//
+	// The gRPC framework will generate this error code when the deadline is
+	// exceeded.
+	ErrDeadlineExceeded ErrCode = 4
+
+	// ErrNotFound means some requested entity (e.g., file or directory) was
+	// not found.
+	//
+	// This error code will not be generated by the gRPC framework.
+	ErrNotFound ErrCode = 5
+
+	// ErrAlreadyExists means an attempt to create an entity failed because one
+	// already exists.
+	//
+	// This error code will not be generated by the gRPC framework.
+	ErrAlreadyExists ErrCode = 6
+
+	// ErrPermissionDenied indicates the caller does not have permission to
+	// execute the specified operation. It must not be used for rejections
+	// caused by exhausting some resource (use ResourceExhausted
+	// instead for those errors). It must not be
+	// used if the caller cannot be identified (use Unauthenticated
+	// instead for those errors).
+	//
+	// This error code will not be generated by the gRPC core framework,
+	// but expect authentication middleware to use it.
+	ErrPermissionDenied ErrCode = 7
+
+	// ErrResourceExhausted indicates some resource has been exhausted, perhaps
+	// a per-user quota, or perhaps the entire file system is out of space.
+	//
+	// This error code will be generated by the gRPC framework in
+	// out-of-memory and server overload situations, or when a message is
+	// larger than the configured maximum size.
+	ErrResourceExhausted ErrCode = 8
+
+	// ErrFailedPrecondition indicates operation was rejected because the
+	// system is not in a state required for the operation's execution.
+	// For example, directory to be deleted may be non-empty, an rmdir
+	// operation is applied to a non-directory, etc.
+	//
+	// A litmus test that may help a service implementor in deciding
+	// between FailedPrecondition, Aborted, and Unavailable:
+	//  (a) Use Unavailable if the client can retry just the failing call.
+	//  (b) Use Aborted if the client should retry at a higher-level
+	//      (e.g., restarting a read-modify-write sequence).
+	//  (c) Use FailedPrecondition if the client should not retry until
+	//      the system state has been explicitly fixed. E.g., if an "rmdir"
+	//      fails because the directory is non-empty, FailedPrecondition
+	//      should be returned since the client should not retry unless
+	//      they have first fixed up the directory by deleting files from it.
+	//  (d) Use FailedPrecondition if the client performs conditional
+	//      REST Get/Update/Delete on a resource and the resource on the
+	//      server does not match the condition. E.g., conflicting
+	//      read-modify-write on the same resource.
+	//
+	// This error code will not be generated by the gRPC framework.
+	ErrFailedPrecondition ErrCode = 9
+
+	// ErrAborted indicates the operation was aborted, typically due to a
+	// concurrency issue like sequencer check failures, transaction aborts,
+	// etc.
+	//
+	// See litmus test above for deciding between FailedPrecondition,
+	// ErrAborted, and Unavailable.
+	ErrAborted ErrCode = 10
+
+	// ErrOutOfRange means operation was attempted past the valid range.
+	// E.g., seeking or reading past end of file.
+	//
+	// Unlike InvalidArgument, this error indicates a problem that may
+	// be fixed if the system state changes. For example, a 32-bit file
+	// may be rotated to a 64-bit file without error.
+	//
+	// There is a fair bit of overlap between FailedPrecondition and
+	// ErrOutOfRange. We recommend using OutOfRange (the more specific
+	// error) when it applies so that callers who are iterating through
+	// a space can easily look for an OutOfRange error to detect when
+	// they are done.
+	//
+	// This error code will not be generated by the gRPC framework.
+	ErrOutOfRange ErrCode = 11
+
+	// ErrUnimplemented indicates operation is not implemented or not
+	// supported/enabled in this service.
+	//
+	// This is not an error, but a feature not available.
+	//
+	// This error code will not be generated by the gRPC framework.
+	ErrUnimplemented ErrCode = 12
+
+	// ErrInternal means some invariant expected by the underlying system has
+	// been broken. This is not a per-message error, it is a global
+	// conditions check.
+	//
+	// This error code will not be generated by the gRPC framework.
+	ErrInternal ErrCode = 13
+
+	// ErrUnavailable indicates the service is currently unavailable.
+	// This is most likely a transient condition, which can be corrected by
+	// retrying with a backoff.
+	//
+	// See litmus test above for deciding between FailedPrecondition,
+	// Aborted, and Unavailable.
+	ErrUnavailable ErrCode = 14
+
+	// ErrDataLoss indicates unrecoverable data loss or corruption.
+	//
+	// This error code is only defined in the gRPC library, and only for
+	// unrecoverable data loss (i.e., data loss resulting from errors
+	// like hard disk corruption or bandwidth exceeded).
+	//
+	// This error code will not be generated by the gRPC framework.
+	ErrDataLoss ErrCode = 15
+
+	// ErrUnauthenticated indicates the request does not have valid
+	// authentication credentials for the operation.
+	//
+	// The gRPC framework will generate this error code when the
+	// authentication metadata is invalid or a Credentials callback fails,
+	// but also expect authentication middleware to generate it.
+	ErrUnauthenticated ErrCode = 16
+)
+
+// String returns the string representation of the error code
+func (c ErrCode) String() string {
+	switch c {
+	case ErrOK:
+		return "ok"
+	case ErrCanceled:
+		return "canceled"
+	case ErrUnknown:
+		return "unknown"
+	case ErrInvalidArgument:
+		return "invalid_argument"
+	case ErrDeadlineExceeded:
+		return "deadline_exceeded"
+	case ErrNotFound:
+		return "not_found"
+	case ErrAlreadyExists:
+		return "already_exists"
+	case ErrPermissionDenied:
+		return "permission_denied"
+	case ErrResourceExhausted:
+		return "resource_exhausted"
+	case ErrFailedPrecondition:
+		return "failed_precondition"
+	case ErrAborted:
+		return "aborted"
+	case ErrOutOfRange:
+		return "out_of_range"
+	case ErrUnimplemented:
+		return "unimplemented"
+	case ErrInternal:
+		return "internal"
+	case ErrUnavailable:
+		return "unavailable"
+	case ErrDataLoss:
+		return "data_loss"
+	case ErrUnauthenticated:
+		return "unauthenticated"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON converts the error code to a human-readable string
+func (c ErrCode) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("\"%s\"", c)), nil
+}
+
+// UnmarshalJSON converts the human-readable string to an error code
+func (c *ErrCode) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case "\"ok\"":
+		*c = ErrOK
+	case "\"canceled\"":
+		*c = ErrCanceled
+	case "\"unknown\"":
+		*c = ErrUnknown
+	case "\"invalid_argument\"":
+		*c = ErrInvalidArgument
+	case "\"deadline_exceeded\"":
+		*c = ErrDeadlineExceeded
+	case "\"not_found\"":
+		*c = ErrNotFound
+	case "\"already_exists\"":
+		*c = ErrAlreadyExists
+	case "\"permission_denied\"":
+		*c = ErrPermissionDenied
+	case "\"resource_exhausted\"":
+		*c = ErrResourceExhausted
+	case "\"failed_precondition\"":
+		*c = ErrFailedPrecondition
+	case "\"aborted\"":
+		*c = ErrAborted
+	case "\"out_of_range\"":
+		*c = ErrOutOfRange
+	case "\"unimplemented\"":
+		*c = ErrUnimplemented
+	case "\"internal\"":
+		*c = ErrInternal
+	case "\"unavailable\"":
+		*c = ErrUnavailable
+	case "\"data_loss\"":
+		*c = ErrDataLoss
+	case "\"unauthenticated\"":
+		*c = ErrUnauthenticated
+	default:
+		*c = ErrUnknown
+	}
+	return nil
+}
+
+// serde is used to serialize request data into strings and deserialize response data from strings
+type serde struct {
+	LastError      error // The last error that occurred
+	NonEmptyValues int   // The number of values this decoder has decoded
+}
+
+func (e *serde) FromString(s string) (v string) {
+	e.NonEmptyValues++
+	return s
+}
+
+// setErr sets the last error within the object if one is not already set
+func (e *serde) setErr(msg, field string, err error) {
+	if err != nil && e.LastError == nil {
+		e.LastError = fmt.Errorf("%s: %s: %w", field, msg, err)
+	}
+}
@@ -0,0 +1,43 @@
+-- go.mod --
+module app
+
+-- encore.app --
+{"id": ""}
+
+-- shared/errors.go --
+package shared
+
+import "encore.dev/beta/errs"
+
+// ErrRateLimited is returned when a caller
+// has made too many requests.
+var ErrRateLimited = errs.Define("rate_limited", errs.ResourceExhausted, "too many requests")
+
+-- svc/svc.go --
+package svc
+
+import (
+    "context"
+
+    "encore.dev/beta/errs"
+
+    "app/shared"
+)
+
+// ErrUserNotFound is returned when the user does not exist.
+var ErrUserNotFound = errs.Define("user.not_found", errs.NotFound, "user not found")
+
+var ErrEmailTaken = errs.Define("user.email_taken", errs.AlreadyExists, "the email is already in use")
+
+type User struct {
+    ID    int
+    Email string
+}
+
+//encore:api public path=/users/:id
+func GetUser(ctx context.Context, id int) (*User, error) {
+    if id <= 0 {
+        return nil, shared.ErrRateLimited.New()
+    }
+    return nil, ErrUserNotFound.New()
+}
@@ -0,0 +1,37 @@
+-- go.mod --
+module app
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import "mime/multipart"
+
+type UploadParams struct {
+    // Title is the title of the upload.
+    Title string
+    Tags []string
+    Public bool `encore:"optional"`
+    Version int `header:"X-Version"`
+    Avatar *multipart.FileHeader
+    Attachments []*multipart.FileHeader
+}
+
+type UploadResponse struct {
+    Count int
+}
+
+-- svc/api.go --
+package svc
+
+import (
+    "context"
+)
+
+// Upload uploads files.
+//encore:api public method=POST maxBodySize=10MB timeout=30s
+func Upload(ctx context.Context, p *UploadParams) (*UploadResponse, error) {
+    return nil, nil
+}
@@ -0,0 +1,85 @@
+-- go.mod --
+module app
+
+-- encore.app --
+{"id": ""}
+
+-- users/users.go --
+package users
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "encore.dev/types/uuid"
+)
+
+// User is a registered user.
+type User struct {
+    ID        uuid.UUID `json:"id"`
+    Name      string    `json:"name"`
+    Nickname  *string   `json:"nickname"`
+    Email     string    `json:"email" encore:"optional"`
+    CreatedAt time.Time `json:"created_at"`
+    Labels    map[string]string
+    Scores    []float64
+    Metadata  json.RawMessage
+    Manager   *User
+    Address   struct {
+        Street string
+        City   string
+    }
+    Secret string `json:"-"`
+}
+
+type UpdateParams struct {
+    Name     string
+    Nickname *string
+}
+
+type ListResponse struct {
+    Users []*User
+    Total int
+}
+
+// Get returns a user by id.
+//
+//encore:api public grpc path=/users/:id
+func Get(ctx context.Context, id string) (*User, error) { return nil, nil }
+
+//encore:api public grpc method=PUT,POST path=/users/:id/update
+func Update(ctx context.Context, id string, p *UpdateParams) (*User, error) { return nil, nil }
+
+//encore:api public grpc
+func List(ctx context.Context) (*ListResponse, error) { return nil, nil }
+
+// Ping is not exposed over gRPC.
+//encore:api public
+func Ping(ctx context.Context) error { return nil }
+
+-- orders/orders.go --
+package orders
+
+import "context"
+
+type User struct {
+    ID string
+}
+
+type CreateParams struct {
+    Buyer    User
+    Quantity uint32
+    Counts   map[int64]uint64
+}
+
+type Order struct {
+    ID    int64
+    Buyer User
+}
+
+//encore:api public grpc
+func Create(ctx context.Context, p *CreateParams) (*Order, error) { return nil, nil }
+
+//encore:api public grpc
+func Purge(ctx context.Context) error { return nil }
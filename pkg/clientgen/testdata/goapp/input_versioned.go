@@ -0,0 +1,38 @@
+-- go.mod --
+module app
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+type User struct {
+    Name string
+}
+
+type UserV2 struct {
+    FirstName string
+    LastName  string
+}
+
+-- svc/api.go --
+package svc
+
+import "context"
+
+// GetUser returns the user with the given id.
+//
+// Deprecated: Use GetUserV2 instead, which splits the user's name.
+//
+//encore:api public method=GET path=/users/:id version=1
+func GetUser(ctx context.Context, id int) (*User, error) {
+    return nil, nil
+}
+
+// GetUserV2 returns the user with the given id.
+//
+//encore:api public method=GET path=/users/:id version=2
+func GetUserV2(ctx context.Context, id int) (*UserV2, error) {
+    return nil, nil
+}
@@ -68,9 +68,12 @@ type typescript struct {
 
 	seenJSON           bool // true if a JSON type was seen
 	seenStream         bool // true if a stream endpoint was seen
+	seenEventStream    bool // true if a server-sent events endpoint was seen
 	seenHeaderResponse bool // true if we've seen a header used in a response object
 	hasAuth            bool // true if we've seen an authentication handler
 	authIsComplexType  bool // true if the auth type is a complex type
+
+	errorDefs []*meta.ErrorDefinition // the error definitions to expose as error ids
 }
 
 func (ts *typescript) Version() int {
@@ -84,6 +87,7 @@ func (ts *typescript) Generate(p clientgentypes.GenerateParams) (err error) {
 	ts.md = p.Meta
 	ts.appSlug = p.AppSlug
 	ts.typs = getNamedTypes(p.Meta, p.Services)
+	ts.errorDefs = errorDefinitions(p.Meta, p.Services)
 
 	if ts.md.AuthHandler != nil {
 		if !ts.isAuthCookieOnly() {
@@ -120,6 +124,7 @@ func (ts *typescript) Generate(p clientgentypes.GenerateParams) (err error) {
 	}
 	ts.writeExtraTypes()
 	ts.writeStreamClasses()
+	ts.writeEventStreamClass()
 	if err := ts.writeBaseClient(p.AppSlug); err != nil {
 		return err
 	}
@@ -309,6 +314,12 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 				ts.WriteString(scanner.Text())
 				ts.WriteByte('\n')
 			}
+			if rpc.Deprecated != nil {
+				indent()
+				ts.WriteString(" * @deprecated ")
+				ts.WriteString(*rpc.Deprecated)
+				ts.WriteByte('\n')
+			}
 			indent()
 			ts.WriteString(" */\n")
 		}
@@ -456,6 +467,11 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 				writeStreamResponse(ns, 0)
 				ts.WriteString(">")
 			}
+		} else if rpc.ServerSentEvents {
+			ts.seenEventStream = true
+			ts.WriteString("EventStream<")
+			ts.writeTyp(ns, rpc.ResponseSchema, 0)
+			ts.WriteString(">")
 		} else if rpc.ResponseSchema != nil {
 			if ts.sharedTypes {
 				fmt.Fprintf(ts, "ResponseType<typeof %s>", rpcImportName(rpc))
@@ -669,7 +685,15 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 		}
 
 		// Generate the body
-		if len(reqEnc.BodyParameters) > 0 {
+		if reqEnc.Multipart() {
+			// Requests containing files are sent as multipart forms
+			body = "form"
+			w.WriteString("// Construct a multipart form, as the request contains files\nconst form = new FormData()\n")
+			for _, field := range reqEnc.BodyParameters {
+				ts.writeFormField(w, field)
+			}
+			w.WriteString("\n")
+		} else if len(reqEnc.BodyParameters) > 0 {
 			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 && (!ts.sharedTypes || !hasPathParams(rpc)) {
 				// In the simple case we can just encode the params as the body directly
 				body = "JSON.stringify(params)"
@@ -689,41 +713,53 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 		}
 	}
 
-	// Build the call to callTypedAPI
-	callAPI := "this.baseClient.callTypedAPI("
+	// Build the arguments to callTypedAPI
+	callArgs := ""
 	if !ts.sharedTypes {
-		callAPI += fmt.Sprintf("\"%s\", ", rpcEncoding.DefaultMethod)
+		callArgs += fmt.Sprintf("\"%s\", ", rpcEncoding.DefaultMethod)
 	}
-	callAPI += fmt.Sprintf("`%s`", rpcPath)
+	callArgs += fmt.Sprintf("`%s`", rpcPath)
 	if body != "" || headers != "" || query != "" || ts.sharedTypes {
 		if body == "" {
 			body = "undefined"
 		}
 		if !ts.sharedTypes {
-			callAPI += ", " + body
+			callArgs += ", " + body
 		}
 
 		if headers != "" || query != "" || ts.sharedTypes {
-			callAPI += ", {" + headers
+			callArgs += ", {" + headers
 
 			if headers != "" && query != "" {
-				callAPI += ", "
+				callArgs += ", "
 			}
 
 			if query != "" {
-				callAPI += query
+				callArgs += query
 			}
 
 			if ts.sharedTypes {
 				if headers != "" || query != "" {
-					callAPI += ", "
+					callArgs += ", "
 				}
-				callAPI += fmt.Sprintf(`method: "%s", body: %s`, rpcEncoding.DefaultMethod, body)
+				callArgs += fmt.Sprintf(`method: "%s", body: %s`, rpcEncoding.DefaultMethod, body)
 			}
-			callAPI += "}"
+			callArgs += "}"
 		}
 	}
-	callAPI += ")"
+	callAPI := "this.baseClient.callTypedAPI(" + callArgs + ")"
+	if body == "form" {
+		// Let fetch set the Content-Type, as it includes the form's boundary
+		callAPI = "this.baseClient.callAPI(" + callArgs + ")"
+	}
+
+	// Server-sent events are received as they're sent, using an EventStream
+	if rpc.ServerSentEvents {
+		w.WriteString("return await this.baseClient.createEventStream<")
+		ts.writeTyp(ns, rpc.ResponseSchema, 0)
+		w.WriteStringf(">(%s)\n", callArgs)
+		return nil
+	}
 
 	// If there's no response schema, we can just return the call to the API directly
 	if rpc.ResponseSchema == nil {
@@ -889,21 +925,18 @@ type StreamResponse<Type> = Type extends
 `)
 	}
 
-	parse := "JSON.parse(event.data)"
+	parse := "JSON.parse(data)"
 	if ts.sharedTypes {
-		parse = "JSON.parse(event.data, dateReviver)"
+		parse = "JSON.parse(data, dateReviver)"
 	}
 
 	send := `
+    /**
+     * Sends a message on the stream. It waits while the send queue is full,
+     * which is also the case while reconnecting.
+     */
     async send(msg: Request) {
-        if (this.socket.ws.readyState === WebSocket.CONNECTING) {
-            // await that the socket is opened
-            await new Promise((resolve) => {
-                this.socket.ws.addEventListener("open", resolve, { once: true });
-            });
-        }
-
-        return this.socket.ws.send(JSON.stringify(msg));
+        return this.socket.send(JSON.stringify(msg));
     }`
 
 	receive := `
@@ -917,13 +950,33 @@ type StreamResponse<Type> = Type extends
             if (this.buffer.length > 0) {
                 yield this.buffer.shift() as Response;
             } else {
-                if (this.socket.ws.readyState === WebSocket.CLOSED) return;
+                if (this.socket.closed) return;
                 await this.socket.hasUpdate();
             }
         }
     }`
 
 	ts.WriteString(`
+/**
+ * StreamOptions configures streams to streaming API endpoints.
+ */
+export interface StreamOptions {
+    /**
+     * Whether to reconnect when the connection is lost, resuming the stream
+     * where it left off. Defaults to true.
+     */
+    reconnect?: boolean
+
+    /** The maximum number of consecutive reconnection attempts. Defaults to 10. */
+    maxReconnectAttempts?: number
+
+    /**
+     * The maximum number of messages waiting to be sent or acknowledged by the
+     * server. Sending waits while the queue is full. Defaults to 64.
+     */
+    sendQueueSize?: number
+}
+
 function encodeWebSocketHeaders(headers: Record<string, string>) {
     // url safe, no pad
     const base64encoded = btoa(JSON.stringify(headers))
@@ -933,26 +986,172 @@ function encodeWebSocketHeaders(headers: Record<string, string>) {
     return "encore.dev.headers." + base64encoded;
 }
 
+/**
+ * WebSocketConnection is a connection to a streaming API endpoint.
+ *
+ * If the connection is lost it reconnects and resumes the stream where it left off,
+ * receiving the messages it missed and resending the ones the server didn't receive.
+ */
 class WebSocketConnection {
     public ws: WebSocket;
 
+    private readonly url: string;
+    private readonly headers?: Record<string, string>;
+    private readonly options: StreamOptions;
+
+    private messageHandlers: ((data: string) => void)[] = [];
+    private listeners: ["error" | "close" | "message" | "open", (event: any) => void][] = [];
     private hasUpdateHandlers: (() => void)[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        let protocols = ["encore-ws"];
-        if (headers) {
+    // The token identifying the stream when resuming it, and the number
+    // of messages received from the server.
+    private resumeToken?: string;
+    private received = 0;
+
+    // The messages waiting to be sent or acknowledged by the server, the number
+    // of them sent on the current connection, and the number acknowledged so far.
+    private queue: string[] = [];
+    private sent = 0;
+    private acked = 0;
+
+    private ready = false;
+    private attempts = 0;
+    private done = false;
+
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.url = url;
+        this.headers = headers;
+        this.options = options ?? {};
+        this.ws = this.connect();
+    }
+
+    // closed reports whether the stream has ended.
+    get closed(): boolean {
+        return this.done;
+    }
+
+    private connect(): WebSocket {
+        let protocols = ["encore-ws-resume", "encore-ws"];
+        if (this.headers || this.resumeToken) {
+            const headers = { ...this.headers };
+            if (this.resumeToken) {
+                headers["x-encore-resume-token"] = this.resumeToken;
+                headers["x-encore-resume-received"] = String(this.received);
+            }
             protocols.push(encodeWebSocketHeaders(headers))
         }
 
-        this.ws = new WebSocket(url, protocols)
+        const ws = new WebSocket(this.url, protocols);
+        ws.binaryType = "arraybuffer";
+        this.ready = false;
+
+        ws.addEventListener("open", () => {
+            // Servers resuming streams acknowledge the connection with a control message.
+            if (ws.protocol !== "encore-ws-resume") {
+                this.ready = true;
+                this.flush();
+            }
+        });
 
-        this.on("error", () => {
+        ws.addEventListener("message", (event: MessageEvent) => {
+            if (typeof event.data === "string") {
+                this.received++;
+                for (const handler of this.messageHandlers) {
+                    handler(event.data);
+                }
+            } else {
+                this.handleControl(JSON.parse(new TextDecoder().decode(event.data)));
+            }
             this.resolveHasUpdateHandlers();
         });
 
-        this.on("close", () => {
+        ws.addEventListener("close", (event: CloseEvent) => {
+            this.handleClose(event);
+        });
+
+        ws.addEventListener("error", () => {
             this.resolveHasUpdateHandlers();
         });
+
+        for (const [type, handler] of this.listeners) {
+            ws.addEventListener(type, handler);
+        }
+
+        return ws;
+    }
+
+    private handleControl(msg: { token: string; received: number }) {
+        this.resumeToken = msg.token;
+
+        // Forget the messages the server has received.
+        const acked = msg.received - this.acked;
+        if (acked > 0) {
+            this.queue.splice(0, acked);
+            this.sent = Math.max(0, this.sent - acked);
+            this.acked = msg.received;
+        }
+
+        if (!this.ready) {
+            // We're connected: resend the messages the server didn't receive.
+            this.ready = true;
+            this.attempts = 0;
+            this.sent = 0;
+        }
+        this.flush();
+    }
+
+    private handleClose(event: CloseEvent) {
+        this.ready = false;
+
+        // Only streams whose connection was lost (code 1006) can be resumed.
+        const reconnect = this.options.reconnect ?? true;
+        const maxAttempts = this.options.maxReconnectAttempts ?? 10;
+        if (!this.done && reconnect && this.resumeToken && event.code === 1006 && this.attempts < maxAttempts) {
+            const delay = Math.min(250 * 2 ** this.attempts, 10000);
+            this.attempts++;
+            setTimeout(() => {
+                if (!this.done) {
+                    this.ws = this.connect();
+                }
+            }, delay);
+        } else {
+            this.done = true;
+        }
+
+        this.resolveHasUpdateHandlers();
+    }
+
+    // flush sends the queued messages not yet sent on the current connection.
+    private flush() {
+        if (!this.ready || this.ws.readyState !== WebSocket.OPEN) return;
+
+        while (this.sent < this.queue.length) {
+            this.ws.send(this.queue[this.sent++]);
+        }
+
+        // Without acknowledgements from the server, sent messages are forgotten right away.
+        if (this.ws.protocol !== "encore-ws-resume") {
+            this.queue = [];
+            this.sent = 0;
+        }
+        this.resolveHasUpdateHandlers();
+    }
+
+    async send(data: string) {
+        const size = this.options.sendQueueSize ?? 64;
+        while (this.queue.length >= size && !this.done) {
+            await this.hasUpdate();
+        }
+        if (this.done) {
+            throw new Error("stream is closed");
+        }
+
+        this.queue.push(data);
+        this.flush();
+    }
+
+    onMessage(handler: (data: string) => void) {
+        this.messageHandlers.push(handler);
     }
 
     resolveHasUpdateHandlers() {
@@ -972,15 +1171,19 @@ class WebSocketConnection {
     }
 
     on(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+        this.listeners.push([type, handler]);
         this.ws.addEventListener(type, handler);
     }
 
     off(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+        this.listeners = this.listeners.filter(([t, h]) => t !== type || h !== handler);
         this.ws.removeEventListener(type, handler);
     }
 
     close() {
+        this.done = true;
         this.ws.close();
+        this.resolveHasUpdateHandlers();
     }
 }
 
@@ -988,11 +1191,10 @@ export class StreamInOut<Request, Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
-        this.socket.on("message", (event: any) => {
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.onMessage((data) => {
             this.buffer.push(` + parse + `);
-            this.socket.resolveHasUpdateHandlers();
         });
     }
 
@@ -1007,11 +1209,10 @@ export class StreamIn<Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
-        this.socket.on("message", (event: any) => {
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.onMessage((data) => {
             this.buffer.push(` + parse + `);
-            this.socket.resolveHasUpdateHandlers();
         });
     }
 
@@ -1025,12 +1226,12 @@ export class StreamOut<Request, Response> {
     public socket: WebSocketConnection;
     private responseValue: Promise<Response>;
 
-    constructor(url: string, headers?: Record<string, string>) {
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
         let responseResolver: (_: any) => void;
         this.responseValue = new Promise((resolve) => responseResolver = resolve);
 
-        this.socket = new WebSocketConnection(url, headers);
-        this.socket.on("message", (event: any) => {
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.onMessage((data) => {
             responseResolver(` + parse + `)
         });
     }
@@ -1046,6 +1247,117 @@ export class StreamOut<Request, Response> {
 }`)
 }
 
+// writeEventStreamClass writes the EventStream class used by
+// endpoints streaming server-sent events, if there are any.
+func (ts *typescript) writeEventStreamClass() {
+	if !ts.seenEventStream {
+		return
+	}
+
+	ts.WriteString(`
+
+/**
+ * EventStream is a stream of server-sent events from an API endpoint.
+ * Iterate over it with "for await" to receive the events.
+ *
+ * If the connection is lost the stream reconnects, resuming after the last
+ * event received. Iteration ends when the server ends the stream, and throws
+ * an APIError if the server reports an error.
+ */
+export class EventStream<Event> {
+    private readonly controller = new AbortController();
+    private reader?: ReadableStreamDefaultReader<Uint8Array>;
+    private status = 200;
+    private lastEventID = "";
+    private retry = 3000;
+
+    constructor(private readonly open: (lastEventID: string, signal: AbortSignal) => Promise<globalThis.Response>) {}
+
+    // connect opens the connection, resuming after the last event received, if any.
+    async connect() {
+        const resp = await this.open(this.lastEventID, this.controller.signal);
+        if (!resp.body) {
+            throw new APIError(resp.status, { code: ErrCode.Unknown, message: "event stream has no body" });
+        }
+        this.status = resp.status;
+        this.reader = resp.body.getReader();
+    }
+
+    // close closes the stream.
+    close() {
+        this.controller.abort();
+    }
+
+    async *[Symbol.asyncIterator](): AsyncGenerator<Event, undefined, void> {
+        const decoder = new TextDecoder();
+        let buffer = "";
+        while (!this.controller.signal.aborted) {
+            let chunk: ReadableStreamReadResult<Uint8Array>;
+            try {
+                chunk = await this.reader!.read();
+            } catch {
+                chunk = { done: true, value: undefined };
+            }
+            if (this.controller.signal.aborted) {
+                return undefined;
+            }
+
+            if (chunk.done) {
+                // The connection was lost; reconnect after the retry delay.
+                await new Promise((resolve) => setTimeout(resolve, this.retry));
+                if (this.controller.signal.aborted) {
+                    return undefined;
+                }
+                buffer = "";
+                await this.connect();
+                continue;
+            }
+
+            buffer += decoder.decode(chunk.value, { stream: true }).replace(/\r\n?/g, "\n");
+            let end: number;
+            while ((end = buffer.indexOf("\n\n")) >= 0) {
+                const block = buffer.slice(0, end);
+                buffer = buffer.slice(end + 2);
+
+                let event = "message";
+                const data: string[] = [];
+                for (const line of block.split("\n")) {
+                    const idx = line.indexOf(":");
+                    if (idx === 0) {
+                        continue; // a comment, such as a heartbeat
+                    }
+                    const field = idx < 0 ? line : line.slice(0, idx);
+                    let value = idx < 0 ? "" : line.slice(idx + 1);
+                    if (value.startsWith(" ")) {
+                        value = value.slice(1);
+                    }
+                    switch (field) {
+                        case "event": event = value; break;
+                        case "data": data.push(value); break;
+                        case "id": this.lastEventID = value; break;
+                        case "retry": if (/^\d+$/.test(value)) this.retry = parseInt(value, 10); break;
+                    }
+                }
+                if (data.length === 0) {
+                    continue;
+                }
+
+                const payload = JSON.parse(data.join("\n"));
+                if (event === "end") {
+                    this.close();
+                    return undefined;
+                } else if (event === "error") {
+                    this.close();
+                    throw new APIError(this.status, isAPIErrorResponse(payload) ? payload : { code: ErrCode.Unknown, message: JSON.stringify(payload) });
+                }
+                yield payload as Event;
+            }
+        }
+        return undefined;
+    }
+}`)
+}
+
 func (ts *typescript) writeClient(set clientgentypes.ServiceSet) {
 	w := ts.newIdentWriter(0)
 	w.WriteStringf(`
@@ -1196,6 +1508,9 @@ export interface ClientOptions {
 
     /** Default RequestInit to be used for the client */
     requestInit?: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+
+    /** Options for streams to streaming API endpoints */
+    stream?: StreamOptions
 `)
 
 	if ts.hasAuth {
@@ -1271,7 +1586,8 @@ class BaseClient {
     readonly baseURL: string
     readonly fetcher: Fetcher
     readonly headers: Record<string, string>
-    readonly requestInit: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }`)
+    readonly requestInit: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+    readonly streamOptions: StreamOptions`)
 
 	if ts.hasAuth {
 		ts.WriteString("\n    readonly authGenerator?: AuthDataGenerator")
@@ -1290,6 +1606,7 @@ class BaseClient {
         }
 
         this.requestInit = options.requestInit ?? {};
+        this.streamOptions = options.stream ?? {};
 
         // Setup what fetch function we'll be using in the base client
         if (options.fetcher !== undefined) {
@@ -1414,7 +1731,7 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamInOut(this.baseURL + path + queryString, headers);
+        return new StreamInOut(this.baseURL + path + queryString, headers, this.streamOptions);
     }
 
     // createStreamIn sets up a stream to a streaming API endpoint.
@@ -1435,7 +1752,7 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamIn(this.baseURL + path + queryString, headers);
+        return new StreamIn(this.baseURL + path + queryString, headers, this.streamOptions);
     }
 
     // createStreamOut sets up a stream to a streaming API endpoint.
@@ -1456,9 +1773,28 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamOut(this.baseURL + path + queryString, headers);
+        return new StreamOut(this.baseURL + path + queryString, headers, this.streamOptions);
+    }
+`)
+
+	if ts.seenEventStream {
+		ts.WriteString(`
+    // createEventStream sets up a stream of server-sent events from an API endpoint.
+    async createEventStream<Event>(method: string, path: string, body?: RequestInit["body"], params?: CallParameters): Promise<EventStream<Event>> {
+        const stream = new EventStream<Event>((lastEventID, signal) => this.callTypedAPI(method, path, body, {
+            ...params,
+            signal,
+            headers: {
+                ...params?.headers,
+                "Accept": "text/event-stream",
+                ...(lastEventID ? { "Last-Event-ID": lastEventID } : {}),
+            },
+        }));
+        await stream.connect();
+        return stream;
     }
 `)
+	}
 
 	callParams := "method: string, path: string, body?: RequestInit[\"body\"], params?: CallParameters"
 	callAPIParams := "method, path, body"
@@ -1657,7 +1993,7 @@ func (ts *typescript) builtinType(typ schema.Builtin) string {
 	case schema.Builtin_STRING:
 		return "string"
 	case schema.Builtin_BYTES:
-		return "string" // TODO
+		return "string" // base64-encoded
 	case schema.Builtin_TIME:
 		return "string" // TODO
 	case schema.Builtin_JSON:
@@ -1669,6 +2005,8 @@ func (ts *typescript) builtinType(typ schema.Builtin) string {
 		return "string"
 	case schema.Builtin_DECIMAL:
 		return "string"
+	case schema.Builtin_FILE:
+		return "Blob"
 	default:
 		ts.errorf("unknown builtin type %v", typ)
 		return "any"
@@ -1700,6 +2038,26 @@ func (ts *typescript) convertBuiltinToString(typ schema.Builtin, val string, isO
 	return code
 }
 
+// writeFormField writes the code appending a body parameter to a multipart form.
+func (ts *typescript) writeFormField(w *indentWriter, field *encoding.ParameterEncoding) {
+	ref := ts.Dot("params", field.SrcName)
+	if list := field.Type.GetList(); list != nil {
+		val := "v"
+		if !encoding.IsFileType(field.Type) {
+			val = ts.convertBuiltinToString(list.Elem.GetBuiltin(), "v", false)
+		}
+		w.WriteStringf("for (const v of %s ?? []) form.append(\"%s\", %s)\n", ref, field.WireFormat, val)
+	} else if encoding.IsFileType(field.Type) {
+		w.WriteStringf("if (%s) form.append(\"%s\", %s)\n", ref, field.WireFormat, ref)
+	} else if field.Optional {
+		w.WriteStringf("if (%s !== undefined) form.append(\"%s\", %s)\n", ref, field.WireFormat,
+			ts.convertBuiltinToString(field.Type.GetBuiltin(), ref, false))
+	} else {
+		w.WriteStringf("form.append(\"%s\", %s)\n", field.WireFormat,
+			ts.convertBuiltinToString(field.Type.GetBuiltin(), ref, false))
+	}
+}
+
 func (ts *typescript) convertStringToBuiltin(typ schema.Builtin, val string) string {
 	switch typ {
 	case schema.Builtin_ANY:
@@ -1848,7 +2206,13 @@ func (ts *typescript) renderTyp(buf *bytes.Buffer, ns string, tt *schema.Type, n
 				buf.WriteString("?")
 			}
 			buf.WriteString(": ")
-			ts.renderTyp(buf, ns, field.Typ, numIndents+1)
+			if field.StringEncoded && field.Wire == nil {
+				// 64-bit integers tagged with encore:"string" are encoded as strings
+				// to avoid losing precision, as JavaScript numbers are limited to 2^53.
+				buf.WriteString("string")
+			} else {
+				ts.renderTyp(buf, ns, field.Typ, numIndents+1)
+			}
 			buf.WriteString("\n")
 
 			// Add another empty line if we have a doc comment
@@ -2056,7 +2420,11 @@ interface APIErrorResponse {
     code: ErrCode
     message: string
     details?: any
-}
+`)
+	if len(ts.errorDefs) > 0 {
+		w.WriteString("    error_id?: string\n")
+	}
+	w.WriteString(`}
 
 function isAPIErrorResponse(err: any): err is APIErrorResponse {
     return (
@@ -2089,7 +2457,16 @@ export class APIError extends Error {
      * The error details
      */
     public readonly details?: any
-
+`)
+	if len(ts.errorDefs) > 0 {
+		w.WriteString(`
+    /**
+     * The id of the error definition the error was created from, if any
+     */
+    public readonly errorID?: ErrorID
+`)
+	}
+	w.WriteString(`
     constructor(status: number, response: APIErrorResponse) {
         // extending errors causes issues after you construct them, unless you apply the following fixes
         super(response.message);
@@ -2117,7 +2494,11 @@ export class APIError extends Error {
         this.status = status
         this.code = response.code
         this.details = response.details
-    }
+`)
+	if len(ts.errorDefs) > 0 {
+		w.WriteString("        this.errorID = response.error_id as ErrorID | undefined\n")
+	}
+	w.WriteString(`    }
 }
 
 /**
@@ -2322,6 +2703,28 @@ export enum ErrCode {
     Unauthenticated = "unauthenticated",
 }
 `)
+
+	if len(ts.errorDefs) > 0 {
+		w.WriteString("\n/**\n * ErrorID identifies the errors declared by the application with errs.Define.\n */\n")
+		w.WriteString("export enum ErrorID {\n")
+		w = w.Indent()
+		for i, def := range ts.errorDefs {
+			if i > 0 {
+				w.WriteString("\n")
+			}
+			if doc := def.GetDoc(); doc != "" {
+				scanner := bufio.NewScanner(strings.NewReader(doc))
+				w.WriteString("/**\n")
+				for scanner.Scan() {
+					w.WriteString(" * " + scanner.Text() + "\n")
+				}
+				w.WriteString(" */\n")
+			}
+			w.WriteStringf("%s = %s,\n", errorIDName(def), ts.Quote(def.Id))
+		}
+		w = w.Dedent()
+		w.WriteString("}\n")
+	}
 }
 
 func stringIsOnly(str string, predicate func(r rune) bool) bool {
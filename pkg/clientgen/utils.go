@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"encr.dev/internal/version"
+	"encr.dev/pkg/clientgen/clientgentypes"
+	"encr.dev/pkg/idents"
 	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
@@ -22,6 +24,30 @@ func hasPublicRPC(svc *meta.Service) bool {
 	return false
 }
 
+// isStreamingRPC reports whether the rpc is a streaming endpoint,
+// called over a WebSocket connection.
+func isStreamingRPC(rpc *meta.RPC) bool {
+	return rpc.StreamingRequest || rpc.StreamingResponse
+}
+
+// errorDefinitions returns the error definitions to include in a client for the
+// given services: those declared in the services and those declared outside of any service.
+func errorDefinitions(md *meta.Data, services clientgentypes.ServiceSet) []*meta.ErrorDefinition {
+	var defs []*meta.ErrorDefinition
+	for _, def := range md.ErrorDefinitions {
+		if def.ServiceName == nil || services.Has(def.GetServiceName()) {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+// errorIDName returns the name of the constant for an error definition's id,
+// such as "UserNotFound" for "user.not_found".
+func errorIDName(def *meta.ErrorDefinition) string {
+	return idents.Convert(def.Id, idents.PascalCase)
+}
+
 type indentWriter struct {
 	w                *bytes.Buffer
 	depth            int
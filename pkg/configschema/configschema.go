@@ -0,0 +1,286 @@
+// Package configschema derives JSON Schemas for the config of an app's
+// services from its metadata, and validates config values against them.
+//
+// The schemas describe the config values as the runtime decodes them
+// from JSON, so they can be used to catch typos and type errors in config
+// files before they are deployed, instead of when the services start.
+package configschema
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// Draft is the JSON Schema draft the schemas conform to.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema, supporting the subset of keywords
+// needed to describe config types.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Type is the JSON type of the value, or "" if it may be of any type.
+	Type            string   `json:"type,omitempty"`
+	Format          string   `json:"format,omitempty"`
+	ContentEncoding string   `json:"contentEncoding,omitempty"`
+	Minimum         *float64 `json:"minimum,omitempty"`
+	Maximum         *float64 `json:"maximum,omitempty"`
+
+	// Items is the schema of the elements of arrays.
+	Items *Schema `json:"items,omitempty"`
+
+	// Properties are the schemas of the known properties of objects.
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	// AdditionalProperties is the schema of properties not listed in
+	// Properties, or false if there may be none. It's nil for objects
+	// allowing any properties.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+}
+
+// Generate returns the schema of the config of the app's services, which
+// is an object with a property for each service that loads config, keyed
+// by the service name.
+func Generate(md *meta.Data) *Schema {
+	g := &generator{
+		decls:    make(map[uint32]*schema.Decl, len(md.Decls)),
+		visiting: make(map[uint32]bool),
+	}
+	for _, d := range md.Decls {
+		g.decls[d.Id] = d
+	}
+
+	doc := &Schema{
+		Schema:               Draft,
+		Title:                "Encore service config",
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+	for _, svc := range md.Svcs {
+		if len(svc.Configs) == 0 {
+			continue
+		}
+		s := &Schema{
+			Title:                svc.Name,
+			Type:                 "object",
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: false,
+		}
+		// Services may load config more than once. As when generating the
+		// CUE definitions, the fields of all the loaded types are merged.
+		for _, typ := range svc.Configs {
+			cfg := g.schema(typ, nil)
+			for name, prop := range cfg.Properties {
+				if _, ok := s.Properties[name]; !ok {
+					s.Properties[name] = prop
+				}
+			}
+			for _, name := range cfg.Required {
+				if !slices.Contains(s.Required, name) {
+					s.Required = append(s.Required, name)
+				}
+			}
+		}
+		doc.Properties[svc.Name] = s
+	}
+	return doc
+}
+
+// Service returns the standalone schema of the config of the given service,
+// or nil if the service doesn't load any config.
+func (s *Schema) Service(name string) *Schema {
+	svc, ok := s.Properties[name]
+	if !ok {
+		return nil
+	}
+	res := *svc
+	res.Schema = s.Schema
+	return &res
+}
+
+type generator struct {
+	decls    map[uint32]*schema.Decl
+	visiting map[uint32]bool // decls currently being generated, to handle recursive types
+}
+
+// typeArgs maps declaration ids to the type arguments they are instantiated with.
+type typeArgs map[uint32][]*schema.Type
+
+// schema returns the schema of values of type typ.
+func (g *generator) schema(typ *schema.Type, args typeArgs) *Schema {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl, ok := g.decls[t.Named.Id]
+		if !ok || g.visiting[decl.Id] {
+			// Recursive types can't be described without
+			// references, so accept any value instead.
+			return &Schema{}
+		}
+		g.visiting[decl.Id] = true
+		defer delete(g.visiting, decl.Id)
+
+		// Resolve the type arguments in the current scope
+		// before binding them to the declaration's parameters.
+		typeArgs := make([]*schema.Type, len(t.Named.TypeArguments))
+		for i, arg := range t.Named.TypeArguments {
+			typeArgs[i] = args.resolve(arg)
+		}
+		s := g.schema(decl.Type, args.with(decl.Id, typeArgs))
+		if s.Description == "" {
+			s.Description = strings.TrimSpace(decl.Doc)
+		}
+		return s
+
+	case *schema.Type_Struct:
+		s := &Schema{
+			Type:                 "object",
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: false,
+		}
+		for _, f := range t.Struct.Fields {
+			name := jsonName(f)
+			if name == "" {
+				continue
+			}
+			prop := g.schema(f.Typ, args)
+			if doc := strings.TrimSpace(f.Doc); doc != "" {
+				prop.Description = doc
+			}
+			s.Properties[name] = prop
+			if !optional(f) {
+				s.Required = append(s.Required, name)
+			}
+		}
+		return s
+
+	case *schema.Type_Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Map.Value, args)}
+	case *schema.Type_List:
+		return &Schema{Type: "array", Items: g.schema(t.List.Elem, args)}
+	case *schema.Type_Pointer:
+		return g.schema(t.Pointer.Base, args)
+	case *schema.Type_Option:
+		return g.schema(t.Option.Value, args)
+	case *schema.Type_Config:
+		if t.Config.IsValuesList {
+			return &Schema{Type: "array", Items: g.schema(t.Config.Elem, args)}
+		}
+		return g.schema(t.Config.Elem, args)
+	case *schema.Type_Builtin:
+		return builtin(t.Builtin)
+
+	case *schema.Type_TypeParameter:
+		if arg := args.lookup(t.TypeParameter); arg != nil {
+			return g.schema(arg, args)
+		}
+	}
+	return &Schema{}
+}
+
+// builtin returns the schema of a builtin type, as the runtime decodes it.
+func builtin(b schema.Builtin) *Schema {
+	intRange := func(min, max float64) *Schema {
+		return &Schema{Type: "integer", Minimum: &min, Maximum: &max}
+	}
+	switch b {
+	case schema.Builtin_BOOL:
+		return &Schema{Type: "boolean"}
+	case schema.Builtin_INT8:
+		return intRange(math.MinInt8, math.MaxInt8)
+	case schema.Builtin_INT16:
+		return intRange(math.MinInt16, math.MaxInt16)
+	case schema.Builtin_INT32:
+		return intRange(math.MinInt32, math.MaxInt32)
+	case schema.Builtin_UINT8:
+		return intRange(0, math.MaxUint8)
+	case schema.Builtin_UINT16:
+		return intRange(0, math.MaxUint16)
+	case schema.Builtin_UINT32:
+		return intRange(0, math.MaxUint32)
+	case schema.Builtin_INT, schema.Builtin_INT64:
+		// The bounds of 64-bit integers can't be represented exactly as JSON numbers.
+		return &Schema{Type: "integer"}
+	case schema.Builtin_UINT, schema.Builtin_UINT64:
+		min := 0.0
+		return &Schema{Type: "integer", Minimum: &min}
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return &Schema{Type: "number"}
+	case schema.Builtin_STRING, schema.Builtin_USER_ID:
+		return &Schema{Type: "string"}
+	case schema.Builtin_DECIMAL:
+		return &Schema{Type: "string", Format: "decimal"}
+	case schema.Builtin_BYTES:
+		return &Schema{Type: "string", ContentEncoding: "base64"}
+	case schema.Builtin_TIME:
+		return &Schema{Type: "string", Format: "date-time"}
+	case schema.Builtin_UUID:
+		return &Schema{Type: "string", Format: "uuid"}
+	default:
+		// ANY and JSON accept any value.
+		return &Schema{}
+	}
+}
+
+// jsonName returns the name of the field in JSON,
+// or "" if the field is not decoded.
+func jsonName(f *schema.Field) string {
+	if f.JsonName == "-" {
+		return ""
+	} else if f.JsonName != "" {
+		return f.JsonName
+	}
+	return f.Name
+}
+
+// optional reports whether a config field may be omitted, which is the case
+// for fields tagged with `json:",omitempty"` or `cue:",opt"`.
+func optional(f *schema.Field) bool {
+	if f.Optional {
+		return true
+	}
+	for _, tag := range f.Tags {
+		if (tag.Key == "json" && slices.Contains(tag.Options, "omitempty")) ||
+			(tag.Key == "cue" && slices.Contains(tag.Options, "opt")) {
+			return true
+		}
+	}
+	return false
+}
+
+// with returns a copy of args where the type parameters
+// of the given declaration are bound to typeArgs.
+func (args typeArgs) with(declID uint32, typeArgs []*schema.Type) typeArgs {
+	if len(typeArgs) == 0 {
+		return args
+	}
+	res := make(map[uint32][]*schema.Type, len(args)+1)
+	for k, v := range args {
+		res[k] = v
+	}
+	res[declID] = typeArgs
+	return res
+}
+
+func (args typeArgs) lookup(ref *schema.TypeParameterRef) *schema.Type {
+	if a := args[ref.DeclId]; int(ref.ParamIdx) < len(a) {
+		return a[ref.ParamIdx]
+	}
+	return nil
+}
+
+// resolve returns the type argument typ refers to, if it's a type parameter.
+func (args typeArgs) resolve(typ *schema.Type) *schema.Type {
+	if ref, ok := typ.Typ.(*schema.Type_TypeParameter); ok {
+		if arg := args.lookup(ref.TypeParameter); arg != nil {
+			return arg
+		}
+	}
+	return typ
+}
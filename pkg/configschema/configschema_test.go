@@ -0,0 +1,146 @@
+package configschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func builtinType(b schema.Builtin) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Builtin{Builtin: b}}
+}
+
+func named(id uint32, typeArgs ...*schema.Type) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: id, TypeArguments: typeArgs}}}
+}
+
+func strct(fields ...*schema.Field) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: fields}}}
+}
+
+func configValue(elem *schema.Type, list bool) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Config{Config: &schema.ConfigValue{Elem: elem, IsValuesList: list}}}
+}
+
+func testMeta() *meta.Data {
+	return &meta.Data{
+		Decls: []*schema.Decl{
+			{Id: 0, Name: "Config", Doc: "Config is the payment config.\n", Type: strct(
+				&schema.Field{Name: "APIKey", Typ: builtinType(schema.Builtin_STRING), Doc: "The provider API key."},
+				&schema.Field{Name: "Retries", Typ: configValue(builtinType(schema.Builtin_UINT8), false)},
+				&schema.Field{Name: "Regions", Typ: configValue(builtinType(schema.Builtin_STRING), true)},
+				&schema.Field{Name: "Timeout", JsonName: "timeout", Typ: builtinType(schema.Builtin_INT64),
+					Tags: []*schema.Tag{{Key: "json", Name: "timeout", Options: []string{"omitempty"}}}},
+				&schema.Field{Name: "Limits", Typ: named(1, builtinType(schema.Builtin_FLOAT64))},
+				&schema.Field{Name: "Internal", JsonName: "-", Typ: builtinType(schema.Builtin_BOOL)},
+			)},
+			{Id: 1, Name: "Limits", TypeParams: []*schema.TypeParameter{{Name: "T"}}, Type: &schema.Type{
+				Typ: &schema.Type_Map{Map: &schema.Map{
+					Key:   builtinType(schema.Builtin_STRING),
+					Value: &schema.Type{Typ: &schema.Type_TypeParameter{TypeParameter: &schema.TypeParameterRef{DeclId: 1}}},
+				}},
+			}},
+			{Id: 2, Name: "Flags", Type: strct(
+				&schema.Field{Name: "Beta", Typ: builtinType(schema.Builtin_BOOL),
+					Tags: []*schema.Tag{{Key: "cue", Options: []string{"opt"}}}},
+			)},
+		},
+		Svcs: []*meta.Service{
+			{Name: "payments", HasConfig: true, Configs: []*schema.Type{named(0), named(2)}},
+			{Name: "users"},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	doc := Generate(testMeta())
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.JSONEquals, map[string]any{
+		"$schema":              Draft,
+		"title":                "Encore service config",
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"payments": map[string]any{
+				"title":                "payments",
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []any{"APIKey", "Retries", "Regions", "Limits"},
+				"properties": map[string]any{
+					"APIKey":  map[string]any{"type": "string", "description": "The provider API key."},
+					"Retries": map[string]any{"type": "integer", "minimum": 0, "maximum": 255},
+					"Regions": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					"timeout": map[string]any{"type": "integer"},
+					"Limits": map[string]any{
+						"type":                 "object",
+						"additionalProperties": map[string]any{"type": "number"},
+					},
+					"Beta": map[string]any{"type": "boolean"},
+				},
+			},
+		},
+	})
+
+	svc := doc.Service("payments")
+	c.Assert(svc.Schema, qt.Equals, Draft)
+	c.Assert(svc.Title, qt.Equals, "payments")
+	c.Assert(doc.Service("users"), qt.IsNil)
+}
+
+func TestValidate(t *testing.T) {
+	c := qt.New(t)
+	doc := Generate(testMeta())
+
+	tests := []struct {
+		name string
+		cfg  string
+		want []string
+	}{
+		{
+			name: "valid",
+			cfg:  `{"payments": {"APIKey": "k", "Retries": 3, "Regions": ["eu"], "Limits": {"x": 1.5}, "Beta": true}}`,
+		},
+		{
+			name: "typos",
+			cfg:  `{"paymnets": {}, "payments": {"ApiKey": "k", "Retries": 3, "Regions": [], "Limits": {}, "timeot": 5}}`,
+			want: []string{
+				`payments: missing required field "APIKey"`,
+				`payments.ApiKey: unknown field (did you mean "APIKey"?)`,
+				`payments.timeot: unknown field (did you mean "timeout"?)`,
+				`paymnets: unknown field (did you mean "payments"?)`,
+			},
+		},
+		{
+			name: "types",
+			cfg:  `{"payments": {"APIKey": 1, "Retries": 300, "Regions": ["eu", 2], "Limits": {"max-rps": "x"}, "timeout": 1.5}}`,
+			want: []string{
+				`payments.APIKey: expected string, got number 1`,
+				`payments.Limits["max-rps"]: expected number, got string "x"`,
+				`payments.Regions[1]: expected string, got number 2`,
+				`payments.Retries: 300 is greater than the maximum of 255`,
+				`payments.timeout: expected integer, got number 1.5`,
+			},
+		},
+	}
+	for _, test := range tests {
+		c.Run(test.name, func(c *qt.C) {
+			errs, err := doc.Validate([]byte(test.cfg))
+			c.Assert(err, qt.IsNil)
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			c.Assert(got, qt.DeepEquals, test.want)
+		})
+	}
+
+	_, err := doc.Validate([]byte(`{"payments": `))
+	c.Assert(err, qt.ErrorMatches, "invalid JSON: .*")
+}
@@ -0,0 +1,205 @@
+package configschema
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Error describes a config value that doesn't match its schema.
+type Error struct {
+	// Path is the path to the value, such as "payments.Retries[2]",
+	// or "" for the top-level value.
+	Path string
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Msg
+	}
+	return e.Path + ": " + e.Msg
+}
+
+// Validate validates the JSON-encoded config values in data against the
+// schema. It returns the values that don't match it, ordered by their path,
+// or an error if data isn't valid JSON.
+func (s *Schema) Validate(data []byte) ([]*Error, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var val any
+	if err := dec.Decode(&val); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	} else if dec.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the top-level value")
+	}
+
+	var errs []*Error
+	validate(&errs, "", s, val)
+	slices.SortStableFunc(errs, func(a, b *Error) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return errs, nil
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`)
+
+func validate(errs *[]*Error, path string, s *Schema, val any) {
+	errorf := func(format string, args ...any) {
+		*errs = append(*errs, &Error{Path: path, Msg: fmt.Sprintf(format, args...)})
+	}
+	if s.Type != "" && s.Type != jsonType(val) && !(s.Type == "number" && jsonType(val) == "integer") {
+		errorf("expected %s, got %s", s.Type, describe(val))
+		return
+	}
+
+	switch val := val.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				errorf("missing required field %q", name)
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(val)) {
+			if prop, ok := s.Properties[key]; ok {
+				validate(errs, childPath(path, key), prop, val[key])
+			} else if additional, ok := s.AdditionalProperties.(*Schema); ok {
+				validate(errs, childPath(path, key), additional, val[key])
+			} else if s.AdditionalProperties == false {
+				msg := "unknown field"
+				if alt := closest(key, slices.Sorted(maps.Keys(s.Properties))); alt != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", alt)
+				}
+				*errs = append(*errs, &Error{Path: childPath(path, key), Msg: msg})
+			}
+		}
+
+	case []any:
+		if s.Items != nil {
+			for i, elem := range val {
+				validate(errs, fmt.Sprintf("%s[%d]", path, i), s.Items, elem)
+			}
+		}
+
+	case json.Number:
+		n, _ := new(big.Float).SetString(val.String())
+		if n == nil {
+			return
+		}
+		if s.Minimum != nil && n.Cmp(big.NewFloat(*s.Minimum)) < 0 {
+			errorf("%s is less than the minimum of %v", val, *s.Minimum)
+		} else if s.Maximum != nil && n.Cmp(big.NewFloat(*s.Maximum)) > 0 {
+			errorf("%s is greater than the maximum of %v", val, *s.Maximum)
+		}
+
+	case string:
+		switch {
+		case s.Format == "date-time":
+			if _, err := time.Parse(time.RFC3339Nano, val); err != nil {
+				errorf("%q is not an RFC 3339 timestamp", val)
+			}
+		case s.Format == "uuid":
+			if !uuidRe.MatchString(val) {
+				errorf("%q is not a UUID", val)
+			}
+		case s.ContentEncoding == "base64":
+			if _, err := base64.StdEncoding.DecodeString(val); err != nil {
+				errorf("%q is not base64-encoded", val)
+			}
+		}
+	}
+}
+
+// jsonType returns the JSON Schema type of a decoded JSON value.
+func jsonType(val any) string {
+	switch val := val.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, ok := new(big.Int).SetString(val.String(), 10); ok {
+			return "integer"
+		}
+		return "number"
+	default:
+		return "null"
+	}
+}
+
+// describe describes a value for use in error messages.
+func describe(val any) string {
+	switch val := val.(type) {
+	case string:
+		return "string " + strconv.Quote(val)
+	case json.Number:
+		return "number " + val.String()
+	case bool:
+		return "boolean " + strconv.FormatBool(val)
+	}
+	return jsonType(val)
+}
+
+// childPath returns the path to the property key of the object at path.
+func childPath(path, key string) string {
+	isIdent := key != "" && strings.IndexFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}) < 0
+	switch {
+	case !isIdent:
+		return path + "[" + strconv.Quote(key) + "]"
+	case path == "":
+		return key
+	default:
+		return path + "." + key
+	}
+}
+
+// closest returns the name in names closest to name, if any is close
+// enough to likely be what was meant.
+func closest(name string, names []string) string {
+	best, bestDist := "", 3
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return n
+		}
+		if d := editDistance(strings.ToLower(n), strings.ToLower(name)); d < bestDist && d < len(n)/2+1 {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -48,17 +48,38 @@ func LoadFromFS(filesys fs.FS, serviceRelPath string, meta *Meta) (cue.Value, er
 	}
 
 	// If there are no config files, return an empty value
-	if len(configFilesForService) == 0 {
+	layers := layersOf(configFilesForService, meta)
+	if len(layers) == 0 {
 		return cue.Value{}, nil
 	}
 
+	ctx := cuecontext.New()
+	if len(layers) > 1 || layers[0].Kind != LayerBase {
+		return loadLayered(ctx, tmpPath, layers, meta)
+	}
+
+	rtnValue, err := buildValue(ctx, tmpPath, layers[0].Files, meta.ToTags())
+	if err != nil {
+		return cue.Value{}, err
+	}
+
+	// Validate the unified value is concrete
+	if err := rtnValue.Validate(cue.Concrete(true)); err != nil {
+		return cue.Value{}, srcerrors.CUEEvaluationFailed(err, tmpPath)
+	}
+
+	return rtnValue, nil
+}
+
+// buildValue loads the given config files from tmpPath and unifies them into a single value.
+func buildValue(ctx *cue.Context, tmpPath string, files []string, tags []string) (cue.Value, error) {
 	// Tell CUE to load all the files
 	loaderCfg := &load.Config{
 		Dir:   tmpPath,
 		Tools: true,
-		Tags:  meta.ToTags(),
+		Tags:  tags,
 	}
-	pkgs := load.Instances(configFilesForService, loaderCfg)
+	pkgs := load.Instances(files, loaderCfg)
 	for _, pkg := range pkgs {
 		if pkg.Err != nil {
 			return cue.Value{}, srcerrors.UnableToLoadCUEInstances(pkg.Err, tmpPath)
@@ -71,7 +92,6 @@ func LoadFromFS(filesys fs.FS, serviceRelPath string, meta *Meta) (cue.Value, er
 	}
 
 	// Build the CUE values
-	ctx := cuecontext.New()
 	values, err := ctx.BuildInstances(pkgs)
 	if err != nil {
 		return cue.Value{}, srcerrors.UnableToLoadCUEInstances(err, tmpPath)
@@ -87,12 +107,6 @@ func LoadFromFS(filesys fs.FS, serviceRelPath string, meta *Meta) (cue.Value, er
 	for _, value := range values {
 		rtnValue = rtnValue.Unify(value)
 	}
-
-	// Validate the unified value is concrete
-	if err := rtnValue.Validate(cue.Concrete(true)); err != nil {
-		return cue.Value{}, srcerrors.CUEEvaluationFailed(err, tmpPath)
-	}
-
 	return rtnValue, nil
 }
 
@@ -0,0 +1,250 @@
+package cueutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+
+	"encr.dev/pkg/eerror"
+	"encr.dev/pkg/errinsrc/srcerrors"
+)
+
+// LayerKind is the kind of a config layer.
+//
+// A service's config files are merged in layers, each overriding
+// the values of the layers before it:
+//
+//   - the base layer holds all config files that aren't layer files,
+//   - the environment layer holds the files named "*.env-<name>.cue"
+//     for the environment being configured, and
+//   - the local layer holds the files named "*.local.cue", which are only
+//     used when running locally and are meant to be kept out of version control.
+type LayerKind string
+
+const (
+	LayerBase        LayerKind = "base"
+	LayerEnvironment LayerKind = "environment"
+	LayerLocal       LayerKind = "local"
+)
+
+// Layer is a layer of config files.
+type Layer struct {
+	Kind  LayerKind `json:"kind"`
+	Files []string  `json:"files"` // the files in the layer, relative to the app root
+}
+
+// schemaFile is the name of the config file generated from the service's config types.
+const schemaFile = "encore.gen.cue"
+
+var envFileRe = regexp.MustCompile(`\.env-([^.]+)\.cue$`)
+
+// Layers returns the config layers of the service at the given app-relative path
+// for the environment described by meta, in the order they are merged.
+// Layers without any files are omitted.
+func Layers(filesys fs.FS, serviceRelPath string, meta *Meta) ([]Layer, error) {
+	files, err := allFilesUnder(filesys, serviceRelPath)
+	if err != nil {
+		return nil, eerror.Wrap(err, "config", "unable to list all config files for service", map[string]any{"path": serviceRelPath})
+	}
+	return layersOf(files, meta), nil
+}
+
+func layersOf(files []string, meta *Meta) []Layer {
+	var base, env, local []string
+	for _, file := range files {
+		if m := envFileRe.FindStringSubmatch(file); m != nil {
+			if meta != nil && m[1] == meta.EnvName {
+				env = append(env, file)
+			}
+		} else if strings.HasSuffix(file, ".local.cue") {
+			if meta != nil && meta.CloudType == CloudType_Local {
+				local = append(local, file)
+			}
+		} else {
+			base = append(base, file)
+		}
+	}
+
+	var layers []Layer
+	for _, l := range []Layer{{LayerBase, base}, {LayerEnvironment, env}, {LayerLocal, local}} {
+		if len(l.Files) > 0 {
+			layers = append(layers, l)
+		}
+	}
+	return layers
+}
+
+// loadLayered loads the config of the given layers and merges them.
+//
+// Each layer is evaluated on its own, so it can't refer to values in
+// other layers. The environment and local layers can refer to #Meta,
+// and must be concrete. Their values are then merged on top of the
+// values of the layers before them: structs are merged field by field,
+// while any other value, including lists, replaces the previous value.
+// Finally the merged config is checked against the service's config types.
+func loadLayered(ctx *cue.Context, tmpPath string, layers []Layer, meta *Meta) (cue.Value, error) {
+	var schema string
+	if layers[0].Kind == LayerBase {
+		if idx := slices.IndexFunc(layers[0].Files, func(f string) bool { return path.Base(f) == schemaFile }); idx >= 0 {
+			schema = layers[0].Files[idx]
+		}
+	}
+
+	// Layers other than the base layer are evaluated together with the
+	// definitions of the schema file, for them to be able to use #Meta.
+	var defs string
+	if schema != "" {
+		var err error
+		if defs, err = writeDefinitions(tmpPath, schema); err != nil {
+			return cue.Value{}, err
+		}
+	}
+
+	var merged any = map[string]any{}
+	for _, l := range layers {
+		files, tags := l.Files, meta.ToTags()
+		if l.Kind != LayerBase {
+			if defs != "" {
+				files = append(slices.Clone(files), defs)
+			} else {
+				tags = nil
+			}
+		}
+
+		v, err := buildValue(ctx, tmpPath, files, tags)
+		if err != nil {
+			return cue.Value{}, err
+		}
+
+		var data any
+		if l.Kind == LayerBase {
+			// The base layer may leave values to the other layers,
+			// so only its concrete values are merged.
+			if err := v.Validate(); err != nil {
+				return cue.Value{}, srcerrors.CUEEvaluationFailed(err, tmpPath)
+			}
+			data, err = concreteData(v)
+		} else {
+			if err := v.Validate(cue.Concrete(true)); err != nil {
+				return cue.Value{}, srcerrors.CUEEvaluationFailed(err, tmpPath)
+			}
+			data, err = toData(v)
+		}
+		if err != nil {
+			return cue.Value{}, srcerrors.CUEEvaluationFailed(err, tmpPath)
+		}
+		merged = mergeData(merged, data)
+	}
+
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return cue.Value{}, eerror.Wrap(err, "config", "unable to marshal merged config", nil)
+	}
+	rtnValue := ctx.CompileBytes(mergedJSON, cue.Filename("merged config"))
+	if schema != "" {
+		schemaValue, err := buildValue(ctx, tmpPath, []string{schema}, meta.ToTags())
+		if err != nil {
+			return cue.Value{}, err
+		}
+		rtnValue = schemaValue.Unify(rtnValue)
+	}
+	if err := rtnValue.Validate(cue.Concrete(true)); err != nil {
+		return cue.Value{}, srcerrors.CUEEvaluationFailed(err, tmpPath)
+	}
+	return rtnValue, nil
+}
+
+// writeDefinitions writes a copy of the given schema file without its
+// top-level embedding of #Config, keeping only its definitions.
+// It returns the path of the copy, relative to tmpPath.
+func writeDefinitions(tmpPath, schema string) (string, error) {
+	f, err := parser.ParseFile(filepath.Join(tmpPath, filepath.FromSlash(schema)), nil, parser.ParseComments)
+	if err != nil {
+		return "", srcerrors.UnableToLoadCUEInstances(err, tmpPath)
+	}
+	f.Decls = slices.DeleteFunc(f.Decls, func(d ast.Decl) bool {
+		_, isEmbed := d.(*ast.EmbedDecl)
+		return isEmbed
+	})
+	data, err := format.Node(f)
+	if err != nil {
+		return "", eerror.Wrap(err, "config", "unable to format config definitions", nil)
+	}
+
+	defs := path.Join(path.Dir(schema), "encore.gen.defs.cue")
+	if err := os.WriteFile(filepath.Join(tmpPath, filepath.FromSlash(defs)), data, 0644); err != nil {
+		return "", eerror.Wrap(err, "config", "unable to write config definitions", nil)
+	}
+	return defs, nil
+}
+
+// concreteData returns the concrete values of v, skipping
+// the fields whose values aren't concrete.
+func concreteData(v cue.Value) (any, error) {
+	v, _ = v.Default()
+	if v.IncompleteKind() != cue.StructKind {
+		if v.Validate(cue.Concrete(true)) != nil {
+			return nil, nil
+		}
+		return toData(v)
+	}
+
+	iter, err := v.Fields()
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]any)
+	for iter.Next() {
+		field, err := concreteData(iter.Value())
+		if err != nil {
+			return nil, err
+		} else if field != nil {
+			data[iter.Label()] = field
+		}
+	}
+	return data, nil
+}
+
+// toData decodes the concrete value v into its JSON representation.
+func toData(v cue.Value) (any, error) {
+	data, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// mergeData merges src on top of dst. Objects are merged field by field,
+// while any other value in src replaces the value in dst.
+func mergeData(dst, src any) any {
+	dstObj, ok1 := dst.(map[string]any)
+	srcObj, ok2 := src.(map[string]any)
+	if !ok1 || !ok2 {
+		return src
+	}
+	for k, v := range srcObj {
+		if prev, ok := dstObj[k]; ok {
+			dstObj[k] = mergeData(prev, v)
+		} else {
+			dstObj[k] = v
+		}
+	}
+	return dstObj
+}
@@ -0,0 +1,151 @@
+package cueutil
+
+import (
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	qt "github.com/frankban/quicktest"
+)
+
+const testSchema = `package svc
+
+#Meta: {
+	APIBaseURL: string @tag(APIBaseURL)
+	Environment: {
+		Name:  string                                              @tag(EnvName)
+		Type:  "production" | "development" | "ephemeral" | "test" @tag(EnvType)
+		Cloud: "aws" | "azure" | "gcp" | "encore" | "local"        @tag(CloudType)
+	}
+}
+
+#Config: {
+	Name: string
+	Port: uint16
+	DB: {
+		Host: string
+		Pool: int
+	}
+	Regions: [...string]
+	CallbackURL: string
+}
+#Config
+`
+
+func TestLoadFromFS_Layers(t *testing.T) {
+	files := fstest.MapFS{
+		"svc/encore.gen.cue": {Data: []byte(testSchema)},
+		"svc/config.cue": {Data: []byte(`package svc
+
+Name: "svc"
+Port: 8080
+DB: {Host: "localhost", Pool: 5}
+Regions: ["eu", "us"]
+CallbackURL: "\(#Meta.APIBaseURL)/callback"
+`)},
+		"svc/config.env-prod.cue": {Data: []byte(`package svc
+
+Port: 443
+DB: Host: "db.\(#Meta.Environment.Name).internal"
+Regions: ["eu"]
+`)},
+		"svc/config.local.cue": {Data: []byte(`package svc
+
+DB: Pool: 1
+`)},
+	}
+
+	tests := []struct {
+		name       string
+		meta       *Meta
+		wantLayers []Layer
+		want       string
+	}{
+		{
+			name: "local",
+			meta: &Meta{APIBaseURL: "http://localhost:4000", EnvName: "local", EnvType: EnvType_Development, CloudType: CloudType_Local},
+			wantLayers: []Layer{
+				{Kind: LayerBase, Files: []string{"svc/config.cue", "svc/encore.gen.cue"}},
+				{Kind: LayerLocal, Files: []string{"svc/config.local.cue"}},
+			},
+			want: `{"Name":"svc","Port":8080,"DB":{"Host":"localhost","Pool":1},"Regions":["eu","us"],"CallbackURL":"http://localhost:4000/callback"}`,
+		},
+		{
+			name: "prod",
+			meta: &Meta{APIBaseURL: "https://api.example.com", EnvName: "prod", EnvType: EnvType_Production, CloudType: CloudType_AWS},
+			wantLayers: []Layer{
+				{Kind: LayerBase, Files: []string{"svc/config.cue", "svc/encore.gen.cue"}},
+				{Kind: LayerEnvironment, Files: []string{"svc/config.env-prod.cue"}},
+			},
+			want: `{"Name":"svc","Port":443,"DB":{"Host":"db.prod.internal","Pool":5},"Regions":["eu"],"CallbackURL":"https://api.example.com/callback"}`,
+		},
+		{
+			name: "staging",
+			meta: &Meta{APIBaseURL: "https://staging.example.com", EnvName: "staging", EnvType: EnvType_Development, CloudType: CloudType_GCP},
+			wantLayers: []Layer{
+				{Kind: LayerBase, Files: []string{"svc/config.cue", "svc/encore.gen.cue"}},
+			},
+			want: `{"Name":"svc","Port":8080,"DB":{"Host":"localhost","Pool":5},"Regions":["eu","us"],"CallbackURL":"https://staging.example.com/callback"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := qt.New(t)
+			layers, err := Layers(files, "svc", test.meta)
+			c.Assert(err, qt.IsNil)
+			c.Assert(layers, qt.DeepEquals, test.wantLayers)
+
+			v, err := LoadFromFS(files, "svc", test.meta)
+			c.Assert(err, qt.IsNil)
+			got, err := v.MarshalJSON()
+			c.Assert(err, qt.IsNil)
+			c.Assert(string(got), qt.JSONEquals, jsonValue(c, test.want))
+		})
+	}
+}
+
+func TestLoadFromFS_LayersIncompleteBase(t *testing.T) {
+	c := qt.New(t)
+	meta := &Meta{APIBaseURL: "https://api.example.com", EnvName: "prod", EnvType: EnvType_Production, CloudType: CloudType_AWS}
+	files := fstest.MapFS{
+		"svc/encore.gen.cue": {Data: []byte(testSchema)},
+		"svc/config.cue": {Data: []byte(`package svc
+
+Name: "svc"
+Port: 8080
+Regions: []
+CallbackURL: "\(#Meta.APIBaseURL)/callback"
+`)},
+		"svc/config.env-prod.cue": {Data: []byte(`package svc
+
+DB: {Host: "db", Pool: 10}
+`)},
+	}
+
+	// The environment layer provides the values left out by the base layer.
+	v, err := LoadFromFS(files, "svc", meta)
+	c.Assert(err, qt.IsNil)
+	got, err := v.MarshalJSON()
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(got), qt.JSONEquals, jsonValue(c, `{"Name":"svc","Port":8080,"DB":{"Host":"db","Pool":10},"Regions":[],"CallbackURL":"https://api.example.com/callback"}`))
+
+	// Other environments are missing them.
+	meta.EnvName = "staging"
+	_, err = LoadFromFS(files, "svc", meta)
+	c.Assert(err, qt.IsNotNil)
+
+	// Unknown fields in layers are reported.
+	meta.EnvName = "prod"
+	files["svc/config.env-prod.cue"] = &fstest.MapFile{Data: []byte(`package svc
+
+DB: {Host: "db", Pool: 10, Poool: 5}
+`)}
+	_, err = LoadFromFS(files, "svc", meta)
+	c.Assert(err, qt.ErrorMatches, `(?s).*Poool.*`)
+}
+
+func jsonValue(c *qt.C, s string) any {
+	var v any
+	c.Assert(json.Unmarshal([]byte(s), &v), qt.IsNil)
+	return v
+}
@@ -0,0 +1,326 @@
+// Package dashgen generates Grafana dashboards for the services in an application,
+// based on the application metadata and the metrics reported by the Encore runtime.
+//
+// Each dashboard covers a single service and contains panels for the
+// API request rate, error rate and latency, the connection pools of the
+// databases the service uses, the delivery lag of its Pub/Sub subscriptions,
+// and the hit rate of its cache keyspaces. Sections for resources the service
+// does not use are left out.
+package dashgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Options configures the generated dashboards.
+type Options struct {
+	// DatasourceUID is the UID of the Prometheus data source selected by default.
+	// If empty, the data source must be selected when importing the dashboard.
+	DatasourceUID string
+}
+
+// Dashboard is a generated Grafana dashboard.
+type Dashboard struct {
+	// Service is the name of the service the dashboard covers.
+	Service string
+
+	// JSON is the dashboard model, suitable for importing into Grafana
+	// or for file-based dashboard provisioning.
+	JSON []byte
+}
+
+// Gen generates a dashboard for each service in md, sorted by service name.
+func Gen(md *meta.Data, opts Options) ([]Dashboard, error) {
+	svcs := slices.Clone(md.Svcs)
+	slices.SortFunc(svcs, func(a, b *meta.Service) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	dashboards := make([]Dashboard, 0, len(svcs))
+	for _, svc := range svcs {
+		b := &builder{md: md, svc: svc}
+		b.apiPanels()
+		b.databasePanels()
+		b.pubsubPanels()
+		b.cachePanels()
+
+		data, err := json.MarshalIndent(b.dashboard(opts), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %v", svc.Name, err)
+		}
+		dashboards = append(dashboards, Dashboard{Service: svc.Name, JSON: append(data, '\n')})
+	}
+	return dashboards, nil
+}
+
+type datasourceRef struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// datasource refers to the data source selected with the dashboard variable.
+var datasource = datasourceRef{Type: "prometheus", UID: "${datasource}"}
+
+type gridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type target struct {
+	RefID        string        `json:"refId"`
+	Datasource   datasourceRef `json:"datasource"`
+	Expr         string        `json:"expr"`
+	LegendFormat string        `json:"legendFormat,omitempty"`
+}
+
+type fieldConfig struct {
+	Defaults  map[string]any `json:"defaults"`
+	Overrides []any          `json:"overrides"`
+}
+
+type panel struct {
+	ID          int            `json:"id"`
+	Type        string         `json:"type"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	GridPos     gridPos        `json:"gridPos"`
+	Datasource  *datasourceRef `json:"datasource,omitempty"`
+	Targets     []target       `json:"targets,omitempty"`
+	FieldConfig *fieldConfig   `json:"fieldConfig,omitempty"`
+}
+
+const (
+	panelHeight = 8
+	rowHeight   = 1
+	gridWidth   = 24
+)
+
+// builder builds the dashboard for a single service.
+type builder struct {
+	md  *meta.Data
+	svc *meta.Service
+
+	panels []panel
+	nextID int
+	y      int // the y position of the next row
+}
+
+func (b *builder) id() int {
+	b.nextID++
+	return b.nextID
+}
+
+// row adds a row titled title, followed by the given panels
+// laid out side by side.
+func (b *builder) row(title string, panels ...panel) {
+	b.panels = append(b.panels, panel{
+		ID:      b.id(),
+		Type:    "row",
+		Title:   title,
+		GridPos: gridPos{H: rowHeight, W: gridWidth, X: 0, Y: b.y},
+	})
+	b.y += rowHeight
+
+	w := gridWidth / len(panels)
+	for i, p := range panels {
+		p.ID = b.id()
+		p.GridPos = gridPos{H: panelHeight, W: w, X: i * w, Y: b.y}
+		b.panels = append(b.panels, p)
+	}
+	b.y += panelHeight
+}
+
+// timeseries returns a time series panel plotting the given queries.
+// Every two consecutive strings in queries are a query and its legend.
+func timeseries(title, desc, unit string, queries ...string) panel {
+	p := panel{
+		Type:        "timeseries",
+		Title:       title,
+		Description: desc,
+		Datasource:  &datasource,
+		FieldConfig: &fieldConfig{
+			Defaults:  map[string]any{"unit": unit},
+			Overrides: []any{},
+		},
+	}
+	for i := 0; i+1 < len(queries); i += 2 {
+		p.Targets = append(p.Targets, target{
+			RefID:        string(rune('A' + i/2)),
+			Datasource:   datasource,
+			Expr:         queries[i],
+			LegendFormat: queries[i+1],
+		})
+	}
+	return p
+}
+
+func (b *builder) apiPanels() {
+	if len(b.svc.Rpcs) == 0 {
+		return
+	}
+
+	sel := "service=" + strconv.Quote(b.svc.Name)
+	total := fmt.Sprintf(`sum by (endpoint) (rate(e_requests_total{%s}[$__rate_interval]))`, sel)
+	errs := fmt.Sprintf(`sum by (endpoint) (rate(e_requests_total{%s,code!="ok"}[$__rate_interval]))`, sel)
+	quantile := func(q string) string {
+		return fmt.Sprintf(`histogram_quantile(%s, sum by (le) (rate(e_request_duration_seconds_bucket{%s}[$__rate_interval])))`, q, sel)
+	}
+
+	b.row("API",
+		timeseries("Request rate", "Requests per second, by endpoint.", "reqps",
+			total, "{{endpoint}}"),
+		timeseries("Error rate", "Fraction of requests returning an error, by endpoint.", "percentunit",
+			errs+" / "+total, "{{endpoint}}"),
+		timeseries("Latency", "Request latency percentiles across all endpoints.", "s",
+			quantile("0.5"), "p50",
+			quantile("0.95"), "p95",
+			quantile("0.99"), "p99"),
+	)
+}
+
+func (b *builder) databasePanels() {
+	if len(b.svc.Databases) == 0 {
+		return
+	}
+
+	sel := "database=~" + matchAny(b.svc.Databases)
+	b.row("Databases",
+		timeseries("Connections in use", "Connections currently acquired from the pool, by database.", "short",
+			fmt.Sprintf(`sum by (database) (e_sys_sqldb_conns_in_use{%s})`, sel), "{{database}}"),
+		timeseries("Idle connections", "Idle connections in the pool, by database.", "short",
+			fmt.Sprintf(`sum by (database) (e_sys_sqldb_conns_idle{%s})`, sel), "{{database}}"),
+		timeseries("Connection wait time", "Time spent waiting for a connection per second, by database.", "s",
+			fmt.Sprintf(`sum by (database) (rate(e_sys_sqldb_wait_duration_seconds{%s}[$__rate_interval]))`, sel), "{{database}}"),
+	)
+}
+
+func (b *builder) pubsubPanels() {
+	var queries []string
+	for _, topic := range b.md.PubsubTopics {
+		for _, sub := range topic.Subscriptions {
+			if sub.ServiceName != b.svc.Name {
+				continue
+			}
+			queries = append(queries,
+				fmt.Sprintf(`max(e_sys_pubsub_subscription_lag_seconds{topic=%s,subscription=%s})`,
+					strconv.Quote(topic.Name), strconv.Quote(sub.Name)),
+				topic.Name+"/"+sub.Name,
+			)
+		}
+	}
+	if len(queries) == 0 {
+		return
+	}
+
+	b.row("Pub/Sub",
+		timeseries("Subscription lag", "Time between publishing and delivering the most recently delivered message, by subscription.", "s",
+			queries...),
+	)
+}
+
+func (b *builder) cachePanels() {
+	var queries []string
+	for _, cluster := range b.md.CacheClusters {
+		var patterns []string
+		for _, ks := range cluster.Keyspaces {
+			if ks.Service == b.svc.Name {
+				patterns = append(patterns, keyPattern(ks.PathPattern))
+			}
+		}
+		if len(patterns) == 0 {
+			continue
+		}
+
+		sel := fmt.Sprintf("cluster=%s,keyspace=~%s", strconv.Quote(cluster.Name), matchAny(patterns))
+		queries = append(queries,
+			fmt.Sprintf(`sum by (keyspace) (rate(e_sys_cache_reads_total{%s,result="hit"}[$__rate_interval])) / sum by (keyspace) (rate(e_sys_cache_reads_total{%s}[$__rate_interval]))`,
+				sel, sel),
+			cluster.Name+": {{keyspace}}",
+		)
+	}
+	if len(queries) == 0 {
+		return
+	}
+
+	b.row("Caches",
+		timeseries("Cache hit rate", "Fraction of cache reads finding the requested key, by keyspace.", "percentunit",
+			queries...),
+	)
+}
+
+func (b *builder) dashboard(opts Options) map[string]any {
+	variable := map[string]any{
+		"name":  "datasource",
+		"label": "Data source",
+		"type":  "datasource",
+		"query": "prometheus",
+	}
+	if opts.DatasourceUID != "" {
+		variable["current"] = map[string]any{"value": opts.DatasourceUID}
+	}
+
+	panels := b.panels
+	if panels == nil {
+		panels = []panel{}
+	}
+	return map[string]any{
+		"uid":           dashboardUID(b.svc.Name),
+		"title":         "Encore / " + b.svc.Name,
+		"tags":          []string{"encore"},
+		"timezone":      "browser",
+		"schemaVersion": 39,
+		"refresh":       "1m",
+		"time":          map[string]any{"from": "now-6h", "to": "now"},
+		"templating":    map[string]any{"list": []any{variable}},
+		"panels":        panels,
+	}
+}
+
+// keyPattern returns the key pattern of a cache keyspace, as given in its KeyPattern
+// configuration. It matches the keyspace label of the cache metrics.
+func keyPattern(path *meta.Path) string {
+	segs := make([]string, len(path.GetSegments()))
+	for i, s := range path.GetSegments() {
+		switch s.Type {
+		case meta.PathSegment_PARAM:
+			segs[i] = ":" + s.Value
+		case meta.PathSegment_WILDCARD:
+			segs[i] = "*" + s.Value
+		default:
+			segs[i] = s.Value
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+// matchAny returns a quoted PromQL regular expression matching any of vals exactly.
+func matchAny(vals []string) string {
+	quoted := make([]string, len(vals))
+	for i, v := range vals {
+		quoted[i] = regexp.QuoteMeta(v)
+	}
+	return strconv.Quote(strings.Join(quoted, "|"))
+}
+
+// dashboardUID returns the dashboard UID for the named service.
+// UIDs longer than Grafana's 40 character limit are shortened,
+// keeping a hash of the service name to tell them apart.
+func dashboardUID(service string) string {
+	uid := "encore-" + service
+	if len(uid) > 40 {
+		sum := sha256.Sum256([]byte(service))
+		uid = uid[:31] + "-" + hex.EncodeToString(sum[:4])
+	}
+	return uid
+}
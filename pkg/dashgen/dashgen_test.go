@@ -0,0 +1,81 @@
+package dashgen
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"encr.dev/pkg/golden"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+	os.Exit(m.Run())
+}
+
+func testMeta() *meta.Data {
+	return &meta.Data{
+		Svcs: []*meta.Service{
+			{
+				Name:      "orders",
+				Rpcs:      []*meta.RPC{{Name: "Create"}, {Name: "Get"}},
+				Databases: []string{"orders"},
+			},
+			{
+				Name: "email",
+			},
+			{
+				Name: "leaderboard",
+				Rpcs: []*meta.RPC{{Name: "Top"}},
+			},
+		},
+		PubsubTopics: []*meta.PubSubTopic{
+			{
+				Name: "order-created",
+				Subscriptions: []*meta.PubSubTopic_Subscription{
+					{Name: "send-receipt", ServiceName: "email"},
+					{Name: "update-scores", ServiceName: "leaderboard"},
+				},
+			},
+		},
+		CacheClusters: []*meta.CacheCluster{
+			{
+				Name: "scores",
+				Keyspaces: []*meta.CacheCluster_Keyspace{
+					{
+						Service: "leaderboard",
+						PathPattern: &meta.Path{Segments: []*meta.PathSegment{
+							{Type: meta.PathSegment_LITERAL, Value: "board.v1"},
+							{Type: meta.PathSegment_PARAM, Value: "key"},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGen(t *testing.T) {
+	dashboards, err := Gen(testMeta(), Options{DatasourceUID: "prom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	for _, d := range dashboards {
+		out.WriteString("-- " + d.Service + ".json --\n")
+		out.Write(d.JSON)
+	}
+	golden.Test(t, out.String())
+}
+
+func TestDashboardUID(t *testing.T) {
+	if got, want := dashboardUID("orders"), "encore-orders"; got != want {
+		t.Errorf("dashboardUID() = %q, want %q", got, want)
+	}
+	long := dashboardUID(strings.Repeat("x", 50))
+	if len(long) != 40 {
+		t.Errorf("dashboardUID() = %q, want 40 characters", long)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	cloudstorage "cloud.google.com/go/storage"
 	"encr.dev/pkg/emulators/storage/gcsutil"
@@ -133,7 +134,7 @@ func (g *GcsEmu) Handler(w http.ResponseWriter, r *http.Request) {
 		} else {
 			alt := r.URL.Query().Get("alt")
 			if alt == "media" || (p.IsPublic && alt == "") {
-				g.handleGcsMediaRequest(baseUrl, w, r.Header.Get("Accept-Encoding"), bucket, object)
+				g.handleGcsMediaRequest(baseUrl, w, r, bucket, object)
 			} else if alt == "json" || (!p.IsPublic && alt == "") {
 				g.handleGcsMetadataRequest(baseUrl, w, bucket, object)
 			} else {
@@ -158,7 +159,7 @@ func (g *GcsEmu) Handler(w http.ResponseWriter, r *http.Request) {
 			// TODO: enforce other conditions outside of generation
 			g.handleGcsCompose(ctx, baseUrl, w, r, bucket, object, conds)
 		} else if strings.Contains(object, "/rewriteTo/") {
-			g.handleGcsCopy(ctx, baseUrl, w, bucket, object)
+			g.handleGcsCopy(ctx, baseUrl, w, r, bucket, object)
 		} else if r.Form.Get("upload_id") != "" {
 			g.handleGcsNewObjectResume(ctx, baseUrl, w, r, r.Form.Get("upload_id"))
 		} else {
@@ -277,7 +278,7 @@ func (g *GcsEmu) handleGcsDelete(ctx context.Context, w http.ResponseWriter, buc
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (g *GcsEmu) handleGcsMediaRequest(baseUrl HttpBaseUrl, w http.ResponseWriter, acceptEncoding, bucket, filename string) {
+func (g *GcsEmu) handleGcsMediaRequest(baseUrl HttpBaseUrl, w http.ResponseWriter, r *http.Request, bucket, filename string) {
 	obj, contents, err := g.store.Get(baseUrl, bucket, filename)
 	if err != nil {
 		g.gapiError(w, http.StatusInternalServerError, fmt.Sprintf("failed to check existence of %s/%s: %s", bucket, filename, err))
@@ -296,7 +297,7 @@ func (g *GcsEmu) handleGcsMediaRequest(baseUrl HttpBaseUrl, w http.ResponseWrite
 	w.Header().Set("Content-Disposition", obj.ContentDisposition)
 
 	if obj.ContentEncoding == "gzip" {
-		if strings.Contains(acceptEncoding, "gzip") {
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
 			w.Header().Set("Content-Encoding", "gzip")
 		} else {
 			// Uncompress on behalf of the client.
@@ -315,6 +316,13 @@ func (g *GcsEmu) handleGcsMediaRequest(baseUrl HttpBaseUrl, w http.ResponseWrite
 		}
 	}
 
+	// Serve the requested byte range, for ranged and resumed downloads.
+	if r.Header.Get("Range") != "" {
+		w.Header().Set("Access-Control-Expose-Headers", "Content-Type, Content-Length, Content-Range, Content-Encoding, Date, X-Goog-Generation, X-Goog-Metageneration")
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(contents))
+		return
+	}
+
 	// Just write the contents
 	w.Header().Set("Content-Length", strconv.Itoa(len(contents)))
 	if _, err := w.Write(contents); err != nil {
@@ -400,8 +408,8 @@ func (g *GcsEmu) handleGcsUpdateMetadataRequest(ctx context.Context, baseUrl Htt
 	g.jsonRespond(w, obj)
 }
 
-func (g *GcsEmu) handleGcsCopy(ctx context.Context, baseUrl HttpBaseUrl, w http.ResponseWriter, b1 string, objectPaths string) {
-	// TODO(dk): this operation supports conditionals and metadata rewriting, but the emulator implementation currently does not.
+func (g *GcsEmu) handleGcsCopy(ctx context.Context, baseUrl HttpBaseUrl, w http.ResponseWriter, r *http.Request, b1 string, objectPaths string) {
+	// TODO(dk): this operation supports conditionals, but the emulator implementation currently does not.
 	// See https://cloud.google.com/storage/docs/json_api/v1/objects/rewrite
 	parts := strings.Split(objectPaths, "/rewriteTo/b/")
 	// Copy is implemented using the Rewrite API, with object strings of format /o/sourceObject/rewriteTo/b/destinationBucket/o/destinationObject
@@ -418,17 +426,37 @@ func (g *GcsEmu) handleGcsCopy(ctx context.Context, baseUrl HttpBaseUrl, w http.
 	b2 := destParts[0]
 	f2 := destParts[1]
 
+	// The request body optionally specifies the metadata of the destination object,
+	// replacing the metadata of the source object.
+	var dstMeta storage.Object
+	if err := json.NewDecoder(r.Body).Decode(&dstMeta); err != nil && err != io.EOF {
+		g.gapiError(w, http.StatusBadRequest, fmt.Sprintf("failed to parse request: %s", err))
+		return
+	}
+
 	// Must lock the destination object.
 	var obj *storage.Object
 	err := g.locks.Run(ctx, lockName(b2, f2), func(ctx context.Context) error {
-		if ok, err := g.store.Copy(b1, f1, b2, f2); err != nil {
+		ok, err := g.store.Copy(b1, f1, b2, f2)
+		if err != nil || !ok {
+			return err // nil if the file is missing
+		}
+		obj, err = g.store.GetMeta(baseUrl, b2, f2)
+		if err != nil || !rewritesMeta(&dstMeta) {
 			return err
-		} else if !ok {
-			return nil // file missing
-		} else {
-			obj, err = g.store.GetMeta(baseUrl, b2, f2)
+		}
+
+		obj.ContentType = dstMeta.ContentType
+		obj.ContentEncoding = dstMeta.ContentEncoding
+		obj.ContentDisposition = dstMeta.ContentDisposition
+		obj.ContentLanguage = dstMeta.ContentLanguage
+		obj.CacheControl = dstMeta.CacheControl
+		obj.Metadata = dstMeta.Metadata
+		if err := g.store.UpdateMeta(b2, f2, obj, obj.Metageneration); err != nil {
 			return err
 		}
+		obj, err = g.store.GetMeta(baseUrl, b2, f2)
+		return err
 	})
 	if err != nil {
 		g.gapiError(w, httpStatusCodeOf(err), fmt.Sprintf("failed to copy: %s", err))
@@ -451,6 +479,13 @@ func (g *GcsEmu) handleGcsCopy(ctx context.Context, baseUrl HttpBaseUrl, w http.
 	g.jsonRespond(w, &rr)
 }
 
+// rewritesMeta reports whether the metadata of a copy request
+// replaces the metadata of the source object.
+func rewritesMeta(meta *storage.Object) bool {
+	return meta.ContentType != "" || meta.ContentEncoding != "" || meta.ContentDisposition != "" ||
+		meta.ContentLanguage != "" || meta.CacheControl != "" || meta.Metadata != nil
+}
+
 type uploadData struct {
 	Object storage.Object
 	Conds  cloudstorage.Conditions
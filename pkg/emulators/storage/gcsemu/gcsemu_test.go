@@ -36,6 +36,7 @@ var (
 		{"Compose", testCompose},
 		{"CopyMetadata", testCopyMetadata},
 		{"CopyConditionals", testCopyConditionals},
+		{"RangeReads", testRangeReads},
 	}
 )
 
@@ -572,8 +573,32 @@ func testCompose(t *testing.T, bh BucketHandle) {
 }
 
 func testCopyMetadata(t *testing.T, bh BucketHandle) {
-	// TODO(dk): Metadata-rewriting on copy is not currently implemented.
-	t.Skip()
+	ctx := context.Background()
+	src := bh.Object("gscemu-test/copy-meta-src.txt")
+	dest := bh.Object("gscemu-test/copy-meta-dest.txt")
+
+	w := src.NewWriter(ctx)
+	w.ContentType = "text/plain"
+	w.Metadata = map[string]string{"a": "1", "b": "2"}
+	assert.NilError(t, write(w, source1), "failed")
+
+	// Without destination attributes, the metadata is copied.
+	attrs, err := dest.CopierFrom(src).Run(ctx)
+	assert.NilError(t, err, "failed")
+	assert.Equal(t, "text/plain", attrs.ContentType, "wrong content type")
+	assert.DeepEqual(t, map[string]string{"a": "1", "b": "2"}, attrs.Metadata)
+
+	// With destination attributes, the metadata is replaced.
+	copier := dest.CopierFrom(src)
+	copier.ContentType = "text/markdown"
+	copier.Metadata = map[string]string{"c": "3"}
+	_, err = copier.Run(ctx)
+	assert.NilError(t, err, "failed")
+
+	attrs, err = dest.Attrs(ctx)
+	assert.NilError(t, err, "failed")
+	assert.Equal(t, "text/markdown", attrs.ContentType, "wrong content type")
+	assert.DeepEqual(t, map[string]string{"c": "3"}, attrs.Metadata)
 }
 
 func testCopyConditionals(t *testing.T, bh BucketHandle) {
@@ -591,3 +616,33 @@ func write(w *storage.Writer, content string) error {
 	}
 	return w.Close()
 }
+
+func testRangeReads(t *testing.T, bh BucketHandle) {
+	const name = "gscemu-test/range.txt"
+	ctx := context.Background()
+	oh := bh.Object(name)
+
+	w := oh.NewWriter(ctx)
+	assert.NilError(t, write(w, v2), "failed")
+
+	for _, tc := range []struct {
+		offset, length int64
+	}{
+		{0, 4},
+		{5, 4},
+		{10, -1},
+		{int64(len(v2)) - 3, 10},
+	} {
+		r, err := oh.NewRangeReader(ctx, tc.offset, tc.length)
+		assert.NilError(t, err, "failed")
+		data, err := io.ReadAll(r)
+		assert.NilError(t, err, "failed")
+		assert.NilError(t, r.Close(), "failed")
+
+		end := int64(len(v2))
+		if tc.length >= 0 {
+			end = min(end, tc.offset+tc.length)
+		}
+		assert.Equal(t, v2[tc.offset:end], string(data), "wrong data")
+	}
+}
@@ -0,0 +1,347 @@
+package httpimport
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"maps"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// pathParam is a path parameter of a route.
+type pathParam struct {
+	name string // the name in the Encore path
+	// key is the name the handler looks the parameter up by,
+	// or "" if the handler reads the request path instead.
+	key      string
+	wildcard bool
+	// fallback is set for parameters matching the whole path,
+	// which become fallback endpoints.
+	fallback bool
+}
+
+// encorePath converts a route path to Encore's syntax,
+// and returns the path parameters it contains.
+func encorePath(fw Framework, path string) (string, []pathParam, error) {
+	if path == "/" && fw == NetHTTP {
+		// The pattern "/" matches all paths.
+		return "/!fallback", []pathParam{{name: "path", fallback: true}}, nil
+	}
+
+	segs := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	var out []string
+	var params []pathParam
+	for i, seg := range segs {
+		last := i == len(segs)-1
+		var param pathParam
+		switch {
+		case seg == "" && last && fw == NetHTTP:
+			// A trailing slash matches the whole subtree.
+			param = pathParam{name: "path", wildcard: true}
+		case seg == "" && last, fw == NetHTTP && seg == "{$}" && last:
+			// A trailing slash only matching itself.
+			continue
+		case seg == "":
+			return "", nil, fmt.Errorf("empty path segment")
+
+		case fw != Gin && strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+			name := seg[1 : len(seg)-1]
+			if fw == Chi {
+				// Drop any regular expression, as in "{id:[0-9]+}".
+				name, _, _ = strings.Cut(name, ":")
+			}
+			if n, ok := strings.CutSuffix(name, "..."); ok && fw == NetHTTP {
+				param = pathParam{name: n, key: n, wildcard: true}
+			} else {
+				param = pathParam{name: name, key: name}
+			}
+		case fw == Chi && seg == "*":
+			param = pathParam{name: "path", key: "*", wildcard: true}
+		case fw == Gin && strings.HasPrefix(seg, ":"):
+			param = pathParam{name: seg[1:], key: seg[1:]}
+		case fw == Gin && strings.HasPrefix(seg, "*"):
+			param = pathParam{name: seg[1:], key: seg[1:], wildcard: true}
+
+		case strings.ContainsAny(seg, "{}:*"):
+			return "", nil, fmt.Errorf("unsupported path segment %q", seg)
+		default:
+			out = append(out, seg)
+			continue
+		}
+
+		if !token.IsIdentifier(param.name) {
+			return "", nil, fmt.Errorf("unsupported path parameter %q", seg)
+		} else if param.wildcard && !last {
+			return "", nil, fmt.Errorf("wildcard %q must be the last path segment", seg)
+		}
+		if param.wildcard && len(out) == 0 {
+			// A wildcard matching all paths.
+			param.fallback = true
+			return "/!fallback", []pathParam{param}, nil
+		}
+		prefix := ":"
+		if param.wildcard {
+			prefix = "*"
+		}
+		out = append(out, prefix+param.name)
+		params = append(params, param)
+	}
+	return "/" + strings.Join(out, "/"), params, nil
+}
+
+// convert decides how to convert each route and names the endpoints.
+func (p *Package) convert() {
+	used := maps.Clone(p.decls)
+	p.serveJSON = "serveJSON"
+	if used[p.serveJSON] {
+		p.serveJSON = "serveImportedJSON"
+	}
+	used[p.serveJSON] = true
+	seen := make(map[string]*Route)
+	for _, r := range p.Routes {
+		if r.Conversion != Manual {
+			r.Conversion = Raw
+			if r.Reason = p.typed(r); r.Reason == "" {
+				r.Conversion = Typed
+			}
+		}
+		r.Endpoint = endpointName(r, used)
+
+		key := r.Method + " " + r.EncorePath
+		if prev, ok := seen[key]; ok {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("%s and %s are both registered at %s; remove one of them",
+				prev.Endpoint, r.Endpoint, strings.TrimSpace(key)))
+		}
+		seen[key] = r
+	}
+}
+
+// typed determines whether the route can be converted to a typed endpoint.
+// If not it returns the reason why.
+func (p *Package) typed(r *Route) string {
+	switch {
+	case r.Framework == Gin:
+		return "gin handlers are wrapped as raw endpoints"
+	case r.Method == "":
+		return "the route matches any method"
+	case len(r.params) > 0:
+		return "the route has path parameters"
+	}
+
+	fn := p.funcs[r.handlerFunc]
+	req, resp, reason := p.jsonTypes(fn)
+	if reason != "" {
+		return reason
+	}
+	if req != "" && r.Method != "POST" && r.Method != "PUT" && r.Method != "PATCH" {
+		return fmt.Sprintf("the handler reads a JSON body in %s requests", r.Method)
+	}
+	r.reqType, r.respType = req, resp
+	return ""
+}
+
+// jsonTypes determines the request and response types of a handler that
+// decodes its request from JSON and encodes its response as JSON.
+// If it can't, it returns the reason why.
+func (p *Package) jsonTypes(fn *ast.FuncDecl) (req, resp string, reason string) {
+	f := p.funcFiles[fn.Name.Name]
+	json := ""
+	for _, imp := range f.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path == "encoding/json" {
+			json = "json"
+			if imp.Name != nil {
+				json = imp.Name.Name
+			}
+		}
+	}
+	params := paramNames(fn.Type)
+	if json == "" || len(params) != 2 {
+		return "", "", "the handler doesn't use JSON"
+	}
+	w, r := params[0], params[1]
+
+	// typeOf reports the named struct type of an expression, if known.
+	locals := make(map[string]string)
+	typeOf := func(expr ast.Expr) string {
+		if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+			expr = u.X
+		}
+		var name string
+		switch e := expr.(type) {
+		case *ast.Ident:
+			name = locals[e.Name]
+		case *ast.CompositeLit:
+			if id, ok := e.Type.(*ast.Ident); ok {
+				name = id.Name
+			}
+		case *ast.CallExpr:
+			// new(T)
+			if id, ok := e.Fun.(*ast.Ident); ok && id.Name == "new" && len(e.Args) == 1 {
+				if t, ok := e.Args[0].(*ast.Ident); ok {
+					name = t.Name
+				}
+			}
+		}
+		if p.types[name] {
+			return name
+		}
+		return ""
+	}
+	// coder reports whether call is json.<newFn>(<arg>).<method>(...).
+	coder := func(call *ast.CallExpr, method, newFn string, arg func(ast.Expr) bool) bool {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != method || len(call.Args) != 1 {
+			return false
+		}
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok || len(inner.Args) != 1 {
+			return false
+		}
+		innerSel, ok := inner.Fun.(*ast.SelectorExpr)
+		if !ok || innerSel.Sel.Name != newFn {
+			return false
+		}
+		pkg, ok := innerSel.X.(*ast.Ident)
+		return ok && pkg.Name == json && arg(inner.Args[0])
+	}
+	isIdent := func(name string) func(ast.Expr) bool {
+		return func(expr ast.Expr) bool {
+			id, ok := expr.(*ast.Ident)
+			return ok && id.Name == name
+		}
+	}
+	isBody := func(expr ast.Expr) bool {
+		sel, ok := expr.(*ast.SelectorExpr)
+		return ok && sel.Sel.Name == "Body" && isIdent(r)(sel.X)
+	}
+
+	var decodes, encodes []string
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if reason != "" {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if id, ok := n.Type.(*ast.Ident); ok {
+					locals[name.Name] = id.Name
+				} else if i < len(n.Values) {
+					locals[name.Name] = typeOf(n.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE && len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						locals[id.Name] = typeOf(n.Rhs[i])
+					}
+				}
+			}
+		case *ast.CallExpr:
+			if coder(n, "Decode", "NewDecoder", isBody) {
+				decodes = append(decodes, typeOf(n.Args[0]))
+			} else if coder(n, "Encode", "NewEncoder", isIdent(w)) {
+				encodes = append(encodes, typeOf(n.Args[0]))
+			}
+		case *ast.SelectorExpr:
+			// The typed endpoint only passes on the request body, headers and context.
+			if isIdent(r)(n.X) && n.Sel.Name != "Body" && n.Sel.Name != "Header" && n.Sel.Name != "Context" {
+				reason = fmt.Sprintf("the handler uses %s.%s", r, n.Sel.Name)
+			}
+		}
+		return true
+	})
+
+	switch {
+	case reason != "":
+		return "", "", reason
+	case len(decodes) == 0 && len(encodes) == 0:
+		return "", "", "the handler doesn't use JSON"
+	case len(decodes) > 1:
+		return "", "", "the handler decodes more than one JSON request"
+	}
+	if len(decodes) == 1 {
+		if req = decodes[0]; req == "" {
+			return "", "", "the handler's request isn't a named struct type"
+		}
+	}
+	for _, typ := range encodes {
+		if typ == "" {
+			return "", "", "the handler's response isn't a named struct type"
+		} else if resp != "" && typ != resp {
+			return "", "", "the handler encodes responses of more than one type"
+		}
+		resp = typ
+	}
+	return req, resp, ""
+}
+
+// paramNames returns the names of a function's parameters.
+func paramNames(typ *ast.FuncType) []string {
+	var names []string
+	for _, field := range typ.Params.List {
+		if len(field.Names) == 0 {
+			names = append(names, "_")
+		}
+		for _, n := range field.Names {
+			names = append(names, n.Name)
+		}
+	}
+	return names
+}
+
+// endpointName names the endpoint of a route, avoiding the names in used.
+func endpointName(r *Route, used map[string]bool) string {
+	var base string
+	if r.handlerFunc != "" {
+		base = r.handlerFunc
+		for _, prefix := range []string{"handle", "Handle"} {
+			if rest, ok := strings.CutPrefix(base, prefix); ok && rest != "" && unicode.IsUpper(rune(rest[0])) {
+				base = rest
+			}
+		}
+		if rest, ok := strings.CutSuffix(base, "Handler"); ok && rest != "" {
+			base = rest
+		}
+		base = title(base)
+	} else {
+		// Name the endpoint after the route, as in "GetUsersByID".
+		method := strings.ToLower(r.Method)
+		if method == "" {
+			method = "any"
+		}
+		words := []string{title(method)}
+		for _, seg := range strings.Split(r.EncorePath, "/") {
+			by := strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*")
+			for _, w := range strings.FieldsFunc(seg, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+				if by {
+					words, by = append(words, "By"), false
+				}
+				words = append(words, title(w))
+			}
+		}
+		base = strings.Join(words, "")
+	}
+	if base == "" || !unicode.IsLetter(rune(base[0])) {
+		base = "Endpoint" + base
+	}
+
+	name := base
+	if used[name] {
+		name = base + "Endpoint"
+	}
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%sEndpoint%d", base, i)
+	}
+	used[name] = true
+	return name
+}
+
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,234 @@
+package httpimport
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GeneratedFile is the name of the file the endpoints
+// are generated to, in the service directory.
+const GeneratedFile = "encore_endpoints.go"
+
+// generatedFile is ignored when analyzing packages,
+// so importing again regenerates the same endpoints.
+const generatedFile = GeneratedFile
+
+// ServiceName returns the name of the package of the generated service.
+func (p *Package) ServiceName() string {
+	if p.Name == "main" {
+		return path.Base(p.ServiceDir)
+	}
+	return p.Name
+}
+
+// Generate generates the Go source of the Encore endpoints for the package's
+// routes, to be written to GeneratedFile in the package's ServiceDir.
+func (p *Package) Generate() ([]byte, error) {
+	imports := map[string]string{"net/http": ""}
+	var body bytes.Buffer
+	typed := false
+	for _, r := range p.Routes {
+		switch r.Conversion {
+		case Typed:
+			typed = true
+			p.genTyped(&body, r, imports)
+		case Raw:
+			p.genRaw(&body, r, imports)
+		case Manual:
+			p.genManual(&body, r)
+		}
+	}
+	if typed {
+		p.genServeJSON(&body, imports)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// This file was generated by encore import and can be edited freely.\n\n")
+	fmt.Fprintf(&buf, "// Service %s serves the routes of the existing HTTP handlers\n", p.ServiceName())
+	fmt.Fprintf(&buf, "// registered in %s.\n", pathOrRoot(p.Dir))
+	fmt.Fprintf(&buf, "package %s\n\n", p.ServiceName())
+	// Group the standard library imports first.
+	var std, other []string
+	for path := range imports {
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+	buf.WriteString("import (\n")
+	for i, group := range [][]string{std, other} {
+		if i > 0 && len(group) > 0 {
+			buf.WriteString("\n")
+		}
+		for _, path := range group {
+			fmt.Fprintf(&buf, "\t%s %s\n", imports[path], strconv.Quote(path))
+		}
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %v", err)
+	}
+	return src, nil
+}
+
+// directive returns the //encore:api directive of a raw or typed endpoint.
+func directive(r *Route, raw bool) string {
+	method := r.Method
+	if method == "" || (len(r.params) == 1 && r.params[0].fallback) {
+		method = "*"
+	}
+	s := "//encore:api public"
+	if raw {
+		s += " raw"
+	}
+	return s + " method=" + method + " path=" + r.EncorePath
+}
+
+// registered describes where the route was registered.
+func registered(r *Route) string {
+	method := r.Method
+	if method == "" {
+		method = "any method"
+	}
+	return fmt.Sprintf("%s:%d as %s %s", filepath.Base(r.Pos.Filename), r.Pos.Line, method, r.Path)
+}
+
+func (p *Package) genTyped(buf *bytes.Buffer, r *Route, imports map[string]string) {
+	imports["context"] = ""
+
+	params := "ctx context.Context"
+	reqArg := "nil"
+	if r.reqType != "" {
+		params += ", p *" + r.reqType
+		reqArg = "p"
+	}
+
+	fmt.Fprintf(buf, "\n// %s wraps %s, registered at %s.\n//\n%s\n", r.Endpoint, r.handlerFunc, registered(r), directive(r, false))
+	if r.respType == "" {
+		fmt.Fprintf(buf, "func %s(%s) error {\n", r.Endpoint, params)
+		fmt.Fprintf(buf, "\treturn %s(ctx, %s, %q, %q, %s, nil)\n}\n", p.serveJSON, r.handlerFunc, r.Method, r.Path, reqArg)
+		return
+	}
+	fmt.Fprintf(buf, "func %s(%s) (*%s, error) {\n", r.Endpoint, params, r.respType)
+	fmt.Fprintf(buf, "\tresp := new(%s)\n", r.respType)
+	fmt.Fprintf(buf, "\tif err := %s(ctx, %s, %q, %q, %s, resp); err != nil {\n\t\treturn nil, err\n\t}\n", p.serveJSON, r.handlerFunc, r.Method, r.Path, reqArg)
+	buf.WriteString("\treturn resp, nil\n}\n")
+}
+
+func (p *Package) genRaw(buf *bytes.Buffer, r *Route, imports map[string]string) {
+	fmt.Fprintf(buf, "\n// %s wraps %s, registered at %s.\n//\n%s\n", r.Endpoint, r.handlerFunc, registered(r), directive(r, true))
+	fmt.Fprintf(buf, "func %s(w http.ResponseWriter, req *http.Request) {\n", r.Endpoint)
+
+	// paramValue returns the expression of a path parameter's value.
+	paramValue := func(param pathParam) string {
+		imports["encore.dev"] = ""
+		if param.fallback {
+			imports["strings"] = ""
+			return `strings.TrimPrefix(req.URL.Path, "/")`
+		}
+		return fmt.Sprintf("encore.CurrentRequest().PathParams.Get(%q)", param.name)
+	}
+
+	switch r.Framework {
+	case NetHTTP:
+		for _, param := range r.params {
+			if param.key != "" {
+				fmt.Fprintf(buf, "\treq.SetPathValue(%q, %s)\n", param.key, paramValue(param))
+			}
+		}
+		fmt.Fprintf(buf, "\t%s(w, req)\n", r.handlerFunc)
+
+	case Chi:
+		if len(r.params) > 0 {
+			imports["context"] = ""
+			imports[r.importPath] = "chi"
+			buf.WriteString("\trctx := chi.NewRouteContext()\n")
+			for _, param := range r.params {
+				fmt.Fprintf(buf, "\trctx.URLParams.Add(%q, %s)\n", param.key, paramValue(param))
+			}
+			buf.WriteString("\treq = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))\n")
+		}
+		fmt.Fprintf(buf, "\t%s(w, req)\n", r.handlerFunc)
+
+	case Gin:
+		imports[r.importPath] = ""
+		buf.WriteString("\tc, _ := gin.CreateTestContext(w)\n")
+		buf.WriteString("\tc.Request = req\n")
+		for _, param := range r.params {
+			value := paramValue(param)
+			if param.wildcard {
+				// Gin includes the leading slash in wildcard parameters.
+				value = `"/" + ` + value
+			}
+			fmt.Fprintf(buf, "\tc.Params = append(c.Params, gin.Param{Key: %q, Value: %s})\n", param.key, value)
+		}
+		fmt.Fprintf(buf, "\t%s(c)\n", r.handlerFunc)
+		buf.WriteString("\tc.Writer.WriteHeaderNow()\n")
+	}
+	buf.WriteString("}\n")
+}
+
+func (p *Package) genManual(buf *bytes.Buffer, r *Route) {
+	fmt.Fprintf(buf, "\n// %s serves the route registered at %s.\n//\n", r.Endpoint, registered(r))
+	fmt.Fprintf(buf, "// TODO: call the existing handler, %s, which couldn't be\n", r.Handler)
+	fmt.Fprintf(buf, "// wrapped automatically because %s.\n//\n", r.Reason)
+	fmt.Fprintf(buf, "%s\n", directive(r, true))
+	fmt.Fprintf(buf, "func %s(w http.ResponseWriter, req *http.Request) {\n", r.Endpoint)
+	buf.WriteString("\thttp.Error(w, \"not implemented\", http.StatusNotImplemented)\n}\n")
+}
+
+// genServeJSON generates the helper typed endpoints use to call handlers.
+func (p *Package) genServeJSON(buf *bytes.Buffer, imports map[string]string) {
+	for _, path := range []string{"bytes", "context", "encoding/json", "net/http/httptest", "strings", "encore.dev", "encore.dev/beta/errs"} {
+		imports[path] = ""
+	}
+	fmt.Fprintf(buf, `
+// %[1]s calls the handler h with a request with the JSON-encoded req,
+// if any, and decodes its JSON response into resp, if any.
+func %[1]s(ctx context.Context, h http.HandlerFunc, method, path string, req, resp any) error {
+	var body bytes.Buffer
+	if req != nil {
+		if err := json.NewEncoder(&body).Encode(req); err != nil {
+			return err
+		}
+	}
+	r, err := http.NewRequestWithContext(ctx, method, path, &body)
+	if err != nil {
+		return err
+	}
+	r.Header = encore.CurrentRequest().Headers.Clone()
+	if r.Header == nil {
+		r.Header = make(http.Header)
+	}
+	r.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	h(w, r)
+	if w.Code >= 400 {
+		return &errs.Error{Code: errs.HTTPStatusToCode(w.Code), Message: strings.TrimSpace(w.Body.String())}
+	} else if resp != nil {
+		return json.Unmarshal(w.Body.Bytes(), resp)
+	}
+	return nil
+}
+`, p.serveJSON)
+}
+
+func pathOrRoot(dir string) string {
+	if dir == "." {
+		return "the root directory"
+	}
+	return strings.TrimSuffix(dir, "/")
+}
@@ -0,0 +1,260 @@
+// Package httpimport analyzes existing Go HTTP services built with net/http,
+// chi or gin, and generates Encore endpoints for the routes they register.
+//
+// Each route is converted in the most precise way the analysis allows:
+// handlers that decode and encode named JSON types are converted to typed
+// endpoints, other handler functions are wrapped as raw endpoints, and
+// handlers that can't be referenced from generated code, such as function
+// literals and method values, get a raw endpoint to wire up by hand.
+//
+// The analysis is purely syntactic, so it works without the code's
+// dependencies being available, at the cost of only recognizing routes
+// registered in the common, direct ways.
+package httpimport
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Framework is the HTTP framework a route is registered with.
+type Framework string
+
+const (
+	NetHTTP Framework = "net/http"
+	Chi     Framework = "chi"
+	Gin     Framework = "gin"
+)
+
+// Conversion describes how a route is converted to an Encore endpoint.
+type Conversion string
+
+const (
+	// Typed routes are converted to typed endpoints, which call the
+	// existing handler with the JSON-encoded request.
+	Typed Conversion = "typed"
+	// Raw routes are converted to raw endpoints wrapping the existing handler.
+	Raw Conversion = "raw"
+	// Manual routes are converted to raw endpoints that must be
+	// wired up to the existing handler by hand.
+	Manual Conversion = "manual"
+)
+
+// Package is a Go package that registers HTTP routes,
+// which becomes an Encore service.
+type Package struct {
+	// Dir is the directory of the package, relative to the analyzed root.
+	Dir string
+	// Name is the name of the package.
+	Name string
+	// ServiceDir is the directory of the generated service, relative to the
+	// analyzed root. It's Dir unless the package is a main package,
+	// which can't be a service.
+	ServiceDir string
+
+	Routes []*Route
+
+	// Warnings describe things the generated endpoints don't carry over,
+	// such as middleware, and routes that couldn't be analyzed.
+	Warnings []string
+
+	serveJSON string // the name of the generated helper typed endpoints use
+
+	decls     map[string]bool          // names of the package's top-level declarations
+	funcs     map[string]*ast.FuncDecl // top-level functions, by name
+	funcFiles map[string]*ast.File     // the files declaring funcs
+	types     map[string]bool          // names of the package's struct types
+	fset      *token.FileSet
+	src       map[*ast.File][]byte
+}
+
+// Route is an HTTP route registered by a package.
+type Route struct {
+	Framework Framework
+	// Method is the HTTP method of the route, or "" if it matches any method.
+	Method string
+	// Path is the path of the route as registered, including any prefixes
+	// of the router it's registered on.
+	Path string
+	// EncorePath is the path in Encore's syntax, such as "/users/:id".
+	EncorePath string
+	// Handler is the source of the handler expression.
+	Handler string
+	// Pos is where the route is registered.
+	Pos token.Position
+
+	// Endpoint is the name of the generated endpoint.
+	Endpoint   string
+	Conversion Conversion
+	// Reason describes why the route wasn't converted to a typed endpoint,
+	// or for manual routes, why the handler couldn't be wrapped.
+	Reason string
+
+	params      []pathParam
+	handlerFunc string // the wrapped top-level handler function
+	reqType     string // the typed endpoint's request type, if any
+	respType    string // the typed endpoint's response type, if any
+	importPath  string // the import path of the framework, for chi and gin
+}
+
+// Analyze analyzes the Go packages in root and its subdirectories,
+// and returns the packages that register HTTP routes.
+func Analyze(root string) ([]*Package, error) {
+	var pkgs []*Package
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+			name == "vendor" || name == "testdata" || name == "node_modules") {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		pkg, err := analyzeDir(path, filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		} else if pkg != nil {
+			pkgs = append(pkgs, pkg)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// analyzeDir analyzes the package in dir, if any.
+// It returns nil if the package doesn't register any routes.
+func analyzeDir(dir, rel string) (*Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &Package{
+		Dir:       rel,
+		decls:     make(map[string]bool),
+		funcs:     make(map[string]*ast.FuncDecl),
+		funcFiles: make(map[string]*ast.File),
+		types:     make(map[string]bool),
+		fset:      token.NewFileSet(),
+		src:       make(map[*ast.File][]byte),
+	}
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == generatedFile {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(pkg.fset, filepath.Join(dir, name), src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if pkg.Name == "" {
+			pkg.Name = f.Name.Name
+		} else if f.Name.Name != pkg.Name {
+			// Ignore files of other packages, such as ones excluded by build tags.
+			continue
+		}
+		pkg.src[f] = src
+		files = append(files, f)
+		pkg.collectDecls(f)
+	}
+
+	for _, f := range files {
+		newScanner(pkg, f).scanFile()
+	}
+	if len(pkg.Routes) == 0 {
+		return nil, nil
+	}
+
+	pkg.ServiceDir = pkg.Dir
+	if pkg.Name == "main" {
+		pkg.ServiceDir = pathJoin(pkg.Dir, "api")
+	}
+	slices.SortStableFunc(pkg.Routes, func(a, b *Route) int {
+		return cmp.Or(cmp.Compare(a.Pos.Filename, b.Pos.Filename), cmp.Compare(a.Pos.Offset, b.Pos.Offset))
+	})
+	pkg.convert()
+	return pkg, nil
+}
+
+// collectDecls records the top-level declarations of f.
+func (p *Package) collectDecls(f *ast.File) {
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				p.decls[d.Name.Name] = true
+				p.funcs[d.Name.Name] = d
+				p.funcFiles[d.Name.Name] = f
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					p.decls[s.Name.Name] = true
+					if _, ok := s.Type.(*ast.StructType); ok && s.TypeParams == nil {
+						p.types[s.Name.Name] = true
+					}
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						p.decls[n.Name] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+// source returns the source of n in f, on a single line.
+func (p *Package) source(f *ast.File, n ast.Node) string {
+	src := p.src[f]
+	start, end := p.fset.Position(n.Pos()).Offset, p.fset.Position(n.End()).Offset
+	if start < 0 || end > len(src) || start > end {
+		return ""
+	}
+	s := strings.Join(strings.Fields(string(src[start:end])), " ")
+	if len(s) > 60 {
+		s = s[:57] + "..."
+	}
+	return s
+}
+
+func (p *Package) warnf(pos token.Pos, format string, args ...any) {
+	position := p.fset.Position(pos)
+	msg := fmt.Sprintf(format, args...)
+	p.Warnings = append(p.Warnings, fmt.Sprintf("%s:%d: %s", filepath.Base(position.Filename), position.Line, msg))
+}
+
+// pathJoin joins a router prefix and a path.
+func pathJoin(prefix, path string) string {
+	if prefix == "" || prefix == "." {
+		return path
+	}
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return strings.TrimSuffix(prefix, "/")
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + path
+}
@@ -0,0 +1,295 @@
+package httpimport
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// writeFiles writes the given files, keyed by their path, to a temporary directory.
+func writeFiles(c *qt.C, files map[string]string) string {
+	dir := c.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0755), qt.IsNil)
+		c.Assert(os.WriteFile(path, []byte(content), 0644), qt.IsNil)
+	}
+	return dir
+}
+
+type routeSummary struct {
+	Method, Path, EncorePath, Endpoint string
+	Conversion                         Conversion
+}
+
+func summarize(routes []*Route) []routeSummary {
+	var res []routeSummary
+	for _, r := range routes {
+		res = append(res, routeSummary{r.Method, r.Path, r.EncorePath, r.Endpoint, r.Conversion})
+	}
+	return res
+}
+
+func TestAnalyze_NetHTTP(t *testing.T) {
+	c := qt.New(t)
+	dir := writeFiles(c, map[string]string{
+		"users/users.go": `package users
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type User struct {
+	ID   string ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /users", handleCreateUser)
+	mux.HandleFunc("GET /users/{id}", getUser)
+	mux.Handle("/static/", http.HandlerFunc(static))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+}
+
+func handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(&User{ID: "1", Name: req.Name})
+}
+
+func getUser(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(User{ID: r.PathValue("id")})
+}
+
+func static(w http.ResponseWriter, r *http.Request) {}
+`,
+	})
+
+	pkgs, err := Analyze(dir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pkgs, qt.HasLen, 1)
+	pkg := pkgs[0]
+	c.Assert(pkg.Dir, qt.Equals, "users")
+	c.Assert(pkg.ServiceName(), qt.Equals, "users")
+	c.Assert(summarize(pkg.Routes), qt.DeepEquals, []routeSummary{
+		{"POST", "/users", "/users", "CreateUser", Typed},
+		{"GET", "/users/{id}", "/users/:id", "GetUser", Raw},
+		{"", "/static/", "/static/*path", "Static", Raw},
+		{"", "/", "/!fallback", "AnyFallback", Manual},
+	})
+	c.Assert(pkg.Routes[1].Reason, qt.Equals, "the route has path parameters")
+	c.Assert(pkg.Routes[3].Reason, qt.Equals, "the handler is a function literal")
+
+	src, err := pkg.Generate()
+	c.Assert(err, qt.IsNil)
+	for _, want := range []string{
+		"package users",
+		"//encore:api public method=POST path=/users\nfunc CreateUser(ctx context.Context, p *CreateUserRequest) (*User, error) {",
+		`serveJSON(ctx, handleCreateUser, "POST", "/users", p, resp)`,
+		"//encore:api public raw method=GET path=/users/:id\nfunc GetUser(w http.ResponseWriter, req *http.Request) {",
+		`req.SetPathValue("id", encore.CurrentRequest().PathParams.Get("id"))`,
+		"//encore:api public raw method=* path=/static/*path\n",
+		"//encore:api public raw method=* path=/!fallback\n",
+		"// TODO: call the existing handler, func(w http.ResponseWriter, r *http.Request) {}, which couldn't be",
+	} {
+		c.Assert(string(src), qt.Contains, want)
+	}
+}
+
+func TestAnalyze_Chi(t *testing.T) {
+	c := qt.New(t)
+	dir := writeFiles(c, map[string]string{
+		"go.mod": "module example.com/app\n",
+		"server.go": `package server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func NewRouter(s *Server) http.Handler {
+	r := chi.NewRouter()
+	r.Use(middleware.Logger)
+	r.Route("/orders", func(r chi.Router) {
+		r.Get("/", listOrders)
+		r.Get("/{orderID:[0-9]+}", getOrder)
+		r.Post("/", s.createOrder)
+	})
+	r.Get("/health", health)
+	r.Mount("/admin", adminRouter())
+	return r
+}
+
+type Server struct{}
+
+func (s *Server) createOrder(w http.ResponseWriter, r *http.Request) {}
+func listOrders(w http.ResponseWriter, r *http.Request)             {}
+func getOrder(w http.ResponseWriter, r *http.Request)               {}
+func health(w http.ResponseWriter, r *http.Request)                 {}
+func adminRouter() http.Handler                                     { return nil }
+`,
+	})
+
+	pkgs, err := Analyze(dir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pkgs, qt.HasLen, 1)
+	pkg := pkgs[0]
+	c.Assert(summarize(pkg.Routes), qt.DeepEquals, []routeSummary{
+		{"GET", "/orders", "/orders", "ListOrders", Raw},
+		{"GET", "/orders/{orderID:[0-9]+}", "/orders/:orderID", "GetOrder", Raw},
+		{"POST", "/orders", "/orders", "PostOrders", Manual},
+		{"GET", "/health", "/health", "Health", Raw},
+		{"", "/admin/*", "/admin/*path", "AnyAdminByPath", Manual},
+	})
+	c.Assert(pkg.Routes[2].Reason, qt.Equals, "the handler is a method value or in another package")
+	c.Assert(pkg.Warnings, qt.HasLen, 1)
+	c.Assert(pkg.Warnings[0], qt.Contains, "middleware r.Use(middleware.Logger) isn't applied")
+
+	src, err := pkg.Generate()
+	c.Assert(err, qt.IsNil)
+	for _, want := range []string{
+		`chi "github.com/go-chi/chi/v5"`,
+		`rctx.URLParams.Add("orderID", encore.CurrentRequest().PathParams.Get("orderID"))`,
+		"req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))\n\tgetOrder(w, req)",
+	} {
+		c.Assert(string(src), qt.Contains, want)
+	}
+}
+
+func TestAnalyze_Gin(t *testing.T) {
+	c := qt.New(t)
+	dir := writeFiles(c, map[string]string{
+		"main.go": `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	v1 := r.Group("/v1")
+	{
+		v1.GET("/items/:id", auth, getItem)
+		v1.GET("/files/*filepath", serveFile)
+	}
+	r.Run()
+}
+
+func auth(c *gin.Context)      {}
+func getItem(c *gin.Context)   {}
+func serveFile(c *gin.Context) {}
+`,
+		"api/api.go": `package api
+
+import "github.com/gin-gonic/gin"
+
+func Routes(r gin.IRouter) {
+	r.GET("/files/*filepath", serveFile)
+}
+
+func serveFile(c *gin.Context) {}
+`,
+	})
+
+	pkgs, err := Analyze(dir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pkgs, qt.HasLen, 2)
+
+	// Handlers in package main can't be wrapped.
+	main := pkgs[0]
+	c.Assert(main.Dir, qt.Equals, ".")
+	c.Assert(main.ServiceDir, qt.Equals, "api")
+	c.Assert(main.ServiceName(), qt.Equals, "api")
+	c.Assert(summarize(main.Routes), qt.DeepEquals, []routeSummary{
+		{"GET", "/v1/items/:id", "/v1/items/:id", "GetV1ItemsById", Manual},
+		{"GET", "/v1/files/*filepath", "/v1/files/*filepath", "GetV1FilesByFilepath", Manual},
+	})
+	c.Assert(main.Warnings, qt.HasLen, 1)
+	c.Assert(main.Warnings[0], qt.Contains, "route middleware isn't applied")
+
+	api := pkgs[1]
+	c.Assert(summarize(api.Routes), qt.DeepEquals, []routeSummary{
+		{"GET", "/files/*filepath", "/files/*filepath", "ServeFile", Raw},
+	})
+	src, err := api.Generate()
+	c.Assert(err, qt.IsNil)
+	for _, want := range []string{
+		"c, _ := gin.CreateTestContext(w)",
+		`c.Params = append(c.Params, gin.Param{Key: "filepath", Value: "/" + encore.CurrentRequest().PathParams.Get("filepath")})`,
+		"serveFile(c)\n\tc.Writer.WriteHeaderNow()",
+	} {
+		c.Assert(string(src), qt.Contains, want)
+	}
+}
+
+func TestEncorePath(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		fw      Framework
+		path    string
+		want    string
+		wantErr string
+	}{
+		{NetHTTP, "/users/{id}", "/users/:id", ""},
+		{NetHTTP, "/files/{path...}", "/files/*path", ""},
+		{NetHTTP, "/users/{$}", "/users", ""},
+		{NetHTTP, "/{$}", "/", ""},
+		{NetHTTP, "/", "/!fallback", ""},
+		{NetHTTP, "/files/{path...}/x", "", "must be the last path segment"},
+		{NetHTTP, "/users/file-{id}", "", "unsupported path segment"},
+		{Chi, "/users/{id:[0-9]+}", "/users/:id", ""},
+		{Chi, "/*", "/!fallback", ""},
+		{Chi, "/users/", "/users", ""},
+		{Gin, "/users/:id/*rest", "/users/:id/*rest", ""},
+		{Gin, "/users/{id}", "", "unsupported path segment"},
+	}
+	for _, test := range tests {
+		got, _, err := encorePath(test.fw, test.path)
+		if test.wantErr != "" {
+			c.Assert(err, qt.ErrorMatches, ".*"+test.wantErr+".*", qt.Commentf("%s %s", test.fw, test.path))
+		} else {
+			c.Assert(err, qt.IsNil, qt.Commentf("%s %s", test.fw, test.path))
+			c.Assert(got, qt.Equals, test.want, qt.Commentf("%s %s", test.fw, test.path))
+		}
+	}
+}
+
+func TestGenerate_Reanalyze(t *testing.T) {
+	c := qt.New(t)
+	dir := writeFiles(c, map[string]string{
+		"svc/svc.go": `package svc
+
+import "net/http"
+
+func Register() {
+	http.HandleFunc("GET /ping", Ping)
+}
+
+func Ping(w http.ResponseWriter, r *http.Request) {}
+`,
+	})
+	pkgs, err := Analyze(dir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pkgs[0].Routes[0].Endpoint, qt.Equals, "PingEndpoint")
+	c.Assert(pkgs[0].Routes[0].Reason, qt.Equals, "the handler doesn't use JSON")
+
+	// The generated file is ignored when analyzing the package again.
+	src, err := pkgs[0].Generate()
+	c.Assert(err, qt.IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dir, "svc", GeneratedFile), src, 0644), qt.IsNil)
+	pkgs, err = Analyze(dir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(pkgs[0].Routes, qt.HasLen, 1)
+	c.Assert(strings.Count(string(src), "//encore:api"), qt.Equals, 1)
+}
@@ -0,0 +1,447 @@
+package httpimport
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+// router is a router variable that routes are registered on.
+type router struct {
+	fw     Framework
+	prefix string
+}
+
+// scanner finds the routes registered in a file.
+type scanner struct {
+	pkg *Package
+	f   *ast.File
+
+	// The names the framework packages are imported as,
+	// or "" if they're not imported.
+	http, chi, gin   string
+	chiPath, ginPath string
+
+	routers map[string]router // by the source of the expression referring to them
+}
+
+func newScanner(pkg *Package, f *ast.File) *scanner {
+	s := &scanner{pkg: pkg, f: f}
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		var name string
+		switch {
+		case path == "net/http":
+			name = "http"
+		case isChiPath(path):
+			name = "chi"
+			s.chiPath = path
+		case path == "github.com/gin-gonic/gin":
+			name = "gin"
+			s.ginPath = path
+		default:
+			continue
+		}
+		alias := name
+		if imp.Name != nil {
+			alias = imp.Name.Name
+		}
+		switch name {
+		case "http":
+			s.http = alias
+		case "chi":
+			s.chi = alias
+		case "gin":
+			s.gin = alias
+		}
+	}
+	return s
+}
+
+func (s *scanner) scanFile() {
+	if s.http == "" && s.chi == "" && s.gin == "" {
+		return
+	}
+	for _, decl := range s.f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		s.routers = make(map[string]router)
+		s.addParams(fn.Type, "")
+		ast.Inspect(fn.Body, s.visit)
+	}
+}
+
+// addParams adds the router parameters of a function, such as chi.Router.
+func (s *scanner) addParams(typ *ast.FuncType, prefix string) {
+	for _, field := range typ.Params.List {
+		fw, ok := s.routerType(field.Type)
+		if !ok {
+			continue
+		}
+		for _, name := range field.Names {
+			s.routers[name.Name] = router{fw: fw, prefix: prefix}
+		}
+	}
+}
+
+// routerType reports the framework of a router type, such as *http.ServeMux.
+func (s *scanner) routerType(expr ast.Expr) (Framework, bool) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	pkg, name, ok := s.qualified(expr)
+	switch {
+	case !ok:
+		return "", false
+	case pkg == s.http && name == "ServeMux":
+		return NetHTTP, true
+	case pkg == s.chi && (name == "Router" || name == "Mux"):
+		return Chi, true
+	case pkg == s.gin && (name == "Engine" || name == "RouterGroup" || name == "IRouter" || name == "IRoutes"):
+		return Gin, true
+	}
+	return "", false
+}
+
+// qualified reports the package and name of a qualified identifier, such as http.ServeMux.
+func (s *scanner) qualified(expr ast.Expr) (pkg, name string, ok bool) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	id, ok := sel.X.(*ast.Ident)
+	if !ok || id.Name == "" {
+		return "", "", false
+	}
+	return id.Name, sel.Sel.Name, true
+}
+
+func (s *scanner) visit(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if len(n.Lhs) == 1 && len(n.Rhs) == 1 {
+			s.assign(n.Lhs[0], n.Rhs[0])
+		}
+	case *ast.ValueSpec:
+		if len(n.Names) == 1 && len(n.Values) == 1 {
+			s.assign(n.Names[0], n.Values[0])
+		}
+	case *ast.CallExpr:
+		return s.call(n)
+	}
+	return true
+}
+
+// assign records routers assigned to variables.
+func (s *scanner) assign(lhs, rhs ast.Expr) {
+	if r, ok := s.routerValue(rhs); ok {
+		s.routers[exprKey(lhs)] = r
+	}
+}
+
+// routerValue reports whether expr evaluates to a router, such as chi.NewRouter().
+func (s *scanner) routerValue(expr ast.Expr) (router, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		if r, ok := s.routers[exprKey(expr)]; ok {
+			return r, true
+		}
+		return router{}, false
+	}
+
+	if pkg, name, ok := s.qualified(call.Fun); ok {
+		switch {
+		case pkg == s.http && name == "NewServeMux":
+			return router{fw: NetHTTP}, true
+		case pkg == s.chi && (name == "NewRouter" || name == "NewMux"):
+			return router{fw: Chi}, true
+		case pkg == s.gin && (name == "New" || name == "Default"):
+			return router{fw: Gin}, true
+		}
+	}
+
+	// Routers derived from other routers, such as gin route groups.
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return router{}, false
+	}
+	parent, ok := s.routers[exprKey(sel.X)]
+	if !ok {
+		return router{}, false
+	}
+	switch {
+	case parent.fw == Gin && sel.Sel.Name == "Group" && len(call.Args) >= 1:
+		if p, ok := stringLit(call.Args[0]); ok {
+			return router{fw: Gin, prefix: pathJoin(parent.prefix, p)}, true
+		}
+	case parent.fw == Chi && (sel.Sel.Name == "With" || sel.Sel.Name == "Group"):
+		return parent, true
+	case parent.fw == Chi && sel.Sel.Name == "Route" && len(call.Args) >= 1:
+		if p, ok := stringLit(call.Args[0]); ok {
+			return router{fw: Chi, prefix: pathJoin(parent.prefix, p)}, true
+		}
+	}
+	return router{}, false
+}
+
+var chiMethods = map[string]string{
+	"Get": "GET", "Post": "POST", "Put": "PUT", "Patch": "PATCH", "Delete": "DELETE",
+	"Head": "HEAD", "Options": "OPTIONS", "Connect": "CONNECT", "Trace": "TRACE",
+}
+
+var ginMethods = map[string]string{
+	"GET": "GET", "POST": "POST", "PUT": "PUT", "PATCH": "PATCH", "DELETE": "DELETE",
+	"HEAD": "HEAD", "OPTIONS": "OPTIONS", "Any": "",
+}
+
+// call records the routes registered by a call, if any.
+// It reports whether to continue scanning the call's arguments.
+func (s *scanner) call(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return true
+	}
+	method := sel.Sel.Name
+
+	// Routes registered on http.DefaultServeMux.
+	if id, ok := sel.X.(*ast.Ident); ok && id.Name == s.http && (method == "HandleFunc" || method == "Handle") {
+		s.netHTTPRoute(call, router{fw: NetHTTP})
+		return true
+	}
+
+	r, ok := s.routers[exprKey(sel.X)]
+	if !ok {
+		return true
+	}
+	if method == "Use" {
+		s.pkg.warnf(call.Pos(), "middleware %s isn't applied to the generated endpoints", s.pkg.source(s.f, call))
+		return true
+	}
+
+	switch r.fw {
+	case NetHTTP:
+		if method == "HandleFunc" || method == "Handle" {
+			s.netHTTPRoute(call, r)
+		}
+
+	case Chi:
+		switch {
+		case chiMethods[method] != "" && len(call.Args) == 2:
+			s.route(call, r, chiMethods[method], call.Args[0], call.Args[1])
+		case (method == "Handle" || method == "HandleFunc") && len(call.Args) == 2:
+			s.route(call, r, "", call.Args[0], call.Args[1])
+		case (method == "Method" || method == "MethodFunc") && len(call.Args) == 3:
+			m, ok := stringLit(call.Args[0])
+			if !ok {
+				s.pkg.warnf(call.Pos(), "skipped route with non-constant method: %s", s.pkg.source(s.f, call))
+				return true
+			}
+			s.route(call, r, strings.ToUpper(m), call.Args[1], call.Args[2])
+		case method == "Mount" && len(call.Args) == 2:
+			p, ok := stringLit(call.Args[0])
+			if !ok {
+				s.pkg.warnf(call.Pos(), "skipped route with non-constant path: %s", s.pkg.source(s.f, call))
+				return true
+			}
+			s.addRoute(call, r, "", pathJoin(p, "*"), call.Args[1])
+		case (method == "Route" || method == "Group") && len(call.Args) >= 1:
+			// Routes registered within the function are prefixed by the path, if any.
+			prefix := r.prefix
+			if method == "Route" {
+				p, ok := stringLit(call.Args[0])
+				if !ok {
+					s.pkg.warnf(call.Pos(), "skipped routes with non-constant path prefix: %s", s.pkg.source(s.f, call))
+					return false
+				}
+				prefix = pathJoin(prefix, p)
+			}
+			if fn, ok := call.Args[len(call.Args)-1].(*ast.FuncLit); ok {
+				// Scan the function with its router parameter in scope.
+				outer := maps.Clone(s.routers)
+				s.addParams(fn.Type, prefix)
+				ast.Inspect(fn.Body, s.visit)
+				s.routers = outer
+				return false
+			}
+		}
+
+	case Gin:
+		switch {
+		case method == "Handle" && len(call.Args) >= 3:
+			m, ok := stringLit(call.Args[0])
+			if !ok {
+				s.pkg.warnf(call.Pos(), "skipped route with non-constant method: %s", s.pkg.source(s.f, call))
+				return true
+			}
+			s.ginRoute(call, r, strings.ToUpper(m), call.Args[1:])
+		case len(call.Args) >= 2:
+			if m, ok := ginMethods[method]; ok {
+				s.ginRoute(call, r, m, call.Args)
+			}
+		}
+	}
+	return true
+}
+
+// netHTTPRoute records a route registered on a http.ServeMux,
+// where the pattern may include a method, as in "GET /users/{id}".
+func (s *scanner) netHTTPRoute(call *ast.CallExpr, r router) {
+	if len(call.Args) != 2 {
+		return
+	}
+	pattern, ok := stringLit(call.Args[0])
+	if !ok {
+		s.pkg.warnf(call.Pos(), "skipped route with non-constant pattern: %s", s.pkg.source(s.f, call))
+		return
+	}
+	var method string
+	if m, rest, ok := strings.Cut(pattern, " "); ok {
+		method, pattern = m, strings.TrimSpace(rest)
+	}
+	if !strings.HasPrefix(pattern, "/") {
+		s.pkg.warnf(call.Pos(), "skipped route with host-specific pattern %q", pattern)
+		return
+	}
+	s.addRoute(call, r, method, pattern, call.Args[1])
+}
+
+// ginRoute records a gin route, where all but the last handler are middleware.
+func (s *scanner) ginRoute(call *ast.CallExpr, r router, method string, args []ast.Expr) {
+	handlers := args[1:]
+	if len(handlers) > 1 {
+		s.pkg.warnf(call.Pos(), "route middleware isn't applied to the generated endpoint: %s", s.pkg.source(s.f, call))
+	}
+	s.route(call, r, method, args[0], handlers[len(handlers)-1])
+}
+
+// route records a route with the given path expression.
+func (s *scanner) route(call *ast.CallExpr, r router, method string, pathExpr, handler ast.Expr) {
+	p, ok := stringLit(pathExpr)
+	if !ok {
+		s.pkg.warnf(call.Pos(), "skipped route with non-constant path: %s", s.pkg.source(s.f, call))
+		return
+	}
+	s.addRoute(call, r, method, p, handler)
+}
+
+func (s *scanner) addRoute(call *ast.CallExpr, r router, method, path string, handler ast.Expr) {
+	path = pathJoin(r.prefix, path)
+	route := &Route{
+		Framework: r.fw,
+		Method:    method,
+		Path:      path,
+		Handler:   s.pkg.source(s.f, handler),
+		Pos:       s.pkg.fset.Position(call.Pos()),
+	}
+	switch r.fw {
+	case Chi:
+		route.importPath = s.chiPath
+	case Gin:
+		route.importPath = s.ginPath
+	}
+
+	var err error
+	if route.EncorePath, route.params, err = encorePath(r.fw, path); err != nil {
+		s.pkg.warnf(call.Pos(), "skipped route %s: %v", path, err)
+		return
+	}
+	s.resolveHandler(route, handler)
+	s.pkg.Routes = append(s.pkg.Routes, route)
+}
+
+// resolveHandler determines whether the handler can be wrapped,
+// which requires it to be a top-level function of the package.
+func (s *scanner) resolveHandler(route *Route, handler ast.Expr) {
+	// Unwrap conversions like http.HandlerFunc(fn).
+	if call, ok := handler.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if pkg, name, ok := s.qualified(call.Fun); ok && pkg == s.http && name == "HandlerFunc" {
+			handler = call.Args[0]
+		}
+	}
+
+	switch h := handler.(type) {
+	case *ast.Ident:
+		fn, ok := s.pkg.funcs[h.Name]
+		switch {
+		case !ok:
+			route.Reason = "the handler is a variable"
+		case s.pkg.Name == "main":
+			route.Reason = "the handler is in package main, which can't be imported; move it to another package"
+		case !newScanner(s.pkg, s.pkg.funcFiles[h.Name]).isHandlerFunc(route.Framework, fn.Type):
+			route.Reason = "the handler doesn't have a supported signature"
+		default:
+			route.handlerFunc = h.Name
+		}
+	case *ast.FuncLit:
+		route.Reason = "the handler is a function literal"
+	case *ast.SelectorExpr:
+		route.Reason = "the handler is a method value or in another package"
+	case *ast.CallExpr:
+		route.Reason = "the handler is constructed when the server starts"
+	default:
+		route.Reason = "the handler isn't a function"
+	}
+	if route.handlerFunc == "" {
+		route.Conversion = Manual
+	}
+}
+
+// isHandlerFunc reports whether typ is the type of a handler function of fw.
+func (s *scanner) isHandlerFunc(fw Framework, typ *ast.FuncType) bool {
+	var params []ast.Expr
+	for _, field := range typ.Params.List {
+		n := max(len(field.Names), 1)
+		for range n {
+			params = append(params, field.Type)
+		}
+	}
+	if typ.Results != nil && len(typ.Results.List) > 0 {
+		return false
+	}
+
+	is := func(expr ast.Expr, pointer bool, pkg, name string) bool {
+		if star, ok := expr.(*ast.StarExpr); ok == pointer {
+			if ok {
+				expr = star.X
+			}
+			p, n, ok := s.qualified(expr)
+			return ok && p == pkg && n == name
+		}
+		return false
+	}
+
+	if fw == Gin {
+		return len(params) == 1 && is(params[0], true, s.gin, "Context")
+	}
+	return len(params) == 2 && is(params[0], false, s.http, "ResponseWriter") && is(params[1], true, s.http, "Request")
+}
+
+// isChiPath reports whether path is the import path of a major version of chi.
+func isChiPath(path string) bool {
+	if path == "github.com/go-chi/chi" {
+		return true
+	}
+	v, ok := strings.CutPrefix(path, "github.com/go-chi/chi/v")
+	_, err := strconv.Atoi(v)
+	return ok && err == nil
+}
+
+// exprKey returns the key of a router expression in the routers map.
+func exprKey(expr ast.Expr) string {
+	return types.ExprString(expr)
+}
+
+// stringLit reports the value of a string literal.
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
@@ -0,0 +1,374 @@
+package pgschema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgx/v5"
+)
+
+// MigrationsTable is the table Encore records applied migrations in.
+// It's excluded from the imported schema.
+const MigrationsTable = "schema_migrations"
+
+// userObjects restricts queries to objects in user-defined schemas, given
+// the alias of pg_namespace, that don't belong to an extension, given the
+// expression of their oid.
+func userObjects(ns, oid string) string {
+	return fmt.Sprintf(`%[1]s.nspname NOT IN ('pg_catalog', 'information_schema') AND %[1]s.nspname NOT LIKE 'pg\_%%'
+		AND NOT EXISTS (SELECT 1 FROM pg_depend dep WHERE dep.objid = %[2]s AND dep.deptype = 'e')`, ns, oid)
+}
+
+// Introspect introspects the schema of the database conn is connected to.
+// It requires Postgres 12 or later.
+//
+// It changes the search_path of the connection to "public", so that
+// names are rendered qualified unless they're in the public schema.
+func Introspect(ctx context.Context, conn *pgx.Conn) (*Schema, error) {
+	if _, err := conn.Exec(ctx, "SELECT pg_catalog.set_config('search_path', 'public', false)"); err != nil {
+		return nil, errors.Wrap(err, "set search_path")
+	}
+
+	s := &Schema{serialSequences: make(map[string]bool)}
+	steps := []struct {
+		name string
+		fn   func(context.Context, pgx.Tx) error
+	}{
+		{"schemas", s.loadSchemas},
+		{"extensions", s.loadExtensions},
+		{"types", s.loadTypes},
+		{"functions", s.loadFunctions},
+		{"tables", s.loadTables},
+		{"sequences", s.loadSequences},
+		{"indexes", s.loadIndexes},
+		{"views", s.loadViews},
+		{"triggers", s.loadTriggers},
+		{"unsupported objects", s.loadUnsupported},
+	}
+
+	// Read everything from the same snapshot.
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, errors.Wrap(err, "begin transaction")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	for _, step := range steps {
+		if err := step.fn(ctx, tx); err != nil {
+			return nil, errors.Wrapf(err, "introspect %s", step.name)
+		}
+	}
+	return s, nil
+}
+
+func (s *Schema) loadSchemas(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT quote_ident(n.nspname) FROM pg_namespace n
+		WHERE n.nspname <> 'public' AND `+userObjects("n", "n.oid")+`
+		ORDER BY n.nspname`)
+	if err != nil {
+		return err
+	}
+	s.Schemas, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	return err
+}
+
+func (s *Schema) loadExtensions(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT quote_ident(e.extname), quote_ident(n.nspname)
+		FROM pg_extension e JOIN pg_namespace n ON n.oid = e.extnamespace
+		WHERE e.extname <> 'plpgsql'
+		ORDER BY e.extname`)
+	if err != nil {
+		return err
+	}
+	s.Extensions, err = pgx.CollectRows(rows, pgx.RowToStructByPos[Extension])
+	return err
+}
+
+func (s *Schema) loadTypes(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT t.oid::regtype::text, array_agg(e.enumlabel::text ORDER BY e.enumsortorder)
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		JOIN pg_enum e ON e.enumtypid = t.oid
+		WHERE `+userObjects("n", "t.oid")+`
+		GROUP BY t.oid
+		ORDER BY t.oid`)
+	if err != nil {
+		return err
+	}
+	if s.Enums, err = pgx.CollectRows(rows, pgx.RowToStructByPos[Enum]); err != nil {
+		return err
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT t.oid::regtype::text, format_type(t.typbasetype, t.typtypmod), t.typnotnull, COALESCE(t.typdefault, ''),
+			ARRAY(SELECT pg_get_constraintdef(c.oid, true) FROM pg_constraint c
+				WHERE c.contypid = t.oid AND c.contype = 'c' ORDER BY c.conname)
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE t.typtype = 'd' AND `+userObjects("n", "t.oid")+`
+		ORDER BY t.oid`)
+	if err != nil {
+		return err
+	}
+	s.Domains, err = pgx.CollectRows(rows, pgx.RowToStructByPos[Domain])
+	return err
+}
+
+func (s *Schema) loadFunctions(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT pg_get_functiondef(p.oid)
+		FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE p.prokind IN ('f', 'p') AND `+userObjects("n", "p.oid")+`
+		ORDER BY p.oid`)
+	if err != nil {
+		return err
+	}
+	s.Functions, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	return err
+}
+
+func (s *Schema) loadTables(ctx context.Context, tx pgx.Tx) error {
+	type tableRow struct {
+		OID         uint32
+		Name        string
+		Unlogged    bool
+		PartitionBy string
+		Parent      string
+		IsPartition bool
+		Bound       string
+		Comment     string
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT c.oid, c.oid::regclass::text, c.relpersistence = 'u',
+			COALESCE(CASE WHEN c.relkind = 'p' THEN pg_get_partkeydef(c.oid) END, ''),
+			COALESCE((SELECT i.inhparent::regclass::text FROM pg_inherits i WHERE i.inhrelid = c.oid ORDER BY i.inhseqno LIMIT 1), ''),
+			c.relispartition,
+			COALESCE(pg_get_expr(c.relpartbound, c.oid), ''),
+			COALESCE(obj_description(c.oid, 'pg_class'), '')
+		FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p') AND `+userObjects("n", "c.oid")+`
+			AND NOT (n.nspname = 'public' AND c.relname = $1)
+		ORDER BY c.relispartition, c.oid`, MigrationsTable)
+	if err != nil {
+		return err
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowToStructByPos[tableRow])
+	if err != nil {
+		return err
+	}
+
+	byOID := make(map[uint32]*Table, len(tables))
+	oids := make([]uint32, len(tables))
+	for i, row := range tables {
+		t := &Table{
+			Name:        row.Name,
+			Unlogged:    row.Unlogged,
+			Comment:     row.Comment,
+			PartitionBy: row.PartitionBy,
+		}
+		if row.IsPartition {
+			t.PartitionOf, t.PartitionBound = row.Parent, row.Bound
+		} else if row.Parent != "" {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("table %s inherits from %s; the inheritance isn't imported, "+
+				"so the table is created with all its columns", t.Name, row.Parent))
+		}
+		s.Tables = append(s.Tables, t)
+		byOID[row.OID] = t
+		oids[i] = row.OID
+	}
+
+	// Serial columns are recognized by their default taking the next value of
+	// a sequence owned by the column, and are created as serial columns.
+	type columnRow struct {
+		Table uint32
+		Column
+		SerialSequence string
+	}
+	rows, err = tx.Query(ctx, `
+		SELECT a.attrelid, quote_ident(a.attname), format_type(a.atttypid, a.atttypmod),
+			CASE WHEN a.attcollation <> t.typcollation THEN quote_ident(co.collname) ELSE '' END,
+			a.attnotnull,
+			CASE WHEN a.attgenerated = '' THEN COALESCE(pg_get_expr(ad.adbin, ad.adrelid), '') ELSE '' END,
+			CASE a.attidentity WHEN 'a' THEN 'ALWAYS' WHEN 'd' THEN 'BY DEFAULT' ELSE '' END,
+			CASE WHEN a.attgenerated = 's' THEN pg_get_expr(ad.adbin, ad.adrelid) ELSE '' END,
+			COALESCE(col_description(a.attrelid, a.attnum), ''),
+			CASE WHEN a.atttypid IN ('int2'::regtype, 'int4'::regtype, 'int8'::regtype) THEN COALESCE((
+				SELECT d.objid::regclass::text FROM pg_depend d
+				WHERE d.refobjid = a.attrelid AND d.refobjsubid = a.attnum AND d.deptype = 'a'
+					AND d.classid = 'pg_class'::regclass
+					AND pg_get_expr(ad.adbin, ad.adrelid) = 'nextval(' || quote_literal(d.objid::regclass::text) || '::regclass)'
+				LIMIT 1
+			), '') ELSE '' END
+		FROM pg_attribute a
+		JOIN pg_type t ON t.oid = a.atttypid
+		LEFT JOIN pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+		LEFT JOIN pg_collation co ON co.oid = a.attcollation
+		WHERE a.attrelid = ANY($1::oid[]) AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attrelid, a.attnum`, oids)
+	if err != nil {
+		return err
+	}
+	columns, err := pgx.CollectRows(rows, pgx.RowToStructByPos[columnRow])
+	if err != nil {
+		return err
+	}
+	for _, row := range columns {
+		c := row.Column
+		if row.SerialSequence != "" {
+			c.Type = map[string]string{"smallint": "smallserial", "integer": "serial", "bigint": "bigserial"}[c.Type]
+			c.Default = ""
+			s.serialSequences[row.SerialSequence] = true
+		}
+		byOID[row.Table].Columns = append(byOID[row.Table].Columns, c)
+	}
+
+	// Constraints are ordered as pg_dump orders them: the primary key first.
+	type constraintRow struct {
+		Table uint32
+		Type  string
+		Constraint
+	}
+	rows, err = tx.Query(ctx, `
+		SELECT c.conrelid, c.contype::text, quote_ident(c.conname), pg_get_constraintdef(c.oid, true)
+		FROM pg_constraint c
+		WHERE c.conrelid = ANY($1::oid[]) AND c.contype IN ('p', 'u', 'c', 'x', 'f') AND c.conislocal
+		ORDER BY c.conrelid, position(c.contype::text IN 'pucxf'), c.conname`, oids)
+	if err != nil {
+		return err
+	}
+	constraints, err := pgx.CollectRows(rows, pgx.RowToStructByPos[constraintRow])
+	if err != nil {
+		return err
+	}
+	for _, row := range constraints {
+		t := byOID[row.Table]
+		if t.PartitionOf != "" {
+			// Partitions inherit the constraints of the partitioned table.
+			continue
+		}
+		if row.Type == "f" {
+			t.ForeignKeys = append(t.ForeignKeys, row.Constraint)
+		} else {
+			t.Constraints = append(t.Constraints, row.Constraint)
+		}
+	}
+	return nil
+}
+
+// loadSequences loads the sequences not backing identity or serial columns.
+// It must be called after loadTables.
+func (s *Schema) loadSequences(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT c.oid::regclass::text, format_type(q.seqtypid, NULL),
+			q.seqstart, q.seqincrement, q.seqmin, q.seqmax, q.seqcache, q.seqcycle
+		FROM pg_sequence q
+		JOIN pg_class c ON c.oid = q.seqrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE `+userObjects("n", "c.oid")+`
+			AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.objid = c.oid AND d.deptype = 'i')
+		ORDER BY c.oid`)
+	if err != nil {
+		return err
+	}
+	seqs, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Sequence])
+	if err != nil {
+		return err
+	}
+
+	// Skip the sequences of serial columns, which are created with the column.
+	s.Sequences = slices.DeleteFunc(seqs, func(seq Sequence) bool { return s.serialSequences[seq.Name] })
+	return nil
+}
+
+func (s *Schema) loadIndexes(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT CASE
+			-- Create indexes of partitioned tables for their partitions too.
+			WHEN c.relkind = 'p' THEN replace(pg_get_indexdef(i.indexrelid), ' ON ONLY ', ' ON ')
+			ELSE pg_get_indexdef(i.indexrelid)
+		END
+		FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p', 'm') AND `+userObjects("n", "c.oid")+`
+			AND NOT (n.nspname = 'public' AND c.relname = $1)
+			-- Skip indexes backing constraints, which are created by the constraint.
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint con
+				WHERE con.conindid = i.indexrelid AND con.conrelid = i.indrelid AND con.contype IN ('p', 'u', 'x'))
+			-- Skip indexes of partitions created for indexes of partitioned tables.
+			AND NOT EXISTS (SELECT 1 FROM pg_inherits inh WHERE inh.inhrelid = i.indexrelid)
+		ORDER BY i.indexrelid`, MigrationsTable)
+	if err != nil {
+		return err
+	}
+	s.Indexes, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	return err
+}
+
+func (s *Schema) loadViews(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT c.oid::regclass::text, pg_get_viewdef(c.oid, true), c.relkind = 'm',
+			COALESCE(obj_description(c.oid, 'pg_class'), '')
+		FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('v', 'm') AND `+userObjects("n", "c.oid")+`
+		ORDER BY c.oid`)
+	if err != nil {
+		return err
+	}
+	s.Views, err = pgx.CollectRows(rows, pgx.RowToStructByPos[View])
+	return err
+}
+
+func (s *Schema) loadTriggers(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT pg_get_triggerdef(t.oid, true)
+		FROM pg_trigger t
+		JOIN pg_class c ON c.oid = t.tgrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE NOT t.tgisinternal AND NOT c.relispartition AND `+userObjects("n", "c.oid")+`
+		ORDER BY t.oid`)
+	if err != nil {
+		return err
+	}
+	s.Triggers, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	return err
+}
+
+// loadUnsupported adds warnings for the objects that aren't imported.
+func (s *Schema) loadUnsupported(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT 'composite type ' || t.oid::regtype::text
+		FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace
+		JOIN pg_class c ON c.oid = t.typrelid
+		WHERE t.typtype = 'c' AND c.relkind = 'c' AND `+userObjects("n", "t.oid")+`
+		UNION ALL
+		SELECT 'range type ' || t.oid::regtype::text
+		FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE t.typtype = 'r' AND `+userObjects("n", "t.oid")+`
+		UNION ALL
+		SELECT 'aggregate ' || p.oid::regprocedure::text
+		FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE p.prokind IN ('a', 'w') AND `+userObjects("n", "p.oid")+`
+		UNION ALL
+		SELECT 'row-level security policy ' || quote_ident(p.polname) || ' on ' || p.polrelid::regclass::text
+		FROM pg_policy p
+		UNION ALL
+		SELECT 'rule ' || quote_ident(r.rulename) || ' on ' || r.ev_class::regclass::text
+		FROM pg_rewrite r JOIN pg_class c ON c.oid = r.ev_class JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE r.rulename <> '_RETURN' AND `+userObjects("n", "c.oid"))
+	if err != nil {
+		return err
+	}
+	objects, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		s.Warnings = append(s.Warnings, obj+" isn't imported; add it to the migration by hand")
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+// Package pgschema introspects the schema of an existing Postgres database,
+// and renders it as the SQL statements that recreate it, for use as the
+// initial migration when moving a running system onto Encore-managed
+// migrations.
+//
+// Only the schema is imported, not the data. Identifiers are quoted and
+// qualified as Postgres itself renders them with a search_path of "public",
+// so objects in the public schema are left unqualified.
+package pgschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Schema is the schema of a database.
+type Schema struct {
+	// Schemas are the names of the schemas other than "public".
+	Schemas    []string
+	Extensions []Extension
+	Enums      []Enum
+	Domains    []Domain
+	// Functions are the definitions of functions and procedures,
+	// as CREATE OR REPLACE statements without the terminating semicolon.
+	Functions []string
+	Sequences []Sequence
+	Tables    []*Table
+	// Indexes are the CREATE INDEX statements of the indexes
+	// not backing a constraint.
+	Indexes  []string
+	Views    []View
+	Triggers []string
+
+	// Warnings describe objects that aren't imported.
+	Warnings []string
+
+	serialSequences map[string]bool // the sequences of serial columns
+}
+
+// Extension is an installed extension.
+type Extension struct {
+	Name   string
+	Schema string
+}
+
+// Enum is an enum type.
+type Enum struct {
+	Name   string
+	Values []string
+}
+
+// Domain is a domain type.
+type Domain struct {
+	Name    string
+	Type    string // the underlying type
+	NotNull bool
+	Default string
+	Checks  []string // the definitions of the CHECK constraints
+}
+
+// Sequence is a sequence that isn't backing a serial or identity column.
+type Sequence struct {
+	Name                              string
+	Type                              string
+	Start, Increment, Min, Max, Cache int64
+	Cycle                             bool
+}
+
+// Table is a table.
+type Table struct {
+	Name     string
+	Unlogged bool
+	Comment  string
+
+	// PartitionBy is the partition key of partitioned tables,
+	// such as "RANGE (created_at)".
+	PartitionBy string
+	// PartitionOf is the parent table of partitions, which
+	// inherit its columns and constraints.
+	PartitionOf string
+	// PartitionBound is the bound of partitions,
+	// such as "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')".
+	PartitionBound string
+
+	Columns []Column
+	// Constraints are the primary key, unique, check and exclusion constraints.
+	Constraints []Constraint
+	// ForeignKeys are added after all tables are created,
+	// so tables can reference each other in any order.
+	ForeignKeys []Constraint
+}
+
+// Column is a column of a table.
+type Column struct {
+	Name      string
+	Type      string
+	Collation string
+	NotNull   bool
+	Default   string
+	// Identity is "ALWAYS" or "BY DEFAULT" for identity columns.
+	Identity string
+	// Generated is the expression of stored generated columns.
+	Generated string
+	Comment   string
+}
+
+// Constraint is a table constraint.
+type Constraint struct {
+	Name string
+	Def  string
+}
+
+// View is a view or materialized view.
+type View struct {
+	Name         string
+	Query        string
+	Materialized bool
+	Comment      string
+}
+
+// SQL renders the statements that create the schema.
+func (s *Schema) SQL() string {
+	var b strings.Builder
+	section := func(stmts ...string) {
+		if len(stmts) == 0 {
+			return
+		}
+		for _, stmt := range stmts {
+			b.WriteString(stmt)
+			b.WriteString(";\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(s.Functions) > 0 {
+		// Function bodies may refer to tables that are created later.
+		section("SET check_function_bodies = false")
+	}
+	for _, name := range s.Schemas {
+		section("CREATE SCHEMA " + name)
+	}
+	var stmts []string
+	for _, e := range s.Extensions {
+		stmts = append(stmts, fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s WITH SCHEMA %s", e.Name, e.Schema))
+	}
+	section(stmts...)
+
+	stmts = nil
+	for _, e := range s.Enums {
+		values := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			values[i] = quoteLiteral(v)
+		}
+		stmts = append(stmts, fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", e.Name, strings.Join(values, ", ")))
+	}
+	for _, d := range s.Domains {
+		stmt := fmt.Sprintf("CREATE DOMAIN %s AS %s", d.Name, d.Type)
+		if d.Default != "" {
+			stmt += " DEFAULT " + d.Default
+		}
+		if d.NotNull {
+			stmt += " NOT NULL"
+		}
+		for _, check := range d.Checks {
+			stmt += " " + check
+		}
+		stmts = append(stmts, stmt)
+	}
+	section(stmts...)
+
+	for _, fn := range s.Functions {
+		section(strings.TrimSpace(fn))
+	}
+
+	stmts = nil
+	for _, seq := range s.Sequences {
+		stmt := fmt.Sprintf("CREATE SEQUENCE %s AS %s START WITH %d INCREMENT BY %d MINVALUE %d MAXVALUE %d CACHE %d",
+			seq.Name, seq.Type, seq.Start, seq.Increment, seq.Min, seq.Max, seq.Cache)
+		if seq.Cycle {
+			stmt += " CYCLE"
+		}
+		stmts = append(stmts, stmt)
+	}
+	section(stmts...)
+
+	for _, t := range s.Tables {
+		section(t.sql())
+	}
+
+	stmts = nil
+	for _, t := range s.Tables {
+		for _, fk := range t.ForeignKeys {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s", t.Name, fk.Name, fk.Def))
+		}
+	}
+	section(stmts...)
+	section(s.Indexes...)
+
+	for _, v := range s.Views {
+		kind := "VIEW"
+		if v.Materialized {
+			kind = "MATERIALIZED VIEW"
+		}
+		query := strings.TrimSuffix(strings.TrimSpace(v.Query), ";")
+		section(fmt.Sprintf("CREATE %s %s AS\n%s", kind, v.Name, query))
+	}
+	section(s.Triggers...)
+
+	stmts = nil
+	for _, t := range s.Tables {
+		if t.Comment != "" {
+			stmts = append(stmts, fmt.Sprintf("COMMENT ON TABLE %s IS %s", t.Name, quoteLiteral(t.Comment)))
+		}
+		for _, c := range t.Columns {
+			if c.Comment != "" {
+				stmts = append(stmts, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", t.Name, c.Name, quoteLiteral(c.Comment)))
+			}
+		}
+	}
+	for _, v := range s.Views {
+		if v.Comment != "" {
+			kind := "VIEW"
+			if v.Materialized {
+				kind = "MATERIALIZED VIEW"
+			}
+			stmts = append(stmts, fmt.Sprintf("COMMENT ON %s %s IS %s", kind, v.Name, quoteLiteral(v.Comment)))
+		}
+	}
+	section(stmts...)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// sql renders the CREATE TABLE statement of the table.
+func (t *Table) sql() string {
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if t.Unlogged {
+		b.WriteString("UNLOGGED ")
+	}
+	b.WriteString("TABLE " + t.Name)
+	if t.PartitionOf != "" {
+		b.WriteString(" PARTITION OF " + t.PartitionOf + " " + t.PartitionBound)
+		if t.PartitionBy != "" {
+			b.WriteString(" PARTITION BY " + t.PartitionBy)
+		}
+		return b.String()
+	}
+
+	var lines []string
+	for _, c := range t.Columns {
+		line := c.Name + " " + c.Type
+		if c.Collation != "" {
+			line += " COLLATE " + c.Collation
+		}
+		switch {
+		case c.Generated != "":
+			line += " GENERATED ALWAYS AS (" + c.Generated + ") STORED"
+		case c.Identity != "":
+			line += " GENERATED " + c.Identity + " AS IDENTITY"
+		case c.Default != "":
+			line += " DEFAULT " + c.Default
+		}
+		if c.NotNull {
+			line += " NOT NULL"
+		}
+		lines = append(lines, line)
+	}
+	for _, c := range t.Constraints {
+		lines = append(lines, "CONSTRAINT "+c.Name+" "+c.Def)
+	}
+	b.WriteString(" (\n    ")
+	b.WriteString(strings.Join(lines, ",\n    "))
+	b.WriteString("\n)")
+	if t.PartitionBy != "" {
+		b.WriteString(" PARTITION BY " + t.PartitionBy)
+	}
+	return b.String()
+}
+
+// quoteLiteral quotes s as an SQL string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
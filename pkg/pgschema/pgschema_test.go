@@ -0,0 +1,107 @@
+package pgschema
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSQL(t *testing.T) {
+	c := qt.New(t)
+	s := &Schema{
+		Schemas:    []string{"billing"},
+		Extensions: []Extension{{Name: `"uuid-ossp"`, Schema: "public"}},
+		Enums:      []Enum{{Name: "order_status", Values: []string{"pending", "shipped", "it's done"}}},
+		Domains: []Domain{{Name: "email", Type: "text", NotNull: true,
+			Checks: []string{"CHECK ((VALUE ~~ '%@%'::text))"}}},
+		Functions: []string{"CREATE OR REPLACE FUNCTION public.touch()\n RETURNS trigger\n LANGUAGE plpgsql\nAS $function$BEGIN NEW.updated_at = now(); RETURN NEW; END$function$\n"},
+		Sequences: []Sequence{{Name: "invoice_no", Type: "bigint", Start: 1000, Increment: 1, Min: 1, Max: 9223372036854775807, Cache: 1}},
+		Tables: []*Table{
+			{
+				Name:    "orders",
+				Comment: "Customer orders.",
+				Columns: []Column{
+					{Name: "id", Type: "bigserial", NotNull: true},
+					{Name: "status", Type: "order_status", NotNull: true, Default: "'pending'::order_status", Comment: "Fulfilment status."},
+					{Name: "email", Type: "email", Collation: `"C"`},
+					{Name: "total", Type: "numeric(10,2)", NotNull: true},
+					{Name: "total_cents", Type: "bigint", Generated: "((total * (100)::numeric))::bigint"},
+					{Name: "updated_at", Type: "timestamp with time zone"},
+				},
+				Constraints: []Constraint{{Name: "orders_pkey", Def: "PRIMARY KEY (id)"}},
+			},
+			{
+				Name: "billing.invoices",
+				Columns: []Column{
+					{Name: "id", Type: "integer", NotNull: true, Identity: "ALWAYS"},
+					{Name: "order_id", Type: "bigint", NotNull: true},
+					{Name: "created_at", Type: "date", NotNull: true},
+				},
+				Constraints: []Constraint{{Name: "invoices_pkey", Def: "PRIMARY KEY (id, created_at)"}},
+				ForeignKeys: []Constraint{{Name: "invoices_order_id_fkey", Def: "FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE"}},
+				PartitionBy: "RANGE (created_at)",
+			},
+			{
+				Name:           "billing.invoices_2024",
+				PartitionOf:    "billing.invoices",
+				PartitionBound: "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')",
+			},
+		},
+		Indexes:  []string{"CREATE INDEX orders_status_idx ON public.orders USING btree (status)"},
+		Views:    []View{{Name: "pending_orders", Query: " SELECT id\n   FROM orders\n  WHERE status = 'pending'::order_status;", Comment: "Orders to ship."}},
+		Triggers: []string{"CREATE TRIGGER orders_touch BEFORE UPDATE ON public.orders FOR EACH ROW EXECUTE FUNCTION touch()"},
+	}
+
+	c.Assert(s.SQL(), qt.Equals, `SET check_function_bodies = false;
+
+CREATE SCHEMA billing;
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp" WITH SCHEMA public;
+
+CREATE TYPE order_status AS ENUM ('pending', 'shipped', 'it''s done');
+CREATE DOMAIN email AS text NOT NULL CHECK ((VALUE ~~ '%@%'::text));
+
+CREATE OR REPLACE FUNCTION public.touch()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$BEGIN NEW.updated_at = now(); RETURN NEW; END$function$;
+
+CREATE SEQUENCE invoice_no AS bigint START WITH 1000 INCREMENT BY 1 MINVALUE 1 MAXVALUE 9223372036854775807 CACHE 1;
+
+CREATE TABLE orders (
+    id bigserial NOT NULL,
+    status order_status DEFAULT 'pending'::order_status NOT NULL,
+    email email COLLATE "C",
+    total numeric(10,2) NOT NULL,
+    total_cents bigint GENERATED ALWAYS AS (((total * (100)::numeric))::bigint) STORED,
+    updated_at timestamp with time zone,
+    CONSTRAINT orders_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE billing.invoices (
+    id integer GENERATED ALWAYS AS IDENTITY NOT NULL,
+    order_id bigint NOT NULL,
+    created_at date NOT NULL,
+    CONSTRAINT invoices_pkey PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE billing.invoices_2024 PARTITION OF billing.invoices FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');
+
+ALTER TABLE billing.invoices ADD CONSTRAINT invoices_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+
+CREATE INDEX orders_status_idx ON public.orders USING btree (status);
+
+CREATE VIEW pending_orders AS
+SELECT id
+   FROM orders
+  WHERE status = 'pending'::order_status;
+
+CREATE TRIGGER orders_touch BEFORE UPDATE ON public.orders FOR EACH ROW EXECUTE FUNCTION touch();
+
+COMMENT ON TABLE orders IS 'Customer orders.';
+COMMENT ON COLUMN orders.status IS 'Fulfilment status.';
+COMMENT ON VIEW pending_orders IS 'Orders to ship.';
+`)
+
+	c.Assert((&Schema{}).SQL(), qt.Equals, "")
+}
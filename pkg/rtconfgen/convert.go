@@ -3,6 +3,7 @@ package rtconfgen
 import (
 	"encoding/base64"
 	"encoding/json"
+	"net/url"
 	"reflect"
 	"slices"
 
@@ -15,6 +16,7 @@ import (
 
 	encore "encore.dev"
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/trace2"
 	"encr.dev/pkg/fns"
 )
 
@@ -216,13 +218,17 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 					}
 
 					cfg.SQLDatabases = append(cfg.SQLDatabases, &config.SQLDatabase{
-						ServerID:       serverIdx,
-						EncoreName:     db.EncoreName,
-						DatabaseName:   db.CloudName,
-						User:           role.Username,
-						Password:       c.secretString(role.Password),
-						MinConnections: int(pool.MinConnections),
-						MaxConnections: int(pool.MaxConnections),
+						ServerID:         serverIdx,
+						EncoreName:       db.EncoreName,
+						DatabaseName:     db.CloudName,
+						User:             role.Username,
+						Password:         c.secretString(role.Password),
+						MinConnections:   int(pool.MinConnections),
+						MaxConnections:   int(pool.MaxConnections),
+						MaxIdleTime:      pool.GetMaxIdleTime().AsDuration(),
+						MaxConnLifetime:  pool.GetMaxConnLifetime().AsDuration(),
+						StatementTimeout: pool.GetStatementTimeout().AsDuration(),
+						ReadReplicas:     c.sqlReadReplicas(cluster, db),
 					})
 				}
 			}
@@ -433,6 +439,11 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 						KeyPrefix:     bkt.GetKeyPrefix(),
 						PublicBaseURL: bkt.GetPublicBaseUrl(),
 					}
+					if enc := bkt.GetEncryption(); enc != nil {
+						cfg.Buckets[bkt.EncoreName].Encryption = &config.BucketEncryption{
+							KMSKey: enc.KmsKey,
+						}
+					}
 				}
 
 			}
@@ -492,6 +503,10 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 			if enc := prov.GetEncore(); enc != nil {
 				cfg.TraceEndpoint = enc.TraceEndpoint
 				cfg.TraceSamplingRate = enc.SamplingRate
+				if enc.TimeAnchor == runtimev1.TracingProvider_EncoreTracingProvider_TIME_ANCHOR_BOOT {
+					cfg.TraceTimeAnchor = string(trace2.TimeAnchorBoot)
+				}
+				cfg.TraceMemStats = enc.MemStats
 				break
 			}
 		}
@@ -682,3 +697,39 @@ func randomMapValue[K comparable, V any](m map[K]V) (V, bool) {
 	var zero V
 	return zero, false
 }
+
+// sqlReadReplicas computes the connection strings for the read replicas
+// of the given database, using the database's read-only connection pool.
+func (c *legacyConverter) sqlReadReplicas(cluster *runtimev1.SQLCluster, db *runtimev1.SQLDatabase) []string {
+	replicas := fns.Filter(cluster.Servers, func(s *runtimev1.SQLServer) bool {
+		return s.Kind == runtimev1.ServerKind_SERVER_KIND_READ_REPLICA
+	})
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	pool, ok := fns.Find(db.ConnPools, func(pool *runtimev1.SQLConnectionPool) bool {
+		return pool.IsReadonly
+	})
+	if !ok {
+		return nil
+	}
+	role, ok := findRID(pool.RoleRid, c.in.Infra.Credentials.SqlRoles)
+	if !ok {
+		c.setErrf("unable to find sql role %q", pool.RoleRid)
+		return nil
+	}
+
+	connStrs := make([]string, 0, len(replicas))
+	for _, srv := range replicas {
+		u := &url.URL{
+			Scheme:   "postgresql",
+			User:     url.UserPassword(role.Username, c.secretString(role.Password)),
+			Host:     srv.Host,
+			Path:     "/" + db.CloudName,
+			RawQuery: "sslmode=prefer",
+		}
+		connStrs = append(connStrs, u.String())
+	}
+	return connStrs
+}
@@ -0,0 +1,462 @@
+// Package svcadvisor analyzes how an application is split into services,
+// and points out service boundaries that may be drawn poorly.
+//
+// It combines the static call graph computed by the parser with the call
+// frequencies observed in traces, and reports cyclic dependencies between
+// services, pairs of services that call each other so often that they may
+// be better off merged, and "god services" that are much larger or more
+// central than the rest of the app. It also reports endpoints that nothing
+// calls, to help prune unused API surface.
+package svcadvisor
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Options configures the thresholds used by Analyze.
+type Options struct {
+	// ChattyCallsPerRequest is the average number of calls a service makes to
+	// another service, per request it handles, above which the pair of services
+	// is considered chatty. If zero, it defaults to 3.
+	ChattyCallsPerRequest float64
+
+	// ChattyMinCalls is the minimum number of observed calls between two services
+	// for them to be considered chatty. If zero, it defaults to 10.
+	ChattyMinCalls int64
+
+	// GodServiceMinEndpoints is the minimum number of endpoints a service must have
+	// to be considered a god service based on its size. A service must also have
+	// at least twice as many endpoints as the app's median service.
+	// If zero, it defaults to 20.
+	GodServiceMinEndpoints int
+}
+
+// Usage describes the traffic observed for an app.
+type Usage struct {
+	// Requests is the number of requests handled by each service,
+	// from any caller, keyed by service name.
+	Requests map[string]int64
+
+	// Calls are the observed calls between services.
+	Calls []Call
+
+	// External is the number of requests made to each endpoint from outside
+	// the app, such as through a generated client, keyed by "service.Endpoint".
+	External map[string]int64
+}
+
+// Call describes the observed calls from one service to an endpoint of another.
+type Call struct {
+	Caller   string // the calling service
+	Service  string // the called service
+	Endpoint string // the called endpoint
+	Count    int64  // number of calls
+
+	// ExampleTraceID is the id of a trace containing such a call, if known.
+	ExampleTraceID string
+}
+
+// Kind is the kind of a finding.
+type Kind string
+
+const (
+	// Cycle means the services depend on each other in a cycle.
+	Cycle Kind = "cycle"
+
+	// GodService means the service is much larger or more
+	// central than the other services in the app.
+	GodService Kind = "god-service"
+
+	// ChattyPair means the two services call each other
+	// so often that they may be better off merged.
+	ChattyPair Kind = "chatty-pair"
+)
+
+// Finding is a suggested service boundary issue.
+type Finding struct {
+	Kind       Kind       `json:"kind"`
+	Services   []string   `json:"services"`
+	Summary    string     `json:"summary"`
+	Suggestion string     `json:"suggestion"`
+	Evidence   []Evidence `json:"evidence"`
+}
+
+// Evidence is a single piece of evidence backing a finding.
+type Evidence struct {
+	Desc string `json:"desc"`
+
+	// Location is the source location the evidence refers to,
+	// in "path/to/file.go:line" form relative to the app root.
+	Location string `json:"location,omitempty"`
+
+	// TraceID is the id of a trace the evidence was observed in.
+	TraceID string `json:"trace_id,omitempty"`
+}
+
+// Analyze analyzes the services in md, along with the observed usage,
+// and returns the findings ordered by kind and services.
+func Analyze(md *meta.Data, usage Usage, opts Options) []Finding {
+	a := newAnalyzer(md, usage, opts)
+	var findings []Finding
+	findings = append(findings, a.cycles()...)
+	findings = append(findings, a.godServices()...)
+	findings = append(findings, a.chattyPairs()...)
+	return findings
+}
+
+// edge describes the dependencies from one service to another.
+type edge struct {
+	from, to string
+
+	// static is the evidence of calls found by the parser.
+	static []Evidence
+
+	// calls are the observed calls, and their total count.
+	calls []Call
+	total int64
+}
+
+type analyzer struct {
+	md    *meta.Data
+	usage Usage
+	opts  Options
+
+	svcs  []string                        // sorted service names
+	edges map[[2]string]*edge             // keyed by [from, to]
+	deps  map[string]map[string]bool      // service -> services it depends on
+	rdeps map[string]map[string]bool      // service -> services depending on it
+	rpcs  map[string]map[string]*meta.RPC // service -> endpoint name -> rpc
+}
+
+func newAnalyzer(md *meta.Data, usage Usage, opts Options) *analyzer {
+	a := &analyzer{
+		md:    md,
+		usage: usage,
+		opts:  opts,
+		edges: make(map[[2]string]*edge),
+		deps:  make(map[string]map[string]bool),
+		rdeps: make(map[string]map[string]bool),
+		rpcs:  make(map[string]map[string]*meta.RPC),
+	}
+
+	for _, svc := range md.Svcs {
+		a.svcs = append(a.svcs, svc.Name)
+		a.rpcs[svc.Name] = make(map[string]*meta.RPC)
+		for _, rpc := range svc.Rpcs {
+			a.rpcs[svc.Name][rpc.Name] = rpc
+		}
+	}
+	slices.Sort(a.svcs)
+
+	pkgSvc := make(map[string]string)
+	for _, pkg := range md.Pkgs {
+		pkgSvc[pkg.RelPath] = pkg.ServiceName
+	}
+
+	pkgs := slices.Clone(md.Pkgs)
+	slices.SortFunc(pkgs, func(a, b *meta.Package) int {
+		return cmp.Compare(a.RelPath, b.RelPath)
+	})
+	for _, pkg := range pkgs {
+		if pkg.ServiceName == "" {
+			continue
+		}
+		for _, call := range pkg.RpcCalls {
+			to := pkgSvc[call.Pkg]
+			if to == "" || to == pkg.ServiceName {
+				continue
+			}
+			e := a.edge(pkg.ServiceName, to)
+			ev := Evidence{Desc: fmt.Sprintf("package %s calls %s.%s", pkg.RelPath, to, call.Name)}
+			if rpc := a.rpcs[to][call.Name]; rpc != nil {
+				ev.Location = location(a.md, rpc)
+			}
+			e.static = append(e.static, ev)
+		}
+	}
+
+	calls := slices.Clone(usage.Calls)
+	slices.SortFunc(calls, func(a, b Call) int {
+		return cmp.Or(
+			cmp.Compare(a.Caller, b.Caller),
+			cmp.Compare(a.Service, b.Service),
+			cmp.Compare(a.Endpoint, b.Endpoint),
+		)
+	})
+	for _, c := range calls {
+		if c.Caller == c.Service || c.Count <= 0 {
+			continue
+		}
+		e := a.edge(c.Caller, c.Service)
+		e.calls = append(e.calls, c)
+		e.total += c.Count
+	}
+
+	return a
+}
+
+// edge returns the edge from one service to another, creating it if necessary.
+func (a *analyzer) edge(from, to string) *edge {
+	key := [2]string{from, to}
+	e, ok := a.edges[key]
+	if !ok {
+		e = &edge{from: from, to: to}
+		a.edges[key] = e
+		addDep(a.deps, from, to)
+		addDep(a.rdeps, to, from)
+	}
+	return e
+}
+
+func addDep(m map[string]map[string]bool, from, to string) {
+	if m[from] == nil {
+		m[from] = make(map[string]bool)
+	}
+	m[from][to] = true
+}
+
+// location returns the source location of the rpc definition,
+// relative to the app root.
+func location(md *meta.Data, rpc *meta.RPC) string {
+	loc := rpc.Loc
+	if loc == nil || loc.Filename == "" {
+		return ""
+	}
+	dir := strings.TrimPrefix(strings.TrimPrefix(loc.PkgPath, md.ModulePath), "/")
+	return fmt.Sprintf("%s:%d", path.Join(dir, loc.Filename), loc.SrcLineStart)
+}
+
+// evidence returns the evidence of the calls along the edge.
+func (a *analyzer) evidence(e *edge) []Evidence {
+	evidence := slices.Clone(e.static)
+	for _, c := range e.calls {
+		ev := Evidence{
+			Desc:    fmt.Sprintf("%s called %s.%s %d times", c.Caller, c.Service, c.Endpoint, c.Count),
+			TraceID: c.ExampleTraceID,
+		}
+		if rpc := a.rpcs[c.Service][c.Endpoint]; rpc != nil {
+			ev.Location = location(a.md, rpc)
+		}
+		evidence = append(evidence, ev)
+	}
+	return evidence
+}
+
+// cycles reports the services that depend on each other in a cycle,
+// computed as the strongly connected components of the dependency graph
+// using Tarjan's algorithm.
+func (a *analyzer) cycles() []Finding {
+	var (
+		index   = make(map[string]int)
+		lowlink = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		sccs    [][]string
+	)
+
+	var visit func(svc string)
+	visit = func(svc string) {
+		index[svc] = len(index)
+		lowlink[svc] = index[svc]
+		stack = append(stack, svc)
+		onStack[svc] = true
+
+		for _, dep := range sortedKeys(a.deps[svc]) {
+			if _, ok := index[dep]; !ok {
+				visit(dep)
+				lowlink[svc] = min(lowlink[svc], lowlink[dep])
+			} else if onStack[dep] {
+				lowlink[svc] = min(lowlink[svc], index[dep])
+			}
+		}
+
+		if lowlink[svc] == index[svc] {
+			var scc []string
+			for {
+				n := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[n] = false
+				scc = append(scc, n)
+				if n == svc {
+					break
+				}
+			}
+			if len(scc) > 1 {
+				slices.Sort(scc)
+				sccs = append(sccs, scc)
+			}
+		}
+	}
+	for _, svc := range a.svcs {
+		if _, ok := index[svc]; !ok {
+			visit(svc)
+		}
+	}
+	slices.SortFunc(sccs, slices.Compare)
+
+	findings := make([]Finding, 0, len(sccs))
+	for _, scc := range sccs {
+		var evidence []Evidence
+		for _, from := range scc {
+			for _, to := range scc {
+				if e := a.edges[[2]string{from, to}]; e != nil {
+					evidence = append(evidence, a.evidence(e)...)
+				}
+			}
+		}
+		findings = append(findings, Finding{
+			Kind:     Cycle,
+			Services: scc,
+			Summary:  fmt.Sprintf("Services %s depend on each other in a cycle.", joinNames(scc)),
+			Suggestion: "Cyclic dependencies prevent the services from being understood, tested and deployed independently. " +
+				"Consider merging them, or breaking the cycle by moving the shared logic into a separate service " +
+				"or by communicating through Pub/Sub.",
+			Evidence: evidence,
+		})
+	}
+	return findings
+}
+
+// godServices reports services that have many more endpoints than the
+// app's median service, or that both depend on and are depended on
+// by most other services.
+func (a *analyzer) godServices() []Finding {
+	minEndpoints := cmp.Or(a.opts.GodServiceMinEndpoints, 20)
+
+	counts := make([]int, 0, len(a.md.Svcs))
+	for _, svc := range a.md.Svcs {
+		counts = append(counts, len(svc.Rpcs))
+	}
+	slices.Sort(counts)
+	var median int
+	if len(counts) > 0 {
+		median = counts[len(counts)/2]
+	}
+
+	// A service is a hub if it's connected to most other services in both directions.
+	// With fewer than four services most services are trivially connected to most others.
+	others := len(a.svcs) - 1
+	minFan := max(2, (others+1)/2)
+
+	var findings []Finding
+	for _, svc := range a.svcs {
+		endpoints := len(a.rpcs[svc])
+		fanIn, fanOut := sortedKeys(a.rdeps[svc]), sortedKeys(a.deps[svc])
+		large := endpoints >= minEndpoints && endpoints >= 2*median
+		hub := len(a.svcs) >= 4 && len(fanIn) >= minFan && len(fanOut) >= minFan
+		if !large && !hub {
+			continue
+		}
+
+		var reasons []string
+		var evidence []Evidence
+		if large {
+			reasons = append(reasons, fmt.Sprintf("has %d endpoints", endpoints))
+			evidence = append(evidence, Evidence{
+				Desc: fmt.Sprintf("%s has %d endpoints, against a median of %d across the app's %d services",
+					svc, endpoints, median, len(a.svcs)),
+			})
+		}
+		if hub {
+			reasons = append(reasons, fmt.Sprintf("is coupled to %d of the other %d services",
+				len(unionKeys(a.rdeps[svc], a.deps[svc])), others))
+			evidence = append(evidence,
+				Evidence{Desc: fmt.Sprintf("%s is called by %s", svc, joinNames(fanIn))},
+				Evidence{Desc: fmt.Sprintf("%s calls %s", svc, joinNames(fanOut))},
+			)
+		}
+
+		findings = append(findings, Finding{
+			Kind:     GodService,
+			Services: []string{svc},
+			Summary:  fmt.Sprintf("Service %s %s.", svc, strings.Join(reasons, " and ")),
+			Suggestion: "Services that own too much tend to become a bottleneck for changes and deployments. " +
+				"Consider splitting it into smaller services along its domain boundaries.",
+			Evidence: evidence,
+		})
+	}
+	return findings
+}
+
+// chattyPairs reports pairs of services that make many calls to each other
+// relative to the number of requests they handle.
+func (a *analyzer) chattyPairs() []Finding {
+	threshold := cmp.Or(a.opts.ChattyCallsPerRequest, 3)
+	minCalls := cmp.Or(a.opts.ChattyMinCalls, 10)
+
+	seen := make(map[[2]string]bool)
+	var findings []Finding
+	for _, from := range a.svcs {
+		for _, to := range sortedKeys(a.deps[from]) {
+			pair := [2]string{min(from, to), max(from, to)}
+			if seen[pair] {
+				continue
+			}
+			seen[pair] = true
+
+			var (
+				total    int64
+				reasons  []string
+				evidence []Evidence
+			)
+			for _, e := range []*edge{a.edges[pair], a.edges[[2]string{pair[1], pair[0]}]} {
+				if e == nil || e.total == 0 {
+					continue
+				}
+				total += e.total
+				perRequest := float64(e.total) / float64(max(a.usage.Requests[e.from], 1))
+				if perRequest >= threshold {
+					reasons = append(reasons, fmt.Sprintf("%s calls %s %.1f times per request it handles", e.from, e.to, perRequest))
+				}
+				evidence = append(evidence, a.evidence(e)...)
+			}
+			if total < minCalls || len(reasons) == 0 {
+				continue
+			}
+
+			findings = append(findings, Finding{
+				Kind:     ChattyPair,
+				Services: pair[:],
+				Summary:  fmt.Sprintf("Services %s are chatty: %s.", joinNames(pair[:]), strings.Join(reasons, ", and ")),
+				Suggestion: "Services this tightly coupled pay the cost of a network round trip many times per request. " +
+					"Consider merging them, or batching the calls into fewer, coarser-grained endpoints.",
+				Evidence: evidence,
+			})
+		}
+	}
+	return findings
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func unionKeys(a, b map[string]bool) []string {
+	union := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		union[k] = true
+	}
+	for k := range b {
+		union[k] = true
+	}
+	return sortedKeys(union)
+}
+
+// joinNames joins names in human-readable form, like "a, b and c".
+func joinNames(names []string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
@@ -0,0 +1,152 @@
+package svcadvisor
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// testMeta returns metadata for an app with the given services,
+// where each service lives in the package of the same name, and
+// calls maps each service to the endpoints ("svc.Endpoint") it calls.
+func testMeta(endpoints map[string]int, calls map[string][]string) *meta.Data {
+	md := &meta.Data{ModulePath: "example.com/app"}
+	for _, svc := range sortedNames(endpoints) {
+		s := &meta.Service{Name: svc, RelPath: svc}
+		for i := range endpoints[svc] {
+			s.Rpcs = append(s.Rpcs, &meta.RPC{
+				Name:        fmt.Sprintf("E%d", i),
+				ServiceName: svc,
+				Loc: &schema.Loc{
+					PkgPath:      "example.com/app/" + svc,
+					Filename:     "api.go",
+					SrcLineStart: int32(10 * (i + 1)),
+				},
+			})
+		}
+		md.Svcs = append(md.Svcs, s)
+
+		pkg := &meta.Package{RelPath: svc, Name: svc, ServiceName: svc}
+		for _, call := range calls[svc] {
+			for i := range call {
+				if call[i] == '.' {
+					pkg.RpcCalls = append(pkg.RpcCalls, &meta.QualifiedName{Pkg: call[:i], Name: call[i+1:]})
+					break
+				}
+			}
+		}
+		md.Pkgs = append(md.Pkgs, pkg)
+	}
+	return md
+}
+
+func sortedNames(m map[string]int) []string {
+	var names []string
+	for k := range m {
+		names = append(names, k)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestAnalyze_Cycle(t *testing.T) {
+	c := qt.New(t)
+	md := testMeta(map[string]int{"a": 1, "b": 1, "c": 1, "d": 1}, map[string][]string{
+		"a": {"b.E0"},
+		"b": {"c.E0"},
+		"c": {"a.E0"},
+		"d": {"a.E0"},
+	})
+
+	findings := Analyze(md, Usage{}, Options{})
+	c.Assert(findings, qt.HasLen, 1)
+	c.Assert(findings[0].Kind, qt.Equals, Cycle)
+	c.Assert(findings[0].Services, qt.DeepEquals, []string{"a", "b", "c"})
+	c.Assert(findings[0].Summary, qt.Equals, "Services a, b and c depend on each other in a cycle.")
+	c.Assert(findings[0].Evidence, qt.DeepEquals, []Evidence{
+		{Desc: "package a calls b.E0", Location: "b/api.go:10"},
+		{Desc: "package b calls c.E0", Location: "c/api.go:10"},
+		{Desc: "package c calls a.E0", Location: "a/api.go:10"},
+	})
+}
+
+func TestAnalyze_ObservedCycle(t *testing.T) {
+	c := qt.New(t)
+	md := testMeta(map[string]int{"a": 1, "b": 1}, map[string][]string{
+		"a": {"b.E0"},
+	})
+
+	// The call from b to a is only known from traces.
+	usage := Usage{
+		Requests: map[string]int64{"a": 100, "b": 100},
+		Calls:    []Call{{Caller: "b", Service: "a", Endpoint: "E0", Count: 5, ExampleTraceID: "trace1"}},
+	}
+	findings := Analyze(md, usage, Options{})
+	c.Assert(findings, qt.HasLen, 1)
+	c.Assert(findings[0].Kind, qt.Equals, Cycle)
+	c.Assert(findings[0].Evidence, qt.DeepEquals, []Evidence{
+		{Desc: "package a calls b.E0", Location: "b/api.go:10"},
+		{Desc: "b called a.E0 5 times", Location: "a/api.go:10", TraceID: "trace1"},
+	})
+}
+
+func TestAnalyze_ChattyPair(t *testing.T) {
+	c := qt.New(t)
+	md := testMeta(map[string]int{"orders": 2, "inventory": 2, "email": 1}, map[string][]string{
+		"orders": {"inventory.E0", "inventory.E1", "email.E0"},
+	})
+
+	usage := Usage{
+		Requests: map[string]int64{"orders": 10, "inventory": 50, "email": 10},
+		Calls: []Call{
+			{Caller: "orders", Service: "inventory", Endpoint: "E0", Count: 30},
+			{Caller: "orders", Service: "inventory", Endpoint: "E1", Count: 20},
+			{Caller: "orders", Service: "email", Endpoint: "E0", Count: 10},
+		},
+	}
+	findings := Analyze(md, usage, Options{})
+	c.Assert(findings, qt.HasLen, 1)
+	c.Assert(findings[0].Kind, qt.Equals, ChattyPair)
+	c.Assert(findings[0].Services, qt.DeepEquals, []string{"inventory", "orders"})
+	c.Assert(findings[0].Summary, qt.Equals, "Services inventory and orders are chatty: orders calls inventory 5.0 times per request it handles.")
+
+	// Below the minimum number of calls nothing is reported.
+	findings = Analyze(md, usage, Options{ChattyMinCalls: 100})
+	c.Assert(findings, qt.HasLen, 0)
+}
+
+func TestAnalyze_GodService(t *testing.T) {
+	c := qt.New(t)
+
+	t.Run("large", func(t *testing.T) {
+		md := testMeta(map[string]int{"big": 25, "a": 3, "b": 4}, nil)
+		findings := Analyze(md, Usage{}, Options{})
+		c.Assert(findings, qt.HasLen, 1)
+		c.Assert(findings[0].Kind, qt.Equals, GodService)
+		c.Assert(findings[0].Summary, qt.Equals, "Service big has 25 endpoints.")
+		c.Assert(findings[0].Evidence, qt.DeepEquals, []Evidence{
+			{Desc: "big has 25 endpoints, against a median of 4 across the app's 3 services"},
+		})
+	})
+
+	t.Run("hub", func(t *testing.T) {
+		md := testMeta(map[string]int{"hub": 1, "a": 1, "b": 1, "c": 1, "d": 1}, map[string][]string{
+			"hub": {"c.E0", "d.E0"},
+			"a":   {"hub.E0"},
+			"b":   {"hub.E0"},
+		})
+		findings := Analyze(md, Usage{}, Options{})
+		c.Assert(findings, qt.HasLen, 1)
+		c.Assert(findings[0].Kind, qt.Equals, GodService)
+		c.Assert(findings[0].Summary, qt.Equals, "Service hub is coupled to 4 of the other 4 services.")
+		c.Assert(findings[0].Evidence, qt.DeepEquals, []Evidence{
+			{Desc: "hub is called by a and b"},
+			{Desc: "hub calls c and d"},
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package svcadvisor
+
+import (
+	"cmp"
+	"path"
+	"slices"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// UnusedEndpoint is an endpoint that nothing was found to call.
+type UnusedEndpoint struct {
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+
+	// Access is the endpoint's access type: "public", "auth" or "private".
+	Access string `json:"access"`
+
+	// Location is the source location of the endpoint definition,
+	// in "path/to/file.go:line" form relative to the app root.
+	Location string `json:"location,omitempty"`
+}
+
+// UnusedEndpoints reports the endpoints in md that are not called by any
+// package in the app, not invoked by a cron job, and that haven't been called
+// from outside the app according to usage, such as through a generated client.
+//
+// Endpoints matching any of the allow patterns are never reported. This is
+// intended for endpoints only called by external systems, such as webhooks.
+// A pattern is either a service name, matching all of its endpoints, or
+// a "service.Endpoint" pattern following the syntax of [path.Match].
+//
+// The endpoints are returned sorted by service and endpoint name.
+func UnusedEndpoints(md *meta.Data, usage Usage, allow []string) []UnusedEndpoint {
+	called := make(map[[2]string]bool) // [service, endpoint]
+
+	pkgSvc := make(map[string]string)
+	for _, pkg := range md.Pkgs {
+		pkgSvc[pkg.RelPath] = pkg.ServiceName
+	}
+	for _, pkg := range md.Pkgs {
+		for _, call := range pkg.RpcCalls {
+			called[[2]string{pkgSvc[call.Pkg], call.Name}] = true
+		}
+	}
+	for _, job := range md.CronJobs {
+		if job.Endpoint != nil {
+			called[[2]string{pkgSvc[job.Endpoint.Pkg], job.Endpoint.Name}] = true
+		}
+	}
+	for _, c := range usage.Calls {
+		if c.Count > 0 {
+			called[[2]string{c.Service, c.Endpoint}] = true
+		}
+	}
+
+	var unused []UnusedEndpoint
+	for _, svc := range md.Svcs {
+		for _, rpc := range svc.Rpcs {
+			switch {
+			case called[[2]string{svc.Name, rpc.Name}],
+				usage.External[svc.Name+"."+rpc.Name] > 0,
+				allowed(allow, svc.Name, rpc.Name):
+				continue
+			}
+			unused = append(unused, UnusedEndpoint{
+				Service:  svc.Name,
+				Endpoint: rpc.Name,
+				Access:   accessName(rpc.AccessType),
+				Location: location(md, rpc),
+			})
+		}
+	}
+
+	slices.SortFunc(unused, func(a, b UnusedEndpoint) int {
+		return cmp.Or(
+			cmp.Compare(a.Service, b.Service),
+			cmp.Compare(a.Endpoint, b.Endpoint),
+		)
+	})
+	return unused
+}
+
+// allowed reports whether the endpoint matches any of the allow patterns.
+func allowed(allow []string, svc, endpoint string) bool {
+	for _, pattern := range allow {
+		if pattern == svc {
+			return true
+		}
+		if ok, _ := path.Match(pattern, svc+"."+endpoint); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func accessName(access meta.RPC_AccessType) string {
+	switch access {
+	case meta.RPC_PUBLIC:
+		return "public"
+	case meta.RPC_AUTH:
+		return "auth"
+	default:
+		return "private"
+	}
+}
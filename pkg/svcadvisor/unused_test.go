@@ -0,0 +1,33 @@
+package svcadvisor
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestUnusedEndpoints(t *testing.T) {
+	c := qt.New(t)
+	md := testMeta(map[string]int{"a": 3, "b": 3, "hooks": 2}, map[string][]string{
+		"a": {"b.E0"},
+	})
+	md.Svcs[0].Rpcs[0].AccessType = meta.RPC_PUBLIC // a.E0
+	md.CronJobs = []*meta.CronJob{{Id: "cleanup", Endpoint: &meta.QualifiedName{Pkg: "b", Name: "E1"}}}
+
+	usage := Usage{External: map[string]int64{"a.E1": 3}}
+	got := UnusedEndpoints(md, usage, nil)
+	c.Assert(got, qt.DeepEquals, []UnusedEndpoint{
+		{Service: "a", Endpoint: "E0", Access: "public", Location: "a/api.go:10"},
+		{Service: "a", Endpoint: "E2", Access: "private", Location: "a/api.go:30"},
+		{Service: "b", Endpoint: "E2", Access: "private", Location: "b/api.go:30"},
+		{Service: "hooks", Endpoint: "E0", Access: "private", Location: "hooks/api.go:10"},
+		{Service: "hooks", Endpoint: "E1", Access: "private", Location: "hooks/api.go:20"},
+	})
+
+	got = UnusedEndpoints(md, usage, []string{"hooks", "a.E*"})
+	c.Assert(got, qt.DeepEquals, []UnusedEndpoint{
+		{Service: "b", Endpoint: "E2", Access: "private", Location: "b/api.go:30"},
+	})
+}
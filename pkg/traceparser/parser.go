@@ -76,6 +76,7 @@ type spanStartEvent struct {
 	DefLoc           option.Option[uint32]
 	CallerEventID    option.Option[trace2.EventID]
 	ExtCorrelationID option.Option[string]
+	Region           option.Option[string]
 }
 
 type spanEndEvent struct {
@@ -84,6 +85,7 @@ type spanEndEvent struct {
 	PanicStack    option.Option[*tracepb2.StackTrace]
 	ParentTraceID option.Option[*tracepb2.TraceID]
 	ParentSpanID  option.Option[uint64]
+	MemStats      *tracepb2.SpanMemStats
 }
 
 type traceParser struct {
@@ -156,6 +158,10 @@ func (tp *traceParser) spanStartEvent() spanStartEvent {
 	defLoc := uint32(tp.UVarint())
 	callerEventID := trace2.EventID(tp.UVarint())
 	extCorrelationID := tp.String()
+	var region string
+	if tp.version >= 31 {
+		region = tp.String()
+	}
 
 	ev := spanStartEvent{
 		Goid:             goid,
@@ -163,6 +169,7 @@ func (tp *traceParser) spanStartEvent() spanStartEvent {
 		DefLoc:           option.AsOptional(defLoc),
 		CallerEventID:    option.AsOptional(callerEventID),
 		ExtCorrelationID: option.AsOptional(extCorrelationID),
+		Region:           option.AsOptional(region),
 	}
 	if !parentTraceID.IsZero() {
 		ev.ParentTraceID = option.Some(parentTraceID)
@@ -189,6 +196,13 @@ func (tp *traceParser) spanEndEvent() spanEndEvent {
 	if !parentTraceID.IsZero() {
 		ev.ParentTraceID = option.Some(parentTraceID)
 	}
+	if tp.version >= 27 && tp.Bool() {
+		ev.MemStats = &tracepb2.SpanMemStats{
+			AllocBytes:   tp.UVarint(),
+			AllocObjects: tp.UVarint(),
+			HeapObjects:  tp.UVarint(),
+		}
+	}
 	return ev
 }
 
@@ -222,6 +236,14 @@ func (tp *traceParser) spanEvent(eventType trace2.EventType) *tracepb2.SpanEvent
 		ev.Data = &tracepb2.SpanEvent_PubsubPublishStart{PubsubPublishStart: tp.pubsubPublishStart()}
 	case trace2.PubsubPublishEnd:
 		ev.Data = &tracepb2.SpanEvent_PubsubPublishEnd{PubsubPublishEnd: tp.pubsubPublishEnd()}
+	case trace2.PubsubMessageLink:
+		ev.Data = &tracepb2.SpanEvent_PubsubMessageLink{PubsubMessageLink: tp.pubsubMessageLink()}
+	case trace2.StreamMessage:
+		ev.Data = &tracepb2.SpanEvent_StreamMessage{StreamMessage: tp.streamMessage()}
+	case trace2.BucketObjectUpdateAttrsStart:
+		ev.Data = &tracepb2.SpanEvent_BucketObjectUpdateAttrsStart{BucketObjectUpdateAttrsStart: tp.bucketObjectUpdateAttrsStart()}
+	case trace2.BucketObjectUpdateAttrsEnd:
+		ev.Data = &tracepb2.SpanEvent_BucketObjectUpdateAttrsEnd{BucketObjectUpdateAttrsEnd: tp.bucketObjectUpdateAttrsEnd()}
 	case trace2.HTTPCallStart:
 		ev.Data = &tracepb2.SpanEvent_HttpCallStart{HttpCallStart: tp.httpCallStart()}
 	case trace2.HTTPCallEnd:
@@ -242,6 +264,8 @@ func (tp *traceParser) spanEvent(eventType trace2.EventType) *tracepb2.SpanEvent
 		ev.Data = &tracepb2.SpanEvent_BucketObjectUploadStart{BucketObjectUploadStart: tp.bucketObjectUploadStart()}
 	case trace2.BucketObjectUploadEnd:
 		ev.Data = &tracepb2.SpanEvent_BucketObjectUploadEnd{BucketObjectUploadEnd: tp.bucketObjectUploadEnd()}
+	case trace2.BucketObjectUploadPart:
+		ev.Data = &tracepb2.SpanEvent_BucketObjectUploadPart{BucketObjectUploadPart: tp.bucketObjectUploadPart()}
 	case trace2.BucketObjectDownloadStart:
 		ev.Data = &tracepb2.SpanEvent_BucketObjectDownloadStart{BucketObjectDownloadStart: tp.bucketObjectDownloadStart()}
 	case trace2.BucketObjectDownloadEnd:
@@ -258,6 +282,12 @@ func (tp *traceParser) spanEvent(eventType trace2.EventType) *tracepb2.SpanEvent
 		ev.Data = &tracepb2.SpanEvent_BucketDeleteObjectsStart{BucketDeleteObjectsStart: tp.bucketDeleteObjectsStart()}
 	case trace2.BucketDeleteObjectsEnd:
 		ev.Data = &tracepb2.SpanEvent_BucketDeleteObjectsEnd{BucketDeleteObjectsEnd: tp.bucketDeleteObjectsEnd()}
+	case trace2.BucketSignedURL:
+		ev.Data = &tracepb2.SpanEvent_BucketSignedUrl{BucketSignedUrl: tp.bucketSignedURL()}
+	case trace2.BucketObjectCopyStart:
+		ev.Data = &tracepb2.SpanEvent_BucketObjectCopyStart{BucketObjectCopyStart: tp.bucketObjectCopyStart()}
+	case trace2.BucketObjectCopyEnd:
+		ev.Data = &tracepb2.SpanEvent_BucketObjectCopyEnd{BucketObjectCopyEnd: tp.bucketObjectCopyEnd()}
 
 	default:
 		tp.bailout(fmt.Errorf("unknown event %v", eventType))
@@ -276,6 +306,7 @@ func (tp *traceParser) requestSpanStart() *tracepb2.SpanStart {
 		DefLoc:                spanStart.DefLoc.PtrOrNil(),
 		CallerEventId:         (*uint64)(spanStart.CallerEventID.PtrOrNil()),
 		ExternalCorrelationId: spanStart.ExtCorrelationID.PtrOrNil(),
+		Region:                spanStart.Region.PtrOrNil(),
 		Data: &tracepb2.SpanStart_Request{
 			Request: &tracepb2.RequestSpanStart{
 				ServiceName:  tp.String(),
@@ -313,6 +344,7 @@ func (tp *traceParser) requestSpanEnd() *tracepb2.SpanEnd {
 		PanicStack:    spanEnd.PanicStack.GetOrElse(nil),
 		ParentTraceId: spanEnd.ParentTraceID.GetOrElse(nil),
 		ParentSpanId:  spanEnd.ParentSpanID.PtrOrNil(),
+		MemStats:      spanEnd.MemStats,
 		Data: &tracepb2.SpanEnd_Request{
 			Request: &tracepb2.RequestSpanEnd{
 				ServiceName:     tp.String(),
@@ -335,6 +367,7 @@ func (tp *traceParser) authSpanStart() *tracepb2.SpanStart {
 		DefLoc:                spanStart.DefLoc.PtrOrNil(),
 		CallerEventId:         (*uint64)(spanStart.CallerEventID.PtrOrNil()),
 		ExternalCorrelationId: spanStart.ExtCorrelationID.PtrOrNil(),
+		Region:                spanStart.Region.PtrOrNil(),
 		Data: &tracepb2.SpanStart_Auth{
 			Auth: &tracepb2.AuthSpanStart{
 				ServiceName:  tp.String(),
@@ -353,6 +386,7 @@ func (tp *traceParser) authSpanEnd() *tracepb2.SpanEnd {
 		PanicStack:    spanEnd.PanicStack.GetOrElse(nil),
 		ParentTraceId: spanEnd.ParentTraceID.GetOrElse(nil),
 		ParentSpanId:  spanEnd.ParentSpanID.PtrOrNil(),
+		MemStats:      spanEnd.MemStats,
 		Data: &tracepb2.SpanEnd_Auth{
 			Auth: &tracepb2.AuthSpanEnd{
 				ServiceName:  tp.String(),
@@ -367,6 +401,19 @@ func (tp *traceParser) authSpanEnd() *tracepb2.SpanEnd {
 func (tp *traceParser) pubsubMessageSpanStart() *tracepb2.SpanStart {
 	spanStart := tp.spanStartEvent()
 
+	msg := &tracepb2.PubsubMessageSpanStart{
+		ServiceName:      tp.String(),
+		TopicName:        tp.String(),
+		SubscriptionName: tp.String(),
+		MessageId:        tp.String(),
+		Attempt:          uint32(tp.UVarint()),
+		PublishTime:      tp.Time(), // TODO use nanotime
+		MessagePayload:   tp.ByteString(),
+	}
+	if tp.version >= 18 {
+		msg.Replayed = tp.Bool()
+	}
+
 	return &tracepb2.SpanStart{
 		Goid:                  spanStart.Goid,
 		ParentTraceId:         spanStart.ParentTraceID.GetOrElse(nil),
@@ -374,16 +421,9 @@ func (tp *traceParser) pubsubMessageSpanStart() *tracepb2.SpanStart {
 		DefLoc:                spanStart.DefLoc.PtrOrNil(),
 		CallerEventId:         (*uint64)(spanStart.CallerEventID.PtrOrNil()),
 		ExternalCorrelationId: spanStart.ExtCorrelationID.PtrOrNil(),
+		Region:                spanStart.Region.PtrOrNil(),
 		Data: &tracepb2.SpanStart_PubsubMessage{
-			PubsubMessage: &tracepb2.PubsubMessageSpanStart{
-				ServiceName:      tp.String(),
-				TopicName:        tp.String(),
-				SubscriptionName: tp.String(),
-				MessageId:        tp.String(),
-				Attempt:          uint32(tp.UVarint()),
-				PublishTime:      tp.Time(), // TODO use nanotime
-				MessagePayload:   tp.ByteString(),
-			},
+			PubsubMessage: msg,
 		},
 	}
 }
@@ -396,6 +436,7 @@ func (tp *traceParser) pubsubMessageSpanEnd() *tracepb2.SpanEnd {
 		PanicStack:    spanEnd.PanicStack.GetOrElse(nil),
 		ParentTraceId: spanEnd.ParentTraceID.GetOrElse(nil),
 		ParentSpanId:  spanEnd.ParentSpanID.PtrOrNil(),
+		MemStats:      spanEnd.MemStats,
 		Data: &tracepb2.SpanEnd_PubsubMessage{
 			PubsubMessage: &tracepb2.PubsubMessageSpanEnd{
 				ServiceName:      tp.String(),
@@ -416,6 +457,7 @@ func (tp *traceParser) testSpanStart() *tracepb2.SpanStart {
 		DefLoc:                spanStart.DefLoc.PtrOrNil(),
 		CallerEventId:         (*uint64)(spanStart.CallerEventID.PtrOrNil()),
 		ExternalCorrelationId: spanStart.ExtCorrelationID.PtrOrNil(),
+		Region:                spanStart.Region.PtrOrNil(),
 		Data: &tracepb2.SpanStart_Test{
 			Test: &tracepb2.TestSpanStart{
 				ServiceName: tp.String(),
@@ -436,6 +478,7 @@ func (tp *traceParser) testSpanEnd() *tracepb2.SpanEnd {
 		PanicStack:    spanEnd.PanicStack.GetOrElse(nil),
 		ParentTraceId: spanEnd.ParentTraceID.GetOrElse(nil),
 		ParentSpanId:  spanEnd.ParentSpanID.PtrOrNil(),
+		MemStats:      spanEnd.MemStats,
 		Data: &tracepb2.SpanEnd_Test{
 			Test: &tracepb2.TestSpanEnd{
 				ServiceName: tp.String(),
@@ -462,10 +505,29 @@ func (tp *traceParser) rpcCallEnd() *tracepb2.RPCCallEnd {
 }
 
 func (tp *traceParser) dbQueryStart() *tracepb2.DBQueryStart {
-	return &tracepb2.DBQueryStart{
+	ev := &tracepb2.DBQueryStart{
 		Query: tp.String(),
 		Stack: tp.stack(),
 	}
+	if tp.version >= 28 {
+		if name := tp.String(); name != "" {
+			ev.StmtName = &name
+		}
+	}
+	if tp.version >= 29 {
+		if node := tp.String(); node != "" {
+			ev.Node = &node
+		}
+	}
+	if tp.version >= 30 {
+		if rows := tp.UVarint(); rows > 0 {
+			ev.Rows = &rows
+		}
+		if attempt := uint32(tp.UVarint()); attempt > 0 {
+			ev.Attempt = &attempt
+		}
+	}
+	return ev
 }
 
 func (tp *traceParser) dbQueryEnd() *tracepb2.DBQueryEnd {
@@ -481,7 +543,7 @@ func (tp *traceParser) dbTransactionStart() *tracepb2.DBTransactionStart {
 }
 
 func (tp *traceParser) dbTransactionEnd() *tracepb2.DBTransactionEnd {
-	return &tracepb2.DBTransactionEnd{
+	ev := &tracepb2.DBTransactionEnd{
 		Completion: (func() tracepb2.DBTransactionEnd_CompletionType {
 			if commit := tp.Bool(); commit {
 				return tracepb2.DBTransactionEnd_COMMIT
@@ -492,21 +554,60 @@ func (tp *traceParser) dbTransactionEnd() *tracepb2.DBTransactionEnd {
 		Stack: tp.stack(),
 		Err:   tp.errWithStack(),
 	}
+	if tp.version >= 17 {
+		ev.Retries = uint32(tp.UVarint())
+	}
+	return ev
 }
 
 func (tp *traceParser) pubsubPublishStart() *tracepb2.PubsubPublishStart {
-	return &tracepb2.PubsubPublishStart{
+	ev := &tracepb2.PubsubPublishStart{
 		Topic:   tp.String(),
 		Message: tp.ByteString(),
 		Stack:   tp.stack(),
 	}
+	if tp.version >= 16 {
+		n := tp.UVarint()
+		for i := 0; i < int(n); i++ {
+			ev.Batch = append(ev.Batch, tp.ByteString())
+		}
+	}
+	return ev
 }
 
 func (tp *traceParser) pubsubPublishEnd() *tracepb2.PubsubPublishEnd {
-	return &tracepb2.PubsubPublishEnd{
+	ev := &tracepb2.PubsubPublishEnd{
 		MessageId: ptrOrNil(tp.String()),
 		Err:       tp.errWithStack(),
 	}
+	if tp.version >= 16 {
+		n := tp.UVarint()
+		for i := 0; i < int(n); i++ {
+			res := &tracepb2.PubsubPublishResult{MessageId: ptrOrNil(tp.String())}
+			if msg := tp.String(); msg != "" {
+				res.Err = &tracepb2.Error{Msg: msg}
+			}
+			ev.BatchResults = append(ev.BatchResults, res)
+		}
+	}
+	return ev
+}
+
+func (tp *traceParser) pubsubMessageLink() *tracepb2.PubsubMessageLink {
+	return &tracepb2.PubsubMessageLink{
+		TraceId: tp.traceID(),
+		SpanId:  tp.spanID(),
+		App:     tp.String(),
+		Env:     tp.String(),
+	}
+}
+
+func (tp *traceParser) streamMessage() *tracepb2.StreamMessage {
+	return &tracepb2.StreamMessage{
+		Direction: tracepb2.StreamMessage_Direction(tp.Byte()),
+		Seq:       tp.UVarint(),
+		Size:      tp.UVarint(),
+	}
 }
 
 func (tp *traceParser) serviceInitStart() *tracepb2.ServiceInitStart {
@@ -565,23 +666,43 @@ func (tp *traceParser) cacheCallStart() *tracepb2.CacheCallStart {
 }
 
 func (tp *traceParser) cacheCallEnd() *tracepb2.CacheCallEnd {
-	return &tracepb2.CacheCallEnd{
-		Result: (func() tracepb2.CacheCallEnd_Result {
-			res := tp.Byte()
-			switch trace2.CacheCallResult(res) {
-			case trace2.CacheOK:
-				return tracepb2.CacheCallEnd_OK
-			case trace2.CacheNoSuchKey:
-				return tracepb2.CacheCallEnd_NO_SUCH_KEY
-			case trace2.CacheConflict:
-				return tracepb2.CacheCallEnd_CONFLICT
-			case trace2.CacheErr:
-				return tracepb2.CacheCallEnd_ERR
-			default:
-				return tracepb2.CacheCallEnd_UNKNOWN
+	ev := &tracepb2.CacheCallEnd{
+		Result: tp.cacheCallResult(),
+		Err:    tp.errWithStack(),
+	}
+	if tp.version >= 22 {
+		n := tp.UVarint()
+		for i := 0; i < int(n); i++ {
+			res := &tracepb2.CacheOpResult{
+				Operation: tp.String(),
+				Key:       tp.String(),
+				Result:    tp.cacheCallResult(),
 			}
-		})(),
-		Err: tp.errWithStack(),
+			if msg := tp.String(); msg != "" {
+				res.Err = &tracepb2.Error{Msg: msg}
+			}
+			ev.OpResults = append(ev.OpResults, res)
+		}
+	}
+	if tp.version >= 24 {
+		ev.IoDurationNanos = uint64(tp.Duration())
+		ev.CodecDurationNanos = uint64(tp.Duration())
+	}
+	return ev
+}
+
+func (tp *traceParser) cacheCallResult() tracepb2.CacheCallEnd_Result {
+	switch trace2.CacheCallResult(tp.Byte()) {
+	case trace2.CacheOK:
+		return tracepb2.CacheCallEnd_OK
+	case trace2.CacheNoSuchKey:
+		return tracepb2.CacheCallEnd_NO_SUCH_KEY
+	case trace2.CacheConflict:
+		return tracepb2.CacheCallEnd_CONFLICT
+	case trace2.CacheErr:
+		return tracepb2.CacheCallEnd_ERR
+	default:
+		return tracepb2.CacheCallEnd_UNKNOWN
 	}
 }
 
@@ -611,20 +732,56 @@ func (tp *traceParser) bucketObjectUploadEnd() *tracepb2.BucketObjectUploadEnd {
 	}
 }
 
+func (tp *traceParser) bucketObjectUploadPart() *tracepb2.BucketObjectUploadPart {
+	return &tracepb2.BucketObjectUploadPart{
+		PartNumber: uint32(tp.UVarint()),
+		Size:       tp.UVarint(),
+		Attempts:   uint32(tp.UVarint()),
+		Err:        tp.errWithStack(),
+	}
+}
+
 func (tp *traceParser) bucketObjectDownloadStart() *tracepb2.BucketObjectDownloadStart {
-	return &tracepb2.BucketObjectDownloadStart{
+	ev := &tracepb2.BucketObjectDownloadStart{
 		Bucket:  tp.String(),
 		Object:  tp.String(),
 		Version: tp.OptString(),
 		Stack:   tp.stack(),
 	}
+	if tp.version >= 25 {
+		ev.Offset = tp.UVarint()
+		ev.Length = tp.OptUVarint()
+	}
+	return ev
 }
 
 func (tp *traceParser) bucketObjectDownloadEnd() *tracepb2.BucketObjectDownloadEnd {
-	return &tracepb2.BucketObjectDownloadEnd{
+	ev := &tracepb2.BucketObjectDownloadEnd{
 		Size: tp.OptUVarint(),
 		Err:  tp.errWithStack(),
 	}
+	if tp.version >= 25 {
+		ev.Resumes = uint32(tp.UVarint())
+	}
+	return ev
+}
+
+func (tp *traceParser) bucketObjectCopyStart() *tracepb2.BucketObjectCopyStart {
+	return &tracepb2.BucketObjectCopyStart{
+		Bucket:     tp.String(),
+		Src:        tp.String(),
+		Dst:        tp.String(),
+		SrcVersion: tp.OptString(),
+		Stack:      tp.stack(),
+	}
+}
+
+func (tp *traceParser) bucketObjectCopyEnd() *tracepb2.BucketObjectCopyEnd {
+	return &tracepb2.BucketObjectCopyEnd{
+		Size:    tp.OptUVarint(),
+		Version: tp.OptString(),
+		Err:     tp.errWithStack(),
+	}
 }
 
 func (tp *traceParser) bucketDeleteObjectsStart() *tracepb2.BucketDeleteObjectsStart {
@@ -650,6 +807,18 @@ func (tp *traceParser) bucketDeleteObjectsEnd() *tracepb2.BucketDeleteObjectsEnd
 	}
 }
 
+func (tp *traceParser) bucketSignedURL() *tracepb2.BucketSignedURL {
+	return &tracepb2.BucketSignedURL{
+		Bucket:      tp.String(),
+		Object:      tp.String(),
+		Operation:   tracepb2.BucketSignedURL_Operation(tp.Byte()),
+		TtlNanos:    uint64(tp.Duration()),
+		ContentType: tp.OptString(),
+		Err:         tp.errWithStack(),
+		Stack:       tp.stack(),
+	}
+}
+
 func (tp *traceParser) bucketListObjectsStart() *tracepb2.BucketListObjectsStart {
 	return &tracepb2.BucketListObjectsStart{
 		Bucket: tp.String(),
@@ -687,6 +856,38 @@ func (tp *traceParser) bucketObjectGetAttrsEnd() *tracepb2.BucketObjectGetAttrsE
 	return ev
 }
 
+func (tp *traceParser) bucketObjectUpdateAttrsStart() *tracepb2.BucketObjectUpdateAttrsStart {
+	ev := &tracepb2.BucketObjectUpdateAttrsStart{
+		Bucket:      tp.String(),
+		Object:      tp.String(),
+		ContentType: tp.OptString(),
+	}
+
+	ev.UpdateMetadata = tp.Bool()
+	for n := tp.UVarint(); n > 0; n-- {
+		ev.MetadataKeys = append(ev.MetadataKeys, tp.String())
+	}
+	ev.UpdateTags = tp.Bool()
+	for n := tp.UVarint(); n > 0; n-- {
+		ev.TagKeys = append(ev.TagKeys, tp.String())
+	}
+	ev.Stack = tp.stack()
+
+	return ev
+}
+
+func (tp *traceParser) bucketObjectUpdateAttrsEnd() *tracepb2.BucketObjectUpdateAttrsEnd {
+	ev := &tracepb2.BucketObjectUpdateAttrsEnd{
+		Err: tp.errWithStack(),
+	}
+
+	if ev.Err == nil {
+		ev.Attrs = tp.bucketObjectAttrs()
+	}
+
+	return ev
+}
+
 func (tp *traceParser) bodyStream() *tracepb2.BodyStream {
 	flags := tp.Byte()
 	data := tp.ByteString()
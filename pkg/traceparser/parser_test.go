@@ -232,6 +232,7 @@ func TestParse(t *testing.T) {
 						Attempt:      3,
 						Published:    now,
 						Payload:      []byte("payload"),
+						Replayed:     true,
 					},
 				}, goid)
 			},
@@ -253,6 +254,7 @@ func TestParse(t *testing.T) {
 							Attempt:          3,
 							PublishTime:      pbNow,
 							MessagePayload:   []byte("payload"),
+							Replayed:         true,
 						},
 					},
 				}},
@@ -367,6 +369,37 @@ func TestParse(t *testing.T) {
 			},
 		},
 
+		{
+			Name: "DBQueryStart_Fields",
+			Emit: func(l *trace2.Log) {
+				l.DBQueryStart(trace2.DBQueryStartParams{
+					EventParams: ep,
+					Query:       "query",
+					StmtName:    "get_user",
+					Node:        "replica-0",
+					Rows:        3,
+					Attempt:     2,
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_DbQueryStart{
+						DbQueryStart: &tracepb2.DBQueryStart{
+							Query:    "query",
+							StmtName: ptr("get_user"),
+							Node:     ptr("replica-0"),
+							Rows:     ptr[uint64](3),
+							Attempt:  ptr[uint32](2),
+						},
+					},
+				}},
+			},
+		},
+
 		{
 			Name: "DBQueryEnd",
 			Emit: func(l *trace2.Log) {
@@ -435,6 +468,34 @@ func TestParse(t *testing.T) {
 			},
 		},
 
+		{
+			Name: "DBTransactionEnd_Retried",
+			Emit: func(l *trace2.Log) {
+				l.DBTransactionEnd(trace2.DBTransactionEndParams{
+					EventParams: ep,
+					StartID:     1,
+					Commit:      false,
+					Stack:       stack.Stack{},
+					Retries:     2,
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_DbTransactionEnd{
+						DbTransactionEnd: &tracepb2.DBTransactionEnd{
+							Completion: tracepb2.DBTransactionEnd_ROLLBACK,
+							Retries:    2,
+						},
+					},
+				}},
+			},
+		},
+
 		{
 			Name: "PubsubPublishStart",
 			Emit: func(l *trace2.Log) {
@@ -462,6 +523,282 @@ func TestParse(t *testing.T) {
 			},
 		},
 
+		{
+			Name: "BucketSignedURL",
+			Emit: func(l *trace2.Log) {
+				l.BucketSignedURL(trace2.BucketSignedURLParams{
+					EventParams: ep,
+					Bucket:      "uploads",
+					Object:      "avatar.png",
+					Operation:   trace2.BucketSignedUpload,
+					TTL:         15 * time.Minute,
+					ContentType: ptr("image/png"),
+					Stack:       stack.Stack{},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_BucketSignedUrl{
+						BucketSignedUrl: &tracepb2.BucketSignedURL{
+							Bucket:      "uploads",
+							Object:      "avatar.png",
+							Operation:   tracepb2.BucketSignedURL_UPLOAD,
+							TtlNanos:    uint64(15 * time.Minute),
+							ContentType: ptr("image/png"),
+							Stack:       nil,
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "BucketObjectUploadPart",
+			Emit: func(l *trace2.Log) {
+				l.BucketObjectUploadPart(trace2.BucketObjectUploadPartParams{
+					EventParams: ep,
+					StartID:     1,
+					PartNumber:  3,
+					Size:        10 << 20,
+					Attempts:    2,
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_BucketObjectUploadPart{
+						BucketObjectUploadPart: &tracepb2.BucketObjectUploadPart{
+							PartNumber: 3,
+							Size:       10 << 20,
+							Attempts:   2,
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "BucketObjectDownloadStart_Range",
+			Emit: func(l *trace2.Log) {
+				l.BucketObjectDownloadStart(trace2.BucketObjectDownloadStartParams{
+					EventParams: ep,
+					Bucket:      "media",
+					Object:      "video.mp4",
+					Stack:       stack.Stack{},
+					Offset:      1024,
+					Length:      ptr[uint64](4096),
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_BucketObjectDownloadStart{
+						BucketObjectDownloadStart: &tracepb2.BucketObjectDownloadStart{
+							Bucket: "media",
+							Object: "video.mp4",
+							Offset: 1024,
+							Length: ptr[uint64](4096),
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "BucketObjectDownloadEnd_Resumed",
+			Emit: func(l *trace2.Log) {
+				l.BucketObjectDownloadEnd(trace2.BucketObjectDownloadEndParams{
+					EventParams: ep,
+					StartID:     1,
+					Size:        4096,
+					Resumes:     2,
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_BucketObjectDownloadEnd{
+						BucketObjectDownloadEnd: &tracepb2.BucketObjectDownloadEnd{
+							Size:    ptr[uint64](4096),
+							Resumes: 2,
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "BucketObjectCopyStart",
+			Emit: func(l *trace2.Log) {
+				l.BucketObjectCopyStart(trace2.BucketObjectCopyStartParams{
+					EventParams: ep,
+					Bucket:      "documents",
+					Src:         "inbox/report.pdf",
+					Dst:         "archive/report.pdf",
+					SrcVersion:  ptr("v1"),
+					Stack:       stack.Stack{},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_BucketObjectCopyStart{
+						BucketObjectCopyStart: &tracepb2.BucketObjectCopyStart{
+							Bucket:     "documents",
+							Src:        "inbox/report.pdf",
+							Dst:        "archive/report.pdf",
+							SrcVersion: ptr("v1"),
+							Stack:      nil,
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "BucketObjectCopyEnd",
+			Emit: func(l *trace2.Log) {
+				l.BucketObjectCopyEnd(trace2.BucketObjectCopyEndParams{
+					EventParams: ep,
+					StartID:     1,
+					Size:        1024,
+					Version:     ptr("v2"),
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_BucketObjectCopyEnd{
+						BucketObjectCopyEnd: &tracepb2.BucketObjectCopyEnd{
+							Size:    ptr[uint64](1024),
+							Version: ptr("v2"),
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "BucketObjectUpdateAttrsStart",
+			Emit: func(l *trace2.Log) {
+				l.BucketObjectUpdateAttrsStart(trace2.BucketObjectUpdateAttrsStartParams{
+					EventParams:  ep,
+					Bucket:       "documents",
+					Object:       "report.pdf",
+					ContentType:  ptr("application/pdf"),
+					MetadataKeys: nil,
+					TagKeys:      []string{},
+					Stack:        stack.Stack{},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_BucketObjectUpdateAttrsStart{
+						BucketObjectUpdateAttrsStart: &tracepb2.BucketObjectUpdateAttrsStart{
+							Bucket:         "documents",
+							Object:         "report.pdf",
+							ContentType:    ptr("application/pdf"),
+							UpdateMetadata: false,
+							UpdateTags:     true,
+							Stack:          nil,
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "BucketObjectUpdateAttrsStart_Keys",
+			Emit: func(l *trace2.Log) {
+				l.BucketObjectUpdateAttrsStart(trace2.BucketObjectUpdateAttrsStartParams{
+					EventParams:  ep,
+					Bucket:       "documents",
+					Object:       "report.pdf",
+					MetadataKeys: []string{"author", "title"},
+					TagKeys:      []string{"customer"},
+					Stack:        stack.Stack{},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_BucketObjectUpdateAttrsStart{
+						BucketObjectUpdateAttrsStart: &tracepb2.BucketObjectUpdateAttrsStart{
+							Bucket:         "documents",
+							Object:         "report.pdf",
+							UpdateMetadata: true,
+							MetadataKeys:   []string{"author", "title"},
+							UpdateTags:     true,
+							TagKeys:        []string{"customer"},
+							Stack:          nil,
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "BucketObjectUpdateAttrsEnd",
+			Emit: func(l *trace2.Log) {
+				l.BucketObjectUpdateAttrsEnd(trace2.BucketObjectUpdateAttrsEndParams{
+					EventParams: ep,
+					StartID:     1,
+					Attrs: &trace2.BucketObjectAttributes{
+						Size:        ptr[uint64](1024),
+						Version:     ptr("v2"),
+						ContentType: ptr("application/pdf"),
+					},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_BucketObjectUpdateAttrsEnd{
+						BucketObjectUpdateAttrsEnd: &tracepb2.BucketObjectUpdateAttrsEnd{
+							Attrs: &tracepb2.BucketObjectAttributes{
+								Size:        ptr[uint64](1024),
+								Version:     ptr("v2"),
+								ContentType: ptr("application/pdf"),
+							},
+						},
+					},
+				}},
+			},
+		},
+
 		{
 			Name: "PubsubPublishEnd",
 			Emit: func(l *trace2.Log) {
@@ -489,6 +826,121 @@ func TestParse(t *testing.T) {
 			},
 		},
 
+		{
+			Name: "PubsubMessageLink",
+			Emit: func(l *trace2.Log) {
+				l.PubsubMessageLink(trace2.PubsubMessageLinkParams{
+					EventParams:   ep,
+					LinkedTraceID: model.TraceID{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+					LinkedSpanID:  model.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+					App:           "billing",
+					Env:           "prod",
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_PubsubMessageLink{
+						PubsubMessageLink: &tracepb2.PubsubMessageLink{
+							TraceId: &tracepb2.TraceID{High: 72623859790382856, Low: 651345242494996240},
+							SpanId:  578437695752307201,
+							App:     "billing",
+							Env:     "prod",
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "StreamMessage",
+			Emit: func(l *trace2.Log) {
+				l.StreamMessage(trace2.StreamMessageParams{
+					EventParams: ep,
+					Outgoing:    true,
+					Seq:         3,
+					Size:        128,
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_StreamMessage{
+						StreamMessage: &tracepb2.StreamMessage{
+							Direction: tracepb2.StreamMessage_OUTGOING,
+							Seq:       3,
+							Size:      128,
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "PubsubPublishStart_Batch",
+			Emit: func(l *trace2.Log) {
+				l.PubsubPublishStart(trace2.PubsubPublishStartParams{
+					EventParams: ep,
+					Topic:       "topic",
+					Stack:       stack.Stack{},
+					Batch:       [][]byte{[]byte("one"), []byte("two")},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_PubsubPublishStart{
+						PubsubPublishStart: &tracepb2.PubsubPublishStart{
+							Topic:   "topic",
+							Message: []byte{},
+							Stack:   nil,
+							Batch:   [][]byte{[]byte("one"), []byte("two")},
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "PubsubPublishEnd_Batch",
+			Emit: func(l *trace2.Log) {
+				l.PubsubPublishEnd(trace2.PubsubPublishEndParams{
+					EventParams: ep,
+					StartID:     1,
+					BatchResults: []trace2.PubsubPublishResult{
+						{MessageID: "message-id"},
+						{Err: errors.New("failed")},
+					},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_PubsubPublishEnd{
+						PubsubPublishEnd: &tracepb2.PubsubPublishEnd{
+							BatchResults: []*tracepb2.PubsubPublishResult{
+								{MessageId: ptr("message-id")},
+								{Err: &tracepb2.Error{Msg: "failed"}},
+							},
+						},
+					},
+				}},
+			},
+		},
+
 		{
 			Name: "ServiceInitStart",
 			Emit: func(l *trace2.Log) {
@@ -589,6 +1041,70 @@ func TestParse(t *testing.T) {
 			},
 		},
 
+		{
+			Name: "CacheCallEnd_Durations",
+			Emit: func(l *trace2.Log) {
+				l.CacheCallEnd(trace2.CacheCallEndParams{
+					EventParams:   ep,
+					StartID:       1,
+					Res:           trace2.CacheOK,
+					IODuration:    3 * time.Millisecond,
+					CodecDuration: 12 * time.Millisecond,
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_CacheCallEnd{
+						CacheCallEnd: &tracepb2.CacheCallEnd{
+							Result:             tracepb2.CacheCallEnd_OK,
+							IoDurationNanos:    uint64(3 * time.Millisecond),
+							CodecDurationNanos: uint64(12 * time.Millisecond),
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "CacheCallEnd_Pipeline",
+			Emit: func(l *trace2.Log) {
+				l.CacheCallEnd(trace2.CacheCallEndParams{
+					EventParams: ep,
+					StartID:     1,
+					Res:         trace2.CacheOK,
+					OpResults: []trace2.CacheOpResult{
+						{Operation: "get", Key: "one", Res: trace2.CacheOK},
+						{Operation: "get", Key: "two", Res: trace2.CacheNoSuchKey},
+						{Operation: "set", Key: "three", Res: trace2.CacheErr, Err: errors.New("boom")},
+					},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_CacheCallEnd{
+						CacheCallEnd: &tracepb2.CacheCallEnd{
+							Result: tracepb2.CacheCallEnd_OK,
+							OpResults: []*tracepb2.CacheOpResult{
+								{Operation: "get", Key: "one", Result: tracepb2.CacheCallEnd_OK},
+								{Operation: "get", Key: "two", Result: tracepb2.CacheCallEnd_NO_SUCH_KEY},
+								{Operation: "set", Key: "three", Result: tracepb2.CacheCallEnd_ERR, Err: &tracepb2.Error{Msg: "boom"}},
+							},
+						},
+					},
+				}},
+			},
+		},
+
 		{
 			Name: "LogMessage",
 			Emit: func(l *trace2.Log) {
@@ -685,6 +1201,110 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParse_MemStats(t *testing.T) {
+	req := &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1, 2, 3},
+		SpanID:  model.SpanID{4, 5, 6},
+		Start:   time.Now(),
+		Traced:  true,
+		RPCData: &model.RPCData{
+			Desc: &model.RPCDesc{Service: "service", Endpoint: "endpoint"},
+		},
+	}
+
+	parseEnd := func(t *testing.T, log *trace2.Log) *tracepb2.SpanEnd {
+		t.Helper()
+		log.RequestSpanStart(req, 1)
+		buf := make([]byte, 1<<20) // allocated during the span
+		log.RequestSpanEnd(trace2.RequestSpanEndParams{
+			EventParams: trace2.EventParams{TraceID: req.TraceID, SpanID: req.SpanID},
+			Req:         req,
+			Resp:        &model.Response{HTTPStatus: 200, Payload: buf[:1]},
+		})
+
+		data, _ := log.GetAndClear()
+		rd := bufio.NewReader(bytes.NewReader(data))
+		ta := trace2.NewTimeAnchor(0, req.Start)
+		if _, err := ParseEvent(rd, ta, trace2.CurrentVersion); err != nil {
+			t.Fatal(err)
+		}
+		ev, err := ParseEvent(rd, ta, trace2.CurrentVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ev.GetSpanEnd()
+	}
+
+	t.Run("recorded", func(t *testing.T) {
+		log := trace2.NewLog()
+		log.RecordMemStats()
+		end := parseEnd(t, log)
+		if end.GetRequest().GetServiceName() != "service" {
+			t.Fatalf("got span end %v, want request span end", end)
+		}
+		mem := end.GetMemStats()
+		if mem == nil {
+			t.Fatal("got no mem stats, want mem stats")
+		}
+		if mem.AllocBytes < 1<<20 || mem.AllocObjects == 0 || mem.HeapObjects == 0 {
+			t.Errorf("got mem stats %v, want at least 1MiB allocated", mem)
+		}
+	})
+
+	t.Run("not_recorded", func(t *testing.T) {
+		end := parseEnd(t, trace2.NewLog())
+		if end.GetRequest().GetServiceName() != "service" {
+			t.Fatalf("got span end %v, want request span end", end)
+		}
+		if mem := end.GetMemStats(); mem != nil {
+			t.Errorf("got mem stats %v, want none", mem)
+		}
+	})
+}
+
+func TestParse_Region(t *testing.T) {
+	req := &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1, 2, 3},
+		SpanID:  model.SpanID{4, 5, 6},
+		Start:   time.Now(),
+		Traced:  true,
+		RPCData: &model.RPCData{
+			Desc: &model.RPCDesc{Service: "service", Endpoint: "endpoint"},
+		},
+	}
+
+	parseStart := func(t *testing.T, log *trace2.Log) *tracepb2.SpanStart {
+		t.Helper()
+		log.RequestSpanStart(req, 1)
+		data, _ := log.GetAndClear()
+		ev, err := ParseEvent(bufio.NewReader(bytes.NewReader(data)), trace2.NewTimeAnchor(0, req.Start), trace2.CurrentVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := ev.GetSpanStart()
+		if start.GetRequest().GetServiceName() != "service" {
+			t.Fatalf("got span start %v, want request span start", start)
+		}
+		return start
+	}
+
+	t.Run("known", func(t *testing.T) {
+		log := trace2.NewLog()
+		log.SetRegion("eu-west-1")
+		if got := parseStart(t, log).Region; got == nil || *got != "eu-west-1" {
+			t.Errorf("got region %v, want eu-west-1", got)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if got := parseStart(t, trace2.NewLog()).Region; got != nil {
+			t.Errorf("got region %q, want none", *got)
+		}
+	})
+}
+
 func ptr[T any](val T) *T {
 	return &val
 }
@@ -0,0 +1,186 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Client is a connected tunnel client.
+type Client struct {
+	// ID is the id of the tunnel, which can be passed to Dial
+	// to request the same public URL when reconnecting.
+	ID string
+
+	// PublicURL is the public URL requests to the tunnel are received on.
+	PublicURL string
+
+	// ReconnectSecret is the secret to pass to Dial along with ID
+	// when reconnecting to the tunnel.
+	ReconnectSecret string
+
+	ws      *websocket.Conn
+	writeMu sync.Mutex // protects writes to ws
+}
+
+// Dial connects to the tunnel server at serverURL.
+// If token is non-empty it is used to authenticate with the server.
+// If tunnelID is non-empty the server is asked to reuse the tunnel with that id,
+// to keep the public URL stable across reconnects. The server only allows it
+// given the reconnect secret it issued along with the id.
+func Dial(ctx context.Context, serverURL, token, tunnelID, reconnectSecret string) (*Client, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tunnel server url: %v", err)
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	u.Path = ConnectPath
+	if tunnelID != "" {
+		u.RawQuery = url.Values{"id": {tunnelID}}.Encode()
+	}
+
+	header := make(http.Header)
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	if reconnectSecret != "" {
+		header.Set(ReconnectSecretHeader, reconnectSecret)
+	}
+	ws, resp, err := websocket.DefaultDialer.DialContext(ctx, u.String(), header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("connect to tunnel server: %v (status %s)", err, resp.Status)
+		}
+		return nil, fmt.Errorf("connect to tunnel server: %v", err)
+	}
+	return NewClient(ws)
+}
+
+// NewClient creates a client from an established connection to a tunnel server.
+// It waits for the server to assign the tunnel's public URL.
+func NewClient(ws *websocket.Conn) (*Client, error) {
+	var hello message
+	if err := ws.ReadJSON(&hello); err != nil {
+		_ = ws.Close()
+		return nil, fmt.Errorf("read tunnel handshake: %v", err)
+	} else if hello.Type != msgHello || hello.PublicURL == "" {
+		_ = ws.Close()
+		return nil, errors.New("invalid tunnel handshake")
+	}
+	ws.SetReadLimit(2 * MaxBodySize)
+	return &Client{ID: hello.TunnelID, PublicURL: hello.PublicURL, ReconnectSecret: hello.ReconnectSecret, ws: ws}, nil
+}
+
+// Serve serves the requests received over the tunnel using handler,
+// until the connection is closed or ctx is canceled.
+//
+// Requests are annotated with the standard X-Forwarded-* and Via headers,
+// so they can be distinguished from requests made directly to the local server.
+func (c *Client) Serve(ctx context.Context, handler http.Handler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		_ = c.ws.Close()
+	}()
+
+	publicURL, _ := url.Parse(c.PublicURL)
+	for {
+		var req message
+		if err := c.ws.ReadJSON(&req); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		} else if req.Type != msgRequest {
+			continue
+		}
+		go c.handle(ctx, handler, publicURL, &req)
+	}
+}
+
+// Close closes the tunnel connection.
+func (c *Client) Close() error {
+	return c.ws.Close()
+}
+
+func (c *Client) handle(ctx context.Context, handler http.Handler, publicURL *url.URL, msg *message) {
+	resp := &message{Type: msgResponse, ID: msg.ID}
+
+	req, err := http.NewRequestWithContext(ctx, msg.Method, msg.URI, bytes.NewReader(msg.Body))
+	if err != nil {
+		resp.Status = http.StatusBadRequest
+		resp.Body = []byte(err.Error())
+	} else {
+		req.Header = msg.Header
+		if req.Header == nil {
+			req.Header = make(http.Header)
+		}
+		req.RemoteAddr = msg.RemoteAddr
+		req.Header.Add("Via", "1.1 encore-tunnel")
+		if msg.RemoteAddr != "" {
+			req.Header.Set("X-Forwarded-For", msg.RemoteAddr)
+		}
+		if publicURL != nil {
+			req.Host = publicURL.Host
+			req.Header.Set("X-Forwarded-Host", publicURL.Host)
+			req.Header.Set("X-Forwarded-Proto", publicURL.Scheme)
+		}
+
+		w := &responseRecorder{header: make(http.Header)}
+		handler.ServeHTTP(w, req)
+		resp.Status = w.statusCode()
+		resp.Header = w.header
+		resp.Body = w.body.Bytes()
+		if len(resp.Body) > MaxBodySize {
+			resp.Status = http.StatusBadGateway
+			resp.Header = nil
+			resp.Body = []byte("response body too large for tunnel")
+		}
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.ws.WriteJSON(resp)
+}
+
+// responseRecorder is an http.ResponseWriter that buffers the response.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header { return r.header }
+
+func (r *responseRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(p)
+}
+
+// Flush implements http.Flusher. It's a no-op since the response is buffered.
+func (r *responseRecorder) Flush() {}
+
+func (r *responseRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
@@ -0,0 +1,51 @@
+// Package tunnel implements a tunnel for exposing a local HTTP server
+// through a public tunnel server, for example to receive webhooks
+// from third-party services during local development.
+//
+// The tunnel client connects to the tunnel server over a WebSocket connection.
+// The server assigns the client a public URL and forwards any requests it
+// receives on that URL over the connection, and the client responds with the
+// response from the local server.
+package tunnel
+
+import (
+	"net/http"
+)
+
+// ConnectPath is the path on the tunnel server clients connect to.
+const ConnectPath = "/_tunnel/connect"
+
+// ReconnectSecretHeader is the header clients provide the reconnect secret
+// of their tunnel in when reconnecting to it.
+const ReconnectSecretHeader = "X-Encore-Tunnel-Reconnect-Secret"
+
+// MaxBodySize is the maximum size of request and response bodies
+// sent through the tunnel.
+const MaxBodySize = 10 << 20 // 10 MiB
+
+type msgType string
+
+const (
+	msgHello    msgType = "hello"
+	msgRequest  msgType = "request"
+	msgResponse msgType = "response"
+)
+
+// message is a message sent over the tunnel connection.
+type message struct {
+	Type msgType `json:"type"`
+
+	// Hello fields, sent by the server when the client connects.
+	TunnelID        string `json:"tunnel_id,omitempty"`
+	PublicURL       string `json:"public_url,omitempty"`
+	ReconnectSecret string `json:"reconnect_secret,omitempty"`
+
+	// Request and response fields.
+	ID         uint64      `json:"id,omitempty"`
+	Method     string      `json:"method,omitempty"`
+	URI        string      `json:"uri,omitempty"` // request path and query
+	RemoteAddr string      `json:"remote_addr,omitempty"`
+	Status     int         `json:"status,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+}
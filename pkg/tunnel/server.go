@@ -0,0 +1,286 @@
+package tunnel
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+)
+
+// Server is a tunnel server, which can be self-hosted
+// to expose local servers through a public URL.
+//
+// Clients connect on ConnectPath and are assigned a tunnel with a
+// random, unguessable id. Requests to "<PublicURL>/<id>/<path>"
+// are forwarded to the client as requests to "/<path>".
+//
+// Since the id is part of the public URL, clients are also issued a reconnect
+// secret, which they must provide to reconnect to the same tunnel.
+type Server struct {
+	// PublicURL is the base URL the server is publicly reachable on,
+	// for example "https://tunnel.example.com".
+	PublicURL string
+
+	// Token, if non-empty, is the bearer token clients must
+	// authenticate with to create tunnels.
+	Token string
+
+	// Log is the logger to use.
+	Log zerolog.Logger
+
+	mu      sync.Mutex
+	tunnels map[string]*tunnelSlot
+}
+
+// reconnectWindow is how long the id of a disconnected tunnel stays reserved
+// for its client to reconnect.
+const reconnectWindow = 1 * time.Hour
+
+// tunnelSlot is a tunnel id reserved for a client.
+type tunnelSlot struct {
+	secret         string        // the reconnect secret issued to the client
+	conn           *serverTunnel // nil while the client is disconnected
+	disconnectedAt time.Time
+}
+
+// validSecret reports whether secret is the slot's reconnect secret.
+func (slot *tunnelSlot) validSecret(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(slot.secret)) == 1
+}
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+}
+
+var tunnelIDRe = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == ConnectPath {
+		s.connect(w, req)
+		return
+	}
+
+	// Determine the tunnel from the first path segment.
+	id, rest, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	s.mu.Lock()
+	var t *serverTunnel
+	if slot := s.tunnels[id]; slot != nil {
+		t = slot.conn
+	}
+	s.mu.Unlock()
+	if t == nil {
+		http.Error(w, "tunnel not found", http.StatusNotFound)
+		return
+	}
+	t.forward(w, req, "/"+rest)
+}
+
+func (s *Server) connect(w http.ResponseWriter, req *http.Request) {
+	if s.Token != "" {
+		token, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+			http.Error(w, "invalid tunnel token", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	id := req.URL.Query().Get("id")
+	secret := req.Header.Get(ReconnectSecretHeader)
+	if id != "" && !tunnelIDRe.MatchString(id) {
+		http.Error(w, "invalid tunnel id", http.StatusBadRequest)
+		return
+	}
+
+	// Only the client the tunnel was issued to can reconnect to it,
+	// as anyone who has seen its public URL knows its id.
+	s.mu.Lock()
+	slot := s.tunnels[id]
+	s.mu.Unlock()
+	if slot != nil && !slot.validSecret(secret) {
+		http.Error(w, "invalid tunnel reconnect secret", http.StatusForbidden)
+		return
+	}
+
+	ws, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		// Upgrade already responded to the client.
+		return
+	}
+	ws.SetReadLimit(2 * MaxBodySize)
+
+	t := &serverTunnel{ws: ws, pending: make(map[uint64]chan *message)}
+	s.mu.Lock()
+	if s.tunnels == nil {
+		s.tunnels = make(map[string]*tunnelSlot)
+	}
+	s.expireSlots(time.Now())
+	// Reuse the requested id to keep the public URL stable when clients reconnect.
+	// If the id isn't reserved, for example because the server restarted,
+	// a new tunnel is created instead.
+	if slot = s.tunnels[id]; slot == nil || !slot.validSecret(secret) {
+		id = newRandomID()
+		slot = &tunnelSlot{secret: newRandomID()}
+		s.tunnels[id] = slot
+	} else if slot.conn != nil {
+		// The existing connection is stale.
+		_ = slot.conn.ws.Close()
+	}
+	slot.conn = t
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		if slot.conn == t {
+			slot.conn = nil
+			slot.disconnectedAt = time.Now()
+		}
+		s.mu.Unlock()
+		_ = ws.Close()
+	}()
+
+	publicURL := strings.TrimSuffix(s.PublicURL, "/") + "/" + id
+	hello := &message{Type: msgHello, TunnelID: id, PublicURL: publicURL, ReconnectSecret: slot.secret}
+	if err := t.write(hello); err != nil {
+		return
+	}
+	s.Log.Info().Str("tunnel", id).Str("remote_addr", req.RemoteAddr).Msg("tunnel connected")
+	err = t.readResponses()
+	s.Log.Info().Err(err).Str("tunnel", id).Msg("tunnel disconnected")
+}
+
+// expireSlots releases the ids of tunnels that have been disconnected
+// for longer than reconnectWindow. It must be called with s.mu held.
+func (s *Server) expireSlots(now time.Time) {
+	for id, slot := range s.tunnels {
+		if slot.conn == nil && now.Sub(slot.disconnectedAt) > reconnectWindow {
+			delete(s.tunnels, id)
+		}
+	}
+}
+
+// newRandomID returns a random, unguessable id.
+func newRandomID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// serverTunnel is a tunnel connected to the server.
+type serverTunnel struct {
+	ws *websocket.Conn
+
+	writeMu sync.Mutex // protects writes to ws
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan *message // nil when the tunnel is closed
+}
+
+var errTunnelClosed = errors.New("tunnel closed")
+
+// forward forwards req to the tunnel client as a request to uri.
+func (t *serverTunnel) forward(w http.ResponseWriter, req *http.Request, uri string) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, MaxBodySize))
+	if err != nil {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if req.URL.RawQuery != "" {
+		uri += "?" + req.URL.RawQuery
+	}
+
+	ch := make(chan *message, 1)
+	t.mu.Lock()
+	if t.pending == nil {
+		t.mu.Unlock()
+		http.Error(w, errTunnelClosed.Error(), http.StatusBadGateway)
+		return
+	}
+	t.nextID++
+	id := t.nextID
+	t.pending[id] = ch
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+	}()
+
+	err = t.write(&message{
+		Type:       msgRequest,
+		ID:         id,
+		Method:     req.Method,
+		URI:        uri,
+		RemoteAddr: req.RemoteAddr,
+		Header:     req.Header,
+		Body:       body,
+	})
+	if err != nil {
+		http.Error(w, "unable to forward request: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			http.Error(w, errTunnelClosed.Error(), http.StatusBadGateway)
+			return
+		}
+		for k, v := range resp.Header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(resp.Status)
+		_, _ = w.Write(resp.Body)
+	case <-req.Context().Done():
+		http.Error(w, "request canceled", http.StatusGatewayTimeout)
+	}
+}
+
+func (t *serverTunnel) write(msg *message) error {
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	return t.ws.WriteJSON(msg)
+}
+
+// readResponses reads responses from the client until the connection is closed,
+// and dispatches them to the pending requests.
+func (t *serverTunnel) readResponses() error {
+	defer func() {
+		t.mu.Lock()
+		for _, ch := range t.pending {
+			close(ch)
+		}
+		t.pending = nil
+		t.mu.Unlock()
+	}()
+
+	for {
+		var msg message
+		if err := t.ws.ReadJSON(&msg); err != nil {
+			return err
+		} else if msg.Type != msgResponse {
+			continue
+		}
+
+		t.mu.Lock()
+		if ch, ok := t.pending[msg.ID]; ok {
+			ch <- &msg
+			delete(t.pending, msg.ID)
+		}
+		t.mu.Unlock()
+	}
+}
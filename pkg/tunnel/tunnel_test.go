@@ -0,0 +1,99 @@
+package tunnel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTunnel(t *testing.T) {
+	srv := &Server{Token: "secret"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	srv.PublicURL = ts.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := Dial(ctx, ts.URL, "invalid", "", ""); err == nil {
+		t.Fatal("got nil error when dialing with invalid token, want non-nil")
+	}
+
+	client, err := Dial(ctx, ts.URL, "secret", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ts.URL + "/" + client.ID; client.PublicURL != want {
+		t.Fatalf("got public url %q, want %q", client.PublicURL, want)
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		w.Header().Set("X-Test", "ok")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, "%s %s %s via=%s", req.Method, req.URL.RequestURI(), body, req.Header.Get("Via"))
+	})
+	done := make(chan error, 1)
+	go func() { done <- client.Serve(ctx, handler) }()
+
+	resp, err := http.Post(client.PublicURL+"/webhook?a=b", "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted || resp.Header.Get("X-Test") != "ok" {
+		t.Errorf("got status %d and X-Test %q, want %d and %q",
+			resp.StatusCode, resp.Header.Get("X-Test"), http.StatusAccepted, "ok")
+	}
+	if got, want := string(body), "POST /webhook?a=b payload via=1.1 encore-tunnel"; got != want {
+		t.Errorf("got body %q, want %q", got, want)
+	}
+
+	// Reconnecting to the tunnel requires its reconnect secret,
+	// as its id is known to anyone who has seen its public URL.
+	if client.ReconnectSecret == "" {
+		t.Fatal("got empty reconnect secret")
+	}
+	_ = client.Close()
+	<-done
+	for _, secret := range []string{"", "invalid"} {
+		if c, err := Dial(ctx, ts.URL, "secret", client.ID, secret); err == nil {
+			_ = c.Close()
+			t.Fatalf("got nil error when reconnecting with secret %q, want non-nil", secret)
+		}
+	}
+
+	// Reconnecting with the same id and the secret should keep the public URL.
+	client2, err := Dial(ctx, ts.URL, "secret", client.ID, client.ReconnectSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client2.Close() }()
+	if client2.PublicURL != client.PublicURL {
+		t.Errorf("got public url %q after reconnect, want %q", client2.PublicURL, client.PublicURL)
+	}
+
+	// Reconnecting to a tunnel the server doesn't know creates a new tunnel.
+	client3, err := Dial(ctx, ts.URL, "secret", strings.Repeat("0", 32), client.ReconnectSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client3.Close() }()
+	if client3.ID == strings.Repeat("0", 32) || client3.ID == client.ID {
+		t.Errorf("got tunnel id %q when reconnecting to an unknown tunnel, want a new id", client3.ID)
+	}
+
+	resp, err = http.Get(ts.URL + "/unknown/path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %d for unknown tunnel, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -273,7 +274,165 @@ func (x DumpMetaRequest_Format) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DumpMetaRequest_Format.Descriptor instead.
 func (DumpMetaRequest_Format) EnumDescriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{32, 0}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{37, 0}
+}
+
+type UsageReportResponse_CallerKind int32
+
+const (
+	// CALLER_EXTERNAL callers make requests to the app from the outside.
+	UsageReportResponse_CALLER_EXTERNAL UsageReportResponse_CallerKind = 0
+	// CALLER_SERVICE callers are other services within the app.
+	UsageReportResponse_CALLER_SERVICE UsageReportResponse_CallerKind = 1
+)
+
+// Enum value maps for UsageReportResponse_CallerKind.
+var (
+	UsageReportResponse_CallerKind_name = map[int32]string{
+		0: "CALLER_EXTERNAL",
+		1: "CALLER_SERVICE",
+	}
+	UsageReportResponse_CallerKind_value = map[string]int32{
+		"CALLER_EXTERNAL": 0,
+		"CALLER_SERVICE":  1,
+	}
+)
+
+func (x UsageReportResponse_CallerKind) Enum() *UsageReportResponse_CallerKind {
+	p := new(UsageReportResponse_CallerKind)
+	*p = x
+	return p
+}
+
+func (x UsageReportResponse_CallerKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UsageReportResponse_CallerKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[5].Descriptor()
+}
+
+func (UsageReportResponse_CallerKind) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[5]
+}
+
+func (x UsageReportResponse_CallerKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UsageReportResponse_CallerKind.Descriptor instead.
+func (UsageReportResponse_CallerKind) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{46, 0}
+}
+
+type ListTracesResponse_Span_Kind int32
+
+const (
+	ListTracesResponse_Span_REQUEST        ListTracesResponse_Span_Kind = 0
+	ListTracesResponse_Span_AUTH           ListTracesResponse_Span_Kind = 1
+	ListTracesResponse_Span_PUBSUB_MESSAGE ListTracesResponse_Span_Kind = 2
+	ListTracesResponse_Span_TEST           ListTracesResponse_Span_Kind = 3
+)
+
+// Enum value maps for ListTracesResponse_Span_Kind.
+var (
+	ListTracesResponse_Span_Kind_name = map[int32]string{
+		0: "REQUEST",
+		1: "AUTH",
+		2: "PUBSUB_MESSAGE",
+		3: "TEST",
+	}
+	ListTracesResponse_Span_Kind_value = map[string]int32{
+		"REQUEST":        0,
+		"AUTH":           1,
+		"PUBSUB_MESSAGE": 2,
+		"TEST":           3,
+	}
+)
+
+func (x ListTracesResponse_Span_Kind) Enum() *ListTracesResponse_Span_Kind {
+	p := new(ListTracesResponse_Span_Kind)
+	*p = x
+	return p
+}
+
+func (x ListTracesResponse_Span_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListTracesResponse_Span_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[6].Descriptor()
+}
+
+func (ListTracesResponse_Span_Kind) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[6]
+}
+
+func (x ListTracesResponse_Span_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListTracesResponse_Span_Kind.Descriptor instead.
+func (ListTracesResponse_Span_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{48, 1, 0}
+}
+
+type ListTracesResponse_Operation_Kind int32
+
+const (
+	ListTracesResponse_Operation_RPC_CALL       ListTracesResponse_Operation_Kind = 0
+	ListTracesResponse_Operation_DB_QUERY       ListTracesResponse_Operation_Kind = 1
+	ListTracesResponse_Operation_PUBSUB_PUBLISH ListTracesResponse_Operation_Kind = 2
+	ListTracesResponse_Operation_HTTP_CALL      ListTracesResponse_Operation_Kind = 3
+	ListTracesResponse_Operation_CACHE_CALL     ListTracesResponse_Operation_Kind = 4
+	ListTracesResponse_Operation_LOG            ListTracesResponse_Operation_Kind = 5
+)
+
+// Enum value maps for ListTracesResponse_Operation_Kind.
+var (
+	ListTracesResponse_Operation_Kind_name = map[int32]string{
+		0: "RPC_CALL",
+		1: "DB_QUERY",
+		2: "PUBSUB_PUBLISH",
+		3: "HTTP_CALL",
+		4: "CACHE_CALL",
+		5: "LOG",
+	}
+	ListTracesResponse_Operation_Kind_value = map[string]int32{
+		"RPC_CALL":       0,
+		"DB_QUERY":       1,
+		"PUBSUB_PUBLISH": 2,
+		"HTTP_CALL":      3,
+		"CACHE_CALL":     4,
+		"LOG":            5,
+	}
+)
+
+func (x ListTracesResponse_Operation_Kind) Enum() *ListTracesResponse_Operation_Kind {
+	p := new(ListTracesResponse_Operation_Kind)
+	*p = x
+	return p
+}
+
+func (x ListTracesResponse_Operation_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListTracesResponse_Operation_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[7].Descriptor()
+}
+
+func (ListTracesResponse_Operation_Kind) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[7]
+}
+
+func (x ListTracesResponse_Operation_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListTracesResponse_Operation_Kind.Descriptor instead.
+func (ListTracesResponse_Operation_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{48, 2, 0}
 }
 
 type CommandMessage struct {
@@ -646,7 +805,15 @@ type RunRequest struct {
 	// debug_mode specifies the debug mode to use.
 	DebugMode RunRequest_DebugMode `protobuf:"varint,11,opt,name=debug_mode,json=debugMode,proto3,enum=encore.daemon.RunRequest_DebugMode" json:"debug_mode,omitempty"`
 	// Log level override.
-	LogLevel      *string `protobuf:"bytes,12,opt,name=log_level,json=logLevel,proto3,oneof" json:"log_level,omitempty"`
+	LogLevel *string `protobuf:"bytes,12,opt,name=log_level,json=logLevel,proto3,oneof" json:"log_level,omitempty"`
+	// tunnel, if true, exposes the app through a public URL using a tunnel,
+	// for example to receive webhooks from third-party services.
+	Tunnel bool `protobuf:"varint,13,opt,name=tunnel,proto3" json:"tunnel,omitempty"`
+	// tunnel_server is the URL of a self-hosted tunnel server to use.
+	// If empty the tunnel is provided by the Encore Platform.
+	TunnelServer *string `protobuf:"bytes,14,opt,name=tunnel_server,json=tunnelServer,proto3,oneof" json:"tunnel_server,omitempty"`
+	// tunnel_token is the token to authenticate with the tunnel server.
+	TunnelToken   *string `protobuf:"bytes,15,opt,name=tunnel_token,json=tunnelToken,proto3,oneof" json:"tunnel_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -751,6 +918,27 @@ func (x *RunRequest) GetLogLevel() string {
 	return ""
 }
 
+func (x *RunRequest) GetTunnel() bool {
+	if x != nil {
+		return x.Tunnel
+	}
+	return false
+}
+
+func (x *RunRequest) GetTunnelServer() string {
+	if x != nil && x.TunnelServer != nil {
+		return *x.TunnelServer
+	}
+	return ""
+}
+
+func (x *RunRequest) GetTunnelToken() string {
+	if x != nil && x.TunnelToken != nil {
+		return *x.TunnelToken
+	}
+	return ""
+}
+
 type TestRequest struct {
 	state      protoimpl.MessageState `protogen:"open.v1"`
 	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
@@ -1668,55 +1856,36 @@ func (x *DBResetRequest) GetNamespace() string {
 	return ""
 }
 
-type GenClientRequest struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	AppId    string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	EnvName  string                 `protobuf:"bytes,2,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"`
-	Lang     string                 `protobuf:"bytes,3,opt,name=lang,proto3" json:"lang,omitempty"`
-	Filepath string                 `protobuf:"bytes,4,opt,name=filepath,proto3" json:"filepath,omitempty"`
-	// Services to include in the output.
-	// If the string "*" is present all services are included.
-	Services []string `protobuf:"bytes,5,rep,name=services,proto3" json:"services,omitempty"`
-	// Services to exclude from the output.
-	// Takes precedence over 'services' above.
-	ExcludedServices []string `protobuf:"bytes,6,rep,name=excluded_services,json=excludedServices,proto3" json:"excluded_services,omitempty"`
-	// Tags of endpoints to include in the output.
-	// Only includes endpoints from services included in 'services' above.
-	EndpointTags []string `protobuf:"bytes,7,rep,name=endpoint_tags,json=endpointTags,proto3" json:"endpoint_tags,omitempty"`
-	// Tags of endpoints to exclude from the output.
-	// Takes precedence over 'endpoint_tags' above.
-	ExcludedEndpointTags []string `protobuf:"bytes,8,rep,name=excluded_endpoint_tags,json=excludedEndpointTags,proto3" json:"excluded_endpoint_tags,omitempty"`
-	// The OpenAPI spec generator by default includes private endpoints.
-	// If this is set to `true`, private endpoints will not be included
-	// in the generated OpenAPI spec.
-	OpenapiExcludePrivateEndpoints *bool `protobuf:"varint,9,opt,name=openapi_exclude_private_endpoints,json=openapiExcludePrivateEndpoints,proto3,oneof" json:"openapi_exclude_private_endpoints,omitempty"`
-	// The TS generator by default re-declares the api types in the client.
-	// If this is set to `true`, the types will be imported and shared between
-	// the client and the server. It assumes "~backend" is available in the
-	// import path.
-	TsSharedTypes *bool `protobuf:"varint,10,opt,name=ts_shared_types,json=tsSharedTypes,proto3,oneof" json:"ts_shared_types,omitempty"`
-	// If set, the default export of the generate TypeScript client will be
-	// an instantiated client with the given target. The target can be e.g.
-	// a variable, e.g. "import.meta.env.VITE_CLIENT_TARGET" or a string literal.
-	TsClientTarget *string `protobuf:"bytes,11,opt,name=ts_client_target,json=tsClientTarget,proto3,oneof" json:"ts_client_target,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type DBMigratePlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	EnvName       string                 `protobuf:"bytes,2,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"`
+	DatabaseNames []string               `protobuf:"bytes,3,rep,name=database_names,json=databaseNames,proto3" json:"database_names,omitempty"` // database names to plan; all if empty
+	ClusterType   DBClusterType          `protobuf:"varint,4,opt,name=cluster_type,json=clusterType,proto3,enum=encore.daemon.DBClusterType" json:"cluster_type,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,5,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// validate, if true, applies the pending migrations inside a transaction
+	// that is always rolled back, to validate them against the live schema.
+	Validate      bool `protobuf:"varint,6,opt,name=validate,proto3" json:"validate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenClientRequest) Reset() {
-	*x = GenClientRequest{}
+func (x *DBMigratePlanRequest) Reset() {
+	*x = DBMigratePlanRequest{}
 	mi := &file_encore_daemon_daemon_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenClientRequest) String() string {
+func (x *DBMigratePlanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenClientRequest) ProtoMessage() {}
+func (*DBMigratePlanRequest) ProtoMessage() {}
 
-func (x *GenClientRequest) ProtoReflect() protoreflect.Message {
+func (x *DBMigratePlanRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_daemon_daemon_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1728,109 +1897,86 @@ func (x *GenClientRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenClientRequest.ProtoReflect.Descriptor instead.
-func (*GenClientRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DBMigratePlanRequest.ProtoReflect.Descriptor instead.
+func (*DBMigratePlanRequest) Descriptor() ([]byte, []int) {
 	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GenClientRequest) GetAppId() string {
+func (x *DBMigratePlanRequest) GetAppRoot() string {
 	if x != nil {
-		return x.AppId
+		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *GenClientRequest) GetEnvName() string {
+func (x *DBMigratePlanRequest) GetEnvName() string {
 	if x != nil {
 		return x.EnvName
 	}
 	return ""
 }
 
-func (x *GenClientRequest) GetLang() string {
-	if x != nil {
-		return x.Lang
-	}
-	return ""
-}
-
-func (x *GenClientRequest) GetFilepath() string {
-	if x != nil {
-		return x.Filepath
-	}
-	return ""
-}
-
-func (x *GenClientRequest) GetServices() []string {
+func (x *DBMigratePlanRequest) GetDatabaseNames() []string {
 	if x != nil {
-		return x.Services
+		return x.DatabaseNames
 	}
 	return nil
 }
 
-func (x *GenClientRequest) GetExcludedServices() []string {
+func (x *DBMigratePlanRequest) GetClusterType() DBClusterType {
 	if x != nil {
-		return x.ExcludedServices
+		return x.ClusterType
 	}
-	return nil
+	return DBClusterType_DB_CLUSTER_TYPE_UNSPECIFIED
 }
 
-func (x *GenClientRequest) GetEndpointTags() []string {
-	if x != nil {
-		return x.EndpointTags
+func (x *DBMigratePlanRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
 	}
-	return nil
+	return ""
 }
 
-func (x *GenClientRequest) GetExcludedEndpointTags() []string {
+func (x *DBMigratePlanRequest) GetValidate() bool {
 	if x != nil {
-		return x.ExcludedEndpointTags
-	}
-	return nil
-}
-
-func (x *GenClientRequest) GetOpenapiExcludePrivateEndpoints() bool {
-	if x != nil && x.OpenapiExcludePrivateEndpoints != nil {
-		return *x.OpenapiExcludePrivateEndpoints
-	}
-	return false
-}
-
-func (x *GenClientRequest) GetTsSharedTypes() bool {
-	if x != nil && x.TsSharedTypes != nil {
-		return *x.TsSharedTypes
+		return x.Validate
 	}
 	return false
 }
 
-func (x *GenClientRequest) GetTsClientTarget() string {
-	if x != nil && x.TsClientTarget != nil {
-		return *x.TsClientTarget
-	}
-	return ""
-}
-
-type GenClientResponse struct {
+type DBSeedRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          []byte                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	WorkingDir    string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	DatabaseNames []string               `protobuf:"bytes,3,rep,name=database_names,json=databaseNames,proto3" json:"database_names,omitempty"` // database names to seed; all if empty
+	ClusterType   DBClusterType          `protobuf:"varint,4,opt,name=cluster_type,json=clusterType,proto3,enum=encore.daemon.DBClusterType" json:"cluster_type,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,5,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// force, if true, applies all seed files again,
+	// including ones that have previously been applied.
+	Force bool `protobuf:"varint,6,opt,name=force,proto3" json:"force,omitempty"`
+	// environ is the environment to set for running seed programs.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ       []string `protobuf:"bytes,7,rep,name=environ,proto3" json:"environ,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenClientResponse) Reset() {
-	*x = GenClientResponse{}
+func (x *DBSeedRequest) Reset() {
+	*x = DBSeedRequest{}
 	mi := &file_encore_daemon_daemon_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenClientResponse) String() string {
+func (x *DBSeedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenClientResponse) ProtoMessage() {}
+func (*DBSeedRequest) ProtoMessage() {}
 
-func (x *GenClientResponse) ProtoReflect() protoreflect.Message {
+func (x *DBSeedRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_daemon_daemon_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1842,39 +1988,81 @@ func (x *GenClientResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenClientResponse.ProtoReflect.Descriptor instead.
-func (*GenClientResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DBSeedRequest.ProtoReflect.Descriptor instead.
+func (*DBSeedRequest) Descriptor() ([]byte, []int) {
 	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GenClientResponse) GetCode() []byte {
+func (x *DBSeedRequest) GetAppRoot() string {
 	if x != nil {
-		return x.Code
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBSeedRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *DBSeedRequest) GetDatabaseNames() []string {
+	if x != nil {
+		return x.DatabaseNames
 	}
 	return nil
 }
 
-type GenWrappersRequest struct {
+func (x *DBSeedRequest) GetClusterType() DBClusterType {
+	if x != nil {
+		return x.ClusterType
+	}
+	return DBClusterType_DB_CLUSTER_TYPE_UNSPECIFIED
+}
+
+func (x *DBSeedRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DBSeedRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *DBSeedRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+type DBMigratePlanResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Databases     []*DBMigrationPlan     `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenWrappersRequest) Reset() {
-	*x = GenWrappersRequest{}
+func (x *DBMigratePlanResponse) Reset() {
+	*x = DBMigratePlanResponse{}
 	mi := &file_encore_daemon_daemon_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenWrappersRequest) String() string {
+func (x *DBMigratePlanResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenWrappersRequest) ProtoMessage() {}
+func (*DBMigratePlanResponse) ProtoMessage() {}
 
-func (x *GenWrappersRequest) ProtoReflect() protoreflect.Message {
+func (x *DBMigratePlanResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_daemon_daemon_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1886,39 +2074,1399 @@ func (x *GenWrappersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenWrappersRequest.ProtoReflect.Descriptor instead.
-func (*GenWrappersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DBMigratePlanResponse.ProtoReflect.Descriptor instead.
+func (*DBMigratePlanResponse) Descriptor() ([]byte, []int) {
 	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GenWrappersRequest) GetAppRoot() string {
+func (x *DBMigratePlanResponse) GetDatabases() []*DBMigrationPlan {
 	if x != nil {
-		return x.AppRoot
+		return x.Databases
 	}
-	return ""
+	return nil
 }
 
-type GenWrappersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type DBMigrationPlan struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	DbName string                 `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	// exists is false if the database has not been created yet,
+	// in which case all migrations are pending.
+	Exists bool `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	// pending are the migrations that would be applied, in order.
+	Pending []*PendingMigration `protobuf:"bytes,3,rep,name=pending,proto3" json:"pending,omitempty"`
+	// drift describes ways in which the applied migrations
+	// differ from the migrations in the app.
+	Drift         []string `protobuf:"bytes,4,rep,name=drift,proto3" json:"drift,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenWrappersResponse) Reset() {
-	*x = GenWrappersResponse{}
+func (x *DBMigrationPlan) Reset() {
+	*x = DBMigrationPlan{}
 	mi := &file_encore_daemon_daemon_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
+func (x *DBMigrationPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBMigrationPlan) ProtoMessage() {}
+
+func (x *DBMigrationPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBMigrationPlan.ProtoReflect.Descriptor instead.
+func (*DBMigrationPlan) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DBMigrationPlan) GetDbName() string {
+	if x != nil {
+		return x.DbName
+	}
+	return ""
+}
+
+func (x *DBMigrationPlan) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *DBMigrationPlan) GetPending() []*PendingMigration {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *DBMigrationPlan) GetDrift() []string {
+	if x != nil {
+		return x.Drift
+	}
+	return nil
+}
+
+type PendingMigration struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Number      uint64                 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Filename    string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// destructive_statements are statements that may result in data loss.
+	DestructiveStatements []string `protobuf:"bytes,4,rep,name=destructive_statements,json=destructiveStatements,proto3" json:"destructive_statements,omitempty"`
+	// validated is true if the migration was applied in the dry run.
+	Validated bool `protobuf:"varint,5,opt,name=validated,proto3" json:"validated,omitempty"`
+	// validation_error is the error from validating the migration, if any.
+	ValidationError *string `protobuf:"bytes,6,opt,name=validation_error,json=validationError,proto3,oneof" json:"validation_error,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PendingMigration) Reset() {
+	*x = PendingMigration{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingMigration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingMigration) ProtoMessage() {}
+
+func (x *PendingMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingMigration.ProtoReflect.Descriptor instead.
+func (*PendingMigration) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PendingMigration) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *PendingMigration) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *PendingMigration) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PendingMigration) GetDestructiveStatements() []string {
+	if x != nil {
+		return x.DestructiveStatements
+	}
+	return nil
+}
+
+func (x *PendingMigration) GetValidated() bool {
+	if x != nil {
+		return x.Validated
+	}
+	return false
+}
+
+func (x *PendingMigration) GetValidationError() string {
+	if x != nil && x.ValidationError != nil {
+		return *x.ValidationError
+	}
+	return ""
+}
+
+type GenClientRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	AppId    string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	EnvName  string                 `protobuf:"bytes,2,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"`
+	Lang     string                 `protobuf:"bytes,3,opt,name=lang,proto3" json:"lang,omitempty"`
+	Filepath string                 `protobuf:"bytes,4,opt,name=filepath,proto3" json:"filepath,omitempty"`
+	// Services to include in the output.
+	// If the string "*" is present all services are included.
+	Services []string `protobuf:"bytes,5,rep,name=services,proto3" json:"services,omitempty"`
+	// Services to exclude from the output.
+	// Takes precedence over 'services' above.
+	ExcludedServices []string `protobuf:"bytes,6,rep,name=excluded_services,json=excludedServices,proto3" json:"excluded_services,omitempty"`
+	// Tags of endpoints to include in the output.
+	// Only includes endpoints from services included in 'services' above.
+	EndpointTags []string `protobuf:"bytes,7,rep,name=endpoint_tags,json=endpointTags,proto3" json:"endpoint_tags,omitempty"`
+	// Tags of endpoints to exclude from the output.
+	// Takes precedence over 'endpoint_tags' above.
+	ExcludedEndpointTags []string `protobuf:"bytes,8,rep,name=excluded_endpoint_tags,json=excludedEndpointTags,proto3" json:"excluded_endpoint_tags,omitempty"`
+	// The OpenAPI spec generator by default includes private endpoints.
+	// If this is set to `true`, private endpoints will not be included
+	// in the generated OpenAPI spec.
+	OpenapiExcludePrivateEndpoints *bool `protobuf:"varint,9,opt,name=openapi_exclude_private_endpoints,json=openapiExcludePrivateEndpoints,proto3,oneof" json:"openapi_exclude_private_endpoints,omitempty"`
+	// The TS generator by default re-declares the api types in the client.
+	// If this is set to `true`, the types will be imported and shared between
+	// the client and the server. It assumes "~backend" is available in the
+	// import path.
+	TsSharedTypes *bool `protobuf:"varint,10,opt,name=ts_shared_types,json=tsSharedTypes,proto3,oneof" json:"ts_shared_types,omitempty"`
+	// If set, the default export of the generate TypeScript client will be
+	// an instantiated client with the given target. The target can be e.g.
+	// a variable, e.g. "import.meta.env.VITE_CLIENT_TARGET" or a string literal.
+	TsClientTarget *string `protobuf:"bytes,11,opt,name=ts_client_target,json=tsClientTarget,proto3,oneof" json:"ts_client_target,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GenClientRequest) Reset() {
+	*x = GenClientRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenClientRequest) ProtoMessage() {}
+
+func (x *GenClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenClientRequest.ProtoReflect.Descriptor instead.
+func (*GenClientRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GenClientRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetEnvName() string {
+	if x != nil {
+		return x.EnvName
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetFilepath() string {
+	if x != nil {
+		return x.Filepath
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *GenClientRequest) GetExcludedServices() []string {
+	if x != nil {
+		return x.ExcludedServices
+	}
+	return nil
+}
+
+func (x *GenClientRequest) GetEndpointTags() []string {
+	if x != nil {
+		return x.EndpointTags
+	}
+	return nil
+}
+
+func (x *GenClientRequest) GetExcludedEndpointTags() []string {
+	if x != nil {
+		return x.ExcludedEndpointTags
+	}
+	return nil
+}
+
+func (x *GenClientRequest) GetOpenapiExcludePrivateEndpoints() bool {
+	if x != nil && x.OpenapiExcludePrivateEndpoints != nil {
+		return *x.OpenapiExcludePrivateEndpoints
+	}
+	return false
+}
+
+func (x *GenClientRequest) GetTsSharedTypes() bool {
+	if x != nil && x.TsSharedTypes != nil {
+		return *x.TsSharedTypes
+	}
+	return false
+}
+
+func (x *GenClientRequest) GetTsClientTarget() string {
+	if x != nil && x.TsClientTarget != nil {
+		return *x.TsClientTarget
+	}
+	return ""
+}
+
+type GenClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          []byte                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenClientResponse) Reset() {
+	*x = GenClientResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenClientResponse) ProtoMessage() {}
+
+func (x *GenClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenClientResponse.ProtoReflect.Descriptor instead.
+func (*GenClientResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GenClientResponse) GetCode() []byte {
+	if x != nil {
+		return x.Code
+	}
+	return nil
+}
+
+type GenWrappersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenWrappersRequest) Reset() {
+	*x = GenWrappersRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenWrappersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenWrappersRequest) ProtoMessage() {}
+
+func (x *GenWrappersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenWrappersRequest.ProtoReflect.Descriptor instead.
+func (*GenWrappersRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GenWrappersRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+type GenWrappersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenWrappersResponse) Reset() {
+	*x = GenWrappersResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
 func (x *GenWrappersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenWrappersResponse) ProtoMessage() {}
+func (*GenWrappersResponse) ProtoMessage() {}
+
+func (x *GenWrappersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenWrappersResponse.ProtoReflect.Descriptor instead.
+func (*GenWrappersResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{26}
+}
+
+type SecretsRefreshRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretsRefreshRequest) Reset() {
+	*x = SecretsRefreshRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretsRefreshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretsRefreshRequest) ProtoMessage() {}
+
+func (x *SecretsRefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretsRefreshRequest.ProtoReflect.Descriptor instead.
+func (*SecretsRefreshRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SecretsRefreshRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *SecretsRefreshRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SecretsRefreshRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SecretsRefreshResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretsRefreshResponse) Reset() {
+	*x = SecretsRefreshResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretsRefreshResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretsRefreshResponse) ProtoMessage() {}
+
+func (x *SecretsRefreshResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretsRefreshResponse.ProtoReflect.Descriptor instead.
+func (*SecretsRefreshResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{28}
+}
+
+type VersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	ConfigHash    string                 `protobuf:"bytes,2,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *VersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetConfigHash() string {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return ""
+}
+
+type Namespace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastActiveAt  *string                `protobuf:"bytes,5,opt,name=last_active_at,json=lastActiveAt,proto3,oneof" json:"last_active_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Namespace) Reset() {
+	*x = Namespace{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Namespace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Namespace) ProtoMessage() {}
+
+func (x *Namespace) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Namespace.ProtoReflect.Descriptor instead.
+func (*Namespace) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Namespace) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Namespace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Namespace) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Namespace) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Namespace) GetLastActiveAt() string {
+	if x != nil && x.LastActiveAt != nil {
+		return *x.LastActiveAt
+	}
+	return ""
+}
+
+type CreateNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceRequest) Reset() {
+	*x = CreateNamespaceRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceRequest) ProtoMessage() {}
+
+func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateNamespaceRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SwitchNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Create        bool                   `protobuf:"varint,3,opt,name=create,proto3" json:"create,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwitchNamespaceRequest) Reset() {
+	*x = SwitchNamespaceRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwitchNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchNamespaceRequest) ProtoMessage() {}
+
+func (x *SwitchNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*SwitchNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SwitchNamespaceRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *SwitchNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SwitchNamespaceRequest) GetCreate() bool {
+	if x != nil {
+		return x.Create
+	}
+	return false
+}
+
+type ListNamespacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesRequest) Reset() {
+	*x = ListNamespacesRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesRequest) ProtoMessage() {}
+
+func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListNamespacesRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+type DeleteNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNamespaceRequest) Reset() {
+	*x = DeleteNamespaceRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNamespaceRequest) ProtoMessage() {}
+
+func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteNamespaceRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DeleteNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListNamespacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespaces    []*Namespace           `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesResponse) ProtoMessage() {}
+
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListNamespacesResponse) GetNamespaces() []*Namespace {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+type TelemetryConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnonId        string                 `protobuf:"bytes,1,opt,name=anon_id,json=anonId,proto3" json:"anon_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Debug         bool                   `protobuf:"varint,3,opt,name=debug,proto3" json:"debug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TelemetryConfig) Reset() {
+	*x = TelemetryConfig{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryConfig) ProtoMessage() {}
+
+func (x *TelemetryConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryConfig.ProtoReflect.Descriptor instead.
+func (*TelemetryConfig) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TelemetryConfig) GetAnonId() string {
+	if x != nil {
+		return x.AnonId
+	}
+	return ""
+}
+
+func (x *TelemetryConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *TelemetryConfig) GetDebug() bool {
+	if x != nil {
+		return x.Debug
+	}
+	return false
+}
+
+type DumpMetaRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"` // for error reporting
+	// environ is the environment to set for the running command.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ []string `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
+	// Whether or not to parse tests.
+	ParseTests bool                   `protobuf:"varint,4,opt,name=parse_tests,json=parseTests,proto3" json:"parse_tests,omitempty"`
+	Format     DumpMetaRequest_Format `protobuf:"varint,5,opt,name=format,proto3,enum=encore.daemon.DumpMetaRequest_Format" json:"format,omitempty"`
+	// revision, if set, is the git revision (such as a commit, branch or tag)
+	// of the app to parse, instead of the current working tree.
+	Revision      string `protobuf:"bytes,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpMetaRequest) Reset() {
+	*x = DumpMetaRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpMetaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpMetaRequest) ProtoMessage() {}
+
+func (x *DumpMetaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpMetaRequest.ProtoReflect.Descriptor instead.
+func (*DumpMetaRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DumpMetaRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DumpMetaRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *DumpMetaRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *DumpMetaRequest) GetParseTests() bool {
+	if x != nil {
+		return x.ParseTests
+	}
+	return false
+}
+
+func (x *DumpMetaRequest) GetFormat() DumpMetaRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return DumpMetaRequest_FORMAT_UNSPECIFIED
+}
+
+func (x *DumpMetaRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+type DumpMetaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          []byte                 `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpMetaResponse) Reset() {
+	*x = DumpMetaResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpMetaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpMetaResponse) ProtoMessage() {}
+
+func (x *DumpMetaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpMetaResponse.ProtoReflect.Descriptor instead.
+func (*DumpMetaResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DumpMetaResponse) GetMeta() []byte {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type URLRegistryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *URLRegistryRequest) Reset() {
+	*x = URLRegistryRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLRegistryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLRegistryRequest) ProtoMessage() {}
+
+func (x *URLRegistryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use URLRegistryRequest.ProtoReflect.Descriptor instead.
+func (*URLRegistryRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *URLRegistryRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+type URLRegistryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	AppId string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// base_url is the base URL of the app's API gateway.
+	BaseUrl string `protobuf:"bytes,2,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// services are the base URLs of the service processes, by service name.
+	// Only set when services run in separate processes.
+	Services map[string]string `protobuf:"bytes,3,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// gateways are the base URLs of the gateway processes, by gateway name.
+	Gateways map[string]string `protobuf:"bytes,4,rep,name=gateways,proto3" json:"gateways,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// resources are the addresses of the local infrastructure resources,
+	// keyed by resource type ("sqldb", "redis", "pubsub" and "objects").
+	Resources     map[string]string `protobuf:"bytes,5,rep,name=resources,proto3" json:"resources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *URLRegistryResponse) Reset() {
+	*x = URLRegistryResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLRegistryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLRegistryResponse) ProtoMessage() {}
+
+func (x *URLRegistryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use URLRegistryResponse.ProtoReflect.Descriptor instead.
+func (*URLRegistryResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *URLRegistryResponse) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *URLRegistryResponse) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *URLRegistryResponse) GetServices() map[string]string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *URLRegistryResponse) GetGateways() map[string]string {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+func (x *URLRegistryResponse) GetResources() map[string]string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+type PubSubReplayRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// topic is the name of the topic to replay messages for.
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	// from is the earliest publish time of the messages to replay.
+	From *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// to, if set, excludes messages published at or after the given time.
+	To *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// subscription, if set, is the only subscription to process the replayed messages.
+	Subscription *string `protobuf:"bytes,5,opt,name=subscription,proto3,oneof" json:"subscription,omitempty"`
+	// dry_run reports the number of messages to replay without replaying them.
+	DryRun        bool `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubReplayRequest) Reset() {
+	*x = PubSubReplayRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubReplayRequest) ProtoMessage() {}
+
+func (x *PubSubReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubReplayRequest.ProtoReflect.Descriptor instead.
+func (*PubSubReplayRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PubSubReplayRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *PubSubReplayRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *PubSubReplayRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *PubSubReplayRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *PubSubReplayRequest) GetSubscription() string {
+	if x != nil && x.Subscription != nil {
+		return *x.Subscription
+	}
+	return ""
+}
+
+func (x *PubSubReplayRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type PubSubReplayResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// replayed is the number of messages replayed.
+	Replayed      int32 `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubReplayResponse) Reset() {
+	*x = PubSubReplayResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubReplayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubReplayResponse) ProtoMessage() {}
+
+func (x *PubSubReplayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubReplayResponse.ProtoReflect.Descriptor instead.
+func (*PubSubReplayResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PubSubReplayResponse) GetReplayed() int32 {
+	if x != nil {
+		return x.Replayed
+	}
+	return 0
+}
+
+type SLOReportRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// from is the start of the time window to report on.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// to, if set, is the end of the time window to report on.
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SLOReportRequest) Reset() {
+	*x = SLOReportRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOReportRequest) ProtoMessage() {}
 
-func (x *GenWrappersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[21]
+func (x *SLOReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1929,35 +3477,61 @@ func (x *GenWrappersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenWrappersResponse.ProtoReflect.Descriptor instead.
-func (*GenWrappersResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use SLOReportRequest.ProtoReflect.Descriptor instead.
+func (*SLOReportRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43}
 }
 
-type SecretsRefreshRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+func (x *SLOReportRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *SLOReportRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *SLOReportRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type SLOReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// endpoints are the endpoints that received requests within the window,
+	// sorted by service and endpoint name.
+	Endpoints []*SLOReportResponse_EndpointStats `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// requests is the number of requests the report was computed from.
+	Requests int64 `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	// truncated is true if the window contained more requests than could be
+	// considered, in which case only the most recent ones were included.
+	Truncated     bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SecretsRefreshRequest) Reset() {
-	*x = SecretsRefreshRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[22]
+func (x *SLOReportResponse) Reset() {
+	*x = SLOReportResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SecretsRefreshRequest) String() string {
+func (x *SLOReportResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SecretsRefreshRequest) ProtoMessage() {}
+func (*SLOReportResponse) ProtoMessage() {}
 
-func (x *SecretsRefreshRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[22]
+func (x *SLOReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1968,53 +3542,58 @@ func (x *SecretsRefreshRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SecretsRefreshRequest.ProtoReflect.Descriptor instead.
-func (*SecretsRefreshRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use SLOReportResponse.ProtoReflect.Descriptor instead.
+func (*SLOReportResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *SecretsRefreshRequest) GetAppRoot() string {
+func (x *SLOReportResponse) GetEndpoints() []*SLOReportResponse_EndpointStats {
 	if x != nil {
-		return x.AppRoot
+		return x.Endpoints
 	}
-	return ""
+	return nil
 }
 
-func (x *SecretsRefreshRequest) GetKey() string {
+func (x *SLOReportResponse) GetRequests() int64 {
 	if x != nil {
-		return x.Key
+		return x.Requests
 	}
-	return ""
+	return 0
 }
 
-func (x *SecretsRefreshRequest) GetValue() string {
+func (x *SLOReportResponse) GetTruncated() bool {
 	if x != nil {
-		return x.Value
+		return x.Truncated
 	}
-	return ""
+	return false
 }
 
-type SecretsRefreshResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type UsageReportRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// from is the start of the time window to report on.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// to, if set, is the end of the time window to report on.
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SecretsRefreshResponse) Reset() {
-	*x = SecretsRefreshResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[23]
+func (x *UsageReportRequest) Reset() {
+	*x = UsageReportRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SecretsRefreshResponse) String() string {
+func (x *UsageReportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SecretsRefreshResponse) ProtoMessage() {}
+func (*UsageReportRequest) ProtoMessage() {}
 
-func (x *SecretsRefreshResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[23]
+func (x *UsageReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2025,34 +3604,58 @@ func (x *SecretsRefreshResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SecretsRefreshResponse.ProtoReflect.Descriptor instead.
-func (*SecretsRefreshResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use UsageReportRequest.ProtoReflect.Descriptor instead.
+func (*UsageReportRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45}
 }
 
-type VersionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	ConfigHash    string                 `protobuf:"bytes,2,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+func (x *UsageReportRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *UsageReportRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *UsageReportRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type UsageReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// usages are the observed (endpoint, caller) pairs within the window,
+	// sorted by service, endpoint, caller kind and caller.
+	Usages []*UsageReportResponse_EndpointUsage `protobuf:"bytes,1,rep,name=usages,proto3" json:"usages,omitempty"`
+	// traces is the number of traces the report was computed from.
+	Traces        int32 `protobuf:"varint,2,opt,name=traces,proto3" json:"traces,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VersionResponse) Reset() {
-	*x = VersionResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[24]
+func (x *UsageReportResponse) Reset() {
+	*x = UsageReportResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VersionResponse) String() string {
+func (x *UsageReportResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VersionResponse) ProtoMessage() {}
+func (*UsageReportResponse) ProtoMessage() {}
 
-func (x *VersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[24]
+func (x *UsageReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2063,51 +3666,52 @@ func (x *VersionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
-func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use UsageReportResponse.ProtoReflect.Descriptor instead.
+func (*UsageReportResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *VersionResponse) GetVersion() string {
+func (x *UsageReportResponse) GetUsages() []*UsageReportResponse_EndpointUsage {
 	if x != nil {
-		return x.Version
+		return x.Usages
 	}
-	return ""
+	return nil
 }
 
-func (x *VersionResponse) GetConfigHash() string {
+func (x *UsageReportResponse) GetTraces() int32 {
 	if x != nil {
-		return x.ConfigHash
+		return x.Traces
 	}
-	return ""
+	return 0
 }
 
-type Namespace struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	LastActiveAt  *string                `protobuf:"bytes,5,opt,name=last_active_at,json=lastActiveAt,proto3,oneof" json:"last_active_at,omitempty"`
+type ListTracesRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// from, if set, only lists traces that started after it.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// limit is the maximum number of traces to list, most recent first.
+	// If zero a default limit is used.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Namespace) Reset() {
-	*x = Namespace{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[25]
+func (x *ListTracesRequest) Reset() {
+	*x = ListTracesRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Namespace) String() string {
+func (x *ListTracesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Namespace) ProtoMessage() {}
+func (*ListTracesRequest) ProtoMessage() {}
 
-func (x *Namespace) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[25]
+func (x *ListTracesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2118,69 +3722,55 @@ func (x *Namespace) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Namespace.ProtoReflect.Descriptor instead.
-func (*Namespace) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *Namespace) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use ListTracesRequest.ProtoReflect.Descriptor instead.
+func (*ListTracesRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *Namespace) GetName() string {
+func (x *ListTracesRequest) GetAppRoot() string {
 	if x != nil {
-		return x.Name
+		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *Namespace) GetActive() bool {
+func (x *ListTracesRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Active
+		return x.From
 	}
-	return false
+	return nil
 }
 
-func (x *Namespace) GetCreatedAt() string {
+func (x *ListTracesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.CreatedAt
-	}
-	return ""
-}
-
-func (x *Namespace) GetLastActiveAt() string {
-	if x != nil && x.LastActiveAt != nil {
-		return *x.LastActiveAt
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type CreateNamespaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+type ListTracesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// traces are the listed traces, sorted by when they started.
+	Traces        []*ListTracesResponse_Trace `protobuf:"bytes,1,rep,name=traces,proto3" json:"traces,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateNamespaceRequest) Reset() {
-	*x = CreateNamespaceRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[26]
+func (x *ListTracesResponse) Reset() {
+	*x = ListTracesResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateNamespaceRequest) String() string {
+func (x *ListTracesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateNamespaceRequest) ProtoMessage() {}
+func (*ListTracesResponse) ProtoMessage() {}
 
-func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[26]
+func (x *ListTracesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2191,49 +3781,40 @@ func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{26}
-}
-
-func (x *CreateNamespaceRequest) GetAppRoot() string {
-	if x != nil {
-		return x.AppRoot
-	}
-	return ""
+// Deprecated: Use ListTracesResponse.ProtoReflect.Descriptor instead.
+func (*ListTracesResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *CreateNamespaceRequest) GetName() string {
+func (x *ListTracesResponse) GetTraces() []*ListTracesResponse_Trace {
 	if x != nil {
-		return x.Name
+		return x.Traces
 	}
-	return ""
+	return nil
 }
 
-type SwitchNamespaceRequest struct {
+// The following messages are used for sqlc plugin integration.
+type SQLCPlugin struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Create        bool                   `protobuf:"varint,3,opt,name=create,proto3" json:"create,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SwitchNamespaceRequest) Reset() {
-	*x = SwitchNamespaceRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[27]
+func (x *SQLCPlugin) Reset() {
+	*x = SQLCPlugin{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SwitchNamespaceRequest) String() string {
+func (x *SQLCPlugin) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SwitchNamespaceRequest) ProtoMessage() {}
+func (*SQLCPlugin) ProtoMessage() {}
 
-func (x *SwitchNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[27]
+func (x *SQLCPlugin) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2244,99 +3825,146 @@ func (x *SwitchNamespaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SwitchNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*SwitchNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use SQLCPlugin.ProtoReflect.Descriptor instead.
+func (*SQLCPlugin) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *SwitchNamespaceRequest) GetAppRoot() string {
+type SLOReportResponse_EndpointStats struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Service  string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Endpoint string                 `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Requests int64                  `protobuf:"varint,3,opt,name=requests,proto3" json:"requests,omitempty"` // number of requests
+	Errors   int64                  `protobuf:"varint,4,opt,name=errors,proto3" json:"errors,omitempty"`     // number of failed requests
+	// Latency percentiles, in nanoseconds.
+	P50Nanos      uint64 `protobuf:"varint,5,opt,name=p50_nanos,json=p50Nanos,proto3" json:"p50_nanos,omitempty"`
+	P90Nanos      uint64 `protobuf:"varint,6,opt,name=p90_nanos,json=p90Nanos,proto3" json:"p90_nanos,omitempty"`
+	P95Nanos      uint64 `protobuf:"varint,7,opt,name=p95_nanos,json=p95Nanos,proto3" json:"p95_nanos,omitempty"`
+	P99Nanos      uint64 `protobuf:"varint,8,opt,name=p99_nanos,json=p99Nanos,proto3" json:"p99_nanos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SLOReportResponse_EndpointStats) Reset() {
+	*x = SLOReportResponse_EndpointStats{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOReportResponse_EndpointStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOReportResponse_EndpointStats) ProtoMessage() {}
+
+func (x *SLOReportResponse_EndpointStats) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
 	if x != nil {
-		return x.AppRoot
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *SwitchNamespaceRequest) GetName() string {
+// Deprecated: Use SLOReportResponse_EndpointStats.ProtoReflect.Descriptor instead.
+func (*SLOReportResponse_EndpointStats) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{44, 0}
+}
+
+func (x *SLOReportResponse_EndpointStats) GetService() string {
 	if x != nil {
-		return x.Name
+		return x.Service
 	}
 	return ""
 }
 
-func (x *SwitchNamespaceRequest) GetCreate() bool {
+func (x *SLOReportResponse_EndpointStats) GetEndpoint() string {
 	if x != nil {
-		return x.Create
+		return x.Endpoint
 	}
-	return false
-}
-
-type ListNamespacesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return ""
 }
-
-func (x *ListNamespacesRequest) Reset() {
-	*x = ListNamespacesRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[28]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+
+func (x *SLOReportResponse_EndpointStats) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
 }
 
-func (x *ListNamespacesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SLOReportResponse_EndpointStats) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
 }
 
-func (*ListNamespacesRequest) ProtoMessage() {}
+func (x *SLOReportResponse_EndpointStats) GetP50Nanos() uint64 {
+	if x != nil {
+		return x.P50Nanos
+	}
+	return 0
+}
 
-func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[28]
+func (x *SLOReportResponse_EndpointStats) GetP90Nanos() uint64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.P90Nanos
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
-func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{28}
+func (x *SLOReportResponse_EndpointStats) GetP95Nanos() uint64 {
+	if x != nil {
+		return x.P95Nanos
+	}
+	return 0
 }
 
-func (x *ListNamespacesRequest) GetAppRoot() string {
+func (x *SLOReportResponse_EndpointStats) GetP99Nanos() uint64 {
 	if x != nil {
-		return x.AppRoot
+		return x.P99Nanos
 	}
-	return ""
+	return 0
 }
 
-type DeleteNamespaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type UsageReportResponse_EndpointUsage struct {
+	state      protoimpl.MessageState         `protogen:"open.v1"`
+	Service    string                         `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Endpoint   string                         `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	CallerKind UsageReportResponse_CallerKind `protobuf:"varint,3,opt,name=caller_kind,json=callerKind,proto3,enum=encore.daemon.UsageReportResponse_CallerKind" json:"caller_kind,omitempty"`
+	// caller is the calling service for CALLER_SERVICE, and the
+	// authenticated user id for CALLER_EXTERNAL (empty if unauthenticated).
+	Caller   string `protobuf:"bytes,4,opt,name=caller,proto3" json:"caller,omitempty"`
+	Requests int64  `protobuf:"varint,5,opt,name=requests,proto3" json:"requests,omitempty"` // number of requests
+	Errors   int64  `protobuf:"varint,6,opt,name=errors,proto3" json:"errors,omitempty"`     // number of failed requests
+	// last_called is when the caller most recently called the endpoint.
+	LastCalled *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_called,json=lastCalled,proto3" json:"last_called,omitempty"`
+	// example_trace_id is the id of the most recent trace
+	// in which the caller called the endpoint.
+	ExampleTraceId string `protobuf:"bytes,8,opt,name=example_trace_id,json=exampleTraceId,proto3" json:"example_trace_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *DeleteNamespaceRequest) Reset() {
-	*x = DeleteNamespaceRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[29]
+func (x *UsageReportResponse_EndpointUsage) Reset() {
+	*x = UsageReportResponse_EndpointUsage{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteNamespaceRequest) String() string {
+func (x *UsageReportResponse_EndpointUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteNamespaceRequest) ProtoMessage() {}
+func (*UsageReportResponse_EndpointUsage) ProtoMessage() {}
 
-func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[29]
+func (x *UsageReportResponse_EndpointUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2347,93 +3975,92 @@ func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use UsageReportResponse_EndpointUsage.ProtoReflect.Descriptor instead.
+func (*UsageReportResponse_EndpointUsage) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{46, 0}
 }
 
-func (x *DeleteNamespaceRequest) GetAppRoot() string {
+func (x *UsageReportResponse_EndpointUsage) GetService() string {
 	if x != nil {
-		return x.AppRoot
+		return x.Service
 	}
 	return ""
 }
 
-func (x *DeleteNamespaceRequest) GetName() string {
+func (x *UsageReportResponse_EndpointUsage) GetEndpoint() string {
 	if x != nil {
-		return x.Name
+		return x.Endpoint
 	}
 	return ""
 }
 
-type ListNamespacesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespaces    []*Namespace           `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UsageReportResponse_EndpointUsage) GetCallerKind() UsageReportResponse_CallerKind {
+	if x != nil {
+		return x.CallerKind
+	}
+	return UsageReportResponse_CALLER_EXTERNAL
 }
 
-func (x *ListNamespacesResponse) Reset() {
-	*x = ListNamespacesResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[30]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UsageReportResponse_EndpointUsage) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
 }
 
-func (x *ListNamespacesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UsageReportResponse_EndpointUsage) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
 }
 
-func (*ListNamespacesResponse) ProtoMessage() {}
-
-func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[30]
+func (x *UsageReportResponse_EndpointUsage) GetErrors() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Errors
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
-func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{30}
+func (x *UsageReportResponse_EndpointUsage) GetLastCalled() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCalled
+	}
+	return nil
 }
 
-func (x *ListNamespacesResponse) GetNamespaces() []*Namespace {
+func (x *UsageReportResponse_EndpointUsage) GetExampleTraceId() string {
 	if x != nil {
-		return x.Namespaces
+		return x.ExampleTraceId
 	}
-	return nil
+	return ""
 }
 
-type TelemetryConfig struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AnonId        string                 `protobuf:"bytes,1,opt,name=anon_id,json=anonId,proto3" json:"anon_id,omitempty"`
-	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Debug         bool                   `protobuf:"varint,3,opt,name=debug,proto3" json:"debug,omitempty"`
+type ListTracesResponse_Trace struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TraceId   string                 `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// spans are the spans of the trace, in depth-first order.
+	Spans         []*ListTracesResponse_Span `protobuf:"bytes,3,rep,name=spans,proto3" json:"spans,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TelemetryConfig) Reset() {
-	*x = TelemetryConfig{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[31]
+func (x *ListTracesResponse_Trace) Reset() {
+	*x = ListTracesResponse_Trace{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TelemetryConfig) String() string {
+func (x *ListTracesResponse_Trace) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TelemetryConfig) ProtoMessage() {}
+func (*ListTracesResponse_Trace) ProtoMessage() {}
 
-func (x *TelemetryConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[31]
+func (x *ListTracesResponse_Trace) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2444,61 +4071,66 @@ func (x *TelemetryConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TelemetryConfig.ProtoReflect.Descriptor instead.
-func (*TelemetryConfig) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use ListTracesResponse_Trace.ProtoReflect.Descriptor instead.
+func (*ListTracesResponse_Trace) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{48, 0}
 }
 
-func (x *TelemetryConfig) GetAnonId() string {
+func (x *ListTracesResponse_Trace) GetTraceId() string {
 	if x != nil {
-		return x.AnonId
+		return x.TraceId
 	}
 	return ""
 }
 
-func (x *TelemetryConfig) GetEnabled() bool {
+func (x *ListTracesResponse_Trace) GetStartedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Enabled
+		return x.StartedAt
 	}
-	return false
+	return nil
 }
 
-func (x *TelemetryConfig) GetDebug() bool {
+func (x *ListTracesResponse_Trace) GetSpans() []*ListTracesResponse_Span {
 	if x != nil {
-		return x.Debug
+		return x.Spans
 	}
-	return false
+	return nil
 }
 
-type DumpMetaRequest struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"` // for error reporting
-	// environ is the environment to set for the running command.
-	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
-	Environ []string `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
-	// Whether or not to parse tests.
-	ParseTests    bool                   `protobuf:"varint,4,opt,name=parse_tests,json=parseTests,proto3" json:"parse_tests,omitempty"`
-	Format        DumpMetaRequest_Format `protobuf:"varint,5,opt,name=format,proto3,enum=encore.daemon.DumpMetaRequest_Format" json:"format,omitempty"`
+type ListTracesResponse_Span struct {
+	state protoimpl.MessageState       `protogen:"open.v1"`
+	Kind  ListTracesResponse_Span_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=encore.daemon.ListTracesResponse_Span_Kind" json:"kind,omitempty"`
+	// depth is the nesting depth of the span within the trace,
+	// with 0 for the root span.
+	Depth   int32  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	Service string `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	// name is the endpoint name for requests and auth, the topic and
+	// subscription ("topic/subscription") for Pub/Sub messages,
+	// and the test name for tests.
+	Name          string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	DurationNanos uint64 `protobuf:"varint,5,opt,name=duration_nanos,json=durationNanos,proto3" json:"duration_nanos,omitempty"`
+	IsError       bool   `protobuf:"varint,6,opt,name=is_error,json=isError,proto3" json:"is_error,omitempty"`
+	// operations are the operations made by the span, in order.
+	Operations    []*ListTracesResponse_Operation `protobuf:"bytes,7,rep,name=operations,proto3" json:"operations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DumpMetaRequest) Reset() {
-	*x = DumpMetaRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[32]
+func (x *ListTracesResponse_Span) Reset() {
+	*x = ListTracesResponse_Span{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DumpMetaRequest) String() string {
+func (x *ListTracesResponse_Span) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpMetaRequest) ProtoMessage() {}
+func (*ListTracesResponse_Span) ProtoMessage() {}
 
-func (x *DumpMetaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[32]
+func (x *ListTracesResponse_Span) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2509,68 +4141,85 @@ func (x *DumpMetaRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpMetaRequest.ProtoReflect.Descriptor instead.
-func (*DumpMetaRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use ListTracesResponse_Span.ProtoReflect.Descriptor instead.
+func (*ListTracesResponse_Span) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{48, 1}
 }
 
-func (x *DumpMetaRequest) GetAppRoot() string {
+func (x *ListTracesResponse_Span) GetKind() ListTracesResponse_Span_Kind {
 	if x != nil {
-		return x.AppRoot
+		return x.Kind
+	}
+	return ListTracesResponse_Span_REQUEST
+}
+
+func (x *ListTracesResponse_Span) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ListTracesResponse_Span) GetService() string {
+	if x != nil {
+		return x.Service
 	}
 	return ""
 }
 
-func (x *DumpMetaRequest) GetWorkingDir() string {
+func (x *ListTracesResponse_Span) GetName() string {
 	if x != nil {
-		return x.WorkingDir
+		return x.Name
 	}
 	return ""
 }
 
-func (x *DumpMetaRequest) GetEnviron() []string {
+func (x *ListTracesResponse_Span) GetDurationNanos() uint64 {
 	if x != nil {
-		return x.Environ
+		return x.DurationNanos
 	}
-	return nil
+	return 0
 }
 
-func (x *DumpMetaRequest) GetParseTests() bool {
+func (x *ListTracesResponse_Span) GetIsError() bool {
 	if x != nil {
-		return x.ParseTests
+		return x.IsError
 	}
 	return false
 }
 
-func (x *DumpMetaRequest) GetFormat() DumpMetaRequest_Format {
+func (x *ListTracesResponse_Span) GetOperations() []*ListTracesResponse_Operation {
 	if x != nil {
-		return x.Format
+		return x.Operations
 	}
-	return DumpMetaRequest_FORMAT_UNSPECIFIED
+	return nil
 }
 
-type DumpMetaResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Meta          []byte                 `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+type ListTracesResponse_Operation struct {
+	state protoimpl.MessageState            `protogen:"open.v1"`
+	Kind  ListTracesResponse_Operation_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=encore.daemon.ListTracesResponse_Operation_Kind" json:"kind,omitempty"`
+	// desc describes the operation, such as the called endpoint,
+	// the database query or the log message.
+	Desc          string `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DumpMetaResponse) Reset() {
-	*x = DumpMetaResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[33]
+func (x *ListTracesResponse_Operation) Reset() {
+	*x = ListTracesResponse_Operation{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DumpMetaResponse) String() string {
+func (x *ListTracesResponse_Operation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpMetaResponse) ProtoMessage() {}
+func (*ListTracesResponse_Operation) ProtoMessage() {}
 
-func (x *DumpMetaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[33]
+func (x *ListTracesResponse_Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2581,53 +4230,23 @@ func (x *DumpMetaResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpMetaResponse.ProtoReflect.Descriptor instead.
-func (*DumpMetaResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use ListTracesResponse_Operation.ProtoReflect.Descriptor instead.
+func (*ListTracesResponse_Operation) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{48, 2}
 }
 
-func (x *DumpMetaResponse) GetMeta() []byte {
+func (x *ListTracesResponse_Operation) GetKind() ListTracesResponse_Operation_Kind {
 	if x != nil {
-		return x.Meta
+		return x.Kind
 	}
-	return nil
-}
-
-// The following messages are used for sqlc plugin integration.
-type SQLCPlugin struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *SQLCPlugin) Reset() {
-	*x = SQLCPlugin{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[34]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *SQLCPlugin) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ListTracesResponse_Operation_RPC_CALL
 }
 
-func (*SQLCPlugin) ProtoMessage() {}
-
-func (x *SQLCPlugin) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[34]
+func (x *ListTracesResponse_Operation) GetDesc() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Desc
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use SQLCPlugin.ProtoReflect.Descriptor instead.
-func (*SQLCPlugin) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34}
+	return ""
 }
 
 type SQLCPlugin_File struct {
@@ -2640,7 +4259,7 @@ type SQLCPlugin_File struct {
 
 func (x *SQLCPlugin_File) Reset() {
 	*x = SQLCPlugin_File{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[35]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2652,7 +4271,7 @@ func (x *SQLCPlugin_File) String() string {
 func (*SQLCPlugin_File) ProtoMessage() {}
 
 func (x *SQLCPlugin_File) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[35]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2665,7 +4284,7 @@ func (x *SQLCPlugin_File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_File.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_File) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 0}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 0}
 }
 
 func (x *SQLCPlugin_File) GetName() string {
@@ -2695,7 +4314,7 @@ type SQLCPlugin_Settings struct {
 
 func (x *SQLCPlugin_Settings) Reset() {
 	*x = SQLCPlugin_Settings{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[36]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2707,7 +4326,7 @@ func (x *SQLCPlugin_Settings) String() string {
 func (*SQLCPlugin_Settings) ProtoMessage() {}
 
 func (x *SQLCPlugin_Settings) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[36]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2720,7 +4339,7 @@ func (x *SQLCPlugin_Settings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Settings.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Settings) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 1}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 1}
 }
 
 func (x *SQLCPlugin_Settings) GetVersion() string {
@@ -2772,7 +4391,7 @@ type SQLCPlugin_Codegen struct {
 
 func (x *SQLCPlugin_Codegen) Reset() {
 	*x = SQLCPlugin_Codegen{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[37]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2784,7 +4403,7 @@ func (x *SQLCPlugin_Codegen) String() string {
 func (*SQLCPlugin_Codegen) ProtoMessage() {}
 
 func (x *SQLCPlugin_Codegen) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[37]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2797,7 +4416,7 @@ func (x *SQLCPlugin_Codegen) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Codegen.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Codegen) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 2}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 2}
 }
 
 func (x *SQLCPlugin_Codegen) GetOut() string {
@@ -2854,7 +4473,7 @@ type SQLCPlugin_Catalog struct {
 
 func (x *SQLCPlugin_Catalog) Reset() {
 	*x = SQLCPlugin_Catalog{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[38]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2866,7 +4485,7 @@ func (x *SQLCPlugin_Catalog) String() string {
 func (*SQLCPlugin_Catalog) ProtoMessage() {}
 
 func (x *SQLCPlugin_Catalog) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[38]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2879,7 +4498,7 @@ func (x *SQLCPlugin_Catalog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Catalog.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Catalog) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 3}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 3}
 }
 
 func (x *SQLCPlugin_Catalog) GetComment() string {
@@ -2923,7 +4542,7 @@ type SQLCPlugin_Schema struct {
 
 func (x *SQLCPlugin_Schema) Reset() {
 	*x = SQLCPlugin_Schema{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[39]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2935,7 +4554,7 @@ func (x *SQLCPlugin_Schema) String() string {
 func (*SQLCPlugin_Schema) ProtoMessage() {}
 
 func (x *SQLCPlugin_Schema) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[39]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2948,7 +4567,7 @@ func (x *SQLCPlugin_Schema) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Schema.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Schema) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 4}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 4}
 }
 
 func (x *SQLCPlugin_Schema) GetComment() string {
@@ -2996,7 +4615,7 @@ type SQLCPlugin_CompositeType struct {
 
 func (x *SQLCPlugin_CompositeType) Reset() {
 	*x = SQLCPlugin_CompositeType{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[40]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3008,7 +4627,7 @@ func (x *SQLCPlugin_CompositeType) String() string {
 func (*SQLCPlugin_CompositeType) ProtoMessage() {}
 
 func (x *SQLCPlugin_CompositeType) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[40]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3021,7 +4640,7 @@ func (x *SQLCPlugin_CompositeType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_CompositeType.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_CompositeType) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 5}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 5}
 }
 
 func (x *SQLCPlugin_CompositeType) GetName() string {
@@ -3049,7 +4668,7 @@ type SQLCPlugin_Enum struct {
 
 func (x *SQLCPlugin_Enum) Reset() {
 	*x = SQLCPlugin_Enum{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[41]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3061,7 +4680,7 @@ func (x *SQLCPlugin_Enum) String() string {
 func (*SQLCPlugin_Enum) ProtoMessage() {}
 
 func (x *SQLCPlugin_Enum) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[41]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3074,7 +4693,7 @@ func (x *SQLCPlugin_Enum) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Enum.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Enum) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 6}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 6}
 }
 
 func (x *SQLCPlugin_Enum) GetName() string {
@@ -3109,7 +4728,7 @@ type SQLCPlugin_Table struct {
 
 func (x *SQLCPlugin_Table) Reset() {
 	*x = SQLCPlugin_Table{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[42]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3121,7 +4740,7 @@ func (x *SQLCPlugin_Table) String() string {
 func (*SQLCPlugin_Table) ProtoMessage() {}
 
 func (x *SQLCPlugin_Table) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[42]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3134,7 +4753,7 @@ func (x *SQLCPlugin_Table) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Table.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Table) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 7}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 7}
 }
 
 func (x *SQLCPlugin_Table) GetRel() *SQLCPlugin_Identifier {
@@ -3169,7 +4788,7 @@ type SQLCPlugin_Identifier struct {
 
 func (x *SQLCPlugin_Identifier) Reset() {
 	*x = SQLCPlugin_Identifier{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3181,7 +4800,7 @@ func (x *SQLCPlugin_Identifier) String() string {
 func (*SQLCPlugin_Identifier) ProtoMessage() {}
 
 func (x *SQLCPlugin_Identifier) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3194,7 +4813,7 @@ func (x *SQLCPlugin_Identifier) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Identifier.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Identifier) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 8}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 8}
 }
 
 func (x *SQLCPlugin_Identifier) GetCatalog() string {
@@ -3243,7 +4862,7 @@ type SQLCPlugin_Column struct {
 
 func (x *SQLCPlugin_Column) Reset() {
 	*x = SQLCPlugin_Column{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3255,7 +4874,7 @@ func (x *SQLCPlugin_Column) String() string {
 func (*SQLCPlugin_Column) ProtoMessage() {}
 
 func (x *SQLCPlugin_Column) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3268,7 +4887,7 @@ func (x *SQLCPlugin_Column) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Column.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Column) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 9}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 9}
 }
 
 func (x *SQLCPlugin_Column) GetName() string {
@@ -3399,7 +5018,7 @@ type SQLCPlugin_Query struct {
 
 func (x *SQLCPlugin_Query) Reset() {
 	*x = SQLCPlugin_Query{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3411,7 +5030,7 @@ func (x *SQLCPlugin_Query) String() string {
 func (*SQLCPlugin_Query) ProtoMessage() {}
 
 func (x *SQLCPlugin_Query) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3424,7 +5043,7 @@ func (x *SQLCPlugin_Query) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Query.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Query) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 10}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 10}
 }
 
 func (x *SQLCPlugin_Query) GetText() string {
@@ -3493,7 +5112,7 @@ type SQLCPlugin_Parameter struct {
 
 func (x *SQLCPlugin_Parameter) Reset() {
 	*x = SQLCPlugin_Parameter{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3505,7 +5124,7 @@ func (x *SQLCPlugin_Parameter) String() string {
 func (*SQLCPlugin_Parameter) ProtoMessage() {}
 
 func (x *SQLCPlugin_Parameter) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3518,7 +5137,7 @@ func (x *SQLCPlugin_Parameter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Parameter.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Parameter) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 11}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 11}
 }
 
 func (x *SQLCPlugin_Parameter) GetNumber() int32 {
@@ -3549,7 +5168,7 @@ type SQLCPlugin_GenerateRequest struct {
 
 func (x *SQLCPlugin_GenerateRequest) Reset() {
 	*x = SQLCPlugin_GenerateRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3561,7 +5180,7 @@ func (x *SQLCPlugin_GenerateRequest) String() string {
 func (*SQLCPlugin_GenerateRequest) ProtoMessage() {}
 
 func (x *SQLCPlugin_GenerateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3574,7 +5193,7 @@ func (x *SQLCPlugin_GenerateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_GenerateRequest.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_GenerateRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 12}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 12}
 }
 
 func (x *SQLCPlugin_GenerateRequest) GetSettings() *SQLCPlugin_Settings {
@@ -3628,7 +5247,7 @@ type SQLCPlugin_GenerateResponse struct {
 
 func (x *SQLCPlugin_GenerateResponse) Reset() {
 	*x = SQLCPlugin_GenerateResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3640,7 +5259,7 @@ func (x *SQLCPlugin_GenerateResponse) String() string {
 func (*SQLCPlugin_GenerateResponse) ProtoMessage() {}
 
 func (x *SQLCPlugin_GenerateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3653,7 +5272,7 @@ func (x *SQLCPlugin_GenerateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_GenerateResponse.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_GenerateResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 13}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 13}
 }
 
 func (x *SQLCPlugin_GenerateResponse) GetFiles() []*SQLCPlugin_File {
@@ -3672,7 +5291,7 @@ type SQLCPlugin_Codegen_Process struct {
 
 func (x *SQLCPlugin_Codegen_Process) Reset() {
 	*x = SQLCPlugin_Codegen_Process{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3684,7 +5303,7 @@ func (x *SQLCPlugin_Codegen_Process) String() string {
 func (*SQLCPlugin_Codegen_Process) ProtoMessage() {}
 
 func (x *SQLCPlugin_Codegen_Process) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3697,7 +5316,7 @@ func (x *SQLCPlugin_Codegen_Process) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Codegen_Process.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Codegen_Process) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 2, 0}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 2, 0}
 }
 
 func (x *SQLCPlugin_Codegen_Process) GetCmd() string {
@@ -3717,7 +5336,7 @@ type SQLCPlugin_Codegen_WASM struct {
 
 func (x *SQLCPlugin_Codegen_WASM) Reset() {
 	*x = SQLCPlugin_Codegen_WASM{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3729,7 +5348,7 @@ func (x *SQLCPlugin_Codegen_WASM) String() string {
 func (*SQLCPlugin_Codegen_WASM) ProtoMessage() {}
 
 func (x *SQLCPlugin_Codegen_WASM) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3742,7 +5361,7 @@ func (x *SQLCPlugin_Codegen_WASM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Codegen_WASM.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Codegen_WASM) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34, 2, 1}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49, 2, 1}
 }
 
 func (x *SQLCPlugin_Codegen_WASM) GetUrl() string {
@@ -3763,7 +5382,7 @@ var File_encore_daemon_daemon_proto protoreflect.FileDescriptor
 
 const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\n" +
-	"\x1aencore/daemon/daemon.proto\x12\rencore.daemon\x1a\x1bgoogle/protobuf/empty.proto\"\xc0\x01\n" +
+	"\x1aencore/daemon/daemon.proto\x12\rencore.daemon\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc0\x01\n" +
 	"\x0eCommandMessage\x126\n" +
 	"\x06output\x18\x01 \x01(\v2\x1c.encore.daemon.CommandOutputH\x00R\x06output\x120\n" +
 	"\x04exit\x18\x02 \x01(\v2\x1a.encore.daemon.CommandExitH\x00R\x04exit\x12=\n" +
@@ -3781,7 +5400,7 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\btemplate\x18\x02 \x01(\tR\btemplate\x12\x1a\n" +
 	"\btutorial\x18\x03 \x01(\bR\btutorial\"*\n" +
 	"\x11CreateAppResponse\x12\x15\n" +
-	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\xbf\x04\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\xcc\x05\n" +
 	"\n" +
 	"RunRequest\x12\x19\n" +
 	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1f\n" +
@@ -3798,7 +5417,10 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	" \x01(\x0e2%.encore.daemon.RunRequest.BrowserModeR\abrowser\x12B\n" +
 	"\n" +
 	"debug_mode\x18\v \x01(\x0e2#.encore.daemon.RunRequest.DebugModeR\tdebugMode\x12 \n" +
-	"\tlog_level\x18\f \x01(\tH\x02R\blogLevel\x88\x01\x01\"F\n" +
+	"\tlog_level\x18\f \x01(\tH\x02R\blogLevel\x88\x01\x01\x12\x16\n" +
+	"\x06tunnel\x18\r \x01(\bR\x06tunnel\x12(\n" +
+	"\rtunnel_server\x18\x0e \x01(\tH\x03R\ftunnelServer\x88\x01\x01\x12&\n" +
+	"\ftunnel_token\x18\x0f \x01(\tH\x04R\vtunnelToken\x88\x01\x01\"F\n" +
 	"\vBrowserMode\x12\x10\n" +
 	"\fBROWSER_AUTO\x10\x00\x12\x11\n" +
 	"\rBROWSER_NEVER\x10\x01\x12\x12\n" +
@@ -3811,7 +5433,9 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\n" +
 	"_namespaceB\f\n" +
 	"\n" +
-	"_log_level\"\xf0\x01\n" +
+	"_log_levelB\x10\n" +
+	"\x0e_tunnel_serverB\x0f\n" +
+	"\r_tunnel_token\"\xf0\x01\n" +
 	"\vTestRequest\x12\x19\n" +
 	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1f\n" +
 	"\vworking_dir\x18\x02 \x01(\tR\n" +
@@ -3900,7 +5524,42 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\fcluster_type\x18\x03 \x01(\x0e2\x1c.encore.daemon.DBClusterTypeR\vclusterType\x12!\n" +
 	"\tnamespace\x18\x04 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
 	"\n" +
-	"_namespace\"\x93\x04\n" +
+	"_namespace\"\x81\x02\n" +
+	"\x14DBMigratePlanRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x19\n" +
+	"\benv_name\x18\x02 \x01(\tR\aenvName\x12%\n" +
+	"\x0edatabase_names\x18\x03 \x03(\tR\rdatabaseNames\x12?\n" +
+	"\fcluster_type\x18\x04 \x01(\x0e2\x1c.encore.daemon.DBClusterTypeR\vclusterType\x12!\n" +
+	"\tnamespace\x18\x05 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12\x1a\n" +
+	"\bvalidate\x18\x06 \x01(\bR\bvalidateB\f\n" +
+	"\n" +
+	"_namespace\"\x94\x02\n" +
+	"\rDBSeedRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1f\n" +
+	"\vworking_dir\x18\x02 \x01(\tR\n" +
+	"workingDir\x12%\n" +
+	"\x0edatabase_names\x18\x03 \x03(\tR\rdatabaseNames\x12?\n" +
+	"\fcluster_type\x18\x04 \x01(\x0e2\x1c.encore.daemon.DBClusterTypeR\vclusterType\x12!\n" +
+	"\tnamespace\x18\x05 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12\x14\n" +
+	"\x05force\x18\x06 \x01(\bR\x05force\x12\x18\n" +
+	"\aenviron\x18\a \x03(\tR\aenvironB\f\n" +
+	"\n" +
+	"_namespace\"U\n" +
+	"\x15DBMigratePlanResponse\x12<\n" +
+	"\tdatabases\x18\x01 \x03(\v2\x1e.encore.daemon.DBMigrationPlanR\tdatabases\"\x93\x01\n" +
+	"\x0fDBMigrationPlan\x12\x17\n" +
+	"\adb_name\x18\x01 \x01(\tR\x06dbName\x12\x16\n" +
+	"\x06exists\x18\x02 \x01(\bR\x06exists\x129\n" +
+	"\apending\x18\x03 \x03(\v2\x1f.encore.daemon.PendingMigrationR\apending\x12\x14\n" +
+	"\x05drift\x18\x04 \x03(\tR\x05drift\"\x82\x02\n" +
+	"\x10PendingMigration\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x04R\x06number\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x125\n" +
+	"\x16destructive_statements\x18\x04 \x03(\tR\x15destructiveStatements\x12\x1c\n" +
+	"\tvalidated\x18\x05 \x01(\bR\tvalidated\x12.\n" +
+	"\x10validation_error\x18\x06 \x01(\tH\x00R\x0fvalidationError\x88\x01\x01B\x13\n" +
+	"\x11_validation_error\"\x93\x04\n" +
 	"\x10GenClientRequest\x12\x15\n" +
 	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x19\n" +
 	"\benv_name\x18\x02 \x01(\tR\aenvName\x12\x12\n" +
@@ -3958,7 +5617,7 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x0fTelemetryConfig\x12\x17\n" +
 	"\aanon_id\x18\x01 \x01(\tR\x06anonId\x12\x18\n" +
 	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x14\n" +
-	"\x05debug\x18\x03 \x01(\bR\x05debug\"\x8c\x02\n" +
+	"\x05debug\x18\x03 \x01(\bR\x05debug\"\xa8\x02\n" +
 	"\x0fDumpMetaRequest\x12\x19\n" +
 	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1f\n" +
 	"\vworking_dir\x18\x02 \x01(\tR\n" +
@@ -3966,13 +5625,117 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\aenviron\x18\x03 \x03(\tR\aenviron\x12\x1f\n" +
 	"\vparse_tests\x18\x04 \x01(\bR\n" +
 	"parseTests\x12=\n" +
-	"\x06format\x18\x05 \x01(\x0e2%.encore.daemon.DumpMetaRequest.FormatR\x06format\"C\n" +
+	"\x06format\x18\x05 \x01(\x0e2%.encore.daemon.DumpMetaRequest.FormatR\x06format\x12\x1a\n" +
+	"\brevision\x18\x06 \x01(\tR\brevision\"C\n" +
 	"\x06Format\x12\x16\n" +
 	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\x0f\n" +
 	"\vFORMAT_JSON\x10\x01\x12\x10\n" +
 	"\fFORMAT_PROTO\x10\x02\"&\n" +
 	"\x10DumpMetaResponse\x12\x12\n" +
-	"\x04meta\x18\x01 \x01(\fR\x04meta\"\xcb\x15\n" +
+	"\x04meta\x18\x01 \x01(\fR\x04meta\"/\n" +
+	"\x12URLRegistryRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\"\xec\x03\n" +
+	"\x13URLRegistryResponse\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x19\n" +
+	"\bbase_url\x18\x02 \x01(\tR\abaseUrl\x12L\n" +
+	"\bservices\x18\x03 \x03(\v20.encore.daemon.URLRegistryResponse.ServicesEntryR\bservices\x12L\n" +
+	"\bgateways\x18\x04 \x03(\v20.encore.daemon.URLRegistryResponse.GatewaysEntryR\bgateways\x12O\n" +
+	"\tresources\x18\x05 \x03(\v21.encore.daemon.URLRegistryResponse.ResourcesEntryR\tresources\x1a;\n" +
+	"\rServicesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rGatewaysEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eResourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf5\x01\n" +
+	"\x13PubSubReplayRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12'\n" +
+	"\fsubscription\x18\x05 \x01(\tH\x00R\fsubscription\x88\x01\x01\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRunB\x0f\n" +
+	"\r_subscription\"2\n" +
+	"\x14PubSubReplayResponse\x12\x1a\n" +
+	"\breplayed\x18\x01 \x01(\x05R\breplayed\"\x89\x01\n" +
+	"\x10SLOReportRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\x8b\x03\n" +
+	"\x11SLOReportResponse\x12L\n" +
+	"\tendpoints\x18\x01 \x03(\v2..encore.daemon.SLOReportResponse.EndpointStatsR\tendpoints\x12\x1a\n" +
+	"\brequests\x18\x02 \x01(\x03R\brequests\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x1a\xed\x01\n" +
+	"\rEndpointStats\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\brequests\x18\x03 \x01(\x03R\brequests\x12\x16\n" +
+	"\x06errors\x18\x04 \x01(\x03R\x06errors\x12\x1b\n" +
+	"\tp50_nanos\x18\x05 \x01(\x04R\bp50Nanos\x12\x1b\n" +
+	"\tp90_nanos\x18\x06 \x01(\x04R\bp90Nanos\x12\x1b\n" +
+	"\tp95_nanos\x18\a \x01(\x04R\bp95Nanos\x12\x1b\n" +
+	"\tp99_nanos\x18\b \x01(\x04R\bp99Nanos\"\x8b\x01\n" +
+	"\x12UsageReportRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xf9\x03\n" +
+	"\x13UsageReportResponse\x12H\n" +
+	"\x06usages\x18\x01 \x03(\v20.encore.daemon.UsageReportResponse.EndpointUsageR\x06usages\x12\x16\n" +
+	"\x06traces\x18\x02 \x01(\x05R\x06traces\x1a\xc8\x02\n" +
+	"\rEndpointUsage\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12N\n" +
+	"\vcaller_kind\x18\x03 \x01(\x0e2-.encore.daemon.UsageReportResponse.CallerKindR\n" +
+	"callerKind\x12\x16\n" +
+	"\x06caller\x18\x04 \x01(\tR\x06caller\x12\x1a\n" +
+	"\brequests\x18\x05 \x01(\x03R\brequests\x12\x16\n" +
+	"\x06errors\x18\x06 \x01(\x03R\x06errors\x12;\n" +
+	"\vlast_called\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastCalled\x12(\n" +
+	"\x10example_trace_id\x18\b \x01(\tR\x0eexampleTraceId\"5\n" +
+	"\n" +
+	"CallerKind\x12\x13\n" +
+	"\x0fCALLER_EXTERNAL\x10\x00\x12\x12\n" +
+	"\x0eCALLER_SERVICE\x10\x01\"t\n" +
+	"\x11ListTracesRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x95\x06\n" +
+	"\x12ListTracesResponse\x12?\n" +
+	"\x06traces\x18\x01 \x03(\v2'.encore.daemon.ListTracesResponse.TraceR\x06traces\x1a\x9b\x01\n" +
+	"\x05Trace\x12\x19\n" +
+	"\btrace_id\x18\x01 \x01(\tR\atraceId\x129\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12<\n" +
+	"\x05spans\x18\x03 \x03(\v2&.encore.daemon.ListTracesResponse.SpanR\x05spans\x1a\xd7\x02\n" +
+	"\x04Span\x12?\n" +
+	"\x04kind\x18\x01 \x01(\x0e2+.encore.daemon.ListTracesResponse.Span.KindR\x04kind\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12%\n" +
+	"\x0eduration_nanos\x18\x05 \x01(\x04R\rdurationNanos\x12\x19\n" +
+	"\bis_error\x18\x06 \x01(\bR\aisError\x12K\n" +
+	"\n" +
+	"operations\x18\a \x03(\v2+.encore.daemon.ListTracesResponse.OperationR\n" +
+	"operations\";\n" +
+	"\x04Kind\x12\v\n" +
+	"\aREQUEST\x10\x00\x12\b\n" +
+	"\x04AUTH\x10\x01\x12\x12\n" +
+	"\x0ePUBSUB_MESSAGE\x10\x02\x12\b\n" +
+	"\x04TEST\x10\x03\x1a\xc5\x01\n" +
+	"\tOperation\x12D\n" +
+	"\x04kind\x18\x01 \x01(\x0e20.encore.daemon.ListTracesResponse.Operation.KindR\x04kind\x12\x12\n" +
+	"\x04desc\x18\x02 \x01(\tR\x04desc\"^\n" +
+	"\x04Kind\x12\f\n" +
+	"\bRPC_CALL\x10\x00\x12\f\n" +
+	"\bDB_QUERY\x10\x01\x12\x12\n" +
+	"\x0ePUBSUB_PUBLISH\x10\x02\x12\r\n" +
+	"\tHTTP_CALL\x10\x03\x12\x0e\n" +
+	"\n" +
+	"CACHE_CALL\x10\x04\x12\a\n" +
+	"\x03LOG\x10\x05\"\xcb\x15\n" +
 	"\n" +
 	"SQLCPlugin\x1a6\n" +
 	"\x04File\x12\x12\n" +
@@ -4079,7 +5842,7 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x1bDB_CLUSTER_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13DB_CLUSTER_TYPE_RUN\x10\x01\x12\x18\n" +
 	"\x14DB_CLUSTER_TYPE_TEST\x10\x02\x12\x1a\n" +
-	"\x16DB_CLUSTER_TYPE_SHADOW\x10\x032\xa7\f\n" +
+	"\x16DB_CLUSTER_TYPE_SHADOW\x10\x032\xf4\x10\n" +
 	"\x06Daemon\x12A\n" +
 	"\x03Run\x12\x19.encore.daemon.RunRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12C\n" +
 	"\x04Test\x12\x1a.encore.daemon.TestRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12K\n" +
@@ -4090,7 +5853,9 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x06Export\x12\x1c.encore.daemon.ExportRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12N\n" +
 	"\tDBConnect\x12\x1f.encore.daemon.DBConnectRequest\x1a .encore.daemon.DBConnectResponse\x12I\n" +
 	"\aDBProxy\x12\x1d.encore.daemon.DBProxyRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12I\n" +
-	"\aDBReset\x12\x1d.encore.daemon.DBResetRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12N\n" +
+	"\aDBReset\x12\x1d.encore.daemon.DBResetRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12Z\n" +
+	"\rDBMigratePlan\x12#.encore.daemon.DBMigratePlanRequest\x1a$.encore.daemon.DBMigratePlanResponse\x12G\n" +
+	"\x06DBSeed\x12\x1c.encore.daemon.DBSeedRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12N\n" +
 	"\tGenClient\x12\x1f.encore.daemon.GenClientRequest\x1a .encore.daemon.GenClientResponse\x12T\n" +
 	"\vGenWrappers\x12!.encore.daemon.GenWrappersRequest\x1a\".encore.daemon.GenWrappersResponse\x12]\n" +
 	"\x0eSecretsRefresh\x12$.encore.daemon.SecretsRefreshRequest\x1a%.encore.daemon.SecretsRefreshResponse\x12A\n" +
@@ -4099,7 +5864,13 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x0fSwitchNamespace\x12%.encore.daemon.SwitchNamespaceRequest\x1a\x18.encore.daemon.Namespace\x12]\n" +
 	"\x0eListNamespaces\x12$.encore.daemon.ListNamespacesRequest\x1a%.encore.daemon.ListNamespacesResponse\x12P\n" +
 	"\x0fDeleteNamespace\x12%.encore.daemon.DeleteNamespaceRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
-	"\bDumpMeta\x12\x1e.encore.daemon.DumpMetaRequest\x1a\x1f.encore.daemon.DumpMetaResponse\x12C\n" +
+	"\bDumpMeta\x12\x1e.encore.daemon.DumpMetaRequest\x1a\x1f.encore.daemon.DumpMetaResponse\x12T\n" +
+	"\vURLRegistry\x12!.encore.daemon.URLRegistryRequest\x1a\".encore.daemon.URLRegistryResponse\x12W\n" +
+	"\fPubSubReplay\x12\".encore.daemon.PubSubReplayRequest\x1a#.encore.daemon.PubSubReplayResponse\x12N\n" +
+	"\tSLOReport\x12\x1f.encore.daemon.SLOReportRequest\x1a .encore.daemon.SLOReportResponse\x12T\n" +
+	"\vUsageReport\x12!.encore.daemon.UsageReportRequest\x1a\".encore.daemon.UsageReportResponse\x12Q\n" +
+	"\n" +
+	"ListTraces\x12 .encore.daemon.ListTracesRequest\x1a!.encore.daemon.ListTracesResponse\x12C\n" +
 	"\tTelemetry\x12\x1e.encore.daemon.TelemetryConfig\x1a\x16.google.protobuf.Empty\x12N\n" +
 	"\tCreateApp\x12\x1f.encore.daemon.CreateAppRequest\x1a .encore.daemon.CreateAppResponseB\x1eZ\x1cencr.dev/proto/encore/daemonb\x06proto3"
 
@@ -4115,146 +5886,211 @@ func file_encore_daemon_daemon_proto_rawDescGZIP() []byte {
 	return file_encore_daemon_daemon_proto_rawDescData
 }
 
-var file_encore_daemon_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_encore_daemon_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
+var file_encore_daemon_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_encore_daemon_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
 var file_encore_daemon_daemon_proto_goTypes = []any{
-	(DBRole)(0),                         // 0: encore.daemon.DBRole
-	(DBClusterType)(0),                  // 1: encore.daemon.DBClusterType
-	(RunRequest_BrowserMode)(0),         // 2: encore.daemon.RunRequest.BrowserMode
-	(RunRequest_DebugMode)(0),           // 3: encore.daemon.RunRequest.DebugMode
-	(DumpMetaRequest_Format)(0),         // 4: encore.daemon.DumpMetaRequest.Format
-	(*CommandMessage)(nil),              // 5: encore.daemon.CommandMessage
-	(*CommandOutput)(nil),               // 6: encore.daemon.CommandOutput
-	(*CommandExit)(nil),                 // 7: encore.daemon.CommandExit
-	(*CommandDisplayErrors)(nil),        // 8: encore.daemon.CommandDisplayErrors
-	(*CreateAppRequest)(nil),            // 9: encore.daemon.CreateAppRequest
-	(*CreateAppResponse)(nil),           // 10: encore.daemon.CreateAppResponse
-	(*RunRequest)(nil),                  // 11: encore.daemon.RunRequest
-	(*TestRequest)(nil),                 // 12: encore.daemon.TestRequest
-	(*TestSpecRequest)(nil),             // 13: encore.daemon.TestSpecRequest
-	(*TestSpecResponse)(nil),            // 14: encore.daemon.TestSpecResponse
-	(*ExecScriptRequest)(nil),           // 15: encore.daemon.ExecScriptRequest
-	(*CheckRequest)(nil),                // 16: encore.daemon.CheckRequest
-	(*ExportRequest)(nil),               // 17: encore.daemon.ExportRequest
-	(*DockerExportParams)(nil),          // 18: encore.daemon.DockerExportParams
-	(*DBConnectRequest)(nil),            // 19: encore.daemon.DBConnectRequest
-	(*DBConnectResponse)(nil),           // 20: encore.daemon.DBConnectResponse
-	(*DBProxyRequest)(nil),              // 21: encore.daemon.DBProxyRequest
-	(*DBResetRequest)(nil),              // 22: encore.daemon.DBResetRequest
-	(*GenClientRequest)(nil),            // 23: encore.daemon.GenClientRequest
-	(*GenClientResponse)(nil),           // 24: encore.daemon.GenClientResponse
-	(*GenWrappersRequest)(nil),          // 25: encore.daemon.GenWrappersRequest
-	(*GenWrappersResponse)(nil),         // 26: encore.daemon.GenWrappersResponse
-	(*SecretsRefreshRequest)(nil),       // 27: encore.daemon.SecretsRefreshRequest
-	(*SecretsRefreshResponse)(nil),      // 28: encore.daemon.SecretsRefreshResponse
-	(*VersionResponse)(nil),             // 29: encore.daemon.VersionResponse
-	(*Namespace)(nil),                   // 30: encore.daemon.Namespace
-	(*CreateNamespaceRequest)(nil),      // 31: encore.daemon.CreateNamespaceRequest
-	(*SwitchNamespaceRequest)(nil),      // 32: encore.daemon.SwitchNamespaceRequest
-	(*ListNamespacesRequest)(nil),       // 33: encore.daemon.ListNamespacesRequest
-	(*DeleteNamespaceRequest)(nil),      // 34: encore.daemon.DeleteNamespaceRequest
-	(*ListNamespacesResponse)(nil),      // 35: encore.daemon.ListNamespacesResponse
-	(*TelemetryConfig)(nil),             // 36: encore.daemon.TelemetryConfig
-	(*DumpMetaRequest)(nil),             // 37: encore.daemon.DumpMetaRequest
-	(*DumpMetaResponse)(nil),            // 38: encore.daemon.DumpMetaResponse
-	(*SQLCPlugin)(nil),                  // 39: encore.daemon.SQLCPlugin
-	(*SQLCPlugin_File)(nil),             // 40: encore.daemon.SQLCPlugin.File
-	(*SQLCPlugin_Settings)(nil),         // 41: encore.daemon.SQLCPlugin.Settings
-	(*SQLCPlugin_Codegen)(nil),          // 42: encore.daemon.SQLCPlugin.Codegen
-	(*SQLCPlugin_Catalog)(nil),          // 43: encore.daemon.SQLCPlugin.Catalog
-	(*SQLCPlugin_Schema)(nil),           // 44: encore.daemon.SQLCPlugin.Schema
-	(*SQLCPlugin_CompositeType)(nil),    // 45: encore.daemon.SQLCPlugin.CompositeType
-	(*SQLCPlugin_Enum)(nil),             // 46: encore.daemon.SQLCPlugin.Enum
-	(*SQLCPlugin_Table)(nil),            // 47: encore.daemon.SQLCPlugin.Table
-	(*SQLCPlugin_Identifier)(nil),       // 48: encore.daemon.SQLCPlugin.Identifier
-	(*SQLCPlugin_Column)(nil),           // 49: encore.daemon.SQLCPlugin.Column
-	(*SQLCPlugin_Query)(nil),            // 50: encore.daemon.SQLCPlugin.Query
-	(*SQLCPlugin_Parameter)(nil),        // 51: encore.daemon.SQLCPlugin.Parameter
-	(*SQLCPlugin_GenerateRequest)(nil),  // 52: encore.daemon.SQLCPlugin.GenerateRequest
-	(*SQLCPlugin_GenerateResponse)(nil), // 53: encore.daemon.SQLCPlugin.GenerateResponse
-	(*SQLCPlugin_Codegen_Process)(nil),  // 54: encore.daemon.SQLCPlugin.Codegen.Process
-	(*SQLCPlugin_Codegen_WASM)(nil),     // 55: encore.daemon.SQLCPlugin.Codegen.WASM
-	(*emptypb.Empty)(nil),               // 56: google.protobuf.Empty
+	(DBRole)(0),                               // 0: encore.daemon.DBRole
+	(DBClusterType)(0),                        // 1: encore.daemon.DBClusterType
+	(RunRequest_BrowserMode)(0),               // 2: encore.daemon.RunRequest.BrowserMode
+	(RunRequest_DebugMode)(0),                 // 3: encore.daemon.RunRequest.DebugMode
+	(DumpMetaRequest_Format)(0),               // 4: encore.daemon.DumpMetaRequest.Format
+	(UsageReportResponse_CallerKind)(0),       // 5: encore.daemon.UsageReportResponse.CallerKind
+	(ListTracesResponse_Span_Kind)(0),         // 6: encore.daemon.ListTracesResponse.Span.Kind
+	(ListTracesResponse_Operation_Kind)(0),    // 7: encore.daemon.ListTracesResponse.Operation.Kind
+	(*CommandMessage)(nil),                    // 8: encore.daemon.CommandMessage
+	(*CommandOutput)(nil),                     // 9: encore.daemon.CommandOutput
+	(*CommandExit)(nil),                       // 10: encore.daemon.CommandExit
+	(*CommandDisplayErrors)(nil),              // 11: encore.daemon.CommandDisplayErrors
+	(*CreateAppRequest)(nil),                  // 12: encore.daemon.CreateAppRequest
+	(*CreateAppResponse)(nil),                 // 13: encore.daemon.CreateAppResponse
+	(*RunRequest)(nil),                        // 14: encore.daemon.RunRequest
+	(*TestRequest)(nil),                       // 15: encore.daemon.TestRequest
+	(*TestSpecRequest)(nil),                   // 16: encore.daemon.TestSpecRequest
+	(*TestSpecResponse)(nil),                  // 17: encore.daemon.TestSpecResponse
+	(*ExecScriptRequest)(nil),                 // 18: encore.daemon.ExecScriptRequest
+	(*CheckRequest)(nil),                      // 19: encore.daemon.CheckRequest
+	(*ExportRequest)(nil),                     // 20: encore.daemon.ExportRequest
+	(*DockerExportParams)(nil),                // 21: encore.daemon.DockerExportParams
+	(*DBConnectRequest)(nil),                  // 22: encore.daemon.DBConnectRequest
+	(*DBConnectResponse)(nil),                 // 23: encore.daemon.DBConnectResponse
+	(*DBProxyRequest)(nil),                    // 24: encore.daemon.DBProxyRequest
+	(*DBResetRequest)(nil),                    // 25: encore.daemon.DBResetRequest
+	(*DBMigratePlanRequest)(nil),              // 26: encore.daemon.DBMigratePlanRequest
+	(*DBSeedRequest)(nil),                     // 27: encore.daemon.DBSeedRequest
+	(*DBMigratePlanResponse)(nil),             // 28: encore.daemon.DBMigratePlanResponse
+	(*DBMigrationPlan)(nil),                   // 29: encore.daemon.DBMigrationPlan
+	(*PendingMigration)(nil),                  // 30: encore.daemon.PendingMigration
+	(*GenClientRequest)(nil),                  // 31: encore.daemon.GenClientRequest
+	(*GenClientResponse)(nil),                 // 32: encore.daemon.GenClientResponse
+	(*GenWrappersRequest)(nil),                // 33: encore.daemon.GenWrappersRequest
+	(*GenWrappersResponse)(nil),               // 34: encore.daemon.GenWrappersResponse
+	(*SecretsRefreshRequest)(nil),             // 35: encore.daemon.SecretsRefreshRequest
+	(*SecretsRefreshResponse)(nil),            // 36: encore.daemon.SecretsRefreshResponse
+	(*VersionResponse)(nil),                   // 37: encore.daemon.VersionResponse
+	(*Namespace)(nil),                         // 38: encore.daemon.Namespace
+	(*CreateNamespaceRequest)(nil),            // 39: encore.daemon.CreateNamespaceRequest
+	(*SwitchNamespaceRequest)(nil),            // 40: encore.daemon.SwitchNamespaceRequest
+	(*ListNamespacesRequest)(nil),             // 41: encore.daemon.ListNamespacesRequest
+	(*DeleteNamespaceRequest)(nil),            // 42: encore.daemon.DeleteNamespaceRequest
+	(*ListNamespacesResponse)(nil),            // 43: encore.daemon.ListNamespacesResponse
+	(*TelemetryConfig)(nil),                   // 44: encore.daemon.TelemetryConfig
+	(*DumpMetaRequest)(nil),                   // 45: encore.daemon.DumpMetaRequest
+	(*DumpMetaResponse)(nil),                  // 46: encore.daemon.DumpMetaResponse
+	(*URLRegistryRequest)(nil),                // 47: encore.daemon.URLRegistryRequest
+	(*URLRegistryResponse)(nil),               // 48: encore.daemon.URLRegistryResponse
+	(*PubSubReplayRequest)(nil),               // 49: encore.daemon.PubSubReplayRequest
+	(*PubSubReplayResponse)(nil),              // 50: encore.daemon.PubSubReplayResponse
+	(*SLOReportRequest)(nil),                  // 51: encore.daemon.SLOReportRequest
+	(*SLOReportResponse)(nil),                 // 52: encore.daemon.SLOReportResponse
+	(*UsageReportRequest)(nil),                // 53: encore.daemon.UsageReportRequest
+	(*UsageReportResponse)(nil),               // 54: encore.daemon.UsageReportResponse
+	(*ListTracesRequest)(nil),                 // 55: encore.daemon.ListTracesRequest
+	(*ListTracesResponse)(nil),                // 56: encore.daemon.ListTracesResponse
+	(*SQLCPlugin)(nil),                        // 57: encore.daemon.SQLCPlugin
+	nil,                                       // 58: encore.daemon.URLRegistryResponse.ServicesEntry
+	nil,                                       // 59: encore.daemon.URLRegistryResponse.GatewaysEntry
+	nil,                                       // 60: encore.daemon.URLRegistryResponse.ResourcesEntry
+	(*SLOReportResponse_EndpointStats)(nil),   // 61: encore.daemon.SLOReportResponse.EndpointStats
+	(*UsageReportResponse_EndpointUsage)(nil), // 62: encore.daemon.UsageReportResponse.EndpointUsage
+	(*ListTracesResponse_Trace)(nil),          // 63: encore.daemon.ListTracesResponse.Trace
+	(*ListTracesResponse_Span)(nil),           // 64: encore.daemon.ListTracesResponse.Span
+	(*ListTracesResponse_Operation)(nil),      // 65: encore.daemon.ListTracesResponse.Operation
+	(*SQLCPlugin_File)(nil),                   // 66: encore.daemon.SQLCPlugin.File
+	(*SQLCPlugin_Settings)(nil),               // 67: encore.daemon.SQLCPlugin.Settings
+	(*SQLCPlugin_Codegen)(nil),                // 68: encore.daemon.SQLCPlugin.Codegen
+	(*SQLCPlugin_Catalog)(nil),                // 69: encore.daemon.SQLCPlugin.Catalog
+	(*SQLCPlugin_Schema)(nil),                 // 70: encore.daemon.SQLCPlugin.Schema
+	(*SQLCPlugin_CompositeType)(nil),          // 71: encore.daemon.SQLCPlugin.CompositeType
+	(*SQLCPlugin_Enum)(nil),                   // 72: encore.daemon.SQLCPlugin.Enum
+	(*SQLCPlugin_Table)(nil),                  // 73: encore.daemon.SQLCPlugin.Table
+	(*SQLCPlugin_Identifier)(nil),             // 74: encore.daemon.SQLCPlugin.Identifier
+	(*SQLCPlugin_Column)(nil),                 // 75: encore.daemon.SQLCPlugin.Column
+	(*SQLCPlugin_Query)(nil),                  // 76: encore.daemon.SQLCPlugin.Query
+	(*SQLCPlugin_Parameter)(nil),              // 77: encore.daemon.SQLCPlugin.Parameter
+	(*SQLCPlugin_GenerateRequest)(nil),        // 78: encore.daemon.SQLCPlugin.GenerateRequest
+	(*SQLCPlugin_GenerateResponse)(nil),       // 79: encore.daemon.SQLCPlugin.GenerateResponse
+	(*SQLCPlugin_Codegen_Process)(nil),        // 80: encore.daemon.SQLCPlugin.Codegen.Process
+	(*SQLCPlugin_Codegen_WASM)(nil),           // 81: encore.daemon.SQLCPlugin.Codegen.WASM
+	(*timestamppb.Timestamp)(nil),             // 82: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                     // 83: google.protobuf.Empty
 }
 var file_encore_daemon_daemon_proto_depIdxs = []int32{
-	6,  // 0: encore.daemon.CommandMessage.output:type_name -> encore.daemon.CommandOutput
-	7,  // 1: encore.daemon.CommandMessage.exit:type_name -> encore.daemon.CommandExit
-	8,  // 2: encore.daemon.CommandMessage.errors:type_name -> encore.daemon.CommandDisplayErrors
+	9,  // 0: encore.daemon.CommandMessage.output:type_name -> encore.daemon.CommandOutput
+	10, // 1: encore.daemon.CommandMessage.exit:type_name -> encore.daemon.CommandExit
+	11, // 2: encore.daemon.CommandMessage.errors:type_name -> encore.daemon.CommandDisplayErrors
 	2,  // 3: encore.daemon.RunRequest.browser:type_name -> encore.daemon.RunRequest.BrowserMode
 	3,  // 4: encore.daemon.RunRequest.debug_mode:type_name -> encore.daemon.RunRequest.DebugMode
-	18, // 5: encore.daemon.ExportRequest.docker:type_name -> encore.daemon.DockerExportParams
+	21, // 5: encore.daemon.ExportRequest.docker:type_name -> encore.daemon.DockerExportParams
 	1,  // 6: encore.daemon.DBConnectRequest.cluster_type:type_name -> encore.daemon.DBClusterType
 	0,  // 7: encore.daemon.DBConnectRequest.role:type_name -> encore.daemon.DBRole
 	1,  // 8: encore.daemon.DBProxyRequest.cluster_type:type_name -> encore.daemon.DBClusterType
 	0,  // 9: encore.daemon.DBProxyRequest.role:type_name -> encore.daemon.DBRole
 	1,  // 10: encore.daemon.DBResetRequest.cluster_type:type_name -> encore.daemon.DBClusterType
-	30, // 11: encore.daemon.ListNamespacesResponse.namespaces:type_name -> encore.daemon.Namespace
-	4,  // 12: encore.daemon.DumpMetaRequest.format:type_name -> encore.daemon.DumpMetaRequest.Format
-	42, // 13: encore.daemon.SQLCPlugin.Settings.codegen:type_name -> encore.daemon.SQLCPlugin.Codegen
-	54, // 14: encore.daemon.SQLCPlugin.Codegen.process:type_name -> encore.daemon.SQLCPlugin.Codegen.Process
-	55, // 15: encore.daemon.SQLCPlugin.Codegen.wasm:type_name -> encore.daemon.SQLCPlugin.Codegen.WASM
-	44, // 16: encore.daemon.SQLCPlugin.Catalog.schemas:type_name -> encore.daemon.SQLCPlugin.Schema
-	47, // 17: encore.daemon.SQLCPlugin.Schema.tables:type_name -> encore.daemon.SQLCPlugin.Table
-	46, // 18: encore.daemon.SQLCPlugin.Schema.enums:type_name -> encore.daemon.SQLCPlugin.Enum
-	45, // 19: encore.daemon.SQLCPlugin.Schema.composite_types:type_name -> encore.daemon.SQLCPlugin.CompositeType
-	48, // 20: encore.daemon.SQLCPlugin.Table.rel:type_name -> encore.daemon.SQLCPlugin.Identifier
-	49, // 21: encore.daemon.SQLCPlugin.Table.columns:type_name -> encore.daemon.SQLCPlugin.Column
-	48, // 22: encore.daemon.SQLCPlugin.Column.table:type_name -> encore.daemon.SQLCPlugin.Identifier
-	48, // 23: encore.daemon.SQLCPlugin.Column.type:type_name -> encore.daemon.SQLCPlugin.Identifier
-	48, // 24: encore.daemon.SQLCPlugin.Column.embed_table:type_name -> encore.daemon.SQLCPlugin.Identifier
-	49, // 25: encore.daemon.SQLCPlugin.Query.columns:type_name -> encore.daemon.SQLCPlugin.Column
-	51, // 26: encore.daemon.SQLCPlugin.Query.params:type_name -> encore.daemon.SQLCPlugin.Parameter
-	48, // 27: encore.daemon.SQLCPlugin.Query.insert_into_table:type_name -> encore.daemon.SQLCPlugin.Identifier
-	49, // 28: encore.daemon.SQLCPlugin.Parameter.column:type_name -> encore.daemon.SQLCPlugin.Column
-	41, // 29: encore.daemon.SQLCPlugin.GenerateRequest.settings:type_name -> encore.daemon.SQLCPlugin.Settings
-	43, // 30: encore.daemon.SQLCPlugin.GenerateRequest.catalog:type_name -> encore.daemon.SQLCPlugin.Catalog
-	50, // 31: encore.daemon.SQLCPlugin.GenerateRequest.queries:type_name -> encore.daemon.SQLCPlugin.Query
-	40, // 32: encore.daemon.SQLCPlugin.GenerateResponse.files:type_name -> encore.daemon.SQLCPlugin.File
-	11, // 33: encore.daemon.Daemon.Run:input_type -> encore.daemon.RunRequest
-	12, // 34: encore.daemon.Daemon.Test:input_type -> encore.daemon.TestRequest
-	13, // 35: encore.daemon.Daemon.TestSpec:input_type -> encore.daemon.TestSpecRequest
-	15, // 36: encore.daemon.Daemon.ExecScript:input_type -> encore.daemon.ExecScriptRequest
-	16, // 37: encore.daemon.Daemon.Check:input_type -> encore.daemon.CheckRequest
-	17, // 38: encore.daemon.Daemon.Export:input_type -> encore.daemon.ExportRequest
-	19, // 39: encore.daemon.Daemon.DBConnect:input_type -> encore.daemon.DBConnectRequest
-	21, // 40: encore.daemon.Daemon.DBProxy:input_type -> encore.daemon.DBProxyRequest
-	22, // 41: encore.daemon.Daemon.DBReset:input_type -> encore.daemon.DBResetRequest
-	23, // 42: encore.daemon.Daemon.GenClient:input_type -> encore.daemon.GenClientRequest
-	25, // 43: encore.daemon.Daemon.GenWrappers:input_type -> encore.daemon.GenWrappersRequest
-	27, // 44: encore.daemon.Daemon.SecretsRefresh:input_type -> encore.daemon.SecretsRefreshRequest
-	56, // 45: encore.daemon.Daemon.Version:input_type -> google.protobuf.Empty
-	31, // 46: encore.daemon.Daemon.CreateNamespace:input_type -> encore.daemon.CreateNamespaceRequest
-	32, // 47: encore.daemon.Daemon.SwitchNamespace:input_type -> encore.daemon.SwitchNamespaceRequest
-	33, // 48: encore.daemon.Daemon.ListNamespaces:input_type -> encore.daemon.ListNamespacesRequest
-	34, // 49: encore.daemon.Daemon.DeleteNamespace:input_type -> encore.daemon.DeleteNamespaceRequest
-	37, // 50: encore.daemon.Daemon.DumpMeta:input_type -> encore.daemon.DumpMetaRequest
-	36, // 51: encore.daemon.Daemon.Telemetry:input_type -> encore.daemon.TelemetryConfig
-	9,  // 52: encore.daemon.Daemon.CreateApp:input_type -> encore.daemon.CreateAppRequest
-	5,  // 53: encore.daemon.Daemon.Run:output_type -> encore.daemon.CommandMessage
-	5,  // 54: encore.daemon.Daemon.Test:output_type -> encore.daemon.CommandMessage
-	14, // 55: encore.daemon.Daemon.TestSpec:output_type -> encore.daemon.TestSpecResponse
-	5,  // 56: encore.daemon.Daemon.ExecScript:output_type -> encore.daemon.CommandMessage
-	5,  // 57: encore.daemon.Daemon.Check:output_type -> encore.daemon.CommandMessage
-	5,  // 58: encore.daemon.Daemon.Export:output_type -> encore.daemon.CommandMessage
-	20, // 59: encore.daemon.Daemon.DBConnect:output_type -> encore.daemon.DBConnectResponse
-	5,  // 60: encore.daemon.Daemon.DBProxy:output_type -> encore.daemon.CommandMessage
-	5,  // 61: encore.daemon.Daemon.DBReset:output_type -> encore.daemon.CommandMessage
-	24, // 62: encore.daemon.Daemon.GenClient:output_type -> encore.daemon.GenClientResponse
-	26, // 63: encore.daemon.Daemon.GenWrappers:output_type -> encore.daemon.GenWrappersResponse
-	28, // 64: encore.daemon.Daemon.SecretsRefresh:output_type -> encore.daemon.SecretsRefreshResponse
-	29, // 65: encore.daemon.Daemon.Version:output_type -> encore.daemon.VersionResponse
-	30, // 66: encore.daemon.Daemon.CreateNamespace:output_type -> encore.daemon.Namespace
-	30, // 67: encore.daemon.Daemon.SwitchNamespace:output_type -> encore.daemon.Namespace
-	35, // 68: encore.daemon.Daemon.ListNamespaces:output_type -> encore.daemon.ListNamespacesResponse
-	56, // 69: encore.daemon.Daemon.DeleteNamespace:output_type -> google.protobuf.Empty
-	38, // 70: encore.daemon.Daemon.DumpMeta:output_type -> encore.daemon.DumpMetaResponse
-	56, // 71: encore.daemon.Daemon.Telemetry:output_type -> google.protobuf.Empty
-	10, // 72: encore.daemon.Daemon.CreateApp:output_type -> encore.daemon.CreateAppResponse
-	53, // [53:73] is the sub-list for method output_type
-	33, // [33:53] is the sub-list for method input_type
-	33, // [33:33] is the sub-list for extension type_name
-	33, // [33:33] is the sub-list for extension extendee
-	0,  // [0:33] is the sub-list for field type_name
+	1,  // 11: encore.daemon.DBMigratePlanRequest.cluster_type:type_name -> encore.daemon.DBClusterType
+	1,  // 12: encore.daemon.DBSeedRequest.cluster_type:type_name -> encore.daemon.DBClusterType
+	29, // 13: encore.daemon.DBMigratePlanResponse.databases:type_name -> encore.daemon.DBMigrationPlan
+	30, // 14: encore.daemon.DBMigrationPlan.pending:type_name -> encore.daemon.PendingMigration
+	38, // 15: encore.daemon.ListNamespacesResponse.namespaces:type_name -> encore.daemon.Namespace
+	4,  // 16: encore.daemon.DumpMetaRequest.format:type_name -> encore.daemon.DumpMetaRequest.Format
+	58, // 17: encore.daemon.URLRegistryResponse.services:type_name -> encore.daemon.URLRegistryResponse.ServicesEntry
+	59, // 18: encore.daemon.URLRegistryResponse.gateways:type_name -> encore.daemon.URLRegistryResponse.GatewaysEntry
+	60, // 19: encore.daemon.URLRegistryResponse.resources:type_name -> encore.daemon.URLRegistryResponse.ResourcesEntry
+	82, // 20: encore.daemon.PubSubReplayRequest.from:type_name -> google.protobuf.Timestamp
+	82, // 21: encore.daemon.PubSubReplayRequest.to:type_name -> google.protobuf.Timestamp
+	82, // 22: encore.daemon.SLOReportRequest.from:type_name -> google.protobuf.Timestamp
+	82, // 23: encore.daemon.SLOReportRequest.to:type_name -> google.protobuf.Timestamp
+	61, // 24: encore.daemon.SLOReportResponse.endpoints:type_name -> encore.daemon.SLOReportResponse.EndpointStats
+	82, // 25: encore.daemon.UsageReportRequest.from:type_name -> google.protobuf.Timestamp
+	82, // 26: encore.daemon.UsageReportRequest.to:type_name -> google.protobuf.Timestamp
+	62, // 27: encore.daemon.UsageReportResponse.usages:type_name -> encore.daemon.UsageReportResponse.EndpointUsage
+	82, // 28: encore.daemon.ListTracesRequest.from:type_name -> google.protobuf.Timestamp
+	63, // 29: encore.daemon.ListTracesResponse.traces:type_name -> encore.daemon.ListTracesResponse.Trace
+	5,  // 30: encore.daemon.UsageReportResponse.EndpointUsage.caller_kind:type_name -> encore.daemon.UsageReportResponse.CallerKind
+	82, // 31: encore.daemon.UsageReportResponse.EndpointUsage.last_called:type_name -> google.protobuf.Timestamp
+	82, // 32: encore.daemon.ListTracesResponse.Trace.started_at:type_name -> google.protobuf.Timestamp
+	64, // 33: encore.daemon.ListTracesResponse.Trace.spans:type_name -> encore.daemon.ListTracesResponse.Span
+	6,  // 34: encore.daemon.ListTracesResponse.Span.kind:type_name -> encore.daemon.ListTracesResponse.Span.Kind
+	65, // 35: encore.daemon.ListTracesResponse.Span.operations:type_name -> encore.daemon.ListTracesResponse.Operation
+	7,  // 36: encore.daemon.ListTracesResponse.Operation.kind:type_name -> encore.daemon.ListTracesResponse.Operation.Kind
+	68, // 37: encore.daemon.SQLCPlugin.Settings.codegen:type_name -> encore.daemon.SQLCPlugin.Codegen
+	80, // 38: encore.daemon.SQLCPlugin.Codegen.process:type_name -> encore.daemon.SQLCPlugin.Codegen.Process
+	81, // 39: encore.daemon.SQLCPlugin.Codegen.wasm:type_name -> encore.daemon.SQLCPlugin.Codegen.WASM
+	70, // 40: encore.daemon.SQLCPlugin.Catalog.schemas:type_name -> encore.daemon.SQLCPlugin.Schema
+	73, // 41: encore.daemon.SQLCPlugin.Schema.tables:type_name -> encore.daemon.SQLCPlugin.Table
+	72, // 42: encore.daemon.SQLCPlugin.Schema.enums:type_name -> encore.daemon.SQLCPlugin.Enum
+	71, // 43: encore.daemon.SQLCPlugin.Schema.composite_types:type_name -> encore.daemon.SQLCPlugin.CompositeType
+	74, // 44: encore.daemon.SQLCPlugin.Table.rel:type_name -> encore.daemon.SQLCPlugin.Identifier
+	75, // 45: encore.daemon.SQLCPlugin.Table.columns:type_name -> encore.daemon.SQLCPlugin.Column
+	74, // 46: encore.daemon.SQLCPlugin.Column.table:type_name -> encore.daemon.SQLCPlugin.Identifier
+	74, // 47: encore.daemon.SQLCPlugin.Column.type:type_name -> encore.daemon.SQLCPlugin.Identifier
+	74, // 48: encore.daemon.SQLCPlugin.Column.embed_table:type_name -> encore.daemon.SQLCPlugin.Identifier
+	75, // 49: encore.daemon.SQLCPlugin.Query.columns:type_name -> encore.daemon.SQLCPlugin.Column
+	77, // 50: encore.daemon.SQLCPlugin.Query.params:type_name -> encore.daemon.SQLCPlugin.Parameter
+	74, // 51: encore.daemon.SQLCPlugin.Query.insert_into_table:type_name -> encore.daemon.SQLCPlugin.Identifier
+	75, // 52: encore.daemon.SQLCPlugin.Parameter.column:type_name -> encore.daemon.SQLCPlugin.Column
+	67, // 53: encore.daemon.SQLCPlugin.GenerateRequest.settings:type_name -> encore.daemon.SQLCPlugin.Settings
+	69, // 54: encore.daemon.SQLCPlugin.GenerateRequest.catalog:type_name -> encore.daemon.SQLCPlugin.Catalog
+	76, // 55: encore.daemon.SQLCPlugin.GenerateRequest.queries:type_name -> encore.daemon.SQLCPlugin.Query
+	66, // 56: encore.daemon.SQLCPlugin.GenerateResponse.files:type_name -> encore.daemon.SQLCPlugin.File
+	14, // 57: encore.daemon.Daemon.Run:input_type -> encore.daemon.RunRequest
+	15, // 58: encore.daemon.Daemon.Test:input_type -> encore.daemon.TestRequest
+	16, // 59: encore.daemon.Daemon.TestSpec:input_type -> encore.daemon.TestSpecRequest
+	18, // 60: encore.daemon.Daemon.ExecScript:input_type -> encore.daemon.ExecScriptRequest
+	19, // 61: encore.daemon.Daemon.Check:input_type -> encore.daemon.CheckRequest
+	20, // 62: encore.daemon.Daemon.Export:input_type -> encore.daemon.ExportRequest
+	22, // 63: encore.daemon.Daemon.DBConnect:input_type -> encore.daemon.DBConnectRequest
+	24, // 64: encore.daemon.Daemon.DBProxy:input_type -> encore.daemon.DBProxyRequest
+	25, // 65: encore.daemon.Daemon.DBReset:input_type -> encore.daemon.DBResetRequest
+	26, // 66: encore.daemon.Daemon.DBMigratePlan:input_type -> encore.daemon.DBMigratePlanRequest
+	27, // 67: encore.daemon.Daemon.DBSeed:input_type -> encore.daemon.DBSeedRequest
+	31, // 68: encore.daemon.Daemon.GenClient:input_type -> encore.daemon.GenClientRequest
+	33, // 69: encore.daemon.Daemon.GenWrappers:input_type -> encore.daemon.GenWrappersRequest
+	35, // 70: encore.daemon.Daemon.SecretsRefresh:input_type -> encore.daemon.SecretsRefreshRequest
+	83, // 71: encore.daemon.Daemon.Version:input_type -> google.protobuf.Empty
+	39, // 72: encore.daemon.Daemon.CreateNamespace:input_type -> encore.daemon.CreateNamespaceRequest
+	40, // 73: encore.daemon.Daemon.SwitchNamespace:input_type -> encore.daemon.SwitchNamespaceRequest
+	41, // 74: encore.daemon.Daemon.ListNamespaces:input_type -> encore.daemon.ListNamespacesRequest
+	42, // 75: encore.daemon.Daemon.DeleteNamespace:input_type -> encore.daemon.DeleteNamespaceRequest
+	45, // 76: encore.daemon.Daemon.DumpMeta:input_type -> encore.daemon.DumpMetaRequest
+	47, // 77: encore.daemon.Daemon.URLRegistry:input_type -> encore.daemon.URLRegistryRequest
+	49, // 78: encore.daemon.Daemon.PubSubReplay:input_type -> encore.daemon.PubSubReplayRequest
+	51, // 79: encore.daemon.Daemon.SLOReport:input_type -> encore.daemon.SLOReportRequest
+	53, // 80: encore.daemon.Daemon.UsageReport:input_type -> encore.daemon.UsageReportRequest
+	55, // 81: encore.daemon.Daemon.ListTraces:input_type -> encore.daemon.ListTracesRequest
+	44, // 82: encore.daemon.Daemon.Telemetry:input_type -> encore.daemon.TelemetryConfig
+	12, // 83: encore.daemon.Daemon.CreateApp:input_type -> encore.daemon.CreateAppRequest
+	8,  // 84: encore.daemon.Daemon.Run:output_type -> encore.daemon.CommandMessage
+	8,  // 85: encore.daemon.Daemon.Test:output_type -> encore.daemon.CommandMessage
+	17, // 86: encore.daemon.Daemon.TestSpec:output_type -> encore.daemon.TestSpecResponse
+	8,  // 87: encore.daemon.Daemon.ExecScript:output_type -> encore.daemon.CommandMessage
+	8,  // 88: encore.daemon.Daemon.Check:output_type -> encore.daemon.CommandMessage
+	8,  // 89: encore.daemon.Daemon.Export:output_type -> encore.daemon.CommandMessage
+	23, // 90: encore.daemon.Daemon.DBConnect:output_type -> encore.daemon.DBConnectResponse
+	8,  // 91: encore.daemon.Daemon.DBProxy:output_type -> encore.daemon.CommandMessage
+	8,  // 92: encore.daemon.Daemon.DBReset:output_type -> encore.daemon.CommandMessage
+	28, // 93: encore.daemon.Daemon.DBMigratePlan:output_type -> encore.daemon.DBMigratePlanResponse
+	8,  // 94: encore.daemon.Daemon.DBSeed:output_type -> encore.daemon.CommandMessage
+	32, // 95: encore.daemon.Daemon.GenClient:output_type -> encore.daemon.GenClientResponse
+	34, // 96: encore.daemon.Daemon.GenWrappers:output_type -> encore.daemon.GenWrappersResponse
+	36, // 97: encore.daemon.Daemon.SecretsRefresh:output_type -> encore.daemon.SecretsRefreshResponse
+	37, // 98: encore.daemon.Daemon.Version:output_type -> encore.daemon.VersionResponse
+	38, // 99: encore.daemon.Daemon.CreateNamespace:output_type -> encore.daemon.Namespace
+	38, // 100: encore.daemon.Daemon.SwitchNamespace:output_type -> encore.daemon.Namespace
+	43, // 101: encore.daemon.Daemon.ListNamespaces:output_type -> encore.daemon.ListNamespacesResponse
+	83, // 102: encore.daemon.Daemon.DeleteNamespace:output_type -> google.protobuf.Empty
+	46, // 103: encore.daemon.Daemon.DumpMeta:output_type -> encore.daemon.DumpMetaResponse
+	48, // 104: encore.daemon.Daemon.URLRegistry:output_type -> encore.daemon.URLRegistryResponse
+	50, // 105: encore.daemon.Daemon.PubSubReplay:output_type -> encore.daemon.PubSubReplayResponse
+	52, // 106: encore.daemon.Daemon.SLOReport:output_type -> encore.daemon.SLOReportResponse
+	54, // 107: encore.daemon.Daemon.UsageReport:output_type -> encore.daemon.UsageReportResponse
+	56, // 108: encore.daemon.Daemon.ListTraces:output_type -> encore.daemon.ListTracesResponse
+	83, // 109: encore.daemon.Daemon.Telemetry:output_type -> google.protobuf.Empty
+	13, // 110: encore.daemon.Daemon.CreateApp:output_type -> encore.daemon.CreateAppResponse
+	84, // [84:111] is the sub-list for method output_type
+	57, // [57:84] is the sub-list for method input_type
+	57, // [57:57] is the sub-list for extension type_name
+	57, // [57:57] is the sub-list for extension extendee
+	0,  // [0:57] is the sub-list for field type_name
 }
 
 func init() { file_encore_daemon_daemon_proto_init() }
@@ -4277,14 +6113,18 @@ func file_encore_daemon_daemon_proto_init() {
 	file_encore_daemon_daemon_proto_msgTypes[16].OneofWrappers = []any{}
 	file_encore_daemon_daemon_proto_msgTypes[17].OneofWrappers = []any{}
 	file_encore_daemon_daemon_proto_msgTypes[18].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[25].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[19].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[22].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[23].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[30].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[41].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_daemon_daemon_proto_rawDesc), len(file_encore_daemon_daemon_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   51,
+			NumEnums:      8,
+			NumMessages:   74,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
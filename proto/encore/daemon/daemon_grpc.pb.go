@@ -29,6 +29,8 @@ const (
 	Daemon_DBConnect_FullMethodName       = "/encore.daemon.Daemon/DBConnect"
 	Daemon_DBProxy_FullMethodName         = "/encore.daemon.Daemon/DBProxy"
 	Daemon_DBReset_FullMethodName         = "/encore.daemon.Daemon/DBReset"
+	Daemon_DBMigratePlan_FullMethodName   = "/encore.daemon.Daemon/DBMigratePlan"
+	Daemon_DBSeed_FullMethodName          = "/encore.daemon.Daemon/DBSeed"
 	Daemon_GenClient_FullMethodName       = "/encore.daemon.Daemon/GenClient"
 	Daemon_GenWrappers_FullMethodName     = "/encore.daemon.Daemon/GenWrappers"
 	Daemon_SecretsRefresh_FullMethodName  = "/encore.daemon.Daemon/SecretsRefresh"
@@ -38,6 +40,11 @@ const (
 	Daemon_ListNamespaces_FullMethodName  = "/encore.daemon.Daemon/ListNamespaces"
 	Daemon_DeleteNamespace_FullMethodName = "/encore.daemon.Daemon/DeleteNamespace"
 	Daemon_DumpMeta_FullMethodName        = "/encore.daemon.Daemon/DumpMeta"
+	Daemon_URLRegistry_FullMethodName     = "/encore.daemon.Daemon/URLRegistry"
+	Daemon_PubSubReplay_FullMethodName    = "/encore.daemon.Daemon/PubSubReplay"
+	Daemon_SLOReport_FullMethodName       = "/encore.daemon.Daemon/SLOReport"
+	Daemon_UsageReport_FullMethodName     = "/encore.daemon.Daemon/UsageReport"
+	Daemon_ListTraces_FullMethodName      = "/encore.daemon.Daemon/ListTraces"
 	Daemon_Telemetry_FullMethodName       = "/encore.daemon.Daemon/Telemetry"
 	Daemon_CreateApp_FullMethodName       = "/encore.daemon.Daemon/CreateApp"
 )
@@ -65,6 +72,11 @@ type DaemonClient interface {
 	DBProxy(ctx context.Context, in *DBProxyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
 	// DBReset resets the given databases, recreating them from scratch.
 	DBReset(ctx context.Context, in *DBResetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
+	// DBMigratePlan reports the pending migrations for the given databases
+	// and detects drift, without applying anything.
+	DBMigratePlan(ctx context.Context, in *DBMigratePlanRequest, opts ...grpc.CallOption) (*DBMigratePlanResponse, error)
+	// DBSeed applies the seed data for the given databases.
+	DBSeed(ctx context.Context, in *DBSeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
 	// GenClient generates a client based on the app's API.
 	GenClient(ctx context.Context, in *GenClientRequest, opts ...grpc.CallOption) (*GenClientResponse, error)
 	// GenWrappers generates user-facing wrapper code.
@@ -83,6 +95,20 @@ type DaemonClient interface {
 	// DeleteNamespace deletes an infra namespace.
 	DeleteNamespace(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DumpMeta(ctx context.Context, in *DumpMetaRequest, opts ...grpc.CallOption) (*DumpMetaResponse, error)
+	// URLRegistry reports the local addresses of the running app's
+	// services, gateways and infrastructure resources.
+	URLRegistry(ctx context.Context, in *URLRegistryRequest, opts ...grpc.CallOption) (*URLRegistryResponse, error)
+	// PubSubReplay re-publishes the messages stored for a topic of the running app.
+	PubSubReplay(ctx context.Context, in *PubSubReplayRequest, opts ...grpc.CallOption) (*PubSubReplayResponse, error)
+	// SLOReport computes per-endpoint availability and latency
+	// from the traces recorded for the app.
+	SLOReport(ctx context.Context, in *SLOReportRequest, opts ...grpc.CallOption) (*SLOReportResponse, error)
+	// UsageReport aggregates which callers call which endpoints
+	// from the traces recorded for the app.
+	UsageReport(ctx context.Context, in *UsageReportRequest, opts ...grpc.CallOption) (*UsageReportResponse, error)
+	// ListTraces lists the most recent traces recorded for the app,
+	// describing the spans of each trace and what they did.
+	ListTraces(ctx context.Context, in *ListTracesRequest, opts ...grpc.CallOption) (*ListTracesResponse, error)
 	// Telemetry enables or disables telemetry.
 	Telemetry(ctx context.Context, in *TelemetryConfig, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// InitTutorial sets the tutorial flag of the app
@@ -250,6 +276,35 @@ func (c *daemonClient) DBReset(ctx context.Context, in *DBResetRequest, opts ...
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Daemon_DBResetClient = grpc.ServerStreamingClient[CommandMessage]
 
+func (c *daemonClient) DBMigratePlan(ctx context.Context, in *DBMigratePlanRequest, opts ...grpc.CallOption) (*DBMigratePlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DBMigratePlanResponse)
+	err := c.cc.Invoke(ctx, Daemon_DBMigratePlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) DBSeed(ctx context.Context, in *DBSeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[7], Daemon_DBSeed_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DBSeedRequest, CommandMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBSeedClient = grpc.ServerStreamingClient[CommandMessage]
+
 func (c *daemonClient) GenClient(ctx context.Context, in *GenClientRequest, opts ...grpc.CallOption) (*GenClientResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GenClientResponse)
@@ -340,6 +395,56 @@ func (c *daemonClient) DumpMeta(ctx context.Context, in *DumpMetaRequest, opts .
 	return out, nil
 }
 
+func (c *daemonClient) URLRegistry(ctx context.Context, in *URLRegistryRequest, opts ...grpc.CallOption) (*URLRegistryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(URLRegistryResponse)
+	err := c.cc.Invoke(ctx, Daemon_URLRegistry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) PubSubReplay(ctx context.Context, in *PubSubReplayRequest, opts ...grpc.CallOption) (*PubSubReplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PubSubReplayResponse)
+	err := c.cc.Invoke(ctx, Daemon_PubSubReplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SLOReport(ctx context.Context, in *SLOReportRequest, opts ...grpc.CallOption) (*SLOReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SLOReportResponse)
+	err := c.cc.Invoke(ctx, Daemon_SLOReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) UsageReport(ctx context.Context, in *UsageReportRequest, opts ...grpc.CallOption) (*UsageReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageReportResponse)
+	err := c.cc.Invoke(ctx, Daemon_UsageReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ListTraces(ctx context.Context, in *ListTracesRequest, opts ...grpc.CallOption) (*ListTracesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTracesResponse)
+	err := c.cc.Invoke(ctx, Daemon_ListTraces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonClient) Telemetry(ctx context.Context, in *TelemetryConfig, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -383,6 +488,11 @@ type DaemonServer interface {
 	DBProxy(*DBProxyRequest, grpc.ServerStreamingServer[CommandMessage]) error
 	// DBReset resets the given databases, recreating them from scratch.
 	DBReset(*DBResetRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// DBMigratePlan reports the pending migrations for the given databases
+	// and detects drift, without applying anything.
+	DBMigratePlan(context.Context, *DBMigratePlanRequest) (*DBMigratePlanResponse, error)
+	// DBSeed applies the seed data for the given databases.
+	DBSeed(*DBSeedRequest, grpc.ServerStreamingServer[CommandMessage]) error
 	// GenClient generates a client based on the app's API.
 	GenClient(context.Context, *GenClientRequest) (*GenClientResponse, error)
 	// GenWrappers generates user-facing wrapper code.
@@ -401,6 +511,20 @@ type DaemonServer interface {
 	// DeleteNamespace deletes an infra namespace.
 	DeleteNamespace(context.Context, *DeleteNamespaceRequest) (*emptypb.Empty, error)
 	DumpMeta(context.Context, *DumpMetaRequest) (*DumpMetaResponse, error)
+	// URLRegistry reports the local addresses of the running app's
+	// services, gateways and infrastructure resources.
+	URLRegistry(context.Context, *URLRegistryRequest) (*URLRegistryResponse, error)
+	// PubSubReplay re-publishes the messages stored for a topic of the running app.
+	PubSubReplay(context.Context, *PubSubReplayRequest) (*PubSubReplayResponse, error)
+	// SLOReport computes per-endpoint availability and latency
+	// from the traces recorded for the app.
+	SLOReport(context.Context, *SLOReportRequest) (*SLOReportResponse, error)
+	// UsageReport aggregates which callers call which endpoints
+	// from the traces recorded for the app.
+	UsageReport(context.Context, *UsageReportRequest) (*UsageReportResponse, error)
+	// ListTraces lists the most recent traces recorded for the app,
+	// describing the spans of each trace and what they did.
+	ListTraces(context.Context, *ListTracesRequest) (*ListTracesResponse, error)
 	// Telemetry enables or disables telemetry.
 	Telemetry(context.Context, *TelemetryConfig) (*emptypb.Empty, error)
 	// InitTutorial sets the tutorial flag of the app
@@ -442,6 +566,12 @@ func (UnimplementedDaemonServer) DBProxy(*DBProxyRequest, grpc.ServerStreamingSe
 func (UnimplementedDaemonServer) DBReset(*DBResetRequest, grpc.ServerStreamingServer[CommandMessage]) error {
 	return status.Error(codes.Unimplemented, "method DBReset not implemented")
 }
+func (UnimplementedDaemonServer) DBMigratePlan(context.Context, *DBMigratePlanRequest) (*DBMigratePlanResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DBMigratePlan not implemented")
+}
+func (UnimplementedDaemonServer) DBSeed(*DBSeedRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Error(codes.Unimplemented, "method DBSeed not implemented")
+}
 func (UnimplementedDaemonServer) GenClient(context.Context, *GenClientRequest) (*GenClientResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GenClient not implemented")
 }
@@ -469,6 +599,21 @@ func (UnimplementedDaemonServer) DeleteNamespace(context.Context, *DeleteNamespa
 func (UnimplementedDaemonServer) DumpMeta(context.Context, *DumpMetaRequest) (*DumpMetaResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DumpMeta not implemented")
 }
+func (UnimplementedDaemonServer) URLRegistry(context.Context, *URLRegistryRequest) (*URLRegistryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method URLRegistry not implemented")
+}
+func (UnimplementedDaemonServer) PubSubReplay(context.Context, *PubSubReplayRequest) (*PubSubReplayResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PubSubReplay not implemented")
+}
+func (UnimplementedDaemonServer) SLOReport(context.Context, *SLOReportRequest) (*SLOReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SLOReport not implemented")
+}
+func (UnimplementedDaemonServer) UsageReport(context.Context, *UsageReportRequest) (*UsageReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UsageReport not implemented")
+}
+func (UnimplementedDaemonServer) ListTraces(context.Context, *ListTracesRequest) (*ListTracesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTraces not implemented")
+}
 func (UnimplementedDaemonServer) Telemetry(context.Context, *TelemetryConfig) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method Telemetry not implemented")
 }
@@ -609,6 +754,35 @@ func _Daemon_DBReset_Handler(srv interface{}, stream grpc.ServerStream) error {
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Daemon_DBResetServer = grpc.ServerStreamingServer[CommandMessage]
 
+func _Daemon_DBMigratePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DBMigratePlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DBMigratePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DBMigratePlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DBMigratePlan(ctx, req.(*DBMigratePlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DBSeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DBSeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).DBSeed(m, &grpc.GenericServerStream[DBSeedRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBSeedServer = grpc.ServerStreamingServer[CommandMessage]
+
 func _Daemon_GenClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenClientRequest)
 	if err := dec(in); err != nil {
@@ -771,6 +945,96 @@ func _Daemon_DumpMeta_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Daemon_URLRegistry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLRegistryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).URLRegistry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_URLRegistry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).URLRegistry(ctx, req.(*URLRegistryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_PubSubReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PubSubReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).PubSubReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_PubSubReplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).PubSubReplay(ctx, req.(*PubSubReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SLOReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SLOReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SLOReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_SLOReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SLOReport(ctx, req.(*SLOReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_UsageReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).UsageReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_UsageReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).UsageReport(ctx, req.(*UsageReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ListTraces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTracesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListTraces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ListTraces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListTraces(ctx, req.(*ListTracesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Daemon_Telemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TelemetryConfig)
 	if err := dec(in); err != nil {
@@ -822,6 +1086,10 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DBConnect",
 			Handler:    _Daemon_DBConnect_Handler,
 		},
+		{
+			MethodName: "DBMigratePlan",
+			Handler:    _Daemon_DBMigratePlan_Handler,
+		},
 		{
 			MethodName: "GenClient",
 			Handler:    _Daemon_GenClient_Handler,
@@ -858,6 +1126,26 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DumpMeta",
 			Handler:    _Daemon_DumpMeta_Handler,
 		},
+		{
+			MethodName: "URLRegistry",
+			Handler:    _Daemon_URLRegistry_Handler,
+		},
+		{
+			MethodName: "PubSubReplay",
+			Handler:    _Daemon_PubSubReplay_Handler,
+		},
+		{
+			MethodName: "SLOReport",
+			Handler:    _Daemon_SLOReport_Handler,
+		},
+		{
+			MethodName: "UsageReport",
+			Handler:    _Daemon_UsageReport_Handler,
+		},
+		{
+			MethodName: "ListTraces",
+			Handler:    _Daemon_ListTraces_Handler,
+		},
 		{
 			MethodName: "Telemetry",
 			Handler:    _Daemon_Telemetry_Handler,
@@ -903,6 +1191,11 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Daemon_DBReset_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DBSeed",
+			Handler:       _Daemon_DBSeed_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "encore/daemon/daemon.proto",
 }
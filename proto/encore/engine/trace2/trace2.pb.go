@@ -205,7 +205,53 @@ func (x DBTransactionEnd_CompletionType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DBTransactionEnd_CompletionType.Descriptor instead.
 func (DBTransactionEnd_CompletionType) EnumDescriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{20, 0}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{21, 0}
+}
+
+type StreamMessage_Direction int32
+
+const (
+	StreamMessage_INCOMING StreamMessage_Direction = 0
+	StreamMessage_OUTGOING StreamMessage_Direction = 1
+)
+
+// Enum value maps for StreamMessage_Direction.
+var (
+	StreamMessage_Direction_name = map[int32]string{
+		0: "INCOMING",
+		1: "OUTGOING",
+	}
+	StreamMessage_Direction_value = map[string]int32{
+		"INCOMING": 0,
+		"OUTGOING": 1,
+	}
+)
+
+func (x StreamMessage_Direction) Enum() *StreamMessage_Direction {
+	p := new(StreamMessage_Direction)
+	*p = x
+	return p
+}
+
+func (x StreamMessage_Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamMessage_Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_engine_trace2_trace2_proto_enumTypes[3].Descriptor()
+}
+
+func (StreamMessage_Direction) Type() protoreflect.EnumType {
+	return &file_encore_engine_trace2_trace2_proto_enumTypes[3]
+}
+
+func (x StreamMessage_Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamMessage_Direction.Descriptor instead.
+func (StreamMessage_Direction) EnumDescriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{28, 0}
 }
 
 type CacheCallEnd_Result int32
@@ -247,11 +293,11 @@ func (x CacheCallEnd_Result) String() string {
 }
 
 func (CacheCallEnd_Result) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_engine_trace2_trace2_proto_enumTypes[3].Descriptor()
+	return file_encore_engine_trace2_trace2_proto_enumTypes[4].Descriptor()
 }
 
 func (CacheCallEnd_Result) Type() protoreflect.EnumType {
-	return &file_encore_engine_trace2_trace2_proto_enumTypes[3]
+	return &file_encore_engine_trace2_trace2_proto_enumTypes[4]
 }
 
 func (x CacheCallEnd_Result) Number() protoreflect.EnumNumber {
@@ -260,7 +306,53 @@ func (x CacheCallEnd_Result) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CacheCallEnd_Result.Descriptor instead.
 func (CacheCallEnd_Result) EnumDescriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{28, 0}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{32, 0}
+}
+
+type BucketSignedURL_Operation int32
+
+const (
+	BucketSignedURL_UPLOAD   BucketSignedURL_Operation = 0
+	BucketSignedURL_DOWNLOAD BucketSignedURL_Operation = 1
+)
+
+// Enum value maps for BucketSignedURL_Operation.
+var (
+	BucketSignedURL_Operation_name = map[int32]string{
+		0: "UPLOAD",
+		1: "DOWNLOAD",
+	}
+	BucketSignedURL_Operation_value = map[string]int32{
+		"UPLOAD":   0,
+		"DOWNLOAD": 1,
+	}
+)
+
+func (x BucketSignedURL_Operation) Enum() *BucketSignedURL_Operation {
+	p := new(BucketSignedURL_Operation)
+	*p = x
+	return p
+}
+
+func (x BucketSignedURL_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BucketSignedURL_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_engine_trace2_trace2_proto_enumTypes[5].Descriptor()
+}
+
+func (BucketSignedURL_Operation) Type() protoreflect.EnumType {
+	return &file_encore_engine_trace2_trace2_proto_enumTypes[5]
+}
+
+func (x BucketSignedURL_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BucketSignedURL_Operation.Descriptor instead.
+func (BucketSignedURL_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{50, 0}
 }
 
 // Note: These values don't match the values used by the binary trace protocol,
@@ -306,11 +398,11 @@ func (x LogMessage_Level) String() string {
 }
 
 func (LogMessage_Level) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_engine_trace2_trace2_proto_enumTypes[4].Descriptor()
+	return file_encore_engine_trace2_trace2_proto_enumTypes[6].Descriptor()
 }
 
 func (LogMessage_Level) Type() protoreflect.EnumType {
-	return &file_encore_engine_trace2_trace2_proto_enumTypes[4]
+	return &file_encore_engine_trace2_trace2_proto_enumTypes[6]
 }
 
 func (x LogMessage_Level) Number() protoreflect.EnumNumber {
@@ -319,7 +411,7 @@ func (x LogMessage_Level) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LogMessage_Level.Descriptor instead.
 func (LogMessage_Level) EnumDescriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{60, 0}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{71, 0}
 }
 
 // SpanSummary summarizes a span for display purposes.
@@ -737,6 +829,8 @@ type SpanStart struct {
 	CallerEventId         *uint64                `protobuf:"varint,4,opt,name=caller_event_id,json=callerEventId,proto3,oneof" json:"caller_event_id,omitempty"`
 	ExternalCorrelationId *string                `protobuf:"bytes,5,opt,name=external_correlation_id,json=externalCorrelationId,proto3,oneof" json:"external_correlation_id,omitempty"`
 	DefLoc                *uint32                `protobuf:"varint,6,opt,name=def_loc,json=defLoc,proto3,oneof" json:"def_loc,omitempty"`
+	// region is the cloud region the span ran in, if known.
+	Region *string `protobuf:"bytes,7,opt,name=region,proto3,oneof" json:"region,omitempty"`
 	// Types that are valid to be assigned to Data:
 	//
 	//	*SpanStart_Request
@@ -820,6 +914,13 @@ func (x *SpanStart) GetDefLoc() uint32 {
 	return 0
 }
 
+func (x *SpanStart) GetRegion() string {
+	if x != nil && x.Region != nil {
+		return *x.Region
+	}
+	return ""
+}
+
 func (x *SpanStart) GetData() isSpanStart_Data {
 	if x != nil {
 		return x.Data
@@ -899,6 +1000,9 @@ type SpanEnd struct {
 	PanicStack    *StackTrace `protobuf:"bytes,3,opt,name=panic_stack,json=panicStack,proto3,oneof" json:"panic_stack,omitempty"`
 	ParentTraceId *TraceID    `protobuf:"bytes,4,opt,name=parent_trace_id,json=parentTraceId,proto3,oneof" json:"parent_trace_id,omitempty"`
 	ParentSpanId  *uint64     `protobuf:"varint,5,opt,name=parent_span_id,json=parentSpanId,proto3,oneof" json:"parent_span_id,omitempty"`
+	// mem_stats describes the memory allocated during the span,
+	// if the app records memory stats in traces.
+	MemStats *SpanMemStats `protobuf:"bytes,6,opt,name=mem_stats,json=memStats,proto3,oneof" json:"mem_stats,omitempty"`
 	// Types that are valid to be assigned to Data:
 	//
 	//	*SpanEnd_Request
@@ -975,6 +1079,13 @@ func (x *SpanEnd) GetParentSpanId() uint64 {
 	return 0
 }
 
+func (x *SpanEnd) GetMemStats() *SpanMemStats {
+	if x != nil {
+		return x.MemStats
+	}
+	return nil
+}
+
 func (x *SpanEnd) GetData() isSpanEnd_Data {
 	if x != nil {
 		return x.Data
@@ -1046,6 +1157,72 @@ func (*SpanEnd_PubsubMessage) isSpanEnd_Data() {}
 
 func (*SpanEnd_Test) isSpanEnd_Data() {}
 
+// SpanMemStats describes the memory allocated by the process during a span.
+// The allocation counters are process-wide, so they include the allocations
+// of other spans running concurrently with the span.
+type SpanMemStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// alloc_bytes is the number of bytes allocated on the heap during the span.
+	AllocBytes uint64 `protobuf:"varint,1,opt,name=alloc_bytes,json=allocBytes,proto3" json:"alloc_bytes,omitempty"`
+	// alloc_objects is the number of heap objects allocated during the span.
+	AllocObjects uint64 `protobuf:"varint,2,opt,name=alloc_objects,json=allocObjects,proto3" json:"alloc_objects,omitempty"`
+	// heap_objects is the number of live heap objects when the span ended.
+	HeapObjects   uint64 `protobuf:"varint,3,opt,name=heap_objects,json=heapObjects,proto3" json:"heap_objects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpanMemStats) Reset() {
+	*x = SpanMemStats{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpanMemStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpanMemStats) ProtoMessage() {}
+
+func (x *SpanMemStats) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpanMemStats.ProtoReflect.Descriptor instead.
+func (*SpanMemStats) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SpanMemStats) GetAllocBytes() uint64 {
+	if x != nil {
+		return x.AllocBytes
+	}
+	return 0
+}
+
+func (x *SpanMemStats) GetAllocObjects() uint64 {
+	if x != nil {
+		return x.AllocObjects
+	}
+	return 0
+}
+
+func (x *SpanMemStats) GetHeapObjects() uint64 {
+	if x != nil {
+		return x.HeapObjects
+	}
+	return 0
+}
+
 type RequestSpanStart struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	ServiceName      string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
@@ -1065,7 +1242,7 @@ type RequestSpanStart struct {
 
 func (x *RequestSpanStart) Reset() {
 	*x = RequestSpanStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[6]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1077,7 +1254,7 @@ func (x *RequestSpanStart) String() string {
 func (*RequestSpanStart) ProtoMessage() {}
 
 func (x *RequestSpanStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[6]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1090,7 +1267,7 @@ func (x *RequestSpanStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestSpanStart.ProtoReflect.Descriptor instead.
 func (*RequestSpanStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{6}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RequestSpanStart) GetServiceName() string {
@@ -1178,7 +1355,7 @@ type RequestSpanEnd struct {
 
 func (x *RequestSpanEnd) Reset() {
 	*x = RequestSpanEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[7]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1190,7 +1367,7 @@ func (x *RequestSpanEnd) String() string {
 func (*RequestSpanEnd) ProtoMessage() {}
 
 func (x *RequestSpanEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[7]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1203,7 +1380,7 @@ func (x *RequestSpanEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestSpanEnd.ProtoReflect.Descriptor instead.
 func (*RequestSpanEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{7}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RequestSpanEnd) GetServiceName() string {
@@ -1252,7 +1429,7 @@ type AuthSpanStart struct {
 
 func (x *AuthSpanStart) Reset() {
 	*x = AuthSpanStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[8]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1264,7 +1441,7 @@ func (x *AuthSpanStart) String() string {
 func (*AuthSpanStart) ProtoMessage() {}
 
 func (x *AuthSpanStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[8]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1277,7 +1454,7 @@ func (x *AuthSpanStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthSpanStart.ProtoReflect.Descriptor instead.
 func (*AuthSpanStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{8}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AuthSpanStart) GetServiceName() string {
@@ -1315,7 +1492,7 @@ type AuthSpanEnd struct {
 
 func (x *AuthSpanEnd) Reset() {
 	*x = AuthSpanEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[9]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1327,7 +1504,7 @@ func (x *AuthSpanEnd) String() string {
 func (*AuthSpanEnd) ProtoMessage() {}
 
 func (x *AuthSpanEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[9]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1340,7 +1517,7 @@ func (x *AuthSpanEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthSpanEnd.ProtoReflect.Descriptor instead.
 func (*AuthSpanEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{9}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AuthSpanEnd) GetServiceName() string {
@@ -1380,13 +1557,15 @@ type PubsubMessageSpanStart struct {
 	Attempt          uint32                 `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`
 	PublishTime      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=publish_time,json=publishTime,proto3" json:"publish_time,omitempty"`
 	MessagePayload   []byte                 `protobuf:"bytes,7,opt,name=message_payload,json=messagePayload,proto3,oneof" json:"message_payload,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// replayed is whether the message was replayed by "encore pubsub replay".
+	Replayed      bool `protobuf:"varint,8,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PubsubMessageSpanStart) Reset() {
 	*x = PubsubMessageSpanStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[10]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1398,7 +1577,7 @@ func (x *PubsubMessageSpanStart) String() string {
 func (*PubsubMessageSpanStart) ProtoMessage() {}
 
 func (x *PubsubMessageSpanStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[10]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1411,7 +1590,7 @@ func (x *PubsubMessageSpanStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubsubMessageSpanStart.ProtoReflect.Descriptor instead.
 func (*PubsubMessageSpanStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{10}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PubsubMessageSpanStart) GetServiceName() string {
@@ -1463,6 +1642,13 @@ func (x *PubsubMessageSpanStart) GetMessagePayload() []byte {
 	return nil
 }
 
+func (x *PubsubMessageSpanStart) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
 type PubsubMessageSpanEnd struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Repeat service/topic/subscription name here to make it possible
@@ -1476,7 +1662,7 @@ type PubsubMessageSpanEnd struct {
 
 func (x *PubsubMessageSpanEnd) Reset() {
 	*x = PubsubMessageSpanEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[11]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1488,7 +1674,7 @@ func (x *PubsubMessageSpanEnd) String() string {
 func (*PubsubMessageSpanEnd) ProtoMessage() {}
 
 func (x *PubsubMessageSpanEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[11]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1501,7 +1687,7 @@ func (x *PubsubMessageSpanEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubsubMessageSpanEnd.ProtoReflect.Descriptor instead.
 func (*PubsubMessageSpanEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{11}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PubsubMessageSpanEnd) GetServiceName() string {
@@ -1538,7 +1724,7 @@ type TestSpanStart struct {
 
 func (x *TestSpanStart) Reset() {
 	*x = TestSpanStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[12]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1550,7 +1736,7 @@ func (x *TestSpanStart) String() string {
 func (*TestSpanStart) ProtoMessage() {}
 
 func (x *TestSpanStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[12]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1563,7 +1749,7 @@ func (x *TestSpanStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestSpanStart.ProtoReflect.Descriptor instead.
 func (*TestSpanStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{12}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TestSpanStart) GetServiceName() string {
@@ -1613,7 +1799,7 @@ type TestSpanEnd struct {
 
 func (x *TestSpanEnd) Reset() {
 	*x = TestSpanEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[13]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1625,7 +1811,7 @@ func (x *TestSpanEnd) String() string {
 func (*TestSpanEnd) ProtoMessage() {}
 
 func (x *TestSpanEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[13]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1638,7 +1824,7 @@ func (x *TestSpanEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestSpanEnd.ProtoReflect.Descriptor instead.
 func (*TestSpanEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{13}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *TestSpanEnd) GetServiceName() string {
@@ -1704,6 +1890,14 @@ type SpanEvent struct {
 	//	*SpanEvent_BucketListObjectsEnd
 	//	*SpanEvent_BucketDeleteObjectsStart
 	//	*SpanEvent_BucketDeleteObjectsEnd
+	//	*SpanEvent_BucketSignedUrl
+	//	*SpanEvent_BucketObjectCopyStart
+	//	*SpanEvent_BucketObjectCopyEnd
+	//	*SpanEvent_BucketObjectUploadPart
+	//	*SpanEvent_PubsubMessageLink
+	//	*SpanEvent_StreamMessage
+	//	*SpanEvent_BucketObjectUpdateAttrsStart
+	//	*SpanEvent_BucketObjectUpdateAttrsEnd
 	Data          isSpanEvent_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1711,7 +1905,7 @@ type SpanEvent struct {
 
 func (x *SpanEvent) Reset() {
 	*x = SpanEvent{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[14]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1723,7 +1917,7 @@ func (x *SpanEvent) String() string {
 func (*SpanEvent) ProtoMessage() {}
 
 func (x *SpanEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[14]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1736,7 +1930,7 @@ func (x *SpanEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpanEvent.ProtoReflect.Descriptor instead.
 func (*SpanEvent) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{14}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *SpanEvent) GetGoid() uint32 {
@@ -2001,6 +2195,78 @@ func (x *SpanEvent) GetBucketDeleteObjectsEnd() *BucketDeleteObjectsEnd {
 	return nil
 }
 
+func (x *SpanEvent) GetBucketSignedUrl() *BucketSignedURL {
+	if x != nil {
+		if x, ok := x.Data.(*SpanEvent_BucketSignedUrl); ok {
+			return x.BucketSignedUrl
+		}
+	}
+	return nil
+}
+
+func (x *SpanEvent) GetBucketObjectCopyStart() *BucketObjectCopyStart {
+	if x != nil {
+		if x, ok := x.Data.(*SpanEvent_BucketObjectCopyStart); ok {
+			return x.BucketObjectCopyStart
+		}
+	}
+	return nil
+}
+
+func (x *SpanEvent) GetBucketObjectCopyEnd() *BucketObjectCopyEnd {
+	if x != nil {
+		if x, ok := x.Data.(*SpanEvent_BucketObjectCopyEnd); ok {
+			return x.BucketObjectCopyEnd
+		}
+	}
+	return nil
+}
+
+func (x *SpanEvent) GetBucketObjectUploadPart() *BucketObjectUploadPart {
+	if x != nil {
+		if x, ok := x.Data.(*SpanEvent_BucketObjectUploadPart); ok {
+			return x.BucketObjectUploadPart
+		}
+	}
+	return nil
+}
+
+func (x *SpanEvent) GetPubsubMessageLink() *PubsubMessageLink {
+	if x != nil {
+		if x, ok := x.Data.(*SpanEvent_PubsubMessageLink); ok {
+			return x.PubsubMessageLink
+		}
+	}
+	return nil
+}
+
+func (x *SpanEvent) GetStreamMessage() *StreamMessage {
+	if x != nil {
+		if x, ok := x.Data.(*SpanEvent_StreamMessage); ok {
+			return x.StreamMessage
+		}
+	}
+	return nil
+}
+
+func (x *SpanEvent) GetBucketObjectUpdateAttrsStart() *BucketObjectUpdateAttrsStart {
+	if x != nil {
+		if x, ok := x.Data.(*SpanEvent_BucketObjectUpdateAttrsStart); ok {
+			return x.BucketObjectUpdateAttrsStart
+		}
+	}
+	return nil
+}
+
+func (x *SpanEvent) GetBucketObjectUpdateAttrsEnd() *BucketObjectUpdateAttrsEnd {
+	if x != nil {
+		if x, ok := x.Data.(*SpanEvent_BucketObjectUpdateAttrsEnd); ok {
+			return x.BucketObjectUpdateAttrsEnd
+		}
+	}
+	return nil
+}
+
 type isSpanEvent_Data interface {
 	isSpanEvent_Data()
 }
@@ -2109,6 +2375,38 @@ type SpanEvent_BucketDeleteObjectsEnd struct {
 	BucketDeleteObjectsEnd *BucketDeleteObjectsEnd `protobuf:"bytes,35,opt,name=bucket_delete_objects_end,json=bucketDeleteObjectsEnd,proto3,oneof"`
 }
 
+type SpanEvent_BucketSignedUrl struct {
+	BucketSignedUrl *BucketSignedURL `protobuf:"bytes,36,opt,name=bucket_signed_url,json=bucketSignedUrl,proto3,oneof"`
+}
+
+type SpanEvent_BucketObjectCopyStart struct {
+	BucketObjectCopyStart *BucketObjectCopyStart `protobuf:"bytes,37,opt,name=bucket_object_copy_start,json=bucketObjectCopyStart,proto3,oneof"`
+}
+
+type SpanEvent_BucketObjectCopyEnd struct {
+	BucketObjectCopyEnd *BucketObjectCopyEnd `protobuf:"bytes,38,opt,name=bucket_object_copy_end,json=bucketObjectCopyEnd,proto3,oneof"`
+}
+
+type SpanEvent_BucketObjectUploadPart struct {
+	BucketObjectUploadPart *BucketObjectUploadPart `protobuf:"bytes,39,opt,name=bucket_object_upload_part,json=bucketObjectUploadPart,proto3,oneof"`
+}
+
+type SpanEvent_PubsubMessageLink struct {
+	PubsubMessageLink *PubsubMessageLink `protobuf:"bytes,40,opt,name=pubsub_message_link,json=pubsubMessageLink,proto3,oneof"`
+}
+
+type SpanEvent_StreamMessage struct {
+	StreamMessage *StreamMessage `protobuf:"bytes,41,opt,name=stream_message,json=streamMessage,proto3,oneof"`
+}
+
+type SpanEvent_BucketObjectUpdateAttrsStart struct {
+	BucketObjectUpdateAttrsStart *BucketObjectUpdateAttrsStart `protobuf:"bytes,42,opt,name=bucket_object_update_attrs_start,json=bucketObjectUpdateAttrsStart,proto3,oneof"`
+}
+
+type SpanEvent_BucketObjectUpdateAttrsEnd struct {
+	BucketObjectUpdateAttrsEnd *BucketObjectUpdateAttrsEnd `protobuf:"bytes,43,opt,name=bucket_object_update_attrs_end,json=bucketObjectUpdateAttrsEnd,proto3,oneof"`
+}
+
 func (*SpanEvent_LogMessage) isSpanEvent_Data() {}
 
 func (*SpanEvent_BodyStream) isSpanEvent_Data() {}
@@ -2161,6 +2459,22 @@ func (*SpanEvent_BucketDeleteObjectsStart) isSpanEvent_Data() {}
 
 func (*SpanEvent_BucketDeleteObjectsEnd) isSpanEvent_Data() {}
 
+func (*SpanEvent_BucketSignedUrl) isSpanEvent_Data() {}
+
+func (*SpanEvent_BucketObjectCopyStart) isSpanEvent_Data() {}
+
+func (*SpanEvent_BucketObjectCopyEnd) isSpanEvent_Data() {}
+
+func (*SpanEvent_BucketObjectUploadPart) isSpanEvent_Data() {}
+
+func (*SpanEvent_PubsubMessageLink) isSpanEvent_Data() {}
+
+func (*SpanEvent_StreamMessage) isSpanEvent_Data() {}
+
+func (*SpanEvent_BucketObjectUpdateAttrsStart) isSpanEvent_Data() {}
+
+func (*SpanEvent_BucketObjectUpdateAttrsEnd) isSpanEvent_Data() {}
+
 type RPCCallStart struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	TargetServiceName  string                 `protobuf:"bytes,1,opt,name=target_service_name,json=targetServiceName,proto3" json:"target_service_name,omitempty"`
@@ -2172,7 +2486,7 @@ type RPCCallStart struct {
 
 func (x *RPCCallStart) Reset() {
 	*x = RPCCallStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[15]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2184,7 +2498,7 @@ func (x *RPCCallStart) String() string {
 func (*RPCCallStart) ProtoMessage() {}
 
 func (x *RPCCallStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[15]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2197,7 +2511,7 @@ func (x *RPCCallStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPCCallStart.ProtoReflect.Descriptor instead.
 func (*RPCCallStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{15}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *RPCCallStart) GetTargetServiceName() string {
@@ -2230,7 +2544,7 @@ type RPCCallEnd struct {
 
 func (x *RPCCallEnd) Reset() {
 	*x = RPCCallEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[16]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2242,7 +2556,7 @@ func (x *RPCCallEnd) String() string {
 func (*RPCCallEnd) ProtoMessage() {}
 
 func (x *RPCCallEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[16]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2255,7 +2569,7 @@ func (x *RPCCallEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPCCallEnd.ProtoReflect.Descriptor instead.
 func (*RPCCallEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{16}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RPCCallEnd) GetErr() *Error {
@@ -2273,7 +2587,7 @@ type GoroutineStart struct {
 
 func (x *GoroutineStart) Reset() {
 	*x = GoroutineStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[17]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2285,7 +2599,7 @@ func (x *GoroutineStart) String() string {
 func (*GoroutineStart) ProtoMessage() {}
 
 func (x *GoroutineStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[17]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2298,7 +2612,7 @@ func (x *GoroutineStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoroutineStart.ProtoReflect.Descriptor instead.
 func (*GoroutineStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{17}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{18}
 }
 
 type GoroutineEnd struct {
@@ -2309,7 +2623,7 @@ type GoroutineEnd struct {
 
 func (x *GoroutineEnd) Reset() {
 	*x = GoroutineEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[18]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2321,7 +2635,7 @@ func (x *GoroutineEnd) String() string {
 func (*GoroutineEnd) ProtoMessage() {}
 
 func (x *GoroutineEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[18]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2334,7 +2648,7 @@ func (x *GoroutineEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoroutineEnd.ProtoReflect.Descriptor instead.
 func (*GoroutineEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{18}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{19}
 }
 
 type DBTransactionStart struct {
@@ -2346,7 +2660,7 @@ type DBTransactionStart struct {
 
 func (x *DBTransactionStart) Reset() {
 	*x = DBTransactionStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[19]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2358,7 +2672,7 @@ func (x *DBTransactionStart) String() string {
 func (*DBTransactionStart) ProtoMessage() {}
 
 func (x *DBTransactionStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[19]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2371,7 +2685,7 @@ func (x *DBTransactionStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DBTransactionStart.ProtoReflect.Descriptor instead.
 func (*DBTransactionStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{19}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DBTransactionStart) GetStack() *StackTrace {
@@ -2382,17 +2696,19 @@ func (x *DBTransactionStart) GetStack() *StackTrace {
 }
 
 type DBTransactionEnd struct {
-	state         protoimpl.MessageState          `protogen:"open.v1"`
-	Completion    DBTransactionEnd_CompletionType `protobuf:"varint,1,opt,name=completion,proto3,enum=encore.engine.trace2.DBTransactionEnd_CompletionType" json:"completion,omitempty"`
-	Stack         *StackTrace                     `protobuf:"bytes,2,opt,name=stack,proto3" json:"stack,omitempty"`
-	Err           *Error                          `protobuf:"bytes,3,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	state      protoimpl.MessageState          `protogen:"open.v1"`
+	Completion DBTransactionEnd_CompletionType `protobuf:"varint,1,opt,name=completion,proto3,enum=encore.engine.trace2.DBTransactionEnd_CompletionType" json:"completion,omitempty"`
+	Stack      *StackTrace                     `protobuf:"bytes,2,opt,name=stack,proto3" json:"stack,omitempty"`
+	Err        *Error                          `protobuf:"bytes,3,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	// retries is the number of times the transaction was retried before this attempt.
+	Retries       uint32 `protobuf:"varint,4,opt,name=retries,proto3" json:"retries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DBTransactionEnd) Reset() {
 	*x = DBTransactionEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[20]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2404,7 +2720,7 @@ func (x *DBTransactionEnd) String() string {
 func (*DBTransactionEnd) ProtoMessage() {}
 
 func (x *DBTransactionEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[20]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2417,7 +2733,7 @@ func (x *DBTransactionEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DBTransactionEnd.ProtoReflect.Descriptor instead.
 func (*DBTransactionEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{20}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *DBTransactionEnd) GetCompletion() DBTransactionEnd_CompletionType {
@@ -2441,17 +2757,34 @@ func (x *DBTransactionEnd) GetErr() *Error {
 	return nil
 }
 
+func (x *DBTransactionEnd) GetRetries() uint32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
 type DBQueryStart struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Stack         *StackTrace            `protobuf:"bytes,2,opt,name=stack,proto3" json:"stack,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Stack *StackTrace            `protobuf:"bytes,2,opt,name=stack,proto3" json:"stack,omitempty"`
+	// stmt_name is the name of the prepared statement executed, if any.
+	StmtName *string `protobuf:"bytes,3,opt,name=stmt_name,json=stmtName,proto3,oneof" json:"stmt_name,omitempty"`
+	// node is the database node that served the query, such as "primary" or "replica-0",
+	// for queries made through a read-only database handle.
+	Node *string `protobuf:"bytes,4,opt,name=node,proto3,oneof" json:"node,omitempty"`
+	// rows is the number of rows copied, for COPY queries.
+	Rows *uint64 `protobuf:"varint,5,opt,name=rows,proto3,oneof" json:"rows,omitempty"`
+	// attempt is the attempt number of the transaction the query is part of,
+	// for queries in transactions.
+	Attempt       *uint32 `protobuf:"varint,6,opt,name=attempt,proto3,oneof" json:"attempt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DBQueryStart) Reset() {
 	*x = DBQueryStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[21]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2463,7 +2796,7 @@ func (x *DBQueryStart) String() string {
 func (*DBQueryStart) ProtoMessage() {}
 
 func (x *DBQueryStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[21]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2476,7 +2809,7 @@ func (x *DBQueryStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DBQueryStart.ProtoReflect.Descriptor instead.
 func (*DBQueryStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{21}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *DBQueryStart) GetQuery() string {
@@ -2493,6 +2826,34 @@ func (x *DBQueryStart) GetStack() *StackTrace {
 	return nil
 }
 
+func (x *DBQueryStart) GetStmtName() string {
+	if x != nil && x.StmtName != nil {
+		return *x.StmtName
+	}
+	return ""
+}
+
+func (x *DBQueryStart) GetNode() string {
+	if x != nil && x.Node != nil {
+		return *x.Node
+	}
+	return ""
+}
+
+func (x *DBQueryStart) GetRows() uint64 {
+	if x != nil && x.Rows != nil {
+		return *x.Rows
+	}
+	return 0
+}
+
+func (x *DBQueryStart) GetAttempt() uint32 {
+	if x != nil && x.Attempt != nil {
+		return *x.Attempt
+	}
+	return 0
+}
+
 type DBQueryEnd struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Err           *Error                 `protobuf:"bytes,1,opt,name=err,proto3,oneof" json:"err,omitempty"`
@@ -2502,7 +2863,7 @@ type DBQueryEnd struct {
 
 func (x *DBQueryEnd) Reset() {
 	*x = DBQueryEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[22]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2514,7 +2875,7 @@ func (x *DBQueryEnd) String() string {
 func (*DBQueryEnd) ProtoMessage() {}
 
 func (x *DBQueryEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[22]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2527,7 +2888,7 @@ func (x *DBQueryEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DBQueryEnd.ProtoReflect.Descriptor instead.
 func (*DBQueryEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{22}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *DBQueryEnd) GetErr() *Error {
@@ -2538,17 +2899,20 @@ func (x *DBQueryEnd) GetErr() *Error {
 }
 
 type PubsubPublishStart struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
-	Message       []byte                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Stack         *StackTrace            `protobuf:"bytes,3,opt,name=stack,proto3" json:"stack,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Topic   string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Message []byte                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Stack   *StackTrace            `protobuf:"bytes,3,opt,name=stack,proto3" json:"stack,omitempty"`
+	// The messages being published when publishing a batch of messages,
+	// in which case message is empty.
+	Batch         [][]byte `protobuf:"bytes,4,rep,name=batch,proto3" json:"batch,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PubsubPublishStart) Reset() {
 	*x = PubsubPublishStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[23]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2560,7 +2924,7 @@ func (x *PubsubPublishStart) String() string {
 func (*PubsubPublishStart) ProtoMessage() {}
 
 func (x *PubsubPublishStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[23]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2573,7 +2937,7 @@ func (x *PubsubPublishStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubsubPublishStart.ProtoReflect.Descriptor instead.
 func (*PubsubPublishStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{23}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *PubsubPublishStart) GetTopic() string {
@@ -2597,17 +2961,26 @@ func (x *PubsubPublishStart) GetStack() *StackTrace {
 	return nil
 }
 
+func (x *PubsubPublishStart) GetBatch() [][]byte {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
 type PubsubPublishEnd struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     *string                `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3,oneof" json:"message_id,omitempty"`
-	Err           *Error                 `protobuf:"bytes,2,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MessageId *string                `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3,oneof" json:"message_id,omitempty"`
+	Err       *Error                 `protobuf:"bytes,2,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	// The result for each message when publishing a batch of messages.
+	BatchResults  []*PubsubPublishResult `protobuf:"bytes,3,rep,name=batch_results,json=batchResults,proto3" json:"batch_results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PubsubPublishEnd) Reset() {
 	*x = PubsubPublishEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[24]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2619,7 +2992,7 @@ func (x *PubsubPublishEnd) String() string {
 func (*PubsubPublishEnd) ProtoMessage() {}
 
 func (x *PubsubPublishEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[24]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2632,7 +3005,7 @@ func (x *PubsubPublishEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubsubPublishEnd.ProtoReflect.Descriptor instead.
 func (*PubsubPublishEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{24}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *PubsubPublishEnd) GetMessageId() string {
@@ -2649,6 +3022,197 @@ func (x *PubsubPublishEnd) GetErr() *Error {
 	return nil
 }
 
+func (x *PubsubPublishEnd) GetBatchResults() []*PubsubPublishResult {
+	if x != nil {
+		return x.BatchResults
+	}
+	return nil
+}
+
+type PubsubPublishResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     *string                `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3,oneof" json:"message_id,omitempty"`
+	Err           *Error                 `protobuf:"bytes,2,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubsubPublishResult) Reset() {
+	*x = PubsubPublishResult{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubsubPublishResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubsubPublishResult) ProtoMessage() {}
+
+func (x *PubsubPublishResult) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubsubPublishResult.ProtoReflect.Descriptor instead.
+func (*PubsubPublishResult) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PubsubPublishResult) GetMessageId() string {
+	if x != nil && x.MessageId != nil {
+		return *x.MessageId
+	}
+	return ""
+}
+
+func (x *PubsubPublishResult) GetErr() *Error {
+	if x != nil {
+		return x.Err
+	}
+	return nil
+}
+
+// PubsubMessageLink links a message processing span to the span
+// in another app that published the message.
+type PubsubMessageLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TraceId       *TraceID               `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	SpanId        uint64                 `protobuf:"varint,2,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	App           string                 `protobuf:"bytes,3,opt,name=app,proto3" json:"app,omitempty"` // the app that published the message
+	Env           string                 `protobuf:"bytes,4,opt,name=env,proto3" json:"env,omitempty"` // the environment the message was published from
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubsubMessageLink) Reset() {
+	*x = PubsubMessageLink{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubsubMessageLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubsubMessageLink) ProtoMessage() {}
+
+func (x *PubsubMessageLink) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubsubMessageLink.ProtoReflect.Descriptor instead.
+func (*PubsubMessageLink) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PubsubMessageLink) GetTraceId() *TraceID {
+	if x != nil {
+		return x.TraceId
+	}
+	return nil
+}
+
+func (x *PubsubMessageLink) GetSpanId() uint64 {
+	if x != nil {
+		return x.SpanId
+	}
+	return 0
+}
+
+func (x *PubsubMessageLink) GetApp() string {
+	if x != nil {
+		return x.App
+	}
+	return ""
+}
+
+func (x *PubsubMessageLink) GetEnv() string {
+	if x != nil {
+		return x.Env
+	}
+	return ""
+}
+
+// StreamMessage describes a message sent or received
+// on a streaming API endpoint.
+type StreamMessage struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Direction     StreamMessage_Direction `protobuf:"varint,1,opt,name=direction,proto3,enum=encore.engine.trace2.StreamMessage_Direction" json:"direction,omitempty"`
+	Seq           uint64                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`   // the position of the message in the stream in its direction, starting at 1
+	Size          uint64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"` // the size of the encoded message, in bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamMessage) Reset() {
+	*x = StreamMessage{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMessage) ProtoMessage() {}
+
+func (x *StreamMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMessage.ProtoReflect.Descriptor instead.
+func (*StreamMessage) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StreamMessage) GetDirection() StreamMessage_Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return StreamMessage_INCOMING
+}
+
+func (x *StreamMessage) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *StreamMessage) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
 type ServiceInitStart struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
@@ -2658,7 +3222,7 @@ type ServiceInitStart struct {
 
 func (x *ServiceInitStart) Reset() {
 	*x = ServiceInitStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[25]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2670,7 +3234,7 @@ func (x *ServiceInitStart) String() string {
 func (*ServiceInitStart) ProtoMessage() {}
 
 func (x *ServiceInitStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[25]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2683,7 +3247,7 @@ func (x *ServiceInitStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceInitStart.ProtoReflect.Descriptor instead.
 func (*ServiceInitStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{25}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ServiceInitStart) GetService() string {
@@ -2702,7 +3266,7 @@ type ServiceInitEnd struct {
 
 func (x *ServiceInitEnd) Reset() {
 	*x = ServiceInitEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[26]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2714,7 +3278,7 @@ func (x *ServiceInitEnd) String() string {
 func (*ServiceInitEnd) ProtoMessage() {}
 
 func (x *ServiceInitEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[26]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2727,7 +3291,7 @@ func (x *ServiceInitEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceInitEnd.ProtoReflect.Descriptor instead.
 func (*ServiceInitEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{26}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ServiceInitEnd) GetErr() *Error {
@@ -2749,7 +3313,7 @@ type CacheCallStart struct {
 
 func (x *CacheCallStart) Reset() {
 	*x = CacheCallStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[27]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2761,7 +3325,7 @@ func (x *CacheCallStart) String() string {
 func (*CacheCallStart) ProtoMessage() {}
 
 func (x *CacheCallStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[27]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2774,7 +3338,7 @@ func (x *CacheCallStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheCallStart.ProtoReflect.Descriptor instead.
 func (*CacheCallStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{27}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *CacheCallStart) GetOperation() string {
@@ -2806,16 +3370,22 @@ func (x *CacheCallStart) GetStack() *StackTrace {
 }
 
 type CacheCallEnd struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Result        CacheCallEnd_Result    `protobuf:"varint,1,opt,name=result,proto3,enum=encore.engine.trace2.CacheCallEnd_Result" json:"result,omitempty"`
-	Err           *Error                 `protobuf:"bytes,2,opt,name=err,proto3,oneof" json:"err,omitempty"` // TODO include more info (like outputs)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Result CacheCallEnd_Result    `protobuf:"varint,1,opt,name=result,proto3,enum=encore.engine.trace2.CacheCallEnd_Result" json:"result,omitempty"`
+	Err    *Error                 `protobuf:"bytes,2,opt,name=err,proto3,oneof" json:"err,omitempty"` // TODO include more info (like outputs)
+	// op_results holds the result for each operation when executing a pipeline.
+	OpResults []*CacheOpResult `protobuf:"bytes,3,rep,name=op_results,json=opResults,proto3" json:"op_results,omitempty"`
+	// io_duration_nanos is the time spent waiting on the cache server,
+	// and codec_duration_nanos the time spent encoding and decoding values.
+	IoDurationNanos    uint64 `protobuf:"varint,4,opt,name=io_duration_nanos,json=ioDurationNanos,proto3" json:"io_duration_nanos,omitempty"`
+	CodecDurationNanos uint64 `protobuf:"varint,5,opt,name=codec_duration_nanos,json=codecDurationNanos,proto3" json:"codec_duration_nanos,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *CacheCallEnd) Reset() {
 	*x = CacheCallEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[28]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2827,7 +3397,7 @@ func (x *CacheCallEnd) String() string {
 func (*CacheCallEnd) ProtoMessage() {}
 
 func (x *CacheCallEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[28]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2840,7 +3410,7 @@ func (x *CacheCallEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheCallEnd.ProtoReflect.Descriptor instead.
 func (*CacheCallEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{28}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CacheCallEnd) GetResult() CacheCallEnd_Result {
@@ -2857,8 +3427,97 @@ func (x *CacheCallEnd) GetErr() *Error {
 	return nil
 }
 
-type BucketObjectUploadStart struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
+func (x *CacheCallEnd) GetOpResults() []*CacheOpResult {
+	if x != nil {
+		return x.OpResults
+	}
+	return nil
+}
+
+func (x *CacheCallEnd) GetIoDurationNanos() uint64 {
+	if x != nil {
+		return x.IoDurationNanos
+	}
+	return 0
+}
+
+func (x *CacheCallEnd) GetCodecDurationNanos() uint64 {
+	if x != nil {
+		return x.CodecDurationNanos
+	}
+	return 0
+}
+
+type CacheOpResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Result        CacheCallEnd_Result    `protobuf:"varint,3,opt,name=result,proto3,enum=encore.engine.trace2.CacheCallEnd_Result" json:"result,omitempty"`
+	Err           *Error                 `protobuf:"bytes,4,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheOpResult) Reset() {
+	*x = CacheOpResult{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheOpResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheOpResult) ProtoMessage() {}
+
+func (x *CacheOpResult) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheOpResult.ProtoReflect.Descriptor instead.
+func (*CacheOpResult) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CacheOpResult) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *CacheOpResult) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CacheOpResult) GetResult() CacheCallEnd_Result {
+	if x != nil {
+		return x.Result
+	}
+	return CacheCallEnd_UNKNOWN
+}
+
+func (x *CacheOpResult) GetErr() *Error {
+	if x != nil {
+		return x.Err
+	}
+	return nil
+}
+
+type BucketObjectUploadStart struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
 	Bucket        string                  `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	Object        string                  `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
 	Attrs         *BucketObjectAttributes `protobuf:"bytes,3,opt,name=attrs,proto3" json:"attrs,omitempty"`
@@ -2869,7 +3528,7 @@ type BucketObjectUploadStart struct {
 
 func (x *BucketObjectUploadStart) Reset() {
 	*x = BucketObjectUploadStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[29]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2881,7 +3540,7 @@ func (x *BucketObjectUploadStart) String() string {
 func (*BucketObjectUploadStart) ProtoMessage() {}
 
 func (x *BucketObjectUploadStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[29]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2894,7 +3553,7 @@ func (x *BucketObjectUploadStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectUploadStart.ProtoReflect.Descriptor instead.
 func (*BucketObjectUploadStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{29}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *BucketObjectUploadStart) GetBucket() string {
@@ -2936,7 +3595,7 @@ type BucketObjectUploadEnd struct {
 
 func (x *BucketObjectUploadEnd) Reset() {
 	*x = BucketObjectUploadEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[30]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2948,7 +3607,7 @@ func (x *BucketObjectUploadEnd) String() string {
 func (*BucketObjectUploadEnd) ProtoMessage() {}
 
 func (x *BucketObjectUploadEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[30]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2961,7 +3620,7 @@ func (x *BucketObjectUploadEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectUploadEnd.ProtoReflect.Descriptor instead.
 func (*BucketObjectUploadEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{30}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *BucketObjectUploadEnd) GetErr() *Error {
@@ -2985,19 +3644,91 @@ func (x *BucketObjectUploadEnd) GetVersion() string {
 	return ""
 }
 
-type BucketObjectDownloadStart struct {
+type BucketObjectUploadPart struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
-	Version       *string                `protobuf:"bytes,3,opt,name=version,proto3,oneof" json:"version,omitempty"`
-	Stack         *StackTrace            `protobuf:"bytes,4,opt,name=stack,proto3" json:"stack,omitempty"`
+	PartNumber    uint32                 `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Size          uint64                 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Attempts      uint32                 `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Err           *Error                 `protobuf:"bytes,4,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketObjectUploadPart) Reset() {
+	*x = BucketObjectUploadPart{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketObjectUploadPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketObjectUploadPart) ProtoMessage() {}
+
+func (x *BucketObjectUploadPart) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketObjectUploadPart.ProtoReflect.Descriptor instead.
+func (*BucketObjectUploadPart) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BucketObjectUploadPart) GetPartNumber() uint32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *BucketObjectUploadPart) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BucketObjectUploadPart) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *BucketObjectUploadPart) GetErr() *Error {
+	if x != nil {
+		return x.Err
+	}
+	return nil
+}
+
+type BucketObjectDownloadStart struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Bucket  string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object  string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Version *string                `protobuf:"bytes,3,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	Stack   *StackTrace            `protobuf:"bytes,4,opt,name=stack,proto3" json:"stack,omitempty"`
+	// offset and length describe the byte range being downloaded.
+	// length is unset if the rest of the object is downloaded.
+	Offset        uint64  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        *uint64 `protobuf:"varint,6,opt,name=length,proto3,oneof" json:"length,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BucketObjectDownloadStart) Reset() {
 	*x = BucketObjectDownloadStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[31]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3009,7 +3740,7 @@ func (x *BucketObjectDownloadStart) String() string {
 func (*BucketObjectDownloadStart) ProtoMessage() {}
 
 func (x *BucketObjectDownloadStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[31]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3022,7 +3753,7 @@ func (x *BucketObjectDownloadStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectDownloadStart.ProtoReflect.Descriptor instead.
 func (*BucketObjectDownloadStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{31}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *BucketObjectDownloadStart) GetBucket() string {
@@ -3053,17 +3784,34 @@ func (x *BucketObjectDownloadStart) GetStack() *StackTrace {
 	return nil
 }
 
+func (x *BucketObjectDownloadStart) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *BucketObjectDownloadStart) GetLength() uint64 {
+	if x != nil && x.Length != nil {
+		return *x.Length
+	}
+	return 0
+}
+
 type BucketObjectDownloadEnd struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Err           *Error                 `protobuf:"bytes,1,opt,name=err,proto3,oneof" json:"err,omitempty"`
-	Size          *uint64                `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Err   *Error                 `protobuf:"bytes,1,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	// size is the number of bytes downloaded.
+	Size *uint64 `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	// resumes is the number of times the download was resumed after a transient failure.
+	Resumes       uint32 `protobuf:"varint,3,opt,name=resumes,proto3" json:"resumes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BucketObjectDownloadEnd) Reset() {
 	*x = BucketObjectDownloadEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[32]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3075,7 +3823,7 @@ func (x *BucketObjectDownloadEnd) String() string {
 func (*BucketObjectDownloadEnd) ProtoMessage() {}
 
 func (x *BucketObjectDownloadEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[32]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3088,7 +3836,7 @@ func (x *BucketObjectDownloadEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectDownloadEnd.ProtoReflect.Descriptor instead.
 func (*BucketObjectDownloadEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{32}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *BucketObjectDownloadEnd) GetErr() *Error {
@@ -3105,6 +3853,13 @@ func (x *BucketObjectDownloadEnd) GetSize() uint64 {
 	return 0
 }
 
+func (x *BucketObjectDownloadEnd) GetResumes() uint32 {
+	if x != nil {
+		return x.Resumes
+	}
+	return 0
+}
+
 type BucketObjectGetAttrsStart struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
@@ -3117,7 +3872,7 @@ type BucketObjectGetAttrsStart struct {
 
 func (x *BucketObjectGetAttrsStart) Reset() {
 	*x = BucketObjectGetAttrsStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[33]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3129,7 +3884,7 @@ func (x *BucketObjectGetAttrsStart) String() string {
 func (*BucketObjectGetAttrsStart) ProtoMessage() {}
 
 func (x *BucketObjectGetAttrsStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[33]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3142,7 +3897,7 @@ func (x *BucketObjectGetAttrsStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectGetAttrsStart.ProtoReflect.Descriptor instead.
 func (*BucketObjectGetAttrsStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{33}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *BucketObjectGetAttrsStart) GetBucket() string {
@@ -3183,7 +3938,7 @@ type BucketObjectGetAttrsEnd struct {
 
 func (x *BucketObjectGetAttrsEnd) Reset() {
 	*x = BucketObjectGetAttrsEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[34]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3195,7 +3950,7 @@ func (x *BucketObjectGetAttrsEnd) String() string {
 func (*BucketObjectGetAttrsEnd) ProtoMessage() {}
 
 func (x *BucketObjectGetAttrsEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[34]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3208,7 +3963,7 @@ func (x *BucketObjectGetAttrsEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectGetAttrsEnd.ProtoReflect.Descriptor instead.
 func (*BucketObjectGetAttrsEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{34}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *BucketObjectGetAttrsEnd) GetErr() *Error {
@@ -3236,7 +3991,7 @@ type BucketListObjectsStart struct {
 
 func (x *BucketListObjectsStart) Reset() {
 	*x = BucketListObjectsStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[35]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3248,7 +4003,7 @@ func (x *BucketListObjectsStart) String() string {
 func (*BucketListObjectsStart) ProtoMessage() {}
 
 func (x *BucketListObjectsStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[35]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3261,7 +4016,7 @@ func (x *BucketListObjectsStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketListObjectsStart.ProtoReflect.Descriptor instead.
 func (*BucketListObjectsStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{35}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *BucketListObjectsStart) GetBucket() string {
@@ -3296,7 +4051,7 @@ type BucketListObjectsEnd struct {
 
 func (x *BucketListObjectsEnd) Reset() {
 	*x = BucketListObjectsEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[36]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3308,7 +4063,7 @@ func (x *BucketListObjectsEnd) String() string {
 func (*BucketListObjectsEnd) ProtoMessage() {}
 
 func (x *BucketListObjectsEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[36]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3321,54 +4076,445 @@ func (x *BucketListObjectsEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketListObjectsEnd.ProtoReflect.Descriptor instead.
 func (*BucketListObjectsEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{36}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BucketListObjectsEnd) GetErr() *Error {
+	if x != nil {
+		return x.Err
+	}
+	return nil
+}
+
+func (x *BucketListObjectsEnd) GetObserved() uint64 {
+	if x != nil {
+		return x.Observed
+	}
+	return 0
+}
+
+func (x *BucketListObjectsEnd) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type BucketDeleteObjectsStart struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Bucket        string                     `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Stack         *StackTrace                `protobuf:"bytes,2,opt,name=stack,proto3" json:"stack,omitempty"`
+	Entries       []*BucketDeleteObjectEntry `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketDeleteObjectsStart) Reset() {
+	*x = BucketDeleteObjectsStart{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketDeleteObjectsStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketDeleteObjectsStart) ProtoMessage() {}
+
+func (x *BucketDeleteObjectsStart) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketDeleteObjectsStart.ProtoReflect.Descriptor instead.
+func (*BucketDeleteObjectsStart) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BucketDeleteObjectsStart) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *BucketDeleteObjectsStart) GetStack() *StackTrace {
+	if x != nil {
+		return x.Stack
+	}
+	return nil
+}
+
+func (x *BucketDeleteObjectsStart) GetEntries() []*BucketDeleteObjectEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type BucketDeleteObjectEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        string                 `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Version       *string                `protobuf:"bytes,2,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketDeleteObjectEntry) Reset() {
+	*x = BucketDeleteObjectEntry{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketDeleteObjectEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketDeleteObjectEntry) ProtoMessage() {}
+
+func (x *BucketDeleteObjectEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketDeleteObjectEntry.ProtoReflect.Descriptor instead.
+func (*BucketDeleteObjectEntry) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BucketDeleteObjectEntry) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *BucketDeleteObjectEntry) GetVersion() string {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return ""
+}
+
+type BucketDeleteObjectsEnd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Err           *Error                 `protobuf:"bytes,1,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketDeleteObjectsEnd) Reset() {
+	*x = BucketDeleteObjectsEnd{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketDeleteObjectsEnd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketDeleteObjectsEnd) ProtoMessage() {}
+
+func (x *BucketDeleteObjectsEnd) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketDeleteObjectsEnd.ProtoReflect.Descriptor instead.
+func (*BucketDeleteObjectsEnd) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *BucketDeleteObjectsEnd) GetErr() *Error {
+	if x != nil {
+		return x.Err
+	}
+	return nil
+}
+
+type BucketObjectCopyStart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Src           string                 `protobuf:"bytes,2,opt,name=src,proto3" json:"src,omitempty"`
+	Dst           string                 `protobuf:"bytes,3,opt,name=dst,proto3" json:"dst,omitempty"`
+	SrcVersion    *string                `protobuf:"bytes,4,opt,name=src_version,json=srcVersion,proto3,oneof" json:"src_version,omitempty"`
+	Stack         *StackTrace            `protobuf:"bytes,5,opt,name=stack,proto3" json:"stack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketObjectCopyStart) Reset() {
+	*x = BucketObjectCopyStart{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketObjectCopyStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketObjectCopyStart) ProtoMessage() {}
+
+func (x *BucketObjectCopyStart) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketObjectCopyStart.ProtoReflect.Descriptor instead.
+func (*BucketObjectCopyStart) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *BucketObjectCopyStart) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *BucketObjectCopyStart) GetSrc() string {
+	if x != nil {
+		return x.Src
+	}
+	return ""
+}
+
+func (x *BucketObjectCopyStart) GetDst() string {
+	if x != nil {
+		return x.Dst
+	}
+	return ""
+}
+
+func (x *BucketObjectCopyStart) GetSrcVersion() string {
+	if x != nil && x.SrcVersion != nil {
+		return *x.SrcVersion
+	}
+	return ""
+}
+
+func (x *BucketObjectCopyStart) GetStack() *StackTrace {
+	if x != nil {
+		return x.Stack
+	}
+	return nil
+}
+
+type BucketObjectCopyEnd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Err           *Error                 `protobuf:"bytes,1,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	Size          *uint64                `protobuf:"varint,2,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	Version       *string                `protobuf:"bytes,3,opt,name=version,proto3,oneof" json:"version,omitempty"` // the version of the destination object
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketObjectCopyEnd) Reset() {
+	*x = BucketObjectCopyEnd{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketObjectCopyEnd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketObjectCopyEnd) ProtoMessage() {}
+
+func (x *BucketObjectCopyEnd) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketObjectCopyEnd.ProtoReflect.Descriptor instead.
+func (*BucketObjectCopyEnd) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *BucketObjectCopyEnd) GetErr() *Error {
+	if x != nil {
+		return x.Err
+	}
+	return nil
+}
+
+func (x *BucketObjectCopyEnd) GetSize() uint64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *BucketObjectCopyEnd) GetVersion() string {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return ""
+}
+
+type BucketObjectUpdateAttrsStart struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Bucket         string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object         string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	ContentType    *string                `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3,oneof" json:"content_type,omitempty"` // the new content type, if updated
+	UpdateMetadata bool                   `protobuf:"varint,4,opt,name=update_metadata,json=updateMetadata,proto3" json:"update_metadata,omitempty"`
+	MetadataKeys   []string               `protobuf:"bytes,5,rep,name=metadata_keys,json=metadataKeys,proto3" json:"metadata_keys,omitempty"` // the new metadata keys, if updated
+	UpdateTags     bool                   `protobuf:"varint,6,opt,name=update_tags,json=updateTags,proto3" json:"update_tags,omitempty"`
+	TagKeys        []string               `protobuf:"bytes,7,rep,name=tag_keys,json=tagKeys,proto3" json:"tag_keys,omitempty"` // the new tag keys, if updated
+	Stack          *StackTrace            `protobuf:"bytes,8,opt,name=stack,proto3" json:"stack,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BucketObjectUpdateAttrsStart) Reset() {
+	*x = BucketObjectUpdateAttrsStart{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketObjectUpdateAttrsStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketObjectUpdateAttrsStart) ProtoMessage() {}
+
+func (x *BucketObjectUpdateAttrsStart) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketObjectUpdateAttrsStart.ProtoReflect.Descriptor instead.
+func (*BucketObjectUpdateAttrsStart) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *BucketObjectUpdateAttrsStart) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *BucketObjectUpdateAttrsStart) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *BucketObjectUpdateAttrsStart) GetContentType() string {
+	if x != nil && x.ContentType != nil {
+		return *x.ContentType
+	}
+	return ""
+}
+
+func (x *BucketObjectUpdateAttrsStart) GetUpdateMetadata() bool {
+	if x != nil {
+		return x.UpdateMetadata
+	}
+	return false
+}
+
+func (x *BucketObjectUpdateAttrsStart) GetMetadataKeys() []string {
+	if x != nil {
+		return x.MetadataKeys
+	}
+	return nil
 }
 
-func (x *BucketListObjectsEnd) GetErr() *Error {
+func (x *BucketObjectUpdateAttrsStart) GetUpdateTags() bool {
 	if x != nil {
-		return x.Err
+		return x.UpdateTags
 	}
-	return nil
+	return false
 }
 
-func (x *BucketListObjectsEnd) GetObserved() uint64 {
+func (x *BucketObjectUpdateAttrsStart) GetTagKeys() []string {
 	if x != nil {
-		return x.Observed
+		return x.TagKeys
 	}
-	return 0
+	return nil
 }
 
-func (x *BucketListObjectsEnd) GetHasMore() bool {
+func (x *BucketObjectUpdateAttrsStart) GetStack() *StackTrace {
 	if x != nil {
-		return x.HasMore
+		return x.Stack
 	}
-	return false
+	return nil
 }
 
-type BucketDeleteObjectsStart struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	Bucket        string                     `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Stack         *StackTrace                `protobuf:"bytes,2,opt,name=stack,proto3" json:"stack,omitempty"`
-	Entries       []*BucketDeleteObjectEntry `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+type BucketObjectUpdateAttrsEnd struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Err           *Error                  `protobuf:"bytes,1,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	Attrs         *BucketObjectAttributes `protobuf:"bytes,2,opt,name=attrs,proto3,oneof" json:"attrs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BucketDeleteObjectsStart) Reset() {
-	*x = BucketDeleteObjectsStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[37]
+func (x *BucketObjectUpdateAttrsEnd) Reset() {
+	*x = BucketObjectUpdateAttrsEnd{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BucketDeleteObjectsStart) String() string {
+func (x *BucketObjectUpdateAttrsEnd) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BucketDeleteObjectsStart) ProtoMessage() {}
+func (*BucketObjectUpdateAttrsEnd) ProtoMessage() {}
 
-func (x *BucketDeleteObjectsStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[37]
+func (x *BucketObjectUpdateAttrsEnd) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3379,55 +4525,53 @@ func (x *BucketDeleteObjectsStart) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BucketDeleteObjectsStart.ProtoReflect.Descriptor instead.
-func (*BucketDeleteObjectsStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{37}
-}
-
-func (x *BucketDeleteObjectsStart) GetBucket() string {
-	if x != nil {
-		return x.Bucket
-	}
-	return ""
+// Deprecated: Use BucketObjectUpdateAttrsEnd.ProtoReflect.Descriptor instead.
+func (*BucketObjectUpdateAttrsEnd) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *BucketDeleteObjectsStart) GetStack() *StackTrace {
+func (x *BucketObjectUpdateAttrsEnd) GetErr() *Error {
 	if x != nil {
-		return x.Stack
+		return x.Err
 	}
 	return nil
 }
 
-func (x *BucketDeleteObjectsStart) GetEntries() []*BucketDeleteObjectEntry {
+func (x *BucketObjectUpdateAttrsEnd) GetAttrs() *BucketObjectAttributes {
 	if x != nil {
-		return x.Entries
+		return x.Attrs
 	}
 	return nil
 }
 
-type BucketDeleteObjectEntry struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Object        string                 `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
-	Version       *string                `protobuf:"bytes,2,opt,name=version,proto3,oneof" json:"version,omitempty"`
+type BucketSignedURL struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Bucket        string                    `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object        string                    `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Operation     BucketSignedURL_Operation `protobuf:"varint,3,opt,name=operation,proto3,enum=encore.engine.trace2.BucketSignedURL_Operation" json:"operation,omitempty"`
+	TtlNanos      uint64                    `protobuf:"varint,4,opt,name=ttl_nanos,json=ttlNanos,proto3" json:"ttl_nanos,omitempty"`
+	ContentType   *string                   `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3,oneof" json:"content_type,omitempty"` // the content type uploads are restricted to, if any
+	Err           *Error                    `protobuf:"bytes,6,opt,name=err,proto3,oneof" json:"err,omitempty"`
+	Stack         *StackTrace               `protobuf:"bytes,7,opt,name=stack,proto3" json:"stack,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BucketDeleteObjectEntry) Reset() {
-	*x = BucketDeleteObjectEntry{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[38]
+func (x *BucketSignedURL) Reset() {
+	*x = BucketSignedURL{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BucketDeleteObjectEntry) String() string {
+func (x *BucketSignedURL) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BucketDeleteObjectEntry) ProtoMessage() {}
+func (*BucketSignedURL) ProtoMessage() {}
 
-func (x *BucketDeleteObjectEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[38]
+func (x *BucketSignedURL) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3438,65 +4582,56 @@ func (x *BucketDeleteObjectEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BucketDeleteObjectEntry.ProtoReflect.Descriptor instead.
-func (*BucketDeleteObjectEntry) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use BucketSignedURL.ProtoReflect.Descriptor instead.
+func (*BucketSignedURL) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *BucketDeleteObjectEntry) GetObject() string {
+func (x *BucketSignedURL) GetBucket() string {
 	if x != nil {
-		return x.Object
+		return x.Bucket
 	}
 	return ""
 }
 
-func (x *BucketDeleteObjectEntry) GetVersion() string {
-	if x != nil && x.Version != nil {
-		return *x.Version
+func (x *BucketSignedURL) GetObject() string {
+	if x != nil {
+		return x.Object
 	}
 	return ""
 }
 
-type BucketDeleteObjectsEnd struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Err           *Error                 `protobuf:"bytes,1,opt,name=err,proto3,oneof" json:"err,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BucketSignedURL) GetOperation() BucketSignedURL_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return BucketSignedURL_UPLOAD
 }
 
-func (x *BucketDeleteObjectsEnd) Reset() {
-	*x = BucketDeleteObjectsEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[39]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *BucketSignedURL) GetTtlNanos() uint64 {
+	if x != nil {
+		return x.TtlNanos
+	}
+	return 0
 }
 
-func (x *BucketDeleteObjectsEnd) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *BucketSignedURL) GetContentType() string {
+	if x != nil && x.ContentType != nil {
+		return *x.ContentType
+	}
+	return ""
 }
 
-func (*BucketDeleteObjectsEnd) ProtoMessage() {}
-
-func (x *BucketDeleteObjectsEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[39]
+func (x *BucketSignedURL) GetErr() *Error {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Err
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use BucketDeleteObjectsEnd.ProtoReflect.Descriptor instead.
-func (*BucketDeleteObjectsEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{39}
+	return nil
 }
 
-func (x *BucketDeleteObjectsEnd) GetErr() *Error {
+func (x *BucketSignedURL) GetStack() *StackTrace {
 	if x != nil {
-		return x.Err
+		return x.Stack
 	}
 	return nil
 }
@@ -3513,7 +4648,7 @@ type BucketObjectAttributes struct {
 
 func (x *BucketObjectAttributes) Reset() {
 	*x = BucketObjectAttributes{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[40]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3525,7 +4660,7 @@ func (x *BucketObjectAttributes) String() string {
 func (*BucketObjectAttributes) ProtoMessage() {}
 
 func (x *BucketObjectAttributes) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[40]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3538,7 +4673,7 @@ func (x *BucketObjectAttributes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectAttributes.ProtoReflect.Descriptor instead.
 func (*BucketObjectAttributes) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{40}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *BucketObjectAttributes) GetSize() uint64 {
@@ -3580,7 +4715,7 @@ type BodyStream struct {
 
 func (x *BodyStream) Reset() {
 	*x = BodyStream{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[41]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3592,7 +4727,7 @@ func (x *BodyStream) String() string {
 func (*BodyStream) ProtoMessage() {}
 
 func (x *BodyStream) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[41]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3605,7 +4740,7 @@ func (x *BodyStream) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BodyStream.ProtoReflect.Descriptor instead.
 func (*BodyStream) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{41}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *BodyStream) GetIsResponse() bool {
@@ -3644,7 +4779,7 @@ type HTTPCallStart struct {
 
 func (x *HTTPCallStart) Reset() {
 	*x = HTTPCallStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[42]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3656,7 +4791,7 @@ func (x *HTTPCallStart) String() string {
 func (*HTTPCallStart) ProtoMessage() {}
 
 func (x *HTTPCallStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[42]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3669,7 +4804,7 @@ func (x *HTTPCallStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPCallStart.ProtoReflect.Descriptor instead.
 func (*HTTPCallStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{42}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *HTTPCallStart) GetCorrelationParentSpanId() uint64 {
@@ -3721,7 +4856,7 @@ type HTTPCallEnd struct {
 
 func (x *HTTPCallEnd) Reset() {
 	*x = HTTPCallEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[43]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3733,7 +4868,7 @@ func (x *HTTPCallEnd) String() string {
 func (*HTTPCallEnd) ProtoMessage() {}
 
 func (x *HTTPCallEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[43]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3746,7 +4881,7 @@ func (x *HTTPCallEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPCallEnd.ProtoReflect.Descriptor instead.
 func (*HTTPCallEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{43}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *HTTPCallEnd) GetStatusCode() uint32 {
@@ -3796,7 +4931,7 @@ type HTTPTraceEvent struct {
 
 func (x *HTTPTraceEvent) Reset() {
 	*x = HTTPTraceEvent{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[44]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3808,7 +4943,7 @@ func (x *HTTPTraceEvent) String() string {
 func (*HTTPTraceEvent) ProtoMessage() {}
 
 func (x *HTTPTraceEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[44]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3821,7 +4956,7 @@ func (x *HTTPTraceEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPTraceEvent.ProtoReflect.Descriptor instead.
 func (*HTTPTraceEvent) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{44}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *HTTPTraceEvent) GetNanotime() int64 {
@@ -4061,7 +5196,7 @@ type HTTPGetConn struct {
 
 func (x *HTTPGetConn) Reset() {
 	*x = HTTPGetConn{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[45]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4073,7 +5208,7 @@ func (x *HTTPGetConn) String() string {
 func (*HTTPGetConn) ProtoMessage() {}
 
 func (x *HTTPGetConn) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[45]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4086,7 +5221,7 @@ func (x *HTTPGetConn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPGetConn.ProtoReflect.Descriptor instead.
 func (*HTTPGetConn) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{45}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *HTTPGetConn) GetHostPort() string {
@@ -4107,7 +5242,7 @@ type HTTPGotConn struct {
 
 func (x *HTTPGotConn) Reset() {
 	*x = HTTPGotConn{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[46]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4119,7 +5254,7 @@ func (x *HTTPGotConn) String() string {
 func (*HTTPGotConn) ProtoMessage() {}
 
 func (x *HTTPGotConn) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[46]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4132,7 +5267,7 @@ func (x *HTTPGotConn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPGotConn.ProtoReflect.Descriptor instead.
 func (*HTTPGotConn) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{46}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *HTTPGotConn) GetReused() bool {
@@ -4164,7 +5299,7 @@ type HTTPGotFirstResponseByte struct {
 
 func (x *HTTPGotFirstResponseByte) Reset() {
 	*x = HTTPGotFirstResponseByte{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[47]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4176,7 +5311,7 @@ func (x *HTTPGotFirstResponseByte) String() string {
 func (*HTTPGotFirstResponseByte) ProtoMessage() {}
 
 func (x *HTTPGotFirstResponseByte) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[47]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4189,7 +5324,7 @@ func (x *HTTPGotFirstResponseByte) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPGotFirstResponseByte.ProtoReflect.Descriptor instead.
 func (*HTTPGotFirstResponseByte) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{47}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{58}
 }
 
 type HTTPGot1XxResponse struct {
@@ -4201,7 +5336,7 @@ type HTTPGot1XxResponse struct {
 
 func (x *HTTPGot1XxResponse) Reset() {
 	*x = HTTPGot1XxResponse{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[48]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4213,7 +5348,7 @@ func (x *HTTPGot1XxResponse) String() string {
 func (*HTTPGot1XxResponse) ProtoMessage() {}
 
 func (x *HTTPGot1XxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[48]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4226,7 +5361,7 @@ func (x *HTTPGot1XxResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPGot1XxResponse.ProtoReflect.Descriptor instead.
 func (*HTTPGot1XxResponse) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{48}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *HTTPGot1XxResponse) GetCode() int32 {
@@ -4245,7 +5380,7 @@ type HTTPDNSStart struct {
 
 func (x *HTTPDNSStart) Reset() {
 	*x = HTTPDNSStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[49]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4257,7 +5392,7 @@ func (x *HTTPDNSStart) String() string {
 func (*HTTPDNSStart) ProtoMessage() {}
 
 func (x *HTTPDNSStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[49]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4270,7 +5405,7 @@ func (x *HTTPDNSStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPDNSStart.ProtoReflect.Descriptor instead.
 func (*HTTPDNSStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{49}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *HTTPDNSStart) GetHost() string {
@@ -4290,7 +5425,7 @@ type HTTPDNSDone struct {
 
 func (x *HTTPDNSDone) Reset() {
 	*x = HTTPDNSDone{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[50]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4302,7 +5437,7 @@ func (x *HTTPDNSDone) String() string {
 func (*HTTPDNSDone) ProtoMessage() {}
 
 func (x *HTTPDNSDone) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[50]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4315,7 +5450,7 @@ func (x *HTTPDNSDone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPDNSDone.ProtoReflect.Descriptor instead.
 func (*HTTPDNSDone) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{50}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *HTTPDNSDone) GetErr() []byte {
@@ -4341,7 +5476,7 @@ type DNSAddr struct {
 
 func (x *DNSAddr) Reset() {
 	*x = DNSAddr{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[51]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4353,7 +5488,7 @@ func (x *DNSAddr) String() string {
 func (*DNSAddr) ProtoMessage() {}
 
 func (x *DNSAddr) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[51]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4366,7 +5501,7 @@ func (x *DNSAddr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DNSAddr.ProtoReflect.Descriptor instead.
 func (*DNSAddr) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{51}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *DNSAddr) GetIp() []byte {
@@ -4386,7 +5521,7 @@ type HTTPConnectStart struct {
 
 func (x *HTTPConnectStart) Reset() {
 	*x = HTTPConnectStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[52]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4398,7 +5533,7 @@ func (x *HTTPConnectStart) String() string {
 func (*HTTPConnectStart) ProtoMessage() {}
 
 func (x *HTTPConnectStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[52]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4411,7 +5546,7 @@ func (x *HTTPConnectStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPConnectStart.ProtoReflect.Descriptor instead.
 func (*HTTPConnectStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{52}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *HTTPConnectStart) GetNetwork() string {
@@ -4439,7 +5574,7 @@ type HTTPConnectDone struct {
 
 func (x *HTTPConnectDone) Reset() {
 	*x = HTTPConnectDone{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[53]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4451,7 +5586,7 @@ func (x *HTTPConnectDone) String() string {
 func (*HTTPConnectDone) ProtoMessage() {}
 
 func (x *HTTPConnectDone) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[53]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4464,7 +5599,7 @@ func (x *HTTPConnectDone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPConnectDone.ProtoReflect.Descriptor instead.
 func (*HTTPConnectDone) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{53}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *HTTPConnectDone) GetNetwork() string {
@@ -4496,7 +5631,7 @@ type HTTPTLSHandshakeStart struct {
 
 func (x *HTTPTLSHandshakeStart) Reset() {
 	*x = HTTPTLSHandshakeStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[54]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4508,7 +5643,7 @@ func (x *HTTPTLSHandshakeStart) String() string {
 func (*HTTPTLSHandshakeStart) ProtoMessage() {}
 
 func (x *HTTPTLSHandshakeStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[54]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4521,7 +5656,7 @@ func (x *HTTPTLSHandshakeStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPTLSHandshakeStart.ProtoReflect.Descriptor instead.
 func (*HTTPTLSHandshakeStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{54}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{65}
 }
 
 type HTTPTLSHandshakeDone struct {
@@ -4537,7 +5672,7 @@ type HTTPTLSHandshakeDone struct {
 
 func (x *HTTPTLSHandshakeDone) Reset() {
 	*x = HTTPTLSHandshakeDone{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[55]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4549,7 +5684,7 @@ func (x *HTTPTLSHandshakeDone) String() string {
 func (*HTTPTLSHandshakeDone) ProtoMessage() {}
 
 func (x *HTTPTLSHandshakeDone) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[55]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4562,7 +5697,7 @@ func (x *HTTPTLSHandshakeDone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPTLSHandshakeDone.ProtoReflect.Descriptor instead.
 func (*HTTPTLSHandshakeDone) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{55}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *HTTPTLSHandshakeDone) GetErr() []byte {
@@ -4608,7 +5743,7 @@ type HTTPWroteHeaders struct {
 
 func (x *HTTPWroteHeaders) Reset() {
 	*x = HTTPWroteHeaders{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[56]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4620,7 +5755,7 @@ func (x *HTTPWroteHeaders) String() string {
 func (*HTTPWroteHeaders) ProtoMessage() {}
 
 func (x *HTTPWroteHeaders) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[56]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4633,7 +5768,7 @@ func (x *HTTPWroteHeaders) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPWroteHeaders.ProtoReflect.Descriptor instead.
 func (*HTTPWroteHeaders) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{56}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{67}
 }
 
 type HTTPWroteRequest struct {
@@ -4645,7 +5780,7 @@ type HTTPWroteRequest struct {
 
 func (x *HTTPWroteRequest) Reset() {
 	*x = HTTPWroteRequest{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[57]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4657,7 +5792,7 @@ func (x *HTTPWroteRequest) String() string {
 func (*HTTPWroteRequest) ProtoMessage() {}
 
 func (x *HTTPWroteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[57]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4670,7 +5805,7 @@ func (x *HTTPWroteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPWroteRequest.ProtoReflect.Descriptor instead.
 func (*HTTPWroteRequest) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{57}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{68}
 }
 
 func (x *HTTPWroteRequest) GetErr() []byte {
@@ -4688,7 +5823,7 @@ type HTTPWait100Continue struct {
 
 func (x *HTTPWait100Continue) Reset() {
 	*x = HTTPWait100Continue{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[58]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4700,7 +5835,7 @@ func (x *HTTPWait100Continue) String() string {
 func (*HTTPWait100Continue) ProtoMessage() {}
 
 func (x *HTTPWait100Continue) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[58]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4713,7 +5848,7 @@ func (x *HTTPWait100Continue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPWait100Continue.ProtoReflect.Descriptor instead.
 func (*HTTPWait100Continue) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{58}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{69}
 }
 
 type HTTPClosedBodyData struct {
@@ -4725,7 +5860,7 @@ type HTTPClosedBodyData struct {
 
 func (x *HTTPClosedBodyData) Reset() {
 	*x = HTTPClosedBodyData{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[59]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4737,7 +5872,7 @@ func (x *HTTPClosedBodyData) String() string {
 func (*HTTPClosedBodyData) ProtoMessage() {}
 
 func (x *HTTPClosedBodyData) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[59]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4750,7 +5885,7 @@ func (x *HTTPClosedBodyData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPClosedBodyData.ProtoReflect.Descriptor instead.
 func (*HTTPClosedBodyData) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{59}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *HTTPClosedBodyData) GetErr() []byte {
@@ -4772,7 +5907,7 @@ type LogMessage struct {
 
 func (x *LogMessage) Reset() {
 	*x = LogMessage{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[60]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4784,7 +5919,7 @@ func (x *LogMessage) String() string {
 func (*LogMessage) ProtoMessage() {}
 
 func (x *LogMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[60]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4797,7 +5932,7 @@ func (x *LogMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogMessage.ProtoReflect.Descriptor instead.
 func (*LogMessage) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{60}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *LogMessage) GetLevel() LogMessage_Level {
@@ -4851,7 +5986,7 @@ type LogField struct {
 
 func (x *LogField) Reset() {
 	*x = LogField{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[61]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4863,7 +5998,7 @@ func (x *LogField) String() string {
 func (*LogField) ProtoMessage() {}
 
 func (x *LogField) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[61]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4876,7 +6011,7 @@ func (x *LogField) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogField.ProtoReflect.Descriptor instead.
 func (*LogField) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{61}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *LogField) GetKey() string {
@@ -5072,7 +6207,7 @@ type StackTrace struct {
 
 func (x *StackTrace) Reset() {
 	*x = StackTrace{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[62]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5084,7 +6219,7 @@ func (x *StackTrace) String() string {
 func (*StackTrace) ProtoMessage() {}
 
 func (x *StackTrace) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[62]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5097,7 +6232,7 @@ func (x *StackTrace) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StackTrace.ProtoReflect.Descriptor instead.
 func (*StackTrace) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{62}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *StackTrace) GetPcs() []int64 {
@@ -5125,7 +6260,7 @@ type StackFrame struct {
 
 func (x *StackFrame) Reset() {
 	*x = StackFrame{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[63]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5137,7 +6272,7 @@ func (x *StackFrame) String() string {
 func (*StackFrame) ProtoMessage() {}
 
 func (x *StackFrame) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[63]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5150,7 +6285,7 @@ func (x *StackFrame) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StackFrame.ProtoReflect.Descriptor instead.
 func (*StackFrame) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{63}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *StackFrame) GetFilename() string {
@@ -5184,7 +6319,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[64]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5196,7 +6331,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[64]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5209,7 +6344,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{64}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *Error) GetMsg() string {
@@ -5287,14 +6422,15 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\bspan_end\x18\v \x01(\v2\x1d.encore.engine.trace2.SpanEndH\x00R\aspanEnd\x12@\n" +
 	"\n" +
 	"span_event\x18\f \x01(\v2\x1f.encore.engine.trace2.SpanEventH\x00R\tspanEventB\a\n" +
-	"\x05event\"\x9a\x05\n" +
+	"\x05event\"\xc2\x05\n" +
 	"\tSpanStart\x12\x12\n" +
 	"\x04goid\x18\x01 \x01(\rR\x04goid\x12J\n" +
 	"\x0fparent_trace_id\x18\x02 \x01(\v2\x1d.encore.engine.trace2.TraceIDH\x01R\rparentTraceId\x88\x01\x01\x12)\n" +
 	"\x0eparent_span_id\x18\x03 \x01(\x04H\x02R\fparentSpanId\x88\x01\x01\x12+\n" +
 	"\x0fcaller_event_id\x18\x04 \x01(\x04H\x03R\rcallerEventId\x88\x01\x01\x12;\n" +
 	"\x17external_correlation_id\x18\x05 \x01(\tH\x04R\x15externalCorrelationId\x88\x01\x01\x12\x1c\n" +
-	"\adef_loc\x18\x06 \x01(\rH\x05R\x06defLoc\x88\x01\x01\x12B\n" +
+	"\adef_loc\x18\x06 \x01(\rH\x05R\x06defLoc\x88\x01\x01\x12\x1b\n" +
+	"\x06region\x18\a \x01(\tH\x06R\x06region\x88\x01\x01\x12B\n" +
 	"\arequest\x18\n" +
 	" \x01(\v2&.encore.engine.trace2.RequestSpanStartH\x00R\arequest\x129\n" +
 	"\x04auth\x18\v \x01(\v2#.encore.engine.trace2.AuthSpanStartH\x00R\x04auth\x12U\n" +
@@ -5306,14 +6442,16 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\x10_caller_event_idB\x1a\n" +
 	"\x18_external_correlation_idB\n" +
 	"\n" +
-	"\b_def_loc\"\xf9\x04\n" +
+	"\b_def_locB\t\n" +
+	"\a_region\"\xcd\x05\n" +
 	"\aSpanEnd\x12%\n" +
 	"\x0eduration_nanos\x18\x01 \x01(\x04R\rdurationNanos\x126\n" +
 	"\x05error\x18\x02 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x01R\x05error\x88\x01\x01\x12F\n" +
 	"\vpanic_stack\x18\x03 \x01(\v2 .encore.engine.trace2.StackTraceH\x02R\n" +
 	"panicStack\x88\x01\x01\x12J\n" +
 	"\x0fparent_trace_id\x18\x04 \x01(\v2\x1d.encore.engine.trace2.TraceIDH\x03R\rparentTraceId\x88\x01\x01\x12)\n" +
-	"\x0eparent_span_id\x18\x05 \x01(\x04H\x04R\fparentSpanId\x88\x01\x01\x12@\n" +
+	"\x0eparent_span_id\x18\x05 \x01(\x04H\x04R\fparentSpanId\x88\x01\x01\x12D\n" +
+	"\tmem_stats\x18\x06 \x01(\v2\".encore.engine.trace2.SpanMemStatsH\x05R\bmemStats\x88\x01\x01\x12@\n" +
 	"\arequest\x18\n" +
 	" \x01(\v2$.encore.engine.trace2.RequestSpanEndH\x00R\arequest\x127\n" +
 	"\x04auth\x18\v \x01(\v2!.encore.engine.trace2.AuthSpanEndH\x00R\x04auth\x12S\n" +
@@ -5323,7 +6461,14 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\x06_errorB\x0e\n" +
 	"\f_panic_stackB\x12\n" +
 	"\x10_parent_trace_idB\x11\n" +
-	"\x0f_parent_span_id\"\x9b\x04\n" +
+	"\x0f_parent_span_idB\f\n" +
+	"\n" +
+	"_mem_stats\"w\n" +
+	"\fSpanMemStats\x12\x1f\n" +
+	"\valloc_bytes\x18\x01 \x01(\x04R\n" +
+	"allocBytes\x12#\n" +
+	"\ralloc_objects\x18\x02 \x01(\x04R\fallocObjects\x12!\n" +
+	"\fheap_objects\x18\x03 \x01(\x04R\vheapObjects\"\x9b\x04\n" +
 	"\x10RequestSpanStart\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12#\n" +
 	"\rendpoint_name\x18\x02 \x01(\tR\fendpointName\x12\x1f\n" +
@@ -5365,7 +6510,7 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\x03uid\x18\x03 \x01(\tR\x03uid\x12 \n" +
 	"\tuser_data\x18\x04 \x01(\fH\x00R\buserData\x88\x01\x01B\f\n" +
 	"\n" +
-	"_user_data\"\xc1\x02\n" +
+	"_user_data\"\xdd\x02\n" +
 	"\x16PubsubMessageSpanStart\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1d\n" +
 	"\n" +
@@ -5375,7 +6520,8 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"message_id\x18\x04 \x01(\tR\tmessageId\x12\x18\n" +
 	"\aattempt\x18\x05 \x01(\rR\aattempt\x12=\n" +
 	"\fpublish_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vpublishTime\x12,\n" +
-	"\x0fmessage_payload\x18\a \x01(\fH\x00R\x0emessagePayload\x88\x01\x01B\x12\n" +
+	"\x0fmessage_payload\x18\a \x01(\fH\x00R\x0emessagePayload\x88\x01\x01\x12\x1a\n" +
+	"\breplayed\x18\b \x01(\bR\breplayedB\x12\n" +
 	"\x10_message_payload\"\x85\x01\n" +
 	"\x14PubsubMessageSpanEnd\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1d\n" +
@@ -5392,7 +6538,7 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1b\n" +
 	"\ttest_name\x18\x02 \x01(\tR\btestName\x12\x16\n" +
 	"\x06failed\x18\x03 \x01(\bR\x06failed\x12\x18\n" +
-	"\askipped\x18\x04 \x01(\bR\askipped\"\xe3\x13\n" +
+	"\askipped\x18\x04 \x01(\bR\askipped\"\x8c\x1a\n" +
 	"\tSpanEvent\x12\x12\n" +
 	"\x04goid\x18\x01 \x01(\rR\x04goid\x12\x1c\n" +
 	"\adef_loc\x18\x02 \x01(\rH\x01R\x06defLoc\x88\x01\x01\x125\n" +
@@ -5427,7 +6573,15 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\x19bucket_list_objects_start\x18  \x01(\v2,.encore.engine.trace2.BucketListObjectsStartH\x00R\x16bucketListObjectsStart\x12c\n" +
 	"\x17bucket_list_objects_end\x18! \x01(\v2*.encore.engine.trace2.BucketListObjectsEndH\x00R\x14bucketListObjectsEnd\x12o\n" +
 	"\x1bbucket_delete_objects_start\x18\" \x01(\v2..encore.engine.trace2.BucketDeleteObjectsStartH\x00R\x18bucketDeleteObjectsStart\x12i\n" +
-	"\x19bucket_delete_objects_end\x18# \x01(\v2,.encore.engine.trace2.BucketDeleteObjectsEndH\x00R\x16bucketDeleteObjectsEndB\x06\n" +
+	"\x19bucket_delete_objects_end\x18# \x01(\v2,.encore.engine.trace2.BucketDeleteObjectsEndH\x00R\x16bucketDeleteObjectsEnd\x12S\n" +
+	"\x11bucket_signed_url\x18$ \x01(\v2%.encore.engine.trace2.BucketSignedURLH\x00R\x0fbucketSignedUrl\x12f\n" +
+	"\x18bucket_object_copy_start\x18% \x01(\v2+.encore.engine.trace2.BucketObjectCopyStartH\x00R\x15bucketObjectCopyStart\x12`\n" +
+	"\x16bucket_object_copy_end\x18& \x01(\v2).encore.engine.trace2.BucketObjectCopyEndH\x00R\x13bucketObjectCopyEnd\x12i\n" +
+	"\x19bucket_object_upload_part\x18' \x01(\v2,.encore.engine.trace2.BucketObjectUploadPartH\x00R\x16bucketObjectUploadPart\x12Y\n" +
+	"\x13pubsub_message_link\x18( \x01(\v2'.encore.engine.trace2.PubsubMessageLinkH\x00R\x11pubsubMessageLink\x12L\n" +
+	"\x0estream_message\x18) \x01(\v2#.encore.engine.trace2.StreamMessageH\x00R\rstreamMessage\x12|\n" +
+	" bucket_object_update_attrs_start\x18* \x01(\v22.encore.engine.trace2.BucketObjectUpdateAttrsStartH\x00R\x1cbucketObjectUpdateAttrsStart\x12v\n" +
+	"\x1ebucket_object_update_attrs_end\x18+ \x01(\v20.encore.engine.trace2.BucketObjectUpdateAttrsEndH\x00R\x1abucketObjectUpdateAttrsEndB\x06\n" +
 	"\x04dataB\n" +
 	"\n" +
 	"\b_def_locB\x17\n" +
@@ -5443,35 +6597,66 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\x0eGoroutineStart\"\x0e\n" +
 	"\fGoroutineEnd\"L\n" +
 	"\x12DBTransactionStart\x126\n" +
-	"\x05stack\x18\x01 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\"\x89\x02\n" +
+	"\x05stack\x18\x01 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\"\xa3\x02\n" +
 	"\x10DBTransactionEnd\x12U\n" +
 	"\n" +
 	"completion\x18\x01 \x01(\x0e25.encore.engine.trace2.DBTransactionEnd.CompletionTypeR\n" +
 	"completion\x126\n" +
 	"\x05stack\x18\x02 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\x122\n" +
-	"\x03err\x18\x03 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01\"*\n" +
+	"\x03err\x18\x03 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01\x12\x18\n" +
+	"\aretries\x18\x04 \x01(\rR\aretries\"*\n" +
 	"\x0eCompletionType\x12\f\n" +
 	"\bROLLBACK\x10\x00\x12\n" +
 	"\n" +
 	"\x06COMMIT\x10\x01B\x06\n" +
-	"\x04_err\"\\\n" +
+	"\x04_err\"\xfb\x01\n" +
 	"\fDBQueryStart\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x126\n" +
-	"\x05stack\x18\x02 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\"H\n" +
+	"\x05stack\x18\x02 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\x12 \n" +
+	"\tstmt_name\x18\x03 \x01(\tH\x00R\bstmtName\x88\x01\x01\x12\x17\n" +
+	"\x04node\x18\x04 \x01(\tH\x01R\x04node\x88\x01\x01\x12\x17\n" +
+	"\x04rows\x18\x05 \x01(\x04H\x02R\x04rows\x88\x01\x01\x12\x1d\n" +
+	"\aattempt\x18\x06 \x01(\rH\x03R\aattempt\x88\x01\x01B\f\n" +
+	"\n" +
+	"_stmt_nameB\a\n" +
+	"\x05_nodeB\a\n" +
+	"\x05_rowsB\n" +
+	"\n" +
+	"\b_attempt\"H\n" +
 	"\n" +
 	"DBQueryEnd\x122\n" +
 	"\x03err\x18\x01 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01B\x06\n" +
-	"\x04_err\"|\n" +
+	"\x04_err\"\x92\x01\n" +
 	"\x12PubsubPublishStart\x12\x14\n" +
 	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\fR\amessage\x126\n" +
-	"\x05stack\x18\x03 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\"\x81\x01\n" +
+	"\x05stack\x18\x03 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\x12\x14\n" +
+	"\x05batch\x18\x04 \x03(\fR\x05batch\"\xd1\x01\n" +
 	"\x10PubsubPublishEnd\x12\"\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tH\x00R\tmessageId\x88\x01\x01\x122\n" +
+	"\x03err\x18\x02 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x01R\x03err\x88\x01\x01\x12N\n" +
+	"\rbatch_results\x18\x03 \x03(\v2).encore.engine.trace2.PubsubPublishResultR\fbatchResultsB\r\n" +
+	"\v_message_idB\x06\n" +
+	"\x04_err\"\x84\x01\n" +
+	"\x13PubsubPublishResult\x12\"\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tH\x00R\tmessageId\x88\x01\x01\x122\n" +
 	"\x03err\x18\x02 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x01R\x03err\x88\x01\x01B\r\n" +
 	"\v_message_idB\x06\n" +
-	"\x04_err\",\n" +
+	"\x04_err\"\x8a\x01\n" +
+	"\x11PubsubMessageLink\x128\n" +
+	"\btrace_id\x18\x01 \x01(\v2\x1d.encore.engine.trace2.TraceIDR\atraceId\x12\x17\n" +
+	"\aspan_id\x18\x02 \x01(\x04R\x06spanId\x12\x10\n" +
+	"\x03app\x18\x03 \x01(\tR\x03app\x12\x10\n" +
+	"\x03env\x18\x04 \x01(\tR\x03env\"\xab\x01\n" +
+	"\rStreamMessage\x12K\n" +
+	"\tdirection\x18\x01 \x01(\x0e2-.encore.engine.trace2.StreamMessage.DirectionR\tdirection\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x04R\x03seq\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x04R\x04size\"'\n" +
+	"\tDirection\x12\f\n" +
+	"\bINCOMING\x10\x00\x12\f\n" +
+	"\bOUTGOING\x10\x01\",\n" +
 	"\x10ServiceInitStart\x12\x18\n" +
 	"\aservice\x18\x01 \x01(\tR\aservice\"L\n" +
 	"\x0eServiceInitEnd\x122\n" +
@@ -5481,16 +6666,26 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\toperation\x18\x01 \x01(\tR\toperation\x12\x12\n" +
 	"\x04keys\x18\x02 \x03(\tR\x04keys\x12\x14\n" +
 	"\x05write\x18\x03 \x01(\bR\x05write\x126\n" +
-	"\x05stack\x18\x04 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\"\xd4\x01\n" +
+	"\x05stack\x18\x04 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\"\xf6\x02\n" +
 	"\fCacheCallEnd\x12A\n" +
 	"\x06result\x18\x01 \x01(\x0e2).encore.engine.trace2.CacheCallEnd.ResultR\x06result\x122\n" +
-	"\x03err\x18\x02 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01\"E\n" +
+	"\x03err\x18\x02 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01\x12B\n" +
+	"\n" +
+	"op_results\x18\x03 \x03(\v2#.encore.engine.trace2.CacheOpResultR\topResults\x12*\n" +
+	"\x11io_duration_nanos\x18\x04 \x01(\x04R\x0fioDurationNanos\x120\n" +
+	"\x14codec_duration_nanos\x18\x05 \x01(\x04R\x12codecDurationNanos\"E\n" +
 	"\x06Result\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\x06\n" +
 	"\x02OK\x10\x01\x12\x0f\n" +
 	"\vNO_SUCH_KEY\x10\x02\x12\f\n" +
 	"\bCONFLICT\x10\x03\x12\a\n" +
 	"\x03ERR\x10\x04B\x06\n" +
+	"\x04_err\"\xbe\x01\n" +
+	"\rCacheOpResult\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12A\n" +
+	"\x06result\x18\x03 \x01(\x0e2).encore.engine.trace2.CacheCallEnd.ResultR\x06result\x122\n" +
+	"\x03err\x18\x04 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01B\x06\n" +
 	"\x04_err\"\xc5\x01\n" +
 	"\x17BucketObjectUploadStart\x12\x16\n" +
 	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
@@ -5504,17 +6699,28 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\x04_errB\a\n" +
 	"\x05_sizeB\n" +
 	"\n" +
-	"\b_version\"\xae\x01\n" +
+	"\b_version\"\xa5\x01\n" +
+	"\x16BucketObjectUploadPart\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\rR\n" +
+	"partNumber\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x04R\x04size\x12\x1a\n" +
+	"\battempts\x18\x03 \x01(\rR\battempts\x122\n" +
+	"\x03err\x18\x04 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01B\x06\n" +
+	"\x04_err\"\xee\x01\n" +
 	"\x19BucketObjectDownloadStart\x12\x16\n" +
 	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
 	"\x06object\x18\x02 \x01(\tR\x06object\x12\x1d\n" +
 	"\aversion\x18\x03 \x01(\tH\x00R\aversion\x88\x01\x01\x126\n" +
-	"\x05stack\x18\x04 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stackB\n" +
+	"\x05stack\x18\x04 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x04R\x06offset\x12\x1b\n" +
+	"\x06length\x18\x06 \x01(\x04H\x01R\x06length\x88\x01\x01B\n" +
 	"\n" +
-	"\b_version\"w\n" +
+	"\b_versionB\t\n" +
+	"\a_length\"\x91\x01\n" +
 	"\x17BucketObjectDownloadEnd\x122\n" +
 	"\x03err\x18\x01 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01\x12\x17\n" +
-	"\x04size\x18\x02 \x01(\x04H\x01R\x04size\x88\x01\x01B\x06\n" +
+	"\x04size\x18\x02 \x01(\x04H\x01R\x04size\x88\x01\x01\x12\x18\n" +
+	"\aresumes\x18\x03 \x01(\rR\aresumesB\x06\n" +
 	"\x04_errB\a\n" +
 	"\x05_size\"\xae\x01\n" +
 	"\x19BucketObjectGetAttrsStart\x12\x16\n" +
@@ -5550,6 +6756,52 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\b_version\"T\n" +
 	"\x16BucketDeleteObjectsEnd\x122\n" +
 	"\x03err\x18\x01 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01B\x06\n" +
+	"\x04_err\"\xc1\x01\n" +
+	"\x15BucketObjectCopyStart\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x10\n" +
+	"\x03src\x18\x02 \x01(\tR\x03src\x12\x10\n" +
+	"\x03dst\x18\x03 \x01(\tR\x03dst\x12$\n" +
+	"\vsrc_version\x18\x04 \x01(\tH\x00R\n" +
+	"srcVersion\x88\x01\x01\x126\n" +
+	"\x05stack\x18\x05 \x01(\v2 .encore.engine.trace2.StackTraceR\x05stackB\x0e\n" +
+	"\f_src_version\"\x9e\x01\n" +
+	"\x13BucketObjectCopyEnd\x122\n" +
+	"\x03err\x18\x01 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01\x12\x17\n" +
+	"\x04size\x18\x02 \x01(\x04H\x01R\x04size\x88\x01\x01\x12\x1d\n" +
+	"\aversion\x18\x03 \x01(\tH\x02R\aversion\x88\x01\x01B\x06\n" +
+	"\x04_errB\a\n" +
+	"\x05_sizeB\n" +
+	"\n" +
+	"\b_version\"\xc9\x02\n" +
+	"\x1cBucketObjectUpdateAttrsStart\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12&\n" +
+	"\fcontent_type\x18\x03 \x01(\tH\x00R\vcontentType\x88\x01\x01\x12'\n" +
+	"\x0fupdate_metadata\x18\x04 \x01(\bR\x0eupdateMetadata\x12#\n" +
+	"\rmetadata_keys\x18\x05 \x03(\tR\fmetadataKeys\x12\x1f\n" +
+	"\vupdate_tags\x18\x06 \x01(\bR\n" +
+	"updateTags\x12\x19\n" +
+	"\btag_keys\x18\a \x03(\tR\atagKeys\x126\n" +
+	"\x05stack\x18\b \x01(\v2 .encore.engine.trace2.StackTraceR\x05stackB\x0f\n" +
+	"\r_content_type\"\xab\x01\n" +
+	"\x1aBucketObjectUpdateAttrsEnd\x122\n" +
+	"\x03err\x18\x01 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x00R\x03err\x88\x01\x01\x12G\n" +
+	"\x05attrs\x18\x02 \x01(\v2,.encore.engine.trace2.BucketObjectAttributesH\x01R\x05attrs\x88\x01\x01B\x06\n" +
+	"\x04_errB\b\n" +
+	"\x06_attrs\"\x81\x03\n" +
+	"\x0fBucketSignedURL\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12M\n" +
+	"\toperation\x18\x03 \x01(\x0e2/.encore.engine.trace2.BucketSignedURL.OperationR\toperation\x12\x1b\n" +
+	"\tttl_nanos\x18\x04 \x01(\x04R\bttlNanos\x12&\n" +
+	"\fcontent_type\x18\x05 \x01(\tH\x00R\vcontentType\x88\x01\x01\x122\n" +
+	"\x03err\x18\x06 \x01(\v2\x1b.encore.engine.trace2.ErrorH\x01R\x03err\x88\x01\x01\x126\n" +
+	"\x05stack\x18\a \x01(\v2 .encore.engine.trace2.StackTraceR\x05stack\"%\n" +
+	"\tOperation\x12\n" +
+	"\n" +
+	"\x06UPLOAD\x10\x00\x12\f\n" +
+	"\bDOWNLOAD\x10\x01B\x0f\n" +
+	"\r_content_typeB\x06\n" +
 	"\x04_err\"\xc0\x01\n" +
 	"\x16BucketObjectAttributes\x12\x17\n" +
 	"\x04size\x18\x01 \x01(\x04H\x00R\x04size\x88\x01\x01\x12\x1d\n" +
@@ -5713,190 +6965,228 @@ func file_encore_engine_trace2_trace2_proto_rawDescGZIP() []byte {
 	return file_encore_engine_trace2_trace2_proto_rawDescData
 }
 
-var file_encore_engine_trace2_trace2_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_encore_engine_trace2_trace2_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
+var file_encore_engine_trace2_trace2_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_encore_engine_trace2_trace2_proto_msgTypes = make([]protoimpl.MessageInfo, 78)
 var file_encore_engine_trace2_trace2_proto_goTypes = []any{
 	(HTTPTraceEventCode)(0),              // 0: encore.engine.trace2.HTTPTraceEventCode
 	(SpanSummary_SpanType)(0),            // 1: encore.engine.trace2.SpanSummary.SpanType
 	(DBTransactionEnd_CompletionType)(0), // 2: encore.engine.trace2.DBTransactionEnd.CompletionType
-	(CacheCallEnd_Result)(0),             // 3: encore.engine.trace2.CacheCallEnd.Result
-	(LogMessage_Level)(0),                // 4: encore.engine.trace2.LogMessage.Level
-	(*SpanSummary)(nil),                  // 5: encore.engine.trace2.SpanSummary
-	(*TraceID)(nil),                      // 6: encore.engine.trace2.TraceID
-	(*EventList)(nil),                    // 7: encore.engine.trace2.EventList
-	(*TraceEvent)(nil),                   // 8: encore.engine.trace2.TraceEvent
-	(*SpanStart)(nil),                    // 9: encore.engine.trace2.SpanStart
-	(*SpanEnd)(nil),                      // 10: encore.engine.trace2.SpanEnd
-	(*RequestSpanStart)(nil),             // 11: encore.engine.trace2.RequestSpanStart
-	(*RequestSpanEnd)(nil),               // 12: encore.engine.trace2.RequestSpanEnd
-	(*AuthSpanStart)(nil),                // 13: encore.engine.trace2.AuthSpanStart
-	(*AuthSpanEnd)(nil),                  // 14: encore.engine.trace2.AuthSpanEnd
-	(*PubsubMessageSpanStart)(nil),       // 15: encore.engine.trace2.PubsubMessageSpanStart
-	(*PubsubMessageSpanEnd)(nil),         // 16: encore.engine.trace2.PubsubMessageSpanEnd
-	(*TestSpanStart)(nil),                // 17: encore.engine.trace2.TestSpanStart
-	(*TestSpanEnd)(nil),                  // 18: encore.engine.trace2.TestSpanEnd
-	(*SpanEvent)(nil),                    // 19: encore.engine.trace2.SpanEvent
-	(*RPCCallStart)(nil),                 // 20: encore.engine.trace2.RPCCallStart
-	(*RPCCallEnd)(nil),                   // 21: encore.engine.trace2.RPCCallEnd
-	(*GoroutineStart)(nil),               // 22: encore.engine.trace2.GoroutineStart
-	(*GoroutineEnd)(nil),                 // 23: encore.engine.trace2.GoroutineEnd
-	(*DBTransactionStart)(nil),           // 24: encore.engine.trace2.DBTransactionStart
-	(*DBTransactionEnd)(nil),             // 25: encore.engine.trace2.DBTransactionEnd
-	(*DBQueryStart)(nil),                 // 26: encore.engine.trace2.DBQueryStart
-	(*DBQueryEnd)(nil),                   // 27: encore.engine.trace2.DBQueryEnd
-	(*PubsubPublishStart)(nil),           // 28: encore.engine.trace2.PubsubPublishStart
-	(*PubsubPublishEnd)(nil),             // 29: encore.engine.trace2.PubsubPublishEnd
-	(*ServiceInitStart)(nil),             // 30: encore.engine.trace2.ServiceInitStart
-	(*ServiceInitEnd)(nil),               // 31: encore.engine.trace2.ServiceInitEnd
-	(*CacheCallStart)(nil),               // 32: encore.engine.trace2.CacheCallStart
-	(*CacheCallEnd)(nil),                 // 33: encore.engine.trace2.CacheCallEnd
-	(*BucketObjectUploadStart)(nil),      // 34: encore.engine.trace2.BucketObjectUploadStart
-	(*BucketObjectUploadEnd)(nil),        // 35: encore.engine.trace2.BucketObjectUploadEnd
-	(*BucketObjectDownloadStart)(nil),    // 36: encore.engine.trace2.BucketObjectDownloadStart
-	(*BucketObjectDownloadEnd)(nil),      // 37: encore.engine.trace2.BucketObjectDownloadEnd
-	(*BucketObjectGetAttrsStart)(nil),    // 38: encore.engine.trace2.BucketObjectGetAttrsStart
-	(*BucketObjectGetAttrsEnd)(nil),      // 39: encore.engine.trace2.BucketObjectGetAttrsEnd
-	(*BucketListObjectsStart)(nil),       // 40: encore.engine.trace2.BucketListObjectsStart
-	(*BucketListObjectsEnd)(nil),         // 41: encore.engine.trace2.BucketListObjectsEnd
-	(*BucketDeleteObjectsStart)(nil),     // 42: encore.engine.trace2.BucketDeleteObjectsStart
-	(*BucketDeleteObjectEntry)(nil),      // 43: encore.engine.trace2.BucketDeleteObjectEntry
-	(*BucketDeleteObjectsEnd)(nil),       // 44: encore.engine.trace2.BucketDeleteObjectsEnd
-	(*BucketObjectAttributes)(nil),       // 45: encore.engine.trace2.BucketObjectAttributes
-	(*BodyStream)(nil),                   // 46: encore.engine.trace2.BodyStream
-	(*HTTPCallStart)(nil),                // 47: encore.engine.trace2.HTTPCallStart
-	(*HTTPCallEnd)(nil),                  // 48: encore.engine.trace2.HTTPCallEnd
-	(*HTTPTraceEvent)(nil),               // 49: encore.engine.trace2.HTTPTraceEvent
-	(*HTTPGetConn)(nil),                  // 50: encore.engine.trace2.HTTPGetConn
-	(*HTTPGotConn)(nil),                  // 51: encore.engine.trace2.HTTPGotConn
-	(*HTTPGotFirstResponseByte)(nil),     // 52: encore.engine.trace2.HTTPGotFirstResponseByte
-	(*HTTPGot1XxResponse)(nil),           // 53: encore.engine.trace2.HTTPGot1xxResponse
-	(*HTTPDNSStart)(nil),                 // 54: encore.engine.trace2.HTTPDNSStart
-	(*HTTPDNSDone)(nil),                  // 55: encore.engine.trace2.HTTPDNSDone
-	(*DNSAddr)(nil),                      // 56: encore.engine.trace2.DNSAddr
-	(*HTTPConnectStart)(nil),             // 57: encore.engine.trace2.HTTPConnectStart
-	(*HTTPConnectDone)(nil),              // 58: encore.engine.trace2.HTTPConnectDone
-	(*HTTPTLSHandshakeStart)(nil),        // 59: encore.engine.trace2.HTTPTLSHandshakeStart
-	(*HTTPTLSHandshakeDone)(nil),         // 60: encore.engine.trace2.HTTPTLSHandshakeDone
-	(*HTTPWroteHeaders)(nil),             // 61: encore.engine.trace2.HTTPWroteHeaders
-	(*HTTPWroteRequest)(nil),             // 62: encore.engine.trace2.HTTPWroteRequest
-	(*HTTPWait100Continue)(nil),          // 63: encore.engine.trace2.HTTPWait100Continue
-	(*HTTPClosedBodyData)(nil),           // 64: encore.engine.trace2.HTTPClosedBodyData
-	(*LogMessage)(nil),                   // 65: encore.engine.trace2.LogMessage
-	(*LogField)(nil),                     // 66: encore.engine.trace2.LogField
-	(*StackTrace)(nil),                   // 67: encore.engine.trace2.StackTrace
-	(*StackFrame)(nil),                   // 68: encore.engine.trace2.StackFrame
-	(*Error)(nil),                        // 69: encore.engine.trace2.Error
-	nil,                                  // 70: encore.engine.trace2.RequestSpanStart.RequestHeadersEntry
-	nil,                                  // 71: encore.engine.trace2.RequestSpanEnd.ResponseHeadersEntry
-	(*timestamppb.Timestamp)(nil),        // 72: google.protobuf.Timestamp
+	(StreamMessage_Direction)(0),         // 3: encore.engine.trace2.StreamMessage.Direction
+	(CacheCallEnd_Result)(0),             // 4: encore.engine.trace2.CacheCallEnd.Result
+	(BucketSignedURL_Operation)(0),       // 5: encore.engine.trace2.BucketSignedURL.Operation
+	(LogMessage_Level)(0),                // 6: encore.engine.trace2.LogMessage.Level
+	(*SpanSummary)(nil),                  // 7: encore.engine.trace2.SpanSummary
+	(*TraceID)(nil),                      // 8: encore.engine.trace2.TraceID
+	(*EventList)(nil),                    // 9: encore.engine.trace2.EventList
+	(*TraceEvent)(nil),                   // 10: encore.engine.trace2.TraceEvent
+	(*SpanStart)(nil),                    // 11: encore.engine.trace2.SpanStart
+	(*SpanEnd)(nil),                      // 12: encore.engine.trace2.SpanEnd
+	(*SpanMemStats)(nil),                 // 13: encore.engine.trace2.SpanMemStats
+	(*RequestSpanStart)(nil),             // 14: encore.engine.trace2.RequestSpanStart
+	(*RequestSpanEnd)(nil),               // 15: encore.engine.trace2.RequestSpanEnd
+	(*AuthSpanStart)(nil),                // 16: encore.engine.trace2.AuthSpanStart
+	(*AuthSpanEnd)(nil),                  // 17: encore.engine.trace2.AuthSpanEnd
+	(*PubsubMessageSpanStart)(nil),       // 18: encore.engine.trace2.PubsubMessageSpanStart
+	(*PubsubMessageSpanEnd)(nil),         // 19: encore.engine.trace2.PubsubMessageSpanEnd
+	(*TestSpanStart)(nil),                // 20: encore.engine.trace2.TestSpanStart
+	(*TestSpanEnd)(nil),                  // 21: encore.engine.trace2.TestSpanEnd
+	(*SpanEvent)(nil),                    // 22: encore.engine.trace2.SpanEvent
+	(*RPCCallStart)(nil),                 // 23: encore.engine.trace2.RPCCallStart
+	(*RPCCallEnd)(nil),                   // 24: encore.engine.trace2.RPCCallEnd
+	(*GoroutineStart)(nil),               // 25: encore.engine.trace2.GoroutineStart
+	(*GoroutineEnd)(nil),                 // 26: encore.engine.trace2.GoroutineEnd
+	(*DBTransactionStart)(nil),           // 27: encore.engine.trace2.DBTransactionStart
+	(*DBTransactionEnd)(nil),             // 28: encore.engine.trace2.DBTransactionEnd
+	(*DBQueryStart)(nil),                 // 29: encore.engine.trace2.DBQueryStart
+	(*DBQueryEnd)(nil),                   // 30: encore.engine.trace2.DBQueryEnd
+	(*PubsubPublishStart)(nil),           // 31: encore.engine.trace2.PubsubPublishStart
+	(*PubsubPublishEnd)(nil),             // 32: encore.engine.trace2.PubsubPublishEnd
+	(*PubsubPublishResult)(nil),          // 33: encore.engine.trace2.PubsubPublishResult
+	(*PubsubMessageLink)(nil),            // 34: encore.engine.trace2.PubsubMessageLink
+	(*StreamMessage)(nil),                // 35: encore.engine.trace2.StreamMessage
+	(*ServiceInitStart)(nil),             // 36: encore.engine.trace2.ServiceInitStart
+	(*ServiceInitEnd)(nil),               // 37: encore.engine.trace2.ServiceInitEnd
+	(*CacheCallStart)(nil),               // 38: encore.engine.trace2.CacheCallStart
+	(*CacheCallEnd)(nil),                 // 39: encore.engine.trace2.CacheCallEnd
+	(*CacheOpResult)(nil),                // 40: encore.engine.trace2.CacheOpResult
+	(*BucketObjectUploadStart)(nil),      // 41: encore.engine.trace2.BucketObjectUploadStart
+	(*BucketObjectUploadEnd)(nil),        // 42: encore.engine.trace2.BucketObjectUploadEnd
+	(*BucketObjectUploadPart)(nil),       // 43: encore.engine.trace2.BucketObjectUploadPart
+	(*BucketObjectDownloadStart)(nil),    // 44: encore.engine.trace2.BucketObjectDownloadStart
+	(*BucketObjectDownloadEnd)(nil),      // 45: encore.engine.trace2.BucketObjectDownloadEnd
+	(*BucketObjectGetAttrsStart)(nil),    // 46: encore.engine.trace2.BucketObjectGetAttrsStart
+	(*BucketObjectGetAttrsEnd)(nil),      // 47: encore.engine.trace2.BucketObjectGetAttrsEnd
+	(*BucketListObjectsStart)(nil),       // 48: encore.engine.trace2.BucketListObjectsStart
+	(*BucketListObjectsEnd)(nil),         // 49: encore.engine.trace2.BucketListObjectsEnd
+	(*BucketDeleteObjectsStart)(nil),     // 50: encore.engine.trace2.BucketDeleteObjectsStart
+	(*BucketDeleteObjectEntry)(nil),      // 51: encore.engine.trace2.BucketDeleteObjectEntry
+	(*BucketDeleteObjectsEnd)(nil),       // 52: encore.engine.trace2.BucketDeleteObjectsEnd
+	(*BucketObjectCopyStart)(nil),        // 53: encore.engine.trace2.BucketObjectCopyStart
+	(*BucketObjectCopyEnd)(nil),          // 54: encore.engine.trace2.BucketObjectCopyEnd
+	(*BucketObjectUpdateAttrsStart)(nil), // 55: encore.engine.trace2.BucketObjectUpdateAttrsStart
+	(*BucketObjectUpdateAttrsEnd)(nil),   // 56: encore.engine.trace2.BucketObjectUpdateAttrsEnd
+	(*BucketSignedURL)(nil),              // 57: encore.engine.trace2.BucketSignedURL
+	(*BucketObjectAttributes)(nil),       // 58: encore.engine.trace2.BucketObjectAttributes
+	(*BodyStream)(nil),                   // 59: encore.engine.trace2.BodyStream
+	(*HTTPCallStart)(nil),                // 60: encore.engine.trace2.HTTPCallStart
+	(*HTTPCallEnd)(nil),                  // 61: encore.engine.trace2.HTTPCallEnd
+	(*HTTPTraceEvent)(nil),               // 62: encore.engine.trace2.HTTPTraceEvent
+	(*HTTPGetConn)(nil),                  // 63: encore.engine.trace2.HTTPGetConn
+	(*HTTPGotConn)(nil),                  // 64: encore.engine.trace2.HTTPGotConn
+	(*HTTPGotFirstResponseByte)(nil),     // 65: encore.engine.trace2.HTTPGotFirstResponseByte
+	(*HTTPGot1XxResponse)(nil),           // 66: encore.engine.trace2.HTTPGot1xxResponse
+	(*HTTPDNSStart)(nil),                 // 67: encore.engine.trace2.HTTPDNSStart
+	(*HTTPDNSDone)(nil),                  // 68: encore.engine.trace2.HTTPDNSDone
+	(*DNSAddr)(nil),                      // 69: encore.engine.trace2.DNSAddr
+	(*HTTPConnectStart)(nil),             // 70: encore.engine.trace2.HTTPConnectStart
+	(*HTTPConnectDone)(nil),              // 71: encore.engine.trace2.HTTPConnectDone
+	(*HTTPTLSHandshakeStart)(nil),        // 72: encore.engine.trace2.HTTPTLSHandshakeStart
+	(*HTTPTLSHandshakeDone)(nil),         // 73: encore.engine.trace2.HTTPTLSHandshakeDone
+	(*HTTPWroteHeaders)(nil),             // 74: encore.engine.trace2.HTTPWroteHeaders
+	(*HTTPWroteRequest)(nil),             // 75: encore.engine.trace2.HTTPWroteRequest
+	(*HTTPWait100Continue)(nil),          // 76: encore.engine.trace2.HTTPWait100Continue
+	(*HTTPClosedBodyData)(nil),           // 77: encore.engine.trace2.HTTPClosedBodyData
+	(*LogMessage)(nil),                   // 78: encore.engine.trace2.LogMessage
+	(*LogField)(nil),                     // 79: encore.engine.trace2.LogField
+	(*StackTrace)(nil),                   // 80: encore.engine.trace2.StackTrace
+	(*StackFrame)(nil),                   // 81: encore.engine.trace2.StackFrame
+	(*Error)(nil),                        // 82: encore.engine.trace2.Error
+	nil,                                  // 83: encore.engine.trace2.RequestSpanStart.RequestHeadersEntry
+	nil,                                  // 84: encore.engine.trace2.RequestSpanEnd.ResponseHeadersEntry
+	(*timestamppb.Timestamp)(nil),        // 85: google.protobuf.Timestamp
 }
 var file_encore_engine_trace2_trace2_proto_depIdxs = []int32{
 	1,   // 0: encore.engine.trace2.SpanSummary.type:type_name -> encore.engine.trace2.SpanSummary.SpanType
-	72,  // 1: encore.engine.trace2.SpanSummary.started_at:type_name -> google.protobuf.Timestamp
-	8,   // 2: encore.engine.trace2.EventList.events:type_name -> encore.engine.trace2.TraceEvent
-	6,   // 3: encore.engine.trace2.TraceEvent.trace_id:type_name -> encore.engine.trace2.TraceID
-	72,  // 4: encore.engine.trace2.TraceEvent.event_time:type_name -> google.protobuf.Timestamp
-	9,   // 5: encore.engine.trace2.TraceEvent.span_start:type_name -> encore.engine.trace2.SpanStart
-	10,  // 6: encore.engine.trace2.TraceEvent.span_end:type_name -> encore.engine.trace2.SpanEnd
-	19,  // 7: encore.engine.trace2.TraceEvent.span_event:type_name -> encore.engine.trace2.SpanEvent
-	6,   // 8: encore.engine.trace2.SpanStart.parent_trace_id:type_name -> encore.engine.trace2.TraceID
-	11,  // 9: encore.engine.trace2.SpanStart.request:type_name -> encore.engine.trace2.RequestSpanStart
-	13,  // 10: encore.engine.trace2.SpanStart.auth:type_name -> encore.engine.trace2.AuthSpanStart
-	15,  // 11: encore.engine.trace2.SpanStart.pubsub_message:type_name -> encore.engine.trace2.PubsubMessageSpanStart
-	17,  // 12: encore.engine.trace2.SpanStart.test:type_name -> encore.engine.trace2.TestSpanStart
-	69,  // 13: encore.engine.trace2.SpanEnd.error:type_name -> encore.engine.trace2.Error
-	67,  // 14: encore.engine.trace2.SpanEnd.panic_stack:type_name -> encore.engine.trace2.StackTrace
-	6,   // 15: encore.engine.trace2.SpanEnd.parent_trace_id:type_name -> encore.engine.trace2.TraceID
-	12,  // 16: encore.engine.trace2.SpanEnd.request:type_name -> encore.engine.trace2.RequestSpanEnd
-	14,  // 17: encore.engine.trace2.SpanEnd.auth:type_name -> encore.engine.trace2.AuthSpanEnd
-	16,  // 18: encore.engine.trace2.SpanEnd.pubsub_message:type_name -> encore.engine.trace2.PubsubMessageSpanEnd
-	18,  // 19: encore.engine.trace2.SpanEnd.test:type_name -> encore.engine.trace2.TestSpanEnd
-	70,  // 20: encore.engine.trace2.RequestSpanStart.request_headers:type_name -> encore.engine.trace2.RequestSpanStart.RequestHeadersEntry
-	71,  // 21: encore.engine.trace2.RequestSpanEnd.response_headers:type_name -> encore.engine.trace2.RequestSpanEnd.ResponseHeadersEntry
-	72,  // 22: encore.engine.trace2.PubsubMessageSpanStart.publish_time:type_name -> google.protobuf.Timestamp
-	65,  // 23: encore.engine.trace2.SpanEvent.log_message:type_name -> encore.engine.trace2.LogMessage
-	46,  // 24: encore.engine.trace2.SpanEvent.body_stream:type_name -> encore.engine.trace2.BodyStream
-	20,  // 25: encore.engine.trace2.SpanEvent.rpc_call_start:type_name -> encore.engine.trace2.RPCCallStart
-	21,  // 26: encore.engine.trace2.SpanEvent.rpc_call_end:type_name -> encore.engine.trace2.RPCCallEnd
-	24,  // 27: encore.engine.trace2.SpanEvent.db_transaction_start:type_name -> encore.engine.trace2.DBTransactionStart
-	25,  // 28: encore.engine.trace2.SpanEvent.db_transaction_end:type_name -> encore.engine.trace2.DBTransactionEnd
-	26,  // 29: encore.engine.trace2.SpanEvent.db_query_start:type_name -> encore.engine.trace2.DBQueryStart
-	27,  // 30: encore.engine.trace2.SpanEvent.db_query_end:type_name -> encore.engine.trace2.DBQueryEnd
-	47,  // 31: encore.engine.trace2.SpanEvent.http_call_start:type_name -> encore.engine.trace2.HTTPCallStart
-	48,  // 32: encore.engine.trace2.SpanEvent.http_call_end:type_name -> encore.engine.trace2.HTTPCallEnd
-	28,  // 33: encore.engine.trace2.SpanEvent.pubsub_publish_start:type_name -> encore.engine.trace2.PubsubPublishStart
-	29,  // 34: encore.engine.trace2.SpanEvent.pubsub_publish_end:type_name -> encore.engine.trace2.PubsubPublishEnd
-	32,  // 35: encore.engine.trace2.SpanEvent.cache_call_start:type_name -> encore.engine.trace2.CacheCallStart
-	33,  // 36: encore.engine.trace2.SpanEvent.cache_call_end:type_name -> encore.engine.trace2.CacheCallEnd
-	30,  // 37: encore.engine.trace2.SpanEvent.service_init_start:type_name -> encore.engine.trace2.ServiceInitStart
-	31,  // 38: encore.engine.trace2.SpanEvent.service_init_end:type_name -> encore.engine.trace2.ServiceInitEnd
-	34,  // 39: encore.engine.trace2.SpanEvent.bucket_object_upload_start:type_name -> encore.engine.trace2.BucketObjectUploadStart
-	35,  // 40: encore.engine.trace2.SpanEvent.bucket_object_upload_end:type_name -> encore.engine.trace2.BucketObjectUploadEnd
-	36,  // 41: encore.engine.trace2.SpanEvent.bucket_object_download_start:type_name -> encore.engine.trace2.BucketObjectDownloadStart
-	37,  // 42: encore.engine.trace2.SpanEvent.bucket_object_download_end:type_name -> encore.engine.trace2.BucketObjectDownloadEnd
-	38,  // 43: encore.engine.trace2.SpanEvent.bucket_object_get_attrs_start:type_name -> encore.engine.trace2.BucketObjectGetAttrsStart
-	39,  // 44: encore.engine.trace2.SpanEvent.bucket_object_get_attrs_end:type_name -> encore.engine.trace2.BucketObjectGetAttrsEnd
-	40,  // 45: encore.engine.trace2.SpanEvent.bucket_list_objects_start:type_name -> encore.engine.trace2.BucketListObjectsStart
-	41,  // 46: encore.engine.trace2.SpanEvent.bucket_list_objects_end:type_name -> encore.engine.trace2.BucketListObjectsEnd
-	42,  // 47: encore.engine.trace2.SpanEvent.bucket_delete_objects_start:type_name -> encore.engine.trace2.BucketDeleteObjectsStart
-	44,  // 48: encore.engine.trace2.SpanEvent.bucket_delete_objects_end:type_name -> encore.engine.trace2.BucketDeleteObjectsEnd
-	67,  // 49: encore.engine.trace2.RPCCallStart.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 50: encore.engine.trace2.RPCCallEnd.err:type_name -> encore.engine.trace2.Error
-	67,  // 51: encore.engine.trace2.DBTransactionStart.stack:type_name -> encore.engine.trace2.StackTrace
-	2,   // 52: encore.engine.trace2.DBTransactionEnd.completion:type_name -> encore.engine.trace2.DBTransactionEnd.CompletionType
-	67,  // 53: encore.engine.trace2.DBTransactionEnd.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 54: encore.engine.trace2.DBTransactionEnd.err:type_name -> encore.engine.trace2.Error
-	67,  // 55: encore.engine.trace2.DBQueryStart.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 56: encore.engine.trace2.DBQueryEnd.err:type_name -> encore.engine.trace2.Error
-	67,  // 57: encore.engine.trace2.PubsubPublishStart.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 58: encore.engine.trace2.PubsubPublishEnd.err:type_name -> encore.engine.trace2.Error
-	69,  // 59: encore.engine.trace2.ServiceInitEnd.err:type_name -> encore.engine.trace2.Error
-	67,  // 60: encore.engine.trace2.CacheCallStart.stack:type_name -> encore.engine.trace2.StackTrace
-	3,   // 61: encore.engine.trace2.CacheCallEnd.result:type_name -> encore.engine.trace2.CacheCallEnd.Result
-	69,  // 62: encore.engine.trace2.CacheCallEnd.err:type_name -> encore.engine.trace2.Error
-	45,  // 63: encore.engine.trace2.BucketObjectUploadStart.attrs:type_name -> encore.engine.trace2.BucketObjectAttributes
-	67,  // 64: encore.engine.trace2.BucketObjectUploadStart.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 65: encore.engine.trace2.BucketObjectUploadEnd.err:type_name -> encore.engine.trace2.Error
-	67,  // 66: encore.engine.trace2.BucketObjectDownloadStart.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 67: encore.engine.trace2.BucketObjectDownloadEnd.err:type_name -> encore.engine.trace2.Error
-	67,  // 68: encore.engine.trace2.BucketObjectGetAttrsStart.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 69: encore.engine.trace2.BucketObjectGetAttrsEnd.err:type_name -> encore.engine.trace2.Error
-	45,  // 70: encore.engine.trace2.BucketObjectGetAttrsEnd.attrs:type_name -> encore.engine.trace2.BucketObjectAttributes
-	67,  // 71: encore.engine.trace2.BucketListObjectsStart.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 72: encore.engine.trace2.BucketListObjectsEnd.err:type_name -> encore.engine.trace2.Error
-	67,  // 73: encore.engine.trace2.BucketDeleteObjectsStart.stack:type_name -> encore.engine.trace2.StackTrace
-	43,  // 74: encore.engine.trace2.BucketDeleteObjectsStart.entries:type_name -> encore.engine.trace2.BucketDeleteObjectEntry
-	69,  // 75: encore.engine.trace2.BucketDeleteObjectsEnd.err:type_name -> encore.engine.trace2.Error
-	67,  // 76: encore.engine.trace2.HTTPCallStart.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 77: encore.engine.trace2.HTTPCallEnd.err:type_name -> encore.engine.trace2.Error
-	49,  // 78: encore.engine.trace2.HTTPCallEnd.trace_events:type_name -> encore.engine.trace2.HTTPTraceEvent
-	50,  // 79: encore.engine.trace2.HTTPTraceEvent.get_conn:type_name -> encore.engine.trace2.HTTPGetConn
-	51,  // 80: encore.engine.trace2.HTTPTraceEvent.got_conn:type_name -> encore.engine.trace2.HTTPGotConn
-	52,  // 81: encore.engine.trace2.HTTPTraceEvent.got_first_response_byte:type_name -> encore.engine.trace2.HTTPGotFirstResponseByte
-	53,  // 82: encore.engine.trace2.HTTPTraceEvent.got_1xx_response:type_name -> encore.engine.trace2.HTTPGot1xxResponse
-	54,  // 83: encore.engine.trace2.HTTPTraceEvent.dns_start:type_name -> encore.engine.trace2.HTTPDNSStart
-	55,  // 84: encore.engine.trace2.HTTPTraceEvent.dns_done:type_name -> encore.engine.trace2.HTTPDNSDone
-	57,  // 85: encore.engine.trace2.HTTPTraceEvent.connect_start:type_name -> encore.engine.trace2.HTTPConnectStart
-	58,  // 86: encore.engine.trace2.HTTPTraceEvent.connect_done:type_name -> encore.engine.trace2.HTTPConnectDone
-	59,  // 87: encore.engine.trace2.HTTPTraceEvent.tls_handshake_start:type_name -> encore.engine.trace2.HTTPTLSHandshakeStart
-	60,  // 88: encore.engine.trace2.HTTPTraceEvent.tls_handshake_done:type_name -> encore.engine.trace2.HTTPTLSHandshakeDone
-	61,  // 89: encore.engine.trace2.HTTPTraceEvent.wrote_headers:type_name -> encore.engine.trace2.HTTPWroteHeaders
-	62,  // 90: encore.engine.trace2.HTTPTraceEvent.wrote_request:type_name -> encore.engine.trace2.HTTPWroteRequest
-	63,  // 91: encore.engine.trace2.HTTPTraceEvent.wait_100_continue:type_name -> encore.engine.trace2.HTTPWait100Continue
-	64,  // 92: encore.engine.trace2.HTTPTraceEvent.closed_body:type_name -> encore.engine.trace2.HTTPClosedBodyData
-	56,  // 93: encore.engine.trace2.HTTPDNSDone.addrs:type_name -> encore.engine.trace2.DNSAddr
-	4,   // 94: encore.engine.trace2.LogMessage.level:type_name -> encore.engine.trace2.LogMessage.Level
-	66,  // 95: encore.engine.trace2.LogMessage.fields:type_name -> encore.engine.trace2.LogField
-	67,  // 96: encore.engine.trace2.LogMessage.stack:type_name -> encore.engine.trace2.StackTrace
-	69,  // 97: encore.engine.trace2.LogField.error:type_name -> encore.engine.trace2.Error
-	72,  // 98: encore.engine.trace2.LogField.time:type_name -> google.protobuf.Timestamp
-	68,  // 99: encore.engine.trace2.StackTrace.frames:type_name -> encore.engine.trace2.StackFrame
-	67,  // 100: encore.engine.trace2.Error.stack:type_name -> encore.engine.trace2.StackTrace
-	101, // [101:101] is the sub-list for method output_type
-	101, // [101:101] is the sub-list for method input_type
-	101, // [101:101] is the sub-list for extension type_name
-	101, // [101:101] is the sub-list for extension extendee
-	0,   // [0:101] is the sub-list for field type_name
+	85,  // 1: encore.engine.trace2.SpanSummary.started_at:type_name -> google.protobuf.Timestamp
+	10,  // 2: encore.engine.trace2.EventList.events:type_name -> encore.engine.trace2.TraceEvent
+	8,   // 3: encore.engine.trace2.TraceEvent.trace_id:type_name -> encore.engine.trace2.TraceID
+	85,  // 4: encore.engine.trace2.TraceEvent.event_time:type_name -> google.protobuf.Timestamp
+	11,  // 5: encore.engine.trace2.TraceEvent.span_start:type_name -> encore.engine.trace2.SpanStart
+	12,  // 6: encore.engine.trace2.TraceEvent.span_end:type_name -> encore.engine.trace2.SpanEnd
+	22,  // 7: encore.engine.trace2.TraceEvent.span_event:type_name -> encore.engine.trace2.SpanEvent
+	8,   // 8: encore.engine.trace2.SpanStart.parent_trace_id:type_name -> encore.engine.trace2.TraceID
+	14,  // 9: encore.engine.trace2.SpanStart.request:type_name -> encore.engine.trace2.RequestSpanStart
+	16,  // 10: encore.engine.trace2.SpanStart.auth:type_name -> encore.engine.trace2.AuthSpanStart
+	18,  // 11: encore.engine.trace2.SpanStart.pubsub_message:type_name -> encore.engine.trace2.PubsubMessageSpanStart
+	20,  // 12: encore.engine.trace2.SpanStart.test:type_name -> encore.engine.trace2.TestSpanStart
+	82,  // 13: encore.engine.trace2.SpanEnd.error:type_name -> encore.engine.trace2.Error
+	80,  // 14: encore.engine.trace2.SpanEnd.panic_stack:type_name -> encore.engine.trace2.StackTrace
+	8,   // 15: encore.engine.trace2.SpanEnd.parent_trace_id:type_name -> encore.engine.trace2.TraceID
+	13,  // 16: encore.engine.trace2.SpanEnd.mem_stats:type_name -> encore.engine.trace2.SpanMemStats
+	15,  // 17: encore.engine.trace2.SpanEnd.request:type_name -> encore.engine.trace2.RequestSpanEnd
+	17,  // 18: encore.engine.trace2.SpanEnd.auth:type_name -> encore.engine.trace2.AuthSpanEnd
+	19,  // 19: encore.engine.trace2.SpanEnd.pubsub_message:type_name -> encore.engine.trace2.PubsubMessageSpanEnd
+	21,  // 20: encore.engine.trace2.SpanEnd.test:type_name -> encore.engine.trace2.TestSpanEnd
+	83,  // 21: encore.engine.trace2.RequestSpanStart.request_headers:type_name -> encore.engine.trace2.RequestSpanStart.RequestHeadersEntry
+	84,  // 22: encore.engine.trace2.RequestSpanEnd.response_headers:type_name -> encore.engine.trace2.RequestSpanEnd.ResponseHeadersEntry
+	85,  // 23: encore.engine.trace2.PubsubMessageSpanStart.publish_time:type_name -> google.protobuf.Timestamp
+	78,  // 24: encore.engine.trace2.SpanEvent.log_message:type_name -> encore.engine.trace2.LogMessage
+	59,  // 25: encore.engine.trace2.SpanEvent.body_stream:type_name -> encore.engine.trace2.BodyStream
+	23,  // 26: encore.engine.trace2.SpanEvent.rpc_call_start:type_name -> encore.engine.trace2.RPCCallStart
+	24,  // 27: encore.engine.trace2.SpanEvent.rpc_call_end:type_name -> encore.engine.trace2.RPCCallEnd
+	27,  // 28: encore.engine.trace2.SpanEvent.db_transaction_start:type_name -> encore.engine.trace2.DBTransactionStart
+	28,  // 29: encore.engine.trace2.SpanEvent.db_transaction_end:type_name -> encore.engine.trace2.DBTransactionEnd
+	29,  // 30: encore.engine.trace2.SpanEvent.db_query_start:type_name -> encore.engine.trace2.DBQueryStart
+	30,  // 31: encore.engine.trace2.SpanEvent.db_query_end:type_name -> encore.engine.trace2.DBQueryEnd
+	60,  // 32: encore.engine.trace2.SpanEvent.http_call_start:type_name -> encore.engine.trace2.HTTPCallStart
+	61,  // 33: encore.engine.trace2.SpanEvent.http_call_end:type_name -> encore.engine.trace2.HTTPCallEnd
+	31,  // 34: encore.engine.trace2.SpanEvent.pubsub_publish_start:type_name -> encore.engine.trace2.PubsubPublishStart
+	32,  // 35: encore.engine.trace2.SpanEvent.pubsub_publish_end:type_name -> encore.engine.trace2.PubsubPublishEnd
+	38,  // 36: encore.engine.trace2.SpanEvent.cache_call_start:type_name -> encore.engine.trace2.CacheCallStart
+	39,  // 37: encore.engine.trace2.SpanEvent.cache_call_end:type_name -> encore.engine.trace2.CacheCallEnd
+	36,  // 38: encore.engine.trace2.SpanEvent.service_init_start:type_name -> encore.engine.trace2.ServiceInitStart
+	37,  // 39: encore.engine.trace2.SpanEvent.service_init_end:type_name -> encore.engine.trace2.ServiceInitEnd
+	41,  // 40: encore.engine.trace2.SpanEvent.bucket_object_upload_start:type_name -> encore.engine.trace2.BucketObjectUploadStart
+	42,  // 41: encore.engine.trace2.SpanEvent.bucket_object_upload_end:type_name -> encore.engine.trace2.BucketObjectUploadEnd
+	44,  // 42: encore.engine.trace2.SpanEvent.bucket_object_download_start:type_name -> encore.engine.trace2.BucketObjectDownloadStart
+	45,  // 43: encore.engine.trace2.SpanEvent.bucket_object_download_end:type_name -> encore.engine.trace2.BucketObjectDownloadEnd
+	46,  // 44: encore.engine.trace2.SpanEvent.bucket_object_get_attrs_start:type_name -> encore.engine.trace2.BucketObjectGetAttrsStart
+	47,  // 45: encore.engine.trace2.SpanEvent.bucket_object_get_attrs_end:type_name -> encore.engine.trace2.BucketObjectGetAttrsEnd
+	48,  // 46: encore.engine.trace2.SpanEvent.bucket_list_objects_start:type_name -> encore.engine.trace2.BucketListObjectsStart
+	49,  // 47: encore.engine.trace2.SpanEvent.bucket_list_objects_end:type_name -> encore.engine.trace2.BucketListObjectsEnd
+	50,  // 48: encore.engine.trace2.SpanEvent.bucket_delete_objects_start:type_name -> encore.engine.trace2.BucketDeleteObjectsStart
+	52,  // 49: encore.engine.trace2.SpanEvent.bucket_delete_objects_end:type_name -> encore.engine.trace2.BucketDeleteObjectsEnd
+	57,  // 50: encore.engine.trace2.SpanEvent.bucket_signed_url:type_name -> encore.engine.trace2.BucketSignedURL
+	53,  // 51: encore.engine.trace2.SpanEvent.bucket_object_copy_start:type_name -> encore.engine.trace2.BucketObjectCopyStart
+	54,  // 52: encore.engine.trace2.SpanEvent.bucket_object_copy_end:type_name -> encore.engine.trace2.BucketObjectCopyEnd
+	43,  // 53: encore.engine.trace2.SpanEvent.bucket_object_upload_part:type_name -> encore.engine.trace2.BucketObjectUploadPart
+	34,  // 54: encore.engine.trace2.SpanEvent.pubsub_message_link:type_name -> encore.engine.trace2.PubsubMessageLink
+	35,  // 55: encore.engine.trace2.SpanEvent.stream_message:type_name -> encore.engine.trace2.StreamMessage
+	55,  // 56: encore.engine.trace2.SpanEvent.bucket_object_update_attrs_start:type_name -> encore.engine.trace2.BucketObjectUpdateAttrsStart
+	56,  // 57: encore.engine.trace2.SpanEvent.bucket_object_update_attrs_end:type_name -> encore.engine.trace2.BucketObjectUpdateAttrsEnd
+	80,  // 58: encore.engine.trace2.RPCCallStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 59: encore.engine.trace2.RPCCallEnd.err:type_name -> encore.engine.trace2.Error
+	80,  // 60: encore.engine.trace2.DBTransactionStart.stack:type_name -> encore.engine.trace2.StackTrace
+	2,   // 61: encore.engine.trace2.DBTransactionEnd.completion:type_name -> encore.engine.trace2.DBTransactionEnd.CompletionType
+	80,  // 62: encore.engine.trace2.DBTransactionEnd.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 63: encore.engine.trace2.DBTransactionEnd.err:type_name -> encore.engine.trace2.Error
+	80,  // 64: encore.engine.trace2.DBQueryStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 65: encore.engine.trace2.DBQueryEnd.err:type_name -> encore.engine.trace2.Error
+	80,  // 66: encore.engine.trace2.PubsubPublishStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 67: encore.engine.trace2.PubsubPublishEnd.err:type_name -> encore.engine.trace2.Error
+	33,  // 68: encore.engine.trace2.PubsubPublishEnd.batch_results:type_name -> encore.engine.trace2.PubsubPublishResult
+	82,  // 69: encore.engine.trace2.PubsubPublishResult.err:type_name -> encore.engine.trace2.Error
+	8,   // 70: encore.engine.trace2.PubsubMessageLink.trace_id:type_name -> encore.engine.trace2.TraceID
+	3,   // 71: encore.engine.trace2.StreamMessage.direction:type_name -> encore.engine.trace2.StreamMessage.Direction
+	82,  // 72: encore.engine.trace2.ServiceInitEnd.err:type_name -> encore.engine.trace2.Error
+	80,  // 73: encore.engine.trace2.CacheCallStart.stack:type_name -> encore.engine.trace2.StackTrace
+	4,   // 74: encore.engine.trace2.CacheCallEnd.result:type_name -> encore.engine.trace2.CacheCallEnd.Result
+	82,  // 75: encore.engine.trace2.CacheCallEnd.err:type_name -> encore.engine.trace2.Error
+	40,  // 76: encore.engine.trace2.CacheCallEnd.op_results:type_name -> encore.engine.trace2.CacheOpResult
+	4,   // 77: encore.engine.trace2.CacheOpResult.result:type_name -> encore.engine.trace2.CacheCallEnd.Result
+	82,  // 78: encore.engine.trace2.CacheOpResult.err:type_name -> encore.engine.trace2.Error
+	58,  // 79: encore.engine.trace2.BucketObjectUploadStart.attrs:type_name -> encore.engine.trace2.BucketObjectAttributes
+	80,  // 80: encore.engine.trace2.BucketObjectUploadStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 81: encore.engine.trace2.BucketObjectUploadEnd.err:type_name -> encore.engine.trace2.Error
+	82,  // 82: encore.engine.trace2.BucketObjectUploadPart.err:type_name -> encore.engine.trace2.Error
+	80,  // 83: encore.engine.trace2.BucketObjectDownloadStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 84: encore.engine.trace2.BucketObjectDownloadEnd.err:type_name -> encore.engine.trace2.Error
+	80,  // 85: encore.engine.trace2.BucketObjectGetAttrsStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 86: encore.engine.trace2.BucketObjectGetAttrsEnd.err:type_name -> encore.engine.trace2.Error
+	58,  // 87: encore.engine.trace2.BucketObjectGetAttrsEnd.attrs:type_name -> encore.engine.trace2.BucketObjectAttributes
+	80,  // 88: encore.engine.trace2.BucketListObjectsStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 89: encore.engine.trace2.BucketListObjectsEnd.err:type_name -> encore.engine.trace2.Error
+	80,  // 90: encore.engine.trace2.BucketDeleteObjectsStart.stack:type_name -> encore.engine.trace2.StackTrace
+	51,  // 91: encore.engine.trace2.BucketDeleteObjectsStart.entries:type_name -> encore.engine.trace2.BucketDeleteObjectEntry
+	82,  // 92: encore.engine.trace2.BucketDeleteObjectsEnd.err:type_name -> encore.engine.trace2.Error
+	80,  // 93: encore.engine.trace2.BucketObjectCopyStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 94: encore.engine.trace2.BucketObjectCopyEnd.err:type_name -> encore.engine.trace2.Error
+	80,  // 95: encore.engine.trace2.BucketObjectUpdateAttrsStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 96: encore.engine.trace2.BucketObjectUpdateAttrsEnd.err:type_name -> encore.engine.trace2.Error
+	58,  // 97: encore.engine.trace2.BucketObjectUpdateAttrsEnd.attrs:type_name -> encore.engine.trace2.BucketObjectAttributes
+	5,   // 98: encore.engine.trace2.BucketSignedURL.operation:type_name -> encore.engine.trace2.BucketSignedURL.Operation
+	82,  // 99: encore.engine.trace2.BucketSignedURL.err:type_name -> encore.engine.trace2.Error
+	80,  // 100: encore.engine.trace2.BucketSignedURL.stack:type_name -> encore.engine.trace2.StackTrace
+	80,  // 101: encore.engine.trace2.HTTPCallStart.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 102: encore.engine.trace2.HTTPCallEnd.err:type_name -> encore.engine.trace2.Error
+	62,  // 103: encore.engine.trace2.HTTPCallEnd.trace_events:type_name -> encore.engine.trace2.HTTPTraceEvent
+	63,  // 104: encore.engine.trace2.HTTPTraceEvent.get_conn:type_name -> encore.engine.trace2.HTTPGetConn
+	64,  // 105: encore.engine.trace2.HTTPTraceEvent.got_conn:type_name -> encore.engine.trace2.HTTPGotConn
+	65,  // 106: encore.engine.trace2.HTTPTraceEvent.got_first_response_byte:type_name -> encore.engine.trace2.HTTPGotFirstResponseByte
+	66,  // 107: encore.engine.trace2.HTTPTraceEvent.got_1xx_response:type_name -> encore.engine.trace2.HTTPGot1xxResponse
+	67,  // 108: encore.engine.trace2.HTTPTraceEvent.dns_start:type_name -> encore.engine.trace2.HTTPDNSStart
+	68,  // 109: encore.engine.trace2.HTTPTraceEvent.dns_done:type_name -> encore.engine.trace2.HTTPDNSDone
+	70,  // 110: encore.engine.trace2.HTTPTraceEvent.connect_start:type_name -> encore.engine.trace2.HTTPConnectStart
+	71,  // 111: encore.engine.trace2.HTTPTraceEvent.connect_done:type_name -> encore.engine.trace2.HTTPConnectDone
+	72,  // 112: encore.engine.trace2.HTTPTraceEvent.tls_handshake_start:type_name -> encore.engine.trace2.HTTPTLSHandshakeStart
+	73,  // 113: encore.engine.trace2.HTTPTraceEvent.tls_handshake_done:type_name -> encore.engine.trace2.HTTPTLSHandshakeDone
+	74,  // 114: encore.engine.trace2.HTTPTraceEvent.wrote_headers:type_name -> encore.engine.trace2.HTTPWroteHeaders
+	75,  // 115: encore.engine.trace2.HTTPTraceEvent.wrote_request:type_name -> encore.engine.trace2.HTTPWroteRequest
+	76,  // 116: encore.engine.trace2.HTTPTraceEvent.wait_100_continue:type_name -> encore.engine.trace2.HTTPWait100Continue
+	77,  // 117: encore.engine.trace2.HTTPTraceEvent.closed_body:type_name -> encore.engine.trace2.HTTPClosedBodyData
+	69,  // 118: encore.engine.trace2.HTTPDNSDone.addrs:type_name -> encore.engine.trace2.DNSAddr
+	6,   // 119: encore.engine.trace2.LogMessage.level:type_name -> encore.engine.trace2.LogMessage.Level
+	79,  // 120: encore.engine.trace2.LogMessage.fields:type_name -> encore.engine.trace2.LogField
+	80,  // 121: encore.engine.trace2.LogMessage.stack:type_name -> encore.engine.trace2.StackTrace
+	82,  // 122: encore.engine.trace2.LogField.error:type_name -> encore.engine.trace2.Error
+	85,  // 123: encore.engine.trace2.LogField.time:type_name -> google.protobuf.Timestamp
+	81,  // 124: encore.engine.trace2.StackTrace.frames:type_name -> encore.engine.trace2.StackFrame
+	80,  // 125: encore.engine.trace2.Error.stack:type_name -> encore.engine.trace2.StackTrace
+	126, // [126:126] is the sub-list for method output_type
+	126, // [126:126] is the sub-list for method input_type
+	126, // [126:126] is the sub-list for extension type_name
+	126, // [126:126] is the sub-list for extension extendee
+	0,   // [0:126] is the sub-list for field type_name
 }
 
 func init() { file_encore_engine_trace2_trace2_proto_init() }
@@ -5922,12 +7212,12 @@ func file_encore_engine_trace2_trace2_proto_init() {
 		(*SpanEnd_PubsubMessage)(nil),
 		(*SpanEnd_Test)(nil),
 	}
-	file_encore_engine_trace2_trace2_proto_msgTypes[6].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[7].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[8].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[9].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[10].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[14].OneofWrappers = []any{
+	file_encore_engine_trace2_trace2_proto_msgTypes[11].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[15].OneofWrappers = []any{
 		(*SpanEvent_LogMessage)(nil),
 		(*SpanEvent_BodyStream)(nil),
 		(*SpanEvent_RpcCallStart)(nil),
@@ -5954,25 +7244,42 @@ func file_encore_engine_trace2_trace2_proto_init() {
 		(*SpanEvent_BucketListObjectsEnd)(nil),
 		(*SpanEvent_BucketDeleteObjectsStart)(nil),
 		(*SpanEvent_BucketDeleteObjectsEnd)(nil),
-	}
-	file_encore_engine_trace2_trace2_proto_msgTypes[16].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[20].OneofWrappers = []any{}
+		(*SpanEvent_BucketSignedUrl)(nil),
+		(*SpanEvent_BucketObjectCopyStart)(nil),
+		(*SpanEvent_BucketObjectCopyEnd)(nil),
+		(*SpanEvent_BucketObjectUploadPart)(nil),
+		(*SpanEvent_PubsubMessageLink)(nil),
+		(*SpanEvent_StreamMessage)(nil),
+		(*SpanEvent_BucketObjectUpdateAttrsStart)(nil),
+		(*SpanEvent_BucketObjectUpdateAttrsEnd)(nil),
+	}
+	file_encore_engine_trace2_trace2_proto_msgTypes[17].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[21].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[22].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[24].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[23].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[25].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[26].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[28].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[30].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[31].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[32].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[33].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[34].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[35].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[36].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[37].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[38].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[39].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[40].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[43].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[44].OneofWrappers = []any{
+	file_encore_engine_trace2_trace2_proto_msgTypes[41].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[42].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[44].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[45].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[46].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[47].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[48].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[49].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[50].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[51].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[54].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[55].OneofWrappers = []any{
 		(*HTTPTraceEvent_GetConn)(nil),
 		(*HTTPTraceEvent_GotConn)(nil),
 		(*HTTPTraceEvent_GotFirstResponseByte)(nil),
@@ -5988,11 +7295,11 @@ func file_encore_engine_trace2_trace2_proto_init() {
 		(*HTTPTraceEvent_Wait_100Continue)(nil),
 		(*HTTPTraceEvent_ClosedBody)(nil),
 	}
-	file_encore_engine_trace2_trace2_proto_msgTypes[50].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[55].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[57].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[59].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[61].OneofWrappers = []any{
+	file_encore_engine_trace2_trace2_proto_msgTypes[61].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[66].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[68].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[70].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[72].OneofWrappers = []any{
 		(*LogField_Error)(nil),
 		(*LogField_Str)(nil),
 		(*LogField_Bool)(nil),
@@ -6005,14 +7312,14 @@ func file_encore_engine_trace2_trace2_proto_init() {
 		(*LogField_Float32)(nil),
 		(*LogField_Float64)(nil),
 	}
-	file_encore_engine_trace2_trace2_proto_msgTypes[64].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[75].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_engine_trace2_trace2_proto_rawDesc), len(file_encore_engine_trace2_trace2_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   67,
+			NumEnums:      7,
+			NumMessages:   78,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -563,7 +563,77 @@ func (x PubSubTopic_DeliveryGuarantee) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PubSubTopic_DeliveryGuarantee.Descriptor instead.
 func (PubSubTopic_DeliveryGuarantee) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{30, 0}
+}
+
+type CacheCluster_Keyspace_Kind int32
+
+const (
+	CacheCluster_Keyspace_UNKNOWN      CacheCluster_Keyspace_Kind = 0
+	CacheCluster_Keyspace_STRING       CacheCluster_Keyspace_Kind = 1
+	CacheCluster_Keyspace_INT          CacheCluster_Keyspace_Kind = 2
+	CacheCluster_Keyspace_FLOAT        CacheCluster_Keyspace_Kind = 3
+	CacheCluster_Keyspace_LIST         CacheCluster_Keyspace_Kind = 4
+	CacheCluster_Keyspace_SET          CacheCluster_Keyspace_Kind = 5
+	CacheCluster_Keyspace_SORTED_SET   CacheCluster_Keyspace_Kind = 6
+	CacheCluster_Keyspace_STRUCT       CacheCluster_Keyspace_Kind = 7
+	CacheCluster_Keyspace_LOCK         CacheCluster_Keyspace_Kind = 8
+	CacheCluster_Keyspace_RATE_LIMITER CacheCluster_Keyspace_Kind = 9
+)
+
+// Enum value maps for CacheCluster_Keyspace_Kind.
+var (
+	CacheCluster_Keyspace_Kind_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "STRING",
+		2: "INT",
+		3: "FLOAT",
+		4: "LIST",
+		5: "SET",
+		6: "SORTED_SET",
+		7: "STRUCT",
+		8: "LOCK",
+		9: "RATE_LIMITER",
+	}
+	CacheCluster_Keyspace_Kind_value = map[string]int32{
+		"UNKNOWN":      0,
+		"STRING":       1,
+		"INT":          2,
+		"FLOAT":        3,
+		"LIST":         4,
+		"SET":          5,
+		"SORTED_SET":   6,
+		"STRUCT":       7,
+		"LOCK":         8,
+		"RATE_LIMITER": 9,
+	}
+)
+
+func (x CacheCluster_Keyspace_Kind) Enum() *CacheCluster_Keyspace_Kind {
+	p := new(CacheCluster_Keyspace_Kind)
+	*p = x
+	return p
+}
+
+func (x CacheCluster_Keyspace_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CacheCluster_Keyspace_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[10].Descriptor()
+}
+
+func (CacheCluster_Keyspace_Kind) Type() protoreflect.EnumType {
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[10]
+}
+
+func (x CacheCluster_Keyspace_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CacheCluster_Keyspace_Kind.Descriptor instead.
+func (CacheCluster_Keyspace_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{31, 1, 0}
 }
 
 type Metric_MetricKind int32
@@ -599,11 +669,11 @@ func (x Metric_MetricKind) String() string {
 }
 
 func (Metric_MetricKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_parser_meta_v1_meta_proto_enumTypes[10].Descriptor()
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[11].Descriptor()
 }
 
 func (Metric_MetricKind) Type() protoreflect.EnumType {
-	return &file_encore_parser_meta_v1_meta_proto_enumTypes[10]
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[11]
 }
 
 func (x Metric_MetricKind) Number() protoreflect.EnumNumber {
@@ -612,7 +682,7 @@ func (x Metric_MetricKind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Metric_MetricKind.Descriptor instead.
 func (Metric_MetricKind) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{29, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{32, 0}
 }
 
 // Data is the metadata associated with an app version.
@@ -635,6 +705,10 @@ type Data struct {
 	Gateways           []*Gateway             `protobuf:"bytes,15,rep,name=gateways,proto3" json:"gateways,omitempty"`
 	Language           Lang                   `protobuf:"varint,16,opt,name=language,proto3,enum=encore.parser.meta.v1.Lang" json:"language,omitempty"`
 	Buckets            []*Bucket              `protobuf:"bytes,17,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	MonitorChecks      []*MonitorCheck        `protobuf:"bytes,18,rep,name=monitor_checks,json=monitorChecks,proto3" json:"monitor_checks,omitempty"`          // synthetic monitoring checks
+	AlertRules         []*AlertRule           `protobuf:"bytes,19,rep,name=alert_rules,json=alertRules,proto3" json:"alert_rules,omitempty"`                   // alert rules declared in code
+	FeatureFlags       []*FeatureFlag         `protobuf:"bytes,20,rep,name=feature_flags,json=featureFlags,proto3" json:"feature_flags,omitempty"`             // feature flags declared in code
+	ErrorDefinitions   []*ErrorDefinition     `protobuf:"bytes,21,rep,name=error_definitions,json=errorDefinitions,proto3" json:"error_definitions,omitempty"` // errors declared with errs.Define
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -788,6 +862,34 @@ func (x *Data) GetBuckets() []*Bucket {
 	return nil
 }
 
+func (x *Data) GetMonitorChecks() []*MonitorCheck {
+	if x != nil {
+		return x.MonitorChecks
+	}
+	return nil
+}
+
+func (x *Data) GetAlertRules() []*AlertRule {
+	if x != nil {
+		return x.AlertRules
+	}
+	return nil
+}
+
+func (x *Data) GetFeatureFlags() []*FeatureFlag {
+	if x != nil {
+		return x.FeatureFlags
+	}
+	return nil
+}
+
+func (x *Data) GetErrorDefinitions() []*ErrorDefinition {
+	if x != nil {
+		return x.ErrorDefinitions
+	}
+	return nil
+}
+
 // QualifiedName is a name of an object in a specific package.
 // It is never an unqualified name, even in circumstances
 // where a package may refer to its own objects.
@@ -936,15 +1038,18 @@ func (x *Package) GetTraceNodes() []*TraceNode {
 }
 
 type Service struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	RelPath       string                 `protobuf:"bytes,2,opt,name=rel_path,json=relPath,proto3" json:"rel_path,omitempty"` // import path relative to app root for the root package in the service
-	Rpcs          []*RPC                 `protobuf:"bytes,3,rep,name=rpcs,proto3" json:"rpcs,omitempty"`
-	Migrations    []*DBMigration         `protobuf:"bytes,4,rep,name=migrations,proto3" json:"migrations,omitempty"`
-	Databases     []string               `protobuf:"bytes,5,rep,name=databases,proto3" json:"databases,omitempty"`                   // databases this service connects to
-	HasConfig     bool                   `protobuf:"varint,6,opt,name=has_config,json=hasConfig,proto3" json:"has_config,omitempty"` // true if the service has uses config
-	Buckets       []*BucketUsage         `protobuf:"bytes,7,rep,name=buckets,proto3" json:"buckets,omitempty"`                       // buckets this service uses
-	Metrics       []string               `protobuf:"bytes,8,rep,name=metrics,proto3" json:"metrics,omitempty"`                       // metrics this service uses
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RelPath    string                 `protobuf:"bytes,2,opt,name=rel_path,json=relPath,proto3" json:"rel_path,omitempty"` // import path relative to app root for the root package in the service
+	Rpcs       []*RPC                 `protobuf:"bytes,3,rep,name=rpcs,proto3" json:"rpcs,omitempty"`
+	Migrations []*DBMigration         `protobuf:"bytes,4,rep,name=migrations,proto3" json:"migrations,omitempty"`
+	Databases  []string               `protobuf:"bytes,5,rep,name=databases,proto3" json:"databases,omitempty"`                   // databases this service connects to
+	HasConfig  bool                   `protobuf:"varint,6,opt,name=has_config,json=hasConfig,proto3" json:"has_config,omitempty"` // true if the service has uses config
+	Buckets    []*BucketUsage         `protobuf:"bytes,7,rep,name=buckets,proto3" json:"buckets,omitempty"`                       // buckets this service uses
+	Metrics    []string               `protobuf:"bytes,8,rep,name=metrics,proto3" json:"metrics,omitempty"`                       // metrics this service uses
+	// The types of the config loaded by the service with config.Load,
+	// in the order they are loaded.
+	Configs       []*v1.Type `protobuf:"bytes,9,rep,name=configs,proto3" json:"configs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1035,6 +1140,13 @@ func (x *Service) GetMetrics() []string {
 	return nil
 }
 
+func (x *Service) GetConfigs() []*v1.Type {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
 type BucketUsage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The encore name of the bucket.
@@ -1169,7 +1281,29 @@ type RPC struct {
 	StreamingResponse bool     `protobuf:"varint,17,opt,name=streaming_response,json=streamingResponse,proto3" json:"streaming_response,omitempty"`
 	HandshakeSchema   *v1.Type `protobuf:"bytes,18,opt,name=handshake_schema,json=handshakeSchema,proto3,oneof" json:"handshake_schema,omitempty"` // handshake schema, or nil
 	// If the endpoint serves static assets.
-	StaticAssets  *RPC_StaticAssets `protobuf:"bytes,19,opt,name=static_assets,json=staticAssets,proto3,oneof" json:"static_assets,omitempty"`
+	StaticAssets *RPC_StaticAssets `protobuf:"bytes,19,opt,name=static_assets,json=staticAssets,proto3,oneof" json:"static_assets,omitempty"`
+	// Whether requests containing fields not part of the request schema
+	// are rejected instead of the unknown fields being ignored.
+	StrictDecoding bool `protobuf:"varint,20,opt,name=strict_decoding,json=strictDecoding,proto3" json:"strict_decoding,omitempty"`
+	// The environment types the endpoint is publicly exposed in,
+	// such as "production" or "local". If empty, it's exposed in all environments.
+	EnvTypes []string `protobuf:"bytes,21,rep,name=env_types,json=envTypes,proto3" json:"env_types,omitempty"`
+	// Whether the endpoint streams its response as server-sent events.
+	// If true, response_schema is the schema of each event.
+	ServerSentEvents bool `protobuf:"varint,22,opt,name=server_sent_events,json=serverSentEvents,proto3" json:"server_sent_events,omitempty"`
+	// Whether the endpoint is also exposed over gRPC.
+	Grpc bool `protobuf:"varint,23,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	// The version of the endpoint, or 0 if it's unversioned.
+	// Versioned endpoints are served on path, which is prefixed with
+	// "/v<version>", and on unversioned_path with the version selected
+	// by the request's API-Version header.
+	Version         int32 `protobuf:"varint,24,opt,name=version,proto3" json:"version,omitempty"`
+	UnversionedPath *Path `protobuf:"bytes,25,opt,name=unversioned_path,json=unversionedPath,proto3,oneof" json:"unversioned_path,omitempty"`
+	// The deprecation notice of the endpoint, if it's deprecated.
+	Deprecated *string `protobuf:"bytes,26,opt,name=deprecated,proto3,oneof" json:"deprecated,omitempty"`
+	// The maximum duration of a request to the endpoint, in milliseconds.
+	// If not set, defaults to no limit.
+	TimeoutMillis *int64 `protobuf:"varint,27,opt,name=timeout_millis,json=timeoutMillis,proto3,oneof" json:"timeout_millis,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1337,6 +1471,62 @@ func (x *RPC) GetStaticAssets() *RPC_StaticAssets {
 	return nil
 }
 
+func (x *RPC) GetStrictDecoding() bool {
+	if x != nil {
+		return x.StrictDecoding
+	}
+	return false
+}
+
+func (x *RPC) GetEnvTypes() []string {
+	if x != nil {
+		return x.EnvTypes
+	}
+	return nil
+}
+
+func (x *RPC) GetServerSentEvents() bool {
+	if x != nil {
+		return x.ServerSentEvents
+	}
+	return false
+}
+
+func (x *RPC) GetGrpc() bool {
+	if x != nil {
+		return x.Grpc
+	}
+	return false
+}
+
+func (x *RPC) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RPC) GetUnversionedPath() *Path {
+	if x != nil {
+		return x.UnversionedPath
+	}
+	return nil
+}
+
+func (x *RPC) GetDeprecated() string {
+	if x != nil && x.Deprecated != nil {
+		return *x.Deprecated
+	}
+	return ""
+}
+
+func (x *RPC) GetTimeoutMillis() int64 {
+	if x != nil && x.TimeoutMillis != nil {
+		return *x.TimeoutMillis
+	}
+	return 0
+}
+
 type AuthHandler struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -2652,33 +2842,39 @@ func (x *CronJob) GetEndpoint() *QualifiedName {
 	return nil
 }
 
-type SQLDatabase struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Doc   *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
-	// migration_rel_path is the slash-separated path to the migrations,
-	// relative to the main module's root directory.
-	MigrationRelPath             *string        `protobuf:"bytes,3,opt,name=migration_rel_path,json=migrationRelPath,proto3,oneof" json:"migration_rel_path,omitempty"`
-	Migrations                   []*DBMigration `protobuf:"bytes,4,rep,name=migrations,proto3" json:"migrations,omitempty"`
-	AllowNonSequentialMigrations bool           `protobuf:"varint,5,opt,name=allow_non_sequential_migrations,json=allowNonSequentialMigrations,proto3" json:"allow_non_sequential_migrations,omitempty"`
-	unknownFields                protoimpl.UnknownFields
-	sizeCache                    protoimpl.SizeCache
+// MonitorCheck is a synthetic monitoring check that periodically
+// probes an HTTP endpoint of the application.
+type MonitorCheck struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Doc             *string                `protobuf:"bytes,3,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	Method          string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`                                           // the HTTP method to use, such as "GET"
+	Path            string                 `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`                                               // the request path, such as "/healthz"
+	IntervalSeconds int64                  `protobuf:"varint,6,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"` // how often to run the check
+	TimeoutMillis   int64                  `protobuf:"varint,7,opt,name=timeout_millis,json=timeoutMillis,proto3" json:"timeout_millis,omitempty"`       // the request timeout
+	// Assertions on the response.
+	ExpectStatus       int32   `protobuf:"varint,8,opt,name=expect_status,json=expectStatus,proto3" json:"expect_status,omitempty"`                          // the expected status code
+	ExpectBodyContains *string `protobuf:"bytes,9,opt,name=expect_body_contains,json=expectBodyContains,proto3,oneof" json:"expect_body_contains,omitempty"` // a string the response body must contain, if set
+	MaxLatencyMillis   *int64  `protobuf:"varint,10,opt,name=max_latency_millis,json=maxLatencyMillis,proto3,oneof" json:"max_latency_millis,omitempty"`     // the maximum response time, if set
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *SQLDatabase) Reset() {
-	*x = SQLDatabase{}
+func (x *MonitorCheck) Reset() {
+	*x = MonitorCheck{}
 	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SQLDatabase) String() string {
+func (x *MonitorCheck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLDatabase) ProtoMessage() {}
+func (*MonitorCheck) ProtoMessage() {}
 
-func (x *SQLDatabase) ProtoReflect() protoreflect.Message {
+func (x *MonitorCheck) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2690,69 +2886,116 @@ func (x *SQLDatabase) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLDatabase.ProtoReflect.Descriptor instead.
-func (*SQLDatabase) Descriptor() ([]byte, []int) {
+// Deprecated: Use MonitorCheck.ProtoReflect.Descriptor instead.
+func (*MonitorCheck) Descriptor() ([]byte, []int) {
 	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *SQLDatabase) GetName() string {
+func (x *MonitorCheck) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *SQLDatabase) GetDoc() string {
+func (x *MonitorCheck) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MonitorCheck) GetDoc() string {
 	if x != nil && x.Doc != nil {
 		return *x.Doc
 	}
 	return ""
 }
 
-func (x *SQLDatabase) GetMigrationRelPath() string {
-	if x != nil && x.MigrationRelPath != nil {
-		return *x.MigrationRelPath
+func (x *MonitorCheck) GetMethod() string {
+	if x != nil {
+		return x.Method
 	}
 	return ""
 }
 
-func (x *SQLDatabase) GetMigrations() []*DBMigration {
+func (x *MonitorCheck) GetPath() string {
 	if x != nil {
-		return x.Migrations
+		return x.Path
 	}
-	return nil
+	return ""
 }
 
-func (x *SQLDatabase) GetAllowNonSequentialMigrations() bool {
+func (x *MonitorCheck) GetIntervalSeconds() int64 {
 	if x != nil {
-		return x.AllowNonSequentialMigrations
+		return x.IntervalSeconds
 	}
-	return false
+	return 0
 }
 
-type DBMigration struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`       // filename
-	Number        uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`          // migration number
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"` // descriptive name
+func (x *MonitorCheck) GetTimeoutMillis() int64 {
+	if x != nil {
+		return x.TimeoutMillis
+	}
+	return 0
+}
+
+func (x *MonitorCheck) GetExpectStatus() int32 {
+	if x != nil {
+		return x.ExpectStatus
+	}
+	return 0
+}
+
+func (x *MonitorCheck) GetExpectBodyContains() string {
+	if x != nil && x.ExpectBodyContains != nil {
+		return *x.ExpectBodyContains
+	}
+	return ""
+}
+
+func (x *MonitorCheck) GetMaxLatencyMillis() int64 {
+	if x != nil && x.MaxLatencyMillis != nil {
+		return *x.MaxLatencyMillis
+	}
+	return 0
+}
+
+// AlertRule is an alert rule declared in code, evaluated
+// against the metrics reported by the runtime.
+type AlertRule struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Name    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title   string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Doc     *string                `protobuf:"bytes,3,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	Service string                 `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"` // the service owning the rule
+	// Types that are valid to be assigned to Condition:
+	//
+	//	*AlertRule_ErrorRate_
+	//	*AlertRule_Latency_
+	//	*AlertRule_QueueLag_
+	Condition     isAlertRule_Condition `protobuf_oneof:"condition"`
+	WindowSeconds int64                 `protobuf:"varint,8,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"` // the window the condition is computed over
+	ForSeconds    int64                 `protobuf:"varint,9,opt,name=for_seconds,json=forSeconds,proto3" json:"for_seconds,omitempty"`          // how long the condition must hold before firing
+	Severity      string                `protobuf:"bytes,10,opt,name=severity,proto3" json:"severity,omitempty"`                                // "warning" or "critical"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DBMigration) Reset() {
-	*x = DBMigration{}
+func (x *AlertRule) Reset() {
+	*x = AlertRule{}
 	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DBMigration) String() string {
+func (x *AlertRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DBMigration) ProtoMessage() {}
+func (*AlertRule) ProtoMessage() {}
 
-func (x *DBMigration) ProtoReflect() protoreflect.Message {
+func (x *AlertRule) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2764,56 +3007,142 @@ func (x *DBMigration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DBMigration.ProtoReflect.Descriptor instead.
-func (*DBMigration) Descriptor() ([]byte, []int) {
+// Deprecated: Use AlertRule.ProtoReflect.Descriptor instead.
+func (*AlertRule) Descriptor() ([]byte, []int) {
 	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *DBMigration) GetFilename() string {
+func (x *AlertRule) GetName() string {
 	if x != nil {
-		return x.Filename
+		return x.Name
 	}
 	return ""
 }
 
-func (x *DBMigration) GetNumber() uint64 {
+func (x *AlertRule) GetTitle() string {
 	if x != nil {
-		return x.Number
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AlertRule) GetDoc() string {
+	if x != nil && x.Doc != nil {
+		return *x.Doc
+	}
+	return ""
+}
+
+func (x *AlertRule) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *AlertRule) GetCondition() isAlertRule_Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+func (x *AlertRule) GetErrorRate() *AlertRule_ErrorRate {
+	if x != nil {
+		if x, ok := x.Condition.(*AlertRule_ErrorRate_); ok {
+			return x.ErrorRate
+		}
+	}
+	return nil
+}
+
+func (x *AlertRule) GetLatency() *AlertRule_Latency {
+	if x != nil {
+		if x, ok := x.Condition.(*AlertRule_Latency_); ok {
+			return x.Latency
+		}
+	}
+	return nil
+}
+
+func (x *AlertRule) GetQueueLag() *AlertRule_QueueLag {
+	if x != nil {
+		if x, ok := x.Condition.(*AlertRule_QueueLag_); ok {
+			return x.QueueLag
+		}
+	}
+	return nil
+}
+
+func (x *AlertRule) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
 	}
 	return 0
 }
 
-func (x *DBMigration) GetDescription() string {
+func (x *AlertRule) GetForSeconds() int64 {
 	if x != nil {
-		return x.Description
+		return x.ForSeconds
+	}
+	return 0
+}
+
+func (x *AlertRule) GetSeverity() string {
+	if x != nil {
+		return x.Severity
 	}
 	return ""
 }
 
-type Bucket struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Doc           *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
-	Versioned     bool                   `protobuf:"varint,3,opt,name=versioned,proto3" json:"versioned,omitempty"`
-	Public        bool                   `protobuf:"varint,4,opt,name=public,proto3" json:"public,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type isAlertRule_Condition interface {
+	isAlertRule_Condition()
 }
 
-func (x *Bucket) Reset() {
-	*x = Bucket{}
+type AlertRule_ErrorRate_ struct {
+	ErrorRate *AlertRule_ErrorRate `protobuf:"bytes,5,opt,name=error_rate,json=errorRate,proto3,oneof"`
+}
+
+type AlertRule_Latency_ struct {
+	Latency *AlertRule_Latency `protobuf:"bytes,6,opt,name=latency,proto3,oneof"`
+}
+
+type AlertRule_QueueLag_ struct {
+	QueueLag *AlertRule_QueueLag `protobuf:"bytes,7,opt,name=queue_lag,json=queueLag,proto3,oneof"`
+}
+
+func (*AlertRule_ErrorRate_) isAlertRule_Condition() {}
+
+func (*AlertRule_Latency_) isAlertRule_Condition() {}
+
+func (*AlertRule_QueueLag_) isAlertRule_Condition() {}
+
+// FeatureFlag is a feature flag declared with flags.New.
+type FeatureFlag struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Doc            *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	RolloutPercent int32                  `protobuf:"varint,3,opt,name=rollout_percent,json=rolloutPercent,proto3" json:"rollout_percent,omitempty"`                                            // the percentage of users the flag is enabled for
+	UserIds        []string               `protobuf:"bytes,4,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`                                                                  // the users the flag is always enabled for
+	Attributes     map[string]string      `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // the auth data fields the flag is enabled for
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FeatureFlag) Reset() {
+	*x = FeatureFlag{}
 	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Bucket) String() string {
+func (x *FeatureFlag) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Bucket) ProtoMessage() {}
+func (*FeatureFlag) ProtoMessage() {}
 
-func (x *Bucket) ProtoReflect() protoreflect.Message {
+func (x *FeatureFlag) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2825,40 +3154,359 @@ func (x *Bucket) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
-func (*Bucket) Descriptor() ([]byte, []int) {
+// Deprecated: Use FeatureFlag.ProtoReflect.Descriptor instead.
+func (*FeatureFlag) Descriptor() ([]byte, []int) {
 	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *Bucket) GetName() string {
+func (x *FeatureFlag) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Bucket) GetDoc() string {
+func (x *FeatureFlag) GetDoc() string {
 	if x != nil && x.Doc != nil {
 		return *x.Doc
 	}
 	return ""
 }
 
-func (x *Bucket) GetVersioned() bool {
+func (x *FeatureFlag) GetRolloutPercent() int32 {
 	if x != nil {
-		return x.Versioned
+		return x.RolloutPercent
 	}
-	return false
+	return 0
 }
 
-func (x *Bucket) GetPublic() bool {
+func (x *FeatureFlag) GetUserIds() []string {
 	if x != nil {
-		return x.Public
+		return x.UserIds
 	}
-	return false
+	return nil
 }
 
-type PubSubTopic struct {
+func (x *FeatureFlag) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type SQLDatabase struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Doc   *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	// migration_rel_path is the slash-separated path to the migrations,
+	// relative to the main module's root directory.
+	MigrationRelPath             *string        `protobuf:"bytes,3,opt,name=migration_rel_path,json=migrationRelPath,proto3,oneof" json:"migration_rel_path,omitempty"`
+	Migrations                   []*DBMigration `protobuf:"bytes,4,rep,name=migrations,proto3" json:"migrations,omitempty"`
+	AllowNonSequentialMigrations bool           `protobuf:"varint,5,opt,name=allow_non_sequential_migrations,json=allowNonSequentialMigrations,proto3" json:"allow_non_sequential_migrations,omitempty"`
+	// granted_services are the services granted access to the database
+	// using sqldb.Grant, in addition to the service declaring it.
+	// If empty, access to the database is not restricted.
+	GrantedServices []string `protobuf:"bytes,6,rep,name=granted_services,json=grantedServices,proto3" json:"granted_services,omitempty"`
+	// seed_rel_path is the slash-separated path to the seed directory,
+	// relative to the main module's root directory. Unset if the database has no seeds.
+	SeedRelPath *string `protobuf:"bytes,7,opt,name=seed_rel_path,json=seedRelPath,proto3,oneof" json:"seed_rel_path,omitempty"`
+	// seed_files are the SQL seed files in the seed directory, in the order to apply them.
+	SeedFiles []string `protobuf:"bytes,8,rep,name=seed_files,json=seedFiles,proto3" json:"seed_files,omitempty"`
+	// seed_program is true if the seed directory contains a Go program
+	// (package main) for seeding the database.
+	SeedProgram bool `protobuf:"varint,9,opt,name=seed_program,json=seedProgram,proto3" json:"seed_program,omitempty"`
+	// tags are the tags to apply to the provisioned database.
+	Tags map[string]string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// regions are the regions the database must be provisioned in,
+	// as region names or prefixes ending with "*". If empty, any region is allowed.
+	Regions       []string `protobuf:"bytes,11,rep,name=regions,proto3" json:"regions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SQLDatabase) Reset() {
+	*x = SQLDatabase{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SQLDatabase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLDatabase) ProtoMessage() {}
+
+func (x *SQLDatabase) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLDatabase.ProtoReflect.Descriptor instead.
+func (*SQLDatabase) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SQLDatabase) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SQLDatabase) GetDoc() string {
+	if x != nil && x.Doc != nil {
+		return *x.Doc
+	}
+	return ""
+}
+
+func (x *SQLDatabase) GetMigrationRelPath() string {
+	if x != nil && x.MigrationRelPath != nil {
+		return *x.MigrationRelPath
+	}
+	return ""
+}
+
+func (x *SQLDatabase) GetMigrations() []*DBMigration {
+	if x != nil {
+		return x.Migrations
+	}
+	return nil
+}
+
+func (x *SQLDatabase) GetAllowNonSequentialMigrations() bool {
+	if x != nil {
+		return x.AllowNonSequentialMigrations
+	}
+	return false
+}
+
+func (x *SQLDatabase) GetGrantedServices() []string {
+	if x != nil {
+		return x.GrantedServices
+	}
+	return nil
+}
+
+func (x *SQLDatabase) GetSeedRelPath() string {
+	if x != nil && x.SeedRelPath != nil {
+		return *x.SeedRelPath
+	}
+	return ""
+}
+
+func (x *SQLDatabase) GetSeedFiles() []string {
+	if x != nil {
+		return x.SeedFiles
+	}
+	return nil
+}
+
+func (x *SQLDatabase) GetSeedProgram() bool {
+	if x != nil {
+		return x.SeedProgram
+	}
+	return false
+}
+
+func (x *SQLDatabase) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SQLDatabase) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+type DBMigration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`       // filename
+	Number        uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`          // migration number
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"` // descriptive name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBMigration) Reset() {
+	*x = DBMigration{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBMigration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBMigration) ProtoMessage() {}
+
+func (x *DBMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBMigration.ProtoReflect.Descriptor instead.
+func (*DBMigration) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DBMigration) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DBMigration) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *DBMigration) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type Bucket struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Doc       *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	Versioned bool                   `protobuf:"varint,3,opt,name=versioned,proto3" json:"versioned,omitempty"`
+	Public    bool                   `protobuf:"varint,4,opt,name=public,proto3" json:"public,omitempty"`
+	// tags are the tags to apply to the provisioned bucket.
+	Tags map[string]string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// regions are the regions the bucket must be provisioned in, if restricted.
+	Regions []string `protobuf:"bytes,6,rep,name=regions,proto3" json:"regions,omitempty"`
+	// lifecycle describes when objects are moved to cold storage or deleted, if set.
+	Lifecycle *Bucket_Lifecycle `protobuf:"bytes,7,opt,name=lifecycle,proto3,oneof" json:"lifecycle,omitempty"`
+	// events_topic is the name of the pubsub topic to publish object
+	// change notifications to, if any.
+	EventsTopic *string `protobuf:"bytes,8,opt,name=events_topic,json=eventsTopic,proto3,oneof" json:"events_topic,omitempty"`
+	// client_side_encryption is whether objects are encrypted by the
+	// runtime before being uploaded, using the bucket's configured KMS key.
+	ClientSideEncryption bool `protobuf:"varint,9,opt,name=client_side_encryption,json=clientSideEncryption,proto3" json:"client_side_encryption,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Bucket) Reset() {
+	*x = Bucket{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bucket) ProtoMessage() {}
+
+func (x *Bucket) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
+func (*Bucket) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Bucket) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Bucket) GetDoc() string {
+	if x != nil && x.Doc != nil {
+		return *x.Doc
+	}
+	return ""
+}
+
+func (x *Bucket) GetVersioned() bool {
+	if x != nil {
+		return x.Versioned
+	}
+	return false
+}
+
+func (x *Bucket) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+func (x *Bucket) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Bucket) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *Bucket) GetLifecycle() *Bucket_Lifecycle {
+	if x != nil {
+		return x.Lifecycle
+	}
+	return nil
+}
+
+func (x *Bucket) GetEventsTopic() string {
+	if x != nil && x.EventsTopic != nil {
+		return *x.EventsTopic
+	}
+	return ""
+}
+
+func (x *Bucket) GetClientSideEncryption() bool {
+	if x != nil {
+		return x.ClientSideEncryption
+	}
+	return false
+}
+
+type PubSubTopic struct {
 	state             protoimpl.MessageState        `protogen:"open.v1"`
 	Name              string                        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                                                              // The pub sub topic name (unique per application)
 	Doc               *string                       `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`                                                                                                          // The documentation for the topic
@@ -2867,13 +3515,16 @@ type PubSubTopic struct {
 	OrderingKey       string                        `protobuf:"bytes,5,opt,name=ordering_key,json=orderingKey,proto3" json:"ordering_key,omitempty"`                                                                             // The field used to group messages; if empty, the topic is not ordered
 	Publishers        []*PubSubTopic_Publisher      `protobuf:"bytes,6,rep,name=publishers,proto3" json:"publishers,omitempty"`                                                                                                  // The publishers for this topic
 	Subscriptions     []*PubSubTopic_Subscription   `protobuf:"bytes,7,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`                                                                                            // The subscriptions to the topic
+	Ordered           bool                          `protobuf:"varint,8,opt,name=ordered,proto3" json:"ordered,omitempty"`                                                                                                       // Whether messages are ordered by ordering keys given when publishing
+	Tags              map[string]string             `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                    // The tags to apply to the provisioned topic
+	Regions           []string                      `protobuf:"bytes,10,rep,name=regions,proto3" json:"regions,omitempty"`                                                                                                       // The regions the topic must be provisioned in, if restricted
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *PubSubTopic) Reset() {
 	*x = PubSubTopic{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[27]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2885,7 +3536,7 @@ func (x *PubSubTopic) String() string {
 func (*PubSubTopic) ProtoMessage() {}
 
 func (x *PubSubTopic) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[27]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2898,7 +3549,7 @@ func (x *PubSubTopic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic.ProtoReflect.Descriptor instead.
 func (*PubSubTopic) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *PubSubTopic) GetName() string {
@@ -2950,19 +3601,41 @@ func (x *PubSubTopic) GetSubscriptions() []*PubSubTopic_Subscription {
 	return nil
 }
 
+func (x *PubSubTopic) GetOrdered() bool {
+	if x != nil {
+		return x.Ordered
+	}
+	return false
+}
+
+func (x *PubSubTopic) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PubSubTopic) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
 type CacheCluster struct {
 	state          protoimpl.MessageState   `protogen:"open.v1"`
-	Name           string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                           // The pub sub topic name (unique per application)
-	Doc            string                   `protobuf:"bytes,2,opt,name=doc,proto3" json:"doc,omitempty"`                                             // The documentation for the topic
-	Keyspaces      []*CacheCluster_Keyspace `protobuf:"bytes,3,rep,name=keyspaces,proto3" json:"keyspaces,omitempty"`                                 // The publishers for this topic
-	EvictionPolicy string                   `protobuf:"bytes,4,opt,name=eviction_policy,json=evictionPolicy,proto3" json:"eviction_policy,omitempty"` // redis eviction policy
+	Name           string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                           // The pub sub topic name (unique per application)
+	Doc            string                   `protobuf:"bytes,2,opt,name=doc,proto3" json:"doc,omitempty"`                                                                             // The documentation for the topic
+	Keyspaces      []*CacheCluster_Keyspace `protobuf:"bytes,3,rep,name=keyspaces,proto3" json:"keyspaces,omitempty"`                                                                 // The publishers for this topic
+	EvictionPolicy string                   `protobuf:"bytes,4,opt,name=eviction_policy,json=evictionPolicy,proto3" json:"eviction_policy,omitempty"`                                 // redis eviction policy
+	Tags           map[string]string        `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // The tags to apply to the provisioned cluster
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CacheCluster) Reset() {
 	*x = CacheCluster{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[28]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2974,7 +3647,7 @@ func (x *CacheCluster) String() string {
 func (*CacheCluster) ProtoMessage() {}
 
 func (x *CacheCluster) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[28]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2987,7 +3660,7 @@ func (x *CacheCluster) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheCluster.ProtoReflect.Descriptor instead.
 func (*CacheCluster) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{28}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *CacheCluster) GetName() string {
@@ -3018,6 +3691,13 @@ func (x *CacheCluster) GetEvictionPolicy() string {
 	return ""
 }
 
+func (x *CacheCluster) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type Metric struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // the name of the metric
@@ -3032,7 +3712,7 @@ type Metric struct {
 
 func (x *Metric) Reset() {
 	*x = Metric{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[29]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3044,7 +3724,7 @@ func (x *Metric) String() string {
 func (*Metric) ProtoMessage() {}
 
 func (x *Metric) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[29]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3057,7 +3737,7 @@ func (x *Metric) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metric.ProtoReflect.Descriptor instead.
 func (*Metric) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{29}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *Metric) GetName() string {
@@ -3081,27 +3761,104 @@ func (x *Metric) GetDoc() string {
 	return ""
 }
 
-func (x *Metric) GetKind() Metric_MetricKind {
-	if x != nil {
-		return x.Kind
+func (x *Metric) GetKind() Metric_MetricKind {
+	if x != nil {
+		return x.Kind
+	}
+	return Metric_COUNTER
+}
+
+func (x *Metric) GetServiceName() string {
+	if x != nil && x.ServiceName != nil {
+		return *x.ServiceName
+	}
+	return ""
+}
+
+func (x *Metric) GetLabels() []*Metric_Label {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// ErrorDefinition is a reusable error declared with errs.Define.
+type ErrorDefinition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`           // the unique id of the error, such as "user.not_found"
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`       // the error code, such as "not_found"
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // the default error message
+	Doc           *string                `protobuf:"bytes,4,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	ServiceName   *string                `protobuf:"bytes,5,opt,name=service_name,json=serviceName,proto3,oneof" json:"service_name,omitempty"` // the service declaring the error, if any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorDefinition) Reset() {
+	*x = ErrorDefinition{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDefinition) ProtoMessage() {}
+
+func (x *ErrorDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDefinition.ProtoReflect.Descriptor instead.
+func (*ErrorDefinition) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ErrorDefinition) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ErrorDefinition) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ErrorDefinition) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorDefinition) GetDoc() string {
+	if x != nil && x.Doc != nil {
+		return *x.Doc
 	}
-	return Metric_COUNTER
+	return ""
 }
 
-func (x *Metric) GetServiceName() string {
+func (x *ErrorDefinition) GetServiceName() string {
 	if x != nil && x.ServiceName != nil {
 		return *x.ServiceName
 	}
 	return ""
 }
 
-func (x *Metric) GetLabels() []*Metric_Label {
-	if x != nil {
-		return x.Labels
-	}
-	return nil
-}
-
 type RPC_ExposeOptions struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -3110,7 +3867,7 @@ type RPC_ExposeOptions struct {
 
 func (x *RPC_ExposeOptions) Reset() {
 	*x = RPC_ExposeOptions{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[31]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3122,7 +3879,7 @@ func (x *RPC_ExposeOptions) String() string {
 func (*RPC_ExposeOptions) ProtoMessage() {}
 
 func (x *RPC_ExposeOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[31]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3154,7 +3911,7 @@ type RPC_StaticAssets struct {
 
 func (x *RPC_StaticAssets) Reset() {
 	*x = RPC_StaticAssets{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[32]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3166,7 +3923,7 @@ func (x *RPC_StaticAssets) String() string {
 func (*RPC_StaticAssets) ProtoMessage() {}
 
 func (x *RPC_StaticAssets) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[32]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3221,7 +3978,7 @@ type RPC_StaticAssets_HeaderValues struct {
 
 func (x *RPC_StaticAssets_HeaderValues) Reset() {
 	*x = RPC_StaticAssets_HeaderValues{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[33]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3233,7 +3990,7 @@ func (x *RPC_StaticAssets_HeaderValues) String() string {
 func (*RPC_StaticAssets_HeaderValues) ProtoMessage() {}
 
 func (x *RPC_StaticAssets_HeaderValues) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[33]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3267,7 +4024,7 @@ type Gateway_Explicit struct {
 
 func (x *Gateway_Explicit) Reset() {
 	*x = Gateway_Explicit{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[35]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3279,7 +4036,7 @@ func (x *Gateway_Explicit) String() string {
 func (*Gateway_Explicit) ProtoMessage() {}
 
 func (x *Gateway_Explicit) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[35]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3309,6 +4066,233 @@ func (x *Gateway_Explicit) GetAuthHandler() *AuthHandler {
 	return nil
 }
 
+// ErrorRate fires when the fraction of failed requests exceeds the threshold.
+type AlertRule_ErrorRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      *string                `protobuf:"bytes,1,opt,name=endpoint,proto3,oneof" json:"endpoint,omitempty"` // the endpoint, or all endpoints of the service if unset
+	Threshold     float64                `protobuf:"fixed64,2,opt,name=threshold,proto3" json:"threshold,omitempty"`   // between 0 and 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertRule_ErrorRate) Reset() {
+	*x = AlertRule_ErrorRate{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRule_ErrorRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule_ErrorRate) ProtoMessage() {}
+
+func (x *AlertRule_ErrorRate) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule_ErrorRate.ProtoReflect.Descriptor instead.
+func (*AlertRule_ErrorRate) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{25, 0}
+}
+
+func (x *AlertRule_ErrorRate) GetEndpoint() string {
+	if x != nil && x.Endpoint != nil {
+		return *x.Endpoint
+	}
+	return ""
+}
+
+func (x *AlertRule_ErrorRate) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+// Latency fires when the given request latency percentile exceeds the threshold.
+type AlertRule_Latency struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint        *string                `protobuf:"bytes,1,opt,name=endpoint,proto3,oneof" json:"endpoint,omitempty"` // the endpoint, or all endpoints of the service if unset
+	Percentile      float64                `protobuf:"fixed64,2,opt,name=percentile,proto3" json:"percentile,omitempty"` // between 0 and 100
+	ThresholdMillis int64                  `protobuf:"varint,3,opt,name=threshold_millis,json=thresholdMillis,proto3" json:"threshold_millis,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AlertRule_Latency) Reset() {
+	*x = AlertRule_Latency{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRule_Latency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule_Latency) ProtoMessage() {}
+
+func (x *AlertRule_Latency) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule_Latency.ProtoReflect.Descriptor instead.
+func (*AlertRule_Latency) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{25, 1}
+}
+
+func (x *AlertRule_Latency) GetEndpoint() string {
+	if x != nil && x.Endpoint != nil {
+		return *x.Endpoint
+	}
+	return ""
+}
+
+func (x *AlertRule_Latency) GetPercentile() float64 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+func (x *AlertRule_Latency) GetThresholdMillis() int64 {
+	if x != nil {
+		return x.ThresholdMillis
+	}
+	return 0
+}
+
+// QueueLag fires when the delivery lag of a subscription exceeds the threshold.
+type AlertRule_QueueLag struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Topic           string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Subscription    string                 `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	ThresholdMillis int64                  `protobuf:"varint,3,opt,name=threshold_millis,json=thresholdMillis,proto3" json:"threshold_millis,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AlertRule_QueueLag) Reset() {
+	*x = AlertRule_QueueLag{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRule_QueueLag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule_QueueLag) ProtoMessage() {}
+
+func (x *AlertRule_QueueLag) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule_QueueLag.ProtoReflect.Descriptor instead.
+func (*AlertRule_QueueLag) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{25, 2}
+}
+
+func (x *AlertRule_QueueLag) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *AlertRule_QueueLag) GetSubscription() string {
+	if x != nil {
+		return x.Subscription
+	}
+	return ""
+}
+
+func (x *AlertRule_QueueLag) GetThresholdMillis() int64 {
+	if x != nil {
+		return x.ThresholdMillis
+	}
+	return 0
+}
+
+type Bucket_Lifecycle struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ExpireAfterDays      int32                  `protobuf:"varint,1,opt,name=expire_after_days,json=expireAfterDays,proto3" json:"expire_after_days,omitempty"`                  // zero means objects are never deleted
+	ColdStorageAfterDays int32                  `protobuf:"varint,2,opt,name=cold_storage_after_days,json=coldStorageAfterDays,proto3" json:"cold_storage_after_days,omitempty"` // zero means objects are never moved to cold storage
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Bucket_Lifecycle) Reset() {
+	*x = Bucket_Lifecycle{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bucket_Lifecycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bucket_Lifecycle) ProtoMessage() {}
+
+func (x *Bucket_Lifecycle) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bucket_Lifecycle.ProtoReflect.Descriptor instead.
+func (*Bucket_Lifecycle) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{29, 1}
+}
+
+func (x *Bucket_Lifecycle) GetExpireAfterDays() int32 {
+	if x != nil {
+		return x.ExpireAfterDays
+	}
+	return 0
+}
+
+func (x *Bucket_Lifecycle) GetColdStorageAfterDays() int32 {
+	if x != nil {
+		return x.ColdStorageAfterDays
+	}
+	return 0
+}
+
 type PubSubTopic_Publisher struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"` // The service the publisher is in
@@ -3318,7 +4302,7 @@ type PubSubTopic_Publisher struct {
 
 func (x *PubSubTopic_Publisher) Reset() {
 	*x = PubSubTopic_Publisher{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[36]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3330,7 +4314,7 @@ func (x *PubSubTopic_Publisher) String() string {
 func (*PubSubTopic_Publisher) ProtoMessage() {}
 
 func (x *PubSubTopic_Publisher) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[36]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3343,7 +4327,7 @@ func (x *PubSubTopic_Publisher) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic_Publisher.ProtoReflect.Descriptor instead.
 func (*PubSubTopic_Publisher) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{30, 1}
 }
 
 func (x *PubSubTopic_Publisher) GetServiceName() string {
@@ -3363,13 +4347,18 @@ type PubSubTopic_Subscription struct {
 	// How many messages each instance can process concurrently.
 	// If not set, the default is provider-specific.
 	MaxConcurrency *int32 `protobuf:"varint,6,opt,name=max_concurrency,json=maxConcurrency,proto3,oneof" json:"max_concurrency,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// The dead-letter policy for the subscription, if any.
+	DeadLetterPolicy *PubSubTopic_DeadLetterPolicy `protobuf:"bytes,7,opt,name=dead_letter_policy,json=deadLetterPolicy,proto3" json:"dead_letter_policy,omitempty"`
+	// The attribute filter for the subscription, in canonical form.
+	// Only messages matching the filter are delivered. Empty means no filter.
+	Filter        string `protobuf:"bytes,8,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PubSubTopic_Subscription) Reset() {
 	*x = PubSubTopic_Subscription{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[37]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3381,7 +4370,7 @@ func (x *PubSubTopic_Subscription) String() string {
 func (*PubSubTopic_Subscription) ProtoMessage() {}
 
 func (x *PubSubTopic_Subscription) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[37]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3394,7 +4383,7 @@ func (x *PubSubTopic_Subscription) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic_Subscription.ProtoReflect.Descriptor instead.
 func (*PubSubTopic_Subscription) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27, 1}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{30, 2}
 }
 
 func (x *PubSubTopic_Subscription) GetName() string {
@@ -3439,6 +4428,20 @@ func (x *PubSubTopic_Subscription) GetMaxConcurrency() int32 {
 	return 0
 }
 
+func (x *PubSubTopic_Subscription) GetDeadLetterPolicy() *PubSubTopic_DeadLetterPolicy {
+	if x != nil {
+		return x.DeadLetterPolicy
+	}
+	return nil
+}
+
+func (x *PubSubTopic_Subscription) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
 type PubSubTopic_RetryPolicy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MinBackoff    int64                  `protobuf:"varint,1,opt,name=min_backoff,json=minBackoff,proto3" json:"min_backoff,omitempty"` // min backoff in nanoseconds
@@ -3450,7 +4453,7 @@ type PubSubTopic_RetryPolicy struct {
 
 func (x *PubSubTopic_RetryPolicy) Reset() {
 	*x = PubSubTopic_RetryPolicy{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[38]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3462,7 +4465,7 @@ func (x *PubSubTopic_RetryPolicy) String() string {
 func (*PubSubTopic_RetryPolicy) ProtoMessage() {}
 
 func (x *PubSubTopic_RetryPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[38]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3475,7 +4478,7 @@ func (x *PubSubTopic_RetryPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic_RetryPolicy.ProtoReflect.Descriptor instead.
 func (*PubSubTopic_RetryPolicy) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27, 2}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{30, 3}
 }
 
 func (x *PubSubTopic_RetryPolicy) GetMinBackoff() int64 {
@@ -3499,20 +4502,73 @@ func (x *PubSubTopic_RetryPolicy) GetMaxRetries() int64 {
 	return 0
 }
 
-type CacheCluster_Keyspace struct {
+type PubSubTopic_DeadLetterPolicy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	KeyType       *v1.Type               `protobuf:"bytes,1,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
-	ValueType     *v1.Type               `protobuf:"bytes,2,opt,name=value_type,json=valueType,proto3" json:"value_type,omitempty"`
-	Service       string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
-	Doc           string                 `protobuf:"bytes,4,opt,name=doc,proto3" json:"doc,omitempty"`
-	PathPattern   *Path                  `protobuf:"bytes,5,opt,name=path_pattern,json=pathPattern,proto3" json:"path_pattern,omitempty"`
+	TopicName     string                 `protobuf:"bytes,1,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`              // The topic messages are published to once they've been dead-lettered
+	MaxDeliveries int32                  `protobuf:"varint,2,opt,name=max_deliveries,json=maxDeliveries,proto3" json:"max_deliveries,omitempty"` // The number of delivery attempts before a message is dead-lettered
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubTopic_DeadLetterPolicy) Reset() {
+	*x = PubSubTopic_DeadLetterPolicy{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubTopic_DeadLetterPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubTopic_DeadLetterPolicy) ProtoMessage() {}
+
+func (x *PubSubTopic_DeadLetterPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubTopic_DeadLetterPolicy.ProtoReflect.Descriptor instead.
+func (*PubSubTopic_DeadLetterPolicy) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{30, 4}
+}
+
+func (x *PubSubTopic_DeadLetterPolicy) GetTopicName() string {
+	if x != nil {
+		return x.TopicName
+	}
+	return ""
+}
+
+func (x *PubSubTopic_DeadLetterPolicy) GetMaxDeliveries() int32 {
+	if x != nil {
+		return x.MaxDeliveries
+	}
+	return 0
+}
+
+type CacheCluster_Keyspace struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	KeyType       *v1.Type                   `protobuf:"bytes,1,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
+	ValueType     *v1.Type                   `protobuf:"bytes,2,opt,name=value_type,json=valueType,proto3" json:"value_type,omitempty"`
+	Service       string                     `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	Doc           string                     `protobuf:"bytes,4,opt,name=doc,proto3" json:"doc,omitempty"`
+	PathPattern   *Path                      `protobuf:"bytes,5,opt,name=path_pattern,json=pathPattern,proto3" json:"path_pattern,omitempty"`
+	Kind          CacheCluster_Keyspace_Kind `protobuf:"varint,6,opt,name=kind,proto3,enum=encore.parser.meta.v1.CacheCluster_Keyspace_Kind" json:"kind,omitempty"` // The kind of keyspace, based on its constructor.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CacheCluster_Keyspace) Reset() {
 	*x = CacheCluster_Keyspace{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[39]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3524,7 +4580,7 @@ func (x *CacheCluster_Keyspace) String() string {
 func (*CacheCluster_Keyspace) ProtoMessage() {}
 
 func (x *CacheCluster_Keyspace) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[39]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3537,7 +4593,7 @@ func (x *CacheCluster_Keyspace) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheCluster_Keyspace.ProtoReflect.Descriptor instead.
 func (*CacheCluster_Keyspace) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{28, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{31, 1}
 }
 
 func (x *CacheCluster_Keyspace) GetKeyType() *v1.Type {
@@ -3575,6 +4631,13 @@ func (x *CacheCluster_Keyspace) GetPathPattern() *Path {
 	return nil
 }
 
+func (x *CacheCluster_Keyspace) GetKind() CacheCluster_Keyspace_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return CacheCluster_Keyspace_UNKNOWN
+}
+
 type Metric_Label struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -3586,7 +4649,7 @@ type Metric_Label struct {
 
 func (x *Metric_Label) Reset() {
 	*x = Metric_Label{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[40]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3598,7 +4661,7 @@ func (x *Metric_Label) String() string {
 func (*Metric_Label) ProtoMessage() {}
 
 func (x *Metric_Label) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[40]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3611,7 +4674,7 @@ func (x *Metric_Label) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metric_Label.ProtoReflect.Descriptor instead.
 func (*Metric_Label) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{29, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{32, 0}
 }
 
 func (x *Metric_Label) GetKey() string {
@@ -3639,7 +4702,8 @@ var File_encore_parser_meta_v1_meta_proto protoreflect.FileDescriptor
 
 const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\n" +
-	" encore/parser/meta/v1/meta.proto\x12\x15encore.parser.meta.v1\x1a$encore/parser/schema/v1/schema.proto\"\xdc\a\n" +
+	" encore/parser/meta/v1/meta.proto\x12\x15encore.parser.meta.v1\x1a$encore/parser/schema/v1/schema.proto\"\x89\n" +
+	"\n" +
 	"\x04Data\x12\x1f\n" +
 	"\vmodule_path\x18\x01 \x01(\tR\n" +
 	"modulePath\x12!\n" +
@@ -3661,7 +4725,12 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\rsql_databases\x18\x0e \x03(\v2\".encore.parser.meta.v1.SQLDatabaseR\fsqlDatabases\x12:\n" +
 	"\bgateways\x18\x0f \x03(\v2\x1e.encore.parser.meta.v1.GatewayR\bgateways\x127\n" +
 	"\blanguage\x18\x10 \x01(\x0e2\x1b.encore.parser.meta.v1.LangR\blanguage\x127\n" +
-	"\abuckets\x18\x11 \x03(\v2\x1d.encore.parser.meta.v1.BucketR\abucketsB\x0f\n" +
+	"\abuckets\x18\x11 \x03(\v2\x1d.encore.parser.meta.v1.BucketR\abuckets\x12J\n" +
+	"\x0emonitor_checks\x18\x12 \x03(\v2#.encore.parser.meta.v1.MonitorCheckR\rmonitorChecks\x12A\n" +
+	"\valert_rules\x18\x13 \x03(\v2 .encore.parser.meta.v1.AlertRuleR\n" +
+	"alertRules\x12G\n" +
+	"\rfeature_flags\x18\x14 \x03(\v2\".encore.parser.meta.v1.FeatureFlagR\ffeatureFlags\x12S\n" +
+	"\x11error_definitions\x18\x15 \x03(\v2&.encore.parser.meta.v1.ErrorDefinitionR\x10errorDefinitionsB\x0f\n" +
 	"\r_auth_handler\"5\n" +
 	"\rQualifiedName\x12\x10\n" +
 	"\x03pkg\x18\x01 \x01(\tR\x03pkg\x12\x12\n" +
@@ -3674,7 +4743,7 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\asecrets\x18\x05 \x03(\tR\asecrets\x12A\n" +
 	"\trpc_calls\x18\x06 \x03(\v2$.encore.parser.meta.v1.QualifiedNameR\brpcCalls\x12A\n" +
 	"\vtrace_nodes\x18\a \x03(\v2 .encore.parser.meta.v1.TraceNodeR\n" +
-	"traceNodes\"\xc1\x02\n" +
+	"traceNodes\"\xfa\x02\n" +
 	"\aService\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
 	"\brel_path\x18\x02 \x01(\tR\arelPath\x12.\n" +
@@ -3686,7 +4755,8 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\n" +
 	"has_config\x18\x06 \x01(\bR\thasConfig\x12<\n" +
 	"\abuckets\x18\a \x03(\v2\".encore.parser.meta.v1.BucketUsageR\abuckets\x12\x18\n" +
-	"\ametrics\x18\b \x03(\tR\ametrics\"\xd8\x02\n" +
+	"\ametrics\x18\b \x03(\tR\ametrics\x127\n" +
+	"\aconfigs\x18\t \x03(\v2\x1d.encore.parser.schema.v1.TypeR\aconfigs\"\xd8\x02\n" +
 	"\vBucketUsage\x12\x16\n" +
 	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12L\n" +
 	"\n" +
@@ -3709,7 +4779,7 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\x04Type\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\a\n" +
 	"\x03ALL\x10\x01\x12\a\n" +
-	"\x03TAG\x10\x02\"\xb4\r\n" +
+	"\x03TAG\x10\x02\"\xab\x10\n" +
 	"\x03RPC\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
 	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12!\n" +
@@ -3732,7 +4802,17 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\x11streaming_request\x18\x10 \x01(\bR\x10streamingRequest\x12-\n" +
 	"\x12streaming_response\x18\x11 \x01(\bR\x11streamingResponse\x12M\n" +
 	"\x10handshake_schema\x18\x12 \x01(\v2\x1d.encore.parser.schema.v1.TypeH\x04R\x0fhandshakeSchema\x88\x01\x01\x12Q\n" +
-	"\rstatic_assets\x18\x13 \x01(\v2'.encore.parser.meta.v1.RPC.StaticAssetsH\x05R\fstaticAssets\x88\x01\x01\x1ac\n" +
+	"\rstatic_assets\x18\x13 \x01(\v2'.encore.parser.meta.v1.RPC.StaticAssetsH\x05R\fstaticAssets\x88\x01\x01\x12'\n" +
+	"\x0fstrict_decoding\x18\x14 \x01(\bR\x0estrictDecoding\x12\x1b\n" +
+	"\tenv_types\x18\x15 \x03(\tR\benvTypes\x12,\n" +
+	"\x12server_sent_events\x18\x16 \x01(\bR\x10serverSentEvents\x12\x12\n" +
+	"\x04grpc\x18\x17 \x01(\bR\x04grpc\x12\x18\n" +
+	"\aversion\x18\x18 \x01(\x05R\aversion\x12K\n" +
+	"\x10unversioned_path\x18\x19 \x01(\v2\x1b.encore.parser.meta.v1.PathH\x06R\x0funversionedPath\x88\x01\x01\x12#\n" +
+	"\n" +
+	"deprecated\x18\x1a \x01(\tH\aR\n" +
+	"deprecated\x88\x01\x01\x12*\n" +
+	"\x0etimeout_millis\x18\x1b \x01(\x03H\bR\rtimeoutMillis\x88\x01\x01\x1ac\n" +
 	"\vExposeEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12>\n" +
 	"\x05value\x18\x02 \x01(\v2(.encore.parser.meta.v1.RPC.ExposeOptionsR\x05value:\x028\x01\x1a\x0f\n" +
@@ -3764,7 +4844,10 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\x10_response_schemaB\r\n" +
 	"\v_body_limitB\x13\n" +
 	"\x11_handshake_schemaB\x10\n" +
-	"\x0e_static_assets\"\xd2\x02\n" +
+	"\x0e_static_assetsB\x13\n" +
+	"\x11_unversioned_pathB\r\n" +
+	"\v_deprecatedB\x11\n" +
+	"\x0f_timeout_millis\"\xd2\x02\n" +
 	"\vAuthHandler\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
 	"\x03doc\x18\x02 \x01(\tR\x03doc\x12\x19\n" +
@@ -3914,7 +4997,65 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\x03doc\x18\x03 \x01(\tH\x00R\x03doc\x88\x01\x01\x12\x1a\n" +
 	"\bschedule\x18\x04 \x01(\tR\bschedule\x12@\n" +
 	"\bendpoint\x18\x05 \x01(\v2$.encore.parser.meta.v1.QualifiedNameR\bendpointB\x06\n" +
-	"\x04_doc\"\x95\x02\n" +
+	"\x04_doc\"\x94\x03\n" +
+	"\fMonitorCheck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x15\n" +
+	"\x03doc\x18\x03 \x01(\tH\x00R\x03doc\x88\x01\x01\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x12)\n" +
+	"\x10interval_seconds\x18\x06 \x01(\x03R\x0fintervalSeconds\x12%\n" +
+	"\x0etimeout_millis\x18\a \x01(\x03R\rtimeoutMillis\x12#\n" +
+	"\rexpect_status\x18\b \x01(\x05R\fexpectStatus\x125\n" +
+	"\x14expect_body_contains\x18\t \x01(\tH\x01R\x12expectBodyContains\x88\x01\x01\x121\n" +
+	"\x12max_latency_millis\x18\n" +
+	" \x01(\x03H\x02R\x10maxLatencyMillis\x88\x01\x01B\x06\n" +
+	"\x04_docB\x17\n" +
+	"\x15_expect_body_containsB\x15\n" +
+	"\x13_max_latency_millis\"\x8b\x06\n" +
+	"\tAlertRule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x15\n" +
+	"\x03doc\x18\x03 \x01(\tH\x01R\x03doc\x88\x01\x01\x12\x18\n" +
+	"\aservice\x18\x04 \x01(\tR\aservice\x12K\n" +
+	"\n" +
+	"error_rate\x18\x05 \x01(\v2*.encore.parser.meta.v1.AlertRule.ErrorRateH\x00R\terrorRate\x12D\n" +
+	"\alatency\x18\x06 \x01(\v2(.encore.parser.meta.v1.AlertRule.LatencyH\x00R\alatency\x12H\n" +
+	"\tqueue_lag\x18\a \x01(\v2).encore.parser.meta.v1.AlertRule.QueueLagH\x00R\bqueueLag\x12%\n" +
+	"\x0ewindow_seconds\x18\b \x01(\x03R\rwindowSeconds\x12\x1f\n" +
+	"\vfor_seconds\x18\t \x01(\x03R\n" +
+	"forSeconds\x12\x1a\n" +
+	"\bseverity\x18\n" +
+	" \x01(\tR\bseverity\x1aW\n" +
+	"\tErrorRate\x12\x1f\n" +
+	"\bendpoint\x18\x01 \x01(\tH\x00R\bendpoint\x88\x01\x01\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\x01R\tthresholdB\v\n" +
+	"\t_endpoint\x1a\x82\x01\n" +
+	"\aLatency\x12\x1f\n" +
+	"\bendpoint\x18\x01 \x01(\tH\x00R\bendpoint\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"percentile\x18\x02 \x01(\x01R\n" +
+	"percentile\x12)\n" +
+	"\x10threshold_millis\x18\x03 \x01(\x03R\x0fthresholdMillisB\v\n" +
+	"\t_endpoint\x1ao\n" +
+	"\bQueueLag\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\"\n" +
+	"\fsubscription\x18\x02 \x01(\tR\fsubscription\x12)\n" +
+	"\x10threshold_millis\x18\x03 \x01(\x03R\x0fthresholdMillisB\v\n" +
+	"\tconditionB\x06\n" +
+	"\x04_doc\"\x97\x02\n" +
+	"\vFeatureFlag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12'\n" +
+	"\x0frollout_percent\x18\x03 \x01(\x05R\x0erolloutPercent\x12\x19\n" +
+	"\buser_ids\x18\x04 \x03(\tR\auserIds\x12R\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v22.encore.parser.meta.v1.FeatureFlag.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x06\n" +
+	"\x04_doc\"\xd2\x04\n" +
 	"\vSQLDatabase\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
 	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x121\n" +
@@ -3922,19 +5063,46 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\n" +
 	"migrations\x18\x04 \x03(\v2\".encore.parser.meta.v1.DBMigrationR\n" +
 	"migrations\x12E\n" +
-	"\x1fallow_non_sequential_migrations\x18\x05 \x01(\bR\x1callowNonSequentialMigrationsB\x06\n" +
+	"\x1fallow_non_sequential_migrations\x18\x05 \x01(\bR\x1callowNonSequentialMigrations\x12)\n" +
+	"\x10granted_services\x18\x06 \x03(\tR\x0fgrantedServices\x12'\n" +
+	"\rseed_rel_path\x18\a \x01(\tH\x02R\vseedRelPath\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"seed_files\x18\b \x03(\tR\tseedFiles\x12!\n" +
+	"\fseed_program\x18\t \x01(\bR\vseedProgram\x12@\n" +
+	"\x04tags\x18\n" +
+	" \x03(\v2,.encore.parser.meta.v1.SQLDatabase.TagsEntryR\x04tags\x12\x18\n" +
+	"\aregions\x18\v \x03(\tR\aregions\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x06\n" +
 	"\x04_docB\x15\n" +
-	"\x13_migration_rel_path\"c\n" +
+	"\x13_migration_rel_pathB\x10\n" +
+	"\x0e_seed_rel_path\"c\n" +
 	"\vDBMigration\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x16\n" +
 	"\x06number\x18\x02 \x01(\x04R\x06number\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\"q\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\xba\x04\n" +
 	"\x06Bucket\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
 	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12\x1c\n" +
 	"\tversioned\x18\x03 \x01(\bR\tversioned\x12\x16\n" +
-	"\x06public\x18\x04 \x01(\bR\x06publicB\x06\n" +
-	"\x04_doc\"\xb8\a\n" +
+	"\x06public\x18\x04 \x01(\bR\x06public\x12;\n" +
+	"\x04tags\x18\x05 \x03(\v2'.encore.parser.meta.v1.Bucket.TagsEntryR\x04tags\x12\x18\n" +
+	"\aregions\x18\x06 \x03(\tR\aregions\x12J\n" +
+	"\tlifecycle\x18\a \x01(\v2'.encore.parser.meta.v1.Bucket.LifecycleH\x01R\tlifecycle\x88\x01\x01\x12&\n" +
+	"\fevents_topic\x18\b \x01(\tH\x02R\veventsTopic\x88\x01\x01\x124\n" +
+	"\x16client_side_encryption\x18\t \x01(\bR\x14clientSideEncryption\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1an\n" +
+	"\tLifecycle\x12*\n" +
+	"\x11expire_after_days\x18\x01 \x01(\x05R\x0fexpireAfterDays\x125\n" +
+	"\x17cold_storage_after_days\x18\x02 \x01(\x05R\x14coldStorageAfterDaysB\x06\n" +
+	"\x04_docB\f\n" +
+	"\n" +
+	"_lifecycleB\x0f\n" +
+	"\r_events_topic\"\xbc\n" +
+	"\n" +
 	"\vPubSubTopic\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
 	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12@\n" +
@@ -3944,16 +5112,25 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\n" +
 	"publishers\x18\x06 \x03(\v2,.encore.parser.meta.v1.PubSubTopic.PublisherR\n" +
 	"publishers\x12U\n" +
-	"\rsubscriptions\x18\a \x03(\v2/.encore.parser.meta.v1.PubSubTopic.SubscriptionR\rsubscriptions\x1a.\n" +
+	"\rsubscriptions\x18\a \x03(\v2/.encore.parser.meta.v1.PubSubTopic.SubscriptionR\rsubscriptions\x12\x18\n" +
+	"\aordered\x18\b \x01(\bR\aordered\x12@\n" +
+	"\x04tags\x18\t \x03(\v2,.encore.parser.meta.v1.PubSubTopic.TagsEntryR\x04tags\x12\x18\n" +
+	"\aregions\x18\n" +
+	" \x03(\tR\aregions\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a.\n" +
 	"\tPublisher\x12!\n" +
-	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x1a\xaa\x02\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x1a\xa5\x03\n" +
 	"\fSubscription\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
 	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12!\n" +
 	"\fack_deadline\x18\x03 \x01(\x03R\vackDeadline\x12+\n" +
 	"\x11message_retention\x18\x04 \x01(\x03R\x10messageRetention\x12Q\n" +
 	"\fretry_policy\x18\x05 \x01(\v2..encore.parser.meta.v1.PubSubTopic.RetryPolicyR\vretryPolicy\x12,\n" +
-	"\x0fmax_concurrency\x18\x06 \x01(\x05H\x00R\x0emaxConcurrency\x88\x01\x01B\x12\n" +
+	"\x0fmax_concurrency\x18\x06 \x01(\x05H\x00R\x0emaxConcurrency\x88\x01\x01\x12a\n" +
+	"\x12dead_letter_policy\x18\a \x01(\v23.encore.parser.meta.v1.PubSubTopic.DeadLetterPolicyR\x10deadLetterPolicy\x12\x16\n" +
+	"\x06filter\x18\b \x01(\tR\x06filterB\x12\n" +
 	"\x10_max_concurrency\x1ap\n" +
 	"\vRetryPolicy\x12\x1f\n" +
 	"\vmin_backoff\x18\x01 \x01(\x03R\n" +
@@ -3961,23 +5138,46 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\vmax_backoff\x18\x02 \x01(\x03R\n" +
 	"maxBackoff\x12\x1f\n" +
 	"\vmax_retries\x18\x03 \x01(\x03R\n" +
-	"maxRetries\"8\n" +
+	"maxRetries\x1aX\n" +
+	"\x10DeadLetterPolicy\x12\x1d\n" +
+	"\n" +
+	"topic_name\x18\x01 \x01(\tR\ttopicName\x12%\n" +
+	"\x0emax_deliveries\x18\x02 \x01(\x05R\rmaxDeliveries\"8\n" +
 	"\x11DeliveryGuarantee\x12\x11\n" +
 	"\rAT_LEAST_ONCE\x10\x00\x12\x10\n" +
 	"\fEXACTLY_ONCE\x10\x01B\x06\n" +
-	"\x04_doc\"\x9a\x03\n" +
+	"\x04_doc\"\xdd\x05\n" +
 	"\fCacheCluster\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
 	"\x03doc\x18\x02 \x01(\tR\x03doc\x12J\n" +
 	"\tkeyspaces\x18\x03 \x03(\v2,.encore.parser.meta.v1.CacheCluster.KeyspaceR\tkeyspaces\x12'\n" +
-	"\x0feviction_policy\x18\x04 \x01(\tR\x0eevictionPolicy\x1a\xee\x01\n" +
+	"\x0feviction_policy\x18\x04 \x01(\tR\x0eevictionPolicy\x12A\n" +
+	"\x04tags\x18\x05 \x03(\v2-.encore.parser.meta.v1.CacheCluster.TagsEntryR\x04tags\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a\xb5\x03\n" +
 	"\bKeyspace\x128\n" +
 	"\bkey_type\x18\x01 \x01(\v2\x1d.encore.parser.schema.v1.TypeR\akeyType\x12<\n" +
 	"\n" +
 	"value_type\x18\x02 \x01(\v2\x1d.encore.parser.schema.v1.TypeR\tvalueType\x12\x18\n" +
 	"\aservice\x18\x03 \x01(\tR\aservice\x12\x10\n" +
 	"\x03doc\x18\x04 \x01(\tR\x03doc\x12>\n" +
-	"\fpath_pattern\x18\x05 \x01(\v2\x1b.encore.parser.meta.v1.PathR\vpathPattern\"\xbb\x03\n" +
+	"\fpath_pattern\x18\x05 \x01(\v2\x1b.encore.parser.meta.v1.PathR\vpathPattern\x12E\n" +
+	"\x04kind\x18\x06 \x01(\x0e21.encore.parser.meta.v1.CacheCluster.Keyspace.KindR\x04kind\"~\n" +
+	"\x04Kind\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\n" +
+	"\n" +
+	"\x06STRING\x10\x01\x12\a\n" +
+	"\x03INT\x10\x02\x12\t\n" +
+	"\x05FLOAT\x10\x03\x12\b\n" +
+	"\x04LIST\x10\x04\x12\a\n" +
+	"\x03SET\x10\x05\x12\x0e\n" +
+	"\n" +
+	"SORTED_SET\x10\x06\x12\n" +
+	"\n" +
+	"\x06STRUCT\x10\a\x12\b\n" +
+	"\x04LOCK\x10\b\x12\x10\n" +
+	"\fRATE_LIMITER\x10\t\"\xbb\x03\n" +
 	"\x06Metric\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12?\n" +
 	"\n" +
@@ -3995,6 +5195,14 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\aCOUNTER\x10\x00\x12\t\n" +
 	"\x05GAUGE\x10\x01\x12\r\n" +
 	"\tHISTOGRAM\x10\x02B\x0f\n" +
+	"\r_service_name\"\xa7\x01\n" +
+	"\x0fErrorDefinition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x15\n" +
+	"\x03doc\x18\x04 \x01(\tH\x00R\x03doc\x88\x01\x01\x12&\n" +
+	"\fservice_name\x18\x05 \x01(\tH\x01R\vserviceName\x88\x01\x01B\x06\n" +
+	"\x04_docB\x0f\n" +
 	"\r_service_name*\x1e\n" +
 	"\x04Lang\x12\x06\n" +
 	"\x02GO\x10\x00\x12\x0e\n" +
@@ -4013,8 +5221,8 @@ func file_encore_parser_meta_v1_meta_proto_rawDescGZIP() []byte {
 	return file_encore_parser_meta_v1_meta_proto_rawDescData
 }
 
-var file_encore_parser_meta_v1_meta_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
-var file_encore_parser_meta_v1_meta_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
+var file_encore_parser_meta_v1_meta_proto_enumTypes = make([]protoimpl.EnumInfo, 12)
+var file_encore_parser_meta_v1_meta_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
 var file_encore_parser_meta_v1_meta_proto_goTypes = []any{
 	(Lang)(0),                             // 0: encore.parser.meta.v1.Lang
 	(BucketUsage_Operation)(0),            // 1: encore.parser.meta.v1.BucketUsage.Operation
@@ -4026,133 +5234,165 @@ var file_encore_parser_meta_v1_meta_proto_goTypes = []any{
 	(PathSegment_SegmentType)(0),          // 7: encore.parser.meta.v1.PathSegment.SegmentType
 	(PathSegment_ParamType)(0),            // 8: encore.parser.meta.v1.PathSegment.ParamType
 	(PubSubTopic_DeliveryGuarantee)(0),    // 9: encore.parser.meta.v1.PubSubTopic.DeliveryGuarantee
-	(Metric_MetricKind)(0),                // 10: encore.parser.meta.v1.Metric.MetricKind
-	(*Data)(nil),                          // 11: encore.parser.meta.v1.Data
-	(*QualifiedName)(nil),                 // 12: encore.parser.meta.v1.QualifiedName
-	(*Package)(nil),                       // 13: encore.parser.meta.v1.Package
-	(*Service)(nil),                       // 14: encore.parser.meta.v1.Service
-	(*BucketUsage)(nil),                   // 15: encore.parser.meta.v1.BucketUsage
-	(*Selector)(nil),                      // 16: encore.parser.meta.v1.Selector
-	(*RPC)(nil),                           // 17: encore.parser.meta.v1.RPC
-	(*AuthHandler)(nil),                   // 18: encore.parser.meta.v1.AuthHandler
-	(*Middleware)(nil),                    // 19: encore.parser.meta.v1.Middleware
-	(*TraceNode)(nil),                     // 20: encore.parser.meta.v1.TraceNode
-	(*RPCDefNode)(nil),                    // 21: encore.parser.meta.v1.RPCDefNode
-	(*RPCCallNode)(nil),                   // 22: encore.parser.meta.v1.RPCCallNode
-	(*StaticCallNode)(nil),                // 23: encore.parser.meta.v1.StaticCallNode
-	(*AuthHandlerDefNode)(nil),            // 24: encore.parser.meta.v1.AuthHandlerDefNode
-	(*PubSubTopicDefNode)(nil),            // 25: encore.parser.meta.v1.PubSubTopicDefNode
-	(*PubSubPublishNode)(nil),             // 26: encore.parser.meta.v1.PubSubPublishNode
-	(*PubSubSubscriberNode)(nil),          // 27: encore.parser.meta.v1.PubSubSubscriberNode
-	(*ServiceInitNode)(nil),               // 28: encore.parser.meta.v1.ServiceInitNode
-	(*MiddlewareDefNode)(nil),             // 29: encore.parser.meta.v1.MiddlewareDefNode
-	(*CacheKeyspaceDefNode)(nil),          // 30: encore.parser.meta.v1.CacheKeyspaceDefNode
-	(*Path)(nil),                          // 31: encore.parser.meta.v1.Path
-	(*PathSegment)(nil),                   // 32: encore.parser.meta.v1.PathSegment
-	(*Gateway)(nil),                       // 33: encore.parser.meta.v1.Gateway
-	(*CronJob)(nil),                       // 34: encore.parser.meta.v1.CronJob
-	(*SQLDatabase)(nil),                   // 35: encore.parser.meta.v1.SQLDatabase
-	(*DBMigration)(nil),                   // 36: encore.parser.meta.v1.DBMigration
-	(*Bucket)(nil),                        // 37: encore.parser.meta.v1.Bucket
-	(*PubSubTopic)(nil),                   // 38: encore.parser.meta.v1.PubSubTopic
-	(*CacheCluster)(nil),                  // 39: encore.parser.meta.v1.CacheCluster
-	(*Metric)(nil),                        // 40: encore.parser.meta.v1.Metric
-	nil,                                   // 41: encore.parser.meta.v1.RPC.ExposeEntry
-	(*RPC_ExposeOptions)(nil),             // 42: encore.parser.meta.v1.RPC.ExposeOptions
-	(*RPC_StaticAssets)(nil),              // 43: encore.parser.meta.v1.RPC.StaticAssets
-	(*RPC_StaticAssets_HeaderValues)(nil), // 44: encore.parser.meta.v1.RPC.StaticAssets.HeaderValues
-	nil,                                   // 45: encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry
-	(*Gateway_Explicit)(nil),              // 46: encore.parser.meta.v1.Gateway.Explicit
-	(*PubSubTopic_Publisher)(nil),         // 47: encore.parser.meta.v1.PubSubTopic.Publisher
-	(*PubSubTopic_Subscription)(nil),      // 48: encore.parser.meta.v1.PubSubTopic.Subscription
-	(*PubSubTopic_RetryPolicy)(nil),       // 49: encore.parser.meta.v1.PubSubTopic.RetryPolicy
-	(*CacheCluster_Keyspace)(nil),         // 50: encore.parser.meta.v1.CacheCluster.Keyspace
-	(*Metric_Label)(nil),                  // 51: encore.parser.meta.v1.Metric.Label
-	(*v1.Decl)(nil),                       // 52: encore.parser.schema.v1.Decl
-	(*v1.Type)(nil),                       // 53: encore.parser.schema.v1.Type
-	(*v1.Loc)(nil),                        // 54: encore.parser.schema.v1.Loc
-	(*v1.ValidationExpr)(nil),             // 55: encore.parser.schema.v1.ValidationExpr
-	(v1.Builtin)(0),                       // 56: encore.parser.schema.v1.Builtin
+	(CacheCluster_Keyspace_Kind)(0),       // 10: encore.parser.meta.v1.CacheCluster.Keyspace.Kind
+	(Metric_MetricKind)(0),                // 11: encore.parser.meta.v1.Metric.MetricKind
+	(*Data)(nil),                          // 12: encore.parser.meta.v1.Data
+	(*QualifiedName)(nil),                 // 13: encore.parser.meta.v1.QualifiedName
+	(*Package)(nil),                       // 14: encore.parser.meta.v1.Package
+	(*Service)(nil),                       // 15: encore.parser.meta.v1.Service
+	(*BucketUsage)(nil),                   // 16: encore.parser.meta.v1.BucketUsage
+	(*Selector)(nil),                      // 17: encore.parser.meta.v1.Selector
+	(*RPC)(nil),                           // 18: encore.parser.meta.v1.RPC
+	(*AuthHandler)(nil),                   // 19: encore.parser.meta.v1.AuthHandler
+	(*Middleware)(nil),                    // 20: encore.parser.meta.v1.Middleware
+	(*TraceNode)(nil),                     // 21: encore.parser.meta.v1.TraceNode
+	(*RPCDefNode)(nil),                    // 22: encore.parser.meta.v1.RPCDefNode
+	(*RPCCallNode)(nil),                   // 23: encore.parser.meta.v1.RPCCallNode
+	(*StaticCallNode)(nil),                // 24: encore.parser.meta.v1.StaticCallNode
+	(*AuthHandlerDefNode)(nil),            // 25: encore.parser.meta.v1.AuthHandlerDefNode
+	(*PubSubTopicDefNode)(nil),            // 26: encore.parser.meta.v1.PubSubTopicDefNode
+	(*PubSubPublishNode)(nil),             // 27: encore.parser.meta.v1.PubSubPublishNode
+	(*PubSubSubscriberNode)(nil),          // 28: encore.parser.meta.v1.PubSubSubscriberNode
+	(*ServiceInitNode)(nil),               // 29: encore.parser.meta.v1.ServiceInitNode
+	(*MiddlewareDefNode)(nil),             // 30: encore.parser.meta.v1.MiddlewareDefNode
+	(*CacheKeyspaceDefNode)(nil),          // 31: encore.parser.meta.v1.CacheKeyspaceDefNode
+	(*Path)(nil),                          // 32: encore.parser.meta.v1.Path
+	(*PathSegment)(nil),                   // 33: encore.parser.meta.v1.PathSegment
+	(*Gateway)(nil),                       // 34: encore.parser.meta.v1.Gateway
+	(*CronJob)(nil),                       // 35: encore.parser.meta.v1.CronJob
+	(*MonitorCheck)(nil),                  // 36: encore.parser.meta.v1.MonitorCheck
+	(*AlertRule)(nil),                     // 37: encore.parser.meta.v1.AlertRule
+	(*FeatureFlag)(nil),                   // 38: encore.parser.meta.v1.FeatureFlag
+	(*SQLDatabase)(nil),                   // 39: encore.parser.meta.v1.SQLDatabase
+	(*DBMigration)(nil),                   // 40: encore.parser.meta.v1.DBMigration
+	(*Bucket)(nil),                        // 41: encore.parser.meta.v1.Bucket
+	(*PubSubTopic)(nil),                   // 42: encore.parser.meta.v1.PubSubTopic
+	(*CacheCluster)(nil),                  // 43: encore.parser.meta.v1.CacheCluster
+	(*Metric)(nil),                        // 44: encore.parser.meta.v1.Metric
+	(*ErrorDefinition)(nil),               // 45: encore.parser.meta.v1.ErrorDefinition
+	nil,                                   // 46: encore.parser.meta.v1.RPC.ExposeEntry
+	(*RPC_ExposeOptions)(nil),             // 47: encore.parser.meta.v1.RPC.ExposeOptions
+	(*RPC_StaticAssets)(nil),              // 48: encore.parser.meta.v1.RPC.StaticAssets
+	(*RPC_StaticAssets_HeaderValues)(nil), // 49: encore.parser.meta.v1.RPC.StaticAssets.HeaderValues
+	nil,                                   // 50: encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry
+	(*Gateway_Explicit)(nil),              // 51: encore.parser.meta.v1.Gateway.Explicit
+	(*AlertRule_ErrorRate)(nil),           // 52: encore.parser.meta.v1.AlertRule.ErrorRate
+	(*AlertRule_Latency)(nil),             // 53: encore.parser.meta.v1.AlertRule.Latency
+	(*AlertRule_QueueLag)(nil),            // 54: encore.parser.meta.v1.AlertRule.QueueLag
+	nil,                                   // 55: encore.parser.meta.v1.FeatureFlag.AttributesEntry
+	nil,                                   // 56: encore.parser.meta.v1.SQLDatabase.TagsEntry
+	nil,                                   // 57: encore.parser.meta.v1.Bucket.TagsEntry
+	(*Bucket_Lifecycle)(nil),              // 58: encore.parser.meta.v1.Bucket.Lifecycle
+	nil,                                   // 59: encore.parser.meta.v1.PubSubTopic.TagsEntry
+	(*PubSubTopic_Publisher)(nil),         // 60: encore.parser.meta.v1.PubSubTopic.Publisher
+	(*PubSubTopic_Subscription)(nil),      // 61: encore.parser.meta.v1.PubSubTopic.Subscription
+	(*PubSubTopic_RetryPolicy)(nil),       // 62: encore.parser.meta.v1.PubSubTopic.RetryPolicy
+	(*PubSubTopic_DeadLetterPolicy)(nil),  // 63: encore.parser.meta.v1.PubSubTopic.DeadLetterPolicy
+	nil,                                   // 64: encore.parser.meta.v1.CacheCluster.TagsEntry
+	(*CacheCluster_Keyspace)(nil),         // 65: encore.parser.meta.v1.CacheCluster.Keyspace
+	(*Metric_Label)(nil),                  // 66: encore.parser.meta.v1.Metric.Label
+	(*v1.Decl)(nil),                       // 67: encore.parser.schema.v1.Decl
+	(*v1.Type)(nil),                       // 68: encore.parser.schema.v1.Type
+	(*v1.Loc)(nil),                        // 69: encore.parser.schema.v1.Loc
+	(*v1.ValidationExpr)(nil),             // 70: encore.parser.schema.v1.ValidationExpr
+	(v1.Builtin)(0),                       // 71: encore.parser.schema.v1.Builtin
 }
 var file_encore_parser_meta_v1_meta_proto_depIdxs = []int32{
-	52, // 0: encore.parser.meta.v1.Data.decls:type_name -> encore.parser.schema.v1.Decl
-	13, // 1: encore.parser.meta.v1.Data.pkgs:type_name -> encore.parser.meta.v1.Package
-	14, // 2: encore.parser.meta.v1.Data.svcs:type_name -> encore.parser.meta.v1.Service
-	18, // 3: encore.parser.meta.v1.Data.auth_handler:type_name -> encore.parser.meta.v1.AuthHandler
-	34, // 4: encore.parser.meta.v1.Data.cron_jobs:type_name -> encore.parser.meta.v1.CronJob
-	38, // 5: encore.parser.meta.v1.Data.pubsub_topics:type_name -> encore.parser.meta.v1.PubSubTopic
-	19, // 6: encore.parser.meta.v1.Data.middleware:type_name -> encore.parser.meta.v1.Middleware
-	39, // 7: encore.parser.meta.v1.Data.cache_clusters:type_name -> encore.parser.meta.v1.CacheCluster
-	40, // 8: encore.parser.meta.v1.Data.metrics:type_name -> encore.parser.meta.v1.Metric
-	35, // 9: encore.parser.meta.v1.Data.sql_databases:type_name -> encore.parser.meta.v1.SQLDatabase
-	33, // 10: encore.parser.meta.v1.Data.gateways:type_name -> encore.parser.meta.v1.Gateway
+	67, // 0: encore.parser.meta.v1.Data.decls:type_name -> encore.parser.schema.v1.Decl
+	14, // 1: encore.parser.meta.v1.Data.pkgs:type_name -> encore.parser.meta.v1.Package
+	15, // 2: encore.parser.meta.v1.Data.svcs:type_name -> encore.parser.meta.v1.Service
+	19, // 3: encore.parser.meta.v1.Data.auth_handler:type_name -> encore.parser.meta.v1.AuthHandler
+	35, // 4: encore.parser.meta.v1.Data.cron_jobs:type_name -> encore.parser.meta.v1.CronJob
+	42, // 5: encore.parser.meta.v1.Data.pubsub_topics:type_name -> encore.parser.meta.v1.PubSubTopic
+	20, // 6: encore.parser.meta.v1.Data.middleware:type_name -> encore.parser.meta.v1.Middleware
+	43, // 7: encore.parser.meta.v1.Data.cache_clusters:type_name -> encore.parser.meta.v1.CacheCluster
+	44, // 8: encore.parser.meta.v1.Data.metrics:type_name -> encore.parser.meta.v1.Metric
+	39, // 9: encore.parser.meta.v1.Data.sql_databases:type_name -> encore.parser.meta.v1.SQLDatabase
+	34, // 10: encore.parser.meta.v1.Data.gateways:type_name -> encore.parser.meta.v1.Gateway
 	0,  // 11: encore.parser.meta.v1.Data.language:type_name -> encore.parser.meta.v1.Lang
-	37, // 12: encore.parser.meta.v1.Data.buckets:type_name -> encore.parser.meta.v1.Bucket
-	12, // 13: encore.parser.meta.v1.Package.rpc_calls:type_name -> encore.parser.meta.v1.QualifiedName
-	20, // 14: encore.parser.meta.v1.Package.trace_nodes:type_name -> encore.parser.meta.v1.TraceNode
-	17, // 15: encore.parser.meta.v1.Service.rpcs:type_name -> encore.parser.meta.v1.RPC
-	36, // 16: encore.parser.meta.v1.Service.migrations:type_name -> encore.parser.meta.v1.DBMigration
-	15, // 17: encore.parser.meta.v1.Service.buckets:type_name -> encore.parser.meta.v1.BucketUsage
-	1,  // 18: encore.parser.meta.v1.BucketUsage.operations:type_name -> encore.parser.meta.v1.BucketUsage.Operation
-	2,  // 19: encore.parser.meta.v1.Selector.type:type_name -> encore.parser.meta.v1.Selector.Type
-	3,  // 20: encore.parser.meta.v1.RPC.access_type:type_name -> encore.parser.meta.v1.RPC.AccessType
-	53, // 21: encore.parser.meta.v1.RPC.request_schema:type_name -> encore.parser.schema.v1.Type
-	53, // 22: encore.parser.meta.v1.RPC.response_schema:type_name -> encore.parser.schema.v1.Type
-	4,  // 23: encore.parser.meta.v1.RPC.proto:type_name -> encore.parser.meta.v1.RPC.Protocol
-	54, // 24: encore.parser.meta.v1.RPC.loc:type_name -> encore.parser.schema.v1.Loc
-	31, // 25: encore.parser.meta.v1.RPC.path:type_name -> encore.parser.meta.v1.Path
-	16, // 26: encore.parser.meta.v1.RPC.tags:type_name -> encore.parser.meta.v1.Selector
-	41, // 27: encore.parser.meta.v1.RPC.expose:type_name -> encore.parser.meta.v1.RPC.ExposeEntry
-	53, // 28: encore.parser.meta.v1.RPC.handshake_schema:type_name -> encore.parser.schema.v1.Type
-	43, // 29: encore.parser.meta.v1.RPC.static_assets:type_name -> encore.parser.meta.v1.RPC.StaticAssets
-	54, // 30: encore.parser.meta.v1.AuthHandler.loc:type_name -> encore.parser.schema.v1.Loc
-	53, // 31: encore.parser.meta.v1.AuthHandler.auth_data:type_name -> encore.parser.schema.v1.Type
-	53, // 32: encore.parser.meta.v1.AuthHandler.params:type_name -> encore.parser.schema.v1.Type
-	12, // 33: encore.parser.meta.v1.Middleware.name:type_name -> encore.parser.meta.v1.QualifiedName
-	54, // 34: encore.parser.meta.v1.Middleware.loc:type_name -> encore.parser.schema.v1.Loc
-	16, // 35: encore.parser.meta.v1.Middleware.target:type_name -> encore.parser.meta.v1.Selector
-	21, // 36: encore.parser.meta.v1.TraceNode.rpc_def:type_name -> encore.parser.meta.v1.RPCDefNode
-	22, // 37: encore.parser.meta.v1.TraceNode.rpc_call:type_name -> encore.parser.meta.v1.RPCCallNode
-	23, // 38: encore.parser.meta.v1.TraceNode.static_call:type_name -> encore.parser.meta.v1.StaticCallNode
-	24, // 39: encore.parser.meta.v1.TraceNode.auth_handler_def:type_name -> encore.parser.meta.v1.AuthHandlerDefNode
-	25, // 40: encore.parser.meta.v1.TraceNode.pubsub_topic_def:type_name -> encore.parser.meta.v1.PubSubTopicDefNode
-	26, // 41: encore.parser.meta.v1.TraceNode.pubsub_publish:type_name -> encore.parser.meta.v1.PubSubPublishNode
-	27, // 42: encore.parser.meta.v1.TraceNode.pubsub_subscriber:type_name -> encore.parser.meta.v1.PubSubSubscriberNode
-	28, // 43: encore.parser.meta.v1.TraceNode.service_init:type_name -> encore.parser.meta.v1.ServiceInitNode
-	29, // 44: encore.parser.meta.v1.TraceNode.middleware_def:type_name -> encore.parser.meta.v1.MiddlewareDefNode
-	30, // 45: encore.parser.meta.v1.TraceNode.cache_keyspace:type_name -> encore.parser.meta.v1.CacheKeyspaceDefNode
-	5,  // 46: encore.parser.meta.v1.StaticCallNode.package:type_name -> encore.parser.meta.v1.StaticCallNode.Package
-	16, // 47: encore.parser.meta.v1.MiddlewareDefNode.target:type_name -> encore.parser.meta.v1.Selector
-	32, // 48: encore.parser.meta.v1.Path.segments:type_name -> encore.parser.meta.v1.PathSegment
-	6,  // 49: encore.parser.meta.v1.Path.type:type_name -> encore.parser.meta.v1.Path.Type
-	7,  // 50: encore.parser.meta.v1.PathSegment.type:type_name -> encore.parser.meta.v1.PathSegment.SegmentType
-	8,  // 51: encore.parser.meta.v1.PathSegment.value_type:type_name -> encore.parser.meta.v1.PathSegment.ParamType
-	55, // 52: encore.parser.meta.v1.PathSegment.validation:type_name -> encore.parser.schema.v1.ValidationExpr
-	46, // 53: encore.parser.meta.v1.Gateway.explicit:type_name -> encore.parser.meta.v1.Gateway.Explicit
-	12, // 54: encore.parser.meta.v1.CronJob.endpoint:type_name -> encore.parser.meta.v1.QualifiedName
-	36, // 55: encore.parser.meta.v1.SQLDatabase.migrations:type_name -> encore.parser.meta.v1.DBMigration
-	53, // 56: encore.parser.meta.v1.PubSubTopic.message_type:type_name -> encore.parser.schema.v1.Type
-	9,  // 57: encore.parser.meta.v1.PubSubTopic.delivery_guarantee:type_name -> encore.parser.meta.v1.PubSubTopic.DeliveryGuarantee
-	47, // 58: encore.parser.meta.v1.PubSubTopic.publishers:type_name -> encore.parser.meta.v1.PubSubTopic.Publisher
-	48, // 59: encore.parser.meta.v1.PubSubTopic.subscriptions:type_name -> encore.parser.meta.v1.PubSubTopic.Subscription
-	50, // 60: encore.parser.meta.v1.CacheCluster.keyspaces:type_name -> encore.parser.meta.v1.CacheCluster.Keyspace
-	56, // 61: encore.parser.meta.v1.Metric.value_type:type_name -> encore.parser.schema.v1.Builtin
-	10, // 62: encore.parser.meta.v1.Metric.kind:type_name -> encore.parser.meta.v1.Metric.MetricKind
-	51, // 63: encore.parser.meta.v1.Metric.labels:type_name -> encore.parser.meta.v1.Metric.Label
-	42, // 64: encore.parser.meta.v1.RPC.ExposeEntry.value:type_name -> encore.parser.meta.v1.RPC.ExposeOptions
-	45, // 65: encore.parser.meta.v1.RPC.StaticAssets.headers:type_name -> encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry
-	44, // 66: encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry.value:type_name -> encore.parser.meta.v1.RPC.StaticAssets.HeaderValues
-	18, // 67: encore.parser.meta.v1.Gateway.Explicit.auth_handler:type_name -> encore.parser.meta.v1.AuthHandler
-	49, // 68: encore.parser.meta.v1.PubSubTopic.Subscription.retry_policy:type_name -> encore.parser.meta.v1.PubSubTopic.RetryPolicy
-	53, // 69: encore.parser.meta.v1.CacheCluster.Keyspace.key_type:type_name -> encore.parser.schema.v1.Type
-	53, // 70: encore.parser.meta.v1.CacheCluster.Keyspace.value_type:type_name -> encore.parser.schema.v1.Type
-	31, // 71: encore.parser.meta.v1.CacheCluster.Keyspace.path_pattern:type_name -> encore.parser.meta.v1.Path
-	56, // 72: encore.parser.meta.v1.Metric.Label.type:type_name -> encore.parser.schema.v1.Builtin
-	73, // [73:73] is the sub-list for method output_type
-	73, // [73:73] is the sub-list for method input_type
-	73, // [73:73] is the sub-list for extension type_name
-	73, // [73:73] is the sub-list for extension extendee
-	0,  // [0:73] is the sub-list for field type_name
+	41, // 12: encore.parser.meta.v1.Data.buckets:type_name -> encore.parser.meta.v1.Bucket
+	36, // 13: encore.parser.meta.v1.Data.monitor_checks:type_name -> encore.parser.meta.v1.MonitorCheck
+	37, // 14: encore.parser.meta.v1.Data.alert_rules:type_name -> encore.parser.meta.v1.AlertRule
+	38, // 15: encore.parser.meta.v1.Data.feature_flags:type_name -> encore.parser.meta.v1.FeatureFlag
+	45, // 16: encore.parser.meta.v1.Data.error_definitions:type_name -> encore.parser.meta.v1.ErrorDefinition
+	13, // 17: encore.parser.meta.v1.Package.rpc_calls:type_name -> encore.parser.meta.v1.QualifiedName
+	21, // 18: encore.parser.meta.v1.Package.trace_nodes:type_name -> encore.parser.meta.v1.TraceNode
+	18, // 19: encore.parser.meta.v1.Service.rpcs:type_name -> encore.parser.meta.v1.RPC
+	40, // 20: encore.parser.meta.v1.Service.migrations:type_name -> encore.parser.meta.v1.DBMigration
+	16, // 21: encore.parser.meta.v1.Service.buckets:type_name -> encore.parser.meta.v1.BucketUsage
+	68, // 22: encore.parser.meta.v1.Service.configs:type_name -> encore.parser.schema.v1.Type
+	1,  // 23: encore.parser.meta.v1.BucketUsage.operations:type_name -> encore.parser.meta.v1.BucketUsage.Operation
+	2,  // 24: encore.parser.meta.v1.Selector.type:type_name -> encore.parser.meta.v1.Selector.Type
+	3,  // 25: encore.parser.meta.v1.RPC.access_type:type_name -> encore.parser.meta.v1.RPC.AccessType
+	68, // 26: encore.parser.meta.v1.RPC.request_schema:type_name -> encore.parser.schema.v1.Type
+	68, // 27: encore.parser.meta.v1.RPC.response_schema:type_name -> encore.parser.schema.v1.Type
+	4,  // 28: encore.parser.meta.v1.RPC.proto:type_name -> encore.parser.meta.v1.RPC.Protocol
+	69, // 29: encore.parser.meta.v1.RPC.loc:type_name -> encore.parser.schema.v1.Loc
+	32, // 30: encore.parser.meta.v1.RPC.path:type_name -> encore.parser.meta.v1.Path
+	17, // 31: encore.parser.meta.v1.RPC.tags:type_name -> encore.parser.meta.v1.Selector
+	46, // 32: encore.parser.meta.v1.RPC.expose:type_name -> encore.parser.meta.v1.RPC.ExposeEntry
+	68, // 33: encore.parser.meta.v1.RPC.handshake_schema:type_name -> encore.parser.schema.v1.Type
+	48, // 34: encore.parser.meta.v1.RPC.static_assets:type_name -> encore.parser.meta.v1.RPC.StaticAssets
+	32, // 35: encore.parser.meta.v1.RPC.unversioned_path:type_name -> encore.parser.meta.v1.Path
+	69, // 36: encore.parser.meta.v1.AuthHandler.loc:type_name -> encore.parser.schema.v1.Loc
+	68, // 37: encore.parser.meta.v1.AuthHandler.auth_data:type_name -> encore.parser.schema.v1.Type
+	68, // 38: encore.parser.meta.v1.AuthHandler.params:type_name -> encore.parser.schema.v1.Type
+	13, // 39: encore.parser.meta.v1.Middleware.name:type_name -> encore.parser.meta.v1.QualifiedName
+	69, // 40: encore.parser.meta.v1.Middleware.loc:type_name -> encore.parser.schema.v1.Loc
+	17, // 41: encore.parser.meta.v1.Middleware.target:type_name -> encore.parser.meta.v1.Selector
+	22, // 42: encore.parser.meta.v1.TraceNode.rpc_def:type_name -> encore.parser.meta.v1.RPCDefNode
+	23, // 43: encore.parser.meta.v1.TraceNode.rpc_call:type_name -> encore.parser.meta.v1.RPCCallNode
+	24, // 44: encore.parser.meta.v1.TraceNode.static_call:type_name -> encore.parser.meta.v1.StaticCallNode
+	25, // 45: encore.parser.meta.v1.TraceNode.auth_handler_def:type_name -> encore.parser.meta.v1.AuthHandlerDefNode
+	26, // 46: encore.parser.meta.v1.TraceNode.pubsub_topic_def:type_name -> encore.parser.meta.v1.PubSubTopicDefNode
+	27, // 47: encore.parser.meta.v1.TraceNode.pubsub_publish:type_name -> encore.parser.meta.v1.PubSubPublishNode
+	28, // 48: encore.parser.meta.v1.TraceNode.pubsub_subscriber:type_name -> encore.parser.meta.v1.PubSubSubscriberNode
+	29, // 49: encore.parser.meta.v1.TraceNode.service_init:type_name -> encore.parser.meta.v1.ServiceInitNode
+	30, // 50: encore.parser.meta.v1.TraceNode.middleware_def:type_name -> encore.parser.meta.v1.MiddlewareDefNode
+	31, // 51: encore.parser.meta.v1.TraceNode.cache_keyspace:type_name -> encore.parser.meta.v1.CacheKeyspaceDefNode
+	5,  // 52: encore.parser.meta.v1.StaticCallNode.package:type_name -> encore.parser.meta.v1.StaticCallNode.Package
+	17, // 53: encore.parser.meta.v1.MiddlewareDefNode.target:type_name -> encore.parser.meta.v1.Selector
+	33, // 54: encore.parser.meta.v1.Path.segments:type_name -> encore.parser.meta.v1.PathSegment
+	6,  // 55: encore.parser.meta.v1.Path.type:type_name -> encore.parser.meta.v1.Path.Type
+	7,  // 56: encore.parser.meta.v1.PathSegment.type:type_name -> encore.parser.meta.v1.PathSegment.SegmentType
+	8,  // 57: encore.parser.meta.v1.PathSegment.value_type:type_name -> encore.parser.meta.v1.PathSegment.ParamType
+	70, // 58: encore.parser.meta.v1.PathSegment.validation:type_name -> encore.parser.schema.v1.ValidationExpr
+	51, // 59: encore.parser.meta.v1.Gateway.explicit:type_name -> encore.parser.meta.v1.Gateway.Explicit
+	13, // 60: encore.parser.meta.v1.CronJob.endpoint:type_name -> encore.parser.meta.v1.QualifiedName
+	52, // 61: encore.parser.meta.v1.AlertRule.error_rate:type_name -> encore.parser.meta.v1.AlertRule.ErrorRate
+	53, // 62: encore.parser.meta.v1.AlertRule.latency:type_name -> encore.parser.meta.v1.AlertRule.Latency
+	54, // 63: encore.parser.meta.v1.AlertRule.queue_lag:type_name -> encore.parser.meta.v1.AlertRule.QueueLag
+	55, // 64: encore.parser.meta.v1.FeatureFlag.attributes:type_name -> encore.parser.meta.v1.FeatureFlag.AttributesEntry
+	40, // 65: encore.parser.meta.v1.SQLDatabase.migrations:type_name -> encore.parser.meta.v1.DBMigration
+	56, // 66: encore.parser.meta.v1.SQLDatabase.tags:type_name -> encore.parser.meta.v1.SQLDatabase.TagsEntry
+	57, // 67: encore.parser.meta.v1.Bucket.tags:type_name -> encore.parser.meta.v1.Bucket.TagsEntry
+	58, // 68: encore.parser.meta.v1.Bucket.lifecycle:type_name -> encore.parser.meta.v1.Bucket.Lifecycle
+	68, // 69: encore.parser.meta.v1.PubSubTopic.message_type:type_name -> encore.parser.schema.v1.Type
+	9,  // 70: encore.parser.meta.v1.PubSubTopic.delivery_guarantee:type_name -> encore.parser.meta.v1.PubSubTopic.DeliveryGuarantee
+	60, // 71: encore.parser.meta.v1.PubSubTopic.publishers:type_name -> encore.parser.meta.v1.PubSubTopic.Publisher
+	61, // 72: encore.parser.meta.v1.PubSubTopic.subscriptions:type_name -> encore.parser.meta.v1.PubSubTopic.Subscription
+	59, // 73: encore.parser.meta.v1.PubSubTopic.tags:type_name -> encore.parser.meta.v1.PubSubTopic.TagsEntry
+	65, // 74: encore.parser.meta.v1.CacheCluster.keyspaces:type_name -> encore.parser.meta.v1.CacheCluster.Keyspace
+	64, // 75: encore.parser.meta.v1.CacheCluster.tags:type_name -> encore.parser.meta.v1.CacheCluster.TagsEntry
+	71, // 76: encore.parser.meta.v1.Metric.value_type:type_name -> encore.parser.schema.v1.Builtin
+	11, // 77: encore.parser.meta.v1.Metric.kind:type_name -> encore.parser.meta.v1.Metric.MetricKind
+	66, // 78: encore.parser.meta.v1.Metric.labels:type_name -> encore.parser.meta.v1.Metric.Label
+	47, // 79: encore.parser.meta.v1.RPC.ExposeEntry.value:type_name -> encore.parser.meta.v1.RPC.ExposeOptions
+	50, // 80: encore.parser.meta.v1.RPC.StaticAssets.headers:type_name -> encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry
+	49, // 81: encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry.value:type_name -> encore.parser.meta.v1.RPC.StaticAssets.HeaderValues
+	19, // 82: encore.parser.meta.v1.Gateway.Explicit.auth_handler:type_name -> encore.parser.meta.v1.AuthHandler
+	62, // 83: encore.parser.meta.v1.PubSubTopic.Subscription.retry_policy:type_name -> encore.parser.meta.v1.PubSubTopic.RetryPolicy
+	63, // 84: encore.parser.meta.v1.PubSubTopic.Subscription.dead_letter_policy:type_name -> encore.parser.meta.v1.PubSubTopic.DeadLetterPolicy
+	68, // 85: encore.parser.meta.v1.CacheCluster.Keyspace.key_type:type_name -> encore.parser.schema.v1.Type
+	68, // 86: encore.parser.meta.v1.CacheCluster.Keyspace.value_type:type_name -> encore.parser.schema.v1.Type
+	32, // 87: encore.parser.meta.v1.CacheCluster.Keyspace.path_pattern:type_name -> encore.parser.meta.v1.Path
+	10, // 88: encore.parser.meta.v1.CacheCluster.Keyspace.kind:type_name -> encore.parser.meta.v1.CacheCluster.Keyspace.Kind
+	71, // 89: encore.parser.meta.v1.Metric.Label.type:type_name -> encore.parser.schema.v1.Builtin
+	90, // [90:90] is the sub-list for method output_type
+	90, // [90:90] is the sub-list for method input_type
+	90, // [90:90] is the sub-list for extension type_name
+	90, // [90:90] is the sub-list for extension extendee
+	0,  // [0:90] is the sub-list for field type_name
 }
 
 func init() { file_encore_parser_meta_v1_meta_proto_init() }
@@ -4180,19 +5420,29 @@ func file_encore_parser_meta_v1_meta_proto_init() {
 	file_encore_parser_meta_v1_meta_proto_msgTypes[22].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[23].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[24].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[25].OneofWrappers = []any{
+		(*AlertRule_ErrorRate_)(nil),
+		(*AlertRule_Latency_)(nil),
+		(*AlertRule_QueueLag_)(nil),
+	}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[26].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[27].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[29].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[30].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[32].OneofWrappers = []any{}
-	file_encore_parser_meta_v1_meta_proto_msgTypes[35].OneofWrappers = []any{}
-	file_encore_parser_meta_v1_meta_proto_msgTypes[37].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[33].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[36].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[39].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[40].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[41].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[49].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_parser_meta_v1_meta_proto_rawDesc), len(file_encore_parser_meta_v1_meta_proto_rawDesc)),
-			NumEnums:      11,
-			NumMessages:   41,
+			NumEnums:      12,
+			NumMessages:   55,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
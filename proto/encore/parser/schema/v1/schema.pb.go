@@ -49,6 +49,8 @@ const (
 	Builtin_INT     Builtin = 18
 	Builtin_UINT    Builtin = 19
 	Builtin_DECIMAL Builtin = 20
+	// A file uploaded in a multipart request.
+	Builtin_FILE Builtin = 21
 )
 
 // Enum value maps for Builtin.
@@ -75,6 +77,7 @@ var (
 		18: "INT",
 		19: "UINT",
 		20: "DECIMAL",
+		21: "FILE",
 	}
 	Builtin_value = map[string]int32{
 		"ANY":     0,
@@ -98,6 +101,7 @@ var (
 		"INT":     18,
 		"UINT":    19,
 		"DECIMAL": 20,
+		"FILE":    21,
 	}
 )
 
@@ -1124,8 +1128,13 @@ type Field struct {
 	RawTag          string                 `protobuf:"bytes,7,opt,name=raw_tag,json=rawTag,proto3" json:"raw_tag,omitempty"`                              // The original Go struct tag; should not be parsed individually
 	Tags            []*Tag                 `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`                                                // Parsed go struct tags. Used for marshalling hints
 	Wire            *WireSpec              `protobuf:"bytes,9,opt,name=wire,proto3,oneof" json:"wire,omitempty"`                                          // The explicitly set wire location of the field.
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Whether the field is encoded as a JSON string on the wire.
+	// Used for 64-bit integers, which would otherwise lose precision in JavaScript.
+	StringEncoded bool `protobuf:"varint,10,opt,name=string_encoded,json=stringEncoded,proto3" json:"string_encoded,omitempty"`
+	// The maximum decoded size in bytes of a []byte field, if limited.
+	MaxSize       *uint64 `protobuf:"varint,11,opt,name=max_size,json=maxSize,proto3,oneof" json:"max_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Field) Reset() {
@@ -1221,6 +1230,20 @@ func (x *Field) GetWire() *WireSpec {
 	return nil
 }
 
+func (x *Field) GetStringEncoded() bool {
+	if x != nil {
+		return x.StringEncoded
+	}
+	return false
+}
+
+func (x *Field) GetMaxSize() uint64 {
+	if x != nil && x.MaxSize != nil {
+		return *x.MaxSize
+	}
+	return 0
+}
+
 // WireLocation provides information about how a field should be encoded on the wire.
 type WireSpec struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2150,7 +2173,7 @@ const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12D\n" +
 	"\x0etype_arguments\x18\x02 \x03(\v2\x1d.encore.parser.schema.v1.TypeR\rtypeArguments\"@\n" +
 	"\x06Struct\x126\n" +
-	"\x06fields\x18\x01 \x03(\v2\x1e.encore.parser.schema.v1.FieldR\x06fields\"\xd3\x02\n" +
+	"\x06fields\x18\x01 \x03(\v2\x1e.encore.parser.schema.v1.FieldR\x06fields\"\xa7\x03\n" +
 	"\x05Field\x12/\n" +
 	"\x03typ\x18\x01 \x01(\v2\x1d.encore.parser.schema.v1.TypeR\x03typ\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
@@ -2160,8 +2183,12 @@ const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
 	"\x11query_string_name\x18\x06 \x01(\tR\x0fqueryStringName\x12\x17\n" +
 	"\araw_tag\x18\a \x01(\tR\x06rawTag\x120\n" +
 	"\x04tags\x18\b \x03(\v2\x1c.encore.parser.schema.v1.TagR\x04tags\x12:\n" +
-	"\x04wire\x18\t \x01(\v2!.encore.parser.schema.v1.WireSpecH\x00R\x04wire\x88\x01\x01B\a\n" +
-	"\x05_wire\"\xc1\x03\n" +
+	"\x04wire\x18\t \x01(\v2!.encore.parser.schema.v1.WireSpecH\x00R\x04wire\x88\x01\x01\x12%\n" +
+	"\x0estring_encoded\x18\n" +
+	" \x01(\bR\rstringEncoded\x12\x1e\n" +
+	"\bmax_size\x18\v \x01(\x04H\x01R\amaxSize\x88\x01\x01B\a\n" +
+	"\x05_wireB\v\n" +
+	"\t_max_size\"\xc1\x03\n" +
 	"\bWireSpec\x12B\n" +
 	"\x06header\x18\x01 \x01(\v2(.encore.parser.schema.v1.WireSpec.HeaderH\x00R\x06header\x12?\n" +
 	"\x05query\x18\x02 \x01(\v2'.encore.parser.schema.v1.WireSpec.QueryH\x00R\x05query\x12B\n" +
@@ -2205,7 +2232,7 @@ const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
 	"\x05value\"d\n" +
 	"\vConfigValue\x121\n" +
 	"\x04elem\x18\x01 \x01(\v2\x1d.encore.parser.schema.v1.TypeR\x04elem\x12\"\n" +
-	"\fIsValuesList\x18\x02 \x01(\bR\fIsValuesList*\xf2\x01\n" +
+	"\fIsValuesList\x18\x02 \x01(\bR\fIsValuesList*\xfc\x01\n" +
 	"\aBuiltin\x12\a\n" +
 	"\x03ANY\x10\x00\x12\b\n" +
 	"\x04BOOL\x10\x01\x12\b\n" +
@@ -2232,7 +2259,8 @@ const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
 	"\aUSER_ID\x10\x11\x12\a\n" +
 	"\x03INT\x10\x12\x12\b\n" +
 	"\x04UINT\x10\x13\x12\v\n" +
-	"\aDECIMAL\x10\x14B(Z&encr.dev/proto/encore/parser/schema/v1b\x06proto3"
+	"\aDECIMAL\x10\x14\x12\b\n" +
+	"\x04FILE\x10\x15B(Z&encr.dev/proto/encore/parser/schema/v1b\x06proto3"
 
 var (
 	file_encore_parser_schema_v1_schema_proto_rawDescOnce sync.Once
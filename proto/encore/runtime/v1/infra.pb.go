@@ -9,6 +9,7 @@ package runtimev1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -587,8 +588,17 @@ type SQLConnectionPool struct {
 	// The minimum and maximum number of connections to use.
 	MinConnections int32 `protobuf:"varint,3,opt,name=min_connections,json=minConnections,proto3" json:"min_connections,omitempty"`
 	MaxConnections int32 `protobuf:"varint,4,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// How long a connection may be idle before it's closed.
+	// If unset the runtime default is used.
+	MaxIdleTime *durationpb.Duration `protobuf:"bytes,5,opt,name=max_idle_time,json=maxIdleTime,proto3" json:"max_idle_time,omitempty"`
+	// How long a connection may be open before it's closed and replaced.
+	// If unset the runtime default is used.
+	MaxConnLifetime *durationpb.Duration `protobuf:"bytes,6,opt,name=max_conn_lifetime,json=maxConnLifetime,proto3" json:"max_conn_lifetime,omitempty"`
+	// How long a single statement may run before it's canceled.
+	// If unset statements do not time out.
+	StatementTimeout *durationpb.Duration `protobuf:"bytes,7,opt,name=statement_timeout,json=statementTimeout,proto3" json:"statement_timeout,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *SQLConnectionPool) Reset() {
@@ -649,6 +659,27 @@ func (x *SQLConnectionPool) GetMaxConnections() int32 {
 	return 0
 }
 
+func (x *SQLConnectionPool) GetMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxIdleTime
+	}
+	return nil
+}
+
+func (x *SQLConnectionPool) GetMaxConnLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxConnLifetime
+	}
+	return nil
+}
+
+func (x *SQLConnectionPool) GetStatementTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.StatementTimeout
+	}
+	return nil
+}
+
 type RedisCluster struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this cluster.
@@ -1615,6 +1646,9 @@ type Bucket struct {
 	// Public base URL for accessing objects in this bucket.
 	// Must be set for public buckets.
 	PublicBaseUrl *string `protobuf:"bytes,5,opt,name=public_base_url,json=publicBaseUrl,proto3,oneof" json:"public_base_url,omitempty"`
+	// Client-side encryption configuration.
+	// Set for buckets using client-side encryption.
+	Encryption    *BucketEncryption `protobuf:"bytes,6,opt,name=encryption,proto3,oneof" json:"encryption,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1684,11 +1718,65 @@ func (x *Bucket) GetPublicBaseUrl() string {
 	return ""
 }
 
+func (x *Bucket) GetEncryption() *BucketEncryption {
+	if x != nil {
+		return x.Encryption
+	}
+	return nil
+}
+
+type BucketEncryption struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The KMS key used to encrypt the data keys of objects,
+	// either an AWS KMS key ARN or a GCP Cloud KMS key resource name.
+	// If empty, a key for local development is used.
+	KmsKey        string `protobuf:"bytes,1,opt,name=kms_key,json=kmsKey,proto3" json:"kms_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketEncryption) Reset() {
+	*x = BucketEncryption{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketEncryption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketEncryption) ProtoMessage() {}
+
+func (x *BucketEncryption) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketEncryption.ProtoReflect.Descriptor instead.
+func (*BucketEncryption) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BucketEncryption) GetKmsKey() string {
+	if x != nil {
+		return x.KmsKey
+	}
+	return ""
+}
+
 type Gateway struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique id for this resource.
 	Rid string `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
-	// The encore name of the gateway.
+	//  The encore name of the gateway.
 	EncoreName string `protobuf:"bytes,2,opt,name=encore_name,json=encoreName,proto3" json:"encore_name,omitempty"`
 	// The base url for reaching this gateway, for returning to the application
 	// via e.g. the metadata APIs.
@@ -1703,7 +1791,7 @@ type Gateway struct {
 
 func (x *Gateway) Reset() {
 	*x = Gateway{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[19]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1715,7 +1803,7 @@ func (x *Gateway) String() string {
 func (*Gateway) ProtoMessage() {}
 
 func (x *Gateway) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[19]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1728,7 +1816,7 @@ func (x *Gateway) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway.ProtoReflect.Descriptor instead.
 func (*Gateway) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{19}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Gateway) GetRid() string {
@@ -1777,7 +1865,7 @@ type Infrastructure_Credentials struct {
 
 func (x *Infrastructure_Credentials) Reset() {
 	*x = Infrastructure_Credentials{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1789,7 +1877,7 @@ func (x *Infrastructure_Credentials) String() string {
 func (*Infrastructure_Credentials) ProtoMessage() {}
 
 func (x *Infrastructure_Credentials) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1840,7 +1928,7 @@ type Infrastructure_Resources struct {
 
 func (x *Infrastructure_Resources) Reset() {
 	*x = Infrastructure_Resources{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1852,7 +1940,7 @@ func (x *Infrastructure_Resources) String() string {
 func (*Infrastructure_Resources) ProtoMessage() {}
 
 func (x *Infrastructure_Resources) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1920,7 +2008,7 @@ type RedisRole_AuthACL struct {
 
 func (x *RedisRole_AuthACL) Reset() {
 	*x = RedisRole_AuthACL{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1932,7 +2020,7 @@ func (x *RedisRole_AuthACL) String() string {
 func (*RedisRole_AuthACL) ProtoMessage() {}
 
 func (x *RedisRole_AuthACL) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1970,7 +2058,7 @@ type PubSubCluster_EncoreCloud struct {
 
 func (x *PubSubCluster_EncoreCloud) Reset() {
 	*x = PubSubCluster_EncoreCloud{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1982,7 +2070,7 @@ func (x *PubSubCluster_EncoreCloud) String() string {
 func (*PubSubCluster_EncoreCloud) ProtoMessage() {}
 
 func (x *PubSubCluster_EncoreCloud) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2006,7 +2094,7 @@ type PubSubCluster_AWSSqsSns struct {
 
 func (x *PubSubCluster_AWSSqsSns) Reset() {
 	*x = PubSubCluster_AWSSqsSns{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2018,7 +2106,7 @@ func (x *PubSubCluster_AWSSqsSns) String() string {
 func (*PubSubCluster_AWSSqsSns) ProtoMessage() {}
 
 func (x *PubSubCluster_AWSSqsSns) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2042,7 +2130,7 @@ type PubSubCluster_GCPPubSub struct {
 
 func (x *PubSubCluster_GCPPubSub) Reset() {
 	*x = PubSubCluster_GCPPubSub{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2054,7 +2142,7 @@ func (x *PubSubCluster_GCPPubSub) String() string {
 func (*PubSubCluster_GCPPubSub) ProtoMessage() {}
 
 func (x *PubSubCluster_GCPPubSub) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2080,7 +2168,7 @@ type PubSubCluster_NSQ struct {
 
 func (x *PubSubCluster_NSQ) Reset() {
 	*x = PubSubCluster_NSQ{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2092,7 +2180,7 @@ func (x *PubSubCluster_NSQ) String() string {
 func (*PubSubCluster_NSQ) ProtoMessage() {}
 
 func (x *PubSubCluster_NSQ) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2124,7 +2212,7 @@ type PubSubCluster_AzureServiceBus struct {
 
 func (x *PubSubCluster_AzureServiceBus) Reset() {
 	*x = PubSubCluster_AzureServiceBus{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2136,7 +2224,7 @@ func (x *PubSubCluster_AzureServiceBus) String() string {
 func (*PubSubCluster_AzureServiceBus) ProtoMessage() {}
 
 func (x *PubSubCluster_AzureServiceBus) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2169,7 +2257,7 @@ type PubSubTopic_GCPConfig struct {
 
 func (x *PubSubTopic_GCPConfig) Reset() {
 	*x = PubSubTopic_GCPConfig{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2181,7 +2269,7 @@ func (x *PubSubTopic_GCPConfig) String() string {
 func (*PubSubTopic_GCPConfig) ProtoMessage() {}
 
 func (x *PubSubTopic_GCPConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2220,7 +2308,7 @@ type PubSubSubscription_GCPConfig struct {
 
 func (x *PubSubSubscription_GCPConfig) Reset() {
 	*x = PubSubSubscription_GCPConfig{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2232,7 +2320,7 @@ func (x *PubSubSubscription_GCPConfig) String() string {
 func (*PubSubSubscription_GCPConfig) ProtoMessage() {}
 
 func (x *PubSubSubscription_GCPConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2285,7 +2373,7 @@ type BucketCluster_S3 struct {
 
 func (x *BucketCluster_S3) Reset() {
 	*x = BucketCluster_S3{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2297,7 +2385,7 @@ func (x *BucketCluster_S3) String() string {
 func (*BucketCluster_S3) ProtoMessage() {}
 
 func (x *BucketCluster_S3) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2356,7 +2444,7 @@ type BucketCluster_GCS struct {
 
 func (x *BucketCluster_GCS) Reset() {
 	*x = BucketCluster_GCS{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2368,7 +2456,7 @@ func (x *BucketCluster_GCS) String() string {
 func (*BucketCluster_GCS) ProtoMessage() {}
 
 func (x *BucketCluster_GCS) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2419,7 +2507,7 @@ type BucketCluster_GCS_LocalSignOptions struct {
 
 func (x *BucketCluster_GCS_LocalSignOptions) Reset() {
 	*x = BucketCluster_GCS_LocalSignOptions{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2431,7 +2519,7 @@ func (x *BucketCluster_GCS_LocalSignOptions) String() string {
 func (*BucketCluster_GCS_LocalSignOptions) ProtoMessage() {}
 
 func (x *BucketCluster_GCS_LocalSignOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2510,7 +2598,7 @@ type Gateway_CORS struct {
 
 func (x *Gateway_CORS) Reset() {
 	*x = Gateway_CORS{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2522,7 +2610,7 @@ func (x *Gateway_CORS) String() string {
 func (*Gateway_CORS) ProtoMessage() {}
 
 func (x *Gateway_CORS) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2535,7 +2623,7 @@ func (x *Gateway_CORS) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway_CORS.ProtoReflect.Descriptor instead.
 func (*Gateway_CORS) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{19, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 0}
 }
 
 func (x *Gateway_CORS) GetDebug() bool {
@@ -2637,7 +2725,7 @@ type Gateway_CORSAllowedOrigins struct {
 
 func (x *Gateway_CORSAllowedOrigins) Reset() {
 	*x = Gateway_CORSAllowedOrigins{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2649,7 +2737,7 @@ func (x *Gateway_CORSAllowedOrigins) String() string {
 func (*Gateway_CORSAllowedOrigins) ProtoMessage() {}
 
 func (x *Gateway_CORSAllowedOrigins) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2662,7 +2750,7 @@ func (x *Gateway_CORSAllowedOrigins) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway_CORSAllowedOrigins.ProtoReflect.Descriptor instead.
 func (*Gateway_CORSAllowedOrigins) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{19, 1}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 1}
 }
 
 func (x *Gateway_CORSAllowedOrigins) GetAllowedOrigins() []string {
@@ -2676,7 +2764,7 @@ var File_encore_runtime_v1_infra_proto protoreflect.FileDescriptor
 
 const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\n" +
-	"\x1dencore/runtime/v1/infra.proto\x12\x11encore.runtime.v1\x1a\"encore/runtime/v1/secretdata.proto\"\x9b\x06\n" +
+	"\x1dencore/runtime/v1/infra.proto\x12\x11encore.runtime.v1\x1a\"encore/runtime/v1/secretdata.proto\x1a\x1egoogle/protobuf/duration.proto\"\x9b\x06\n" +
 	"\x0eInfrastructure\x12I\n" +
 	"\tresources\x18\x01 \x01(\v2+.encore.runtime.v1.Infrastructure.ResourcesR\tresources\x12O\n" +
 	"\vcredentials\x18\x02 \x01(\v2-.encore.runtime.v1.Infrastructure.CredentialsR\vcredentials\x1a\xc7\x01\n" +
@@ -2728,13 +2816,16 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\n" +
 	"cloud_name\x18\x03 \x01(\tR\tcloudName\x12C\n" +
 	"\n" +
-	"conn_pools\x18\x04 \x03(\v2$.encore.runtime.v1.SQLConnectionPoolR\tconnPools\"\xa1\x01\n" +
+	"conn_pools\x18\x04 \x03(\v2$.encore.runtime.v1.SQLConnectionPoolR\tconnPools\"\xef\x02\n" +
 	"\x11SQLConnectionPool\x12\x1f\n" +
 	"\vis_readonly\x18\x01 \x01(\bR\n" +
 	"isReadonly\x12\x19\n" +
 	"\brole_rid\x18\x02 \x01(\tR\aroleRid\x12'\n" +
 	"\x0fmin_connections\x18\x03 \x01(\x05R\x0eminConnections\x12'\n" +
-	"\x0fmax_connections\x18\x04 \x01(\x05R\x0emaxConnections\"\x9a\x01\n" +
+	"\x0fmax_connections\x18\x04 \x01(\x05R\x0emaxConnections\x12=\n" +
+	"\rmax_idle_time\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\vmaxIdleTime\x12E\n" +
+	"\x11max_conn_lifetime\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x0fmaxConnLifetime\x12F\n" +
+	"\x11statement_timeout\x18\a \x01(\v2\x19.google.protobuf.DurationR\x10statementTimeout\"\x9a\x01\n" +
 	"\fRedisCluster\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x128\n" +
 	"\aservers\x18\x02 \x03(\v2\x1e.encore.runtime.v1.RedisServerR\aservers\x12>\n" +
@@ -2862,7 +2953,7 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\t_endpointB\r\n" +
 	"\v_local_signB\n" +
 	"\n" +
-	"\bprovider\"\xce\x01\n" +
+	"\bprovider\"\xa7\x02\n" +
 	"\x06Bucket\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
 	"\vencore_name\x18\x02 \x01(\tR\n" +
@@ -2871,9 +2962,15 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"cloud_name\x18\x03 \x01(\tR\tcloudName\x12\"\n" +
 	"\n" +
 	"key_prefix\x18\x04 \x01(\tH\x00R\tkeyPrefix\x88\x01\x01\x12+\n" +
-	"\x0fpublic_base_url\x18\x05 \x01(\tH\x01R\rpublicBaseUrl\x88\x01\x01B\r\n" +
+	"\x0fpublic_base_url\x18\x05 \x01(\tH\x01R\rpublicBaseUrl\x88\x01\x01\x12H\n" +
+	"\n" +
+	"encryption\x18\x06 \x01(\v2#.encore.runtime.v1.BucketEncryptionH\x02R\n" +
+	"encryption\x88\x01\x01B\r\n" +
 	"\v_key_prefixB\x12\n" +
-	"\x10_public_base_url\"\xb9\x06\n" +
+	"\x10_public_base_urlB\r\n" +
+	"\v_encryption\"+\n" +
+	"\x10BucketEncryption\x12\x17\n" +
+	"\akms_key\x18\x01 \x01(\tR\x06kmsKey\"\xb9\x06\n" +
 	"\aGateway\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
 	"\vencore_name\x18\x02 \x01(\tR\n" +
@@ -2913,7 +3010,7 @@ func file_encore_runtime_v1_infra_proto_rawDescGZIP() []byte {
 }
 
 var file_encore_runtime_v1_infra_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_encore_runtime_v1_infra_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_encore_runtime_v1_infra_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_encore_runtime_v1_infra_proto_goTypes = []any{
 	(ServerKind)(0),                            // 0: encore.runtime.v1.ServerKind
 	(PubSubTopic_DeliveryGuarantee)(0),         // 1: encore.runtime.v1.PubSubTopic.DeliveryGuarantee
@@ -2936,75 +3033,81 @@ var file_encore_runtime_v1_infra_proto_goTypes = []any{
 	(*PubSubSubscription)(nil),                 // 18: encore.runtime.v1.PubSubSubscription
 	(*BucketCluster)(nil),                      // 19: encore.runtime.v1.BucketCluster
 	(*Bucket)(nil),                             // 20: encore.runtime.v1.Bucket
-	(*Gateway)(nil),                            // 21: encore.runtime.v1.Gateway
-	(*Infrastructure_Credentials)(nil),         // 22: encore.runtime.v1.Infrastructure.Credentials
-	(*Infrastructure_Resources)(nil),           // 23: encore.runtime.v1.Infrastructure.Resources
-	(*RedisRole_AuthACL)(nil),                  // 24: encore.runtime.v1.RedisRole.AuthACL
-	(*PubSubCluster_EncoreCloud)(nil),          // 25: encore.runtime.v1.PubSubCluster.EncoreCloud
-	(*PubSubCluster_AWSSqsSns)(nil),            // 26: encore.runtime.v1.PubSubCluster.AWSSqsSns
-	(*PubSubCluster_GCPPubSub)(nil),            // 27: encore.runtime.v1.PubSubCluster.GCPPubSub
-	(*PubSubCluster_NSQ)(nil),                  // 28: encore.runtime.v1.PubSubCluster.NSQ
-	(*PubSubCluster_AzureServiceBus)(nil),      // 29: encore.runtime.v1.PubSubCluster.AzureServiceBus
-	(*PubSubTopic_GCPConfig)(nil),              // 30: encore.runtime.v1.PubSubTopic.GCPConfig
-	(*PubSubSubscription_GCPConfig)(nil),       // 31: encore.runtime.v1.PubSubSubscription.GCPConfig
-	(*BucketCluster_S3)(nil),                   // 32: encore.runtime.v1.BucketCluster.S3
-	(*BucketCluster_GCS)(nil),                  // 33: encore.runtime.v1.BucketCluster.GCS
-	(*BucketCluster_GCS_LocalSignOptions)(nil), // 34: encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
-	(*Gateway_CORS)(nil),                       // 35: encore.runtime.v1.Gateway.CORS
-	(*Gateway_CORSAllowedOrigins)(nil),         // 36: encore.runtime.v1.Gateway.CORSAllowedOrigins
-	(*SecretData)(nil),                         // 37: encore.runtime.v1.SecretData
+	(*BucketEncryption)(nil),                   // 21: encore.runtime.v1.BucketEncryption
+	(*Gateway)(nil),                            // 22: encore.runtime.v1.Gateway
+	(*Infrastructure_Credentials)(nil),         // 23: encore.runtime.v1.Infrastructure.Credentials
+	(*Infrastructure_Resources)(nil),           // 24: encore.runtime.v1.Infrastructure.Resources
+	(*RedisRole_AuthACL)(nil),                  // 25: encore.runtime.v1.RedisRole.AuthACL
+	(*PubSubCluster_EncoreCloud)(nil),          // 26: encore.runtime.v1.PubSubCluster.EncoreCloud
+	(*PubSubCluster_AWSSqsSns)(nil),            // 27: encore.runtime.v1.PubSubCluster.AWSSqsSns
+	(*PubSubCluster_GCPPubSub)(nil),            // 28: encore.runtime.v1.PubSubCluster.GCPPubSub
+	(*PubSubCluster_NSQ)(nil),                  // 29: encore.runtime.v1.PubSubCluster.NSQ
+	(*PubSubCluster_AzureServiceBus)(nil),      // 30: encore.runtime.v1.PubSubCluster.AzureServiceBus
+	(*PubSubTopic_GCPConfig)(nil),              // 31: encore.runtime.v1.PubSubTopic.GCPConfig
+	(*PubSubSubscription_GCPConfig)(nil),       // 32: encore.runtime.v1.PubSubSubscription.GCPConfig
+	(*BucketCluster_S3)(nil),                   // 33: encore.runtime.v1.BucketCluster.S3
+	(*BucketCluster_GCS)(nil),                  // 34: encore.runtime.v1.BucketCluster.GCS
+	(*BucketCluster_GCS_LocalSignOptions)(nil), // 35: encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
+	(*Gateway_CORS)(nil),                       // 36: encore.runtime.v1.Gateway.CORS
+	(*Gateway_CORSAllowedOrigins)(nil),         // 37: encore.runtime.v1.Gateway.CORSAllowedOrigins
+	(*SecretData)(nil),                         // 38: encore.runtime.v1.SecretData
+	(*durationpb.Duration)(nil),                // 39: google.protobuf.Duration
 }
 var file_encore_runtime_v1_infra_proto_depIdxs = []int32{
-	23, // 0: encore.runtime.v1.Infrastructure.resources:type_name -> encore.runtime.v1.Infrastructure.Resources
-	22, // 1: encore.runtime.v1.Infrastructure.credentials:type_name -> encore.runtime.v1.Infrastructure.Credentials
+	24, // 0: encore.runtime.v1.Infrastructure.resources:type_name -> encore.runtime.v1.Infrastructure.Resources
+	23, // 1: encore.runtime.v1.Infrastructure.credentials:type_name -> encore.runtime.v1.Infrastructure.Credentials
 	5,  // 2: encore.runtime.v1.SQLCluster.servers:type_name -> encore.runtime.v1.SQLServer
 	8,  // 3: encore.runtime.v1.SQLCluster.databases:type_name -> encore.runtime.v1.SQLDatabase
 	0,  // 4: encore.runtime.v1.SQLServer.kind:type_name -> encore.runtime.v1.ServerKind
 	4,  // 5: encore.runtime.v1.SQLServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
-	37, // 6: encore.runtime.v1.ClientCert.key:type_name -> encore.runtime.v1.SecretData
-	37, // 7: encore.runtime.v1.SQLRole.password:type_name -> encore.runtime.v1.SecretData
+	38, // 6: encore.runtime.v1.ClientCert.key:type_name -> encore.runtime.v1.SecretData
+	38, // 7: encore.runtime.v1.SQLRole.password:type_name -> encore.runtime.v1.SecretData
 	9,  // 8: encore.runtime.v1.SQLDatabase.conn_pools:type_name -> encore.runtime.v1.SQLConnectionPool
-	11, // 9: encore.runtime.v1.RedisCluster.servers:type_name -> encore.runtime.v1.RedisServer
-	14, // 10: encore.runtime.v1.RedisCluster.databases:type_name -> encore.runtime.v1.RedisDatabase
-	0,  // 11: encore.runtime.v1.RedisServer.kind:type_name -> encore.runtime.v1.ServerKind
-	4,  // 12: encore.runtime.v1.RedisServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
-	24, // 13: encore.runtime.v1.RedisRole.acl:type_name -> encore.runtime.v1.RedisRole.AuthACL
-	37, // 14: encore.runtime.v1.RedisRole.auth_string:type_name -> encore.runtime.v1.SecretData
-	12, // 15: encore.runtime.v1.RedisDatabase.conn_pools:type_name -> encore.runtime.v1.RedisConnectionPool
-	37, // 16: encore.runtime.v1.AppSecret.data:type_name -> encore.runtime.v1.SecretData
-	17, // 17: encore.runtime.v1.PubSubCluster.topics:type_name -> encore.runtime.v1.PubSubTopic
-	18, // 18: encore.runtime.v1.PubSubCluster.subscriptions:type_name -> encore.runtime.v1.PubSubSubscription
-	25, // 19: encore.runtime.v1.PubSubCluster.encore:type_name -> encore.runtime.v1.PubSubCluster.EncoreCloud
-	26, // 20: encore.runtime.v1.PubSubCluster.aws:type_name -> encore.runtime.v1.PubSubCluster.AWSSqsSns
-	27, // 21: encore.runtime.v1.PubSubCluster.gcp:type_name -> encore.runtime.v1.PubSubCluster.GCPPubSub
-	29, // 22: encore.runtime.v1.PubSubCluster.azure:type_name -> encore.runtime.v1.PubSubCluster.AzureServiceBus
-	28, // 23: encore.runtime.v1.PubSubCluster.nsq:type_name -> encore.runtime.v1.PubSubCluster.NSQ
-	1,  // 24: encore.runtime.v1.PubSubTopic.delivery_guarantee:type_name -> encore.runtime.v1.PubSubTopic.DeliveryGuarantee
-	30, // 25: encore.runtime.v1.PubSubTopic.gcp_config:type_name -> encore.runtime.v1.PubSubTopic.GCPConfig
-	31, // 26: encore.runtime.v1.PubSubSubscription.gcp_config:type_name -> encore.runtime.v1.PubSubSubscription.GCPConfig
-	20, // 27: encore.runtime.v1.BucketCluster.buckets:type_name -> encore.runtime.v1.Bucket
-	32, // 28: encore.runtime.v1.BucketCluster.s3:type_name -> encore.runtime.v1.BucketCluster.S3
-	33, // 29: encore.runtime.v1.BucketCluster.gcs:type_name -> encore.runtime.v1.BucketCluster.GCS
-	35, // 30: encore.runtime.v1.Gateway.cors:type_name -> encore.runtime.v1.Gateway.CORS
-	6,  // 31: encore.runtime.v1.Infrastructure.Credentials.client_certs:type_name -> encore.runtime.v1.ClientCert
-	7,  // 32: encore.runtime.v1.Infrastructure.Credentials.sql_roles:type_name -> encore.runtime.v1.SQLRole
-	13, // 33: encore.runtime.v1.Infrastructure.Credentials.redis_roles:type_name -> encore.runtime.v1.RedisRole
-	21, // 34: encore.runtime.v1.Infrastructure.Resources.gateways:type_name -> encore.runtime.v1.Gateway
-	3,  // 35: encore.runtime.v1.Infrastructure.Resources.sql_clusters:type_name -> encore.runtime.v1.SQLCluster
-	16, // 36: encore.runtime.v1.Infrastructure.Resources.pubsub_clusters:type_name -> encore.runtime.v1.PubSubCluster
-	10, // 37: encore.runtime.v1.Infrastructure.Resources.redis_clusters:type_name -> encore.runtime.v1.RedisCluster
-	15, // 38: encore.runtime.v1.Infrastructure.Resources.app_secrets:type_name -> encore.runtime.v1.AppSecret
-	19, // 39: encore.runtime.v1.Infrastructure.Resources.bucket_clusters:type_name -> encore.runtime.v1.BucketCluster
-	37, // 40: encore.runtime.v1.RedisRole.AuthACL.password:type_name -> encore.runtime.v1.SecretData
-	37, // 41: encore.runtime.v1.BucketCluster.S3.secret_access_key:type_name -> encore.runtime.v1.SecretData
-	34, // 42: encore.runtime.v1.BucketCluster.GCS.local_sign:type_name -> encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
-	36, // 43: encore.runtime.v1.Gateway.CORS.allowed_origins:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
-	36, // 44: encore.runtime.v1.Gateway.CORS.allowed_origins_without_credentials:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
-	45, // [45:45] is the sub-list for method output_type
-	45, // [45:45] is the sub-list for method input_type
-	45, // [45:45] is the sub-list for extension type_name
-	45, // [45:45] is the sub-list for extension extendee
-	0,  // [0:45] is the sub-list for field type_name
+	39, // 9: encore.runtime.v1.SQLConnectionPool.max_idle_time:type_name -> google.protobuf.Duration
+	39, // 10: encore.runtime.v1.SQLConnectionPool.max_conn_lifetime:type_name -> google.protobuf.Duration
+	39, // 11: encore.runtime.v1.SQLConnectionPool.statement_timeout:type_name -> google.protobuf.Duration
+	11, // 12: encore.runtime.v1.RedisCluster.servers:type_name -> encore.runtime.v1.RedisServer
+	14, // 13: encore.runtime.v1.RedisCluster.databases:type_name -> encore.runtime.v1.RedisDatabase
+	0,  // 14: encore.runtime.v1.RedisServer.kind:type_name -> encore.runtime.v1.ServerKind
+	4,  // 15: encore.runtime.v1.RedisServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
+	25, // 16: encore.runtime.v1.RedisRole.acl:type_name -> encore.runtime.v1.RedisRole.AuthACL
+	38, // 17: encore.runtime.v1.RedisRole.auth_string:type_name -> encore.runtime.v1.SecretData
+	12, // 18: encore.runtime.v1.RedisDatabase.conn_pools:type_name -> encore.runtime.v1.RedisConnectionPool
+	38, // 19: encore.runtime.v1.AppSecret.data:type_name -> encore.runtime.v1.SecretData
+	17, // 20: encore.runtime.v1.PubSubCluster.topics:type_name -> encore.runtime.v1.PubSubTopic
+	18, // 21: encore.runtime.v1.PubSubCluster.subscriptions:type_name -> encore.runtime.v1.PubSubSubscription
+	26, // 22: encore.runtime.v1.PubSubCluster.encore:type_name -> encore.runtime.v1.PubSubCluster.EncoreCloud
+	27, // 23: encore.runtime.v1.PubSubCluster.aws:type_name -> encore.runtime.v1.PubSubCluster.AWSSqsSns
+	28, // 24: encore.runtime.v1.PubSubCluster.gcp:type_name -> encore.runtime.v1.PubSubCluster.GCPPubSub
+	30, // 25: encore.runtime.v1.PubSubCluster.azure:type_name -> encore.runtime.v1.PubSubCluster.AzureServiceBus
+	29, // 26: encore.runtime.v1.PubSubCluster.nsq:type_name -> encore.runtime.v1.PubSubCluster.NSQ
+	1,  // 27: encore.runtime.v1.PubSubTopic.delivery_guarantee:type_name -> encore.runtime.v1.PubSubTopic.DeliveryGuarantee
+	31, // 28: encore.runtime.v1.PubSubTopic.gcp_config:type_name -> encore.runtime.v1.PubSubTopic.GCPConfig
+	32, // 29: encore.runtime.v1.PubSubSubscription.gcp_config:type_name -> encore.runtime.v1.PubSubSubscription.GCPConfig
+	20, // 30: encore.runtime.v1.BucketCluster.buckets:type_name -> encore.runtime.v1.Bucket
+	33, // 31: encore.runtime.v1.BucketCluster.s3:type_name -> encore.runtime.v1.BucketCluster.S3
+	34, // 32: encore.runtime.v1.BucketCluster.gcs:type_name -> encore.runtime.v1.BucketCluster.GCS
+	21, // 33: encore.runtime.v1.Bucket.encryption:type_name -> encore.runtime.v1.BucketEncryption
+	36, // 34: encore.runtime.v1.Gateway.cors:type_name -> encore.runtime.v1.Gateway.CORS
+	6,  // 35: encore.runtime.v1.Infrastructure.Credentials.client_certs:type_name -> encore.runtime.v1.ClientCert
+	7,  // 36: encore.runtime.v1.Infrastructure.Credentials.sql_roles:type_name -> encore.runtime.v1.SQLRole
+	13, // 37: encore.runtime.v1.Infrastructure.Credentials.redis_roles:type_name -> encore.runtime.v1.RedisRole
+	22, // 38: encore.runtime.v1.Infrastructure.Resources.gateways:type_name -> encore.runtime.v1.Gateway
+	3,  // 39: encore.runtime.v1.Infrastructure.Resources.sql_clusters:type_name -> encore.runtime.v1.SQLCluster
+	16, // 40: encore.runtime.v1.Infrastructure.Resources.pubsub_clusters:type_name -> encore.runtime.v1.PubSubCluster
+	10, // 41: encore.runtime.v1.Infrastructure.Resources.redis_clusters:type_name -> encore.runtime.v1.RedisCluster
+	15, // 42: encore.runtime.v1.Infrastructure.Resources.app_secrets:type_name -> encore.runtime.v1.AppSecret
+	19, // 43: encore.runtime.v1.Infrastructure.Resources.bucket_clusters:type_name -> encore.runtime.v1.BucketCluster
+	38, // 44: encore.runtime.v1.RedisRole.AuthACL.password:type_name -> encore.runtime.v1.SecretData
+	38, // 45: encore.runtime.v1.BucketCluster.S3.secret_access_key:type_name -> encore.runtime.v1.SecretData
+	35, // 46: encore.runtime.v1.BucketCluster.GCS.local_sign:type_name -> encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
+	37, // 47: encore.runtime.v1.Gateway.CORS.allowed_origins:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
+	37, // 48: encore.runtime.v1.Gateway.CORS.allowed_origins_without_credentials:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
+	49, // [49:49] is the sub-list for method output_type
+	49, // [49:49] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_encore_runtime_v1_infra_proto_init() }
@@ -3040,10 +3143,10 @@ func file_encore_runtime_v1_infra_proto_init() {
 		(*BucketCluster_Gcs)(nil),
 	}
 	file_encore_runtime_v1_infra_proto_msgTypes[18].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[29].OneofWrappers = []any{}
 	file_encore_runtime_v1_infra_proto_msgTypes[30].OneofWrappers = []any{}
 	file_encore_runtime_v1_infra_proto_msgTypes[31].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[33].OneofWrappers = []any{
+	file_encore_runtime_v1_infra_proto_msgTypes[32].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[34].OneofWrappers = []any{
 		(*Gateway_CORS_AllowedOrigins)(nil),
 		(*Gateway_CORS_UnsafeAllowAllOriginsWithCredentials)(nil),
 	}
@@ -3053,7 +3156,7 @@ func file_encore_runtime_v1_infra_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_runtime_v1_infra_proto_rawDesc), len(file_encore_runtime_v1_infra_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   35,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -136,6 +136,57 @@ func (Environment_Cloud) EnumDescriptor() ([]byte, []int) {
 	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{1, 1}
 }
 
+type TracingProvider_EncoreTracingProvider_TimeAnchor int32
+
+const (
+	// TIME_ANCHOR_SEND anchors events to the wall-clock time at which
+	// the trace data is sent, following adjustments of the system clock.
+	TracingProvider_EncoreTracingProvider_TIME_ANCHOR_SEND TracingProvider_EncoreTracingProvider_TimeAnchor = 0
+	// TIME_ANCHOR_BOOT anchors events to the wall-clock time at which
+	// the process started, keeping timestamps consistent across traces
+	// regardless of adjustments of the system clock.
+	TracingProvider_EncoreTracingProvider_TIME_ANCHOR_BOOT TracingProvider_EncoreTracingProvider_TimeAnchor = 1
+)
+
+// Enum value maps for TracingProvider_EncoreTracingProvider_TimeAnchor.
+var (
+	TracingProvider_EncoreTracingProvider_TimeAnchor_name = map[int32]string{
+		0: "TIME_ANCHOR_SEND",
+		1: "TIME_ANCHOR_BOOT",
+	}
+	TracingProvider_EncoreTracingProvider_TimeAnchor_value = map[string]int32{
+		"TIME_ANCHOR_SEND": 0,
+		"TIME_ANCHOR_BOOT": 1,
+	}
+)
+
+func (x TracingProvider_EncoreTracingProvider_TimeAnchor) Enum() *TracingProvider_EncoreTracingProvider_TimeAnchor {
+	p := new(TracingProvider_EncoreTracingProvider_TimeAnchor)
+	*p = x
+	return p
+}
+
+func (x TracingProvider_EncoreTracingProvider_TimeAnchor) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TracingProvider_EncoreTracingProvider_TimeAnchor) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_runtime_v1_runtime_proto_enumTypes[2].Descriptor()
+}
+
+func (TracingProvider_EncoreTracingProvider_TimeAnchor) Type() protoreflect.EnumType {
+	return &file_encore_runtime_v1_runtime_proto_enumTypes[2]
+}
+
+func (x TracingProvider_EncoreTracingProvider_TimeAnchor) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TracingProvider_EncoreTracingProvider_TimeAnchor.Descriptor instead.
+func (TracingProvider_EncoreTracingProvider_TimeAnchor) EnumDescriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{6, 0, 0}
+}
+
 type RuntimeConfig struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Environment    *Environment           `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
@@ -1389,7 +1440,11 @@ type TracingProvider_EncoreTracingProvider struct {
 	TraceEndpoint string                 `protobuf:"bytes,1,opt,name=trace_endpoint,json=traceEndpoint,proto3" json:"trace_endpoint,omitempty"`
 	// The sampling rate to use for traces, between [0, 1].
 	// If unset it defaults to 1 (meaning all requests are traced).
-	SamplingRate  *float64 `protobuf:"fixed64,2,opt,name=sampling_rate,json=samplingRate,proto3,oneof" json:"sampling_rate,omitempty"`
+	SamplingRate *float64 `protobuf:"fixed64,2,opt,name=sampling_rate,json=samplingRate,proto3,oneof" json:"sampling_rate,omitempty"`
+	// How trace event timestamps are anchored to wall-clock time.
+	TimeAnchor TracingProvider_EncoreTracingProvider_TimeAnchor `protobuf:"varint,3,opt,name=time_anchor,json=timeAnchor,proto3,enum=encore.runtime.v1.TracingProvider_EncoreTracingProvider_TimeAnchor" json:"time_anchor,omitempty"`
+	// Whether to record the memory allocated during spans in their span end events.
+	MemStats      bool `protobuf:"varint,4,opt,name=mem_stats,json=memStats,proto3" json:"mem_stats,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1438,6 +1493,20 @@ func (x *TracingProvider_EncoreTracingProvider) GetSamplingRate() float64 {
 	return 0
 }
 
+func (x *TracingProvider_EncoreTracingProvider) GetTimeAnchor() TracingProvider_EncoreTracingProvider_TimeAnchor {
+	if x != nil {
+		return x.TimeAnchor
+	}
+	return TracingProvider_EncoreTracingProvider_TIME_ANCHOR_SEND
+}
+
+func (x *TracingProvider_EncoreTracingProvider) GetMemStats() bool {
+	if x != nil {
+		return x.MemStats
+	}
+	return false
+}
+
 type MetricsProvider_GCPCloudMonitoring struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The GCP project id to send metrics to.
@@ -1831,14 +1900,21 @@ const file_encore_runtime_v1_runtime_proto_rawDesc = "" +
 	"\n" +
 	"EncoreAuth\x12=\n" +
 	"\tauth_keys\x18\x01 \x03(\v2 .encore.runtime.v1.EncoreAuthKeyR\bauthKeysB\r\n" +
-	"\vauth_method\"\xff\x01\n" +
+	"\vauth_method\"\xbd\x03\n" +
 	"\x0fTracingProvider\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12R\n" +
 	"\x06encore\x18\n" +
-	" \x01(\v28.encore.runtime.v1.TracingProvider.EncoreTracingProviderH\x00R\x06encore\x1az\n" +
+	" \x01(\v28.encore.runtime.v1.TracingProvider.EncoreTracingProviderH\x00R\x06encore\x1a\xb7\x02\n" +
 	"\x15EncoreTracingProvider\x12%\n" +
 	"\x0etrace_endpoint\x18\x01 \x01(\tR\rtraceEndpoint\x12(\n" +
-	"\rsampling_rate\x18\x02 \x01(\x01H\x00R\fsamplingRate\x88\x01\x01B\x10\n" +
+	"\rsampling_rate\x18\x02 \x01(\x01H\x00R\fsamplingRate\x88\x01\x01\x12d\n" +
+	"\vtime_anchor\x18\x03 \x01(\x0e2C.encore.runtime.v1.TracingProvider.EncoreTracingProvider.TimeAnchorR\n" +
+	"timeAnchor\x12\x1b\n" +
+	"\tmem_stats\x18\x04 \x01(\bR\bmemStats\"8\n" +
+	"\n" +
+	"TimeAnchor\x12\x14\n" +
+	"\x10TIME_ANCHOR_SEND\x10\x00\x12\x14\n" +
+	"\x10TIME_ANCHOR_BOOT\x10\x01B\x10\n" +
 	"\x0e_sampling_rateB\n" +
 	"\n" +
 	"\bprovider\"\xf6\t\n" +
@@ -1921,91 +1997,93 @@ func file_encore_runtime_v1_runtime_proto_rawDescGZIP() []byte {
 	return file_encore_runtime_v1_runtime_proto_rawDescData
 }
 
-var file_encore_runtime_v1_runtime_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_encore_runtime_v1_runtime_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
 var file_encore_runtime_v1_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_encore_runtime_v1_runtime_proto_goTypes = []any{
-	(Environment_Type)(0),                         // 0: encore.runtime.v1.Environment.Type
-	(Environment_Cloud)(0),                        // 1: encore.runtime.v1.Environment.Cloud
-	(*RuntimeConfig)(nil),                         // 2: encore.runtime.v1.RuntimeConfig
-	(*Environment)(nil),                           // 3: encore.runtime.v1.Environment
-	(*Deployment)(nil),                            // 4: encore.runtime.v1.Deployment
-	(*Observability)(nil),                         // 5: encore.runtime.v1.Observability
-	(*HostedService)(nil),                         // 6: encore.runtime.v1.HostedService
-	(*ServiceAuth)(nil),                           // 7: encore.runtime.v1.ServiceAuth
-	(*TracingProvider)(nil),                       // 8: encore.runtime.v1.TracingProvider
-	(*MetricsProvider)(nil),                       // 9: encore.runtime.v1.MetricsProvider
-	(*LogsProvider)(nil),                          // 10: encore.runtime.v1.LogsProvider
-	(*EncoreAuthKey)(nil),                         // 11: encore.runtime.v1.EncoreAuthKey
-	(*ServiceDiscovery)(nil),                      // 12: encore.runtime.v1.ServiceDiscovery
-	(*GracefulShutdown)(nil),                      // 13: encore.runtime.v1.GracefulShutdown
-	(*EncorePlatform)(nil),                        // 14: encore.runtime.v1.EncorePlatform
-	(*RateLimiter)(nil),                           // 15: encore.runtime.v1.RateLimiter
-	(*EncoreCloudProvider)(nil),                   // 16: encore.runtime.v1.EncoreCloudProvider
-	(*Metric)(nil),                                // 17: encore.runtime.v1.Metric
-	(*ServiceAuth_NoopAuth)(nil),                  // 18: encore.runtime.v1.ServiceAuth.NoopAuth
-	(*ServiceAuth_EncoreAuth)(nil),                // 19: encore.runtime.v1.ServiceAuth.EncoreAuth
-	(*TracingProvider_EncoreTracingProvider)(nil), // 20: encore.runtime.v1.TracingProvider.EncoreTracingProvider
-	(*MetricsProvider_GCPCloudMonitoring)(nil),    // 21: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
-	(*MetricsProvider_AWSCloudWatch)(nil),         // 22: encore.runtime.v1.MetricsProvider.AWSCloudWatch
-	(*MetricsProvider_PrometheusRemoteWrite)(nil), // 23: encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite
-	(*MetricsProvider_Datadog)(nil),               // 24: encore.runtime.v1.MetricsProvider.Datadog
-	nil,                                           // 25: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MonitoredResourceLabelsEntry
-	nil,                                           // 26: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MetricNamesEntry
-	nil,                                           // 27: encore.runtime.v1.ServiceDiscovery.ServicesEntry
-	(*ServiceDiscovery_Location)(nil),             // 28: encore.runtime.v1.ServiceDiscovery.Location
-	(*RateLimiter_TokenBucket)(nil),               // 29: encore.runtime.v1.RateLimiter.TokenBucket
-	(*Infrastructure)(nil),                        // 30: encore.runtime.v1.Infrastructure
-	(*timestamppb.Timestamp)(nil),                 // 31: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),                   // 32: google.protobuf.Duration
-	(*SecretData)(nil),                            // 33: encore.runtime.v1.SecretData
+	(Environment_Type)(0),                                 // 0: encore.runtime.v1.Environment.Type
+	(Environment_Cloud)(0),                                // 1: encore.runtime.v1.Environment.Cloud
+	(TracingProvider_EncoreTracingProvider_TimeAnchor)(0), // 2: encore.runtime.v1.TracingProvider.EncoreTracingProvider.TimeAnchor
+	(*RuntimeConfig)(nil),                                 // 3: encore.runtime.v1.RuntimeConfig
+	(*Environment)(nil),                                   // 4: encore.runtime.v1.Environment
+	(*Deployment)(nil),                                    // 5: encore.runtime.v1.Deployment
+	(*Observability)(nil),                                 // 6: encore.runtime.v1.Observability
+	(*HostedService)(nil),                                 // 7: encore.runtime.v1.HostedService
+	(*ServiceAuth)(nil),                                   // 8: encore.runtime.v1.ServiceAuth
+	(*TracingProvider)(nil),                               // 9: encore.runtime.v1.TracingProvider
+	(*MetricsProvider)(nil),                               // 10: encore.runtime.v1.MetricsProvider
+	(*LogsProvider)(nil),                                  // 11: encore.runtime.v1.LogsProvider
+	(*EncoreAuthKey)(nil),                                 // 12: encore.runtime.v1.EncoreAuthKey
+	(*ServiceDiscovery)(nil),                              // 13: encore.runtime.v1.ServiceDiscovery
+	(*GracefulShutdown)(nil),                              // 14: encore.runtime.v1.GracefulShutdown
+	(*EncorePlatform)(nil),                                // 15: encore.runtime.v1.EncorePlatform
+	(*RateLimiter)(nil),                                   // 16: encore.runtime.v1.RateLimiter
+	(*EncoreCloudProvider)(nil),                           // 17: encore.runtime.v1.EncoreCloudProvider
+	(*Metric)(nil),                                        // 18: encore.runtime.v1.Metric
+	(*ServiceAuth_NoopAuth)(nil),                          // 19: encore.runtime.v1.ServiceAuth.NoopAuth
+	(*ServiceAuth_EncoreAuth)(nil),                        // 20: encore.runtime.v1.ServiceAuth.EncoreAuth
+	(*TracingProvider_EncoreTracingProvider)(nil),         // 21: encore.runtime.v1.TracingProvider.EncoreTracingProvider
+	(*MetricsProvider_GCPCloudMonitoring)(nil),            // 22: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
+	(*MetricsProvider_AWSCloudWatch)(nil),                 // 23: encore.runtime.v1.MetricsProvider.AWSCloudWatch
+	(*MetricsProvider_PrometheusRemoteWrite)(nil),         // 24: encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite
+	(*MetricsProvider_Datadog)(nil),                       // 25: encore.runtime.v1.MetricsProvider.Datadog
+	nil,                                                   // 26: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MonitoredResourceLabelsEntry
+	nil,                                                   // 27: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MetricNamesEntry
+	nil,                                                   // 28: encore.runtime.v1.ServiceDiscovery.ServicesEntry
+	(*ServiceDiscovery_Location)(nil),                     // 29: encore.runtime.v1.ServiceDiscovery.Location
+	(*RateLimiter_TokenBucket)(nil),                       // 30: encore.runtime.v1.RateLimiter.TokenBucket
+	(*Infrastructure)(nil),                                // 31: encore.runtime.v1.Infrastructure
+	(*timestamppb.Timestamp)(nil),                         // 32: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                           // 33: google.protobuf.Duration
+	(*SecretData)(nil),                                    // 34: encore.runtime.v1.SecretData
 }
 var file_encore_runtime_v1_runtime_proto_depIdxs = []int32{
-	3,  // 0: encore.runtime.v1.RuntimeConfig.environment:type_name -> encore.runtime.v1.Environment
-	30, // 1: encore.runtime.v1.RuntimeConfig.infra:type_name -> encore.runtime.v1.Infrastructure
-	4,  // 2: encore.runtime.v1.RuntimeConfig.deployment:type_name -> encore.runtime.v1.Deployment
-	14, // 3: encore.runtime.v1.RuntimeConfig.encore_platform:type_name -> encore.runtime.v1.EncorePlatform
+	4,  // 0: encore.runtime.v1.RuntimeConfig.environment:type_name -> encore.runtime.v1.Environment
+	31, // 1: encore.runtime.v1.RuntimeConfig.infra:type_name -> encore.runtime.v1.Infrastructure
+	5,  // 2: encore.runtime.v1.RuntimeConfig.deployment:type_name -> encore.runtime.v1.Deployment
+	15, // 3: encore.runtime.v1.RuntimeConfig.encore_platform:type_name -> encore.runtime.v1.EncorePlatform
 	0,  // 4: encore.runtime.v1.Environment.env_type:type_name -> encore.runtime.v1.Environment.Type
 	1,  // 5: encore.runtime.v1.Environment.cloud:type_name -> encore.runtime.v1.Environment.Cloud
-	31, // 6: encore.runtime.v1.Deployment.deployed_at:type_name -> google.protobuf.Timestamp
-	6,  // 7: encore.runtime.v1.Deployment.hosted_services:type_name -> encore.runtime.v1.HostedService
-	7,  // 8: encore.runtime.v1.Deployment.auth_methods:type_name -> encore.runtime.v1.ServiceAuth
-	5,  // 9: encore.runtime.v1.Deployment.observability:type_name -> encore.runtime.v1.Observability
-	12, // 10: encore.runtime.v1.Deployment.service_discovery:type_name -> encore.runtime.v1.ServiceDiscovery
-	13, // 11: encore.runtime.v1.Deployment.graceful_shutdown:type_name -> encore.runtime.v1.GracefulShutdown
-	17, // 12: encore.runtime.v1.Deployment.metrics:type_name -> encore.runtime.v1.Metric
-	8,  // 13: encore.runtime.v1.Observability.tracing:type_name -> encore.runtime.v1.TracingProvider
-	9,  // 14: encore.runtime.v1.Observability.metrics:type_name -> encore.runtime.v1.MetricsProvider
-	10, // 15: encore.runtime.v1.Observability.logs:type_name -> encore.runtime.v1.LogsProvider
-	18, // 16: encore.runtime.v1.ServiceAuth.noop:type_name -> encore.runtime.v1.ServiceAuth.NoopAuth
-	19, // 17: encore.runtime.v1.ServiceAuth.encore_auth:type_name -> encore.runtime.v1.ServiceAuth.EncoreAuth
-	20, // 18: encore.runtime.v1.TracingProvider.encore:type_name -> encore.runtime.v1.TracingProvider.EncoreTracingProvider
-	32, // 19: encore.runtime.v1.MetricsProvider.collection_interval:type_name -> google.protobuf.Duration
-	21, // 20: encore.runtime.v1.MetricsProvider.encore_cloud:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
-	21, // 21: encore.runtime.v1.MetricsProvider.gcp:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
-	22, // 22: encore.runtime.v1.MetricsProvider.aws:type_name -> encore.runtime.v1.MetricsProvider.AWSCloudWatch
-	23, // 23: encore.runtime.v1.MetricsProvider.prom_remote_write:type_name -> encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite
-	24, // 24: encore.runtime.v1.MetricsProvider.datadog:type_name -> encore.runtime.v1.MetricsProvider.Datadog
-	33, // 25: encore.runtime.v1.EncoreAuthKey.data:type_name -> encore.runtime.v1.SecretData
-	27, // 26: encore.runtime.v1.ServiceDiscovery.services:type_name -> encore.runtime.v1.ServiceDiscovery.ServicesEntry
-	32, // 27: encore.runtime.v1.GracefulShutdown.total:type_name -> google.protobuf.Duration
-	32, // 28: encore.runtime.v1.GracefulShutdown.shutdown_hooks:type_name -> google.protobuf.Duration
-	32, // 29: encore.runtime.v1.GracefulShutdown.handlers:type_name -> google.protobuf.Duration
-	11, // 30: encore.runtime.v1.EncorePlatform.platform_signing_keys:type_name -> encore.runtime.v1.EncoreAuthKey
-	16, // 31: encore.runtime.v1.EncorePlatform.encore_cloud:type_name -> encore.runtime.v1.EncoreCloudProvider
-	29, // 32: encore.runtime.v1.RateLimiter.token_bucket:type_name -> encore.runtime.v1.RateLimiter.TokenBucket
-	11, // 33: encore.runtime.v1.EncoreCloudProvider.auth_keys:type_name -> encore.runtime.v1.EncoreAuthKey
-	11, // 34: encore.runtime.v1.ServiceAuth.EncoreAuth.auth_keys:type_name -> encore.runtime.v1.EncoreAuthKey
-	25, // 35: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.monitored_resource_labels:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MonitoredResourceLabelsEntry
-	26, // 36: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.metric_names:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MetricNamesEntry
-	33, // 37: encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite.remote_write_url:type_name -> encore.runtime.v1.SecretData
-	33, // 38: encore.runtime.v1.MetricsProvider.Datadog.api_key:type_name -> encore.runtime.v1.SecretData
-	28, // 39: encore.runtime.v1.ServiceDiscovery.ServicesEntry.value:type_name -> encore.runtime.v1.ServiceDiscovery.Location
-	7,  // 40: encore.runtime.v1.ServiceDiscovery.Location.auth_methods:type_name -> encore.runtime.v1.ServiceAuth
-	41, // [41:41] is the sub-list for method output_type
-	41, // [41:41] is the sub-list for method input_type
-	41, // [41:41] is the sub-list for extension type_name
-	41, // [41:41] is the sub-list for extension extendee
-	0,  // [0:41] is the sub-list for field type_name
+	32, // 6: encore.runtime.v1.Deployment.deployed_at:type_name -> google.protobuf.Timestamp
+	7,  // 7: encore.runtime.v1.Deployment.hosted_services:type_name -> encore.runtime.v1.HostedService
+	8,  // 8: encore.runtime.v1.Deployment.auth_methods:type_name -> encore.runtime.v1.ServiceAuth
+	6,  // 9: encore.runtime.v1.Deployment.observability:type_name -> encore.runtime.v1.Observability
+	13, // 10: encore.runtime.v1.Deployment.service_discovery:type_name -> encore.runtime.v1.ServiceDiscovery
+	14, // 11: encore.runtime.v1.Deployment.graceful_shutdown:type_name -> encore.runtime.v1.GracefulShutdown
+	18, // 12: encore.runtime.v1.Deployment.metrics:type_name -> encore.runtime.v1.Metric
+	9,  // 13: encore.runtime.v1.Observability.tracing:type_name -> encore.runtime.v1.TracingProvider
+	10, // 14: encore.runtime.v1.Observability.metrics:type_name -> encore.runtime.v1.MetricsProvider
+	11, // 15: encore.runtime.v1.Observability.logs:type_name -> encore.runtime.v1.LogsProvider
+	19, // 16: encore.runtime.v1.ServiceAuth.noop:type_name -> encore.runtime.v1.ServiceAuth.NoopAuth
+	20, // 17: encore.runtime.v1.ServiceAuth.encore_auth:type_name -> encore.runtime.v1.ServiceAuth.EncoreAuth
+	21, // 18: encore.runtime.v1.TracingProvider.encore:type_name -> encore.runtime.v1.TracingProvider.EncoreTracingProvider
+	33, // 19: encore.runtime.v1.MetricsProvider.collection_interval:type_name -> google.protobuf.Duration
+	22, // 20: encore.runtime.v1.MetricsProvider.encore_cloud:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
+	22, // 21: encore.runtime.v1.MetricsProvider.gcp:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
+	23, // 22: encore.runtime.v1.MetricsProvider.aws:type_name -> encore.runtime.v1.MetricsProvider.AWSCloudWatch
+	24, // 23: encore.runtime.v1.MetricsProvider.prom_remote_write:type_name -> encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite
+	25, // 24: encore.runtime.v1.MetricsProvider.datadog:type_name -> encore.runtime.v1.MetricsProvider.Datadog
+	34, // 25: encore.runtime.v1.EncoreAuthKey.data:type_name -> encore.runtime.v1.SecretData
+	28, // 26: encore.runtime.v1.ServiceDiscovery.services:type_name -> encore.runtime.v1.ServiceDiscovery.ServicesEntry
+	33, // 27: encore.runtime.v1.GracefulShutdown.total:type_name -> google.protobuf.Duration
+	33, // 28: encore.runtime.v1.GracefulShutdown.shutdown_hooks:type_name -> google.protobuf.Duration
+	33, // 29: encore.runtime.v1.GracefulShutdown.handlers:type_name -> google.protobuf.Duration
+	12, // 30: encore.runtime.v1.EncorePlatform.platform_signing_keys:type_name -> encore.runtime.v1.EncoreAuthKey
+	17, // 31: encore.runtime.v1.EncorePlatform.encore_cloud:type_name -> encore.runtime.v1.EncoreCloudProvider
+	30, // 32: encore.runtime.v1.RateLimiter.token_bucket:type_name -> encore.runtime.v1.RateLimiter.TokenBucket
+	12, // 33: encore.runtime.v1.EncoreCloudProvider.auth_keys:type_name -> encore.runtime.v1.EncoreAuthKey
+	12, // 34: encore.runtime.v1.ServiceAuth.EncoreAuth.auth_keys:type_name -> encore.runtime.v1.EncoreAuthKey
+	2,  // 35: encore.runtime.v1.TracingProvider.EncoreTracingProvider.time_anchor:type_name -> encore.runtime.v1.TracingProvider.EncoreTracingProvider.TimeAnchor
+	26, // 36: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.monitored_resource_labels:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MonitoredResourceLabelsEntry
+	27, // 37: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.metric_names:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MetricNamesEntry
+	34, // 38: encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite.remote_write_url:type_name -> encore.runtime.v1.SecretData
+	34, // 39: encore.runtime.v1.MetricsProvider.Datadog.api_key:type_name -> encore.runtime.v1.SecretData
+	29, // 40: encore.runtime.v1.ServiceDiscovery.ServicesEntry.value:type_name -> encore.runtime.v1.ServiceDiscovery.Location
+	8,  // 41: encore.runtime.v1.ServiceDiscovery.Location.auth_methods:type_name -> encore.runtime.v1.ServiceAuth
+	42, // [42:42] is the sub-list for method output_type
+	42, // [42:42] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_encore_runtime_v1_runtime_proto_init() }
@@ -2041,7 +2119,7 @@ func file_encore_runtime_v1_runtime_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_runtime_v1_runtime_proto_rawDesc), len(file_encore_runtime_v1_runtime_proto_rawDesc)),
-			NumEnums:      2,
+			NumEnums:      3,
 			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   0,
@@ -524,6 +524,7 @@ impl EndpointHandler {
                 parsed_payload,
                 websocket_upgrade,
                 direction,
+                tracer: self.shared.tracer.clone(),
             })
         } else {
             model::RequestData::RPC(model::RPCRequestData {
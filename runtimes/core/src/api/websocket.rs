@@ -1,17 +1,115 @@
-use std::sync::Arc;
+use std::collections::{HashMap, VecDeque};
+use std::sync::{Arc, Mutex};
+use std::time::Duration;
 
 use anyhow::{anyhow, Context};
-use axum::extract::ws::{Message, WebSocket};
+use axum::extract::ws::{CloseFrame, Message, WebSocket};
+use base64::{engine::general_purpose::URL_SAFE_NO_PAD, Engine};
 use futures::Future;
+use http::header::SEC_WEBSOCKET_PROTOCOL;
 use tokio::sync::{
     mpsc::{self, UnboundedReceiver, UnboundedSender},
     watch,
 };
 
 use crate::model::{self, Request, RequestData};
+use crate::names::EndpointName;
+use crate::trace::protocol::{StreamMessageData, StreamMessageDirection};
 
 use super::{schema, APIResult, HandlerResponse, HandlerResponseInner, PValues};
 
+/// The WebSocket subprotocol spoken by all clients.
+const PROTOCOL: &str = "encore-ws";
+
+/// The WebSocket subprotocol spoken by clients able to resume streams
+/// after losing the connection.
+///
+/// Resumable streams send control messages to the client as binary messages,
+/// containing the stream's resume token and the number of messages received
+/// from the client. One is sent when the client connects, and one
+/// for every message received.
+const RESUMABLE_PROTOCOL: &str = "encore-ws-resume";
+
+/// The headers sent by clients resuming a stream: the resume token,
+/// and the number of messages the client has received.
+const RESUME_TOKEN_HEADER: &str = "x-encore-resume-token";
+const RESUME_RECEIVED_HEADER: &str = "x-encore-resume-received";
+
+/// How long a stream waits for the client to resume it after losing the connection.
+const RESUME_TIMEOUT: Duration = Duration::from_secs(30);
+
+/// The close code sent to clients trying to resume a stream that can't be resumed.
+const CLOSE_RESUME_FAILED: u16 = 4001;
+
+/// The number of outgoing messages queued for a stream before sending blocks,
+/// which is also the number of sent messages kept for resuming streams.
+static SEND_QUEUE_SIZE: once_cell::sync::Lazy<usize> = once_cell::sync::Lazy::new(|| {
+    std::env::var("ENCORE_STREAM_SEND_QUEUE_SIZE")
+        .ok()
+        .and_then(|v| v.parse().ok())
+        .filter(|&n| n > 0)
+        .unwrap_or(64)
+});
+
+/// The resumable streams, by resume token.
+static RESUMABLE_STREAMS: once_cell::sync::Lazy<Mutex<HashMap<String, ResumableStream>>> =
+    once_cell::sync::Lazy::new(Default::default);
+
+/// A stream that can be resumed by clients presenting its resume token.
+struct ResumableStream {
+    /// The endpoint and authenticated user the stream belongs to,
+    /// which resuming clients must match.
+    endpoint: EndpointName,
+    auth_uid: Option<String>,
+
+    /// Whether the stream's connection has been lost.
+    /// Streams are only handed over to resuming clients once it has.
+    lost: bool,
+
+    resumes: UnboundedSender<Resume>,
+}
+
+/// Generates a resume token. Anyone presenting the token can take
+/// over the stream, so it must be unguessable.
+fn new_resume_token() -> String {
+    URL_SAFE_NO_PAD.encode(rand::random::<[u8; 32]>())
+}
+
+/// Claims the stream with the given resume token for a resuming client,
+/// reporting where to hand over the new connection.
+///
+/// It reports None if there's no such stream, if it belongs to another
+/// endpoint or user, or if its connection hasn't been lost.
+fn claim_stream(
+    token: &str,
+    endpoint: &EndpointName,
+    auth_uid: Option<&str>,
+) -> Option<UnboundedSender<Resume>> {
+    let mut streams = RESUMABLE_STREAMS.lock().unwrap();
+    let stream = streams.get_mut(token)?;
+    if !stream.lost || stream.endpoint != *endpoint || stream.auth_uid.as_deref() != auth_uid {
+        return None;
+    }
+    stream.lost = false;
+    Some(stream.resumes.clone())
+}
+
+/// Marks the stream with the given resume token as having lost its connection,
+/// allowing a resuming client to claim it.
+fn mark_lost(token: &str) {
+    if let Some(stream) = RESUMABLE_STREAMS.lock().unwrap().get_mut(token) {
+        stream.lost = true;
+    }
+}
+
+/// A client connection resuming a stream.
+struct Resume {
+    websocket: WebSocket,
+
+    /// The number of messages the client has received.
+    received: u64,
+}
+
 pub enum StreamMessagePayload {
     InOut(Socket),
     Out(Sink),
@@ -59,112 +157,160 @@ where
         }
     };
 
+    let resumable = data
+        .req_headers
+        .get_all(SEC_WEBSOCKET_PROTOCOL)
+        .iter()
+        .filter_map(|v| v.to_str().ok())
+        .flat_map(|v| v.split(','))
+        .any(|p| p.trim() == RESUMABLE_PROTOCOL);
+
+    let upgrade = upgrade
+        .protocols([RESUMABLE_PROTOCOL, PROTOCOL])
+        .on_failed_upgrade(|err| log::debug!("websocket upgrade failed: {err}"));
+
+    // If the client is resuming a stream, hand the connection over to it
+    // instead of calling the handler. The stream must belong to the same
+    // endpoint and authenticated user as the resuming request.
+    if let Some(token) = data.req_headers.get(RESUME_TOKEN_HEADER) {
+        let token = token.to_str().ok().map(str::to_string);
+        let endpoint = data.endpoint.name.clone();
+        let auth_uid = data.auth_user_id.clone();
+        let received = data
+            .req_headers
+            .get(RESUME_RECEIVED_HEADER)
+            .and_then(|v| v.to_str().ok())
+            .and_then(|v| v.parse().ok())
+            .unwrap_or(0);
+
+        return Ok(upgrade.on_upgrade(move |websocket| async move {
+            let resume = Resume {
+                websocket,
+                received,
+            };
+            let stream =
+                token.and_then(|token| claim_stream(&token, &endpoint, auth_uid.as_deref()));
+            let resume = match stream {
+                Some(stream) => match stream.send(resume) {
+                    Ok(()) => return,
+                    Err(mpsc::error::SendError(resume)) => resume,
+                },
+                None => resume,
+            };
+            log::debug!("websocket stream can no longer be resumed");
+            reject_resume(resume.websocket).await;
+        }));
+    }
+
     let (tx, mut rx) = mpsc::unbounded_channel::<HandlerResponse>();
 
     let direction = data.direction;
-    Ok(upgrade
-        .protocols(["encore-ws"])
-        .on_failed_upgrade(|err| log::debug!("websocket upgrade failed: {err}"))
-        .on_upgrade(move |ws| async move {
-            let socket = Socket::new(ws, schema::Stream::new(req_schema, resp_schema).into());
-
-            let payload = match direction {
-                model::StreamDirection::InOut => StreamMessagePayload::InOut(socket),
-                model::StreamDirection::In => {
-                    let (sink, stream) = socket.split();
-
-                    tokio::spawn(async move {
-                        match rx.recv().await {
-                            Some(resp) => match resp {
-                                Ok(HandlerResponseInner {
-                                    payload: Some(resp),
-                                    ..
-                                }) => {
-                                    if sink.send(resp).is_err() {
-                                        log::debug!("sink channel closed");
-                                    }
+    Ok(upgrade.on_upgrade(move |ws| async move {
+        let socket = Socket::new(
+            ws,
+            schema::Stream::new(req_schema, resp_schema).into(),
+            req.clone(),
+            resumable,
+        );
+
+        let payload = match direction {
+            model::StreamDirection::InOut => StreamMessagePayload::InOut(socket),
+            model::StreamDirection::In => {
+                let (sink, stream) = socket.split();
+
+                tokio::spawn(async move {
+                    match rx.recv().await {
+                        Some(resp) => match resp {
+                            Ok(HandlerResponseInner {
+                                payload: Some(resp),
+                                ..
+                            }) => {
+                                if sink.send(resp).await.is_err() {
+                                    log::debug!("sink channel closed");
                                 }
-                                Ok(HandlerResponseInner { payload: None, .. }) => {
-                                    log::warn!("responded with empty response")
-                                }
-                                Err(err) => log::warn!("responded with error: {err:?}"),
-                            },
-                            None => log::debug!("response channel closed"),
-                        };
-                    });
+                            }
+                            Ok(HandlerResponseInner { payload: None, .. }) => {
+                                log::warn!("responded with empty response")
+                            }
+                            Err(err) => log::warn!("responded with error: {err:?}"),
+                        },
+                        None => log::debug!("response channel closed"),
+                    };
+                });
 
-                    StreamMessagePayload::In(stream)
-                }
-                model::StreamDirection::Out => {
-                    let (sink, _stream) = socket.split();
-                    StreamMessagePayload::Out(sink)
-                }
-            };
+                StreamMessagePayload::In(stream)
+            }
+            model::StreamDirection::Out => {
+                let (sink, _stream) = socket.split();
+                StreamMessagePayload::Out(sink)
+            }
+        };
 
-            (callback)(req, payload, tx).await
-        }))
+        (callback)(req, payload, tx).await
+    }))
+}
+
+async fn reject_resume(mut websocket: WebSocket) {
+    _ = websocket
+        .send(Message::Close(Some(CloseFrame {
+            code: CLOSE_RESUME_FAILED,
+            reason: "stream can no longer be resumed".into(),
+        })))
+        .await;
 }
 
 pub struct Socket {
-    outgoing_message_tx: UnboundedSender<PValues>,
+    outgoing_message_tx: mpsc::Sender<PValues>,
     incoming_message_rx: tokio::sync::Mutex<UnboundedReceiver<PValues>>,
     shutdown: watch::Sender<bool>,
 }
 
 impl Socket {
-    fn new(mut websocket: WebSocket, schema: Arc<schema::Stream>) -> Self {
-        let (shutdown, mut shutdown_watch) = watch::channel(false);
-
-        let (outgoing_message_tx, mut outgoing_messages_rx) = mpsc::unbounded_channel();
+    fn new(
+        websocket: WebSocket,
+        schema: Arc<schema::Stream>,
+        req: Arc<Request>,
+        resumable: bool,
+    ) -> Self {
+        let (shutdown, shutdown_watch) = watch::channel(false);
+
+        let (outgoing_message_tx, outgoing_messages_rx) = mpsc::channel(*SEND_QUEUE_SIZE);
         let (incoming_messages_tx, incoming_message_rx) = mpsc::unbounded_channel();
 
-        tokio::spawn({
-            async move {
-                loop {
-                    tokio::select! {
-                        msg = websocket.recv() => match msg {
-                            None => {
-                                log::trace!("websocket closed");
-                                break
-                            },
-                            Some(Ok(msg)) => {
-                                if let Err(e) = Socket::handle_incoming_message(
-                                    &schema,
-                                    &incoming_messages_tx,
-                                    msg,
-                                )
-                                .await
-                                {
-                                    log::warn!("failed handling incoming message: {e}");
-                                    break;
-                                }
-                            },
-                            Some(Err(e)) => {
-                                log::debug!("websocket receive failed: {e}");
-                                break;
-                            }
-                        },
-                        msg = outgoing_messages_rx.recv() => {
-                            match msg {
-                                None => {
-                                    _ = websocket.close().await;
-                                    log::trace!("websocket closed");
-                                    break;
-                                }
-                                Some(msg) => Socket::handle_outgoing_message(&schema, &mut websocket, msg).await
-                            }
-                        },
-                        _ = shutdown_watch.changed() => {
-                            // gracefully shutdown, wait for all messages to be read on out channel
-                            // before closing the websocket
-                            outgoing_messages_rx.close();
-                        }
-                    }
-                }
-
-                log::trace!("socket closed");
+        let resume = match &req.data {
+            RequestData::Stream(data) if resumable => {
+                let token = new_resume_token();
+                let (tx, rx) = mpsc::unbounded_channel();
+                let stream = ResumableStream {
+                    endpoint: data.endpoint.name.clone(),
+                    auth_uid: data.auth_user_id.clone(),
+                    lost: false,
+                    resumes: tx,
+                };
+                RESUMABLE_STREAMS
+                    .lock()
+                    .unwrap()
+                    .insert(token.clone(), stream);
+                Some((token, rx))
             }
-        });
+            _ => None,
+        };
+
+        let conn = Connection {
+            schema,
+            req,
+            incoming: incoming_messages_tx,
+            token: resume.as_ref().map(|(token, _)| token.clone()),
+            sent: VecDeque::new(),
+            sent_count: 0,
+            received_count: 0,
+        };
+        tokio::spawn(conn.run(
+            websocket,
+            outgoing_messages_rx,
+            shutdown_watch,
+            resume.map(|(_, rx)| rx),
+        ));
 
         Socket {
             outgoing_message_tx,
@@ -173,8 +319,9 @@ impl Socket {
         }
     }
 
-    pub fn send(&self, msg: PValues) -> anyhow::Result<()> {
-        self.outgoing_message_tx.send(msg)?;
+    /// Sends a message, waiting for room in the send queue if it's full.
+    pub async fn send(&self, msg: PValues) -> anyhow::Result<()> {
+        self.outgoing_message_tx.send(msg).await?;
         Ok(())
     }
 
@@ -198,46 +345,267 @@ impl Socket {
 
         (sink, stream)
     }
+}
+
+/// How a connection to the client ended.
+enum ConnectionEnd {
+    /// The stream is done.
+    Closed,
+    /// The connection was lost, and the stream can be resumed.
+    Lost,
+}
+
+/// Connection carries a stream's messages between the socket and the client,
+/// across the connections of resumed streams.
+struct Connection {
+    schema: Arc<schema::Stream>,
+    req: Arc<Request>,
+    incoming: UnboundedSender<PValues>,
+
+    /// The resume token, if the stream is resumable.
+    token: Option<String>,
+
+    /// The most recent messages sent, for replaying to resuming clients.
+    sent: VecDeque<String>,
+
+    sent_count: u64,
+    received_count: u64,
+}
+
+impl Connection {
+    async fn run(
+        mut self,
+        mut websocket: WebSocket,
+        mut outgoing: mpsc::Receiver<PValues>,
+        mut shutdown: watch::Receiver<bool>,
+        mut resumes: Option<UnboundedReceiver<Resume>>,
+    ) {
+        let mut end = match self.send_control(&mut websocket).await {
+            Ok(()) => {
+                self.serve(&mut websocket, &mut outgoing, &mut shutdown)
+                    .await
+            }
+            Err(_) => self.lost(),
+        };
+
+        loop {
+            let resume = match end {
+                ConnectionEnd::Closed => break,
+                ConnectionEnd::Lost => {
+                    let (Some(token), Some(resumes)) = (&self.token, resumes.as_mut()) else {
+                        break;
+                    };
+                    mark_lost(token);
+                    match tokio::time::timeout(RESUME_TIMEOUT, resumes.recv()).await {
+                        Ok(Some(resume)) => resume,
+                        Ok(None) | Err(_) => {
+                            log::debug!("websocket stream not resumed in time");
+                            break;
+                        }
+                    }
+                }
+            };
+
+            websocket = resume.websocket;
+            end = match self.resume(&mut websocket, resume.received).await {
+                Ok(true) => {
+                    self.serve(&mut websocket, &mut outgoing, &mut shutdown)
+                        .await
+                }
+                Ok(false) => {
+                    reject_resume(websocket).await;
+                    break;
+                }
+                Err(_) => ConnectionEnd::Lost,
+            };
+        }
+
+        if let Some(token) = &self.token {
+            RESUMABLE_STREAMS.lock().unwrap().remove(token);
+        }
+        log::trace!("socket closed");
+    }
+
+    async fn serve(
+        &mut self,
+        websocket: &mut WebSocket,
+        outgoing: &mut mpsc::Receiver<PValues>,
+        shutdown: &mut watch::Receiver<bool>,
+    ) -> ConnectionEnd {
+        loop {
+            tokio::select! {
+                msg = websocket.recv() => match msg {
+                    None => {
+                        log::trace!("websocket closed");
+                        return self.lost();
+                    },
+                    Some(Ok(Message::Close(_))) => {
+                        log::trace!("websocket closed by client");
+                        return ConnectionEnd::Closed;
+                    },
+                    Some(Ok(msg)) => {
+                        match self.handle_incoming_message(websocket, msg).await {
+                            Ok(()) => {},
+                            Err(e) if self.incoming.is_closed() => {
+                                log::warn!("failed handling incoming message: {e}");
+                                return ConnectionEnd::Closed;
+                            },
+                            Err(e) => {
+                                log::debug!("failed to acknowledge message: {e}");
+                                return self.lost();
+                            },
+                        }
+                    },
+                    Some(Err(e)) => {
+                        log::debug!("websocket receive failed: {e}");
+                        return self.lost();
+                    }
+                },
+                msg = outgoing.recv() => {
+                    match msg {
+                        None => {
+                            _ = websocket.send(Message::Close(None)).await;
+                            log::trace!("websocket closed");
+                            return ConnectionEnd::Closed;
+                        }
+                        Some(msg) => {
+                            if let Err(e) = self.handle_outgoing_message(websocket, msg).await {
+                                log::debug!("failed to send message to socket: {e}");
+                                return self.lost();
+                            }
+                        }
+                    }
+                },
+                _ = shutdown.changed() => {
+                    // gracefully shutdown, wait for all messages to be read on out channel
+                    // before closing the websocket
+                    outgoing.close();
+                }
+            }
+        }
+    }
+
+    /// Reports the connection as lost, which ends the stream
+    /// unless it can be resumed.
+    fn lost(&self) -> ConnectionEnd {
+        if self.token.is_some() {
+            ConnectionEnd::Lost
+        } else {
+            ConnectionEnd::Closed
+        }
+    }
+
+    /// Resumes the stream on a new connection, replaying the messages
+    /// the client hasn't received. It reports false if messages the client
+    /// hasn't received are no longer available.
+    async fn resume(&mut self, websocket: &mut WebSocket, received: u64) -> anyhow::Result<bool> {
+        let oldest = self.sent_count - self.sent.len() as u64;
+        if received < oldest || received > self.sent_count {
+            return Ok(false);
+        }
+
+        self.send_control(websocket).await?;
+        for msg in self.sent.iter().skip((received - oldest) as usize) {
+            websocket.send(Message::Text(msg.clone())).await?;
+        }
+
+        log::debug!(
+            "resumed websocket stream, replayed {} messages",
+            self.sent_count - received
+        );
+        Ok(true)
+    }
+
+    /// Sends a control message to the client of a resumable stream.
+    async fn send_control(&self, websocket: &mut WebSocket) -> anyhow::Result<()> {
+        let Some(token) = &self.token else {
+            return Ok(());
+        };
+
+        let msg = serde_json::json!({
+            "token": token,
+            "received": self.received_count,
+        });
+        websocket
+            .send(Message::Binary(serde_json::to_vec(&msg)?))
+            .await?;
+        Ok(())
+    }
 
     async fn handle_outgoing_message(
-        schema: &schema::Stream,
+        &mut self,
         websocket: &mut WebSocket,
         msg: PValues,
-    ) {
-        let msg = schema
+    ) -> anyhow::Result<()> {
+        let msg = self
+            .schema
             .to_outgoing_message(msg)
             .and_then(|msg| String::from_utf8(msg).map_err(super::Error::internal));
 
-        match msg {
-            Ok(msg) => {
-                if let Err(e) = websocket.send(Message::Text(msg)).await {
-                    log::debug!("failed to send message to socket: {e}")
-                }
+        let msg = match msg {
+            Ok(msg) => msg,
+            Err(e) => {
+                log::warn!("failed to send message to socket: {e}");
+                return Ok(());
+            }
+        };
+
+        self.sent_count += 1;
+        if let RequestData::Stream(data) = &self.req.data {
+            data.tracer.stream_message(StreamMessageData {
+                source: &self.req,
+                direction: StreamMessageDirection::Outgoing,
+                seq: self.sent_count,
+                size: msg.len(),
+            });
+        }
+
+        // Keep the message until we know the client has received it,
+        // as the connection may be lost before it's delivered.
+        if self.token.is_some() {
+            if self.sent.len() >= *SEND_QUEUE_SIZE {
+                self.sent.pop_front();
             }
-            Err(e) => log::warn!("failed to send message to socket: {e}"),
+            self.sent.push_back(msg.clone());
         }
+
+        websocket.send(Message::Text(msg)).await?;
+        Ok(())
     }
 
     async fn handle_incoming_message<M>(
-        schema: &schema::Stream,
-        incoming: &UnboundedSender<PValues>,
+        &mut self,
+        websocket: &mut WebSocket,
         msg: M,
     ) -> anyhow::Result<()>
     where
         M: MessagePayload,
     {
-        if let Some(data) = msg.payload() {
-            match schema.parse_incoming_message(data).await {
-                Ok(msg) => {
-                    if let Err(e) = incoming.send(msg) {
-                        return Err(anyhow!("tried to send on closed channel: {e}"));
-                    }
-                }
-                Err(e) => log::warn!("failed to parse incoming message: {e}"),
-            };
+        let Some(data) = msg.payload() else {
+            return Ok(());
+        };
+
+        self.received_count += 1;
+        if let RequestData::Stream(req) = &self.req.data {
+            req.tracer.stream_message(StreamMessageData {
+                source: &self.req,
+                direction: StreamMessageDirection::Incoming,
+                seq: self.received_count,
+                size: data.len(),
+            });
         }
 
-        Ok(())
+        match self.schema.parse_incoming_message(data).await {
+            Ok(msg) => {
+                if let Err(e) = self.incoming.send(msg) {
+                    return Err(anyhow!("tried to send on closed channel: {e}"));
+                }
+            }
+            Err(e) => log::warn!("failed to parse incoming message: {e}"),
+        };
+
+        // Acknowledge the message, so the client can stop keeping it around.
+        self.send_control(websocket).await
     }
 }
 
@@ -256,13 +624,14 @@ impl MessagePayload for axum::extract::ws::Message {
     }
 }
 pub struct Sink {
-    tx: UnboundedSender<PValues>,
+    tx: mpsc::Sender<PValues>,
     shutdown: watch::Sender<bool>,
 }
 
 impl Sink {
-    pub fn send(&self, msg: PValues) -> anyhow::Result<()> {
-        self.tx.send(msg)?;
+    /// Sends a message, waiting for room in the send queue if it's full.
+    pub async fn send(&self, msg: PValues) -> anyhow::Result<()> {
+        self.tx.send(msg).await?;
         Ok(())
     }
 
@@ -279,3 +648,47 @@ impl Stream {
         self.rx.lock().await.recv().await
     }
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_resume_token_is_random() {
+        let token = new_resume_token();
+        assert_eq!(URL_SAFE_NO_PAD.decode(&token).unwrap().len(), 32);
+        assert_ne!(token, new_resume_token());
+    }
+
+    #[test]
+    fn test_claim_stream() {
+        let endpoint = EndpointName::new("svc", "Stream");
+        let token = new_resume_token();
+        let (tx, _rx) = mpsc::unbounded_channel();
+        RESUMABLE_STREAMS.lock().unwrap().insert(
+            token.clone(),
+            ResumableStream {
+                endpoint: endpoint.clone(),
+                auth_uid: Some("alice".into()),
+                lost: false,
+                resumes: tx,
+            },
+        );
+
+        // The stream can't be taken over while its connection is live.
+        assert!(claim_stream(&token, &endpoint, Some("alice")).is_none());
+
+        mark_lost(&token);
+        assert!(claim_stream(&token, &endpoint, Some("mallory")).is_none());
+        assert!(claim_stream(&token, &endpoint, None).is_none());
+        let other = EndpointName::new("svc", "Other");
+        assert!(claim_stream(&token, &other, Some("alice")).is_none());
+        assert!(claim_stream(&new_resume_token(), &endpoint, Some("alice")).is_none());
+
+        // The stream is handed over once per lost connection.
+        assert!(claim_stream(&token, &endpoint, Some("alice")).is_some());
+        assert!(claim_stream(&token, &endpoint, Some("alice")).is_none());
+
+        RESUMABLE_STREAMS.lock().unwrap().remove(&token);
+    }
+}
@@ -467,6 +467,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                             cloud_name: bucket.name,
                             key_prefix: bucket.key_prefix,
                             public_base_url: bucket.public_base_url,
+                            encryption: None,
                             rid: get_next_rid(),
                         })
                         .collect(),
@@ -492,6 +493,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                             cloud_name: bucket.name,
                             key_prefix: bucket.key_prefix,
                             public_base_url: bucket.public_base_url,
+                            encryption: None,
                             rid: get_next_rid(),
                         })
                         .collect(),
@@ -678,6 +680,9 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                                 role_rid,
                                 min_connections: db.min_connections.unwrap_or(0),
                                 max_connections: db.max_connections.unwrap_or(100),
+                                max_idle_time: None,
+                                max_conn_lifetime: None,
+                                statement_timeout: None,
                             }],
                         }
                     })
@@ -12,7 +12,7 @@ use tokio::time::Instant;
 use crate::api::reqauth::caller::Caller;
 use crate::api::schema::JSONPayload;
 use crate::api::{auth, Endpoint, PValue, PValues};
-use crate::{api, EncoreName, EndpointName};
+use crate::{api, trace, EncoreName, EndpointName};
 
 #[derive(Clone, Copy, Debug, Hash, Eq, PartialEq)]
 pub struct TraceId(pub [u8; 16]);
@@ -228,6 +228,9 @@ pub struct StreamRequestData {
 
     /// Stream direction
     pub direction: StreamDirection,
+
+    /// The tracer to record the stream's messages with.
+    pub tracer: trace::Tracer,
 }
 
 #[derive(Debug)]
@@ -48,6 +48,7 @@ pub enum EventType {
     BucketListObjectsEnd = 0x20,
     BucketDeleteObjectsStart = 0x21,
     BucketDeleteObjectsEnd = 0x22,
+    StreamMessage = 0x28,
 }
 
 // A global event id counter.
@@ -835,6 +836,43 @@ impl Tracer {
     }
 }
 
+#[derive(Debug, Clone, Copy)]
+pub enum StreamMessageDirection {
+    Incoming,
+    Outgoing,
+}
+
+pub struct StreamMessageData<'a> {
+    pub source: &'a Request,
+    pub direction: StreamMessageDirection,
+
+    /// The position of the message in the stream in its direction, starting at 1.
+    pub seq: u64,
+
+    /// The size of the encoded message, in bytes.
+    pub size: usize,
+}
+
+impl Tracer {
+    #[inline]
+    pub fn stream_message(&self, data: StreamMessageData) {
+        let mut eb = BasicEventData {
+            correlation_event_id: None,
+            extra_space: 1 + 10 + 10,
+        }
+        .into_eb();
+
+        eb.byte(match data.direction {
+            StreamMessageDirection::Incoming => 0,
+            StreamMessageDirection::Outgoing => 1,
+        });
+        eb.uvarint(data.seq);
+        eb.uvarint(data.size as u64);
+
+        _ = self.send(EventType::StreamMessage, data.source.span, eb);
+    }
+}
+
 impl Tracer {
     #[inline]
     fn send(&self, typ: EventType, span: model::SpanKey, eb: EventBuffer) -> model::TraceEventId {
@@ -0,0 +1,98 @@
+// Package alerts provides support for declaring alert rules next to the code they monitor.
+//
+// Alert rules are not evaluated by the application itself. Instead they are parsed by
+// the Encore compiler and exported using "encore gen alerts" as Prometheus alerting rules
+// or Grafana alert provisioning files, based on the metrics reported by the Encore runtime.
+//
+// For more information see https://encore.dev/docs/observability/alerts.
+package alerts
+
+import "time"
+
+// NewRule declares a new alert rule. It must be called from within a service,
+// and the rule is owned by that service.
+//
+// The name argument is a unique identifier for the rule, defined in kebab-case.
+//
+// The fields provided in the RuleConfig must be constant literals, as they are parsed
+// directly by the Encore compiler and are not actually used at runtime.
+//
+// To declare an alert rule, call NewRule and assign it to a package-level variable:
+//
+//	import "encore.dev/alerts"
+//
+//	// Alert when more than 5% of payments fail.
+//	var _ = alerts.NewRule("payment-errors", alerts.RuleConfig{
+//		Title:          "Payments are failing",
+//		Endpoint:       Pay,
+//		ErrorRateAbove: 0.05,
+//		Severity:       alerts.Critical,
+//	})
+//
+// Exactly one of ErrorRateAbove, LatencyAbove and QueueLagAbove must be set.
+func NewRule(name string, cfg RuleConfig) *Rule {
+	return &Rule{name: name, cfg: cfg}
+}
+
+// RuleConfig is the configuration of an alert rule.
+//
+// The fields provided in the RuleConfig must be constant literals, as they are parsed
+// directly by the Encore compiler and are not actually used at runtime.
+type RuleConfig struct {
+	// Title is a short description of the alert, such as "Payments are failing".
+	// It defaults to the name of the rule.
+	Title string
+
+	// Endpoint is the API endpoint the rule applies to, for error rate and latency rules.
+	// If nil, the rule applies to all endpoints of the service.
+	Endpoint any
+
+	// Subscription is the Pub/Sub subscription the rule applies to, for queue lag rules.
+	Subscription any
+
+	// ErrorRateAbove triggers the alert when the fraction of requests
+	// returning an error exceeds it. It must be between 0 and 1.
+	ErrorRateAbove float64
+
+	// LatencyAbove triggers the alert when the request latency percentile
+	// given by LatencyPercentile exceeds it.
+	LatencyAbove time.Duration
+
+	// LatencyPercentile is the percentile of the request latency compared against LatencyAbove,
+	// between 0 and 100 (exclusive). It defaults to 99.
+	LatencyPercentile float64
+
+	// QueueLagAbove triggers the alert when the time between publishing a message
+	// and delivering it to the Subscription exceeds it.
+	QueueLagAbove time.Duration
+
+	// Window is the time window the error rate and latency are computed over.
+	// It defaults to 5 minutes.
+	Window time.Duration
+
+	// For is how long the condition must hold before the alert fires.
+	// It defaults to 5 minutes.
+	For time.Duration
+
+	// Severity is the severity of the alert. It defaults to Warning.
+	Severity Severity
+}
+
+// Severity is the severity of an alert.
+type Severity string
+
+const (
+	Warning  Severity = "warning"
+	Critical Severity = "critical"
+)
+
+// Rule is an alert rule, declared with NewRule.
+type Rule struct {
+	name string
+	cfg  RuleConfig
+}
+
+// Name returns the name of the rule.
+func (r *Rule) Name() string {
+	return r.name
+}
@@ -22,6 +22,7 @@ type AuthHandlerDesc[Params any] struct {
 	Endpoint    string
 	DefLoc      uint32
 	HasAuthData bool // whether the handler returns custom auth data
+	Order       int  // the resolution order, when multiple auth handlers are defined
 
 	DecodeAuth  func(*http.Request) (Params, error)
 	AuthHandler func(context.Context, Params) (model.AuthInfo, error)
@@ -65,6 +66,7 @@ func (d *AuthHandlerDesc[Params]) Authenticate(c IncomingContext) (model.AuthInf
 			TraceID:       c.callMeta.TraceID,
 			ParentSpanID:  c.callMeta.ParentSpanID,
 			ParentSampled: c.callMeta.TraceSampled,
+			ForceTrace:    c.callMeta.ForceTrace,
 			SpanID:        call.SpanID,
 			DefLoc:        d.DefLoc,
 			Type:          model.AuthHandler,
@@ -122,14 +124,17 @@ func (d *AuthHandlerDesc[Params]) ParseAuthData(c IncomingContext) error {
 
 // runAuthHandler runs the auth handler, if provided.
 // It reports whether to proceed with calling the handler.
-func (s *Server) runAuthHandler(h Handler, c IncomingContext) (info model.AuthInfo, proceed bool) {
+//
+// If the request included auth information that the auth handler rejected,
+// and the endpoint doesn't require auth, it proceeds and reports the rejection as authErr.
+func (s *Server) runAuthHandler(h Handler, c IncomingContext) (info model.AuthInfo, authErr error, proceed bool) {
 	requiresAuth := h.AccessType() == RequiresAuth
 	if s.authHandler == nil {
 		if requiresAuth {
 			panic(fmt.Sprintf("internal error: API %s.%s requires auth but no auth handler set",
 				h.ServiceName(), h.EndpointName()))
 		}
-		return model.AuthInfo{}, true
+		return model.AuthInfo{}, nil, true
 	}
 
 	// If this is a service to service call, we use the existing auth info.
@@ -138,10 +143,10 @@ func (s *Server) runAuthHandler(h Handler, c IncomingContext) (info model.AuthIn
 			// Unless there isn't some and we need it, in which case we error.
 			err := errs.B().Code(errs.Unauthenticated).Msg("no auth info provided").Err()
 			returnError(c, err, 0, nil)
-			return model.AuthInfo{}, false
+			return model.AuthInfo{}, nil, false
 		}
 
-		return c.auth, true
+		return c.auth, c.authErr, true
 	}
 
 	var err error
@@ -150,14 +155,19 @@ func (s *Server) runAuthHandler(h Handler, c IncomingContext) (info model.AuthIn
 		// If the auth handler returned Unauthenticated and the endpoint doesn't actually require auth,
 		// continue as if no auth information was provided.
 		if errs.Code(err) == errs.Unauthenticated && !requiresAuth {
-			return model.AuthInfo{}, true
+			// Only report the error if auth information was actually provided,
+			// as opposed to the auth handler not being called at all.
+			if s.authHandler.ParseAuthData(c) == nil {
+				authErr = err
+			}
+			return model.AuthInfo{}, authErr, true
 		} else {
 			returnError(c, err, 0, nil)
-			return model.AuthInfo{}, false
+			return model.AuthInfo{}, nil, false
 		}
 	}
 
-	return info, true
+	return info, nil, true
 }
 
 // rpcDesc returns the RPC description for this endpoint,
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"cmp"
+	"slices"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/beta/errs"
+)
+
+// authChain is an AuthHandler that resolves requests using multiple auth handlers.
+//
+// Each request is authenticated by the first handler, in resolution order,
+// whose auth parameters are present in the request.
+// If no handler applies, the first handler is used.
+type authChain struct {
+	handlers []AuthHandler // sorted in resolution order
+}
+
+var _ AuthHandler = (*authChain)(nil)
+
+// orderedAuthHandler is implemented by auth handlers with a resolution order.
+type orderedAuthHandler interface {
+	authOrder() int
+}
+
+func (d *AuthHandlerDesc[Params]) authOrder() int {
+	return d.Order
+}
+
+// add adds an auth handler to the chain, keeping the chain in resolution order.
+func (a *authChain) add(h AuthHandler) {
+	a.handlers = append(a.handlers, h)
+	slices.SortStableFunc(a.handlers, func(x, y AuthHandler) int {
+		return cmp.Compare(authOrderOf(x), authOrderOf(y))
+	})
+}
+
+func (a *authChain) Authenticate(c IncomingContext) (model.AuthInfo, error) {
+	return a.resolve(c).Authenticate(c)
+}
+
+func (a *authChain) HostedByService() string {
+	// All auth handlers are hosted by the same service,
+	// as validated by the compiler.
+	return a.handlers[0].HostedByService()
+}
+
+func (a *authChain) ParseAuthData(c IncomingContext) error {
+	return a.resolve(c).ParseAuthData(c)
+}
+
+// resolve returns the auth handler to use for the given request.
+func (a *authChain) resolve(c IncomingContext) AuthHandler {
+	for _, h := range a.handlers {
+		// An auth handler applies unless its auth parameters are missing,
+		// which is reported as Unauthenticated.
+		if err := h.ParseAuthData(c); err == nil || errs.Code(err) != errs.Unauthenticated {
+			return h
+		}
+	}
+	return a.handlers[0]
+}
+
+func authOrderOf(h AuthHandler) int {
+	if o, ok := h.(orderedAuthHandler); ok {
+		return o.authOrder()
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"encore.dev/beta/errs"
+)
+
+func TestAuthChain_Resolve(t *testing.T) {
+	newHandler := func(name, header string, order int) *AuthHandlerDesc[string] {
+		return &AuthHandlerDesc[string]{
+			Service:  "svc",
+			Endpoint: name,
+			Order:    order,
+			DecodeAuth: func(req *http.Request) (string, error) {
+				val := req.Header.Get(header)
+				if val == "" {
+					return "", errs.B().Code(errs.Unauthenticated).Msg("missing auth param").Err()
+				} else if val == "invalid" {
+					return "", errs.B().Code(errs.InvalidArgument).Msg("invalid auth param").Err()
+				}
+				return val, nil
+			},
+		}
+	}
+
+	apiKey := newHandler("APIKey", "X-API-Key", 1)
+	bearer := newHandler("Bearer", "Authorization", 2)
+
+	// Add the handlers out of order to verify they are sorted.
+	var chain authChain
+	chain.add(bearer)
+	chain.add(apiKey)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    *AuthHandlerDesc[string]
+		wantErr error
+	}{
+		{"none", nil, apiKey, errors.New("missing auth param")},
+		{"api_key", map[string]string{"X-API-Key": "key"}, apiKey, nil},
+		{"bearer", map[string]string{"Authorization": "token"}, bearer, nil},
+		{"both", map[string]string{"X-API-Key": "key", "Authorization": "token"}, apiKey, nil},
+		{"invalid_api_key", map[string]string{"X-API-Key": "invalid", "Authorization": "token"}, apiKey, errors.New("invalid auth param")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			c := IncomingContext{req: req}
+
+			if got := chain.resolve(c); got != tt.want {
+				t.Errorf("resolve() = %s, want %s", got.(*AuthHandlerDesc[string]).Endpoint, tt.want.Endpoint)
+			}
+
+			err := chain.ParseAuthData(c)
+			if (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("ParseAuthData() = %v, want %v", err, tt.wantErr)
+			} else if err != nil && errs.Convert(err).(*errs.Error).Message != tt.wantErr.Error() {
+				t.Errorf("ParseAuthData() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
+
+	encore "encore.dev"
+	"encore.dev/appruntime/apisdk/api/transport"
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/health"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/beta/errs"
+	"encore.dev/metrics"
+	"encore.dev/pubsub"
+	"encore.dev/storage/cache"
+)
+
+// authStatus is the auth information an endpoint observed.
+type authStatus struct {
+	UID     model.UID
+	AuthErr string // the auth handler's rejection, if any
+}
+
+func TestServer_AuthStatus(t *testing.T) {
+	s := newAuthTestServer(t)
+	public := newAuthStatusDesc(s, "Public", Public)
+	private := newAuthStatusDesc(s, "Private", Private)
+	requiresAuth := newAuthStatusDesc(s, "RequiresAuth", RequiresAuth)
+
+	apiCaller := ApiCaller{ServiceName: "caller", Endpoint: "Caller"}
+
+	tests := []struct {
+		name     string
+		desc     *Desc[Void, *authStatus]
+		token    string
+		meta     CallMeta
+		wantCode int
+		want     authStatus
+	}{
+		{
+			name:     "anonymous",
+			desc:     public,
+			wantCode: 200,
+			want:     authStatus{},
+		},
+		{
+			name:     "authenticated",
+			desc:     public,
+			token:    "valid",
+			wantCode: 200,
+			want:     authStatus{UID: "alice"},
+		},
+		{
+			name:     "rejected",
+			desc:     public,
+			token:    "expired",
+			wantCode: 200,
+			want:     authStatus{AuthErr: "invalid token"},
+		},
+		{
+			name:     "requires_auth_authenticated",
+			desc:     requiresAuth,
+			token:    "valid",
+			wantCode: 200,
+			want:     authStatus{UID: "alice"},
+		},
+		{
+			name:     "requires_auth_anonymous",
+			desc:     requiresAuth,
+			wantCode: 401,
+		},
+		{
+			name:     "requires_auth_rejected",
+			desc:     requiresAuth,
+			token:    "expired",
+			wantCode: 401,
+		},
+		{
+			name: "service_call_authenticated",
+			desc: private,
+			meta: CallMeta{Internal: &InternalCallMeta{
+				Caller:  apiCaller,
+				AuthUID: "alice",
+			}},
+			wantCode: 200,
+			want:     authStatus{UID: "alice"},
+		},
+		{
+			name: "service_call_rejected",
+			desc: private,
+			meta: CallMeta{Internal: &InternalCallMeta{
+				Caller:  apiCaller,
+				AuthErr: "invalid token",
+			}},
+			wantCode: 200,
+			want:     authStatus{AuthErr: "invalid token"},
+		},
+		{
+			name: "service_call_requires_auth_rejected",
+			desc: requiresAuth,
+			meta: CallMeta{Internal: &InternalCallMeta{
+				Caller:  apiCaller,
+				AuthErr: "invalid token",
+			}},
+			wantCode: 401,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			if tt.meta.IsServiceToService() {
+				transport.HTTPRequest(req).SetMeta(calleeMetaName, "svc."+tt.desc.Endpoint)
+			}
+			s.processRequest(tt.desc, s.NewIncomingContext(w, req, nil, tt.meta))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("got code %d, want %d (body: %s)", w.Code, tt.wantCode, w.Body.String())
+			} else if w.Code != 200 {
+				return
+			}
+			var got authStatus
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			} else if got != tt.want {
+				t.Errorf("got auth status %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestServer_AuthStatusCallMeta tests that the auth status of a request
+// is propagated to the services it calls.
+func TestServer_AuthStatusCallMeta(t *testing.T) {
+	s := newAuthTestServer(t)
+
+	tests := []struct {
+		name  string
+		token string
+		auth  *model.AuthInfo // auth override for the call, if any
+		want  InternalCallMeta
+	}{
+		{
+			name: "anonymous",
+			want: InternalCallMeta{},
+		},
+		{
+			name:  "authenticated",
+			token: "valid",
+			want:  InternalCallMeta{AuthUID: "alice"},
+		},
+		{
+			name:  "rejected",
+			token: "expired",
+			want:  InternalCallMeta{AuthErr: "invalid token"},
+		},
+		{
+			name:  "rejected_with_auth_override",
+			token: "expired",
+			auth:  &model.AuthInfo{UID: "bob"},
+			want:  InternalCallMeta{AuthUID: "bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *InternalCallMeta
+			desc := newAuthStatusDesc(s, "Caller", Public)
+			desc.AppHandler = func(ctx context.Context, _ Void) (*authStatus, error) {
+				if tt.auth != nil {
+					ctx = WithCallOptions(ctx, &CallOptions{Auth: tt.auth})
+				}
+				call, meta, err := s.beginCall(ctx, "svc", "Callee", 0)
+				if err != nil {
+					return nil, err
+				}
+				s.finishCall(call, nil)
+				got = meta.Internal
+				return &authStatus{}, nil
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			s.processRequest(desc, s.NewIncomingContext(w, req, nil, CallMeta{}))
+
+			if w.Code != 200 {
+				t.Fatalf("got code %d, want 200 (body: %s)", w.Code, w.Body.String())
+			} else if got == nil {
+				t.Fatal("no internal call metadata")
+			}
+			if got.AuthUID != tt.want.AuthUID || got.AuthErr != tt.want.AuthErr {
+				t.Errorf("got uid %q and auth error %q, want %q and %q",
+					got.AuthUID, got.AuthErr, tt.want.AuthUID, tt.want.AuthErr)
+			}
+		})
+	}
+}
+
+// newAuthTestServer returns a server with a bearer token auth handler,
+// which authenticates the token "valid" as the user "alice".
+func newAuthTestServer(t *testing.T) *Server {
+	static := &config.Static{}
+	runtime := &config.Runtime{}
+	logger := zerolog.Nop()
+	rt := reqtrack.New(logger, nil, nil)
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	testingMgr := testsupport.NewManager(static, rt, logger)
+	s := NewServer(static, runtime, rt, nil,
+		encore.NewManager(static, runtime, rt),
+		pubsub.NewManager(static, runtime, rt, testingMgr, logger, json),
+		cache.NewManager(static, runtime, rt, testingMgr, json),
+		logger, metrics.NewRegistry(rt, 0), health.NewCheckRegistry(), testingMgr, json, clock.New())
+
+	s.setAuthHandler(&AuthHandlerDesc[string]{
+		Service:  "svc",
+		Endpoint: "AuthHandler",
+		DecodeAuth: func(req *http.Request) (string, error) {
+			token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				return "", errs.B().Code(errs.Unauthenticated).Msg("missing auth param").Err()
+			}
+			return token, nil
+		},
+		AuthHandler: func(ctx context.Context, token string) (model.AuthInfo, error) {
+			if token != "valid" {
+				return model.AuthInfo{}, errs.B().Code(errs.Unauthenticated).Msg("invalid token").Err()
+			}
+			return model.AuthInfo{UID: "alice"}, nil
+		},
+	})
+	return s
+}
+
+// newAuthStatusDesc returns an endpoint that responds with the auth status it observed.
+func newAuthStatusDesc(s *Server, endpoint string, access Access) *Desc[Void, *authStatus] {
+	return &Desc[Void, *authStatus]{
+		Service:  "svc",
+		Endpoint: endpoint,
+		Methods:  []string{"POST"},
+		Path:     "/" + endpoint,
+		Access:   access,
+
+		DecodeReq: func(req *http.Request, ps UnnamedParams, json jsoniter.API) (Void, UnnamedParams, error) {
+			return Void{}, ps, nil
+		},
+		CloneReq: func(req Void) (Void, error) {
+			return req, nil
+		},
+		ReqPath: func(req Void) (string, UnnamedParams, error) {
+			return "/" + endpoint, nil, nil
+		},
+		ReqUserPayload: func(req Void) any {
+			return nil
+		},
+		AppHandler: func(ctx context.Context, _ Void) (*authStatus, error) {
+			data := s.rt.Current().Req.RPCData
+			st := &authStatus{UID: data.UserID}
+			if data.AuthErr != nil {
+				st.AuthErr = errs.Convert(data.AuthErr).(*errs.Error).Message
+			}
+			return st, nil
+		},
+		EncodeResp: func(w http.ResponseWriter, json jsoniter.API, resp *authStatus, status int) error {
+			data, err := json.Marshal(resp)
+			_, _ = w.Write(data)
+			return err
+		},
+		CloneResp: func(resp *authStatus) (*authStatus, error) {
+			clone := *resp
+			return &clone, nil
+		},
+	}
+}
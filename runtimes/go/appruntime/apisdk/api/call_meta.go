@@ -49,6 +49,10 @@ type CallMeta struct {
 	CorrelationID string             // The correlation ID of the calling request
 	TraceSampled  bool               // Whether the caller sampled trace info
 
+	// ForceTrace is whether the request carries a valid force-trace token,
+	// forcing it to be traced regardless of the trace sampling rate.
+	ForceTrace bool
+
 	// Internal meta data which gets populated by Encore on service to service calls
 	//
 	// If set, the values can be trusted as they would have been authenticated to be correct
@@ -61,6 +65,7 @@ type InternalCallMeta struct {
 	Caller   Caller // The name of the service which is making the call
 	AuthUID  string // The UID of the authenticated user
 	AuthData any    // The data of the authenticated user
+	AuthErr  string // The auth handler's error message, if it rejected the provided auth information
 }
 
 // addInternalCallMeta adds internal metadata to the external request
@@ -109,6 +114,9 @@ func (s *Server) metaFromAPICall(call *model.APICall) (meta CallMeta, err error)
 		AuthUID:  string(call.UserID),
 		AuthData: call.AuthData,
 	}
+	if call.AuthErr != nil && call.UserID == "" {
+		meta.Internal.AuthErr = errs.Convert(call.AuthErr).(*errs.Error).Message
+	}
 
 	return meta, nil
 }
@@ -165,6 +173,8 @@ func (meta CallMeta) AddToRequest(server *Server, targetService config.Service,
 				}
 				req.SetMeta("AuthData", string(authData))
 			}
+		} else if meta.Internal.AuthErr != "" {
+			req.SetMeta("AuthError", meta.Internal.AuthErr)
 		}
 
 		// If we're making an internal call, sign the request
@@ -226,6 +236,8 @@ func (s *Server) MetaFromRequest(req transport.Transport) (meta CallMeta, err er
 					return CallMeta{}, errs.B().Cause(err).Msg("failed to unmarshal auth data").Err()
 				}
 			}
+		} else if authErr, found := req.ReadMeta("AuthError"); found {
+			meta.Internal.AuthErr = authErr
 		}
 	}
 
@@ -262,6 +274,12 @@ func (s *Server) MetaFromRequest(req transport.Transport) (meta CallMeta, err er
 		}
 	}
 
+	// A valid force-trace token lets support engineers capture a full trace
+	// of a specific request, even when the sampling rate is low.
+	if token, found := req.ReadMeta(transport.ForceTraceKey); found && s.pc != nil {
+		meta.ForceTrace = s.pc.ValidateForceTraceToken(token)
+	}
+
 	if correlationID, found := req.ReadMeta(transport.CorrelationIDKey); found {
 		// Don't allow arbitrary correlation IDs to be passed through
 		if len(meta.CorrelationID) > 64 {
@@ -0,0 +1,309 @@
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+
+	"encore.dev/beta/errs"
+)
+
+// compressMinSize is the minimum size of response bodies to compress.
+// Smaller responses are sent uncompressed, as compressing them saves
+// too little to be worth the CPU time on both ends.
+const compressMinSize = 1024
+
+// contentEncodings are the supported content encodings, in order of preference.
+var contentEncodings = []string{"zstd", "br", "gzip"}
+
+// encoder is implemented by the compressing writers of all content encodings.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// encoderPools pool the encoders of each content encoding,
+// as they're expensive to allocate.
+var encoderPools = map[string]*sync.Pool{
+	"zstd": {New: func() any {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedDefault))
+		return enc
+	}},
+	"br": {New: func() any {
+		// Brotli's default level is too slow for compressing responses on the fly.
+		return brotli.NewWriterLevel(nil, 4)
+	}},
+	"gzip": {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+}
+
+// negotiateEncoding returns the content encoding to compress a response with,
+// given the request's Accept-Encoding header, or "" if it must not be compressed.
+func negotiateEncoding(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	qvalues := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if key, val, ok := strings.Cut(params, "="); ok && strings.TrimSpace(key) == "q" {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(val), 64); err == nil {
+				q = f
+			}
+		}
+		qvalues[name] = q
+	}
+
+	// Pick the encoding with the highest q-value, breaking ties by our preference.
+	best, bestQ := "", 0.0
+	for _, enc := range contentEncodings {
+		q, ok := qvalues[enc]
+		if !ok {
+			q = qvalues["*"]
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// compressResponse wraps c.w to compress the response if the endpoint allows it
+// and the caller accepts a supported encoding. It returns nil if it doesn't.
+func (d *Desc[Req, Resp]) compressResponse(c IncomingContext) *compressWriter {
+	// Service-to-service calls stay within the application,
+	// where compressing them costs more than it saves.
+	if d.Raw || d.DisableCompression || c.callMeta.PrivateAPIAccess() {
+		return nil
+	}
+
+	// The response depends on the Accept-Encoding header, even when it isn't compressed.
+	c.w.Header().Add("Vary", "Accept-Encoding")
+	encoding := negotiateEncoding(c.req.Header.Get("Accept-Encoding"))
+	if encoding == "" {
+		return nil
+	}
+	return &compressWriter{ResponseWriter: c.w, encoding: encoding}
+}
+
+// compressWriter compresses the response body written to it using the negotiated encoding.
+//
+// The start of the body is buffered until compressMinSize bytes have been written,
+// to decide whether it's worth compressing. Responses that are already encoded,
+// or that must not be buffered such as event streams, are written as-is.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+
+	status  int    // status code passed to WriteHeader, or 0 if it hasn't been called
+	buf     []byte // the start of the body, until decided
+	decided bool
+	enc     encoder // set if the body is being compressed
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	} else if status < 200 {
+		// Informational responses are followed by the final one.
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+	if status == http.StatusNoContent || status == http.StatusNotModified || !w.compressible() {
+		w.decide(false)
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		if w.status == 0 {
+			w.status = http.StatusOK
+			if !w.compressible() {
+				w.decide(false)
+				return w.ResponseWriter.Write(p)
+			}
+		}
+		w.buf = append(w.buf, p...)
+		if len(w.buf) >= compressMinSize {
+			if err := w.decide(true); err != nil {
+				return 0, err
+			}
+		}
+		return len(p), nil
+	}
+
+	if w.enc != nil {
+		return w.enc.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush writes out the body written so far, deciding
+// not to compress it if it's still below the minimum size.
+func (w *compressWriter) Flush() {
+	if !w.decided && w.status != 0 {
+		_ = w.decide(false)
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying writer, for use by http.ResponseController.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close writes out the rest of the body once the handler is done.
+func (w *compressWriter) Close() {
+	if !w.decided && w.status != 0 {
+		_ = w.decide(false)
+	}
+	if w.enc != nil {
+		_ = w.enc.Close()
+		w.enc.Reset(nil)
+		encoderPools[w.encoding].Put(w.enc)
+		w.enc = nil
+	}
+}
+
+// compressible reports whether the response is worth compressing given its headers.
+func (w *compressWriter) compressible() bool {
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	switch {
+	case mediaType == "text/event-stream":
+		// Events must reach the client as soon as they're sent.
+		return false
+	case strings.HasPrefix(mediaType, "image/") && mediaType != "image/svg+xml",
+		strings.HasPrefix(mediaType, "video/"),
+		strings.HasPrefix(mediaType, "audio/"),
+		mediaType == "application/zip",
+		mediaType == "application/gzip",
+		mediaType == "application/zstd",
+		strings.HasPrefix(mediaType, "application/vnd.openxmlformats-officedocument."):
+		// Already compressed.
+		return false
+	}
+	return true
+}
+
+// decide writes the header and the buffered start of the body,
+// compressing it and the rest of the body if compress is true.
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+	if compress {
+		h := w.Header()
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		// The compressed representation differs byte-for-byte from the uncompressed one.
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		w.enc = encoderPools[w.encoding].Get().(encoder)
+		w.enc.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	} else if w.enc != nil {
+		_, err := w.enc.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// zstdMaxWindow is the largest zstd window size accepted for request bodies,
+// which is the limit recommended for HTTP by RFC 8878.
+const zstdMaxWindow = 8 << 20
+
+// decompressRequest decodes the request body according to its Content-Encoding header.
+// It reports whether the body is decompressed.
+func decompressRequest(req *http.Request) (decompressed bool, err error) {
+	encoding := strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" || req.Body == nil || req.Body == http.NoBody {
+		return false, nil
+	}
+
+	var body io.ReadCloser
+	switch encoding {
+	case "gzip", "x-gzip":
+		body = &lazyReader{body: req.Body, open: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }}
+	case "br":
+		body = &lazyReader{body: req.Body, open: func(r io.Reader) (io.ReadCloser, error) {
+			return io.NopCloser(brotli.NewReader(r)), nil
+		}}
+	case "zstd":
+		body = &lazyReader{body: req.Body, open: func(r io.Reader) (io.ReadCloser, error) {
+			// Bound the memory used for the decoding window, which is otherwise
+			// sized by the frame header sent by the client.
+			dec, err := zstd.NewReader(r,
+				zstd.WithDecoderConcurrency(1),
+				zstd.WithDecoderMaxMemory(defaultMaxDecompressedBodySize),
+				zstd.WithDecoderMaxWindow(zstdMaxWindow),
+			)
+			if err != nil {
+				return nil, err
+			}
+			return dec.IOReadCloser(), nil
+		}}
+	default:
+		return false, errs.B().Code(errs.InvalidArgument).Msgf("unsupported Content-Encoding %q", encoding).Err()
+	}
+
+	req.Body = body
+	req.Header.Del("Content-Encoding")
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+	return true, nil
+}
+
+// lazyReader opens the decoder of a request body when it's first read,
+// so that malformed bodies are reported by the request decoding.
+type lazyReader struct {
+	body io.ReadCloser
+	open func(io.Reader) (io.ReadCloser, error)
+	dec  io.ReadCloser
+	err  error
+}
+
+func (r *lazyReader) Read(p []byte) (int, error) {
+	if r.dec == nil && r.err == nil {
+		r.dec, r.err = r.open(r.body)
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.dec.Read(p)
+}
+
+func (r *lazyReader) Close() error {
+	if r.dec != nil {
+		_ = r.dec.Close()
+	}
+	return r.body.Close()
+}
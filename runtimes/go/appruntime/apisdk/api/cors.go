@@ -0,0 +1,113 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/appruntime/apisdk/cors"
+	"encore.dev/appruntime/exported/config"
+)
+
+// CORS is the CORS policy of an API, overriding the app's CORS configuration
+// for requests to the API.
+type CORS struct {
+	// AllowOrigins are the origins allowed to call the API.
+	// If empty, the origins allowed by the app's CORS configuration are allowed.
+	AllowOrigins []string
+
+	// AllowHeaders are the request headers allowed in addition to
+	// those allowed by the app's CORS configuration.
+	AllowHeaders []string
+
+	// AllowCredentials is whether AllowOrigins are allowed
+	// to call the API with credentials.
+	AllowCredentials bool
+}
+
+// corsHandler is implemented by handlers of APIs with their own CORS policy.
+type corsHandler interface {
+	// CORSPolicy returns the CORS policy of the API, or nil if it has none.
+	CORSPolicy() *CORS
+}
+
+func (d *Desc[Req, Resp]) CORSPolicy() *CORS { return d.CORS }
+
+// config returns the CORS configuration for requests to an API with the policy,
+// given the app's CORS configuration.
+func (p *CORS) config(global *config.CORS) *config.CORS {
+	cfg := *global
+	cfg.ExtraAllowedHeaders = append(slices.Clip(global.ExtraAllowedHeaders), p.AllowHeaders...)
+	if len(p.AllowOrigins) > 0 {
+		cfg.AllowOriginsWithoutCredentials = p.AllowOrigins
+		cfg.AllowOriginsWithCredentials = nil
+		if p.AllowCredentials {
+			cfg.AllowOriginsWithCredentials = p.AllowOrigins
+		}
+		cfg.DisableCredentials = !p.AllowCredentials
+	}
+	return &cfg
+}
+
+// corsRouters route requests to the CORS handlers of APIs with their own CORS policy.
+// Requests to other APIs are handled according to the app's CORS configuration.
+type corsRouters struct {
+	cfg  *config.CORS // the app's CORS configuration
+	next http.Handler // the handler wrapped by the CORS handlers
+
+	routes, fallback *httprouter.Router
+}
+
+// withCORS wraps next with CORS support, according to the app's CORS configuration
+// and the CORS policies of the APIs registered with the server.
+func (s *Server) withCORS(cfg *config.CORS, next http.Handler) http.Handler {
+	s.cors = &corsRouters{cfg: cfg, next: next, routes: newRouter(), fallback: newRouter()}
+	global := cors.Wrap(cfg, s.static.CORSAllowHeaders, s.static.CORSExposeHeaders, next, s.rootLogger)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Preflight requests are handled by the policy of the API they're for.
+		method := req.Method
+		if m := req.Header.Get("Access-Control-Request-Method"); method == http.MethodOptions && m != "" {
+			method = m
+		}
+
+		path := determineRequestPath(req.URL)
+		for _, router := range []*httprouter.Router{s.cors.routes, s.cors.fallback} {
+			h, ps, _ := router.Lookup(method, path)
+			if h == nil {
+				h, ps, _ = router.Lookup(wildcardMethod, path)
+			}
+			if h != nil {
+				h(w, req, ps)
+				return
+			}
+		}
+		global.ServeHTTP(w, req)
+	})
+}
+
+// registerCORS registers the CORS handler of a publicly exposed API
+// with its own CORS policy, for the given method and httprouter path.
+func (s *Server) registerCORS(h Handler, method, routerPath string) {
+	ch, ok := h.(corsHandler)
+	if !ok || ch.CORSPolicy() == nil || s.cors == nil {
+		return
+	}
+
+	router := s.cors.routes
+	if h.IsFallback() {
+		router = s.cors.fallback
+	}
+	if existing, _, _ := router.Lookup(method, routerPath); existing != nil {
+		// The versions of a versioned API share its unversioned path,
+		// which is served according to the policy of the first version.
+		return
+	}
+
+	cfg := ch.CORSPolicy().config(s.cors.cfg)
+	handler := cors.Wrap(cfg, s.static.CORSAllowHeaders, s.static.CORSExposeHeaders, s.cors.next, s.rootLogger)
+	router.Handle(method, routerPath, func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		handler.ServeHTTP(w, req)
+	})
+}
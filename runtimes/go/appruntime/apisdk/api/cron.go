@@ -0,0 +1,27 @@
+package api
+
+import (
+	"reflect"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+// CronCaller is implemented by the endpoint descriptions,
+// to call endpoints as cron job executions without knowing their types.
+type CronCaller interface {
+	CallCron(c CallContext, exec *model.CronExecution) error
+}
+
+var _ CronCaller = (*Desc[*struct{}, *struct{}])(nil)
+
+// CallCron calls the endpoint as the cron job execution exec,
+// with an empty request payload as cron job endpoints take no parameters.
+func (d *Desc[Req, Resp]) CallCron(c CallContext, exec *model.CronExecution) error {
+	var req Req
+	if t := reflect.TypeOf(req); t != nil && t.Kind() == reflect.Pointer {
+		req = reflect.New(t.Elem()).Interface().(Req)
+	}
+	c.cron = exec
+	_, err := d.Call(c, req)
+	return err
+}
@@ -69,6 +69,14 @@ func TestMarshal(t *testing.T) {
 		fmt.Println("Error In: ", errIn)
 		fmt.Println("Error Out:", errOut)
 	})
+
+	t.Run("encore err from definition", func(t *testing.T) {
+		def := errs.Define("foo.out_of_range", errs.OutOfRange, "out of range in foo")
+		errOut := roundTrip(t, fmt.Errorf("outer: %w", def.New()))
+		if !def.Is(errOut) {
+			t.Errorf("Expected error of definition %q, got error id %q", def.ID, errs.ErrorID(errOut))
+		}
+	})
 }
 
 func roundTrip(t *testing.T, err error) error {
@@ -45,7 +45,7 @@ func (s *Server) createGatewayHandlerAdapter(h Handler) httprouter.Handle {
 
 		meta := CallMetaFromContext(req.Context())
 
-		info, proceed := s.runAuthHandler(h, s.NewIncomingContext(w, req, toUnnamedParams(ps), meta))
+		info, authErr, proceed := s.runAuthHandler(h, s.NewIncomingContext(w, req, toUnnamedParams(ps), meta))
 		if proceed {
 			meta.Internal = &InternalCallMeta{
 				Caller: GatewayCaller{
@@ -54,6 +54,9 @@ func (s *Server) createGatewayHandlerAdapter(h Handler) httprouter.Handle {
 				AuthUID:  string(info.UID),
 				AuthData: info.UserData,
 			}
+			if authErr != nil {
+				meta.Internal.AuthErr = errs.Convert(authErr).(*errs.Error).Message
+			}
 			req = req.WithContext(SetCallMetaInContext(req.Context(), meta))
 
 			if s.runtime.EnvCloud != "local" {
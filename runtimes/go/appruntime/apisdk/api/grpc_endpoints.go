@@ -0,0 +1,301 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	// Register the well-known types the descriptor may depend on.
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+
+	"encore.dev/beta/errs"
+)
+
+// registerGRPCEndpoints registers the endpoints exposed over gRPC with the gRPC server,
+// as described by the protobuf descriptor in the static config.
+//
+// gRPC calls are served as POST requests to the endpoint's HTTP handler,
+// so they're authenticated, validated and traced like any other API call.
+// It must be called before the server starts serving requests.
+func (s *Server) registerGRPCEndpoints() error {
+	if len(s.static.GRPCDescriptor) == 0 {
+		return nil
+	}
+
+	var fdp descriptorpb.FileDescriptorProto
+	if err := proto.Unmarshal(s.static.GRPCDescriptor, &fdp); err != nil {
+		return fmt.Errorf("unmarshal gRPC descriptor: %v", err)
+	}
+	fd, err := protodesc.NewFile(&fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		return fmt.Errorf("parse gRPC descriptor: %v", err)
+	}
+
+	// Register the file so the services can be discovered using gRPC reflection.
+	if _, err := protoregistry.GlobalFiles.FindFileByPath(fd.Path()); errors.Is(err, protoregistry.NotFound) {
+		if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+			return fmt.Errorf("register gRPC descriptor: %v", err)
+		}
+	}
+
+	handlers := make(map[[2]string]Handler, len(s.registeredHandlers))
+	for _, h := range s.registeredHandlers {
+		handlers[[2]string{h.ServiceName(), h.EndpointName()}] = h
+	}
+
+	svcs := fd.Services()
+	for i := 0; i < svcs.Len(); i++ {
+		sd := svcs.Get(i)
+		desc := &grpc.ServiceDesc{
+			ServiceName: string(sd.FullName()),
+			HandlerType: (*any)(nil),
+			Metadata:    fd.Path(),
+		}
+
+		methods := sd.Methods()
+		for j := 0; j < methods.Len(); j++ {
+			md := methods.Get(j)
+			h, ok := handlers[[2]string{string(sd.Name()), string(md.Name())}]
+			if !ok {
+				continue
+			}
+			desc.Methods = append(desc.Methods, grpc.MethodDesc{
+				MethodName: string(md.Name()),
+				Handler:    s.grpcMethodHandler(h, md),
+			})
+		}
+		if len(desc.Methods) > 0 {
+			s.grpcsrv.RegisterService(desc, struct{}{})
+		}
+	}
+	return nil
+}
+
+// grpcUnaryHandler is the signature of the handlers in a grpc.MethodDesc.
+type grpcUnaryHandler = func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error)
+
+// grpcMethodHandler returns a gRPC handler for md, serving calls using the endpoint's HTTP handler.
+func (s *Server) grpcMethodHandler(h Handler, md protoreflect.MethodDescriptor) grpcUnaryHandler {
+	fullMethod := "/" + string(md.Parent().FullName()) + "/" + string(md.Name())
+	call := func(ctx context.Context, req any) (any, error) {
+		return s.callGRPCEndpoint(ctx, h, req.(*dynamicpb.Message), md.Output())
+	}
+
+	return func(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := dynamicpb.NewMessage(md.Input())
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(ctx, in)
+		}
+		return interceptor(ctx, in, &grpc.UnaryServerInfo{FullMethod: fullMethod}, call)
+	}
+}
+
+// callGRPCEndpoint calls the endpoint with the given request message,
+// and converts the endpoint's response to a message of the given type.
+func (s *Server) callGRPCEndpoint(ctx context.Context, h Handler, in *dynamicpb.Message, out protoreflect.MessageDescriptor) (proto.Message, error) {
+	path, nParams, err := grpcRequestPath(h.HTTPRouterPath(), in)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	body, err := json.Marshal(grpcMessageJSON(in, nParams))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for key, vals := range md {
+			if forwardGRPCMetadata(key) {
+				for _, val := range vals {
+					req.Header.Add(key, val)
+				}
+			}
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		req.RemoteAddr = p.Addr.String()
+	}
+
+	w := &grpcResponseWriter{header: make(http.Header)}
+	s.grpcBridge.ServeHTTP(w, req)
+
+	if w.status != 0 && (w.status < 200 || w.status >= 300) {
+		return nil, grpcError(w.status, w.body.Bytes())
+	}
+	resp := dynamicpb.NewMessage(out)
+	if w.body.Len() > 0 {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(w.body.Bytes(), resp); err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid response: %v", err)
+		}
+	}
+	return resp, nil
+}
+
+// grpcRequestPath returns the request path for a call to an endpoint with the given router path,
+// using the path parameters from the first fields of msg, and the number of path parameters.
+func grpcRequestPath(routerPath string, msg protoreflect.Message) (path string, nParams int, err error) {
+	fields := msg.Descriptor().Fields()
+	segs := strings.Split(routerPath, "/")
+	for i, seg := range segs {
+		if seg == "" || (seg[0] != ':' && seg[0] != '*') {
+			continue
+		}
+		idx, err := strconv.Atoi(seg[1:])
+		if err != nil || idx >= fields.Len() {
+			return "", 0, fmt.Errorf("unknown path parameter %s", seg)
+		}
+		fd := fields.Get(idx)
+		val := fmt.Sprint(msg.Get(fd).Interface())
+		if seg[0] == ':' {
+			if val == "" {
+				return "", 0, fmt.Errorf("missing path parameter %s", fd.JSONName())
+			}
+			segs[i] = url.PathEscape(val)
+		} else {
+			// Wildcards can span multiple segments.
+			parts := strings.Split(val, "/")
+			for j, part := range parts {
+				parts[j] = url.PathEscape(part)
+			}
+			segs[i] = strings.Join(parts, "/")
+		}
+		nParams++
+	}
+	return strings.Join(segs, "/"), nParams, nil
+}
+
+// grpcMessageJSON returns the JSON representation of msg that the endpoint's
+// request decoding expects, skipping the first skip fields.
+// Unset fields with presence, and empty lists and maps, are omitted.
+func grpcMessageJSON(msg protoreflect.Message, skip int) map[string]any {
+	fields := msg.Descriptor().Fields()
+	obj := make(map[string]any, fields.Len())
+	for i := skip; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !msg.Has(fd) && (fd.HasPresence() || fd.IsList() || fd.IsMap()) {
+			continue
+		}
+		val := msg.Get(fd)
+
+		switch {
+		case fd.IsList():
+			list := val.List()
+			elems := make([]any, list.Len())
+			for j := range elems {
+				elems[j] = grpcValueJSON(fd, list.Get(j))
+			}
+			obj[fd.JSONName()] = elems
+		case fd.IsMap():
+			m := make(map[string]any, val.Map().Len())
+			val.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				m[k.String()] = grpcValueJSON(fd.MapValue(), v)
+				return true
+			})
+			obj[fd.JSONName()] = m
+		default:
+			obj[fd.JSONName()] = grpcValueJSON(fd, val)
+		}
+	}
+	return obj
+}
+
+// grpcValueJSON returns the JSON representation of a single value of the given field.
+func grpcValueJSON(fd protoreflect.FieldDescriptor, val protoreflect.Value) any {
+	if fd.Kind() != protoreflect.MessageKind {
+		// Scalars encode as expected, including 64-bit integers as numbers
+		// and bytes as base64 strings.
+		return val.Interface()
+	}
+
+	msg := val.Message()
+	if msg.Descriptor().FullName().Parent() == "google.protobuf" {
+		// Well-known types, such as timestamps and JSON values,
+		// use their canonical JSON representation.
+		data, err := protojson.Marshal(msg.Interface())
+		if err != nil {
+			return nil
+		}
+		return json.RawMessage(data)
+	}
+	return grpcMessageJSON(msg, 0)
+}
+
+// forwardGRPCMetadata reports whether the gRPC metadata key should be
+// forwarded as a request header, excluding transport-level headers.
+func forwardGRPCMetadata(key string) bool {
+	switch key {
+	case "content-type", "content-length", "te", "user-agent":
+		return false
+	}
+	return !strings.HasPrefix(key, ":") && !strings.HasPrefix(key, "grpc-")
+}
+
+// grpcError converts an endpoint's error response to a gRPC status error.
+// Encore's error codes are the same as gRPC's status codes.
+func grpcError(httpStatus int, body []byte) error {
+	var resp struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return status.Error(codes.Code(errs.HTTPStatusToCode(httpStatus)), strings.TrimSpace(string(body)))
+	}
+
+	code := errs.HTTPStatusToCode(httpStatus)
+	for c := errs.OK; c <= errs.Unauthenticated; c++ {
+		if c.String() == resp.Code {
+			code = c
+			break
+		}
+	}
+	return status.Error(codes.Code(code), resp.Message)
+}
+
+// grpcResponseWriter is an http.ResponseWriter buffering
+// the response of an endpoint called over gRPC.
+type grpcResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *grpcResponseWriter) Header() http.Header { return w.header }
+
+func (w *grpcResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *grpcResponseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(p)
+}
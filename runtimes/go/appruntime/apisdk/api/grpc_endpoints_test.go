@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/beta/errs"
+)
+
+type grpcTestHandler struct {
+	Handler
+	svc, endpoint, path string
+}
+
+func (h grpcTestHandler) ServiceName() string    { return h.svc }
+func (h grpcTestHandler) EndpointName() string   { return h.endpoint }
+func (h grpcTestHandler) HTTPRouterPath() string { return h.path }
+
+// grpcTestDescriptor describes:
+//
+//	service users {
+//	  rpc Get(GetRequest) returns (User);
+//	}
+func grpcTestDescriptor() *descriptorpb.FileDescriptorProto {
+	field := func(name, jsonName string, num int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(jsonName),
+			Number:   proto.Int32(num),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+
+	nick := field("nick", "Nick", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	nick.Proto3Optional, nick.OneofIndex = proto.Bool(true), proto.Int32(0)
+	tags := field("tags", "Tags", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	tags.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	at := field("at", "At", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	at.TypeName = proto.String(".google.protobuf.Timestamp")
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("encore/grpc_endpoints_test.proto"),
+		Package:    proto.String("encore.app"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("GetRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", "id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+					field("name", "Name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					nick, tags, at,
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_nick")}},
+			},
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", "ID", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("name", "Name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("score", "Score", 3, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("users"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Get"),
+				InputType:  proto.String(".encore.app.GetRequest"),
+				OutputType: proto.String(".encore.app.User"),
+			}},
+		}},
+	}
+}
+
+func TestGRPCEndpoints(t *testing.T) {
+	desc, err := proto.Marshal(grpcTestDescriptor())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type call struct {
+		path, auth string
+		body       map[string]any
+	}
+	var got call
+	s := &Server{
+		static: &config.Static{GRPCDescriptor: desc},
+		registeredHandlers: []Handler{
+			grpcTestHandler{svc: "users", endpoint: "Get", path: "/users/:0"},
+		},
+		grpcBridge: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			data, _ := io.ReadAll(req.Body)
+			got = call{path: req.URL.Path, auth: req.Header.Get("Authorization")}
+			_ = json.Unmarshal(data, &got.body)
+			if got.body["Name"] == "missing" {
+				errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("user not found").Err())
+				return
+			}
+			_, _ = w.Write([]byte(`{"ID": "42", "Name": "alice", "Score": 10, "Unknown": true}`))
+		}),
+	}
+	s.grpcsrv = grpc.NewServer()
+	if err := s.registerGRPCEndpoints(); err != nil {
+		t.Fatal(err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = s.grpcsrv.Serve(ln) }()
+	defer s.grpcsrv.Stop()
+
+	conn, err := grpc.NewClient(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	fd, err := protoregistry.GlobalFiles.FindFileByPath("encore/grpc_endpoints_test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs := fd.Messages()
+	newRequest := func(name string) *dynamicpb.Message {
+		req := dynamicpb.NewMessage(msgs.ByName("GetRequest"))
+		fields := req.Descriptor().Fields()
+		req.Set(fields.ByName("id"), protoreflect.ValueOfInt64(42))
+		req.Set(fields.ByName("name"), protoreflect.ValueOfString(name))
+		tags := req.Mutable(fields.ByName("tags")).List()
+		tags.Append(protoreflect.ValueOfString("a"))
+		tags.Append(protoreflect.ValueOfString("b"))
+		at := timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+		req.Set(fields.ByName("at"), protoreflect.ValueOfMessage(at.ProtoReflect()))
+		return req
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+	resp := dynamicpb.NewMessage(msgs.ByName("User"))
+	if err := conn.Invoke(ctx, "/encore.app.users/Get", newRequest("alice"), resp); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	want := call{
+		path: "/users/42",
+		auth: "Bearer token",
+		body: map[string]any{"Name": "alice", "Tags": []any{"a", "b"}, "At": "2024-01-02T03:04:05Z"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("endpoint called with %+v, want %+v", got, want)
+	}
+	fields := resp.Descriptor().Fields()
+	if id, score := resp.Get(fields.ByName("id")).String(), resp.Get(fields.ByName("score")).Int(); id != "42" || score != 10 {
+		t.Errorf("got response id=%q score=%d, want id=42 score=10", id, score)
+	}
+
+	// Errors are reported with the equivalent gRPC status code.
+	err = conn.Invoke(ctx, "/encore.app.users/Get", newRequest("missing"), resp)
+	if st := status.Convert(err); st.Code() != codes.NotFound || st.Message() != "user not found" {
+		t.Errorf("got error %v, want NotFound: user not found", err)
+	}
+}
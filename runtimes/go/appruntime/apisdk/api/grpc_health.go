@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionpbalpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+
+	"encore.dev/appruntime/shared/cfgutil"
+)
+
+// healthCheckTTL is how long the result of running the registered health checks
+// is reused for gRPC health checks, so frequent probes don't run them on every call.
+const healthCheckTTL = 1 * time.Second
+
+// grpcHealth implements the standard gRPC health checking protocol (grpc.health.v1.Health)
+// on top of the runtime's health check registry. This lets tooling that probes gRPC health,
+// such as Kubernetes gRPC probes or Consul, monitor Encore services even though they speak HTTP.
+type grpcHealth struct {
+	*grpchealth.Server
+	srv *Server
+
+	mu        sync.Mutex // held while running the health checks
+	checkedAt time.Time  // when the health checks last ran
+	healthy   bool       // whether the health checks passed when they last ran
+}
+
+// newInternalGRPCServer creates the gRPC server exposing the health and reflection services.
+// It's only served on the internal listener, as it's meant for infrastructure tooling
+// and not for the clients of the app.
+//
+// The reflection service describes both the internal services and the endpoints exposed over gRPC.
+func (s *Server) newInternalGRPCServer() (*grpc.Server, *grpcHealth) {
+	h := &grpcHealth{Server: grpchealth.NewServer(), srv: s}
+	gs := grpc.NewServer()
+	healthpb.RegisterHealthServer(gs, h)
+
+	opts := reflection.ServerOptions{Services: grpcServices{gs, s.grpcsrv}}
+	reflectionpb.RegisterServerReflectionServer(gs, reflection.NewServerV1(opts))
+	reflectionpbalpha.RegisterServerReflectionServer(gs, reflection.NewServer(opts))
+	return gs, h
+}
+
+// grpcServices describes the services of several gRPC servers.
+type grpcServices []*grpc.Server
+
+func (gs grpcServices) GetServiceInfo() map[string]grpc.ServiceInfo {
+	info := make(map[string]grpc.ServiceInfo)
+	for _, s := range gs {
+		if s != nil {
+			maps.Copy(info, s.GetServiceInfo())
+		}
+	}
+	return info
+}
+
+// markServing marks the overall server and each service hosted by this
+// container as serving. It must be called after all handlers are registered.
+func (h *grpcHealth) markServing() {
+	h.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+
+	var svcs []string
+	for _, handler := range h.srv.registeredHandlers {
+		svc := handler.ServiceName()
+		if cfgutil.IsHostedService(h.srv.runtime, svc) && !slices.Contains(svcs, svc) {
+			svcs = append(svcs, svc)
+			h.SetServingStatus(svc, healthpb.HealthCheckResponse_SERVING)
+		}
+	}
+}
+
+// Check reports NOT_SERVING if the server is shutting down or any of
+// the registered health checks fail. Unknown services return NotFound.
+func (h *grpcHealth) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	resp, err := h.Server.Check(ctx, req)
+	if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return resp, err
+	}
+
+	if !h.runChecks(ctx) {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return resp, nil
+}
+
+// runChecks reports whether the registered health checks pass.
+//
+// The result is reused for healthCheckTTL, and concurrent calls wait for
+// the checks already running, so the checks run at most once per healthCheckTTL.
+func (h *grpcHealth) runChecks(ctx context.Context) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.srv.clock.Now()
+	if !h.checkedAt.IsZero() && now.Sub(h.checkedAt) < healthCheckTTL {
+		return h.healthy
+	}
+
+	healthy := true
+	for _, result := range h.srv.healthMgr.RunAll(ctx) {
+		if result.Err != nil {
+			healthy = false
+			break
+		}
+	}
+
+	// Don't reuse the result of checks cut short by the caller going away.
+	if ctx.Err() == nil {
+		h.checkedAt, h.healthy = now, healthy
+	}
+	return healthy
+}
+
+// isGRPCRequest reports whether req is a gRPC request.
+func isGRPCRequest(req *http.Request) bool {
+	return req.ProtoMajor == 2 && strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc")
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/health"
+)
+
+type svcHandler struct {
+	Handler
+	svc string
+}
+
+func (h svcHandler) ServiceName() string { return h.svc }
+
+type healthCheckFunc func(ctx context.Context) error
+
+func (f healthCheckFunc) HealthCheck(ctx context.Context) []health.CheckResult {
+	return []health.CheckResult{{Name: "test", Err: f(ctx)}}
+}
+
+func TestGRPCHealth_Check(t *testing.T) {
+	var checkErr error
+	healthMgr := health.NewCheckRegistry()
+	healthMgr.Register(healthCheckFunc(func(ctx context.Context) error { return checkErr }))
+
+	klock := clock.NewMock()
+	s := &Server{
+		runtime:            &config.Runtime{HostedServices: []string{"foo"}},
+		healthMgr:          healthMgr,
+		registeredHandlers: []Handler{svcHandler{svc: "foo"}, svcHandler{svc: "bar"}},
+		clock:              klock,
+	}
+	_, h := s.newInternalGRPCServer()
+	h.markServing()
+
+	check := func(svc string) (healthpb.HealthCheckResponse_ServingStatus, codes.Code) {
+		t.Helper()
+		resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: svc})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN, status.Code(err)
+		}
+		return resp.Status, codes.OK
+	}
+
+	tests := []struct {
+		svc      string
+		checkErr error
+		shutdown bool
+		want     healthpb.HealthCheckResponse_ServingStatus
+		code     codes.Code
+	}{
+		{svc: "", want: healthpb.HealthCheckResponse_SERVING},
+		{svc: "foo", want: healthpb.HealthCheckResponse_SERVING},
+		{svc: "bar", code: codes.NotFound},
+		{svc: "foo", checkErr: errors.New("db down"), want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{svc: "foo", shutdown: true, want: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+	for _, test := range tests {
+		klock.Add(healthCheckTTL) // don't reuse the result of the previous checks
+		checkErr = test.checkErr
+		if test.shutdown {
+			h.Shutdown()
+		}
+		got, code := check(test.svc)
+		if code != test.code {
+			t.Errorf("svc %q: got code %v, want %v", test.svc, code, test.code)
+		} else if code == codes.OK && got != test.want {
+			t.Errorf("svc %q: got status %v, want %v", test.svc, got, test.want)
+		}
+	}
+}
+
+func TestGRPCHealth_CheckCached(t *testing.T) {
+	var runs int
+	var checkErr error
+	healthMgr := health.NewCheckRegistry()
+	healthMgr.Register(healthCheckFunc(func(ctx context.Context) error {
+		runs++
+		return checkErr
+	}))
+
+	klock := clock.NewMock()
+	s := &Server{runtime: &config.Runtime{}, healthMgr: healthMgr, clock: klock}
+	_, h := s.newInternalGRPCServer()
+	h.markServing()
+
+	check := func() healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Status
+	}
+
+	// The health checks run at most once per healthCheckTTL.
+	for i := 0; i < 3; i++ {
+		if got := check(); got != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("got status %v, want SERVING", got)
+		}
+	}
+	checkErr = errors.New("db down")
+	if got := check(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("got status %v within the TTL, want SERVING", got)
+	}
+	if runs != 1 {
+		t.Fatalf("health checks ran %d times, want 1", runs)
+	}
+
+	klock.Add(healthCheckTTL)
+	if got := check(); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("got status %v after the TTL, want NOT_SERVING", got)
+	}
+	if runs != 2 {
+		t.Fatalf("health checks ran %d times, want 2", runs)
+	}
+}
+
+func TestGRPCHealth_InternalOnly(t *testing.T) {
+	s := &Server{
+		runtime:   &config.Runtime{},
+		healthMgr: health.NewCheckRegistry(),
+		clock:     clock.NewMock(),
+		grpcsrv:   grpc.NewServer(),
+	}
+	s.grpcInternal, s.grpcHealth = s.newInternalGRPCServer()
+	s.grpcHealth.markServing()
+
+	serve := func(gs *grpc.Server) *grpc.ClientConn {
+		t.Helper()
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func() { _ = gs.Serve(ln) }()
+		t.Cleanup(gs.Stop)
+
+		conn, err := grpc.NewClient(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = conn.Close() })
+		return conn
+	}
+
+	// The health service isn't served alongside the endpoints.
+	public := healthpb.NewHealthClient(serve(s.grpcsrv))
+	_, err := public.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("public server: got code %v, want Unimplemented", code)
+	}
+
+	internal := healthpb.NewHealthClient(serve(s.grpcInternal))
+	resp, err := internal.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("internal server: %v", err)
+	} else if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("internal server: got status %v, want SERVING", resp.Status)
+	}
+}
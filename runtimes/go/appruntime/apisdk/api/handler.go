@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
@@ -10,7 +11,9 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 
@@ -21,9 +24,11 @@ import (
 	"encore.dev/appruntime/exported/experiments"
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
+	"encore.dev/appruntime/exported/validation"
 	"encore.dev/appruntime/shared/cfgutil"
 	"encore.dev/appruntime/shared/cloudtrace"
 	"encore.dev/appruntime/shared/jsonapi"
+	"encore.dev/beta/codec"
 	"encore.dev/beta/errs"
 	"encore.dev/internal/platformauth"
 	"encore.dev/middleware"
@@ -77,6 +82,44 @@ type Desc[Req, Resp any] struct {
 	// Access describes the access type for this API.
 	Access Access
 
+	// EnvTypes are the environment types the API is publicly exposed in.
+	// If empty it's exposed in all environments.
+	EnvTypes []string
+
+	// RateLimit is the rate limit of the API, if any.
+	RateLimit *RateLimit
+
+	// Idempotency is set if the API replays responses to
+	// requests retried with the same Idempotency-Key header.
+	Idempotency *Idempotency
+
+	// ResponseCache is set if the API caches its responses.
+	ResponseCache *ResponseCache
+
+	// Version is the version of the API, or 0 if it's unversioned.
+	// Versioned APIs are also served on UnversionedRawPath,
+	// with the version selected by the API-Version header.
+	Version            int
+	UnversionedRawPath string
+
+	// Deprecated is true if the API is deprecated.
+	Deprecated bool
+
+	// DisableCompression is true if the API's responses are never compressed.
+	DisableCompression bool
+
+	// Timeout is the maximum duration of a request to the API,
+	// after which its context is canceled, or 0 if it's unlimited.
+	Timeout time.Duration
+
+	// MaxBodySize is the maximum size of request bodies in bytes,
+	// or 0 if it's unlimited. Larger requests are rejected with 413 Request Entity Too Large.
+	MaxBodySize int64
+
+	// CORS is the CORS policy of the API, or nil if requests
+	// to it are handled according to the app's CORS configuration.
+	CORS *CORS
+
 	// If raw is true, RawHandler is set and AppHandler and EncodeResp are nil.
 	Raw bool
 
@@ -118,19 +161,47 @@ type Desc[Req, Resp any] struct {
 	mockFuncCache map[uint64]reflectedAPIMethod[Req, Resp] // map of model.ApiMock.ID to reflected method
 }
 
-func (d *Desc[Req, Resp]) AccessType() Access     { return d.Access }
-func (d *Desc[Req, Resp]) ServiceName() string    { return d.Service }
-func (d *Desc[Req, Resp]) EndpointName() string   { return d.Endpoint }
-func (d *Desc[Req, Resp]) HTTPMethods() []string  { return d.Methods }
-func (d *Desc[Req, Resp]) SemanticPath() string   { return d.Path }
-func (d *Desc[Req, Resp]) HTTPRouterPath() string { return d.RawPath }
-func (d *Desc[Req, Resp]) IsFallback() bool       { return d.Fallback }
+func (d *Desc[Req, Resp]) AccessType() Access        { return d.Access }
+func (d *Desc[Req, Resp]) ServiceName() string       { return d.Service }
+func (d *Desc[Req, Resp]) EndpointName() string      { return d.Endpoint }
+func (d *Desc[Req, Resp]) HTTPMethods() []string     { return d.Methods }
+func (d *Desc[Req, Resp]) SemanticPath() string      { return d.Path }
+func (d *Desc[Req, Resp]) HTTPRouterPath() string    { return d.RawPath }
+func (d *Desc[Req, Resp]) IsFallback() bool          { return d.Fallback }
+func (d *Desc[Req, Resp]) ExposedEnvTypes() []string { return d.EnvTypes }
 
 func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
+	if d.Deprecated {
+		c.w.Header().Set(deprecationHeader, "true")
+	}
+
+	// net/http only removes the temporary files of multipart uploads parsed
+	// from the request it passed to the server, not from the copy decoded here.
+	defer removeMultipartFiles(c.req)
+
 	if d.Raw {
+		if err := d.limitBody(&c, false); err != nil {
+			returnError(c, err, http.StatusRequestEntityTooLarge, nil)
+			return
+		}
 		c.capturer = newRawRequestBodyCapturer(c.req)
 		c.req.Body = c.capturer
 		defer c.capturer.Dispose()
+	} else {
+		if cw := d.compressResponse(c); cw != nil {
+			c.w = cw
+			defer cw.Close()
+		}
+		decompressed, err := decompressRequest(c.req)
+		if err != nil {
+			returnError(c, err, http.StatusUnsupportedMediaType, nil)
+			return
+		}
+		// Limit the decompressed body, so compressed requests can't get around the limit.
+		if err := d.limitBody(&c, decompressed); err != nil {
+			returnError(c, err, http.StatusRequestEntityTooLarge, nil)
+			return
+		}
 	}
 
 	// If this is an internal encore-to-encore call, we need to verify the caller is allowed to make this call.
@@ -146,8 +217,28 @@ func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
 
 	reqData, beginErr := d.begin(c)
 	if beginErr != nil {
-		returnError(c, beginErr, 0, nil)
+		returnError(c, beginErr, beginErrStatus(beginErr), nil)
+		return
+	}
+
+	if shed := d.checkRateLimit(c); shed != nil {
+		c.server.finishRequest(shed)
+		returnError(c, shed.Err, shed.HTTPStatus, shed.Headers)
+		return
+	}
+
+	cached, handled := d.beginCached(&c)
+	if handled {
+		return
+	} else if cached != nil {
+		defer cached.complete(c)
+	}
+
+	idem, handled := d.beginIdempotent(&c, reqData)
+	if handled {
 		return
+	} else if idem != nil {
+		defer idem.complete(c)
 	}
 
 	resp, respData := d.handleIncoming(c, reqData)
@@ -170,13 +261,116 @@ func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
 			}
 		}
 
-		c.w.Header().Set("Content-Type", "application/json")
 		c.w.Header().Set("X-Content-Type-Options", "nosniff")
-		resp.Err = d.EncodeResp(c.w, c.server.json, respData, resp.HTTPStatus)
+		if sr, ok := any(respData).(streamingResponse); ok {
+			resp.Err = sr.ServeStream(c.w, c.req.WithContext(c.ctx))
+		} else if respCodec := d.respCodec(c); respCodec != nil {
+			resp.Err = d.encodeRespWithCodec(c, respCodec, respData, resp.HTTPStatus)
+		} else {
+			c.w.Header().Set("Content-Type", "application/json")
+			resp.Err = d.EncodeResp(c.w, c.server.json, respData, resp.HTTPStatus)
+		}
 	}
 	c.server.finishRequest(resp)
 }
 
+// removeMultipartFiles removes the temporary files of the multipart form parsed from req, if any.
+func removeMultipartFiles(req *http.Request) {
+	if req.MultipartForm != nil {
+		_ = req.MultipartForm.RemoveAll()
+	}
+}
+
+// streamingResponse is implemented by response types that are written
+// to the client as they're produced, such as *stream.Events.
+type streamingResponse interface {
+	ServeStream(w http.ResponseWriter, req *http.Request) error
+}
+
+// reqCodec returns the codec to decode the request body with,
+// or nil if it should be decoded as JSON.
+func (d *Desc[Req, Resp]) reqCodec(c IncomingContext) codec.Codec {
+	// Service-to-service calls always use JSON.
+	if d.Raw || c.callMeta.IsServiceToService() {
+		return nil
+	}
+	return codec.ForContentType(c.req.Header.Get("Content-Type"))
+}
+
+// respCodec returns the codec to encode the response with,
+// or nil if it should be encoded as JSON.
+func (d *Desc[Req, Resp]) respCodec(c IncomingContext) codec.Codec {
+	// Service-to-service calls always use JSON.
+	if d.Raw || c.callMeta.IsServiceToService() {
+		return nil
+	}
+	return codec.Negotiate(c.req.Header.Get("Accept"))
+}
+
+// decodeReq decodes the incoming request, using the codec
+// matching the request's content type for the request body.
+func (d *Desc[Req, Resp]) decodeReq(c IncomingContext) (reqData Req, params UnnamedParams, err error) {
+	reqCodec := d.reqCodec(c)
+	if reqCodec == nil {
+		return d.DecodeReq(c.req, c.ps, c.server.json)
+	}
+
+	// Decode the path parameters, headers and query string with an empty body,
+	// and then decode the body into the payload using the codec.
+	body, err := io.ReadAll(c.req.Body)
+	if err != nil {
+		return reqData, nil, err
+	}
+	c.req.Body = io.NopCloser(strings.NewReader("{}"))
+	reqData, params, err = d.DecodeReq(c.req, c.ps, c.server.json)
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		return reqData, params, err
+	}
+
+	payload := d.ReqUserPayload(reqData)
+	if v := reflect.ValueOf(payload); v.Kind() != reflect.Pointer || v.IsNil() {
+		return reqData, params, fmt.Errorf("endpoint does not accept %s request bodies", reqCodec.ContentType())
+	}
+	if err := reqCodec.Unmarshal(body, payload); err != nil {
+		return reqData, params, err
+	}
+	return reqData, params, nil
+}
+
+// encodeRespWithCodec writes the response, encoding the response body using the given codec.
+// Headers and the status code are written by the generated response encoder.
+func (d *Desc[Req, Resp]) encodeRespWithCodec(c IncomingContext, respCodec codec.Codec, respData Resp, status int) error {
+	hw := &headerOnlyWriter{ResponseWriter: c.w}
+	if err := d.EncodeResp(hw, c.server.json, respData, status); err != nil {
+		return err
+	}
+
+	var body []byte
+	if _, isVoid := any(respData).(Void); !isVoid {
+		var err error
+		if body, err = respCodec.Marshal(respData); err != nil {
+			return err
+		}
+	}
+
+	c.w.Header().Set("Content-Type", respCodec.ContentType())
+	if hw.status != 0 {
+		c.w.WriteHeader(hw.status)
+	}
+	_, err := c.w.Write(body)
+	return err
+}
+
+// headerOnlyWriter is a http.ResponseWriter that records the status code
+// and discards the response body, while passing through headers.
+type headerOnlyWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *headerOnlyWriter) WriteHeader(status int)      { w.status = status }
+func (w *headerOnlyWriter) Write(b []byte) (int, error) { return len(b), nil }
+
 // returnError is a helper function which will return an error to the client when we handle
 // an incoming request.
 //
@@ -225,7 +419,7 @@ func returnError(c IncomingContext, err error, statusCodeToUse int, headers http
 }
 
 func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error) {
-	reqData, params, decodeErr := d.DecodeReq(c.req, c.ps, c.server.json)
+	reqData, params, decodeErr := d.decodeReq(c)
 
 	if d.Access == RequiresAuth && c.auth.UID == "" {
 		beginErr = errs.B().
@@ -254,6 +448,7 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 		ParentSpanID:  c.callMeta.ParentSpanID,
 		CallerEventID: c.callMeta.ParentEventID,
 		ParentSampled: c.callMeta.TraceSampled,
+		ForceTrace:    c.callMeta.ForceTrace,
 
 		Data: &model.RPCData{
 			Desc:                 d.rpcDesc(),
@@ -264,6 +459,7 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 			NonRawPayload:        nonRawPayload,
 			UserID:               c.auth.UID,
 			AuthData:             c.auth.UserData,
+			AuthErr:              c.authErr,
 			RequestHeaders:       headersWithHost(c.req),
 			FromEncorePlatform:   platformauth.IsEncorePlatformRequest(c.req.Context()),
 			ServiceToServiceCall: c.callMeta.IsServiceToService(),
@@ -281,12 +477,20 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 	// If we fail after having begun the request, mark it as completed.
 	defer func() {
 		if beginErr != nil {
-			c.server.finishRequest(newErrResp(beginErr, 0))
+			c.server.finishRequest(newErrResp(beginErr, beginErrStatus(beginErr)))
 		}
 	}()
 
 	if decodeErr != nil {
-		beginErr = errs.WrapCode(decodeErr, errs.InvalidArgument, "decode request")
+		var unknownErr unknownFieldsError
+		if c.limitedBody != nil && c.limitedBody.exceeded != nil {
+			beginErr = bodyTooLargeErr(c.limitedBody.exceeded.Limit)
+		} else if errors.As(decodeErr, &unknownErr) {
+			beginErr = errs.WrapCode(decodeErr, errs.InvalidArgument,
+				"request contains unknown fields: "+strings.Join(unknownErr.UnknownFieldPaths(), ", "))
+		} else {
+			beginErr = errs.WrapCode(decodeErr, errs.InvalidArgument, "decode request")
+		}
 		return
 	}
 
@@ -318,6 +522,15 @@ func (d *Desc[Req, Resp]) handleIncoming(c IncomingContext, reqData Req) (resp *
 
 // executeEndpoint executes the given handler, running middleware in the process.
 func (d *Desc[Req, Resp]) executeEndpoint(c execContext, invokeHandler func(middleware.Request) middleware.Response) (resp Resp, httpStatus int, headers http.Header, respErr error) {
+	ctx, cancel := d.withTimeout(c.ctx)
+	defer cancel()
+	c.ctx = ctx
+	defer func() {
+		if err := d.timeoutErr(ctx, respErr); err != respErr {
+			respErr, httpStatus = err, errs.HTTPStatus(err)
+		}
+	}()
+
 	var counter int
 	var nextFn middleware.Next
 
@@ -463,6 +676,7 @@ func (d *Desc[Req, Resp]) toNamedParams(ps UnnamedParams) NamedParams {
 type CallContext struct {
 	ctx    context.Context
 	server *Server
+	cron   *model.CronExecution // set when simulating a cron job execution
 }
 
 func (d *Desc[Req, Resp]) Call(c CallContext, req Req) (respData Resp, respErr error) {
@@ -669,6 +883,7 @@ func (d *Desc[Req, Resp]) runCall(c CallContext, req Req, mocked bool, executor
 				RequestHeaders:       nil, // not set right now for internal requests
 				ServiceToServiceCall: true,
 				Mocked:               mocked,
+				Cron:                 c.cron,
 			},
 		})
 
@@ -882,8 +1097,12 @@ func (d *Desc[Req, Resp]) validate(req Req) error {
 }
 
 // runValidate validates the request, and returns a validation error on failure.
-// If the user payload does not implement Validator, it returns nil.
+// It first checks the rules declared by "validate" struct tags,
+// and then calls the Validate method if the payload implements Validator.
 func runValidate(userPayload any) error {
+	if err := validateTags(userPayload); err != nil {
+		return err
+	}
 	if v, ok := userPayload.(Validator); ok {
 		if err := v.Validate(); err != nil {
 			// If we already have an *errs.Error, return it directly.
@@ -896,6 +1115,26 @@ func runValidate(userPayload any) error {
 	return nil
 }
 
+// validateTags checks the request against the rules declared by its
+// "validate" struct tags, reporting the failing fields in the error details.
+func validateTags(userPayload any) error {
+	violations, err := validation.Struct(userPayload)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Cause(err).Msg("invalid validation rules").Err()
+	} else if len(violations) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(violations))
+	details := errs.ValidationDetails{Fields: make([]errs.FieldError, len(violations))}
+	for i, v := range violations {
+		msgs[i] = v.String()
+		details.Fields[i] = errs.FieldError{Field: v.Field, Rule: v.Rule.String(), Message: v.Message}
+	}
+	return errs.B().Code(errs.InvalidArgument).Details(details).
+		Msg("validation failed: " + strings.Join(msgs, "; ")).Err()
+}
+
 // rpcDesc returns the RPC description for this endpoint,
 // computing and caching the first time it's called.
 func (d *Desc[Req, Resp]) rpcDesc() *model.RPCDesc {
@@ -963,6 +1202,32 @@ func newRespWithHeaders[Resp any](respData Resp, httpStatus int, err error, head
 
 // newErrResp returns an *model.Response for an error.
 // If httpStatus is 0 it's inferred from the error.
+// unknownFieldsError is implemented by errors reported when a request
+// decoded in strict mode contains fields not part of the request schema.
+type unknownFieldsError interface {
+	error
+	UnknownFieldPaths() []string
+}
+
+// beginErrStatus returns the HTTP status code to use for an error
+// returned by begin, or 0 to use the default status code for the error.
+//
+// Requests rejected by strict decoding because of unknown fields
+// are reported with 422 Unprocessable Entity, and requests with
+// too large bodies with 413 Request Entity Too Large.
+func beginErrStatus(err error) int {
+	var (
+		unknownErr unknownFieldsError
+		tooLarge   *http.MaxBytesError
+	)
+	if errors.As(err, &unknownErr) {
+		return http.StatusUnprocessableEntity
+	} else if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return 0
+}
+
 func newErrResp(err error, httpStatus int) *model.Response {
 	if httpStatus == 0 {
 		httpStatus = errs.HTTPStatus(err)
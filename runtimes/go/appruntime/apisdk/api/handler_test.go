@@ -1,8 +1,13 @@
 package api_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -11,27 +16,35 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/andybalholm/brotli"
 	"github.com/benbjohnson/clock"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/klauspost/compress/zstd"
 	"github.com/rs/zerolog"
 
 	encore "encore.dev"
 	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/apisdk/api/transport"
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/etype"
 	"encore.dev/appruntime/shared/health"
+	"encore.dev/appruntime/shared/platform"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/testsupport"
 	"encore.dev/appruntime/shared/traceprovider"
 	"encore.dev/appruntime/shared/traceprovider/mock_trace"
+	"encore.dev/beta/codec"
 	"encore.dev/beta/errs"
 	usermetrics "encore.dev/metrics"
 	"encore.dev/middleware"
 	"encore.dev/pubsub"
+	"encore.dev/storage/cache"
 )
 
 type mockReq struct {
@@ -107,8 +120,22 @@ func TestDesc_EndToEnd(t *testing.T) {
 	}
 
 	collected := metricsRegistry.Collect()
-	if len(collected) != 2 {
-		t.Fatalf("got %d metrics, want 2", len(collected))
+	var numRequestsTotal int
+	for _, m := range collected {
+		if m.Info.Name() == "e_requests_total" {
+			numRequestsTotal++
+		}
+	}
+	if numRequestsTotal != 2 {
+		t.Fatalf("got %d e_requests_total metrics, want 2", numRequestsTotal)
+	}
+
+	infBucket := findMetric(collected, "e_request_duration_seconds_bucket", []usermetrics.KeyValue{
+		{Key: "endpoint", Value: "endpoint"},
+		{Key: "le", Value: "+Inf"},
+	})
+	if infBucket == nil {
+		t.Fatal(`e_request_duration_seconds_bucket{endpoint="endpoint",le="+Inf"} metric not found`)
 	}
 
 	okLabels := []usermetrics.KeyValue{
@@ -154,6 +181,633 @@ func TestDesc_EndToEnd(t *testing.T) {
 	}
 }
 
+func TestDesc_StrictDecoding(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	desc := newMockAPIDesc(api.Public)
+	desc.DecodeReq = func(req *http.Request, ps api.UnnamedParams, json jsoniter.API) (*mockReq, api.UnnamedParams, error) {
+		reqData := new(mockReq)
+		dec := new(etype.Unmarshaller)
+		iter := jsoniter.Parse(json, req.Body, 512)
+		for iter.ReadObjectCB(func(_ *jsoniter.Iterator, key string) bool {
+			switch strings.ToLower(key) {
+			case "body":
+				dec.ParseJSON("Body", iter, &reqData.Body)
+			default:
+				dec.UnknownField(key)
+				_ = iter.SkipAndReturnBytes()
+			}
+			return true
+		}) {
+		}
+		if err := dec.Error; err != nil {
+			return nil, ps, err
+		}
+		return reqData, ps, nil
+	}
+
+	tests := []struct {
+		name    string
+		reqBody string
+		status  int
+		msg     string
+	}{
+		{
+			name:    "known_fields",
+			reqBody: `{"Body": "foo"}`,
+			status:  200,
+		},
+		{
+			name:    "unknown_fields",
+			reqBody: `{"Body": "foo", "Bdy": "bar", "extra": 1}`,
+			status:  422,
+			msg:     "request contains unknown fields: Bdy, extra",
+		},
+		{
+			name:    "invalid_field",
+			reqBody: `{"Bdy": "bar", "Body": 5}`,
+			status:  400,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(test.reqBody))
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+			if w.Code != test.status {
+				t.Fatalf("got code %d, want %d: %s", w.Code, test.status, w.Body.String())
+			}
+			if test.msg != "" && !strings.Contains(w.Body.String(), test.msg) {
+				t.Errorf("got body %q, want it to contain %q", w.Body.String(), test.msg)
+			}
+		})
+	}
+}
+
+func TestDesc_ValidateTags(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	type payload struct {
+		Body string `json:"body" validate:"required,max=5"`
+	}
+	desc := newMockAPIDesc(api.Public)
+	desc.ReqUserPayload = func(req *mockReq) any {
+		return &payload{Body: req.Body}
+	}
+
+	tests := []struct {
+		name    string
+		reqBody string
+		status  int
+		details string
+	}{
+		{
+			name:    "valid",
+			reqBody: `{"Body": "foo"}`,
+			status:  200,
+		},
+		{
+			name:    "too_long",
+			reqBody: `{"Body": "foobar"}`,
+			status:  400,
+			details: `"details":{"fields":[{"field":"body","rule":"max=5","message":"must have at most 5 characters"}]}`,
+		},
+		{
+			name:    "missing",
+			reqBody: `{}`,
+			status:  400,
+			details: `"details":{"fields":[{"field":"body","rule":"required","message":"is required"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(test.reqBody))
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+			if w.Code != test.status {
+				t.Fatalf("got code %d, want %d: %s", w.Code, test.status, w.Body.String())
+			}
+			var body bytes.Buffer
+			if err := json.Compact(&body, w.Body.Bytes()); err != nil {
+				t.Fatalf("invalid response body: %v", err)
+			}
+			if test.details != "" && !strings.Contains(body.String(), test.details) {
+				t.Errorf("got body %q, want it to contain %q", body.String(), test.details)
+			}
+		})
+	}
+}
+
+func TestDesc_RateLimit(t *testing.T) {
+	server, _, metricsRegistry := testServer(t, clock.New(), false)
+
+	desc := newMockAPIDesc(api.Public)
+	desc.RateLimit = &api.RateLimit{Cluster: "cache", Rate: 2, Period: time.Minute}
+
+	call := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"Body": "foo"}`))
+		desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := call(); w.Code != 200 {
+			t.Fatalf("call %d: got code %d, want 200: %s", i, w.Code, w.Body.String())
+		}
+	}
+
+	// The burst is exhausted, so the request is shed.
+	w := call()
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("got code %d, want %d: %s", w.Code, http.StatusTooManyRequests, w.Body.String())
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("got Retry-After %q, want %q", got, "30")
+	}
+	if !strings.Contains(w.Body.String(), "resource_exhausted") {
+		t.Errorf("got body %q, want resource_exhausted error", w.Body.String())
+	}
+
+	shed := findMetric(metricsRegistry.Collect(), "e_requests_shed_total", []usermetrics.KeyValue{
+		{Key: "endpoint", Value: "endpoint"},
+	})
+	if shed == nil {
+		t.Fatal(`e_requests_shed_total{endpoint="endpoint"} metric not found`)
+	}
+}
+
+func TestDesc_Deprecated(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	desc := newMockAPIDesc(api.Public)
+	desc.Deprecated = true
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"Body": "foo"}`))
+	desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+	if w.Code != 200 {
+		t.Fatalf("got code %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("got Deprecation %q, want %q", got, "true")
+	}
+}
+
+func TestDesc_Compression(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	large := strings.Repeat("compressible ", 200)
+	decoders := map[string]func(io.Reader) (io.Reader, error){
+		"": func(r io.Reader) (io.Reader, error) { return r, nil },
+		"gzip": func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		},
+		"br": func(r io.Reader) (io.Reader, error) {
+			return brotli.NewReader(r), nil
+		},
+		"zstd": func(r io.Reader) (io.Reader, error) {
+			return zstd.NewReader(r)
+		},
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		acceptEncoding string
+		disable        bool
+		wantEncoding   string
+	}{
+		{"gzip", large, "gzip, deflate", false, "gzip"},
+		{"br", large, "gzip;q=0.5, br", false, "br"},
+		{"zstd_preferred", large, "gzip, br, zstd", false, "zstd"},
+		{"wildcard", large, "*", false, "zstd"},
+		{"refused", large, "gzip;q=0", false, ""},
+		{"no_accept", large, "", false, ""},
+		{"small", "small", "gzip", false, ""},
+		{"disabled", large, "gzip", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := newMockAPIDesc(api.Public)
+			desc.DisableCompression = tt.disable
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(fmt.Sprintf(`{"Body": %q}`, tt.body)))
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+			if w.Code != 200 {
+				t.Fatalf("got code %d, want 200: %s", w.Code, w.Body.String())
+			}
+
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("got Content-Encoding %q, want %q", got, tt.wantEncoding)
+			}
+			if got, want := w.Header().Get("Vary"), "Accept-Encoding"; !tt.disable && got != want {
+				t.Errorf("got Vary %q, want %q", got, want)
+			}
+			r, err := decoders[tt.wantEncoding](w.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fmt.Sprintf(`{"Message":%q}`, tt.body); string(got) != want {
+				t.Errorf("got body %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDesc_RequestDecompression(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+	desc := newMockAPIDesc(api.Public)
+
+	var compressed bytes.Buffer
+	gw := gzip.NewWriter(&compressed)
+	_, _ = gw.Write([]byte(`{"Body": "foo"}`))
+	_ = gw.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", &compressed)
+	req.Header.Set("Content-Encoding", "gzip")
+	desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+	if w.Code != 200 || w.Body.String() != `{"Message":"foo"}` {
+		t.Fatalf("got code %d body %q, want 200 %q", w.Code, w.Body.String(), `{"Message":"foo"}`)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"Body": "foo"}`))
+	req.Header.Set("Content-Encoding", "compress")
+	desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("got code %d, want %d: %s", w.Code, http.StatusUnsupportedMediaType, w.Body.String())
+	}
+}
+
+func TestDesc_RequestDecompressionLimit(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+	desc := newMockAPIDesc(api.Public)
+
+	// A body that decompresses to more than the default limit of 64 MiB.
+	compress := func(w io.WriteCloser) {
+		_, _ = io.WriteString(w, `{"Body": "`)
+		zeros := bytes.Repeat([]byte("0"), 1<<20)
+		for i := 0; i < 65; i++ {
+			_, _ = w.Write(zeros)
+		}
+		_, _ = io.WriteString(w, `"}`)
+		_ = w.Close()
+	}
+	var gzipped, zstded bytes.Buffer
+	compress(gzip.NewWriter(&gzipped))
+	zw, err := zstd.NewWriter(&zstded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compress(zw)
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{"gzip", "gzip", gzipped.Bytes()},
+		{"zstd", "zstd", zstded.Bytes()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.body) > 1<<20 {
+				t.Fatalf("compressed body is %d bytes, want a highly compressible body", len(tt.body))
+			}
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Encoding", tt.encoding)
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+			if w.Code != http.StatusRequestEntityTooLarge {
+				t.Fatalf("got code %d, want %d: %.200s", w.Code, http.StatusRequestEntityTooLarge, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDesc_MaxBodySize(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+	desc := newMockAPIDesc(api.Public)
+	desc.MaxBodySize = 20
+
+	tests := []struct {
+		name       string
+		body       io.Reader
+		wantStatus int
+	}{
+		{"within_limit", strings.NewReader(`{"Body": "foo"}`), 200},
+		{"content_length", strings.NewReader(`{"Body": "too large for the limit"}`), http.StatusRequestEntityTooLarge},
+		// Without a Content-Length the limit is enforced while reading the body.
+		{"streamed", io.MultiReader(strings.NewReader(`{"Body": "too large for the limit"}`)), http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", tt.body)
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got code %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDesc_MultipartTempFiles(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	tempFiles := func() int {
+		t.Helper()
+		entries, err := os.ReadDir(tmpDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(entries)
+	}
+
+	desc := newMockAPIDesc(api.Public)
+	desc.DecodeReq = func(req *http.Request, ps api.UnnamedParams, json jsoniter.API) (*mockReq, api.UnnamedParams, error) {
+		dec := new(etype.Unmarshaller)
+		form := dec.ReadMultipartForm(req)
+		file := dec.FormFile(form.File["file"])
+		if err := dec.Error; err != nil {
+			return nil, ps, err
+		} else if file == nil {
+			return nil, ps, errs.B().Code(errs.InvalidArgument).Msg("missing file").Err()
+		}
+		return &mockReq{Body: fmt.Sprint(file.Size)}, ps, nil
+	}
+	var duringRequest int
+	desc.AppHandler = func(ctx context.Context, req *mockReq) (*mockResp, error) {
+		duringRequest = tempFiles()
+		return &mockResp{Message: req.Body}, nil
+	}
+
+	// Upload a file larger than what's kept in memory.
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "large.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const size = 33 << 20
+	if _, err := fw.Write(make([]byte, size)); err != nil {
+		t.Fatal(err)
+	}
+	_ = mw.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+	if want := fmt.Sprintf(`{"Message":"%d"}`, size); w.Code != 200 || w.Body.String() != want {
+		t.Fatalf("got code %d body %q, want 200 %q", w.Code, w.Body.String(), want)
+	}
+	if duringRequest == 0 {
+		t.Fatal("expected the upload to be stored in a temporary file")
+	}
+	if n := tempFiles(); n != 0 {
+		t.Errorf("got %d temporary files after the request, want 0", n)
+	}
+}
+
+func TestDesc_Timeout(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+	desc := newMockAPIDesc(api.Public)
+	desc.Timeout = 10 * time.Millisecond
+	desc.AppHandler = func(ctx context.Context, req *mockReq) (*mockResp, error) {
+		if req.Body == "slow" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &mockResp{Message: req.Body}, nil
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"Body": "slow"}`))
+	desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("got code %d, want %d: %s", w.Code, http.StatusGatewayTimeout, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "deadline_exceeded") {
+		t.Errorf("got body %s, want deadline_exceeded error", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"Body": "fast"}`))
+	desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+	if w.Code != 200 {
+		t.Fatalf("got code %d, want 200: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDesc_Idempotency(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	var calls int
+	desc := newMockAPIDesc(api.Public)
+	desc.Idempotency = &api.Idempotency{Cluster: "cache"}
+	desc.AppHandler = func(ctx context.Context, req *mockReq) (*mockResp, error) {
+		calls++
+		return &mockResp{Message: fmt.Sprintf("%s %d", req.Body, calls)}, nil
+	}
+
+	call := func(key, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+		return w
+	}
+
+	first := call("key", `{"Body": "foo"}`)
+	if first.Code != 200 || first.Body.String() != `{"Message":"foo 1"}` {
+		t.Fatalf("got code %d body %q, want 200 %q", first.Code, first.Body.String(), `{"Message":"foo 1"}`)
+	}
+
+	// Retrying with the same key replays the response without calling the handler.
+	retry := call("key", `{"Body": "foo"}`)
+	if retry.Code != 200 || retry.Body.String() != first.Body.String() {
+		t.Fatalf("got code %d body %q, want replayed response %q", retry.Code, retry.Body.String(), first.Body.String())
+	}
+	if got := retry.Header().Get("Idempotent-Replayed"); got != "true" {
+		t.Errorf("got Idempotent-Replayed %q, want %q", got, "true")
+	}
+	if calls != 1 {
+		t.Errorf("got %d handler calls, want 1", calls)
+	}
+
+	// Reusing the key for a different request is rejected.
+	if w := call("key", `{"Body": "bar"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("got code %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body.String())
+	}
+
+	// Requests without a key are processed as usual.
+	for i := 0; i < 2; i++ {
+		call("", `{"Body": "foo"}`)
+	}
+	if calls != 3 {
+		t.Errorf("got %d handler calls, want 3", calls)
+	}
+}
+
+func TestDesc_ResponseCache(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	var calls int
+	desc := newMockAPIDesc(api.Public)
+	desc.ResponseCache = &api.ResponseCache{Cluster: "cache", TTL: time.Minute, VaryHeaders: []string{"Accept-Language"}}
+	desc.AppHandler = func(ctx context.Context, req *mockReq) (*mockResp, error) {
+		calls++
+		return &mockResp{Message: fmt.Sprintf("%s %d", req.Body, calls)}, nil
+	}
+
+	call := func(method string, header http.Header) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/?q=1", strings.NewReader(`{"Body": "foo"}`))
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+		return w
+	}
+
+	first := call("GET", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != 200 || first.Body.String() != `{"Message":"foo 1"}` {
+		t.Fatalf("got code %d body %q, want 200 %q", first.Code, first.Body.String(), `{"Message":"foo 1"}`)
+	} else if etag == "" {
+		t.Fatal("got no ETag header")
+	}
+	if got, want := first.Header().Get("Cache-Control"), "public, max-age=60"; got != want {
+		t.Errorf("got Cache-Control %q, want %q", got, want)
+	}
+
+	// The response is served from the cache without calling the handler.
+	hit := call("GET", nil)
+	if hit.Code != 200 || hit.Body.String() != first.Body.String() {
+		t.Fatalf("got code %d body %q, want cached response %q", hit.Code, hit.Body.String(), first.Body.String())
+	}
+	if got := hit.Header().Get("ETag"); got != etag {
+		t.Errorf("got ETag %q, want %q", got, etag)
+	}
+	if calls != 1 {
+		t.Errorf("got %d handler calls, want 1", calls)
+	}
+
+	// Clients with the response already get 304 Not Modified.
+	if w := call("GET", http.Header{"If-None-Match": {etag}}); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("got code %d body %q, want %d with no body", w.Code, w.Body.String(), http.StatusNotModified)
+	}
+
+	// Responses are cached separately for each value of the vary headers,
+	// and requests with Cache-Control: no-cache bypass the cache.
+	if w := call("GET", http.Header{"Accept-Language": {"sv"}}); w.Body.String() != `{"Message":"foo 2"}` {
+		t.Errorf("got body %q for Accept-Language: sv, want a new response", w.Body.String())
+	}
+	if w := call("GET", http.Header{"Cache-Control": {"no-cache"}}); w.Body.String() != `{"Message":"foo 3"}` {
+		t.Errorf("got body %q with Cache-Control: no-cache, want a new response", w.Body.String())
+	}
+	if w := call("GET", nil); w.Body.String() != `{"Message":"foo 3"}` {
+		t.Errorf("got body %q, want the response cached by the no-cache request", w.Body.String())
+	}
+
+	// Only read-only requests are cached.
+	call("POST", nil)
+	call("POST", nil)
+	if calls != 5 {
+		t.Errorf("got %d handler calls, want 5", calls)
+	}
+}
+
+func TestDesc_Codec(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+	codec.Register(codec.XML)
+
+	tests := []struct {
+		name        string
+		contentType string
+		accept      string
+		reqBody     string
+		respBody    string
+		respType    string
+		status      int
+	}{
+		{
+			name:     "json",
+			reqBody:  `{"Body": "foo"}`,
+			respBody: `{"Message":"foo"}`,
+			respType: "application/json",
+			status:   200,
+		},
+		{
+			name:        "xml",
+			contentType: "application/xml; charset=utf-8",
+			accept:      "application/xml",
+			reqBody:     `<mockReq><Body>foo</Body></mockReq>`,
+			respBody:    `<mockResp><Message>foo</Message></mockResp>`,
+			respType:    "application/xml",
+			status:      200,
+		},
+		{
+			name:        "xml_request_json_response",
+			contentType: "application/xml",
+			accept:      "application/json, application/xml",
+			reqBody:     `<mockReq><Body>foo</Body></mockReq>`,
+			respBody:    `{"Message":"foo"}`,
+			respType:    "application/json",
+			status:      200,
+		},
+		{
+			name:        "invalid_xml",
+			contentType: "application/xml",
+			reqBody:     `<mockReq><Body>foo</mockReq>`,
+			status:      400,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(test.reqBody))
+			if test.contentType != "" {
+				req.Header.Set("Content-Type", test.contentType)
+			}
+			if test.accept != "" {
+				req.Header.Set("Accept", test.accept)
+			}
+			desc := newMockAPIDesc(api.Public)
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+			if w.Code != test.status {
+				t.Fatalf("got code %d, want %d: %s", w.Code, test.status, w.Body.String())
+			}
+			if test.respBody != "" {
+				if got := w.Body.String(); got != test.respBody {
+					t.Errorf("got body %q, want %q", got, test.respBody)
+				}
+				if got := w.Header().Get("Content-Type"); got != test.respType {
+					t.Errorf("got content type %q, want %q", got, test.respType)
+				}
+			}
+		})
+	}
+}
+
 func findMetric(collected []usermetrics.CollectedMetric, name string, labels []usermetrics.KeyValue) *usermetrics.CollectedMetric {
 	for _, metric := range collected {
 		if metric.Info.Name() == name &&
@@ -330,6 +984,62 @@ func TestDescGeneratesTrace(t *testing.T) {
 	}
 }
 
+// unsampledFactory is a trace factory that samples no traces.
+type unsampledFactory struct {
+	traceprovider.Factory
+}
+
+func (unsampledFactory) SampleTrace() bool { return false }
+
+func TestDesc_ForceTrace(t *testing.T) {
+	key := config.EncoreAuthKey{KeyID: 1, Data: []byte("secret")}
+
+	tests := []struct {
+		name   string
+		token  string
+		traced bool
+	}{
+		{name: "no_token", token: "", traced: false},
+		{name: "invalid_token", token: "invalid", traced: false},
+		{name: "expired_token", token: platform.SignForceTraceToken(key, time.Now().Add(-time.Minute)), traced: false},
+		{name: "valid_token", token: platform.SignForceTraceToken(key, time.Now().Add(time.Hour)), traced: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traceMock := mock_trace.NewMockLogger(gomock.NewController(t))
+			tf := unsampledFactory{mock_trace.NewMockFactory(traceMock)}
+			server, _ := testServerWith(t, clock.New(), tf, []config.EncoreAuthKey{key})
+
+			req := httptest.NewRequest("POST", "/path/hello", strings.NewReader(`{"Body": "foo"}`))
+			if tt.token != "" {
+				req.Header.Set("X-Encore-Force-Trace", tt.token)
+			}
+			meta, err := server.MetaFromRequest(transport.HTTPRequest(req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var traced bool
+			traceMock.EXPECT().RequestSpanStart(gomock.Any(), gomock.Any()).Do(func(*model.Request, uint32) {
+				traced = true
+			}).MaxTimes(1)
+			traceMock.EXPECT().RequestSpanEnd(gomock.Any()).MaxTimes(1)
+			traceMock.EXPECT().WaitAndClear().AnyTimes()
+			traceMock.EXPECT().WaitUntilDone().AnyTimes()
+			traceMock.EXPECT().MarkDone().MaxTimes(1)
+
+			w := httptest.NewRecorder()
+			newMockAPIDesc(api.Public).Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"hello"}, meta))
+			if w.Code != http.StatusOK {
+				t.Fatalf("got code %d, want 200: %s", w.Code, w.Body.String())
+			}
+			if traced != tt.traced {
+				t.Errorf("got traced %v, want %v", traced, tt.traced)
+			}
+		})
+	}
+}
+
 // TestRawEndpointOverflow tests that raw endpoint capturing
 // is limited to the max capture size.
 func TestRawEndpointOverflow(t *testing.T) {
@@ -418,8 +1128,25 @@ func testServer(t *testing.T, klock clock.Clock, mockTraces bool) (*api.Server,
 		tf = &traceprovider.DefaultFactory{}
 	}
 
+	server, metricsRegistry := testServerWith(t, klock, tf, nil)
+	return server, traceMock, metricsRegistry
+}
+
+// testServerWith is like testServer, but with the given trace factory and auth keys.
+// If authKeys is non-empty the server authenticates requests against the platform.
+func testServerWith(t *testing.T, klock clock.Clock, tf traceprovider.Factory, authKeys []config.EncoreAuthKey) (*api.Server, *usermetrics.Registry) {
+	// Endpoint rate limits are stored in the "cache" cluster.
+	redisSrv := miniredis.RunT(t)
 	static := &config.Static{}
-	runtime := &config.Runtime{}
+	runtime := &config.Runtime{
+		RedisServers:   []*config.RedisServer{{Host: redisSrv.Addr()}},
+		RedisDatabases: []*config.RedisDatabase{{EncoreName: "cache"}},
+		AuthKeys:       authKeys,
+	}
+	var pc *platform.Client
+	if len(authKeys) > 0 {
+		pc = platform.NewClient(static, runtime)
+	}
 
 	logger := zerolog.New(os.Stdout)
 	rt := reqtrack.New(logger, nil, tf)
@@ -430,8 +1157,9 @@ func testServer(t *testing.T, klock clock.Clock, mockTraces bool) (*api.Server,
 	pubsubMgr := pubsub.NewManager(static, runtime, rt, tsMgr, logger, json)
 	healthMgr := health.NewCheckRegistry()
 	testingMgr := testsupport.NewManager(static, rt, logger)
-	server := api.NewServer(static, runtime, rt, nil, encoreMgr, pubsubMgr, logger, metricsRegistry, healthMgr, testingMgr, json, klock)
-	return server, traceMock, metricsRegistry
+	cacheMgr := cache.NewManager(static, runtime, rt, testingMgr, json)
+	server := api.NewServer(static, runtime, rt, pc, encoreMgr, pubsubMgr, cacheMgr, logger, metricsRegistry, healthMgr, testingMgr, json, klock)
+	return server, metricsRegistry
 }
 
 func newMockAPIDesc(access api.Access) *api.Desc[*mockReq, *mockResp] {
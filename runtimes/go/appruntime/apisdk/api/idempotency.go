@@ -0,0 +1,259 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/stack"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/cache"
+)
+
+const (
+	// idempotencyKeyHeader is the request header clients set to
+	// make retries of a request to an idempotent endpoint safe.
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// idempotentReplayedHeader is set on responses replayed
+	// from an earlier request with the same idempotency key.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+
+	// idempotencyTTL is how long responses are stored for.
+	idempotencyTTL = 24 * time.Hour
+
+	// maxIdempotencyKeyLen is the maximum length of an idempotency key.
+	maxIdempotencyKeyLen = 255
+
+	// maxStoredResponseSize is the maximum size of a response body to store.
+	// Larger responses aren't stored, so retries execute the request again.
+	maxStoredResponseSize = 1 << 20
+)
+
+// Idempotency describes how an endpoint deduplicates retried requests,
+// as declared by the "idempotent" field of its //encore:api directive.
+type Idempotency struct {
+	// Cluster is the name of the cache cluster the responses
+	// are stored in, so that they're shared by all instances of the service.
+	Cluster string
+}
+
+// storedResponse is the response of a request with an idempotency key.
+type storedResponse struct {
+	// RequestHash identifies the request the idempotency key was used with.
+	RequestHash string `json:"hash"`
+
+	// InProgress is true while the request is being processed.
+	InProgress bool `json:"in_progress,omitempty"`
+
+	Status int         `json:"status,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+// responseStores holds the keyspaces storing the responses of
+// idempotent endpoints, keyed by cache cluster name.
+type responseStores struct {
+	mgr *cache.Manager
+
+	mu     sync.Mutex
+	stores map[string]*cache.StringKeyspace[string]
+}
+
+func (r *responseStores) get(cluster string) *cache.StringKeyspace[string] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.stores[cluster]; ok {
+		return s
+	}
+
+	s := r.mgr.EndpointResponses(cluster, idempotencyTTL)
+	if r.stores == nil {
+		r.stores = make(map[string]*cache.StringKeyspace[string])
+	}
+	r.stores[cluster] = s
+	return s
+}
+
+// idempotentRequest is a request being processed with an idempotency key.
+type idempotentRequest struct {
+	store *cache.StringKeyspace[string]
+	key   string
+	hash  string
+	rec   *responseRecorder
+}
+
+// beginIdempotent checks the request's idempotency key against earlier requests.
+//
+// If the request has already been responded to, it replays the stored response
+// and reports handled. Otherwise, if the request has an idempotency key, it
+// returns the request to call complete on once the response has been written,
+// with c.w replaced to record the response.
+func (d *Desc[Req, Resp]) beginIdempotent(c *IncomingContext, reqData Req) (ir *idempotentRequest, handled bool) {
+	key := c.req.Header.Get(idempotencyKeyHeader)
+	if d.Idempotency == nil || key == "" || c.callMeta.IsServiceToService() || c.server.responseStores == nil {
+		return nil, false
+	}
+
+	fail := func(err error) (*idempotentRequest, bool) {
+		c.server.finishRequest(newErrResp(err, 0))
+		returnError(*c, err, 0, nil)
+		return nil, true
+	}
+	if len(key) > maxIdempotencyKeyLen {
+		return fail(errs.B().Code(errs.InvalidArgument).
+			Msgf("%s header must be at most %d characters", idempotencyKeyHeader, maxIdempotencyKeyLen).Err())
+	}
+
+	// Scope the key to the endpoint and the user, so that clients
+	// can't observe the responses to other users' requests.
+	scope := sha256.Sum256([]byte(string(c.auth.UID) + "\x00" + key))
+	ir = &idempotentRequest{
+		store: c.server.responseStores.get(d.Idempotency.Cluster),
+		key:   d.Service + "." + d.Endpoint + "/" + hex.EncodeToString(scope[:]),
+		hash:  d.requestHash(c, reqData),
+	}
+
+	marker, _ := c.server.json.MarshalToString(storedResponse{RequestHash: ir.hash, InProgress: true})
+	err := ir.store.SetIfNotExists(c.ctx, ir.key, marker)
+	if err == nil {
+		ir.rec = &responseRecorder{ResponseWriter: c.w}
+		c.w = ir.rec
+		return ir, false
+	} else if !errors.Is(err, cache.KeyExists) {
+		// Fail open: an unavailable cache cluster shouldn't take down the endpoint.
+		c.server.rootLogger.Error().Err(err).Str("service", d.Service).Str("endpoint", d.Endpoint).
+			Msg("unable to check idempotency key, processing request")
+		return nil, false
+	}
+
+	var stored storedResponse
+	if val, err := ir.store.Get(c.ctx, ir.key); err != nil {
+		if errors.Is(err, cache.Miss) {
+			// The earlier request failed and its key was released.
+			err = errs.B().Code(errs.Aborted).Msg("a request with the same idempotency key failed, retry the request").Err()
+		}
+		return fail(err)
+	} else if err := c.server.json.UnmarshalFromString(val, &stored); err != nil {
+		return fail(err)
+	}
+
+	switch {
+	case stored.RequestHash != ir.hash:
+		return fail(errs.B().Code(errs.InvalidArgument).
+			Msg("idempotency key has already been used with a different request").Err())
+	case stored.InProgress:
+		return fail(errs.B().Code(errs.Aborted).
+			Msg("a request with the same idempotency key is being processed").Err())
+	}
+
+	d.traceReplay(*c, stored.Status)
+	c.server.finishRequest(&model.Response{HTTPStatus: stored.Status})
+	for name, values := range stored.Header {
+		c.w.Header()[name] = values
+	}
+	c.w.Header().Set(idempotentReplayedHeader, "true")
+	c.w.WriteHeader(stored.Status)
+	_, _ = c.w.Write(stored.Body)
+	return nil, true
+}
+
+// requestHash returns a hash identifying the request,
+// to detect idempotency keys being reused for different requests.
+func (d *Desc[Req, Resp]) requestHash(c *IncomingContext, reqData Req) string {
+	h := sha256.New()
+	h.Write([]byte(c.req.Method + " " + c.req.URL.Path + "\n"))
+	h.Write(marshalParams(c.server.json, d.ReqUserPayload(reqData)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// complete stores the recorded response, so that it's replayed to
+// retries of the request. Server errors aren't stored, so that the
+// request can be retried.
+func (ir *idempotentRequest) complete(c IncomingContext) {
+	// Store the response even if the client went away,
+	// since the request has been processed.
+	ctx := context.WithoutCancel(c.ctx)
+
+	rec := ir.rec
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	if rec.status >= 500 || rec.overflow {
+		if _, err := ir.store.Delete(ctx, ir.key); err != nil {
+			c.server.rootLogger.Error().Err(err).Msg("unable to release idempotency key")
+		}
+		return
+	}
+
+	val, err := c.server.json.MarshalToString(storedResponse{
+		RequestHash: ir.hash,
+		Status:      rec.status,
+		Header:      rec.header,
+		Body:        rec.body,
+	})
+	if err == nil {
+		err = ir.store.Set(ctx, ir.key, val)
+	}
+	if err != nil {
+		c.server.rootLogger.Error().Err(err).Msg("unable to store idempotent response")
+	}
+}
+
+// traceReplay records that the response was replayed in the current trace, if any.
+func (d *Desc[Req, Resp]) traceReplay(c IncomingContext, status int) {
+	curr := c.server.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+
+	curr.Trace.LogMessage(trace2.LogMessageParams{
+		EventParams: trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+		},
+		Level: model.LevelInfo,
+		Msg:   "replayed idempotent response",
+		Stack: stack.Build(3),
+		Fields: []trace2.LogField{
+			{Key: "status", Value: status},
+		},
+	})
+}
+
+// responseRecorder is a http.ResponseWriter that records
+// the response written, while passing it through.
+type responseRecorder struct {
+	http.ResponseWriter
+	status   int
+	header   http.Header
+	body     []byte
+	overflow bool // the body exceeded maxStoredResponseSize
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+		r.header = r.ResponseWriter.Header().Clone()
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+	if len(r.body)+len(b) > maxStoredResponseSize {
+		r.overflow = true
+	} else if !r.overflow {
+		r.body = append(r.body, b...)
+	}
+	return r.ResponseWriter.Write(b)
+}
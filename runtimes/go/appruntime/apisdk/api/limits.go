@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"encore.dev/beta/errs"
+)
+
+// withTimeout returns a context for executing the endpoint with the
+// endpoint's timeout, if any, and a function to release its resources.
+func (d *Desc[Req, Resp]) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d.Timeout)
+}
+
+// timeoutErr reports the error to return for a request that failed with err,
+// having executed with the context ctx returned by withTimeout. Requests that
+// failed because they timed out are reported as DeadlineExceeded.
+func (d *Desc[Req, Resp]) timeoutErr(ctx context.Context, err error) error {
+	if d.Timeout > 0 && errors.Is(err, context.DeadlineExceeded) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errs.B().Code(errs.DeadlineExceeded).Cause(err).Msgf("request timed out after %s", d.Timeout).Err()
+	}
+	return err
+}
+
+// defaultMaxDecompressedBodySize is the maximum size of decompressed request bodies
+// for endpoints without a maximum body size, so that small compressed requests
+// can't expand into arbitrarily large bodies.
+const defaultMaxDecompressedBodySize = 64 << 20
+
+// limitBody limits the size of the request body to the endpoint's maximum body size, if any.
+// Decompressed bodies are limited to defaultMaxDecompressedBodySize if the endpoint has none.
+// It returns an error if the request's Content-Length header already exceeds the limit.
+func (d *Desc[Req, Resp]) limitBody(c *IncomingContext, decompressed bool) error {
+	limit := d.MaxBodySize
+	if limit <= 0 && decompressed {
+		limit = defaultMaxDecompressedBodySize
+	}
+	if limit <= 0 || c.req.Body == nil || c.req.Body == http.NoBody {
+		return nil
+	} else if c.req.ContentLength > limit {
+		return bodyTooLargeErr(limit)
+	}
+
+	c.limitedBody = &limitedBody{ReadCloser: http.MaxBytesReader(c.w, c.req.Body, limit)}
+	c.req.Body = c.limitedBody
+	return nil
+}
+
+// bodyTooLargeErr returns the error for a request body exceeding limit bytes.
+// It wraps an *http.MaxBytesError, which beginErrStatus reports with 413 Request Entity Too Large.
+func bodyTooLargeErr(limit int64) error {
+	return errs.B().Code(errs.InvalidArgument).Cause(&http.MaxBytesError{Limit: limit}).
+		Msgf("request body exceeds the maximum size of %d bytes", limit).Err()
+}
+
+// limitedBody is a request body limited by http.MaxBytesReader.
+// It records whether the limit was exceeded, as request decoders
+// don't always preserve the error reporting it.
+type limitedBody struct {
+	io.ReadCloser
+	exceeded *http.MaxBytesError
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && b.exceeded == nil {
+		errors.As(err, &b.exceeded)
+	}
+	return n, err
+}
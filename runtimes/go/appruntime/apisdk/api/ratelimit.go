@@ -0,0 +1,133 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/stack"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/cache"
+)
+
+// RateLimit describes the rate limit of an endpoint,
+// as declared by the "ratelimit" field of its //encore:api directive.
+type RateLimit struct {
+	// Cluster is the name of the cache cluster the rate limiter state
+	// is stored in, so that it's shared by all instances of the service.
+	Cluster string
+
+	// Rate is the number of requests allowed per Period.
+	Rate   int
+	Period time.Duration
+
+	// Burst is the maximum number of requests allowed at once.
+	// If zero, it defaults to Rate.
+	Burst int
+
+	// PerUser reports whether each authenticated user is limited separately.
+	// Unauthenticated requests share a single limit.
+	PerUser bool
+}
+
+type requestsShedLabels struct {
+	endpoint string // Endpoint name.
+}
+
+// rateLimiters holds the rate limiters of the cache clusters
+// storing endpoint rate limits, keyed by cluster name.
+type rateLimiters struct {
+	mgr *cache.Manager
+
+	mu       sync.Mutex
+	limiters map[string]*cache.RateLimiter[string]
+}
+
+func (r *rateLimiters) get(cluster string) *cache.RateLimiter[string] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l, ok := r.limiters[cluster]; ok {
+		return l
+	}
+
+	l := r.mgr.EndpointRateLimiter(cluster)
+	if r.limiters == nil {
+		r.limiters = make(map[string]*cache.RateLimiter[string])
+	}
+	r.limiters[cluster] = l
+	return l
+}
+
+// checkRateLimit checks the request against the endpoint's rate limit,
+// and returns the response to send if the request is shed.
+//
+// Only requests from outside the application are rate limited, so that
+// calls between services behave the same regardless of whether
+// the services run in the same process.
+func (d *Desc[Req, Resp]) checkRateLimit(c IncomingContext) (shed *model.Response) {
+	rl := d.RateLimit
+	if rl == nil || c.callMeta.IsServiceToService() || c.server.rateLimiters == nil {
+		return nil
+	}
+
+	key := d.Service + "." + d.Endpoint
+	if rl.PerUser {
+		if c.auth.UID != "" {
+			key += "/user/" + string(c.auth.UID)
+		} else {
+			key += "/anonymous"
+		}
+	}
+
+	limit := cache.Limit{Rate: rl.Rate, Period: rl.Period, Burst: rl.Burst}
+	res, err := c.server.rateLimiters.get(rl.Cluster).Allow(c.ctx, key, limit)
+	if err != nil {
+		// Fail open: an unavailable cache cluster shouldn't take down the endpoint.
+		c.server.rootLogger.Error().Err(err).Str("service", d.Service).Str("endpoint", d.Endpoint).
+			Msg("unable to check rate limit, allowing request")
+		return nil
+	} else if res.Allowed {
+		return nil
+	}
+
+	c.server.requestsShed.With(requestsShedLabels{endpoint: d.Endpoint}).Increment()
+	d.traceShed(c, rl, res.RetryAfter)
+
+	retryAfter := int(math.Ceil(res.RetryAfter.Seconds()))
+	err = errs.B().Code(errs.ResourceExhausted).
+		Meta("service", d.Service, "endpoint", d.Endpoint, "retry_after", res.RetryAfter.String()).
+		Msg("rate limit exceeded").Err()
+	resp := newErrResp(err, 0)
+	resp.Headers = http.Header{"Retry-After": []string{strconv.Itoa(max(retryAfter, 1))}}
+	return resp
+}
+
+// traceShed records that the request was shed in the current trace, if any.
+func (d *Desc[Req, Resp]) traceShed(c IncomingContext, rl *RateLimit, retryAfter time.Duration) {
+	curr := c.server.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+
+	curr.Trace.LogMessage(trace2.LogMessageParams{
+		EventParams: trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+		},
+		Level: model.LevelWarn,
+		Msg:   "request shed by rate limit",
+		Stack: stack.Build(3),
+		Fields: []trace2.LogField{
+			{Key: "rate", Value: rl.Rate},
+			{Key: "period", Value: rl.Period.String()},
+			{Key: "burst", Value: rl.Burst},
+			{Key: "per_user", Value: rl.PerUser},
+			{Key: "retry_after", Value: retryAfter.String()},
+		},
+	})
+}
@@ -45,6 +45,9 @@ type beginRequestParams struct {
 	// ParentSampled indicates whether the parent span sampled trace information.
 	ParentSampled bool
 
+	// ForceTrace forces the request to be traced, regardless of the sampling rate.
+	ForceTrace bool
+
 	// CallerEventID is the event ID in the parent span that triggered this request.
 	// It's used to correlate the request with the originating call.
 	CallerEventID model.TraceEventID
@@ -85,9 +88,12 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 	}
 
 	var traced bool
-	if p.ParentSpanID.IsZero() {
+	switch {
+	case p.ForceTrace:
+		traced = s.rt.TracingEnabled()
+	case p.ParentSpanID.IsZero():
 		traced = s.rt.SampleTrace()
-	} else {
+	default:
 		traced = p.ParentSampled
 	}
 
@@ -277,9 +283,22 @@ func (s *Server) finishRequest(resp *model.Response) {
 		endpoint: req.RPCData.Desc.Endpoint,
 		code:     Code(resp.Err, resp.HTTPStatus),
 	}).Increment()
+	s.observeDuration(req.RPCData.Desc.Endpoint, resp.Duration)
 	s.rt.FinishRequest(false)
 }
 
+// observeDuration records the duration of a request to the given endpoint
+// in every histogram bucket it falls within.
+func (s *Server) observeDuration(endpoint string, dur time.Duration) {
+	secs := dur.Seconds()
+	for i, le := range requestDurationBuckets {
+		if secs <= le {
+			s.requestsDur.With(requestDurationLabels{endpoint: endpoint, le: requestDurationBucketLabels[i]}).Increment()
+		}
+	}
+	s.requestsDur.With(requestDurationLabels{endpoint: endpoint, le: "+Inf"}).Increment()
+}
+
 type CallOptions struct {
 	Auth *model.AuthInfo
 }
@@ -354,6 +373,7 @@ func (s *Server) beginCall(ctx context.Context, serviceName, endpointName string
 	if curr.Req != nil && curr.Req.RPCData != nil {
 		call.UserID = curr.Req.RPCData.UserID
 		call.AuthData = curr.Req.RPCData.AuthData
+		call.AuthErr = curr.Req.RPCData.AuthErr
 	}
 
 	// Update request data based on call options, if any
@@ -361,6 +381,7 @@ func (s *Server) beginCall(ctx context.Context, serviceName, endpointName string
 		if a := opts.Auth; a != nil {
 			call.UserID = a.UID
 			call.AuthData = a.UserData
+			call.AuthErr = nil
 		}
 	}
 
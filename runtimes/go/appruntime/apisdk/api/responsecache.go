@@ -0,0 +1,344 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/stack"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/storage/cache"
+)
+
+// ResponseCache describes how the responses of an endpoint are cached,
+// as declared by the "cache" and "vary" fields of its //encore:api directive.
+type ResponseCache struct {
+	// Cluster is the name of the cache cluster the responses
+	// are cached in, so that they're shared by all instances of the service.
+	Cluster string
+
+	// TTL is how long responses are cached for.
+	TTL time.Duration
+
+	// VaryHeaders are the request headers responses are cached separately for.
+	VaryHeaders []string
+
+	// VaryAuth reports whether responses are cached separately
+	// for each authenticated user.
+	VaryAuth bool
+}
+
+// requestHeaders are response headers describing the request
+// the response was computed for, which aren't cached.
+var requestHeaders = []string{"X-Encore-Trace-ID", "X-Request-ID", "X-Correlation-ID"}
+
+// cachedResponse is a cached response of an endpoint.
+type cachedResponse struct {
+	Status  int         `json:"status"`
+	Header  http.Header `json:"header,omitempty"`
+	Body    []byte      `json:"body,omitempty"`
+	Expires time.Time   `json:"expires"`
+}
+
+// responseCaches holds the keyspaces caching the responses
+// of endpoints, keyed by cache cluster name.
+type responseCaches struct {
+	mgr *cache.Manager
+
+	mu     sync.Mutex
+	caches map[string]*cache.StringKeyspace[string]
+}
+
+func (r *responseCaches) get(cluster string) *cache.StringKeyspace[string] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ks, ok := r.caches[cluster]; ok {
+		return ks
+	}
+
+	ks := r.mgr.EndpointResponseCache(cluster)
+	if r.caches == nil {
+		r.caches = make(map[string]*cache.StringKeyspace[string])
+	}
+	r.caches[cluster] = ks
+	return ks
+}
+
+// cachedRequest is a request whose response is to be cached.
+type cachedRequest struct {
+	cfg   *ResponseCache
+	store *cache.StringKeyspace[string]
+	key   string
+	buf   *responseBuffer
+
+	// storable reports whether the response may be cached.
+	storable bool
+}
+
+// beginCached serves the request from the endpoint's response cache.
+//
+// If a cached response was found, it writes it and reports handled.
+// Otherwise, if the endpoint caches its responses, it returns the request
+// to call complete on once the response has been written, with c.w replaced
+// to buffer the response.
+func (d *Desc[Req, Resp]) beginCached(c *IncomingContext) (cr *cachedRequest, handled bool) {
+	rc := d.ResponseCache
+	if rc == nil || c.callMeta.IsServiceToService() || c.server.responseCaches == nil {
+		return nil, false
+	} else if m := c.req.Method; m != http.MethodGet && m != http.MethodHead {
+		return nil, false
+	}
+
+	directives := cacheControl(c.req.Header)
+	if directives["no-store"] {
+		return nil, false
+	}
+
+	cr = &cachedRequest{
+		cfg:   rc,
+		store: c.server.responseCaches.get(rc.Cluster).With(cache.ExpireIn(rc.TTL)),
+		key:   d.Service + "." + d.Endpoint + "/" + d.responseCacheKey(c),
+		// HEAD responses have no body, so they're served from
+		// the cache but never stored.
+		storable: c.req.Method == http.MethodGet,
+	}
+
+	if !directives["no-cache"] {
+		val, err := cr.store.Get(c.ctx, cr.key)
+		var cached cachedResponse
+		switch {
+		case errors.Is(err, cache.Miss):
+		case err != nil:
+			// Fail open: an unavailable cache cluster shouldn't take down the endpoint.
+			c.server.rootLogger.Error().Err(err).Str("service", d.Service).Str("endpoint", d.Endpoint).
+				Msg("unable to read cached response, processing request")
+			return nil, false
+		case c.server.json.UnmarshalFromString(val, &cached) == nil:
+			d.serveCached(*c, &cached)
+			return nil, true
+		}
+	}
+
+	cr.buf = &responseBuffer{ResponseWriter: c.w}
+	c.w = cr.buf
+	return cr, false
+}
+
+// responseCacheKey returns a hash identifying the responses the request
+// can be served, based on its path, query string and the headers
+// the response varies by.
+func (d *Desc[Req, Resp]) responseCacheKey(c *IncomingContext) string {
+	h := sha256.New()
+	h.Write([]byte(c.req.URL.Path + "?" + c.req.URL.Query().Encode() + "\n"))
+
+	// The response codec is negotiated using the Accept header.
+	h.Write([]byte(c.req.Header.Get("Accept") + "\n"))
+	for _, name := range d.ResponseCache.VaryHeaders {
+		h.Write([]byte(name + ": " + strings.Join(c.req.Header.Values(name), ",") + "\n"))
+	}
+	if d.ResponseCache.VaryAuth {
+		h.Write([]byte("uid: " + string(c.auth.UID) + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// serveCached writes a cached response, or 304 Not Modified
+// if the client already has it.
+func (d *Desc[Req, Resp]) serveCached(c IncomingContext, cached *cachedResponse) {
+	age := max(d.ResponseCache.TTL-time.Until(cached.Expires), 0)
+	d.traceCacheHit(c, cached.Status, age)
+
+	header := c.w.Header()
+	for name, values := range cached.Header {
+		header[name] = values
+	}
+	header.Set("Age", strconv.Itoa(int(age/time.Second)))
+	if header.Get("Cache-Control") == "" {
+		header.Set("Cache-Control", cacheControlValue(d.ResponseCache, time.Until(cached.Expires)))
+	}
+
+	status := cached.Status
+	if etagMatches(c.req.Header.Get("If-None-Match"), header.Get("ETag")) {
+		status = http.StatusNotModified
+	}
+	c.server.finishRequest(&model.Response{HTTPStatus: status})
+	c.w.WriteHeader(status)
+	if status != http.StatusNotModified && c.req.Method != http.MethodHead {
+		_, _ = c.w.Write(cached.Body)
+	}
+}
+
+// complete caches the buffered response if it was successful,
+// and writes it to the client, responding with 304 Not Modified
+// if the client already has it.
+func (cr *cachedRequest) complete(c IncomingContext) {
+	buf := cr.buf
+	if buf.overflow {
+		// The response was too large to buffer, and has been written already.
+		return
+	}
+	if buf.status == 0 {
+		buf.status = http.StatusOK
+	}
+
+	w, header := buf.ResponseWriter, buf.ResponseWriter.Header()
+	if buf.status == http.StatusOK {
+		if header.Get("ETag") == "" {
+			sum := sha256.Sum256(buf.body.Bytes())
+			header.Set("ETag", `"`+base64.RawURLEncoding.EncodeToString(sum[:16])+`"`)
+		}
+		if vary := cr.cfg.VaryHeaders; len(vary) > 0 {
+			header.Add("Vary", strings.Join(append([]string{"Accept"}, vary...), ", "))
+		} else {
+			header.Add("Vary", "Accept")
+		}
+
+		// Responses setting cookies are specific to the client, so they aren't cached.
+		if cr.storable && !cacheControl(header)["no-store"] && header.Get("Set-Cookie") == "" {
+			cr.save(c, header)
+		}
+		if header.Get("Cache-Control") == "" {
+			header.Set("Cache-Control", cacheControlValue(cr.cfg, cr.cfg.TTL))
+		}
+
+		if etagMatches(c.req.Header.Get("If-None-Match"), header.Get("ETag")) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.WriteHeader(buf.status)
+	_, _ = w.Write(buf.body.Bytes())
+}
+
+// save stores the buffered response in the cache.
+func (cr *cachedRequest) save(c IncomingContext, header http.Header) {
+	// Cache the response even if the client went away,
+	// since it has been computed.
+	ctx := context.WithoutCancel(c.ctx)
+
+	header = header.Clone()
+	for _, name := range requestHeaders {
+		header.Del(name)
+	}
+	val, err := c.server.json.MarshalToString(cachedResponse{
+		Status:  cr.buf.status,
+		Header:  header,
+		Body:    cr.buf.body.Bytes(),
+		Expires: time.Now().Add(cr.cfg.TTL),
+	})
+	if err == nil {
+		err = cr.store.Set(ctx, cr.key, val)
+	}
+	if err != nil {
+		c.server.rootLogger.Error().Err(err).Msg("unable to cache response")
+	}
+}
+
+// cacheControlValue returns the Cache-Control header of responses
+// that are fresh for the given duration.
+func cacheControlValue(rc *ResponseCache, fresh time.Duration) string {
+	// Responses cached per user mustn't be stored by shared caches.
+	scope := "public"
+	if rc.VaryAuth {
+		scope = "private"
+	}
+	return scope + ", max-age=" + strconv.Itoa(int(max(fresh, 0)/time.Second))
+}
+
+// traceCacheHit records that the response was served from the cache
+// in the current trace, if any.
+func (d *Desc[Req, Resp]) traceCacheHit(c IncomingContext, status int, age time.Duration) {
+	curr := c.server.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+
+	curr.Trace.LogMessage(trace2.LogMessageParams{
+		EventParams: trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+		},
+		Level: model.LevelInfo,
+		Msg:   "served cached response",
+		Stack: stack.Build(3),
+		Fields: []trace2.LogField{
+			{Key: "status", Value: status},
+			{Key: "age", Value: age},
+		},
+	})
+}
+
+// cacheControl parses the directives of the Cache-Control header,
+// ignoring their arguments.
+func cacheControl(header http.Header) map[string]bool {
+	directives := make(map[string]bool)
+	for _, v := range header.Values("Cache-Control") {
+		for _, d := range strings.Split(v, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(d), "=")
+			directives[strings.ToLower(name)] = true
+		}
+	}
+	return directives
+}
+
+// etagMatches reports whether the If-None-Match header matches the
+// given ETag, using the weak comparison RFC 9110 requires.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// responseBuffer is a http.ResponseWriter that buffers the response,
+// so that it can be cached and written once complete. Responses larger
+// than maxStoredResponseSize are written through as they're produced.
+type responseBuffer struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	overflow bool // the body exceeded maxStoredResponseSize
+}
+
+func (b *responseBuffer) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	if b.overflow {
+		return b.ResponseWriter.Write(p)
+	} else if b.body.Len()+len(p) > maxStoredResponseSize {
+		// Write the response through from here on.
+		b.overflow = true
+		b.ResponseWriter.WriteHeader(b.status)
+		if _, err := b.ResponseWriter.Write(b.body.Bytes()); err != nil {
+			return 0, err
+		}
+		b.body = bytes.Buffer{}
+		return b.ResponseWriter.Write(p)
+	}
+	return b.body.Write(p)
+}
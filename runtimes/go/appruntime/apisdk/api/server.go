@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -18,11 +19,11 @@ import (
 	"github.com/rs/zerolog"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
 
 	encore "encore.dev"
 	"encore.dev/appruntime/apisdk/api/svcauth"
 	"encore.dev/appruntime/apisdk/api/transport"
-	"encore.dev/appruntime/apisdk/cors"
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/experiments"
 	"encore.dev/appruntime/exported/model"
@@ -37,6 +38,7 @@ import (
 	"encore.dev/internal/platformauth"
 	"encore.dev/metrics"
 	"encore.dev/pubsub"
+	"encore.dev/storage/cache"
 )
 
 type Access string
@@ -66,6 +68,11 @@ type execContext struct {
 	ps     UnnamedParams
 	auth   model.AuthInfo
 
+	// authErr is the error from the auth handler if the request
+	// included auth information that was rejected, for endpoints
+	// that don't require auth.
+	authErr error
+
 	callMeta CallMeta
 }
 
@@ -77,6 +84,9 @@ type IncomingContext struct {
 	// capturer is set in handleIncoming for raw requests
 	// to capture the request body
 	capturer *rawRequestBodyCapturer
+
+	// limitedBody is set if the size of the request body is limited.
+	limitedBody *limitedBody
 }
 
 type Handler interface {
@@ -87,6 +97,7 @@ type Handler interface {
 	HTTPRouterPath() string
 	HTTPMethods() []string
 	IsFallback() bool
+	ExposedEnvTypes() []string
 	Handle(c IncomingContext)
 }
 
@@ -95,6 +106,24 @@ type requestsTotalLabels struct {
 	code     string // Human-readable HTTP status code.
 }
 
+type requestDurationLabels struct {
+	endpoint string // Endpoint name.
+	le       string // Upper bound of the bucket, in seconds.
+}
+
+// requestDurationBuckets are the upper bounds, in seconds, of the request
+// duration histogram buckets. They match the Prometheus client defaults.
+var requestDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// requestDurationBucketLabels are the "le" labels of requestDurationBuckets.
+var requestDurationBucketLabels = func() []string {
+	labels := make([]string, len(requestDurationBuckets))
+	for i, le := range requestDurationBuckets {
+		labels[i] = strconv.FormatFloat(le, 'g', -1, 64)
+	}
+	return labels
+}()
+
 type Server struct {
 	static         *config.Static
 	runtime        *config.Runtime
@@ -103,6 +132,11 @@ type Server struct {
 	encoreMgr      *encore.Manager
 	pubsubMgr      *pubsub.Manager
 	requestsTotal  *metrics.CounterGroup[requestsTotalLabels, uint64]
+	requestsDur    *metrics.CounterGroup[requestDurationLabels, uint64]
+	requestsShed   *metrics.CounterGroup[requestsShedLabels, uint64]
+	rateLimiters   *rateLimiters   // nil if no cache manager is available
+	responseStores *responseStores // nil if no cache manager is available
+	responseCaches *responseCaches // nil if no cache manager is available
 	httpClient     *http.Client
 	clock          clock.Clock
 	rootLogger     zerolog.Logger
@@ -111,10 +145,13 @@ type Server struct {
 	experiments    *experiments.Set // The set of experiments enabled for this runtime
 
 	authHandler AuthHandler
+	authChain   authChain // the locally defined auth handlers
 
 	globalMiddleware    map[string]*Middleware
 	registeredHandlers  []Handler
 	functionsToHandlers map[uintptr]Handler
+	versionSets         map[versionSetKey]*versionSet
+	cors                *corsRouters // nil if not acting as an API Gateway
 
 	public           *httprouter.Router
 	publicFallback   *httprouter.Router
@@ -124,6 +161,10 @@ type Server struct {
 	inboundSvcAuth   map[string]svcauth.ServiceAuth // auth methods used to accept inbound service-to-service calls
 	outboundSvcAuth  map[string]svcauth.ServiceAuth // auth methods used to make outbound service-to-service calls
 	httpsrv          *http.Server
+	grpcsrv          *grpc.Server // serves the endpoints exposed over gRPC
+	grpcInternal     *grpc.Server // serves the gRPC health and reflection services on the internal listener
+	grpcBridge       http.Handler // serves gRPC calls to endpoints as HTTP requests
+	grpcHealth       *grpcHealth
 	httpCtx          context.Context
 	httpCtxCancel    context.CancelFunc
 	runningHandlers  sync.WaitGroup
@@ -136,7 +177,7 @@ type Server struct {
 	testingMgr          *testsupport.Manager
 }
 
-func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, pc *platform.Client, encoreMgr *encore.Manager, pubsubMgr *pubsub.Manager, rootLogger zerolog.Logger, reg *metrics.Registry, healthMgr *health.CheckRegistry, testingMgr *testsupport.Manager, json jsoniter.API, clock clock.Clock) *Server {
+func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, pc *platform.Client, encoreMgr *encore.Manager, pubsubMgr *pubsub.Manager, cacheMgr *cache.Manager, rootLogger zerolog.Logger, reg *metrics.Registry, healthMgr *health.CheckRegistry, testingMgr *testsupport.Manager, json jsoniter.API, clock clock.Clock) *Server {
 	requestsTotal := metrics.NewCounterGroupInternal[requestsTotalLabels, uint64](reg, "e_requests_total", metrics.CounterConfig{
 		EncoreInternal_LabelMapper: func(labels requestsTotalLabels) []metrics.KeyValue {
 			return []metrics.KeyValue{
@@ -146,12 +187,34 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		},
 	})
 
-	newRouter := func() *httprouter.Router {
-		router := httprouter.New()
-		router.HandleOPTIONS = false
-		router.RedirectFixedPath = false
-		router.RedirectTrailingSlash = false
-		return router
+	// The request durations are reported as a cumulative histogram,
+	// in the form expected by Prometheus' histogram_quantile.
+	requestsDur := metrics.NewCounterGroupInternal[requestDurationLabels, uint64](reg, "e_request_duration_seconds_bucket", metrics.CounterConfig{
+		EncoreInternal_LabelMapper: func(labels requestDurationLabels) []metrics.KeyValue {
+			return []metrics.KeyValue{
+				{Key: "endpoint", Value: labels.endpoint},
+				{Key: "le", Value: labels.le},
+			}
+		},
+	})
+
+	requestsShed := metrics.NewCounterGroupInternal[requestsShedLabels, uint64](reg, "e_requests_shed_total", metrics.CounterConfig{
+		EncoreInternal_LabelMapper: func(labels requestsShedLabels) []metrics.KeyValue {
+			return []metrics.KeyValue{
+				{Key: "endpoint", Value: labels.endpoint},
+			}
+		},
+	})
+
+	var (
+		limiters *rateLimiters
+		stores   *responseStores
+		caches   *responseCaches
+	)
+	if cacheMgr != nil {
+		limiters = &rateLimiters{mgr: cacheMgr}
+		stores = &responseStores{mgr: cacheMgr}
+		caches = &responseCaches{mgr: cacheMgr}
 	}
 
 	inboundSvcAuth, outboundSvcAuth, err := svcauth.LoadMethods(clock, runtime)
@@ -169,6 +232,11 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		healthMgr:           healthMgr,
 		testingMgr:          testingMgr,
 		requestsTotal:       requestsTotal,
+		requestsDur:         requestsDur,
+		requestsShed:        requestsShed,
+		rateLimiters:        limiters,
+		responseStores:      stores,
+		responseCaches:      caches,
 		httpClient:          &http.Client{},
 		clock:               clock,
 		rootLogger:          rootLogger,
@@ -176,6 +244,7 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		tracingEnabled:      rt.TracingEnabled(),
 		experiments:         experiments.FromConfig(static, runtime),
 		functionsToHandlers: make(map[uintptr]Handler),
+		versionSets:         make(map[versionSetKey]*versionSet),
 
 		public:           newRouter(),
 		publicFallback:   newRouter(),
@@ -198,13 +267,7 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		if runtime.CORS != nil {
 			corsCfg = runtime.CORS
 		}
-		baseHandler = cors.Wrap(
-			corsCfg,
-			static.CORSAllowHeaders,
-			static.CORSExposeHeaders,
-			baseHandler,
-			rootLogger,
-		)
+		baseHandler = s.withCORS(corsCfg, baseHandler)
 	}
 
 	// Finally, this handler is used to track the number of running handlers
@@ -234,10 +297,24 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		baseHandler.ServeHTTP(w, r)
 	})
 
+	// gRPC requests are served outside the running handlers tracking, as the calls to
+	// endpoints are tracked by grpcBridge. The gRPC health and reflection services
+	// are served separately, on the internal listener.
+	s.grpcsrv = grpc.NewServer()
+	s.grpcInternal, s.grpcHealth = s.newInternalGRPCServer()
+	s.grpcBridge = activeHandlersWrapper
+	rootHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isGRPCRequest(r) {
+			s.grpcsrv.ServeHTTP(w, r)
+			return
+		}
+		activeHandlersWrapper.ServeHTTP(w, r)
+	})
+
 	// Now we have the handler chain setup, create the HTTP server object
 	s.httpCtx, s.httpCtxCancel = context.WithCancel(context.Background())
 	s.httpsrv = &http.Server{
-		Handler: h2c.NewHandler(activeHandlersWrapper, &http2.Server{}),
+		Handler: h2c.NewHandler(rootHandler, &http2.Server{}),
 		BaseContext: func(_ net.Listener) context.Context {
 			// We set the base context which allows us to cancel it when the server is shutting down
 			return s.httpCtx
@@ -282,9 +359,16 @@ func (s *Server) configureRemotePubsubPush() {
 	}
 }
 
-// setAuthHandler sets the auth handler to use.
-// If h is nil it means no auth handler is used.
-func (s *Server) setAuthHandler(h AuthHandler) {
+// setAuthHandler adds an auth handler to use.
+// When multiple auth handlers are added they are combined into
+// a chain, which resolves requests in the handlers' resolution order.
+func (s *Server) setAuthHandler(handler AuthHandler) {
+	s.authChain.add(handler)
+	var h AuthHandler = &s.authChain
+	if len(s.authChain.handlers) == 1 {
+		h = handler
+	}
+
 	authService := h.HostedByService()
 
 	if !cfgutil.IsHostedService(s.runtime, authService) {
@@ -312,6 +396,16 @@ func (s *Server) RegisteredHandlers() []Handler {
 	return s.registeredHandlers
 }
 
+// newRouter returns a router that leaves OPTIONS requests
+// and redirects to the server's handler.
+func newRouter() *httprouter.Router {
+	router := httprouter.New()
+	router.HandleOPTIONS = false
+	router.RedirectFixedPath = false
+	router.RedirectTrailingSlash = false
+	return router
+}
+
 // wildcardMethod is an internal method name we register wildcard methods under.
 const wildcardMethod = "__ENCORE_WILDCARD__"
 
@@ -340,6 +434,10 @@ func (s *Server) registerEndpoint(h Handler, function any) {
 
 	s.registeredHandlers = append(s.registeredHandlers, h)
 
+	// Only expose the endpoint publicly in the environments it's restricted to, if any.
+	access := h.AccessType()
+	exposed := (access == Public || access == RequiresAuth) && exposedInEnv(s.runtime, h.ExposedEnvTypes())
+
 	// Register the adapter
 	for _, m := range h.HTTPMethods() {
 		if m == "*" {
@@ -347,8 +445,18 @@ func (s *Server) registerEndpoint(h Handler, function any) {
 		}
 
 		private.Handle(m, routerPath, adapter)
-		if access := h.AccessType(); access == Public || access == RequiresAuth {
+		if exposed {
 			public.Handle(m, routerPath, adapter)
+			s.registerCORS(h, m, routerPath)
+		}
+
+		// Also serve versioned APIs on their unversioned path.
+		if vh, ok := h.(versionedHandler); ok && vh.APIVersion() > 0 {
+			s.registerVersion(private, m, vh, adapter)
+			if exposed {
+				s.registerVersion(public, m, vh, adapter)
+				s.registerCORS(h, m, vh.UnversionedRouterPath())
+			}
 		}
 	}
 
@@ -363,6 +471,21 @@ func (s *Server) registerEndpoint(h Handler, function any) {
 	}
 }
 
+// exposedInEnv reports whether an endpoint restricted to the given environment types
+// is exposed in the current environment. The "local" environment type matches
+// environments running locally, regardless of their environment type.
+func exposedInEnv(rt *config.Runtime, envTypes []string) bool {
+	if len(envTypes) == 0 {
+		return true
+	}
+	for _, env := range envTypes {
+		if env == rt.EnvType || (env == "local" && rt.EnvCloud == "local") {
+			return true
+		}
+	}
+	return false
+}
+
 // HandlerForFunc returns the Handler for the given function or nil if it does not exist.
 func (s *Server) HandlerForFunc(function any) Handler {
 	return s.functionsToHandlers[reflect.ValueOf(function).Pointer()]
@@ -408,11 +531,26 @@ func (s *Server) Serve(ln net.Listener) error {
 	if s.runtime.EnvCloud != "local" || s.IsGateway() {
 		s.rootLogger.Trace().Msg("listening for incoming HTTP requests")
 	}
+	if err := s.registerGRPCEndpoints(); err != nil {
+		return err
+	}
+	s.grpcHealth.markServing()
 	return s.httpsrv.Serve(ln)
 }
 
+// ServeInternal serves the gRPC health and reflection services on ln.
+//
+// The listener is meant to be reachable only by the infrastructure running the app,
+// such as health checkers, and not to be exposed to the app's clients.
+func (s *Server) ServeInternal(ln net.Listener) error {
+	return s.grpcInternal.Serve(ln)
+}
+
 // Shutdown gracefully shuts down the server.
 func (s *Server) Shutdown(p *shutdown.Process) error {
+	// Report NOT_SERVING to gRPC health checkers so traffic is drained.
+	s.grpcHealth.Shutdown()
+
 	// Once it's time to force-close tasks, cancel the base context.
 	go func() {
 		<-p.ForceCloseTasks.Done()
@@ -427,6 +565,11 @@ func (s *Server) Shutdown(p *shutdown.Process) error {
 	s.runningHandlers.Wait()
 	p.MarkOutstandingRequestsCompleted()
 
+	// Close any open gRPC streams, such as health watch streams,
+	// so the servers can finish shutting down.
+	s.grpcsrv.Stop()
+	s.grpcInternal.Stop()
+
 	return <-shutdownErr
 }
 
@@ -546,23 +689,29 @@ func (s *Server) extractCallMeta(w http.ResponseWriter, req *http.Request) (upda
 }
 
 func (s *Server) newExecContext(ctx context.Context, ps UnnamedParams, callMeta CallMeta) execContext {
-	var auth model.AuthInfo
+	var (
+		auth    model.AuthInfo
+		authErr error
+	)
 	if callMeta.Internal != nil {
 		auth = model.AuthInfo{
 			UID:      model.UID(callMeta.Internal.AuthUID),
 			UserData: callMeta.Internal.AuthData,
 		}
+		if msg := callMeta.Internal.AuthErr; msg != "" {
+			authErr = errs.B().Code(errs.Unauthenticated).Msg(msg).Err()
+		}
 	}
-	return execContext{s, ctx, ps, auth, callMeta}
+	return execContext{s, ctx, ps, auth, authErr, callMeta}
 }
 
 func (s *Server) NewIncomingContext(w http.ResponseWriter, req *http.Request, ps UnnamedParams, callMeta CallMeta) IncomingContext {
 	ec := s.newExecContext(req.Context(), ps, callMeta)
-	return IncomingContext{ec, w, req, nil}
+	return IncomingContext{execContext: ec, w: w, req: req}
 }
 
 func (s *Server) NewCallContext(ctx context.Context) CallContext {
-	return CallContext{ctx, s}
+	return CallContext{ctx: ctx, server: s}
 }
 
 func toUnnamedParams(ps httprouter.Params) UnnamedParams {
@@ -4,9 +4,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"testing"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
 )
 
 func Test_handleTrailingSlashRedirect(t *testing.T) {
@@ -82,3 +86,108 @@ func Test_determineRequestPath(t *testing.T) {
 		}
 	}
 }
+
+func Test_exposedInEnv(t *testing.T) {
+	tests := []struct {
+		envType, envCloud string
+		envTypes          []string
+		want              bool
+	}{
+		{"production", "aws", nil, true},
+		{"production", "aws", []string{"production"}, true},
+		{"production", "aws", []string{"development", "local"}, false},
+		{"development", "gcp", []string{"development"}, true},
+		{"development", "local", []string{"local"}, true},
+		{"test", "local", []string{"local"}, true},
+		{"development", "gcp", []string{"local"}, false},
+		{"ephemeral", "encore", []string{"production", "development"}, false},
+	}
+	for _, tt := range tests {
+		rt := &config.Runtime{EnvType: tt.envType, EnvCloud: tt.envCloud}
+		if got := exposedInEnv(rt, tt.envTypes); got != tt.want {
+			t.Errorf("exposedInEnv(%s/%s, %v) = %v, want %v",
+				tt.envType, tt.envCloud, tt.envTypes, got, tt.want)
+		}
+	}
+}
+
+func Test_versionSet(t *testing.T) {
+	s := &Server{versionSets: make(map[versionSetKey]*versionSet)}
+	r := httprouter.New()
+	for _, version := range []int{2, 1} {
+		version := version
+		vh := &Desc[Void, Void]{Version: version, UnversionedRawPath: "/users/:0"}
+		s.registerVersion(r, "GET", vh, func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+			w.Header().Set("X-Version", strconv.Itoa(version))
+		})
+	}
+
+	tests := []struct {
+		header  string
+		code    int
+		version string
+	}{
+		{"", 200, "1"},
+		{"2", 200, "2"},
+		{"v2", 200, "2"},
+		{"3", 404, ""},
+		{"latest", 400, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/users/5", nil)
+		if tt.header != "" {
+			req.Header.Set(apiVersionHeader, tt.header)
+		}
+		r.ServeHTTP(w, req)
+		if w.Code != tt.code || w.Header().Get("X-Version") != tt.version {
+			t.Errorf("API-Version %q: got code %d and version %q, want %d and %q",
+				tt.header, w.Code, w.Header().Get("X-Version"), tt.code, tt.version)
+		}
+		if got := w.Header().Get("Vary"); got != apiVersionHeader {
+			t.Errorf("API-Version %q: got Vary %q, want %q", tt.header, got, apiVersionHeader)
+		}
+	}
+}
+
+func TestServer_withCORS(t *testing.T) {
+	s := &Server{static: &config.Static{}, rootLogger: zerolog.Nop()}
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})
+	h := s.withCORS(&config.CORS{AllowOriginsWithCredentials: []string{"https://global.com"}}, next)
+	s.registerCORS(&Desc[Void, Void]{CORS: &CORS{
+		AllowOrigins:     []string{"https://*.example.com"},
+		AllowHeaders:     []string{"X-Custom"},
+		AllowCredentials: true,
+	}}, "GET", "/api/:0")
+	s.registerCORS(&Desc[Void, Void]{}, "GET", "/other")
+
+	tests := []struct {
+		method, path, origin string
+		header               http.Header
+		want                 string // Access-Control-Allow-Origin
+	}{
+		{"GET", "/api/1", "https://app.example.com", http.Header{"Cookie": {"a=b"}}, "https://app.example.com"},
+		{"GET", "/api/1", "https://global.com", http.Header{"Cookie": {"a=b"}}, ""},
+		{"GET", "/other", "https://global.com", http.Header{"Cookie": {"a=b"}}, "https://global.com"},
+		{"GET", "/other", "https://app.example.com", http.Header{"Cookie": {"a=b"}}, ""},
+		{"OPTIONS", "/api/1", "https://app.example.com", http.Header{
+			"Access-Control-Request-Method":  {"GET"},
+			"Access-Control-Request-Headers": {"x-custom"},
+		}, "https://app.example.com"},
+		{"OPTIONS", "/other", "https://app.example.com", http.Header{
+			"Access-Control-Request-Method":  {"GET"},
+			"Access-Control-Request-Headers": {"x-custom"},
+		}, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Header = tt.header.Clone()
+		req.Header.Set("Origin", tt.origin)
+		h.ServeHTTP(w, req)
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.want {
+			t.Errorf("%s %s from %s: got Access-Control-Allow-Origin %q, want %q",
+				tt.method, tt.path, tt.origin, got, tt.want)
+		}
+	}
+}
@@ -45,9 +45,10 @@ func (s *Server) processRequest(h Handler, c IncomingContext) {
 	c.server.beginOperation()
 	defer c.server.finishOperation()
 
-	info, proceed := s.runAuthHandler(h, c)
+	info, authErr, proceed := s.runAuthHandler(h, c)
 	if proceed {
 		c.auth = info
+		c.authErr = authErr
 		h.Handle(c)
 	}
 }
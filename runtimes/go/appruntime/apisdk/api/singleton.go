@@ -15,11 +15,12 @@ import (
 	"encore.dev/appruntime/shared/testsupport"
 	"encore.dev/metrics"
 	"encore.dev/pubsub"
+	"encore.dev/storage/cache"
 )
 
 var Singleton = NewServer(
 	appconf.Static, appconf.Runtime, reqtrack.Singleton, platform.Singleton,
-	encore.Singleton, pubsub.Singleton, logging.RootLogger, metrics.Singleton,
+	encore.Singleton, pubsub.Singleton, cache.Singleton, logging.RootLogger, metrics.Singleton,
 	health.Singleton, testsupport.Singleton,
 	jsonapi.Default, clock.New(),
 )
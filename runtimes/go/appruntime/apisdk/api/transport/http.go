@@ -38,6 +38,8 @@ func metaKeyToHTTPHeader(key string) string {
 		return "tracestate"
 	case CorrelationIDKey:
 		return "X-Correlation-ID"
+	case ForceTraceKey:
+		return "X-Encore-Force-Trace"
 	default:
 		return "X-Encore-Meta-" + key
 	}
@@ -71,6 +73,8 @@ func (h *httpHeaders) ListMetaKeys() []string {
 			rtn = append(rtn, TraceStateKey)
 		case key == "X-Correlation-Id":
 			rtn = append(rtn, CorrelationIDKey)
+		case key == "X-Encore-Force-Trace":
+			rtn = append(rtn, ForceTraceKey)
 		case strings.HasPrefix(key, "X-Encore-Meta-"):
 			rtn = append(rtn, key[14:])
 		}
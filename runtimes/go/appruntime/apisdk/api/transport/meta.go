@@ -8,4 +8,5 @@ const (
 	TraceParentKey   = "Traceparent"
 	TraceStateKey    = "Tracestate"
 	CorrelationIDKey = "Correlation-ID"
+	ForceTraceKey    = "Force-Trace"
 )
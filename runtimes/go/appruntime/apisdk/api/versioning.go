@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/beta/errs"
+)
+
+const (
+	// apiVersionHeader is the request header selecting the version of a
+	// versioned API to call when it's called on its unversioned path.
+	apiVersionHeader = "API-Version"
+
+	// deprecationHeader is set on responses from deprecated APIs.
+	deprecationHeader = "Deprecation"
+)
+
+// versionedHandler is implemented by handlers of versioned APIs.
+type versionedHandler interface {
+	// APIVersion returns the version of the API, or 0 if it's unversioned.
+	APIVersion() int
+
+	// UnversionedRouterPath returns the httprouter path of the API
+	// without its "/v<version>" prefix.
+	UnversionedRouterPath() string
+}
+
+func (d *Desc[Req, Resp]) APIVersion() int               { return d.Version }
+func (d *Desc[Req, Resp]) UnversionedRouterPath() string { return d.UnversionedRawPath }
+
+// versionSetKey identifies the version set of a versioned API in a router.
+type versionSetKey struct {
+	router *httprouter.Router
+	method string
+	path   string
+}
+
+// versionSet serves the unversioned path of a versioned API, dispatching
+// requests to the version selected by their API-Version header.
+type versionSet struct {
+	versions map[int]httprouter.Handle
+
+	// def is the version serving requests without an API-Version header:
+	// the oldest one, so that existing callers keep working as versions are added.
+	def int
+}
+
+// registerVersion registers the adapter of a version of a versioned API
+// to be served on its unversioned path in router, for the given method.
+func (s *Server) registerVersion(router *httprouter.Router, method string, vh versionedHandler, adapter httprouter.Handle) {
+	key := versionSetKey{router: router, method: method, path: vh.UnversionedRouterPath()}
+	vs, ok := s.versionSets[key]
+	if !ok {
+		vs = &versionSet{versions: make(map[int]httprouter.Handle)}
+		s.versionSets[key] = vs
+		router.Handle(method, key.path, vs.serve)
+	}
+
+	version := vh.APIVersion()
+	vs.versions[version] = adapter
+	if vs.def == 0 || version < vs.def {
+		vs.def = version
+	}
+}
+
+func (vs *versionSet) serve(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	// The response depends on the requested version.
+	w.Header().Add("Vary", apiVersionHeader)
+
+	version := vs.def
+	if v := req.Header.Get(apiVersionHeader); v != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(v), "v"))
+		if err != nil {
+			errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Msgf("invalid %s header %q", apiVersionHeader, v).Err())
+			return
+		}
+		version = n
+	}
+
+	adapter, ok := vs.versions[version]
+	if !ok {
+		errs.HTTPError(w, errs.B().Code(errs.NotFound).Msgf("unknown API version %d", version).Err())
+		return
+	}
+	adapter(w, req, ps)
+}
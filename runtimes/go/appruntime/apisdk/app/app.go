@@ -1,12 +1,16 @@
 package app
 
 import (
+	"context"
+	"time"
+
 	"github.com/rs/zerolog"
 	"go.uber.org/automaxprocs/maxprocs"
 
 	"encore.dev/appruntime/apisdk/api"
 	"encore.dev/appruntime/apisdk/service"
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/preflight"
 	"encore.dev/appruntime/shared/shutdown"
 
 	// Initialize the metric subsystem
@@ -14,20 +18,22 @@ import (
 )
 
 type App struct {
-	runtime  *config.Runtime
-	service  *service.Manager
-	api      *api.Server
-	shutdown *shutdown.Tracker
-	logger   zerolog.Logger
+	runtime   *config.Runtime
+	service   *service.Manager
+	api       *api.Server
+	shutdown  *shutdown.Tracker
+	preflight *preflight.Registry
+	logger    zerolog.Logger
 }
 
-func New(runtime *config.Runtime, service *service.Manager, api *api.Server, shutdown *shutdown.Tracker, logger zerolog.Logger) *App {
+func New(runtime *config.Runtime, service *service.Manager, api *api.Server, shutdown *shutdown.Tracker, preflight *preflight.Registry, logger zerolog.Logger) *App {
 	app := &App{
-		runtime:  runtime,
-		service:  service,
-		api:      api,
-		shutdown: shutdown,
-		logger:   logger,
+		runtime:   runtime,
+		service:   service,
+		api:       api,
+		shutdown:  shutdown,
+		preflight: preflight,
+		logger:    logger,
 	}
 
 	return app
@@ -59,13 +65,30 @@ func (app *App) Run() error {
 	}
 	defer func() { _ = ln.Close() }()
 
+	internalLn, err := ListenInternal()
+	if err != nil {
+		return err
+	}
+
 	app.Start()
 
+	if err := app.runPreflight(); err != nil {
+		app.shutdown.Shutdown(nil, err)
+		return err
+	}
+
 	// Begin serving requests.
 	serveCh := make(chan error, 1)
 	go func() {
 		serveCh <- app.api.Serve(ln)
 	}()
+	if internalLn != nil {
+		go func() {
+			if err := app.api.ServeInternal(internalLn); err != nil && !app.shutdown.ShutdownInitiated() {
+				app.logger.Error().Err(err).Msg("failed to serve internal listener")
+			}
+		}()
+	}
 
 	if err := app.service.InitializeServices(); err != nil {
 		app.shutdown.Shutdown(nil, err)
@@ -114,3 +137,35 @@ func (app *App) logStartupInfo() {
 		}
 	}
 }
+
+// runPreflight runs the preflight checks for the application's
+// infrastructure resources and logs a startup report.
+// It reports an error if a check failed and the runtime
+// is configured to fail fast.
+func (app *App) runPreflight() error {
+	cfg := app.runtime.Preflight
+	if cfg == nil {
+		cfg = &config.Preflight{}
+	}
+	if cfg.Disabled || app.runtime.EnvType == "test" {
+		return nil
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	report := app.preflight.Run(ctx)
+	if len(report.Results) == 0 {
+		return nil
+	}
+	report.Log(app.logger)
+
+	if cfg.FailFast {
+		return report.Err()
+	}
+	return nil
+}
@@ -10,12 +10,13 @@ import (
 	"encore.dev/appruntime/apisdk/service"
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/preflight"
 	"encore.dev/appruntime/shared/shutdown"
 )
 
 // AppMain is the entrypoint to the Encore Application.
 func AppMain() {
-	inst := app.New(appconf.Runtime, service.Singleton, api.Singleton, shutdown.Singleton, logging.RootLogger)
+	inst := app.New(appconf.Runtime, service.Singleton, api.Singleton, shutdown.Singleton, preflight.Singleton, logging.RootLogger)
 	if err := inst.Run(); err != nil && err != io.EOF {
 		logging.RootLogger.Fatal().Err(err).Msg("could not run")
 	}
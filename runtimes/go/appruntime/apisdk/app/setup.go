@@ -25,3 +25,18 @@ func Listen() (net.Listener, error) {
 	}
 	return net.Listen("tcp", ":"+strconv.Itoa(port))
 }
+
+// ListenInternal listens on the internal listen address, which serves
+// the gRPC health and reflection services to the infrastructure running the app.
+// It reports a nil listener if no internal listen address is configured.
+func ListenInternal() (net.Listener, error) {
+	listenAddr := encoreenv.Get("ENCORE_INTERNAL_LISTEN_ADDR")
+	if listenAddr == "" {
+		return nil, nil
+	}
+	addrPort, err := netip.ParseAddrPort(listenAddr)
+	if err != nil {
+		return nil, err
+	}
+	return net.Listen("tcp", addrPort.String())
+}
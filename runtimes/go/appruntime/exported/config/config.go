@@ -34,6 +34,10 @@ type Static struct {
 
 	// EmbeddedEnvs is a set of embedded environment variables.
 	EmbeddedEnvs map[string]string
+
+	// GRPCDescriptor is the serialized protobuf file descriptor
+	// describing the endpoints exposed over gRPC, if any.
+	GRPCDescriptor []byte `json:",omitempty"`
 }
 
 type Runtime struct {
@@ -44,10 +48,13 @@ type Runtime struct {
 	EnvName           string          `json:"env_name"`
 	EnvType           string          `json:"env_type"`
 	EnvCloud          string          `json:"env_cloud"`
-	DeployID          string          `json:"deploy_id"` // Overridden by ENCORE_DEPLOY_ID env var if set
+	EnvRegion         string          `json:"env_region,omitempty"` // Overridden by ENCORE_REGION env var if set
+	DeployID          string          `json:"deploy_id"`            // Overridden by ENCORE_DEPLOY_ID env var if set
 	DeployedAt        time.Time       `json:"deploy_time"`
 	TraceEndpoint     string          `json:"trace_endpoint,omitempty"`
 	TraceSamplingRate *float64        `json:"trace_sampling_rate,omitempty"`
+	TraceTimeAnchor   string          `json:"trace_time_anchor,omitempty"` // "send" (the default) or "boot", see trace2.TimeAnchorMode
+	TraceMemStats     bool            `json:"trace_mem_stats,omitempty"`   // record the memory allocated during spans in traces
 	AuthKeys          []EncoreAuthKey `json:"auth_keys,omitempty"`
 	CORS              *CORS           `json:"cors,omitempty"`
 	EncoreCloudAPI    *EncoreCloudAPI `json:"ec_api,omitempty"` // If nil, the app is not running in Encore Cloud
@@ -58,6 +65,7 @@ type Runtime struct {
 	PubsubTopics     map[string]*PubsubTopic `json:"pubsub_topics,omitempty"`
 	RedisServers     []*RedisServer          `json:"redis_servers,omitempty"`
 	RedisDatabases   []*RedisDatabase        `json:"redis_databases,omitempty"`
+	Memcached        []*MemcachedCluster     `json:"memcached_clusters,omitempty"`
 	BucketProviders  []*BucketProvider       `json:"bucket_providers,omitempty"`
 	Buckets          map[string]*Bucket      `json:"buckets,omitempty"`
 	Metrics          *Metrics                `json:"metrics,omitempty"`
@@ -82,6 +90,10 @@ type Runtime struct {
 	// GracefulShutdown defines the timings for the graceful shutdown process.
 	GracefulShutdown *GracefulShutdownTimings `json:"graceful_shutdown,omitempty"`
 
+	// Preflight configures the checks run against the application's
+	// infrastructure resources on startup.
+	Preflight *Preflight `json:"preflight,omitempty"`
+
 	// DynamicExperiments is a list of experiments that are enabled for this app
 	// which impact runtime behaviour, but which were not enabled at compile time.
 	//
@@ -125,6 +137,22 @@ type GracefulShutdownTimings struct {
 	Handlers *time.Duration `json:"handlers,omitempty"`
 }
 
+// Preflight configures the checks run against the application's
+// infrastructure resources on startup.
+type Preflight struct {
+	// Disabled disables the preflight checks.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// FailFast causes the application to exit on startup
+	// if any preflight check fails. If false failures are
+	// logged and the application starts anyway.
+	FailFast bool `json:"fail_fast,omitempty"`
+
+	// Timeout is how long to wait for the checks to complete.
+	// If zero it defaults to 10 seconds.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
 // Gateway defines the configuration of a gateway which should be served
 // by the container
 type Gateway struct {
@@ -142,6 +170,10 @@ type Service struct {
 	URL string `json:"url"`
 	// Protocol is the protocol that the service talks
 	Protocol SvcProtocol `json:"protocol"`
+	// RegionURLs are the base URLs of the service in each region it's deployed to,
+	// for apps running in multiple regions. Calls made from one of those regions
+	// are routed to the service in the same region instead of to URL.
+	RegionURLs map[string]string `json:"region_urls,omitempty"`
 
 	// ServiceAuth is the authentication configuration required for
 	// internal service to service calls being made to this service.
@@ -255,6 +287,7 @@ type PubsubProvider struct {
 	GCP         *GCPPubsubProvider         `json:"gcp,omitempty"`          // set if the provider is GCP
 	AWS         *AWSPubsubProvider         `json:"aws,omitempty"`          // set if the provider is AWS
 	Azure       *AzureServiceBusProvider   `json:"azure,omitempty"`        // set if the provider is Azure
+	Kafka       *KafkaProvider             `json:"kafka,omitempty"`        // set if the provider is Kafka
 	EncoreCloud *EncoreCloudPubsubProvider `json:"encore_cloud,omitempty"` // set if the provider is Encore Cloud
 }
 
@@ -265,6 +298,28 @@ type NSQProvider struct {
 	Host string `json:"host"`
 }
 
+type KafkaProvider struct {
+	// Brokers are the addresses ("host:port") of the seed brokers to connect to.
+	Brokers []string `json:"brokers"`
+
+	// SASLMechanism is the SASL mechanism to authenticate with,
+	// one of "plain", "scram-sha-256" and "scram-sha-512", or "" for no authentication.
+	SASLMechanism string `json:"sasl_mechanism,omitempty"`
+	SASLUser      string `json:"sasl_user,omitempty"`
+	SASLPassword  string `json:"sasl_password,omitempty"`
+
+	// EnableTLS specifies whether or not to use TLS to connect.
+	// If ServerCACert, ClientCert, or ClientKey are provided it is
+	// automatically enabled regardless of the value.
+	EnableTLS bool `json:"enable_tls"`
+	// ServerCACert is the PEM-encoded server CA cert, or "" if not required.
+	ServerCACert string `json:"server_ca_cert,omitempty"`
+	// ClientCert is the PEM-encoded client cert, or "" if not required.
+	ClientCert string `json:"client_cert,omitempty"`
+	// ClientKey is the PEM-encoded client key, or "" if not required.
+	ClientKey string `json:"client_key,omitempty"`
+}
+
 type EncoreCloudPubsubProvider struct{}
 
 // GCPPubsubProvider currently has no specific configuration.
@@ -353,6 +408,22 @@ type SQLDatabase struct {
 	// MaxConnections is the maximum number of open connections to use
 	// for this database. If zero it defaults to 30.
 	MaxConnections int `json:"max_connections"`
+
+	// MaxIdleTime is the duration after which an idle connection
+	// is closed. If zero it defaults to 30 minutes.
+	MaxIdleTime time.Duration `json:"max_idle_time,omitempty"`
+
+	// MaxConnLifetime is the duration after which a connection
+	// is closed and replaced. If zero it defaults to one hour.
+	MaxConnLifetime time.Duration `json:"max_conn_lifetime,omitempty"`
+
+	// StatementTimeout is the maximum duration of a single statement,
+	// after which the database cancels it. If zero there is no timeout.
+	StatementTimeout time.Duration `json:"statement_timeout,omitempty"`
+
+	// ReadReplicas are connection strings for read replicas of this database,
+	// in either the keyword/value or URI format understood by libpq.
+	ReadReplicas []string `json:"read_replicas,omitempty"`
 }
 
 type RedisServer struct {
@@ -401,6 +472,28 @@ type RedisDatabase struct {
 	KeyPrefix string `json:"key_prefix"`
 }
 
+// MemcachedCluster describes a cache cluster backed by Memcached
+// instead of Redis. Only a subset of cache operations are supported.
+type MemcachedCluster struct {
+	EncoreName string `json:"encore_name"` // the Encore name for the cache cluster
+
+	// Servers are the Memcached servers to use, in "host:port" form
+	// or as a path to a unix socket. Keys are distributed across the servers.
+	Servers []string `json:"servers"`
+
+	// KeyPrefix specifies a prefix to add to all cache keys,
+	// to allow sharing a Memcached fleet with other applications.
+	KeyPrefix string `json:"key_prefix,omitempty"`
+
+	// MaxIdleConnections is the maximum number of idle connections
+	// to keep open per server. If zero it defaults to 10*GOMAXPROCS.
+	MaxIdleConnections int `json:"max_idle_connections,omitempty"`
+
+	// Timeout is the socket read/write timeout.
+	// If zero it defaults to 500ms.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
 type BucketProvider struct {
 	S3  *S3BucketProvider  `json:"s3,omitempty"`  // set if the provider is S3
 	GCS *GCSBucketProvider `json:"gcs,omitempty"` // set if the provider is GCS
@@ -441,6 +534,18 @@ type Bucket struct {
 	// The public base url for the bucket.
 	// Only set if the bucket is public.
 	PublicBaseURL string `json:"public_base_url"`
+
+	// Encryption configures client-side encryption of objects.
+	// Only set if the bucket uses client-side encryption.
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+}
+
+// BucketEncryption configures client-side envelope encryption for a bucket.
+type BucketEncryption struct {
+	// KMSKey is the KMS key used to encrypt the data keys of objects,
+	// either an AWS KMS key ARN or a GCP Cloud KMS key resource name.
+	// If empty, a key for local development is used.
+	KMSKey string `json:"kms_key,omitempty"`
 }
 
 type Metrics struct {
@@ -12,11 +12,13 @@ import (
 type InfraConfig struct {
 	Metadata         Metadata                     `json:"metadata,omitempty"`
 	GracefulShutdown *GracefulShutdown            `json:"graceful_shutdown,omitempty"`
+	Preflight        *Preflight                   `json:"preflight,omitempty"`
 	Auth             []*Auth                      `json:"auth,omitempty"`
 	ServiceDiscovery map[string]*ServiceDiscovery `json:"service_discovery,omitempty"`
 	Metrics          *Metrics                     `json:"metrics,omitempty"`
 	SQLServers       []*SQLServer                 `json:"sql_servers,omitempty"`
 	Redis            map[string]*Redis            `json:"redis,omitempty"`
+	Memcached        map[string]*Memcached        `json:"memcached,omitempty"`
 	PubSub           []*PubSub                    `json:"pubsub,omitempty"`
 	Secrets          Secrets                      `json:"secrets,omitempty"`
 	ObjectStorage    []*ObjectStorage             `json:"object_storage,omitempty"`
@@ -160,6 +162,7 @@ func (a *S3) Validate(v *validator) {
 
 type GCS struct {
 	Endpoint string             `json:"endpoint,omitempty"`
+	Region   string             `json:"region,omitempty"` // the location of the buckets
 	Buckets  map[string]*Bucket `json:"buckets,omitempty"`
 }
 
@@ -171,6 +174,26 @@ type Bucket struct {
 	Name          string `json:"name,omitempty"`
 	KeyPrefix     string `json:"key_prefix,omitempty"`
 	PublicBaseURL string `json:"public_base_url,omitempty"`
+
+	// Tags to apply to the bucket. When building an image, the tags declared
+	// for the bucket in the application are added, with tags set here taking precedence.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Lifecycle rules to apply to the bucket. When building an image,
+	// it's set to the lifecycle declared for the bucket in the application.
+	Lifecycle *BucketLifecycle `json:"lifecycle,omitempty"`
+
+	// KMSKey is the KMS key used for client-side encryption of objects,
+	// either an AWS KMS key ARN or a GCP Cloud KMS key resource name.
+	// It must be set for buckets declared with client-side encryption.
+	KMSKey string `json:"kms_key,omitempty"`
+}
+
+// BucketLifecycle describes when objects are moved to cold storage or deleted,
+// measured in days from when an object was created. Zero means never.
+type BucketLifecycle struct {
+	ExpireAfterDays      int `json:"expire_after_days,omitempty"`
+	ColdStorageAfterDays int `json:"cold_storage_after_days,omitempty"`
 }
 
 func (a *Bucket) Validate(v *validator) {
@@ -192,6 +215,11 @@ type Metadata struct {
 	EnvType string `json:"env_type,omitempty"`
 	Cloud   string `json:"cloud,omitempty"`
 	BaseURL string `json:"base_url,omitempty"`
+
+	// Region is the cloud region the environment runs in, such as "eu-west-1".
+	// It's used as the region of resources that don't configure their own
+	// when checking the regions resources are restricted to.
+	Region string `json:"region,omitempty"`
 }
 
 // Copy of the CORS struct from the appfile
@@ -205,12 +233,14 @@ type CORS struct {
 
 func (i *InfraConfig) Validate(v *validator) {
 	v.ValidateChild("graceful_shutdown", i.GracefulShutdown)
+	v.ValidateChild("preflight", i.Preflight)
 	ValidateChildList(v, "auth", i.Auth)
 	ValidateChildMap(v, "service_discovery", i.ServiceDiscovery)
 	ValidateChildList(v, "object_storage", i.ObjectStorage)
 	v.ValidateChild("metrics", i.Metrics)
 	ValidateChildList(v, "sql_servers", i.SQLServers)
 	ValidateChildMap(v, "redis", i.Redis)
+	ValidateChildMap(v, "memcached", i.Memcached)
 	ValidateChildList(v, "pubsub", i.PubSub)
 	v.ValidateChild("secrets", i.Secrets)
 }
@@ -218,9 +248,15 @@ func (i *InfraConfig) Validate(v *validator) {
 type Secrets struct {
 	SecretsMap map[string]EnvString
 	EnvRef     *EnvRef
+	// Provider resolves secrets at runtime from an external secrets manager.
+	Provider *SecretsProvider
 }
 
 func (s Secrets) Validate(v *validator) {
+	if s.Provider != nil {
+		s.Provider.Validate(v)
+		return
+	}
 	if s.EnvRef != nil {
 		v.ValidateEnvRef("env_ref", *s.EnvRef, "An environment variable containing a JSON object of secrets")
 		return
@@ -230,7 +266,12 @@ func (s Secrets) Validate(v *validator) {
 	}
 }
 
+// GetSecrets returns the secrets defined in the config.
+// It returns nil if the secrets are resolved by a provider.
 func (s *Secrets) GetSecrets() map[string]string {
+	if s.Provider != nil {
+		return nil
+	}
 	if s.EnvRef != nil {
 		refs := make(map[string]string)
 		envValue := os.Getenv(s.EnvRef.Env)
@@ -253,6 +294,19 @@ func (s *Secrets) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	// Try unmarshalling as a secrets provider, identified by its type.
+	var aux struct {
+		Type any `json:"type"`
+	}
+	if err := json.Unmarshal(data, &aux); err == nil && isSecretsProviderType(aux.Type) {
+		var p SecretsProvider
+		if err := json.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		s.Provider = &p
+		return nil
+	}
+
 	// Try unmarshalling as a map of strings to EnvString.
 	var m map[string]EnvString
 	if err := json.Unmarshal(data, &m); err == nil {
@@ -264,12 +318,138 @@ func (s *Secrets) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON is a custom JSON marshaller for the Secrets type.
 func (s Secrets) MarshalJSON() ([]byte, error) {
+	if s.Provider != nil {
+		return json.Marshal(s.Provider)
+	}
 	if s.EnvRef == nil {
 		return json.Marshal(s.SecretsMap)
 	}
 	return json.Marshal(s.EnvRef)
 }
 
+func isSecretsProviderType(typ any) bool {
+	switch typ {
+	case "vault", "aws_secrets_manager":
+		return true
+	}
+	return false
+}
+
+// SecretsProvider configures an external secrets manager to resolve secrets from.
+type SecretsProvider struct {
+	Type string `json:"type,omitempty"`
+	// CacheTTL is the number of seconds resolved secrets are cached
+	// before they're fetched again. If unset it defaults to 300.
+	CacheTTL          *int `json:"cache_ttl,omitempty"`
+	Vault             *VaultSecrets
+	AWSSecretsManager *AWSSecretsManager
+}
+
+func (p *SecretsProvider) Validate(v *validator) {
+	v.ValidateField("cache_ttl", NilOr(p.CacheTTL, GreaterOrEqual(0)))
+	switch p.Type {
+	case "vault":
+		p.Vault.Validate(v)
+	case "aws_secrets_manager":
+		p.AWSSecretsManager.Validate(v)
+	default:
+		v.ValidateField("type", Err("unsupported secrets provider type"))
+	}
+}
+
+// MarshalJSON custom marshaller to handle dynamic types in SecretsProvider.
+func (p *SecretsProvider) MarshalJSON() ([]byte, error) {
+	data := make(map[string]interface{})
+	data["type"] = p.Type
+	if p.CacheTTL != nil {
+		data["cache_ttl"] = *p.CacheTTL
+	}
+
+	switch p.Type {
+	case "vault":
+		if p.Vault != nil {
+			for k, v := range structToMap(p.Vault) {
+				data[k] = v
+			}
+		}
+	case "aws_secrets_manager":
+		if p.AWSSecretsManager != nil {
+			for k, v := range structToMap(p.AWSSecretsManager) {
+				data[k] = v
+			}
+		}
+	default:
+		return nil, errors.New("unsupported secrets provider type")
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON custom unmarshaller to handle dynamic types in SecretsProvider.
+func (p *SecretsProvider) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type     string `json:"type,omitempty"`
+		CacheTTL *int   `json:"cache_ttl,omitempty"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Type = aux.Type
+	p.CacheTTL = aux.CacheTTL
+
+	switch aux.Type {
+	case "vault":
+		var vs VaultSecrets
+		if err := json.Unmarshal(data, &vs); err != nil {
+			return err
+		}
+		p.Vault = &vs
+	case "aws_secrets_manager":
+		var a AWSSecretsManager
+		if err := json.Unmarshal(data, &a); err != nil {
+			return err
+		}
+		p.AWSSecretsManager = &a
+	default:
+		return errors.New("unsupported secrets provider type")
+	}
+
+	return nil
+}
+
+// VaultSecrets resolves secrets from a HashiCorp Vault KV version 2 secret,
+// where each key of the secret is the name of an Encore secret.
+type VaultSecrets struct {
+	Address string    `json:"address,omitempty"`
+	Token   EnvString `json:"token,omitempty"`
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string `json:"namespace,omitempty"`
+	// Mount is the mount path of the KV secrets engine.
+	// If empty it defaults to "secret".
+	Mount string `json:"mount,omitempty"`
+	Path  string `json:"path,omitempty"`
+}
+
+func (vs *VaultSecrets) Validate(v *validator) {
+	v.ValidateField("address", NotZero(vs.Address))
+	v.ValidateEnvString("token", vs.Token, "Vault Token", NotZero[string])
+	v.ValidateField("path", NotZero(vs.Path))
+}
+
+// AWSSecretsManager resolves secrets from an AWS Secrets Manager secret
+// containing a JSON object, where each key is the name of an Encore secret.
+type AWSSecretsManager struct {
+	// Region is the AWS region of the secret.
+	// If empty it's taken from the secret ARN or the default AWS config.
+	Region string `json:"region,omitempty"`
+	// SecretID is the name or ARN of the secret.
+	SecretID string `json:"secret_id,omitempty"`
+}
+
+func (a *AWSSecretsManager) Validate(v *validator) {
+	v.ValidateField("secret_id", NotZero(a.SecretID))
+}
+
 type GracefulShutdown struct {
 	Total         *int `json:"total,omitempty"`
 	ShutdownHooks *int `json:"shutdown_hooks,omitempty"`
@@ -282,6 +462,16 @@ func (g *GracefulShutdown) Validate(v *validator) {
 	v.ValidateField("handlers", NilOr(g.Handlers, GreaterOrEqual(0)))
 }
 
+type Preflight struct {
+	Disabled bool `json:"disabled,omitempty"`
+	FailFast bool `json:"fail_fast,omitempty"`
+	Timeout  int  `json:"timeout,omitempty"`
+}
+
+func (p *Preflight) Validate(v *validator) {
+	v.ValidateField("timeout", GreaterOrEqual(0)(p.Timeout))
+}
+
 type Auth struct {
 	Type string    `json:"type,omitempty"`
 	ID   int       `json:"id,omitempty"`
@@ -296,6 +486,10 @@ func (a *Auth) Validate(v *validator) {
 type ServiceDiscovery struct {
 	BaseURL string  `json:"base_url,omitempty"`
 	Auth    []*Auth `json:"auth,omitempty"`
+
+	// RegionURLs are the base URLs of the service keyed by region,
+	// used instead of BaseURL when calling it from one of those regions.
+	RegionURLs map[string]string `json:"region_urls,omitempty"`
 }
 
 func (s *ServiceDiscovery) Validate(v *validator) {
@@ -458,6 +652,7 @@ func (a *AWSCloudWatch) Validate(v *validator) {
 
 type SQLServer struct {
 	Host      string                  `json:"host,omitempty"`
+	Region    string                  `json:"region,omitempty"` // the region the server runs in
 	TLSConfig *TLSConfig              `json:"tls_config,omitempty"`
 	Databases map[string]*SQLDatabase `json:"databases,omitempty"`
 }
@@ -486,14 +681,34 @@ type SQLDatabase struct {
 	Username       EnvString   `json:"username,omitempty"`
 	Password       EnvString   `json:"password,omitempty"`
 	ClientCert     *ClientCert `json:"client_cert,omitempty"`
+
+	// MaxIdleTime is the number of seconds after which an idle connection is closed.
+	MaxIdleTime int `json:"max_idle_time,omitempty"`
+	// MaxConnLifetime is the number of seconds after which a connection is closed and replaced.
+	MaxConnLifetime int `json:"max_conn_lifetime,omitempty"`
+	// StatementTimeout is the number of seconds after which a running statement is canceled.
+	StatementTimeout int `json:"statement_timeout,omitempty"`
+
+	// ReadReplicas are connection strings for read replicas of the database.
+	// Queries made through (*sqldb.Database).ReadOnly are routed to them.
+	ReadReplicas []EnvString `json:"read_replicas,omitempty"`
+
+	// Tags to apply to the database, merged with the tags declared in the application.
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 func (s *SQLDatabase) Validate(v *validator) {
 	v.ValidateField("max_connections", GreaterOrEqual(s.MinConnections)(s.MaxConnections))
 	v.ValidateField("min_connections", GreaterOrEqual(0)(s.MinConnections))
+	v.ValidateField("max_idle_time", GreaterOrEqual(0)(s.MaxIdleTime))
+	v.ValidateField("max_conn_lifetime", GreaterOrEqual(0)(s.MaxConnLifetime))
+	v.ValidateField("statement_timeout", GreaterOrEqual(0)(s.StatementTimeout))
 	v.ValidateEnvString("username", s.Username, "Database Username", NotZero[string])
 	v.ValidateEnvString("password", s.Password, "Database Password", NotZero[string])
 	v.ValidateChild("client_cert", s.ClientCert)
+	for i, replica := range s.ReadReplicas {
+		v.ValidateEnvString(fmt.Sprintf("read_replicas[%d]", i), replica, "Database Read Replica Connection String", NotZero[string])
+	}
 }
 
 type Redis struct {
@@ -504,6 +719,9 @@ type Redis struct {
 	TLSConfig      *TLSConfig `json:"tls_config,omitempty"`
 	MaxConnections *int       `json:"max_connections,omitempty"`
 	MinConnections *int       `json:"min_connections,omitempty"`
+
+	// Tags to apply to the cache cluster, merged with the tags declared in the application.
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 func (r *Redis) Validate(v *validator) {
@@ -535,6 +753,24 @@ func (r *RedisAuth) Validate(v *validator) {
 	}
 }
 
+// Memcached configures a cache cluster to be backed by Memcached instead of Redis.
+// Only string, int, float and struct keyspaces are supported.
+type Memcached struct {
+	Servers            []string `json:"servers,omitempty"`
+	KeyPrefix          *string  `json:"key_prefix,omitempty"`
+	MaxIdleConnections *int     `json:"max_idle_connections,omitempty"`
+	TimeoutMillis      *int     `json:"timeout_ms,omitempty"`
+}
+
+func (m *Memcached) Validate(v *validator) {
+	v.ValidateField("servers", NotZero(len(m.Servers)))
+	for i, srv := range m.Servers {
+		v.ValidateField(fmt.Sprintf("servers[%d]", i), NotZero(srv))
+	}
+	v.ValidateField("max_idle_connections", NilOr(m.MaxIdleConnections, GreaterOrEqual(0)))
+	v.ValidateField("timeout_ms", NilOr(m.TimeoutMillis, GreaterOrEqual(0)))
+}
+
 type ClientCert struct {
 	Cert string    `json:"cert,omitempty"`
 	Key  EnvString `json:"key,omitempty"`
@@ -547,10 +783,11 @@ func (c *ClientCert) Validate(v *validator) {
 
 // Main PubSub struct which embeds different PubSub types.
 type PubSub struct {
-	Type string `json:"type,omitempty"`
-	GCP  *GCPPubsub
-	AWS  *AWSSNS_SQS
-	NSQ  *NSQPubsub
+	Type  string `json:"type,omitempty"`
+	GCP   *GCPPubsub
+	AWS   *AWSSNS_SQS
+	NSQ   *NSQPubsub
+	Kafka *KafkaPubsub
 }
 
 func (p *PubSub) Validate(v *validator) {
@@ -561,6 +798,8 @@ func (p *PubSub) Validate(v *validator) {
 		p.AWS.Validate(v)
 	case "nsq":
 		p.NSQ.Validate(v)
+	case "kafka":
+		p.Kafka.Validate(v)
 	default:
 		v.ValidateField("type", Err("unsupported pubsub type"))
 	}
@@ -574,6 +813,8 @@ func (p *PubSub) DeleteTopic(name string) {
 		p.AWS.DeleteTopic(name)
 	case "nsq":
 		p.NSQ.DeleteTopic(name)
+	case "kafka":
+		p.Kafka.DeleteTopic(name)
 	}
 }
 
@@ -585,6 +826,8 @@ func (p *PubSub) GetTopics() map[string]PubsubTopic {
 		return p.AWS.GetTopics()
 	case "nsq":
 		return p.NSQ.GetTopics()
+	case "kafka":
+		return p.Kafka.GetTopics()
 	default:
 		panic("unsupported pubsub type")
 	}
@@ -593,6 +836,8 @@ func (p *PubSub) GetTopics() map[string]PubsubTopic {
 type PubsubTopic interface {
 	GetSubscriptions() map[string]PubsubSubscription
 	DeleteSubscription(name string)
+	GetTags() map[string]string
+	SetTags(tags map[string]string)
 }
 
 type PubsubSubscription interface{}
@@ -626,6 +871,7 @@ type GCPTopic struct {
 	Name          string             `json:"name,omitempty"`
 	ProjectID     string             `json:"project_id,omitempty"`
 	Subscriptions map[string]*GCPSub `json:"subscriptions,omitempty"`
+	Tags          map[string]string  `json:"tags,omitempty"`
 }
 
 func (g *GCPTopic) Validate(v *validator) {
@@ -645,6 +891,14 @@ func (g *GCPTopic) DeleteSubscription(name string) {
 	delete(g.Subscriptions, name)
 }
 
+func (g *GCPTopic) GetTags() map[string]string {
+	return g.Tags
+}
+
+func (g *GCPTopic) SetTags(tags map[string]string) {
+	g.Tags = tags
+}
+
 type GCPSub struct {
 	Name       string      `json:"name,omitempty"`
 	ProjectID  string      `json:"project_id,omitempty"`
@@ -692,6 +946,7 @@ func (a *AWSSNS_SQS) DeleteTopic(name string) {
 type AWSTopic struct {
 	ARN           string             `json:"arn,omitempty"`
 	Subscriptions map[string]*AWSSub `json:"subscriptions,omitempty"`
+	Tags          map[string]string  `json:"tags,omitempty"`
 }
 
 func (a *AWSTopic) Validate(v *validator) {
@@ -709,6 +964,14 @@ func (a *AWSTopic) DeleteSubscription(name string) {
 	delete(a.Subscriptions, name)
 }
 
+func (a *AWSTopic) GetTags() map[string]string {
+	return a.Tags
+}
+
+func (a *AWSTopic) SetTags(tags map[string]string) {
+	a.Tags = tags
+}
+
 type AWSSub struct {
 	URL string `json:"url,omitempty"`
 }
@@ -741,6 +1004,7 @@ func (n *NSQPubsub) DeleteTopic(name string) {
 type NSQTopic struct {
 	Name          string             `json:"name,omitempty"`
 	Subscriptions map[string]*NSQSub `json:"subscriptions,omitempty"`
+	Tags          map[string]string  `json:"tags,omitempty"`
 }
 
 func (n *NSQTopic) Validate(v *validator) {
@@ -758,6 +1022,14 @@ func (n *NSQTopic) DeleteSubscription(name string) {
 	delete(n.Subscriptions, name)
 }
 
+func (n *NSQTopic) GetTags() map[string]string {
+	return n.Tags
+}
+
+func (n *NSQTopic) SetTags(tags map[string]string) {
+	n.Tags = tags
+}
+
 type NSQSub struct {
 	Name string `json:"name,omitempty"`
 }
@@ -766,6 +1038,85 @@ func (n *NSQSub) Validate(v *validator) {
 	v.ValidateField("name", NotZero(n.Name))
 }
 
+// KafkaPubsub specific configuration.
+type KafkaPubsub struct {
+	Brokers   []string               `json:"brokers,omitempty"`
+	SASL      *KafkaSASL             `json:"sasl,omitempty"`
+	TLSConfig *TLSConfig             `json:"tls_config,omitempty"`
+	Topics    map[string]*KafkaTopic `json:"topics,omitempty"`
+}
+
+func (k *KafkaPubsub) Validate(v *validator) {
+	v.ValidateField("brokers", NotZero(len(k.Brokers)))
+	v.ValidateChild("sasl", k.SASL)
+	v.ValidateChild("tls_config", k.TLSConfig)
+	ValidateChildMap(v, "topics", k.Topics)
+}
+
+func (k *KafkaPubsub) GetTopics() map[string]PubsubTopic {
+	return MapValues(k.Topics, func(_ string, v *KafkaTopic) PubsubTopic {
+		return v
+	})
+}
+
+func (k *KafkaPubsub) DeleteTopic(name string) {
+	delete(k.Topics, name)
+}
+
+type KafkaSASL struct {
+	Mechanism string    `json:"mechanism,omitempty"`
+	Username  EnvString `json:"username,omitempty"`
+	Password  EnvString `json:"password,omitempty"`
+}
+
+func (k *KafkaSASL) Validate(v *validator) {
+	switch k.Mechanism {
+	case "plain", "scram-sha-256", "scram-sha-512":
+	default:
+		v.ValidateField("mechanism", Err("unsupported Kafka SASL mechanism"))
+	}
+	v.ValidateEnvString("username", k.Username, "Kafka SASL Username", NotZero[string])
+	v.ValidateEnvString("password", k.Password, "Kafka SASL Password", NotZero[string])
+}
+
+type KafkaTopic struct {
+	Name          string               `json:"name,omitempty"`
+	Subscriptions map[string]*KafkaSub `json:"subscriptions,omitempty"`
+	Tags          map[string]string    `json:"tags,omitempty"`
+}
+
+func (k *KafkaTopic) Validate(v *validator) {
+	v.ValidateField("name", NotZero(k.Name))
+	ValidateChildMap(v, "subscriptions", k.Subscriptions)
+}
+
+func (k *KafkaTopic) GetSubscriptions() map[string]PubsubSubscription {
+	return MapValues(k.Subscriptions, func(_ string, v *KafkaSub) PubsubSubscription {
+		return v
+	})
+}
+
+func (k *KafkaTopic) DeleteSubscription(name string) {
+	delete(k.Subscriptions, name)
+}
+
+func (k *KafkaTopic) GetTags() map[string]string {
+	return k.Tags
+}
+
+func (k *KafkaTopic) SetTags(tags map[string]string) {
+	k.Tags = tags
+}
+
+// KafkaSub is a subscription, which is implemented as a Kafka consumer group.
+type KafkaSub struct {
+	GroupID string `json:"group_id,omitempty"`
+}
+
+func (k *KafkaSub) Validate(v *validator) {
+	v.ValidateField("group_id", NotZero(k.GroupID))
+}
+
 // MarshalJSON custom marshaller for PubSub.
 func (p *PubSub) MarshalJSON() ([]byte, error) {
 	// Create a map to hold the JSON structure
@@ -794,6 +1145,12 @@ func (p *PubSub) MarshalJSON() ([]byte, error) {
 				m[k] = v
 			}
 		}
+	case "kafka":
+		if p.Kafka != nil {
+			for k, v := range structToMap(p.Kafka) {
+				m[k] = v
+			}
+		}
 	default:
 		return nil, errors.New("unsupported pubsub type")
 	}
@@ -843,6 +1200,12 @@ func (p *PubSub) UnmarshalJSON(data []byte) error {
 			return err
 		}
 		p.NSQ = &n
+	case "kafka":
+		var k KafkaPubsub
+		if err := json.Unmarshal(data, &k); err != nil {
+			return err
+		}
+		p.Kafka = &k
 	default:
 		return errors.New("unsupported pubsub type")
 	}
@@ -30,3 +30,38 @@ func TestInfraConfigMarshalUnmarshal(t *testing.T) {
 
 	c.Assert(newConfig, qt.DeepEquals, config)
 }
+
+func TestSecretsProviderUnmarshal(t *testing.T) {
+	c := qt.New(t)
+	data := []byte(`{
+		"type": "vault",
+		"cache_ttl": 60,
+		"address": "https://vault.example.com:8200",
+		"token": {"$env": "VAULT_TOKEN"},
+		"path": "my-app"
+	}`)
+
+	var secrets Secrets
+	c.Assert(json.Unmarshal(data, &secrets), qt.IsNil)
+	c.Assert(secrets.SecretsMap, qt.IsNil)
+	c.Assert(secrets.Provider, qt.IsNotNil)
+	c.Assert(secrets.Provider.Type, qt.Equals, "vault")
+	c.Assert(*secrets.Provider.CacheTTL, qt.Equals, 60)
+	c.Assert(secrets.Provider.Vault, qt.DeepEquals, &VaultSecrets{
+		Address: "https://vault.example.com:8200",
+		Token:   EnvString{Env: &EnvRef{Env: "VAULT_TOKEN"}},
+		Path:    "my-app",
+	})
+
+	marshaled, err := json.Marshal(secrets)
+	c.Assert(err, qt.IsNil)
+	var roundTrip Secrets
+	c.Assert(json.Unmarshal(marshaled, &roundTrip), qt.IsNil)
+	c.Assert(roundTrip, qt.DeepEquals, secrets)
+
+	// A secret named "type" is still a secret.
+	var plain Secrets
+	c.Assert(json.Unmarshal([]byte(`{"type": "not-a-provider"}`), &plain), qt.IsNil)
+	c.Assert(plain.Provider, qt.IsNil)
+	c.Assert(plain.SecretsMap["type"].Value(), qt.Equals, "not-a-provider")
+}
@@ -152,6 +152,7 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 	cfg.EnvName = infraCfg.Metadata.EnvName
 	cfg.EnvType = infraCfg.Metadata.EnvType
 	cfg.EnvCloud = infraCfg.Metadata.Cloud
+	cfg.EnvRegion = infraCfg.Metadata.Region
 	cfg.APIBaseURL = infraCfg.Metadata.BaseURL
 	cfg.LogConfig = infraCfg.LogConfig
 
@@ -169,6 +170,15 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 		}
 	}
 
+	// Map preflight configuration
+	if infraCfg.Preflight != nil {
+		cfg.Preflight = &Preflight{
+			Disabled: infraCfg.Preflight.Disabled,
+			FailFast: infraCfg.Preflight.FailFast,
+			Timeout:  time.Duration(infraCfg.Preflight.Timeout) * time.Second,
+		}
+	}
+
 	// Map authentication configuration
 	cfg.ServiceAuth = make([]ServiceAuth, len(infraCfg.Auth))
 	if len(infraCfg.Auth) == 0 {
@@ -241,14 +251,23 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 		}
 
 		for dbName, db := range sqlServer.Databases {
+			var replicas []string
+			for _, replica := range db.ReadReplicas {
+				replicas = append(replicas, replica.Value())
+			}
+
 			cfg.SQLDatabases = append(cfg.SQLDatabases, &SQLDatabase{
-				ServerID:       i,
-				EncoreName:     orDefault(db.Name, dbName),
-				DatabaseName:   dbName,
-				User:           db.Username.Value(),
-				Password:       db.Password.Value(),
-				MinConnections: db.MinConnections,
-				MaxConnections: db.MaxConnections,
+				ServerID:         i,
+				EncoreName:       orDefault(db.Name, dbName),
+				DatabaseName:     dbName,
+				User:             db.Username.Value(),
+				Password:         db.Password.Value(),
+				MinConnections:   db.MinConnections,
+				MaxConnections:   db.MaxConnections,
+				MaxIdleTime:      time.Duration(db.MaxIdleTime) * time.Second,
+				MaxConnLifetime:  time.Duration(db.MaxConnLifetime) * time.Second,
+				StatementTimeout: time.Duration(db.StatementTimeout) * time.Second,
+				ReadReplicas:     replicas,
 			})
 		}
 	}
@@ -290,8 +309,20 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 		i++
 	}
 
+	// Map Memcached configuration
+	for name, mc := range infraCfg.Memcached {
+		cfg.Memcached = append(cfg.Memcached, &MemcachedCluster{
+			EncoreName:         name,
+			Servers:            mc.Servers,
+			KeyPrefix:          orDefaultPtr(mc.KeyPrefix, ""),
+			MaxIdleConnections: orDefaultPtr(mc.MaxIdleConnections, 0),
+			Timeout:            time.Duration(orDefaultPtr(mc.TimeoutMillis, 0)) * time.Millisecond,
+		})
+	}
+
 	// Map PubSub configuration
 	cfg.PubsubProviders = make([]*PubsubProvider, len(infraCfg.PubSub))
+	cfg.PubsubTopics = map[string]*PubsubTopic{}
 	for i, pubsub := range infraCfg.PubSub {
 		switch pubsub.Type {
 		case "gcp_pubsub":
@@ -308,8 +339,25 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 					Host: pubsub.NSQ.Hosts,
 				},
 			}
+		case "kafka":
+			kafka := &KafkaProvider{
+				Brokers: pubsub.Kafka.Brokers,
+			}
+			if sasl := pubsub.Kafka.SASL; sasl != nil {
+				kafka.SASLMechanism = sasl.Mechanism
+				kafka.SASLUser = sasl.Username.Value()
+				kafka.SASLPassword = sasl.Password.Value()
+			}
+			if tlsCfg := pubsub.Kafka.TLSConfig; tlsCfg != nil {
+				kafka.EnableTLS = true
+				kafka.ServerCACert = tlsCfg.CA
+				if tlsCfg.ClientCert != nil {
+					kafka.ClientCert = tlsCfg.ClientCert.Cert
+					kafka.ClientKey = tlsCfg.ClientCert.Key.Value()
+				}
+			}
+			cfg.PubsubProviders[i] = &PubsubProvider{Kafka: kafka}
 		}
-		cfg.PubsubTopics = map[string]*PubsubTopic{}
 		for topicName, topic := range pubsub.GetTopics() {
 			switch topic := topic.(type) {
 			case *infra.GCPTopic:
@@ -336,6 +384,13 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 					ProviderName:  topic.Name,
 					Subscriptions: map[string]*PubsubSubscription{},
 				}
+			case *infra.KafkaTopic:
+				cfg.PubsubTopics[topicName] = &PubsubTopic{
+					EncoreName:    topicName,
+					ProviderID:    i,
+					ProviderName:  topic.Name,
+					Subscriptions: map[string]*PubsubSubscription{},
+				}
 			}
 
 			for subName, subscription := range topic.GetSubscriptions() {
@@ -364,6 +419,12 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 						ProviderName: subscription.Name,
 						PushOnly:     false,
 					}
+				case *infra.KafkaSub:
+					cfg.PubsubTopics[topicName].Subscriptions[subName] = &PubsubSubscription{
+						EncoreName:   subName,
+						ProviderName: subscription.GroupID,
+						PushOnly:     false,
+					}
 				}
 			}
 		}
@@ -377,6 +438,7 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 			URL:         service.BaseURL,
 			Protocol:    Http,
 			ServiceAuth: cfg.ServiceAuth[0],
+			RegionURLs:  service.RegionURLs,
 		}
 	}
 
@@ -409,6 +471,9 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 				KeyPrefix:     bucket.KeyPrefix,
 				PublicBaseURL: bucket.PublicBaseURL,
 			}
+			if bucket.KMSKey != "" {
+				cfg.Buckets[bucketName].Encryption = &BucketEncryption{KMSKey: bucket.KMSKey}
+			}
 		}
 	}
 
@@ -459,7 +524,7 @@ func orDefault[T comparable](val T, def T) T {
 }
 
 // ParseRuntime parses the Encore runtime config.
-func ParseRuntime(runtimeConfig, runtimeConfigPath, processCfg, infraCfgPath, deployID string) *Runtime {
+func ParseRuntime(runtimeConfig, runtimeConfigPath, processCfg, infraCfgPath, deployID, region string) *Runtime {
 	var cfg *Runtime
 	if infraCfgPath != "" {
 		cfg = parseInfraConfigEnv(infraCfgPath)
@@ -482,9 +547,30 @@ func ParseRuntime(runtimeConfig, runtimeConfigPath, processCfg, infraCfgPath, de
 		cfg.DeployID = deployID
 	}
 
+	// The same image may be deployed to several regions,
+	// in which case the region is provided by the environment.
+	if region != "" {
+		cfg.EnvRegion = region
+	}
+	routeToRegion(cfg)
+
 	return cfg
 }
 
+// routeToRegion points service discovery at the services deployed
+// in the same region as the running app, where available.
+func routeToRegion(cfg *Runtime) {
+	if cfg.EnvRegion == "" {
+		return
+	}
+	for name, svc := range cfg.ServiceDiscovery {
+		if url, ok := svc.RegionURLs[cfg.EnvRegion]; ok {
+			svc.URL = url
+			cfg.ServiceDiscovery[name] = svc
+		}
+	}
+}
+
 // ParseStatic parses the Encore static config.
 func ParseStatic(config string) *Static {
 	if config == "" {
@@ -123,7 +123,7 @@ func TestGZippedContent(t *testing.T) {
 				}
 				procCfg = base64.StdEncoding.EncodeToString(rawData)
 			}
-			resp := ParseRuntime(cfgString, "", procCfg, "", "")
+			resp := ParseRuntime(cfgString, "", procCfg, "", "", "")
 			if !reflect.DeepEqual(resp, expected) {
 				t.Fatalf("expected %+v, got %+v", test.Config, resp)
 			}
@@ -149,3 +149,29 @@ func TestParseInfraConfigEnv(t *testing.T) {
 	// Compare the parsed runtime with the expected runtime
 	c.Assert(parsedRuntime, qt.DeepEquals, &expectedRuntime)
 }
+
+func TestRouteToRegion(t *testing.T) {
+	c := qt.New(t)
+
+	cfg := &Runtime{
+		EnvRegion: "eu-west-1",
+		ServiceDiscovery: map[string]Service{
+			"regional": {
+				Name: "regional",
+				URL:  "https://regional.example.com",
+				RegionURLs: map[string]string{
+					"eu-west-1": "https://eu.regional.example.com",
+					"us-east-1": "https://us.regional.example.com",
+				},
+			},
+			"global": {
+				Name: "global",
+				URL:  "https://global.example.com",
+			},
+		},
+	}
+	routeToRegion(cfg)
+
+	c.Assert(cfg.ServiceDiscovery["regional"].URL, qt.Equals, "https://eu.regional.example.com")
+	c.Assert(cfg.ServiceDiscovery["global"].URL, qt.Equals, "https://global.example.com")
+}
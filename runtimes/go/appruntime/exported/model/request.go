@@ -104,6 +104,12 @@ type RPCData struct {
 	UserID   UID
 	AuthData any
 
+	// AuthErr is the error returned by the auth handler if the request
+	// included authentication information that was rejected.
+	// It's only set for endpoints that don't require authentication,
+	// as the request is aborted otherwise.
+	AuthErr error
+
 	// Decoded request payload, for non-raw requests
 	TypedPayload any
 
@@ -124,6 +130,19 @@ type RPCData struct {
 
 	// Mocked is true if the request was handled by a mock.
 	Mocked bool
+
+	// Cron is the cron job execution simulated by et.RunCron, or nil.
+	// Executions triggered by the Encore Platform are identified
+	// by the request headers instead.
+	Cron *CronExecution
+}
+
+// CronExecution describes a cron job execution.
+type CronExecution struct {
+	// ID uniquely identifies the execution.
+	ID string
+	// Scheduled is the time the execution was scheduled for.
+	Scheduled time.Time
 }
 
 type PubSubMsgData struct {
@@ -136,6 +155,8 @@ type PubSubMsgData struct {
 	DecodedPayload any
 	// Payload is the JSON-encoded payload.
 	Payload []byte
+	// Replayed is whether the message was replayed by "encore pubsub replay".
+	Replayed bool
 }
 
 type TestData struct {
@@ -176,6 +197,8 @@ type TestConfig struct {
 	APIMocks         map[string]map[string]ApiMock
 	IsolatedServices *bool                // Whether to isolate services for this test
 	EndCallbacks     []func(t *testing.T) // Callbacks to run when the test ends
+	HTTPInterceptor  http.RoundTripper    // Intercepts outgoing HTTP requests made during this test
+	Secrets          map[string]string    // Secret values overridden for this test
 }
 
 type ServiceMock struct {
@@ -241,6 +264,7 @@ type APICall struct {
 	// Auth info for the target endpoint
 	UserID   UID
 	AuthData any
+	AuthErr  error // the auth handler's rejection of the source request's auth info, if any
 
 	StartEventID TraceEventID
 }
@@ -60,6 +60,12 @@ func ParseTraceID(str string) (TraceID, error) {
 	return traceID, err
 }
 
+func ParseSpanID(str string) (SpanID, error) {
+	var spanID SpanID
+	_, err := b32.Decode(spanID[:], []byte(str))
+	return spanID, err
+}
+
 // GenSpanID generates a span id.
 func GenSpanID() (SpanID, error) {
 	if GenerateConstantValsForTests {
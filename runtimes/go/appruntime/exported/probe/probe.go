@@ -0,0 +1,90 @@
+// Package probe runs synthetic monitoring checks, which make an HTTP
+// request to an endpoint and verify the response against a set of assertions.
+//
+// It's used both by the runtime, to run the checks declared with
+// monitor.NewCheck in deployed environments, and by the CLI during
+// local development.
+package probe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxBodySize is the maximum number of bytes of the response body
+// searched when checking ExpectBodyContains.
+const maxBodySize = 1 << 20
+
+// Spec describes a check to run.
+type Spec struct {
+	Method  string        // the HTTP method to use
+	URL     string        // the URL to request
+	Timeout time.Duration // the request timeout
+
+	ExpectStatus       int           // the expected status code
+	ExpectBodyContains string        // a string the response body must contain, if non-empty
+	MaxLatency         time.Duration // the maximum response time, if non-zero
+}
+
+// Result is the outcome of running a check.
+type Result struct {
+	Time    time.Time     // when the check started
+	OK      bool          // whether the response satisfied all the assertions
+	Status  int           // the response status code, or zero if the request failed
+	Latency time.Duration // the time taken to receive the response headers
+	Err     string        // the reason the check failed, if not OK
+}
+
+// Run runs the check described by spec using the given client.
+func Run(ctx context.Context, client *http.Client, spec Spec) Result {
+	res := Result{Time: time.Now()}
+
+	if spec.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, spec.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, spec.Method, spec.URL, nil)
+	if err != nil {
+		res.Err = err.Error()
+		return res
+	}
+	req.Header.Set("User-Agent", "Encore-Monitor/1.0")
+
+	resp, err := client.Do(req)
+	res.Latency = time.Since(res.Time)
+	if err != nil {
+		res.Err = err.Error()
+		return res
+	}
+	defer func() { _ = resp.Body.Close() }()
+	res.Status = resp.StatusCode
+
+	if resp.StatusCode != spec.ExpectStatus {
+		res.Err = fmt.Sprintf("got status %d, want %d", resp.StatusCode, spec.ExpectStatus)
+		return res
+	}
+	if spec.MaxLatency > 0 && res.Latency > spec.MaxLatency {
+		res.Err = fmt.Sprintf("response took %s, want at most %s", res.Latency.Round(time.Millisecond), spec.MaxLatency)
+		return res
+	}
+	if spec.ExpectBodyContains != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+		if err != nil {
+			res.Err = fmt.Sprintf("read response body: %v", err)
+			return res
+		}
+		if !bytes.Contains(body, []byte(spec.ExpectBodyContains)) {
+			res.Err = fmt.Sprintf("response body does not contain %q", spec.ExpectBodyContains)
+			return res
+		}
+	}
+
+	res.OK = true
+	return res
+}
@@ -0,0 +1,70 @@
+package probe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRun(t *testing.T) {
+	c := qt.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		case "/slow":
+			time.Sleep(50 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		spec    Spec
+		wantErr string // empty means the check should pass
+	}{
+		{
+			name: "ok",
+			spec: Spec{Method: "GET", URL: srv.URL + "/ok", ExpectStatus: 200, ExpectBodyContains: `"ok"`},
+		},
+		{
+			name:    "status",
+			spec:    Spec{Method: "GET", URL: srv.URL + "/missing", ExpectStatus: 200},
+			wantErr: "got status 404, want 200",
+		},
+		{
+			name:    "body",
+			spec:    Spec{Method: "GET", URL: srv.URL + "/ok", ExpectStatus: 200, ExpectBodyContains: "healthy"},
+			wantErr: `response body does not contain "healthy"`,
+		},
+		{
+			name:    "latency",
+			spec:    Spec{Method: "GET", URL: srv.URL + "/slow", ExpectStatus: 200, MaxLatency: time.Millisecond},
+			wantErr: "response took",
+		},
+		{
+			name:    "timeout",
+			spec:    Spec{Method: "GET", URL: srv.URL + "/slow", ExpectStatus: 200, Timeout: time.Millisecond},
+			wantErr: "context deadline exceeded",
+		},
+	}
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			res := Run(context.Background(), srv.Client(), tt.spec)
+			if tt.wantErr == "" {
+				c.Assert(res.OK, qt.IsTrue, qt.Commentf("err: %s", res.Err))
+				c.Assert(res.Status, qt.Equals, 200)
+			} else {
+				c.Assert(res.OK, qt.IsFalse)
+				c.Assert(strings.Contains(res.Err, tt.wantErr), qt.IsTrue, qt.Commentf("got err %q", res.Err))
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+// Package pubsubfilter implements the attribute filters subscriptions
+// can use to only receive a subset of the messages published to a topic.
+//
+// A filter is a list of conditions on message attributes joined with "&&".
+// Each condition is one of:
+//
+//	attr = "value"
+//	attr != "value"
+//	attr IN ("value1", "value2")
+//
+// A condition only matches messages which have the attribute set,
+// so a message matches a filter if it has all the referenced attributes
+// and satisfies all the conditions.
+package pubsubfilter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxConditions is the maximum number of conditions in a filter.
+// It matches the number of attributes supported by AWS SNS filter policies.
+const MaxConditions = 5
+
+// Op is a comparison operator in a filter condition.
+type Op int
+
+const (
+	Equal    Op = iota // attr = "value"
+	NotEqual           // attr != "value"
+	In                 // attr IN ("value1", "value2")
+)
+
+func (op Op) String() string {
+	switch op {
+	case Equal:
+		return "="
+	case NotEqual:
+		return "!="
+	case In:
+		return "IN"
+	default:
+		return fmt.Sprintf("Op(%d)", int(op))
+	}
+}
+
+// Cond is a single condition on a message attribute.
+type Cond struct {
+	Attr   string
+	Op     Op
+	Values []string // the values to compare against; exactly one unless Op is In
+}
+
+// Filter is a parsed subscription filter.
+type Filter struct {
+	Conds []Cond
+}
+
+// Match reports whether a message with the given attributes matches the filter.
+func (f *Filter) Match(attrs map[string]string) bool {
+	for _, c := range f.Conds {
+		val, ok := attrs[c.Attr]
+		if !ok {
+			return false
+		}
+		switch c.Op {
+		case Equal, In:
+			if !slices.Contains(c.Values, val) {
+				return false
+			}
+		case NotEqual:
+			if val == c.Values[0] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Attrs returns the attributes referenced by the filter.
+func (f *Filter) Attrs() []string {
+	attrs := make([]string, len(f.Conds))
+	for i, c := range f.Conds {
+		attrs[i] = c.Attr
+	}
+	return attrs
+}
+
+// String returns the filter in its canonical form.
+func (f *Filter) String() string {
+	conds := make([]string, len(f.Conds))
+	for i, c := range f.Conds {
+		switch c.Op {
+		case In:
+			vals := make([]string, len(c.Values))
+			for j, v := range c.Values {
+				vals[j] = strconv.Quote(v)
+			}
+			conds[i] = fmt.Sprintf("%s IN (%s)", c.Attr, strings.Join(vals, ", "))
+		default:
+			conds[i] = fmt.Sprintf("%s %s %s", c.Attr, c.Op, strconv.Quote(c.Values[0]))
+		}
+	}
+	return strings.Join(conds, " && ")
+}
+
+// GCPFilter returns the filter as a GCP Pub/Sub subscription filter expression.
+func (f *Filter) GCPFilter() string {
+	conds := make([]string, len(f.Conds))
+	for i, c := range f.Conds {
+		key := c.Attr
+		if !gcpSimpleKey.MatchString(key) {
+			key = strconv.Quote(key)
+		}
+
+		switch c.Op {
+		case Equal:
+			conds[i] = fmt.Sprintf("attributes.%s = %s", key, strconv.Quote(c.Values[0]))
+		case NotEqual:
+			// GCP matches messages without the attribute, so require it explicitly.
+			conds[i] = fmt.Sprintf("attributes:%s AND attributes.%s != %s", key, key, strconv.Quote(c.Values[0]))
+		case In:
+			alts := make([]string, len(c.Values))
+			for j, v := range c.Values {
+				alts[j] = fmt.Sprintf("attributes.%s = %s", key, strconv.Quote(v))
+			}
+			conds[i] = "(" + strings.Join(alts, " OR ") + ")"
+		}
+	}
+	return strings.Join(conds, " AND ")
+}
+
+var gcpSimpleKey = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// SNSFilterPolicy returns the filter as an AWS SNS subscription filter policy,
+// to be marshalled as JSON.
+func (f *Filter) SNSFilterPolicy() map[string][]any {
+	policy := make(map[string][]any, len(f.Conds))
+	for _, c := range f.Conds {
+		switch c.Op {
+		case Equal, In:
+			vals := make([]any, len(c.Values))
+			for i, v := range c.Values {
+				vals[i] = v
+			}
+			policy[c.Attr] = vals
+		case NotEqual:
+			policy[c.Attr] = []any{map[string]any{"anything-but": c.Values[0]}}
+		}
+	}
+	return policy
+}
+
+// Error is an error parsing a filter.
+type Error struct {
+	Offset int // the byte offset in the filter where the error occurred
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid filter at offset %d: %s", e.Offset, e.Msg)
+}
+
+// Parse parses a filter expression.
+// If it returns an error, it is of type *Error.
+func Parse(expr string) (*Filter, error) {
+	p := &parser{src: expr}
+	f := &Filter{}
+	seen := make(map[string]bool)
+
+	for {
+		start := p.skipSpace()
+		c, err := p.parseCond()
+		if err != nil {
+			return nil, err
+		}
+		if seen[c.Attr] {
+			return nil, &Error{Offset: start, Msg: fmt.Sprintf("attribute %q is used in multiple conditions", c.Attr)}
+		}
+		seen[c.Attr] = true
+		f.Conds = append(f.Conds, c)
+
+		if p.skipSpace() == len(p.src) {
+			break
+		} else if !p.consume("&&") {
+			return nil, p.errorf("expected \"&&\"")
+		}
+	}
+
+	if len(f.Conds) > MaxConditions {
+		return nil, &Error{Offset: 0, Msg: fmt.Sprintf("filters can have at most %d conditions, got %d", MaxConditions, len(f.Conds))}
+	}
+	return f, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) parseCond() (Cond, error) {
+	attr, err := p.parseAttr()
+	if err != nil {
+		return Cond{}, err
+	}
+
+	p.skipSpace()
+	switch {
+	case p.consume("!="):
+		val, err := p.parseString()
+		if err != nil {
+			return Cond{}, err
+		}
+		return Cond{Attr: attr, Op: NotEqual, Values: []string{val}}, nil
+
+	case p.consume("="):
+		val, err := p.parseString()
+		if err != nil {
+			return Cond{}, err
+		}
+		return Cond{Attr: attr, Op: Equal, Values: []string{val}}, nil
+
+	case p.consumeKeyword("IN"):
+		p.skipSpace()
+		if !p.consume("(") {
+			return Cond{}, p.errorf("expected \"(\"")
+		}
+		var vals []string
+		for {
+			val, err := p.parseString()
+			if err != nil {
+				return Cond{}, err
+			}
+			vals = append(vals, val)
+
+			p.skipSpace()
+			if p.consume(")") {
+				break
+			} else if !p.consume(",") {
+				return Cond{}, p.errorf("expected \",\" or \")\"")
+			}
+		}
+		return Cond{Attr: attr, Op: In, Values: vals}, nil
+
+	default:
+		return Cond{}, p.errorf("expected \"=\", \"!=\", or \"IN\"")
+	}
+}
+
+func (p *parser) parseAttr() (string, error) {
+	start := p.pos
+	for p.pos < len(p.src) && isAttrChar(p.src[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", p.errorf("expected attribute name")
+	}
+	return p.src[start:p.pos], nil
+}
+
+func (p *parser) parseString() (string, error) {
+	p.skipSpace()
+	start := p.pos
+	if p.pos >= len(p.src) || p.src[p.pos] != '"' {
+		return "", p.errorf("expected quoted string")
+	}
+
+	// Find the closing quote, skipping escaped characters.
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			val, err := strconv.Unquote(p.src[start:p.pos])
+			if err != nil {
+				return "", &Error{Offset: start, Msg: "invalid quoted string"}
+			}
+			return val, nil
+		}
+	}
+	return "", &Error{Offset: start, Msg: "unterminated string"}
+}
+
+// skipSpace skips whitespace and returns the new position.
+func (p *parser) skipSpace() int {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n') {
+		p.pos++
+	}
+	return p.pos
+}
+
+// consume consumes tok if it's next in the input.
+func (p *parser) consume(tok string) bool {
+	if strings.HasPrefix(p.src[p.pos:], tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+// consumeKeyword is like consume, but requires the keyword
+// to not be followed by an attribute name character.
+func (p *parser) consumeKeyword(kw string) bool {
+	end := p.pos + len(kw)
+	if strings.HasPrefix(p.src[p.pos:], kw) && (end == len(p.src) || !isAttrChar(p.src[end])) {
+		p.pos = end
+		return true
+	}
+	return false
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	if p.pos >= len(p.src) {
+		msg += ", got end of filter"
+	}
+	return &Error{Offset: p.pos, Msg: msg}
+}
+
+func isAttrChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.'
+}
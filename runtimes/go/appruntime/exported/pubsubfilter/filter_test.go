@@ -0,0 +1,77 @@
+package pubsubfilter
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    string // canonical form
+		wantErr string
+	}{
+		{expr: `region = "eu"`, want: `region = "eu"`},
+		{expr: `region="eu"&&kind!="test"`, want: `region = "eu" && kind != "test"`},
+		{expr: ` region IN ("eu", "us")  && x-tenant = "a\"b" `, want: `region IN ("eu", "us") && x-tenant = "a\"b"`},
+		{expr: ``, wantErr: `invalid filter at offset 0: expected attribute name, got end of filter`},
+		{expr: `region == "eu"`, wantErr: `invalid filter at offset 8: expected quoted string`},
+		{expr: `region = eu`, wantErr: `invalid filter at offset 9: expected quoted string`},
+		{expr: `region = "eu`, wantErr: `invalid filter at offset 9: unterminated string`},
+		{expr: `region = "eu" || kind = "a"`, wantErr: `invalid filter at offset 14: expected "&&"`},
+		{expr: `region INSIDE ("eu")`, wantErr: `invalid filter at offset 7: expected "=", "!=", or "IN"`},
+		{expr: `region IN ("eu" "us")`, wantErr: `invalid filter at offset 16: expected "," or ")"`},
+		{expr: `region = "eu" && region = "us"`, wantErr: `invalid filter at offset 17: attribute "region" is used in multiple conditions`},
+		{expr: `a = "1" && b = "1" && c = "1" && d = "1" && e = "1" && f = "1"`, wantErr: `invalid filter at offset 0: filters can have at most 5 conditions, got 6`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c := qt.New(t)
+			f, err := Parse(tt.expr)
+			if tt.wantErr != "" {
+				c.Assert(err, qt.IsNotNil)
+				c.Assert(err.Error(), qt.Equals, tt.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(f.String(), qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	f, err := Parse(`region IN ("eu", "us") && kind != "test"`)
+	qt.Assert(t, err, qt.IsNil)
+
+	tests := []struct {
+		attrs map[string]string
+		want  bool
+	}{
+		{map[string]string{"region": "eu", "kind": "prod"}, true},
+		{map[string]string{"region": "us", "kind": "prod", "other": "x"}, true},
+		{map[string]string{"region": "asia", "kind": "prod"}, false},
+		{map[string]string{"region": "eu", "kind": "test"}, false},
+		{map[string]string{"region": "eu"}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		qt.Assert(t, f.Match(tt.attrs), qt.Equals, tt.want, qt.Commentf("attrs: %v", tt.attrs))
+	}
+}
+
+func TestFilter_Provider(t *testing.T) {
+	c := qt.New(t)
+	f, err := Parse(`region IN ("eu", "us") && kind != "test" && x-tenant = "acme"`)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(f.GCPFilter(), qt.Equals,
+		`(attributes.region = "eu" OR attributes.region = "us") AND attributes:kind AND attributes.kind != "test" AND attributes."x-tenant" = "acme"`)
+
+	policy, err := json.Marshal(f.SNSFilterPolicy())
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(policy), qt.Equals,
+		`{"kind":[{"anything-but":"test"}],"region":["eu","us"],"x-tenant":["acme"]}`)
+}
@@ -16,40 +16,48 @@ import (
 type EventType byte
 
 const (
-	RequestSpanStart          EventType = 0x01
-	RequestSpanEnd            EventType = 0x02
-	AuthSpanStart             EventType = 0x03
-	AuthSpanEnd               EventType = 0x04
-	PubsubMessageSpanStart    EventType = 0x05
-	PubsubMessageSpanEnd      EventType = 0x06
-	DBTransactionStart        EventType = 0x07
-	DBTransactionEnd          EventType = 0x08
-	DBQueryStart              EventType = 0x09
-	DBQueryEnd                EventType = 0x0A
-	RPCCallStart              EventType = 0x0B
-	RPCCallEnd                EventType = 0x0C
-	HTTPCallStart             EventType = 0x0D
-	HTTPCallEnd               EventType = 0x0E
-	LogMessage                EventType = 0x0F
-	PubsubPublishStart        EventType = 0x10
-	PubsubPublishEnd          EventType = 0x11
-	ServiceInitStart          EventType = 0x12
-	ServiceInitEnd            EventType = 0x13
-	CacheCallStart            EventType = 0x14
-	CacheCallEnd              EventType = 0x15
-	BodyStream                EventType = 0x16
-	TestStart                 EventType = 0x17
-	TestEnd                   EventType = 0x18
-	BucketObjectUploadStart   EventType = 0x19
-	BucketObjectUploadEnd     EventType = 0x1A
-	BucketObjectDownloadStart EventType = 0x1B
-	BucketObjectDownloadEnd   EventType = 0x1C
-	BucketObjectGetAttrsStart EventType = 0x1D
-	BucketObjectGetAttrsEnd   EventType = 0x1E
-	BucketListObjectsStart    EventType = 0x1F
-	BucketListObjectsEnd      EventType = 0x20
-	BucketDeleteObjectsStart  EventType = 0x21
-	BucketDeleteObjectsEnd    EventType = 0x22
+	RequestSpanStart             EventType = 0x01
+	RequestSpanEnd               EventType = 0x02
+	AuthSpanStart                EventType = 0x03
+	AuthSpanEnd                  EventType = 0x04
+	PubsubMessageSpanStart       EventType = 0x05
+	PubsubMessageSpanEnd         EventType = 0x06
+	DBTransactionStart           EventType = 0x07
+	DBTransactionEnd             EventType = 0x08
+	DBQueryStart                 EventType = 0x09
+	DBQueryEnd                   EventType = 0x0A
+	RPCCallStart                 EventType = 0x0B
+	RPCCallEnd                   EventType = 0x0C
+	HTTPCallStart                EventType = 0x0D
+	HTTPCallEnd                  EventType = 0x0E
+	LogMessage                   EventType = 0x0F
+	PubsubPublishStart           EventType = 0x10
+	PubsubPublishEnd             EventType = 0x11
+	ServiceInitStart             EventType = 0x12
+	ServiceInitEnd               EventType = 0x13
+	CacheCallStart               EventType = 0x14
+	CacheCallEnd                 EventType = 0x15
+	BodyStream                   EventType = 0x16
+	TestStart                    EventType = 0x17
+	TestEnd                      EventType = 0x18
+	BucketObjectUploadStart      EventType = 0x19
+	BucketObjectUploadEnd        EventType = 0x1A
+	BucketObjectDownloadStart    EventType = 0x1B
+	BucketObjectDownloadEnd      EventType = 0x1C
+	BucketObjectGetAttrsStart    EventType = 0x1D
+	BucketObjectGetAttrsEnd      EventType = 0x1E
+	BucketListObjectsStart       EventType = 0x1F
+	BucketListObjectsEnd         EventType = 0x20
+	BucketDeleteObjectsStart     EventType = 0x21
+	BucketDeleteObjectsEnd       EventType = 0x22
+	BucketSignedURL              EventType = 0x23
+	BucketObjectCopyStart        EventType = 0x24
+	BucketObjectCopyEnd          EventType = 0x25
+	BucketObjectUploadPart       EventType = 0x26
+	PubsubMessageLink            EventType = 0x27
+	StreamMessage                EventType = 0x28
+	BucketObjectUpdateAttrsStart EventType = 0x29
+	BucketObjectUpdateAttrsEnd   EventType = 0x2A
 )
 
 func (te EventType) String() string {
@@ -122,6 +130,22 @@ func (te EventType) String() string {
 		return "BucketDeleteObjectsStart"
 	case BucketDeleteObjectsEnd:
 		return "BucketDeleteObjectsEnd"
+	case BucketSignedURL:
+		return "BucketSignedURL"
+	case BucketObjectCopyStart:
+		return "BucketObjectCopyStart"
+	case BucketObjectCopyEnd:
+		return "BucketObjectCopyEnd"
+	case BucketObjectUploadPart:
+		return "BucketObjectUploadPart"
+	case PubsubMessageLink:
+		return "PubsubMessageLink"
+	case StreamMessage:
+		return "StreamMessage"
+	case BucketObjectUpdateAttrsStart:
+		return "BucketObjectUpdateAttrsStart"
+	case BucketObjectUpdateAttrsEnd:
+		return "BucketObjectUpdateAttrsEnd"
 
 	default:
 		return fmt.Sprintf("Unknown(%x)", byte(te))
@@ -136,6 +160,7 @@ type EventParams struct {
 }
 
 type spanStartEventData struct {
+	SpanID           model.SpanID
 	Goid             uint32
 	ParentTraceID    model.TraceID
 	ParentSpanID     model.SpanID
@@ -147,17 +172,25 @@ type spanStartEventData struct {
 }
 
 func (l *Log) newSpanStartEvent(data spanStartEventData) EventBuffer {
-	tb := NewEventBuffer(4 + 16 + 8 + 4 + len(data.ExtCorrelationID) + 2 + data.ExtraSpace)
+	l.beginMemStats(data.SpanID)
+	var region string
+	if l != nil {
+		region = l.region
+	}
+
+	tb := NewEventBuffer(4 + 16 + 8 + 4 + len(data.ExtCorrelationID) + len(region) + 4 + data.ExtraSpace)
 	tb.UVarint(uint64(data.Goid))
 	tb.Bytes(data.ParentTraceID[:])
 	tb.Bytes(data.ParentSpanID[:])
 	tb.UVarint(uint64(data.DefLoc))
 	tb.UVarint(uint64(data.CallerEventID))
 	tb.String(data.ExtCorrelationID)
+	tb.String(region)
 	return tb
 }
 
 type spanEndEventData struct {
+	SpanID        model.SpanID
 	Duration      time.Duration
 	Err           error
 	ParentTraceID model.TraceID
@@ -177,6 +210,14 @@ func (l *Log) newSpanEndEvent(data spanEndEventData) EventBuffer {
 
 	tb.Bytes(data.ParentTraceID[:])
 	tb.Bytes(data.ParentSpanID[:])
+
+	mem := l.endMemStats(data.SpanID)
+	tb.Bool(mem != nil)
+	if mem != nil {
+		tb.UVarint(mem.AllocBytes)
+		tb.UVarint(mem.AllocObjects)
+		tb.UVarint(mem.HeapObjects)
+	}
 	return tb
 }
 
@@ -198,6 +239,7 @@ func (l *Log) RequestSpanStart(req *model.Request, goid uint32) {
 	data := req.RPCData
 	desc := data.Desc
 	tb := l.newSpanStartEvent(spanStartEventData{
+		SpanID:           req.SpanID,
 		ParentTraceID:    req.ParentTraceID,
 		ParentSpanID:     req.ParentSpanID,
 		DefLoc:           req.DefLoc,
@@ -240,6 +282,7 @@ type RequestSpanEndParams struct {
 func (l *Log) RequestSpanEnd(p RequestSpanEndParams) {
 	desc := p.Req.RPCData.Desc
 	tb := l.newSpanEndEvent(spanEndEventData{
+		SpanID:        p.Req.SpanID,
 		Duration:      p.Resp.Duration,
 		Err:           p.Resp.Err,
 		ParentTraceID: p.Req.ParentTraceID,
@@ -266,6 +309,7 @@ func (l *Log) AuthSpanStart(req *model.Request, goid uint32) {
 	data := req.RPCData
 	desc := data.Desc
 	tb := l.newSpanStartEvent(spanStartEventData{
+		SpanID:           req.SpanID,
 		ParentTraceID:    req.ParentTraceID,
 		ParentSpanID:     req.ParentSpanID,
 		DefLoc:           req.DefLoc,
@@ -296,6 +340,7 @@ type AuthSpanEndParams struct {
 func (l *Log) AuthSpanEnd(p AuthSpanEndParams) {
 	desc := p.Req.RPCData.Desc
 	tb := l.newSpanEndEvent(spanEndEventData{
+		SpanID:        p.Req.SpanID,
 		Duration:      p.Resp.Duration,
 		Err:           p.Resp.Err,
 		ParentTraceID: p.Req.ParentTraceID,
@@ -319,13 +364,14 @@ func (l *Log) AuthSpanEnd(p AuthSpanEndParams) {
 func (l *Log) PubsubMessageSpanStart(req *model.Request, goid uint32) {
 	data := req.MsgData
 	tb := l.newSpanStartEvent(spanStartEventData{
+		SpanID:           req.SpanID,
 		ParentTraceID:    req.ParentTraceID,
 		ParentSpanID:     req.ParentSpanID,
 		DefLoc:           req.DefLoc,
 		Goid:             goid,
 		CallerEventID:    req.CallerEventID,
 		ExtCorrelationID: req.ExtCorrelationID,
-		ExtraSpace:       len(data.Service) + len(data.Topic) + len(data.Subscription) + len(data.Payload) + 20,
+		ExtraSpace:       len(data.Service) + len(data.Topic) + len(data.Subscription) + len(data.Payload) + 21,
 	})
 
 	tb.String(data.Service)
@@ -335,6 +381,7 @@ func (l *Log) PubsubMessageSpanStart(req *model.Request, goid uint32) {
 	tb.UVarint(uint64(data.Attempt))
 	tb.Time(data.Published)
 	tb.ByteString(data.Payload)
+	tb.Bool(data.Replayed)
 
 	l.Add(Event{
 		Type:    PubsubMessageSpanStart,
@@ -353,6 +400,7 @@ type PubsubMessageSpanEndParams struct {
 func (l *Log) PubsubMessageSpanEnd(p PubsubMessageSpanEndParams) {
 	msg := p.Req.MsgData
 	tb := l.newSpanEndEvent(spanEndEventData{
+		SpanID:        p.Req.SpanID,
 		Duration:      p.Resp.Duration,
 		Err:           p.Resp.Err,
 		ParentTraceID: p.Req.ParentTraceID,
@@ -375,6 +423,7 @@ func (l *Log) PubsubMessageSpanEnd(p PubsubMessageSpanEndParams) {
 func (l *Log) TestSpanStart(req *model.Request, goid uint32) {
 	data := req.Test
 	tb := l.newSpanStartEvent(spanStartEventData{
+		SpanID:           req.SpanID,
 		ParentTraceID:    req.ParentTraceID,
 		ParentSpanID:     req.ParentSpanID,
 		DefLoc:           req.DefLoc,
@@ -412,6 +461,7 @@ func (l *Log) TestSpanEnd(p TestSpanEndParams) {
 		err = errors.New("test failed")
 	}
 	tb := l.newSpanEndEvent(spanEndEventData{
+		SpanID:        p.Req.SpanID,
 		Duration:      time.Since(p.Req.Start),
 		Err:           err,
 		ParentTraceID: p.Req.ParentTraceID,
@@ -473,6 +523,10 @@ type DBQueryStartParams struct {
 	TxStartID EventID // zero if not in a transaction
 	Stack     stack.Stack
 	Query     string
+	StmtName  string // name of the prepared statement, if any
+	Node      string // node serving the query, for read-only database handles
+	Rows      uint64 // number of rows copied, for COPY queries
+	Attempt   int    // transaction attempt number, for queries in transactions
 }
 
 func (l *Log) DBQueryStart(p DBQueryStartParams) EventID {
@@ -484,6 +538,10 @@ func (l *Log) DBQueryStart(p DBQueryStartParams) EventID {
 
 	tb.String(p.Query)
 	tb.Stack(p.Stack)
+	tb.String(p.StmtName)
+	tb.String(p.Node)
+	tb.UVarint(p.Rows)
+	tb.UVarint(uint64(p.Attempt))
 
 	return l.Add(Event{
 		Type:    DBQueryStart,
@@ -530,6 +588,10 @@ type DBTransactionEndParams struct {
 	Commit  bool
 	Err     error
 	Stack   stack.Stack
+
+	// Retries is the number of times the transaction was retried before this attempt,
+	// when run with sqldb.RunInTx.
+	Retries int
 }
 
 func (l *Log) DBTransactionEnd(p DBTransactionEndParams) {
@@ -542,6 +604,7 @@ func (l *Log) DBTransactionEnd(p DBTransactionEndParams) {
 	tb.Bool(p.Commit)
 	tb.Stack(p.Stack)
 	tb.ErrWithStack(p.Err)
+	tb.UVarint(uint64(p.Retries))
 
 	l.Add(Event{
 		Type:    DBTransactionEnd,
@@ -556,6 +619,9 @@ type PubsubPublishStartParams struct {
 	Topic   string
 	Message []byte
 	Stack   stack.Stack
+
+	// Batch holds the messages when publishing a batch of messages, in which case Message is empty.
+	Batch [][]byte
 }
 
 func (l *Log) PubsubPublishStart(p PubsubPublishStartParams) EventID {
@@ -567,6 +633,10 @@ func (l *Log) PubsubPublishStart(p PubsubPublishStartParams) EventID {
 	tb.String(p.Topic)
 	tb.ByteString(p.Message)
 	tb.Stack(p.Stack)
+	tb.UVarint(uint64(len(p.Batch)))
+	for _, msg := range p.Batch {
+		tb.ByteString(msg)
+	}
 
 	return l.Add(Event{
 		Type:    PubsubPublishStart,
@@ -581,6 +651,15 @@ type PubsubPublishEndParams struct {
 	StartID   EventID
 	MessageID string
 	Err       error
+
+	// BatchResults holds the result for each message when publishing a batch of messages.
+	BatchResults []PubsubPublishResult
+}
+
+// PubsubPublishResult is the result of publishing a single message in a batch.
+type PubsubPublishResult struct {
+	MessageID string
+	Err       error
 }
 
 func (l *Log) PubsubPublishEnd(p PubsubPublishEndParams) {
@@ -592,6 +671,11 @@ func (l *Log) PubsubPublishEnd(p PubsubPublishEndParams) {
 
 	tb.String(p.MessageID)
 	tb.ErrWithStack(p.Err)
+	tb.UVarint(uint64(len(p.BatchResults)))
+	for _, res := range p.BatchResults {
+		tb.String(res.MessageID)
+		tb.Err(res.Err)
+	}
 
 	l.Add(Event{
 		Type:    PubsubPublishEnd,
@@ -601,6 +685,70 @@ func (l *Log) PubsubPublishEnd(p PubsubPublishEndParams) {
 	})
 }
 
+type PubsubMessageLinkParams struct {
+	EventParams
+
+	// LinkedTraceID and LinkedSpanID identify the span
+	// that published the message being processed.
+	LinkedTraceID model.TraceID
+	LinkedSpanID  model.SpanID
+
+	App string // the app that published the message
+	Env string // the environment the message was published from
+}
+
+// PubsubMessageLink links a message processing span to the span that
+// published the message, when the message was published by another app.
+func (l *Log) PubsubMessageLink(p PubsubMessageLinkParams) {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: 16 + 8 + len(p.App) + len(p.Env) + 4,
+	})
+
+	tb.Bytes(p.LinkedTraceID[:])
+	tb.Bytes(p.LinkedSpanID[:])
+	tb.String(p.App)
+	tb.String(p.Env)
+
+	l.Add(Event{
+		Type:    PubsubMessageLink,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type StreamMessageParams struct {
+	EventParams
+
+	Outgoing bool   // whether the message was sent to the client
+	Seq      uint64 // the position of the message in the stream in its direction, starting at 1
+	Size     int    // the size of the encoded message, in bytes
+}
+
+// StreamMessage records a message sent or received on a streaming endpoint.
+func (l *Log) StreamMessage(p StreamMessageParams) {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: 1 + 10 + 10,
+	})
+
+	if p.Outgoing {
+		tb.Byte(1)
+	} else {
+		tb.Byte(0)
+	}
+	tb.UVarint(p.Seq)
+	tb.UVarint(uint64(p.Size))
+
+	l.Add(Event{
+		Type:    StreamMessage,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
 type ServiceInitStartParams struct {
 	EventParams
 	Service string
@@ -674,6 +822,22 @@ type CacheCallEndParams struct {
 	StartID EventID
 	Res     CacheCallResult
 	Err     error
+
+	// OpResults holds the result for each operation when executing a pipeline of operations.
+	OpResults []CacheOpResult
+
+	// IODuration is the time spent waiting on the cache server,
+	// and CodecDuration the time spent encoding and decoding values.
+	IODuration    time.Duration
+	CodecDuration time.Duration
+}
+
+// CacheOpResult is the result of a single operation in a pipeline.
+type CacheOpResult struct {
+	Operation string
+	Key       string
+	Res       CacheCallResult
+	Err       error
 }
 
 func (l *Log) CacheCallEnd(p CacheCallEndParams) {
@@ -685,6 +849,15 @@ func (l *Log) CacheCallEnd(p CacheCallEndParams) {
 
 	tb.Byte(byte(p.Res))
 	tb.ErrWithStack(p.Err)
+	tb.UVarint(uint64(len(p.OpResults)))
+	for _, res := range p.OpResults {
+		tb.String(res.Operation)
+		tb.String(res.Key)
+		tb.Byte(byte(res.Res))
+		tb.Err(res.Err)
+	}
+	tb.Duration(p.IODuration)
+	tb.Duration(p.CodecDuration)
 
 	l.Add(Event{
 		Type:    CacheCallEnd,
@@ -811,12 +984,47 @@ func (l *Log) BucketObjectUploadEnd(p BucketObjectUploadEndParams) {
 	})
 }
 
+type BucketObjectUploadPartParams struct {
+	EventParams
+	StartID EventID // the upload the part belongs to
+
+	PartNumber uint32
+	Size       uint64
+	Attempts   uint32
+	Err        error
+}
+
+func (l *Log) BucketObjectUploadPart(p BucketObjectUploadPartParams) {
+	tb := l.newEvent(eventData{
+		Common:             p.EventParams,
+		CorrelationEventID: p.StartID,
+		ExtraSpace:         32,
+	})
+
+	tb.UVarint(uint64(p.PartNumber))
+	tb.UVarint(p.Size)
+	tb.UVarint(uint64(p.Attempts))
+	tb.ErrWithStack(p.Err)
+
+	l.Add(Event{
+		Type:    BucketObjectUploadPart,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
 type BucketObjectDownloadStartParams struct {
 	EventParams
 	Bucket  string
 	Object  string
 	Version *string
 	Stack   stack.Stack
+
+	// Offset and Length describe the byte range being downloaded.
+	// Length is nil if the rest of the object is downloaded.
+	Offset uint64
+	Length *uint64
 }
 
 func (l *Log) BucketObjectDownloadStart(p BucketObjectDownloadStartParams) EventID {
@@ -829,6 +1037,8 @@ func (l *Log) BucketObjectDownloadStart(p BucketObjectDownloadStartParams) Event
 	tb.String(p.Object)
 	tb.OptString(p.Version)
 	tb.Stack(p.Stack)
+	tb.UVarint(p.Offset)
+	tb.OptUVarint(p.Length)
 
 	return l.Add(Event{
 		Type:    BucketObjectDownloadStart,
@@ -843,19 +1053,24 @@ type BucketObjectDownloadEndParams struct {
 	StartID EventID
 
 	Err error
-	// Set iff err == nil
+	// Size is the number of bytes downloaded.
 	Size uint64
+
+	// Resumes is the number of times the download was resumed
+	// after a transient failure.
+	Resumes uint32
 }
 
 func (l *Log) BucketObjectDownloadEnd(p BucketObjectDownloadEndParams) {
 	tb := l.newEvent(eventData{
 		Common:             p.EventParams,
 		CorrelationEventID: p.StartID,
-		ExtraSpace:         4 + 4 + 8,
+		ExtraSpace:         4 + 4 + 8 + 4,
 	})
 
 	tb.UVarint(p.Size)
 	tb.ErrWithStack(p.Err)
+	tb.UVarint(uint64(p.Resumes))
 
 	l.Add(Event{
 		Type:    BucketObjectDownloadEnd,
@@ -921,6 +1136,72 @@ func (l *Log) BucketObjectGetAttrsEnd(p BucketObjectGetAttrsEndParams) {
 	})
 }
 
+type BucketObjectUpdateAttrsStartParams struct {
+	EventParams
+	Bucket      string
+	Object      string
+	ContentType *string
+	// Nil if not updated
+	MetadataKeys []string
+	TagKeys      []string
+	Stack        stack.Stack
+}
+
+func (l *Log) BucketObjectUpdateAttrsStart(p BucketObjectUpdateAttrsStartParams) EventID {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: 64,
+	})
+
+	tb.String(p.Bucket)
+	tb.String(p.Object)
+	tb.OptString(p.ContentType)
+	for _, keys := range [][]string{p.MetadataKeys, p.TagKeys} {
+		tb.Bool(keys != nil)
+		tb.UVarint(uint64(len(keys)))
+		for _, k := range keys {
+			tb.String(k)
+		}
+	}
+	tb.Stack(p.Stack)
+
+	return l.Add(Event{
+		Type:    BucketObjectUpdateAttrsStart,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type BucketObjectUpdateAttrsEndParams struct {
+	EventParams
+	StartID EventID
+
+	Err error
+	// Set iff err == nil
+	Attrs *BucketObjectAttributes
+}
+
+func (l *Log) BucketObjectUpdateAttrsEnd(p BucketObjectUpdateAttrsEndParams) {
+	tb := l.newEvent(eventData{
+		Common:             p.EventParams,
+		CorrelationEventID: p.StartID,
+		ExtraSpace:         4 + 4 + 8,
+	})
+
+	tb.ErrWithStack(p.Err)
+	if p.Err == nil {
+		tb.bucketObjectAttrs(p.Attrs)
+	}
+
+	l.Add(Event{
+		Type:    BucketObjectUpdateAttrsEnd,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
 type BucketListObjectsStartParams struct {
 	EventParams
 	Bucket string
@@ -1033,6 +1314,105 @@ func (l *Log) BucketDeleteObjectsEnd(p BucketDeleteObjectsEndParams) {
 	})
 }
 
+// BucketSignedURLOperation is the operation a signed URL grants access to.
+type BucketSignedURLOperation byte
+
+const (
+	BucketSignedUpload   BucketSignedURLOperation = 0x00
+	BucketSignedDownload BucketSignedURLOperation = 0x01
+)
+
+type BucketSignedURLParams struct {
+	EventParams
+	Bucket      string
+	Object      string
+	Operation   BucketSignedURLOperation
+	TTL         time.Duration
+	ContentType *string
+	Err         error
+	Stack       stack.Stack
+}
+
+func (l *Log) BucketSignedURL(p BucketSignedURLParams) {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: 64,
+	})
+
+	tb.String(p.Bucket)
+	tb.String(p.Object)
+	tb.Byte(byte(p.Operation))
+	tb.Duration(p.TTL)
+	tb.OptString(p.ContentType)
+	tb.ErrWithStack(p.Err)
+	tb.Stack(p.Stack)
+
+	l.Add(Event{
+		Type:    BucketSignedURL,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type BucketObjectCopyStartParams struct {
+	EventParams
+	Bucket     string
+	Src        string
+	Dst        string
+	SrcVersion *string
+	Stack      stack.Stack
+}
+
+func (l *Log) BucketObjectCopyStart(p BucketObjectCopyStartParams) EventID {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: 64,
+	})
+
+	tb.String(p.Bucket)
+	tb.String(p.Src)
+	tb.String(p.Dst)
+	tb.OptString(p.SrcVersion)
+	tb.Stack(p.Stack)
+
+	return l.Add(Event{
+		Type:    BucketObjectCopyStart,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type BucketObjectCopyEndParams struct {
+	EventParams
+	StartID EventID
+
+	Err error
+	// Set iff err == nil
+	Size    uint64
+	Version *string
+}
+
+func (l *Log) BucketObjectCopyEnd(p BucketObjectCopyEndParams) {
+	tb := l.newEvent(eventData{
+		Common:             p.EventParams,
+		CorrelationEventID: p.StartID,
+		ExtraSpace:         64,
+	})
+
+	tb.UVarint(p.Size)
+	tb.OptString(p.Version)
+	tb.ErrWithStack(p.Err)
+
+	l.Add(Event{
+		Type:    BucketObjectCopyEnd,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
 func (l *Log) logHeaders(tb *EventBuffer, headers http.Header) {
 	tb.UVarint(uint64(len(headers)))
 	for k, v := range headers {
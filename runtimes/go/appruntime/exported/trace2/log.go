@@ -28,6 +28,19 @@ type Log struct {
 	data []byte
 	done bool
 	cond *sync.Cond
+
+	// memStarts are the allocation counters at the start of the spans in progress,
+	// if the log records memory stats. See RecordMemStats.
+	memStarts map[model.SpanID]MemStats
+
+	// region is the cloud region recorded in span start events. See SetRegion.
+	region string
+}
+
+// SetRegion makes l record the given cloud region in span start events.
+// It must be called before any events are added to l.
+func (l *Log) SetRegion(region string) {
+	l.region = region
 }
 
 // Ensure Log implements Logger.
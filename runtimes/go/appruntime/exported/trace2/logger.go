@@ -36,6 +36,7 @@ type Logger interface {
 	DBTransactionEnd(DBTransactionEndParams)
 	PubsubPublishStart(PubsubPublishStartParams) EventID
 	PubsubPublishEnd(PubsubPublishEndParams)
+	PubsubMessageLink(PubsubMessageLinkParams)
 	ServiceInitStart(ServiceInitStartParams) EventID
 	ServiceInitEnd(EventParams, EventID, error)
 	CacheCallStart(CacheCallStartParams) EventID
@@ -47,6 +48,7 @@ type Logger interface {
 
 	BucketObjectUploadStart(BucketObjectUploadStartParams) EventID
 	BucketObjectUploadEnd(BucketObjectUploadEndParams)
+	BucketObjectUploadPart(BucketObjectUploadPartParams)
 	BucketObjectDownloadStart(BucketObjectDownloadStartParams) EventID
 	BucketObjectDownloadEnd(BucketObjectDownloadEndParams)
 	BucketObjectGetAttrsStart(BucketObjectGetAttrsStartParams) EventID
@@ -55,4 +57,9 @@ type Logger interface {
 	BucketListObjectsEnd(BucketListObjectsEndParams)
 	BucketDeleteObjectsStart(BucketDeleteObjectsStartParams) EventID
 	BucketDeleteObjectsEnd(BucketDeleteObjectsEndParams)
+	BucketSignedURL(BucketSignedURLParams)
+	BucketObjectCopyStart(BucketObjectCopyStartParams) EventID
+	BucketObjectCopyEnd(BucketObjectCopyEndParams)
+	BucketObjectUpdateAttrsStart(BucketObjectUpdateAttrsStartParams) EventID
+	BucketObjectUpdateAttrsEnd(BucketObjectUpdateAttrsEndParams)
 }
@@ -0,0 +1,86 @@
+package trace2
+
+import (
+	"runtime/metrics"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+// memMetrics are the runtime/metrics sampled to compute the memory stats of spans.
+// They're cheap to read, unlike runtime.ReadMemStats which stops the world.
+var memMetrics = []string{
+	"/gc/heap/allocs:bytes",
+	"/gc/heap/allocs:objects",
+	"/gc/heap/objects:objects",
+}
+
+// MemStats describes the memory allocated by the process during a span.
+//
+// The allocation counters are process-wide, so the allocations of spans
+// running concurrently with the span are included as well.
+type MemStats struct {
+	AllocBytes   uint64 // bytes allocated on the heap during the span
+	AllocObjects uint64 // heap objects allocated during the span
+	HeapObjects  uint64 // live heap objects when the span ended
+}
+
+// sampleMem reads the heap allocation counters of the process.
+func sampleMem() MemStats {
+	samples := make([]metrics.Sample, len(memMetrics))
+	for i, name := range memMetrics {
+		samples[i].Name = name
+	}
+	metrics.Read(samples)
+
+	value := func(s metrics.Sample) uint64 {
+		if s.Value.Kind() == metrics.KindUint64 {
+			return s.Value.Uint64()
+		}
+		return 0
+	}
+	return MemStats{
+		AllocBytes:   value(samples[0]),
+		AllocObjects: value(samples[1]),
+		HeapObjects:  value(samples[2]),
+	}
+}
+
+// RecordMemStats makes l record the memory allocated during spans in their span end events.
+// It must be called before any events are added to l.
+func (l *Log) RecordMemStats() {
+	l.memStarts = make(map[model.SpanID]MemStats)
+}
+
+// beginMemStats records the allocation counters at the start of a span,
+// if l records memory stats.
+func (l *Log) beginMemStats(spanID model.SpanID) {
+	if l == nil || l.memStarts == nil {
+		return
+	}
+	start := sampleMem()
+	l.mu.Lock()
+	l.memStarts[spanID] = start
+	l.mu.Unlock()
+}
+
+// endMemStats reports the memory allocated during a span that's ending.
+// It reports nil if l doesn't record memory stats, or the start of the span wasn't recorded.
+func (l *Log) endMemStats(spanID model.SpanID) *MemStats {
+	if l == nil || l.memStarts == nil {
+		return nil
+	}
+	l.mu.Lock()
+	start, ok := l.memStarts[spanID]
+	delete(l.memStarts, spanID)
+	l.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	end := sampleMem()
+	return &MemStats{
+		AllocBytes:   end.AllocBytes - start.AllocBytes,
+		AllocObjects: end.AllocObjects - start.AllocObjects,
+		HeapObjects:  end.HeapObjects,
+	}
+}
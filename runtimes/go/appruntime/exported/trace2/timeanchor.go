@@ -19,6 +19,18 @@ func NewTimeAnchorNow() TimeAnchor {
 	return NewTimeAnchor(nano, now)
 }
 
+// bootAnchor is the time anchor captured when the process started.
+var bootAnchor = NewTimeAnchorNow()
+
+// BootTimeAnchor returns the time anchor captured when the process started.
+//
+// Event timestamps are monotonic, so converting them with the same anchor
+// keeps them consistent with each other across all traces of the process,
+// even if the system clock is adjusted (such as by NTP) while it's running.
+func BootTimeAnchor() TimeAnchor {
+	return bootAnchor
+}
+
 // TimeAnchor represents a mapping between nanotime() timestamps
 // and real-world time.Time instants.
 type TimeAnchor struct {
@@ -26,11 +38,54 @@ type TimeAnchor struct {
 	real time.Time
 }
 
+// IsZero reports whether ta is the zero TimeAnchor.
+func (ta TimeAnchor) IsZero() bool {
+	return ta.nano == 0 && ta.real.IsZero()
+}
+
 // ToReal converts a nanotime() timestamp to a real-world time.Time instant.
 func (ta TimeAnchor) ToReal(nano int64) time.Time {
 	return ta.real.Add(time.Duration(nano - ta.nano))
 }
 
+// ToNano converts a real-world time.Time instant to a nanotime() timestamp.
+// It's the inverse of ToReal.
+func (ta TimeAnchor) ToNano(t time.Time) int64 {
+	return ta.nano + int64(t.Sub(ta.real))
+}
+
+// Skew reports how much the system clock was adjusted between
+// the anchors ta and other were captured: the difference between
+// the real time of other and the real time ta maps its timestamp to.
+func (ta TimeAnchor) Skew(other TimeAnchor) time.Duration {
+	return other.real.Sub(ta.ToReal(other.nano))
+}
+
+// TimeAnchorMode determines which time anchor trace data is sent with,
+// and therefore how the wall-clock times of its events are reconstructed.
+type TimeAnchorMode string
+
+const (
+	// TimeAnchorSend anchors trace data to the time it's sent.
+	// Event times follow adjustments of the system clock, so the
+	// times of events in traces sent before and after an adjustment
+	// may appear out of order. It's the default.
+	TimeAnchorSend TimeAnchorMode = "send"
+
+	// TimeAnchorBoot anchors trace data to the time the process started.
+	// Event times are consistent across all traces of the process,
+	// but drift from the system clock by any adjustments made since.
+	TimeAnchorBoot TimeAnchorMode = "boot"
+)
+
+// Anchor returns the time anchor to send trace data with.
+func (m TimeAnchorMode) Anchor() TimeAnchor {
+	if m == TimeAnchorBoot {
+		return bootAnchor
+	}
+	return NewTimeAnchorNow()
+}
+
 // MarshalText marshals the anchor as text. It never fails.
 func (ta TimeAnchor) MarshalText() ([]byte, error) {
 	nano := strconv.FormatInt(ta.nano, 10)
@@ -0,0 +1,26 @@
+package trace2
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestTimeAnchor(t *testing.T) {
+	c := qt.New(t)
+	boot := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ta := NewTimeAnchor(1000, boot)
+
+	c.Assert(ta.ToReal(1000+int64(time.Second)), qt.Equals, boot.Add(time.Second))
+	c.Assert(ta.ToNano(boot.Add(time.Second)), qt.Equals, 1000+int64(time.Second))
+
+	// An anchor captured after the clock was stepped back by 2s.
+	later := NewTimeAnchor(1000+int64(time.Minute), boot.Add(time.Minute-2*time.Second))
+	c.Assert(ta.Skew(later), qt.Equals, -2*time.Second)
+	c.Assert(ta.Skew(ta), qt.Equals, time.Duration(0))
+
+	c.Assert(TimeAnchor{}.IsZero(), qt.IsTrue)
+	c.Assert(ta.IsZero(), qt.IsFalse)
+	c.Assert(TimeAnchorBoot.Anchor(), qt.Equals, BootTimeAnchor())
+}
@@ -3,4 +3,4 @@ package trace2
 type Version int
 
 // CurrentVersion is the trace protocol version this package produces traces in.
-const CurrentVersion Version = 15
+const CurrentVersion Version = 31
@@ -0,0 +1,220 @@
+// Package validation implements the validation rules request fields
+// can declare using the "validate" struct tag, such as:
+//
+//	Name  string `validate:"required,max=100"`
+//	Email string `validate:"email"`
+//	Kind  string `validate:"oneof=a b c"`
+//
+// The rules are separated by commas. The supported rules are:
+//
+//	required   the value must not be the zero value
+//	min=N      numbers must be at least N; strings, lists and maps must have at least N elements
+//	max=N      numbers must be at most N; strings, lists and maps must have at most N elements
+//	len=N      strings, lists and maps must have exactly N elements
+//	oneof=A B  strings and integers must be one of the space-separated values
+//	email      strings must be an email address
+//	url        strings must be an absolute URL
+//	uuid       strings must be a UUID
+//
+// The length of a string is its number of characters (runes).
+// Rules other than required are not checked for nil pointers and absent options,
+// so optional fields are only validated when they are set.
+//
+// The rules are checked by the parser at compile time, and enforced
+// by the runtime before the request is passed to the handler.
+package validation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Kind describes the kind of value a rule is applied to.
+type Kind int
+
+const (
+	Other  Kind = iota // a value not covered by the other kinds, such as a struct
+	String             // a string
+	Int                // a signed integer
+	Uint               // an unsigned integer
+	Float              // a floating-point number
+	Bool               // a boolean
+	List               // a slice or array
+	Map                // a map
+)
+
+func (k Kind) String() string {
+	switch k {
+	case String:
+		return "string"
+	case Int, Uint:
+		return "integer"
+	case Float:
+		return "floating-point number"
+	case Bool:
+		return "boolean"
+	case List:
+		return "list"
+	case Map:
+		return "map"
+	default:
+		return "value of this type"
+	}
+}
+
+// Rule is a single validation rule.
+type Rule struct {
+	Name string // the name of the rule, such as "max"
+	Arg  string // the argument of the rule, if any, such as "100"
+}
+
+func (r Rule) String() string {
+	if r.Arg == "" {
+		return r.Name
+	}
+	return r.Name + "=" + r.Arg
+}
+
+// Rules are the rules declared by a "validate" struct tag.
+type Rules []Rule
+
+// takesArg reports whether each supported rule takes an argument.
+var takesArg = map[string]bool{
+	"required": false,
+	"min":      true,
+	"max":      true,
+	"len":      true,
+	"oneof":    true,
+	"email":    false,
+	"url":      false,
+	"uuid":     false,
+}
+
+// Parse parses the value of a "validate" struct tag.
+func Parse(tag string) (Rules, error) {
+	if strings.TrimSpace(tag) == "" {
+		return nil, fmt.Errorf("no rules specified")
+	}
+
+	var rules Rules
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(tag, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(part), "=")
+		wantArg, ok := takesArg[name]
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("empty rule in %q", tag)
+		case !ok:
+			return nil, fmt.Errorf("unknown rule %q", name)
+		case wantArg && (!hasArg || strings.TrimSpace(arg) == ""):
+			return nil, fmt.Errorf("rule %q requires an argument, like %s=10", name, name)
+		case !wantArg && hasArg:
+			return nil, fmt.Errorf("rule %q does not take an argument", name)
+		case seen[name]:
+			return nil, fmt.Errorf("rule %q is specified multiple times", name)
+		}
+		seen[name] = true
+
+		arg = strings.TrimSpace(arg)
+		switch name {
+		case "min", "max", "len":
+			if _, err := strconv.ParseFloat(arg, 64); err != nil {
+				return nil, fmt.Errorf("rule %q requires a number, got %q", name, arg)
+			}
+		case "oneof":
+			arg = strings.Join(strings.Fields(arg), " ")
+		}
+		rules = append(rules, Rule{Name: name, Arg: arg})
+	}
+	return rules, nil
+}
+
+// CheckKind reports an error if any of the rules
+// cannot be applied to values of the given kind.
+func (rs Rules) CheckKind(kind Kind) error {
+	for _, r := range rs {
+		if err := r.checkKind(kind); err != nil {
+			return err
+		}
+	}
+
+	if min, ok := rs.find("min"); ok {
+		if max, ok := rs.find("max"); ok && min.num() > max.num() {
+			return fmt.Errorf("min=%s is greater than max=%s", min.Arg, max.Arg)
+		}
+	}
+	return nil
+}
+
+func (r Rule) checkKind(kind Kind) error {
+	unsupported := func() error {
+		return fmt.Errorf("rule %q cannot be used on a %s", r.Name, kind)
+	}
+
+	switch r.Name {
+	case "required":
+		return nil
+
+	case "min", "max", "len":
+		switch kind {
+		case String, List, Map:
+			if n := r.num(); n < 0 || n != math.Trunc(n) {
+				return fmt.Errorf("rule %q requires a non-negative integer length, got %q", r.Name, r.Arg)
+			}
+			return nil
+		case Int, Uint:
+			if n := r.num(); n != math.Trunc(n) {
+				return fmt.Errorf("rule %q requires an integer, got %q", r.Name, r.Arg)
+			}
+			if r.Name == "len" {
+				return unsupported()
+			}
+			return nil
+		case Float:
+			if r.Name == "len" {
+				return unsupported()
+			}
+			return nil
+		}
+		return unsupported()
+
+	case "oneof":
+		switch kind {
+		case String:
+			return nil
+		case Int, Uint:
+			for _, val := range strings.Fields(r.Arg) {
+				if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+					return fmt.Errorf("rule %q requires integer values, got %q", r.Name, val)
+				}
+			}
+			return nil
+		}
+		return unsupported()
+
+	case "email", "url", "uuid":
+		if kind == String {
+			return nil
+		}
+		return unsupported()
+	}
+	return fmt.Errorf("unknown rule %q", r.Name)
+}
+
+func (rs Rules) find(name string) (Rule, bool) {
+	for _, r := range rs {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// num returns the numeric argument of the rule.
+// Parse guarantees it's valid for rules taking a number.
+func (r Rule) num() float64 {
+	n, _ := strconv.ParseFloat(r.Arg, 64)
+	return n
+}
@@ -0,0 +1,379 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Violation describes a field failing a validation rule.
+type Violation struct {
+	// Field is the path to the field, using the field names of the request,
+	// such as "address.zip_code" or "items[2].name".
+	Field string
+
+	// Rule is the rule that failed.
+	Rule Rule
+
+	// Message describes the failure, such as "must be at most 100".
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Field + " " + v.Message
+}
+
+// Struct validates the fields of v, which must be a struct or a pointer to a struct,
+// according to the rules declared by their "validate" struct tags.
+// Nested structs, including those in lists and maps, are validated recursively.
+//
+// It reports the fields failing validation, in field order. It reports an error
+// if a struct tag is invalid, which the parser prevents for request types.
+func Struct(v any) ([]Violation, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil, nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct || !needsValidation(val.Type()) {
+		return nil, nil
+	}
+
+	var c checker
+	if err := c.checkStruct("", val); err != nil {
+		return nil, err
+	}
+	return c.violations, nil
+}
+
+type checker struct {
+	violations []Violation
+}
+
+func (c *checker) checkStruct(path string, v reflect.Value) error {
+	plan, err := structPlanFor(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range plan.fields {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			// A nil embedded pointer; its fields are absent.
+			continue
+		}
+		if err := c.checkValue(joinPath(path, f.name), fv, f.rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkValue checks the value at path against rules,
+// and then the values it contains.
+func (c *checker) checkValue(path string, v reflect.Value, rules Rules) error {
+	// Rules apply to the value pointed to, or held by an option.
+	for {
+		if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				c.checkRequired(path, rules)
+				return nil
+			}
+			v = v.Elem()
+		} else if isOption(v.Type()) {
+			if !v.FieldByName("present").Bool() {
+				c.checkRequired(path, rules)
+				return nil
+			}
+			v = v.FieldByName("value")
+		} else {
+			break
+		}
+	}
+
+	kind := kindOf(v.Type())
+	if len(rules) > 0 {
+		if err := rules.CheckKind(kind); err != nil {
+			return fmt.Errorf("invalid validate tag on %s: %v", path, err)
+		}
+		for _, r := range rules {
+			if msg, ok := check(r, kind, v); !ok {
+				c.violations = append(c.violations, Violation{Field: path, Rule: r, Message: msg})
+			}
+		}
+	}
+
+	if !needsValidation(v.Type()) {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return c.checkStruct(path, v)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := c.checkValue(path+"["+strconv.Itoa(i)+"]", v.Index(i), nil); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+		})
+		for _, k := range keys {
+			if err := c.checkValue(path+"["+fmt.Sprint(k)+"]", v.MapIndex(k), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *checker) checkRequired(path string, rules Rules) {
+	if r, ok := rules.find("required"); ok {
+		c.violations = append(c.violations, Violation{Field: path, Rule: r, Message: "is required"})
+	}
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// check checks v against the rule, and returns a
+// description of the failure if it does not pass.
+func check(r Rule, kind Kind, v reflect.Value) (msg string, ok bool) {
+	switch r.Name {
+	case "required":
+		return "is required", !v.IsZero()
+
+	case "min", "max", "len":
+		var (
+			n    float64
+			unit string
+		)
+		switch kind {
+		case String:
+			n, unit = float64(utf8.RuneCountInString(v.String())), " characters"
+		case List, Map:
+			n, unit = float64(v.Len()), " elements"
+		case Int:
+			n = float64(v.Int())
+		case Uint:
+			n = float64(v.Uint())
+		case Float:
+			n = v.Float()
+		}
+		bound := r.num()
+		switch r.Name {
+		case "min":
+			if unit != "" {
+				return "must have at least " + r.Arg + unit, n >= bound
+			}
+			return "must be at least " + r.Arg, n >= bound
+		case "max":
+			if unit != "" {
+				return "must have at most " + r.Arg + unit, n <= bound
+			}
+			return "must be at most " + r.Arg, n <= bound
+		default:
+			return "must have exactly " + r.Arg + unit, n == bound
+		}
+
+	case "oneof":
+		var s string
+		switch kind {
+		case Int:
+			s = strconv.FormatInt(v.Int(), 10)
+		case Uint:
+			s = strconv.FormatUint(v.Uint(), 10)
+		default:
+			s = v.String()
+		}
+		vals := strings.Fields(r.Arg)
+		return "must be one of: " + strings.Join(vals, ", "), slices.Contains(vals, s)
+
+	case "email":
+		addr, err := mail.ParseAddress(v.String())
+		return "must be a valid email address", err == nil && addr.Address == v.String()
+
+	case "url":
+		u, err := url.Parse(v.String())
+		return "must be a valid URL", err == nil && u.Scheme != "" && u.Host != ""
+
+	case "uuid":
+		return "must be a valid UUID", uuidRegexp.MatchString(v.String())
+	}
+	return "", true
+}
+
+// kindOf returns the kind of values of type t.
+func kindOf(t reflect.Type) Kind {
+	switch t.Kind() {
+	case reflect.String:
+		return String
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Uint
+	case reflect.Float32, reflect.Float64:
+		return Float
+	case reflect.Bool:
+		return Bool
+	case reflect.Slice, reflect.Array:
+		return List
+	case reflect.Map:
+		return Map
+	default:
+		return Other
+	}
+}
+
+// isOption reports whether t is an encore.dev/types/option.Option type.
+func isOption(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == "encore.dev/types/option" &&
+		strings.HasPrefix(t.Name(), "Option[")
+}
+
+type structPlan struct {
+	fields []fieldPlan
+	err    error
+}
+
+type fieldPlan struct {
+	index []int
+	name  string
+	rules Rules
+}
+
+var (
+	plans sync.Map // reflect.Type -> *structPlan
+	needs sync.Map // reflect.Type -> bool
+)
+
+// structPlanFor returns the fields of the struct type t to validate.
+// Fields of embedded structs are promoted, like in JSON.
+func structPlanFor(t reflect.Type) (*structPlan, error) {
+	if p, ok := plans.Load(t); ok {
+		p := p.(*structPlan)
+		return p, p.err
+	}
+
+	plan := &structPlan{}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous && isStructOrPtrToStruct(f.Type) {
+			continue
+		}
+		name, ok := fieldName(f)
+		if !ok {
+			continue
+		}
+
+		var rules Rules
+		if tag, ok := f.Tag.Lookup("validate"); ok {
+			var err error
+			if rules, err = Parse(tag); err != nil {
+				plan.err = fmt.Errorf("invalid validate tag on %s.%s: %v", t, f.Name, err)
+				break
+			}
+		}
+		if len(rules) > 0 || needsValidation(f.Type) {
+			plan.fields = append(plan.fields, fieldPlan{index: f.Index, name: name, rules: rules})
+		}
+	}
+
+	p, _ := plans.LoadOrStore(t, plan)
+	plan = p.(*structPlan)
+	return plan, plan.err
+}
+
+// fieldName returns the name of the field in the request,
+// and false if the field is not part of the request.
+func fieldName(f reflect.StructField) (string, bool) {
+	for _, key := range [...]string{"header", "query", "qs", "cookie", "json"} {
+		tag, ok := f.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return "", false
+		} else if name != "" {
+			return name, true
+		}
+	}
+	return f.Name, true
+}
+
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func isStructOrPtrToStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !isOption(t)
+}
+
+// needsValidation reports whether values of type t
+// contain any fields with validation rules.
+func needsValidation(t reflect.Type) bool {
+	if n, ok := needs.Load(t); ok {
+		return n.(bool)
+	}
+	n := computeNeeds(t, make(map[reflect.Type]bool))
+	needs.Store(t, n)
+	return n
+}
+
+// computeNeeds computes needsValidation for t. Visiting tracks the types
+// being computed, to handle recursive types: the rules reachable through
+// them are found by the call that's computing them.
+func computeNeeds(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return computeNeeds(t.Elem(), visiting)
+	case reflect.Struct:
+		if isOption(t) {
+			f, _ := t.FieldByName("value")
+			return computeNeeds(f.Type, visiting)
+		}
+		for _, f := range reflect.VisibleFields(t) {
+			if !f.IsExported() {
+				continue
+			}
+			if _, ok := f.Tag.Lookup("validate"); ok || computeNeeds(f.Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,169 @@
+package validation
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/types/option"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string // rules joined with ","
+		wantErr string
+	}{
+		{tag: `required`, want: `required`},
+		{tag: `max=100, email`, want: `max=100,email`},
+		{tag: `oneof=a  b c,min=1.5`, want: `oneof=a b c,min=1.5`},
+		{tag: ``, wantErr: `no rules specified`},
+		{tag: `required,`, wantErr: `empty rule in "required,"`},
+		{tag: `maximum=3`, wantErr: `unknown rule "maximum"`},
+		{tag: `max`, wantErr: `rule "max" requires an argument, like max=10`},
+		{tag: `email=yes`, wantErr: `rule "email" does not take an argument`},
+		{tag: `max=ten`, wantErr: `rule "max" requires a number, got "ten"`},
+		{tag: `min=1,min=2`, wantErr: `rule "min" is specified multiple times`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			c := qt.New(t)
+			rules, err := Parse(tt.tag)
+			if tt.wantErr != "" {
+				c.Assert(err, qt.ErrorMatches, tt.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			var got string
+			for i, r := range rules {
+				if i > 0 {
+					got += ","
+				}
+				got += r.String()
+			}
+			c.Assert(got, qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestRules_CheckKind(t *testing.T) {
+	tests := []struct {
+		tag     string
+		kind    Kind
+		wantErr string
+	}{
+		{tag: `required,max=100`, kind: String},
+		{tag: `min=-1.5,max=2.5`, kind: Float},
+		{tag: `oneof=1 2 3`, kind: Int},
+		{tag: `len=2`, kind: List},
+		{tag: `required`, kind: Other},
+		{tag: `email`, kind: Int, wantErr: `rule "email" cannot be used on a integer`},
+		{tag: `len=2`, kind: Float, wantErr: `rule "len" cannot be used on a floating-point number`},
+		{tag: `max=1.5`, kind: String, wantErr: `rule "max" requires a non-negative integer length, got "1.5"`},
+		{tag: `min=-1`, kind: Map, wantErr: `rule "min" requires a non-negative integer length, got "-1"`},
+		{tag: `oneof=a b`, kind: Uint, wantErr: `rule "oneof" requires integer values, got "a"`},
+		{tag: `min=10,max=5`, kind: Int, wantErr: `min=10 is greater than max=5`},
+		{tag: `max=5`, kind: Bool, wantErr: `rule "max" cannot be used on a boolean`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			c := qt.New(t)
+			rules, err := Parse(tt.tag)
+			c.Assert(err, qt.IsNil)
+			err = rules.CheckKind(tt.kind)
+			if tt.wantErr != "" {
+				c.Assert(err, qt.ErrorMatches, tt.wantErr)
+			} else {
+				c.Assert(err, qt.IsNil)
+			}
+		})
+	}
+}
+
+type address struct {
+	Zip string `json:"zip_code" validate:"len=5"`
+}
+
+type Embedded struct {
+	Tenant string `validate:"required"`
+}
+
+type request struct {
+	Embedded
+	Name     string                `json:"name" validate:"required,max=5"`
+	Email    string                `validate:"email"`
+	Kind     string                `query:"kind" validate:"oneof=a b"`
+	Count    int                   `header:"X-Count" validate:"min=1,max=10"`
+	Website  *string               `validate:"url"`
+	ID       option.Option[string] `validate:"required,uuid"`
+	Tags     []string              `validate:"max=2"`
+	Address  *address
+	Previous []address
+	Ignored  string `json:"-" validate:"required"`
+}
+
+func TestStruct(t *testing.T) {
+	c := qt.New(t)
+	website := "not a url"
+	req := &request{
+		Name:     "Alexander",
+		Email:    "alex@",
+		Kind:     "c",
+		Count:    0,
+		Website:  &website,
+		Tags:     []string{"a", "b", "c"},
+		Address:  &address{Zip: "123"},
+		Previous: []address{{Zip: "12345"}, {Zip: "1"}},
+	}
+
+	violations, err := Struct(req)
+	c.Assert(err, qt.IsNil)
+
+	var got []string
+	for _, v := range violations {
+		got = append(got, v.String())
+	}
+	c.Assert(got, qt.DeepEquals, []string{
+		"Tenant is required",
+		"name must have at most 5 characters",
+		"Email must be a valid email address",
+		"kind must be one of: a, b",
+		"X-Count must be at least 1",
+		"Website must be a valid URL",
+		"ID is required",
+		"Tags must have at most 2 elements",
+		"Address.zip_code must have exactly 5 characters",
+		"Previous[1].zip_code must have exactly 5 characters",
+	})
+
+	// A valid request has no violations.
+	website = "https://encore.dev"
+	*req = request{
+		Embedded: Embedded{Tenant: "acme"},
+		Name:     "Alex",
+		Email:    "alex@example.com",
+		Kind:     "a",
+		Count:    3,
+		Website:  &website,
+		ID:       option.Some("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	}
+	violations, err = Struct(req)
+	c.Assert(err, qt.IsNil)
+	c.Assert(violations, qt.HasLen, 0)
+
+	// Types without rules are not validated.
+	violations, err = Struct(struct{ Name string }{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(violations, qt.HasLen, 0)
+}
+
+func TestStruct_InvalidTag(t *testing.T) {
+	type invalid struct {
+		Enabled bool `validate:"max=1"`
+	}
+	_, err := Struct(invalid{})
+	qt.Assert(t, err, qt.ErrorMatches, `invalid validate tag on Enabled: rule "max" cannot be used on a boolean`)
+}
@@ -44,6 +44,7 @@ type ContainerMetadata struct {
 	RevisionID string
 	InstanceID string
 	EnvName    string
+	Region     string
 }
 
 func (md *ContainerMetadata) Labels() Labels {
@@ -52,6 +53,7 @@ func (md *ContainerMetadata) Labels() Labels {
 	labels.AddNonEmpty("revision_id", md.RevisionID)
 	labels.AddNonEmpty("instance_id", md.InstanceID)
 	labels.AddNonEmpty("env_name", md.EnvName)
+	labels.AddNonEmpty("region", md.Region)
 	return labels
 }
 
@@ -71,6 +73,7 @@ func GetContainerMetadata(cfg *config.Runtime) (*ContainerMetadata, error) {
 				return nil, err
 			}
 			md.EnvName = cfg.EnvName
+			md.Region = cfg.EnvRegion
 			return md, nil
 		}
 	}
@@ -173,7 +173,7 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 
 func (x *Exporter) getSysMetrics(now time.Time) []types.MetricDatum {
 	sysMetrics := system.ReadSysMetrics(x.rootLogger)
-	return []types.MetricDatum{
+	data := []types.MetricDatum{
 		{
 			MetricName: aws.String(system.MetricNameHeapObjectsBytes),
 			Timestamp:  aws.Time(now),
@@ -187,6 +187,21 @@ func (x *Exporter) getSysMetrics(now time.Time) []types.MetricDatum {
 			Dimensions: x.containerMetadataDims,
 		},
 	}
+
+	for _, sample := range system.ReadCollectedMetrics() {
+		dims := make([]types.Dimension, len(x.containerMetadataDims), len(x.containerMetadataDims)+len(sample.Labels))
+		copy(dims, x.containerMetadataDims)
+		for _, l := range sample.Labels {
+			dims = append(dims, types.Dimension{Name: aws.String(l.Key), Value: aws.String(l.Value)})
+		}
+		data = append(data, types.MetricDatum{
+			MetricName: aws.String(sample.Name),
+			Timestamp:  aws.Time(now),
+			Value:      aws.Float64(sample.Value),
+			Dimensions: dims,
+		})
+	}
+	return data
 }
 
 func (x *Exporter) getClient() *cloudwatch.Client {
@@ -173,7 +173,7 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 
 func (x *Exporter) getSysMetrics(now time.Time) []datadogV2.MetricSeries {
 	sysMetrics := system.ReadSysMetrics(x.rootLogger)
-	return []datadogV2.MetricSeries{
+	data := []datadogV2.MetricSeries{
 		{
 			Metric: system.MetricNameHeapObjectsBytes,
 			Points: []datadogV2.MetricPoint{{
@@ -193,6 +193,24 @@ func (x *Exporter) getSysMetrics(now time.Time) []datadogV2.MetricSeries {
 			Type: datadogV2.METRICINTAKETYPE_GAUGE.Ptr(),
 		},
 	}
+
+	for _, sample := range system.ReadCollectedMetrics() {
+		tags := make([]string, len(x.containerMetadataLabels), len(x.containerMetadataLabels)+len(sample.Labels))
+		copy(tags, x.containerMetadataLabels)
+		for _, l := range sample.Labels {
+			tags = append(tags, l.Key+":"+l.Value)
+		}
+		data = append(data, datadogV2.MetricSeries{
+			Metric: sample.Name,
+			Points: []datadogV2.MetricPoint{{
+				Timestamp: datadog.PtrInt64(now.Unix()),
+				Value:     datadog.PtrFloat64(sample.Value),
+			}},
+			Tags: tags,
+			Type: datadogV2.METRICINTAKETYPE_GAUGE.Ptr(),
+		})
+	}
+	return data
 }
 
 func (x *Exporter) newContext(parent context.Context) context.Context {
@@ -5,6 +5,7 @@ package gcp
 import (
 	"context"
 	"fmt"
+	"maps"
 	"math"
 	"sync"
 	"time"
@@ -345,6 +346,35 @@ func (x *Exporter) getSysMetrics(now time.Time) []*monitoringpb.TimeSeries {
 		})
 	}
 
+	for _, sample := range system.ReadCollectedMetrics() {
+		// Collected metrics are only exported once they've been
+		// provisioned, so skip the ones missing from the config.
+		cloudMetricName, ok := x.metricNames[sample.Name]
+		if !ok {
+			continue
+		}
+
+		labels := make(map[string]string, len(x.containerMetadataLabels)+len(sample.Labels))
+		maps.Copy(labels, x.containerMetadataLabels)
+		for _, l := range sample.Labels {
+			labels[l.Key] = l.Value
+		}
+		output = append(output, &monitoringpb.TimeSeries{
+			MetricKind: metricpb.MetricDescriptor_GAUGE,
+			Metric: &metricpb.Metric{
+				Type:   "custom.googleapis.com/" + cloudMetricName,
+				Labels: labels,
+			},
+			Resource: monitoredResource,
+			Points: []*monitoringpb.Point{{
+				Interval: &monitoringpb.TimeInterval{EndTime: timestamppb.New(now)},
+				Value: &monitoringpb.TypedValue{
+					Value: &monitoringpb.TypedValue_DoubleValue{DoubleValue: sample.Value},
+				},
+			}},
+		})
+	}
+
 	return output
 }
 
@@ -170,7 +170,7 @@ func (x *Exporter) getSysMetrics(now time.Time) []*prompb.TimeSeries {
 	}
 
 	sysMetrics := system.ReadSysMetrics(x.rootLogger)
-	return []*prompb.TimeSeries{
+	data := []*prompb.TimeSeries{
 		{
 			Labels: addMetricNameLabel(system.MetricNameHeapObjectsBytes),
 			Samples: []*prompb.Sample{{
@@ -186,6 +186,21 @@ func (x *Exporter) getSysMetrics(now time.Time) []*prompb.TimeSeries {
 			}},
 		},
 	}
+
+	for _, sample := range system.ReadCollectedMetrics() {
+		labels := addMetricNameLabel(sample.Name)
+		for _, l := range sample.Labels {
+			labels = append(labels, &prompb.Label{Name: l.Key, Value: l.Value})
+		}
+		data = append(data, &prompb.TimeSeries{
+			Labels: labels,
+			Samples: []*prompb.Sample{{
+				Value:     sample.Value,
+				Timestamp: FromTime(now),
+			}},
+		})
+	}
+	return data
 }
 
 // FromTime returns a new millisecond timestamp from a time.
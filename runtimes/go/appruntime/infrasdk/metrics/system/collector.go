@@ -0,0 +1,45 @@
+package system
+
+import (
+	"sync"
+)
+
+// Sample is a process-level metric value reported by a runtime subsystem,
+// such as the connection pool statistics of a database.
+type Sample struct {
+	Name   string
+	Labels []Label
+	Value  float64
+}
+
+// Label is a key-value pair identifying a sample.
+type Label struct {
+	Key   string
+	Value string
+}
+
+var (
+	collectorsMu sync.Mutex
+	collectors   []func() []Sample
+)
+
+// RegisterCollector registers a function that is called on each
+// metrics collection to report additional process-level metrics.
+func RegisterCollector(fn func() []Sample) {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+	collectors = append(collectors, fn)
+}
+
+// ReadCollectedMetrics returns the samples reported by all registered collectors.
+func ReadCollectedMetrics() []Sample {
+	collectorsMu.Lock()
+	fns := collectors
+	collectorsMu.Unlock()
+
+	var samples []Sample
+	for _, fn := range fns {
+		samples = append(samples, fn()...)
+	}
+	return samples
+}
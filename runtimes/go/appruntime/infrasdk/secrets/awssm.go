@@ -0,0 +1,178 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+
+	"encore.dev/appruntime/exported/config/infra"
+)
+
+// awsSecretsManager resolves secrets from an AWS Secrets Manager secret
+// containing a JSON object, using the GetSecretValue API.
+type awsSecretsManager struct {
+	secretID string
+	region   string
+
+	once    sync.Once
+	cfg     aws.Config
+	initErr error
+}
+
+func newAWSSecretsManager(cfg *infra.AWSSecretsManager) (*awsSecretsManager, error) {
+	region := cfg.Region
+	if region == "" && strings.HasPrefix(cfg.SecretID, "arn:") {
+		// arn:aws:secretsmanager:<region>:<account>:secret:<name>
+		parts := strings.SplitN(cfg.SecretID, ":", 7)
+		if len(parts) != 7 || parts[2] != "secretsmanager" {
+			return nil, fmt.Errorf("invalid AWS Secrets Manager secret ARN %q", cfg.SecretID)
+		}
+		region = parts[3]
+	}
+	return &awsSecretsManager{secretID: cfg.SecretID, region: region}, nil
+}
+
+func (a *awsSecretsManager) String() string {
+	return fmt.Sprintf("aws secrets manager (%s)", a.secretID)
+}
+
+func (a *awsSecretsManager) Fetch(ctx context.Context) (map[string][]string, error) {
+	current, err := a.getValue(ctx, "AWSCURRENT")
+	if err != nil {
+		return nil, err
+	}
+
+	// Include the previous version, if there is one,
+	// so secrets can be rotated without downtime.
+	previous, err := a.getValue(ctx, "AWSPREVIOUS")
+	if err != nil {
+		var aerr *awsError
+		if !errors.As(err, &aerr) || aerr.Type != "ResourceNotFoundException" {
+			return nil, err
+		}
+	}
+	return mergeVersions(current, previous), nil
+}
+
+// getValue gets the value of the secret version with the given staging label.
+func (a *awsSecretsManager) getValue(ctx context.Context, stage string) (map[string]string, error) {
+	var resp struct {
+		SecretString *string
+	}
+	params := map[string]any{"SecretId": a.secretID, "VersionStage": stage}
+	if err := a.call(ctx, "GetSecretValue", params, &resp); err != nil {
+		return nil, a.diagnose(err)
+	}
+	if resp.SecretString == nil {
+		return nil, a.diagnose(errors.New("secret has no string value"))
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(*resp.SecretString), &obj); err != nil {
+		return nil, a.diagnose(fmt.Errorf("secret value is not a JSON object: %v", err))
+	}
+	return secretValues(obj), nil
+}
+
+func (a *awsSecretsManager) call(ctx context.Context, op string, params, resp any) error {
+	a.once.Do(func() {
+		var opts []func(*awsconfig.LoadOptions) error
+		if a.region != "" {
+			opts = append(opts, awsconfig.WithRegion(a.region))
+		}
+		a.cfg, a.initErr = awsconfig.LoadDefaultConfig(context.Background(), opts...)
+		if a.initErr == nil && a.cfg.Region == "" {
+			a.initErr = errors.New("no AWS region configured")
+		}
+	})
+	if a.initErr != nil {
+		return fmt.Errorf("load config: %w", a.initErr)
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	endpoint := "https://secretsmanager." + a.cfg.Region + ".amazonaws.com/"
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager."+op)
+
+	creds, err := a.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieve credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "secretsmanager", a.cfg.Region, time.Now()); err != nil {
+		return fmt.Errorf("sign request: %w", err)
+	}
+
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		aerr := &awsError{Status: httpResp.StatusCode}
+		_ = json.Unmarshal(data, aerr)
+		// The type may be prefixed with a namespace, as in "namespace#Type".
+		if _, typ, ok := strings.Cut(aerr.Type, "#"); ok {
+			aerr.Type = typ
+		}
+		return aerr
+	}
+	return json.Unmarshal(data, resp)
+}
+
+// diagnose wraps err in a providerError with a hint on how to resolve it.
+func (a *awsSecretsManager) diagnose(err error) error {
+	var hint string
+	var aerr *awsError
+	switch {
+	case errors.As(err, &aerr):
+		switch aerr.Type {
+		case "AccessDeniedException", "UnrecognizedClientException", "InvalidSignatureException":
+			hint = fmt.Sprintf("check that the AWS credentials are valid and allow secretsmanager:GetSecretValue on %q", a.secretID)
+		case "ResourceNotFoundException":
+			hint = "check that the secret exists in the configured region"
+		case "DecryptionFailure":
+			hint = "check that the AWS credentials allow kms:Decrypt with the secret's KMS key"
+		}
+	case strings.Contains(err.Error(), "retrieve credentials") || strings.Contains(err.Error(), "load config"):
+		hint = "configure AWS credentials and a region, for example through the environment or an IAM role"
+	}
+	return &providerError{provider: a.String(), err: err, hint: hint}
+}
+
+// awsError is an error response from the AWS Secrets Manager API.
+type awsError struct {
+	Status  int    `json:"-"`
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (e *awsError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("aws responded with HTTP %d", e.Status)
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+}
@@ -4,51 +4,261 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"maps"
 	"os"
+	"slices"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
 
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/config/infra"
 	"encore.dev/appruntime/shared/cfgutil"
+	"encore.dev/appruntime/shared/testsupport"
 )
 
+// reloadInterval is how often the secrets file and the infra config
+// are checked for rotated secrets.
+const reloadInterval = 10 * time.Second
+
 type Manager struct {
-	cfg     *config.Runtime
-	secrets map[string]string
+	cfg         *config.Runtime
+	ts          *testsupport.Manager // nil outside of tests
+	rootLogger  zerolog.Logger
+	infraCfgEnv string
+	appSecrets  string // the ENCORE_APP_SECRETS value
+	secretsPath string // the ENCORE_APP_SECRETS_PATH value
+
+	// provider resolves secrets from an external secrets manager,
+	// if one is configured in the infra config. It's created on first use.
+	providerMu sync.Mutex
+	provider   *cachedProvider
+
+	mu sync.RWMutex
+	// versions holds the active versions of each secret, newest first.
+	versions  map[string][]string
+	source    string // the contents of the secrets file and infra config last loaded
+	callbacks []func()
 }
 
-func NewManager(cfg *config.Runtime, infraCfgEnv, appSecretsEnv string) *Manager {
-	secrets := parse(appSecretsEnv)
-	if infraCfgEnv != "" {
-		cfg, err := config.LoadInfraConfig(infraCfgEnv)
-		if err != nil {
-			log.Fatalln("encore: could not read infra config", err)
-		}
-		maps.Copy(secrets, cfg.Secrets.GetSecrets())
+func NewManager(cfg *config.Runtime, ts *testsupport.Manager, rootLogger zerolog.Logger, infraCfgEnv, appSecretsEnv, appSecretsPath string) *Manager {
+	mgr := &Manager{
+		cfg:         cfg,
+		ts:          ts,
+		rootLogger:  rootLogger,
+		infraCfgEnv: infraCfgEnv,
+		appSecrets:  appSecretsEnv,
+		secretsPath: appSecretsPath,
 	}
-	return &Manager{cfg: cfg, secrets: secrets}
+	versions, source, err := mgr.read()
+	if err != nil {
+		log.Fatalln("encore runtime: fatal error:", err)
+	}
+	mgr.versions, mgr.source = versions, source
+	return mgr
 }
 
 // Load loads a secret.
 func (mgr *Manager) Load(key string, inService string) string {
-	if val, ok := mgr.secrets[key]; ok {
+	if val, ok := mgr.Current(key); ok {
 		return val
 	}
 
 	// For anything but local development or a gateway, a missing secret is a fatal error.
 	if mgr.cfg.EnvCloud != "local" && cfgutil.IsHostedService(mgr.cfg, inService) {
-		fmt.Fprintln(os.Stderr, "encore: could not find secret", key)
+		if p := mgr.secretsProvider(); p != nil {
+			fmt.Fprintf(os.Stderr, "encore: could not find secret %s in %s\n", key, p)
+		} else {
+			fmt.Fprintln(os.Stderr, "encore: could not find secret", key)
+		}
 		os.Exit(2)
 	}
 
 	return ""
 }
 
+// Current returns the current (newest) version of a secret.
+func (mgr *Manager) Current(key string) (val string, ok bool) {
+	if val, ok := mgr.testOverride(key); ok {
+		return val, true
+	}
+
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+	if v := mgr.versions[key]; len(v) > 0 {
+		return v[0], true
+	}
+	return "", false
+}
+
+// Versions returns the active versions of a secret, newest first.
+func (mgr *Manager) Versions(key string) []string {
+	if val, ok := mgr.testOverride(key); ok {
+		return []string{val}
+	}
+
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+	return slices.Clone(mgr.versions[key])
+}
+
+// testOverride returns the value of a secret set by the running test, if any.
+func (mgr *Manager) testOverride(key string) (val string, ok bool) {
+	if mgr.ts == nil || mgr.cfg.EnvType != "test" {
+		return "", false
+	}
+	return mgr.ts.GetSecret(key)
+}
+
+// OnChange registers fn to be called whenever secrets are rotated.
+func (mgr *Manager) OnChange(fn func()) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.callbacks = append(mgr.callbacks, fn)
+}
+
+// Watch periodically reloads secrets from the secrets file and the infra config,
+// if either is in use, calling the registered callbacks when a secret changes.
+func (mgr *Manager) Watch() {
+	if mgr.secretsPath == "" && mgr.infraCfgEnv == "" {
+		return
+	}
+	go func() {
+		for range time.Tick(reloadInterval) {
+			mgr.Reload()
+		}
+	}()
+}
+
+// Reload reloads secrets from the secrets file and the infra config,
+// and calls the registered callbacks if any secret has changed.
+func (mgr *Manager) Reload() {
+	versions, source, err := mgr.read()
+	if err != nil {
+		mgr.rootLogger.Error().Err(err).Msg("could not reload secrets")
+		return
+	}
+
+	mgr.mu.Lock()
+	if source == mgr.source {
+		mgr.mu.Unlock()
+		return
+	}
+	changed := !maps.EqualFunc(versions, mgr.versions, slices.Equal[[]string])
+	mgr.versions, mgr.source = versions, source
+	callbacks := slices.Clone(mgr.callbacks)
+	mgr.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	mgr.rootLogger.Info().Msg("secrets rotated")
+	for _, fn := range callbacks {
+		mgr.call(fn)
+	}
+}
+
+func (mgr *Manager) call(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			mgr.rootLogger.Error().Interface("panic", r).Msg("secrets change callback panicked")
+		}
+	}()
+	fn()
+}
+
+// read reads the secrets from all sources. Secrets in the secrets file take
+// precedence over ENCORE_APP_SECRETS, and secrets in the infra config, or
+// resolved by the secrets provider it configures, take precedence over both.
+// It also returns the raw contents of the secrets file and infra config,
+// and the resolved secrets, to cheaply detect changes.
+func (mgr *Manager) read() (versions map[string][]string, source string, err error) {
+	versions, err = parse(mgr.appSecrets)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not parse app secrets: %v", err)
+	}
+
+	var src strings.Builder
+	if mgr.secretsPath != "" {
+		data, err := os.ReadFile(mgr.secretsPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, "", fmt.Errorf("could not read secrets file: %v", err)
+		}
+		fromFile, err := parse(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, "", fmt.Errorf("could not parse secrets file: %v", err)
+		}
+		maps.Copy(versions, fromFile)
+		src.Write(data)
+	}
+
+	if mgr.infraCfgEnv != "" {
+		data, err := os.ReadFile(mgr.infraCfgEnv)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not read infra config: %v", err)
+		}
+		var cfg infra.InfraConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, "", fmt.Errorf("could not decode infra config: %v", err)
+		}
+		for key, val := range cfg.Secrets.GetSecrets() {
+			versions[key] = []string{val}
+		}
+		src.WriteByte(0)
+		src.Write(data)
+
+		if cfg.Secrets.Provider != nil {
+			fromProvider, err := mgr.fetchFromProvider(cfg.Secrets.Provider)
+			if err != nil {
+				return nil, "", err
+			}
+			maps.Copy(versions, fromProvider)
+			resolved, _ := json.Marshal(fromProvider)
+			src.WriteByte(0)
+			src.Write(resolved)
+		}
+	}
+
+	return versions, src.String(), nil
+}
+
+// fetchFromProvider fetches secrets from the provider configured by cfg,
+// creating the provider on first use. Changes to the provider configuration
+// take effect when the application is restarted.
+func (mgr *Manager) fetchFromProvider(cfg *infra.SecretsProvider) (map[string][]string, error) {
+	mgr.providerMu.Lock()
+	if mgr.provider == nil {
+		p, err := newCachedProvider(cfg, mgr.rootLogger)
+		if err != nil {
+			mgr.providerMu.Unlock()
+			return nil, fmt.Errorf("invalid secrets provider: %v", err)
+		}
+		mgr.provider = p
+	}
+	p := mgr.provider
+	mgr.providerMu.Unlock()
+	return p.Fetch()
+}
+
+// secretsProvider returns the secrets provider in use, if any.
+func (mgr *Manager) secretsProvider() *cachedProvider {
+	mgr.providerMu.Lock()
+	defer mgr.providerMu.Unlock()
+	return mgr.provider
+}
+
 // parse parses secrets in "key1=base64(val1),key2=base64(val2)" format into a map.
-func parse(s string) map[string]string {
+//
+// A secret with multiple active versions has its versions separated by '.',
+// newest first, as in "key1=base64(new).base64(old)".
+func parse(s string) (map[string][]string, error) {
 	s, isGzipped := strings.CutPrefix(s, "gzip:")
 	if isGzipped {
 		var b []byte
@@ -58,33 +268,35 @@ func parse(s string) map[string]string {
 			b, err = base64.RawURLEncoding.DecodeString(s)
 		}
 		if err != nil {
-			log.Fatalln("encore runtime: fatal error: could not decode app secrets:", err)
+			return nil, fmt.Errorf("could not decode: %v", err)
 		}
 		gz, err := gzip.NewReader(bytes.NewReader(b))
 		if err != nil {
-			log.Fatalln("encore runtime: fatal error: could not unzip app secrets:", err)
+			return nil, fmt.Errorf("could not unzip: %v", err)
 		}
 		b, err = io.ReadAll(gz)
 		if err != nil {
-			log.Fatalln("encore runtime: fatal error: could not read app secrets:", err)
+			return nil, fmt.Errorf("could not read: %v", err)
 		}
 
 		s = string(b)
 	}
-	m := make(map[string]string)
+	m := make(map[string][]string)
 	if s == "" {
-		return m
+		return m, nil
 	}
 	for _, part := range strings.Split(s, ",") {
-		kv := strings.SplitN(part, "=", 2)
-		if len(kv) != 2 {
-			log.Fatalln("encore runtime: fatal error: invalid secret value")
+		key, vals, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, errors.New("invalid secret value")
 		}
-		val, err := base64.RawURLEncoding.DecodeString(kv[1])
-		if err != nil {
-			log.Fatalln("encore runtime: fatal error: invalid secret value")
+		for _, v := range strings.Split(vals, ".") {
+			val, err := base64.RawURLEncoding.DecodeString(v)
+			if err != nil {
+				return nil, errors.New("invalid secret value")
+			}
+			m[key] = append(m[key], string(val))
 		}
-		m[kv[0]] = string(val)
 	}
-	return m
+	return m, nil
 }
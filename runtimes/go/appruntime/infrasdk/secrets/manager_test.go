@@ -0,0 +1,123 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+func encode(key string, versions ...string) string {
+	s := key + "="
+	for i, v := range versions {
+		if i > 0 {
+			s += "."
+		}
+		s += base64.RawURLEncoding.EncodeToString([]byte(v))
+	}
+	return s
+}
+
+func TestParse(t *testing.T) {
+	got, err := parse(encode("Foo", "foo") + "," + encode("Bar", "new", "old"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"Foo": {"foo"},
+		"Bar": {"new", "old"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := parse("Foo"); err == nil {
+		t.Error("expected error for missing value")
+	}
+}
+
+func TestManager_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets")
+	write := func(s string) {
+		if err := os.WriteFile(path, []byte(s), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(encode("Password", "one"))
+
+	cfg := &config.Runtime{EnvCloud: "local"}
+	mgr := NewManager(cfg, nil, zerolog.Nop(), "", encode("Password", "env")+","+encode("Token", "token"), path)
+	if got := mgr.Load("Password", "svc"); got != "one" {
+		t.Fatalf("got password %q, want %q", got, "one")
+	}
+	if got := mgr.Load("Token", "svc"); got != "token" {
+		t.Fatalf("got token %q, want %q", got, "token")
+	}
+
+	calls := 0
+	mgr.OnChange(func() { calls++ })
+
+	// Reloading unchanged secrets should not call the callbacks.
+	mgr.Reload()
+	if calls != 0 {
+		t.Fatalf("got %d calls, want 0", calls)
+	}
+
+	// Rotate the password, keeping the old version active.
+	write(encode("Password", "two", "one"))
+	mgr.Reload()
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+	if got := mgr.Load("Password", "svc"); got != "two" {
+		t.Errorf("got password %q, want %q", got, "two")
+	}
+	if got, want := mgr.Versions("Password"), []string{"two", "one"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got versions %v, want %v", got, want)
+	}
+
+	// An invalid file keeps the previous secrets.
+	write("invalid")
+	mgr.Reload()
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+	if got := mgr.Load("Password", "svc"); got != "two" {
+		t.Errorf("got password %q, want %q", got, "two")
+	}
+}
+
+func TestManager_TestOverride(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, nil)
+	ts := testsupport.NewManager(&config.Static{}, rt, zerolog.Nop())
+	cfg := &config.Runtime{EnvCloud: "local", EnvType: "test"}
+	mgr := NewManager(cfg, ts, zerolog.Nop(), "", encode("Password", "env")+","+encode("Token", "token"), "")
+
+	rt.BeginOperation()
+	defer rt.FinishOperation()
+	rt.BeginRequest(&model.Request{Test: &model.TestData{Config: &model.TestConfig{}}})
+	ts.SetSecret("Password", "test")
+	if got, _ := mgr.Current("Password"); got != "test" {
+		t.Errorf("got password %q, want %q", got, "test")
+	}
+	if got, want := mgr.Versions("Password"), []string{"test"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got versions %v, want %v", got, want)
+	}
+	if got, _ := mgr.Current("Token"); got != "token" {
+		t.Errorf("got token %q, want %q", got, "token")
+	}
+	rt.FinishRequest(false)
+
+	// The override doesn't outlive the test.
+	if got, _ := mgr.Current("Password"); got != "env" {
+		t.Errorf("got password %q after test, want %q", got, "env")
+	}
+}
@@ -0,0 +1,165 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config/infra"
+)
+
+const (
+	// defaultCacheTTL is how long secrets resolved from a provider
+	// are cached if the infra config doesn't specify a cache TTL.
+	defaultCacheTTL = 5 * time.Minute
+
+	// fetchTimeout is the timeout for resolving secrets from a provider.
+	fetchTimeout = 10 * time.Second
+)
+
+// provider resolves secrets from an external secrets manager.
+type provider interface {
+	// Fetch fetches the active versions of all secrets, newest first.
+	Fetch(ctx context.Context) (map[string][]string, error)
+	// String describes the provider, for diagnostics.
+	String() string
+}
+
+// renewer is implemented by providers that hold leases,
+// such as Vault tokens, that must be renewed periodically.
+type renewer interface {
+	// Renew renews any leases that are close to expiring.
+	Renew(ctx context.Context) error
+}
+
+// newProvider returns the provider for the given config.
+func newProvider(cfg *infra.SecretsProvider) (provider, error) {
+	switch cfg.Type {
+	case "vault":
+		if cfg.Vault == nil {
+			return nil, fmt.Errorf("missing vault configuration")
+		}
+		return newVaultProvider(cfg.Vault), nil
+	case "aws_secrets_manager":
+		if cfg.AWSSecretsManager == nil {
+			return nil, fmt.Errorf("missing aws_secrets_manager configuration")
+		}
+		return newAWSSecretsManager(cfg.AWSSecretsManager)
+	default:
+		return nil, fmt.Errorf("unsupported secrets provider type %q", cfg.Type)
+	}
+}
+
+// cachedProvider caches the secrets resolved by a provider.
+type cachedProvider struct {
+	p      provider
+	ttl    time.Duration
+	logger zerolog.Logger
+
+	mu        sync.Mutex
+	versions  map[string][]string
+	fetchedAt time.Time
+}
+
+func newCachedProvider(cfg *infra.SecretsProvider, logger zerolog.Logger) (*cachedProvider, error) {
+	p, err := newProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	ttl := defaultCacheTTL
+	if cfg.CacheTTL != nil {
+		ttl = time.Duration(*cfg.CacheTTL) * time.Second
+	}
+	return &cachedProvider{
+		p:      p,
+		ttl:    ttl,
+		logger: logger.With().Str("secrets_provider", p.String()).Logger(),
+	}, nil
+}
+
+// Fetch returns the cached secrets, fetching them from the provider
+// if they haven't been fetched within the cache TTL.
+// It also renews any leases held by the provider.
+func (c *cachedProvider) Fetch() (map[string][]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	if r, ok := c.p.(renewer); ok {
+		if err := r.Renew(ctx); err != nil {
+			// The lease may still be valid, so keep going.
+			c.logger.Warn().Err(err).Msg("could not renew secrets provider lease")
+		}
+	}
+
+	if c.versions != nil && time.Since(c.fetchedAt) < c.ttl {
+		return c.versions, nil
+	}
+	versions, err := c.p.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.versions, c.fetchedAt = versions, time.Now()
+	return versions, nil
+}
+
+// String describes the underlying provider.
+func (c *cachedProvider) String() string {
+	return c.p.String()
+}
+
+// providerError is an error resolving secrets from a provider,
+// with a hint on how to resolve it.
+type providerError struct {
+	provider string
+	err      error
+	hint     string
+}
+
+func (e *providerError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "could not resolve secrets from %s: %v", e.provider, e.err)
+	if e.hint != "" {
+		fmt.Fprintf(&b, " (hint: %s)", e.hint)
+	}
+	return b.String()
+}
+
+func (e *providerError) Unwrap() error {
+	return e.err
+}
+
+// secretValues converts the values of a JSON object holding secrets to strings.
+// String values are used as-is, and other values are JSON-encoded.
+func secretValues(obj map[string]json.RawMessage) map[string]string {
+	vals := make(map[string]string, len(obj))
+	for key, raw := range obj {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			vals[key] = s
+		} else {
+			vals[key] = string(raw)
+		}
+	}
+	return vals
+}
+
+// mergeVersions returns the versions of each secret given the current
+// values and, if available, the previous values, newest first.
+func mergeVersions(current, previous map[string]string) map[string][]string {
+	versions := make(map[string][]string, len(current))
+	for key, val := range current {
+		versions[key] = []string{val}
+		if prev, ok := previous[key]; ok && prev != val {
+			versions[key] = append(versions[key], prev)
+		}
+	}
+	return versions
+}
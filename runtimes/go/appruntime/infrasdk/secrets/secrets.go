@@ -5,14 +5,23 @@ package secrets
 import (
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/encoreenv"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/testsupport"
 )
 
-var singleton = NewManager(
+var Singleton = NewManager(
 	appconf.Runtime,
+	testsupport.Singleton,
+	logging.RootLogger,
 	encoreenv.Get("ENCORE_INFRA_CONFIG_PATH"),
 	encoreenv.Get("ENCORE_APP_SECRETS"),
+	encoreenv.Get("ENCORE_APP_SECRETS_PATH"),
 )
 
+func init() {
+	Singleton.Watch()
+}
+
 func Load(key string, inService string) string {
-	return singleton.Load(key, inService)
+	return Singleton.Load(key, inService)
 }
@@ -0,0 +1,204 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"encore.dev/appruntime/exported/config/infra"
+)
+
+// vaultProvider resolves secrets from a HashiCorp Vault KV version 2 secret
+// using the Vault HTTP API.
+type vaultProvider struct {
+	address   string
+	token     string
+	namespace string
+	mount     string
+	path      string
+	client    *http.Client
+
+	// renewAt is when the token should next be renewed,
+	// or zero if the token hasn't been looked up yet.
+	renewAt time.Time
+	// never is set if the token doesn't expire or can't be renewed.
+	never bool
+}
+
+func newVaultProvider(cfg *infra.VaultSecrets) *vaultProvider {
+	mount := strings.Trim(cfg.Mount, "/")
+	if mount == "" {
+		mount = "secret"
+	}
+	return &vaultProvider{
+		address:   strings.TrimSuffix(cfg.Address, "/"),
+		token:     cfg.Token.Value(),
+		namespace: cfg.Namespace,
+		mount:     mount,
+		path:      strings.Trim(cfg.Path, "/"),
+		client:    http.DefaultClient,
+	}
+}
+
+func (v *vaultProvider) String() string {
+	return fmt.Sprintf("vault (%s, %s/%s)", v.address, v.mount, v.path)
+}
+
+func (v *vaultProvider) Fetch(ctx context.Context) (map[string][]string, error) {
+	current, version, err := v.read(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include the previous version, if it's still available,
+	// so secrets can be rotated without downtime.
+	var previous map[string]string
+	if version > 1 {
+		previous, _, err = v.read(ctx, version-1)
+		if err != nil {
+			var verr *vaultError
+			if !errors.As(err, &verr) || verr.status != http.StatusNotFound {
+				return nil, err
+			}
+		}
+	}
+	return mergeVersions(current, previous), nil
+}
+
+// read reads the given version of the secret, or the current version if it's 0.
+// It returns the secret values and the version read. It reports a 404 vaultError
+// if the version has been deleted.
+func (v *vaultProvider) read(ctx context.Context, version int) (map[string]string, int, error) {
+	path := "/v1/" + v.mount + "/data/" + v.path
+	if version > 0 {
+		path += "?version=" + url.QueryEscape(fmt.Sprint(version))
+	}
+	var resp struct {
+		Data *struct {
+			Data     map[string]json.RawMessage `json:"data"`
+			Metadata struct {
+				Version int `json:"version"`
+			} `json:"metadata"`
+		} `json:"data"`
+	}
+	if err := v.do(ctx, "GET", path, &resp); err != nil {
+		return nil, 0, v.diagnose(err)
+	}
+	if resp.Data == nil || resp.Data.Data == nil {
+		// A deleted version has no data.
+		return nil, 0, v.diagnose(&vaultError{status: http.StatusNotFound, errors: []string{"secret version deleted"}})
+	}
+	return secretValues(resp.Data.Data), resp.Data.Metadata.Version, nil
+}
+
+// Renew renews the token once half of its TTL has passed.
+func (v *vaultProvider) Renew(ctx context.Context) error {
+	if v.never {
+		return nil
+	}
+
+	var resp struct {
+		Data struct {
+			TTL       int  `json:"ttl"`
+			Renewable bool `json:"renewable"`
+		} `json:"data"`
+		Auth struct {
+			LeaseDuration int  `json:"lease_duration"`
+			Renewable     bool `json:"renewable"`
+		} `json:"auth"`
+	}
+	if v.renewAt.IsZero() {
+		if err := v.do(ctx, "GET", "/v1/auth/token/lookup-self", &resp); err != nil {
+			return fmt.Errorf("look up token: %w", err)
+		}
+		v.schedule(resp.Data.TTL, resp.Data.Renewable)
+		return nil
+	} else if time.Now().Before(v.renewAt) {
+		return nil
+	}
+
+	if err := v.do(ctx, "POST", "/v1/auth/token/renew-self", &resp); err != nil {
+		return fmt.Errorf("renew token: %w", err)
+	}
+	v.schedule(resp.Auth.LeaseDuration, resp.Auth.Renewable)
+	return nil
+}
+
+func (v *vaultProvider) schedule(ttl int, renewable bool) {
+	if !renewable || ttl <= 0 {
+		// Root tokens and tokens without a TTL never expire,
+		// and non-renewable tokens can't be renewed.
+		v.never = true
+		return
+	}
+	v.renewAt = time.Now().Add(time.Duration(ttl) * time.Second / 2)
+}
+
+func (v *vaultProvider) do(ctx context.Context, method, path string, resp any) error {
+	req, err := http.NewRequestWithContext(ctx, method, v.address+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	httpResp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		verr := &vaultError{status: httpResp.StatusCode}
+		var body struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(data, &body) == nil {
+			verr.errors = body.Errors
+		}
+		return verr
+	}
+	return json.Unmarshal(data, resp)
+}
+
+// diagnose wraps err in a providerError with a hint on how to resolve it.
+func (v *vaultProvider) diagnose(err error) error {
+	var hint string
+	var verr *vaultError
+	switch {
+	case errors.As(err, &verr) && verr.status == http.StatusForbidden:
+		hint = fmt.Sprintf("check that the token is valid and has a policy granting read access to %q", v.mount+"/data/"+v.path)
+	case errors.As(err, &verr) && verr.status == http.StatusNotFound:
+		hint = fmt.Sprintf("check that %q is a KV version 2 secrets engine containing the secret %q", v.mount, v.path)
+	case errors.As(err, &verr) && verr.status == http.StatusServiceUnavailable:
+		hint = "check that the Vault server is unsealed"
+	case !errors.As(err, &verr):
+		hint = fmt.Sprintf("check that the Vault server is reachable at %s", v.address)
+	}
+	return &providerError{provider: v.String(), err: err, hint: hint}
+}
+
+// vaultError is an error response from the Vault API.
+type vaultError struct {
+	status int
+	errors []string
+}
+
+func (e *vaultError) Error() string {
+	msg := fmt.Sprintf("vault responded with HTTP %d", e.status)
+	if len(e.errors) > 0 {
+		msg += ": " + strings.Join(e.errors, "; ")
+	}
+	return msg
+}
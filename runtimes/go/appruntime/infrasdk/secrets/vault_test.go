@@ -0,0 +1,175 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/config/infra"
+)
+
+// fakeVault is a fake Vault server with a single KV version 2 secret.
+type fakeVault struct {
+	token    string
+	versions []string // JSON objects of each version of the secret, oldest first
+	ttl      int      // the token TTL, in seconds
+	renewals int
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("X-Vault-Token") != f.token {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"errors": ["permission denied"]}`)
+		return
+	}
+
+	switch req.URL.Path {
+	case "/v1/auth/token/lookup-self":
+		fmt.Fprintf(w, `{"data": {"ttl": %d, "renewable": true}}`, f.ttl)
+	case "/v1/auth/token/renew-self":
+		f.renewals++
+		fmt.Fprintf(w, `{"auth": {"lease_duration": %d, "renewable": true}}`, f.ttl)
+	case "/v1/secret/data/my-app":
+		version := len(f.versions)
+		if v := req.URL.Query().Get("version"); v != "" {
+			_, _ = fmt.Sscan(v, &version)
+		}
+		if version < 1 || version > len(f.versions) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors": []}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data": {"data": %s, "metadata": {"version": %d}}}`, f.versions[version-1], version)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors": []}`)
+	}
+}
+
+func newTestVaultProvider(addr, token string) *vaultProvider {
+	return newVaultProvider(&infra.VaultSecrets{
+		Address: addr,
+		Token:   infra.EnvString{Str: token},
+		Path:    "my-app",
+	})
+}
+
+func TestVaultProvider_Fetch(t *testing.T) {
+	fake := &fakeVault{token: "token", versions: []string{
+		`{"Password": "one", "Token": "token"}`,
+	}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	p := newTestVaultProvider(srv.URL, "token")
+	got, err := p.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{"Password": {"one"}, "Token": {"token"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Rotating the password should keep the previous version active.
+	fake.versions = append(fake.versions, `{"Password": "two", "Token": "token", "Port": 5432}`)
+	got, err = p.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = map[string][]string{"Password": {"two", "one"}, "Token": {"token"}, "Port": {"5432"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestVaultProvider_Diagnostics(t *testing.T) {
+	srv := httptest.NewServer(&fakeVault{token: "token"})
+	defer srv.Close()
+
+	_, err := newTestVaultProvider(srv.URL, "wrong").Fetch(context.Background())
+	var perr *providerError
+	if !errors.As(err, &perr) {
+		t.Fatalf("got error %v, want a providerError", err)
+	}
+	if !strings.Contains(perr.hint, "read access") {
+		t.Errorf("got hint %q, want a hint about read access", perr.hint)
+	}
+
+	// Version 1 doesn't exist.
+	_, err = newTestVaultProvider(srv.URL, "token").Fetch(context.Background())
+	if !errors.As(err, &perr) || !strings.Contains(perr.hint, "KV version 2") {
+		t.Errorf("got error %v, want a hint about the KV engine", err)
+	}
+}
+
+func TestVaultProvider_Renew(t *testing.T) {
+	fake := &fakeVault{token: "token", ttl: 0}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	// A token without a TTL is never renewed.
+	p := newTestVaultProvider(srv.URL, "token")
+	for range 2 {
+		if err := p.Renew(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fake.renewals != 0 {
+		t.Fatalf("got %d renewals, want 0", fake.renewals)
+	}
+
+	// A token is renewed after half of its TTL has passed.
+	fake.ttl = 1
+	p = newTestVaultProvider(srv.URL, "token")
+	if err := p.Renew(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	p.renewAt = p.renewAt.Add(-time.Second)
+	if err := p.Renew(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if fake.renewals != 1 {
+		t.Fatalf("got %d renewals, want 1", fake.renewals)
+	}
+}
+
+func TestManager_Provider(t *testing.T) {
+	fake := &fakeVault{token: "token", versions: []string{`{"Password": "vault"}`}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	infraCfg := filepath.Join(t.TempDir(), "infra.config.json")
+	data := fmt.Sprintf(`{"secrets": {"type": "vault", "cache_ttl": 0, "address": %q, "token": "token", "path": "my-app"}}`, srv.URL)
+	if err := os.WriteFile(infraCfg, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Runtime{EnvCloud: "local"}
+	mgr := NewManager(cfg, nil, zerolog.Nop(), infraCfg, encode("Password", "env"), "")
+	if got := mgr.Load("Password", "svc"); got != "vault" {
+		t.Fatalf("got password %q, want %q", got, "vault")
+	}
+
+	calls := 0
+	mgr.OnChange(func() { calls++ })
+	fake.versions = append(fake.versions, `{"Password": "rotated"}`)
+	mgr.Reload()
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+	if got, want := mgr.Versions("Password"), []string{"rotated", "vault"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got versions %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package etype
+
+import (
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"encore.dev/appruntime/shared/jsonapi"
+)
+
+// Int64String is a signed integer that is encoded as a JSON string.
+// It's used for fields tagged with `encore:"string"`.
+type Int64String int64
+
+func (v Int64String) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, strconv.FormatInt(int64(v), 10)), nil
+}
+
+// Uint64String is an unsigned integer that is encoded as a JSON string.
+// It's used for fields tagged with `encore:"string"`.
+type Uint64String uint64
+
+func (v Uint64String) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, strconv.FormatUint(uint64(v), 10)), nil
+}
+
+// ParseJSONIntString parses a field tagged with `encore:"string"` into dst.
+// It accepts the integer encoded both as a JSON string and as a JSON number.
+func ParseJSONIntString[T ~int | ~int64 | ~uint | ~uint64](u *Unmarshaller, field string, iter *jsoniter.Iterator, dst *T) {
+	s, ok := jsonapi.ReadIntString(iter)
+	if !ok {
+		u.setErr("invalid json parameter", field, iter.Error)
+		return
+	}
+
+	var zero T
+	if signed := zero-1 < zero; signed {
+		v, err := strconv.ParseInt(s, 10, 64)
+		u.setErr("invalid json parameter", field, err)
+		*dst = T(v)
+	} else {
+		v, err := strconv.ParseUint(s, 10, 64)
+		u.setErr("invalid json parameter", field, err)
+		*dst = T(v)
+	}
+}
@@ -0,0 +1,64 @@
+package etype
+
+import (
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"sort"
+)
+
+// multipartMaxMemory is the number of bytes of a multipart request body
+// kept in memory. The rest of the uploaded files is stored in temporary files,
+// which the API handler removes once the request has been handled.
+const multipartMaxMemory = 32 << 20
+
+// ReadMultipartForm parses the multipart/form-data body of req.
+// If it cannot be parsed it records an error and returns an empty form.
+func (u *Unmarshaller) ReadMultipartForm(req *http.Request) *multipart.Form {
+	if err := req.ParseMultipartForm(multipartMaxMemory); err != nil {
+		u.setErr("could not parse multipart request body", "request_body", err)
+		return &multipart.Form{}
+	}
+	return req.MultipartForm
+}
+
+// FormFile returns the first of the files uploaded for a field,
+// or nil if there are none.
+func (u *Unmarshaller) FormFile(files []*multipart.FileHeader) *multipart.FileHeader {
+	if len(files) == 0 {
+		return nil
+	}
+	u.NonEmptyValues++
+	return files[0]
+}
+
+// FormFiles returns the files uploaded for a field, or nil if there are none.
+func (u *Unmarshaller) FormFiles(files []*multipart.FileHeader) []*multipart.FileHeader {
+	if len(files) == 0 {
+		return nil
+	}
+	u.NonEmptyValues++
+	return files
+}
+
+// UnknownFormFields records the values and files in form
+// whose field names are not among the known ones as unknown fields.
+func (u *Unmarshaller) UnknownFormFields(form *multipart.Form, known ...string) {
+	var unknown []string
+	for key := range form.Value {
+		if !slices.Contains(known, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	for key := range form.File {
+		if !slices.Contains(known, key) && !slices.Contains(unknown, key) {
+			unknown = append(unknown, key)
+		}
+	}
+
+	// Sort the fields as map iteration order is random.
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		u.UnknownField(key)
+	}
+}
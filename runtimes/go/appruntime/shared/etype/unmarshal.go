@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
@@ -86,8 +87,17 @@ func UnmarshalFloat64(s string) (v float64, err error) {
 	return x, err
 }
 
+// UnmarshalBytes decodes base64-encoded bytes.
+// Both the standard and URL-safe alphabets are accepted, with or without padding.
 func UnmarshalBytes(s string) ([]byte, error) {
-	v, err := base64.URLEncoding.DecodeString(s)
+	enc := base64.URLEncoding
+	if strings.ContainsAny(s, "+/") {
+		enc = base64.StdEncoding
+	}
+	if !strings.HasSuffix(s, "=") {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	v, err := enc.DecodeString(s)
 	return v, err
 }
 
@@ -163,13 +173,51 @@ func UnmarshalInt64(s string) (int64, error) {
 	return x, err
 }
 
-// setErr sets the error within the object if one is not already set
+// setErr sets the error within the object if one is not already set.
+// Unknown field errors are replaced, as other errors take precedence.
 func (u *Unmarshaller) setErr(msg, field string, err error) {
-	if err != nil && u.Error == nil {
+	if err == nil {
+		return
+	}
+	if _, unknown := u.Error.(*UnknownFieldsError); u.Error == nil || unknown {
 		u.Error = fmt.Errorf("%s: %s: %w", field, msg, err)
 	}
 }
 
+// UnknownFieldsError is reported when decoding a request in strict mode
+// and the request contains fields that are not part of the request schema.
+type UnknownFieldsError struct {
+	Paths []string // the paths of the unknown fields, in the order they were encountered
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return "unknown fields: " + strings.Join(e.Paths, ", ")
+}
+
+// UnknownFieldPaths returns the paths of the unknown fields.
+func (e *UnknownFieldsError) UnknownFieldPaths() []string {
+	return e.Paths
+}
+
+// UnknownField records that the field at the given path is not part of the schema.
+// It sets the error to an *UnknownFieldsError unless another error is already set.
+func (u *Unmarshaller) UnknownField(path string) {
+	if u.Error == nil {
+		u.Error = &UnknownFieldsError{}
+	}
+	if e, ok := u.Error.(*UnknownFieldsError); ok {
+		e.Paths = append(e.Paths, path)
+	}
+}
+
+// CheckMaxSize records an error if the decoded size of the field,
+// in bytes, exceeds max. It's used for fields tagged with encore:"maxsize=N".
+func (u *Unmarshaller) CheckMaxSize(field string, size, max int) {
+	if size > max {
+		u.setErr("invalid parameter", field, fmt.Errorf("size of %d bytes exceeds the maximum of %d bytes", size, max))
+	}
+}
+
 func (u *Unmarshaller) ReadBody(body io.Reader) (payload []byte) {
 	payload, err := io.ReadAll(body)
 	if err == nil && len(payload) == 0 {
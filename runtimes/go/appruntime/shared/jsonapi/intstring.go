@@ -0,0 +1,124 @@
+package jsonapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// intStringExtension encodes 64-bit integer struct fields tagged with
+// `encore:"string"` as JSON strings, to avoid losing precision in
+// JavaScript clients where numbers are limited to 2^53.
+//
+// When decoding, both JSON strings and JSON numbers are accepted.
+type intStringExtension struct {
+	jsoniter.DummyExtension
+}
+
+func (*intStringExtension) UpdateStructDescriptor(desc *jsoniter.StructDescriptor) {
+	for _, binding := range desc.Fields {
+		if !HasStringTag(binding.Field.Tag()) {
+			continue
+		}
+		switch kind := binding.Field.Type().Kind(); kind {
+		case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+			codec := &intStringCodec{kind: kind}
+			binding.Encoder = codec
+			binding.Decoder = codec
+		}
+	}
+}
+
+// HasStringTag reports whether the struct tag contains the `encore:"string"` option.
+func HasStringTag(tag reflect.StructTag) bool {
+	for _, opt := range strings.Split(tag.Get("encore"), ",") {
+		if strings.TrimSpace(opt) == "string" {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadIntString reads an integer that is encoded either as a JSON string or a JSON number,
+// returning its textual representation. It reports false if the value is null or invalid,
+// in which case the iterator's error is set for invalid values.
+func ReadIntString(iter *jsoniter.Iterator) (string, bool) {
+	switch iter.WhatIsNext() {
+	case jsoniter.StringValue:
+		return iter.ReadString(), true
+	case jsoniter.NumberValue:
+		return string(iter.ReadNumber()), true
+	case jsoniter.NilValue:
+		iter.ReadNil()
+		return "", false
+	default:
+		iter.ReportError("ReadIntString", "expected a number or a string")
+		iter.Skip()
+		return "", false
+	}
+}
+
+type intStringCodec struct {
+	kind reflect.Kind
+}
+
+func (c *intStringCodec) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	s, ok := ReadIntString(iter)
+	if !ok {
+		return
+	}
+
+	switch c.kind {
+	case reflect.Int, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			iter.ReportError("decode integer", err.Error())
+			return
+		}
+		if c.kind == reflect.Int {
+			*(*int)(ptr) = int(v)
+		} else {
+			*(*int64)(ptr) = v
+		}
+	case reflect.Uint, reflect.Uint64:
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			iter.ReportError("decode integer", err.Error())
+			return
+		}
+		if c.kind == reflect.Uint {
+			*(*uint)(ptr) = uint(v)
+		} else {
+			*(*uint64)(ptr) = v
+		}
+	}
+}
+
+func (c *intStringCodec) IsEmpty(ptr unsafe.Pointer) bool {
+	switch c.kind {
+	case reflect.Int:
+		return *(*int)(ptr) == 0
+	case reflect.Int64:
+		return *(*int64)(ptr) == 0
+	case reflect.Uint:
+		return *(*uint)(ptr) == 0
+	default:
+		return *(*uint64)(ptr) == 0
+	}
+}
+
+func (c *intStringCodec) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	switch c.kind {
+	case reflect.Int:
+		stream.WriteString(strconv.FormatInt(int64(*(*int)(ptr)), 10))
+	case reflect.Int64:
+		stream.WriteString(strconv.FormatInt(*(*int64)(ptr), 10))
+	case reflect.Uint:
+		stream.WriteString(strconv.FormatUint(uint64(*(*uint)(ptr)), 10))
+	default:
+		stream.WriteString(strconv.FormatUint(*(*uint64)(ptr), 10))
+	}
+}
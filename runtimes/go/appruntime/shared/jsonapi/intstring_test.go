@@ -0,0 +1,64 @@
+package jsonapi
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type intStringTest struct {
+	ID      int64  `json:"id" encore:"string"`
+	Count   uint64 `json:"count,omitempty" encore:"optional,string"`
+	Regular int64  `json:"regular"`
+}
+
+func TestIntString(t *testing.T) {
+	c := qt.New(t)
+
+	data, err := Default.Marshal(intStringTest{ID: 9007199254740993, Regular: 5})
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, `{
+  "id": "9007199254740993",
+  "regular": 5
+}`)
+
+	tests := map[string]struct {
+		json    string
+		want    intStringTest
+		wantErr bool
+	}{
+		"string": {
+			json: `{"id": "9007199254740993", "count": "18446744073709551615"}`,
+			want: intStringTest{ID: 9007199254740993, Count: 18446744073709551615},
+		},
+		"number": {
+			json: `{"id": 9007199254740993, "count": 1}`,
+			want: intStringTest{ID: 9007199254740993, Count: 1},
+		},
+		"null": {
+			json: `{"id": null}`,
+			want: intStringTest{},
+		},
+		"invalid_string": {
+			json:    `{"id": "abc"}`,
+			wantErr: true,
+		},
+		"invalid_type": {
+			json:    `{"id": true}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		c.Run(name, func(c *qt.C) {
+			var got intStringTest
+			err := Default.Unmarshal([]byte(tt.json), &got)
+			if tt.wantErr {
+				c.Assert(err, qt.IsNotNil)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(got, qt.Equals, tt.want)
+		})
+	}
+}
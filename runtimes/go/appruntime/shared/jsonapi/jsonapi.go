@@ -16,10 +16,12 @@ func jsonAPI(rt *config.Runtime) jsoniter.API {
 	if rt.EnvType == "production" {
 		indentStep = 0
 	}
-	return jsoniter.Config{
+	api := jsoniter.Config{
 		EscapeHTML:             false,
 		IndentionStep:          indentStep,
 		SortMapKeys:            true,
 		ValidateJsonRawMessage: true,
 	}.Froze()
+	api.RegisterExtension(&intStringExtension{})
+	return api
 }
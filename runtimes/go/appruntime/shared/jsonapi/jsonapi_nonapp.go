@@ -14,10 +14,12 @@ import (
 var Default = jsonAPI()
 
 func jsonAPI() jsoniter.API {
-	return jsoniter.Config{
+	api := jsoniter.Config{
 		EscapeHTML:             false,
 		IndentionStep:          2,
 		SortMapKeys:            true,
 		ValidateJsonRawMessage: true,
 	}.Froze()
+	api.RegisterExtension(&intStringExtension{})
+	return api
 }
@@ -83,3 +83,47 @@ func checkAuthKey(key config.EncoreAuthKey, req *http.Request, gotMac []byte) bo
 	expected := mac.Sum(nil)
 	return hmac.Equal(expected, gotMac)
 }
+
+// ForceTraceMaxTTL is the maximum lifetime of a force-trace token.
+const ForceTraceMaxTTL = 24 * time.Hour
+
+// SignForceTraceToken returns a token that, when passed in the X-Encore-Force-Trace
+// header, forces requests to be traced regardless of the trace sampling rate
+// until the token expires.
+func SignForceTraceToken(key config.EncoreAuthKey, expires time.Time) string {
+	bytes := make([]byte, 12, 12+sha256.Size)
+	binary.BigEndian.PutUint32(bytes[0:4], key.KeyID)
+	binary.BigEndian.PutUint64(bytes[4:12], uint64(expires.Unix()))
+	bytes = append(bytes, forceTraceMAC(key, bytes[4:12])...)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// ValidateForceTraceToken reports whether token is a valid, unexpired force-trace token.
+func (c *Client) ValidateForceTraceToken(token string) bool {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(data) != 12+sha256.Size {
+		return false
+	}
+
+	// Tokens must expire within the maximum lifetime (with some leeway for clock skew),
+	// so a leaked token can't be used indefinitely.
+	const threshold = 15 * time.Minute
+	expires := time.Unix(int64(binary.BigEndian.Uint64(data[4:12])), 0)
+	if now := time.Now(); expires.Before(now) || expires.After(now.Add(ForceTraceMaxTTL+threshold)) {
+		return false
+	}
+
+	keyID := binary.BigEndian.Uint32(data[0:4])
+	for _, k := range c.runtime.AuthKeys {
+		if k.KeyID == keyID {
+			return hmac.Equal(forceTraceMAC(k, data[4:12]), data[12:])
+		}
+	}
+	return false
+}
+
+func forceTraceMAC(key config.EncoreAuthKey, expires []byte) []byte {
+	mac := hmac.New(sha256.New, key.Data)
+	_, _ = fmt.Fprintf(mac, "force-trace\x00%s", expires)
+	return mac.Sum(nil)
+}
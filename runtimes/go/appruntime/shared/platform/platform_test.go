@@ -0,0 +1,33 @@
+package platform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestForceTraceToken(t *testing.T) {
+	q := quicktest.New(t)
+
+	key := config.EncoreAuthKey{KeyID: 2, Data: []byte("secret")}
+	c := &Client{runtime: &config.Runtime{AuthKeys: []config.EncoreAuthKey{
+		{KeyID: 1, Data: []byte("other")},
+		key,
+	}}}
+
+	token := SignForceTraceToken(key, time.Now().Add(time.Hour))
+	q.Assert(c.ValidateForceTraceToken(token), quicktest.IsTrue)
+
+	// Expired tokens and tokens outliving the maximum lifetime are rejected.
+	q.Assert(c.ValidateForceTraceToken(SignForceTraceToken(key, time.Now().Add(-time.Minute))), quicktest.IsFalse)
+	q.Assert(c.ValidateForceTraceToken(SignForceTraceToken(key, time.Now().Add(7*24*time.Hour))), quicktest.IsFalse)
+
+	// Tokens signed with unknown keys, and tampered tokens, are rejected.
+	q.Assert(c.ValidateForceTraceToken(SignForceTraceToken(config.EncoreAuthKey{KeyID: 2, Data: []byte("wrong")}, time.Now().Add(time.Hour))), quicktest.IsFalse)
+	q.Assert(c.ValidateForceTraceToken(SignForceTraceToken(config.EncoreAuthKey{KeyID: 3, Data: []byte("secret")}, time.Now().Add(time.Hour))), quicktest.IsFalse)
+	q.Assert(c.ValidateForceTraceToken(token[:len(token)-2]+"AA"), quicktest.IsFalse)
+	q.Assert(c.ValidateForceTraceToken("not a token"), quicktest.IsFalse)
+}
@@ -71,7 +71,11 @@ func (c *Client) sendTraceRequest(ctx context.Context, body io.Reader) error {
 		return err
 	}
 
-	ta, err := trace2.NewTimeAnchorNow().MarshalText()
+	ta, err := trace2.TimeAnchorMode(c.runtime.TraceTimeAnchor).Anchor().MarshalText()
+	if err != nil {
+		return err
+	}
+	bootTA, err := trace2.BootTimeAnchor().MarshalText()
 	if err != nil {
 		return err
 	}
@@ -82,6 +86,10 @@ func (c *Client) sendTraceRequest(ctx context.Context, body io.Reader) error {
 	req.Header.Set("X-Encore-App-Commit", c.static.AppCommit.AsRevisionString())
 	req.Header.Set("X-Encore-Trace-Version", strconv.Itoa(int(trace2.CurrentVersion)))
 	req.Header.Set("X-Encore-Trace-TimeAnchor", string(ta))
+	req.Header.Set("X-Encore-Trace-BootTimeAnchor", string(bootTA))
+	if c.runtime.EnvRegion != "" {
+		req.Header.Set("X-Encore-Region", c.runtime.EnvRegion)
+	}
 	c.addAuthKey(req)
 
 	resp, err := http.DefaultClient.Do(req)
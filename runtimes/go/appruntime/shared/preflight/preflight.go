@@ -0,0 +1,158 @@
+// Package preflight runs checks against the infrastructure resources
+// an application depends on when it starts up, so that misconfigured
+// or unreachable resources are reported at boot rather than surfacing
+// as errors when handling requests.
+package preflight
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Check is a preflight check for a single resource.
+type Check struct {
+	Kind string // Kind is the kind of resource, like "sqldb" or "cache".
+	Name string // Name is the name of the resource.
+
+	// Run performs the check, returning a non-nil error if it failed.
+	Run func(ctx context.Context) error
+}
+
+// Result is the result of running a single check.
+type Result struct {
+	Kind     string
+	Name     string
+	Err      error // Err is the error reported by the check (nil for success).
+	Duration time.Duration
+}
+
+// Registry is a registry of preflight checks for the resources
+// used by the running application.
+type Registry struct {
+	mu     sync.Mutex
+	checks []Check
+}
+
+// NewRegistry creates a new Registry.
+//
+// If running in an app there is a [Singleton].
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register registers a preflight check for the resource
+// of the given kind and name.
+//
+// Checks are run concurrently and must respect the context deadline.
+func (r *Registry) Register(kind, name string, run func(ctx context.Context) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, Check{Kind: kind, Name: name, Run: run})
+}
+
+// Run runs all registered checks concurrently and returns a report
+// of the results. Checks that have not completed when ctx is done
+// are reported as failed.
+func (r *Registry) Run(ctx context.Context) *Report {
+	r.mu.Lock()
+	checks := slices.Clone(r.checks)
+	r.mu.Unlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	wg.Add(len(checks))
+	for i, check := range checks {
+		results[i] = Result{Kind: check.Kind, Name: check.Name}
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := runCheck(ctx, check)
+			results[i].Err = err
+			results[i].Duration = time.Since(start)
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(results, func(a, b Result) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+	return &Report{Results: results}
+}
+
+// runCheck runs a single check, recovering from panics and
+// reporting the context error if the check does not return in time.
+func runCheck(ctx context.Context, check Check) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		done <- check.Run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Report is the result of running all preflight checks.
+type Report struct {
+	Results []Result
+}
+
+// Failed returns the results of the checks that failed.
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Err returns an error describing the failed checks,
+// or nil if all checks succeeded.
+func (r *Report) Err() error {
+	failed := r.Failed()
+	switch len(failed) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("preflight check failed for %s %q: %w", failed[0].Kind, failed[0].Name, failed[0].Err)
+	default:
+		return fmt.Errorf("%d preflight checks failed, including %s %q: %w",
+			len(failed), failed[0].Kind, failed[0].Name, failed[0].Err)
+	}
+}
+
+// Log logs the report, with one log line per check
+// followed by a summary. Successful checks are logged at debug level.
+func (r *Report) Log(logger zerolog.Logger) {
+	for _, res := range r.Results {
+		ev := logger.Debug()
+		if res.Err != nil {
+			ev = logger.Error().Err(res.Err)
+		}
+		ev.Str("kind", res.Kind).Str("resource", res.Name).Dur("duration", res.Duration).
+			Msg("preflight check")
+	}
+
+	failed := len(r.Failed())
+	ev := logger.Info()
+	if failed > 0 {
+		ev = logger.Warn()
+	}
+	ev.Int("checks", len(r.Results)).Int("failed", failed).Msg("startup report")
+}
@@ -0,0 +1,48 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegistryRun(t *testing.T) {
+	r := NewRegistry()
+	r.Register("sqldb", "b", func(ctx context.Context) error { return nil })
+	r.Register("sqldb", "a", func(ctx context.Context) error { return errors.New("unreachable") })
+	r.Register("cache", "c", func(ctx context.Context) error { panic("boom") })
+	r.Register("bucket", "d", func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second) // ignore the deadline
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	report := r.Run(ctx)
+
+	var got []string
+	for _, res := range report.Results {
+		got = append(got, res.Kind+"/"+res.Name)
+	}
+	want := []string{"bucket/d", "cache/c", "sqldb/a", "sqldb/b"}
+	if len(got) != len(want) {
+		t.Fatalf("got results %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got results %v, want %v", got, want)
+		}
+	}
+
+	if failed := report.Failed(); len(failed) != 3 {
+		t.Errorf("got %d failed checks, want 3", len(failed))
+	}
+	if err := report.Results[0].Err; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got err %v for timed out check, want context.DeadlineExceeded", err)
+	}
+	if report.Err() == nil {
+		t.Error("got nil report error, want non-nil")
+	}
+}
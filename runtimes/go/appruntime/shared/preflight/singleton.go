@@ -0,0 +1,7 @@
+//go:build encore_app
+
+package preflight
+
+// Singleton is the singleton instance of the preflight check registry
+// for a running Encore application.
+var Singleton = NewRegistry()
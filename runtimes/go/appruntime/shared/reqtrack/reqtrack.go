@@ -58,6 +58,9 @@ func copyReqInfoFromParent(next, prev *model.Request) {
 		if nextData.AuthData == nil {
 			nextData.AuthData = prevData.AuthData
 		}
+		if nextData.UserID == "" && nextData.AuthErr == nil {
+			nextData.AuthErr = prevData.AuthErr
+		}
 	} else if nextData != nil && prev.Test != nil {
 		if nextData.UserID == "" {
 			nextData.UserID = prev.Test.UserID
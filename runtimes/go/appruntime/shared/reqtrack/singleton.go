@@ -17,6 +17,8 @@ func init() {
 	if tracingEnabled {
 		traceFactory = &traceprovider.DefaultFactory{
 			SampleRate: appconf.Runtime.TraceSamplingRate,
+			MemStats:   appconf.Runtime.TraceMemStats,
+			Region:     appconf.Runtime.EnvRegion,
 		}
 	}
 
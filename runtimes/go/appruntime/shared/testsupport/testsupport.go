@@ -2,6 +2,7 @@ package testsupport
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -338,6 +339,47 @@ func (mgr *Manager) GetAPIMock(service string, api string) (model.ApiMock, bool)
 	})
 }
 
+// SetHTTPInterceptor sets the round tripper intercepting outgoing HTTP requests for the current test
+func (mgr *Manager) SetHTTPInterceptor(rt http.RoundTripper) {
+	cfg := mgr.currentConfig()
+	cfg.Mu.Lock()
+	defer cfg.Mu.Unlock()
+	cfg.HTTPInterceptor = rt
+}
+
+// GetHTTPInterceptor returns the round tripper intercepting outgoing HTTP requests
+// for the current test or its closest parent test that has one.
+func (mgr *Manager) GetHTTPInterceptor() (http.RoundTripper, bool) {
+	return walkConfig(mgr.currentConfig(), func(cfg *TestConfig) (value http.RoundTripper, found bool) {
+		return cfg.HTTPInterceptor, cfg.HTTPInterceptor != nil
+	})
+}
+
+// SetSecret overrides the value of a secret for the current test
+func (mgr *Manager) SetSecret(name, value string) {
+	cfg := mgr.currentConfig()
+	cfg.Mu.Lock()
+	defer cfg.Mu.Unlock()
+	if cfg.Secrets == nil {
+		cfg.Secrets = make(map[string]string)
+	}
+	cfg.Secrets[name] = value
+}
+
+// GetSecret returns the overridden value of a secret
+// for the current test or its closest parent test that overrides it.
+func (mgr *Manager) GetSecret(name string) (string, bool) {
+	req := mgr.rt.Current().Req
+	if req == nil || req.Test == nil {
+		// Secrets can only be overridden by tests.
+		return "", false
+	}
+	return walkConfig(mgr.currentConfig(), func(cfg *TestConfig) (value string, found bool) {
+		value, found = cfg.Secrets[name]
+		return
+	})
+}
+
 func (mgr *Manager) AddEndCallback(fn func(t *testing.T)) {
 	cfg := mgr.currentConfig()
 	cfg.Mu.Lock()
@@ -101,6 +101,18 @@ func (mr *MockLoggerMockRecorder) BucketDeleteObjectsEnd(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketDeleteObjectsEnd", reflect.TypeOf((*MockLogger)(nil).BucketDeleteObjectsEnd), arg0)
 }
 
+// BucketSignedURL mocks base method.
+func (m *MockLogger) BucketSignedURL(arg0 trace2.BucketSignedURLParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BucketSignedURL", arg0)
+}
+
+// BucketSignedURL indicates an expected call of BucketSignedURL.
+func (mr *MockLoggerMockRecorder) BucketSignedURL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketSignedURL", reflect.TypeOf((*MockLogger)(nil).BucketSignedURL), arg0)
+}
+
 // BucketDeleteObjectsStart mocks base method.
 func (m *MockLogger) BucketDeleteObjectsStart(arg0 trace2.BucketDeleteObjectsStartParams) trace2.EventID {
 	m.ctrl.T.Helper()
@@ -141,6 +153,32 @@ func (mr *MockLoggerMockRecorder) BucketListObjectsStart(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketListObjectsStart", reflect.TypeOf((*MockLogger)(nil).BucketListObjectsStart), arg0)
 }
 
+// BucketObjectCopyEnd mocks base method.
+func (m *MockLogger) BucketObjectCopyEnd(arg0 trace2.BucketObjectCopyEndParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BucketObjectCopyEnd", arg0)
+}
+
+// BucketObjectCopyEnd indicates an expected call of BucketObjectCopyEnd.
+func (mr *MockLoggerMockRecorder) BucketObjectCopyEnd(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketObjectCopyEnd", reflect.TypeOf((*MockLogger)(nil).BucketObjectCopyEnd), arg0)
+}
+
+// BucketObjectCopyStart mocks base method.
+func (m *MockLogger) BucketObjectCopyStart(arg0 trace2.BucketObjectCopyStartParams) trace2.EventID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BucketObjectCopyStart", arg0)
+	ret0, _ := ret[0].(trace2.EventID)
+	return ret0
+}
+
+// BucketObjectCopyStart indicates an expected call of BucketObjectCopyStart.
+func (mr *MockLoggerMockRecorder) BucketObjectCopyStart(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketObjectCopyStart", reflect.TypeOf((*MockLogger)(nil).BucketObjectCopyStart), arg0)
+}
+
 // BucketObjectDownloadEnd mocks base method.
 func (m *MockLogger) BucketObjectDownloadEnd(arg0 trace2.BucketObjectDownloadEndParams) {
 	m.ctrl.T.Helper()
@@ -193,6 +231,32 @@ func (mr *MockLoggerMockRecorder) BucketObjectGetAttrsStart(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketObjectGetAttrsStart", reflect.TypeOf((*MockLogger)(nil).BucketObjectGetAttrsStart), arg0)
 }
 
+// BucketObjectUpdateAttrsEnd mocks base method.
+func (m *MockLogger) BucketObjectUpdateAttrsEnd(arg0 trace2.BucketObjectUpdateAttrsEndParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BucketObjectUpdateAttrsEnd", arg0)
+}
+
+// BucketObjectUpdateAttrsEnd indicates an expected call of BucketObjectUpdateAttrsEnd.
+func (mr *MockLoggerMockRecorder) BucketObjectUpdateAttrsEnd(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketObjectUpdateAttrsEnd", reflect.TypeOf((*MockLogger)(nil).BucketObjectUpdateAttrsEnd), arg0)
+}
+
+// BucketObjectUpdateAttrsStart mocks base method.
+func (m *MockLogger) BucketObjectUpdateAttrsStart(arg0 trace2.BucketObjectUpdateAttrsStartParams) trace2.EventID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BucketObjectUpdateAttrsStart", arg0)
+	ret0, _ := ret[0].(trace2.EventID)
+	return ret0
+}
+
+// BucketObjectUpdateAttrsStart indicates an expected call of BucketObjectUpdateAttrsStart.
+func (mr *MockLoggerMockRecorder) BucketObjectUpdateAttrsStart(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketObjectUpdateAttrsStart", reflect.TypeOf((*MockLogger)(nil).BucketObjectUpdateAttrsStart), arg0)
+}
+
 // BucketObjectUploadEnd mocks base method.
 func (m *MockLogger) BucketObjectUploadEnd(arg0 trace2.BucketObjectUploadEndParams) {
 	m.ctrl.T.Helper()
@@ -205,6 +269,18 @@ func (mr *MockLoggerMockRecorder) BucketObjectUploadEnd(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketObjectUploadEnd", reflect.TypeOf((*MockLogger)(nil).BucketObjectUploadEnd), arg0)
 }
 
+// BucketObjectUploadPart mocks base method.
+func (m *MockLogger) BucketObjectUploadPart(arg0 trace2.BucketObjectUploadPartParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BucketObjectUploadPart", arg0)
+}
+
+// BucketObjectUploadPart indicates an expected call of BucketObjectUploadPart.
+func (mr *MockLoggerMockRecorder) BucketObjectUploadPart(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketObjectUploadPart", reflect.TypeOf((*MockLogger)(nil).BucketObjectUploadPart), arg0)
+}
+
 // BucketObjectUploadStart mocks base method.
 func (m *MockLogger) BucketObjectUploadStart(arg0 trace2.BucketObjectUploadStartParams) trace2.EventID {
 	m.ctrl.T.Helper()
@@ -363,6 +439,18 @@ func (mr *MockLoggerMockRecorder) MarkDone() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDone", reflect.TypeOf((*MockLogger)(nil).MarkDone))
 }
 
+// PubsubMessageLink mocks base method.
+func (m *MockLogger) PubsubMessageLink(arg0 trace2.PubsubMessageLinkParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PubsubMessageLink", arg0)
+}
+
+// PubsubMessageLink indicates an expected call of PubsubMessageLink.
+func (mr *MockLoggerMockRecorder) PubsubMessageLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PubsubMessageLink", reflect.TypeOf((*MockLogger)(nil).PubsubMessageLink), arg0)
+}
+
 // PubsubMessageSpanEnd mocks base method.
 func (m *MockLogger) PubsubMessageSpanEnd(params trace2.PubsubMessageSpanEndParams) {
 	m.ctrl.T.Helper()
@@ -15,10 +15,23 @@ type DefaultFactory struct {
 	// SampleRate is the rate at which to sample traces, between [0, 1].
 	// If nil, 100% of traces are sampled.
 	SampleRate *float64
+
+	// MemStats, if true, makes traces record the memory allocated during spans.
+	MemStats bool
+
+	// Region is the cloud region to record in spans, if known.
+	Region string
 }
 
 func (f *DefaultFactory) NewLogger() trace2.Logger {
-	return trace2.NewLog()
+	log := trace2.NewLog()
+	if f.MemStats {
+		log.RecordMemStats()
+	}
+	if f.Region != "" {
+		log.SetRegion(f.Region)
+	}
+	return log
 }
 
 func (f *DefaultFactory) SampleTrace() bool {
@@ -5,6 +5,7 @@ package auth
 
 import (
 	"context"
+	"fmt"
 
 	"encore.dev/appruntime/apisdk/api"
 	"encore.dev/appruntime/exported/model"
@@ -48,6 +49,52 @@ func (mgr *Manager) Data() interface{} {
 	return nil
 }
 
+// Status describes the outcome of authenticating a request.
+type Status int
+
+const (
+	// StatusAnonymous means the request didn't include any authentication information.
+	StatusAnonymous Status = iota
+
+	// StatusAuthenticated means the auth handler successfully authenticated the request.
+	StatusAuthenticated
+
+	// StatusRejected means the request included authentication information,
+	// but the auth handler rejected it by returning an errs.Unauthenticated error.
+	//
+	// It only happens for public endpoints, as requests to endpoints
+	// requiring authentication are aborted with the error instead.
+	StatusRejected
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusAnonymous:
+		return "anonymous"
+	case StatusAuthenticated:
+		return "authenticated"
+	case StatusRejected:
+		return "rejected"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+func (mgr *Manager) RequestStatus() (Status, error) {
+	if curr := mgr.rt.Current(); curr.Req != nil {
+		if data := curr.Req.RPCData; data != nil {
+			if data.UserID != "" {
+				return StatusAuthenticated, nil
+			} else if data.AuthErr != nil {
+				return StatusRejected, data.AuthErr
+			}
+		} else if curr.Req.Test != nil && curr.Req.Test.UserID != "" {
+			return StatusAuthenticated, nil
+		}
+	}
+	return StatusAnonymous, nil
+}
+
 // WithContext returns a new context that sets the auth information for outgoing API calls.
 // It does not affect the auth information for the current request.
 //
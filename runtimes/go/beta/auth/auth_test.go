@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/beta/errs"
+)
+
+func TestManager_RequestStatus(t *testing.T) {
+	rejected := errs.B().Code(errs.Unauthenticated).Msg("invalid token").Err()
+	rpcReq := func(uid UID, authErr error) *model.Request {
+		return &model.Request{
+			Type: model.RPCCall,
+			RPCData: &model.RPCData{
+				Desc:    &model.RPCDesc{Service: "svc", Endpoint: "Endpoint"},
+				UserID:  uid,
+				AuthErr: authErr,
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		// reqs are the requests to begin, each nested in the previous one
+		// like a service-to-service call.
+		reqs    []*model.Request
+		want    Status
+		wantErr error
+	}{
+		{
+			name: "no_request",
+			want: StatusAnonymous,
+		},
+		{
+			name: "anonymous",
+			reqs: []*model.Request{rpcReq("", nil)},
+			want: StatusAnonymous,
+		},
+		{
+			name: "authenticated",
+			reqs: []*model.Request{rpcReq("alice", nil)},
+			want: StatusAuthenticated,
+		},
+		{
+			name:    "rejected",
+			reqs:    []*model.Request{rpcReq("", rejected)},
+			want:    StatusRejected,
+			wantErr: rejected,
+		},
+		{
+			name: "test",
+			reqs: []*model.Request{{Type: model.Test, Test: &model.TestData{UserID: "alice"}}},
+			want: StatusAuthenticated,
+		},
+		{
+			name: "service_call_authenticated",
+			reqs: []*model.Request{rpcReq("alice", nil), rpcReq("", nil)},
+			want: StatusAuthenticated,
+		},
+		{
+			name:    "service_call_rejected",
+			reqs:    []*model.Request{rpcReq("", rejected), rpcReq("", nil)},
+			want:    StatusRejected,
+			wantErr: rejected,
+		},
+		{
+			name: "service_call_with_auth_override",
+			reqs: []*model.Request{rpcReq("", rejected), rpcReq("bob", nil)},
+			want: StatusAuthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := reqtrack.New(zerolog.Nop(), nil, nil)
+			mgr := NewManager(rt)
+			for _, req := range tt.reqs {
+				rt.BeginRequest(req)
+			}
+			if len(tt.reqs) > 0 {
+				defer rt.FinishRequest(false)
+			}
+
+			got, err := mgr.RequestStatus()
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("RequestStatus() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
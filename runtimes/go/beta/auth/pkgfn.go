@@ -25,3 +25,22 @@ func UserID() (UID, bool) {
 func Data() any {
 	return Singleton.Data()
 }
+
+// RequestStatus reports the outcome of authenticating the current request.
+//
+// It lets public endpoints distinguish between requests made without
+// authentication information, and requests whose authentication information
+// was rejected by the auth handler. In the latter case it also returns the
+// error returned by the auth handler.
+//
+//	switch status, err := auth.RequestStatus(); status {
+//	case auth.StatusAuthenticated:
+//		// auth.UserID() and auth.Data() are set
+//	case auth.StatusRejected:
+//		// the credentials were invalid; err describes why
+//	case auth.StatusAnonymous:
+//		// no credentials were provided
+//	}
+func RequestStatus() (Status, error) {
+	return Singleton.RequestStatus()
+}
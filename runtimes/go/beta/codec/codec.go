@@ -0,0 +1,141 @@
+// Package codec lets Encore applications accept and produce request and
+// response bodies in formats other than JSON, such as XML or MessagePack.
+//
+// Codecs are registered for the whole application, typically from an init function:
+//
+//	func init() {
+//		codec.Register(codec.XML)
+//	}
+//
+// Once registered, API endpoints decode request bodies using the codec matching
+// the request's Content-Type header, and encode responses using the codec
+// negotiated from the request's Accept header. JSON remains the default
+// and is always supported.
+//
+// Codecs decode into and encode from the endpoint's request and response
+// structs in full, so use the codec's own struct tags (like `xml:"-"`) to
+// exclude fields that are sent as headers or query strings.
+// Errors are always returned as JSON.
+package codec
+
+import (
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Codec encodes and decodes request and response bodies of a given media type.
+type Codec interface {
+	// ContentType returns the media type handled by the codec, such as "application/xml".
+	ContentType() string
+
+	// Marshal encodes v.
+	Marshal(v any) ([]byte, error)
+
+	// Unmarshal decodes data into v, which is a pointer to the value to decode.
+	Unmarshal(data []byte, v any) error
+}
+
+// XML is a codec for "application/xml" using the encoding/xml package.
+var XML Codec = xmlCodec{}
+
+type xmlCodec struct{}
+
+func (xmlCodec) ContentType() string                { return "application/xml" }
+func (xmlCodec) Marshal(v any) ([]byte, error)      { return xml.Marshal(v) }
+func (xmlCodec) Unmarshal(data []byte, v any) error { return xml.Unmarshal(data, v) }
+
+var (
+	mu     sync.RWMutex
+	codecs = make(map[string]Codec) // keyed by media type
+)
+
+// Register registers a codec for the application, replacing
+// any codec previously registered for the same media type.
+//
+// It panics if the codec's media type is invalid or is "application/json",
+// which is always handled by Encore.
+func Register(c Codec) {
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err != nil {
+		panic(fmt.Sprintf("codec: invalid content type %q: %v", c.ContentType(), err))
+	} else if isJSON(mediaType) {
+		panic("codec: cannot register a codec for application/json")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	codecs[mediaType] = c
+}
+
+// ForContentType returns the codec to decode a body with the given
+// Content-Type header, or nil if the body should be decoded as JSON.
+//
+//publicapigen:drop
+func ForContentType(contentType string) Codec {
+	if contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	return codecs[mediaType]
+}
+
+// Negotiate returns the codec to encode a response for a request
+// with the given Accept header, or nil if the response should be encoded as JSON.
+//
+// JSON is used unless a registered codec is preferred over JSON by the
+// Accept header. Media ranges like "*/*" are treated as accepting JSON.
+//
+//publicapigen:drop
+func Negotiate(accept string) Codec {
+	if accept == "" {
+		return nil
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if len(codecs) == 0 {
+		return nil
+	}
+
+	var (
+		best  Codec
+		bestQ = 0.0
+	)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+
+		// Earlier entries win ties, matching the order the client listed them in.
+		if q <= bestQ {
+			continue
+		}
+		if isJSON(mediaType) || strings.HasSuffix(mediaType, "/*") {
+			best, bestQ = nil, q
+		} else if c, ok := codecs[mediaType]; ok {
+			best, bestQ = c, q
+		}
+	}
+	return best
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json"
+}
@@ -0,0 +1,60 @@
+package codec
+
+import (
+	"testing"
+)
+
+type testCodec struct{ contentType string }
+
+func (c testCodec) ContentType() string                { return c.contentType }
+func (c testCodec) Marshal(v any) ([]byte, error)      { return nil, nil }
+func (c testCodec) Unmarshal(data []byte, v any) error { return nil }
+
+func TestNegotiate(t *testing.T) {
+	msgpack := testCodec{"application/msgpack"}
+	Register(XML)
+	Register(msgpack)
+
+	tests := []struct {
+		accept string
+		want   Codec
+	}{
+		{"", nil},
+		{"application/json", nil},
+		{"*/*", nil},
+		{"application/xml", XML},
+		{"text/html, application/xml", XML},
+		{"application/xml, application/json", XML},
+		{"application/json, application/xml", nil},
+		{"application/json;q=0.5, application/msgpack", msgpack},
+		{"application/xml;q=0.2, */*;q=0.8", nil},
+		{"application/xml;q=invalid, application/msgpack;q=0.1", msgpack},
+		{"text/csv", nil},
+	}
+	for _, tt := range tests {
+		if got := Negotiate(tt.accept); got != tt.want {
+			t.Errorf("Negotiate(%q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestForContentType(t *testing.T) {
+	Register(XML)
+
+	tests := []struct {
+		contentType string
+		want        Codec
+	}{
+		{"", nil},
+		{"application/json", nil},
+		{"application/xml", XML},
+		{"application/xml; charset=utf-8", XML},
+		{"text/xml", nil},
+		{"invalid;;", nil},
+	}
+	for _, tt := range tests {
+		if got := ForContentType(tt.contentType); got != tt.want {
+			t.Errorf("ForContentType(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+
+	"encore.dev/appruntime/exported/stack"
+)
+
+// A Definition is a reusable error, declared with Define.
+//
+// Errors created from a definition carry its ID, which is returned
+// to clients so they can match on it instead of on the error message.
+type Definition struct {
+	// ID uniquely identifies the error within the application,
+	// such as "user.not_found".
+	ID string
+	// Code is the error code of errors created from the definition.
+	Code ErrCode
+	// Message is the default message of errors created from the definition.
+	Message string
+}
+
+// Define declares a reusable error with the given id, code and default message.
+//
+// The id must be unique within the application and consist of lowercase,
+// dot-separated segments, such as "user.not_found". Encore includes the
+// definitions in the API metadata, and generated clients expose them
+// as typed constants.
+//
+// Define must be called at package level, with constant arguments:
+//
+//	var ErrUserNotFound = errs.Define("user.not_found", errs.NotFound, "user not found")
+func Define(id string, code ErrCode, msg string) *Definition {
+	return &Definition{ID: id, Code: code, Message: msg}
+}
+
+// New returns a new error of the definition, with
+// the given metadata key-value pairs.
+func (d *Definition) New(metaPairs ...interface{}) error {
+	return &Error{
+		Code:    d.Code,
+		Message: d.Message,
+		ErrorID: d.ID,
+		Meta:    mergeMeta(nil, metaPairs),
+		stack:   stack.Build(2),
+	}
+}
+
+// Newf is like New but uses fmt.Sprintf to construct
+// the message, instead of using the default message.
+func (d *Definition) Newf(format string, args ...interface{}) error {
+	return &Error{
+		Code:    d.Code,
+		Message: fmt.Sprintf(format, args...),
+		ErrorID: d.ID,
+		stack:   stack.Build(2),
+	}
+}
+
+// Wrap returns a new error of the definition wrapping err,
+// with the given metadata key-value pairs. If err is nil it returns nil.
+func (d *Definition) Wrap(err error, metaPairs ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	e := &Error{Code: d.Code, Message: d.Message, ErrorID: d.ID, underlying: err}
+	var ee *Error
+	if errors.As(err, &ee) {
+		e.Meta = mergeMeta(ee.Meta, metaPairs)
+		e.stack = ee.stack
+	} else {
+		e.Meta = mergeMeta(nil, metaPairs)
+		e.stack = stack.Build(2)
+	}
+	return e
+}
+
+// Is reports whether err is, or wraps, an error of the definition.
+// It works for errors returned by API calls to other services, too.
+func (d *Definition) Is(err error) bool {
+	var e *Error
+	for errors.As(err, &e) {
+		if e.ErrorID == d.ID {
+			return true
+		}
+		err = e.underlying
+	}
+	return false
+}
+
+// ErrorID reports the id of the definition the error was created from.
+// If err is nil or was not created from a definition it reports "".
+func ErrorID(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.ErrorID
+	}
+	return ""
+}
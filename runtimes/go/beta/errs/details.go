@@ -9,3 +9,26 @@ package errs
 type ErrDetails interface {
 	ErrDetails() // marker method; it need not do anything
 }
+
+// ValidationDetails are the details of an InvalidArgument error
+// reported when a request fails the rules declared by its
+// "validate" struct tags.
+type ValidationDetails struct {
+	// Fields are the fields failing validation, in field order.
+	Fields []FieldError `json:"fields"`
+}
+
+func (ValidationDetails) ErrDetails() {}
+
+// FieldError describes a request field failing a validation rule.
+type FieldError struct {
+	// Field is the path to the field, using the field names of the request,
+	// such as "address.zip_code" or "items[2].name".
+	Field string `json:"field"`
+
+	// Rule is the rule that failed, such as "max=100".
+	Rule string `json:"rule"`
+
+	// Message describes the failure, such as "must be at most 100".
+	Message string `json:"message"`
+}
@@ -42,6 +42,9 @@ type Error struct {
 	Message string `json:"message"`
 	// Details are user-defined additional details.
 	Details ErrDetails `json:"details"`
+	// ErrorID is the id of the error definition the error
+	// was created from, if any. See Define.
+	ErrorID string `json:"error_id,omitempty"`
 	// Meta are arbitrary key-value pairs for use within
 	// the Encore application. They are not exposed to external clients.
 	Meta Metadata `json:"-"`
@@ -74,6 +77,7 @@ func Wrap(err error, msg string, metaPairs ...interface{}) error {
 	if errors.As(err, &ee) {
 		e.Details = ee.Details
 		e.Code = ee.Code
+		e.ErrorID = ee.ErrorID
 		e.Meta = mergeMeta(ee.Meta, metaPairs)
 		e.stack = ee.stack
 	} else {
@@ -124,6 +128,7 @@ func Convert(err error) error {
 			Code:       e.Code,
 			Message:    err.Error(),
 			Details:    e.Details,
+			ErrorID:    e.ErrorID,
 			Meta:       e.Meta,
 			underlying: err,
 			stack:      e.stack,
@@ -251,6 +256,11 @@ func init() {
 		stream.WriteObjectField("message")
 		stream.WriteString(e.ErrorMessage())
 		stream.WriteMore()
+		if e.ErrorID != "" {
+			stream.WriteObjectField("error_id")
+			stream.WriteString(e.ErrorID)
+			stream.WriteMore()
+		}
 		stream.WriteObjectField("details")
 		stream.WriteVal(e.Details)
 		stream.WriteObjectEnd()
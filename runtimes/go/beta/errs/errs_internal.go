@@ -66,6 +66,7 @@ func RoundTrip(err error) error {
 		e2 := &Error{
 			Code:    e.Code,
 			Message: e.Message,
+			ErrorID: e.ErrorID,
 			stack:   stack.Build(3), // skip caller of RoundTrip as well
 		}
 
@@ -199,7 +200,7 @@ func HTTPErrorWithCode(w http.ResponseWriter, err error, code int) {
 	data, err2 := json.MarshalIndent(e, "", "  ")
 	if err2 != nil {
 		// Must be the details; drop them
-		e2 := &Error{Code: e.Code, Message: e.Message}
+		e2 := &Error{Code: e.Code, Message: e.Message, ErrorID: e.ErrorID}
 		data, _ = json.MarshalIndent(e2, "", "  ")
 	}
 	w.WriteHeader(code)
@@ -221,6 +222,12 @@ func writeErrorFieldsToInternalStream(e *Error, stream *jsoniter.Stream) {
 	stream.WriteObjectField(errmarshalling.MessageKey)
 	stream.WriteString(e.Message)
 
+	if e.ErrorID != "" {
+		stream.WriteMore()
+		stream.WriteObjectField("error_id")
+		stream.WriteString(e.ErrorID)
+	}
+
 	if len(e.Meta) > 0 {
 		if err := errmarshalling.TryWriteValue(stream, "meta", e.Meta); err != nil {
 			// Only report the error in the JSON stream
@@ -246,6 +253,8 @@ func unmarshalFromInternalIterator(e *Error, itr *jsoniter.Iterator) {
 			e.Code = ErrCode(itr.ReadInt())
 		case errmarshalling.MessageKey:
 			e.Message = itr.ReadString()
+		case "error_id":
+			e.ErrorID = itr.ReadString()
 		case "meta":
 			itr.ReadVal(&e.Meta)
 		case errmarshalling.WrappedKey:
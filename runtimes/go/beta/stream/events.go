@@ -0,0 +1,254 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/shared/jsonapi"
+	"encore.dev/beta/errs"
+)
+
+// heartbeatInterval is how often a comment is sent on an idle event stream,
+// which keeps proxies and load balancers from closing the connection.
+var heartbeatInterval = 15 * time.Second
+
+// reconnectDelay is how long clients wait before reconnecting
+// to an event stream after losing the connection.
+const reconnectDelay = 3 * time.Second
+
+// Events is the response of an API endpoint streaming server-sent events
+// of type T to the client.
+//
+// An endpoint streams events by returning a *stream.Events[T]:
+//
+//	//encore:api public method=GET path=/orders/:id/updates
+//	func Updates(ctx context.Context, id int) (*stream.Events[OrderUpdate], error) {
+//		order, err := getOrder(ctx, id)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return stream.NewEvents(func(ctx context.Context, w *stream.EventWriter[OrderUpdate]) error {
+//			for update := range watchOrder(ctx, order, w.LastEventID()) {
+//				if err := w.SendWithID(update.ID, update); err != nil {
+//					return err
+//				}
+//			}
+//			return nil
+//		}), nil
+//	}
+//
+// Errors returned by the endpoint itself are sent as regular error responses.
+// Once the endpoint returns the events are streamed to the client
+// with the Content-Type text/event-stream, and each event is sent
+// as soon as it's written. Idle streams are kept alive with heartbeats.
+//
+// When the stream ends an "end" event is sent, and if the function
+// producing the events fails an "error" event with the error is sent,
+// after which the connection is closed.
+type Events[T any] struct {
+	fn func(ctx context.Context, w *EventWriter[T]) error
+}
+
+// NewEvents returns a response streaming the events written by fn.
+//
+// The stream ends when fn returns. The context passed to fn is canceled
+// when the client disconnects.
+func NewEvents[T any](fn func(ctx context.Context, w *EventWriter[T]) error) *Events[T] {
+	return &Events[T]{fn: fn}
+}
+
+// ServeStream streams the events to the client.
+// It's called by the Encore runtime once the endpoint has returned.
+//
+//publicapigen:drop
+func (e *Events[T]) ServeStream(w http.ResponseWriter, req *http.Request) error {
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Del("Content-Length")
+	// Keep reverse proxies from buffering the stream.
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	ew := &EventWriter[T]{
+		out:         &flushWriter{w: w, rc: http.NewResponseController(w)},
+		lastEventID: req.Header.Get("Last-Event-ID"),
+	}
+	if err := ew.write("retry: " + strconv.FormatInt(reconnectDelay.Milliseconds(), 10) + "\n\n"); err != nil {
+		return nil // the client is gone
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		ew.heartbeat(ctx)
+	}()
+
+	err := e.produce(ctx, ew)
+	cancel()
+	<-heartbeatDone
+
+	if req.Context().Err() != nil {
+		// The client disconnected, which is how event streams normally end.
+		err = nil
+	} else if err != nil {
+		_ = ew.writeEvent("error", "", marshalError(err))
+	} else {
+		_ = ew.writeEvent("end", "", []byte("null"))
+	}
+
+	traceEvents(ew.events, ew.out.n)
+	return err
+}
+
+// produce calls the function producing the events,
+// reporting panics as errors.
+func (e *Events[T]) produce(ctx context.Context, w *EventWriter[T]) (err error) {
+	if e == nil || e.fn == nil {
+		return nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = errs.B().Code(errs.Internal).Msgf("panic streaming events: %v", r).Err()
+		}
+	}()
+	return e.fn(ctx, w)
+}
+
+// marshalError encodes an error for an "error" event,
+// in the same format as error responses.
+func marshalError(err error) []byte {
+	e := errs.Convert(err).(*errs.Error)
+	data, merr := json.Marshal(e)
+	if merr != nil {
+		// Must be the details; drop them.
+		data, _ = json.Marshal(&errs.Error{Code: e.Code, Message: e.Message})
+	}
+	return data
+}
+
+// EventWriter writes server-sent events of type T to the client.
+// It's safe for concurrent use.
+type EventWriter[T any] struct {
+	lastEventID string
+
+	mu      sync.Mutex
+	out     *flushWriter
+	scratch bytes.Buffer
+	wrote   bool // whether anything was written since the last heartbeat
+	events  int64
+	err     error
+}
+
+// LastEventID returns the ID of the last event the client received,
+// as reported by the client when it reconnects after losing the connection.
+// It's empty for new connections.
+//
+// Endpoints supporting reconnection use it to resume the stream
+// after that event, which requires sending events using SendWithID.
+func (w *EventWriter[T]) LastEventID() string {
+	return w.lastEventID
+}
+
+// Send sends an event to the client.
+//
+// Once Send returns an error the client has disconnected
+// and all further calls return the same error.
+func (w *EventWriter[T]) Send(data T) error {
+	return w.SendWithID("", data)
+}
+
+// SendWithID sends an event with the given ID to the client.
+// If the client reconnects after losing the connection, the ID of the
+// last event it received is reported by LastEventID.
+//
+// Once SendWithID returns an error the client has disconnected
+// and all further calls return the same error.
+func (w *EventWriter[T]) SendWithID(id string, data T) error {
+	if strings.ContainsAny(id, "\r\n\x00") {
+		return errors.New("stream: event id must not contain newlines or NUL characters")
+	}
+
+	payload, err := jsonapi.Default.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if err := w.writeEvent("", id, payload); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.events++
+	w.mu.Unlock()
+	return nil
+}
+
+// writeEvent writes a single event with the given name, ID and JSON payload.
+func (w *EventWriter[T]) writeEvent(name, id string, payload []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+
+	// The payload must be on a single line.
+	w.scratch.Reset()
+	if name != "" {
+		w.scratch.WriteString("event: " + name + "\n")
+	}
+	if id != "" {
+		w.scratch.WriteString("id: " + id + "\n")
+	}
+	w.scratch.WriteString("data: ")
+	if err := json.Compact(&w.scratch, payload); err != nil {
+		return err
+	}
+	w.scratch.WriteString("\n\n")
+	return w.writeLocked(w.scratch.Bytes())
+}
+
+func (w *EventWriter[T]) write(s string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writeLocked([]byte(s))
+}
+
+func (w *EventWriter[T]) writeLocked(p []byte) error {
+	if w.err != nil {
+		return w.err
+	}
+	if _, err := w.out.Write(p); err != nil {
+		w.err = err
+		return err
+	}
+	w.wrote = true
+	return nil
+}
+
+// heartbeat sends a comment whenever nothing has been written
+// for a heartbeat interval, until ctx is canceled.
+func (w *EventWriter[T]) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			if !w.wrote {
+				_ = w.writeLocked([]byte(": heartbeat\n\n"))
+			}
+			w.wrote = false
+			w.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/beta/errs"
+)
+
+type tick struct {
+	N   int    `json:"n"`
+	Msg string `json:"msg,omitempty"`
+}
+
+func TestEvents(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/events", nil)
+	req.Header.Set("Last-Event-ID", "1")
+
+	events := NewEvents(func(ctx context.Context, w *EventWriter[tick]) error {
+		c.Assert(w.LastEventID(), qt.Equals, "1")
+		c.Assert(w.SendWithID("2", tick{N: 2}), qt.IsNil)
+		c.Assert(w.Send(tick{N: 3, Msg: "multi\nline"}), qt.IsNil)
+		c.Assert(w.SendWithID("bad\nid", tick{}), qt.IsNotNil)
+		return nil
+	})
+	c.Assert(events.ServeStream(rec, req), qt.IsNil)
+
+	c.Assert(rec.Header().Get("Content-Type"), qt.Equals, "text/event-stream")
+	c.Assert(rec.Header().Get("Cache-Control"), qt.Equals, "no-cache")
+	c.Assert(rec.Flushed, qt.IsTrue)
+	c.Assert(rec.Body.String(), qt.Equals, "retry: 3000\n\n"+
+		"id: 2\n"+`data: {"n":2}`+"\n\n"+
+		`data: {"n":3,"msg":"multi\nline"}`+"\n\n"+
+		"event: end\ndata: null\n\n")
+}
+
+func TestEvents_Error(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/events", nil)
+
+	wantErr := errs.B().Code(errs.Unavailable).Msg("feed unavailable").Err()
+	events := NewEvents(func(ctx context.Context, w *EventWriter[tick]) error {
+		return wantErr
+	})
+	c.Assert(events.ServeStream(rec, req), qt.Equals, wantErr)
+	c.Assert(rec.Body.String(), qt.Equals, "retry: 3000\n\n"+
+		`event: error`+"\n"+`data: {"code":"unavailable","message":"feed unavailable","details":null}`+"\n\n")
+}
+
+func TestEvents_Disconnect(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest("GET", "/events", nil).WithContext(ctx)
+
+	events := NewEvents(func(ctx context.Context, w *EventWriter[tick]) error {
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	// Clients disconnecting is how streams normally end, so it's not an error.
+	c.Assert(events.ServeStream(rec, req), qt.IsNil)
+	c.Assert(strings.Contains(rec.Body.String(), "event:"), qt.IsFalse)
+}
+
+func TestEvents_Heartbeat(t *testing.T) {
+	c := qt.New(t)
+	defer func(d time.Duration) { heartbeatInterval = d }(heartbeatInterval)
+	heartbeatInterval = 10 * time.Millisecond
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/events", nil)
+	events := NewEvents(func(ctx context.Context, w *EventWriter[tick]) error {
+		time.Sleep(50 * time.Millisecond)
+		return errors.New("done")
+	})
+	c.Assert(events.ServeStream(rec, req), qt.IsNotNil)
+	c.Assert(strings.Contains(rec.Body.String(), ": heartbeat\n\n"), qt.IsTrue)
+}
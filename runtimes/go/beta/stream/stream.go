@@ -0,0 +1,376 @@
+// Package stream provides typed readers and writers for streams of records,
+// for use by bulk-import and export API endpoints.
+//
+// An API endpoint receives a stream of records by declaring
+// a *stream.Reader[T] as its request payload:
+//
+//	//encore:api public method=POST path=/users/import
+//	func Import(ctx context.Context, users *stream.Reader[User]) (*ImportResponse, error) {
+//		for user, err := range users.All() {
+//			if err != nil {
+//				// Handle the invalid record, or return err to abort the import.
+//				continue
+//			}
+//			// Import the user.
+//		}
+//		return &ImportResponse{}, nil
+//	}
+//
+// The request body is decoded as newline-delimited JSON or CSV
+// depending on the request's Content-Type header, one record at a time.
+// Records are only read from the request body as they are consumed,
+// so a slow consumer slows down the client instead of buffering the
+// whole request in memory.
+//
+// Conversely, a raw endpoint streams records to the client as a file
+// download using a Writer:
+//
+//	//encore:api public raw method=GET path=/users/export
+//	func Export(w http.ResponseWriter, req *http.Request) {
+//		sw := stream.NewWriter[User](w, stream.CSV, "users.csv")
+//		for user := range listUsers(req.Context()) {
+//			if err := sw.Write(user); err != nil {
+//				return
+//			}
+//		}
+//		sw.Close()
+//	}
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"encore.dev/appruntime/shared/jsonapi"
+	"encore.dev/beta/errs"
+)
+
+// Format is the encoding of a stream of records.
+type Format int
+
+const (
+	// NDJSON is newline-delimited JSON, with one JSON object per line.
+	// Blank lines are ignored.
+	NDJSON Format = iota
+
+	// CSV is comma-separated values with a header row.
+	// Columns are matched to struct fields using the field's `csv` tag,
+	// falling back to its `json` tag and then its name (case-insensitively).
+	// Columns without a matching field are ignored.
+	CSV
+
+	// XLSX is an Excel spreadsheet with a header row, using the same
+	// column names as CSV. It's only supported for writing.
+	XLSX
+)
+
+func (f Format) String() string {
+	switch f {
+	case NDJSON:
+		return "ndjson"
+	case CSV:
+		return "csv"
+	case XLSX:
+		return "xlsx"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// RecordError is the error yielded for a record that could not be decoded.
+// The stream continues past records that fail to decode.
+type RecordError struct {
+	// Line is the line number of the record, starting at 1.
+	// For CSV streams the header row is line 1.
+	Line int
+
+	// Err is the underlying decoding error.
+	Err error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads a stream of records of type T.
+//
+// A Reader can only be iterated once.
+type Reader[T any] struct {
+	r      io.Reader
+	format Format
+	err    error
+	read   bool
+}
+
+// NewReader returns a Reader that decodes records of type T from r.
+func NewReader[T any](r io.Reader, format Format) *Reader[T] {
+	return &Reader[T]{r: r, format: format}
+}
+
+// NewRequestReader returns a Reader that decodes records of type T from the
+// request body, using the format given by the request's Content-Type header.
+//
+//publicapigen:drop
+func NewRequestReader[T any](req *http.Request) (*Reader[T], error) {
+	format, err := formatForContentType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	return NewReader[T](req.Body, format), nil
+}
+
+// formatForContentType returns the stream format for the given Content-Type header.
+// Requests without a Content-Type are treated as newline-delimited JSON.
+func formatForContentType(contentType string) (Format, error) {
+	if contentType == "" {
+		return NDJSON, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return 0, errs.B().Code(errs.InvalidArgument).Msgf("invalid Content-Type header: %v", err).Err()
+	}
+	switch mediaType {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return NDJSON, nil
+	case "text/csv":
+		return CSV, nil
+	default:
+		return 0, errs.B().Code(errs.InvalidArgument).Msgf("unsupported Content-Type %q for record stream", mediaType).Err()
+	}
+}
+
+// All returns an iterator over the records in the stream.
+//
+// Records that fail to decode are yielded together with a *RecordError,
+// and iteration continues with the next record. If reading the stream itself
+// fails, the error is yielded last and iteration stops; it is also reported by Err.
+func (r *Reader[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if r.read {
+			return
+		}
+		r.read = true
+
+		var err error
+		switch r.format {
+		case NDJSON:
+			err = r.readNDJSON(yield)
+		case CSV:
+			err = r.readCSV(yield)
+		default:
+			err = fmt.Errorf("stream: reading %v streams is not supported", r.format)
+		}
+		if err != nil {
+			r.err = err
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Err returns the error, if any, that stopped the stream from being read.
+// Errors for individual records are not reported by Err.
+func (r *Reader[T]) Err() error {
+	return r.err
+}
+
+func (r *Reader[T]) readNDJSON(yield func(T, error) bool) error {
+	br := bufio.NewReader(r.r)
+	for line := 1; ; line++ {
+		data, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			var rec T
+			var recErr error
+			if uerr := jsonapi.Default.Unmarshal(data, &rec); uerr != nil {
+				recErr = &RecordError{Line: line, Err: uerr}
+			}
+			if !yield(rec, recErr) {
+				return nil
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+func (r *Reader[T]) readCSV(yield func(T, error) bool) error {
+	cr := csv.NewReader(r.r)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("stream: read csv header: %w", err)
+	}
+
+	dec, err := newCSVDecoder[T](header)
+	if err != nil {
+		return err
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var rec T
+		var recErr error
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// Malformed rows are reported per record; the reader can continue past them.
+			recErr = &RecordError{Line: parseErr.Line, Err: parseErr.Err}
+		} else if err != nil {
+			return err
+		} else if derr := dec.decode(&rec, row); derr != nil {
+			line, _ := cr.FieldPos(0)
+			recErr = &RecordError{Line: line, Err: derr}
+		}
+		if !yield(rec, recErr) {
+			return nil
+		}
+	}
+}
+
+// csvDecoder decodes CSV rows into values of a struct type.
+type csvDecoder struct {
+	// fields maps each column to the index path of its struct field,
+	// or nil if the column doesn't map to a field.
+	fields [][]int
+	header []string
+}
+
+func newCSVDecoder[T any](header []string) (*csvDecoder, error) {
+	typ := reflect.TypeFor[T]()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("stream: cannot decode csv into %s: not a struct", typ)
+	}
+
+	byName := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name := csvFieldName(f)
+		if name == "-" {
+			continue
+		}
+		byName[strings.ToLower(name)] = f.Index
+	}
+
+	d := &csvDecoder{
+		fields: make([][]int, len(header)),
+		header: append([]string(nil), header...),
+	}
+	for i, col := range header {
+		d.fields[i] = byName[strings.ToLower(strings.TrimSpace(col))]
+	}
+	return d, nil
+}
+
+// csvFieldName returns the column name for a struct field.
+func csvFieldName(f reflect.StructField) string {
+	for _, key := range []string{"csv", "json"} {
+		if tag, ok := f.Tag.Lookup(key); ok {
+			if name, _, _ := strings.Cut(tag, ","); name != "" {
+				return name
+			}
+		}
+	}
+	return f.Name
+}
+
+func (d *csvDecoder) decode(dst any, row []string) error {
+	v := reflect.ValueOf(dst).Elem()
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	for i, val := range row {
+		if i >= len(d.fields) || d.fields[i] == nil {
+			continue
+		}
+		if err := setValue(v.FieldByIndex(d.fields[i]), val); err != nil {
+			return fmt.Errorf("column %q: %w", d.header[i], err)
+		}
+	}
+	return nil
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// setValue parses s into v based on v's type. Empty values leave v unchanged.
+func setValue(v reflect.Value, s string) error {
+	if s == "" {
+		return nil
+	}
+
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setValue(v.Elem(), s)
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
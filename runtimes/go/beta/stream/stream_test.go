@@ -0,0 +1,128 @@
+package stream
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type user struct {
+	Name     string
+	Email    string `json:"email"`
+	Age      int    `csv:"years"`
+	Admin    *bool
+	JoinedAt time.Time `json:"joined_at"`
+	Ignored  string    `csv:"-"`
+}
+
+func collect[T any](r *Reader[T]) (recs []T, errs []error) {
+	for rec, err := range r.All() {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	return recs, errs
+}
+
+func TestReader_NDJSON(t *testing.T) {
+	c := qt.New(t)
+	body := `{"Name":"Alice","email":"alice@example.com","Age":30}
+
+{"Name":"Bob","Age":"invalid"}
+{"Name":"Carol"}`
+
+	recs, errs := collect(NewReader[user](strings.NewReader(body), NDJSON))
+	c.Assert(recs, qt.HasLen, 2)
+	c.Assert(recs[0].Name, qt.Equals, "Alice")
+	c.Assert(recs[0].Email, qt.Equals, "alice@example.com")
+	c.Assert(recs[0].Age, qt.Equals, 30)
+	c.Assert(recs[1].Name, qt.Equals, "Carol")
+
+	c.Assert(errs, qt.HasLen, 1)
+	var recErr *RecordError
+	c.Assert(errors.As(errs[0], &recErr), qt.IsTrue)
+	c.Assert(recErr.Line, qt.Equals, 3)
+}
+
+func TestReader_CSV(t *testing.T) {
+	c := qt.New(t)
+	body := `name,email,years,admin,joined_at,ignored,unknown
+Alice,alice@example.com,30,true,2024-01-02T03:04:05Z,x,y
+Bob,bob@example.com,old,,,,
+Carol,,,false,,,
+`
+
+	recs, errs := collect(NewReader[user](strings.NewReader(body), CSV))
+	c.Assert(recs, qt.HasLen, 2)
+	c.Assert(recs[0].Name, qt.Equals, "Alice")
+	c.Assert(recs[0].Email, qt.Equals, "alice@example.com")
+	c.Assert(recs[0].Age, qt.Equals, 30)
+	c.Assert(*recs[0].Admin, qt.IsTrue)
+	c.Assert(recs[0].JoinedAt.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), qt.IsTrue)
+	c.Assert(recs[0].Ignored, qt.Equals, "")
+	c.Assert(recs[1].Name, qt.Equals, "Carol")
+	c.Assert(*recs[1].Admin, qt.IsFalse)
+
+	c.Assert(errs, qt.HasLen, 1)
+	var recErr *RecordError
+	c.Assert(errors.As(errs[0], &recErr), qt.IsTrue)
+	c.Assert(recErr.Line, qt.Equals, 3)
+	c.Assert(recErr.Error(), qt.Matches, `line 3: column "years": .*invalid syntax`)
+}
+
+func TestReader_CSVNotStruct(t *testing.T) {
+	c := qt.New(t)
+	r := NewReader[string](strings.NewReader("a\nb\n"), CSV)
+	_, errs := collect(r)
+	c.Assert(errs, qt.HasLen, 1)
+	c.Assert(r.Err(), qt.ErrorMatches, `stream: cannot decode csv into string: not a struct`)
+}
+
+func TestReader_Stop(t *testing.T) {
+	c := qt.New(t)
+	r := NewReader[user](strings.NewReader("{}\n{}\n{}\n"), NDJSON)
+	n := 0
+	for range r.All() {
+		n++
+		break
+	}
+	c.Assert(n, qt.Equals, 1)
+	c.Assert(r.Err(), qt.IsNil)
+
+	// The reader can only be iterated once.
+	recs, errs := collect(r)
+	c.Assert(recs, qt.HasLen, 0)
+	c.Assert(errs, qt.HasLen, 0)
+}
+
+func TestNewRequestReader(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		contentType string
+		want        Format
+		wantErr     string
+	}{
+		{"", NDJSON, ""},
+		{"application/x-ndjson", NDJSON, ""},
+		{"application/jsonl; charset=utf-8", NDJSON, ""},
+		{"text/csv", CSV, ""},
+		{"application/xml", 0, `invalid_argument: unsupported Content-Type "application/xml" for record stream`},
+	}
+	for _, test := range tests {
+		req, _ := http.NewRequest("POST", "/", strings.NewReader(""))
+		req.Header.Set("Content-Type", test.contentType)
+		r, err := NewRequestReader[user](req)
+		if test.wantErr != "" {
+			c.Assert(err, qt.ErrorMatches, test.wantErr)
+			continue
+		}
+		c.Assert(err, qt.IsNil)
+		c.Assert(r.format, qt.Equals, test.want)
+	}
+}
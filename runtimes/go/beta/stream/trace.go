@@ -0,0 +1,15 @@
+//go:build encore_app
+
+package stream
+
+import "encore.dev/rlog"
+
+// traceWritten records a completed stream response in the current request's trace.
+func traceWritten(format Format, rows, bytes int64) {
+	rlog.Debug("stream: response written", "format", format.String(), "rows", rows, "bytes", bytes)
+}
+
+// traceEvents records a completed event stream in the current request's trace.
+func traceEvents(events, bytes int64) {
+	rlog.Debug("stream: events sent", "events", events, "bytes", bytes)
+}
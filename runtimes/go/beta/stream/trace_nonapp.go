@@ -0,0 +1,9 @@
+//go:build !encore_app
+
+package stream
+
+// traceWritten is a no-op outside of Encore applications, where there is no request trace.
+func traceWritten(format Format, rows, bytes int64) {}
+
+// traceEvents is a no-op outside of Encore applications, where there is no request trace.
+func traceEvents(events, bytes int64) {}
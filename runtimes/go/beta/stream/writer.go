@@ -0,0 +1,436 @@
+package stream
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"encore.dev/appruntime/shared/jsonapi"
+)
+
+// flushSize is the amount of buffered data written to the client at a time.
+// Each flush is sent as a separate chunk, which bounds the memory used
+// by a Writer regardless of the number of records written.
+const flushSize = 32 * 1024
+
+// Writer writes a stream of records of type T to an HTTP response,
+// for use by export endpoints.
+//
+// Records are encoded and sent to the client as they are written,
+// using chunked transfer encoding, so exports of any size use a
+// bounded amount of memory. The response headers are written together
+// with the first record, so an endpoint can still respond with an error
+// until then.
+//
+// The Writer must be closed with Close once all records have been written.
+type Writer[T any] struct {
+	w        http.ResponseWriter
+	format   Format
+	filename string
+
+	out     *flushWriter
+	buf     *bufio.Writer
+	scratch bytes.Buffer
+	cols    []column
+	csv     *csv.Writer
+	xlsx    *xlsxEncoder
+	started bool
+	closed  bool
+	rows    int64
+	err     error
+}
+
+// NewWriter returns a Writer that writes records of type T to w in the given format.
+//
+// If filename is non-empty the response is sent as an attachment with that name,
+// which makes browsers download it as a file.
+func NewWriter[T any](w http.ResponseWriter, format Format, filename string) *Writer[T] {
+	return &Writer[T]{w: w, format: format, filename: filename}
+}
+
+// WriteAll writes the records yielded by rows to w and closes the writer.
+// If rows yields an error, writing stops and the error is returned.
+//
+// See NewWriter for a description of the parameters.
+func WriteAll[T any](w http.ResponseWriter, format Format, filename string, rows iter.Seq2[T, error]) error {
+	sw := NewWriter[T](w, format, filename)
+	for rec, err := range rows {
+		if err != nil {
+			return err
+		}
+		if err := sw.Write(rec); err != nil {
+			return err
+		}
+	}
+	return sw.Close()
+}
+
+// Write writes a single record.
+//
+// Once Write returns an error the response is incomplete,
+// and all further calls to Write and Close return the same error.
+func (w *Writer[T]) Write(rec T) error {
+	if w.closed {
+		return errors.New("stream: write to closed writer")
+	} else if err := w.start(); err != nil {
+		return err
+	}
+
+	var err error
+	if w.format == NDJSON {
+		err = w.writeNDJSON(rec)
+	} else {
+		err = w.writeRow(reflect.ValueOf(&rec).Elem())
+	}
+	if err != nil {
+		w.err = err
+		return err
+	}
+	w.rows++
+	return nil
+}
+
+// Close writes any remaining data to the client and completes the response.
+// Closing a Writer without writing any records writes an empty stream,
+// with only the header row for CSV and XLSX.
+//
+// The number of records and bytes written are recorded in the request's trace.
+func (w *Writer[T]) Close() error {
+	if w.closed {
+		return w.err
+	} else if err := w.start(); err != nil {
+		return err
+	}
+	w.closed = true
+
+	var err error
+	switch {
+	case w.csv != nil:
+		w.csv.Flush()
+		err = w.csv.Error()
+	case w.xlsx != nil:
+		err = w.xlsx.close()
+	}
+	if err == nil {
+		err = w.buf.Flush()
+	}
+	if err != nil {
+		w.err = err
+	}
+
+	traceWritten(w.format, w.rows, w.out.n)
+	return w.err
+}
+
+// start writes the response headers and the header row, if not already done.
+func (w *Writer[T]) start() error {
+	if w.err != nil || w.started {
+		return w.err
+	}
+	w.started = true
+
+	if w.format != NDJSON {
+		cols, err := columnsFor[T]()
+		if err != nil {
+			w.err = err
+			return err
+		}
+		w.cols = cols
+	}
+
+	contentType, err := contentTypeFor(w.format)
+	if err != nil {
+		w.err = err
+		return err
+	}
+	h := w.w.Header()
+	h.Set("Content-Type", contentType)
+	h.Del("Content-Length")
+	if w.filename != "" {
+		h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": w.filename}))
+	}
+
+	w.out = &flushWriter{w: w.w, rc: http.NewResponseController(w.w)}
+	w.buf = bufio.NewWriterSize(w.out, flushSize)
+
+	names := make([]string, len(w.cols))
+	for i, col := range w.cols {
+		names[i] = col.name
+	}
+	switch w.format {
+	case CSV:
+		w.csv = csv.NewWriter(w.buf)
+		err = w.csv.Write(names)
+	case XLSX:
+		w.xlsx, err = newXLSXEncoder(w.buf)
+		if err == nil {
+			err = w.xlsx.writeHeader(names)
+		}
+	}
+	if err != nil {
+		w.err = err
+	}
+	return err
+}
+
+func (w *Writer[T]) writeNDJSON(rec T) error {
+	data, err := jsonapi.Default.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("stream: encode record %d: %w", w.rows+1, err)
+	}
+	// Each record must be on a single line.
+	w.scratch.Reset()
+	if err := json.Compact(&w.scratch, data); err != nil {
+		return err
+	}
+	w.scratch.WriteByte('\n')
+	_, err = w.buf.Write(w.scratch.Bytes())
+	return err
+}
+
+func (w *Writer[T]) writeRow(v reflect.Value) error {
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	cells := make([]cell, len(w.cols))
+	if v.IsValid() {
+		for i, col := range w.cols {
+			fv, err := v.FieldByIndexErr(col.index)
+			if err != nil {
+				// A nil embedded pointer; leave the cell empty.
+				continue
+			}
+			if cells[i], err = formatValue(fv); err != nil {
+				return fmt.Errorf("stream: encode record %d: column %q: %w", w.rows+1, col.name, err)
+			}
+		}
+	}
+
+	if w.csv != nil {
+		row := make([]string, len(cells))
+		for i, c := range cells {
+			row[i] = c.value
+		}
+		return w.csv.Write(row)
+	}
+	return w.xlsx.writeRow(cells)
+}
+
+// contentTypeFor returns the Content-Type header for the given stream format.
+func contentTypeFor(format Format) (string, error) {
+	switch format {
+	case NDJSON:
+		return "application/x-ndjson", nil
+	case CSV:
+		return "text/csv; charset=utf-8", nil
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", nil
+	default:
+		return "", fmt.Errorf("stream: unknown format %v", format)
+	}
+}
+
+// flushWriter writes to an http.ResponseWriter, flushing after every write
+// so each write is sent to the client as a separate chunk.
+type flushWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+	n  int64 // number of bytes written
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.n += int64(n)
+	if err == nil {
+		if ferr := f.rc.Flush(); ferr != nil && !errors.Is(ferr, http.ErrNotSupported) {
+			err = ferr
+		}
+	}
+	return n, err
+}
+
+// column is a column of a CSV or XLSX stream.
+type column struct {
+	name  string
+	index []int // index path of the struct field
+}
+
+// columnsFor returns the columns for a record type, in struct field order.
+func columnsFor[T any]() ([]column, error) {
+	typ := reflect.TypeFor[T]()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("stream: cannot encode %s as a table: not a struct", typ)
+	}
+
+	var cols []column
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name := csvFieldName(f)
+		if name == "-" {
+			continue
+		}
+		cols = append(cols, column{name: name, index: f.Index})
+	}
+	return cols, nil
+}
+
+// cellKind is the type of a cell's value.
+type cellKind int
+
+const (
+	cellEmpty cellKind = iota
+	cellString
+	cellNumber
+	cellBool
+)
+
+// cell is a formatted field value.
+type cell struct {
+	kind  cellKind
+	value string
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// formatValue formats v as a cell. It's the inverse of setValue.
+func formatValue(v reflect.Value) (cell, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return cell{}, nil
+		}
+		return formatValue(v.Elem())
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return cell{kind: cellString, value: string(text)}, err
+	} else if v.CanAddr() && v.Addr().Type().Implements(textMarshalerType) {
+		text, err := v.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		return cell{kind: cellString, value: string(text)}, err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return cell{kind: cellString, value: v.String()}, nil
+	case reflect.Bool:
+		return cell{kind: cellBool, value: strconv.FormatBool(v.Bool())}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cell{kind: cellNumber, value: strconv.FormatInt(v.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cell{kind: cellNumber, value: strconv.FormatUint(v.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return cell{kind: cellNumber, value: strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())}, nil
+	default:
+		return cell{}, fmt.Errorf("unsupported field type %s", v.Type())
+	}
+}
+
+// xlsxEncoder writes a single-sheet XLSX workbook.
+//
+// The worksheet is the last part of the archive so that rows can be
+// written as they come, without holding the sheet in memory.
+type xlsxEncoder struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+}
+
+// xlsxParts are the fixed parts of the workbook, by name.
+var xlsxParts = []struct{ name, data string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+func newXLSXEncoder(w io.Writer) (*xlsxEncoder, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.data); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(f)
+	sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return &xlsxEncoder{zw: zw, sheet: sheet}, nil
+}
+
+func (e *xlsxEncoder) writeHeader(names []string) error {
+	cells := make([]cell, len(names))
+	for i, name := range names {
+		cells[i] = cell{kind: cellString, value: name}
+	}
+	return e.writeRow(cells)
+}
+
+func (e *xlsxEncoder) writeRow(cells []cell) error {
+	w := e.sheet
+	w.WriteString("<row>")
+	for _, c := range cells {
+		switch c.kind {
+		case cellEmpty:
+			w.WriteString("<c/>")
+		case cellNumber:
+			w.WriteString(`<c t="n"><v>` + c.value + `</v></c>`)
+		case cellBool:
+			v := "0"
+			if c.value == "true" {
+				v = "1"
+			}
+			w.WriteString(`<c t="b"><v>` + v + `</v></c>`)
+		default:
+			w.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			if err := xml.EscapeText(w, []byte(c.value)); err != nil {
+				return err
+			}
+			w.WriteString(`</t></is></c>`)
+		}
+	}
+	_, err := w.WriteString("</row>")
+	return err
+}
+
+func (e *xlsxEncoder) close() error {
+	e.sheet.WriteString(`</sheetData></worksheet>`)
+	if err := e.sheet.Flush(); err != nil {
+		return err
+	}
+	return e.zw.Close()
+}
@@ -0,0 +1,130 @@
+package stream
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func testUsers() []user {
+	admin := true
+	return []user{
+		{Name: "Alice", Email: "alice@example.com", Age: 30, Admin: &admin, JoinedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Ignored: "x"},
+		{Name: `Bob "B" <b>`, Age: 40},
+	}
+}
+
+func TestWriter_CSV(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	w := NewWriter[user](rec, CSV, "users.csv")
+	for _, u := range testUsers() {
+		c.Assert(w.Write(u), qt.IsNil)
+	}
+	c.Assert(w.Close(), qt.IsNil)
+
+	c.Assert(rec.Header().Get("Content-Type"), qt.Equals, "text/csv; charset=utf-8")
+	c.Assert(rec.Header().Get("Content-Disposition"), qt.Equals, `attachment; filename=users.csv`)
+	c.Assert(rec.Flushed, qt.IsTrue)
+	c.Assert(rec.Body.String(), qt.Equals, `Name,email,years,Admin,joined_at
+Alice,alice@example.com,30,true,2024-01-02T03:04:05Z
+"Bob ""B"" <b>",,40,,0001-01-01T00:00:00Z
+`)
+
+	// The written CSV can be read back.
+	recs, errs := collect(NewReader[user](rec.Body, CSV))
+	c.Assert(errs, qt.HasLen, 0)
+	c.Assert(recs, qt.HasLen, 2)
+	c.Assert(recs[1].Name, qt.Equals, `Bob "B" <b>`)
+}
+
+func TestWriter_NDJSON(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	c.Assert(WriteAll(rec, NDJSON, "", func(yield func(user, error) bool) {
+		for _, u := range testUsers() {
+			if !yield(u, nil) {
+				return
+			}
+		}
+	}), qt.IsNil)
+
+	c.Assert(rec.Header().Get("Content-Type"), qt.Equals, "application/x-ndjson")
+	c.Assert(rec.Header().Get("Content-Disposition"), qt.Equals, "")
+	recs, errs := collect(NewReader[user](rec.Body, NDJSON))
+	c.Assert(errs, qt.HasLen, 0)
+	c.Assert(recs, qt.HasLen, 2)
+	c.Assert(recs[0].Email, qt.Equals, "alice@example.com")
+}
+
+func TestWriter_XLSX(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	w := NewWriter[*user](rec, XLSX, "users.xlsx")
+	for _, u := range testUsers() {
+		c.Assert(w.Write(&u), qt.IsNil)
+	}
+	c.Assert(w.Close(), qt.IsNil)
+	c.Assert(rec.Header().Get("Content-Type"), qt.Equals, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	c.Assert(err, qt.IsNil)
+	var names []string
+	var sheet string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			r, err := f.Open()
+			c.Assert(err, qt.IsNil)
+			data, err := io.ReadAll(r)
+			c.Assert(err, qt.IsNil)
+			sheet = string(data)
+		}
+	}
+	c.Assert(names, qt.DeepEquals, []string{
+		"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml",
+	})
+	c.Assert(sheet, qt.Contains, `<row><c t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`)
+	c.Assert(sheet, qt.Contains, `<c t="n"><v>30</v></c><c t="b"><v>1</v></c>`)
+	c.Assert(sheet, qt.Contains, `Bob &#34;B&#34; &lt;b&gt;`)
+	c.Assert(sheet, qt.Contains, `<c t="n"><v>40</v></c><c/>`)
+	c.Assert(strings.HasSuffix(sheet, `</sheetData></worksheet>`), qt.IsTrue)
+}
+
+func TestWriter_Empty(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	c.Assert(NewWriter[user](rec, CSV, "").Close(), qt.IsNil)
+	c.Assert(rec.Body.String(), qt.Equals, "Name,email,years,Admin,joined_at\n")
+}
+
+func TestWriter_Errors(t *testing.T) {
+	c := qt.New(t)
+
+	// Non-struct records can't be written as a table.
+	rec := httptest.NewRecorder()
+	w := NewWriter[string](rec, CSV, "")
+	c.Assert(w.Write("a"), qt.ErrorMatches, `stream: cannot encode string as a table: not a struct`)
+	c.Assert(w.Close(), qt.ErrorMatches, `stream: cannot encode string as a table: not a struct`)
+	c.Assert(rec.Header().Get("Content-Type"), qt.Equals, "")
+
+	// Errors from the records iterator are returned as-is.
+	rec = httptest.NewRecorder()
+	iterErr := errors.New("query failed")
+	err := WriteAll(rec, CSV, "", func(yield func(user, error) bool) {
+		yield(user{}, iterErr)
+	})
+	c.Assert(err, qt.Equals, iterErr)
+
+	// Writing to a closed writer fails.
+	w2 := NewWriter[user](httptest.NewRecorder(), CSV, "")
+	c.Assert(w2.Close(), qt.IsNil)
+	c.Assert(w2.Write(user{}), qt.ErrorMatches, `stream: write to closed writer`)
+}
@@ -6,7 +6,7 @@ package cron
 // NewJob defines a new cron job. It is specially recognized by the Encore Parser
 // and results in the Encore Platform provisioning the cron job on next deploy.
 // Note that cron jobs do not automatically execute when running the application locally.
-// To test the cron job implementation, test the target endpoint directly.
+// To test the cron job implementation, run it with encore.dev/et.RunCron.
 //
 // The id argument is a unique identifier you give to each cron job. If you later
 // refactor the code and move the cron job definition to another package, Encore uses
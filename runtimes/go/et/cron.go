@@ -0,0 +1,70 @@
+//go:build encore_app
+
+package et
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/cron"
+)
+
+// CronOption is a function that can be passed to RunCron to configure the execution.
+type CronOption func(*cronOptions)
+
+//publicapigen:keep
+type cronOptions struct {
+	scheduled time.Time
+}
+
+// ScheduledAt is a CronOption that sets the time the execution is simulated
+// to have been scheduled for. It defaults to the current time.
+func ScheduledAt(t time.Time) CronOption {
+	return func(options *cronOptions) {
+		options.scheduled = t
+	}
+}
+
+// RunCron executes the cron job's endpoint within the current test,
+// the same way it's called when the job is triggered on its schedule,
+// and returns the error returned by the endpoint.
+//
+// The endpoint sees the execution through encore.CurrentRequest, where
+// CronIdempotencyKey identifies it and Started is the time it was scheduled for.
+// Mocks of the endpoint and its service apply as for any other call.
+//
+// For example:
+//
+//	var cleanupJob = cron.NewJob("cleanup", cron.JobConfig{
+//		Every:    cron.Hour,
+//		Endpoint: Cleanup,
+//	})
+//
+// Can be tested with:
+//
+//	err := et.RunCron(t, cleanupJob, et.ScheduledAt(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+func RunCron(t *testing.T, job *cron.Job, opts ...CronOption) error {
+	t.Helper()
+	if Singleton.runtime.EnvType != "test" {
+		panic("et: cannot run cron jobs in non-test environment")
+	}
+	options := &cronOptions{scheduled: time.Now()}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	caller, ok := Singleton.server.HandlerForFunc(job.Endpoint).(api.CronCaller)
+	if !ok {
+		t.Fatalf("et: the endpoint of cron job %q does not appear to be an Encore API", job.ID)
+	}
+
+	exec := &model.CronExecution{
+		ID:        fmt.Sprintf("%s-%d", job.ID, options.scheduled.Unix()),
+		Scheduled: options.scheduled,
+	}
+	return caller.CallCron(Singleton.server.NewCallContext(context.Background()), exec)
+}
@@ -0,0 +1,294 @@
+package et
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// HTTPInterceptor intercepts outgoing HTTP requests made during a test,
+// recording them and responding with stubbed responses instead of making
+// them over the network. Create one with InterceptHTTP.
+//
+// Requests that match no stub fail with an error, unless AllowPassthrough
+// has been called, in which case they're made over the network as usual.
+type HTTPInterceptor struct {
+	mgr  *Manager
+	next http.RoundTripper
+
+	mu          sync.Mutex
+	stubs       []*HTTPStub
+	calls       []*HTTPCall
+	passthrough bool
+}
+
+// HTTPCall is an outgoing HTTP request recorded by an HTTPInterceptor.
+type HTTPCall struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+
+	// StatusCode is the status code of the response,
+	// or 0 if the request failed.
+	StatusCode int
+}
+
+// HTTPStub is a stubbed response to the requests matching a method and URL.
+type HTTPStub struct {
+	mgr     *Manager
+	method  string
+	pattern *url.URL
+	prefix  bool
+
+	mu      sync.Mutex
+	respond func(*http.Request) (*http.Response, error)
+	calls   int
+}
+
+// Stub stubs the response to requests with the given method and URL,
+// responding with 200 OK and an empty body until configured otherwise.
+//
+// The method may be "*" to match any method. The URL matches requests with the
+// same scheme, host and path, and that have all the query parameters it specifies.
+// A URL ending with "*" matches all paths beginning with what precedes it.
+//
+// Stubs are matched in the order they're added, so add more specific stubs first.
+func (i *HTTPInterceptor) Stub(method, rawURL string) *HTTPStub {
+	s := newHTTPStub(i.mgr, method, rawURL)
+	s.Respond(http.StatusOK, "")
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.stubs = append(i.stubs, s)
+	return s
+}
+
+// AllowPassthrough makes requests that match no stub
+// be made over the network, instead of failing.
+func (i *HTTPInterceptor) AllowPassthrough() *HTTPInterceptor {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.passthrough = true
+	return i
+}
+
+// Calls returns the requests made so far, in order.
+func (i *HTTPInterceptor) Calls() []*HTTPCall {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]*HTTPCall(nil), i.calls...)
+}
+
+// CallsTo returns the requests made so far with the given method and URL,
+// matched the same way as by Stub.
+func (i *HTTPInterceptor) CallsTo(method, rawURL string) []*HTTPCall {
+	s := newHTTPStub(i.mgr, method, rawURL)
+	var matched []*HTTPCall
+	for _, c := range i.Calls() {
+		if s.matches(c.Method, c.URL) {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// RoundTrip implements http.RoundTripper.
+func (i *HTTPInterceptor) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := &HTTPCall{
+		Method: req.Method,
+		URL:    req.URL,
+		Header: req.Header.Clone(),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		call.Body = body
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	i.mu.Lock()
+	i.calls = append(i.calls, call)
+	var stub *HTTPStub
+	for _, s := range i.stubs {
+		if s.matches(req.Method, req.URL) {
+			stub = s
+			break
+		}
+	}
+	passthrough := i.passthrough
+	i.mu.Unlock()
+
+	var (
+		resp *http.Response
+		err  error
+	)
+	switch {
+	case stub != nil:
+		resp, err = stub.serve(req)
+	case passthrough:
+		resp, err = i.next.RoundTrip(req)
+	default:
+		err = fmt.Errorf("et: no stub matches %s %s", req.Method, req.URL)
+	}
+
+	if resp != nil {
+		i.mu.Lock()
+		call.StatusCode = resp.StatusCode
+		i.mu.Unlock()
+	}
+	return resp, err
+}
+
+// newHTTPStub returns a stub matching requests with the given method and URL.
+func newHTTPStub(mgr *Manager, method, rawURL string) *HTTPStub {
+	u, err := url.Parse(strings.TrimSuffix(rawURL, "*"))
+	if err != nil {
+		panic(fmt.Sprintf("et: invalid url %q: %v", rawURL, err))
+	}
+	return &HTTPStub{
+		mgr:     mgr,
+		method:  strings.ToUpper(method),
+		pattern: u,
+		prefix:  strings.HasSuffix(rawURL, "*"),
+	}
+}
+
+// Respond makes the stub respond with the given status code and body.
+func (s *HTTPStub) Respond(status int, body string) *HTTPStub {
+	return s.RespondWith(func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse(req, status, nil, []byte(body)), nil
+	})
+}
+
+// RespondJSON makes the stub respond with the given status code and
+// the JSON encoding of v. It panics if v cannot be encoded.
+func (s *HTTPStub) RespondJSON(status int, v any) *HTTPStub {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("et: cannot encode stub response: %v", err))
+	}
+	header := http.Header{"Content-Type": {"application/json"}}
+	return s.RespondWith(func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse(req, status, header.Clone(), body), nil
+	})
+}
+
+// RespondError makes the stub fail requests with the given error,
+// as if they could not be made.
+func (s *HTTPStub) RespondError(err error) *HTTPStub {
+	return s.RespondWith(func(*http.Request) (*http.Response, error) {
+		return nil, err
+	})
+}
+
+// RespondWith makes the stub respond to requests by calling fn.
+func (s *HTTPStub) RespondWith(fn func(req *http.Request) (*http.Response, error)) *HTTPStub {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.respond = fn
+	return s
+}
+
+// Times reports a test failure unless the stub has been called exactly n times
+// by the end of the current test.
+func (s *HTTPStub) Times(n int) *HTTPStub {
+	s.mgr.testMgr.AddEndCallback(func(t *testing.T) {
+		if got := s.CallCount(); got != n {
+			t.Errorf("et: stub for %s was called %d times, want %d", s, got, n)
+		}
+	})
+	return s
+}
+
+// CallCount returns the number of requests the stub has responded to.
+func (s *HTTPStub) CallCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func (s *HTTPStub) String() string {
+	str := s.method + " " + s.pattern.String()
+	if s.prefix {
+		str += "*"
+	}
+	return str
+}
+
+func (s *HTTPStub) serve(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	s.calls++
+	respond := s.respond
+	s.mu.Unlock()
+	return respond(req)
+}
+
+// matches reports whether a request with the given method and URL matches the stub.
+func (s *HTTPStub) matches(method string, u *url.URL) bool {
+	p := s.pattern
+	if s.method != "*" && s.method != method {
+		return false
+	}
+	if !strings.EqualFold(p.Scheme, u.Scheme) || !strings.EqualFold(p.Host, u.Host) {
+		return false
+	}
+	if s.prefix {
+		if !strings.HasPrefix(u.Path, p.Path) {
+			return false
+		}
+	} else if strings.TrimSuffix(u.Path, "/") != strings.TrimSuffix(p.Path, "/") {
+		return false
+	}
+
+	query := u.Query()
+	for key, values := range p.Query() {
+		for _, v := range values {
+			if !slices.Contains(query[key], v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func newHTTPResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// interceptingTransport routes outgoing HTTP requests to the
+// HTTPInterceptor of the current test, if there is one.
+type interceptingTransport struct {
+	mgr  *Manager
+	next http.RoundTripper
+}
+
+func (t *interceptingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt, ok := t.mgr.testMgr.GetHTTPInterceptor(); ok {
+		return rt.RoundTrip(req)
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,142 @@
+package et
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+// roundTripFunc is a http.RoundTripper standing in for the network.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+var errNetwork = errors.New("network access")
+
+func newTestInterceptor() *HTTPInterceptor {
+	return &HTTPInterceptor{next: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errNetwork
+	})}
+}
+
+func get(t *testing.T, rt http.RoundTripper, method, url, body string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rt.RoundTrip(req)
+}
+
+func TestHTTPInterceptor_Stub(t *testing.T) {
+	i := newTestInterceptor()
+	user := i.Stub("GET", "https://api.example.com/users/1").RespondJSON(200, map[string]string{"name": "Alice"})
+	search := i.Stub("GET", "https://api.example.com/search?q=foo").Respond(404, "not found")
+	files := i.Stub("*", "https://api.example.com/files/*")
+
+	tests := []struct {
+		method, url string
+		wantStatus  int
+		wantBody    string
+		wantErr     bool
+	}{
+		{"GET", "https://api.example.com/users/1", 200, `{"name":"Alice"}`, false},
+		{"GET", "https://API.example.com/users/1/", 200, `{"name":"Alice"}`, false},
+		{"POST", "https://api.example.com/users/1", 0, "", true},
+		{"GET", "https://api.example.com/search?q=foo&page=2", 404, "not found", false},
+		{"GET", "https://api.example.com/search?q=bar", 0, "", true},
+		{"PUT", "https://api.example.com/files/a/b.txt", 200, "", false},
+		{"GET", "http://api.example.com/users/1", 0, "", true},
+	}
+	for _, tt := range tests {
+		resp, err := get(t, i, tt.method, tt.url, "")
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "no stub matches") {
+				t.Errorf("%s %s: got err %v, want no stub error", tt.method, tt.url, err)
+			}
+			continue
+		} else if err != nil {
+			t.Fatalf("%s %s: %v", tt.method, tt.url, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != tt.wantStatus || string(body) != tt.wantBody {
+			t.Errorf("%s %s: got %d %q, want %d %q", tt.method, tt.url, resp.StatusCode, body, tt.wantStatus, tt.wantBody)
+		}
+	}
+
+	if got := user.CallCount(); got != 2 {
+		t.Errorf("got %d calls to user stub, want 2", got)
+	}
+	if got := search.CallCount(); got != 1 {
+		t.Errorf("got %d calls to search stub, want 1", got)
+	}
+	if got := files.CallCount(); got != 1 {
+		t.Errorf("got %d calls to files stub, want 1", got)
+	}
+	if got := len(i.Calls()); got != len(tests) {
+		t.Errorf("got %d recorded calls, want %d", got, len(tests))
+	}
+}
+
+func TestHTTPInterceptor_Calls(t *testing.T) {
+	i := newTestInterceptor()
+	i.Stub("POST", "https://api.example.com/charges").Respond(201, "")
+
+	resp, err := get(t, i, "POST", "https://api.example.com/charges", `{"amount":100}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	calls := i.CallsTo("POST", "https://api.example.com/charges")
+	if len(calls) != 1 {
+		t.Fatalf("got %d calls, want 1", len(calls))
+	}
+	if c := calls[0]; string(c.Body) != `{"amount":100}` || c.StatusCode != 201 {
+		t.Errorf("got call with body %q status %d, want %q 201", c.Body, c.StatusCode, `{"amount":100}`)
+	}
+	if got := i.CallsTo("GET", "https://api.example.com/*"); len(got) != 0 {
+		t.Errorf("got %d GET calls, want 0", len(got))
+	}
+}
+
+func TestHTTPInterceptor_Passthrough(t *testing.T) {
+	i := newTestInterceptor().AllowPassthrough()
+	if _, err := get(t, i, "GET", "https://api.example.com/", ""); !errors.Is(err, errNetwork) {
+		t.Errorf("got err %v, want %v", err, errNetwork)
+	}
+}
+
+func TestInterceptingTransport(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, nil)
+	mgr := &Manager{testMgr: testsupport.NewManager(&config.Static{}, rt, zerolog.Nop())}
+	transport := &interceptingTransport{mgr: mgr, next: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errNetwork
+	})}
+
+	// Outside of a test, requests go to the network.
+	if _, err := get(t, transport, "GET", "https://api.example.com/", ""); !errors.Is(err, errNetwork) {
+		t.Errorf("got err %v, want %v", err, errNetwork)
+	}
+
+	rt.BeginOperation()
+	defer rt.FinishOperation()
+	rt.BeginRequest(&model.Request{Test: &model.TestData{Config: &model.TestConfig{}}})
+	defer rt.FinishRequest(false)
+
+	i := newTestInterceptor()
+	i.Stub("GET", "https://api.example.com/")
+	mgr.testMgr.SetHTTPInterceptor(i)
+	if resp, err := get(t, transport, "GET", "https://api.example.com/", ""); err != nil || resp.StatusCode != 200 {
+		t.Errorf("got err %v, want stubbed response", err)
+	}
+}
@@ -1,6 +1,8 @@
 package et
 
 import (
+	"net/http"
+
 	"encore.dev/appruntime/apisdk/api"
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/shared/reqtrack"
@@ -16,6 +18,10 @@ type Manager struct {
 	testMgr *testsupport.Manager
 	server  *api.Server
 	db      *sqldb.Manager
+
+	// httpTransport is installed as http.DefaultTransport,
+	// to route outgoing HTTP requests made during tests.
+	httpTransport *interceptingTransport
 }
 
 //publicapigen:drop
@@ -24,5 +30,8 @@ func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.Req
 		panic("et: cannot create manager in non-test environment")
 	}
 
-	return &Manager{static, runtime, rt, testMgr, server, db}
+	mgr := &Manager{static: static, runtime: runtime, rt: rt, testMgr: testMgr, server: server, db: db}
+	mgr.httpTransport = &interceptingTransport{mgr: mgr, next: http.DefaultTransport}
+	http.DefaultTransport = mgr.httpTransport
+	return mgr
 }
@@ -50,6 +50,30 @@ func EnableServiceInstanceIsolation() {
 	Singleton.testMgr.SetIsolatedServices(true)
 }
 
+// InterceptHTTP intercepts outgoing HTTP requests made during the current test and
+// any of its sub-tests, including by the APIs it calls. Requests are recorded and
+// answered by the stubs added to the returned HTTPInterceptor, instead of being made
+// over the network. For example:
+//
+//	func TestCharge(t *testing.T) {
+//		httpmock := et.InterceptHTTP()
+//		stub := httpmock.Stub("POST", "https://api.payments.example/v1/charges").
+//			RespondJSON(200, map[string]string{"id": "ch_123"}).
+//			Times(1)
+//		...
+//	}
+//
+// It intercepts requests made with http.DefaultTransport, which is used by
+// http.Get, http.DefaultClient and HTTP clients that don't set a Transport.
+func InterceptHTTP() *HTTPInterceptor {
+	if Singleton.runtime.EnvType != "test" {
+		panic("et: cannot intercept http requests in non-test environment")
+	}
+	i := &HTTPInterceptor{mgr: Singleton, next: Singleton.httpTransport.next}
+	Singleton.testMgr.SetHTTPInterceptor(i)
+	return i
+}
+
 //publicapigen:keep
 type stringLiteral string
 
@@ -11,7 +11,7 @@ func Topic[T any](topic *pubsub.Topic[T]) TopicHelpers[T] {
 	if Singleton.runtime.EnvType != "test" {
 		panic("et: cannot mock topic in non-test environment")
 	}
-	return pubsub.GetTestTopicInstance(topic).(TopicHelpers[T])
+	return &topicHelpers[T]{inst: pubsub.GetTestTopicInstance(topic).(testTopicInstance[T])}
 }
 
 // TopicHelpers provides functions for interacting with the backing topic implementation
@@ -22,4 +22,69 @@ func Topic[T any](topic *pubsub.Topic[T]) TopicHelpers[T] {
 type TopicHelpers[T any] interface {
 	// PublishedMessages returns a slice of all messages published during this test on this topic.
 	PublishedMessages() []T
+
+	// HoldDeliveries makes the messages published on this topic from now on during this test
+	// be delivered to the topic's subscriptions when the test delivers them, using PendingDeliveries.
+	//
+	// This lets the test control the order messages are processed in, including how the
+	// deliveries of different messages to different subscriptions interleave, to test
+	// code that makes assumptions about ordering or has to handle messages racing each other.
+	//
+	// Without it, messages published during tests are not delivered to subscriptions.
+	HoldDeliveries()
+
+	// PendingDeliveries returns the held deliveries that have yet to succeed, in the order
+	// the messages were published, and for each message in the order of subscription names.
+	PendingDeliveries() []*Delivery[T]
+}
+
+// Delivery is the delivery of a message to one of a topic's subscriptions,
+// held until the test delivers it. See TopicHelpers.HoldDeliveries.
+type Delivery[T any] struct {
+	Subscription string // the name of the subscription
+	MessageID    string // the id of the message
+	Message      T      // the message
+
+	deliver func() error
+}
+
+// Deliver delivers the message to the subscription and waits for it to be processed,
+// returning the error returned by the subscription handler.
+//
+// If the handler returns an error the delivery stays pending, and calling Deliver
+// again redelivers the message as the next delivery attempt.
+func (d *Delivery[T]) Deliver() error {
+	return d.deliver()
+}
+
+// testTopicInstance is the test-specific instance of a topic.
+type testTopicInstance[T any] interface {
+	PublishedMessages() []T
+	HoldDeliveries()
+	ForEachPendingDelivery(fn func(subscription, msgID string, msg T, deliver func() error))
+}
+
+type topicHelpers[T any] struct {
+	inst testTopicInstance[T]
+}
+
+func (h *topicHelpers[T]) PublishedMessages() []T {
+	return h.inst.PublishedMessages()
+}
+
+func (h *topicHelpers[T]) HoldDeliveries() {
+	h.inst.HoldDeliveries()
+}
+
+func (h *topicHelpers[T]) PendingDeliveries() []*Delivery[T] {
+	var deliveries []*Delivery[T]
+	h.inst.ForEachPendingDelivery(func(subscription, msgID string, msg T, deliver func() error) {
+		deliveries = append(deliveries, &Delivery[T]{
+			Subscription: subscription,
+			MessageID:    msgID,
+			Message:      msg,
+			deliver:      deliver,
+		})
+	})
+	return deliveries
 }
@@ -0,0 +1,23 @@
+//go:build encore_app
+
+package et
+
+import "testing"
+
+// SetSecret changes the value of the secret with the given name within the current test
+// and any subtests. Other tests running will not be affected.
+//
+// The override applies to the values returned by encore.dev/secrets.Get and Versions.
+// The fields of a service's secrets struct hold the values loaded when the application
+// started, and are not affected, so read secrets using secrets.Get in code paths
+// whose tests need to change them.
+func SetSecret(t *testing.T, name, value string) {
+	t.Helper()
+	if Singleton.runtime.EnvType != "test" {
+		panic("et: cannot set secrets in non-test environment")
+	}
+	if Singleton.testMgr.CurrentTest() != t {
+		t.Fatal("et.SetSecret must be called from the test it's passed")
+	}
+	Singleton.testMgr.SetSecret(name, value)
+}
@@ -0,0 +1,13 @@
+package encore
+
+import "mime/multipart"
+
+// File is a file uploaded in a multipart/form-data request.
+//
+// Endpoints whose request type has fields of type *encore.File
+// (or []*encore.File, to accept several files under the same name)
+// accept multipart/form-data requests, with each file sent as a form file
+// and the other body fields as form values. Use Open to read the file's contents.
+//
+// For more information see https://encore.dev/docs/go/primitives/defining-apis#file-uploads.
+type File = multipart.FileHeader
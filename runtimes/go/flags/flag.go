@@ -0,0 +1,26 @@
+//go:build encore_app
+
+package flags
+
+import "encore.dev/beta/auth"
+
+// New declares a new feature flag.
+//
+// The name must be defined in kebab-case and be unique within the application.
+// During local development the flag can be toggled on and off for all users
+// from the development dashboard, regardless of its targeting rules.
+//
+//	var NewCheckout = flags.New("new-checkout", flags.Config{
+//		Rollout: 10,
+//		UserIDs: []string{"admin"},
+//	})
+func New(name string, cfg Config) *Flag {
+	return newFlag(Singleton, name, cfg)
+}
+
+// Enabled reports whether the flag is enabled for the user
+// making the current request.
+func (f *Flag) Enabled() bool {
+	uid, _ := auth.UserID()
+	return f.EnabledFor(uid, auth.Data())
+}
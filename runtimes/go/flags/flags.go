@@ -0,0 +1,120 @@
+// Package flags provides feature flags: named switches that enable
+// functionality for some or all users, based on targeting rules.
+//
+// For more information see https://encore.dev/docs/primitives/feature-flags.
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+
+	"encore.dev/beta/auth"
+)
+
+// Config is the configuration of a feature flag.
+//
+// A flag is enabled for a user if the user is listed in UserIDs,
+// if the user's auth data matches all the Attributes, or if
+// the user falls within the Rollout percentage.
+//
+// The fields provided in the Config must be constant literals,
+// as they are parsed by the Encore compiler.
+type Config struct {
+	// Rollout is the percentage of users the flag is enabled for,
+	// between 0 and 100. Users are assigned to the rollout based on
+	// their user ID, so a user consistently sees the same result
+	// while the percentage is unchanged, and keeps seeing the flag
+	// enabled as the percentage is increased.
+	//
+	// Requests without authentication details only have the
+	// flag enabled if Rollout is 100.
+	Rollout int
+
+	// UserIDs are the IDs of users the flag is always enabled for.
+	UserIDs []string
+
+	// Attributes, if set, enable the flag for users whose auth data
+	// has all the given fields set to the given values. Fields are
+	// referred to by their JSON names, and values are compared
+	// by their string representation, such as "true" or "42".
+	Attributes map[string]string
+}
+
+// Flag is a feature flag, declared with New.
+type Flag struct {
+	name string
+	cfg  Config
+	mgr  *Manager
+}
+
+func newFlag(mgr *Manager, name string, cfg Config) *Flag {
+	return &Flag{name: name, cfg: cfg, mgr: mgr}
+}
+
+// Name returns the name of the flag.
+func (f *Flag) Name() string {
+	return f.name
+}
+
+// EnabledFor reports whether the flag is enabled for the user with the
+// given uid and auth data. An empty uid and nil data stand for a request
+// without authentication details.
+func (f *Flag) EnabledFor(uid auth.UID, data any) bool {
+	if enabled, ok := f.mgr.override(f.name); ok {
+		return enabled
+	}
+	return f.evaluate(uid, data)
+}
+
+// evaluate evaluates the flag's targeting rules.
+func (f *Flag) evaluate(uid auth.UID, data any) bool {
+	if f.cfg.Rollout >= 100 {
+		return true
+	}
+	if uid == "" {
+		return false
+	}
+	if slices.Contains(f.cfg.UserIDs, string(uid)) {
+		return true
+	}
+	if len(f.cfg.Attributes) > 0 && matchAttributes(f.cfg.Attributes, data) {
+		return true
+	}
+	return f.cfg.Rollout > 0 && bucket(f.name, uid) < f.cfg.Rollout
+}
+
+// bucket assigns the user to one of 100 buckets for the given flag.
+// Including the flag name spreads users differently for each flag,
+// so that the same users aren't always the first to get new features.
+func bucket(flag string, uid auth.UID) int {
+	h := fnv.New32a()
+	h.Write([]byte(flag))
+	h.Write([]byte{0})
+	h.Write([]byte(uid))
+	return int(h.Sum32() % 100)
+}
+
+// matchAttributes reports whether the auth data has all the given
+// fields set to the given values.
+func matchAttributes(attrs map[string]string, data any) bool {
+	if data == nil {
+		return false
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return false
+	}
+	for key, want := range attrs {
+		val, ok := fields[key]
+		if !ok || val == nil || fmt.Sprint(val) != want {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rs/zerolog"
+
+	"encore.dev/beta/auth"
+)
+
+type authData struct {
+	Plan  string `json:"plan"`
+	Beta  bool   `json:"beta"`
+	Seats int    `json:"seats"`
+}
+
+func TestEvaluate(t *testing.T) {
+	c := qt.New(t)
+	mgr := NewManager(zerolog.Nop(), "")
+
+	f := newFlag(mgr, "targeted", Config{
+		UserIDs:    []string{"alice"},
+		Attributes: map[string]string{"plan": "pro", "beta": "true"},
+	})
+	c.Assert(f.EnabledFor("alice", nil), qt.IsTrue)
+	c.Assert(f.EnabledFor("bob", &authData{Plan: "pro", Beta: true}), qt.IsTrue)
+	c.Assert(f.EnabledFor("bob", &authData{Plan: "pro"}), qt.IsFalse)
+	c.Assert(f.EnabledFor("bob", nil), qt.IsFalse)
+	c.Assert(f.EnabledFor("", nil), qt.IsFalse)
+
+	numeric := newFlag(mgr, "numeric", Config{Attributes: map[string]string{"seats": "42"}})
+	c.Assert(numeric.EnabledFor("bob", authData{Seats: 42}), qt.IsTrue)
+
+	all := newFlag(mgr, "all", Config{Rollout: 100})
+	c.Assert(all.EnabledFor("", nil), qt.IsTrue)
+
+	// A partial rollout enables the flag for roughly the given share of users,
+	// and users enabled at a lower percentage stay enabled at a higher one.
+	low := newFlag(mgr, "rollout", Config{Rollout: 20})
+	high := newFlag(mgr, "rollout", Config{Rollout: 50})
+	var lowCount, highCount int
+	for i := 0; i < 1000; i++ {
+		uid := auth.UID(fmt.Sprintf("user-%d", i))
+		if low.EnabledFor(uid, nil) {
+			lowCount++
+			c.Assert(high.EnabledFor(uid, nil), qt.IsTrue)
+		}
+		if high.EnabledFor(uid, nil) {
+			highCount++
+		}
+	}
+	c.Assert(lowCount > 150 && lowCount < 250, qt.IsTrue, qt.Commentf("got %d", lowCount))
+	c.Assert(highCount > 430 && highCount < 570, qt.IsTrue, qt.Commentf("got %d", highCount))
+	c.Assert(low.EnabledFor("", nil), qt.IsFalse)
+}
+
+func TestOverrides(t *testing.T) {
+	c := qt.New(t)
+	path := filepath.Join(t.TempDir(), "flags.json")
+	mgr := NewManager(zerolog.Nop(), path)
+
+	on := newFlag(mgr, "on", Config{Rollout: 100})
+	off := newFlag(mgr, "off", Config{})
+	c.Assert(on.EnabledFor("alice", nil), qt.IsTrue)
+	c.Assert(off.EnabledFor("alice", nil), qt.IsFalse)
+
+	c.Assert(os.WriteFile(path, []byte(`{"on": false, "off": true}`), 0644), qt.IsNil)
+	mgr.Reload()
+	c.Assert(on.EnabledFor("alice", nil), qt.IsFalse)
+	c.Assert(off.EnabledFor("", nil), qt.IsTrue)
+
+	c.Assert(os.Remove(path), qt.IsNil)
+	mgr.Reload()
+	c.Assert(on.EnabledFor("alice", nil), qt.IsTrue)
+	c.Assert(off.EnabledFor("alice", nil), qt.IsFalse)
+}
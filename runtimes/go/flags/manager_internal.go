@@ -0,0 +1,95 @@
+package flags
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// reloadInterval is how often the overrides file is checked for changes.
+const reloadInterval = time.Second
+
+type Manager struct {
+	logger        zerolog.Logger
+	overridesPath string // the ENCORE_FLAG_OVERRIDES_PATH value
+
+	mu        sync.RWMutex
+	source    []byte // the contents of the overrides file last loaded
+	overrides map[string]bool
+}
+
+// NewManager returns a new flag manager. If overridesPath is set, it names
+// a JSON file mapping flag names to whether they are forcibly enabled,
+// which is written by the Encore daemon during local development.
+func NewManager(rootLogger zerolog.Logger, overridesPath string) *Manager {
+	mgr := &Manager{
+		logger:        rootLogger.With().Str("subsystem", "flags").Logger(),
+		overridesPath: overridesPath,
+	}
+	mgr.Reload()
+	return mgr
+}
+
+// Watch periodically reloads the overrides file, if one is in use.
+func (mgr *Manager) Watch() {
+	if mgr.overridesPath == "" {
+		return
+	}
+	go func() {
+		for range time.Tick(reloadInterval) {
+			mgr.Reload()
+		}
+	}()
+}
+
+// Reload reloads the overrides file, if one is in use.
+// A missing file means there are no overrides.
+func (mgr *Manager) Reload() {
+	if mgr.overridesPath == "" {
+		return
+	}
+	data, err := os.ReadFile(mgr.overridesPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		data, err = nil, nil
+	} else if err != nil {
+		mgr.logger.Error().Err(err).Msg("could not read feature flag overrides")
+		return
+	}
+
+	mgr.mu.RLock()
+	unchanged := mgr.source != nil && bytes.Equal(data, mgr.source)
+	mgr.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	var overrides map[string]bool
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &overrides); err != nil {
+			mgr.logger.Error().Err(err).Msg("could not parse feature flag overrides")
+			return
+		}
+	}
+
+	mgr.mu.Lock()
+	mgr.source = append([]byte{}, data...)
+	mgr.overrides = overrides
+	mgr.mu.Unlock()
+}
+
+// override returns the override of the given flag, if any.
+func (mgr *Manager) override(name string) (enabled, ok bool) {
+	if mgr == nil {
+		return false, false
+	}
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+	enabled, ok = mgr.overrides[name]
+	return enabled, ok
+}
@@ -0,0 +1,16 @@
+//go:build encore_app
+
+package flags
+
+import (
+	"encore.dev/appruntime/shared/encoreenv"
+	"encore.dev/appruntime/shared/logging"
+)
+
+//publicapigen:drop
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(logging.RootLogger, encoreenv.Get("ENCORE_FLAG_OVERRIDES_PATH"))
+	Singleton.Watch()
+}
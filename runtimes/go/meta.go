@@ -34,9 +34,10 @@ func (mgr *Manager) Meta() *AppMetadata {
 		AppID:      mgr.runtime.AppSlug,
 		APIBaseURL: *mgr.apiBaseURL,
 		Environment: EnvironmentMeta{
-			Name:  mgr.runtime.EnvName,
-			Type:  EnvironmentType(mgr.runtime.EnvType),
-			Cloud: CloudProvider(mgr.runtime.EnvCloud),
+			Name:   mgr.runtime.EnvName,
+			Type:   EnvironmentType(mgr.runtime.EnvType),
+			Cloud:  CloudProvider(mgr.runtime.EnvCloud),
+			Region: mgr.runtime.EnvRegion,
 		},
 		Build: BuildMeta{
 			Revision:           mgr.static.AppCommit.Revision,
@@ -86,6 +87,12 @@ type EnvironmentMeta struct {
 	// The cloud that this environment is running on
 	// For local development this is CloudLocal
 	Cloud CloudProvider
+
+	// The cloud region this instance of the application is running in,
+	// such as "eu-west-1". When an environment spans multiple regions
+	// each instance reports its own region.
+	// It is empty if the region is not known, such as for local development.
+	Region string
 }
 
 type BuildMeta struct {
@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"maps"
+	"slices"
+	"sync/atomic"
+
+	"encore.dev/appruntime/infrasdk/metrics/system"
+)
+
+// DefaultMaxLabelCombinations is the maximum number of unique label combinations
+// tracked by a counter or gauge group, unless configured otherwise.
+const DefaultMaxLabelCombinations = 1000
+
+// OverflowLabelValue is the value of every label of the time series that
+// aggregates the values of label combinations beyond a group's limit,
+// when using AggregateOverflow.
+const OverflowLabelValue = "__overflow__"
+
+// Overflow determines how a metric group handles label combinations
+// beyond its maximum number of label combinations.
+type Overflow string
+
+const (
+	// DropOverflow drops the values recorded for label combinations beyond the limit.
+	// It is the default.
+	DropOverflow Overflow = "drop"
+
+	// AggregateOverflow records the values for label combinations beyond the limit
+	// in a single time series, where every label has the value OverflowLabelValue.
+	AggregateOverflow Overflow = "aggregate"
+)
+
+// metricLabelOverflow is the name of the metric counting, for each metric,
+// the number of times a label combination beyond the metric's limit was used.
+const metricLabelOverflow = "e_sys_metrics_label_overflow_total"
+
+// seriesLimiter limits the number of label combinations tracked by a metric group.
+type seriesLimiter struct {
+	max      int64 // the maximum number of label combinations; no limit if <= 0
+	overflow Overflow
+	n        atomic.Int64
+}
+
+func newSeriesLimiter(max int, overflow Overflow) *seriesLimiter {
+	switch {
+	case max == 0:
+		max = DefaultMaxLabelCombinations
+	case max < 0:
+		max = 0
+	}
+	if overflow == "" {
+		overflow = DropOverflow
+	}
+	return &seriesLimiter{max: int64(max), overflow: overflow}
+}
+
+// admit reports whether a new label combination may be tracked.
+// If so it counts towards the limit until released.
+func (l *seriesLimiter) admit() bool {
+	if l.max <= 0 {
+		return true
+	}
+	if l.n.Add(1) > l.max {
+		l.n.Add(-1)
+		return false
+	}
+	return true
+}
+
+// release releases a label combination admitted by admit
+// that turned out to already be tracked.
+func (l *seriesLimiter) release() {
+	if l.max > 0 {
+		l.n.Add(-1)
+	}
+}
+
+// overflowLabels is the registry key labels of the time series
+// aggregating the values of label combinations beyond the limit.
+type overflowLabels struct{}
+
+// groupTS returns the time series for the given labels of a metric group,
+// enforcing the group's label combination limit. The labels are mapped with
+// mapLabels when a new time series is created.
+func (m *metricInfo[V]) groupTS(labels any, lim *seriesLimiter, mapLabels func() []KeyValue) *timeseries[V] {
+	if ts, ok := lookupTS[V](m.reg, m.name, labels); ok {
+		ts.init.Wait()
+		return ts
+	}
+
+	if _, isOverflow := labels.(overflowLabels); !isOverflow && !lim.admit() {
+		m.reg.recordOverflow(m.name, lim)
+		if lim.overflow == AggregateOverflow {
+			return m.groupTS(overflowLabels{}, lim, func() []KeyValue {
+				kvs := mapLabels()
+				for i := range kvs {
+					kvs[i].Value = OverflowLabelValue
+				}
+				return kvs
+			})
+		}
+		return m.droppedTS()
+	}
+
+	ts, setup := m.getTS(labels)
+	if setup {
+		// Another goroutine created the time series concurrently.
+		lim.release()
+		ts.init.Wait()
+	} else {
+		ts.setup(mapLabels())
+	}
+	return ts
+}
+
+// droppedTS returns a time series that is not part of the registry,
+// for recording values that are dropped.
+func (m *metricInfo[V]) droppedTS() *timeseries[V] {
+	m.dropOnce.Do(func() {
+		n := m.reg.numSvcs
+		if m.svcNum > 0 {
+			n = 1
+		}
+		ts := &timeseries[V]{info: m, value: make([]V, n), valid: make([]atomic.Bool, n)}
+		ts.setup(nil)
+		m.dropped = ts
+	})
+	return m.dropped
+}
+
+// recordOverflow records that a label combination beyond the limit of the named metric was used.
+// The first time it happens for a metric a warning is logged.
+func (r *Registry) recordOverflow(name string, lim *seriesLimiter) {
+	val, loaded := r.overflows.LoadOrStore(name, new(atomic.Uint64))
+	val.(*atomic.Uint64).Add(1)
+	if !loaded {
+		r.rt.Logger().Warn().
+			Str("metric", name).
+			Int64("limit", lim.max).
+			Str("overflow", string(lim.overflow)).
+			Msg("metrics: too many label combinations, values for new label combinations will not be tracked separately")
+	}
+}
+
+// overflowMetrics reports the number of times a label combination
+// beyond the limit was used for each metric.
+func (r *Registry) overflowMetrics() []system.Sample {
+	counts := make(map[string]uint64)
+	r.overflows.Range(func(key, value any) bool {
+		counts[key.(string)] = value.(*atomic.Uint64).Load()
+		return true
+	})
+
+	samples := make([]system.Sample, 0, len(counts))
+	for _, name := range slices.Sorted(maps.Keys(counts)) {
+		samples = append(samples, system.Sample{
+			Name:   metricLabelOverflow,
+			Labels: []system.Label{{Key: "metric", Value: name}},
+			Value:  float64(counts[name]),
+		})
+	}
+	return samples
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
 )
 
@@ -10,8 +11,19 @@ type Labels interface {
 }
 
 // CounterConfig configures a counter.
-// It's currently a placeholder as there is not yet any additional configuration.
 type CounterConfig struct {
+	// MaxLabelCombinations is the maximum number of unique label combinations
+	// tracked by a counter group. Values recorded for label combinations beyond
+	// the limit are handled according to Overflow.
+	//
+	// If zero, DefaultMaxLabelCombinations is used. If negative, there is no limit.
+	// It has no effect on counters without labels.
+	MaxLabelCombinations int
+
+	// Overflow determines how values recorded for label combinations beyond
+	// MaxLabelCombinations are handled. It defaults to DropOverflow.
+	Overflow Overflow
+
 	//publicapigen:drop
 	EncoreInternal_LabelMapper any // func(L) []KeyValue
 
@@ -54,18 +66,27 @@ func (c *Counter[V]) Add(delta V) {
 
 //publicapigen:drop
 func NewCounterGroupInternal[L Labels, V Value](reg *Registry, name string, cfg CounterConfig) *CounterGroup[L, V] {
+	// The runtime's own metrics have bounded label sets.
+	if cfg.MaxLabelCombinations == 0 {
+		cfg.MaxLabelCombinations = -1
+	}
 	return newCounterGroup[L, V](reg, name, cfg)
 }
 
 func newCounterGroup[L Labels, V Value](mgr *Registry, name string, cfg CounterConfig) *CounterGroup[L, V] {
 	labelMapper := cfg.EncoreInternal_LabelMapper.(func(L) []KeyValue)
 	m := newMetricInfo[V](mgr, name, CounterType, cfg.EncoreInternal_SvcNum)
-	return &CounterGroup[L, V]{metricInfo: m, labelMapper: labelMapper}
+	return &CounterGroup[L, V]{
+		metricInfo:  m,
+		labelMapper: labelMapper,
+		limit:       newSeriesLimiter(cfg.MaxLabelCombinations, cfg.Overflow),
+	}
 }
 
 type CounterGroup[L Labels, V Value] struct {
 	*metricInfo[V]
 	labelMapper func(L) []KeyValue
+	limit       *seriesLimiter
 }
 
 func (c *CounterGroup[L, V]) With(labels L) *Counter[V] {
@@ -74,14 +95,9 @@ func (c *CounterGroup[L, V]) With(labels L) *Counter[V] {
 }
 
 func (c *CounterGroup[L, V]) get(labels L) *timeseries[V] {
-	ts, setup := c.metricInfo.getTS(labels)
-	if !setup {
-		ts.setup(c.labelMapper(labels))
-	} else {
-		// Wait for the timeseries to be initialized before we continue.
-		ts.init.Wait()
-	}
-	return ts
+	return c.metricInfo.groupTS(labels, c.limit, func() []KeyValue {
+		return c.labelMapper(labels)
+	})
 }
 
 func newGauge[V Value](m *metricInfo[V]) *Gauge[V] {
@@ -94,8 +110,21 @@ func newGauge[V Value](m *metricInfo[V]) *Gauge[V] {
 }
 
 // GaugeConfig configures a gauge.
-// It's currently a placeholder as there is not yet any additional configuration.
 type GaugeConfig struct {
+	// MaxLabelCombinations is the maximum number of unique label combinations
+	// tracked by a gauge group. Values recorded for label combinations beyond
+	// the limit are handled according to Overflow.
+	//
+	// If zero, DefaultMaxLabelCombinations is used. If negative, there is no limit.
+	// It has no effect on gauges without labels.
+	MaxLabelCombinations int
+
+	// Overflow determines how values recorded for label combinations beyond
+	// MaxLabelCombinations are handled. It defaults to DropOverflow.
+	// Note that with AggregateOverflow, the values set for different label
+	// combinations overwrite each other.
+	Overflow Overflow
+
 	//publicapigen:drop
 	EncoreInternal_LabelMapper any // func(L) any) []KeyValue
 
@@ -125,12 +154,17 @@ func (g *Gauge[V]) Add(val V) {
 func newGaugeGroup[L Labels, V Value](mgr *Registry, name string, cfg GaugeConfig) *GaugeGroup[L, V] {
 	labelMapper := cfg.EncoreInternal_LabelMapper.(func(L) []KeyValue)
 	m := newMetricInfo[V](mgr, name, GaugeType, cfg.EncoreInternal_SvcNum)
-	return &GaugeGroup[L, V]{metricInfo: m, labelMapper: labelMapper}
+	return &GaugeGroup[L, V]{
+		metricInfo:  m,
+		labelMapper: labelMapper,
+		limit:       newSeriesLimiter(cfg.MaxLabelCombinations, cfg.Overflow),
+	}
 }
 
 type GaugeGroup[L Labels, V Value] struct {
 	*metricInfo[V]
 	labelMapper func(L) []KeyValue
+	limit       *seriesLimiter
 }
 
 func (g *GaugeGroup[L, V]) With(labels L) *Gauge[V] {
@@ -139,14 +173,9 @@ func (g *GaugeGroup[L, V]) With(labels L) *Gauge[V] {
 }
 
 func (g *GaugeGroup[L, V]) get(labels L) *timeseries[V] {
-	ts, setup := g.metricInfo.getTS(labels)
-	if !setup {
-		ts.setup(g.labelMapper(labels))
-	} else {
-		// Wait for the timeseries to be initialized before we continue.
-		ts.init.Wait()
-	}
-	return ts
+	return g.metricInfo.groupTS(labels, g.limit, func() []KeyValue {
+		return g.labelMapper(labels)
+	})
 }
 
 func newMetricInfo[V Value](mgr *Registry, name string, typ MetricType, svcNum uint16) *metricInfo[V] {
@@ -175,6 +204,9 @@ type metricInfo[V Value] struct {
 	add func(addr *V, val V)
 	set func(addr *V, val V)
 	inc func(addr *V)
+
+	dropOnce sync.Once
+	dropped  *timeseries[V] // records values dropped due to the label combination limit
 }
 
 func (m *metricInfo[V]) svcIdx() (idx uint16, ok bool) {
@@ -200,6 +200,90 @@ func TestGaugeGroup(t *testing.T) {
 	eq(t, countryRegistry(&mgr.registry), 2)
 }
 
+func TestCounterGroup_DropOverflow(t *testing.T) {
+	type myLabels struct {
+		key string
+	}
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewRegistry(rt, 1)
+	c := newCounterGroup[myLabels, int64](mgr, "foo", CounterConfig{
+		EncoreInternal_SvcNum: 1,
+		EncoreInternal_LabelMapper: func(labels myLabels) []KeyValue {
+			return []KeyValue{{Key: "Key", Value: labels.key}}
+		},
+		MaxLabelCombinations: 2,
+	})
+
+	c.With(myLabels{key: "a"}).Increment()
+	c.With(myLabels{key: "b"}).Increment()
+	eq(t, countryRegistry(&mgr.registry), 2)
+
+	// Label combinations beyond the limit are dropped.
+	c.With(myLabels{key: "c"}).Add(5)
+	c.With(myLabels{key: "d"}).Add(5)
+	eq(t, countryRegistry(&mgr.registry), 2)
+
+	// Existing label combinations are still tracked.
+	c.With(myLabels{key: "a"}).Add(2)
+	eq(t, c.get(myLabels{key: "a"}).value[0], 3)
+	eq(t, countryRegistry(&mgr.registry), 2)
+
+	samples := mgr.overflowMetrics()
+	eq(t, len(samples), 1)
+	eq(t, samples[0].Name, metricLabelOverflow)
+	eq(t, samples[0].Labels[0].Value, "foo")
+	eq(t, samples[0].Value, 2)
+}
+
+func TestGaugeGroup_AggregateOverflow(t *testing.T) {
+	type myLabels struct {
+		key string
+	}
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewRegistry(rt, 1)
+	c := newGaugeGroup[myLabels, int64](mgr, "foo", GaugeConfig{
+		EncoreInternal_SvcNum: 1,
+		EncoreInternal_LabelMapper: func(labels myLabels) []KeyValue {
+			return []KeyValue{{Key: "Key", Value: labels.key}}
+		},
+		MaxLabelCombinations: 1,
+		Overflow:             AggregateOverflow,
+	})
+
+	c.With(myLabels{key: "a"}).Set(1)
+	c.With(myLabels{key: "b"}).Add(2)
+	c.With(myLabels{key: "c"}).Add(3)
+	eq(t, countryRegistry(&mgr.registry), 2)
+
+	ts := c.get(myLabels{key: "b"})
+	if !reflect.DeepEqual(ts.labels, []KeyValue{{Key: "Key", Value: OverflowLabelValue}}) {
+		t.Fatalf("got labels %+v, want [{Key %s}]", ts.labels, OverflowLabelValue)
+	}
+	eq(t, ts.value[0], 5)
+	eq(t, c.get(myLabels{key: "a"}).value[0], 1)
+}
+
+func TestCounterGroup_Unlimited(t *testing.T) {
+	type myLabels struct {
+		key string
+	}
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewRegistry(rt, 1)
+	c := newCounterGroup[myLabels, int64](mgr, "foo", CounterConfig{
+		EncoreInternal_SvcNum: 1,
+		EncoreInternal_LabelMapper: func(labels myLabels) []KeyValue {
+			return []KeyValue{{Key: "Key", Value: labels.key}}
+		},
+		MaxLabelCombinations: -1,
+	})
+
+	for i := 0; i < DefaultMaxLabelCombinations+10; i++ {
+		c.With(myLabels{key: strconv.Itoa(i)}).Increment()
+	}
+	eq(t, countryRegistry(&mgr.registry), DefaultMaxLabelCombinations+10)
+	eq(t, len(mgr.overflowMetrics()), 0)
+}
+
 func BenchmarkCounter_Inc(b *testing.B) {
 	b.ReportAllocs()
 	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
@@ -14,6 +14,8 @@ type Registry struct {
 	numSvcs  uint16
 	tsid     uint64
 	registry sync.Map // map[registryKey]*timeseries
+
+	overflows sync.Map // map[string]*atomic.Uint64, keyed by metric name
 }
 
 func NewRegistry(rt *reqtrack.RequestTracker, numServicesInBinary int) *Registry {
@@ -111,6 +113,14 @@ type KeyValue struct {
 	Value string
 }
 
+// lookupTS returns the time series for the given metric and labels, if it exists.
+func lookupTS[T any](r *Registry, name string, labels any) (ts *timeseries[T], ok bool) {
+	if val, ok := r.registry.Load(registryKey{metricName: name, labels: labels}); ok {
+		return val.(*timeseries[T]), true
+	}
+	return nil, false
+}
+
 func getTS[T any](r *Registry, name string, labels any, info MetricInfo) (ts *timeseries[T], loaded bool) {
 	key := registryKey{metricName: name, labels: labels}
 	if val, ok := r.registry.Load(key); ok {
@@ -3,8 +3,13 @@
 package metrics
 
 import (
+	"encore.dev/appruntime/infrasdk/metrics/system"
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/reqtrack"
 )
 
 var Singleton = NewRegistry(reqtrack.Singleton, len(appconf.Static.BundledServices))
+
+func init() {
+	system.RegisterCollector(Singleton.overflowMetrics)
+}
@@ -0,0 +1,23 @@
+//go:build encore_app
+
+package monitor
+
+// NewCheck declares a new synthetic monitoring check, which periodically
+// makes a request to an endpoint of the application and verifies the response.
+//
+// In deployed environments the checks are run by the processes serving the
+// API gateway, and the results are reported as metrics. During local development
+// the checks are run by Encore and their status is shown in the development dashboard.
+//
+// The name must be defined in kebab-case and be unique within the application.
+//
+//	var _ = monitor.NewCheck("checkout", monitor.CheckConfig{
+//		Path:       "/checkout/health",
+//		Interval:   30 * time.Second,
+//		MaxLatency: 500 * time.Millisecond,
+//	})
+func NewCheck(name string, cfg CheckConfig) *Check {
+	c := newCheck(name, cfg)
+	Singleton.register(c)
+	return c
+}
@@ -0,0 +1,137 @@
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/probe"
+	"encore.dev/appruntime/infrasdk/metrics/system"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+// Names of the metrics reported for each check.
+const (
+	metricCheckUp       = "e_sys_monitor_check_up"
+	metricCheckLatency  = "e_sys_monitor_check_latency_seconds"
+	metricCheckFailures = "e_sys_monitor_check_failures"
+)
+
+type Manager struct {
+	ctx       context.Context
+	cancelCtx func()
+	static    *config.Static
+	runtime   *config.Runtime
+	logger    zerolog.Logger
+	client    *http.Client
+
+	mu     sync.Mutex
+	checks []*Check
+}
+
+func NewManager(static *config.Static, runtime *config.Runtime, rootLogger zerolog.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		ctx:       ctx,
+		cancelCtx: cancel,
+		static:    static,
+		runtime:   runtime,
+		logger:    rootLogger.With().Str("subsystem", "monitor").Logger(),
+		client: &http.Client{
+			// Report redirects as-is rather than following them.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+}
+
+// Shutdown stops running checks.
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	mgr.cancelCtx()
+	return nil
+}
+
+// register registers a check, and starts running it if the
+// current process is responsible for running checks.
+func (mgr *Manager) register(c *Check) {
+	mgr.mu.Lock()
+	mgr.checks = append(mgr.checks, c)
+	mgr.mu.Unlock()
+
+	if mgr.runsChecks() {
+		go mgr.run(c)
+	}
+}
+
+// runsChecks reports whether this process runs the checks.
+// During local development checks are run by the Encore daemon,
+// and in deployed environments by the processes serving the API gateway,
+// which the checks' requests are made to.
+func (mgr *Manager) runsChecks() bool {
+	if mgr.static.Testing || mgr.runtime.EnvCloud == "local" || mgr.runtime.APIBaseURL == "" {
+		return false
+	}
+	hostsAll := len(mgr.runtime.HostedServices) == 0 && len(mgr.runtime.Gateways) == 0
+	return hostsAll || len(mgr.runtime.Gateways) > 0
+}
+
+// run runs the check at its configured interval until the manager is shut down.
+// The first run happens after one interval, to give the application time to start.
+func (mgr *Manager) run(c *Check) {
+	baseURL := strings.TrimSuffix(mgr.runtime.APIBaseURL, "/")
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-mgr.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		res := probe.Run(mgr.ctx, mgr.client, c.spec(baseURL))
+		if mgr.ctx.Err() != nil {
+			return
+		}
+		changed := c.record(res)
+		if !res.OK {
+			mgr.logger.Warn().Str("check", c.name).Str("reason", res.Err).Msg("monitoring check failed")
+		} else if changed {
+			mgr.logger.Info().Str("check", c.name).Msg("monitoring check recovered")
+		}
+	}
+}
+
+// checkMetrics reports the latest result of each check that has run.
+// The failure count is cumulative.
+func (mgr *Manager) checkMetrics() []system.Sample {
+	mgr.mu.Lock()
+	checks := mgr.checks
+	mgr.mu.Unlock()
+
+	var samples []system.Sample
+	for _, c := range checks {
+		c.mu.Lock()
+		last, failures := c.last, c.failures
+		c.mu.Unlock()
+		if last.Time.IsZero() {
+			continue
+		}
+
+		up := 0.0
+		if last.OK {
+			up = 1
+		}
+		labels := []system.Label{{Key: "check", Value: c.name}}
+		samples = append(samples,
+			system.Sample{Name: metricCheckUp, Labels: labels, Value: up},
+			system.Sample{Name: metricCheckLatency, Labels: labels, Value: last.Latency.Seconds()},
+			system.Sample{Name: metricCheckFailures, Labels: labels, Value: float64(failures)},
+		)
+	}
+	return samples
+}
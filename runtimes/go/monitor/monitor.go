@@ -0,0 +1,110 @@
+// Package monitor provides synthetic monitoring checks: probes that
+// periodically make a request to an endpoint of the application
+// and verify that it responds as expected.
+//
+// For more information see https://encore.dev/docs/observability/synthetic-monitoring.
+package monitor
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/probe"
+)
+
+// CheckConfig is the configuration of a monitoring check.
+//
+// The fields provided in the CheckConfig must be constant literals,
+// as they are parsed by the Encore compiler.
+type CheckConfig struct {
+	// Title is the descriptive title of the check, such as "Checkout is reachable".
+	// It defaults to the name of the check.
+	Title string
+
+	// Method is the HTTP method to use. It defaults to "GET".
+	Method string
+
+	// Path is the request path to probe, such as "/healthz".
+	// The request is made to the application's API base URL,
+	// without any authentication.
+	Path string
+
+	// Interval defines how often the check runs.
+	// It must be between 10 seconds and 24 hours, and defaults to one minute.
+	Interval time.Duration
+
+	// Timeout is the time to wait for a response before the check fails.
+	// It must not exceed the Interval, and defaults to 10 seconds.
+	Timeout time.Duration
+
+	// ExpectStatus is the expected response status code. It defaults to 200.
+	ExpectStatus int
+
+	// ExpectBodyContains, if set, is a string the response body must contain.
+	ExpectBodyContains string
+
+	// MaxLatency, if set, is the maximum time to wait for the response
+	// headers before the check is considered failed.
+	MaxLatency time.Duration
+}
+
+// Check is a synthetic monitoring check, declared with NewCheck.
+type Check struct {
+	name string
+	cfg  CheckConfig
+
+	mu       sync.Mutex
+	last     probe.Result
+	failures uint64 // number of failed runs
+}
+
+// Name returns the name of the check.
+func (c *Check) Name() string {
+	return c.name
+}
+
+func newCheck(name string, cfg CheckConfig) *Check {
+	if cfg.Title == "" {
+		cfg.Title = name
+	}
+	if cfg.Method == "" {
+		cfg.Method = "GET"
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Minute
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.ExpectStatus == 0 {
+		cfg.ExpectStatus = http.StatusOK
+	}
+	return &Check{name: name, cfg: cfg}
+}
+
+// spec returns the probe specification for running the check
+// against the given base URL.
+func (c *Check) spec(baseURL string) probe.Spec {
+	return probe.Spec{
+		Method:             c.cfg.Method,
+		URL:                baseURL + c.cfg.Path,
+		Timeout:            c.cfg.Timeout,
+		ExpectStatus:       c.cfg.ExpectStatus,
+		ExpectBodyContains: c.cfg.ExpectBodyContains,
+		MaxLatency:         c.cfg.MaxLatency,
+	}
+}
+
+// record records the result of running the check, and reports
+// whether the check's state changed between passing and failing.
+func (c *Check) record(res probe.Result) (changed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	changed = !c.last.Time.IsZero() && c.last.OK != res.OK
+	c.last = res
+	if !res.OK {
+		c.failures++
+	}
+	return changed
+}
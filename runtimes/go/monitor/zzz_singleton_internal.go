@@ -0,0 +1,19 @@
+//go:build encore_app
+
+package monitor
+
+import (
+	"encore.dev/appruntime/infrasdk/metrics/system"
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+//publicapigen:drop
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(appconf.Static, appconf.Runtime, logging.RootLogger)
+	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	system.RegisterCollector(Singleton.checkMetrics)
+}
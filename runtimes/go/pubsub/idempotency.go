@@ -0,0 +1,119 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"encore.dev/beta/errs"
+	"encore.dev/storage/cache"
+	"encore.dev/storage/sqldb"
+)
+
+// IdempotencyStore records the messages a subscription has processed,
+// so that redeliveries of already-processed messages can be skipped.
+//
+// Use DatabaseIdempotencyStore or CacheIdempotencyStore to create one,
+// and set it as the subscription's SubscriptionConfig.Idempotency.
+type IdempotencyStore interface {
+	// Processed reports whether the message with the given key has been processed.
+	Processed(ctx context.Context, key string) (bool, error)
+
+	// MarkProcessed records that the message with the given key has been processed.
+	MarkProcessed(ctx context.Context, key string) error
+}
+
+// DatabaseIdempotencyStore returns an IdempotencyStore that records processed
+// messages in the given database table. The table must have a text primary key
+// column named "key", and can be created with a migration such as:
+//
+//	CREATE TABLE processed_messages (
+//		key TEXT PRIMARY KEY,
+//		processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+//	);
+//
+// Rows are never deleted by Encore; prune old rows periodically,
+// for example using a cron job, to keep the table from growing indefinitely.
+func DatabaseIdempotencyStore(db *sqldb.Database, table string) IdempotencyStore {
+	ident := pgx.Identifier(strings.Split(table, ".")).Sanitize()
+	return &dbIdempotencyStore{
+		db:          db,
+		selectQuery: fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE key = $1)", ident),
+		insertQuery: fmt.Sprintf("INSERT INTO %s (key) VALUES ($1) ON CONFLICT (key) DO NOTHING", ident),
+	}
+}
+
+type dbIdempotencyStore struct {
+	db          *sqldb.Database
+	selectQuery string
+	insertQuery string
+}
+
+func (s *dbIdempotencyStore) Processed(ctx context.Context, key string) (processed bool, err error) {
+	err = s.db.QueryRow(ctx, s.selectQuery, key).Scan(&processed)
+	return processed, err
+}
+
+func (s *dbIdempotencyStore) MarkProcessed(ctx context.Context, key string) error {
+	_, err := s.db.Exec(ctx, s.insertQuery, key)
+	return err
+}
+
+// CacheIdempotencyStore returns an IdempotencyStore that records processed
+// messages in the given cache keyspace.
+//
+// Keys expire according to the keyspace's DefaultExpiry, which should be
+// longer than the subscription's message retention for duplicates to be
+// reliably detected. Since caches may evict keys under memory pressure,
+// use DatabaseIdempotencyStore when duplicates must never be processed.
+func CacheIdempotencyStore(keyspace *cache.StringKeyspace[string]) IdempotencyStore {
+	return &cacheIdempotencyStore{keyspace: keyspace}
+}
+
+type cacheIdempotencyStore struct {
+	keyspace *cache.StringKeyspace[string]
+}
+
+func (s *cacheIdempotencyStore) Processed(ctx context.Context, key string) (bool, error) {
+	_, err := s.keyspace.Get(ctx, key)
+	if errors.Is(err, cache.Miss) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *cacheIdempotencyStore) MarkProcessed(ctx context.Context, key string) error {
+	return s.keyspace.Set(ctx, key, "1")
+}
+
+// idempotencyKey returns the key identifying a message processed by a subscription.
+func idempotencyKey(subscription, msgID string) string {
+	return subscription + "/" + msgID
+}
+
+// processIdempotently calls process unless the message with the given key has already
+// been processed according to store, in which case it calls onDuplicate instead.
+// The message is recorded as processed once process succeeds.
+func processIdempotently(ctx context.Context, log *zerolog.Logger, store IdempotencyStore, key string, onDuplicate func(), process func() error) error {
+	if processed, err := store.Processed(ctx, key); err != nil {
+		return errs.B().Code(errs.Unavailable).Cause(err).Msg("failed to check whether message was processed").Err()
+	} else if processed {
+		onDuplicate()
+		return nil
+	}
+
+	if err := process(); err != nil {
+		return err
+	}
+
+	// Don't fail the message if it can't be marked as processed,
+	// as that would cause it to be redelivered and processed again.
+	if err := store.MarkProcessed(ctx, key); err != nil {
+		log.Err(err).Str("idempotency_key", key).Msg("failed to mark message as processed")
+	}
+	return nil
+}
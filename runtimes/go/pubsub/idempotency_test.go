@@ -0,0 +1,66 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rs/zerolog"
+)
+
+type memIdempotencyStore struct {
+	processed map[string]bool
+	checkErr  error
+}
+
+func (s *memIdempotencyStore) Processed(ctx context.Context, key string) (bool, error) {
+	return s.processed[key], s.checkErr
+}
+
+func (s *memIdempotencyStore) MarkProcessed(ctx context.Context, key string) error {
+	s.processed[key] = true
+	return nil
+}
+
+func TestProcessIdempotently(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	log := zerolog.Nop()
+	store := &memIdempotencyStore{processed: make(map[string]bool)}
+
+	var calls, duplicates int
+	process := func(err error) func() error {
+		return func() error {
+			calls++
+			return err
+		}
+	}
+	onDuplicate := func() { duplicates++ }
+
+	// Failed messages aren't marked as processed.
+	handlerErr := errors.New("boom")
+	err := processIdempotently(ctx, &log, store, idempotencyKey("sub", "1"), onDuplicate, process(handlerErr))
+	c.Assert(err, qt.Equals, handlerErr)
+	c.Assert(store.processed, qt.HasLen, 0)
+
+	// Successful messages are, and redeliveries are skipped.
+	for range 2 {
+		err = processIdempotently(ctx, &log, store, idempotencyKey("sub", "1"), onDuplicate, process(nil))
+		c.Assert(err, qt.IsNil)
+	}
+	c.Assert(calls, qt.Equals, 2)
+	c.Assert(duplicates, qt.Equals, 1)
+	c.Assert(store.processed, qt.DeepEquals, map[string]bool{"sub/1": true})
+
+	// The same message is processed by other subscriptions.
+	err = processIdempotently(ctx, &log, store, idempotencyKey("other", "1"), onDuplicate, process(nil))
+	c.Assert(err, qt.IsNil)
+	c.Assert(calls, qt.Equals, 3)
+
+	// Messages aren't processed if the store can't be checked.
+	store.checkErr = errors.New("unavailable")
+	err = processIdempotently(ctx, &log, store, idempotencyKey("sub", "2"), onDuplicate, process(nil))
+	c.Assert(err, qt.ErrorMatches, `unavailable: failed to check whether message was processed: unavailable`)
+	c.Assert(calls, qt.Equals, 3)
+}
@@ -33,41 +33,103 @@ type topic struct {
 
 var _ types.TopicImplementation = (*topic)(nil)
 
+func (t *topic) CheckTopic(ctx context.Context) error {
+	_, err := t.snsClient.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{
+		TopicArn: aws.String(t.runtimeCfg.ProviderName),
+	})
+	return err
+}
+
 func (t *topic) PublishMessage(ctx context.Context, orderingKey string, attrs map[string]string, data []byte) (id string, err error) {
-	attributes := make(map[string]snsTypes.MessageAttributeValue)
-	for key, value := range attrs {
-		attributes[key] = snsTypes.MessageAttributeValue{
-			DataType:    aws.String("String"),
-			StringValue: aws.String(value),
-		}
+	groupID, dedupID := t.messageIDs(orderingKey)
+	params := &sns.PublishInput{
+		Message:                aws.String(string(data)),
+		MessageAttributes:      messageAttributes(attrs),
+		TopicArn:               aws.String(t.runtimeCfg.ProviderName),
+		MessageGroupId:         groupID,
+		MessageDeduplicationId: dedupID,
 	}
 
-	params := &sns.PublishInput{
-		Message:           aws.String(string(data)),
-		MessageAttributes: attributes,
-		TopicArn:          aws.String(t.runtimeCfg.ProviderName),
+	result, err := t.snsClient.Publish(ctx, params)
+	if err != nil {
+		return "", err
 	}
+	return aws.ToString(result.MessageId), nil
+}
+
+// maxBatchEntries is the maximum number of messages in an SNS PublishBatch request.
+const maxBatchEntries = 10
 
+func (t *topic) PublishMessages(ctx context.Context, msgs []types.OutgoingMessage) []types.PublishResult {
+	results := make([]types.PublishResult, len(msgs))
+	for start := 0; start < len(msgs); start += maxBatchEntries {
+		end := min(start+maxBatchEntries, len(msgs))
+
+		entries := make([]snsTypes.PublishBatchRequestEntry, 0, end-start)
+		for i := start; i < end; i++ {
+			groupID, dedupID := t.messageIDs(msgs[i].OrderingKey)
+			entries = append(entries, snsTypes.PublishBatchRequestEntry{
+				Id:                     aws.String(strconv.Itoa(i)),
+				Message:                aws.String(string(msgs[i].Data)),
+				MessageAttributes:      messageAttributes(msgs[i].Attrs),
+				MessageGroupId:         groupID,
+				MessageDeduplicationId: dedupID,
+			})
+		}
+
+		out, err := t.snsClient.PublishBatch(ctx, &sns.PublishBatchInput{
+			TopicArn:                   aws.String(t.runtimeCfg.ProviderName),
+			PublishBatchRequestEntries: entries,
+		})
+		if err != nil {
+			for i := start; i < end; i++ {
+				results[i].Err = err
+			}
+			continue
+		}
+
+		for _, entry := range out.Successful {
+			if i, err := strconv.Atoi(aws.ToString(entry.Id)); err == nil && i >= start && i < end {
+				results[i].ID = aws.ToString(entry.MessageId)
+			}
+		}
+		for _, entry := range out.Failed {
+			if i, err := strconv.Atoi(aws.ToString(entry.Id)); err == nil && i >= start && i < end {
+				results[i].Err = fmt.Errorf("%s: %s", aws.ToString(entry.Code), aws.ToString(entry.Message))
+			}
+		}
+	}
+	return results
+}
+
+// messageIDs returns the message group ID and deduplication ID to use for a message.
+func (t *topic) messageIDs(orderingKey string) (groupID, dedupID *string) {
 	// For exactly-once delivery on AWS we need to:
 	//
 	// 1. Set a message group ID (as this is a requirement for FIFO queues)
 	// 2. Set a message deduplication ID as this is required to enable exactly-once delivery
 	if t.staticCfg.DeliveryGuarantee == types.ExactlyOnce {
-		params.MessageGroupId = aws.String(fmt.Sprintf("inst_%s", t.publisherID.String()))
-		params.MessageDeduplicationId = aws.String(fmt.Sprintf("msg_%s", xid.New().String()))
+		groupID = aws.String(fmt.Sprintf("inst_%s", t.publisherID.String()))
+		dedupID = aws.String(fmt.Sprintf("msg_%s", xid.New().String()))
 	}
 
 	// If we have an explicit ordering key, use that as the message group ID and mark the topic as FIFO
 	if orderingKey != "" {
-		params.MessageGroupId = aws.String(orderingKey)
-		params.MessageDeduplicationId = aws.String(fmt.Sprintf("msg_%s", xid.New().String()))
+		groupID = aws.String(orderingKey)
+		dedupID = aws.String(fmt.Sprintf("msg_%s", xid.New().String()))
 	}
+	return groupID, dedupID
+}
 
-	result, err := t.snsClient.Publish(ctx, params)
-	if err != nil {
-		return "", err
+func messageAttributes(attrs map[string]string) map[string]snsTypes.MessageAttributeValue {
+	attributes := make(map[string]snsTypes.MessageAttributeValue)
+	for key, value := range attrs {
+		attributes[key] = snsTypes.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
 	}
-	return aws.ToString(result.MessageId), nil
+	return attributes
 }
 
 func (t *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadline time.Duration, retryPolicy *types.RetryPolicy, implCfg *config.PubsubSubscription, f types.RawSubscriptionCallback) {
@@ -157,10 +219,17 @@ func (t *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadlin
 					defer responseCancel()
 
 					if err != nil {
-						logger.Err(err).Str("msg_id", msgWrapper.MessageId).Msg("unable to process message")
+						var delay time.Duration
+						var deferred *types.DeferredDelivery
+						if errors.As(err, &deferred) {
+							// The message was received before its delivery time; hide it until then.
+							delay = deferred.Delay
+						} else {
+							logger.Err(err).Str("msg_id", msgWrapper.MessageId).Msg("unable to process message")
 
-						// If there was an error processing the message, apply the backoff policy
-						_, delay := utils.GetDelay(retryPolicy.MaxRetries, retryPolicy.MinBackoff, retryPolicy.MaxBackoff, uint16(deliveryAttempt))
+							// If there was an error processing the message, apply the backoff policy
+							_, delay = utils.GetDelay(retryPolicy.MaxRetries, retryPolicy.MinBackoff, retryPolicy.MaxBackoff, uint16(deliveryAttempt))
+						}
 						_, visibilityChangeErr := t.sqsClient.ChangeMessageVisibility(t.ctxs.Connection, &sqs.ChangeMessageVisibilityInput{
 							QueueUrl:          aws.String(implCfg.ProviderName),
 							ReceiptHandle:     msg.ReceiptHandle,
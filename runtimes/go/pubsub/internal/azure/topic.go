@@ -2,6 +2,7 @@ package azure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
@@ -19,6 +20,7 @@ import (
 
 const RetryCountAttribute = "encore-retry-count"
 const TargetSubAttribute = "encore-target-sub"
+const PublishTimeAttribute = "encore-publish-time"
 
 type Manager struct {
 	ctxs *utils.Contexts
@@ -65,7 +67,15 @@ func (t *topic) sender() *azservicebus.Sender {
 }
 
 func (t *topic) PublishMessage(ctx context.Context, groupingKey string, attrs map[string]string, data []byte) (id string, err error) {
+	return t.publish(ctx, attrs, data, nil)
+}
+
+// PublishDelayedMessage publishes a message which is enqueued after the given delay.
+func (t *topic) PublishDelayedMessage(ctx context.Context, groupingKey string, attrs map[string]string, data []byte, delay time.Duration) (id string, err error) {
+	return t.publish(ctx, attrs, data, to.Ptr(time.Now().Add(delay)))
+}
 
+func (t *topic) publish(ctx context.Context, attrs map[string]string, data []byte, enqueueAt *time.Time) (id string, err error) {
 	messageID, err := uuid.NewV4()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate message ID: %v", err.Error())
@@ -74,6 +84,7 @@ func (t *topic) PublishMessage(ctx context.Context, groupingKey string, attrs ma
 		MessageID:             to.Ptr(messageID.String()),
 		Body:                  data,
 		ApplicationProperties: map[string]interface{}{},
+		ScheduledEnqueueTime:  enqueueAt,
 	}
 	for k, v := range attrs {
 		msg.ApplicationProperties[k] = v
@@ -84,9 +95,17 @@ func (t *topic) PublishMessage(ctx context.Context, groupingKey string, attrs ma
 	return *msg.MessageID, err
 }
 
-func (t *topic) scheduleRetry(subName string, msg *azservicebus.ReceivedMessage, backoff time.Duration) error {
-	retryCount, _ := strconv.ParseInt(fmt.Sprintf("%v", msg.ApplicationProperties[RetryCountAttribute]), 10, 64)
-	msg.ApplicationProperties[RetryCountAttribute] = retryCount + 1
+// scheduleRetry schedules the message to be redelivered to the subscription after the backoff.
+// If countRetry is false the redelivery doesn't count as a retry, as when deferring delayed messages.
+func (t *topic) scheduleRetry(subName string, msg *azservicebus.ReceivedMessage, backoff time.Duration, countRetry bool) error {
+	// Keep track of the original publish time, since rescheduling the message resets its enqueued time.
+	if _, ok := msg.ApplicationProperties[PublishTimeAttribute]; !ok {
+		msg.ApplicationProperties[PublishTimeAttribute] = msg.EnqueuedTime.UTC().Format(time.RFC3339Nano)
+	}
+	if countRetry {
+		retryCount, _ := strconv.ParseInt(fmt.Sprintf("%v", msg.ApplicationProperties[RetryCountAttribute]), 10, 64)
+		msg.ApplicationProperties[RetryCountAttribute] = retryCount + 1
+	}
 	msg.ApplicationProperties[TargetSubAttribute] = subName
 
 	reMsg := &azservicebus.Message{
@@ -122,8 +141,18 @@ func (t *topic) processMessage(
 	}
 	retryCount, _ := strconv.ParseInt(fmt.Sprintf("%v", msg.ApplicationProperties[RetryCountAttribute]), 10, 64)
 	deliveryAttempt := retryCount + 1
-	err = f(ctx, msg.MessageID, *msg.EnqueuedTime, int(deliveryAttempt), attrs, msg.Body)
-	if err != nil {
+	publishTime := *msg.EnqueuedTime
+	if val, ok := attrs[PublishTimeAttribute]; ok {
+		if ts, err := time.Parse(time.RFC3339Nano, val); err == nil {
+			publishTime = ts
+		}
+	}
+	err = f(ctx, msg.MessageID, publishTime, int(deliveryAttempt), attrs, msg.Body)
+	var deferred *types.DeferredDelivery
+	if errors.As(err, &deferred) {
+		// The message was received before its delivery time; schedule it for then.
+		err = t.scheduleRetry(subCfg.ProviderName, msg, deferred.Delay, false)
+	} else if err != nil {
 		logger.Warn().Err(err).Msg("failed to process messsage")
 		shouldRetry, backoff := utils.GetDelay(
 			rp.MaxRetries, rp.MinBackoff, rp.MaxBackoff, uint16(deliveryAttempt))
@@ -136,7 +165,7 @@ func (t *topic) processMessage(
 			})
 		} else {
 			logger.Warn().Msgf("scheduling msg retry in %v (attempt %v)", backoff, deliveryAttempt)
-			err = t.scheduleRetry(subCfg.ProviderName, msg, backoff)
+			err = t.scheduleRetry(subCfg.ProviderName, msg, backoff, true)
 		}
 	}
 	// if err == nil we have either successfully processed the message or we have scheduled/deadlettered it
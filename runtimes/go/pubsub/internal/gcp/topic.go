@@ -2,6 +2,7 @@ package gcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -59,6 +60,16 @@ func (mgr *Manager) NewTopic(_ *config.PubsubProvider, staticCfg types.TopicConf
 	return &topic{mgr, gcpTopic, runtimeCfg}
 }
 
+func (t *topic) CheckTopic(ctx context.Context) error {
+	exists, err := t.gcpTopic.Exists(ctx)
+	if err != nil {
+		return err
+	} else if !exists {
+		return fmt.Errorf("topic %s does not exist", t.gcpTopic.ID())
+	}
+	return nil
+}
+
 func (t *topic) PublishMessage(ctx context.Context, orderingKey string, attrs map[string]string, data []byte) (id string, err error) {
 	gcpMsg := &pubsub.Message{
 		Data:        data,
@@ -75,6 +86,28 @@ func (t *topic) PublishMessage(ctx context.Context, orderingKey string, attrs ma
 	return id, err
 }
 
+func (t *topic) PublishMessages(ctx context.Context, msgs []types.OutgoingMessage) []types.PublishResult {
+	// The GCP client batches messages published concurrently,
+	// so publish them all before waiting for the results.
+	results := make([]*pubsub.PublishResult, len(msgs))
+	for i, msg := range msgs {
+		results[i] = t.gcpTopic.Publish(ctx, &pubsub.Message{
+			Data:        msg.Data,
+			Attributes:  msg.Attrs,
+			OrderingKey: msg.OrderingKey,
+		})
+	}
+
+	out := make([]types.PublishResult, len(msgs))
+	for i, res := range results {
+		out[i].ID, out[i].Err = res.Get(ctx)
+		if t.gcpTopic.EnableMessageOrdering && out[i].Err != nil {
+			t.gcpTopic.ResumePublish(msgs[i].OrderingKey)
+		}
+	}
+	return out
+}
+
 func (t *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadline time.Duration, retryPolicy *types.RetryPolicy, subCfg *config.PubsubSubscription, f types.RawSubscriptionCallback) {
 	if subCfg.PushOnly && subCfg.ID == "" {
 		panic("push-only subscriptions must have a subscription ID")
@@ -127,12 +160,8 @@ func (t *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadlin
 						deliveryAttempt = *msg.DeliveryAttempt
 					}
 
-					// Create a context from the handler context with a deadline of the ackdeadline
-					ctx, cancel := context.WithTimeout(t.mgr.ctxs.Handler, ackDeadline)
-					defer cancel()
-
 					var result *pubsub.AckResult
-					if err := f(ctx, msg.ID, msg.PublishTime, deliveryAttempt, msg.Attributes, msg.Data); err != nil {
+					if err := t.deliver(msg, deliveryAttempt, ackDeadline, f); err != nil {
 						result = msg.NackWithResult()
 					} else {
 						result = msg.AckWithResult()
@@ -165,3 +194,37 @@ func (t *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadlin
 		}()
 	}
 }
+
+// maxLocalDeferral is the longest a message received before its delivery time is held
+// before nacking it. GCP Pub/Sub can't delay individual messages, so delays are
+// implemented by holding the message until then, while the client library extends its
+// ack deadline. Longer delays are implemented by nacking the message and deferring
+// it again when it's redelivered.
+const maxLocalDeferral = 30 * time.Minute
+
+// deliver calls f with the message, holding the message until its delivery time
+// if it was received before it.
+func (t *topic) deliver(msg *pubsub.Message, deliveryAttempt int, ackDeadline time.Duration, f types.RawSubscriptionCallback) error {
+	for {
+		// Create a context from the handler context with a deadline of the ackdeadline
+		ctx, cancel := context.WithTimeout(t.mgr.ctxs.Handler, ackDeadline)
+		err := f(ctx, msg.ID, msg.PublishTime, deliveryAttempt, msg.Attributes, msg.Data)
+		cancel()
+
+		var deferred *types.DeferredDelivery
+		if !errors.As(err, &deferred) {
+			return err
+		}
+
+		timer := time.NewTimer(min(deferred.Delay, maxLocalDeferral))
+		select {
+		case <-t.mgr.ctxs.Fetch.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		if deferred.Delay > maxLocalDeferral {
+			return err
+		}
+	}
+}
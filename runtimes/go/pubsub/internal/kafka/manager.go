@@ -0,0 +1,141 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/pubsub/internal/types"
+	"encore.dev/pubsub/internal/utils"
+)
+
+type Manager struct {
+	ctxs *utils.Contexts
+	rt   *reqtrack.RequestTracker
+
+	producersMu sync.Mutex
+	producers   map[*config.PubsubProvider]*kgo.Client // producer clients, keyed by provider
+}
+
+func NewManager(ctxs *utils.Contexts, rt *reqtrack.RequestTracker) *Manager {
+	return &Manager{ctxs: ctxs, rt: rt, producers: make(map[*config.PubsubProvider]*kgo.Client)}
+}
+
+func (mgr *Manager) ProviderName() string { return "kafka" }
+
+func (mgr *Manager) Matches(cfg *config.PubsubProvider) bool {
+	return cfg.Kafka != nil
+}
+
+func (mgr *Manager) NewTopic(providerCfg *config.PubsubProvider, staticCfg types.TopicConfig, runtimeCfg *config.PubsubTopic) types.TopicImplementation {
+	return &topic{
+		mgr:         mgr,
+		providerCfg: providerCfg,
+		runtimeCfg:  runtimeCfg,
+		ordered:     staticCfg.Ordered || staticCfg.OrderingAttribute != "",
+	}
+}
+
+// getProducer returns the producer client for the given provider, creating it if necessary.
+// The producer is shared between all topics of the provider.
+func (mgr *Manager) getProducer(providerCfg *config.PubsubProvider) (*kgo.Client, error) {
+	mgr.producersMu.Lock()
+	defer mgr.producersMu.Unlock()
+
+	if cl, ok := mgr.producers[providerCfg]; ok {
+		return cl, nil
+	}
+
+	opts, err := clientOpts(providerCfg.Kafka)
+	if err != nil {
+		return nil, err
+	}
+	log := mgr.rt.Logger().With().Str("provider", "kafka").Logger()
+	opts = append(opts, kgo.WithLogger(&logAdapter{&log}))
+
+	cl, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create kafka client: %v", err)
+	}
+	mgr.producers[providerCfg] = cl
+
+	// Flush and close the producer once we're closing connections.
+	go func() {
+		<-mgr.ctxs.Connection.Done()
+		cl.Close()
+	}()
+	return cl, nil
+}
+
+// clientOpts returns the options for connecting to the Kafka cluster
+// described by the provider configuration.
+func clientOpts(cfg *config.KafkaProvider) ([]kgo.Opt, error) {
+	opts := []kgo.Opt{kgo.SeedBrokers(cfg.Brokers...)}
+
+	if cfg.EnableTLS || cfg.ServerCACert != "" || cfg.ClientCert != "" {
+		tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.ServerCACert != "" {
+			caCertPool := x509.NewCertPool()
+			if !caCertPool.AppendCertsFromPEM([]byte(cfg.ServerCACert)) {
+				return nil, fmt.Errorf("invalid server ca cert")
+			}
+			tlsCfg.RootCAs = caCertPool
+		}
+		if cfg.ClientCert != "" {
+			cert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+			if err != nil {
+				return nil, fmt.Errorf("parse client cert: %v", err)
+			}
+			tlsCfg.Certificates = []tls.Certificate{cert}
+		}
+		opts = append(opts, kgo.DialTLSConfig(tlsCfg))
+	}
+
+	if cfg.SASLMechanism != "" {
+		var mechanism sasl.Mechanism
+		switch cfg.SASLMechanism {
+		case "plain":
+			mechanism = plain.Auth{User: cfg.SASLUser, Pass: cfg.SASLPassword}.AsMechanism()
+		case "scram-sha-256":
+			mechanism = scram.Auth{User: cfg.SASLUser, Pass: cfg.SASLPassword}.AsSha256Mechanism()
+		case "scram-sha-512":
+			mechanism = scram.Auth{User: cfg.SASLUser, Pass: cfg.SASLPassword}.AsSha512Mechanism()
+		default:
+			return nil, fmt.Errorf("unsupported sasl mechanism %q", cfg.SASLMechanism)
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+
+	return opts, nil
+}
+
+// logAdapter adapts a zerolog.Logger to the kgo.Logger interface.
+// Only warnings and above are logged.
+type logAdapter struct{ logger *zerolog.Logger }
+
+func (l *logAdapter) Level() kgo.LogLevel { return kgo.LogLevelWarn }
+
+func (l *logAdapter) Log(level kgo.LogLevel, msg string, keyvals ...any) {
+	var ev *zerolog.Event
+	switch level {
+	case kgo.LogLevelError:
+		ev = l.logger.Error()
+	case kgo.LogLevelWarn:
+		ev = l.logger.Warn()
+	default:
+		return
+	}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		ev = ev.Interface(fmt.Sprint(keyvals[i]), keyvals[i+1])
+	}
+	ev.Msg(msg)
+}
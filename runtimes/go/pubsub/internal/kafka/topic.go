@@ -0,0 +1,343 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/rs/zerolog"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/beta/errs"
+	"encore.dev/pubsub/internal/types"
+	"encore.dev/pubsub/internal/utils"
+)
+
+// topic is the Kafka implementation of pubsub.Topic.
+//
+// Each Encore topic maps to a Kafka topic, and each subscription to a consumer group.
+// Message attributes are stored as record headers, and ordering keys as record keys
+// so that messages with the same ordering key are written to the same partition.
+type topic struct {
+	mgr         *Manager
+	providerCfg *config.PubsubProvider
+	runtimeCfg  *config.PubsubTopic
+
+	// ordered is whether messages must be delivered in the order they were published.
+	ordered bool
+}
+
+var _ types.TopicImplementation = (*topic)(nil)
+
+// msgIDHeader is the record header containing the Encore message ID.
+const msgIDHeader = "encore_msg_id"
+
+const (
+	// defaultMaxConcurrency is the number of messages processed concurrently
+	// by a subscription if the subscription doesn't specify it.
+	defaultMaxConcurrency = 100
+
+	// unlimitedPollRecords is the number of records fetched at a time
+	// by subscriptions with unlimited concurrency.
+	unlimitedPollRecords = 1000
+)
+
+func (t *topic) CheckTopic(ctx context.Context) error {
+	cl, err := t.mgr.getProducer(t.providerCfg)
+	if err != nil {
+		return err
+	}
+
+	reqTopic := kmsg.NewMetadataRequestTopic()
+	reqTopic.Topic = kmsg.StringPtr(t.runtimeCfg.ProviderName)
+	req := kmsg.NewPtrMetadataRequest()
+	req.Topics = append(req.Topics, reqTopic)
+
+	resp, err := req.RequestWith(ctx, cl)
+	if err != nil {
+		return err
+	} else if len(resp.Topics) != 1 {
+		return fmt.Errorf("topic %s not found", t.runtimeCfg.ProviderName)
+	}
+	return kerr.ErrorForCode(resp.Topics[0].ErrorCode)
+}
+
+// EmulateDeadLetters marks the topic as requiring dead-letter emulation,
+// since Kafka has no concept of dead-letter topics.
+func (t *topic) EmulateDeadLetters() {}
+
+func (t *topic) PublishMessage(ctx context.Context, orderingKey string, attrs map[string]string, data []byte) (id string, err error) {
+	cl, err := t.mgr.getProducer(t.providerCfg)
+	if err != nil {
+		return "", errs.B().Cause(err).Code(errs.Internal).Msg("failed to connect to kafka").Err()
+	}
+
+	id = xid.New().String()
+	if err := cl.ProduceSync(ctx, t.newRecord(id, orderingKey, attrs, data)).FirstErr(); err != nil {
+		return "", errs.B().Cause(err).Code(errs.Unavailable).Msg("failed to publish message").Err()
+	}
+	return id, nil
+}
+
+// PublishMessages publishes multiple messages to the Kafka topic, batching them where possible.
+func (t *topic) PublishMessages(ctx context.Context, msgs []types.OutgoingMessage) []types.PublishResult {
+	results := make([]types.PublishResult, len(msgs))
+
+	cl, err := t.mgr.getProducer(t.providerCfg)
+	if err != nil {
+		err = errs.B().Cause(err).Code(errs.Internal).Msg("failed to connect to kafka").Err()
+		for i := range results {
+			results[i].Err = err
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(msgs))
+	for i, msg := range msgs {
+		results[i].ID = xid.New().String()
+		cl.Produce(ctx, t.newRecord(results[i].ID, msg.OrderingKey, msg.Attrs, msg.Data), func(_ *kgo.Record, err error) {
+			defer wg.Done()
+			if err != nil {
+				results[i] = types.PublishResult{Err: errs.B().Cause(err).Code(errs.Unavailable).Msg("failed to publish message").Err()}
+			}
+		})
+	}
+	wg.Wait()
+	return results
+}
+
+// newRecord creates a record for publishing a message to the topic.
+func (t *topic) newRecord(id, orderingKey string, attrs map[string]string, data []byte) *kgo.Record {
+	r := &kgo.Record{
+		Topic:   t.runtimeCfg.ProviderName,
+		Value:   data,
+		Headers: make([]kgo.RecordHeader, 0, len(attrs)+1),
+	}
+	if orderingKey != "" {
+		r.Key = []byte(orderingKey)
+	}
+	r.Headers = append(r.Headers, kgo.RecordHeader{Key: msgIDHeader, Value: []byte(id)})
+	for k, v := range attrs {
+		r.Headers = append(r.Headers, kgo.RecordHeader{Key: k, Value: []byte(v)})
+	}
+	return r
+}
+
+// parseRecord returns the message ID and attributes of a record.
+func parseRecord(r *kgo.Record) (id string, attrs map[string]string) {
+	attrs = make(map[string]string, len(r.Headers))
+	for _, h := range r.Headers {
+		if h.Key == msgIDHeader {
+			id = string(h.Value)
+		} else {
+			attrs[h.Key] = string(h.Value)
+		}
+	}
+	if id == "" {
+		// The record wasn't published by Encore; derive a stable ID from its position.
+		id = fmt.Sprintf("%s/%d/%d", r.Topic, r.Partition, r.Offset)
+	}
+	return id, attrs
+}
+
+func (t *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadline time.Duration, retryPolicy *types.RetryPolicy, implCfg *config.PubsubSubscription, f types.RawSubscriptionCallback) {
+	if implCfg.PushOnly {
+		panic("push-only subscriptions are not supported by kafka")
+	}
+
+	if maxConcurrency == 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+
+	opts, err := clientOpts(t.providerCfg.Kafka)
+	if err != nil {
+		panic(fmt.Sprintf("unable to setup subscription %s for topic %s: %v", implCfg.EncoreName, t.runtimeCfg.EncoreName, err))
+	}
+	opts = append(opts,
+		kgo.WithLogger(&logAdapter{logger}),
+		kgo.ConsumerGroup(implCfg.ProviderName),
+		kgo.ConsumeTopics(t.runtimeCfg.ProviderName),
+
+		// Like subscriptions in other providers, new subscriptions
+		// only receive messages published after they were created.
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtEnd()),
+
+		// Offsets are committed once the polled records have been processed,
+		// and rebalancing is blocked in the meantime so that partitions aren't
+		// reassigned while their records are being processed.
+		kgo.DisableAutoCommit(),
+		kgo.BlockRebalanceOnPoll(),
+	)
+
+	cl, err := kgo.NewClient(opts...)
+	if err != nil {
+		panic(fmt.Sprintf("unable to setup subscription %s for topic %s: %v", implCfg.EncoreName, t.runtimeCfg.EncoreName, err))
+	}
+
+	s := &subscription{
+		topic:          t,
+		logger:         logger,
+		cl:             cl,
+		maxConcurrency: maxConcurrency,
+		ackDeadline:    ackDeadline,
+		retryPolicy:    retryPolicy,
+		f:              f,
+	}
+	go s.consume()
+}
+
+// subscription consumes messages from a Kafka topic using a consumer group.
+type subscription struct {
+	topic          *topic
+	logger         *zerolog.Logger
+	cl             *kgo.Client
+	maxConcurrency int // the max number of messages processed concurrently, or < 0 if unlimited
+	ackDeadline    time.Duration
+	retryPolicy    *types.RetryPolicy
+	f              types.RawSubscriptionCallback
+}
+
+// consume polls and processes records until the fetch context is cancelled.
+//
+// Records are processed in batches: a batch of records is polled, processed,
+// and then the offsets of the batch are committed. If the process shuts down
+// while processing a batch, its offsets are left uncommitted so the records
+// are redelivered, giving at-least-once delivery.
+func (s *subscription) consume() {
+	ctxs := s.topic.mgr.ctxs
+	defer s.cl.CloseAllowingRebalance()
+
+	maxPollRecords := s.maxConcurrency
+	if maxPollRecords < 0 {
+		maxPollRecords = unlimitedPollRecords
+	}
+
+	for {
+		fetches := s.cl.PollRecords(ctxs.Fetch, maxPollRecords)
+		if fetches.IsClientClosed() || ctxs.Fetch.Err() != nil {
+			return
+		}
+		fetches.EachError(func(topic string, partition int32, err error) {
+			s.logger.Warn().Err(err).Str("kafka_topic", topic).Int32("partition", partition).Msg("failed to fetch messages")
+		})
+
+		if !s.process(fetches) {
+			// We're shutting down; leave the offsets uncommitted.
+			return
+		}
+		if err := s.cl.CommitUncommittedOffsets(ctxs.Connection); err != nil {
+			s.logger.Err(err).Msg("failed to commit message offsets")
+		}
+		s.cl.AllowRebalance()
+	}
+}
+
+// process processes the polled records, returning once all of them have been processed.
+// It reports false if processing was aborted because the subscription is shutting down.
+//
+// Records in the same partition are processed in order on ordered topics,
+// and concurrently otherwise.
+func (s *subscription) process(fetches kgo.Fetches) bool {
+	var lanes [][]*kgo.Record
+	if s.topic.ordered {
+		fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+			if len(p.Records) > 0 {
+				lanes = append(lanes, p.Records)
+			}
+		})
+	} else {
+		fetches.EachRecord(func(r *kgo.Record) {
+			lanes = append(lanes, []*kgo.Record{r})
+		})
+	}
+
+	var sem chan struct{}
+	if s.maxConcurrency > 0 {
+		sem = make(chan struct{}, s.maxConcurrency)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		aborted bool
+		mu      sync.Mutex
+	)
+	for _, lane := range lanes {
+		if sem != nil {
+			sem <- struct{}{}
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if sem != nil {
+					<-sem
+				}
+				wg.Done()
+			}()
+			for _, r := range lane {
+				if !s.deliver(r) {
+					mu.Lock()
+					aborted = true
+					mu.Unlock()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return !aborted
+}
+
+// deliver delivers a record to the subscription handler.
+//
+// Kafka has no concept of redelivering individual messages, so failed deliveries
+// are retried in place according to the retry policy. It reports false if delivery
+// was aborted because the subscription is shutting down.
+func (s *subscription) deliver(r *kgo.Record) bool {
+	id, attrs := parseRecord(r)
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(s.topic.mgr.ctxs.Handler, s.ackDeadline)
+		err := s.f(ctx, id, r.Timestamp, attempt, attrs, r.Value)
+		cancel()
+		if err == nil {
+			return true
+		}
+
+		// Wait for deferred messages in place; the deferral doesn't count as a delivery attempt.
+		var deferred *types.DeferredDelivery
+		if errors.As(err, &deferred) {
+			if !s.wait(deferred.Delay) {
+				return false
+			}
+			attempt--
+			continue
+		}
+
+		retry, delay := utils.GetDelay(s.retryPolicy.MaxRetries, s.retryPolicy.MinBackoff, s.retryPolicy.MaxBackoff, uint16(attempt))
+		if !retry {
+			s.logger.Error().Str("msg_id", id).Int("retry", attempt-1).Msg("depleted message retries. Dropping message")
+			return true
+		}
+		if !s.wait(delay) {
+			return false
+		}
+	}
+}
+
+// wait waits for the given delay. It reports false if the subscription is shutting down.
+func (s *subscription) wait(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-s.topic.mgr.ctxs.Fetch.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestRecordRoundTrip(t *testing.T) {
+	c := qt.New(t)
+	tp := &topic{runtimeCfg: &config.PubsubTopic{ProviderName: "orders"}}
+
+	attrs := map[string]string{"region": "eu", "encore_deliver_at": "2024-01-01T00:00:00Z"}
+	r := tp.newRecord("msg-id", "customer-1", attrs, []byte(`{"id":1}`))
+	c.Assert(r.Topic, qt.Equals, "orders")
+	c.Assert(string(r.Key), qt.Equals, "customer-1")
+	c.Assert(string(r.Value), qt.Equals, `{"id":1}`)
+
+	id, gotAttrs := parseRecord(r)
+	c.Assert(id, qt.Equals, "msg-id")
+	c.Assert(gotAttrs, qt.DeepEquals, attrs)
+
+	// Messages without an ordering key are distributed across partitions.
+	r = tp.newRecord("msg-id", "", nil, nil)
+	c.Assert(r.Key, qt.IsNil)
+}
+
+func TestParseRecord_External(t *testing.T) {
+	c := qt.New(t)
+	r := &kgo.Record{
+		Topic:     "orders",
+		Partition: 2,
+		Offset:    42,
+		Headers:   []kgo.RecordHeader{{Key: "region", Value: []byte("us")}},
+	}
+	id, attrs := parseRecord(r)
+	c.Assert(id, qt.Equals, "orders/2/42")
+	c.Assert(attrs, qt.DeepEquals, map[string]string{"region": "us"})
+}
+
+func TestClientOpts(t *testing.T) {
+	c := qt.New(t)
+
+	opts, err := clientOpts(&config.KafkaProvider{Brokers: []string{"localhost:9092"}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(opts, qt.HasLen, 1)
+
+	opts, err = clientOpts(&config.KafkaProvider{
+		Brokers:       []string{"localhost:9092"},
+		EnableTLS:     true,
+		SASLMechanism: "scram-sha-512",
+		SASLUser:      "user",
+		SASLPassword:  "pass",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(opts, qt.HasLen, 3)
+
+	_, err = clientOpts(&config.KafkaProvider{Brokers: []string{"localhost:9092"}, SASLMechanism: "gssapi"})
+	c.Assert(err, qt.ErrorMatches, `unsupported sasl mechanism "gssapi"`)
+
+	_, err = clientOpts(&config.KafkaProvider{Brokers: []string{"localhost:9092"}, ServerCACert: "invalid"})
+	c.Assert(err, qt.ErrorMatches, `invalid server ca cert`)
+}
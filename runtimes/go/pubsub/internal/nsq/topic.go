@@ -3,6 +3,7 @@ package nsq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -36,6 +37,9 @@ type topic struct {
 	m         sync.Mutex
 	producer  *nsq.Producer
 	consumers map[string]*nsq.Consumer
+
+	// ordered is whether messages must be delivered in the order they were published.
+	ordered bool
 }
 
 func (mgr *Manager) ProviderName() string { return "nsq" }
@@ -44,13 +48,14 @@ func (mgr *Manager) Matches(cfg *config.PubsubProvider) bool {
 	return cfg.NSQ != nil
 }
 
-func (mgr *Manager) NewTopic(providerCfg *config.PubsubProvider, _ types.TopicConfig, runtimeCfg *config.PubsubTopic) types.TopicImplementation {
+func (mgr *Manager) NewTopic(providerCfg *config.PubsubProvider, staticCfg types.TopicConfig, runtimeCfg *config.PubsubTopic) types.TopicImplementation {
 	return &topic{
 		mgr:       mgr,
 		name:      runtimeCfg.EncoreName,
 		addr:      providerCfg.NSQ.Host,
 		producer:  nil,
 		consumers: make(map[string]*nsq.Consumer),
+		ordered:   staticCfg.Ordered || staticCfg.OrderingAttribute != "",
 	}
 }
 
@@ -85,6 +90,11 @@ func (l *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadlin
 		maxConcurrency = 100
 	}
 
+	if l.ordered {
+		// nsq has no concept of ordering keys, so deliver messages one at a time instead.
+		maxConcurrency = 1
+	}
+
 	conCfg := getConsumerConfig(maxConcurrency, ackDeadline, retryPolicy)
 	consumer, err := nsq.NewConsumer(l.name, implCfg.EncoreName, conCfg)
 	if err != nil {
@@ -104,7 +114,6 @@ func (l *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadlin
 				if !retry {
 
 					logger.Error().Str("msg_id", msg.ID).Int("retry", int(m.Attempts)-1).Msg("depleted message retries. Dropping message")
-					// TODO; offload this to the dead letter queue
 					m.Finish()
 					return
 				}
@@ -121,8 +130,16 @@ func (l *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadlin
 		msgCtx, cancel := context.WithTimeout(l.mgr.ctxs.Handler, ackDeadline)
 		defer cancel()
 
+		if l.ordered {
+			return l.deliverOrdered(logger, retryPolicy, ackDeadline, m, msg, f)
+		}
+
 		err = f(msgCtx, msg.ID, time.Unix(0, m.Timestamp), int(m.Attempts), msg.Attributes, msg.Data)
-		if err != nil {
+		var deferred *types.DeferredDelivery
+		if errors.As(err, &deferred) {
+			m.RequeueWithoutBackoff(min(deferred.Delay, maxDeferral))
+			return nil
+		} else if err != nil {
 			return err
 		}
 		m.Finish()
@@ -150,24 +167,90 @@ func (l *topic) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadlin
 	}()
 }
 
-// PublishMessage publishes a message to an nsq Topic
-func (l *topic) PublishMessage(ctx context.Context, orderingKey string, attrs map[string]string, data []byte) (id string, err error) {
-	// instantiate a Producer if there isn;t one already
-	if l.producer == nil {
-		l.m.Lock()
-		defer l.m.Unlock()
-		if l.producer == nil {
-			cfg := nsq.NewConfig()
-			producer, err := nsq.NewProducer(l.addr, cfg)
-			if err != nil {
-				return "", errs.B().Cause(err).Code(errs.Internal).Msg("failed to connect to NSQD").Err()
+// deliverOrdered delivers a message on an ordered topic. Failed deliveries are retried
+// before returning, instead of requeuing the message, so that subsequent messages aren't
+// delivered until the message has been processed or its retries have been depleted.
+func (l *topic) deliverOrdered(logger *zerolog.Logger, retryPolicy *types.RetryPolicy, ackDeadline time.Duration, m *nsq.Message, msg *messageWrapper, f types.RawSubscriptionCallback) error {
+	for attempt := int(m.Attempts); ; attempt++ {
+		msgCtx, cancel := context.WithTimeout(l.mgr.ctxs.Handler, ackDeadline)
+		err := f(msgCtx, msg.ID, time.Unix(0, m.Timestamp), attempt, msg.Attributes, msg.Data)
+		cancel()
+		if err == nil {
+			m.Finish()
+			return nil
+		}
+
+		// Wait for deferred messages in place to keep subsequent messages from being delivered first.
+		// The deferral doesn't count as a delivery attempt.
+		var deferred *types.DeferredDelivery
+		if errors.As(err, &deferred) {
+			if !l.waitTouching(m, deferred.Delay, ackDeadline) {
+				m.RequeueWithoutBackoff(0)
+				return nil
 			}
-			// only log warnings and above from the NSQ library
-			log := l.mgr.rt.Logger().With().Str("topic", l.name).Logger()
-			producer.SetLogger(&LogAdapter{Logger: &log}, nsq.LogLevelWarning)
-			l.producer = producer
+			attempt--
+			continue
+		}
+
+		retry, delay := utils.GetDelay(retryPolicy.MaxRetries, retryPolicy.MinBackoff, retryPolicy.MaxBackoff, uint16(attempt))
+		if !retry {
+			logger.Error().Str("msg_id", msg.ID).Int("retry", attempt-1).Msg("depleted message retries. Dropping message")
+			m.Finish()
+			return nil
+		}
+
+		if !l.waitTouching(m, delay, ackDeadline) {
+			// We're shutting down; let nsq redeliver the message later.
+			m.RequeueWithoutBackoff(0)
+			return nil
+		}
+	}
+}
+
+// waitTouching waits for the given delay while periodically touching the message
+// to keep nsq from redelivering it. It reports false if the topic is shutting down.
+func (l *topic) waitTouching(m *nsq.Message, delay, ackDeadline time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	ticker := time.NewTicker(max(ackDeadline/2, time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.mgr.ctxs.Fetch.Done():
+			return false
+		case <-ticker.C:
+			m.Touch()
+		case <-timer.C:
+			m.Touch()
+			return true
 		}
 	}
+}
+
+// EmulateDeadLetters marks the topic as requiring dead-letter emulation,
+// since NSQ has no concept of dead-letter topics.
+func (l *topic) EmulateDeadLetters() {}
+
+// maxDeferral is the longest nsqd allows delaying a message by default.
+// Longer delays are enforced by deferring the message again when it's received.
+const maxDeferral = time.Hour
+
+// PublishMessage publishes a message to an nsq Topic
+func (l *topic) PublishMessage(ctx context.Context, orderingKey string, attrs map[string]string, data []byte) (id string, err error) {
+	return l.publish(attrs, data, 0)
+}
+
+// PublishDelayedMessage publishes a message to an nsq Topic which is delivered after the given delay.
+func (l *topic) PublishDelayedMessage(ctx context.Context, orderingKey string, attrs map[string]string, data []byte, delay time.Duration) (id string, err error) {
+	return l.publish(attrs, data, min(delay, maxDeferral))
+}
+
+func (l *topic) publish(attrs map[string]string, data []byte, delay time.Duration) (id string, err error) {
+	producer, err := l.getProducer()
+	if err != nil {
+		return "", err
+	}
 
 	// generate a new message ID
 	msgID := xid.New().String()
@@ -177,13 +260,65 @@ func (l *topic) PublishMessage(ctx context.Context, orderingKey string, attrs ma
 	if err != nil {
 		return "", errs.B().Cause(err).Code(errs.Internal).Msg("failed to marshal message").Err()
 	}
-	err = l.producer.Publish(l.name, data)
+	if delay > 0 {
+		err = producer.DeferredPublish(l.name, delay, data)
+	} else {
+		err = producer.Publish(l.name, data)
+	}
 	if err != nil {
 		return "", errs.B().Cause(err).Code(errs.Internal).Msg("failed to connect to NSQD").Err()
 	}
 	return msgID, nil
 }
 
+// PublishMessages publishes multiple messages to an nsq Topic in a single request.
+func (l *topic) PublishMessages(ctx context.Context, msgs []types.OutgoingMessage) []types.PublishResult {
+	results := make([]types.PublishResult, len(msgs))
+	setErr := func(err error) []types.PublishResult {
+		for i := range results {
+			results[i] = types.PublishResult{Err: err}
+		}
+		return results
+	}
+
+	producer, err := l.getProducer()
+	if err != nil {
+		return setErr(err)
+	}
+
+	bodies := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		results[i].ID = xid.New().String()
+		bodies[i], err = json.Marshal(&messageWrapper{ID: results[i].ID, Data: msg.Data, Attributes: msg.Attrs})
+		if err != nil {
+			return setErr(errs.B().Cause(err).Code(errs.Internal).Msg("failed to marshal message").Err())
+		}
+	}
+
+	if err := producer.MultiPublish(l.name, bodies); err != nil {
+		return setErr(errs.B().Cause(err).Code(errs.Internal).Msg("failed to connect to NSQD").Err())
+	}
+	return results
+}
+
+// getProducer returns the producer for the topic, creating it if necessary.
+func (l *topic) getProducer() (*nsq.Producer, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	if l.producer == nil {
+		cfg := nsq.NewConfig()
+		producer, err := nsq.NewProducer(l.addr, cfg)
+		if err != nil {
+			return nil, errs.B().Cause(err).Code(errs.Internal).Msg("failed to connect to NSQD").Err()
+		}
+		// only log warnings and above from the NSQ library
+		log := l.mgr.rt.Logger().With().Str("topic", l.name).Logger()
+		producer.SetLogger(&LogAdapter{Logger: &log}, nsq.LogLevelWarning)
+		l.producer = producer
+	}
+	return l.producer, nil
+}
+
 func getConsumerConfig(maxConcurrency int, ackDeadline time.Duration, retryPolicy *types.RetryPolicy) *nsq.Config {
 	conCfg := nsq.NewConfig()
 	conCfg.MsgTimeout = utils.Clamp(ackDeadline, 0, 15*time.Minute)
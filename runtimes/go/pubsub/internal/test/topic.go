@@ -3,6 +3,8 @@ package test
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -21,7 +23,9 @@ import (
 // It records all published messages on a per-test basis, allowing a unit test
 // to assert that the correct messages were published.
 //
-// Any messages published to this type of topic _will not_ be passed to subscribers.
+// Any messages published to this type of topic _will not_ be passed to subscribers,
+// unless the test holds deliveries, in which case they're passed to subscribers
+// when the test delivers them.
 type TestTopic[T any] struct {
 	ts          *testsupport.Manager
 	name        string
@@ -60,9 +64,27 @@ func (t *TestTopic[T]) PublishMessage(ctx context.Context, orderingKey string, a
 		return "", err
 	}
 
-	// If subscriptions are enabled for this test, then trigger those subscribers asynchronously
-	// allowing the publishing code to continue as it would in a real system
-	if instance.subscriptionsEnabled {
+	// If deliveries are held for this test, queue them until the test delivers them
+	if instance.holdingDeliveries() {
+		published := time.Now()
+
+		t.m.RLock()
+		names := slices.Sorted(maps.Keys(t.subscribers))
+		for _, name := range names {
+			instance.hold(&heldDelivery[T]{
+				subscription: name,
+				msgID:        msgID,
+				msg:          unmarshalled,
+				published:    published,
+				attrs:        attrs,
+				data:         data,
+				sub:          t.subscribers[name],
+			})
+		}
+		t.m.RUnlock()
+	} else if instance.subscriptionsEnabled {
+		// If subscriptions are enabled for this test, then trigger those subscribers asynchronously
+		// allowing the publishing code to continue as it would in a real system
 		published := time.Now()
 
 		for name, sub := range t.subscribers {
@@ -114,9 +136,27 @@ type testInstance[T any] struct {
 	topicName            string     // The topic name
 	t                    *testing.T // The test we're running against
 	msgID                int32      // The last message ID we sent (updated atomically)
-	m                    sync.Mutex // Mutex for the published messages
+	m                    sync.Mutex // Mutex for the published messages and held deliveries
 	messages             []T        // What messages have been published
 	subscriptionsEnabled bool       // If subscriptions are enabled for this test
+
+	holdDeliveries bool               // If deliveries are held until the test delivers them
+	held           []*heldDelivery[T] // The held deliveries that have yet to succeed
+}
+
+// heldDelivery is the delivery of a message to a subscription,
+// held until the test delivers it.
+type heldDelivery[T any] struct {
+	subscription string
+	msgID        string
+	msg          T
+	published    time.Time
+	attrs        map[string]string
+	data         []byte
+	sub          types.RawSubscriptionCallback
+
+	attempts  int  // The number of delivery attempts made so far
+	delivered bool // If the delivery has succeeded
 }
 
 // publishMessage records the message which was sent, and generates a deterministic message ID
@@ -137,3 +177,67 @@ func (t *testInstance[T]) PublishedMessages() []T {
 	defer t.m.Unlock()
 	return t.messages
 }
+
+// HoldDeliveries makes the messages published during this test be held
+// for delivery to the topic's subscribers, until the test delivers them.
+func (t *testInstance[T]) HoldDeliveries() {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.holdDeliveries = true
+}
+
+// ForEachPendingDelivery calls fn for each held delivery that has yet to succeed,
+// in the order they were held. Calling deliver delivers the message and waits for
+// the subscriber to process it, returning the error it returned.
+func (t *testInstance[T]) ForEachPendingDelivery(fn func(subscription, msgID string, msg T, deliver func() error)) {
+	t.m.Lock()
+	held := slices.Clone(t.held)
+	t.m.Unlock()
+
+	for _, d := range held {
+		fn(d.subscription, d.msgID, d.msg, func() error { return t.deliver(d) })
+	}
+}
+
+func (t *testInstance[T]) holdingDeliveries() bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.holdDeliveries
+}
+
+func (t *testInstance[T]) hold(d *heldDelivery[T]) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.held = append(t.held, d)
+}
+
+// deliver delivers the held message to its subscriber. If the subscriber fails
+// the delivery stays held, and delivering it again makes the next delivery attempt.
+func (t *testInstance[T]) deliver(d *heldDelivery[T]) error {
+	t.m.Lock()
+	if d.delivered {
+		t.m.Unlock()
+		return fmt.Errorf("message %s has already been delivered to subscription %s", d.msgID, d.subscription)
+	}
+	d.attempts++
+	attempt := d.attempts
+	t.m.Unlock()
+
+	// Process the message in its own goroutine, as it runs as a separate request,
+	// but wait for it so the test controls the order messages are processed in.
+	var err error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err = d.sub(context.Background(), d.msgID, d.published, attempt, d.attrs, d.data)
+	}()
+	<-done
+
+	if err == nil {
+		t.m.Lock()
+		d.delivered = true
+		t.held = slices.DeleteFunc(t.held, func(h *heldDelivery[T]) bool { return h == d })
+		t.m.Unlock()
+	}
+	return err
+}
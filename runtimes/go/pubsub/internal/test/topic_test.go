@@ -0,0 +1,109 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+type event struct {
+	Name string
+}
+
+func TestTopic_HoldDeliveries(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, nil)
+	ts := testsupport.NewManager(&config.Static{}, rt, zerolog.Nop())
+	topic := NewTopic[event](ts, "topic").(*TestTopic[event])
+
+	var (
+		mu        sync.Mutex
+		processed []string
+		fail      = true
+	)
+	subscribe := func(name string) {
+		topic.Subscribe(nil, 1, time.Second, nil, &config.PubsubSubscription{EncoreName: name},
+			func(ctx context.Context, msgID string, published time.Time, attempt int, attrs map[string]string, data []byte) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if name == "b" && fail {
+					fail = false
+					return errors.New("failed")
+				}
+				var ev event
+				_ = json.Unmarshal(data, &ev)
+				processed = append(processed, name+":"+ev.Name)
+				return nil
+			})
+	}
+	subscribe("b")
+	subscribe("a")
+
+	rt.BeginOperation()
+	defer rt.FinishOperation()
+	rt.BeginRequest(&model.Request{Test: &model.TestData{Current: t, Config: &model.TestConfig{}}})
+	defer rt.FinishRequest(false)
+
+	inst := topic.TestInstance(t)
+	inst.HoldDeliveries()
+	for _, msg := range []string{`{"Name":"one"}`, `{"Name":"two"}`} {
+		if _, err := topic.PublishMessage(context.Background(), "", nil, []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type delivery struct {
+		sub, msg string
+		deliver  func() error
+	}
+	pending := func() []delivery {
+		var ds []delivery
+		inst.ForEachPendingDelivery(func(subscription, msgID string, msg event, deliver func() error) {
+			ds = append(ds, delivery{subscription, msg.Name, deliver})
+		})
+		return ds
+	}
+
+	ds := pending()
+	if len(ds) != 4 || ds[0].sub != "a" || ds[0].msg != "one" || ds[3].sub != "b" || ds[3].msg != "two" {
+		t.Fatalf("got pending deliveries %+v, want each message to a and b, in publish order", ds)
+	}
+	if len(processed) != 0 {
+		t.Fatalf("got processed %v before delivering, want none", processed)
+	}
+
+	// Deliver the second message before the first, failing the first attempt to b.
+	if err := ds[3].deliver(); err == nil {
+		t.Fatal("expected the first attempt to fail")
+	}
+	for _, i := range []int{3, 2, 0, 1} {
+		if err := ds[i].deliver(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ds[0].deliver(); err == nil {
+		t.Error("expected error delivering a message twice")
+	}
+
+	want := []string{"b:two", "a:two", "a:one", "b:one"}
+	if len(processed) != len(want) {
+		t.Fatalf("got processed %v, want %v", processed, want)
+	}
+	for i := range want {
+		if processed[i] != want[i] {
+			t.Fatalf("got processed %v, want %v", processed, want)
+		}
+	}
+	if ds := pending(); len(ds) != 0 {
+		t.Errorf("got %d pending deliveries, want none", len(ds))
+	}
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -17,3 +18,60 @@ type TopicImplementation interface {
 	PublishMessage(ctx context.Context, orderingKey string, attrs map[string]string, data []byte) (id string, err error)
 	Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadline time.Duration, retryPolicy *RetryPolicy, implCfg *config.PubsubSubscription, f RawSubscriptionCallback)
 }
+
+// TopicChecker is implemented by topic implementations that can verify
+// the topic exists and is accessible. It's used for preflight checks on startup.
+type TopicChecker interface {
+	CheckTopic(ctx context.Context) error
+}
+
+// DeadLetterEmulator is implemented by topic implementations whose provider
+// has no native support for dead-letter topics. For those the runtime itself
+// publishes messages that have exhausted their deliveries to the dead-letter topic.
+type DeadLetterEmulator interface {
+	EmulateDeadLetters()
+}
+
+// OutgoingMessage is a message to be published as part of a batch.
+type OutgoingMessage struct {
+	OrderingKey string
+	Attrs       map[string]string
+	Data        []byte
+}
+
+// PublishResult is the result of publishing a single message in a batch.
+type PublishResult struct {
+	ID  string
+	Err error
+}
+
+// BatchPublisher is implemented by topic implementations whose provider
+// supports publishing multiple messages in a single request.
+type BatchPublisher interface {
+	// PublishMessages publishes the messages, returning a result for each message in the same order.
+	PublishMessages(ctx context.Context, msgs []OutgoingMessage) []PublishResult
+}
+
+// DelayedPublisher is implemented by topic implementations whose provider
+// supports delaying the delivery of individual messages natively.
+type DelayedPublisher interface {
+	// PublishDelayedMessage publishes a message that becomes visible to subscribers after the given delay.
+	// The provider may deliver the message earlier than requested if the delay exceeds what it supports,
+	// in which case the remaining delay is enforced when the message is received.
+	PublishDelayedMessage(ctx context.Context, orderingKey string, attrs map[string]string, data []byte, delay time.Duration) (id string, err error)
+}
+
+// DeliverAtAttribute is the attribute name for the earliest time (in RFC 3339 format)
+// a delayed message may be delivered.
+const DeliverAtAttribute = "encore_deliver_at"
+
+// DeferredDelivery is returned by a RawSubscriptionCallback when a message was received
+// before its delivery time. Topic implementations should redeliver the message after Delay
+// without treating it as a failed delivery.
+type DeferredDelivery struct {
+	Delay time.Duration
+}
+
+func (d *DeferredDelivery) Error() string {
+	return fmt.Sprintf("message delivery deferred for %s", d.Delay)
+}
@@ -128,9 +128,45 @@ type TopicConfig struct {
 	// - AWS: 300 messages per second for the topic (see [AWS SQS Quotas]).
 	// - GCP: 1MB/s for each ordering key (see [GCP PubSub Quotas]).
 	//
-	// Note: OrderingAttribute currently has no effect during local development.
+	// During local development messages are delivered to each subscription one at a time,
+	// in the order they were published.
 	//
 	// [AWS SQS Quotas]: https://docs.aws.amazon.com/AWSSimpleQueueService/latest/SQSDeveloperGuide/quotas-messages.html
 	// [GCP PubSub Quotas]: https://cloud.google.com/pubsub/quotas#resource_limits
 	OrderingAttribute string
+
+	// Ordered enables ordered delivery using ordering keys given when publishing
+	// messages with pubsub.WithOrderingKey, for when the ordering key isn't
+	// part of the message. Messages with the same ordering key are delivered
+	// in the order they were published, in the same way as with OrderingAttribute.
+	//
+	//  var topic = pubsub.NewTopic[*UserEvent]("user-events", pubsub.TopicConfig{
+	// 		DeliveryGuarantee: pubsub.ExactlyOnce,
+	//		Ordered:           true,
+	//	})
+	//
+	//  topic.Publish(ctx, &UserEvent{Action: "login"}, pubsub.WithOrderingKey(userID))
+	//
+	// Ordered topics are implemented using FIFO message groups on AWS and ordering keys on GCP,
+	// and require the ExactlyOnce delivery guarantee. Messages published without an ordering key
+	// can be delivered in any order relative to other messages.
+	Ordered bool
+
+	// Tags are key-value pairs applied to the provisioned topic as resource
+	// tags or labels, for example to attribute costs or record data residency:
+	//
+	//	Tags: map[string]string{"cost-center": "billing"},
+	//
+	// Tag keys must start with a lowercase letter, and keys and values may only
+	// contain lowercase letters, numbers, dashes and underscores (at most 63 characters).
+	// The map must be given as a literal with constant keys and values.
+	Tags map[string]string
+
+	// Regions restricts the regions the topic can be provisioned in, for example
+	// to keep data within the EU. Each entry is a region name like "eu-west-1",
+	// or a prefix ending with "*" like "eu-*".
+	//
+	// Self-hosted builds check the regions configured in the infra config
+	// against these when building the image. If empty, any region is allowed.
+	Regions []string
 }
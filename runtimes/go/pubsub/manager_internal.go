@@ -9,6 +9,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/preflight"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
@@ -26,11 +27,13 @@ type Manager struct {
 	rootLogger zerolog.Logger
 	json       jsoniter.API
 	providers  []provider
+	preflight  *preflight.Registry
 
 	publishCounter  uint64
 	pushHandlers    map[types.SubscriptionID]http.HandlerFunc
 	runningFetches  sync.WaitGroup
 	runningHandlers sync.WaitGroup
+	lag             lagTracker
 }
 
 func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker,
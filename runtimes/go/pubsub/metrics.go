@@ -0,0 +1,64 @@
+package pubsub
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/infrasdk/metrics/system"
+)
+
+// metricSubscriptionLag is the name of the metric reporting, for each subscription,
+// the time between publishing and delivering the most recently delivered message.
+const metricSubscriptionLag = "e_sys_pubsub_subscription_lag_seconds"
+
+type subscriptionKey struct {
+	topic, subscription string
+}
+
+// lagTracker tracks the delivery lag of each subscription.
+type lagTracker struct {
+	mu  sync.Mutex
+	lag map[subscriptionKey]time.Duration
+}
+
+// record records the delivery of a message published at the given time.
+func (t *lagTracker) record(topic, subscription string, published time.Time) {
+	if published.IsZero() {
+		return
+	}
+	lag := max(time.Since(published), 0)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.lag == nil {
+		t.lag = make(map[subscriptionKey]time.Duration)
+	}
+	t.lag[subscriptionKey{topic, subscription}] = lag
+}
+
+// lagMetrics reports the delivery lag of each subscription.
+func (mgr *Manager) lagMetrics() []system.Sample {
+	t := &mgr.lag
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	keys := slices.SortedFunc(maps.Keys(t.lag), func(a, b subscriptionKey) int {
+		return cmp.Or(cmp.Compare(a.topic, b.topic), cmp.Compare(a.subscription, b.subscription))
+	})
+
+	samples := make([]system.Sample, 0, len(keys))
+	for _, key := range keys {
+		samples = append(samples, system.Sample{
+			Name: metricSubscriptionLag,
+			Labels: []system.Label{
+				{Key: "topic", Value: key.topic},
+				{Key: "subscription", Value: key.subscription},
+			},
+			Value: t.lag[key].Seconds(),
+		})
+	}
+	return samples
+}
@@ -0,0 +1,56 @@
+package pubsub
+
+import "time"
+
+// PublishOption describes available options for the Publish operation.
+type PublishOption interface {
+	//publicapigen:keep
+	publishOption()
+
+	applyPublish(*publishOptions)
+}
+
+// WithOrderingKey specifies the ordering key of the message being published.
+//
+// Messages published with the same ordering key are delivered to each
+// subscription in the order they were published. It overrides the key
+// taken from the topic's OrderingAttribute, if any.
+//
+// The topic must be configured with Ordered set to true or with an OrderingAttribute.
+func WithOrderingKey(key string) withOrderingKeyOption {
+	return withOrderingKeyOption{key: key}
+}
+
+//publicapigen:keep
+type withOrderingKeyOption struct {
+	key string
+}
+
+//publicapigen:keep
+func (o withOrderingKeyOption) publishOption() {}
+
+func (o withOrderingKeyOption) applyPublish(opts *publishOptions) { opts.orderingKey = o.key }
+
+// WithDeliveryDelay delays the delivery of the message being published
+// to subscribers until the given duration has passed.
+//
+// The delay is implemented natively by providers that support it, and otherwise
+// by deferring the message when it's received before its delivery time.
+func WithDeliveryDelay(delay time.Duration) withDeliveryDelayOption {
+	return withDeliveryDelayOption{delay: delay}
+}
+
+//publicapigen:keep
+type withDeliveryDelayOption struct {
+	delay time.Duration
+}
+
+//publicapigen:keep
+func (o withDeliveryDelayOption) publishOption() {}
+
+func (o withDeliveryDelayOption) applyPublish(opts *publishOptions) { opts.delay = o.delay }
+
+type publishOptions struct {
+	orderingKey string
+	delay       time.Duration
+}
@@ -0,0 +1,20 @@
+package pubsub
+
+import (
+	"encore.dev/appruntime/shared/preflight"
+	"encore.dev/pubsub/internal/types"
+)
+
+// registerPreflightChecks registers preflight checks for the topics
+// bound to the running application with r, as they are declared.
+func (mgr *Manager) registerPreflightChecks(r *preflight.Registry) {
+	mgr.preflight = r
+}
+
+// registerPreflightCheck registers a preflight check for the topic,
+// if the provider supports checking that topics exist.
+func (mgr *Manager) registerPreflightCheck(name string, impl types.TopicImplementation) {
+	if checker, ok := impl.(types.TopicChecker); ok && mgr.preflight != nil {
+		mgr.preflight.Register("pubsub", name, checker.CheckTopic)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !encore_no_kafka
+
+package pubsub
+
+import "encore.dev/pubsub/internal/kafka"
+
+func init() {
+	registerProvider(func(mgr *Manager) provider {
+		return kafka.NewManager(mgr.ctxs, mgr.rt)
+	})
+}
@@ -22,7 +22,10 @@ type TopicPerms[T any] interface {
 // to Encore's static analysis restrictions that apply to MyTopic.
 type Publisher[T any] interface {
 	// Publish publishes a message to the topic.
-	Publish(ctx context.Context, msg T) (id string, err error)
+	Publish(ctx context.Context, msg T, opts ...PublishOption) (id string, err error)
+
+	// PublishBatch publishes multiple messages to the topic.
+	PublishBatch(ctx context.Context, msgs []T, opts ...PublishOption) (ids []string, err error)
 
 	// Meta returns metadata about the topic.
 	Meta() TopicMeta
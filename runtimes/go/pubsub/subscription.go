@@ -7,13 +7,16 @@ import (
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
 
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/pubsubfilter"
 	"encore.dev/appruntime/exported/trace2"
 	"encore.dev/appruntime/shared/cfgutil"
 	"encore.dev/beta/errs"
 	"encore.dev/pubsub/internal/noop"
+	"encore.dev/pubsub/internal/types"
 	"encore.dev/pubsub/internal/utils"
 )
 
@@ -87,6 +90,35 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 	cfg.RetryPolicy.MinBackoff = utils.WithDefaultValue(cfg.RetryPolicy.MinBackoff, 10*time.Second)
 	cfg.RetryPolicy.MaxBackoff = utils.WithDefaultValue(cfg.RetryPolicy.MaxBackoff, 10*time.Minute)
 
+	if cfg.DeadLetter != nil {
+		if cfg.DeadLetter.Topic == nil {
+			panic("DeadLetter.Topic must be set")
+		} else if cfg.DeadLetter.MaxDeliveries < 0 {
+			panic("DeadLetter.MaxDeliveries cannot be negative")
+		}
+		cfg.DeadLetter.MaxDeliveries = utils.WithDefaultValue(cfg.DeadLetter.MaxDeliveries, 5)
+
+		// Retry until the message is dead-lettered.
+		if cfg.DeadLetter.MaxDeliveries == 1 {
+			cfg.RetryPolicy.MaxRetries = NoRetries
+		} else {
+			cfg.RetryPolicy.MaxRetries = cfg.DeadLetter.MaxDeliveries - 1
+		}
+	}
+
+	if cfg.DeliveryDelay < 0 {
+		panic("DeliveryDelay cannot be negative")
+	}
+
+	var filter *pubsubfilter.Filter
+	if cfg.Filter != "" {
+		var err error
+		filter, err = pubsubfilter.Parse(cfg.Filter)
+		if err != nil {
+			panic(fmt.Sprintf("invalid Filter: %v", err))
+		}
+	}
+
 	if cfg.AckDeadline == 0 {
 		cfg.AckDeadline = 30 * time.Second
 	} else if cfg.AckDeadline < 0 {
@@ -120,13 +152,13 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 		Str("subscription", name).
 		Logger()
 
-	// Subscribe to the topic
-	topic.topic.Subscribe(&log, cfg.MaxConcurrency, cfg.AckDeadline, cfg.RetryPolicy, subscription, func(ctx context.Context, msgID string, publishTime time.Time, deliveryAttempt int, attrs map[string]string, data []byte) (err error) {
+	handler := func(ctx context.Context, msgID string, publishTime time.Time, deliveryAttempt int, attrs map[string]string, data []byte) (err error) {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 		mgr.runningHandlers.Add(1)
 		defer mgr.runningHandlers.Done()
+		mgr.lag.record(topic.runtimeCfg.EncoreName, subscription.EncoreName, publishTime)
 
 		if !mgr.static.Testing {
 			// Under test we're already inside an operation
@@ -196,6 +228,7 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 				Published:      publishTime,
 				DecodedPayload: msg,
 				Payload:        marshalParams(mgr.json, msg),
+				Replayed:       attrs[replayAttribute] != "",
 			},
 			DefLoc: staticCfg.TraceIdx,
 			SvcNum: staticCfg.SvcNum,
@@ -220,9 +253,40 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 		curr := mgr.rt.Current()
 		if curr.Trace != nil {
 			curr.Trace.PubsubMessageSpanStart(req, curr.Goctr)
+
+			// If the message was published by another app, link to the span that published it,
+			// so the flow can be followed across apps.
+			if link, ok := mgr.crossAppLink(attrs, parentTraceID); ok {
+				link.EventParams = trace2.EventParams{
+					TraceID: req.TraceID,
+					SpanID:  req.SpanID,
+					Goid:    curr.Goctr,
+				}
+				curr.Trace.PubsubMessageLink(link)
+			}
 		}
 
-		err = panicCatchWrapper(ctx, msg)
+		if cfg.Idempotency != nil && !req.MsgData.Replayed {
+			key := idempotencyKey(subscription.EncoreName, msgID)
+			err = processIdempotently(ctx, &reqLogger, cfg.Idempotency, key, func() {
+				if curr.Trace != nil {
+					curr.Trace.LogMessage(trace2.LogMessageParams{
+						EventParams: trace2.EventParams{
+							TraceID: req.TraceID,
+							SpanID:  req.SpanID,
+							Goid:    curr.Goctr,
+						},
+						Level:  model.LevelInfo,
+						Msg:    "duplicate message suppressed",
+						Fields: []trace2.LogField{{Key: "idempotency_key", Value: key}},
+					})
+				}
+			}, func() error {
+				return panicCatchWrapper(ctx, msg)
+			})
+		} else {
+			err = panicCatchWrapper(ctx, msg)
+		}
 
 		if curr.Trace != nil {
 			resp := &model.Response{
@@ -242,7 +306,31 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 		mgr.rt.FinishRequest(false)
 
 		return err
-	})
+	}
+
+	// If the provider can't dead-letter messages itself, forward messages
+	// to the dead-letter topic once they've exhausted their deliveries.
+	if _, emulate := topic.topic.(types.DeadLetterEmulator); emulate && cfg.DeadLetter != nil {
+		handler = deadLetterHandler(&log, cfg.DeadLetter, handler)
+	}
+
+	// Defer messages received before their delivery time. Under test messages are delivered immediately.
+	if !mgr.static.Testing {
+		handler = delayedDeliveryHandler(cfg.DeliveryDelay, handler)
+	}
+
+	// Acknowledge messages not matching the filter without processing them.
+	// The filter is also provisioned in the cloud where supported,
+	// in which case this is a no-op.
+	if filter != nil {
+		handler = filteredHandler(filter, handler)
+	}
+
+	// Acknowledge messages replayed to other subscriptions without processing them.
+	handler = replayedHandler(subscription.EncoreName, handler)
+
+	// Subscribe to the topic
+	topic.topic.Subscribe(&log, cfg.MaxConcurrency, cfg.AckDeadline, cfg.RetryPolicy, subscription, handler)
 
 	if !mgr.static.Testing {
 		// Log the subscription registration - unless we're in unit tests
@@ -252,6 +340,66 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 	return &Subscription[T]{topic: topic, name: name, cfg: cfg, mgr: mgr}
 }
 
+// deadLetterHandler wraps a subscription handler to publish messages to the dead-letter
+// topic when processing fails on the final delivery attempt.
+func deadLetterHandler[T any](log *zerolog.Logger, dl *DeadLetterPolicy[T], handler types.RawSubscriptionCallback) types.RawSubscriptionCallback {
+	return func(ctx context.Context, msgID string, publishTime time.Time, deliveryAttempt int, attrs map[string]string, data []byte) error {
+		err := handler(ctx, msgID, publishTime, deliveryAttempt, attrs, data)
+		if err == nil || deliveryAttempt < dl.MaxDeliveries {
+			return err
+		}
+
+		id, pubErr := dl.Topic.publishRaw(context.WithoutCancel(ctx), attrs, data)
+		if pubErr != nil {
+			log.Err(pubErr).Str("msg_id", msgID).Int("delivery_attempt", deliveryAttempt).Msg("failed to publish message to dead-letter topic")
+			return err
+		}
+		log.Warn().Err(err).Str("msg_id", msgID).Str("dead_letter_msg_id", id).Int("delivery_attempt", deliveryAttempt).
+			Msgf("depleted message deliveries, published message to dead-letter topic %s", dl.Topic.runtimeCfg.EncoreName)
+		return nil
+	}
+}
+
+// delayedDeliveryHandler wraps a subscription handler to defer messages that are
+// received before their delivery time, which is the latest of the publish time plus
+// the subscription's delivery delay and the delivery time requested when publishing.
+func delayedDeliveryHandler(delay time.Duration, handler types.RawSubscriptionCallback) types.RawSubscriptionCallback {
+	return func(ctx context.Context, msgID string, publishTime time.Time, deliveryAttempt int, attrs map[string]string, data []byte) error {
+		deliverAt := publishTime.Add(delay)
+		if val := attrs[deliverAtAttribute]; val != "" {
+			if t, err := time.Parse(time.RFC3339Nano, val); err == nil && t.After(deliverAt) {
+				deliverAt = t
+			}
+		}
+
+		if wait := time.Until(deliverAt); wait > 0 {
+			return &types.DeferredDelivery{Delay: wait}
+		}
+		return handler(ctx, msgID, publishTime, deliveryAttempt, attrs, data)
+	}
+}
+
+// filteredHandler wraps a subscription handler to only process messages matching the filter.
+func filteredHandler(filter *pubsubfilter.Filter, handler types.RawSubscriptionCallback) types.RawSubscriptionCallback {
+	return func(ctx context.Context, msgID string, publishTime time.Time, deliveryAttempt int, attrs map[string]string, data []byte) error {
+		if !filter.Match(attrs) {
+			return nil
+		}
+		return handler(ctx, msgID, publishTime, deliveryAttempt, attrs, data)
+	}
+}
+
+// replayedHandler wraps a subscription handler to only process replayed messages
+// if they were replayed to all subscriptions or to the given subscription.
+func replayedHandler(subscription string, handler types.RawSubscriptionCallback) types.RawSubscriptionCallback {
+	return func(ctx context.Context, msgID string, publishTime time.Time, deliveryAttempt int, attrs map[string]string, data []byte) error {
+		if target := attrs[replayAttribute]; target != "" && target != "*" && target != subscription {
+			return nil
+		}
+		return handler(ctx, msgID, publishTime, deliveryAttempt, attrs, data)
+	}
+}
+
 // SubscriptionMeta contains metadata about a subscription.
 // The fields should not be modified by the caller.
 // Additional fields may be added in the future.
@@ -3,7 +3,9 @@ package pubsub
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"time"
 
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/model"
@@ -63,6 +65,7 @@ func newTopic[T any](mgr *Manager, name string, cfg TopicConfig) *Topic[T] {
 	for _, p := range mgr.providers {
 		if p.Matches(provider) {
 			impl := p.NewTopic(provider, cfg, topic)
+			mgr.registerPreflightCheck(name, impl)
 			return &Topic[T]{
 				staticCfg:      cfg,
 				mgr:            mgr,
@@ -79,6 +82,19 @@ func newTopic[T any](mgr *Manager, name string, cfg TopicConfig) *Topic[T] {
 	panic("unreachable")
 }
 
+// publishRaw publishes an already marshalled message with the given attributes
+// to the topic, as when forwarding a message to a dead-letter topic.
+func (t *Topic[T]) publishRaw(ctx context.Context, attrs map[string]string, data []byte) (id string, err error) {
+	var orderingKey string
+	if t.staticCfg.OrderingAttribute != "" {
+		orderingKey = attrs[t.staticCfg.OrderingAttribute]
+	}
+	if err = t.publishLimiter.Wait(ctx); err != nil {
+		return "", err
+	}
+	return t.topic.PublishMessage(ctx, orderingKey, attrs, data)
+}
+
 // TopicMeta contains metadata about a topic.
 // The fields should not be modified by the caller.
 // Additional fields may be added in the future.
@@ -103,7 +119,10 @@ func (t *Topic[T]) Meta() TopicMeta {
 //
 // If an error is returned, it is probable that the message failed to be published, however it is possible
 // that the message could still be received by subscriptions to the topic.
-func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
+//
+// Use [WithOrderingKey] to specify the ordering key of the message on ordered topics,
+// and [WithDeliveryDelay] to delay its delivery to subscribers.
+func (t *Topic[T]) Publish(ctx context.Context, msg T, opts ...PublishOption) (id string, err error) {
 	if ctx.Err() != nil {
 		return "", ctx.Err()
 	}
@@ -112,51 +131,16 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 		return "", errs.B().Code(errs.Unimplemented).Msg("pubsub topic was not created using pubsub.NewTopic").Err()
 	}
 
-	// Extract the message attributes
-	attrs, err := utils.MarshalFields(msg, utils.AttrTag)
-	if err != nil {
-		return "", errs.B().Cause(err).Code(errs.InvalidArgument).Msgf("failed to extract message attributes for topic %s", t.runtimeCfg.EncoreName).Err()
+	var o publishOptions
+	for _, opt := range opts {
+		opt.applyPublish(&o)
 	}
 
-	// Marshal the message to JSON
-	data, err := json.Marshal(msg)
+	out, err := t.prepareMessage(msg, &o)
 	if err != nil {
-		return "", errs.B().Cause(err).Code(errs.InvalidArgument).Msgf("failed to marshal message to JSON for topic %s", t.runtimeCfg.EncoreName).Err()
-	}
-
-	// Add the ordering attribute if it is set
-	var orderingKey string
-	if t.staticCfg.OrderingAttribute != "" {
-		value, found := attrs[t.staticCfg.OrderingAttribute]
-		if !found {
-			// This is checked statically, so this should never happen
-			return "", errs.B().Code(errs.InvalidArgument).Msgf("ordering attribute %s not found in message for topic %s", t.staticCfg.OrderingAttribute, t.runtimeCfg.EncoreName).Err()
-		}
-
-		if value == "" {
-			return "", errs.B().Code(errs.InvalidArgument).Msgf("ordering attribute %s cannot be an empty string for topic %s", t.staticCfg.OrderingAttribute, t.runtimeCfg.EncoreName).Err()
-		}
-
-		orderingKey = value
-	}
-
-	// Add the correlation ID to the attributes
-	if req := t.mgr.rt.Current().Req; req != nil {
-		// Pass our trace ID through, so the subscribers can mark their traces as children of this trace
-		if req.TraceID != (model.TraceID{}) {
-			attrs[parentTraceIDAttribute] = req.TraceID.String()
-		}
-
-		if req.ExtCorrelationID != "" {
-			// If we have a correlation ID from the request, use that
-			attrs[extCorrelationIDAttribute] = req.ExtCorrelationID
-		} else if req.TraceID != (model.TraceID{}) {
-			// Otherwise this is the first request in the event chain, so this trace ID becomes the correlation ID
-			attrs[extCorrelationIDAttribute] = req.TraceID.String()
-		}
-
-		attrs[parentSampledAttribute] = strconv.FormatBool(req.Traced)
+		return "", err
 	}
+	orderingKey, attrs, data := out.OrderingKey, out.Attrs, out.Data
 
 	// Start the trace span
 	curr := t.mgr.rt.Current()
@@ -177,7 +161,7 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 	// Publish once the rate limiter allows it
 	if err = t.publishLimiter.Wait(ctx); err == nil {
 		// Publish to the clouds topic
-		id, err = t.topic.PublishMessage(ctx, orderingKey, attrs, data)
+		id, err = t.publishMessage(ctx, orderingKey, attrs, data, o.delay)
 	}
 
 	// End the trace span
@@ -200,3 +184,212 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 
 	return id, nil
 }
+
+// PublishBatch publishes multiple messages to the topic and returns a unique message ID for each message,
+// in the same order as the messages.
+//
+// Where the cloud provider supports it, messages are published using its batch publishing APIs,
+// making this considerably faster than publishing the messages one at a time.
+//
+// If some of the messages fail to be published the returned error wraps a [*PublishBatchError]
+// reporting which messages failed (use errors.As to access it), and the IDs of the failed messages are empty.
+// The options apply to all messages in the batch.
+func (t *Topic[T]) PublishBatch(ctx context.Context, msgs []T, opts ...PublishOption) (ids []string, err error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if t.runtimeCfg == nil || t.topic == nil {
+		return nil, errs.B().Code(errs.Unimplemented).Msg("pubsub topic was not created using pubsub.NewTopic").Err()
+	}
+
+	var o publishOptions
+	for _, opt := range opts {
+		opt.applyPublish(&o)
+	}
+
+	out := make([]types.OutgoingMessage, len(msgs))
+	for i, msg := range msgs {
+		if out[i], err = t.prepareMessage(msg, &o); err != nil {
+			return nil, err
+		}
+	}
+
+	// Start the trace span
+	curr := t.mgr.rt.Current()
+	var startEventID trace2.EventID
+	if curr.Req != nil && curr.Trace != nil {
+		batch := make([][]byte, len(out))
+		for i, msg := range out {
+			batch[i] = msg.Data
+		}
+		startEventID = curr.Trace.PubsubPublishStart(trace2.PubsubPublishStartParams{
+			EventParams: trace2.EventParams{
+				TraceID: curr.Req.TraceID,
+				SpanID:  curr.Req.SpanID,
+				Goid:    curr.Goctr,
+			},
+			Topic: t.runtimeCfg.EncoreName,
+			Batch: batch,
+			Stack: stack.Build(1),
+		})
+	}
+
+	results := t.publishMessages(ctx, out, o.delay)
+
+	// End the trace span
+	if curr.Req != nil && curr.Trace != nil {
+		batchResults := make([]trace2.PubsubPublishResult, len(results))
+		for i, res := range results {
+			batchResults[i] = trace2.PubsubPublishResult{MessageID: res.ID, Err: res.Err}
+		}
+		curr.Trace.PubsubPublishEnd(trace2.PubsubPublishEndParams{
+			EventParams: trace2.EventParams{
+				TraceID: curr.Req.TraceID,
+				SpanID:  curr.Req.SpanID,
+				Goid:    curr.Goctr,
+			},
+			StartID:      startEventID,
+			BatchResults: batchResults,
+		})
+	}
+
+	ids = make([]string, len(results))
+	var batchErr *PublishBatchError
+	for i, res := range results {
+		if res.Err != nil {
+			if batchErr == nil {
+				batchErr = &PublishBatchError{Errors: make([]error, len(results))}
+			}
+			batchErr.Errors[i] = res.Err
+		} else {
+			ids[i] = res.ID
+		}
+	}
+	if batchErr != nil {
+		return ids, errs.B().Cause(batchErr).Code(errs.Unavailable).Msgf("failed to publish messages to %s", t.runtimeCfg.EncoreName).Err()
+	}
+	return ids, nil
+}
+
+// publishMessages publishes the messages once the rate limiter allows it,
+// using the topic implementation's batch API if it has one.
+func (t *Topic[T]) publishMessages(ctx context.Context, msgs []types.OutgoingMessage, delay time.Duration) []types.PublishResult {
+	results := make([]types.PublishResult, len(msgs))
+	for i := range msgs {
+		if err := t.publishLimiter.Wait(ctx); err != nil {
+			for j := i; j < len(msgs); j++ {
+				results[j].Err = err
+			}
+			msgs = msgs[:i]
+			break
+		}
+	}
+
+	// Batch publishing doesn't support delaying messages natively.
+	_, delayed := t.topic.(types.DelayedPublisher)
+	if bp, ok := t.topic.(types.BatchPublisher); ok && !(delay > 0 && delayed) {
+		copy(results, bp.PublishMessages(ctx, msgs))
+	} else {
+		for i, msg := range msgs {
+			results[i].ID, results[i].Err = t.publishMessage(ctx, msg.OrderingKey, msg.Attrs, msg.Data, delay)
+		}
+	}
+	return results
+}
+
+// publishMessage publishes a single message, delaying it natively if the
+// topic implementation supports it.
+func (t *Topic[T]) publishMessage(ctx context.Context, orderingKey string, attrs map[string]string, data []byte, delay time.Duration) (id string, err error) {
+	if dp, ok := t.topic.(types.DelayedPublisher); ok && delay > 0 {
+		return dp.PublishDelayedMessage(ctx, orderingKey, attrs, data, delay)
+	}
+	return t.topic.PublishMessage(ctx, orderingKey, attrs, data)
+}
+
+// prepareMessage marshals a message and computes its attributes and ordering key.
+func (t *Topic[T]) prepareMessage(msg T, o *publishOptions) (types.OutgoingMessage, error) {
+	// Extract the message attributes
+	attrs, err := utils.MarshalFields(msg, utils.AttrTag)
+	if err != nil {
+		return types.OutgoingMessage{}, errs.B().Cause(err).Code(errs.InvalidArgument).Msgf("failed to extract message attributes for topic %s", t.runtimeCfg.EncoreName).Err()
+	}
+
+	// Marshal the message to JSON
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return types.OutgoingMessage{}, errs.B().Cause(err).Code(errs.InvalidArgument).Msgf("failed to marshal message to JSON for topic %s", t.runtimeCfg.EncoreName).Err()
+	}
+
+	// Determine the ordering key, preferring an explicitly given one over the ordering attribute
+	var orderingKey string
+	if o.orderingKey != "" {
+		if !t.staticCfg.Ordered && t.staticCfg.OrderingAttribute == "" {
+			return types.OutgoingMessage{}, errs.B().Code(errs.InvalidArgument).Msgf("cannot publish with an ordering key to topic %s as it is not ordered", t.runtimeCfg.EncoreName).Err()
+		}
+		orderingKey = o.orderingKey
+	} else if t.staticCfg.OrderingAttribute != "" {
+		value, found := attrs[t.staticCfg.OrderingAttribute]
+		if !found {
+			// This is checked statically, so this should never happen
+			return types.OutgoingMessage{}, errs.B().Code(errs.InvalidArgument).Msgf("ordering attribute %s not found in message for topic %s", t.staticCfg.OrderingAttribute, t.runtimeCfg.EncoreName).Err()
+		}
+
+		if value == "" {
+			return types.OutgoingMessage{}, errs.B().Code(errs.InvalidArgument).Msgf("ordering attribute %s cannot be an empty string for topic %s", t.staticCfg.OrderingAttribute, t.runtimeCfg.EncoreName).Err()
+		}
+
+		orderingKey = value
+	}
+
+	// Add the correlation ID to the attributes
+	if req := t.mgr.rt.Current().Req; req != nil {
+		// Pass our trace ID through, so the subscribers can mark their traces as children of this trace
+		if req.TraceID != (model.TraceID{}) {
+			attrs[parentTraceIDAttribute] = req.TraceID.String()
+			attrs[parentSpanIDAttribute] = req.SpanID.String()
+			attrs[originAppAttribute] = t.mgr.appName()
+			attrs[originEnvAttribute] = t.mgr.runtime.EnvName
+		}
+
+		if req.ExtCorrelationID != "" {
+			// If we have a correlation ID from the request, use that
+			attrs[extCorrelationIDAttribute] = req.ExtCorrelationID
+		} else if req.TraceID != (model.TraceID{}) {
+			// Otherwise this is the first request in the event chain, so this trace ID becomes the correlation ID
+			attrs[extCorrelationIDAttribute] = req.TraceID.String()
+		}
+
+		attrs[parentSampledAttribute] = strconv.FormatBool(req.Traced)
+	}
+
+	// Record when the message may be delivered, so the delay can be enforced by subscribers
+	// where the provider can't delay messages natively.
+	if o.delay > 0 {
+		attrs[deliverAtAttribute] = time.Now().Add(o.delay).UTC().Format(time.RFC3339Nano)
+	}
+
+	return types.OutgoingMessage{OrderingKey: orderingKey, Attrs: attrs, Data: data}, nil
+}
+
+// PublishBatchError is returned by PublishBatch when some of the messages failed to be published.
+type PublishBatchError struct {
+	// Errors holds the error for each message in the batch,
+	// in the same order as the messages. It's nil for messages
+	// that were published successfully.
+	Errors []error
+}
+
+func (e *PublishBatchError) Error() string {
+	failed := 0
+	var first error
+	for _, err := range e.Errors {
+		if err != nil {
+			failed++
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	return fmt.Sprintf("%d of %d messages failed to publish: %v", failed, len(e.Errors), first)
+}
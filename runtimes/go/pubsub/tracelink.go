@@ -0,0 +1,39 @@
+package pubsub
+
+import (
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+)
+
+// appName returns the name identifying this app in messages it publishes.
+func (mgr *Manager) appName() string {
+	if mgr.runtime.AppSlug != "" {
+		return mgr.runtime.AppSlug
+	}
+	return mgr.runtime.AppID
+}
+
+// crossAppLink reports the link to the span that published a message,
+// if the message was published by another app (or another environment
+// of this app). The returned params lack the EventParams of the span
+// processing the message.
+func (mgr *Manager) crossAppLink(attrs map[string]string, parentTraceID model.TraceID) (trace2.PubsubMessageLinkParams, bool) {
+	app, env := attrs[originAppAttribute], attrs[originEnvAttribute]
+	if app == "" || parentTraceID.IsZero() {
+		return trace2.PubsubMessageLinkParams{}, false
+	} else if app == mgr.appName() && env == mgr.runtime.EnvName {
+		// Messages published by this app are already linked through the parent trace id.
+		return trace2.PubsubMessageLinkParams{}, false
+	}
+
+	spanID, err := model.ParseSpanID(attrs[parentSpanIDAttribute])
+	if err != nil || spanID.IsZero() {
+		return trace2.PubsubMessageLinkParams{}, false
+	}
+	return trace2.PubsubMessageLinkParams{
+		LinkedTraceID: parentTraceID,
+		LinkedSpanID:  spanID,
+		App:           app,
+		Env:           env,
+	}, true
+}
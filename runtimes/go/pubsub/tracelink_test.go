@@ -0,0 +1,52 @@
+package pubsub
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+)
+
+func TestCrossAppLink(t *testing.T) {
+	c := qt.New(t)
+	mgr := &Manager{runtime: &config.Runtime{AppID: "app-id", AppSlug: "orders", EnvName: "prod"}}
+
+	traceID := model.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanID := model.SpanID{8, 7, 6, 5, 4, 3, 2, 1}
+	attrs := func(app, env string) map[string]string {
+		return map[string]string{
+			parentTraceIDAttribute: traceID.String(),
+			parentSpanIDAttribute:  spanID.String(),
+			originAppAttribute:     app,
+			originEnvAttribute:     env,
+		}
+	}
+
+	link, ok := mgr.crossAppLink(attrs("billing", "prod"), traceID)
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(link, qt.DeepEquals, trace2.PubsubMessageLinkParams{
+		LinkedTraceID: traceID,
+		LinkedSpanID:  spanID,
+		App:           "billing",
+		Env:           "prod",
+	})
+
+	// Another environment of the same app is linked too.
+	_, ok = mgr.crossAppLink(attrs("orders", "staging"), traceID)
+	c.Assert(ok, qt.IsTrue)
+
+	// Messages from this app, or without span context, are not.
+	_, ok = mgr.crossAppLink(attrs("orders", "prod"), traceID)
+	c.Assert(ok, qt.IsFalse)
+	_, ok = mgr.crossAppLink(attrs("", ""), traceID)
+	c.Assert(ok, qt.IsFalse)
+	_, ok = mgr.crossAppLink(attrs("billing", "prod"), model.TraceID{})
+	c.Assert(ok, qt.IsFalse)
+	noSpan := attrs("billing", "prod")
+	delete(noSpan, parentSpanIDAttribute)
+	_, ok = mgr.crossAppLink(noSpan, traceID)
+	c.Assert(ok, qt.IsFalse)
+}
@@ -10,12 +10,29 @@ import (
 // parentTraceIDAttribute is the attribute name we use to track request correlation IDs
 const parentTraceIDAttribute = "encore_parent_trace_id"
 
+// parentSpanIDAttribute is the attribute name for the span that published the message
+const parentSpanIDAttribute = "encore_parent_span_id"
+
+// originAppAttribute and originEnvAttribute are the attribute names for the app and environment
+// that published the message, used to link traces across apps.
+const (
+	originAppAttribute = "encore_origin_app"
+	originEnvAttribute = "encore_origin_env"
+)
+
 // extCorrelationIDAttribute is the attribute name we use to track externally provided correlation IDs
 const extCorrelationIDAttribute = "encore_ext_correlation_id"
 
 // parentSampledAttribute is the attribute name for determining if the parent was sampled.
 const parentSampledAttribute = "encore_parent_sampled"
 
+// deliverAtAttribute is the attribute name for the earliest time a delayed message may be delivered.
+const deliverAtAttribute = types.DeliverAtAttribute
+
+// replayAttribute is the attribute name set on messages replayed by "encore pubsub replay".
+// Its value is the name of the subscription the message is replayed to, or "*" for all subscriptions.
+const replayAttribute = "encore_replay"
+
 // SubscriptionConfig is used when creating a subscription
 //
 // The values given here may be clamped to the supported values by
@@ -70,10 +87,21 @@ type SubscriptionConfig[T any] struct {
 	// [GCP Push Delivery Rate]: https://cloud.google.com/pubsub/docs/push#push_delivery_rate
 	MaxConcurrency int
 
-	// Filter is a boolean expression using =, !=, IN, &&
-	// It is used to filter which messages are forwarded from the
-	// topic to a subscription
-	// Filter string - Filters are not currently supported
+	// Filter is a boolean expression on message attributes
+	// used to filter which messages are forwarded from the topic
+	// to the subscription. It supports =, !=, IN and &&, for example:
+	//
+	//	Filter: `region IN ("eu", "us") && kind != "test"`
+	//
+	// The attributes are the message fields tagged with `pubsub-attr`.
+	// A condition only matches messages which have the attribute set.
+	//
+	// Messages which don't match the filter are acknowledged without
+	// being passed to the Handler. Defaults to receiving all messages.
+	//
+	// The filter is parsed at compile time, such that it can be provisioned
+	// as part of the subscription in the target cloud.
+	Filter string
 
 	// AckDeadline is the time a consumer has to process a message
 	// before it's returned to the subscription
@@ -90,6 +118,61 @@ type SubscriptionConfig[T any] struct {
 	// RetryPolicy defines how a message should be retried when
 	// the subscriber returns an error
 	RetryPolicy *RetryPolicy
+
+	// DeliveryDelay delays the delivery of every message to the subscription
+	// until the given duration has passed since the message was published.
+	//
+	// Messages published with [WithDeliveryDelay] are delivered once both
+	// delays have passed. Defaults to no delay.
+	DeliveryDelay time.Duration
+
+	// DeadLetter configures a dead-letter topic for the subscription.
+	//
+	// Messages the subscriber has failed to process DeadLetter.MaxDeliveries times
+	// are published to the dead-letter topic instead of being retried further,
+	// so they can be inspected or reprocessed separately.
+	//
+	// If DeadLetter is set, RetryPolicy.MaxRetries must not be set.
+	DeadLetter *DeadLetterPolicy[T]
+
+	// Idempotency, if set, is used to skip messages the subscription has
+	// already processed, such as messages redelivered by the cloud provider
+	// under at-least-once delivery. For example:
+	//
+	//	Idempotency: pubsub.DatabaseIdempotencyStore(db, "processed_messages")
+	//
+	// Messages are identified by their message id and the subscription name,
+	// and are recorded as processed once the Handler returns a nil error.
+	// Skipped messages are acknowledged and annotated in the message's trace.
+	// Messages replayed with "encore pubsub replay" are always processed.
+	//
+	// The check isn't transactional with the Handler: a message redelivered
+	// while it's still being processed can be processed twice.
+	Idempotency IdempotencyStore
+}
+
+// DeadLetterPolicy configures where messages are sent after repeatedly
+// failing to be processed by a subscription.
+//
+// The values given to this structure are parsed at compile time, such that
+// the correct Cloud resources can be provisioned to support the dead-letter topic.
+type DeadLetterPolicy[T any] struct {
+	// Topic is the topic messages are published to once they
+	// have been delivered MaxDeliveries times without being processed successfully.
+	//
+	// It must be declared with pubsub.NewTopic, and cannot be the
+	// topic the subscription is subscribing to.
+	//
+	// This field is required.
+	Topic *Topic[T]
+
+	// MaxDeliveries is the number of times a message is delivered to the
+	// subscriber before it's published to the dead-letter topic.
+	// Defaults to 5.
+	//
+	// The value may be clamped to the range supported by the target cloud
+	// (for example GCP supports between 5 and 100 delivery attempts).
+	MaxDeliveries int
 }
 
 type RetryPolicy = types.RetryPolicy
@@ -3,9 +3,11 @@
 package pubsub
 
 import (
+	"encore.dev/appruntime/infrasdk/metrics/system"
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/jsonapi"
 	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/preflight"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
@@ -25,4 +27,6 @@ func init() {
 		logging.RootLogger, jsonapi.Default,
 	)
 	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	Singleton.registerPreflightChecks(preflight.Singleton)
+	system.RegisterCollector(Singleton.lagMetrics)
 }
@@ -46,6 +46,11 @@ type Request struct {
 	// Trace contains the trace information for the current request.
 	Trace *TraceData
 
+	// Region is the cloud region the request is being processed in,
+	// such as "eu-west-1". It is the same as Meta().Environment.Region,
+	// and is empty if the region is not known, such as for local development.
+	Region string
+
 	// APICall specific parameters.
 	// These will be empty for operations with a type not APICall
 	API        *APIDesc   // Metadata about the API endpoint being called
@@ -78,6 +83,9 @@ type Request struct {
 	// and other requests.
 	//
 	// If the request was not triggered by a Cron Job the value is the empty string.
+	//
+	// For executions simulated in tests with et.RunCron, Started is
+	// the simulated time the execution was scheduled for.
 	CronIdempotencyKey string
 }
 
@@ -152,11 +160,13 @@ func (mgr *Manager) CurrentRequest() *Request {
 		return &Request{
 			Type:    None,
 			Started: applicationStartTime,
+			Region:  mgr.runtime.EnvRegion,
 		}
 	}
 
 	result := &Request{
 		Started: req.Start,
+		Region:  mgr.runtime.EnvRegion,
 		Trace: &TraceData{
 			TraceID:          req.TraceID.String(),
 			SpanID:           req.SpanID.String(),
@@ -197,6 +207,10 @@ func (mgr *Manager) CurrentRequest() *Request {
 
 		if data.FromEncorePlatform {
 			result.CronIdempotencyKey = data.RequestHeaders.Get("X-Encore-Cron-Execution")
+		} else if data.Cron != nil {
+			// A cron job execution simulated by et.RunCron.
+			result.CronIdempotencyKey = data.Cron.ID
+			result.Started = data.Cron.Scheduled
 		}
 
 	case model.PubSubMessage:
@@ -0,0 +1,35 @@
+//go:build encore_app
+
+package secrets
+
+import (
+	secretsmgr "encore.dev/appruntime/infrasdk/secrets"
+)
+
+// Get returns the current value of the secret with the given name,
+// or "" if there is no such secret.
+//
+// Unlike the fields of a service's secrets struct, which hold the values
+// the service started with, Get reflects any rotations made since.
+func Get(name string) string {
+	val, _ := secretsmgr.Singleton.Current(name)
+	return val
+}
+
+// Versions returns the active versions of the secret with the given name,
+// newest first. The first version is the one returned by Get.
+//
+// A secret has multiple active versions while it's being rotated,
+// which lets you accept both the new and the previous value,
+// for example when verifying webhook signatures.
+func Versions(name string) []string {
+	return secretsmgr.Singleton.Versions(name)
+}
+
+// OnChange registers fn to be called whenever one or more secrets are rotated.
+//
+// The function is called from a background goroutine
+// after the new values are available using Get and Versions.
+func OnChange(fn func()) {
+	secretsmgr.Singleton.OnChange(fn)
+}
@@ -0,0 +1,17 @@
+// Package secrets provides access to the current values of an app's secrets,
+// and lets you react to secrets being rotated without redeploying.
+//
+// Secrets are declared and loaded using a `var secrets struct { ... }`
+// declaration in each service, whose fields hold the secret values the service
+// started with. When a secret is rotated its new value is loaded in the
+// background, and is available using Get and Versions. Use OnChange to be
+// notified when that happens, for example to reconnect to a database using new
+// credentials.
+//
+// Since the `secrets` variable conflicts with the name of this package,
+// import it with a different name in services that declare secrets:
+//
+//	import encoresecrets "encore.dev/secrets"
+//
+// For more information about secrets see https://encore.dev/docs/primitives/secrets.
+package secrets
@@ -15,7 +15,7 @@ import (
 // named struct type or a basic type (string, int, etc).
 func NewStringKeyspace[K any](cluster *Cluster, cfg KeyspaceConfig) *StringKeyspace[K] {
 	fromRedis := func(val string) (string, error) { return val, nil }
-	toRedis := func(val string) (any, error) { return val, nil }
+	toRedis := limitValueSize(cluster, cfg, true, func(val string) (any, error) { return val, nil })
 
 	return &StringKeyspace[K]{
 		&basicKeyspace[K, string]{
@@ -47,6 +47,20 @@ func (s *StringKeyspace[K]) MultiGet(ctx context.Context, keys ...K) ([]Result[s
 	return s.basicKeyspace.MultiGet(ctx, keys...)
 }
 
+// GetOrLoad gets the value stored at key. If the key does not exist,
+// the value is loaded by calling load and stored before being returned.
+//
+// The load function reports that no value exists for the key by returning
+// an error matching Miss, in which case GetOrLoad reports an error matching Miss.
+// Other errors from load are returned as-is.
+//
+// Concurrent calls loading the same key share a single call to load.
+// See KeyspaceConfig.NegativeCache and KeyspaceConfig.StaleWhileRevalidate
+// for caching "not found" results and serving stale values while they're refreshed.
+func (s *StringKeyspace[K]) GetOrLoad(ctx context.Context, key K, load func(context.Context, K) (string, error)) (string, error) {
+	return s.basicKeyspace.GetOrLoad(ctx, key, load)
+}
+
 // Set updates the value stored at key to val.
 //
 // See https://redis.io/commands/set/ for more information.
@@ -107,6 +121,12 @@ func (k *StringKeyspace[K]) With(opts ...WriteOption) *StringKeyspace[K] {
 	return &StringKeyspace[K]{k.with(opts)}
 }
 
+// Pipeline returns a new pipeline for batching operations on the keyspace
+// into a single round trip to the cache cluster. See Pipeline for more information.
+func (k *StringKeyspace[K]) Pipeline() *Pipeline[K, string] {
+	return newPipeline(k.basicKeyspace)
+}
+
 // Append appends to the string with the given key.
 //
 // If the key does not exist it is first created and set as the empty string,
@@ -232,6 +252,12 @@ func (k *IntKeyspace[K]) With(opts ...WriteOption) *IntKeyspace[K] {
 	return &IntKeyspace[K]{k.basicKeyspace.with(opts)}
 }
 
+// Pipeline returns a new pipeline for batching operations on the keyspace
+// into a single round trip to the cache cluster. See Pipeline for more information.
+func (k *IntKeyspace[K]) Pipeline() *Pipeline[K, int64] {
+	return newPipeline(k.basicKeyspace)
+}
+
 // Get gets the value stored at key.
 // If the key does not exist, it returns an error matching Miss.
 //
@@ -250,6 +276,20 @@ func (s *IntKeyspace[K]) MultiGet(ctx context.Context, keys ...K) ([]Result[int6
 	return s.basicKeyspace.MultiGet(ctx, keys...)
 }
 
+// GetOrLoad gets the value stored at key. If the key does not exist,
+// the value is loaded by calling load and stored before being returned.
+//
+// The load function reports that no value exists for the key by returning
+// an error matching Miss, in which case GetOrLoad reports an error matching Miss.
+// Other errors from load are returned as-is.
+//
+// Concurrent calls loading the same key share a single call to load.
+// See KeyspaceConfig.NegativeCache and KeyspaceConfig.StaleWhileRevalidate
+// for caching "not found" results and serving stale values while they're refreshed.
+func (s *IntKeyspace[K]) GetOrLoad(ctx context.Context, key K, load func(context.Context, K) (int64, error)) (int64, error) {
+	return s.basicKeyspace.GetOrLoad(ctx, key, load)
+}
+
 // Set updates the value stored at key to val.
 //
 // See https://redis.io/commands/set/ for more information.
@@ -383,6 +423,12 @@ func (k *FloatKeyspace[K]) With(opts ...WriteOption) *FloatKeyspace[K] {
 	return &FloatKeyspace[K]{k.basicKeyspace.with(opts)}
 }
 
+// Pipeline returns a new pipeline for batching operations on the keyspace
+// into a single round trip to the cache cluster. See Pipeline for more information.
+func (k *FloatKeyspace[K]) Pipeline() *Pipeline[K, float64] {
+	return newPipeline(k.basicKeyspace)
+}
+
 // Get gets the value stored at key.
 // If the key does not exist, it returns an error matching Miss.
 //
@@ -401,6 +447,20 @@ func (s *FloatKeyspace[K]) MultiGet(ctx context.Context, keys ...K) ([]Result[fl
 	return s.basicKeyspace.MultiGet(ctx, keys...)
 }
 
+// GetOrLoad gets the value stored at key. If the key does not exist,
+// the value is loaded by calling load and stored before being returned.
+//
+// The load function reports that no value exists for the key by returning
+// an error matching Miss, in which case GetOrLoad reports an error matching Miss.
+// Other errors from load are returned as-is.
+//
+// Concurrent calls loading the same key share a single call to load.
+// See KeyspaceConfig.NegativeCache and KeyspaceConfig.StaleWhileRevalidate
+// for caching "not found" results and serving stale values while they're refreshed.
+func (s *FloatKeyspace[K]) GetOrLoad(ctx context.Context, key K, load func(context.Context, K) (float64, error)) (float64, error) {
+	return s.basicKeyspace.GetOrLoad(ctx, key, load)
+}
+
 // Set updates the value stored at key to val.
 //
 // See https://redis.io/commands/set/ for more information.
@@ -520,7 +580,7 @@ func (s *basicKeyspace[K, V]) Get(ctx context.Context, key K) (val V, err error)
 		return val, err
 	}
 
-	res, err := s.redis.Get(ctx, k).Result()
+	res, err := s.getRaw(ctx, k)
 	if err == nil {
 		val, err = s.fromRedis(res)
 	}
@@ -540,7 +600,7 @@ func (s *basicKeyspace[K, V]) MultiGet(ctx context.Context, keys ...K) ([]Result
 	if len(ks) > 0 {
 		firstKey = ks[0]
 	}
-	res, err := s.redis.MGet(ctx, ks...).Result()
+	res, err := s.multiGetRaw(ctx, ks)
 	if err != nil {
 		return nil, toErr(err, op, firstKey)
 	}
@@ -648,7 +708,6 @@ func (s *basicKeyspace[K, V]) set(ctx context.Context, key K, val V, flag setFla
 
 	get := (flag & setGet) == setGet
 	nx := (flag & setNX) == setNX
-	xx := (flag & setXX) == setXX
 
 	if nx {
 		// If this is a setNX, convert Miss to KeyExists.
@@ -664,6 +723,27 @@ func (s *basicKeyspace[K, V]) set(ctx context.Context, key K, val V, flag setFla
 		return "", k, toErr(err, op, k)
 	}
 
+	args := s.setArgs(k, redisVal, flag)
+	if get {
+		cmd := redis.NewStringCmd(ctx, args...)
+		_ = s.redis.Process(ctx, cmd)
+		res, err := cmd.Result()
+		err = toErr(err, op, k)
+		return res, k, err
+	}
+
+	cmd := redis.NewStatusCmd(ctx, args...)
+	_ = s.redis.Process(ctx, cmd)
+	return "", k, toErr(cmd.Err(), op, k)
+}
+
+// setArgs returns the arguments of the Redis SET command
+// storing redisVal at key k, including the expiry.
+func (s *basicKeyspace[K, V]) setArgs(k string, redisVal any, flag setFlag) []any {
+	get := (flag & setGet) == setGet
+	nx := (flag & setNX) == setNX
+	xx := (flag & setXX) == setXX
+
 	args := make([]any, 3, 7)
 	args[0] = "set"
 	args[1] = k
@@ -700,17 +780,7 @@ func (s *basicKeyspace[K, V]) set(ctx context.Context, key K, val V, flag setFla
 		}
 	}
 
-	if get {
-		cmd := redis.NewStringCmd(ctx, args...)
-		_ = s.redis.Process(ctx, cmd)
-		res, err := cmd.Result()
-		err = toErr(err, op, k)
-		return res, k, err
-	}
-
-	cmd := redis.NewStatusCmd(ctx, args...)
-	_ = s.redis.Process(ctx, cmd)
-	return "", k, toErr(cmd.Err(), op, k)
+	return args
 }
 
 func usePreciseDur(dur time.Duration) bool {
@@ -20,6 +20,10 @@ type ClusterConfig struct {
 	//
 	// If not specified the cache defaults to AllKeysLRU.
 	EvictionPolicy EvictionPolicy
+
+	// Tags to apply to the provisioned cache cluster, such as {"cost-center": "billing"}.
+	// They must be constant and follow the same rules as pubsub.TopicConfig.Tags.
+	Tags map[string]string
 }
 
 // An EvictionPolicy describes how the cache evicts keys to make room for new data
@@ -70,9 +74,10 @@ type constStr string
 
 // Cluster represents a Redis cache cluster.
 type Cluster struct {
-	cfg ClusterConfig
-	mgr *Manager
-	cl  *redis.Client
+	name string
+	cfg  ClusterConfig
+	mgr  *Manager
+	cl   *redis.Client
 }
 
 // KeyspaceConfig specifies the configuration options for a cache keyspace.
@@ -96,6 +101,40 @@ type KeyspaceConfig struct {
 	// an ExpiryFunc or KeepTTL as a WriteOption to a specific operation.
 	DefaultExpiry ExpiryFunc
 
+	// LocalCache, if set, adds an in-process cache tier in front of the
+	// keyspace, serving repeated reads of hot keys from memory.
+	// See LocalCacheConfig for more information.
+	//
+	// It only applies to the Get and MultiGet operations
+	// of string, int, float and struct keyspaces.
+	LocalCache *LocalCacheConfig
+
+	// Compression, if set, transparently compresses values
+	// above a size threshold. See CompressionConfig for more information.
+	//
+	// It's only supported by struct keyspaces.
+	Compression *CompressionConfig
+
+	// ValueSizeLimit, if set, limits the size of the values
+	// stored in the keyspace. See ValueSizeLimitConfig for more information.
+	//
+	// It's only supported by string and struct keyspaces.
+	ValueSizeLimit *ValueSizeLimitConfig
+
+	// NegativeCache, if set, caches "not found" results of GetOrLoad
+	// for a short time. See NegativeCacheConfig for more information.
+	//
+	// It's only supported by string, int, float and struct keyspaces.
+	NegativeCache *NegativeCacheConfig
+
+	// StaleWhileRevalidate, if set, makes GetOrLoad serve expired values
+	// while refreshing them in the background.
+	// See StaleWhileRevalidateConfig for more information.
+	//
+	// It's only supported by string, int, float and struct keyspaces,
+	// and requires DefaultExpiry to be set.
+	StaleWhileRevalidate *StaleWhileRevalidateConfig
+
 	// EncoreInternal_DefLoc specifies where the keyspace is defined.
 	// It's an internal field set by Encore's compiler.
 	//publicapigen:drop
@@ -135,6 +174,22 @@ var Miss = errors.New("cache miss")
 // It must be checked against with errors.Is.
 var KeyExists = errors.New("key already exists")
 
+// Locked is the error reported when trying to acquire a lock
+// that is already held by someone else.
+// It must be checked against with errors.Is.
+var Locked = errors.New("lock already held")
+
+// LockLost is the error reported when renewing or releasing
+// a lease on a lock that is no longer held, typically because
+// the lease expired before it could be renewed.
+// It must be checked against with errors.Is.
+var LockLost = errors.New("lock lease lost")
+
+// ValueTooLarge is the error reported when writing a value
+// larger than the keyspace's value size limit.
+// It must be checked against with errors.Is.
+var ValueTooLarge = errors.New("value too large")
+
 // Result represents the result of a cache operation that may or may not have found a value.
 // If Err is nil, Value contains the cached value.
 // If Err matches Miss, the key was not found in the cache.
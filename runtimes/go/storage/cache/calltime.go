@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+// callTimer measures how much of the time of the traced cache operations
+// is spent waiting on the cache server, and how much encoding and decoding values.
+//
+// Cache operations run to completion on the goroutine that started them,
+// so the times are tracked per goroutine.
+type callTimer struct {
+	active atomic.Int32 // number of operations being timed
+	mu     sync.Mutex
+	calls  map[callKey]*callTimes
+}
+
+// callKey identifies a goroutine within a request.
+type callKey struct {
+	req   *model.Request
+	goctr uint32
+}
+
+// callTimes are the times spent by a single cache operation.
+// They're only modified by the goroutine running the operation.
+type callTimes struct {
+	io    time.Duration
+	codec time.Duration
+}
+
+// start starts timing the operation the goroutine described by curr is running.
+func (t *callTimer) start(curr reqtrack.Current) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.calls == nil {
+		t.calls = make(map[callKey]*callTimes)
+	}
+	t.calls[callKey{curr.Req, curr.Goctr}] = &callTimes{}
+	t.active.Add(1)
+}
+
+// stop stops timing the operation the goroutine described by curr is running,
+// and returns the times it spent.
+func (t *callTimer) stop(curr reqtrack.Current) callTimes {
+	key := callKey{curr.Req, curr.Goctr}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ct, ok := t.calls[key]
+	if !ok {
+		return callTimes{}
+	}
+	delete(t.calls, key)
+	t.active.Add(-1)
+	return *ct
+}
+
+// current returns the times of the operation being timed
+// on the calling goroutine, or nil if there is none.
+func (t *callTimer) current(rt *reqtrack.RequestTracker) *callTimes {
+	if t.active.Load() == 0 {
+		return nil
+	}
+	curr := rt.Current()
+	if curr.Req == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.calls[callKey{curr.Req, curr.Goctr}]
+}
+
+// codec returns fn wrapped to record the time spent encoding or decoding values.
+func codec[A, B any](rt *reqtrack.RequestTracker, t *callTimer, fn func(A) (B, error)) func(A) (B, error) {
+	return func(a A) (B, error) {
+		ct := t.current(rt)
+		if ct == nil {
+			return fn(a)
+		}
+		start := time.Now()
+		defer func() { ct.codec += time.Since(start) }()
+		return fn(a)
+	}
+}
+
+// ioTimingHook is a redis.Hook recording the time spent
+// waiting on the cache server by the operations being timed.
+type ioTimingHook struct {
+	rt *reqtrack.RequestTracker
+	t  *callTimer
+}
+
+type ioTimingKey struct{}
+
+type ioTiming struct {
+	ct    *callTimes
+	start time.Time
+}
+
+func (h *ioTimingHook) before(ctx context.Context) context.Context {
+	if ct := h.t.current(h.rt); ct != nil {
+		ctx = context.WithValue(ctx, ioTimingKey{}, ioTiming{ct, time.Now()})
+	}
+	return ctx
+}
+
+func (h *ioTimingHook) after(ctx context.Context) {
+	if it, ok := ctx.Value(ioTimingKey{}).(ioTiming); ok {
+		it.ct.io += time.Since(it.start)
+	}
+}
+
+func (h *ioTimingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return h.before(ctx), nil
+}
+
+func (h *ioTimingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	h.after(ctx)
+	return nil
+}
+
+func (h *ioTimingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return h.before(ctx), nil
+}
+
+func (h *ioTimingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	h.after(ctx)
+	return nil
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestCallTimer(t *testing.T) {
+	cluster, _ := newTestCluster(t)
+	mgr := cluster.mgr
+	cluster.cl.AddHook(&ioTimingHook{rt: mgr.rt, t: &mgr.calls})
+
+	const decodeTime = 20 * time.Millisecond
+	fromRedis := func(val string) (string, error) {
+		time.Sleep(decodeTime)
+		return val, nil
+	}
+	toRedis := func(val string) (any, error) { return val, nil }
+	ks := &basicKeyspace[string, string]{newClient[string, string](cluster, KeyspaceConfig{
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	}, fromRedis, toRedis)}
+	ctx := context.Background()
+	check(ks.Set(ctx, "one", "alpha"))
+
+	mgr.rt.BeginOperation()
+	defer mgr.rt.FinishOperation()
+	mgr.rt.BeginRequest(&model.Request{})
+	defer mgr.rt.FinishRequest(false)
+
+	curr := mgr.rt.Current()
+	mgr.calls.start(curr)
+	must(ks.Get(ctx, "one"))
+	times := mgr.calls.stop(curr)
+
+	if times.codec < decodeTime {
+		t.Errorf("codec time = %v, want at least %v", times.codec, decodeTime)
+	}
+	if times.io <= 0 || times.io >= times.codec {
+		t.Errorf("io time = %v, want between 0 and %v", times.io, times.codec)
+	}
+
+	// Operations are no longer timed once stopped.
+	must(ks.Get(ctx, "one"))
+	if got := mgr.calls.stop(curr); got != (callTimes{}) {
+		t.Errorf("stop() after stopping = %+v, want zero times", got)
+	}
+}
@@ -0,0 +1,237 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// NegativeCacheConfig configures caching of "not found" results in GetOrLoad.
+//
+// When configured and the load function reports that a key does not exist
+// (by returning an error matching Miss), the result is remembered for the TTL,
+// and GetOrLoad reports Miss for the key without calling the load function again.
+// This protects the source of truth from repeated lookups of keys that don't exist.
+//
+// Writing a value to the key makes it visible immediately, without waiting for the TTL.
+type NegativeCacheConfig struct {
+	// TTL is how long a "not found" result is cached.
+	// It should typically be short, since keys may be created
+	// in the source of truth at any time.
+	//
+	// If zero, it defaults to 10 seconds.
+	TTL time.Duration
+}
+
+// StaleWhileRevalidateConfig configures serving stale values in GetOrLoad
+// while they're refreshed in the background.
+//
+// When configured, values stored by GetOrLoad are kept in the cache for
+// MaxStale past the keyspace's expiry time. Reading such a stale value
+// returns it immediately and refreshes it in the background by calling the
+// load function, so callers don't have to wait for the source of truth
+// when a hot key expires.
+//
+// It requires the keyspace to have a DefaultExpiry, since values that
+// never expire never go stale.
+type StaleWhileRevalidateConfig struct {
+	// MaxStale is how long past its expiry time a value can be served
+	// while it's being refreshed. Values not read within that time
+	// are evicted, and loaded again on the next read.
+	MaxStale time.Duration
+}
+
+const defaultNegativeCacheTTL = 10 * time.Second
+
+// GetOrLoad implements GetOrLoad for the string, int, float and struct keyspaces.
+func (s *basicKeyspace[K, V]) GetOrLoad(ctx context.Context, key K, load func(context.Context, K) (V, error)) (val V, err error) {
+	const op = "get or load"
+	k, err := s.key(key, op)
+	if err != nil {
+		return val, err
+	}
+
+	res, state, err := s.lookup(ctx, k, op)
+	switch state {
+	case lookupHit, lookupStale:
+		val, err = s.fromRedis(res)
+		if err != nil {
+			return val, toErr(err, op, k)
+		}
+		if state == lookupStale {
+			s.refresh(ctx, key, k, load)
+		}
+		return val, nil
+	case lookupNotFound:
+		return val, err
+	case lookupMiss:
+		if errors.Is(err, Miss) {
+			return s.load(ctx, key, k, load)
+		}
+	}
+	return val, err
+}
+
+type lookupState int
+
+const (
+	lookupMiss     lookupState = iota // the key is not cached
+	lookupHit                         // the key holds a fresh value
+	lookupStale                       // the key holds a stale value
+	lookupNotFound                    // the key is cached as not found
+)
+
+// lookup reads the value stored at key k for GetOrLoad, together with
+// the markers recording whether it's stale or cached as not found,
+// in a single round trip to the cache cluster.
+func (s *basicKeyspace[K, V]) lookup(ctx context.Context, k, op string) (res string, state lookupState, err error) {
+	endTrace := s.doTrace(op, false, k)
+	defer func() { endTrace(err) }()
+
+	if s.local != nil {
+		if res, ok := s.local.get(k); ok {
+			return res, lookupHit, nil
+		}
+	}
+
+	var (
+		swr = s.cfg.StaleWhileRevalidate != nil
+		neg = s.cfg.NegativeCache != nil
+		ks  = []string{k}
+	)
+	if swr {
+		ks = append(ks, freshUntilKey(k))
+	}
+	if neg {
+		ks = append(ks, notFoundKey(k))
+	}
+
+	var gen uint64
+	if s.local != nil {
+		gen = s.local.generation()
+	}
+	vals, err := s.redis.MGet(ctx, ks...).Result()
+	if err != nil {
+		return "", lookupMiss, toErr(err, op, k)
+	}
+
+	if res, ok := vals[0].(string); ok {
+		if s.local != nil {
+			s.local.add(k, res, gen)
+		}
+		// Values written without GetOrLoad have no marker, and are always fresh.
+		if swr {
+			marker, _ := vals[1].(string)
+			freshUntil, err := strconv.ParseInt(marker, 10, 64)
+			if err == nil && time.Now().UnixMilli() >= freshUntil {
+				return res, lookupStale, nil
+			}
+		}
+		return res, lookupHit, nil
+	}
+
+	if neg && vals[len(vals)-1] != nil {
+		return "", lookupNotFound, toErr(Miss, op, k)
+	}
+	return "", lookupMiss, toErr(Miss, op, k)
+}
+
+// load loads the value for key with load and stores it in the cache.
+// Concurrent loads of the same key share the result of a single call to load.
+func (s *basicKeyspace[K, V]) load(ctx context.Context, key K, k string, load func(context.Context, K) (V, error)) (V, error) {
+	res, err, _ := s.loads.Do(k, func() (any, error) {
+		return s.loadAndStore(ctx, key, k, load)
+	})
+	val, _ := res.(V)
+	return val, err
+}
+
+// refresh reloads the stale value for key in the background,
+// unless it's already being loaded.
+func (s *basicKeyspace[K, V]) refresh(ctx context.Context, key K, k string, load func(context.Context, K) (V, error)) {
+	// The refresh outlives the operation that triggered it.
+	ctx = context.WithoutCancel(ctx)
+	s.loads.DoChan(k, func() (any, error) {
+		return s.loadAndStore(ctx, key, k, load)
+	})
+}
+
+func (s *basicKeyspace[K, V]) loadAndStore(ctx context.Context, key K, k string, load func(context.Context, K) (V, error)) (V, error) {
+	val, err := load(ctx, key)
+	if errors.Is(err, Miss) {
+		_ = s.storeNotFound(ctx, k)
+		return val, toErr(Miss, "get or load", k)
+	} else if err != nil {
+		return val, err
+	}
+
+	// The loaded value is returned even if it can't be cached;
+	// the error is recorded in the trace of the write.
+	_ = s.store(ctx, k, val)
+	return val, nil
+}
+
+// store stores a loaded value at key k. If the keyspace serves stale values,
+// the value is kept for MaxStale past its expiry time, and the time it
+// goes stale is recorded alongside it.
+func (s *basicKeyspace[K, V]) store(ctx context.Context, k string, val V) (err error) {
+	const op = "set"
+	endTrace := s.doTrace(op, true, k)
+	defer func() { endTrace(err) }()
+
+	redisVal, err := s.toRedis(val)
+	if err != nil {
+		return toErr(err, op, k)
+	}
+
+	swr := s.cfg.StaleWhileRevalidate
+	exp := s.expiry(time.Now())
+	if swr == nil || exp == neverExpire || exp == keepTTL {
+		cmd := redis.NewStatusCmd(ctx, s.setArgs(k, redisVal, 0)...)
+		_ = s.redis.Process(ctx, cmd)
+		return toErr(cmd.Err(), op, k)
+	}
+
+	stale := s.with([]WriteOption{expiryTime(exp.Add(swr.MaxStale))})
+	pipe := s.redis.TxPipeline()
+	pipe.Do(ctx, stale.setArgs(k, redisVal, 0)...)
+	pipe.Do(ctx, stale.setArgs(freshUntilKey(k), exp.UnixMilli(), 0)...)
+	_, err = pipe.Exec(ctx)
+	return toErr(err, op, k)
+}
+
+// storeNotFound records that no value exists for key k,
+// removing any stale value stored at it.
+func (s *basicKeyspace[K, V]) storeNotFound(ctx context.Context, k string) (err error) {
+	const op = "set not found"
+	endTrace := s.doTrace(op, true, k)
+	defer func() { endTrace(err) }()
+
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, k)
+	if s.cfg.StaleWhileRevalidate != nil {
+		pipe.Del(ctx, freshUntilKey(k))
+	}
+	if neg := s.cfg.NegativeCache; neg != nil {
+		pipe.Set(ctx, notFoundKey(k), "1", orDefault(neg.TTL, defaultNegativeCacheTTL))
+	}
+	_, err = pipe.Exec(ctx)
+	return toErr(err, op, k)
+}
+
+// freshUntilKey returns the key recording when the value stored at k goes stale,
+// as a Unix timestamp in milliseconds.
+//
+// Like the other marker keys it uses the reserved "__encore" prefix,
+// so it can't collide with the keyspace's own keys.
+func freshUntilKey(k string) string {
+	return "__encore/fresh/" + k
+}
+
+// notFoundKey returns the key recording that no value exists for k.
+func notFoundKey(k string) string {
+	return "__encore/notfound/" + k
+}
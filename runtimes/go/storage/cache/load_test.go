@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrLoad(t *testing.T) {
+	cluster, _ := newTestCluster(t)
+	ks := NewIntKeyspace[string](cluster, KeyspaceConfig{
+		KeyPattern:               "load/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context, key string) (int64, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	// Concurrent loads of the same key share a single call to load.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := must(ks.GetOrLoad(ctx, "one", load)); got != 42 {
+				t.Errorf("get or load: got %d, want 42", got)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("got %d calls to load, want 1", n)
+	}
+
+	// The loaded value is stored in the cache.
+	if got := must(ks.Get(ctx, "one")); got != 42 {
+		t.Errorf("get: got %d, want 42", got)
+	}
+	if got := must(ks.GetOrLoad(ctx, "one", load)); got != 42 || calls.Load() != 1 {
+		t.Errorf("get or load: got %d after %d calls, want 42 after 1 call", got, calls.Load())
+	}
+
+	// Errors are returned as-is and not cached.
+	errLoad := errors.New("load failed")
+	_, err := ks.GetOrLoad(ctx, "two", func(context.Context, string) (int64, error) { return 0, errLoad })
+	if !errors.Is(err, errLoad) {
+		t.Errorf("get or load: got err %v, want %v", err, errLoad)
+	}
+	if _, err := ks.Get(ctx, "two"); !errors.Is(err, Miss) {
+		t.Errorf("get: got err %v, want Miss", err)
+	}
+}
+
+func TestGetOrLoad_NegativeCache(t *testing.T) {
+	cluster, srv := newTestCluster(t)
+	ks := NewStringKeyspace[string](cluster, KeyspaceConfig{
+		KeyPattern:               "negative/:key",
+		NegativeCache:            &NegativeCacheConfig{TTL: 5 * time.Second},
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	var calls int
+	notFound := func(context.Context, string) (string, error) {
+		calls++
+		return "", Miss
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := ks.GetOrLoad(ctx, "one", notFound); !errors.Is(err, Miss) {
+			t.Fatalf("get or load: got err %v, want Miss", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls to load, want 1", calls)
+	}
+
+	// The result is loaded again once the TTL expires.
+	srv.FastForward(5 * time.Second)
+	if _, err := ks.GetOrLoad(ctx, "one", notFound); !errors.Is(err, Miss) {
+		t.Fatalf("get or load: got err %v, want Miss", err)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls to load, want 2", calls)
+	}
+
+	// Values written to the key are visible immediately.
+	check(ks.Set(ctx, "one", "alpha"))
+	if got := must(ks.GetOrLoad(ctx, "one", notFound)); got != "alpha" {
+		t.Errorf("get or load: got %q, want %q", got, "alpha")
+	}
+}
+
+func TestGetOrLoad_StaleWhileRevalidate(t *testing.T) {
+	cluster, srv := newTestCluster(t)
+	ks := NewStringKeyspace[string](cluster, KeyspaceConfig{
+		KeyPattern:               "stale/:key",
+		DefaultExpiry:            ExpireIn(time.Minute),
+		StaleWhileRevalidate:     &StaleWhileRevalidateConfig{MaxStale: time.Hour},
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	val := "alpha"
+	var mu sync.Mutex
+	load := func(context.Context, string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return val, nil
+	}
+
+	if got := must(ks.GetOrLoad(ctx, "one", load)); got != "alpha" {
+		t.Fatalf("get or load: got %q, want %q", got, "alpha")
+	}
+	// The value is kept for MaxStale past its expiry.
+	if ttl := srv.TTL("one"); ttl <= time.Hour || ttl > time.Hour+time.Minute {
+		t.Errorf("got ttl %v, want about %v", ttl, time.Hour+time.Minute)
+	}
+
+	// Mark the value as stale.
+	mu.Lock()
+	val = "beta"
+	mu.Unlock()
+	check(srv.Set(freshUntilKey("one"), "1"))
+
+	// The stale value is served while it's refreshed in the background.
+	if got := must(ks.GetOrLoad(ctx, "one", load)); got != "alpha" {
+		t.Errorf("get or load: got %q, want stale %q", got, "alpha")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if got, _ := srv.Get("one"); got == "beta" {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("stale value not refreshed: got %q, want %q", got, "beta")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := must(ks.GetOrLoad(ctx, "one", load)); got != "beta" {
+		t.Errorf("get or load: got %q, want %q", got, "beta")
+	}
+}
@@ -0,0 +1,362 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// LocalCacheConfig configures an in-process cache tier in front of a keyspace.
+//
+// When configured, values read from the cache cluster are kept in memory
+// and subsequent reads of the same key are served without a round trip to
+// the cache cluster. This is useful for hot keys that are read far more
+// often than they are written.
+//
+// Values are evicted from memory when written through the keyspace,
+// when Encore is notified by the cache cluster that they have been modified
+// elsewhere, or when the TTL expires.
+//
+// Writes through the keyspace are broadcast to the other instances of
+// the application using the cluster's pub/sub, so they evict the written
+// keys too. Modifications made outside of Encore are picked up using
+// Redis keyspace notifications, when the cluster permits enabling them.
+// The TTL bounds how stale a value can get if notifications are delayed or lost.
+type LocalCacheConfig struct {
+	// MaxEntries is the maximum number of values to keep in memory.
+	// When exceeded, the least recently used values are evicted.
+	//
+	// If zero, it defaults to 1000.
+	MaxEntries int
+
+	// MaxBytes is the maximum total size of the keys and values
+	// kept in memory. When exceeded, the least recently used values
+	// are evicted. Values larger than MaxBytes are never kept in memory.
+	//
+	// If zero, the size is only bounded by MaxEntries.
+	MaxBytes int
+
+	// TTL is the maximum duration a value is served from memory
+	// before it's fetched again from the cache cluster.
+	//
+	// If zero, it defaults to one minute.
+	TTL time.Duration
+}
+
+const (
+	defaultLocalMaxEntries = 1000
+	defaultLocalTTL        = time.Minute
+)
+
+// localCache is an in-process LRU cache of raw (Redis-encoded) values.
+//
+// Raw values are stored rather than decoded ones so that callers
+// never share memory with the cache, at the cost of decoding the value
+// on every read.
+type localCache struct {
+	maxEntries int
+	maxBytes   int
+	ttl        time.Duration
+	now        func() time.Time // for testing
+
+	mu      sync.Mutex
+	gen     uint64 // incremented on every invalidation
+	size    int    // total size of the keys and values in memory
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+type localEntry struct {
+	key     string
+	val     string
+	expires time.Time
+}
+
+func newLocalCache(cfg LocalCacheConfig) *localCache {
+	return &localCache{
+		maxEntries: orDefault(cfg.MaxEntries, defaultLocalMaxEntries),
+		maxBytes:   cfg.MaxBytes,
+		ttl:        orDefault(cfg.TTL, defaultLocalTTL),
+		now:        time.Now,
+		ll:         list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// get returns the value stored in memory for key, if any.
+func (c *localCache) get(key string) (val string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem := c.entries[key]
+	if elem == nil {
+		return "", false
+	}
+
+	e := elem.Value.(*localEntry)
+	if !c.now().Before(e.expires) {
+		c.remove(elem)
+		return "", false
+	}
+	c.ll.MoveToFront(elem)
+	return e.val, true
+}
+
+// generation reports the current invalidation generation.
+// It must be called before reading a value from the cache cluster
+// and passed to add when storing the value, so that values
+// invalidated while being read are not stored.
+func (c *localCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// add stores val for key, unless an invalidation has happened since gen.
+func (c *localCache) add(key, val string, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+
+	if elem := c.entries[key]; elem != nil {
+		c.remove(elem)
+	}
+	if c.maxBytes > 0 && len(key)+len(val) > c.maxBytes {
+		return
+	}
+
+	expires := c.now().Add(c.ttl)
+	c.entries[key] = c.ll.PushFront(&localEntry{key: key, val: val, expires: expires})
+	c.size += len(key) + len(val)
+	for c.ll.Len() > c.maxEntries || (c.maxBytes > 0 && c.size > c.maxBytes) {
+		c.remove(c.ll.Back())
+	}
+}
+
+// invalidate removes the given keys from memory.
+func (c *localCache) invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	for _, key := range keys {
+		if elem := c.entries[key]; elem != nil {
+			c.remove(elem)
+		}
+	}
+}
+
+// clear removes all keys from memory.
+func (c *localCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.size = 0
+	c.ll.Init()
+	clear(c.entries)
+}
+
+func (c *localCache) remove(elem *list.Element) {
+	e := elem.Value.(*localEntry)
+	c.ll.Remove(elem)
+	c.size -= len(e.key) + len(e.val)
+	delete(c.entries, e.key)
+}
+
+// getRaw gets the raw value stored at key k, consulting the
+// local cache tier first if the keyspace has one.
+func (s *client[K, V]) getRaw(ctx context.Context, k string) (string, error) {
+	if s.local == nil {
+		return s.redis.Get(ctx, k).Result()
+	}
+
+	if res, ok := s.local.get(k); ok {
+		return res, nil
+	}
+	gen := s.local.generation()
+	res, err := s.redis.Get(ctx, k).Result()
+	if err == nil {
+		s.local.add(k, res, gen)
+	}
+	return res, err
+}
+
+// multiGetRaw is like getRaw, but for multiple keys. Only the keys
+// not found in the local cache tier are fetched from the cache cluster.
+// Missing keys are reported as nil, like MGET does.
+func (s *client[K, V]) multiGetRaw(ctx context.Context, ks []string) ([]any, error) {
+	if s.local == nil {
+		return s.redis.MGet(ctx, ks...).Result()
+	}
+
+	res := make([]any, len(ks))
+	var (
+		remoteKeys []string
+		remoteIdx  []int
+	)
+	for i, k := range ks {
+		if val, ok := s.local.get(k); ok {
+			res[i] = val
+		} else {
+			remoteKeys = append(remoteKeys, k)
+			remoteIdx = append(remoteIdx, i)
+		}
+	}
+	if len(remoteKeys) == 0 {
+		return res, nil
+	}
+
+	gen := s.local.generation()
+	remote, err := s.redis.MGet(ctx, remoteKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range remote {
+		res[remoteIdx[i]] = r
+		if val, ok := r.(string); ok {
+			s.local.add(remoteKeys[i], val, gen)
+		}
+	}
+	return res, nil
+}
+
+// registerLocalCache registers a keyspace's local cache tier so that
+// it's invalidated by keyspace notifications from the given cluster.
+func (mgr *Manager) registerLocalCache(cluster string, cl *redis.Client, c *localCache) {
+	mgr.localMu.Lock()
+	defer mgr.localMu.Unlock()
+	if mgr.localCaches == nil {
+		mgr.localCaches = make(map[string][]*localCache)
+	}
+	first := len(mgr.localCaches[cluster]) == 0
+	mgr.localCaches[cluster] = append(mgr.localCaches[cluster], c)
+
+	// Only watch clusters this service is configured to use;
+	// the others don't serve any values to cache.
+	if first && mgr.isConfigured(cluster) && mgr.watchCtx != nil {
+		go mgr.watchInvalidations(mgr.watchCtx, cluster, cl)
+	}
+}
+
+func (mgr *Manager) isConfigured(cluster string) bool {
+	mgr.clientMu.RLock()
+	defer mgr.clientMu.RUnlock()
+	return mgr.clients[cluster] != nil
+}
+
+// invalidateLocal removes key from the local cache tiers of all
+// keyspaces in the given cluster. If key is empty, all keys are removed.
+func (mgr *Manager) invalidateLocal(cluster, key string) {
+	mgr.localMu.Lock()
+	caches := mgr.localCaches[cluster]
+	mgr.localMu.Unlock()
+	for _, c := range caches {
+		if key == "" {
+			c.clear()
+		} else {
+			c.invalidate(key)
+		}
+	}
+}
+
+// invalidationChannel is the pub/sub channel writes to keyspaces with
+// a local cache tier are broadcast on. Messages are JSON-encoded lists
+// of the written keys.
+const invalidationChannel = "__encore/cache/invalidate"
+
+// broadcastInvalidation tells the other instances of the application
+// that the given keys have been written, so they evict them from their
+// local cache tiers. It's best effort; the local cache TTL bounds
+// staleness if the broadcast is lost.
+func (c *client[K, V]) broadcastInvalidation(keys []string) {
+	payload, err := json.Marshal(keys)
+	if err != nil {
+		return
+	}
+	_ = c.redis.Publish(context.Background(), invalidationChannel, payload).Err()
+}
+
+// watchInvalidations subscribes to invalidation broadcasts and keyspace
+// notifications from the given cluster and invalidates the local cache
+// tiers of modified keys. It runs until ctx is canceled.
+func (mgr *Manager) watchInvalidations(ctx context.Context, cluster string, cl *redis.Client) {
+	enableKeyspaceNotifications(ctx, cl)
+
+	pattern := fmt.Sprintf("__keyevent@%d__:*", cl.Options().DB)
+	ps := cl.PSubscribe(ctx, pattern)
+	defer func() { _ = ps.Close() }()
+	// The channel is resubscribed on reconnect even if this fails.
+	_ = ps.Subscribe(ctx, invalidationChannel)
+
+	const (
+		minBackoff = 100 * time.Millisecond
+		maxBackoff = 30 * time.Second
+	)
+	backoff := minBackoff
+	for {
+		msg, err := ps.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			// Notifications may be lost while disconnected, so drop
+			// everything rather than risk serving stale values.
+			mgr.invalidateLocal(cluster, "")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, maxBackoff)
+			continue
+		}
+
+		backoff = minBackoff
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			// We've (re-)subscribed; anything modified before
+			// this point may have gone unnoticed.
+			mgr.invalidateLocal(cluster, "")
+		case *redis.Message:
+			if msg.Channel == invalidationChannel {
+				var keys []string
+				if err := json.Unmarshal([]byte(msg.Payload), &keys); err != nil {
+					continue
+				}
+				for _, key := range keys {
+					if key != "" {
+						mgr.invalidateLocal(cluster, key)
+					}
+				}
+			} else if msg.Payload != "" {
+				mgr.invalidateLocal(cluster, msg.Payload)
+			}
+		}
+	}
+}
+
+// enableKeyspaceNotifications enables keyevent notifications for all
+// modifications, keeping any notification classes already enabled.
+//
+// Errors are ignored since managed Redis offerings frequently disallow
+// the CONFIG command, in which case notifications must be enabled when
+// provisioning the cluster and the local cache TTL bounds staleness.
+func enableKeyspaceNotifications(ctx context.Context, cl *redis.Client) {
+	const param = "notify-keyspace-events"
+	res, err := cl.ConfigGet(ctx, param).Result()
+	if err != nil || len(res) != 2 {
+		return
+	}
+	curr, _ := res[1].(string)
+	if strings.Contains(curr, "E") && strings.Contains(curr, "A") {
+		return
+	}
+	_ = cl.ConfigSet(ctx, param, curr+"EA").Err()
+}
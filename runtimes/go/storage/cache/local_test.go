@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestLocalCache_LRU(t *testing.T) {
+	c := newLocalCache(LocalCacheConfig{MaxEntries: 2})
+	c.add("one", "1", c.generation())
+	c.add("two", "2", c.generation())
+
+	// Touch "one" so that "two" is the least recently used.
+	if _, ok := c.get("one"); !ok {
+		t.Fatalf("get one: not found")
+	}
+	c.add("three", "3", c.generation())
+
+	if _, ok := c.get("two"); ok {
+		t.Errorf("get two: want evicted")
+	}
+	for _, key := range []string{"one", "three"} {
+		if _, ok := c.get(key); !ok {
+			t.Errorf("get %s: not found", key)
+		}
+	}
+}
+
+func TestLocalCache_MaxBytes(t *testing.T) {
+	c := newLocalCache(LocalCacheConfig{MaxBytes: 10})
+	c.add("a", "1234", c.generation()) // 5 bytes
+	c.add("b", "1234", c.generation()) // 10 bytes
+	c.add("c", "12", c.generation())   // 13 bytes; evicts "a"
+
+	if _, ok := c.get("a"); ok {
+		t.Errorf("get a: want evicted")
+	}
+	for _, key := range []string{"b", "c"} {
+		if _, ok := c.get(key); !ok {
+			t.Errorf("get %s: not found", key)
+		}
+	}
+
+	// Values larger than MaxBytes are not kept.
+	c.add("d", "1234567890", c.generation())
+	if _, ok := c.get("d"); ok {
+		t.Errorf("get d: want too large to keep")
+	}
+	if c.size != 8 {
+		t.Errorf("got size %d, want 8", c.size)
+	}
+}
+
+func TestLocalCache_TTL(t *testing.T) {
+	now := time.Now()
+	c := newLocalCache(LocalCacheConfig{TTL: time.Second})
+	c.now = func() time.Time { return now }
+
+	c.add("one", "1", c.generation())
+	if val, ok := c.get("one"); !ok || val != "1" {
+		t.Fatalf("get one: got %q, %v, want %q, true", val, ok, "1")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := c.get("one"); ok {
+		t.Errorf("get one: want expired")
+	}
+}
+
+func TestLocalCache_Generation(t *testing.T) {
+	c := newLocalCache(LocalCacheConfig{})
+	gen := c.generation()
+	c.invalidate("one")
+
+	// The value was invalidated while being read, so it must not be stored.
+	c.add("one", "stale", gen)
+	if _, ok := c.get("one"); ok {
+		t.Errorf("get one: want stale value to be dropped")
+	}
+}
+
+func TestLocalCache_Keyspace(t *testing.T) {
+	cluster, srv := newTestCluster(t)
+	ks := NewStringKeyspace[string](cluster, KeyspaceConfig{
+		KeyPattern:               "local/:key",
+		LocalCache:               &LocalCacheConfig{MaxEntries: 10, TTL: time.Minute},
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	check(ks.Set(ctx, "one", "alpha"))
+	if got := must(ks.Get(ctx, "one")); got != "alpha" {
+		t.Fatalf("get: got %q, want %q", got, "alpha")
+	}
+
+	// Modifying the key behind the keyspace's back is not noticed
+	// until the key is invalidated.
+	check(srv.Set("one", "beta"))
+	if got := must(ks.Get(ctx, "one")); got != "alpha" {
+		t.Errorf("get: got %q, want cached %q", got, "alpha")
+	}
+	cluster.mgr.invalidateLocal(cluster.name, "one")
+	if got := must(ks.Get(ctx, "one")); got != "beta" {
+		t.Errorf("get after invalidation: got %q, want %q", got, "beta")
+	}
+
+	// Writes through the keyspace invalidate the key.
+	check(ks.Set(ctx, "one", "charlie"))
+	if got := must(ks.Get(ctx, "one")); got != "charlie" {
+		t.Errorf("get after set: got %q, want %q", got, "charlie")
+	}
+
+	// MultiGet serves cached keys from memory and fetches the rest.
+	check(srv.Set("one", "delta"))
+	check(srv.Set("two", "echo"))
+	res := must(ks.MultiGet(ctx, "one", "two", "three"))
+	if len(res) != 3 {
+		t.Fatalf("multi get: got %d results, want 3", len(res))
+	}
+	if res[0].Value != "charlie" || res[1].Value != "echo" {
+		t.Errorf("multi get: got %q, %q, want %q, %q", res[0].Value, res[1].Value, "charlie", "echo")
+	}
+	if !errors.Is(res[2].Err, Miss) {
+		t.Errorf("multi get: got err %v, want Miss", res[2].Err)
+	}
+
+	must(ks.Delete(ctx, "one"))
+	if _, err := ks.Get(ctx, "one"); !errors.Is(err, Miss) {
+		t.Errorf("get after delete: got err %v, want Miss", err)
+	}
+}
+
+func TestLocalCache_Broadcast(t *testing.T) {
+	// Simulate two instances of the application using the same cluster.
+	writer, srv := newTestCluster(t)
+	reader := &Cluster{
+		mgr: &Manager{static: writer.mgr.static, rt: writer.mgr.rt},
+		cl:  redis.NewClient(&redis.Options{Addr: srv.Addr()}),
+	}
+	cfg := KeyspaceConfig{
+		KeyPattern:               "broadcast/:key",
+		LocalCache:               &LocalCacheConfig{TTL: time.Hour},
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	}
+	writerKs := NewStringKeyspace[string](writer, cfg)
+	readerKs := NewStringKeyspace[string](reader, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reader.mgr.watchInvalidations(ctx, reader.name, reader.cl)
+	for srv.PubSubNumSub(invalidationChannel)[invalidationChannel] == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	check(writerKs.Set(ctx, "one", "alpha"))
+	if got := must(readerKs.Get(ctx, "one")); got != "alpha" {
+		t.Fatalf("get: got %q, want %q", got, "alpha")
+	}
+
+	// The write is broadcast to the reader, which evicts the key.
+	check(writerKs.Set(ctx, "one", "beta"))
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if got := must(readerKs.Get(ctx, "one")); got == "beta" {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("get: got %q, want %q after broadcast", got, "beta")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,235 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	mathrand "math/rand" // nosemgrep
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// NewLock creates a keyspace of distributed locks in the given cluster.
+//
+// The type parameter K specifies the key type, which can either be a
+// named struct type or a basic type (string, int, etc).
+// Each key identifies a separate lock.
+func NewLock[K any](cluster *Cluster, cfg KeyspaceConfig) *Lock[K] {
+	fromRedis := func(val string) (string, error) { return val, nil }
+	toRedis := func(val string) (any, error) { return val, nil }
+
+	return &Lock[K]{
+		client: newClient[K, string](cluster, cfg, fromRedis, toRedis),
+	}
+}
+
+// Lock represents a set of distributed locks, one per key.
+//
+// A lock is held by acquiring a lease on it. Leases expire after their TTL
+// and are renewed automatically in the background until released,
+// so that locks held by processes that crash are eventually released.
+//
+// The lock follows the single-instance Redlock algorithm: acquiring a lock
+// stores a random token at the key, and the lease is only renewed or released
+// while the key still holds that token.
+type Lock[K any] struct {
+	client *client[K, string]
+}
+
+// TryAcquire attempts to acquire the lock for key once, with a lease
+// that expires after ttl unless renewed.
+//
+// If the lock is already held it reports an error matching Locked.
+//
+// The returned lease must be released with Release when no longer needed.
+func (l *Lock[K]) TryAcquire(ctx context.Context, key K, ttl time.Duration) (*Lease, error) {
+	const op = "lock acquire"
+	k, err := l.client.key(key, op)
+	if err != nil {
+		return nil, err
+	}
+	return l.tryAcquire(ctx, k, ttl)
+}
+
+// Acquire acquires the lock for key, with a lease that expires after
+// ttl unless renewed. If the lock is already held it waits until
+// it's released or ctx is canceled, whichever happens first.
+//
+// The returned lease must be released with Release when no longer needed.
+func (l *Lock[K]) Acquire(ctx context.Context, key K, ttl time.Duration) (*Lease, error) {
+	const op = "lock acquire"
+	k, err := l.client.key(key, op)
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		minBackoff = 10 * time.Millisecond
+		maxBackoff = time.Second
+	)
+	backoff := minBackoff
+	for {
+		lease, err := l.tryAcquire(ctx, k, ttl)
+		if !errors.Is(err, Locked) {
+			return lease, err
+		}
+
+		// Add jitter so that waiting callers don't retry in lockstep.
+		wait := backoff/2 + time.Duration(mathrand.Int63n(int64(backoff/2)+1))
+		select {
+		case <-ctx.Done():
+			return nil, toErr(ctx.Err(), op, k)
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func (l *Lock[K]) tryAcquire(ctx context.Context, k string, ttl time.Duration) (lease *Lease, err error) {
+	const op = "lock acquire"
+	endTrace := l.client.doTrace(op, true, k)
+	defer func() { endTrace(err) }()
+
+	if ttl < time.Millisecond {
+		return nil, toErr(errors.New("lease ttl must be at least 1ms"), op, k)
+	}
+
+	token, err := newLockToken()
+	if err != nil {
+		return nil, toErr(err, op, k)
+	}
+
+	ok, err := l.client.redis.SetNX(ctx, k, token, ttl).Result()
+	if err != nil {
+		return nil, toErr(err, op, k)
+	} else if !ok {
+		return nil, toErr(Locked, op, k)
+	}
+
+	lease = &Lease{
+		key:   k,
+		token: token,
+		ttl:   ttl,
+		redis: l.client.redis,
+		trace: l.client.doTrace,
+		stop:  make(chan struct{}),
+		lost:  make(chan struct{}),
+	}
+	go lease.keepAlive()
+	return lease, nil
+}
+
+// Lease represents a held lock, as returned by Lock.Acquire and Lock.TryAcquire.
+type Lease struct {
+	key   string
+	token string
+	ttl   time.Duration
+	redis *redis.Client
+	trace func(op string, write bool, keys ...string) func(error)
+
+	stopOnce sync.Once
+	stop     chan struct{} // closed when the lease is released
+
+	lostOnce sync.Once
+	lost     chan struct{} // closed when the lease is lost
+}
+
+// Lost returns a channel that is closed if the lease is lost,
+// meaning the lock may since have been acquired by someone else.
+//
+// This happens if the lease could not be renewed before it expired,
+// for example due to the cache cluster being unreachable.
+func (l *Lease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Renew extends the lease to expire after its TTL, starting now.
+// Leases are renewed automatically in the background, so calling Renew
+// is only necessary to confirm the lock is still held.
+//
+// If the lease is no longer held it reports an error matching LockLost.
+func (l *Lease) Renew(ctx context.Context) (err error) {
+	const op = "lock renew"
+	endTrace := l.trace(op, true, l.key)
+	defer func() { endTrace(err) }()
+	return toErr(l.renew(ctx), op, l.key)
+}
+
+// Release releases the lock and stops renewing the lease.
+//
+// If the lease was no longer held it reports an error matching LockLost.
+func (l *Lease) Release(ctx context.Context) (err error) {
+	const op = "lock release"
+	endTrace := l.trace(op, true, l.key)
+	defer func() { endTrace(err) }()
+
+	l.stopOnce.Do(func() { close(l.stop) })
+	released, err := releaseLockScript.Run(ctx, l.redis, []string{l.key}, l.token).Int64()
+	if err == nil && released == 0 {
+		err = LockLost
+	}
+	return toErr(err, op, l.key)
+}
+
+func (l *Lease) renew(ctx context.Context) error {
+	renewed, err := renewLockScript.Run(ctx, l.redis, []string{l.key}, l.token, l.ttl.Milliseconds()).Int64()
+	if err == nil && renewed == 0 {
+		err = LockLost
+	}
+	return err
+}
+
+// keepAlive renews the lease periodically until it's released or lost.
+func (l *Lease) keepAlive() {
+	interval := l.ttl / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastRenewed := time.Now()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		err := l.renew(ctx)
+		cancel()
+
+		if err == nil {
+			lastRenewed = start
+		} else if errors.Is(err, LockLost) || time.Since(lastRenewed) >= l.ttl {
+			l.lostOnce.Do(func() { close(l.lost) })
+			return
+		}
+	}
+}
+
+func newLockToken() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+var (
+	releaseLockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+	renewLockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0
+`)
+)
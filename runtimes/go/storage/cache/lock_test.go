@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLock(t *testing.T) {
+	cluster, srv := newTestCluster(t)
+	lock := NewLock[string](cluster, KeyspaceConfig{
+		KeyPattern:               "lock/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	lease := must(lock.TryAcquire(ctx, "one", time.Minute))
+	if got := srv.TTL("one"); got != time.Minute {
+		t.Errorf("ttl: got %v, want %v", got, time.Minute)
+	}
+
+	if _, err := lock.TryAcquire(ctx, "one", time.Minute); !errors.Is(err, Locked) {
+		t.Errorf("try acquire held lock: got err %v, want Locked", err)
+	}
+
+	// Other keys are independent.
+	check(must(lock.TryAcquire(ctx, "two", time.Minute)).Release(ctx))
+
+	// Acquire waits until the lock is released.
+	acquired := make(chan *Lease, 1)
+	go func() {
+		acquired <- must(lock.Acquire(ctx, "one", time.Minute))
+	}()
+	select {
+	case <-acquired:
+		t.Fatalf("acquire: got lease while lock was held")
+	case <-time.After(50 * time.Millisecond):
+	}
+	check(lease.Release(ctx))
+	select {
+	case lease2 := <-acquired:
+		check(lease2.Release(ctx))
+	case <-time.After(5 * time.Second):
+		t.Fatalf("acquire: timed out waiting for lease")
+	}
+
+	// Releasing again reports the lease as lost.
+	if err := lease.Release(ctx); !errors.Is(err, LockLost) {
+		t.Errorf("release released lease: got err %v, want LockLost", err)
+	}
+
+	// Acquire gives up when the context is canceled.
+	lease = must(lock.TryAcquire(ctx, "one", time.Minute))
+	ctx2, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := lock.Acquire(ctx2, "one", time.Minute); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("acquire with canceled context: got err %v, want DeadlineExceeded", err)
+	}
+	check(lease.Release(ctx))
+}
+
+func TestLock_Renewal(t *testing.T) {
+	cluster, srv := newTestCluster(t)
+	lock := NewLock[string](cluster, KeyspaceConfig{
+		KeyPattern:               "lock/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	const ttl = 30 * time.Millisecond
+	lease := must(lock.TryAcquire(ctx, "one", ttl))
+	check(lease.Renew(ctx))
+
+	// Let the lease expire without being renewed in time.
+	srv.FastForward(ttl)
+	select {
+	case <-lease.Lost():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("lease not lost after expiring")
+	}
+	if err := lease.Renew(ctx); !errors.Is(err, LockLost) {
+		t.Errorf("renew lost lease: got err %v, want LockLost", err)
+	}
+
+	// The lock can now be acquired by someone else.
+	check(must(lock.TryAcquire(ctx, "one", ttl)).Release(ctx))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/alicebob/miniredis/v2"
 	"github.com/go-redis/redis/v8"
 	jsoniter "github.com/json-iterator/go"
+	"golang.org/x/sync/singleflight"
 
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/model"
@@ -39,16 +40,31 @@ type Manager struct {
 
 	clientMu sync.RWMutex
 	clients  map[string]*redis.Client
+
+	reads     readTracker
+	oversized keyspaceCounter
+	calls     callTimer
+
+	localMu     sync.Mutex
+	localCaches map[string][]*localCache // cluster name -> local cache tiers
+
+	// watchCtx is canceled on shutdown to stop watching
+	// for keyspace notifications.
+	watchCtx  context.Context
+	stopWatch context.CancelFunc
 }
 
 func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager, json jsoniter.API) *Manager {
+	watchCtx, stopWatch := context.WithCancel(context.Background())
 	return &Manager{
-		static:  static,
-		runtime: runtime,
-		rt:      rt,
-		ts:      ts,
-		json:    json,
-		clients: make(map[string]*redis.Client),
+		static:    static,
+		runtime:   runtime,
+		rt:        rt,
+		ts:        ts,
+		json:      json,
+		clients:   make(map[string]*redis.Client),
+		watchCtx:  watchCtx,
+		stopWatch: stopWatch,
 	}
 }
 
@@ -75,8 +91,13 @@ func (mgr *Manager) getClient(clusterName string) *redis.Client {
 		if err != nil {
 			panic(fmt.Sprintf("cache: unable to start redis mock: %v", err))
 		}
-		mgr.clients[clusterName] = cl
-		return cl
+		return mgr.addClient(clusterName, cl)
+	}
+
+	for _, mc := range mgr.runtime.Memcached {
+		if mc.EncoreName == clusterName {
+			return mgr.addClient(clusterName, newMemcachedClient(mc))
+		}
 	}
 
 	for _, rdb := range mgr.runtime.RedisDatabases {
@@ -85,14 +106,64 @@ func (mgr *Manager) getClient(clusterName string) *redis.Client {
 			if err != nil {
 				panic(fmt.Sprintf("cache: unable to create redis client: %v", err))
 			}
-			mgr.clients[clusterName] = cl
-			return cl
+			return mgr.addClient(clusterName, cl)
 		}
 	}
 
 	return newNoopClient()
 }
 
+// EndpointRateLimiter returns the rate limiter the API server uses to
+// enforce endpoint rate limits, storing its state in the given cluster.
+// Its keys use the reserved "__encore" prefix, so they can't collide
+// with the keys of the application's keyspaces.
+func (mgr *Manager) EndpointRateLimiter(cluster string) *RateLimiter[string] {
+	const prefix = "__encore/ratelimit/"
+	rl := NewRateLimiter[string](&Cluster{name: cluster, mgr: mgr, cl: mgr.getClient(cluster)}, KeyspaceConfig{
+		KeyPattern:               prefix + ":key",
+		EncoreInternal_KeyMapper: func(key string) string { return prefix + key },
+	})
+	rl.client.reservedKeys = true
+	return rl
+}
+
+// EndpointResponses returns the keyspace the API server uses to store
+// the responses of idempotent endpoints in the given cluster, keyed by
+// idempotency key. Like EndpointRateLimiter, its keys use the reserved
+// "__encore" prefix.
+func (mgr *Manager) EndpointResponses(cluster string, ttl time.Duration) *StringKeyspace[string] {
+	const prefix = "__encore/idempotency/"
+	ks := NewStringKeyspace[string](&Cluster{name: cluster, mgr: mgr, cl: mgr.getClient(cluster)}, KeyspaceConfig{
+		KeyPattern:               prefix + ":key",
+		DefaultExpiry:            ExpireIn(ttl),
+		EncoreInternal_KeyMapper: func(key string) string { return prefix + key },
+	})
+	ks.reservedKeys = true
+	return ks
+}
+
+// EndpointResponseCache returns the keyspace the API server uses to cache
+// the responses of endpoints in the given cluster. Its keys have no default
+// expiry, as each endpoint caches its responses for its own duration.
+// Like EndpointRateLimiter, its keys use the reserved "__encore" prefix.
+func (mgr *Manager) EndpointResponseCache(cluster string) *StringKeyspace[string] {
+	const prefix = "__encore/respcache/"
+	ks := NewStringKeyspace[string](&Cluster{name: cluster, mgr: mgr, cl: mgr.getClient(cluster)}, KeyspaceConfig{
+		KeyPattern:               prefix + ":key",
+		EncoreInternal_KeyMapper: func(key string) string { return prefix + key },
+	})
+	ks.reservedKeys = true
+	return ks
+}
+
+// addClient registers cl as the client for the given cluster.
+// mgr.clientMu must be held.
+func (mgr *Manager) addClient(clusterName string, cl *redis.Client) *redis.Client {
+	cl.AddHook(&ioTimingHook{rt: mgr.rt, t: &mgr.calls})
+	mgr.clients[clusterName] = cl
+	return cl
+}
+
 func (mgr *Manager) runningInEncoreCloud() bool {
 	if mgr.runtime != nil && mgr.runtime.EnvCloud == "encore" {
 		return true
@@ -173,6 +244,10 @@ func (mgr *Manager) Shutdown(p *shutdown.Process) error {
 	<-p.ServicesShutdownCompleted.Done()
 	<-p.OutstandingTasks.Done()
 
+	if mgr.stopWatch != nil {
+		mgr.stopWatch()
+	}
+
 	mgr.clientMu.Lock()
 	mgr.clientMu.Unlock()
 	for _, c := range mgr.clients {
@@ -206,25 +281,48 @@ func newClient[K, V any](cluster *Cluster, cfg KeyspaceConfig,
 		}
 	}
 
+	// The local cache tier relies on Redis pub/sub for invalidation,
+	// which Memcached lacks, so it's disabled for Memcached.
+	var local *localCache
+	if cfg.LocalCache != nil && !cluster.mgr.isMemcached(cluster.name) {
+		local = newLocalCache(*cfg.LocalCache)
+		cluster.mgr.registerLocalCache(cluster.name, cluster.cl, local)
+	}
+
+	rt, calls := cluster.mgr.rt, &cluster.mgr.calls
 	return &client[K, V]{
-		rt:        cluster.mgr.rt,
+		rt:        rt,
+		reads:     &cluster.mgr.reads,
+		calls:     calls,
+		cluster:   cluster.name,
 		redis:     cluster.cl,
 		cfg:       cfg,
 		expiry:    defaultExpiry,
 		keyMapper: keyMapper,
-		toRedis:   toRedis,
-		fromRedis: fromRedis,
+		toRedis:   codec(rt, calls, toRedis),
+		fromRedis: codec(rt, calls, fromRedis),
+		local:     local,
+		loads:     &singleflight.Group{},
 	}
 }
 
 type client[K, V any] struct {
 	rt        *reqtrack.RequestTracker
+	reads     *readTracker
+	calls     *callTimer
+	cluster   string
 	redis     *redis.Client
 	cfg       KeyspaceConfig
 	expiry    ExpiryFunc
 	keyMapper func(K) string
 	toRedis   func(V) (any, error)
 	fromRedis func(string) (V, error)
+	local     *localCache         // nil if the keyspace has no local cache tier
+	loads     *singleflight.Group // deduplicates GetOrLoad loads, shared with derived clients
+
+	// reservedKeys reports whether the keyspace belongs to the runtime,
+	// and may use the reserved "__encore" key prefix.
+	reservedKeys bool
 }
 
 func (c *client[K, V]) with(opts []WriteOption) *client[K, V] {
@@ -242,7 +340,7 @@ func (c *client[K, V]) with(opts []WriteOption) *client[K, V] {
 
 func (s *client[K, V]) key(k K, op string) (string, error) {
 	res := s.keyMapper(k)
-	if strings.HasPrefix(res, "__encore") {
+	if strings.HasPrefix(res, "__encore") && !s.reservedKeys {
 		return "", &OpError{
 			Operation: op,
 			RawKey:    res,
@@ -325,7 +423,34 @@ func (s *client[K, V]) expiryDur() time.Duration {
 func (c *client[K, V]) doTrace(op string, write bool, keys ...string) func(error) {
 	eventID := c.traceStart(op, write, keys...)
 	return func(err error) {
-		c.traceEnd(eventID, err)
+		c.traceEnd(eventID, err, nil)
+		if write {
+			c.invalidateLocal(keys)
+		} else {
+			c.reads.record(c.cluster, string(c.cfg.KeyPattern), err)
+		}
+	}
+}
+
+// doPipelineTrace is like doTrace, but for executing a pipeline of operations.
+// The returned function additionally records the result of each operation.
+func (c *client[K, V]) doPipelineTrace(op string, write bool, keys ...string) func(error, []trace2.CacheOpResult) {
+	eventID := c.traceStart(op, write, keys...)
+	return func(err error, opResults []trace2.CacheOpResult) {
+		c.traceEnd(eventID, err, opResults)
+		if write {
+			c.invalidateLocal(keys)
+		}
+	}
+}
+
+// invalidateLocal removes keys from the keyspace's local cache tier, if any,
+// and broadcasts the invalidation to the other instances of the application.
+// It's called after every write operation completes, successful or not.
+func (c *client[K, V]) invalidateLocal(keys []string) {
+	if c.local != nil {
+		c.local.invalidate(keys...)
+		c.broadcastInvalidation(keys)
 	}
 }
 
@@ -343,44 +468,53 @@ func (c *client[K, V]) traceStart(op string, write bool, keys ...string) (eventI
 			Keys:      keys,
 			Stack:     stack.Build(3),
 		})
+		c.calls.start(curr)
 	}
 
 	return eventID
 }
 
-func (c *client[K, V]) traceEnd(startEventID model.TraceEventID, err error, values ...any) {
+func (c *client[K, V]) traceEnd(startEventID model.TraceEventID, err error, opResults []trace2.CacheOpResult) {
 	if startEventID == 0 { // indicates the operation start was not traced
 		return
 	}
 
-	if curr := c.rt.Current(); curr.Trace != nil && curr.Req != nil {
-		var cacheErr error
-		var res trace2.CacheCallResult
-		switch {
-		case err == nil:
-			res = trace2.CacheOK
-		case errors.Is(err, Miss):
-			res = trace2.CacheNoSuchKey
-		case errors.Is(err, KeyExists):
-			res = trace2.CacheConflict
-		case err != nil:
-			res = trace2.CacheErr
-			cacheErr = err
-		}
-
+	curr := c.rt.Current()
+	times := c.calls.stop(curr)
+	if curr.Trace != nil && curr.Req != nil {
+		res, cacheErr := cacheCallResult(err)
 		curr.Trace.CacheCallEnd(trace2.CacheCallEndParams{
 			EventParams: trace2.EventParams{
 				TraceID: curr.Req.TraceID,
 				SpanID:  curr.Req.SpanID,
 				Goid:    curr.Goctr,
 			},
-			StartID: startEventID,
-			Res:     res,
-			Err:     cacheErr,
+			StartID:       startEventID,
+			Res:           res,
+			Err:           cacheErr,
+			OpResults:     opResults,
+			IODuration:    times.io,
+			CodecDuration: times.codec,
 		})
 	}
 }
 
+// cacheCallResult returns the trace result of an operation completing with err,
+// and the error to record. Misses and conflicts (including held locks)
+// are not recorded as errors.
+func cacheCallResult(err error) (trace2.CacheCallResult, error) {
+	switch {
+	case err == nil:
+		return trace2.CacheOK, nil
+	case errors.Is(err, Miss):
+		return trace2.CacheNoSuchKey, nil
+	case errors.Is(err, KeyExists), errors.Is(err, Locked):
+		return trace2.CacheConflict, nil
+	default:
+		return trace2.CacheErr, err
+	}
+}
+
 type errWrapper struct {
 	err error
 }
@@ -0,0 +1,632 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/go-redis/redis/v8"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+// newMemcachedClient returns a Redis client for a cache cluster backed by Memcached.
+//
+// The keyspaces are built on Redis commands, so rather than abstracting over
+// both backends the client dials in-process connections that translate the
+// commands used by the basic keyspaces (string, int, float and struct keyspaces)
+// into Memcached operations. Other commands fail with an error.
+//
+// Memcached has no transactions, so commands within MULTI/EXEC are executed
+// one at a time, and read-modify-write commands like INCRBY are implemented
+// using compare-and-swap. Expiry times have a resolution of one second.
+func newMemcachedClient(cfg *config.MemcachedCluster) *redis.Client {
+	mc := memcache.New(cfg.Servers...)
+	mc.MaxIdleConns = orDefault(cfg.MaxIdleConnections, runtime.GOMAXPROCS(0)*10)
+	mc.Timeout = orDefault(cfg.Timeout, 500*time.Millisecond)
+	b := &memcachedBackend{mc: mc, prefix: cfg.KeyPrefix}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     "memcached",
+		PoolSize: runtime.GOMAXPROCS(0) * 10,
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return &memcachedConn{b: b}, nil
+		},
+	})
+}
+
+// isMemcached reports whether the cluster is backed by Memcached.
+func (mgr *Manager) isMemcached(clusterName string) bool {
+	if mgr.runtime == nil || mgr.static.Testing || mgr.runningInEncoreCloud() {
+		return false
+	}
+	for _, mc := range mgr.runtime.Memcached {
+		if mc.EncoreName == clusterName {
+			return true
+		}
+	}
+	return false
+}
+
+// memcachedCASAttempts is the number of times a compare-and-swap
+// update is attempted before giving up due to contention.
+const memcachedCASAttempts = 100
+
+// memcachedBackend executes Redis commands against Memcached.
+type memcachedBackend struct {
+	mc     *memcache.Client
+	prefix string
+}
+
+// memcachedConn is an in-process connection speaking the Redis protocol,
+// as used by the Redis client. Commands are executed as they are written,
+// and their replies buffered until read.
+type memcachedConn struct {
+	b *memcachedBackend
+
+	mu     sync.Mutex
+	in     bytes.Buffer // unparsed request data
+	out    bytes.Buffer // pending replies
+	multi  bool         // within MULTI
+	queued [][]string   // commands queued within MULTI
+	closed bool
+}
+
+func (c *memcachedConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, net.ErrClosed
+	}
+
+	c.in.Write(p)
+	for {
+		args, n, err := parseRESPCommand(c.in.Bytes())
+		if err != nil {
+			return 0, err
+		} else if n == 0 {
+			return len(p), nil
+		}
+		c.in.Next(n)
+		c.handle(args)
+	}
+}
+
+func (c *memcachedConn) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, net.ErrClosed
+	} else if c.out.Len() == 0 {
+		// Replies are written synchronously with their commands,
+		// so there's nothing to wait for.
+		return 0, io.EOF
+	}
+	return c.out.Read(p)
+}
+
+func (c *memcachedConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *memcachedConn) LocalAddr() net.Addr                { return memcachedAddr{} }
+func (c *memcachedConn) RemoteAddr() net.Addr               { return memcachedAddr{} }
+func (c *memcachedConn) SetDeadline(t time.Time) error      { return nil }
+func (c *memcachedConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *memcachedConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type memcachedAddr struct{}
+
+func (memcachedAddr) Network() string { return "memcached" }
+func (memcachedAddr) String() string  { return "memcached" }
+
+// handle handles a single command, writing its reply to c.out.
+func (c *memcachedConn) handle(args []string) {
+	w := respWriter{&c.out}
+	switch name := strings.ToLower(args[0]); {
+	case name == "multi":
+		c.multi, c.queued = true, nil
+		w.status("OK")
+	case name == "exec":
+		if !c.multi {
+			w.err("ERR EXEC without MULTI")
+			return
+		}
+		w.arrayLen(len(c.queued))
+		for _, cmd := range c.queued {
+			c.b.exec(w, cmd)
+		}
+		c.multi, c.queued = false, nil
+	case name == "discard":
+		c.multi, c.queued = false, nil
+		w.status("OK")
+	case c.multi:
+		c.queued = append(c.queued, args)
+		w.status("QUEUED")
+	default:
+		c.b.exec(w, args)
+	}
+}
+
+// exec executes a single command, writing its reply to w.
+func (b *memcachedBackend) exec(w respWriter, args []string) {
+	name := strings.ToLower(args[0])
+	if err := b.execCmd(w, name, args[1:]); err != nil {
+		if !strings.HasPrefix(err.Error(), "ERR ") {
+			err = fmt.Errorf("ERR memcached: %v", err)
+		}
+		w.err(err.Error())
+	}
+}
+
+func (b *memcachedBackend) execCmd(w respWriter, name string, args []string) error {
+	arity := map[string]int{
+		"ping": 0, "get": 1, "getdel": 1, "strlen": 1, "persist": 1,
+		"incrby": 2, "decrby": 2, "incrbyfloat": 2, "append": 2, "pexpireat": 2,
+		"getrange": 3, "setrange": 3,
+	}
+	if n, ok := arity[name]; (ok && len(args) != n) || (!ok && len(args) == 0) {
+		return fmt.Errorf("ERR wrong number of arguments for '%s' command", name)
+	}
+
+	switch name {
+	case "ping":
+		if err := b.mc.Ping(); err != nil {
+			return err
+		}
+		w.status("PONG")
+
+	case "get":
+		item, err := b.mc.Get(b.key(args[0]))
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			w.null()
+		} else if err != nil {
+			return err
+		} else {
+			w.bulk(item.Value)
+		}
+
+	case "mget":
+		keys := make([]string, len(args))
+		for i, k := range args {
+			keys[i] = b.key(k)
+		}
+		items, err := b.mc.GetMulti(keys)
+		if err != nil {
+			return err
+		}
+		w.arrayLen(len(keys))
+		for _, k := range keys {
+			if item, ok := items[k]; ok {
+				w.bulk(item.Value)
+			} else {
+				w.null()
+			}
+		}
+
+	case "set":
+		return b.set(w, args)
+
+	case "getdel":
+		key := b.key(args[0])
+		item, err := b.mc.Get(key)
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			w.null()
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := b.mc.Delete(key); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+			return err
+		}
+		w.bulk(item.Value)
+
+	case "del":
+		deleted := 0
+		for _, k := range args {
+			err := b.mc.Delete(b.key(k))
+			if err == nil {
+				deleted++
+			} else if !errors.Is(err, memcache.ErrCacheMiss) {
+				return err
+			}
+		}
+		w.int(int64(deleted))
+
+	case "incrby", "decrby":
+		delta, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errors.New("ERR value is not an integer or out of range")
+		}
+		if name == "decrby" {
+			delta = -delta
+		}
+		val, err := b.update(b.key(args[0]), func(old []byte) ([]byte, error) {
+			n, err := parseMemcachedInt(old)
+			if err != nil {
+				return nil, err
+			}
+			return strconv.AppendInt(nil, n+delta, 10), nil
+		})
+		if err != nil {
+			return err
+		}
+		n, _ := strconv.ParseInt(string(val), 10, 64)
+		w.int(n)
+
+	case "incrbyfloat":
+		delta, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return errors.New("ERR value is not a valid float")
+		}
+		val, err := b.update(b.key(args[0]), func(old []byte) ([]byte, error) {
+			f := 0.0
+			if old != nil {
+				if f, err = strconv.ParseFloat(string(old), 64); err != nil {
+					return nil, errors.New("ERR value is not a valid float")
+				}
+			}
+			return strconv.AppendFloat(nil, f+delta, 'f', -1, 64), nil
+		})
+		if err != nil {
+			return err
+		}
+		w.bulk(val)
+
+	case "append":
+		val, err := b.update(b.key(args[0]), func(old []byte) ([]byte, error) {
+			return append(old, args[1]...), nil
+		})
+		if err != nil {
+			return err
+		}
+		w.int(int64(len(val)))
+
+	case "strlen":
+		item, err := b.mc.Get(b.key(args[0]))
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			w.int(0)
+		} else if err != nil {
+			return err
+		} else {
+			w.int(int64(len(item.Value)))
+		}
+
+	case "getrange":
+		start, err1 := strconv.ParseInt(args[1], 10, 64)
+		end, err2 := strconv.ParseInt(args[2], 10, 64)
+		if err1 != nil || err2 != nil {
+			return errors.New("ERR value is not an integer or out of range")
+		}
+		item, err := b.mc.Get(b.key(args[0]))
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			w.bulk(nil)
+		} else if err != nil {
+			return err
+		} else {
+			w.bulk(getRange(item.Value, start, end))
+		}
+
+	case "setrange":
+		offset, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || offset < 0 {
+			return errors.New("ERR offset is out of range")
+		}
+		key, val := b.key(args[0]), args[2]
+		if val == "" {
+			// Like Redis, don't create the key if there's nothing to set.
+			return b.execCmd(w, "strlen", args[:1])
+		}
+		res, err := b.update(key, func(old []byte) ([]byte, error) {
+			if n := int(offset) + len(val); len(old) < n {
+				old = append(old, make([]byte, n-len(old))...)
+			}
+			copy(old[offset:], val)
+			return old, nil
+		})
+		if err != nil {
+			return err
+		}
+		w.int(int64(len(res)))
+
+	case "pexpireat":
+		ms, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errors.New("ERR value is not an integer or out of range")
+		}
+		exp, expired := memcachedExpiry(time.UnixMilli(ms))
+		var touchErr error
+		if expired {
+			touchErr = b.mc.Delete(b.key(args[0]))
+		} else {
+			touchErr = b.mc.Touch(b.key(args[0]), exp)
+		}
+		return b.replyTouched(w, touchErr)
+
+	case "persist":
+		return b.replyTouched(w, b.mc.Touch(b.key(args[0]), 0))
+
+	default:
+		return fmt.Errorf("ERR command '%s' is not supported by Memcached-backed cache clusters", name)
+	}
+	return nil
+}
+
+// set implements the SET command.
+func (b *memcachedBackend) set(w respWriter, args []string) error {
+	if len(args) < 2 {
+		return errors.New("ERR wrong number of arguments for 'set' command")
+	}
+	item := &memcache.Item{Key: b.key(args[0]), Value: []byte(args[1])}
+
+	var nx, xx, get bool
+	for i := 2; i < len(args); i++ {
+		switch opt := strings.ToLower(args[i]); opt {
+		case "nx":
+			nx = true
+		case "xx":
+			xx = true
+		case "get":
+			get = true
+		case "keepttl":
+			return errors.New("ERR keeping the existing expiry is not supported by Memcached-backed cache clusters")
+		case "ex", "px", "exat", "pxat":
+			if i+1 >= len(args) {
+				return errors.New("ERR syntax error")
+			}
+			i++
+			n, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return errors.New("ERR value is not an integer or out of range")
+			}
+			var expiry time.Time
+			switch opt {
+			case "ex":
+				expiry = time.Now().Add(time.Duration(n) * time.Second)
+			case "px":
+				expiry = time.Now().Add(time.Duration(n) * time.Millisecond)
+			case "exat":
+				expiry = time.Unix(n, 0)
+			case "pxat":
+				expiry = time.UnixMilli(n)
+			}
+			item.Expiration, _ = memcachedExpiry(expiry)
+		default:
+			return errors.New("ERR syntax error")
+		}
+	}
+
+	if !get {
+		var err error
+		switch {
+		case nx:
+			err = b.mc.Add(item)
+		case xx:
+			err = b.mc.Replace(item)
+		default:
+			err = b.mc.Set(item)
+		}
+		if errors.Is(err, memcache.ErrNotStored) {
+			w.null()
+			return nil
+		} else if err != nil {
+			return err
+		}
+		w.status("OK")
+		return nil
+	}
+
+	// With GET the previous value is returned, so read it and
+	// use compare-and-swap to make sure it's not changed concurrently.
+	for attempt := 0; attempt < memcachedCASAttempts; attempt++ {
+		prev, err := b.mc.Get(item.Key)
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			if !xx {
+				err = b.mc.Add(item)
+				if errors.Is(err, memcache.ErrNotStored) {
+					continue // created concurrently
+				} else if err != nil {
+					return err
+				}
+			}
+			w.null()
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		prevValue := prev.Value
+		if !nx {
+			prev.Value, prev.Expiration = item.Value, item.Expiration
+			err = b.mc.CompareAndSwap(prev)
+			if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) {
+				continue // changed or deleted concurrently
+			} else if err != nil {
+				return err
+			}
+		}
+		w.bulk(prevValue)
+		return nil
+	}
+	return errors.New("too much contention")
+}
+
+// update replaces the value stored at key with the result of fn,
+// using compare-and-swap. The function is called with the current value,
+// or nil if the key doesn't exist, and may be called multiple times.
+//
+// Note that Memcached resets the expiry of updated keys.
+func (b *memcachedBackend) update(key string, fn func(old []byte) ([]byte, error)) ([]byte, error) {
+	for attempt := 0; attempt < memcachedCASAttempts; attempt++ {
+		item, err := b.mc.Get(key)
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			val, err := fn(nil)
+			if err != nil {
+				return nil, err
+			}
+			err = b.mc.Add(&memcache.Item{Key: key, Value: val})
+			if errors.Is(err, memcache.ErrNotStored) {
+				continue // created concurrently
+			}
+			return val, err
+		} else if err != nil {
+			return nil, err
+		}
+
+		val, err := fn(item.Value)
+		if err != nil {
+			return nil, err
+		}
+		item.Value = val
+		err = b.mc.CompareAndSwap(item)
+		if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) {
+			continue // changed or deleted concurrently
+		}
+		return val, err
+	}
+	return nil, errors.New("too much contention")
+}
+
+func (b *memcachedBackend) replyTouched(w respWriter, err error) error {
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		w.int(0)
+	} else if err != nil {
+		return err
+	} else {
+		w.int(1)
+	}
+	return nil
+}
+
+// key returns the Memcached key to use for the given cache key.
+// Keys that aren't valid Memcached keys are hashed.
+func (b *memcachedBackend) key(k string) string {
+	if key := b.prefix + k; validMemcachedKey(key) {
+		return key
+	}
+	sum := sha256.Sum256([]byte(k))
+	return b.prefix + "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func validMemcachedKey(k string) bool {
+	return len(k) <= 250 && !strings.ContainsFunc(k, func(r rune) bool {
+		return r <= ' ' || r == 0x7f
+	})
+}
+
+// memcachedExpiry converts an expiry time into a Memcached expiration value,
+// rounding up to the nearest second. It reports whether the time has passed.
+func memcachedExpiry(t time.Time) (exp int32, expired bool) {
+	dur := time.Until(t)
+	if dur <= 0 {
+		return -1, true
+	}
+
+	secs := int64((dur + time.Second - 1) / time.Second)
+	// Memcached interprets expirations over 30 days as unix timestamps.
+	const maxRelative = 30 * 24 * 60 * 60
+	if secs > maxRelative {
+		return int32(t.Unix() + 1), false
+	}
+	return int32(secs), false
+}
+
+func parseMemcachedInt(val []byte) (int64, error) {
+	if val == nil {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(string(val), 10, 64)
+	if err != nil {
+		return 0, errors.New("ERR value is not an integer or out of range")
+	}
+	return n, nil
+}
+
+// getRange implements the semantics of the GETRANGE command.
+func getRange(s []byte, start, end int64) []byte {
+	n := int64(len(s))
+	if start < 0 {
+		start = max(n+start, 0)
+	}
+	if end < 0 {
+		end = n + end
+	}
+	end = min(end, n-1)
+	if start > end {
+		return nil
+	}
+	return s[start : end+1]
+}
+
+// parseRESPCommand parses a single command in the Redis protocol from buf,
+// as an array of bulk strings. It returns the command arguments and the number
+// of bytes consumed, which is zero if buf doesn't contain a complete command.
+func parseRESPCommand(buf []byte) (args []string, n int, err error) {
+	readLine := func(prefix byte) (int, bool, error) {
+		i := bytes.Index(buf[n:], []byte("\r\n"))
+		if i < 0 {
+			return 0, false, nil
+		}
+		line := buf[n : n+i]
+		if len(line) == 0 || line[0] != prefix {
+			return 0, false, fmt.Errorf("cache: invalid redis protocol data: %q", line)
+		}
+		v, err := strconv.Atoi(string(line[1:]))
+		if err != nil || v < 0 {
+			return 0, false, fmt.Errorf("cache: invalid redis protocol data: %q", line)
+		}
+		n += i + 2
+		return v, true, nil
+	}
+
+	count, ok, err := readLine('*')
+	if !ok || err != nil {
+		return nil, 0, err
+	} else if count == 0 {
+		return nil, 0, errors.New("cache: invalid redis protocol data: empty command")
+	}
+
+	args = make([]string, 0, count)
+	for range count {
+		size, ok, err := readLine('$')
+		if !ok || err != nil {
+			return nil, 0, err
+		} else if len(buf)-n < size+2 {
+			return nil, 0, nil
+		}
+		args = append(args, string(buf[n:n+size]))
+		n += size + 2
+	}
+	return args, n, nil
+}
+
+// respWriter writes replies in the Redis protocol.
+type respWriter struct {
+	buf *bytes.Buffer
+}
+
+func (w respWriter) status(s string) { fmt.Fprintf(w.buf, "+%s\r\n", s) }
+func (w respWriter) err(s string)    { fmt.Fprintf(w.buf, "-%s\r\n", strings.ReplaceAll(s, "\r\n", " ")) }
+func (w respWriter) int(n int64)     { fmt.Fprintf(w.buf, ":%d\r\n", n) }
+func (w respWriter) null()           { w.buf.WriteString("$-1\r\n") }
+func (w respWriter) arrayLen(n int)  { fmt.Fprintf(w.buf, "*%d\r\n", n) }
+
+func (w respWriter) bulk(b []byte) {
+	fmt.Fprintf(w.buf, "$%d\r\n", len(b))
+	w.buf.Write(b)
+	w.buf.WriteString("\r\n")
+}
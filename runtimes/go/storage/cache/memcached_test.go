@@ -0,0 +1,328 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func newMemcachedTestCluster(t *testing.T) (*Cluster, *fakeMemcached) {
+	srv := newFakeMemcached(t)
+	rt := reqtrack.New(zerolog.New(os.Stdout), nil, nil)
+	mgr := &Manager{
+		static: &config.Static{},
+		runtime: &config.Runtime{
+			Memcached: []*config.MemcachedCluster{{EncoreName: "cluster", Servers: []string{srv.addr}, KeyPrefix: "app:"}},
+		},
+		rt:      rt,
+		clients: make(map[string]*redis.Client),
+	}
+	cluster := &Cluster{
+		name: "cluster",
+		mgr:  mgr,
+		cl:   mgr.getClient("cluster"),
+	}
+	t.Cleanup(func() { _ = cluster.cl.Close() })
+	return cluster, srv
+}
+
+func TestMemcached_StringKeyspace(t *testing.T) {
+	cluster, srv := newMemcachedTestCluster(t)
+	ks := NewStringKeyspace[string](cluster, KeyspaceConfig{
+		KeyPattern:               "foo/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	if _, err := ks.Get(ctx, "one"); !errors.Is(err, Miss) {
+		t.Fatalf("get missing key: got err %v, want Miss", err)
+	}
+	check(ks.Set(ctx, "one", "alpha"))
+	if got := must(ks.Get(ctx, "one")); got != "alpha" {
+		t.Errorf("get: got %q, want %q", got, "alpha")
+	}
+	if got := srv.get("app:one"); got != "alpha" {
+		t.Errorf("stored value: got %q, want %q", got, "alpha")
+	}
+
+	if err := ks.SetIfNotExists(ctx, "one", "beta"); !errors.Is(err, KeyExists) {
+		t.Errorf("set if not exists: got err %v, want KeyExists", err)
+	}
+	if err := ks.Replace(ctx, "two", "beta"); !errors.Is(err, Miss) {
+		t.Errorf("replace missing key: got err %v, want Miss", err)
+	}
+	if prev := must(ks.GetAndSet(ctx, "one", "gamma")); prev != "alpha" {
+		t.Errorf("get and set: got %q, want %q", prev, "alpha")
+	}
+
+	if got := must(ks.Append(ctx, "one", "!")); got != 6 {
+		t.Errorf("append: got len %d, want 6", got)
+	}
+	if got := must(ks.GetRange(ctx, "one", 1, -2)); got != "amma" {
+		t.Errorf("get range: got %q, want %q", got, "amma")
+	}
+	if got := must(ks.SetRange(ctx, "one", 7, "x")); got != 8 {
+		t.Errorf("set range: got len %d, want 8", got)
+	}
+	if got := must(ks.Len(ctx, "one")); got != 8 {
+		t.Errorf("len: got %d, want 8", got)
+	}
+
+	check(ks.Set(ctx, "two", "beta"))
+	res := must(ks.MultiGet(ctx, "one", "missing", "two"))
+	if res[0].Value != "gamma!\x00x" || !errors.Is(res[1].Err, Miss) || res[2].Value != "beta" {
+		t.Errorf("multi get: got %+v", res)
+	}
+
+	if got := must(ks.GetAndDelete(ctx, "two")); got != "beta" {
+		t.Errorf("get and delete: got %q, want %q", got, "beta")
+	}
+	if got := must(ks.Delete(ctx, "one", "two")); got != 1 {
+		t.Errorf("delete: got %d deleted, want 1", got)
+	}
+
+	// Keys that aren't valid in Memcached are hashed.
+	check(ks.Set(ctx, "with space", "delta"))
+	if got := must(ks.Get(ctx, "with space")); got != "delta" {
+		t.Errorf("get invalid key: got %q, want %q", got, "delta")
+	}
+
+	// KeepTTL can't be supported since Memcached doesn't expose expiry times.
+	if err := ks.With(KeepTTL).Set(ctx, "one", "alpha"); err == nil {
+		t.Errorf("set with KeepTTL: got nil err, want error")
+	}
+}
+
+func TestMemcached_IntKeyspace(t *testing.T) {
+	cluster, srv := newMemcachedTestCluster(t)
+	ks := NewIntKeyspace[string](cluster, KeyspaceConfig{
+		KeyPattern:               "foo/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	if got := must(ks.Increment(ctx, "one", 3)); got != 3 {
+		t.Errorf("increment: got %d, want 3", got)
+	}
+	if got := must(ks.Decrement(ctx, "one", 5)); got != -2 {
+		t.Errorf("decrement: got %d, want -2", got)
+	}
+
+	// Expiry is applied to updates.
+	if got := must(ks.With(ExpireIn(time.Hour)).Increment(ctx, "one", 1)); got != -1 {
+		t.Errorf("increment with expiry: got %d, want -1", got)
+	}
+	if exp := srv.expiry("app:one"); exp.IsZero() || time.Until(exp) > time.Hour {
+		t.Errorf("expiry: got %v, want within an hour", exp)
+	}
+
+	// Concurrent increments don't get lost.
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			must(ks.Increment(ctx, "two", 1))
+		}()
+	}
+	wg.Wait()
+	if got := must(ks.Get(ctx, "two")); got != 10 {
+		t.Errorf("concurrent increments: got %d, want 10", got)
+	}
+}
+
+func TestMemcached_Unsupported(t *testing.T) {
+	cluster, _ := newMemcachedTestCluster(t)
+	ks := NewListKeyspace[string, string](cluster, KeyspaceConfig{
+		KeyPattern:               "foo/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+
+	_, err := ks.PushLeft(context.Background(), "one", "a")
+	if err == nil || !strings.Contains(err.Error(), "not supported by Memcached") {
+		t.Errorf("push left: got err %v, want unsupported error", err)
+	}
+}
+
+// fakeMemcached is a minimal in-memory Memcached server
+// implementing the subset of the text protocol used by the client.
+type fakeMemcached struct {
+	addr string
+
+	mu    sync.Mutex
+	cas   uint64
+	items map[string]*fakeMemcachedItem
+}
+
+type fakeMemcachedItem struct {
+	value  []byte
+	flags  uint32
+	cas    uint64
+	expiry time.Time // zero if the item never expires
+}
+
+func newFakeMemcached(t *testing.T) *fakeMemcached {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	srv := &fakeMemcached{addr: ln.Addr().String(), items: make(map[string]*fakeMemcachedItem)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go srv.serve(conn)
+		}
+	}()
+	return srv
+}
+
+func (s *fakeMemcached) get(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if it := s.lookup(key); it != nil {
+		return string(it.value)
+	}
+	return ""
+}
+
+func (s *fakeMemcached) expiry(key string) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if it := s.lookup(key); it != nil {
+		return it.expiry
+	}
+	return time.Time{}
+}
+
+// lookup returns the item stored at key, or nil if there is none.
+// s.mu must be held.
+func (s *fakeMemcached) lookup(key string) *fakeMemcachedItem {
+	it := s.items[key]
+	if it != nil && !it.expiry.IsZero() && !it.expiry.After(time.Now()) {
+		delete(s.items, key)
+		return nil
+	}
+	return it
+}
+
+func (s *fakeMemcached) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	for {
+		line, err := rw.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var value []byte
+		switch fields[0] {
+		case "set", "add", "replace", "cas":
+			size, _ := strconv.Atoi(fields[4])
+			value = make([]byte, size+2)
+			if _, err := io.ReadFull(rw, value); err != nil {
+				return
+			}
+			value = value[:size]
+		}
+
+		s.mu.Lock()
+		s.handle(rw, fields, value)
+		s.mu.Unlock()
+		if err := rw.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// handle handles a single command. s.mu must be held.
+func (s *fakeMemcached) handle(w io.Writer, fields []string, value []byte) {
+	expiry := func(exp string) time.Time {
+		n, _ := strconv.ParseInt(exp, 10, 64)
+		switch {
+		case n == 0:
+			return time.Time{}
+		case n < 0:
+			return time.Unix(1, 0)
+		case n > 30*24*60*60:
+			return time.Unix(n, 0)
+		default:
+			return time.Now().Add(time.Duration(n) * time.Second)
+		}
+	}
+
+	switch cmd := fields[0]; cmd {
+	case "version":
+		fmt.Fprintf(w, "VERSION 1.6.0\r\n")
+
+	case "gets":
+		for _, key := range fields[1:] {
+			if it := s.lookup(key); it != nil {
+				fmt.Fprintf(w, "VALUE %s %d %d %d\r\n%s\r\n", key, it.flags, len(it.value), it.cas, it.value)
+			}
+		}
+		fmt.Fprintf(w, "END\r\n")
+
+	case "set", "add", "replace", "cas":
+		key := fields[1]
+		flags, _ := strconv.ParseUint(fields[2], 10, 32)
+		existing := s.lookup(key)
+		switch {
+		case cmd == "add" && existing != nil, cmd == "replace" && existing == nil:
+			fmt.Fprintf(w, "NOT_STORED\r\n")
+			return
+		case cmd == "cas" && existing == nil:
+			fmt.Fprintf(w, "NOT_FOUND\r\n")
+			return
+		case cmd == "cas" && fields[5] != strconv.FormatUint(existing.cas, 10):
+			fmt.Fprintf(w, "EXISTS\r\n")
+			return
+		}
+		s.cas++
+		s.items[key] = &fakeMemcachedItem{value: value, flags: uint32(flags), cas: s.cas, expiry: expiry(fields[3])}
+		fmt.Fprintf(w, "STORED\r\n")
+
+	case "delete":
+		if s.lookup(fields[1]) == nil {
+			fmt.Fprintf(w, "NOT_FOUND\r\n")
+			return
+		}
+		delete(s.items, fields[1])
+		fmt.Fprintf(w, "DELETED\r\n")
+
+	case "touch":
+		it := s.lookup(fields[1])
+		if it == nil {
+			fmt.Fprintf(w, "NOT_FOUND\r\n")
+			return
+		}
+		it.expiry = expiry(fields[2])
+		fmt.Fprintf(w, "TOUCHED\r\n")
+
+	default:
+		fmt.Fprintf(w, "ERROR\r\n")
+	}
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
+	"encore.dev/appruntime/infrasdk/metrics/system"
+)
+
+// metricReads is the name of the metric counting, for each keyspace,
+// the number of read operations that found (result="hit") or did not
+// find (result="miss") the requested key.
+const metricReads = "e_sys_cache_reads_total"
+
+// metricOversizedValues is the name of the metric counting, for each keyspace,
+// the number of values written exceeding the keyspace's value size limit,
+// either failing the write (action="rejected") or being truncated (action="truncated").
+const metricOversizedValues = "e_sys_cache_oversized_values_total"
+
+type counterKey struct {
+	cluster, keyspace, value string
+}
+
+// keyspaceCounter counts events per keyspace, split by a label value.
+type keyspaceCounter struct {
+	mu     sync.Mutex
+	counts map[counterKey]uint64
+}
+
+// inc increments the count of events with the given label value on the given keyspace.
+func (c *keyspaceCounter) inc(cluster, keyspace, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[counterKey]uint64)
+	}
+	c.counts[counterKey{cluster, keyspace, value}]++
+}
+
+// samples reports the counts as samples of the given metric,
+// with the label values under the given label key.
+func (c *keyspaceCounter) samples(name, label string) []system.Sample {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := slices.SortedFunc(maps.Keys(c.counts), func(a, b counterKey) int {
+		return cmp.Or(
+			cmp.Compare(a.cluster, b.cluster),
+			cmp.Compare(a.keyspace, b.keyspace),
+			cmp.Compare(a.value, b.value),
+		)
+	})
+
+	samples := make([]system.Sample, 0, len(keys))
+	for _, key := range keys {
+		samples = append(samples, system.Sample{
+			Name: name,
+			Labels: []system.Label{
+				{Key: "cluster", Value: key.cluster},
+				{Key: "keyspace", Value: key.keyspace},
+				{Key: label, Value: key.value},
+			},
+			Value: float64(c.counts[key]),
+		})
+	}
+	return samples
+}
+
+// readTracker counts the cache hits and misses of read operations.
+type readTracker struct {
+	keyspaceCounter
+}
+
+// record records the outcome of a read operation on the given keyspace.
+// Operations failing with errors other than Miss are not counted.
+func (t *readTracker) record(cluster, keyspace string, err error) {
+	result := "hit"
+	if errors.Is(err, Miss) {
+		result = "miss"
+	} else if err != nil {
+		return
+	}
+	t.inc(cluster, keyspace, result)
+}
+
+// readMetrics reports the number of cache hits and misses of each keyspace.
+func (mgr *Manager) readMetrics() []system.Sample {
+	return mgr.reads.samples(metricReads, "result")
+}
+
+// oversizedValueMetrics reports the number of oversized values written to each keyspace.
+func (mgr *Manager) oversizedValueMetrics() []system.Sample {
+	return mgr.oversized.samples(metricOversizedValues, "action")
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"encore.dev/appruntime/infrasdk/metrics/system"
+)
+
+func TestReadMetrics(t *testing.T) {
+	cluster, _ := newTestCluster(t)
+	cluster.name = "cluster"
+	ks := NewStringKeyspace[string](cluster, KeyspaceConfig{
+		KeyPattern:               "user/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	check(ks.Set(ctx, "a", "value"))
+	must(ks.Get(ctx, "a"))
+	must(ks.Get(ctx, "a"))
+	if _, err := ks.Get(ctx, "b"); err == nil {
+		t.Fatal("Get: expected a miss")
+	}
+
+	labels := func(result string) []system.Label {
+		return []system.Label{
+			{Key: "cluster", Value: "cluster"},
+			{Key: "keyspace", Value: "user/:key"},
+			{Key: "result", Value: result},
+		}
+	}
+	got := cluster.mgr.readMetrics()
+	want := []system.Sample{
+		{Name: metricReads, Labels: labels("hit"), Value: 2},
+		{Name: metricReads, Labels: labels("miss"), Value: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readMetrics() = %+v, want %+v", got, want)
+	}
+}
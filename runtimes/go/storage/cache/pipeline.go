@@ -0,0 +1,270 @@
+package cache
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+
+	"encore.dev/appruntime/exported/trace2"
+)
+
+// Pipeline batches operations on a keyspace so that they are sent
+// to the cache cluster in a single round trip when Exec is called,
+// instead of paying a round trip per operation.
+//
+// Operations are queued by calling the corresponding methods on the pipeline,
+// each of which returns a Pending result that is available once Exec returns.
+// The operations are not executed atomically: other clients may observe
+// or modify the keys in between the operations of a pipeline.
+//
+// A Pipeline must not be used concurrently, and can only be executed once.
+//
+//	p := MyKeyspace.Pipeline()
+//	a := p.Get("a")
+//	b := p.Get("b")
+//	p.Set("c", "value")
+//	if err := p.Exec(ctx); err != nil {
+//		return err
+//	}
+//	valA, err := a.Result()
+type Pipeline[K, V any] struct {
+	ks       *basicKeyspace[K, V]
+	ops      []*pipelineOp
+	executed bool
+}
+
+type pipelineOp struct {
+	name  string
+	key   string
+	write bool
+	err   error // non-nil if the operation failed before being queued
+
+	newCmd func(ctx context.Context) redis.Cmder
+	finish func(cmd redis.Cmder) error // decodes the result of cmd
+	cmd    redis.Cmder
+	res    pendingResult
+}
+
+// Pending is the result of an operation queued on a Pipeline.
+type Pending[T any] struct {
+	val  T
+	err  error
+	done bool
+}
+
+// Result returns the result of the operation.
+// It reports an error if the pipeline has not yet been executed.
+func (p *Pending[T]) Result() (T, error) {
+	if !p.done {
+		var zero T
+		return zero, errPipelineNotExecuted
+	}
+	return p.val, p.err
+}
+
+// Err returns the error of the operation, if any.
+// It reports an error if the pipeline has not yet been executed.
+func (p *Pending[T]) Err() error {
+	_, err := p.Result()
+	return err
+}
+
+type pendingResult interface {
+	resolve(err error)
+}
+
+func (p *Pending[T]) resolve(err error) {
+	p.err = err
+	p.done = true
+}
+
+var errPipelineNotExecuted = errors.New("cache: pipeline not executed")
+
+func newPipeline[K, V any](ks *basicKeyspace[K, V]) *Pipeline[K, V] {
+	return &Pipeline[K, V]{ks: ks}
+}
+
+func (p *Pipeline[K, V]) add(op *pipelineOp) {
+	p.ops = append(p.ops, op)
+}
+
+// Get queues an operation getting the value stored at key.
+// If the key does not exist, the result reports an error matching Miss.
+//
+// See https://redis.io/commands/get/ for more information.
+func (p *Pipeline[K, V]) Get(key K) *Pending[V] {
+	const op = "get"
+	res := &Pending[V]{}
+	k, err := p.ks.key(key, op)
+	p.add(&pipelineOp{
+		name: op,
+		key:  k,
+		err:  err,
+		res:  res,
+		newCmd: func(ctx context.Context) redis.Cmder {
+			return redis.NewStringCmd(ctx, "get", k)
+		},
+		finish: func(cmd redis.Cmder) error {
+			val, err := cmd.(*redis.StringCmd).Result()
+			if err == nil {
+				res.val, err = p.ks.fromRedis(val)
+			}
+			return toErr(err, op, k)
+		},
+	})
+	return res
+}
+
+// Set queues an operation updating the value stored at key to val.
+//
+// See https://redis.io/commands/set/ for more information.
+func (p *Pipeline[K, V]) Set(key K, val V) *Pending[struct{}] {
+	return p.set(key, val, 0, "set")
+}
+
+// SetIfNotExists queues an operation setting the value stored at key to val,
+// but only if the key does not exist beforehand.
+// If the key already exists, the result reports an error matching KeyExists.
+//
+// See https://redis.io/commands/setnx/ for more information.
+func (p *Pipeline[K, V]) SetIfNotExists(key K, val V) *Pending[struct{}] {
+	return p.set(key, val, setNX, "set if not exists")
+}
+
+// Replace queues an operation replacing the existing value stored at key with val.
+// If the key does not already exist, the result reports an error matching Miss.
+//
+// See https://redis.io/commands/set/ for more information.
+func (p *Pipeline[K, V]) Replace(key K, val V) *Pending[struct{}] {
+	return p.set(key, val, setXX, "replace")
+}
+
+func (p *Pipeline[K, V]) set(key K, val V, flag setFlag, op string) *Pending[struct{}] {
+	res := &Pending[struct{}]{}
+	k, err := p.ks.key(key, op)
+	var redisVal any
+	if err == nil {
+		redisVal, err = p.ks.toRedis(val)
+		err = toErr(err, op, k)
+	}
+
+	p.add(&pipelineOp{
+		name:  op,
+		key:   k,
+		write: true,
+		err:   err,
+		res:   res,
+		newCmd: func(ctx context.Context) redis.Cmder {
+			return redis.NewStatusCmd(ctx, p.ks.setArgs(k, redisVal, flag)...)
+		},
+		finish: func(cmd redis.Cmder) error {
+			err := cmd.Err()
+			if flag == setNX && errors.Is(err, redis.Nil) {
+				err = KeyExists
+			}
+			return toErr(err, op, k)
+		},
+	})
+	return res
+}
+
+// Delete queues an operation deleting the specified keys.
+// If a key does not exist it is ignored.
+//
+// The result reports the number of keys that were deleted.
+//
+// See https://redis.io/commands/del/ for more information.
+func (p *Pipeline[K, V]) Delete(keys ...K) *Pending[int] {
+	const op = "delete"
+	res := &Pending[int]{}
+	ks, err := p.ks.keys(keys, op)
+	var firstKey string
+	if len(ks) > 0 {
+		firstKey = ks[0]
+	}
+
+	p.add(&pipelineOp{
+		name:  op,
+		key:   firstKey,
+		write: true,
+		err:   err,
+		res:   res,
+		newCmd: func(ctx context.Context) redis.Cmder {
+			args := make([]any, 0, len(ks)+1)
+			args = append(args, "del")
+			for _, k := range ks {
+				args = append(args, k)
+			}
+			return redis.NewIntCmd(ctx, args...)
+		},
+		finish: func(cmd redis.Cmder) error {
+			n, err := cmd.(*redis.IntCmd).Result()
+			res.val = int(n)
+			return toErr(err, op, firstKey)
+		},
+	})
+	return res
+}
+
+// Len reports the number of operations queued on the pipeline.
+func (p *Pipeline[K, V]) Len() int {
+	return len(p.ops)
+}
+
+// Exec sends the queued operations to the cache cluster in a single round trip
+// and makes their results available through the Pending values.
+//
+// It reports the first error of an operation that failed, not including
+// operations failing with errors matching Miss or KeyExists, which are
+// only reported through the results of the individual operations.
+func (p *Pipeline[K, V]) Exec(ctx context.Context) (err error) {
+	const op = "pipeline"
+	if p.executed {
+		return toErr(errors.New("pipeline already executed"), op, "")
+	}
+	p.executed = true
+	if len(p.ops) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(p.ops))
+	write := false
+	for _, o := range p.ops {
+		keys = append(keys, o.key)
+		write = write || o.write
+	}
+
+	var results []trace2.CacheOpResult
+	endTrace := p.ks.doPipelineTrace(op, write, keys...)
+	defer func() { endTrace(err, results) }()
+
+	pipe := p.ks.redis.Pipeline()
+	for _, o := range p.ops {
+		if o.err == nil {
+			o.cmd = o.newCmd(ctx)
+			_ = pipe.Process(ctx, o.cmd)
+		}
+	}
+	// The errors of the individual commands are inspected below.
+	_, _ = pipe.Exec(ctx)
+
+	results = make([]trace2.CacheOpResult, len(p.ops))
+	for i, o := range p.ops {
+		opErr := o.err
+		if opErr == nil {
+			opErr = o.finish(o.cmd)
+		}
+		o.res.resolve(opErr)
+		if !o.write {
+			p.ks.reads.record(p.ks.cluster, string(p.ks.cfg.KeyPattern), opErr)
+		}
+
+		res, traceErr := cacheCallResult(opErr)
+		results[i] = trace2.CacheOpResult{Operation: o.name, Key: o.key, Res: res, Err: traceErr}
+		if traceErr != nil && err == nil {
+			err = opErr
+		}
+	}
+	return err
+}
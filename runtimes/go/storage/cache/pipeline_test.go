@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPipeline(t *testing.T) {
+	kt := newStringTest(t)
+	ks, ctx := kt.ks, kt.ctx
+	kt.Set("one", "alpha")
+	kt.Set("exists", "x")
+
+	p := ks.Pipeline()
+	one := p.Get("one")
+	missing := p.Get("missing")
+	p.Set("two", "beta")
+	nx := p.SetIfNotExists("exists", "y")
+	replace := p.Replace("nope", "z")
+	del := p.Delete("exists", "missing")
+	if got, want := p.Len(), 6; got != want {
+		t.Fatalf("Len() = %d, want %d", got, want)
+	}
+
+	if _, err := one.Result(); !errors.Is(err, errPipelineNotExecuted) {
+		t.Errorf("Result() before Exec: got err %v, want %v", err, errPipelineNotExecuted)
+	}
+	if err := p.Exec(ctx); err != nil {
+		t.Fatalf("Exec() = %v, want nil", err)
+	}
+
+	if got, err := one.Result(); err != nil || got != "alpha" {
+		t.Errorf("Get(one) = %q, %v, want %q, nil", got, err, "alpha")
+	}
+	if err := missing.Err(); !errors.Is(err, Miss) {
+		t.Errorf("Get(missing): got err %v, want %v", err, Miss)
+	}
+	if err := nx.Err(); !errors.Is(err, KeyExists) {
+		t.Errorf("SetIfNotExists(exists): got err %v, want %v", err, KeyExists)
+	}
+	if err := replace.Err(); !errors.Is(err, Miss) {
+		t.Errorf("Replace(nope): got err %v, want %v", err, Miss)
+	}
+	if got, err := del.Result(); err != nil || got != 1 {
+		t.Errorf("Delete() = %d, %v, want 1, nil", got, err)
+	}
+	kt.Val("two", "beta")
+	kt.Missing("exists")
+
+	if err := p.Exec(ctx); err == nil {
+		t.Errorf("second Exec() = nil, want error")
+	}
+}
+
+func TestPipelineExpiry(t *testing.T) {
+	kt := newStringTest(t)
+	ks, ctx := kt.ks, kt.ctx
+
+	p := ks.With(ExpireIn(time.Minute)).Pipeline()
+	p.Set("key", "value")
+	if err := p.Exec(ctx); err != nil {
+		t.Fatalf("Exec() = %v, want nil", err)
+	}
+	if got, want := kt.srv.TTL("key"), time.Minute; got != want {
+		t.Errorf("TTL = %v, want %v", got, want)
+	}
+}
+
+func TestPipelineErrors(t *testing.T) {
+	kt := newStringTest(t)
+	ks, ctx := kt.ks, kt.ctx
+
+	p := ks.Pipeline()
+	reserved := p.Get("__encore_reserved")
+	ok := p.Set("key", "value")
+	if err := p.Exec(ctx); err == nil {
+		t.Fatal("Exec() = nil, want error")
+	}
+	if err := reserved.Err(); err == nil {
+		t.Errorf("Get(reserved) = nil, want error")
+	}
+	if err := ok.Err(); err != nil {
+		t.Errorf("Set(key) = %v, want nil", err)
+	}
+	kt.Val("key", "value")
+}
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"context"
+
+	"encore.dev/appruntime/shared/preflight"
+)
+
+// registerPreflightChecks registers a preflight check for
+// each cache cluster bound to the running application.
+func (mgr *Manager) registerPreflightChecks(r *preflight.Registry) {
+	for _, rdb := range mgr.runtime.RedisDatabases {
+		name := rdb.EncoreName
+		r.Register("cache", name, func(ctx context.Context) error {
+			// PING requires authentication, so this also verifies the credentials.
+			return mgr.getClient(name).Ping(ctx).Err()
+		})
+	}
+	for _, mc := range mgr.runtime.Memcached {
+		name := mc.EncoreName
+		r.Register("cache", name, func(ctx context.Context) error {
+			return mgr.getClient(name).Ping(ctx).Err()
+		})
+	}
+}
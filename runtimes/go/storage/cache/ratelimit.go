@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// NewRateLimiter creates a keyspace of rate limiters in the given cluster.
+//
+// The type parameter K specifies the key type, which can either be a
+// named struct type or a basic type (string, int, etc).
+// Each key is rate limited separately.
+func NewRateLimiter[K any](cluster *Cluster, cfg KeyspaceConfig) *RateLimiter[K] {
+	fromRedis := func(val string) (int64, error) { return strconv.ParseInt(val, 10, 64) }
+	toRedis := func(val int64) (any, error) { return val, nil }
+
+	return &RateLimiter[K]{
+		client: newClient[K, int64](cluster, cfg, fromRedis, toRedis),
+	}
+}
+
+// RateLimiter represents a set of rate limiters, one per key.
+//
+// It implements the generic cell rate algorithm (GCRA), a token bucket
+// variant that stores a single timestamp per key and never needs
+// to refill buckets in the background. The time is taken from the
+// cache cluster, so rate limiting is consistent across processes.
+type RateLimiter[K any] struct {
+	client *client[K, int64]
+}
+
+// A Limit describes the rate at which events are allowed.
+type Limit struct {
+	// Rate is the number of events allowed per Period.
+	Rate int
+
+	// Period is the period over which Rate events are allowed.
+	Period time.Duration
+
+	// Burst is the maximum number of events allowed at once.
+	// If zero, it defaults to Rate.
+	Burst int
+}
+
+// PerSecond returns a Limit allowing rate events per second.
+func PerSecond(rate int) Limit {
+	return Limit{Rate: rate, Period: time.Second, Burst: rate}
+}
+
+// PerMinute returns a Limit allowing rate events per minute.
+func PerMinute(rate int) Limit {
+	return Limit{Rate: rate, Period: time.Minute, Burst: rate}
+}
+
+// PerHour returns a Limit allowing rate events per hour.
+func PerHour(rate int) Limit {
+	return Limit{Rate: rate, Period: time.Hour, Burst: rate}
+}
+
+// RateLimitResult is the result of a rate limiting decision.
+type RateLimitResult struct {
+	// Allowed reports whether the events were allowed.
+	Allowed bool
+
+	// Remaining is the number of additional events that
+	// would be allowed right now.
+	Remaining int
+
+	// RetryAfter is how long to wait until the events would be allowed.
+	// It's zero if the events were allowed.
+	RetryAfter time.Duration
+
+	// ResetAfter is how long until the full burst is available again.
+	ResetAfter time.Duration
+}
+
+// Allow reports whether an event for key is allowed under limit,
+// and if so records it.
+func (r *RateLimiter[K]) Allow(ctx context.Context, key K, limit Limit) (RateLimitResult, error) {
+	return r.AllowN(ctx, key, limit, 1)
+}
+
+// AllowN reports whether n events for key are allowed under limit,
+// and if so records them. Either all n events are allowed or none are.
+func (r *RateLimiter[K]) AllowN(ctx context.Context, key K, limit Limit, n int) (res RateLimitResult, err error) {
+	const op = "rate limit"
+	k, err := r.client.key(key, op)
+	endTrace := r.client.doTrace(op, true, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return res, err
+	}
+
+	burst := orDefault(limit.Burst, limit.Rate)
+	switch {
+	case limit.Rate <= 0 || limit.Period <= 0:
+		return res, toErr(errors.New("invalid limit: rate and period must be positive"), op, k)
+	case n <= 0:
+		return res, toErr(errors.New("invalid number of events: must be positive"), op, k)
+	case n > burst:
+		return res, toErr(errors.New("invalid number of events: exceeds the burst limit"), op, k)
+	}
+
+	vals, err := rateLimitScript.Run(ctx, r.client.redis, []string{k},
+		burst, limit.Rate, limit.Period.Microseconds(), n).Int64Slice()
+	if err != nil {
+		return res, toErr(err, op, k)
+	} else if len(vals) != 4 {
+		return res, toErr(errors.New("unexpected rate limit script result"), op, k)
+	}
+
+	return RateLimitResult{
+		Allowed:    vals[0] == 1,
+		Remaining:  int(vals[1]),
+		RetryAfter: time.Duration(vals[2]) * time.Microsecond,
+		ResetAfter: time.Duration(vals[3]) * time.Microsecond,
+	}, nil
+}
+
+// Reset resets the rate limiter for key, making the full burst available.
+func (r *RateLimiter[K]) Reset(ctx context.Context, key K) (err error) {
+	const op = "rate limit reset"
+	k, err := r.client.key(key, op)
+	endTrace := r.client.doTrace(op, true, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return err
+	}
+
+	err = r.client.redis.Del(ctx, k).Err()
+	return toErr(err, op, k)
+}
+
+// rateLimitScript implements GCRA. The key stores the theoretical arrival
+// time (TAT) of the next event, in microseconds since the Unix epoch.
+//
+// It returns {allowed, remaining, retry after, reset after},
+// with durations in microseconds.
+var rateLimitScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local period = tonumber(ARGV[3])
+local cost = tonumber(ARGV[4])
+
+local emission_interval = period / rate
+local burst_offset = emission_interval * burst
+
+local t = redis.call("time")
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+
+local tat = tonumber(redis.call("get", KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+
+local new_tat = tat + emission_interval * cost
+local diff = now - (new_tat - burst_offset)
+if diff < 0 then
+	local remaining = math.floor((now - (tat - burst_offset)) / emission_interval)
+	return {0, remaining, math.ceil(-diff), math.ceil(tat - now)}
+end
+
+local reset_after = math.ceil(new_tat - now)
+redis.call("set", KEYS[1], string.format("%.0f", math.floor(new_tat)), "px", math.max(1, math.ceil(reset_after / 1000)))
+return {1, math.floor(diff / emission_interval), 0, reset_after}
+`)
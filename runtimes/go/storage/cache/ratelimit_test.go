@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	cluster, srv := newTestCluster(t)
+	limiter := NewRateLimiter[string](cluster, KeyspaceConfig{
+		KeyPattern:               "ratelimit/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	srv.SetTime(now)
+
+	limit := PerSecond(2)
+	for i := 0; i < 2; i++ {
+		res := must(limiter.Allow(ctx, "one", limit))
+		if !res.Allowed || res.Remaining != 1-i {
+			t.Errorf("allow %d: got %+v, want allowed with %d remaining", i, res, 1-i)
+		}
+	}
+
+	res := must(limiter.Allow(ctx, "one", limit))
+	if res.Allowed || res.Remaining != 0 || res.RetryAfter != 500*time.Millisecond {
+		t.Errorf("allow over limit: got %+v, want denied with retry after 500ms", res)
+	}
+
+	// Other keys are independent.
+	if res := must(limiter.Allow(ctx, "two", limit)); !res.Allowed {
+		t.Errorf("allow other key: got %+v, want allowed", res)
+	}
+
+	// Tokens are regained as time passes.
+	srv.SetTime(now.Add(500 * time.Millisecond))
+	if res := must(limiter.Allow(ctx, "one", limit)); !res.Allowed || res.Remaining != 0 {
+		t.Errorf("allow after waiting: got %+v, want allowed with 0 remaining", res)
+	}
+
+	// Either all events are allowed or none are.
+	if res := must(limiter.AllowN(ctx, "one", limit, 2)); res.Allowed {
+		t.Errorf("allow n: got %+v, want denied", res)
+	}
+
+	check(limiter.Reset(ctx, "one"))
+	if res := must(limiter.AllowN(ctx, "one", limit, 2)); !res.Allowed || res.ResetAfter != time.Second {
+		t.Errorf("allow n after reset: got %+v, want allowed with reset after 1s", res)
+	}
+
+	if _, err := limiter.AllowN(ctx, "one", limit, 3); err == nil {
+		t.Errorf("allow n above burst: got nil err, want error")
+	}
+}
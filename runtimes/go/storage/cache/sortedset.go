@@ -0,0 +1,406 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// NewSortedSetKeyspace creates a keyspace that stores sorted sets in the given cluster.
+// Each element in a sorted set has an associated score, and elements are ordered
+// by their score (and lexicographically for elements with the same score).
+//
+// Sorted sets are well suited for leaderboards, priority queues and
+// rate limiting windows.
+//
+// The type parameter K specifies the key type, which can either be a
+// named struct type or a basic type (string, int, etc).
+//
+// The type parameter V specifies the value type, which is the type
+// of the elements in each sorted set. It must be a basic type (string, int, int64, or float64).
+func NewSortedSetKeyspace[K any, V BasicType](cluster *Cluster, cfg KeyspaceConfig) *SortedSetKeyspace[K, V] {
+	fromRedis := basicFromRedisFactory[V]()
+	toRedis := basicToRedisFactory[V]()
+
+	return &SortedSetKeyspace[K, V]{
+		newClient[K, V](cluster, cfg, fromRedis, toRedis),
+	}
+}
+
+// SortedSetKeyspace represents a set of cache keys,
+// each containing a sorted set of values of type V.
+type SortedSetKeyspace[K any, V BasicType] struct {
+	*client[K, V]
+}
+
+// ScoredValue is an element of a sorted set together with its score.
+type ScoredValue[V BasicType] struct {
+	Value V
+	Score float64
+}
+
+// With returns a reference to the same keyspace but with customized write options.
+// The primary use case is for overriding the expiration time for certain cache operations.
+//
+// It is intended to be used with method chaining:
+//
+//	myKeyspace.With(cache.ExpireIn(3 * time.Second)).Add(...)
+func (k *SortedSetKeyspace[K, V]) With(opts ...WriteOption) *SortedSetKeyspace[K, V] {
+	return &SortedSetKeyspace[K, V]{k.client.with(opts)}
+}
+
+// Delete deletes the specified keys.
+//
+// If a key does not exist it is ignored.
+//
+// It reports the number of keys that were deleted.
+//
+// See https://redis.io/commands/del/ for more information.
+func (s *SortedSetKeyspace[K, V]) Delete(ctx context.Context, keys ...K) (deleted int, err error) {
+	return s.client.Delete(ctx, keys...)
+}
+
+// Add adds one or more values with their scores to the sorted set stored at key.
+// If the key does not already exist, it is first created as an empty sorted set.
+//
+// If a value is already present in the sorted set its score is updated.
+//
+// It reports the number of values that were added to the sorted set,
+// not including values already present beforehand.
+//
+// See https://redis.io/commands/zadd/ for more information.
+func (s *SortedSetKeyspace[K, V]) Add(ctx context.Context, key K, values ...ScoredValue[V]) (added int, err error) {
+	const op = "sorted set add"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, true, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return 0, err
+	}
+
+	members := fnMap(values, func(v ScoredValue[V]) *redis.Z {
+		return &redis.Z{Score: v.Score, Member: v.Value}
+	})
+	res, err := do(s.client, ctx, k, func(c cmdable) *redis.IntCmd {
+		return c.ZAdd(ctx, k, members...)
+	}).Result()
+
+	err = toErr(err, op, k)
+	return int(res), err
+}
+
+// Remove removes one or more values from the sorted set stored at key.
+//
+// If a value is not present in the sorted set is it ignored.
+//
+// Remove reports the number of values that were removed from the sorted set.
+// If the key does not already exist, it is a no-op and reports 0, nil.
+//
+// See https://redis.io/commands/zrem/ for more information.
+func (s *SortedSetKeyspace[K, V]) Remove(ctx context.Context, key K, values ...V) (removed int, err error) {
+	const op = "sorted set remove"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, true, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return 0, err
+	}
+
+	vals := fnMap(values, func(v V) any { return v })
+	res, err := do(s.client, ctx, k, func(c cmdable) *redis.IntCmd {
+		return c.ZRem(ctx, k, vals...)
+	}).Result()
+
+	err = toErr(err, op, k)
+	return int(res), err
+}
+
+// IncrementScore increments the score of val in the sorted set stored at key by delta,
+// and returns the new score. The delta can be negative.
+//
+// If val is not present in the sorted set (or the key does not exist),
+// it is added with delta as its score.
+//
+// See https://redis.io/commands/zincrby/ for more information.
+func (s *SortedSetKeyspace[K, V]) IncrementScore(ctx context.Context, key K, val V, delta float64) (newScore float64, err error) {
+	const op = "sorted set increment score"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, true, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return 0, err
+	}
+
+	member := basicToString(val)
+	res, err := do(s.client, ctx, k, func(c cmdable) *redis.FloatCmd {
+		return c.ZIncrBy(ctx, k, delta, member)
+	}).Result()
+
+	err = toErr(err, op, k)
+	return res, err
+}
+
+// Score returns the score of val in the sorted set stored at key.
+//
+// If the key does not exist or val is not present in the sorted set,
+// it reports an error matching Miss.
+//
+// See https://redis.io/commands/zscore/ for more information.
+func (s *SortedSetKeyspace[K, V]) Score(ctx context.Context, key K, val V) (score float64, err error) {
+	const op = "sorted set score"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, false, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.redis.ZScore(ctx, k, basicToString(val)).Result()
+	err = toErr(err, op, k)
+	return res, err
+}
+
+// Rank returns the rank of val in the sorted set stored at key,
+// with the scores ordered from low to high. The rank is 0-based,
+// meaning the value with the lowest score has rank 0.
+//
+// If the key does not exist or val is not present in the sorted set,
+// it reports an error matching Miss.
+//
+// See https://redis.io/commands/zrank/ for more information.
+func (s *SortedSetKeyspace[K, V]) Rank(ctx context.Context, key K, val V) (rank int64, err error) {
+	const op = "sorted set rank"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, false, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.redis.ZRank(ctx, k, basicToString(val)).Result()
+	err = toErr(err, op, k)
+	return res, err
+}
+
+// RevRank is like Rank, except the scores are ordered from high to low,
+// meaning the value with the highest score has rank 0.
+//
+// See https://redis.io/commands/zrevrank/ for more information.
+func (s *SortedSetKeyspace[K, V]) RevRank(ctx context.Context, key K, val V) (rank int64, err error) {
+	const op = "sorted set rev rank"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, false, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.redis.ZRevRank(ctx, k, basicToString(val)).Result()
+	err = toErr(err, op, k)
+	return res, err
+}
+
+// Len reports the number of elements in the sorted set stored at key.
+//
+// If the key does not exist it reports 0, nil.
+//
+// See https://redis.io/commands/zcard/ for more information.
+func (s *SortedSetKeyspace[K, V]) Len(ctx context.Context, key K) (length int64, err error) {
+	const op = "sorted set len"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, false, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.redis.ZCard(ctx, k).Result()
+	err = toErr(err, op, k)
+	return res, err
+}
+
+// CountByScore reports the number of elements in the sorted set stored at key
+// with a score between min and max (inclusive).
+//
+// If the key does not exist it reports 0, nil.
+//
+// See https://redis.io/commands/zcount/ for more information.
+func (s *SortedSetKeyspace[K, V]) CountByScore(ctx context.Context, key K, min, max float64) (count int64, err error) {
+	const op = "sorted set count by score"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, false, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.redis.ZCount(ctx, k, formatScore(min), formatScore(max)).Result()
+	err = toErr(err, op, k)
+	return res, err
+}
+
+// GetRange returns the elements in the sorted set stored at key between
+// the ranks start and stop (inclusive), ordered by score from low to high.
+//
+// Negative ranks can be used to indicate offsets from the end of the sorted set,
+// where -1 is the element with the highest score, -2 the one before it, and so on.
+//
+// If the key does not exist it returns an empty slice and no error.
+//
+// See https://redis.io/commands/zrange/ for more information.
+func (s *SortedSetKeyspace[K, V]) GetRange(ctx context.Context, key K, start, stop int64) (values []ScoredValue[V], err error) {
+	const op = "sorted set get range"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, false, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.redis.ZRangeWithScores(ctx, k, start, stop).Result()
+	if err == nil {
+		values, err = s.toScored(res)
+	}
+	err = toErr(err, op, k)
+	return values, err
+}
+
+// GetRevRange is like GetRange, except the elements are ordered
+// by score from high to low. It is useful for retrieving the top
+// entries of a leaderboard.
+//
+// See https://redis.io/commands/zrevrange/ for more information.
+func (s *SortedSetKeyspace[K, V]) GetRevRange(ctx context.Context, key K, start, stop int64) (values []ScoredValue[V], err error) {
+	const op = "sorted set get rev range"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, false, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.redis.ZRevRangeWithScores(ctx, k, start, stop).Result()
+	if err == nil {
+		values, err = s.toScored(res)
+	}
+	err = toErr(err, op, k)
+	return values, err
+}
+
+// GetRangeByScore returns the elements in the sorted set stored at key
+// with a score between min and max (inclusive), ordered by score from low to high.
+//
+// If the key does not exist it returns an empty slice and no error.
+//
+// See https://redis.io/commands/zrangebyscore/ for more information.
+func (s *SortedSetKeyspace[K, V]) GetRangeByScore(ctx context.Context, key K, min, max float64) (values []ScoredValue[V], err error) {
+	const op = "sorted set get range by score"
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, false, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.redis.ZRangeByScoreWithScores(ctx, k, &redis.ZRangeBy{
+		Min: formatScore(min),
+		Max: formatScore(max),
+	}).Result()
+	if err == nil {
+		values, err = s.toScored(res)
+	}
+	err = toErr(err, op, k)
+	return values, err
+}
+
+// PopMin removes and returns up to 'count' elements with the lowest scores
+// from the sorted set stored at key, ordered by score from low to high.
+//
+// If the key does not exist it returns an empty slice and no error.
+//
+// See https://redis.io/commands/zpopmin/ for more information.
+func (s *SortedSetKeyspace[K, V]) PopMin(ctx context.Context, key K, count int) (values []ScoredValue[V], err error) {
+	const op = "sorted set pop min"
+	return s.pop(ctx, op, key, count, func(c cmdable, k string) *redis.ZSliceCmd {
+		return c.ZPopMin(ctx, k, int64(count))
+	})
+}
+
+// PopMax removes and returns up to 'count' elements with the highest scores
+// from the sorted set stored at key, ordered by score from high to low.
+//
+// If the key does not exist it returns an empty slice and no error.
+//
+// See https://redis.io/commands/zpopmax/ for more information.
+func (s *SortedSetKeyspace[K, V]) PopMax(ctx context.Context, key K, count int) (values []ScoredValue[V], err error) {
+	const op = "sorted set pop max"
+	return s.pop(ctx, op, key, count, func(c cmdable, k string) *redis.ZSliceCmd {
+		return c.ZPopMax(ctx, k, int64(count))
+	})
+}
+
+func (s *SortedSetKeyspace[K, V]) pop(ctx context.Context, op string, key K, count int, fn func(c cmdable, k string) *redis.ZSliceCmd) (values []ScoredValue[V], err error) {
+	k, err := s.key(key, op)
+	endTrace := s.doTrace(op, true, k)
+	defer func() { endTrace(err) }()
+	if err != nil {
+		return nil, err
+	}
+
+	if count < 0 {
+		err = toErr(errors.New("negative count"), op, k)
+		return nil, err
+	} else if count == 0 {
+		return nil, nil
+	}
+
+	res, err := do(s.client, ctx, k, func(c cmdable) *redis.ZSliceCmd {
+		return fn(c, k)
+	}).Result()
+	if err == nil {
+		values, err = s.toScored(res)
+	}
+	err = toErr(err, op, k)
+	return values, err
+}
+
+func (s *SortedSetKeyspace[K, V]) toScored(res []redis.Z) ([]ScoredValue[V], error) {
+	ret := make([]ScoredValue[V], len(res))
+	for i, z := range res {
+		str, ok := z.Member.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected sorted set member type %T", z.Member)
+		}
+		val, err := s.fromRedis(str)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = ScoredValue[V]{Value: val, Score: z.Score}
+	}
+	return ret, nil
+}
+
+// basicToString formats val the same way the Redis client does
+// when it's passed as a command argument.
+func basicToString[V BasicType](val V) string {
+	switch v := any(val).(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		panic(fmt.Sprintf("unsupported BasicType %T", v))
+	}
+}
+
+// formatScore formats a score as a Redis score range bound.
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
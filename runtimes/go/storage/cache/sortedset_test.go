@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSortedSets(t *testing.T) {
+	cluster, _ := newTestCluster(t)
+	ks := NewSortedSetKeyspace[string, string](cluster, KeyspaceConfig{
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	if got, want := must(ks.Add(ctx, "board",
+		ScoredValue[string]{"alice", 10},
+		ScoredValue[string]{"bob", 20},
+		ScoredValue[string]{"carol", 15},
+	)), 3; got != want {
+		t.Errorf("Add() = %d, want %d", got, want)
+	}
+	if got, want := must(ks.Add(ctx, "board", ScoredValue[string]{"alice", 25})), 0; got != want {
+		t.Errorf("Add() = %d, want %d", got, want)
+	}
+
+	checkScored(t, must(ks.GetRange(ctx, "board", 0, -1)), "carol", 15, "bob", 20, "alice", 25)
+	checkScored(t, must(ks.GetRevRange(ctx, "board", 0, 1)), "alice", 25, "bob", 20)
+	checkScored(t, must(ks.GetRangeByScore(ctx, "board", 15, 20)), "carol", 15, "bob", 20)
+
+	if got, want := must(ks.Score(ctx, "board", "bob")), 20.0; got != want {
+		t.Errorf("Score() = %v, want %v", got, want)
+	}
+	if got, want := must(ks.IncrementScore(ctx, "board", "bob", 10)), 30.0; got != want {
+		t.Errorf("IncrementScore() = %v, want %v", got, want)
+	}
+	if got, want := must(ks.Rank(ctx, "board", "bob")), int64(2); got != want {
+		t.Errorf("Rank() = %d, want %d", got, want)
+	}
+	if got, want := must(ks.RevRank(ctx, "board", "bob")), int64(0); got != want {
+		t.Errorf("RevRank() = %d, want %d", got, want)
+	}
+	if _, err := ks.Rank(ctx, "board", "dave"); !errors.Is(err, Miss) {
+		t.Errorf("Rank: got err %v, want %v", err, Miss)
+	}
+	if _, err := ks.Score(ctx, "board", "dave"); !errors.Is(err, Miss) {
+		t.Errorf("Score: got err %v, want %v", err, Miss)
+	}
+
+	if got, want := must(ks.Len(ctx, "board")), int64(3); got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+	if got, want := must(ks.CountByScore(ctx, "board", 20, 30)), int64(2); got != want {
+		t.Errorf("CountByScore() = %d, want %d", got, want)
+	}
+
+	checkScored(t, must(ks.PopMin(ctx, "board", 1)), "carol", 15)
+	checkScored(t, must(ks.PopMax(ctx, "board", 1)), "bob", 30)
+	if got, want := must(ks.Remove(ctx, "board", "alice", "dave")), 1; got != want {
+		t.Errorf("Remove() = %d, want %d", got, want)
+	}
+	checkScored(t, must(ks.GetRange(ctx, "board", 0, -1)))
+	checkScored(t, must(ks.PopMin(ctx, "board", 1)))
+}
+
+func TestSortedSetsIntValues(t *testing.T) {
+	cluster, _ := newTestCluster(t)
+	ks := NewSortedSetKeyspace[string, int64](cluster, KeyspaceConfig{
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	ctx := context.Background()
+
+	must(ks.Add(ctx, "queue", ScoredValue[int64]{5, 2}, ScoredValue[int64]{7, 1}))
+	if got, want := must(ks.Rank(ctx, "queue", 5)), int64(1); got != want {
+		t.Errorf("Rank() = %d, want %d", got, want)
+	}
+	got := must(ks.PopMin(ctx, "queue", 2))
+	want := []ScoredValue[int64]{{7, 1}, {5, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PopMin() = %+v, want %+v", got, want)
+	}
+}
+
+// checkScored checks that got matches the given value and score pairs.
+func checkScored(t *testing.T, got []ScoredValue[string], want ...any) {
+	t.Helper()
+	var exp []ScoredValue[string]
+	for i := 0; i < len(want); i += 2 {
+		exp = append(exp, ScoredValue[string]{Value: want[i].(string), Score: float64(want[i+1].(int))})
+	}
+	if len(got) == 0 && len(exp) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("got %+v, want %+v", got, exp)
+	}
+}
@@ -12,12 +12,20 @@ func NewStructKeyspace[K, V any](cluster *Cluster, cfg KeyspaceConfig) *StructKe
 	json := cluster.mgr.json
 	fromRedis := func(val string) (V, error) {
 		var v V
-		err := json.UnmarshalFromString(val, &v)
+		val, err := decompressValue(val)
+		if err != nil {
+			return v, err
+		}
+		err = json.UnmarshalFromString(val, &v)
 		return v, err
 	}
-	toRedis := func(val V) (any, error) {
-		return json.MarshalToString(val)
-	}
+	toRedis := limitValueSize(cluster, cfg, false, func(val V) (any, error) {
+		str, err := json.MarshalToString(val)
+		if err != nil || cfg.Compression == nil {
+			return str, err
+		}
+		return compressValue(*cfg.Compression, str)
+	})
 
 	return &StructKeyspace[K, V]{
 		&basicKeyspace[K, V]{
@@ -41,6 +49,12 @@ func (k *StructKeyspace[K, V]) With(opts ...WriteOption) *StructKeyspace[K, V] {
 	return &StructKeyspace[K, V]{k.basicKeyspace.with(opts)}
 }
 
+// Pipeline returns a new pipeline for batching operations on the keyspace
+// into a single round trip to the cache cluster. See Pipeline for more information.
+func (k *StructKeyspace[K, V]) Pipeline() *Pipeline[K, V] {
+	return newPipeline(k.basicKeyspace)
+}
+
 // Get gets the value stored at key.
 // If the key does not exist, it returns an error matching Miss.
 //
@@ -59,6 +73,20 @@ func (s *StructKeyspace[K, V]) MultiGet(ctx context.Context, keys ...K) ([]Resul
 	return s.basicKeyspace.MultiGet(ctx, keys...)
 }
 
+// GetOrLoad gets the value stored at key. If the key does not exist,
+// the value is loaded by calling load and stored before being returned.
+//
+// The load function reports that no value exists for the key by returning
+// an error matching Miss, in which case GetOrLoad reports an error matching Miss.
+// Other errors from load are returned as-is.
+//
+// Concurrent calls loading the same key share a single call to load.
+// See KeyspaceConfig.NegativeCache and KeyspaceConfig.StaleWhileRevalidate
+// for caching "not found" results and serving stale values while they're refreshed.
+func (s *StructKeyspace[K, V]) GetOrLoad(ctx context.Context, key K, load func(context.Context, K) (V, error)) (V, error) {
+	return s.basicKeyspace.GetOrLoad(ctx, key, load)
+}
+
 // Set updates the value stored at key to val.
 //
 // See https://redis.io/commands/set/ for more information.
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// CompressionConfig configures transparent compression of the values
+// stored in a keyspace.
+//
+// Values larger than MinSize are compressed with gzip before being stored,
+// and decompressed when read. Smaller values, and values that don't get
+// any smaller when compressed, are stored as is.
+//
+// Compressed values are always decompressed when read, so compression
+// can safely be enabled or disabled for a keyspace already holding values.
+type CompressionConfig struct {
+	// MinSize is the size in bytes above which values are compressed.
+	//
+	// If zero, it defaults to 1024.
+	MinSize int
+}
+
+// ValueSizeLimitConfig limits the size of the values stored in a keyspace,
+// guarding the cache cluster against unexpectedly large values.
+//
+// Writing a value larger than MaxSize fails with an error matching ValueTooLarge,
+// unless Truncate is set. Either way the value is counted by the
+// e_sys_cache_oversized_values_total metric.
+type ValueSizeLimitConfig struct {
+	// MaxSize is the maximum size of a value in bytes.
+	// When compression is enabled, it applies to the compressed value.
+	MaxSize int
+
+	// Truncate, if true, truncates values larger than MaxSize
+	// instead of failing the write. Values are truncated
+	// on a UTF-8 character boundary.
+	//
+	// It's only supported by string keyspaces.
+	Truncate bool
+}
+
+const defaultCompressionMinSize = 1024
+
+// gzipMagic is the header all gzip streams begin with.
+// It can't begin a JSON document, which makes compressed
+// struct values distinguishable from uncompressed ones.
+const gzipMagic = "\x1f\x8b"
+
+var gzipWriters = sync.Pool{
+	New: func() any { return gzip.NewWriter(nil) },
+}
+
+// compressValue compresses val if it's larger than the configured minimum size,
+// and if doing so makes it smaller. Otherwise it returns val unchanged.
+func compressValue(cfg CompressionConfig, val string) (string, error) {
+	if len(val) <= orDefault(cfg.MinSize, defaultCompressionMinSize) {
+		return val, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(zw)
+	zw.Reset(&buf)
+	if _, err := io.WriteString(zw, val); err != nil {
+		return "", fmt.Errorf("compress value: %w", err)
+	} else if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("compress value: %w", err)
+	}
+
+	if buf.Len() >= len(val) {
+		return val, nil
+	}
+	return buf.String(), nil
+}
+
+// decompressValue decompresses val if it was compressed by compressValue.
+// Otherwise it returns val unchanged.
+func decompressValue(val string) (string, error) {
+	if !strings.HasPrefix(val, gzipMagic) {
+		return val, nil
+	}
+
+	zr, err := gzip.NewReader(strings.NewReader(val))
+	if err != nil {
+		return "", fmt.Errorf("decompress value: %w", err)
+	}
+	var buf strings.Builder
+	if _, err := io.Copy(&buf, zr); err != nil {
+		return "", fmt.Errorf("decompress value: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// limitValueSize returns toRedis wrapped to enforce the keyspace's
+// value size limit, if one is configured. Oversized values are truncated
+// if the limit says so and canTruncate is true, and rejected otherwise.
+func limitValueSize[V any](cluster *Cluster, cfg KeyspaceConfig, canTruncate bool, toRedis func(V) (any, error)) func(V) (any, error) {
+	limit := cfg.ValueSizeLimit
+	if limit == nil || limit.MaxSize <= 0 {
+		return toRedis
+	}
+
+	oversized, keyspace := &cluster.mgr.oversized, string(cfg.KeyPattern)
+	return func(val V) (any, error) {
+		redisVal, err := toRedis(val)
+		if err != nil {
+			return nil, err
+		}
+		str, ok := redisVal.(string)
+		if !ok || len(str) <= limit.MaxSize {
+			return redisVal, nil
+		}
+
+		if limit.Truncate && canTruncate {
+			oversized.inc(cluster.name, keyspace, "truncated")
+			return truncateUTF8(str, limit.MaxSize), nil
+		}
+		oversized.inc(cluster.name, keyspace, "rejected")
+		return nil, fmt.Errorf("%w: %d bytes exceeds the limit of %d bytes", ValueTooLarge, len(str), limit.MaxSize)
+	}
+}
+
+// truncateUTF8 truncates s to at most n bytes
+// without splitting a multi-byte UTF-8 character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
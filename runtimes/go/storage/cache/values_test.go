@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"encore.dev/appruntime/infrasdk/metrics/system"
+)
+
+type compressTestValue struct {
+	Data string
+}
+
+func TestCompression(t *testing.T) {
+	cluster, srv := newTestCluster(t)
+	cluster.mgr.json = jsoniter.ConfigCompatibleWithStandardLibrary
+	ks := NewStructKeyspace[string, compressTestValue](cluster, KeyspaceConfig{
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+		Compression:              &CompressionConfig{MinSize: 100},
+	})
+	ctx := context.Background()
+
+	small := compressTestValue{Data: "small"}
+	large := compressTestValue{Data: strings.Repeat("large ", 1000)}
+	check(ks.Set(ctx, "small", small))
+	check(ks.Set(ctx, "large", large))
+
+	// Small values are stored as is, large ones compressed.
+	if got := must(srv.Get("small")); got != `{"Data":"small"}` {
+		t.Errorf("raw small value = %q, want it uncompressed", got)
+	}
+	if got := must(srv.Get("large")); !strings.HasPrefix(got, gzipMagic) || len(got) >= len(large.Data) {
+		t.Errorf("raw large value is %d bytes, want it compressed", len(got))
+	}
+
+	if got := must(ks.Get(ctx, "small")); got != small {
+		t.Errorf("Get(small) = %+v, want %+v", got, small)
+	}
+	if got := must(ks.Get(ctx, "large")); got != large {
+		t.Errorf("Get(large) returned %d bytes of data, want %d", len(got.Data), len(large.Data))
+	}
+
+	// Compressed values are decompressed even with compression disabled.
+	uncompressed := NewStructKeyspace[string, compressTestValue](cluster, KeyspaceConfig{
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+	})
+	if got := must(uncompressed.Get(ctx, "large")); got != large {
+		t.Errorf("Get(large) without compression returned %d bytes of data, want %d", len(got.Data), len(large.Data))
+	}
+}
+
+func TestValueSizeLimit(t *testing.T) {
+	cluster, srv := newTestCluster(t)
+	cluster.name = "cluster"
+	ctx := context.Background()
+
+	rejecting := NewStringKeyspace[string](cluster, KeyspaceConfig{
+		KeyPattern:               "rejecting/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+		ValueSizeLimit:           &ValueSizeLimitConfig{MaxSize: 5},
+	})
+	check(rejecting.Set(ctx, "ok", "12345"))
+	if err := rejecting.Set(ctx, "big", "123456"); !errors.Is(err, ValueTooLarge) {
+		t.Errorf("Set: got err %v, want ValueTooLarge", err)
+	}
+	if srv.Exists("big") {
+		t.Error("oversized value was stored")
+	}
+
+	truncating := NewStringKeyspace[string](cluster, KeyspaceConfig{
+		KeyPattern:               "truncating/:key",
+		EncoreInternal_KeyMapper: func(s string) string { return s },
+		ValueSizeLimit:           &ValueSizeLimitConfig{MaxSize: 5, Truncate: true},
+	})
+	// "åäö" is six bytes; truncating must not split a character.
+	check(truncating.Set(ctx, "big", "åäö"))
+	if got := must(truncating.Get(ctx, "big")); got != "åä" {
+		t.Errorf("Get(big) = %q, want %q", got, "åä")
+	}
+
+	labels := func(keyspace, action string) []system.Label {
+		return []system.Label{
+			{Key: "cluster", Value: "cluster"},
+			{Key: "keyspace", Value: keyspace},
+			{Key: "action", Value: action},
+		}
+	}
+	got := cluster.mgr.oversizedValueMetrics()
+	want := []system.Sample{
+		{Name: metricOversizedValues, Labels: labels("rejecting/:key", "rejected"), Value: 1},
+		{Name: metricOversizedValues, Labels: labels("truncating/:key", "truncated"), Value: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("oversizedValueMetrics() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTruncateUTF8(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 3, "hel"},
+		{"åäö", 4, "åä"},
+		{"åäö", 3, "å"},
+		{"åäö", 1, ""},
+	}
+	for _, test := range tests {
+		if got := truncateUTF8(test.s, test.n); got != test.want {
+			t.Errorf("truncateUTF8(%q, %d) = %q, want %q", test.s, test.n, got, test.want)
+		}
+	}
+}
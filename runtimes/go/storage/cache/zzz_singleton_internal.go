@@ -3,8 +3,10 @@
 package cache
 
 import (
+	"encore.dev/appruntime/infrasdk/metrics/system"
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/jsonapi"
+	"encore.dev/appruntime/shared/preflight"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
@@ -21,4 +23,7 @@ var Singleton *Manager
 func init() {
 	Singleton = NewManager(appconf.Static, appconf.Runtime, reqtrack.Singleton, testsupport.Singleton, jsonapi.Default)
 	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	Singleton.registerPreflightChecks(preflight.Singleton)
+	system.RegisterCollector(Singleton.readMetrics)
+	system.RegisterCollector(Singleton.oversizedValueMetrics)
 }
@@ -3,15 +3,22 @@ package objects
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"iter"
+	"maps"
 	"net/url"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/exported/trace2"
 	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/pubsub"
+	"encore.dev/storage/objects/internal/encryption"
 	"encore.dev/storage/objects/internal/providers/noop"
 	"encore.dev/storage/objects/internal/types"
 )
@@ -30,6 +37,12 @@ type Bucket struct {
 
 	// publicBaseURL, if the bucket is public
 	publicBaseURL *url.URL
+
+	// events is the topic bucket events are published to, if any.
+	events *pubsub.Topic[*BucketEvent]
+
+	// enc wraps the data keys of objects, if the bucket uses client-side encryption.
+	enc encryption.KeyWrapper
 }
 
 // BucketConfig is the configuration for a Bucket.
@@ -42,6 +55,46 @@ type BucketConfig struct {
 	// If true, the bucket will store multiple versions of each object
 	// whenever it changes, as opposed to overwriting the old version.
 	Versioned bool
+
+	// Tags to apply to the provisioned bucket, such as {"data-residency": "eu"}.
+	// They must be constant and follow the same rules as pubsub.TopicConfig.Tags.
+	Tags map[string]string
+
+	// Regions restricts where the bucket's objects can be stored,
+	// such as []string{"eu-*"}. See pubsub.TopicConfig.Regions.
+	Regions []string
+
+	// Lifecycle configures when objects are moved to cold storage
+	// or deleted. The rules are applied by the cloud provider when
+	// the bucket is provisioned.
+	Lifecycle *Lifecycle
+
+	// Events is the topic to publish a BucketEvent to whenever an object
+	// in the bucket is created or deleted. The notifications are
+	// configured on the bucket when it is provisioned.
+	Events *pubsub.Topic[*BucketEvent]
+
+	// ClientSideEncryption encrypts objects before they are uploaded,
+	// using a new data key for each object that is in turn encrypted
+	// with the KMS key configured for the bucket.
+	//
+	// Objects are transparently decrypted when downloaded through the
+	// bucket, so signed URLs and public access are not supported.
+	ClientSideEncryption bool
+}
+
+// Lifecycle describes the lifecycle rules for objects in a bucket.
+// The ages are measured from the time an object was created.
+type Lifecycle struct {
+	// ExpireAfterDays deletes objects this many days after they were created.
+	// Zero means objects are never deleted.
+	ExpireAfterDays int
+
+	// ColdStorageAfterDays moves objects to a cheaper, infrequent access
+	// storage class this many days after they were created, such as
+	// Glacier Instant Retrieval on AWS and Coldline on GCP.
+	// Zero means objects are never moved.
+	ColdStorageAfterDays int
 }
 
 func newBucket(mgr *Manager, name string) *Bucket {
@@ -74,6 +127,15 @@ func newBucket(mgr *Manager, name string) *Bucket {
 				}
 			}
 
+			var enc encryption.KeyWrapper
+			if bkt.Encryption != nil {
+				var err error
+				enc, err = newKeyWrapper(mgr, bkt)
+				if err != nil {
+					mgr.rootLogger.Fatal().Msgf("invalid encryption config for bucket %s: %v", name, err)
+				}
+			}
+
 			return &Bucket{
 				mgr:             mgr,
 				runtimeCfg:      bkt,
@@ -81,6 +143,7 @@ func newBucket(mgr *Manager, name string) *Bucket {
 				name:            name,
 				baseCloudPrefix: bkt.KeyPrefix,
 				publicBaseURL:   publicBaseURL,
+				enc:             enc,
 			}
 		}
 
@@ -164,6 +227,10 @@ type Writer struct {
 	// Set if tracing
 	curr         reqtrack.Current
 	startEventID trace2.EventID
+
+	// Progress so far, guarded by mu
+	mu       sync.Mutex
+	progress UploadProgress
 }
 
 // Write writes data to the object being uploaded.
@@ -204,19 +271,41 @@ func (w *Writer) Close() error {
 		w.curr.Trace.BucketObjectUploadEnd(params)
 	}
 
+	if err == nil {
+		w.bkt.publishEvent(w.ctx, ObjectCreated, w.obj, w.bkt.mapAttrs(attrs))
+	}
 	return err
 }
 
 func (w *Writer) initUpload() types.Uploader {
 	if w.u == nil {
-		u, err := w.bkt.impl.Upload(types.UploadData{
+		if err := validateAttrs(w.opt.attrs.Metadata, w.opt.attrs.Tags); err != nil {
+			w.u = &errUploader{err: err}
+			return w.u
+		}
+
+		data := types.UploadData{
 			Ctx:    w.ctx,
 			Object: w.bkt.toCloudObject(w.obj),
 			Attrs:  w.opt.attrs,
 			Pre: types.Preconditions{
 				NotExists: w.opt.pre.NotExists,
 			},
-		})
+			PartSize: w.opt.partSize,
+		}
+		if w.curr.Trace != nil || w.opt.progress != nil {
+			data.OnPart = w.onPart
+		}
+
+		var (
+			u   types.Uploader
+			err error
+		)
+		if w.bkt.enc != nil {
+			u, err = w.bkt.encryptUpload(data)
+		} else {
+			u, err = w.bkt.impl.Upload(data)
+		}
 		if err != nil {
 			w.u = &errUploader{err: err}
 		} else {
@@ -227,6 +316,32 @@ func (w *Writer) initUpload() types.Uploader {
 	return w.u
 }
 
+// onPart records the upload of a part of the object.
+func (w *Writer) onPart(part types.UploadPart) {
+	if w.curr.Trace != nil {
+		w.curr.Trace.BucketObjectUploadPart(trace2.BucketObjectUploadPartParams{
+			StartID: w.startEventID,
+			EventParams: trace2.EventParams{
+				TraceID: w.curr.Req.TraceID,
+				SpanID:  w.curr.Req.SpanID,
+				Goid:    w.curr.Goctr,
+			},
+			PartNumber: uint32(part.Number),
+			Size:       uint64(part.Size),
+			Attempts:   uint32(part.Attempts),
+			Err:        part.Err,
+		})
+	}
+
+	if w.opt.progress != nil && part.Err == nil {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.progress.Parts++
+		w.progress.BytesUploaded += part.Size
+		w.opt.progress(w.progress)
+	}
+}
+
 type errUploader struct {
 	err error
 }
@@ -245,8 +360,27 @@ var _ types.Uploader = &errUploader{}
 // Any error is encountered is reported by the methods on *Reader.
 // To check if the operation failed, call (*Reader).Err.
 //
+// If the download is interrupted by a transient error, such as a dropped
+// connection, it's transparently resumed from where it left off.
+//
 // If the object does not exist, the error may be checked with errors.Is(err, ErrObjectNotFound).
 func (b *Bucket) Download(ctx context.Context, object string, options ...DownloadOption) *Reader {
+	return b.download(ctx, object, 0, -1, options)
+}
+
+// DownloadRange downloads length bytes of an object, starting at offset.
+// A negative length downloads the rest of the object.
+// Any error is encountered is reported by the methods on *Reader.
+// To check if the operation failed, call (*Reader).Err.
+//
+// Like Download, interrupted downloads are transparently resumed.
+//
+// If the object does not exist, the error may be checked with errors.Is(err, ErrObjectNotFound).
+func (b *Bucket) DownloadRange(ctx context.Context, object string, offset, length int64, options ...DownloadOption) *Reader {
+	return b.download(ctx, object, offset, length, options)
+}
+
+func (b *Bucket) download(ctx context.Context, object string, offset, length int64, options []DownloadOption) *Reader {
 	var opt downloadOptions
 	for _, o := range options {
 		o.applyDownload(&opt)
@@ -255,6 +389,10 @@ func (b *Bucket) Download(ctx context.Context, object string, options ...Downloa
 	var startEventID trace2.EventID
 	curr := b.mgr.rt.Current()
 	if curr.Req != nil && curr.Trace != nil {
+		var traceLength *uint64
+		if length >= 0 {
+			traceLength = ptrOrNil(uint64(length))
+		}
 		startEventID = curr.Trace.BucketObjectDownloadStart(trace2.BucketObjectDownloadStartParams{
 			EventParams: trace2.EventParams{
 				TraceID: curr.Req.TraceID,
@@ -264,24 +402,58 @@ func (b *Bucket) Download(ctx context.Context, object string, options ...Downloa
 			Bucket:  b.name,
 			Object:  object,
 			Version: ptrOrNil(opt.version),
-			Stack:   stack.Build(1),
+			Stack:   stack.Build(2),
+			Offset:  uint64(max(offset, 0)),
+			Length:  traceLength,
 		})
 	}
 
-	r, err := b.impl.Download(types.DownloadData{
-		Ctx:     ctx,
-		Object:  b.toCloudObject(object),
-		Version: opt.version,
-	})
-	return &Reader{r: r, err: err, curr: curr, startEventID: startEventID}
+	r := &Reader{
+		b:            b,
+		ctx:          ctx,
+		object:       b.toCloudObject(object),
+		version:      opt.version,
+		offset:       offset,
+		length:       length,
+		curr:         curr,
+		startEventID: startEventID,
+	}
+	switch {
+	case offset < 0:
+		r.err = fmt.Errorf("%w: negative download offset %d", ErrInvalidArgument, offset)
+	case length == 0:
+		r.r = io.NopCloser(strings.NewReader(""))
+	default:
+		r.r, r.err = r.open()
+	}
+	return r
 }
 
+const (
+	// maxDownloadResumes is the maximum number of consecutive attempts
+	// to resume an interrupted download without making progress.
+	maxDownloadResumes = 3
+
+	// downloadResumeBackoff is the delay before resuming a download,
+	// multiplied by the attempt number.
+	downloadResumeBackoff = 200 * time.Millisecond
+)
+
 // Reader is the reader for an object being downloaded from a bucket.
 type Reader struct {
 	err       error // any error encountered
 	r         types.Downloader
 	totalRead uint64
 
+	// Used to resume interrupted downloads.
+	b              *Bucket
+	ctx            context.Context
+	object         types.CloudObject
+	version, etag  string // the object being downloaded
+	offset, length int64  // the requested range; a negative length means the rest of the object
+	attempts       int    // consecutive resume attempts without progress
+	resumes        uint32 // number of times the download was resumed
+
 	// Set if traced
 	traceCompleted bool
 	curr           reqtrack.Current
@@ -299,10 +471,23 @@ func (r *Reader) Read(p []byte) (int, error) {
 		return 0, r.err
 	}
 
-	n, err := r.r.Read(p)
-	r.err = err
-	r.totalRead += uint64(n)
-	return n, err
+	for {
+		n, err := r.r.Read(p)
+		r.totalRead += uint64(n)
+		if n > 0 {
+			r.attempts = 0
+		}
+		if err != nil && r.canResume(err) {
+			if err = r.resume(); err == nil {
+				if n == 0 {
+					continue
+				}
+				return n, nil
+			}
+		}
+		r.err = err
+		return n, err
+	}
 }
 
 // Close closes the reader.
@@ -317,6 +502,92 @@ func (r *Reader) Close() error {
 	return r.err
 }
 
+// open starts downloading the remainder of the requested range.
+func (r *Reader) open() (types.Downloader, error) {
+	data := types.DownloadData{
+		Ctx:     r.ctx,
+		Object:  r.object,
+		Version: r.version,
+		Offset:  r.offset + int64(r.totalRead),
+	}
+	if r.length > 0 {
+		data.Length = r.length - int64(r.totalRead)
+	}
+	if r.version == "" {
+		data.IfMatch = r.etag
+	}
+
+	var (
+		dl  types.Downloader
+		err error
+	)
+	if r.b.enc != nil {
+		dl, err = r.b.decryptDownload(r.ctx, data)
+	} else {
+		dl, err = r.b.impl.Download(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Pin the object being downloaded, so that a resumed
+	// download doesn't mix the contents of different objects.
+	if obj, ok := dl.(types.DownloadedObject); ok && r.version == "" && r.etag == "" {
+		r.version, r.etag = obj.Version(), obj.ETag()
+	}
+	return dl, nil
+}
+
+// canResume reports whether the download can be resumed after err.
+func (r *Reader) canResume(err error) bool {
+	if r.b == nil || r.attempts >= maxDownloadResumes || !isTransientDownloadErr(err) {
+		return false
+	} else if r.length >= 0 && int64(r.totalRead) >= r.length {
+		return false
+	}
+	// Without knowing which object was being downloaded,
+	// we can't guarantee the resumed download continues the same object.
+	return r.version != "" || r.etag != ""
+}
+
+// resume reopens the download where it was interrupted.
+// It reports the error that caused it to give up, if any.
+func (r *Reader) resume() error {
+	_ = r.r.Close()
+	for {
+		r.attempts++
+		select {
+		case <-r.ctx.Done():
+			return r.ctx.Err()
+		case <-time.After(downloadResumeBackoff * time.Duration(r.attempts)):
+		}
+
+		dl, err := r.open()
+		if err == nil {
+			r.r = dl
+			r.resumes++
+			return nil
+		} else if r.attempts >= maxDownloadResumes || !isTransientDownloadErr(err) {
+			return err
+		}
+	}
+}
+
+// isTransientDownloadErr reports whether err may succeed if retried.
+func isTransientDownloadErr(err error) bool {
+	switch {
+	case errors.Is(err, io.EOF),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, types.ErrObjectNotExist),
+		errors.Is(err, types.ErrPreconditionFailed),
+		errors.Is(err, types.ErrInvalidArgument),
+		errors.Is(err, encryption.ErrCorrupted):
+		return false
+	}
+	return true
+}
+
 func (r *Reader) completeTrace() {
 	if r.traceCompleted {
 		return
@@ -331,8 +602,9 @@ func (r *Reader) completeTrace() {
 				SpanID:  r.curr.Req.SpanID,
 				Goid:    r.curr.Goctr,
 			},
-			Err:  r.err,
-			Size: r.totalRead,
+			Err:     r.err,
+			Size:    r.totalRead,
+			Resumes: r.resumes,
 		})
 	}
 }
@@ -371,18 +643,64 @@ type ObjectAttrs struct {
 
 	// The computed ETag of the object.
 	ETag string
+
+	// User-defined metadata of the object.
+	Metadata map[string]string
+
+	// Tags of the object. They're only reported by Attrs and UpdateAttrs.
+	Tags map[string]string
 }
 
 func (b *Bucket) mapAttrs(attrs *types.ObjectAttrs) *ObjectAttrs {
+	var meta map[string]string
+	for k, v := range attrs.Metadata {
+		if isReservedMetadataKey(k) {
+			continue
+		} else if meta == nil {
+			meta = make(map[string]string, len(attrs.Metadata))
+		}
+		meta[k] = v
+	}
+
 	return &ObjectAttrs{
 		Name:        b.fromCloudObject(attrs.Object),
 		Version:     attrs.Version,
 		ContentType: attrs.ContentType,
 		Size:        attrs.Size,
 		ETag:        attrs.ETag,
+		Metadata:    meta,
+		Tags:        attrs.Tags,
 	}
 }
 
+// maxObjectTags is the maximum number of tags on an object,
+// as limited by S3.
+const maxObjectTags = 10
+
+func isReservedMetadataKey(key string) bool {
+	return strings.HasPrefix(strings.ToLower(key), types.ReservedMetadataPrefix)
+}
+
+// validateAttrs validates user-defined metadata and tags.
+func validateAttrs(meta, tags map[string]string) error {
+	for k := range meta {
+		if k == "" {
+			return fmt.Errorf("%w: empty metadata key", ErrInvalidArgument)
+		} else if isReservedMetadataKey(k) {
+			return fmt.Errorf("%w: metadata key %q uses the reserved prefix %q", ErrInvalidArgument, k, types.ReservedMetadataPrefix)
+		}
+	}
+	if len(tags) > maxObjectTags {
+		return fmt.Errorf("%w: objects can have at most %d tags, got %d", ErrInvalidArgument, maxObjectTags, len(tags))
+	}
+	for k := range tags {
+		if k == "" {
+			return fmt.Errorf("%w: empty tag key", ErrInvalidArgument)
+		}
+	}
+	return nil
+}
+
 // ListEntry describes an objects during listing.
 type ListEntry struct {
 	// The name of the object.
@@ -394,9 +712,15 @@ type ListEntry struct {
 }
 
 func (b *Bucket) mapListEntry(entry *types.ListEntry) *ListEntry {
+	size := entry.Size
+	if b.enc != nil {
+		// Listing doesn't include object metadata,
+		// so assume all objects in the bucket are encrypted.
+		size = encryption.PlaintextSize(size)
+	}
 	return &ListEntry{
 		Name: b.fromCloudObject(entry.Object),
-		Size: entry.Size,
+		Size: size,
 		ETag: entry.ETag,
 	}
 }
@@ -404,11 +728,21 @@ func (b *Bucket) mapListEntry(entry *types.ListEntry) *ListEntry {
 type SignedUploadURL struct {
 	// The signed URL
 	URL string
+
+	// Expires is the time at which the URL expires.
+	Expires time.Time
+
+	// ContentType is the content type the upload is restricted to, if any.
+	// It must be sent as the Content-Type header when uploading.
+	ContentType string
 }
 
 type SignedDownloadURL struct {
 	// The signed URL
 	URL string
+
+	// Expires is the time at which the URL expires.
+	Expires time.Time
 }
 
 // List lists objects in the bucket.
@@ -509,6 +843,9 @@ func (b *Bucket) Remove(ctx context.Context, object string, options ...RemoveOpt
 		Version: opts.version,
 	})
 
+	if removeErr == nil {
+		b.publishEvent(ctx, ObjectDeleted, object, &ObjectAttrs{Name: object, Version: opts.version})
+	}
 	return removeErr
 }
 
@@ -579,18 +916,118 @@ func (b *Bucket) Attrs(ctx context.Context, object string, options ...AttrsOptio
 		Ctx:     ctx,
 		Object:  b.toCloudObject(object),
 		Version: opt.version,
+		Tags:    true,
 	})
 	if attrsErr != nil {
 		return nil, attrsErr
 	}
+	attrs = decryptedAttrs(attrs)
 
 	return b.mapAttrs(attrs), nil
 }
 
+// ObjectAttrsToUpdate describes the attributes of an object to update using UpdateAttrs.
+type ObjectAttrsToUpdate struct {
+	// ContentType, if non-empty, sets the content type of the object.
+	ContentType string
+
+	// Metadata, if non-nil, replaces the user-defined metadata of the object.
+	// Use an empty map to remove all metadata.
+	Metadata map[string]string
+
+	// Tags, if non-nil, replaces the tags of the object.
+	// Use an empty map to remove all tags.
+	Tags map[string]string
+}
+
+// UpdateAttrs updates the attributes of an object in the bucket,
+// without uploading its contents again, and returns its new attributes.
+// If the object does not exist, it returns ErrObjectNotFound.
+//
+// Updating the content type or metadata, and on GCP the tags, rewrites
+// the object in the cloud provider. This creates a new version of the
+// object if the bucket is versioned, and emits an ObjectCreated event.
+func (b *Bucket) UpdateAttrs(ctx context.Context, object string, update ObjectAttrsToUpdate) (*ObjectAttrs, error) {
+	var (
+		attrs     *types.ObjectAttrs
+		updateErr error
+	)
+
+	curr := b.mgr.rt.Current()
+	if curr.Req != nil && curr.Trace != nil {
+		startEventID := curr.Trace.BucketObjectUpdateAttrsStart(trace2.BucketObjectUpdateAttrsStartParams{
+			EventParams: trace2.EventParams{
+				TraceID: curr.Req.TraceID,
+				SpanID:  curr.Req.SpanID,
+				Goid:    curr.Goctr,
+			},
+			Bucket:       b.name,
+			Object:       object,
+			ContentType:  ptrOrNil(update.ContentType),
+			MetadataKeys: sortedKeys(update.Metadata),
+			TagKeys:      sortedKeys(update.Tags),
+			Stack:        stack.Build(1),
+		})
+
+		defer func() {
+			params := trace2.BucketObjectUpdateAttrsEndParams{
+				StartID: startEventID,
+				EventParams: trace2.EventParams{
+					TraceID: curr.Req.TraceID,
+					SpanID:  curr.Req.SpanID,
+					Goid:    curr.Goctr,
+				},
+				Err: updateErr,
+			}
+			if attrs != nil {
+				size := uint64(attrs.Size)
+				params.Attrs = &trace2.BucketObjectAttributes{
+					Size:        &size,
+					Version:     ptrOrNil(attrs.Version),
+					ETag:        ptrOrNil(attrs.ETag),
+					ContentType: ptrOrNil(attrs.ContentType),
+				}
+			}
+			curr.Trace.BucketObjectUpdateAttrsEnd(params)
+		}()
+	}
+
+	if updateErr = validateAttrs(update.Metadata, update.Tags); updateErr != nil {
+		return nil, updateErr
+	}
+	attrs, updateErr = b.impl.UpdateAttrs(types.UpdateAttrsData{
+		Ctx:         ctx,
+		Object:      b.toCloudObject(object),
+		ContentType: update.ContentType,
+		Metadata:    update.Metadata,
+		Tags:        update.Tags,
+	})
+	if updateErr != nil {
+		return nil, updateErr
+	}
+	attrs = decryptedAttrs(attrs)
+
+	// Local development uses the GCS emulator, which rewrites the object.
+	res := b.mapAttrs(attrs)
+	b.publishEvent(ctx, ObjectCreated, object, res)
+	return res, nil
+}
+
+// sortedKeys returns the sorted keys of m, or nil if m is nil.
+func sortedKeys(m map[string]string) []string {
+	if m == nil {
+		return nil
+	}
+	keys := slices.AppendSeq(make([]string, 0, len(m)), maps.Keys(m))
+	slices.Sort(keys)
+	return keys
+}
+
 // Generates an external URL to allow uploading an object to the bucket.
 //
 // Anyone with possession of the URL can write to the given object name
-// without any additional auth.
+// without any additional auth. Use WithUploadAttrs to restrict the
+// content type of the upload.
 func (b *Bucket) SignedUploadURL(ctx context.Context, object string, options ...UploadURLOption) (*SignedUploadURL, error) {
 	var opt uploadURLOptions
 	for _, o := range options {
@@ -602,15 +1039,26 @@ func (b *Bucket) SignedUploadURL(ctx context.Context, object string, options ...
 	if opt.TTL > 7*24*time.Hour {
 		return nil, types.ErrInvalidArgument
 	}
-	url, err := b.impl.SignedUploadURL(types.UploadURLData{
-		Ctx:    ctx,
-		Object: b.toCloudObject(object),
-		TTL:    opt.TTL,
-	})
+	expires := time.Now().Add(opt.TTL)
+	var (
+		url string
+		err error
+	)
+	if b.enc != nil {
+		err = errSignedURLEncrypted
+	} else {
+		url, err = b.impl.SignedUploadURL(types.UploadURLData{
+			Ctx:         ctx,
+			Object:      b.toCloudObject(object),
+			TTL:         opt.TTL,
+			ContentType: opt.ContentType,
+		})
+	}
+	b.traceSignedURL(trace2.BucketSignedUpload, object, opt.TTL, opt.ContentType, err)
 	if err != nil {
 		return nil, err
 	}
-	return &SignedUploadURL{URL: url}, nil
+	return &SignedUploadURL{URL: url, Expires: expires, ContentType: opt.ContentType}, nil
 }
 
 // Generates an external URL to allow downloading an object from the bucket.
@@ -628,15 +1076,47 @@ func (b *Bucket) SignedDownloadURL(ctx context.Context, object string, options .
 	if opt.TTL > 7*24*time.Hour {
 		return nil, types.ErrInvalidArgument
 	}
-	url, err := b.impl.SignedDownloadURL(types.DownloadURLData{
-		Ctx:    ctx,
-		Object: b.toCloudObject(object),
-		TTL:    opt.TTL,
-	})
+	expires := time.Now().Add(opt.TTL)
+	var (
+		url string
+		err error
+	)
+	if b.enc != nil {
+		err = errSignedURLEncrypted
+	} else {
+		url, err = b.impl.SignedDownloadURL(types.DownloadURLData{
+			Ctx:    ctx,
+			Object: b.toCloudObject(object),
+			TTL:    opt.TTL,
+		})
+	}
+	b.traceSignedURL(trace2.BucketSignedDownload, object, opt.TTL, "", err)
 	if err != nil {
 		return nil, err
 	}
-	return &SignedDownloadURL{URL: url}, nil
+	return &SignedDownloadURL{URL: url, Expires: expires}, nil
+}
+
+// traceSignedURL records the issuance of a signed URL in the current trace, if any.
+func (b *Bucket) traceSignedURL(op trace2.BucketSignedURLOperation, object string, ttl time.Duration, contentType string, err error) {
+	curr := b.mgr.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+	curr.Trace.BucketSignedURL(trace2.BucketSignedURLParams{
+		EventParams: trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+		},
+		Bucket:      b.name,
+		Object:      object,
+		Operation:   op,
+		TTL:         ttl,
+		ContentType: ptrOrNil(contentType),
+		Err:         err,
+		Stack:       stack.Build(2),
+	})
 }
 
 // Exists reports whether an object exists in the bucket.
@@ -701,6 +1181,101 @@ func (b *Bucket) Exists(ctx context.Context, object string, options ...ExistsOpt
 	return true, nil
 }
 
+// Copy copies the object src to dst within the bucket, overwriting dst if it exists.
+// The copy is performed by the storage provider without transferring the
+// object's contents through the application. Use WithVersion to copy
+// a specific version of src.
+//
+// If src does not exist, it returns ErrObjectNotFound.
+func (b *Bucket) Copy(ctx context.Context, src, dst string, options ...CopyOption) (*ObjectAttrs, error) {
+	var opt copyOptions
+	for _, o := range options {
+		o.applyCopy(&opt)
+	}
+	return b.copy(ctx, src, dst, opt)
+}
+
+// Move moves the object src to dst within the bucket, by copying it
+// and then removing src.
+//
+// The operation is not atomic: if removing src fails, the error is returned
+// together with the attributes of dst, which has already been written.
+func (b *Bucket) Move(ctx context.Context, src, dst string, options ...CopyOption) (*ObjectAttrs, error) {
+	var opt copyOptions
+	for _, o := range options {
+		o.applyCopy(&opt)
+	}
+
+	attrs, err := b.copy(ctx, src, dst, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var removeOpts []RemoveOption
+	if opt.version != "" {
+		removeOpts = append(removeOpts, WithVersion(opt.version))
+	}
+	if err := b.Remove(ctx, src, removeOpts...); err != nil {
+		return attrs, err
+	}
+	return attrs, nil
+}
+
+func (b *Bucket) copy(ctx context.Context, src, dst string, opt copyOptions) (*ObjectAttrs, error) {
+	var (
+		attrs   *types.ObjectAttrs
+		copyErr error
+	)
+
+	curr := b.mgr.rt.Current()
+	if curr.Req != nil && curr.Trace != nil {
+		startEventID := curr.Trace.BucketObjectCopyStart(trace2.BucketObjectCopyStartParams{
+			EventParams: trace2.EventParams{
+				TraceID: curr.Req.TraceID,
+				SpanID:  curr.Req.SpanID,
+				Goid:    curr.Goctr,
+			},
+			Bucket:     b.name,
+			Src:        src,
+			Dst:        dst,
+			SrcVersion: ptrOrNil(opt.version),
+			Stack:      stack.Build(2),
+		})
+
+		defer func() {
+			params := trace2.BucketObjectCopyEndParams{
+				StartID: startEventID,
+				EventParams: trace2.EventParams{
+					TraceID: curr.Req.TraceID,
+					SpanID:  curr.Req.SpanID,
+					Goid:    curr.Goctr,
+				},
+				Err: copyErr,
+			}
+			if attrs != nil {
+				params.Size = uint64(attrs.Size)
+				params.Version = ptrOrNil(attrs.Version)
+			}
+			curr.Trace.BucketObjectCopyEnd(params)
+		}()
+	}
+
+	attrs, copyErr = b.impl.Copy(types.CopyData{
+		Ctx:        ctx,
+		Src:        b.toCloudObject(src),
+		Dst:        b.toCloudObject(dst),
+		SrcVersion: opt.version,
+	})
+	if copyErr != nil {
+		return nil, copyErr
+	}
+	attrs = decryptedAttrs(attrs)
+
+	dstAttrs := b.mapAttrs(attrs)
+	b.publishEvent(ctx, ObjectCreated, dst, dstAttrs)
+	return dstAttrs, nil
+}
+
 func (b *Bucket) toCloudObject(object string) types.CloudObject {
 	return types.CloudObject(b.cloudPrefix() + object)
 }
@@ -0,0 +1,196 @@
+package objects
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/storage/objects/internal/providers/noop"
+	"encore.dev/storage/objects/internal/types"
+)
+
+// flakyBucket serves a single object, failing each download
+// after failAfter bytes have been read.
+type flakyBucket struct {
+	noop.BucketImpl
+	content   []byte
+	version   string
+	failAfter int
+
+	downloads []types.DownloadData
+}
+
+func (f *flakyBucket) Download(data types.DownloadData) (types.Downloader, error) {
+	f.downloads = append(f.downloads, data)
+	if data.Version != "" && data.Version != f.version {
+		return nil, types.ErrPreconditionFailed
+	}
+
+	end := int64(len(f.content))
+	if data.Length > 0 {
+		end = min(end, data.Offset+data.Length)
+	}
+	return &flakyDownloader{
+		r:       bytes.NewReader(f.content[data.Offset:end]),
+		left:    f.failAfter,
+		version: f.version,
+	}, nil
+}
+
+type flakyDownloader struct {
+	r       *bytes.Reader
+	left    int
+	version string
+}
+
+var errConnReset = errors.New("connection reset by peer")
+
+func (d *flakyDownloader) Read(p []byte) (int, error) {
+	if d.left <= 0 {
+		return 0, errConnReset
+	}
+	n, err := d.r.Read(p[:min(len(p), d.left)])
+	d.left -= n
+	return n, err
+}
+
+func (d *flakyDownloader) Close() error    { return nil }
+func (d *flakyDownloader) Version() string { return d.version }
+func (d *flakyDownloader) ETag() string    { return "" }
+
+func newTestBucket(impl types.BucketImpl) *Bucket {
+	return &Bucket{
+		mgr:  &Manager{static: &config.Static{}, rt: reqtrack.New(zerolog.Nop(), nil, nil)},
+		impl: impl,
+		name: "test",
+	}
+}
+
+func TestReader_Resume(t *testing.T) {
+	content := []byte("the quick brown fox jumps over the lazy dog")
+	tests := []struct {
+		name           string
+		offset, length int64
+		want           string
+	}{
+		{name: "full", offset: 0, length: -1, want: string(content)},
+		{name: "range", offset: 4, length: 15, want: "quick brown fox"},
+		{name: "tail", offset: 35, length: -1, want: "lazy dog"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := &flakyBucket{content: content, version: "v1", failAfter: 10}
+			r := newTestBucket(impl).DownloadRange(context.Background(), "obj", test.offset, test.length)
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			} else if string(got) != test.want {
+				t.Fatalf("got %q, want %q", got, test.want)
+			}
+			if want := (len(test.want) - 1) / impl.failAfter; int(r.resumes) != want {
+				t.Errorf("got %d resumes, want %d", r.resumes, want)
+			}
+			for _, d := range impl.downloads[1:] {
+				if d.Version != "v1" {
+					t.Errorf("resumed download of version %q, want v1", d.Version)
+				}
+			}
+		})
+	}
+}
+
+func TestReader_ResumeGivesUp(t *testing.T) {
+	impl := &flakyBucket{content: []byte("hello world"), version: "v1", failAfter: 0}
+	r := newTestBucket(impl).Download(context.Background(), "obj")
+	if _, err := io.ReadAll(r); !errors.Is(err, errConnReset) {
+		t.Fatalf("got err %v, want %v", err, errConnReset)
+	}
+	if got, want := len(impl.downloads), 1+maxDownloadResumes; got != want {
+		t.Errorf("got %d downloads, want %d", got, want)
+	}
+}
+
+func TestDownloadRange_Empty(t *testing.T) {
+	impl := &flakyBucket{content: []byte("hello world"), version: "v1", failAfter: 100}
+	r := newTestBucket(impl).DownloadRange(context.Background(), "obj", 3, 0)
+	got, err := io.ReadAll(r)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("got %q, %v; want empty", got, err)
+	}
+	if len(impl.downloads) != 0 {
+		t.Errorf("got %d downloads, want none", len(impl.downloads))
+	}
+}
+
+// attrsBucket serves the attributes of objects, including
+// reserved metadata such as encryption parameters.
+type attrsBucket struct {
+	noop.BucketImpl
+	updates []types.UpdateAttrsData
+}
+
+func (a *attrsBucket) UpdateAttrs(data types.UpdateAttrsData) (*types.ObjectAttrs, error) {
+	a.updates = append(a.updates, data)
+	meta := maps.Clone(data.Metadata)
+	if meta == nil {
+		meta = make(map[string]string)
+	}
+	meta[encMetaScheme] = encSchemeV1
+	return &types.ObjectAttrs{
+		Object:      data.Object,
+		ContentType: data.ContentType,
+		Metadata:    meta,
+		Tags:        data.Tags,
+	}, nil
+}
+
+func TestBucket_UpdateAttrs(t *testing.T) {
+	impl := &attrsBucket{}
+	bkt := newTestBucket(impl)
+	ctx := context.Background()
+
+	attrs, err := bkt.UpdateAttrs(ctx, "obj", ObjectAttrsToUpdate{
+		ContentType: "text/plain",
+		Metadata:    map[string]string{"author": "alice"},
+		Tags:        map[string]string{"customer": "acme"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ObjectAttrs{
+		Name:        "obj",
+		ContentType: "text/plain",
+		Metadata:    map[string]string{"author": "alice"},
+		Tags:        map[string]string{"customer": "acme"},
+	}
+	if diff := cmp.Diff(want, attrs); diff != "" {
+		t.Errorf("attrs mismatch (-want +got):\n%s", diff)
+	}
+
+	tooManyTags := make(map[string]string)
+	for i := range maxObjectTags + 1 {
+		tooManyTags[strconv.Itoa(i)] = "x"
+	}
+	for _, update := range []ObjectAttrsToUpdate{
+		{Metadata: map[string]string{"Encore-Encryption": "none"}},
+		{Metadata: map[string]string{"": "x"}},
+		{Tags: map[string]string{"": "x"}},
+		{Tags: tooManyTags},
+	} {
+		if _, err := bkt.UpdateAttrs(ctx, "obj", update); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("UpdateAttrs(%v): got err %v, want ErrInvalidArgument", update, err)
+		}
+	}
+	if len(impl.updates) != 1 {
+		t.Errorf("got %d updates, want 1", len(impl.updates))
+	}
+}
@@ -0,0 +1,199 @@
+package objects
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/objects/internal/encryption"
+	"encore.dev/storage/objects/internal/types"
+)
+
+// Metadata keys used to store the encryption parameters of an object.
+// They're lowercase since S3 lowercases metadata keys.
+const (
+	encMetaScheme = "encore-encryption"
+	encMetaKey    = "encore-encryption-key"
+	encMetaNonce  = "encore-encryption-nonce"
+	encMetaKMSKey = "encore-encryption-kms-key"
+
+	encSchemeV1 = "aes256gcm-chunked-v1"
+)
+
+// errSignedURLEncrypted is returned when requesting a signed URL for a bucket
+// using client-side encryption, since the contents are only readable through the runtime.
+var errSignedURLEncrypted = fmt.Errorf("%w: signed URLs are not supported for buckets with client-side encryption", types.ErrInvalidArgument)
+
+// newKeyWrapper returns the key wrapper for a bucket using client-side encryption.
+func newKeyWrapper(mgr *Manager, bkt *config.Bucket) (encryption.KeyWrapper, error) {
+	if bkt.Encryption.KMSKey == "" {
+		if mgr.runtime.EnvCloud != "local" {
+			return nil, errors.New("client-side encryption requires a KMS key outside of local development")
+		}
+		return encryption.NewLocalKeyWrapper(bkt.CloudName), nil
+	}
+	return encryption.NewKeyWrapper(bkt.Encryption.KMSKey)
+}
+
+// encryptUpload starts an upload that encrypts the object with a new data key.
+func (b *Bucket) encryptUpload(data types.UploadData) (types.Uploader, error) {
+	key, nonce, err := encryption.NewDataKey()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := b.enc.WrapKey(data.Ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("objects: wrap data key: %w", err)
+	}
+
+	meta := make(map[string]string, len(data.Attrs.Metadata)+4)
+	maps.Copy(meta, data.Attrs.Metadata)
+	meta[encMetaScheme] = encSchemeV1
+	meta[encMetaKey] = base64.StdEncoding.EncodeToString(wrapped)
+	meta[encMetaNonce] = base64.StdEncoding.EncodeToString(nonce)
+	meta[encMetaKMSKey] = b.enc.KeyID()
+	data.Attrs.Metadata = meta
+
+	u, err := b.impl.Upload(data)
+	if err != nil {
+		return nil, err
+	}
+	w, err := encryption.NewWriter(u, key, nonce)
+	if err != nil {
+		u.Abort(err)
+		return nil, err
+	}
+	return &encryptingUploader{u: u, w: w}, nil
+}
+
+// encryptingUploader encrypts the data written to it before uploading it.
+type encryptingUploader struct {
+	u    types.Uploader
+	w    *encryption.Writer
+	size int64 // plaintext bytes written
+}
+
+func (e *encryptingUploader) Write(p []byte) (int, error) {
+	n, err := e.w.Write(p)
+	e.size += int64(n)
+	return n, err
+}
+
+func (e *encryptingUploader) Abort(err error) {
+	e.u.Abort(err)
+}
+
+func (e *encryptingUploader) Complete() (*types.ObjectAttrs, error) {
+	if err := e.w.Close(); err != nil {
+		e.u.Abort(err)
+		return nil, err
+	}
+	attrs, err := e.u.Complete()
+	if err != nil {
+		return nil, err
+	}
+	attrs.Size = e.size
+	return attrs, nil
+}
+
+var _ types.Uploader = &encryptingUploader{}
+
+// decryptDownload downloads and decrypts an object.
+// Objects that were not encrypted, such as ones uploaded before
+// client-side encryption was enabled, are downloaded as-is.
+func (b *Bucket) decryptDownload(ctx context.Context, data types.DownloadData) (types.Downloader, error) {
+	attrs, err := b.impl.Attrs(types.AttrsData{Ctx: ctx, Object: data.Object, Version: data.Version})
+	if err != nil {
+		return nil, err
+	}
+	if attrs.Metadata[encMetaScheme] == "" {
+		return b.impl.Download(data)
+	}
+
+	key, nonce, err := b.dataKey(ctx, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Download the version the data key belongs to,
+	// in case the object is concurrently overwritten.
+	if attrs.Version != "" {
+		data.Version = attrs.Version
+	} else {
+		data.IfMatch = attrs.ETag
+	}
+
+	// Ranges are decrypted from the start of the chunk containing the offset.
+	// The rest of the object is downloaded, since the last chunk is authenticated
+	// as such, and the plaintext is then limited to the requested length.
+	chunk, cipherOffset, skip := encryption.ChunkAt(data.Offset)
+	length := data.Length
+	data.Offset, data.Length = cipherOffset, 0
+
+	r, err := b.impl.Download(data)
+	if err != nil {
+		return nil, err
+	}
+	dr, err := encryption.NewReaderAt(r, key, nonce, chunk)
+	if err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+	if _, err := io.CopyN(io.Discard, dr, skip); err != nil && err != io.EOF {
+		_ = r.Close()
+		return nil, err
+	}
+
+	var plain io.Reader = dr
+	if length > 0 {
+		plain = io.LimitReader(dr, length)
+	}
+	return &decryptingDownloader{Reader: plain, c: r, version: attrs.Version, etag: attrs.ETag}, nil
+}
+
+// dataKey unwraps the data key of an encrypted object.
+func (b *Bucket) dataKey(ctx context.Context, attrs *types.ObjectAttrs) (key, nonce []byte, err error) {
+	if scheme := attrs.Metadata[encMetaScheme]; scheme != encSchemeV1 {
+		return nil, nil, fmt.Errorf("objects: unsupported encryption scheme %q", scheme)
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(attrs.Metadata[encMetaKey])
+	if err != nil {
+		return nil, nil, fmt.Errorf("objects: invalid encrypted data key: %w", err)
+	}
+	nonce, err = base64.StdEncoding.DecodeString(attrs.Metadata[encMetaNonce])
+	if err != nil {
+		return nil, nil, fmt.Errorf("objects: invalid encryption nonce: %w", err)
+	}
+	key, err = b.enc.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		return nil, nil, fmt.Errorf("objects: unwrap data key: %w", err)
+	}
+	return key, nonce, nil
+}
+
+type decryptingDownloader struct {
+	io.Reader
+	c types.Downloader
+
+	version, etag string
+}
+
+func (d *decryptingDownloader) Close() error {
+	return d.c.Close()
+}
+
+func (d *decryptingDownloader) Version() string { return d.version }
+func (d *decryptingDownloader) ETag() string    { return d.etag }
+
+// decryptedAttrs updates the attributes of an encrypted object
+// to describe its plaintext content.
+func decryptedAttrs(attrs *types.ObjectAttrs) *types.ObjectAttrs {
+	if attrs != nil && attrs.Metadata[encMetaScheme] != "" {
+		attrs.Size = encryption.PlaintextSize(attrs.Size)
+	}
+	return attrs
+}
@@ -0,0 +1,160 @@
+package objects
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// EventType describes the kind of change to an object a BucketEvent reports.
+type EventType string
+
+const (
+	// ObjectCreated is reported when an object is uploaded, copied or otherwise written.
+	ObjectCreated EventType = "OBJECT_FINALIZE"
+
+	// ObjectDeleted is reported when an object is removed.
+	ObjectDeleted EventType = "OBJECT_DELETE"
+)
+
+// BucketEvent describes a change to an object in a bucket.
+//
+// Bucket events are published to the topic configured in BucketConfig.Events,
+// and can be processed by declaring a regular subscription to that topic:
+//
+//	var ImageEvents = pubsub.NewTopic[*objects.BucketEvent]("image-events", pubsub.TopicConfig{
+//		DeliveryGuarantee: pubsub.AtLeastOnce,
+//	})
+//
+//	var Images = objects.NewBucket("images", objects.BucketConfig{
+//		Events: ImageEvents,
+//	})
+//
+//	var _ = pubsub.NewSubscription(ImageEvents, "make-thumbnails", pubsub.SubscriptionConfig[*objects.BucketEvent]{
+//		Handler: MakeThumbnail,
+//	})
+//
+// When running in the cloud the events are delivered by the cloud provider's
+// own bucket notifications, so handlers should ignore event types they don't recognize.
+type BucketEvent struct {
+	// Type is the kind of change.
+	Type EventType `pubsub-attr:"eventType"`
+
+	// Bucket is the cloud name of the bucket.
+	Bucket string
+
+	// Object is the name of the object that changed.
+	Object string
+
+	// Version is the version of the object, if the bucket is versioned.
+	Version string
+
+	// Size is the size of the object, in bytes.
+	// It is zero for ObjectDeleted events.
+	Size int64
+
+	// ETag is the computed ETag of the object, if known.
+	ETag string
+}
+
+// UnmarshalJSON decodes a BucketEvent from either the format published by Encore,
+// an S3 event notification, or a GCS Pub/Sub notification. For GCS notifications
+// the event type is carried in the "eventType" message attribute.
+func (e *BucketEvent) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Records []s3EventRecord `json:"Records"`
+		Kind    string          `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	switch {
+	case len(probe.Records) > 0:
+		rec := probe.Records[0]
+		// S3 object keys are URL encoded in event notifications.
+		key, err := url.QueryUnescape(rec.S3.Object.Key)
+		if err != nil {
+			key = rec.S3.Object.Key
+		}
+		*e = BucketEvent{
+			Bucket:  rec.S3.Bucket.Name,
+			Object:  key,
+			Version: rec.S3.Object.VersionID,
+			Size:    rec.S3.Object.Size,
+			ETag:    rec.S3.Object.ETag,
+		}
+		if strings.HasPrefix(rec.EventName, "ObjectCreated:") {
+			e.Type = ObjectCreated
+		} else if strings.HasPrefix(rec.EventName, "ObjectRemoved:") {
+			e.Type = ObjectDeleted
+		}
+		return nil
+
+	case probe.Kind == "storage#object":
+		var obj struct {
+			Bucket     string `json:"bucket"`
+			Name       string `json:"name"`
+			Size       string `json:"size"`
+			ETag       string `json:"etag"`
+			Generation string `json:"generation"`
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		size, _ := strconv.ParseInt(obj.Size, 10, 64)
+		*e = BucketEvent{
+			Bucket:  obj.Bucket,
+			Object:  obj.Name,
+			Version: obj.Generation,
+			Size:    size,
+			ETag:    obj.ETag,
+		}
+		return nil
+	}
+
+	type plain BucketEvent
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+type s3EventRecord struct {
+	EventName string `json:"eventName"`
+	S3        struct {
+		Bucket struct {
+			Name string `json:"name"`
+		} `json:"bucket"`
+		Object struct {
+			Key       string `json:"key"`
+			Size      int64  `json:"size"`
+			ETag      string `json:"eTag"`
+			VersionID string `json:"versionId"`
+		} `json:"object"`
+	} `json:"s3"`
+}
+
+// publishEvent publishes ev to the bucket's events topic.
+//
+// Bucket notifications are only simulated when running locally;
+// in the cloud they are delivered by the provider.
+func (b *Bucket) publishEvent(ctx context.Context, typ EventType, object string, attrs *ObjectAttrs) {
+	if b.events == nil || b.mgr.runtime.EnvCloud != "local" {
+		return
+	}
+
+	ev := &BucketEvent{
+		Type:   typ,
+		Bucket: b.runtimeCfg.CloudName,
+		Object: object,
+	}
+	if attrs != nil {
+		ev.Version = attrs.Version
+		ev.Size = attrs.Size
+		ev.ETag = attrs.ETag
+	}
+
+	if _, err := b.events.Publish(ctx, ev); err != nil {
+		b.mgr.rootLogger.Err(err).Str("bucket", b.name).Str("object", object).Msg("unable to publish bucket event")
+	}
+}
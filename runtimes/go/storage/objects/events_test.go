@@ -0,0 +1,50 @@
+package objects
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBucketEvent_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want BucketEvent
+	}{
+		{
+			name: "encore",
+			data: `{"Type":"OBJECT_DELETE","Bucket":"images","Object":"a.png","Version":"","Size":0,"ETag":""}`,
+			want: BucketEvent{Type: ObjectDeleted, Bucket: "images", Object: "a.png"},
+		},
+		{
+			name: "s3",
+			data: `{"Records":[{"eventName":"ObjectCreated:Put","s3":{"bucket":{"name":"images"},
+				"object":{"key":"dir/my+photo%21.png","size":1024,"eTag":"abc","versionId":"v1"}}}]}`,
+			want: BucketEvent{Type: ObjectCreated, Bucket: "images", Object: "dir/my photo!.png", Version: "v1", Size: 1024, ETag: "abc"},
+		},
+		{
+			name: "s3_removed",
+			data: `{"Records":[{"eventName":"ObjectRemoved:Delete","s3":{"bucket":{"name":"images"},"object":{"key":"a.png"}}}]}`,
+			want: BucketEvent{Type: ObjectDeleted, Bucket: "images", Object: "a.png"},
+		},
+		{
+			name: "gcs",
+			data: `{"kind":"storage#object","bucket":"images","name":"a.png","size":"2048","etag":"CJ","generation":"17"}`,
+			want: BucketEvent{Bucket: "images", Object: "a.png", Version: "17", Size: 2048, ETag: "CJ"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got BucketEvent
+			if err := json.Unmarshal([]byte(test.data), &got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("unexpected event (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
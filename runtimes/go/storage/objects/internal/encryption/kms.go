@@ -0,0 +1,168 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2/google"
+)
+
+// KeyWrapper encrypts and decrypts data keys.
+type KeyWrapper interface {
+	// KeyID identifies the key used to wrap data keys.
+	KeyID() string
+	// WrapKey encrypts a data key.
+	WrapKey(ctx context.Context, key []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key encrypted by WrapKey.
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// NewKeyWrapper returns a KeyWrapper for the given KMS key, which is either
+// an AWS KMS key ARN ("arn:aws:kms:...") or a GCP Cloud KMS key resource name
+// ("projects/.../locations/.../keyRings/.../cryptoKeys/...").
+func NewKeyWrapper(kmsKey string) (KeyWrapper, error) {
+	switch {
+	case strings.HasPrefix(kmsKey, "arn:"):
+		// arn:aws:kms:<region>:<account>:key/<id>
+		parts := strings.SplitN(kmsKey, ":", 6)
+		if len(parts) != 6 || parts[2] != "kms" || parts[3] == "" {
+			return nil, fmt.Errorf("invalid AWS KMS key ARN %q", kmsKey)
+		}
+		if newAWSKMS == nil {
+			return nil, fmt.Errorf("AWS KMS key %q is not supported: the runtime was built without AWS support", kmsKey)
+		}
+		return newAWSKMS(kmsKey, parts[3]), nil
+	case strings.HasPrefix(kmsKey, "projects/"):
+		if !strings.Contains(kmsKey, "/cryptoKeys/") {
+			return nil, fmt.Errorf("invalid GCP Cloud KMS key name %q", kmsKey)
+		}
+		return &gcpKMS{keyName: kmsKey}, nil
+	default:
+		return nil, fmt.Errorf("unsupported KMS key %q: must be an AWS KMS key ARN or a GCP Cloud KMS key name", kmsKey)
+	}
+}
+
+// newAWSKMS returns a KeyWrapper for an AWS KMS key in the given region.
+// It's set by kms_aws.go, and is nil when built with the encore_no_aws build tag.
+var newAWSKMS func(keyARN, region string) KeyWrapper
+
+// NewLocalKeyWrapper returns a KeyWrapper for local development,
+// which wraps data keys with a key derived from the given seed.
+// It provides no security and must not be used in deployed environments.
+func NewLocalKeyWrapper(seed string) KeyWrapper {
+	key := sha256.Sum256([]byte("encore-local-object-encryption:" + seed))
+	return &localKey{key: key[:]}
+}
+
+type localKey struct {
+	key []byte
+}
+
+func (k *localKey) KeyID() string { return "local" }
+
+func (k *localKey) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	aead, err := newAEAD(k.key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, key, nil), nil
+}
+
+func (k *localKey) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	aead, err := newAEAD(k.key)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrCorrupted
+	}
+	nonce, sealed := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	key, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, ErrCorrupted
+	}
+	return key, nil
+}
+
+// gcpKMS wraps keys using the GCP Cloud KMS encrypt and decrypt APIs.
+type gcpKMS struct {
+	keyName string
+
+	once    sync.Once
+	client  *http.Client
+	initErr error
+}
+
+func (k *gcpKMS) KeyID() string { return k.keyName }
+
+func (k *gcpKMS) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	var resp struct {
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	err := k.call(ctx, "encrypt", map[string]any{"plaintext": key}, &resp)
+	return resp.Ciphertext, err
+}
+
+func (k *gcpKMS) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	var resp struct {
+		Plaintext []byte `json:"plaintext"`
+	}
+	err := k.call(ctx, "decrypt", map[string]any{"ciphertext": wrapped}, &resp)
+	return resp.Plaintext, err
+}
+
+func (k *gcpKMS) call(ctx context.Context, op string, params, resp any) error {
+	k.once.Do(func() {
+		k.client, k.initErr = google.DefaultClient(context.Background(), "https://www.googleapis.com/auth/cloudkms")
+	})
+	if k.initErr != nil {
+		return fmt.Errorf("gcp kms: load credentials: %w", k.initErr)
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	endpoint := "https://cloudkms.googleapis.com/v1/" + (&url.URL{Path: k.keyName}).EscapedPath() + ":" + op
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gcp kms: %s: %w", op, err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("gcp kms: %s: %w", op, err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(data, &apiErr)
+		if apiErr.Error.Message == "" {
+			return fmt.Errorf("gcp kms: %s: %s", op, httpResp.Status)
+		}
+		return fmt.Errorf("gcp kms: %s: %s", op, apiErr.Error.Message)
+	}
+	return json.Unmarshal(data, resp)
+}
@@ -0,0 +1,66 @@
+//go:build !encore_no_aws
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+func init() {
+	newAWSKMS = func(keyARN, region string) KeyWrapper {
+		return &awsKMS{keyARN: keyARN, region: region}
+	}
+}
+
+// awsKMS wraps keys using the AWS KMS Encrypt and Decrypt APIs.
+type awsKMS struct {
+	keyARN string
+	region string
+
+	once    sync.Once
+	client  *kms.Client
+	initErr error
+}
+
+func (k *awsKMS) KeyID() string { return k.keyARN }
+
+func (k *awsKMS) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	client, err := k.getClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Encrypt(ctx, &kms.EncryptInput{KeyId: &k.keyARN, Plaintext: key})
+	if err != nil {
+		return nil, fmt.Errorf("aws kms: encrypt: %w", err)
+	}
+	return resp.CiphertextBlob, nil
+}
+
+func (k *awsKMS) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	client, err := k.getClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Decrypt(ctx, &kms.DecryptInput{KeyId: &k.keyARN, CiphertextBlob: wrapped})
+	if err != nil {
+		return nil, fmt.Errorf("aws kms: decrypt: %w", err)
+	}
+	return resp.Plaintext, nil
+}
+
+func (k *awsKMS) getClient() (*kms.Client, error) {
+	k.once.Do(func() {
+		cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(k.region))
+		if err != nil {
+			k.initErr = fmt.Errorf("aws kms: load config: %w", err)
+			return
+		}
+		k.client = kms.NewFromConfig(cfg)
+	})
+	return k.client, k.initErr
+}
@@ -0,0 +1,262 @@
+// Package encryption implements client-side envelope encryption of objects.
+//
+// Each object is encrypted with its own randomly generated data key using
+// AES-256-GCM. The data key is in turn encrypted ("wrapped") by a KeyWrapper,
+// typically backed by a cloud KMS key, and stored in the object's metadata.
+//
+// The content is encrypted in fixed-size chunks so that objects can be
+// streamed without buffering them in memory. The nonce of each chunk is
+// derived from a random base nonce and the chunk's index, and the last
+// chunk is authenticated as such to detect truncation.
+package encryption
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// ChunkSize is the number of plaintext bytes encrypted per chunk.
+	ChunkSize = 64 * 1024
+
+	// KeySize is the size of data keys, in bytes.
+	KeySize = 32
+
+	// NonceSize is the size of the base nonce, in bytes.
+	NonceSize = 12
+
+	tagSize = 16
+)
+
+// Additional authenticated data for chunks, marking whether it's the last one.
+var (
+	aadChunk     = []byte{0}
+	aadLastChunk = []byte{1}
+)
+
+// ErrCorrupted is returned when encrypted content fails to decrypt.
+var ErrCorrupted = errors.New("encryption: object content is corrupted or was tampered with")
+
+// NewDataKey generates a new random data key and base nonce.
+func NewDataKey() (key, nonce []byte, err error) {
+	key = make([]byte, KeySize)
+	nonce = make([]byte, NonceSize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, fmt.Errorf("generate data key: %w", err)
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return key, nonce, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce computes the nonce for the chunk with the given index.
+func chunkNonce(dst, base []byte, index uint64) []byte {
+	dst = append(dst[:0], base...)
+	var ctr [8]byte
+	binary.BigEndian.PutUint64(ctr[:], index)
+	for i, b := range ctr {
+		dst[NonceSize-8+i] ^= b
+	}
+	return dst
+}
+
+// Writer encrypts the data written to it and writes the ciphertext to an underlying writer.
+// Close must be called to write the final chunk; it does not close the underlying writer.
+type Writer struct {
+	w     io.Writer
+	aead  cipher.AEAD
+	nonce []byte
+	index uint64
+
+	buf    []byte // buffered plaintext, at most ChunkSize bytes
+	sealed []byte // scratch buffer for ciphertext
+	err    error
+}
+
+// NewWriter returns a Writer that encrypts data with the given data key and base nonce.
+func NewWriter(w io.Writer, key, nonce []byte) (*Writer, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	} else if len(nonce) != NonceSize {
+		return nil, fmt.Errorf("encryption: invalid nonce size %d", len(nonce))
+	}
+	return &Writer{
+		w:     w,
+		aead:  aead,
+		nonce: nonce,
+		buf:   make([]byte, 0, ChunkSize),
+	}, nil
+}
+
+// Write encrypts p. Since the last chunk must be marked as such,
+// a full chunk is only written once more data follows it.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	written := 0
+	for len(p) > 0 {
+		if len(w.buf) == ChunkSize {
+			if err := w.seal(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(w.buf[len(w.buf):ChunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close encrypts and writes the final chunk.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.seal(true); err != nil {
+		return err
+	}
+	w.err = errors.New("encryption: write to closed writer")
+	return nil
+}
+
+func (w *Writer) seal(last bool) error {
+	aad := aadChunk
+	if last {
+		aad = aadLastChunk
+	}
+	var nonce [NonceSize]byte
+	w.sealed = w.aead.Seal(w.sealed[:0], chunkNonce(nonce[:0], w.nonce, w.index), w.buf, aad)
+	w.index++
+	w.buf = w.buf[:0]
+	if _, err := w.w.Write(w.sealed); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
+
+// Reader decrypts the content read from an underlying reader.
+type Reader struct {
+	r     *bufio.Reader
+	aead  cipher.AEAD
+	nonce []byte
+	index uint64
+
+	chunk []byte // ciphertext scratch buffer
+	plain []byte // decrypted plaintext not yet returned
+	done  bool   // whether the last chunk has been decrypted
+	err   error
+}
+
+// NewReader returns a Reader that decrypts content encrypted with the given data key and base nonce.
+func NewReader(r io.Reader, key, nonce []byte) (*Reader, error) {
+	return NewReaderAt(r, key, nonce, 0)
+}
+
+// NewReaderAt is like NewReader, but decrypts content starting at the given chunk,
+// such as from the ciphertext offset reported by ChunkAt.
+// The content must extend to the end of the encrypted object, since the last
+// chunk is authenticated as such.
+func NewReaderAt(r io.Reader, key, nonce []byte, chunk uint64) (*Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	} else if len(nonce) != NonceSize {
+		return nil, fmt.Errorf("encryption: invalid nonce size %d", len(nonce))
+	}
+	return &Reader{
+		r:     bufio.NewReaderSize(r, ChunkSize+tagSize),
+		aead:  aead,
+		nonce: nonce,
+		index: chunk,
+		chunk: make([]byte, ChunkSize+tagSize),
+	}, nil
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		} else if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.open()
+	}
+
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+// open reads and decrypts the next chunk.
+func (r *Reader) open() error {
+	n, err := io.ReadFull(r.r, r.chunk)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// A short chunk must be the last one.
+		r.done = true
+	} else if err != nil {
+		return err
+	} else if _, err := r.r.Peek(1); err == io.EOF {
+		r.done = true
+	} else if err != nil {
+		return err
+	}
+
+	aad := aadChunk
+	if r.done {
+		aad = aadLastChunk
+	}
+	var nonce [NonceSize]byte
+	plain, err := r.aead.Open(r.chunk[:0], chunkNonce(nonce[:0], r.nonce, r.index), r.chunk[:n], aad)
+	if err != nil {
+		return ErrCorrupted
+	}
+	r.index++
+	r.plain = plain
+	return nil
+}
+
+// ChunkAt reports the chunk containing the given plaintext offset,
+// the offset the chunk starts at in the encrypted content, and the
+// number of plaintext bytes in the chunk before the offset.
+func ChunkAt(offset int64) (chunk uint64, ciphertextOffset, skip int64) {
+	chunk = uint64(offset / ChunkSize)
+	return chunk, int64(chunk) * (ChunkSize + tagSize), offset % ChunkSize
+}
+
+// CiphertextSize reports the size of the encrypted content
+// for a plaintext of the given size.
+func CiphertextSize(plaintext int64) int64 {
+	chunks := plaintext/ChunkSize + 1
+	if plaintext > 0 && plaintext%ChunkSize == 0 {
+		chunks--
+	}
+	return plaintext + chunks*tagSize
+}
+
+// PlaintextSize reports the size of the plaintext
+// for encrypted content of the given size.
+func PlaintextSize(ciphertext int64) int64 {
+	const sealedChunk = ChunkSize + tagSize
+	chunks := (ciphertext + sealedChunk - 1) / sealedChunk
+	return max(ciphertext-chunks*tagSize, 0)
+}
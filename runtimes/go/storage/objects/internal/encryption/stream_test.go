@@ -0,0 +1,158 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	sizes := []int{0, 1, ChunkSize - 1, ChunkSize, ChunkSize + 1, 3*ChunkSize + 17}
+	for _, size := range sizes {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+		key, nonce, err := NewDataKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, key, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Write in uneven pieces to exercise the chunk buffering.
+		for p := plain; len(p) > 0; {
+			n := min(len(p), 1000)
+			if _, err := w.Write(p[:n]); err != nil {
+				t.Fatal(err)
+			}
+			p = p[n:]
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := int64(buf.Len()), CiphertextSize(int64(size)); got != want {
+			t.Errorf("size %d: got ciphertext size %d, want %d", size, got, want)
+		}
+		if got := PlaintextSize(int64(buf.Len())); got != int64(size) {
+			t.Errorf("size %d: got plaintext size %d", size, got)
+		}
+
+		r, err := NewReader(bytes.NewReader(buf.Bytes()), key, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("size %d: decrypt: %v", size, err)
+		}
+		if !bytes.Equal(got, plain) {
+			t.Errorf("size %d: decrypted content does not match", size)
+		}
+	}
+}
+
+func TestReaderAt(t *testing.T) {
+	plain := make([]byte, 3*ChunkSize+17)
+	_, _ = rand.Read(plain)
+	key, nonce, err := NewDataKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, key, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write(plain)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, offset := range []int64{0, 1, ChunkSize, 2*ChunkSize + 5, int64(len(plain))} {
+		chunk, cipherOffset, skip := ChunkAt(offset)
+		r, err := NewReaderAt(bytes.NewReader(buf.Bytes()[cipherOffset:]), key, nonce, chunk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("offset %d: decrypt: %v", offset, err)
+		}
+		if !bytes.Equal(got[skip:], plain[offset:]) {
+			t.Errorf("offset %d: decrypted content does not match", offset)
+		}
+	}
+}
+
+func TestReader_Corrupted(t *testing.T) {
+	key, nonce, _ := NewDataKey()
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, key, nonce)
+	_, _ = w.Write(make([]byte, 2*ChunkSize+10))
+	_ = w.Close()
+	ciphertext := buf.Bytes()
+
+	tests := map[string][]byte{
+		"truncated at chunk boundary": ciphertext[:ChunkSize+tagSize],
+		"truncated within chunk":      ciphertext[:len(ciphertext)-5],
+		"modified":                    append([]byte{ciphertext[0] ^ 1}, ciphertext[1:]...),
+		"empty":                       nil,
+	}
+	for name, data := range tests {
+		r, _ := NewReader(bytes.NewReader(data), key, nonce)
+		if _, err := io.ReadAll(r); !errors.Is(err, ErrCorrupted) {
+			t.Errorf("%s: got err %v, want ErrCorrupted", name, err)
+		}
+	}
+}
+
+func TestLocalKeyWrapper(t *testing.T) {
+	ctx := context.Background()
+	key, _, _ := NewDataKey()
+
+	kw := NewLocalKeyWrapper("bucket")
+	wrapped, err := kw.WrapKey(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewLocalKeyWrapper("bucket").UnwrapKey(ctx, wrapped)
+	if err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(got, key) {
+		t.Fatal("unwrapped key does not match")
+	}
+
+	if _, err := NewLocalKeyWrapper("other").UnwrapKey(ctx, wrapped); !errors.Is(err, ErrCorrupted) {
+		t.Fatalf("got err %v, want ErrCorrupted", err)
+	}
+}
+
+func TestNewKeyWrapper(t *testing.T) {
+	awsKey := "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	valid := []string{"projects/p/locations/global/keyRings/r/cryptoKeys/k"}
+	invalid := []string{"", "alias/foo", "arn:aws:s3:::bucket", "projects/p/locations/global/keyRings/r"}
+
+	// AWS KMS keys are only supported when built with AWS support.
+	if newAWSKMS != nil {
+		valid = append(valid, awsKey)
+	} else {
+		invalid = append(invalid, awsKey)
+	}
+
+	for _, k := range valid {
+		if _, err := NewKeyWrapper(k); err != nil {
+			t.Errorf("NewKeyWrapper(%q): %v", k, err)
+		}
+	}
+	for _, k := range invalid {
+		if _, err := NewKeyWrapper(k); err == nil {
+			t.Errorf("NewKeyWrapper(%q): expected error", k)
+		}
+	}
+}
@@ -66,10 +66,26 @@ func (b *bucket) Download(data types.DownloadData) (types.Downloader, error) {
 			obj = obj.Generation(gen)
 		}
 	}
-	r, err := obj.NewReader(data.Ctx)
-	return r, mapErr(err)
+	length := data.Length
+	if length == 0 {
+		length = -1 // the rest of the object
+	}
+	r, err := obj.NewRangeReader(data.Ctx, data.Offset, length)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+	return &downloader{r}, nil
 }
 
+// downloader reports the generation of the object being downloaded.
+// GCS always reports it, so resumed downloads never need IfMatch.
+type downloader struct {
+	*storage.Reader
+}
+
+func (d *downloader) Version() string { return strconv.FormatInt(d.Attrs.Generation, 10) }
+func (d *downloader) ETag() string    { return "" }
+
 func (b *bucket) Upload(data types.UploadData) (types.Uploader, error) {
 	ctx, cancel := context.WithCancelCause(data.Ctx)
 	obj := b.handle.Object(data.Object.String())
@@ -82,6 +98,21 @@ func (b *bucket) Upload(data types.UploadData) (types.Uploader, error) {
 
 	w := obj.NewWriter(ctx)
 	w.ContentType = data.Attrs.ContentType
+	w.Metadata = withTags(data.Attrs.Metadata, data.Attrs.Tags)
+	if data.PartSize > 0 {
+		w.ChunkSize = data.PartSize
+	}
+	if onPart := data.OnPart; onPart != nil {
+		// The writer uploads the object in chunks, retrying failed ones,
+		// and reports the total number of bytes uploaded after each.
+		var part int
+		var uploaded int64
+		w.ProgressFunc = func(total int64) {
+			part++
+			onPart(types.UploadPart{Number: part, Size: total - uploaded, Attempts: 1})
+			uploaded = total
+		}
+	}
 
 	u := &uploader{
 		cancel: cancel,
@@ -117,15 +148,55 @@ func mapAttrs(attrs *storage.ObjectAttrs) *types.ObjectAttrs {
 	if attrs == nil {
 		return nil
 	}
+	meta, tags := splitTags(attrs.Metadata)
 	return &types.ObjectAttrs{
 		Object:      types.CloudObject(attrs.Name),
 		Version:     strconv.FormatInt(attrs.Generation, 10),
 		ContentType: attrs.ContentType,
 		Size:        attrs.Size,
 		ETag:        attrs.Etag,
+		Metadata:    meta,
+		Tags:        tags,
 	}
 }
 
+// tagMetadataPrefix is the prefix of the metadata keys tags are stored as,
+// since GCS doesn't support object tags.
+const tagMetadataPrefix = types.ReservedMetadataPrefix + "tag-"
+
+// withTags returns the metadata to store for an object with the given tags.
+func withTags(meta, tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return meta
+	}
+	res := make(map[string]string, len(meta)+len(tags))
+	for k, v := range meta {
+		res[k] = v
+	}
+	for k, v := range tags {
+		res[tagMetadataPrefix+k] = v
+	}
+	return res
+}
+
+// splitTags splits the metadata of an object into its metadata and tags.
+func splitTags(meta map[string]string) (rest, tags map[string]string) {
+	for k, v := range meta {
+		if tag, ok := strings.CutPrefix(k, tagMetadataPrefix); ok {
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[tag] = v
+		} else {
+			if rest == nil {
+				rest = make(map[string]string, len(meta))
+			}
+			rest[k] = v
+		}
+	}
+	return rest, tags
+}
+
 func mapListEntry(attrs *storage.ObjectAttrs) *types.ListEntry {
 	return &types.ListEntry{
 		Object: types.CloudObject(attrs.Name),
@@ -190,11 +261,81 @@ func (b *bucket) Attrs(data types.AttrsData) (*types.ObjectAttrs, error) {
 	return mapAttrs(resp), mapErr(err)
 }
 
+// Copy copies an object within the bucket using the rewrite API,
+// without transferring the object's contents through the client.
+func (b *bucket) Copy(data types.CopyData) (*types.ObjectAttrs, error) {
+	src := b.handle.Object(data.Src.String())
+	if data.SrcVersion != "" {
+		if gen, err := strconv.ParseInt(data.SrcVersion, 10, 64); err == nil {
+			src = src.Generation(gen)
+		}
+	}
+
+	dst := b.handle.Object(data.Dst.String())
+	attrs, err := dst.CopierFrom(src).Run(data.Ctx)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+	return mapAttrs(attrs), nil
+}
+
+// UpdateAttrs updates the attributes of an object by rewriting it onto itself,
+// since patching an object's metadata can't remove individual keys.
+// The rewrite doesn't transfer the object's contents through the client.
+func (b *bucket) UpdateAttrs(data types.UpdateAttrsData) (*types.ObjectAttrs, error) {
+	obj := b.handle.Object(data.Object.String())
+	cur, err := obj.Attrs(data.Ctx)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	meta, tags := splitTags(cur.Metadata)
+	if data.Metadata != nil {
+		for k := range meta {
+			if !strings.HasPrefix(k, types.ReservedMetadataPrefix) {
+				delete(meta, k)
+			}
+		}
+		if meta == nil {
+			meta = make(map[string]string, len(data.Metadata))
+		}
+		for k, v := range data.Metadata {
+			meta[k] = v
+		}
+	}
+	if data.Tags != nil {
+		tags = data.Tags
+	}
+
+	// Rewrite the version we read, in case the object is concurrently overwritten.
+	src := obj.Generation(cur.Generation).If(storage.Conditions{MetagenerationMatch: cur.Metageneration})
+	c := obj.CopierFrom(src)
+	c.ContentType = cur.ContentType
+	if data.ContentType != "" {
+		c.ContentType = data.ContentType
+	}
+	c.ContentEncoding = cur.ContentEncoding
+	c.ContentDisposition = cur.ContentDisposition
+	c.ContentLanguage = cur.ContentLanguage
+	c.CacheControl = cur.CacheControl
+	c.Metadata = withTags(meta, tags)
+	if c.Metadata == nil {
+		c.Metadata = map[string]string{}
+	}
+
+	attrs, err := c.Run(data.Ctx)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+	return mapAttrs(attrs), nil
+}
+
 func (b *bucket) SignedUploadURL(data types.UploadURLData) (string, error) {
 	opts := &storage.SignedURLOptions{
-		Scheme:  storage.SigningSchemeV4,
-		Method:  "PUT",
-		Expires: time.Now().Add(data.TTL),
+		Scheme:      storage.SigningSchemeV4,
+		Method:      "PUT",
+		Expires:     time.Now().Add(data.TTL),
+		ContentType: data.ContentType,
 	}
 	return b.signedURL(data.Object.String(), opts)
 }
@@ -40,3 +40,11 @@ func (b *BucketImpl) SignedUploadURL(data types.UploadURLData) (string, error) {
 func (b *BucketImpl) SignedDownloadURL(data types.DownloadURLData) (string, error) {
 	return "", fmt.Errorf("cannot get download url from noop bucket")
 }
+
+func (b *BucketImpl) Copy(data types.CopyData) (*types.ObjectAttrs, error) {
+	return nil, fmt.Errorf("cannot copy within noop bucket")
+}
+
+func (b *BucketImpl) UpdateAttrs(data types.UpdateAttrsData) (*types.ObjectAttrs, error) {
+	return nil, fmt.Errorf("cannot update attributes of objects in noop bucket")
+}
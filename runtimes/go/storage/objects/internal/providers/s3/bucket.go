@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"iter"
+	"net/url"
+	"strings"
 	"sync"
 
 	"cloud.google.com/go/storage"
@@ -60,17 +63,34 @@ func (mgr *Manager) NewBucket(provider *config.BucketProvider, runtimeCfg *confi
 
 func (b *bucket) Download(data types.DownloadData) (types.Downloader, error) {
 	object := string(data.Object)
+	var byteRange string
+	if data.Length > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-%d", data.Offset, data.Offset+data.Length-1)
+	} else if data.Offset > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-", data.Offset)
+	}
 	resp, err := b.client.GetObject(data.Ctx, &s3.GetObjectInput{
 		Bucket:    &b.cfg.CloudName,
 		Key:       &object,
 		VersionId: ptrOrNil(data.Version),
+		Range:     ptrOrNil(byteRange),
+		IfMatch:   ptrOrNil(data.IfMatch),
 	})
 	if err != nil {
 		return nil, mapErr(err)
 	}
-	return resp.Body, nil
+	return &downloader{resp.Body, aws.ToString(resp.VersionId), aws.ToString(resp.ETag)}, nil
+}
+
+// downloader reports the version and ETag of the object being downloaded.
+type downloader struct {
+	io.ReadCloser
+	version, etag string
 }
 
+func (d *downloader) Version() string { return d.version }
+func (d *downloader) ETag() string    { return d.etag }
+
 func (b *bucket) Upload(data types.UploadData) (types.Uploader, error) {
 	return newUploader(b.client, b.cfg.CloudName, data), nil
 }
@@ -149,13 +169,147 @@ func (b *bucket) Attrs(data types.AttrsData) (*types.ObjectAttrs, error) {
 	if err != nil {
 		return nil, mapErr(err)
 	}
-	return &types.ObjectAttrs{
+	attrs := &types.ObjectAttrs{
 		Object:      data.Object,
 		Version:     valOrZero(resp.VersionId),
 		ContentType: valOrZero(resp.ContentType),
 		Size:        valOrZero(resp.ContentLength),
 		ETag:        valOrZero(resp.ETag),
-	}, nil
+		Metadata:    resp.Metadata,
+	}
+
+	if data.Tags {
+		tagResp, err := b.client.GetObjectTagging(data.Ctx, &s3.GetObjectTaggingInput{
+			Bucket:    &b.cfg.CloudName,
+			Key:       &object,
+			VersionId: resp.VersionId,
+		})
+		if err != nil {
+			return nil, mapErr(err)
+		}
+		for _, tag := range tagResp.TagSet {
+			if attrs.Tags == nil {
+				attrs.Tags = make(map[string]string, len(tagResp.TagSet))
+			}
+			attrs.Tags[valOrZero(tag.Key)] = valOrZero(tag.Value)
+		}
+	}
+	return attrs, nil
+}
+
+// UpdateAttrs updates the attributes of an object. The content type and
+// metadata are updated by copying the object onto itself, which supports
+// objects up to 5 GB in size, while tags are updated in place.
+func (b *bucket) UpdateAttrs(data types.UpdateAttrsData) (*types.ObjectAttrs, error) {
+	object := string(data.Object)
+	var version *string
+	if data.ContentType != "" || data.Metadata != nil {
+		cur, err := b.client.HeadObject(data.Ctx, &s3.HeadObjectInput{
+			Bucket: &b.cfg.CloudName,
+			Key:    &object,
+		})
+		if err != nil {
+			return nil, mapErr(err)
+		}
+
+		meta := cur.Metadata
+		if data.Metadata != nil {
+			meta = make(map[string]string, len(data.Metadata))
+			for k, v := range cur.Metadata {
+				if strings.HasPrefix(k, types.ReservedMetadataPrefix) {
+					meta[k] = v
+				}
+			}
+			for k, v := range data.Metadata {
+				meta[k] = v
+			}
+		}
+		contentType := cur.ContentType
+		if data.ContentType != "" {
+			contentType = &data.ContentType
+		}
+
+		source := (&url.URL{Path: b.cfg.CloudName + "/" + object}).EscapedPath()
+		if cur.VersionId != nil {
+			source += "?versionId=" + url.QueryEscape(*cur.VersionId)
+		}
+		resp, err := b.client.CopyObject(data.Ctx, &s3.CopyObjectInput{
+			Bucket:     &b.cfg.CloudName,
+			Key:        &object,
+			CopySource: &source,
+			// Copy the version we read, in case the object is concurrently overwritten.
+			CopySourceIfMatch:  cur.ETag,
+			MetadataDirective:  s3types.MetadataDirectiveReplace,
+			Metadata:           meta,
+			ContentType:        contentType,
+			ContentEncoding:    cur.ContentEncoding,
+			ContentDisposition: cur.ContentDisposition,
+			ContentLanguage:    cur.ContentLanguage,
+			CacheControl:       cur.CacheControl,
+		})
+		if err != nil {
+			return nil, mapErr(err)
+		}
+		version = resp.VersionId
+	}
+
+	if data.Tags != nil {
+		var err error
+		if len(data.Tags) == 0 {
+			_, err = b.client.DeleteObjectTagging(data.Ctx, &s3.DeleteObjectTaggingInput{
+				Bucket:    &b.cfg.CloudName,
+				Key:       &object,
+				VersionId: version,
+			})
+		} else {
+			tagSet := make([]s3types.Tag, 0, len(data.Tags))
+			for k, v := range data.Tags {
+				tagSet = append(tagSet, s3types.Tag{Key: ptr(k), Value: ptr(v)})
+			}
+			_, err = b.client.PutObjectTagging(data.Ctx, &s3.PutObjectTaggingInput{
+				Bucket:    &b.cfg.CloudName,
+				Key:       &object,
+				VersionId: version,
+				Tagging:   &s3types.Tagging{TagSet: tagSet},
+			})
+		}
+		if err != nil {
+			return nil, mapErr(err)
+		}
+	}
+
+	return b.Attrs(types.AttrsData{
+		Ctx:     data.Ctx,
+		Object:  data.Object,
+		Version: valOrZero(version),
+		Tags:    true,
+	})
+}
+
+// Copy copies an object within the bucket using CopyObject,
+// which supports objects up to 5 GB in size.
+func (b *bucket) Copy(data types.CopyData) (*types.ObjectAttrs, error) {
+	source := (&url.URL{Path: b.cfg.CloudName + "/" + string(data.Src)}).EscapedPath()
+	if data.SrcVersion != "" {
+		source += "?versionId=" + url.QueryEscape(data.SrcVersion)
+	}
+
+	dst := string(data.Dst)
+	resp, err := b.client.CopyObject(data.Ctx, &s3.CopyObjectInput{
+		Bucket:     &b.cfg.CloudName,
+		Key:        &dst,
+		CopySource: &source,
+	})
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	// CopyObject doesn't report the size or content type of the new object.
+	return b.Attrs(types.AttrsData{
+		Ctx:     data.Ctx,
+		Object:  data.Dst,
+		Version: valOrZero(resp.VersionId),
+	})
 }
 
 func (b *bucket) SignedUploadURL(data types.UploadURLData) (string, error) {
@@ -164,6 +318,9 @@ func (b *bucket) SignedUploadURL(data types.UploadURLData) (string, error) {
 		Bucket: &b.cfg.CloudName,
 		Key:    &object,
 	}
+	if data.ContentType != "" {
+		params.ContentType = &data.ContentType
+	}
 	sign_opts := func(opts *s3.PresignOptions) {
 		opts.Expires = data.TTL
 	}
@@ -261,6 +418,18 @@ func mapErr(err error) error {
 	}
 }
 
+// encodeTags encodes tags for the Tagging parameter of uploads.
+func encodeTags(tags map[string]string) *string {
+	if len(tags) == 0 {
+		return nil
+	}
+	vals := make(url.Values, len(tags))
+	for k, v := range tags {
+		vals.Set(k, v)
+	}
+	return ptr(vals.Encode())
+}
+
 func ptrOrNil[T comparable](val T) *T {
 	var zero T
 	if val != zero {
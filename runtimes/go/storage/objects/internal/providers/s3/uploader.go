@@ -6,11 +6,14 @@ import (
 	"crypto/md5"
 	"encoding/base64"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	"encore.dev/storage/objects/internal/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -56,7 +59,7 @@ func (u *uploader) Write(p []byte) (n int, err error) {
 	for len(p) > 0 {
 		curr := u.curr
 		if curr == nil {
-			curr = getBuf()
+			curr = u.getBuf()
 		}
 
 		copied := copy(curr.buf[curr.n:], p)
@@ -163,15 +166,22 @@ func (u *uploader) singlePartUpload(buf []byte) (*types.ObjectAttrs, error) {
 		ifNoneMatch = ptr("*")
 	}
 
+	if err := u.checkPartSize(); err != nil {
+		return nil, err
+	}
+
 	resp, err := u.client.PutObject(u.ctx, &s3.PutObjectInput{
 		Bucket:        &u.bucket,
 		Key:           key,
 		Body:          bytes.NewReader(buf),
 		ContentType:   ptrOrNil(u.data.Attrs.ContentType),
+		Metadata:      u.data.Attrs.Metadata,
+		Tagging:       encodeTags(u.data.Attrs.Tags),
 		ContentMD5:    &contentMD5,
 		ContentLength: ptr(int64(len(buf))),
 		IfNoneMatch:   ifNoneMatch,
 	})
+	u.reportPart(types.UploadPart{Number: 1, Size: int64(len(buf)), Attempts: 1, Err: err})
 	if err != nil {
 		return nil, err
 	}
@@ -180,17 +190,25 @@ func (u *uploader) singlePartUpload(buf []byte) (*types.ObjectAttrs, error) {
 		Object:      u.data.Object,
 		Version:     valOrZero(resp.VersionId),
 		ContentType: u.data.Attrs.ContentType,
+		Metadata:    u.data.Attrs.Metadata,
+		Tags:        u.data.Attrs.Tags,
 		Size:        int64(len(buf)),
 		ETag:        valOrZero(resp.ETag),
 	}, nil
 }
 
 func (u *uploader) multiPartUpload(initial *buffer) (attrs *types.ObjectAttrs, err error) {
+	if err := u.checkPartSize(); err != nil {
+		return nil, err
+	}
+
 	key := ptr(u.data.Object.String())
 	resp, err := u.client.CreateMultipartUpload(u.ctx, &s3.CreateMultipartUploadInput{
 		Bucket:      &u.bucket,
 		Key:         key,
 		ContentType: ptrOrNil(u.data.Attrs.ContentType),
+		Metadata:    u.data.Attrs.Metadata,
+		Tagging:     encodeTags(u.data.Attrs.Tags),
 	})
 	if err != nil {
 		return nil, err
@@ -214,7 +232,11 @@ func (u *uploader) multiPartUpload(initial *buffer) (attrs *types.ObjectAttrs, e
 
 	g, groupCtx := errgroup.WithContext(u.ctx)
 	partNumber := int32(1)
-	var totalSize int64
+	var (
+		totalSize int64
+		partsMu   sync.Mutex
+		completed []s3types.CompletedPart
+	)
 	uploadPart := func(buf *buffer) {
 		if buf == nil {
 			// No data to upload.
@@ -226,19 +248,46 @@ func (u *uploader) multiPartUpload(initial *buffer) (attrs *types.ObjectAttrs, e
 		partNumber++
 		g.Go(func() error {
 			data := buf.buf[:buf.n]
-			defer putBuf(buf)
+			defer u.putBuf(buf)
 
 			md5sum := md5.Sum(data)
 			contentMD5 := base64.StdEncoding.EncodeToString(md5sum[:])
-			_, err := u.client.UploadPart(groupCtx, &s3.UploadPartInput{
-				Bucket:        &u.bucket,
-				UploadId:      &uploadID,
-				PartNumber:    &part,
-				Body:          bytes.NewReader(data),
-				ContentLength: ptr(int64(len(data))),
-				ContentMD5:    ptr(contentMD5),
-			})
-			return err
+
+			// Retry the part on failure, so a transient error
+			// doesn't require restarting the whole upload.
+			var (
+				resp     *s3.UploadPartOutput
+				err      error
+				attempts int
+			)
+			for attempts = 1; ; attempts++ {
+				resp, err = u.client.UploadPart(groupCtx, &s3.UploadPartInput{
+					Bucket:        &u.bucket,
+					Key:           key,
+					UploadId:      &uploadID,
+					PartNumber:    &part,
+					Body:          bytes.NewReader(data),
+					ContentLength: ptr(int64(len(data))),
+					ContentMD5:    ptr(contentMD5),
+				})
+				if err == nil || attempts >= maxPartAttempts || groupCtx.Err() != nil {
+					break
+				}
+				select {
+				case <-time.After(partRetryBackoff * time.Duration(attempts)):
+				case <-groupCtx.Done():
+				}
+			}
+
+			u.reportPart(types.UploadPart{Number: int(part), Size: int64(len(data)), Attempts: attempts, Err: err})
+			if err != nil {
+				return err
+			}
+
+			partsMu.Lock()
+			completed = append(completed, s3types.CompletedPart{PartNumber: ptr(part), ETag: resp.ETag})
+			partsMu.Unlock()
+			return nil
 		})
 	}
 
@@ -270,12 +319,17 @@ func (u *uploader) multiPartUpload(initial *buffer) (attrs *types.ObjectAttrs, e
 		ifNoneMatch = ptr("*")
 	}
 
+	slices.SortFunc(completed, func(a, b s3types.CompletedPart) int {
+		return int(valOrZero(a.PartNumber) - valOrZero(b.PartNumber))
+	})
+
 	var completeResp *s3.CompleteMultipartUploadOutput
 	completeResp, err = u.client.CompleteMultipartUpload(u.ctx, &s3.CompleteMultipartUploadInput{
-		Bucket:      &u.bucket,
-		Key:         key,
-		UploadId:    &uploadID,
-		IfNoneMatch: ifNoneMatch,
+		Bucket:          &u.bucket,
+		Key:             key,
+		UploadId:        &uploadID,
+		IfNoneMatch:     ifNoneMatch,
+		MultipartUpload: &s3types.CompletedMultipartUpload{Parts: completed},
 	})
 	if err != nil {
 		return nil, err
@@ -284,15 +338,55 @@ func (u *uploader) multiPartUpload(initial *buffer) (attrs *types.ObjectAttrs, e
 		Object:      u.data.Object,
 		Version:     valOrZero(completeResp.VersionId),
 		ContentType: u.data.Attrs.ContentType,
+		Metadata:    u.data.Attrs.Metadata,
+		Tags:        u.data.Attrs.Tags,
 		Size:        totalSize,
 		ETag:        valOrZero(completeResp.ETag),
 	}, nil
 }
 
-// bufSize is the size of buffers allocated by bufPool.
+// bufSize is the size of buffers allocated by bufPool,
+// used as the part size unless one is specified.
 // It's a variable for testing purposes.
 var bufSize = 10 * 1024 * 1024
 
+// minPartSize is the smallest part size S3 accepts for all but the last part.
+const minPartSize = 5 * 1024 * 1024
+
+const (
+	// maxPartAttempts is the number of times uploading a part is attempted.
+	maxPartAttempts = 3
+	// partRetryBackoff is the delay before retrying a part, multiplied by the attempt number.
+	partRetryBackoff = 500 * time.Millisecond
+)
+
+func (u *uploader) checkPartSize() error {
+	if ps := u.data.PartSize; ps != 0 && ps < minPartSize {
+		return fmt.Errorf("%w: part size must be at least %d bytes", types.ErrInvalidArgument, minPartSize)
+	}
+	return nil
+}
+
+func (u *uploader) reportPart(part types.UploadPart) {
+	if u.data.OnPart != nil {
+		u.data.OnPart(part)
+	}
+}
+
+// getBuf returns a buffer for the next part of the upload.
+func (u *uploader) getBuf() *buffer {
+	if ps := u.data.PartSize; ps != 0 && ps != bufSize {
+		return &buffer{buf: make([]byte, ps)}
+	}
+	return getBuf()
+}
+
+func (u *uploader) putBuf(buf *buffer) {
+	if len(buf.buf) == bufSize {
+		putBuf(buf)
+	}
+}
+
 var bufPool = sync.Pool{
 	New: func() any {
 		return &buffer{
@@ -303,6 +397,10 @@ var bufPool = sync.Pool{
 
 func getBuf() *buffer {
 	buf := bufPool.Get().(*buffer)
+	if len(buf.buf) != bufSize {
+		// Allocated before bufSize changed.
+		return &buffer{buf: make([]byte, bufSize)}
+	}
 	buf.n = 0
 	return buf
 }
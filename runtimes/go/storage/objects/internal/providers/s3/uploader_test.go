@@ -3,9 +3,12 @@ package s3
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 
 	"encore.dev/storage/objects/internal/types"
@@ -61,6 +64,36 @@ func TestUploader_Sync(t *testing.T) {
 	})
 }
 
+func TestUploader_Tags(t *testing.T) {
+	c := qt.New(t)
+
+	ctrl := gomock.NewController(c)
+	client := NewMocks3Client(ctrl)
+
+	tags := map[string]string{"customer": "acme corp", "tier": "gold"}
+	u := newUploader(client, "bucket", types.UploadData{
+		Ctx:    context.Background(),
+		Object: "object",
+		Attrs: types.UploadAttrs{
+			Metadata: map[string]string{"author": "alice"},
+			Tags:     tags,
+		},
+	})
+
+	client.EXPECT().PutObject(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+			c.Assert(in.Metadata, qt.DeepEquals, map[string]string{"author": "alice"})
+			c.Assert(*in.Tagging, qt.Equals, "customer=acme+corp&tier=gold")
+			return &s3.PutObjectOutput{}, nil
+		})
+
+	_, err := u.Write([]byte("test"))
+	c.Assert(err, qt.IsNil)
+	attrs, err := u.Complete()
+	c.Assert(err, qt.IsNil)
+	c.Assert(attrs.Tags, qt.DeepEquals, tags)
+}
+
 func TestUploader_MultipleWrites(t *testing.T) {
 	c := qt.New(t)
 
@@ -166,6 +199,58 @@ func TestUploader_MultipartUpload(t *testing.T) {
 	})
 }
 
+func TestUploader_MultipartRetry(t *testing.T) {
+	c := qt.New(t)
+
+	ctrl := gomock.NewController(c)
+	client := NewMocks3Client(ctrl)
+
+	var (
+		partsMu sync.Mutex
+		parts   []types.UploadPart
+	)
+	u := newUploader(client, "bucket", types.UploadData{
+		Ctx:    context.Background(),
+		Object: "object",
+		OnPart: func(p types.UploadPart) {
+			partsMu.Lock()
+			defer partsMu.Unlock()
+			parts = append(parts, p)
+		},
+	})
+
+	withBufSize(c, 10)
+	client.EXPECT().CreateMultipartUpload(gomock.Any(), gomock.Any()).Return(&s3.CreateMultipartUploadOutput{
+		UploadId: ptr("uploadID"),
+	}, nil)
+	gomock.InOrder(
+		client.EXPECT().UploadPart(gomock.Any(), &partMatcher{num: 1, data: "abcdefghij"}).Return(nil, errors.New("connection reset")),
+		client.EXPECT().UploadPart(gomock.Any(), &partMatcher{num: 1, data: "abcdefghij"}).Return(&s3.UploadPartOutput{ETag: ptr("etag1")}, nil),
+	)
+	client.EXPECT().UploadPart(gomock.Any(), &partMatcher{num: 2, data: "klm"}).Return(&s3.UploadPartOutput{ETag: ptr("etag2")}, nil)
+	client.EXPECT().CompleteMultipartUpload(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *s3.CompleteMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+			c.Assert(in.MultipartUpload.Parts, qt.HasLen, 2)
+			for i, p := range in.MultipartUpload.Parts {
+				c.Assert(valOrZero(p.PartNumber), qt.Equals, int32(i+1))
+				c.Assert(valOrZero(p.ETag), qt.Equals, fmt.Sprintf("etag%d", i+1))
+			}
+			return &s3.CompleteMultipartUploadOutput{ETag: ptr("etag")}, nil
+		})
+
+	_, err := u.Write([]byte("abcdefghijklm"))
+	c.Assert(err, qt.IsNil)
+	attrs, err := u.Complete()
+	c.Assert(err, qt.IsNil)
+	c.Assert(attrs.Size, qt.Equals, int64(13))
+
+	slices.SortFunc(parts, func(a, b types.UploadPart) int { return a.Number - b.Number })
+	c.Assert(parts, qt.DeepEquals, []types.UploadPart{
+		{Number: 1, Size: 10, Attempts: 2},
+		{Number: 2, Size: 3, Attempts: 1},
+	})
+}
+
 func withBufSize(c *qt.C, n int) {
 	orig := bufSize
 	bufSize = n
@@ -16,8 +16,15 @@ type BucketImpl interface {
 	Attrs(data AttrsData) (*ObjectAttrs, error)
 	SignedUploadURL(data UploadURLData) (string, error)
 	SignedDownloadURL(data DownloadURLData) (string, error)
+	Copy(data CopyData) (*ObjectAttrs, error)
+	UpdateAttrs(data UpdateAttrsData) (*ObjectAttrs, error)
 }
 
+// ReservedMetadataPrefix is the prefix of metadata keys used by Encore,
+// such as for storing encryption parameters and tags on providers
+// without native object tags. They're not exposed as user metadata.
+const ReservedMetadataPrefix = "encore-"
+
 // CloudObject is the cloud name for an object.
 // It can differ from the logical name when using a prefix bucket.
 type CloudObject string
@@ -30,6 +37,22 @@ type UploadData struct {
 
 	Attrs UploadAttrs
 	Pre   Preconditions
+
+	// PartSize is the size of the parts large objects are uploaded in.
+	// Zero means the provider's default.
+	PartSize int
+
+	// OnPart, if non-nil, is called when each part of the object
+	// has been uploaded. It may be called concurrently.
+	OnPart func(UploadPart)
+}
+
+// UploadPart describes a part of an object that has been uploaded.
+type UploadPart struct {
+	Number   int   // the part number, starting at 1
+	Size     int64 // the size of the part, in bytes
+	Attempts int   // the number of attempts made to upload the part
+	Err      error // non-nil if the part failed to upload
 }
 
 type Preconditions struct {
@@ -38,6 +61,12 @@ type Preconditions struct {
 
 type UploadAttrs struct {
 	ContentType string
+
+	// Metadata is custom metadata to store with the object.
+	Metadata map[string]string
+
+	// Tags are the tags to set on the object.
+	Tags map[string]string
 }
 
 type Uploader interface {
@@ -52,6 +81,16 @@ type DownloadData struct {
 
 	// Non-zero to download a specific version
 	Version string
+
+	// Offset and Length select the byte range to download.
+	// A zero Length downloads the rest of the object.
+	Offset int64
+	Length int64
+
+	// IfMatch, if set, fails the download with ErrPreconditionFailed
+	// unless the object's ETag matches. It's used to resume downloads
+	// of objects whose version is not known.
+	IfMatch string
 }
 
 type Downloader interface {
@@ -59,12 +98,27 @@ type Downloader interface {
 	io.Closer
 }
 
+// DownloadedObject is implemented by Downloaders that report
+// which object version is being downloaded, so that interrupted
+// downloads can be resumed from the same version.
+type DownloadedObject interface {
+	// Version is the version being downloaded, or "" if unknown.
+	Version() string
+	// ETag is the ETag of the object being downloaded, or "" if unknown.
+	ETag() string
+}
+
 type ObjectAttrs struct {
 	Object      CloudObject
 	Version     string
 	ContentType string
 	Size        int64
 	ETag        string
+	Metadata    map[string]string
+
+	// Tags are the object's tags. They're only set
+	// if requested with AttrsData.Tags.
+	Tags map[string]string
 }
 
 type ListData struct {
@@ -91,6 +145,26 @@ type AttrsData struct {
 	Object CloudObject
 
 	Version string // non-zero means specific version
+
+	// Tags reports whether to also fetch the object's tags,
+	// which takes an additional request on some providers.
+	Tags bool
+}
+
+// UpdateAttrsData describes the attributes of an object to update.
+// An empty ContentType or nil map leaves the attribute unchanged.
+type UpdateAttrsData struct {
+	Ctx    context.Context
+	Object CloudObject
+
+	ContentType string
+
+	// Metadata replaces the object's metadata, except for keys with
+	// ReservedMetadataPrefix which are preserved.
+	Metadata map[string]string
+
+	// Tags replaces the object's tags.
+	Tags map[string]string
 }
 
 type UploadURLData struct {
@@ -98,6 +172,17 @@ type UploadURLData struct {
 	Object CloudObject
 
 	TTL time.Duration
+
+	// ContentType is the content type the upload is restricted to, if non-empty.
+	ContentType string
+}
+
+type CopyData struct {
+	Ctx context.Context
+	Src CloudObject
+	Dst CloudObject
+
+	SrcVersion string // non-zero means specific version
 }
 
 type DownloadURLData struct {
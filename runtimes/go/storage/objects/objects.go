@@ -6,7 +6,9 @@ package objects
 //
 // See https://encore.dev/docs/primitives/object-storage for more information.
 func NewBucket(name string, cfg BucketConfig) *Bucket {
-	return newBucket(Singleton, name)
+	bkt := newBucket(Singleton, name)
+	bkt.events = cfg.Events
+	return bkt
 }
 
 // constStr is a string that can only be provided as a constant.
@@ -37,18 +37,26 @@ func (o withVersionOption) attrsOption() {}
 //publicapigen:keep
 func (o withVersionOption) existsOption() {}
 
+//publicapigen:keep
+func (o withVersionOption) copyOption() {}
+
 //publicapigen:keep
 func (o withTTLOption) uploadURLOption() {}
 
 //publicapigen:keep
 func (o withTTLOption) downloadURLOption() {}
 
-func (o withVersionOption) applyDownload(opts *downloadOptions)   { opts.version = o.version }
-func (o withVersionOption) applyRemove(opts *removeOptions)       { opts.version = o.version }
-func (o withVersionOption) applyAttrs(opts *attrsOptions)         { opts.version = o.version }
-func (o withVersionOption) applyExists(opts *existsOptions)       { opts.version = o.version }
-func (o withTTLOption) applyUploadURL(opts *uploadURLOptions)     { opts.TTL = o.TTL }
-func (o withTTLOption) applyDownloadURL(opts *downloadURLOptions) { opts.TTL = o.TTL }
+//publicapigen:keep
+func (o withTTLOption) prefixAccessOption() {}
+
+func (o withVersionOption) applyDownload(opts *downloadOptions)     { opts.version = o.version }
+func (o withVersionOption) applyRemove(opts *removeOptions)         { opts.version = o.version }
+func (o withVersionOption) applyAttrs(opts *attrsOptions)           { opts.version = o.version }
+func (o withVersionOption) applyExists(opts *existsOptions)         { opts.version = o.version }
+func (o withVersionOption) applyCopy(opts *copyOptions)             { opts.version = o.version }
+func (o withTTLOption) applyUploadURL(opts *uploadURLOptions)       { opts.TTL = o.TTL }
+func (o withTTLOption) applyDownloadURL(opts *downloadURLOptions)   { opts.TTL = o.TTL }
+func (o withTTLOption) applyPrefixAccess(opts *prefixAccessOptions) { opts.TTL = o.TTL }
 
 // WithTTL is used for signed URLs, to specify the lifetime of the generated
 // URL. The max value is seven days. The default lifetime, if this
@@ -102,10 +110,25 @@ func (o withPreconditionsOption) applyUpload(opts *uploadOptions) {
 type UploadAttrs struct {
 	// ContentType specifies the content type of the object.
 	ContentType string
+
+	// Metadata is user-defined metadata to store with the object.
+	// Keys are case-insensitive and are lowercased on AWS.
+	// The "encore-" prefix is reserved.
+	Metadata map[string]string
+
+	// Tags are key-value pairs to categorize the object by, such as
+	// {"customer": "acme"}. They're stored as object tags on AWS, which
+	// can be used in IAM policies and lifecycle rules, and as metadata
+	// on GCP. An object can have at most 10 tags.
+	Tags map[string]string
 }
 
 // WithUploadAttrs is an UploadOption for specifying additional object attributes
 // to set during upload.
+//
+// When used with SignedUploadURL, the upload is restricted to the given
+// content type: the client must send a matching Content-Type header,
+// or the upload is rejected.
 func WithUploadAttrs(attrs UploadAttrs) withUploadAttrsOption {
 	return withUploadAttrsOption{attrs: attrs}
 }
@@ -118,15 +141,76 @@ type withUploadAttrsOption struct {
 //publicapigen:keep
 func (o withUploadAttrsOption) uploadOption() {}
 
+//publicapigen:keep
+func (o withUploadAttrsOption) uploadURLOption() {}
+
+func (o withUploadAttrsOption) applyUploadURL(opts *uploadURLOptions) {
+	opts.ContentType = o.attrs.ContentType
+}
+
 func (o withUploadAttrsOption) applyUpload(opts *uploadOptions) {
 	opts.attrs = types.UploadAttrs{
 		ContentType: o.attrs.ContentType,
+		Metadata:    o.attrs.Metadata,
+		Tags:        o.attrs.Tags,
 	}
 }
 
+// WithPartSize is an UploadOption for setting the size, in bytes, of the
+// parts large objects are uploaded in. Each part is buffered in memory
+// while it's uploaded, and retried independently if it fails.
+//
+// S3 requires parts to be at least 5 MiB, and GCS rounds the size up
+// to a multiple of 256 KiB. If unset, the provider's default is used.
+func WithPartSize(size int) withPartSizeOption {
+	return withPartSizeOption{size: size}
+}
+
+//publicapigen:keep
+type withPartSizeOption struct {
+	size int
+}
+
+//publicapigen:keep
+func (o withPartSizeOption) uploadOption() {}
+
+func (o withPartSizeOption) applyUpload(opts *uploadOptions) {
+	opts.partSize = o.size
+}
+
+// UploadProgress describes the progress of an upload.
+type UploadProgress struct {
+	// Parts is the number of parts uploaded so far.
+	Parts int
+
+	// BytesUploaded is the number of bytes uploaded so far.
+	BytesUploaded int64
+}
+
+// WithProgress is an UploadOption for tracking the progress of an upload.
+// The function is called each time a part of the object has been uploaded.
+// It may be called from another goroutine, but never concurrently.
+func WithProgress(fn func(UploadProgress)) withProgressOption {
+	return withProgressOption{fn: fn}
+}
+
+//publicapigen:keep
+type withProgressOption struct {
+	fn func(UploadProgress)
+}
+
+//publicapigen:keep
+func (o withProgressOption) uploadOption() {}
+
+func (o withProgressOption) applyUpload(opts *uploadOptions) {
+	opts.progress = o.fn
+}
+
 type uploadOptions struct {
-	attrs types.UploadAttrs
-	pre   Preconditions
+	attrs    types.UploadAttrs
+	pre      Preconditions
+	partSize int
+	progress func(UploadProgress)
 }
 
 // ListOption describes available options for the List operation.
@@ -172,7 +256,8 @@ type UploadURLOption interface {
 }
 
 type uploadURLOptions struct {
-	TTL time.Duration
+	TTL         time.Duration
+	ContentType string
 }
 
 // DownloadURLOption describes available options for the SignedDownloadURL operation.
@@ -187,6 +272,34 @@ type downloadURLOptions struct {
 	TTL time.Duration
 }
 
+// PrefixAccessOption describes available options for the SignedPrefixAccess operation.
+type PrefixAccessOption interface {
+	//publicapigen:keep
+	prefixAccessOption()
+
+	applyPrefixAccess(*prefixAccessOptions)
+}
+
+type prefixAccessOptions struct {
+	TTL         time.Duration
+	currentUser bool
+}
+
+// ForCurrentUser is used for signed prefix access, to restrict the access
+// to requests authenticated as the user making the current request.
+// It requires the current request to be authenticated.
+func ForCurrentUser() forCurrentUserOption {
+	return forCurrentUserOption{}
+}
+
+//publicapigen:keep
+type forCurrentUserOption struct{}
+
+//publicapigen:keep
+func (o forCurrentUserOption) prefixAccessOption() {}
+
+func (o forCurrentUserOption) applyPrefixAccess(opts *prefixAccessOptions) { opts.currentUser = true }
+
 // ExistsOption describes available options for the Exists operation.
 type ExistsOption interface {
 	//publicapigen:keep
@@ -199,6 +312,18 @@ type existsOptions struct {
 	version string
 }
 
+// CopyOption describes available options for the Copy and Move operations.
+type CopyOption interface {
+	//publicapigen:keep
+	copyOption()
+
+	applyCopy(*copyOptions)
+}
+
+type copyOptions struct {
+	version string // the version of the source object
+}
+
 // PublicURLOption describes available options for the PublicURL operation.
 type PublicURLOption interface {
 	//publicapigen:keep
@@ -0,0 +1,25 @@
+package objects
+
+import (
+	"context"
+
+	"encore.dev/appruntime/shared/preflight"
+)
+
+// registerPreflightChecks registers a preflight check for
+// each bucket bound to the running application.
+func (mgr *Manager) registerPreflightChecks(r *preflight.Registry) {
+	for name := range mgr.runtime.Buckets {
+		r.Register("bucket", name, func(ctx context.Context) error {
+			// Listing a single object verifies both that
+			// the bucket exists and that we can access it.
+			bkt := newBucket(mgr, name)
+			for _, err := range bkt.impl.List(bkt.mapQuery(ctx, &Query{Limit: 1})) {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
@@ -3,6 +3,7 @@ package objects
 import (
 	"context"
 	"iter"
+	"net/http"
 	"net/url"
 )
 
@@ -22,6 +23,9 @@ type ReadWriter interface {
 	Remover
 	Lister
 	Attrser
+	AttrsUpdater
+	Copier
+	Mover
 }
 
 // Uploader is the interface for uploading objects to a bucket.
@@ -79,6 +83,9 @@ type Downloader interface {
 	// Download downloads an object from the bucket.
 	Download(ctx context.Context, object string, options ...DownloadOption) *Reader
 
+	// DownloadRange downloads a byte range of an object from the bucket.
+	DownloadRange(ctx context.Context, object string, offset, length int64, options ...DownloadOption) *Reader
+
 	perms()
 }
 
@@ -100,6 +107,14 @@ type SignedDownloader interface {
 	// from storage, without any other authentication.
 	SignedDownloadURL(ctx context.Context, object string, options ...DownloadURLOption) (*SignedDownloadURL, error)
 
+	// SignedPrefixAccess returns a signed token granting time-limited read access
+	// to the objects whose names begin with prefix.
+	SignedPrefixAccess(ctx context.Context, prefix string, options ...PrefixAccessOption) (*SignedPrefixAccess, error)
+
+	// ServeSignedAccess serves a request for an object using signed prefix access,
+	// redirecting it to a signed download URL if it carries a valid token.
+	ServeSignedAccess(w http.ResponseWriter, req *http.Request, object string)
+
 	perms()
 }
 
@@ -163,6 +178,65 @@ type Attrser interface {
 	perms()
 }
 
+// AttrsUpdater is the interface for updating the attributes of objects in a bucket,
+// such as their metadata and tags.
+// It can be used in conjunction with [BucketRef] to declare
+// a reference that can update object attributes in a bucket.
+//
+// For example:
+//
+//	var MyBucket = objects.NewBucket(...)
+//	var ref = objects.BucketRef[objects.AttrsUpdater](MyBucket)
+//
+// The ref object can then be used to update object attributes and can be
+// passed around freely within the service, without being subject
+// to Encore's static analysis restrictions that apply to MyBucket.
+type AttrsUpdater interface {
+	// UpdateAttrs updates the attributes of an object.
+	UpdateAttrs(ctx context.Context, object string, update ObjectAttrsToUpdate) (*ObjectAttrs, error)
+
+	perms()
+}
+
+// Copier is the interface for copying objects within a bucket.
+// It can be used in conjunction with [BucketRef] to declare
+// a reference that can copy objects in the bucket.
+//
+// For example:
+//
+//	var MyBucket = objects.NewBucket(...)
+//	var ref = objects.BucketRef[objects.Copier](MyBucket)
+//
+// The ref object can then be used to copy objects and can be
+// passed around freely within the service, without being subject
+// to Encore's static analysis restrictions that apply to MyBucket.
+type Copier interface {
+	// Copy copies an object within the bucket.
+	Copy(ctx context.Context, src, dst string, options ...CopyOption) (*ObjectAttrs, error)
+
+	perms()
+}
+
+// Mover is the interface for moving objects within a bucket.
+// Moving an object requires permission to remove the original object.
+// It can be used in conjunction with [BucketRef] to declare
+// a reference that can move objects in the bucket.
+//
+// For example:
+//
+//	var MyBucket = objects.NewBucket(...)
+//	var ref = objects.BucketRef[objects.Mover](MyBucket)
+//
+// The ref object can then be used to move objects and can be
+// passed around freely within the service, without being subject
+// to Encore's static analysis restrictions that apply to MyBucket.
+type Mover interface {
+	// Move moves an object within the bucket.
+	Move(ctx context.Context, src, dst string, options ...CopyOption) (*ObjectAttrs, error)
+
+	perms()
+}
+
 // PublicURLer is the interface for resolving the public URL for an object.
 // It can be used in conjunction with [BucketRef] to declare
 // a reference that can resolve an object's public URL.
@@ -0,0 +1,273 @@
+package objects
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/objects/internal/types"
+)
+
+const (
+	// maxSignedAccessTTL is the maximum lifetime of signed prefix access.
+	maxSignedAccessTTL = 7 * 24 * time.Hour
+
+	// signedAccessRedirectTTL is the maximum lifetime of the signed download URLs
+	// that ServeSignedAccess redirects to. They're only used to follow the redirect.
+	signedAccessRedirectTTL = 5 * time.Minute
+
+	// signedAccessQueryParam is the query parameter carrying a signed access token.
+	signedAccessQueryParam = "token"
+
+	// signedAccessCookiePrefix is the prefix of the names of cookies
+	// carrying signed access tokens.
+	signedAccessCookiePrefix = "encore-access-"
+)
+
+// SignedPrefixAccess grants time-limited read access to the objects
+// in a bucket whose names begin with a prefix.
+//
+// The access is checked by Bucket.ServeSignedAccess, given the token either
+// as the "token" query parameter of the request or in the cookie returned by Cookie.
+type SignedPrefixAccess struct {
+	// Prefix is the prefix of the names of the objects access is granted to.
+	Prefix string
+
+	// Token is the signed token granting access.
+	Token string
+
+	// Expires is the time at which the access expires.
+	Expires time.Time
+
+	// UserID is the user the access is restricted to,
+	// or empty if it's not restricted to a user.
+	UserID string
+
+	bucket string
+}
+
+// Cookie returns a cookie carrying the access token, to set on a response
+// so that browsers send it along with requests served by ServeSignedAccess.
+// Set its Path and Domain to restrict which requests it's sent with.
+func (a *SignedPrefixAccess) Cookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     signedAccessCookieName(a.bucket, a.Prefix),
+		Value:    a.Token,
+		Path:     "/",
+		Expires:  a.Expires,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// SignedPrefixAccess returns a signed token granting read access to the objects
+// in the bucket whose names begin with prefix, until it expires.
+//
+// Use ForCurrentUser to restrict the access to requests authenticated as the
+// current user. Prefixes should end with a "/", since a prefix like "users/1"
+// also grants access to objects beginning with "users/10".
+func (b *Bucket) SignedPrefixAccess(ctx context.Context, prefix string, options ...PrefixAccessOption) (*SignedPrefixAccess, error) {
+	var opt prefixAccessOptions
+	for _, o := range options {
+		o.applyPrefixAccess(&opt)
+	}
+	if opt.TTL == 0 {
+		opt.TTL = time.Hour
+	}
+	if prefix == "" || opt.TTL > maxSignedAccessTTL {
+		return nil, types.ErrInvalidArgument
+	}
+	if b.enc != nil {
+		return nil, errSignedURLEncrypted
+	}
+
+	tok := signedAccessToken{
+		Expires: time.Now().Add(opt.TTL).Truncate(time.Second),
+		Prefix:  prefix,
+	}
+	if opt.currentUser {
+		uid, ok := b.mgr.currentUserID()
+		if !ok {
+			return nil, fmt.Errorf("%w: ForCurrentUser requires an authenticated request", types.ErrInvalidArgument)
+		}
+		tok.UserID = uid
+	}
+
+	keys := b.mgr.runtime.AuthKeys
+	if len(keys) == 0 {
+		return nil, errors.New("objects: no signing key available for signed prefix access")
+	}
+	return &SignedPrefixAccess{
+		Prefix:  prefix,
+		Token:   tok.sign(keys[0], b.name),
+		Expires: tok.Expires,
+		UserID:  tok.UserID,
+		bucket:  b.name,
+	}, nil
+}
+
+// ServeSignedAccess serves a request for the given object using signed prefix access.
+// It's meant to be called from a raw endpoint serving the bucket's objects, such as:
+//
+//	//encore:api public raw method=GET path=/media/*object
+//	func Media(w http.ResponseWriter, req *http.Request) {
+//		object := encore.CurrentRequest().PathParams.Get("object")
+//		Uploads.ServeSignedAccess(w, req, object)
+//	}
+//
+// If the request carries a valid token granting access to the object, in the "token"
+// query parameter or in a cookie returned by SignedPrefixAccess.Cookie, it's redirected
+// to a short-lived signed download URL so that the object is served directly from storage.
+// Otherwise it's rejected with a 403 Forbidden response.
+func (b *Bucket) ServeSignedAccess(w http.ResponseWriter, req *http.Request, object string) {
+	if object == "" {
+		http.Error(w, "object not found", http.StatusNotFound)
+		return
+	}
+
+	tok, ok := b.checkSignedAccess(req, object)
+	if !ok {
+		http.Error(w, "access denied", http.StatusForbidden)
+		return
+	}
+
+	ttl := min(time.Until(tok.Expires), signedAccessRedirectTTL)
+	signed, err := b.SignedDownloadURL(req.Context(), object, WithTTL(max(ttl, time.Second)))
+	if err != nil {
+		b.mgr.rootLogger.Error().Err(err).Str("bucket", b.name).Str("object", object).Msg("unable to sign download url")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// The redirect is only valid for as long as the signed URL,
+	// and must not be shared between users.
+	w.Header().Set("Cache-Control", "private, no-store")
+	http.Redirect(w, req, signed.URL, http.StatusFound)
+}
+
+// checkSignedAccess returns the token in req granting access to object, if any.
+func (b *Bucket) checkSignedAccess(req *http.Request, object string) (signedAccessToken, bool) {
+	candidates := req.URL.Query()[signedAccessQueryParam]
+	for _, c := range req.Cookies() {
+		if strings.HasPrefix(c.Name, signedAccessCookiePrefix+b.name+"-") {
+			candidates = append(candidates, c.Value)
+		}
+	}
+
+	now := time.Now()
+	for _, s := range candidates {
+		tok, ok := verifySignedAccessToken(b.mgr.runtime.AuthKeys, b.name, s)
+		if !ok || !tok.Expires.After(now) || tok.Expires.After(now.Add(maxSignedAccessTTL+15*time.Minute)) {
+			continue
+		}
+		if !strings.HasPrefix(object, tok.Prefix) {
+			continue
+		}
+		if tok.UserID != "" {
+			if uid, ok := b.mgr.currentUserID(); !ok || uid != tok.UserID {
+				continue
+			}
+		}
+		return tok, true
+	}
+	return signedAccessToken{}, false
+}
+
+// currentUserID returns the id of the user the current request is authenticated as.
+func (mgr *Manager) currentUserID() (string, bool) {
+	if curr := mgr.rt.Current(); curr.Req != nil {
+		if curr.Req.RPCData != nil {
+			uid := string(curr.Req.RPCData.UserID)
+			return uid, uid != ""
+		} else if curr.Req.Test != nil {
+			uid := string(curr.Req.Test.UserID)
+			return uid, uid != ""
+		}
+	}
+	return "", false
+}
+
+// signedAccessCookieName returns the name of the cookie carrying a token for
+// the given prefix, so that tokens for different prefixes can be set at once.
+func signedAccessCookieName(bucket, prefix string) string {
+	sum := sha256.Sum256([]byte(prefix))
+	return signedAccessCookiePrefix + bucket + "-" + hex.EncodeToString(sum[:4])
+}
+
+// signedAccessToken is the payload of a signed prefix access token.
+type signedAccessToken struct {
+	Expires time.Time
+	Prefix  string
+	UserID  string
+}
+
+// sign encodes the token as:
+//
+//	key id (4) | expires (8) | prefix len (2) | prefix | user id len (2) | user id | mac (32)
+func (t signedAccessToken) sign(key config.EncoreAuthKey, bucket string) string {
+	data := binary.BigEndian.AppendUint32(nil, key.KeyID)
+	data = binary.BigEndian.AppendUint64(data, uint64(t.Expires.Unix()))
+	data = binary.BigEndian.AppendUint16(data, uint16(len(t.Prefix)))
+	data = append(data, t.Prefix...)
+	data = binary.BigEndian.AppendUint16(data, uint16(len(t.UserID)))
+	data = append(data, t.UserID...)
+	data = append(data, signedAccessMAC(key, bucket, data)...)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// verifySignedAccessToken decodes a token signed for the given bucket
+// by one of keys, reporting whether its signature is valid.
+func verifySignedAccessToken(keys []config.EncoreAuthKey, bucket, s string) (signedAccessToken, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) < 4+8+2+2+sha256.Size {
+		return signedAccessToken{}, false
+	}
+	payload, mac := data[:len(data)-sha256.Size], data[len(data)-sha256.Size:]
+
+	keyID := binary.BigEndian.Uint32(payload[0:4])
+	valid := false
+	for _, k := range keys {
+		if k.KeyID == keyID {
+			valid = hmac.Equal(signedAccessMAC(k, bucket, payload), mac)
+			break
+		}
+	}
+	if !valid {
+		return signedAccessToken{}, false
+	}
+
+	tok := signedAccessToken{Expires: time.Unix(int64(binary.BigEndian.Uint64(payload[4:12])), 0)}
+	rest := payload[12:]
+	readString := func() (string, bool) {
+		if len(rest) < 2 {
+			return "", false
+		}
+		n := int(binary.BigEndian.Uint16(rest))
+		if len(rest) < 2+n {
+			return "", false
+		}
+		s := string(rest[2 : 2+n])
+		rest = rest[2+n:]
+		return s, true
+	}
+	var ok1, ok2 bool
+	tok.Prefix, ok1 = readString()
+	tok.UserID, ok2 = readString()
+	return tok, ok1 && ok2 && len(rest) == 0
+}
+
+func signedAccessMAC(key config.EncoreAuthKey, bucket string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key.Data)
+	_, _ = fmt.Fprintf(mac, "bucket-access\x00%s\x00%s", bucket, payload)
+	return mac.Sum(nil)
+}
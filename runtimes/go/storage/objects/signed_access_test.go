@@ -0,0 +1,160 @@
+package objects
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/storage/objects/internal/providers/noop"
+	"encore.dev/storage/objects/internal/types"
+)
+
+// signingBucket signs download URLs pointing at a fake storage host.
+type signingBucket struct {
+	noop.BucketImpl
+}
+
+func (*signingBucket) SignedDownloadURL(data types.DownloadURLData) (string, error) {
+	return "https://storage.example/" + string(data.Object) + "?ttl=" + data.TTL.String(), nil
+}
+
+func newSignedAccessBucket() *Bucket {
+	b := newTestBucket(&signingBucket{})
+	b.mgr.runtime = &config.Runtime{AuthKeys: []config.EncoreAuthKey{
+		{KeyID: 2, Data: []byte("current key")},
+		{KeyID: 1, Data: []byte("previous key")},
+	}}
+	return b
+}
+
+func serveSignedAccess(b *Bucket, object, token string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/media/"+object+"?token="+url.QueryEscape(token), nil)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	w := httptest.NewRecorder()
+	b.ServeSignedAccess(w, req, object)
+	return w
+}
+
+func TestSignedPrefixAccess(t *testing.T) {
+	b := newSignedAccessBucket()
+	ctx := context.Background()
+
+	access, err := b.SignedPrefixAccess(ctx, "users/1/", WithTTL(10*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		object string
+		token  string
+		want   int
+	}{
+		{"in_prefix", "users/1/avatar.png", access.Token, http.StatusFound},
+		{"nested", "users/1/photos/a.jpg", access.Token, http.StatusFound},
+		{"other_prefix", "users/2/avatar.png", access.Token, http.StatusForbidden},
+		{"no_token", "users/1/avatar.png", "", http.StatusForbidden},
+		{"tampered", "users/1/avatar.png", access.Token[:len(access.Token)-2] + "AA", http.StatusForbidden},
+		{"no_object", "", access.Token, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveSignedAccess(b, tt.object, tt.token)
+			if w.Code != tt.want {
+				t.Fatalf("got status %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusFound {
+				want := "https://storage.example/" + tt.object + "?ttl=5m0s"
+				if got := w.Header().Get("Location"); got != want {
+					t.Errorf("got redirect to %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSignedPrefixAccess_Cookie(t *testing.T) {
+	b := newSignedAccessBucket()
+	access, err := b.SignedPrefixAccess(context.Background(), "shared/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cookie := access.Cookie()
+	if !cookie.HttpOnly || !cookie.Secure || !cookie.Expires.Equal(access.Expires) {
+		t.Errorf("got cookie %+v, want a secure http-only cookie expiring with the access", cookie)
+	}
+	if w := serveSignedAccess(b, "shared/doc.pdf", "", cookie); w.Code != http.StatusFound {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusFound)
+	}
+
+	// Cookies for other buckets are ignored.
+	other := newSignedAccessBucket()
+	other.name = "other"
+	if w := serveSignedAccess(other, "shared/doc.pdf", "", cookie); w.Code != http.StatusForbidden {
+		t.Errorf("got status %d for other bucket, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestSignedPrefixAccess_Expiry(t *testing.T) {
+	b := newSignedAccessBucket()
+
+	expired := signedAccessToken{Expires: time.Now().Add(-time.Second), Prefix: "a/"}
+	if w := serveSignedAccess(b, "a/b", expired.sign(b.mgr.runtime.AuthKeys[0], b.name)); w.Code != http.StatusForbidden {
+		t.Errorf("got status %d for expired token, want %d", w.Code, http.StatusForbidden)
+	}
+
+	// Tokens signed with a key that has been rotated out are still accepted
+	// as long as the key is listed.
+	rotated := signedAccessToken{Expires: time.Now().Add(time.Minute), Prefix: "a/"}
+	w := serveSignedAccess(b, "a/b", rotated.sign(b.mgr.runtime.AuthKeys[1], b.name))
+	if w.Code != http.StatusFound {
+		t.Fatalf("got status %d for rotated key, want %d", w.Code, http.StatusFound)
+	}
+	// The redirect doesn't outlive the token.
+	loc, _ := url.Parse(w.Header().Get("Location"))
+	if ttl, _ := time.ParseDuration(loc.Query().Get("ttl")); ttl > time.Minute {
+		t.Errorf("got redirect ttl %v, want at most 1m", ttl)
+	}
+
+	if _, err := b.SignedPrefixAccess(context.Background(), "a/", WithTTL(8*24*time.Hour)); err == nil {
+		t.Error("expected error for ttl above max")
+	}
+}
+
+func TestSignedPrefixAccess_CurrentUser(t *testing.T) {
+	b := newSignedAccessBucket()
+	ctx := context.Background()
+
+	if _, err := b.SignedPrefixAccess(ctx, "users/1/", ForCurrentUser()); err == nil {
+		t.Fatal("expected error without an authenticated request")
+	}
+
+	b.mgr.rt.BeginOperation()
+	defer b.mgr.rt.FinishOperation()
+	b.mgr.rt.BeginRequest(&model.Request{RPCData: &model.RPCData{UserID: "1"}})
+	access, err := b.SignedPrefixAccess(ctx, "users/1/", ForCurrentUser())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if access.UserID != "1" {
+		t.Fatalf("got user id %q, want %q", access.UserID, "1")
+	}
+	if w := serveSignedAccess(b, "users/1/a.png", access.Token); w.Code != http.StatusFound {
+		t.Errorf("got status %d for same user, want %d", w.Code, http.StatusFound)
+	}
+	b.mgr.rt.FinishRequest(false)
+
+	b.mgr.rt.BeginRequest(&model.Request{RPCData: &model.RPCData{UserID: "2"}})
+	defer b.mgr.rt.FinishRequest(false)
+	if w := serveSignedAccess(b, "users/1/a.png", access.Token); w.Code != http.StatusForbidden {
+		t.Errorf("got status %d for other user, want %d", w.Code, http.StatusForbidden)
+	}
+}
@@ -5,6 +5,7 @@ package objects
 import (
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/preflight"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
@@ -22,4 +23,5 @@ func init() {
 	Singleton = NewManager(appconf.Static, appconf.Runtime, reqtrack.Singleton,
 		testsupport.Singleton, logging.RootLogger)
 	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	Singleton.registerPreflightChecks(preflight.Singleton)
 }
@@ -11,6 +11,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -35,6 +36,25 @@ type Database struct {
 
 	stdlibOnce sync.Once
 	stdlib     *sql.DB
+
+	// readOnly is true if this is a handle returned by ReadOnly,
+	// in which case primary is the database it was created from
+	// and replicas are the connection pools to its read replicas.
+	readOnly    bool
+	primary     *Database
+	replicas    []*pgxpool.Pool
+	nextReplica atomic.Uint32
+
+	readOnlyOnce sync.Once
+	readOnlyDB   *Database
+
+	// stmts are the names of the statements created by Prepare.
+	stmtsMu sync.Mutex
+	stmts   map[string]struct{}
+
+	// subs are the active notification subscriptions created by Listen.
+	subsMu sync.Mutex
+	subs   map[*Subscription]struct{}
 }
 
 var errNoopDB = errors.New("sqldb: this service is not configured to use this database. Use sqldb.Named in this service to get a reference and access to the database from this service")
@@ -45,6 +65,11 @@ func (db *Database) init() {
 	}
 
 	db.initOnce.Do(func() {
+		if db.readOnly {
+			db.initReadOnly()
+			return
+		}
+
 		if db.pool == nil {
 			pool, found := db.mgr.getPool(db.origName, db.name)
 			db.pool, db.noopDB = pool, !found
@@ -94,9 +119,16 @@ func (db *Database) Stdlib() *sql.DB {
 }
 
 func (db *Database) shutdown() {
-	if db.pool != nil {
+	db.closeSubscriptions()
+	if db.readOnlyDB != nil {
+		db.readOnlyDB.shutdown()
+	}
+	if db.pool != nil && !db.readOnly {
 		db.pool.Close()
 	}
+	for _, replica := range db.replicas {
+		replica.Close()
+	}
 	if db.stdlib != nil {
 		_ = db.stdlib.Close()
 	}
@@ -132,11 +164,7 @@ func dbConf(srv *config.SQLServer, db *config.SQLDatabase, dbNameOverride string
 		return nil, fmt.Errorf("invalid database uri: %v", err)
 	}
 
-	// Set the pool size based on the config.
-	cfg.MaxConns = 30
-	if n := db.MaxConnections; n > 0 {
-		cfg.MaxConns = int32(n)
-	}
+	applyPoolConfig(cfg, db)
 
 	// If we have a server CA, set it in the TLS config.
 	if srv.ServerCACert != "" {
@@ -170,35 +198,16 @@ func (db *Database) Exec(ctx context.Context, query string, args ...interface{})
 	}
 
 	db.init()
-
-	var (
-		startEventID model.TraceEventID
-		eventParams  trace2.EventParams
-	)
-
-	curr := db.mgr.rt.Current()
-	if curr.Req != nil && curr.Trace != nil {
-		eventParams = trace2.EventParams{
-			TraceID: curr.Req.TraceID,
-			SpanID:  curr.Req.SpanID,
-			Goid:    curr.Goctr,
-			DefLoc:  0,
-		}
-		startEventID = curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
-			EventParams: eventParams,
-			Query:       query,
-			TxStartID:   0,
-			Stack:       stack.Build(4),
-		})
-	}
-
-	res, err := db.pool.Exec(markTraced(ctx), query, args...)
-	err = convertErr(err)
-
-	if curr.Trace != nil {
-		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+	ctx, cancel := withQueryTimeout(ctx)
+	if cancel != nil {
+		defer cancel()
 	}
 
+	var res ExecResult
+	err := db.runQuery(ctx, trace2.DBQueryStartParams{Query: query}, func(pool *pgxpool.Pool) (err error) {
+		res, err = pool.Exec(markTraced(ctx), query, args...)
+		return err
+	})
 	return res, err
 }
 
@@ -212,38 +221,20 @@ func (db *Database) Query(ctx context.Context, query string, args ...interface{}
 	}
 
 	db.init()
+	ctx, cancel := withQueryTimeout(ctx)
 
-	var (
-		startEventID model.TraceEventID
-		eventParams  trace2.EventParams
-	)
-
-	curr := db.mgr.rt.Current()
-	if curr.Req != nil && curr.Trace != nil {
-		eventParams = trace2.EventParams{
-			TraceID: curr.Req.TraceID,
-			SpanID:  curr.Req.SpanID,
-			Goid:    curr.Goctr,
-			DefLoc:  0,
-		}
-		startEventID = curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
-			EventParams: eventParams,
-			Query:       query,
-			Stack:       stack.Build(4),
-		})
-	}
-
-	rows, err := db.pool.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
-
-	if curr.Trace != nil {
-		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
-	}
-
+	var rows pgx.Rows
+	err := db.runQuery(ctx, trace2.DBQueryStartParams{Query: query}, func(pool *pgxpool.Pool) (err error) {
+		rows, err = pool.Query(markTraced(ctx), query, args...)
+		return err
+	})
 	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
 		return nil, err
 	}
-	return &Rows{std: rows}, nil
+	return &Rows{std: withCancel(ctx, rows, cancel)}, nil
 }
 
 // QueryRow executes a query that is expected to return at most one row.
@@ -255,34 +246,17 @@ func (db *Database) QueryRow(ctx context.Context, query string, args ...interfac
 	}
 
 	db.init()
+	ctx, cancel := withQueryTimeout(ctx)
 
-	var (
-		startEventID model.TraceEventID
-		eventParams  trace2.EventParams
-	)
-
-	curr := db.mgr.rt.Current()
-	if curr.Req != nil && curr.Trace != nil {
-		eventParams = trace2.EventParams{
-			TraceID: curr.Req.TraceID,
-			SpanID:  curr.Req.SpanID,
-			Goid:    curr.Goctr,
-			DefLoc:  0,
-		}
-		startEventID = curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
-			EventParams: eventParams,
-			Query:       query,
-			Stack:       stack.Build(4),
-		})
-	}
-
-	rows, err := db.pool.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
-	r := &Row{rows: rows, err: err}
-
-	if curr.Trace != nil {
-		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+	var rows pgx.Rows
+	err := db.runQuery(ctx, trace2.DBQueryStartParams{Query: query}, func(pool *pgxpool.Pool) (err error) {
+		rows, err = pool.Query(markTraced(ctx), query, args...)
+		return err
+	})
+	if err != nil && cancel != nil {
+		cancel()
 	}
+	r := &Row{rows: withCancel(ctx, rows, cancel), err: err}
 
 	return r
 }
@@ -295,8 +269,16 @@ func (db *Database) Begin(ctx context.Context) (*Tx, error) {
 		return nil, errNoopDB
 	}
 
+	return db.beginTx(ctx, pgx.TxOptions{}, 1)
+}
+
+// beginTx begins a transaction with the given options.
+// The attempt number is used to annotate traced queries
+// for transactions retried by RunInTx.
+func (db *Database) beginTx(ctx context.Context, opts pgx.TxOptions, attempt int) (*Tx, error) {
 	db.init()
-	tx, err := db.pool.Begin(markTraced(ctx))
+	pool, _ := db.queryPool()
+	tx, err := pool.BeginTx(markTraced(ctx), opts)
 	err = convertErr(err)
 	if err != nil {
 		return nil, err
@@ -309,10 +291,10 @@ func (db *Database) Begin(ctx context.Context) (*Tx, error) {
 			TraceID: curr.Req.TraceID,
 			SpanID:  curr.Req.SpanID,
 			Goid:    curr.Goctr,
-		}, stack.Build(4))
+		}, stack.Build(5))
 	}
 
-	return &Tx{mgr: db.mgr, std: tx, startID: startID}, nil
+	return &Tx{mgr: db.mgr, std: tx, startID: startID, attempt: attempt}, nil
 }
 
 // Driver returns the underlying database driver for this database connection pool.
@@ -86,11 +86,17 @@ func (pe *Error) Unwrap() error {
 
 func convertErr(err error) error {
 	var pgerr *pgconn.PgError
-	if errors.As(err, &pgerr) {
+	// Timeout errors from convertQueryErr already wrap the converted error.
+	if !errors.Is(err, ErrQueryTimeout) && errors.As(err, &pgerr) {
 		err = convertPgError(pgerr)
+		if isStatementTimeout(pgerr) {
+			err = &timeoutError{err: err}
+		}
 	}
 
 	switch {
+	case errors.Is(err, ErrQueryTimeout):
+		err = errs.WrapCode(err, errs.DeadlineExceeded, "")
 	case errors.Is(err, pgx.ErrNoRows), errors.Is(err, sql.ErrNoRows):
 		err = errs.WrapCode(sql.ErrNoRows, errs.NotFound, "")
 	case errors.Is(err, pgx.ErrTxClosed), errors.Is(err, pgx.ErrTxCommitRollback), errors.Is(err, sql.ErrTxDone), errors.Is(err, sql.ErrConnDone):
@@ -0,0 +1,243 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/stack"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/jsonapi"
+)
+
+// Subscription is a subscription to notifications on a Postgres channel,
+// created by Listen.
+type Subscription struct {
+	db      *Database
+	channel string
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// Channel returns the name of the channel the subscription listens on.
+func (s *Subscription) Channel() string { return s.channel }
+
+// Close stops listening for notifications and waits for any
+// in-progress notification handler to return.
+func (s *Subscription) Close() {
+	s.cancel()
+	<-s.done
+	s.db.removeSubscription(s)
+}
+
+// Listen subscribes to notifications sent on the given Postgres channel
+// using NOTIFY or pg_notify, calling handler for each notification received.
+//
+// The notification payload is decoded into T. If T is string or []byte
+// the raw payload is used, and otherwise the payload is decoded as JSON.
+// Notifications that cannot be decoded, and errors returned by handler,
+// are logged and otherwise ignored.
+//
+// Listen uses a dedicated database connection, separate from the connection pool.
+// If the connection is lost it is automatically re-established. Notifications
+// sent while reconnecting are lost, as Postgres only delivers notifications
+// to connected listeners.
+//
+// Listen returns once the subscription has been established.
+// The subscription is closed when ctx is canceled, Close is called,
+// or the application shuts down.
+//
+// When a notification is received during a traced request,
+// it is recorded in the trace.
+func Listen[T any](ctx context.Context, db *Database, channel string, handler func(ctx context.Context, payload T) error) (*Subscription, error) {
+	if db.noopDB {
+		return nil, errNoopDB
+	} else if channel == "" {
+		return nil, errors.New("sqldb: Listen called with empty channel name")
+	}
+	db.init()
+
+	l := &listener[T]{db: db, channel: channel, handler: handler}
+	conn, err := l.connect(ctx)
+	if err != nil {
+		return nil, convertErr(err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &Subscription{
+		db:      db,
+		channel: channel,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	db.addSubscription(sub)
+
+	go func() {
+		defer close(sub.done)
+		l.run(ctx, conn)
+	}()
+	return sub, nil
+}
+
+const (
+	listenMinBackoff = 100 * time.Millisecond
+	listenMaxBackoff = 10 * time.Second
+)
+
+type listener[T any] struct {
+	db      *Database
+	channel string
+	handler func(ctx context.Context, payload T) error
+}
+
+// connect opens a new connection and starts listening on the channel.
+func (l *listener[T]) connect(ctx context.Context) (*pgx.Conn, error) {
+	conn, err := pgx.ConnectConfig(ctx, l.db.pool.Config().ConnConfig.Copy())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		_ = conn.Close(context.Background())
+		return nil, err
+	}
+	return conn, nil
+}
+
+// run receives notifications until ctx is canceled, reconnecting as necessary.
+func (l *listener[T]) run(ctx context.Context, conn *pgx.Conn) {
+	log := l.db.mgr.rootLogger.With().Str("db", l.db.name).Str("channel", l.channel).Logger()
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			_ = conn.Close(context.Background())
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.Warn().Err(err).Msg("sqldb: lost listen connection, reconnecting")
+			if conn = l.reconnect(ctx); conn == nil {
+				return
+			}
+			continue
+		}
+
+		l.handle(ctx, n)
+	}
+}
+
+// reconnect re-establishes the listen connection with exponential backoff.
+// It returns nil if ctx is canceled before a connection could be established.
+func (l *listener[T]) reconnect(ctx context.Context) *pgx.Conn {
+	backoff := listenMinBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		conn, err := l.connect(ctx)
+		if err == nil {
+			return conn
+		} else if ctx.Err() != nil {
+			return nil
+		}
+
+		l.db.mgr.rootLogger.Warn().Err(err).Str("db", l.db.name).Str("channel", l.channel).
+			Dur("backoff", backoff).Msg("sqldb: failed to reconnect listen connection")
+		backoff = min(backoff*2, listenMaxBackoff)
+	}
+}
+
+// handle decodes the notification and calls the handler.
+func (l *listener[T]) handle(ctx context.Context, n *pgconn.Notification) {
+	l.traceNotification(n)
+
+	log := l.db.mgr.rootLogger.With().Str("db", l.db.name).Str("channel", l.channel).Logger()
+	var payload T
+	if err := decodeNotification(n.Payload, &payload); err != nil {
+		log.Error().Err(err).Msg("sqldb: could not decode notification payload")
+		return
+	}
+	if err := l.handler(ctx, payload); err != nil {
+		log.Error().Err(err).Msg("sqldb: notification handler failed")
+	}
+}
+
+// traceNotification records the notification in the current trace, if any.
+func (l *listener[T]) traceNotification(n *pgconn.Notification) {
+	curr := l.db.mgr.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+
+	curr.Trace.LogMessage(trace2.LogMessageParams{
+		EventParams: trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+		},
+		Level: model.LevelInfo,
+		Msg:   "sqldb: notification received",
+		Stack: stack.Build(3),
+		Fields: []trace2.LogField{
+			{Key: "db", Value: l.db.name},
+			{Key: "channel", Value: n.Channel},
+			{Key: "payload_size", Value: len(n.Payload)},
+		},
+	})
+}
+
+// decodeNotification decodes a notification payload into dst.
+func decodeNotification[T any](payload string, dst *T) error {
+	switch dst := any(dst).(type) {
+	case *string:
+		*dst = payload
+		return nil
+	case *[]byte:
+		*dst = []byte(payload)
+		return nil
+	default:
+		return jsonapi.Default.UnmarshalFromString(payload, dst)
+	}
+}
+
+func (db *Database) addSubscription(sub *Subscription) {
+	db.subsMu.Lock()
+	defer db.subsMu.Unlock()
+	if db.subs == nil {
+		db.subs = make(map[*Subscription]struct{})
+	}
+	db.subs[sub] = struct{}{}
+}
+
+func (db *Database) removeSubscription(sub *Subscription) {
+	db.subsMu.Lock()
+	defer db.subsMu.Unlock()
+	delete(db.subs, sub)
+}
+
+// closeSubscriptions closes all active subscriptions.
+func (db *Database) closeSubscriptions() {
+	db.subsMu.Lock()
+	subs := make([]*Subscription, 0, len(db.subs))
+	for sub := range db.subs {
+		subs = append(subs, sub)
+	}
+	db.subsMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, sub := range subs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sub.Close()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,38 @@
+package sqldb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeNotification(t *testing.T) {
+	type event struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	var str string
+	if err := decodeNotification(`{"id": 1}`, &str); err != nil {
+		t.Fatalf("decode string: unexpected error: %v", err)
+	} else if str != `{"id": 1}` {
+		t.Errorf("decode string: got %q, want raw payload", str)
+	}
+
+	var raw []byte
+	if err := decodeNotification("hello", &raw); err != nil {
+		t.Fatalf("decode bytes: unexpected error: %v", err)
+	} else if string(raw) != "hello" {
+		t.Errorf("decode bytes: got %q, want %q", raw, "hello")
+	}
+
+	var ev event
+	if err := decodeNotification(`{"id": 1, "name": "foo"}`, &ev); err != nil {
+		t.Fatalf("decode json: unexpected error: %v", err)
+	} else if want := (event{ID: 1, Name: "foo"}); !reflect.DeepEqual(ev, want) {
+		t.Errorf("decode json: got %+v, want %+v", ev, want)
+	}
+
+	if err := decodeNotification("not json", &ev); err == nil {
+		t.Errorf("decode invalid json: expected error")
+	}
+}
@@ -2,6 +2,8 @@ package sqldb
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"sync"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -22,6 +24,11 @@ type Manager struct {
 
 	mu  sync.RWMutex
 	dbs map[string]*Database
+
+	// pools are the connection pools created, keyed by database name.
+	// They're tracked for reporting pool metrics.
+	poolsMu sync.Mutex
+	pools   map[string][]*pgxpool.Pool
 }
 
 func NewManager(runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager, rootLogger zerolog.Logger) *Manager {
@@ -31,6 +38,7 @@ func NewManager(runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsu
 		ts:         ts,
 		rootLogger: rootLogger,
 		dbs:        make(map[string]*Database),
+		pools:      make(map[string][]*pgxpool.Pool),
 	}
 }
 
@@ -98,10 +106,65 @@ func (mgr *Manager) getPool(encoreName, dbNameOverride string) (pool *pgxpool.Po
 	if err != nil {
 		panic("sqldb: setup db: " + err.Error())
 	}
+	mgr.trackPool(encoreName, pool)
 
 	return pool, true
 }
 
+// getReplicaPools returns connection pools for the read replicas
+// of the given database. Each time it's called it returns new pools.
+func (mgr *Manager) getReplicaPools(encoreName string) []*pgxpool.Pool {
+	var db *config.SQLDatabase
+	for _, d := range mgr.runtime.SQLDatabases {
+		if d.EncoreName == encoreName {
+			db = d
+			break
+		}
+	}
+	if db == nil {
+		return nil
+	}
+
+	pools := make([]*pgxpool.Pool, 0, len(db.ReadReplicas))
+	for i, connStr := range db.ReadReplicas {
+		cfg, err := pgxpool.ParseConfig(connStr)
+		if err != nil {
+			panic(fmt.Sprintf("sqldb: invalid read replica %d for database %s: %v", i, encoreName, err))
+		}
+
+		applyPoolConfig(cfg, db)
+		cfg.ConnConfig.Tracer = &pgxTracer{mgr: mgr}
+		pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
+		if err != nil {
+			panic("sqldb: setup read replica: " + err.Error())
+		}
+		mgr.trackPool(encoreName, pool)
+		pools = append(pools, pool)
+	}
+	return pools
+}
+
+// applyPoolConfig applies the connection pool settings for the database to cfg.
+func applyPoolConfig(cfg *pgxpool.Config, db *config.SQLDatabase) {
+	cfg.MaxConns = 30
+	if n := db.MaxConnections; n > 0 {
+		cfg.MaxConns = int32(n)
+	}
+	if n := db.MinConnections; n > 0 {
+		cfg.MinConns = min(int32(n), cfg.MaxConns)
+	}
+	if d := db.MaxIdleTime; d > 0 {
+		cfg.MaxConnIdleTime = d
+	}
+	if d := db.MaxConnLifetime; d > 0 {
+		cfg.MaxConnLifetime = d
+	}
+	if d := db.StatementTimeout; d > 0 {
+		// Set as a run-time parameter so the database enforces it on each connection.
+		cfg.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(d.Milliseconds(), 10)
+	}
+}
+
 func (mgr *Manager) Shutdown(p *shutdown.Process) error {
 	// Wait for all user code to finish before shutting down databases.
 	<-p.ServicesShutdownCompleted.Done()
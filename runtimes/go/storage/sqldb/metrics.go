@@ -0,0 +1,61 @@
+package sqldb
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"encore.dev/appruntime/infrasdk/metrics/system"
+)
+
+// Names of the connection pool metrics reported for each database.
+const (
+	metricConnsInUse   = "e_sys_sqldb_conns_in_use"
+	metricConnsIdle    = "e_sys_sqldb_conns_idle"
+	metricWaitCount    = "e_sys_sqldb_wait_count"
+	metricWaitDuration = "e_sys_sqldb_wait_duration_seconds"
+)
+
+// trackPool records a connection pool created for the given database,
+// so that its statistics are included in the pool metrics.
+func (mgr *Manager) trackPool(dbName string, pool *pgxpool.Pool) {
+	mgr.poolsMu.Lock()
+	defer mgr.poolsMu.Unlock()
+	mgr.pools[dbName] = append(mgr.pools[dbName], pool)
+}
+
+// poolMetrics reports the connection pool statistics of each database.
+// The statistics of the primary and read replica pools are combined.
+//
+// The wait count and wait duration are cumulative: the number of connection
+// acquisitions that had to wait for a connection to become available, and the
+// total time spent acquiring connections.
+func (mgr *Manager) poolMetrics() []system.Sample {
+	mgr.poolsMu.Lock()
+	defer mgr.poolsMu.Unlock()
+
+	names := slices.Sorted(maps.Keys(mgr.pools))
+
+	samples := make([]system.Sample, 0, 4*len(names))
+	for _, name := range names {
+		var inUse, idle, waitCount int64
+		var waitSecs float64
+		for _, pool := range mgr.pools[name] {
+			stat := pool.Stat()
+			inUse += int64(stat.AcquiredConns())
+			idle += int64(stat.IdleConns())
+			waitCount += stat.EmptyAcquireCount()
+			waitSecs += stat.AcquireDuration().Seconds()
+		}
+
+		labels := []system.Label{{Key: "database", Value: name}}
+		samples = append(samples,
+			system.Sample{Name: metricConnsInUse, Labels: labels, Value: float64(inUse)},
+			system.Sample{Name: metricConnsIdle, Labels: labels, Value: float64(idle)},
+			system.Sample{Name: metricWaitCount, Labels: labels, Value: float64(waitCount)},
+			system.Sample{Name: metricWaitDuration, Labels: labels, Value: waitSecs},
+		)
+	}
+	return samples
+}
@@ -33,6 +33,23 @@ type DatabaseConfig struct {
 	//
 	// Migrations are an ordered sequence of sql files of the format <number>_<description>.up.sql.
 	Migrations string
+
+	// Seed is the directory containing seed data for this database,
+	// applied after migrations in local development and tests.
+	// It defaults to the "seed" directory in the package directory, if it exists.
+	//
+	// The directory contains sql files applied in lexical order, each applied once,
+	// and optionally a Go program (package main) run by "encore db seed".
+	Seed string
+
+	// Tags are applied to the provisioned database server's resources as tags
+	// (or labels on GCP), for example {"cost-center": "billing"}.
+	// They must be constant and follow the same rules as pubsub.TopicConfig.Tags.
+	Tags map[string]string
+
+	// Regions restricts the regions the database can be provisioned in,
+	// such as []string{"eu-*", "europe-*"}. See pubsub.TopicConfig.Regions.
+	Regions []string
 }
 
 // Exec executes a query without returning any rows.
@@ -117,6 +134,28 @@ func Named(name constStr) *Database {
 	return Singleton.GetDB(string(name))
 }
 
+// Grant declares that the given services are allowed to access db,
+// to share a database between services.
+//
+// Once a database has been granted to one or more services, it can only be
+// accessed by the service declaring it and the granted services, which Encore
+// validates at compile time. In cloud environments Encore uses the grants to
+// provision credentials for each service that only provide access to
+// the databases the service is allowed to use.
+// Databases without any grants can be accessed by any service.
+//
+// The database must be declared with NewDatabase in the same package,
+// and the service names must be string literals. A call to Grant can only
+// be made when declaring a package level variable:
+//
+//	var _ = sqldb.Grant(db, "billing", "shipping")
+func Grant(db *Database, services ...constStr) *AccessGrant {
+	return &AccessGrant{}
+}
+
+// AccessGrant represents access to a database granted using Grant.
+type AccessGrant struct{}
+
 func getCurrentDB() *Database {
 	return Singleton.GetCurrentDB()
 }
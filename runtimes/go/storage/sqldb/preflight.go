@@ -0,0 +1,57 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"encore.dev/appruntime/shared/preflight"
+)
+
+// registerPreflightChecks registers a preflight check for
+// each database bound to the running application.
+func (mgr *Manager) registerPreflightChecks(r *preflight.Registry) {
+	for _, db := range mgr.runtime.SQLDatabases {
+		name := db.EncoreName
+		r.Register("sqldb", name, func(ctx context.Context) error {
+			return mgr.GetDB(name).preflight(ctx)
+		})
+	}
+}
+
+// preflight checks that the database is reachable and
+// that its migrations have been applied successfully.
+func (db *Database) preflight(ctx context.Context) error {
+	if db.noopDB {
+		return errNoopDB
+	}
+	db.init()
+
+	if err := db.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("database unreachable: %w", err)
+	}
+
+	// Report a dirty migration if there is one, and otherwise the latest migration.
+	var (
+		version int64
+		dirty   bool
+	)
+	err := db.pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations ORDER BY dirty DESC, version DESC LIMIT 1").
+		Scan(&version, &dirty)
+
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == "42P01": // undefined_table
+		return errors.New("migrations have not been applied: schema_migrations table not found")
+	case errors.Is(err, pgx.ErrNoRows):
+		return errors.New("migrations have not been applied")
+	case err != nil:
+		return fmt.Errorf("could not read applied migrations: %w", err)
+	case dirty:
+		return fmt.Errorf("migration %d is marked dirty: it failed to apply", version)
+	}
+	return nil
+}
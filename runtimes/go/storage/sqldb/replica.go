@@ -0,0 +1,170 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/stack"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/storage/sqldb/internal/stdlibdriver"
+)
+
+// ReadOnly returns a handle to the database that routes queries
+// to the database's read replicas, if any are configured.
+//
+// If the database has no read replicas, or a replica cannot be reached,
+// queries are automatically routed to the primary instead.
+// Traces of the queries record the node that served them.
+//
+// Writes made through the returned handle will fail when served by a replica,
+// so it should only be used for read-only queries.
+func (db *Database) ReadOnly() *Database {
+	if db.readOnly {
+		return db
+	}
+
+	db.readOnlyOnce.Do(func() {
+		db.init()
+		db.readOnlyDB = &Database{
+			name:     db.name,
+			origName: db.origName,
+			mgr:      db.mgr,
+			noopDB:   db.noopDB,
+			readOnly: true,
+			primary:  db,
+		}
+	})
+	return db.readOnlyDB
+}
+
+// initReadOnly initializes a read-only database handle.
+func (db *Database) initReadOnly() {
+	db.primary.init()
+
+	// Test databases are clones of the original database,
+	// so replicas of the original database do not apply to them.
+	if db.name == db.origName {
+		db.replicas = db.mgr.getReplicaPools(db.origName)
+	}
+
+	db.pool = db.primary.pool
+	if len(db.replicas) > 0 {
+		db.pool = db.replicas[0]
+	}
+	db.connStr = stdlibdriver.RegisterConnConfig(db.pool.Config().ConnConfig)
+}
+
+// queryPool returns the connection pool to use for the next query,
+// along with the name of the node it's connected to.
+//
+// The node name is empty for regular database handles, and is
+// either "primary" or "replica-N" for read-only handles.
+func (db *Database) queryPool() (pool *pgxpool.Pool, node string) {
+	switch {
+	case !db.readOnly:
+		return db.pool, ""
+	case len(db.replicas) == 0:
+		return db.primary.pool, "primary"
+	case len(db.replicas) == 1:
+		return db.replicas[0], "replica-0"
+	default:
+		idx := int(db.nextReplica.Add(1)-1) % len(db.replicas)
+		return db.replicas[idx], "replica-" + strconv.Itoa(idx)
+	}
+}
+
+// runQuery runs fn against the connection pool selected by queryPool, tracing it as described by p.
+// If fn fails because a read replica could not be reached, it is retried against the primary.
+func (db *Database) runQuery(ctx context.Context, p trace2.DBQueryStartParams, fn func(pool *pgxpool.Pool) error) error {
+	pool, node := db.queryPool()
+	err := db.tracedQuery(ctx, p, node, func() error { return fn(pool) })
+
+	if node != "" && node != "primary" && isReplicaUnavailable(ctx, err) {
+		err = db.tracedQuery(ctx, p, "primary", func() error { return fn(db.primary.pool) })
+	}
+	return err
+}
+
+// tracedQuery runs fn, emitting DBQueryStart and DBQueryEnd trace events around it.
+// The DBQueryStart event is described by p, and records the node serving the query, if non-empty.
+func (db *Database) tracedQuery(ctx context.Context, p trace2.DBQueryStartParams, node string, fn func() error) error {
+	var (
+		startEventID model.TraceEventID
+		eventParams  trace2.EventParams
+	)
+
+	curr := db.mgr.rt.Current()
+	if curr.Req != nil && curr.Trace != nil {
+		eventParams = trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+			DefLoc:  0,
+		}
+		p.EventParams = eventParams
+		p.Node = node
+		p.Stack = stack.Build(6)
+		startEventID = curr.Trace.DBQueryStart(p)
+	}
+
+	err := convertQueryErr(ctx, fn())
+
+	if startEventID > 0 {
+		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+	}
+	return err
+}
+
+// isReplicaUnavailable reports whether err indicates the read replica
+// could not serve the query at all, as opposed to the query itself failing.
+//
+// Only errors connecting to or communicating with the replica, and errors
+// reported by a replica that isn't accepting queries, cause the query to be
+// retried against the primary. Other errors are returned as-is, as the primary
+// would likely fail the same way.
+func isReplicaUnavailable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	} else if errors.Is(err, ErrQueryTimeout) {
+		// The replica served the query but it took too long.
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case strings.HasPrefix(pgErr.Code, "08"):
+			// Class 08 is "Connection Exception".
+			return true
+		case pgErr.Code == "57P01", pgErr.Code == "57P02", pgErr.Code == "57P03":
+			// The replica is shutting down, has crashed, or is not yet accepting connections.
+			return true
+		case pgErr.Code == "53300":
+			// The replica has too many connections.
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr):
+		// We failed to connect or talk to the replica.
+		return true
+	case pgconn.SafeToRetry(err):
+		// The query failed before it was sent to the replica.
+		return true
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		// The replica closed the connection.
+		return true
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestIsReplicaUnavailable(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "dial", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: true},
+		{name: "net", err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}, want: true},
+		{name: "eof", err: fmt.Errorf("receive message: %w", io.ErrUnexpectedEOF), want: true},
+		{name: "connection_exception", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "admin_shutdown", err: &pgconn.PgError{Code: "57P01"}, want: true},
+		{name: "cannot_connect_now", err: &pgconn.PgError{Code: "57P03"}, want: true},
+		{name: "too_many_connections", err: &pgconn.PgError{Code: "53300"}, want: true},
+		{name: "converted", err: convertErr(&pgconn.PgError{Code: "57P01"}), want: true},
+		{name: "statement_timeout", err: convertErr(&pgconn.PgError{Code: "57014", Message: "canceling statement due to statement timeout"}), want: false},
+		{name: "unique_violation", err: &pgconn.PgError{Code: "23505"}, want: false},
+		{name: "read_only", err: &pgconn.PgError{Code: "25006"}, want: false},
+		{name: "scan", err: errors.New("can't scan into dest[0]"), want: false},
+		{name: "canceled", ctx: canceled, err: &net.OpError{Op: "read", Err: context.Canceled}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if got := isReplicaUnavailable(ctx, tt.err); got != tt.want {
+				t.Errorf("isReplicaUnavailable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunQuery_Fallback(t *testing.T) {
+	newPool := func(host string) *pgxpool.Pool {
+		t.Helper()
+		// The pools are never connected to.
+		pool, err := pgxpool.New(context.Background(), "postgres://"+host+"/db")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(pool.Close)
+		return pool
+	}
+
+	log := &queryLogger{Log: trace2.NewLog()}
+	rt := reqtrack.New(zerolog.Nop(), nil, log)
+	primary := &Database{name: "db", origName: "db", mgr: &Manager{rt: rt}, pool: newPool("primary")}
+	replica := newPool("replica")
+	ro := &Database{name: "db", origName: "db", mgr: primary.mgr, readOnly: true, primary: primary, replicas: []*pgxpool.Pool{replica}}
+
+	tests := []struct {
+		name       string
+		replicaErr error
+		wantNodes  []string
+		wantErr    bool
+	}{
+		{name: "ok", wantNodes: []string{"replica-0"}},
+		{name: "unavailable", replicaErr: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, wantNodes: []string{"replica-0", "primary"}},
+		{name: "query_error", replicaErr: &pgconn.PgError{Code: "23505"}, wantNodes: []string{"replica-0"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log.queries = nil
+			rt.BeginRequest(&model.Request{Traced: true})
+			defer rt.FinishRequest(true)
+
+			err := ro.runQuery(context.Background(), trace2.DBQueryStartParams{Query: "SELECT 1"}, func(pool *pgxpool.Pool) error {
+				if pool == replica {
+					return tt.replicaErr
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got err %v, want error %v", err, tt.wantErr)
+			}
+
+			var nodes []string
+			for _, q := range log.queries {
+				if q.Query != "SELECT 1" {
+					t.Errorf("got traced query %q, want %q", q.Query, "SELECT 1")
+				}
+				nodes = append(nodes, q.Node)
+			}
+			if fmt.Sprint(nodes) != fmt.Sprint(tt.wantNodes) {
+				t.Errorf("got traced nodes %v, want %v", nodes, tt.wantNodes)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package sqldb
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"encore.dev/storage/sqldb/sqlerr"
+)
+
+// IsolationLevel is the isolation level of a transaction.
+// See https://www.postgresql.org/docs/current/transaction-iso.html.
+type IsolationLevel string
+
+const (
+	// DefaultIsolation uses the database's default isolation level,
+	// which is ReadCommitted unless configured otherwise.
+	DefaultIsolation IsolationLevel = ""
+	ReadCommitted    IsolationLevel = "read committed"
+	RepeatableRead   IsolationLevel = "repeatable read"
+	Serializable     IsolationLevel = "serializable"
+)
+
+// TxOptions configures a transaction run with RunInTx.
+type TxOptions struct {
+	// Isolation is the isolation level of the transaction.
+	// If empty the database's default isolation level is used.
+	Isolation IsolationLevel
+
+	// ReadOnly specifies whether the transaction is read-only.
+	ReadOnly bool
+
+	// MaxAttempts is the maximum number of times the transaction is attempted,
+	// including the first attempt. If zero it defaults to 5.
+	// Use 1 to disable retries.
+	MaxAttempts int
+}
+
+const (
+	defaultTxMaxAttempts  = 5
+	txRetryInitialBackoff = 10 * time.Millisecond
+	txRetryMaxBackoff     = 1 * time.Second
+)
+
+// RunInTx runs fn within a transaction, committing it if fn returns nil
+// and rolling it back otherwise.
+//
+// If the transaction fails due to a serialization failure or a deadlock,
+// either when running fn or when committing, it is retried from the start
+// with a new transaction, up to opts.MaxAttempts times. Since fn may be called
+// multiple times it must not have side effects outside of the transaction.
+// fn must not commit or roll back the transaction itself.
+//
+// Traces record the attempt number of each query made in the transaction.
+func (db *Database) RunInTx(ctx context.Context, opts TxOptions, fn func(tx *Tx) error) error {
+	if db.noopDB {
+		return errNoopDB
+	}
+
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultTxMaxAttempts
+	}
+	txOpts := pgx.TxOptions{IsoLevel: pgx.TxIsoLevel(opts.Isolation)}
+	if opts.ReadOnly {
+		txOpts.AccessMode = pgx.ReadOnly
+	}
+
+	backoff := txRetryInitialBackoff
+	for attempt := 1; ; attempt++ {
+		tx, err := db.beginTx(ctx, txOpts, attempt)
+		if err != nil {
+			return err
+		}
+
+		if err = runTxFunc(tx, fn); err == nil {
+			err = tx.commit()
+		} else {
+			_ = tx.rollback()
+		}
+		if err == nil || attempt >= maxAttempts || !isRetryableTxErr(err) {
+			return err
+		}
+
+		// Wait with jittered exponential backoff before retrying.
+		wait := backoff/2 + rand.N(backoff/2+1)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, txRetryMaxBackoff)
+	}
+}
+
+// runTxFunc calls fn, rolling back the transaction if fn panics.
+func runTxFunc(tx *Tx, fn func(tx *Tx) error) error {
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.rollback()
+			panic(r)
+		}
+	}()
+	return fn(tx)
+}
+
+// isRetryableTxErr reports whether err indicates the transaction
+// failed due to concurrent transactions and can be retried.
+func isRetryableTxErr(err error) bool {
+	switch ErrCode(err) {
+	case sqlerr.SerializationFailure, sqlerr.DeadlockDetected:
+		return true
+	default:
+		return false
+	}
+}
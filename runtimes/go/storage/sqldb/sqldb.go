@@ -34,6 +34,7 @@ type Tx struct {
 	std pgx.Tx
 
 	startID model.TraceEventID
+	attempt int // attempt number when run with RunInTx; 1 otherwise
 }
 
 // Commit commits the given transaction.
@@ -62,6 +63,7 @@ func (tx *Tx) commit() error {
 			Commit:  true,
 			Err:     err,
 			Stack:   stack.Build(4),
+			Retries: tx.attempt - 1,
 		})
 	}
 
@@ -84,6 +86,7 @@ func (tx *Tx) rollback() error {
 			Commit:  false,
 			Err:     err,
 			Stack:   stack.Build(4),
+			Retries: tx.attempt - 1,
 		})
 	}
 
@@ -96,6 +99,10 @@ func (tx *Tx) Exec(ctx context.Context, query string, args ...interface{}) (Exec
 
 func (tx *Tx) exec(ctx context.Context, query string, args ...interface{}) (ExecResult, error) {
 	curr := tx.mgr.rt.Current()
+	ctx, cancel := withQueryTimeout(ctx)
+	if cancel != nil {
+		defer cancel()
+	}
 
 	var (
 		startEventID model.TraceEventID
@@ -113,12 +120,13 @@ func (tx *Tx) exec(ctx context.Context, query string, args ...interface{}) (Exec
 			EventParams: eventParams,
 			TxStartID:   tx.startID,
 			Query:       query,
+			Attempt:     tx.attempt,
 			Stack:       stack.Build(4),
 		})
 	}
 
 	res, err := tx.std.Exec(markTraced(ctx), query, args...)
-	err = convertErr(err)
+	err = convertQueryErr(ctx, err)
 
 	if startEventID > 0 {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
@@ -129,6 +137,7 @@ func (tx *Tx) exec(ctx context.Context, query string, args ...interface{}) (Exec
 
 func (tx *Tx) Query(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
 	curr := tx.mgr.rt.Current()
+	ctx, cancel := withQueryTimeout(ctx)
 
 	var (
 		startEventID model.TraceEventID
@@ -145,26 +154,31 @@ func (tx *Tx) Query(ctx context.Context, query string, args ...interface{}) (*Ro
 		startEventID = curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
 			EventParams: eventParams,
 			Query:       query,
+			Attempt:     tx.attempt,
 			TxStartID:   tx.startID,
 			Stack:       stack.Build(4),
 		})
 	}
 
 	rows, err := tx.std.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
+	err = convertQueryErr(ctx, err)
 
 	if startEventID > 0 {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
 	}
 
 	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
 		return nil, err
 	}
-	return &Rows{std: rows}, nil
+	return &Rows{std: withCancel(ctx, rows, cancel)}, nil
 }
 
 func (tx *Tx) QueryRow(ctx context.Context, query string, args ...interface{}) *Row {
 	curr := tx.mgr.rt.Current()
+	ctx, cancel := withQueryTimeout(ctx)
 
 	var (
 		startEventID model.TraceEventID
@@ -181,6 +195,7 @@ func (tx *Tx) QueryRow(ctx context.Context, query string, args ...interface{}) *
 		curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
 			EventParams: eventParams,
 			Query:       query,
+			Attempt:     tx.attempt,
 			TxStartID:   tx.startID,
 			Stack:       stack.Build(4),
 		})
@@ -189,8 +204,11 @@ func (tx *Tx) QueryRow(ctx context.Context, query string, args ...interface{}) *
 	// pgx currently does not support .Err() on Row.
 	// Work around this by using Query.
 	rows, err := tx.std.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
-	r := &Row{rows: rows, err: err}
+	err = convertQueryErr(ctx, err)
+	if err != nil && cancel != nil {
+		cancel()
+	}
+	r := &Row{rows: withCancel(ctx, rows, cancel), err: err}
 
 	if startEventID > 0 {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
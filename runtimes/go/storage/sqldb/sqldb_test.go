@@ -1,12 +1,19 @@
 package sqldb
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
+	"time"
 	_ "unsafe" // for go:linkname
 
+	"github.com/jackc/pgx/v5/pgconn"
+
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/beta/errs"
 )
 
 func TestDBConf(t *testing.T) {
@@ -91,3 +98,81 @@ func TestDBConf(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyPoolConfig(t *testing.T) {
+	cfg, err := dbConf(&config.SQLServer{Host: "hostname"}, &config.SQLDatabase{
+		DatabaseName:     "dbname",
+		User:             "user",
+		MinConnections:   50,
+		MaxConnections:   20,
+		MaxIdleTime:      5 * time.Minute,
+		MaxConnLifetime:  2 * time.Hour,
+		StatementTimeout: 1500 * time.Millisecond,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.MaxConns != 20 {
+		t.Errorf("got max conns %d, want 20", cfg.MaxConns)
+	} else if cfg.MinConns != 20 {
+		t.Errorf("got min conns %d, want 20", cfg.MinConns)
+	} else if cfg.MaxConnIdleTime != 5*time.Minute {
+		t.Errorf("got max idle time %v, want 5m", cfg.MaxConnIdleTime)
+	} else if cfg.MaxConnLifetime != 2*time.Hour {
+		t.Errorf("got max conn lifetime %v, want 2h", cfg.MaxConnLifetime)
+	} else if got := cfg.ConnConfig.RuntimeParams["statement_timeout"]; got != "1500" {
+		t.Errorf("got statement timeout %q, want %q", got, "1500")
+	}
+}
+
+func TestConvertTimeoutErr(t *testing.T) {
+	serverErr := convertErr(&pgconn.PgError{Code: "57014", Message: "canceling statement due to statement timeout"})
+	if !errors.Is(serverErr, ErrQueryTimeout) {
+		t.Errorf("got %v, want ErrQueryTimeout", serverErr)
+	} else if pgerr := (*Error)(nil); !errors.As(serverErr, &pgerr) || pgerr.DatabaseCode != "57014" {
+		t.Errorf("got %v, want *Error with database code 57014", serverErr)
+	} else if code := errs.Code(serverErr); code != errs.DeadlineExceeded {
+		t.Errorf("got errs code %v, want DeadlineExceeded", code)
+	}
+
+	userErr := convertErr(&pgconn.PgError{Code: "57014", Message: "canceling statement due to user request"})
+	if errors.Is(userErr, ErrQueryTimeout) {
+		t.Errorf("got %v, want non-timeout error", userErr)
+	}
+
+	ctx, cancel := withQueryTimeout(WithTimeout(context.Background(), time.Nanosecond))
+	defer cancel()
+	<-ctx.Done()
+	clientErr := convertQueryErr(ctx, ctx.Err())
+	if !errors.Is(clientErr, ErrQueryTimeout) || !errors.Is(clientErr, context.DeadlineExceeded) {
+		t.Errorf("got %v, want ErrQueryTimeout wrapping context.DeadlineExceeded", clientErr)
+	}
+}
+
+func TestCopyTraceQuery(t *testing.T) {
+	got := copyTraceQuery("myschema.users", []string{"id", "name"})
+	want := `COPY "myschema"."users" ("id", "name") FROM STDIN`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestIsRetryableTxErr(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: errors.New("some error"), want: false},
+		{err: convertErr(&pgconn.PgError{Code: "40001"}), want: true},
+		{err: convertErr(&pgconn.PgError{Code: "40P01"}), want: true},
+		{err: convertErr(&pgconn.PgError{Code: "23505"}), want: false},
+		{err: fmt.Errorf("wrapped: %w", convertErr(&pgconn.PgError{Code: "40001"})), want: true},
+	}
+	for _, tt := range tests {
+		if got := isRetryableTxErr(tt.err); got != tt.want {
+			t.Errorf("isRetryableTxErr(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
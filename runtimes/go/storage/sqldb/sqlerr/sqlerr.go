@@ -46,6 +46,10 @@ const (
 	// can be detected.
 	DeadlockDetected Code = "deadlock_detected"
 
+	// SerializationFailure is reported when a transaction could not be
+	// serialized due to concurrent updates. The transaction can be retried.
+	SerializationFailure Code = "serialization_failure"
+
 	// TooManyConnections is reported when the database rejects a connection request
 	// due to reaching the maximum number of connections.
 	// This is different from blocking waiting on a connection pool.
@@ -69,6 +73,8 @@ func MapCode(code string) Code {
 		return ExcludeViolation
 	case "25P02":
 		return TransactionFailed
+	case "40001":
+		return SerializationFailure
 	case "40P01":
 		return DeadlockDetected
 	case "53300":
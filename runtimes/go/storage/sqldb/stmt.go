@@ -0,0 +1,336 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/stack"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+// Stmt is a named prepared statement.
+//
+// The statement is prepared lazily on each database connection the first
+// time it is executed on that connection, and the prepared statement is then
+// reused for subsequent executions on the same connection.
+//
+// Queries executed through a Stmt are traced with the statement name,
+// making it easy to identify hot queries in traces.
+// Use (*Tx).Stmt to execute the statement as part of a transaction.
+//
+// A Stmt is safe for concurrent use by multiple goroutines.
+type Stmt struct {
+	db   *Database
+	name string
+	sql  string
+}
+
+// Prepare creates a named prepared statement for the given query.
+// The name must be unique within the database and must not be empty;
+// Prepare panics otherwise.
+//
+// Prepare does not contact the database; the statement is prepared
+// on each connection the first time it is used. It is therefore
+// safe to call Prepare when declaring a package level variable:
+//
+//	var getUser = db.Prepare("get_user", "SELECT name FROM users WHERE id = $1")
+func (db *Database) Prepare(name, query string) *Stmt {
+	if name == "" {
+		panic("sqldb: Prepare called with empty statement name")
+	}
+
+	// Statements are prepared on the connections of the database's read replicas
+	// as well, so the names of the read-only handle's statements are tracked by the primary.
+	root := db
+	if db.readOnly {
+		root = db.primary
+	}
+	root.stmtsMu.Lock()
+	defer root.stmtsMu.Unlock()
+	if _, exists := root.stmts[name]; exists {
+		panic(fmt.Sprintf("sqldb: statement %q is already prepared on database %q", name, db.name))
+	}
+	if root.stmts == nil {
+		root.stmts = make(map[string]struct{})
+	}
+	root.stmts[name] = struct{}{}
+
+	return &Stmt{db: db, name: name, sql: query}
+}
+
+// Name returns the name of the prepared statement.
+func (s *Stmt) Name() string { return s.name }
+
+// Exec executes the prepared statement without returning any rows.
+// The args are for any placeholder parameters in the query.
+//
+// See (*database/sql.Stmt).ExecContext() for additional documentation.
+func (s *Stmt) Exec(ctx context.Context, args ...interface{}) (ExecResult, error) {
+	if s.db.noopDB {
+		return nil, errNoopDB
+	}
+	s.db.init()
+
+	ctx, cancel := withQueryTimeout(ctx)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	var res ExecResult
+	err := s.db.runQuery(ctx, s.traceParams(), func(pool *pgxpool.Pool) error {
+		conn, err := s.acquire(ctx, pool)
+		if err != nil {
+			return err
+		}
+		defer conn.Release()
+		res, err = conn.Exec(markTraced(ctx), s.name, args...)
+		return err
+	})
+	return res, err
+}
+
+// Query executes the prepared statement, returning rows.
+// The args are for any placeholder parameters in the query.
+//
+// See (*database/sql.Stmt).QueryContext() for additional documentation.
+func (s *Stmt) Query(ctx context.Context, args ...interface{}) (*Rows, error) {
+	if s.db.noopDB {
+		return nil, errNoopDB
+	}
+	s.db.init()
+
+	ctx, cancel := withQueryTimeout(ctx)
+
+	var rows pgx.Rows
+	err := s.db.runQuery(ctx, s.traceParams(), func(pool *pgxpool.Pool) (err error) {
+		rows, err = s.query(ctx, pool, args...)
+		return err
+	})
+	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
+		return nil, err
+	}
+	return &Rows{std: withCancel(ctx, rows, cancel)}, nil
+}
+
+// QueryRow executes the prepared statement, which is expected to return at most one row.
+//
+// See (*database/sql.Stmt).QueryRowContext() for additional documentation.
+func (s *Stmt) QueryRow(ctx context.Context, args ...interface{}) *Row {
+	if s.db.noopDB {
+		return &Row{err: errNoopDB}
+	}
+	s.db.init()
+
+	ctx, cancel := withQueryTimeout(ctx)
+
+	var rows pgx.Rows
+	err := s.db.runQuery(ctx, s.traceParams(), func(pool *pgxpool.Pool) (err error) {
+		rows, err = s.query(ctx, pool, args...)
+		return err
+	})
+	if err != nil && cancel != nil {
+		cancel()
+	}
+	return &Row{rows: withCancel(ctx, rows, cancel), err: err}
+}
+
+// acquire acquires a connection from pool and ensures
+// the statement is prepared on it. Queries of read-only handles falling back
+// to the primary thus prepare the statement on the primary's connections.
+func (s *Stmt) acquire(ctx context.Context, pool *pgxpool.Pool) (*pgxpool.Conn, error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare is idempotent and pgx caches prepared statements per connection,
+	// so this only round-trips to the database the first time on each connection.
+	if _, err := conn.Conn().Prepare(markTraced(ctx), s.name, s.sql); err != nil {
+		conn.Release()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// query executes the statement and returns rows that release
+// the underlying connection back to the pool once closed.
+func (s *Stmt) query(ctx context.Context, pool *pgxpool.Pool, args ...interface{}) (pgx.Rows, error) {
+	conn, err := s.acquire(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(markTraced(ctx), s.name, args...)
+	if err != nil {
+		conn.Release()
+		return nil, err
+	}
+	return &stmtRows{Rows: rows, conn: conn}, nil
+}
+
+// traceParams describes the statement's queries in traces.
+func (s *Stmt) traceParams() trace2.DBQueryStartParams {
+	return trace2.DBQueryStartParams{Query: s.sql, StmtName: s.name}
+}
+
+// TxStmt is a named prepared statement bound to a transaction.
+//
+// The statement is prepared on the transaction's connection
+// the first time it is executed in the transaction.
+type TxStmt struct {
+	tx   *Tx
+	stmt *Stmt
+}
+
+// Stmt returns the prepared statement s bound to the transaction,
+// so that it executes as part of the transaction.
+//
+// See (*database/sql.Tx).StmtContext() for additional documentation.
+func (tx *Tx) Stmt(s *Stmt) *TxStmt {
+	return &TxStmt{tx: tx, stmt: s}
+}
+
+// Exec executes the prepared statement in the transaction without returning any rows.
+// The args are for any placeholder parameters in the query.
+//
+// See (*database/sql.Stmt).ExecContext() for additional documentation.
+func (s *TxStmt) Exec(ctx context.Context, args ...interface{}) (ExecResult, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	if cancel != nil {
+		defer cancel()
+	}
+	eventParams, startEventID, curr := s.traceStart()
+
+	var res ExecResult
+	err := s.prepare(ctx)
+	if err == nil {
+		res, err = s.tx.std.Exec(markTraced(ctx), s.stmt.name, args...)
+	}
+	err = convertQueryErr(ctx, err)
+
+	if startEventID > 0 {
+		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+	}
+
+	return res, err
+}
+
+// Query executes the prepared statement in the transaction, returning rows.
+// The args are for any placeholder parameters in the query.
+//
+// See (*database/sql.Stmt).QueryContext() for additional documentation.
+func (s *TxStmt) Query(ctx context.Context, args ...interface{}) (*Rows, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	eventParams, startEventID, curr := s.traceStart()
+
+	rows, err := s.query(ctx, args...)
+	err = convertQueryErr(ctx, err)
+
+	if startEventID > 0 {
+		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+	}
+
+	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
+		return nil, err
+	}
+	return &Rows{std: withCancel(ctx, rows, cancel)}, nil
+}
+
+// QueryRow executes the prepared statement in the transaction,
+// which is expected to return at most one row.
+//
+// See (*database/sql.Stmt).QueryRowContext() for additional documentation.
+func (s *TxStmt) QueryRow(ctx context.Context, args ...interface{}) *Row {
+	ctx, cancel := withQueryTimeout(ctx)
+	eventParams, startEventID, curr := s.traceStart()
+
+	rows, err := s.query(ctx, args...)
+	err = convertQueryErr(ctx, err)
+	if err != nil && cancel != nil {
+		cancel()
+	}
+	r := &Row{rows: withCancel(ctx, rows, cancel), err: err}
+
+	if startEventID > 0 {
+		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+	}
+
+	return r
+}
+
+// prepare ensures the statement is prepared on the transaction's connection.
+func (s *TxStmt) prepare(ctx context.Context) error {
+	// Prepare is idempotent and pgx caches prepared statements per connection,
+	// so this only round-trips to the database the first time on the connection.
+	_, err := s.tx.std.Prepare(markTraced(ctx), s.stmt.name, s.stmt.sql)
+	return err
+}
+
+func (s *TxStmt) query(ctx context.Context, args ...interface{}) (pgx.Rows, error) {
+	if err := s.prepare(ctx); err != nil {
+		return nil, err
+	}
+	return s.tx.std.Query(markTraced(ctx), s.stmt.name, args...)
+}
+
+// traceStart emits a DBQueryStart event for the statement as part of the transaction,
+// if the current request is traced.
+func (s *TxStmt) traceStart() (trace2.EventParams, model.TraceEventID, reqtrack.Current) {
+	var (
+		startEventID model.TraceEventID
+		eventParams  trace2.EventParams
+	)
+
+	curr := s.tx.mgr.rt.Current()
+	if curr.Req != nil && curr.Trace != nil {
+		eventParams = trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+			DefLoc:  0,
+		}
+		startEventID = curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
+			EventParams: eventParams,
+			TxStartID:   s.tx.startID,
+			Query:       s.stmt.sql,
+			Attempt:     s.tx.attempt,
+			StmtName:    s.stmt.name,
+			Stack:       stack.Build(5),
+		})
+	}
+	return eventParams, startEventID, curr
+}
+
+// stmtRows wraps pgx.Rows to release the connection
+// back to the pool when the rows are closed or exhausted.
+type stmtRows struct {
+	pgx.Rows
+	conn *pgxpool.Conn
+}
+
+func (r *stmtRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.Close()
+	return false
+}
+
+func (r *stmtRows) Close() {
+	r.Rows.Close()
+	if r.conn != nil {
+		r.conn.Release()
+		r.conn = nil
+	}
+}
@@ -0,0 +1,158 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestPrepare_UniqueNames(t *testing.T) {
+	mgr := &Manager{}
+	db := &Database{name: "db", origName: "db", mgr: mgr}
+	other := &Database{name: "other", origName: "other", mgr: mgr}
+
+	mustPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		fn()
+	}
+
+	if s := db.Prepare("get_user", "SELECT 1"); s.Name() != "get_user" {
+		t.Errorf("got name %q, want %q", s.Name(), "get_user")
+	}
+	mustPanic("empty name", func() { db.Prepare("", "SELECT 1") })
+	mustPanic("duplicate name", func() { db.Prepare("get_user", "SELECT 2") })
+
+	// The read-only handle shares the names of the database's statements.
+	ro := &Database{name: "db", origName: "db", mgr: mgr, readOnly: true, primary: db}
+	mustPanic("duplicate read-only name", func() { ro.Prepare("get_user", "SELECT 1") })
+	ro.Prepare("list_users", "SELECT 1")
+	mustPanic("duplicate primary name", func() { db.Prepare("list_users", "SELECT 1") })
+
+	// Names only need to be unique within a database.
+	other.Prepare("get_user", "SELECT 1")
+}
+
+// queryLogger is a trace logger recording the DBQueryStart events.
+type queryLogger struct {
+	*trace2.Log
+
+	mu      sync.Mutex
+	queries []trace2.DBQueryStartParams
+}
+
+func (l *queryLogger) DBQueryStart(p trace2.DBQueryStartParams) trace2.EventID {
+	l.mu.Lock()
+	l.queries = append(l.queries, p)
+	l.mu.Unlock()
+	return l.Log.DBQueryStart(p)
+}
+
+func (l *queryLogger) NewLogger() trace2.Logger { return l }
+func (l *queryLogger) SampleTrace() bool        { return true }
+
+func TestStmt_Trace(t *testing.T) {
+	log := &queryLogger{Log: trace2.NewLog()}
+	rt := reqtrack.New(zerolog.Nop(), nil, log)
+	rt.BeginRequest(&model.Request{Traced: true})
+	defer rt.FinishRequest(true)
+
+	db := &Database{name: "db", origName: "db", mgr: &Manager{rt: rt}}
+	stmt := db.Prepare("get_user", "SELECT name FROM users WHERE id = $1")
+	_ = db.tracedQuery(context.Background(), stmt.traceParams(), "replica-0", func() error { return nil })
+	tx := &Tx{mgr: db.mgr, startID: 42, attempt: 2}
+	tx.Stmt(stmt).traceStart()
+
+	got := log.queries
+	if len(got) != 2 {
+		t.Fatalf("got %d query events, want 2", len(got))
+	}
+	for i, p := range got {
+		if p.StmtName != "get_user" || p.Query != "SELECT name FROM users WHERE id = $1" {
+			t.Errorf("event %d: got statement %q with query %q, want get_user with its query", i, p.StmtName, p.Query)
+		}
+	}
+	if got[0].TxStartID != 0 || got[1].TxStartID != 42 {
+		t.Errorf("got tx start ids %d and %d, want 0 and 42", got[0].TxStartID, got[1].TxStartID)
+	}
+	if got[0].Attempt != 0 || got[1].Attempt != 2 {
+		t.Errorf("got attempts %d and %d, want 0 and 2", got[0].Attempt, got[1].Attempt)
+	}
+	if got[0].Node != "replica-0" || got[1].Node != "" {
+		t.Errorf("got nodes %q and %q, want replica-0 and none", got[0].Node, got[1].Node)
+	}
+}
+
+func TestStmt_Fallback(t *testing.T) {
+	newPool := func() *pgxpool.Pool {
+		t.Helper()
+		// Nothing listens on the port, so connecting fails as for an unavailable node.
+		pool, err := pgxpool.New(context.Background(), "postgres://127.0.0.1:1/db?sslmode=disable")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(pool.Close)
+		return pool
+	}
+
+	log := &queryLogger{Log: trace2.NewLog()}
+	rt := reqtrack.New(zerolog.Nop(), nil, log)
+	primary := &Database{name: "db", origName: "db", mgr: &Manager{rt: rt}, pool: newPool()}
+	ro := &Database{name: "db", origName: "db", mgr: primary.mgr, readOnly: true, primary: primary, replicas: []*pgxpool.Pool{newPool()}}
+	primary.initOnce.Do(func() {})
+	ro.initOnce.Do(func() {})
+	stmt := ro.Prepare("get_user", "SELECT name FROM users WHERE id = $1")
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{"exec", func(ctx context.Context) error {
+			_, err := stmt.Exec(ctx, 1)
+			return err
+		}},
+		{"query", func(ctx context.Context) error {
+			_, err := stmt.Query(ctx, 1)
+			return err
+		}},
+		{"query_row", func(ctx context.Context) error {
+			var name string
+			return stmt.QueryRow(ctx, 1).Scan(&name)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log.queries = nil
+			rt.BeginRequest(&model.Request{Traced: true})
+			defer rt.FinishRequest(true)
+
+			// Both nodes are unavailable, so the query fails after falling back to the primary.
+			if err := tt.run(context.Background()); err == nil {
+				t.Fatal("got no error, want the query to fail")
+			}
+
+			var nodes []string
+			for _, q := range log.queries {
+				if q.StmtName != "get_user" {
+					t.Errorf("got traced statement %q, want %q", q.StmtName, "get_user")
+				}
+				nodes = append(nodes, q.Node)
+			}
+			if want := []string{"replica-0", "primary"}; fmt.Sprint(nodes) != fmt.Sprint(want) {
+				t.Errorf("got traced nodes %v, want %v", nodes, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"encore.dev/beta/errs"
+)
+
+// ErrQueryTimeout is reported when a query is canceled because it exceeded
+// its statement timeout, either the database's configured statement timeout
+// or a per-query timeout set with WithTimeout.
+// It must be tested against with errors.Is.
+var ErrQueryTimeout = errors.New("sqldb: query canceled due to statement timeout")
+
+type timeoutKey struct{}
+
+// WithTimeout returns a copy of ctx that limits the duration of each
+// query executed with it to d, overriding the database's statement timeout
+// for those queries. Queries exceeding the timeout are canceled and
+// report an error matching ErrQueryTimeout.
+//
+// Unlike context.WithTimeout the timeout applies to each query separately,
+// starting when the query is executed. The database's statement timeout
+// is enforced by the database server and therefore still applies,
+// so a per-query timeout can only be used to shorten it.
+// If d is zero or negative no per-query timeout is applied.
+func WithTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// withQueryTimeout applies the per-query timeout set with WithTimeout, if any.
+// If no timeout is set it returns ctx unmodified and a nil cancel func.
+func withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d, ok := ctx.Value(timeoutKey{}).(time.Duration); ok && d > 0 {
+		return context.WithTimeoutCause(ctx, d, ErrQueryTimeout)
+	}
+	return ctx, nil
+}
+
+// convertQueryErr is like convertErr but additionally reports
+// whether the query executed with ctx exceeded its per-query timeout.
+func convertQueryErr(ctx context.Context, err error) error {
+	return errs.DropStackFrame(convertErr(markTimeout(ctx, err)))
+}
+
+// markTimeout wraps err as a timeout error if ctx exceeded
+// its per-query timeout set with WithTimeout.
+func markTimeout(ctx context.Context, err error) error {
+	if err == nil || context.Cause(ctx) != ErrQueryTimeout {
+		return err
+	}
+	var pgerr *pgconn.PgError
+	if errors.As(err, &pgerr) {
+		err = convertPgError(pgerr)
+	}
+	return &timeoutError{err: err}
+}
+
+// isStatementTimeout reports whether the database canceled the query
+// because it exceeded the statement_timeout setting.
+func isStatementTimeout(err *pgconn.PgError) bool {
+	return err.Code == "57014" && strings.Contains(err.Message, "statement timeout")
+}
+
+// timeoutError wraps an error caused by a query exceeding its statement timeout.
+type timeoutError struct {
+	err error
+}
+
+func (e *timeoutError) Error() string {
+	return ErrQueryTimeout.Error() + ": " + e.err.Error()
+}
+
+func (e *timeoutError) Unwrap() []error {
+	return []error{ErrQueryTimeout, e.err}
+}
+
+// timeoutRows wraps pgx.Rows to release the per-query timeout
+// when the rows are closed or exhausted.
+type timeoutRows struct {
+	pgx.Rows
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// withCancel wraps rows, queried with ctx, to call cancel once the rows are closed.
+// If cancel is nil rows is returned unmodified.
+func withCancel(ctx context.Context, rows pgx.Rows, cancel context.CancelFunc) pgx.Rows {
+	if cancel == nil || rows == nil {
+		return rows
+	}
+	return &timeoutRows{Rows: rows, ctx: ctx, cancel: cancel}
+}
+
+func (r *timeoutRows) Err() error {
+	return markTimeout(r.ctx, r.Rows.Err())
+}
+
+func (r *timeoutRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.Close()
+	return false
+}
+
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
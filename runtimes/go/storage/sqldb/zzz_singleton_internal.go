@@ -3,8 +3,10 @@
 package sqldb
 
 import (
+	"encore.dev/appruntime/infrasdk/metrics/system"
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/preflight"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
@@ -21,4 +23,6 @@ var Singleton *Manager
 func init() {
 	Singleton = NewManager(appconf.Runtime, reqtrack.Singleton, testsupport.Singleton, logging.RootLogger)
 	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	Singleton.registerPreflightChecks(preflight.Singleton)
+	system.RegisterCollector(Singleton.poolMetrics)
 }
@@ -187,6 +187,13 @@ export interface StreamOutWithResponse<Request, Response>
 }
 
 export interface StreamOut<Response> {
+  /**
+   * Sends a message to the client.
+   *
+   * Messages are queued while the client catches up, and the returned promise
+   * resolves once there is room in the queue. Await it to avoid sending
+   * messages faster than the client can receive them.
+   */
   send: (msg: Response) => Promise<void>;
   close: () => Promise<void>;
 }
@@ -29,7 +29,8 @@ export class IterableSocket {
     this.socket = socket;
   }
 
-  send(msg: Record<string, any>): void {
+  // send waits for room in the send queue if it's full.
+  send(msg: Record<string, any>): Promise<void> {
     return this.socket.send(msg);
   }
   recv(): Promise<Record<string, any>> {
@@ -58,7 +59,8 @@ export class Sink {
     this.sink = sink;
   }
 
-  send(msg: Record<string, any>): void {
+  // send waits for room in the send queue if it's full.
+  send(msg: Record<string, any>): Promise<void> {
     return this.sink.send(msg);
   }
 
@@ -76,9 +76,10 @@ impl Socket {
 
     #[napi]
     #[allow(dead_code)]
-    pub fn send(&self, msg: PVals) -> napi::Result<()> {
+    pub async fn send(&self, msg: PVals) -> napi::Result<()> {
         self.inner
             .send(msg.0)
+            .await
             .map_err(|e| napi::Error::new(napi::Status::Unknown, e))
     }
 
@@ -113,9 +114,10 @@ impl Sink {
 
     #[napi]
     #[allow(dead_code)]
-    pub fn send(&self, msg: PVals) -> napi::Result<()> {
+    pub async fn send(&self, msg: PVals) -> napi::Result<()> {
         self.inner
             .send(msg.0)
+            .await
             .map_err(|e| napi::Error::new(napi::Status::Unknown, e))
     }
 
@@ -89,6 +89,7 @@ impl MetaBuilder<'_> {
                 buckets: vec![],   // filled in later
                 metrics: vec![],   // filled in later
                 has_config: false, // TODO change when config is supported
+                configs: vec![],
 
                 // We no longer care about migrations in a service, so just set
                 // this to the empty array. The field is required for backwards compatibility.
@@ -232,6 +233,7 @@ impl MetaBuilder<'_> {
                         streaming_request: ep.streaming_request,
                         streaming_response: ep.streaming_response,
                         static_assets,
+                        strict_decoding: false,
                     };
 
                     let Some(service_idx) =
@@ -728,6 +730,7 @@ impl MetaBuilder<'_> {
             ordering_key: topic.ordering_attribute.clone().unwrap_or_default(),
             publishers: vec![],    // filled in below
             subscriptions: vec![], // filled in below
+            ordered: false,
         })
     }
 
@@ -756,6 +759,8 @@ impl MetaBuilder<'_> {
                 max_backoff: sub.config.max_retry_backoff.as_nanos() as i64,
                 max_retries: sub.config.max_retries as i64,
             }),
+            dead_letter_policy: None,
+            filter: String::new(),
         })
     }
 
@@ -788,6 +793,10 @@ impl MetaBuilder<'_> {
             migration_rel_path,
             migrations,
             allow_non_sequential_migrations,
+            granted_services: vec![],
+            seed_rel_path: None,
+            seed_files: vec![],
+            seed_program: false,
         })
     }
 
@@ -426,6 +426,8 @@ impl BuilderCtx<'_, '_> {
                 raw_tag,
                 query_string_name,
                 doc: doc.unwrap_or_else(|| "".into()),
+                string_encoded: false,
+                max_size: None,
             });
         }
 
@@ -1,6 +1,9 @@
 package app
 
 import (
+	"cmp"
+	"slices"
+
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/option"
 	"encr.dev/v2/app/apiframework"
@@ -42,18 +45,12 @@ func configureAPIFramework(pc *parsectx.Context, services []*Service, res *parse
 		}
 	}
 
-	// Add the app's auth handler
-	for _, ah := range authHandlers {
-		if fw.AuthHandler.Empty() {
-			fw.AuthHandler = option.Some(ah)
-		} else {
-			pc.Errs.Add(
-				authhandler.ErrMultipleAuthHandlers.
-					AtGoNode(fw.AuthHandler.MustGet().Decl.AST.Type, errors.AsError("first auth handler defined here")).
-					AtGoNode(ah.Decl.AST.Type, errors.AsError("second auth handler defined here")),
-			)
-		}
-	}
+	// Add the app's auth handlers, sorted by their resolution order.
+	// The order is validated in validateAuthHandlers.
+	fw.AuthHandlers = slices.Clone(authHandlers)
+	slices.SortStableFunc(fw.AuthHandlers, func(a, b *authhandler.AuthHandler) int {
+		return cmp.Compare(a.Order.GetOrElse(0), b.Order.GetOrElse(0))
+	})
 
 	modifySvcDesc := func(pkg *pkginfo.Package, errTemplate *errors.Template, fn func(svc *Service, desc *apiframework.ServiceDesc)) {
 		for _, svc := range services {
@@ -120,5 +117,14 @@ func configureAPIFramework(pc *parsectx.Context, services []*Service, res *parse
 		modifySvcDesc(ah.Package(), nil, func(svc *Service, desc *apiframework.ServiceDesc) {})
 	}
 
+	// Sort the middleware in the order they run in. Global middleware
+	// always runs before service middleware.
+	fw.GlobalMiddleware = middleware.Order(pc.Errs, fw.GlobalMiddleware, nil)
+	for _, svc := range services {
+		svc.Framework.ForAll(func(desc *apiframework.ServiceDesc) {
+			desc.Middleware = middleware.Order(pc.Errs, desc.Middleware, fw.GlobalMiddleware)
+		})
+	}
+
 	return option.Some(fw)
 }
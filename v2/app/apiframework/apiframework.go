@@ -14,8 +14,17 @@ type AppDesc struct {
 	// GlobalMiddleware is the list of application-global middleware.
 	GlobalMiddleware []*middleware.Middleware
 
-	// AuthHandler defines the application's auth handler, if any.
-	AuthHandler option.Option[*authhandler.AuthHandler]
+	// AuthHandlers are the application's auth handlers, in resolution order.
+	AuthHandlers []*authhandler.AuthHandler
+}
+
+// AuthHandler returns the application's primary auth handler, if any.
+// It's the first auth handler in resolution order.
+func (d *AppDesc) AuthHandler() option.Option[*authhandler.AuthHandler] {
+	if len(d.AuthHandlers) == 0 {
+		return option.None[*authhandler.AuthHandler]()
+	}
+	return option.Some(d.AuthHandlers[0])
 }
 
 // ServiceDesc describes an Encore Framework-based service.
@@ -10,6 +10,7 @@ import (
 	"encr.dev/v2/parser"
 	"encr.dev/v2/parser/apis/api"
 	"encr.dev/v2/parser/apis/middleware"
+	"encr.dev/v2/parser/infra/caches"
 	"encr.dev/v2/parser/resource"
 	"encr.dev/v2/parser/resource/usage"
 )
@@ -27,6 +28,10 @@ type Desc struct {
 	// Framework describes API Framework-specific application-global data.
 	Framework option.Option[*apiframework.AppDesc]
 
+	// VersionSets are the sets of versions of versioned endpoints,
+	// in the order their first version was defined.
+	VersionSets []*api.VersionSet
+
 	// ResourceUsageOutsideServices describes resources that are used outside of a service.
 	ResourceUsageOutsideServices map[resource.Resource][]usage.Usage
 }
@@ -75,6 +80,35 @@ func (d *Desc) MatchingGlobalMiddleware(ep *api.Endpoint) []*middleware.Middlewa
 	return matches
 }
 
+// CacheCluster reports the cache cluster storing the rate limiter state,
+// idempotent and cached responses of the given endpoint: the cluster named by its
+// "cluster" field, or otherwise the application's only cache cluster.
+// It reports false if the endpoint doesn't store any state.
+func (d *Desc) CacheCluster(ep *api.Endpoint) (*caches.Cluster, bool) {
+	if !ep.UsesCacheCluster() {
+		return nil, false
+	}
+
+	var clusters []*caches.Cluster
+	for _, res := range d.Parse.Resources() {
+		if cluster, ok := res.(*caches.Cluster); ok {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	if name, ok := ep.CacheCluster.Get(); ok {
+		for _, cluster := range clusters {
+			if cluster.Name == name {
+				return cluster, true
+			}
+		}
+		return nil, false
+	} else if len(clusters) == 1 {
+		return clusters[0], true
+	}
+	return nil, false
+}
+
 // ValidateAndDescribe validates the application and computes the
 // application description.
 func ValidateAndDescribe(pc *parsectx.Context, result *parser.Result) *Desc {
@@ -7,6 +7,7 @@ import (
 	gotoken "go/token"
 	"slices"
 	"sort"
+	"time"
 
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/paths"
@@ -20,10 +21,14 @@ import (
 	"encr.dev/v2/parser/apis/authhandler"
 	"encr.dev/v2/parser/apis/middleware"
 	"encr.dev/v2/parser/apis/servicestruct"
+	"encr.dev/v2/parser/infra/alerts"
 	"encr.dev/v2/parser/infra/caches"
 	"encr.dev/v2/parser/infra/config"
 	"encr.dev/v2/parser/infra/crons"
+	"encr.dev/v2/parser/infra/errdefs"
+	"encr.dev/v2/parser/infra/flags"
 	"encr.dev/v2/parser/infra/metrics"
+	"encr.dev/v2/parser/infra/monitors"
 	"encr.dev/v2/parser/infra/objects"
 	"encr.dev/v2/parser/infra/pubsub"
 	"encr.dev/v2/parser/infra/secrets"
@@ -84,22 +89,43 @@ func (b *builder) Build() *meta.Data {
 		if fw, ok := svc.Framework.Get(); ok {
 			out.RelPath = b.relPath(fw.RootPkg.ImportPath)
 			for _, ep := range fw.Endpoints {
+				proto, reqSchema := meta.RPC_REGULAR, ep.Request
+				if ep.Raw || ep.StreamRecord != nil {
+					// Clients send the request body as-is to raw endpoints and record streams.
+					proto, reqSchema = meta.RPC_RAW, nil
+				}
+
+				respSchema := ep.Response
+				if ep.StreamEvent != nil {
+					respSchema = ep.StreamEvent
+				}
+
 				rpc := &meta.RPC{
-					Name:           ep.Name,
-					Doc:            zeroNil(ep.Doc),
-					ServiceName:    svc.Name,
-					RequestSchema:  b.schemaTypeUnwrapPointer(ep.Request),
-					ResponseSchema: b.schemaTypeUnwrapPointer(ep.Response),
-					Proto:          meta.RPC_REGULAR,
-					Loc:            b.schemaLoc(ep.Decl.File, ep.Decl.AST),
-					Path:           b.apiPath(ep.Decl.AST.Pos(), ep.Path),
-					HttpMethods:    ep.HTTPMethods,
-					Tags:           ep.Tags.ToProto(),
-					Sensitive:      ep.Sensitive,
-					Expose:         make(map[string]*meta.RPC_ExposeOptions),
+					Name:             ep.Name,
+					Doc:              zeroNil(ep.Doc),
+					ServiceName:      svc.Name,
+					RequestSchema:    b.schemaTypeUnwrapPointer(reqSchema),
+					ResponseSchema:   b.schemaTypeUnwrapPointer(respSchema),
+					Proto:            proto,
+					Loc:              b.schemaLoc(ep.Decl.File, ep.Decl.AST),
+					Path:             b.apiPath(ep.Decl.AST.Pos(), ep.Path),
+					HttpMethods:      ep.HTTPMethods,
+					Tags:             ep.Tags.ToProto(),
+					Sensitive:        ep.Sensitive,
+					StrictDecoding:   ep.StrictDecoding,
+					Grpc:             ep.GRPC,
+					ServerSentEvents: ep.StreamEvent != nil,
+					BodyLimit:        zeroNil(uint64(ep.MaxBodySize)),
+					TimeoutMillis:    zeroNil(ep.Timeout.Milliseconds()),
+					Expose:           make(map[string]*meta.RPC_ExposeOptions),
 				}
-				if ep.Raw {
-					rpc.Proto = meta.RPC_RAW
+
+				if ep.Version > 0 {
+					rpc.Version = int32(ep.Version)
+					rpc.UnversionedPath = b.apiPath(ep.Decl.AST.Pos(), ep.UnversionedPath)
+				}
+				if notice, ok := ep.Deprecated.Get(); ok {
+					rpc.Deprecated = &notice
 				}
 
 				switch ep.Access {
@@ -107,9 +133,11 @@ func (b *builder) Build() *meta.Data {
 					rpc.AccessType = meta.RPC_PUBLIC
 					rpc.Expose["api-gateway"] = &meta.RPC_ExposeOptions{}
 					rpc.AllowUnauthenticated = true
+					rpc.EnvTypes = svc.EndpointEnvTypes(ep)
 				case api.Auth:
 					rpc.AccessType = meta.RPC_AUTH
 					rpc.Expose["api-gateway"] = &meta.RPC_ExposeOptions{}
+					rpc.EnvTypes = svc.EndpointEnvTypes(ep)
 				case api.Private:
 					rpc.AccessType = meta.RPC_PRIVATE
 					rpc.AllowUnauthenticated = true
@@ -208,6 +236,46 @@ func (b *builder) Build() *meta.Data {
 	selectorLookup := computeSelectorLookup(b.app)
 	for _, r := range b.app.Parse.Resources() {
 		switch r := r.(type) {
+		case *monitors.Check:
+			mc := &meta.MonitorCheck{
+				Name:            r.Name,
+				Title:           r.Title,
+				Doc:             zeroNil(r.Doc),
+				Method:          r.Method,
+				Path:            r.Path,
+				IntervalSeconds: int64(r.Interval / time.Second),
+				TimeoutMillis:   r.Timeout.Milliseconds(),
+				ExpectStatus:    int32(r.ExpectStatus),
+
+				ExpectBodyContains: zeroNil(r.ExpectBodyContains),
+			}
+			if r.MaxLatency > 0 {
+				maxLatency := r.MaxLatency.Milliseconds()
+				mc.MaxLatencyMillis = &maxLatency
+			}
+			md.MonitorChecks = append(md.MonitorChecks, mc)
+
+		case *flags.Flag:
+			md.FeatureFlags = append(md.FeatureFlags, &meta.FeatureFlag{
+				Name:           r.Name,
+				Doc:            zeroNil(r.Doc),
+				RolloutPercent: int32(r.Rollout),
+				UserIds:        r.UserIDs,
+				Attributes:     r.Attributes,
+			})
+
+		case *errdefs.Definition:
+			def := &meta.ErrorDefinition{
+				Id:      r.ID,
+				Code:    r.Code,
+				Message: r.Message,
+				Doc:     zeroNil(r.Doc),
+			}
+			if svc, ok := b.app.ServiceForPath(r.File.Pkg.FSPath); ok {
+				def.ServiceName = &svc.Name
+			}
+			md.ErrorDefinitions = append(md.ErrorDefinitions, def)
+
 		case *crons.Job:
 			cj := &meta.CronJob{
 				Id:       r.Name,
@@ -241,7 +309,11 @@ func (b *builder) Build() *meta.Data {
 				if data, ok := r.AuthData.Get(); ok {
 					ah.AuthData = b.typeDeclRefUnwrapPointer(data)
 				}
-				md.AuthHandler = ah
+				// The metadata describes the primary auth handler,
+				// which is the first one in resolution order.
+				if b.isPrimaryAuthHandler(r) {
+					md.AuthHandler = ah
+				}
 				b.nodes.addAuthHandler(r, svc.Name)
 			} else {
 				b.errs.Addf(r.Decl.AST.Pos(), "auth handler %q must be defined within a service", r.Name)
@@ -253,6 +325,14 @@ func (b *builder) Build() *meta.Data {
 				Doc:              zeroNil(r.Doc),
 				MigrationRelPath: zeroNil(r.MigrationDir.String()),
 				Migrations:       fns.Map(r.Migrations, transformMigration),
+				GrantedServices:  fns.Map(r.Grants, func(g sqldb.Grant) string { return g.Service }),
+				Tags:             r.Tags,
+				Regions:          r.Regions,
+			}
+			if seeds, ok := r.Seeds.Get(); ok {
+				db.SeedRelPath = zeroNil(seeds.Dir.String())
+				db.SeedFiles = seeds.Files
+				db.SeedProgram = seeds.Program
 			}
 			md.SqlDatabases = append(md.SqlDatabases, db)
 
@@ -262,6 +342,9 @@ func (b *builder) Build() *meta.Data {
 				Doc:           zeroNil(r.Doc),
 				MessageType:   b.typeDeclRefUnwrapPointer(r.MessageType),
 				OrderingKey:   r.OrderingAttribute,
+				Ordered:       r.Ordered,
+				Tags:          r.Tags,
+				Regions:       r.Regions,
 				Publishers:    nil,
 				Subscriptions: nil, // filled in later
 			}
@@ -331,6 +414,23 @@ func (b *builder) Build() *meta.Data {
 				Doc:       zeroNil(r.Doc),
 				Versioned: r.Versioned,
 				Public:    r.Public,
+				Tags:      r.Tags,
+				Regions:   r.Regions,
+
+				ClientSideEncryption: r.ClientSideEncryption,
+			}
+			if lc := r.Lifecycle; lc != nil {
+				bkt.Lifecycle = &meta.Bucket_Lifecycle{
+					ExpireAfterDays:      int32(lc.ExpireAfterDays),
+					ColdStorageAfterDays: int32(lc.ColdStorageAfterDays),
+				}
+			}
+			if qn, ok := r.Events.Get(); ok {
+				if topic, ok := b.app.Parse.ResourceForQN(qn).Get(); ok {
+					if topic, ok := topic.(*pubsub.Topic); ok {
+						bkt.EventsTopic = &topic.Name
+					}
+				}
 			}
 			md.Buckets = append(md.Buckets, bkt)
 
@@ -344,7 +444,7 @@ func (b *builder) Build() *meta.Data {
 				switch u := u.(type) {
 				case *objects.MethodUsage:
 					if svc, ok := b.app.ServiceForPath(u.DeclaredIn().FSPath); ok {
-						addPerms(svc.Name, u.Perm)
+						addPerms(svc.Name, u.Perms...)
 					}
 				case *objects.RefUsage:
 					if svc, ok := b.app.ServiceForPath(u.DeclaredIn().FSPath); ok {
@@ -377,6 +477,7 @@ func (b *builder) Build() *meta.Data {
 				Doc:            r.Doc,
 				Keyspaces:      nil,
 				EvictionPolicy: r.EvictionPolicy,
+				Tags:           r.Tags,
 			}
 			for _, b := range b.app.Parse.PkgDeclBinds(r) {
 				clusterMap[b.QualifiedName()] = cluster
@@ -420,13 +521,14 @@ func (b *builder) Build() *meta.Data {
 			md.Metrics = append(md.Metrics, m)
 
 		case *config.Load:
+			// Register the types.
+			typ := b.schemaType(r.Type)
 			if svc, ok := b.app.ServiceForPath(r.File.Pkg.FSPath); ok {
 				if metaSvc, ok := svcByName[svc.Name]; ok {
 					metaSvc.HasConfig = true
+					metaSvc.Configs = append(metaSvc.Configs, typ)
 				}
 			}
-			// Register the types.
-			b.schemaType(r.Type)
 
 		case *secrets.Secrets:
 			pkg, ok := pkgByPath[r.Package().ImportPath]
@@ -461,7 +563,7 @@ func (b *builder) Build() *meta.Data {
 				b.nodes.addServiceStruct(r, svc.Name)
 			}
 
-		case *pubsub.Subscription, *caches.Keyspace:
+		case *pubsub.Subscription, *caches.Keyspace, *alerts.Rule:
 			dependent = append(dependent, r)
 		}
 	}
@@ -484,6 +586,25 @@ func (b *builder) Build() *meta.Data {
 				continue
 			}
 
+			var deadLetter *meta.PubSubTopic_DeadLetterPolicy
+			if dl, ok := r.Cfg.DeadLetter.Get(); ok {
+				dlTopic, ok := topicMap[dl.Topic]
+				if !ok {
+					b.errs.Addf(dl.TopicExpr.Pos(), "dead-letter topic %q not found",
+						dl.Topic.NaiveDisplayName())
+					continue
+				}
+				deadLetter = &meta.PubSubTopic_DeadLetterPolicy{
+					TopicName:     dlTopic.Name,
+					MaxDeliveries: int32(dl.MaxDeliveries),
+				}
+			}
+
+			var filter string
+			if f, ok := r.Cfg.Filter.Get(); ok {
+				filter = f.String()
+			}
+
 			topic.Subscriptions = append(topic.Subscriptions, &meta.PubSubTopic_Subscription{
 				Name:             r.Name,
 				ServiceName:      svc.Name,
@@ -495,10 +616,72 @@ func (b *builder) Build() *meta.Data {
 					MaxBackoff: r.Cfg.MaxRetryBackoff.Nanoseconds(),
 					MaxRetries: int64(r.Cfg.MaxRetries),
 				},
+				DeadLetterPolicy: deadLetter,
+				Filter:           filter,
 			})
 
 			b.nodes.addSub(r, svc.Name, topic.Name)
 
+		case *alerts.Rule:
+			svc, ok := b.app.ServiceForPath(r.File.Pkg.FSPath)
+			if !ok {
+				b.errs.Addf(r.ASTExpr().Pos(), "alert rule %q must be defined within a service", r.Name)
+				continue
+			}
+
+			rule := &meta.AlertRule{
+				Name:          r.Name,
+				Title:         r.Title,
+				Doc:           zeroNil(r.Doc),
+				Service:       svc.Name,
+				WindowSeconds: int64(r.Window / time.Second),
+				ForSeconds:    int64(r.For / time.Second),
+				Severity:      r.Severity,
+			}
+
+			var endpoint *string
+			if qn, ok := r.Endpoint.Get(); ok {
+				if ep, ok := b.app.Parse.ResourceForQN(qn).Get(); ok {
+					endpoint = &ep.(*api.Endpoint).Name
+				} else {
+					b.errs.Addf(r.EndpointAST.Pos(), "could not find endpoint %q", qn.NaiveDisplayName())
+					continue
+				}
+			}
+
+			switch {
+			case r.ErrorRateAbove > 0:
+				rule.Condition = &meta.AlertRule_ErrorRate_{ErrorRate: &meta.AlertRule_ErrorRate{
+					Endpoint:  endpoint,
+					Threshold: r.ErrorRateAbove,
+				}}
+			case r.LatencyAbove > 0:
+				rule.Condition = &meta.AlertRule_Latency_{Latency: &meta.AlertRule_Latency{
+					Endpoint:        endpoint,
+					Percentile:      r.LatencyPercentile,
+					ThresholdMillis: r.LatencyAbove.Milliseconds(),
+				}}
+			case r.QueueLagAbove > 0:
+				qn, _ := r.Subscription.Get()
+				res, ok := b.app.Parse.ResourceForQN(qn).Get()
+				if !ok {
+					b.errs.Addf(r.SubscriptionAST.Pos(), "could not find subscription %q", qn.NaiveDisplayName())
+					continue
+				}
+				sub := res.(*pubsub.Subscription)
+				topic, ok := topicMap[sub.Topic]
+				if !ok {
+					b.errs.Addf(r.SubscriptionAST.Pos(), "topic %q not found", sub.Topic.NaiveDisplayName())
+					continue
+				}
+				rule.Condition = &meta.AlertRule_QueueLag_{QueueLag: &meta.AlertRule_QueueLag{
+					Topic:           topic.Name,
+					Subscription:    sub.Name,
+					ThresholdMillis: r.QueueLagAbove.Milliseconds(),
+				}}
+			}
+			md.AlertRules = append(md.AlertRules, rule)
+
 		case *caches.Keyspace:
 			cluster, ok := clusterMap[r.Cluster]
 			if !ok {
@@ -519,6 +702,7 @@ func (b *builder) Build() *meta.Data {
 				ValueType:   b.schemaType(r.ValueType),
 				PathPattern: b.keyspacePath(r.Path),
 				Doc:         r.Doc,
+				Kind:        keyspaceKind(r.KeyspaceKind),
 			})
 		}
 	}
@@ -633,6 +817,41 @@ func (b *builder) keyspacePath(path *resourcepaths.Path) *meta.Path {
 	return res
 }
 
+func keyspaceKind(kind caches.KeyspaceKind) meta.CacheCluster_Keyspace_Kind {
+	switch kind {
+	case caches.StringKeyspace:
+		return meta.CacheCluster_Keyspace_STRING
+	case caches.IntKeyspace:
+		return meta.CacheCluster_Keyspace_INT
+	case caches.FloatKeyspace:
+		return meta.CacheCluster_Keyspace_FLOAT
+	case caches.ListKeyspace:
+		return meta.CacheCluster_Keyspace_LIST
+	case caches.SetKeyspace:
+		return meta.CacheCluster_Keyspace_SET
+	case caches.SortedSetKeyspace:
+		return meta.CacheCluster_Keyspace_SORTED_SET
+	case caches.StructKeyspace:
+		return meta.CacheCluster_Keyspace_STRUCT
+	case caches.LockKeyspace:
+		return meta.CacheCluster_Keyspace_LOCK
+	case caches.RateLimiterKeyspace:
+		return meta.CacheCluster_Keyspace_RATE_LIMITER
+	default:
+		return meta.CacheCluster_Keyspace_UNKNOWN
+	}
+}
+
+// isPrimaryAuthHandler reports whether ah is the app's primary auth handler.
+func (b *builder) isPrimaryAuthHandler(ah *authhandler.AuthHandler) bool {
+	if fw, ok := b.app.Framework.Get(); ok {
+		if primary, ok := fw.AuthHandler().Get(); ok {
+			return primary == ah
+		}
+	}
+	return true
+}
+
 func (b *builder) relPath(pkg paths.Pkg) string {
 	rel, ok := b.app.MainModule.Path.RelativePathToPkg(pkg)
 	if !ok {
@@ -3,6 +3,8 @@ package legacymeta
 import (
 	"fmt"
 	"go/ast"
+	"strconv"
+	"strings"
 
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/idents"
@@ -56,6 +58,8 @@ func (b *builder) builtinType(typ schemav2.BuiltinType) schema.Builtin {
 		return schema.Builtin_JSON
 	case schemav2.UserID:
 		return schema.Builtin_USER_ID
+	case schemav2.File:
+		return schema.Builtin_FILE
 
 	default:
 		panic(fmt.Sprintf("unknown builtin type %v", typ.Kind))
@@ -192,6 +196,14 @@ func (b *builder) structField(f schemav2.StructField) *schema.Field {
 			switch o {
 			case "optional":
 				field.Optional = true
+			case "string":
+				field.StringEncoded = true
+			default:
+				if val, ok := strings.CutPrefix(o, "maxsize="); ok {
+					if n, err := strconv.ParseUint(val, 10, 64); err == nil && n > 0 {
+						field.MaxSize = &n
+					}
+				}
 			case "httpstatus":
 				// Set WireSpec for HttpStatus fields
 				field.Wire = &schema.WireSpec{
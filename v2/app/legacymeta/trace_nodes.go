@@ -19,12 +19,13 @@ import (
 
 func newTraceNodes(b *builder) *TraceNodes {
 	return &TraceNodes{
-		b:           b,
-		nodes:       make(map[paths.Pkg][]*meta.TraceNode),
-		middlewares: make(map[*middleware.Middleware]*meta.TraceNode),
-		subs:        make(map[*pubsub.Subscription]*meta.TraceNode),
-		svcStructs:  make(map[*servicestruct.ServiceStruct]*meta.TraceNode),
-		endpoints:   make(map[*api.Endpoint]*meta.TraceNode),
+		b:            b,
+		nodes:        make(map[paths.Pkg][]*meta.TraceNode),
+		authHandlers: make(map[*authhandler.AuthHandler]*meta.TraceNode),
+		middlewares:  make(map[*middleware.Middleware]*meta.TraceNode),
+		subs:         make(map[*pubsub.Subscription]*meta.TraceNode),
+		svcStructs:   make(map[*servicestruct.ServiceStruct]*meta.TraceNode),
+		endpoints:    make(map[*api.Endpoint]*meta.TraceNode),
 	}
 }
 
@@ -35,18 +36,18 @@ type TraceNodes struct {
 	id    int32
 	nodes map[paths.Pkg][]*meta.TraceNode
 
-	authHandler *meta.TraceNode
-	middlewares map[*middleware.Middleware]*meta.TraceNode
-	subs        map[*pubsub.Subscription]*meta.TraceNode
-	svcStructs  map[*servicestruct.ServiceStruct]*meta.TraceNode
-	endpoints   map[*api.Endpoint]*meta.TraceNode
+	authHandlers map[*authhandler.AuthHandler]*meta.TraceNode
+	middlewares  map[*middleware.Middleware]*meta.TraceNode
+	subs         map[*pubsub.Subscription]*meta.TraceNode
+	svcStructs   map[*servicestruct.ServiceStruct]*meta.TraceNode
+	endpoints    map[*api.Endpoint]*meta.TraceNode
 }
 
-func (n *TraceNodes) AuthHandler() uint32 {
+func (n *TraceNodes) AuthHandler(ah *authhandler.AuthHandler) uint32 {
 	if n == nil {
 		return 0
 	}
-	return nodeID(n.authHandler)
+	return nodeID(n.authHandlers[ah])
 }
 
 func (n *TraceNodes) Middleware(mw *middleware.Middleware) uint32 {
@@ -86,7 +87,7 @@ func (n *TraceNodes) addAuthHandler(ah *authhandler.AuthHandler, svcName string)
 			Context:     string(context),
 		},
 	}
-	n.authHandler = traceNode
+	n.authHandlers[ah] = traceNode
 }
 
 func (n *TraceNodes) addServiceStruct(s *servicestruct.ServiceStruct, svcName string) {
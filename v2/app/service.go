@@ -5,6 +5,7 @@ import (
 	"encr.dev/pkg/paths"
 	"encr.dev/v2/app/apiframework"
 	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/parser/apis/api"
 	"encr.dev/v2/parser/resource"
 	"encr.dev/v2/parser/resource/usage"
 )
@@ -34,3 +35,33 @@ type Service struct {
 func (s *Service) ContainsPackage(pkg *pkginfo.Package) bool {
 	return s.FSRoot.HasPrefix(pkg.FSPath)
 }
+
+// EndpointEnvTypes returns the environment types the endpoint is publicly exposed in.
+// Endpoints without an "env" field inherit the environment types of their service struct.
+// It returns nil if the endpoint is exposed in all environments.
+func (s *Service) EndpointEnvTypes(ep *api.Endpoint) []string {
+	if len(ep.EnvTypes) > 0 {
+		return ep.EnvTypes
+	}
+	if fw, ok := s.Framework.Get(); ok {
+		if ss, ok := fw.ServiceStruct.Get(); ok {
+			return ss.EnvTypes
+		}
+	}
+	return nil
+}
+
+// EndpointCORS returns the CORS policy of the endpoint.
+// Endpoints without a CORS policy of their own inherit the policy of their service struct.
+// It returns nil if the endpoint uses the global CORS configuration.
+func (s *Service) EndpointCORS(ep *api.Endpoint) *api.CORS {
+	if ep.CORS != nil {
+		return ep.CORS
+	}
+	if fw, ok := s.Framework.Get(); ok {
+		if ss, ok := fw.ServiceStruct.Get(); ok {
+			return ss.CORS
+		}
+	}
+	return nil
+}
@@ -6,6 +6,9 @@ import (
 	"encr.dev/v2/parser/apis/authhandler"
 	"encr.dev/v2/parser/apis/middleware"
 	"encr.dev/v2/parser/infra/caches"
+	"encr.dev/v2/parser/infra/errdefs"
+	"encr.dev/v2/parser/infra/flags"
+	"encr.dev/v2/parser/infra/monitors"
 	"encr.dev/v2/parser/infra/objects"
 	"encr.dev/v2/parser/infra/pubsub"
 	"encr.dev/v2/parser/infra/secrets"
@@ -31,6 +34,10 @@ func (d *Desc) validate(pc *parsectx.Context, result *parser.Result) {
 	d.validateDatabases(pc, result)
 	d.validatePubSub(pc, result)
 	d.validateObjects(pc, result)
+	d.validateMonitors(pc, result)
+	d.validateAlerts(pc, result)
+	d.validateFlags(pc, result)
+	d.validateErrorDefinitions(pc, result)
 
 	// Validate all resources are defined within a service
 	for _, b := range result.AllBinds() {
@@ -57,6 +64,15 @@ func (d *Desc) validate(pc *parsectx.Context, result *parser.Result) {
 		case *caches.Cluster:
 			// Cache clusters are allowed anywhere
 			continue
+		case *monitors.Check:
+			// Monitoring checks probe the app as a whole, so they're allowed anywhere
+			continue
+		case *flags.Flag:
+			// Feature flags are shared between services, so they're allowed anywhere
+			continue
+		case *errdefs.Definition:
+			// Error definitions are commonly shared between services, so they're allowed anywhere
+			continue
 
 		default:
 			_, ok := d.ServiceForPath(b.Package().FSPath)
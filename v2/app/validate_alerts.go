@@ -0,0 +1,54 @@
+package app
+
+import (
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/parser"
+	"encr.dev/v2/parser/infra/alerts"
+	"encr.dev/v2/parser/resource"
+)
+
+func (d *Desc) validateAlerts(pc *parsectx.Context, result *parser.Result) {
+	found := make(map[string]*alerts.Rule)
+
+	for _, rule := range parser.Resources[*alerts.Rule](result) {
+		if previous, ok := found[rule.Name]; ok {
+			pc.Errs.Add(
+				alerts.ErrDuplicateNames.
+					AtGoNode(rule.AST.Args[0]).
+					AtGoNode(previous.AST.Args[0]),
+			)
+		}
+		found[rule.Name] = rule
+
+		// Rules declared outside of a service are reported
+		// by the generic resource validation.
+		svc, ok := d.ServiceForPath(rule.File.FSPath)
+		if !ok {
+			continue
+		}
+
+		if qn, ok := rule.Endpoint.Get(); ok {
+			res, ok := result.ResourceForQN(qn).Get()
+			if !ok || res.Kind() != resource.APIEndpoint || !d.inService(svc, res) {
+				pc.Errs.Add(alerts.ErrEndpointNotInService.AtGoNode(rule.EndpointAST))
+			}
+		}
+		if qn, ok := rule.Subscription.Get(); ok {
+			res, ok := result.ResourceForQN(qn).Get()
+			if !ok || res.Kind() != resource.PubSubSubscription || !d.inService(svc, res) {
+				pc.Errs.Add(alerts.ErrSubscriptionNotInService.AtGoNode(rule.SubscriptionAST))
+			}
+		}
+	}
+}
+
+// inService reports whether the resource is declared within the given service.
+func (d *Desc) inService(svc *Service, res resource.Resource) bool {
+	pkg, ok := res.(interface{ Package() *pkginfo.Package })
+	if !ok {
+		return false
+	}
+	resSvc, ok := d.ServiceForPath(pkg.Package().FSPath)
+	return ok && resSvc == svc
+}
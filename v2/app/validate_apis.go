@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"slices"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/v2/app/apiframework"
@@ -10,8 +11,11 @@ import (
 	"encr.dev/v2/internals/schema/schemautil"
 	"encr.dev/v2/parser"
 	"encr.dev/v2/parser/apis/api"
+	"encr.dev/v2/parser/apis/api/apienc"
 	"encr.dev/v2/parser/apis/authhandler"
+	"encr.dev/v2/parser/apis/directive"
 	"encr.dev/v2/parser/apis/servicestruct"
+	"encr.dev/v2/parser/infra/caches"
 	"encr.dev/v2/parser/infra/crons"
 	"encr.dev/v2/parser/infra/pubsub"
 	"encr.dev/v2/parser/resource"
@@ -31,7 +35,7 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 
 		for _, ep := range fwSvc.Endpoints {
 			// Check if an auth handler is defined for an endpoint that requires auth.
-			if ep.Access == api.Auth && fw.AuthHandler.Empty() {
+			if ep.Access == api.Auth && len(fw.AuthHandlers) == 0 {
 				pc.Errs.Add(
 					errors.AtOptionalNode(authhandler.ErrNoAuthHandlerDefined, ep.AccessField),
 				)
@@ -62,6 +66,27 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 				}
 			}
 
+			// Endpoints can't be exposed in environments their service isn't exposed in.
+			if hasSvcStruct && len(svcStruct.EnvTypes) > 0 {
+				if envField, ok := ep.EnvTypesField.Get(); ok {
+					for _, env := range ep.EnvTypes {
+						if !slices.Contains(svcStruct.EnvTypes, env) {
+							pc.Errs.Add(
+								api.ErrEndpointEnvNotInService(env).
+									AtGoNode(envField, errors.AsError("exposed here")).
+									AtGoNode(svcStruct.EnvTypesField.MustGet(), errors.AsHelp("the service is exposed here")),
+							)
+						}
+					}
+				}
+			}
+
+			if ep.UsesCacheCluster() {
+				if _, ok := d.CacheCluster(ep); !ok {
+					d.reportCacheCluster(pc, ep)
+				}
+			}
+
 			if ep.Raw {
 				for _, rawUsage := range result.Usages(ep) {
 					pc.Errs.Add(
@@ -71,14 +96,49 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 					)
 				}
 			} else {
+				if ep.StreamRecord != nil || ep.StreamEvent != nil {
+					for _, usage := range result.Usages(ep) {
+						if call, ok := usage.(*api.CallUsage); ok {
+							pc.Errs.Add(
+								api.ErrStreamEndpointsCannotBeCalled.
+									AtGoNode(call, errors.AsError("called here")).
+									AtGoNode(ep.Decl.AST.Name, errors.AsHelp("defined here")),
+							)
+						}
+					}
+				}
+
+				// Service-to-service calls are sent as JSON, which can't contain files.
+				// Calls from tests and from within the same service are made in-process.
+				if slices.ContainsFunc(ep.RequestEncoding(), (*apienc.RequestEncoding).Multipart) {
+					for _, usage := range result.Usages(ep) {
+						call, ok := usage.(*api.CallUsage)
+						if !ok || call.File.TestFile {
+							continue
+						}
+						if callerSvc, ok := d.ServiceForPath(call.File.Pkg.FSPath); !ok || callerSvc != svc {
+							pc.Errs.Add(
+								apienc.ErrInvalidFileUpload("Endpoints receiving files cannot be called by other services.").
+									AtGoNode(call, errors.AsError("called here")).
+									AtGoNode(ep.Decl.AST.Name, errors.AsHelp("defined here")),
+							)
+						}
+					}
+				}
+
 				// If typed endpoint, validate the types of the request and response
-				if ep.Request != nil {
+				if ep.StreamRecord != nil {
+					field, _ := schemautil.GetArgument(ep.Decl.AST.Type.Params, len(ep.Path.Params())+1)
+					d.validateType(pc, field.Type, ep.StreamRecord)
+				} else if ep.Request != nil {
 					// The request is always the first parameter after any path params (and after the ctx)
 					field, _ := schemautil.GetArgument(ep.Decl.AST.Type.Params, len(ep.Path.Params())+1)
 					d.validateType(pc, field.Type, ep.Request)
 				}
 
-				if ep.Response != nil {
+				if ep.StreamEvent != nil {
+					d.validateType(pc, ep.Decl.AST.Type.Results.List[0].Type, ep.StreamEvent)
+				} else if ep.Response != nil {
 					// The response is always the first return value
 					d.validateType(pc, ep.Decl.AST.Type.Results.List[0].Type, ep.Response)
 				}
@@ -125,4 +185,47 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 			}
 		}
 	}
+
+	d.validateVersionSets(pc, apiPaths)
+}
+
+// reportCacheCluster reports why the cache cluster storing the state
+// of a rate limited, cached or idempotent endpoint could not be resolved.
+func (d *Desc) reportCacheCluster(pc *parsectx.Context, ep *api.Endpoint) {
+	hasClusters := false
+	for _, res := range d.Parse.Resources() {
+		if _, ok := res.(*caches.Cluster); ok {
+			hasClusters = true
+			break
+		}
+	}
+
+	if rl := ep.RateLimit; rl != nil {
+		if clusterField, ok := ep.CacheClusterField.Get(); ok {
+			pc.Errs.Add(api.ErrRateLimitUnknownCacheCluster(ep.CacheCluster.MustGet()).AtGoNode(clusterField))
+		} else if hasClusters {
+			pc.Errs.Add(api.ErrRateLimitAmbiguousCacheCluster.AtGoNode(rl.Field, errors.AsError("rate limited here")))
+		} else {
+			pc.Errs.Add(api.ErrRateLimitNoCacheCluster.AtGoNode(rl.Field, errors.AsError("rate limited here")))
+		}
+		return
+	}
+
+	if clusterField, ok := ep.CacheClusterField.Get(); ok {
+		pc.Errs.Add(api.ErrEndpointUnknownCacheCluster(ep.CacheCluster.MustGet()).AtGoNode(clusterField))
+		return
+	}
+
+	var kind string
+	var field directive.Field
+	if ep.ResponseCache != nil {
+		kind, field = "Cached", ep.ResponseCache.Field
+	} else {
+		kind, field = "Idempotent", ep.IdempotentField.MustGet()
+	}
+	if hasClusters {
+		pc.Errs.Add(api.ErrEndpointAmbiguousCacheCluster.AtGoNode(field, errors.AsError("declared here")))
+	} else {
+		pc.Errs.Add(api.ErrEndpointNoCacheCluster(kind).AtGoNode(field, errors.AsError("declared here")))
+	}
 }
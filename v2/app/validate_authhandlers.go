@@ -1,19 +1,132 @@
 package app
 
 import (
+	"fmt"
+	"strings"
+
+	"encr.dev/pkg/errors"
 	"encr.dev/v2/app/apiframework"
 	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/parser/apis/api/apienc"
+	"encr.dev/v2/parser/apis/authhandler"
 )
 
 func (d *Desc) validateAuthHandlers(pc *parsectx.Context, fw *apiframework.AppDesc) {
-	handler, found := fw.AuthHandler.Get()
-	if !found {
-		return
+	for _, handler := range fw.AuthHandlers {
+		// Validate the auth data can be marshalled
+		// (the same validation we run on request/response types)
+		if authData, found := handler.AuthData.Get(); found {
+			d.validateType(pc, handler.Decl.AST.Type.Results.List[1].Type, authData.ToType())
+		}
+	}
+
+	if len(fw.AuthHandlers) > 1 {
+		d.validateAuthHandlerChain(pc, fw.AuthHandlers)
+	}
+}
+
+// validateAuthHandlerChain validates an application defining multiple auth handlers.
+// The handlers are expected to be sorted in resolution order.
+func (d *Desc) validateAuthHandlerChain(pc *parsectx.Context, handlers []*authhandler.AuthHandler) {
+	first := handlers[0]
+	firstSvc, _ := d.ServiceForPath(first.Decl.File.FSPath)
+
+	// seenParams tracks the auth parameters handled by earlier auth handlers.
+	seenParams := make(map[string]bool)
+
+	for i, ah := range handlers {
+		order, hasOrder := ah.Order.Get()
+		if !hasOrder {
+			pc.Errs.Add(authhandler.ErrMissingOrder.AtGoNode(ah.Decl.AST.Name))
+		} else if i > 0 {
+			if prev := handlers[i-1]; prev.Order.Present() && prev.Order.MustGet() == order {
+				pc.Errs.Add(
+					authhandler.ErrDuplicateOrder.
+						AtGoNode(prev.Decl.AST.Name, errors.AsError(fmt.Sprintf("order=%d defined here", order))).
+						AtGoNode(ah.Decl.AST.Name, errors.AsError("and here")),
+				)
+			}
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		// The remote auth handling only supports handlers hosted by a single service.
+		if svc, ok := d.ServiceForPath(ah.Decl.File.FSPath); ok && firstSvc != nil && svc != firstSvc {
+			pc.Errs.Add(
+				authhandler.ErrAuthHandlersInDifferentServices.
+					AtGoNode(first.Decl.AST.Name, errors.AsError(fmt.Sprintf("defined in service %q", firstSvc.Name))).
+					AtGoNode(ah.Decl.AST.Name, errors.AsError(fmt.Sprintf("defined in service %q", svc.Name))),
+			)
+		}
+
+		// All handlers must resolve to the same auth data type,
+		// since auth.Data() must return a consistent type.
+		if !sameAuthData(first, ah) {
+			pc.Errs.Add(
+				authhandler.ErrAuthDataMismatch.
+					AtGoNode(first.Decl.AST.Type.Results, errors.AsError("first auth handler defined here")).
+					AtGoNode(ah.Decl.AST.Type.Results, errors.AsError("mismatched auth handler defined here")),
+			)
+		}
 	}
 
-	// Validate the auth data can be marshalled
-	// (the same validation we run on request/response types)
-	if authData, found := handler.AuthData.Get(); found {
-		d.validateType(pc, handler.Decl.AST.Type.Results.List[1].Type, authData.ToType())
+	// An auth handler is selected if any of its auth parameters are present
+	// in the request, so a handler whose parameters are all handled by earlier
+	// handlers can never be reached.
+	for _, ah := range handlers {
+		params := authParamKeys(pc, ah)
+		if len(params) == 0 {
+			continue
+		}
+
+		reachable := false
+		for _, p := range params {
+			if !seenParams[p] {
+				reachable = true
+				seenParams[p] = true
+			}
+		}
+		if !reachable {
+			pc.Errs.Add(authhandler.ErrUnreachableAuthHandler.AtGoNode(ah.Decl.AST.Type.Params))
+		}
+	}
+}
+
+// sameAuthData reports whether a and b have the same auth data type.
+func sameAuthData(a, b *authhandler.AuthHandler) bool {
+	dataA, okA := a.AuthData.Get()
+	dataB, okB := b.AuthData.Get()
+	if okA != okB {
+		return false
+	} else if !okA {
+		return true
+	}
+	return dataA.Decl == dataB.Decl && dataA.Pointers == dataB.Pointers
+}
+
+// authParamKeys returns a key for each auth parameter of the given auth handler,
+// identifying where in the request the parameter is read from.
+func authParamKeys(pc *parsectx.Context, ah *authhandler.AuthHandler) []string {
+	enc := apienc.DescribeAuth(pc.Errs, ah.Param)
+	if enc == nil {
+		return nil
+	} else if enc.LegacyTokenFormat {
+		// Legacy token handlers only accept "Bearer" and "Token" credentials,
+		// so other Authorization schemes can still be handled by another handler.
+		return []string{"header:authorization:bearer"}
+	}
+
+	var keys []string
+	for _, p := range enc.HeaderParameters {
+		keys = append(keys, "header:"+strings.ToLower(p.WireName))
+	}
+	for _, p := range enc.QueryParameters {
+		keys = append(keys, "query:"+p.WireName)
+	}
+	for _, p := range enc.CookieParameters {
+		keys = append(keys, "cookie:"+p.WireName)
 	}
+	return keys
 }
@@ -1,6 +1,9 @@
 package app
 
 import (
+	"go/token"
+	"slices"
+
 	"encr.dev/pkg/errors"
 	"encr.dev/v2/internals/parsectx"
 	"encr.dev/v2/parser"
@@ -32,4 +35,36 @@ func (d *Desc) validateDatabases(pc *parsectx.Context, result *parser.Result) {
 			}
 		}
 	}
+
+	// Check that databases with grants are only accessed by the service
+	// declaring them and the services granted access.
+	for _, db := range dbs {
+		if len(db.Grants) == 0 {
+			continue
+		}
+
+		allowed := make(map[string]bool)
+		if owner, ok := d.ServiceForPath(db.Pkg.FSPath); ok {
+			allowed[owner.Name] = true
+		}
+		for _, g := range db.Grants {
+			if !slices.ContainsFunc(d.Services, func(svc *Service) bool { return svc.Name == g.Service }) {
+				pc.Errs.Add(sqldb.ErrGrantUnknownService(g.Service).AtGoNode(g.AST))
+			}
+			allowed[g.Service] = true
+		}
+
+		for _, svc := range d.Services {
+			if allowed[svc.Name] {
+				continue
+			}
+			if uses := svc.ResourceUsage[db]; len(uses) > 0 {
+				pc.Errs.Add(sqldb.ErrAccessNotGranted(svc.Name, db.Name).
+					AtGoNode(uses[0], errors.AsError("used here")))
+			} else if binds := svc.ResourceBinds[db]; len(binds) > 0 {
+				pc.Errs.Add(sqldb.ErrAccessNotGranted(svc.Name, db.Name).
+					AtGoPos(binds[0].Pos(), token.NoPos, errors.AsError("referenced here")))
+			}
+		}
+	}
 }
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/parser"
+	"encr.dev/v2/parser/infra/errdefs"
+)
+
+func (d *Desc) validateErrorDefinitions(pc *parsectx.Context, result *parser.Result) {
+	found := make(map[string]*errdefs.Definition)
+
+	for _, def := range parser.Resources[*errdefs.Definition](result) {
+		if previous, ok := found[def.ID]; ok {
+			pc.Errs.Add(
+				errdefs.ErrDuplicateIDs.
+					AtGoNode(def.AST.Args[0]).
+					AtGoNode(previous.AST.Args[0]),
+			)
+		}
+		found[def.ID] = def
+	}
+}
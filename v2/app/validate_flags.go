@@ -0,0 +1,22 @@
+package app
+
+import (
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/parser"
+	"encr.dev/v2/parser/infra/flags"
+)
+
+func (d *Desc) validateFlags(pc *parsectx.Context, result *parser.Result) {
+	found := make(map[string]*flags.Flag)
+
+	for _, flag := range parser.Resources[*flags.Flag](result) {
+		if previous, ok := found[flag.Name]; ok {
+			pc.Errs.Add(
+				flags.ErrDuplicateNames.
+					AtGoNode(flag.AST.Args[0]).
+					AtGoNode(previous.AST.Args[0]),
+			)
+		}
+		found[flag.Name] = flag
+	}
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/parser"
+	"encr.dev/v2/parser/infra/monitors"
+)
+
+func (d *Desc) validateMonitors(pc *parsectx.Context, result *parser.Result) {
+	found := make(map[string]*monitors.Check)
+
+	for _, check := range parser.Resources[*monitors.Check](result) {
+		if previous, ok := found[check.Name]; ok {
+			pc.Errs.Add(
+				monitors.ErrDuplicateNames.
+					AtGoNode(check.AST.Args[0]).
+					AtGoNode(previous.AST.Args[0]),
+			)
+		}
+		found[check.Name] = check
+	}
+}
@@ -1,10 +1,14 @@
 package app
 
 import (
+	"go/ast"
+
 	"encr.dev/pkg/errors"
 	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/parser"
 	"encr.dev/v2/parser/infra/objects"
+	"encr.dev/v2/parser/infra/pubsub"
 )
 
 func (d *Desc) validateObjects(pc *parsectx.Context, result *parser.Result) {
@@ -22,6 +26,10 @@ func (d *Desc) validateObjects(pc *parsectx.Context, result *parser.Result) {
 				buckets[res.Name] = res
 			}
 
+			if qn, ok := res.Events.Get(); ok {
+				d.validateBucketEvents(pc, qn, res.EventsExpr)
+			}
+
 			// Make sure any BucketRef calls are within a service.
 			for _, use := range d.Parse.Usages(res) {
 				switch use := use.(type) {
@@ -38,10 +46,14 @@ func (d *Desc) validateObjects(pc *parsectx.Context, result *parser.Result) {
 					}
 
 				case *objects.MethodUsage:
-					if use.Perm == objects.GetPublicURL && !res.Public {
+					if use.HasPerm(objects.GetPublicURL) && !res.Public {
 						pc.Errs.Add(objects.ErrBucketNotPublic.
 							AtGoNode(use, errors.AsError("used here")))
 					}
+					if (use.HasPerm(objects.SignedUploadURL) || use.HasPerm(objects.SignedDownloadURL)) && res.ClientSideEncryption {
+						pc.Errs.Add(objects.ErrBucketSignedURLEncrypted(use.Method).
+							AtGoNode(use, errors.AsError("used here")))
+					}
 
 					errTxt := "used here"
 					if _, ok := d.ServiceForPath(use.DeclaredIn().FSPath); !ok && !use.DeclaredIn().TestFile {
@@ -54,3 +66,21 @@ func (d *Desc) validateObjects(pc *parsectx.Context, result *parser.Result) {
 		}
 	}
 }
+
+// validateBucketEvents validates that the bucket's events topic
+// is a pubsub topic with the objects.BucketEvent message type.
+func (d *Desc) validateBucketEvents(pc *parsectx.Context, qn pkginfo.QualifiedName, expr ast.Expr) {
+	res, ok := d.Parse.ResourceForQN(qn).Get()
+	topic, isTopic := res.(*pubsub.Topic)
+	if !ok || !isTopic {
+		pc.Errs.Add(objects.ErrBucketEventsNotTopic.AtGoNode(expr))
+		return
+	}
+
+	msg := topic.MessageType
+	if msg.Pointers != 1 || msg.Decl.Name != "BucketEvent" || msg.Decl.File.Pkg.ImportPath != "encore.dev/storage/objects" {
+		pc.Errs.Add(objects.ErrBucketEventsMessageType.
+			AtGoNode(expr).
+			AtGoNode(topic.AST, errors.AsHelp("topic defined here")))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encr.dev/pkg/paths"
 	"encr.dev/v2/internals/parsectx"
 	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/internals/schema"
 	"encr.dev/v2/parser"
 	"encr.dev/v2/parser/apis/api"
 	"encr.dev/v2/parser/apis/servicestruct"
@@ -72,6 +73,27 @@ func (d *Desc) validatePubSub(pc *parsectx.Context, result *parser.Result) {
 			continue
 		}
 
+		if dl, ok := sub.Cfg.DeadLetter.Get(); ok {
+			if dlTopicName, ok := topicsByBinding[dl.Topic]; !ok {
+				pc.Errs.Add(pubsub.ErrSubscriptionDeadLetterTopicNotResource.AtGoNode(dl.TopicExpr))
+			} else if dlTopicName == topicName {
+				pc.Errs.Add(pubsub.ErrSubscriptionDeadLetterTopicIsSubscribedTopic.AtGoNode(dl.TopicExpr))
+			}
+		}
+
+		// Make sure the filter only references attributes on the message type.
+		if filter, ok := sub.Cfg.Filter.Get(); ok {
+			attrs := messageAttrs(topic.resource)
+			for _, attr := range filter.Attrs() {
+				if !attrs[attr] {
+					pc.Errs.Add(pubsub.ErrSubscriptionFilterUnknownAttr(attr).
+						AtGoNode(sub.Cfg.FilterExpr).
+						AtGoNode(topic.resource.MessageType.Decl.AST.Name, errors.AsHelp("message type defined here")),
+					)
+				}
+			}
+		}
+
 		if existing, ok := topic.subs[sub.Name]; ok {
 			pc.Errs.Add(pubsub.ErrSubscriptionNameNotUnique.
 				AtGoNode(existing.AST.Args[1], errors.AsHelp("originally defined here")).
@@ -162,3 +184,16 @@ func (d *Desc) validatePubSub(pc *parsectx.Context, result *parser.Result) {
 		}
 	}
 }
+
+// messageAttrs returns the set of attributes defined on the topic's message type.
+func messageAttrs(topic *pubsub.Topic) map[string]bool {
+	attrs := make(map[string]bool)
+	if str, ok := topic.MessageType.Decl.Type.(schema.StructType); ok {
+		for _, field := range str.Fields {
+			if tag, err := field.Tag.Get("pubsub-attr"); err == nil {
+				attrs[tag.Name] = true
+			}
+		}
+	}
+	return attrs
+}
@@ -23,9 +23,14 @@ import (
 	"encr.dev/v2/internals/testutil"
 	"encr.dev/v2/parser"
 	"encr.dev/v2/parser/apis/middleware"
+	"encr.dev/v2/parser/infra/caches"
 	"encr.dev/v2/parser/infra/config"
 	"encr.dev/v2/parser/infra/crons"
+	"encr.dev/v2/parser/infra/errdefs"
+	"encr.dev/v2/parser/infra/flags"
 	"encr.dev/v2/parser/infra/metrics"
+	"encr.dev/v2/parser/infra/monitors"
+	"encr.dev/v2/parser/infra/objects"
 	"encr.dev/v2/parser/infra/pubsub"
 	"encr.dev/v2/parser/infra/sqldb"
 )
@@ -215,10 +220,51 @@ func parse(ts *testscript.TestScript, neg bool, args []string) {
 				printf("rpc %s.%s access=%v raw=%v path=%v recv=%v",
 					svc.Name, rpc.Name, rpc.Access, rpc.Raw, rpc.Path, recvName,
 				)
+				if mws := desc.MatchingMiddleware(rpc); len(mws) > 0 {
+					names := make([]string, len(mws))
+					for i, mw := range mws {
+						names[i] = mw.Decl.File.Pkg.Name + "." + mw.Decl.Name
+					}
+					printf("rpcMiddleware %s.%s %s", svc.Name, rpc.Name, strings.Join(names, ","))
+				}
+				if envTypes := svc.EndpointEnvTypes(rpc); len(envTypes) > 0 {
+					printf("rpcEnv %s.%s %s", svc.Name, rpc.Name, strings.Join(envTypes, ","))
+				}
+				if c := svc.EndpointCORS(rpc); c != nil {
+					printf("rpcCORS %s.%s origins=%s headers=%s credentials=%v", svc.Name, rpc.Name,
+						strings.Join(c.AllowOrigins, ","), strings.Join(c.AllowHeaders, ","), c.AllowCredentials)
+				}
+				if cluster, ok := desc.CacheCluster(rpc); ok {
+					if rl := rpc.RateLimit; rl != nil {
+						printf("rpcRateLimit %s.%s rate=%d period=%v burst=%d perUser=%v cluster=%s",
+							svc.Name, rpc.Name, rl.Rate, rl.Period, rl.Burst, rl.PerUser, cluster.Name)
+					}
+					if rpc.Idempotent {
+						printf("rpcIdempotent %s.%s cluster=%s", svc.Name, rpc.Name, cluster.Name)
+					}
+					if rc := rpc.ResponseCache; rc != nil {
+						printf("rpcResponseCache %s.%s ttl=%v vary=%s varyAuth=%v cluster=%s",
+							svc.Name, rpc.Name, rc.TTL, strings.Join(rc.VaryHeaders, ","), rc.VaryAuth, cluster.Name)
+					}
+				}
 			}
 		})
 	}
 
+	if fw, ok := desc.Framework.Get(); ok {
+		for _, ah := range fw.AuthHandlers {
+			printf("authHandler %s order=%d", ah.Name, ah.Order.GetOrElse(0))
+		}
+	}
+
+	for _, vs := range desc.VersionSets {
+		var versions []string
+		for _, ep := range vs.Endpoints {
+			versions = append(versions, fmt.Sprintf("%s=v%d", ep.Name, ep.Version))
+		}
+		printf("versionSet %s default=%s %s", vs.Path, vs.Default().Name, strings.Join(versions, ","))
+	}
+
 	// First find all the bindings for each topic
 	topicsByName := make(map[pkginfo.QualifiedName]*pubsub.Topic)
 	for _, res := range desc.Parse.Resources() {
@@ -240,13 +286,36 @@ func parse(ts *testscript.TestScript, neg bool, args []string) {
 			printf("config %s %s", svc.Name, res.Type)
 		case *crons.Job:
 			printf("cronJob %s title=%q", res.Name, res.Title)
+		case *monitors.Check:
+			printf("monitorCheck %s %s %s interval=%s timeout=%s status=%d body=%q maxLatency=%s",
+				res.Name, res.Method, res.Path, res.Interval, res.Timeout, res.ExpectStatus, res.ExpectBodyContains, res.MaxLatency)
+		case *flags.Flag:
+			printf("featureFlag %s rollout=%d users=%s attributes=%s",
+				res.Name, res.Rollout, strings.Join(res.UserIDs, ","), formatTags(res.Attributes))
+		case *errdefs.Definition:
+			printf("errorDefinition %s code=%s message=%q", res.ID, res.Code, res.Message)
 		case *sqldb.Database:
 			for _, b := range desc.Parse.PkgDeclBinds(res) {
 				printf("resource SQLDBResource %s.%s db=%s",
 					b.File.Pkg.Name, b.BoundName.Name, res.Name)
 			}
+			if len(res.Tags) > 0 {
+				printf("resourceTags sqldb %s %s", res.Name, formatTags(res.Tags))
+			}
+			if len(res.Regions) > 0 {
+				printf("resourceRegions sqldb %s %s", res.Name, strings.Join(res.Regions, ","))
+			}
 		case *pubsub.Topic:
 			printf("pubsubTopic %s", res.Name)
+			if res.Ordered {
+				printf("pubsubOrdered %s", res.Name)
+			}
+			if len(res.Tags) > 0 {
+				printf("resourceTags topic %s %s", res.Name, formatTags(res.Tags))
+			}
+			if len(res.Regions) > 0 {
+				printf("resourceRegions topic %s %s", res.Name, strings.Join(res.Regions, ","))
+			}
 
 			for _, u := range desc.Parse.Usages(res) {
 				if pub, ok := u.(*pubsub.PublishUsage); ok {
@@ -275,12 +344,51 @@ func parse(ts *testscript.TestScript, neg bool, args []string) {
 				topicsByName[res.Topic].Name, res.Name, svc.Name, res.Cfg.AckDeadline,
 				res.Cfg.MessageRetention, res.Cfg.MaxRetries, res.Cfg.MinRetryBackoff,
 				res.Cfg.MaxRetryBackoff)
+			if dl, ok := res.Cfg.DeadLetter.Get(); ok {
+				printf("pubsubDeadLetter %s %s %d", res.Name, topicsByName[dl.Topic].Name, dl.MaxDeliveries)
+			}
+			if f, ok := res.Cfg.Filter.Get(); ok {
+				printf("pubsubFilter %s %s", res.Name, f.GCPFilter())
+			}
+			if res.Cfg.DeliveryDelay > 0 {
+				printf("pubsubDeliveryDelay %s %s", res.Name, res.Cfg.DeliveryDelay)
+			}
+		case *objects.Bucket:
+			if len(res.Tags) > 0 {
+				printf("resourceTags bucket %s %s", res.Name, formatTags(res.Tags))
+			}
+			if len(res.Regions) > 0 {
+				printf("resourceRegions bucket %s %s", res.Name, strings.Join(res.Regions, ","))
+			}
+			if lc := res.Lifecycle; lc != nil {
+				printf("bucketLifecycle %s expire=%d cold=%d", res.Name, lc.ExpireAfterDays, lc.ColdStorageAfterDays)
+			}
+			if topic, ok := res.Events.Get(); ok {
+				printf("bucketEvents %s %s", res.Name, topic.Name)
+			}
+			if res.ClientSideEncryption {
+				printf("bucketEncrypted %s", res.Name)
+			}
+		case *caches.Cluster:
+			if len(res.Tags) > 0 {
+				printf("resourceTags cache %s %s", res.Name, formatTags(res.Tags))
+			}
 		case *metrics.Metric:
 			printf("metric %s %s %s %s", res.Name, strings.ToUpper(res.ValueType.String()), strings.ToUpper(res.Type.String()), res.Labels)
 		}
 	}
 }
 
+// formatTags formats resource tags as sorted key=value pairs.
+func formatTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
 func assertGoldenErrors(ts *testscript.TestScript, errs *perr.List, sourceDir string, updateGoldenFiles bool) {
 	// Read the want: errors file
 	// allow for it not to exist
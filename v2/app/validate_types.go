@@ -32,6 +32,20 @@ func (d *Desc) validateType(pc *parsectx.Context, usedAt ast.Node, typ schema.Ty
 							AtGoNode(usedAt, errors.AsHelp("used here")),
 					)
 				}
+				if apienc.IsStringEncoded(field) && !apienc.IsValidStringEncodedType(field.Type) {
+					pc.Errs.Add(
+						apienc.ErrInvalidStringEncodedType.
+							AtGoNode(field.AST, errors.AsError("defined here")).
+							AtGoNode(usedAt, errors.AsHelp("used here")),
+					)
+				}
+				if n, ok := apienc.MaxSize(field); ok && (n <= 0 || !apienc.IsValidMaxSizeType(field.Type)) {
+					pc.Errs.Add(
+						apienc.ErrInvalidMaxSize.
+							AtGoNode(field.AST, errors.AsError("defined here")).
+							AtGoNode(usedAt, errors.AsHelp("used here")),
+					)
+				}
 			}
 
 		case schema.FuncType:
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/internals/resourcepaths"
+	"encr.dev/v2/parser/apis/api"
+)
+
+// validateVersionSets groups the versioned endpoints into version sets by their
+// unversioned path, and adds the unversioned paths the sets are served on to apiPaths.
+func (d *Desc) validateVersionSets(pc *parsectx.Context, apiPaths *resourcepaths.Set) {
+	sets := make(map[string]*api.VersionSet)
+	var unversioned []*api.Endpoint
+	for _, svc := range d.Services {
+		fwSvc, ok := svc.Framework.Get()
+		if !ok {
+			continue
+		}
+		for _, ep := range fwSvc.Endpoints {
+			if ep.Version == 0 {
+				unversioned = append(unversioned, ep)
+				continue
+			}
+
+			key := pathKey(ep.UnversionedPath)
+			vs, ok := sets[key]
+			if !ok {
+				vs = &api.VersionSet{Path: ep.UnversionedPath}
+				sets[key] = vs
+				d.VersionSets = append(d.VersionSets, vs)
+			}
+			vs.Endpoints = append(vs.Endpoints, ep)
+		}
+	}
+
+	// Endpoints with the same version have the same path,
+	// which apiPaths has already reported.
+	for _, vs := range d.VersionSets {
+		slices.SortStableFunc(vs.Endpoints, func(a, b *api.Endpoint) int {
+			return cmp.Compare(a.Version, b.Version)
+		})
+	}
+
+	// An unversioned endpoint on the unversioned path of a version set
+	// would be ambiguous with the set's default version.
+	conflicting := make(map[*api.VersionSet]bool)
+	for _, ep := range unversioned {
+		vs, ok := sets[pathKey(ep.Path)]
+		if !ok || !methodsOverlap(versionSetMethods(vs), ep.HTTPMethods) {
+			continue
+		}
+		def := vs.Default()
+		pc.Errs.Add(api.ErrVersionSetUnversioned(def.Name, ep.Name).
+			AtGoNode(def.VersionField.MustGet(), errors.AsHelp("versioned here")).
+			AtGoNode(ep.Decl.AST.Name, errors.AsError("not versioned")))
+		conflicting[vs] = true
+	}
+
+	for _, vs := range d.VersionSets {
+		if conflicting[vs] {
+			continue
+		}
+		for _, method := range versionSetMethods(vs) {
+			apiPaths.Add(pc.Errs, method, vs.Path)
+		}
+	}
+}
+
+// versionSetMethods returns the HTTP methods the versions in vs accept, sorted.
+func versionSetMethods(vs *api.VersionSet) []string {
+	var methods []string
+	for _, ep := range vs.Endpoints {
+		methods = append(methods, ep.HTTPMethods...)
+	}
+	slices.Sort(methods)
+	return slices.Compact(methods)
+}
+
+// methodsOverlap reports whether an endpoint accepting methods a
+// and one accepting methods b would both serve some requests.
+func methodsOverlap(a, b []string) bool {
+	for _, m := range a {
+		if m == "*" || slices.Contains(b, m) || slices.Contains(b, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// pathKey returns a key identifying the requests path matches,
+// disregarding the names of its parameters.
+func pathKey(path *resourcepaths.Path) string {
+	var b strings.Builder
+	for _, s := range path.Segments {
+		b.WriteByte('/')
+		switch s.Type {
+		case resourcepaths.Literal:
+			b.WriteString(s.Value)
+		case resourcepaths.Param:
+			b.WriteByte(':')
+		case resourcepaths.Wildcard:
+			b.WriteByte('*')
+		case resourcepaths.Fallback:
+			b.WriteByte('!')
+		}
+	}
+	return b.String()
+}
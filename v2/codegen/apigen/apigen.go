@@ -6,6 +6,7 @@ import (
 	"encore.dev/appruntime/exported/config"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 	"encr.dev/v2/app"
 	"encr.dev/v2/codegen"
 	"encr.dev/v2/codegen/apigen/authhandlergen"
@@ -26,6 +27,9 @@ type Params struct {
 	MainModule    *pkginfo.Module
 	RuntimeModule *pkginfo.Module
 
+	// Meta is the app metadata, used to describe the endpoints exposed over gRPC.
+	Meta *meta.Data
+
 	CompilerVersion string
 	AppRevision     string
 	AppUncommitted  bool
@@ -41,6 +45,7 @@ func Process(p Params) *config.Static {
 		Desc:              p.Desc,
 		MainModule:        p.MainModule,
 		RuntimeModule:     p.RuntimeModule,
+		Meta:              p.Meta,
 		Test:              p.Test,
 		ExecScriptMainPkg: p.ExecScriptMainPkg,
 
@@ -50,10 +55,8 @@ func Process(p Params) *config.Static {
 
 		APIHandlers:    make(map[*api.Endpoint]*codegen.VarDecl),
 		Middleware:     make(map[*middleware.Middleware]*codegen.VarDecl),
+		AuthHandlers:   make(map[*authhandler.AuthHandler]*codegen.VarDecl),
 		ServiceStructs: make(map[*app.Service]*codegen.VarDecl),
-
-		// Set below
-		AuthHandler: option.None[*codegen.VarDecl](),
 	}
 
 	if fw, ok := p.Desc.Framework.Get(); ok {
@@ -83,13 +86,13 @@ func Process(p Params) *config.Static {
 			userfacinggen.Gen(p.Gen, svc, svcStruct)
 		}
 
-		gp.AuthHandler = option.Map(fw.AuthHandler, func(ah *authhandler.AuthHandler) *codegen.VarDecl {
+		for _, ah := range fw.AuthHandlers {
 			var svcStruct option.Option[*codegen.VarDecl]
 			if svc, ok := p.Desc.ServiceForPath(ah.Decl.File.FSPath); ok {
 				svcStruct = option.AsOptional(svcStructBySvc[svc.Name])
 			}
-			return authhandlergen.Gen(p.Gen, p.Desc, ah, svcStruct)
-		})
+			gp.AuthHandlers[ah] = authhandlergen.Gen(p.Gen, p.Desc, ah, svcStruct)
+		}
 
 		mws := middlewaregen.Gen(p.Gen, fw.GlobalMiddleware, option.None[*codegen.VarDecl]())
 		maps.Copy(gp.Middleware, mws)
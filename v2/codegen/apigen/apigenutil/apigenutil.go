@@ -28,6 +28,7 @@ func DecodeHeaders(g *Group, httpHeaderExpr, paramExpr *Statement, dec *genutil.
 		listValExpr := Id("h").Dot("Values").Call(Lit(f.WireName))
 		decodeExpr := dec.UnmarshalQueryOrHeader(f.Type, f.WireName, singleValExpr, listValExpr)
 		g.Add(paramExpr.Clone().Dot(f.SrcName).Op("=").Add(decodeExpr))
+		checkMaxSize(g, paramExpr, dec, f, f.WireName)
 	}
 	g.Line()
 }
@@ -45,6 +46,7 @@ func DecodeQuery(g *Group, urlValuesExpr, paramExpr *Statement, dec *genutil.Typ
 		listValExpr := Id("qs").Index(Lit(f.WireName))
 		decodeExpr := dec.UnmarshalQueryOrHeader(f.Type, f.WireName, singleValExpr, listValExpr)
 		g.Add(paramExpr.Clone()).Dot(f.SrcName).Op("=").Add(decodeExpr)
+		checkMaxSize(g, paramExpr, dec, f, f.WireName)
 	}
 	g.Line()
 }
@@ -64,6 +66,7 @@ func DecodeCookie(errs *perr.List, g *Group, httpReqExpr, paramExpr *Statement,
 			if builtin, ok := f.Type.(schema.BuiltinType); ok {
 				decodeExpr := dec.UnmarshalBuiltin(builtin.Kind, f.WireName, Id("c").Dot("Value"), false)
 				g.Add(paramExpr.Clone()).Dot(f.SrcName).Op("=").Add(decodeExpr)
+				checkMaxSize(g, paramExpr, dec, f, f.WireName)
 			} else if info, ok := schemautil.DerefNamedInfo(f.Type, true); ok && info.QualifiedName() == cookieType {
 				g.Add(paramExpr.Clone()).Dot(f.SrcName).Op("=").Id("c")
 				g.Add(dec.IncNonEmpty())
@@ -75,10 +78,106 @@ func DecodeCookie(errs *perr.List, g *Group, httpReqExpr, paramExpr *Statement,
 	g.Line()
 }
 
+// DecodeResponseCookies generates code for decoding the Set-Cookie headers of the
+// *http.Response given by httpRespExpr into the fields given by paramExpr.
+func DecodeResponseCookies(errs *perr.List, g *Group, httpRespExpr, paramExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
+	if len(params) == 0 {
+		return
+	}
+	g.Comment("Decode cookies")
+
+	g.For(List(Id("_"), Id("c")).Op(":=").Range().Add(httpRespExpr.Clone()).Dot("Cookies").Call()).Block(
+		Switch(Id("c").Dot("Name")).BlockFunc(func(g *Group) {
+			for _, f := range params {
+				g.Case(Lit(f.WireName)).BlockFunc(func(g *Group) {
+					if builtin, ok := f.Type.(schema.BuiltinType); ok {
+						decodeExpr := dec.UnmarshalBuiltin(builtin.Kind, f.WireName, Id("c").Dot("Value"), false)
+						g.Add(paramExpr.Clone()).Dot(f.SrcName).Op("=").Add(decodeExpr)
+					} else if apienc.IsHTTPCookie(f.Type) {
+						g.Add(paramExpr.Clone()).Dot(f.SrcName).Op("=").Id("c")
+						g.Add(dec.IncNonEmpty())
+					} else {
+						errs.Addf(f.Type.ASTExpr().Pos(), "cannot unmarshal cookie into field of type %s", f.Type)
+					}
+				})
+			}
+		}),
+	)
+	g.Line()
+}
+
+// EncodeCookies generates code for encoding cookies as "Cookie" headers into a http.Header map.
+// Only the cookie name and value are sent; Set-Cookie attributes are not part of requests.
+func EncodeCookies(errs *perr.List, g *Group, httpHeaderExpr, paramExpr *Statement, params []*apienc.ParameterEncoding) {
+	if len(params) == 0 {
+		return
+	}
+
+	g.Line()
+	g.Comment("Encode cookies")
+	g.If(httpHeaderExpr.Clone().Op("==").Nil()).Block(
+		httpHeaderExpr.Clone().Op("=").Make(Qual("net/http", "Header"), Lit(1)),
+	)
+
+	cookieType := Qual("net/http", "Cookie")
+	for _, f := range params {
+		if builtin, ok := f.Type.(schema.BuiltinType); ok {
+			cookie := Op("&").Add(cookieType.Clone()).Values(Dict{
+				Id("Name"):  Lit(f.WireName),
+				Id("Value"): genutil.MarshalBuiltin(builtin.Kind, paramExpr.Clone().Dot(f.SrcName)),
+			})
+			g.Add(httpHeaderExpr.Clone()).Dot("Add").Call(Lit("Cookie"), Parens(cookie).Dot("String").Call())
+		} else if apienc.IsHTTPCookie(f.Type) {
+			g.If(Id("c").Op(":=").Add(paramExpr.Clone()).Dot(f.SrcName).Op(";").Id("c").Op("!=").Nil()).Block(
+				httpHeaderExpr.Clone().Dot("Add").Call(Lit("Cookie"), Parens(Op("&").Add(cookieType.Clone()).Values(Dict{
+					Id("Name"):  Lit(f.WireName),
+					Id("Value"): Id("c").Dot("Value"),
+				})).Dot("String").Call()),
+			)
+		} else {
+			errs.Addf(f.Type.ASTExpr().Pos(), "cannot marshal %s to cookie", f.Type)
+		}
+	}
+
+	g.Line()
+}
+
+// EncodeResponseCookies generates code for appending the cookies described by params
+// to the []*http.Cookie slice given by cookiesExpr, for writing as Set-Cookie headers.
+//
+// Fields of type *http.Cookie retain their attributes (Path, Expires, HttpOnly, etc),
+// but the cookie name is always taken from the struct tag.
+func EncodeResponseCookies(errs *perr.List, g *Group, cookiesExpr, paramExpr *Statement, params []*apienc.ParameterEncoding) {
+	if len(params) == 0 {
+		return
+	}
+
+	g.Line().Comment("Encode cookies")
+	cookieType := Qual("net/http", "Cookie")
+	for _, f := range params {
+		if builtin, ok := f.Type.(schema.BuiltinType); ok {
+			g.Add(cookiesExpr.Clone()).Op("=").Append(cookiesExpr.Clone(), Op("&").Add(cookieType.Clone()).Values(Dict{
+				Id("Name"):  Lit(f.WireName),
+				Id("Value"): genutil.MarshalBuiltin(builtin.Kind, paramExpr.Clone().Dot(f.SrcName)),
+			}))
+		} else if apienc.IsHTTPCookie(f.Type) {
+			g.If(Id("c").Op(":=").Add(paramExpr.Clone()).Dot(f.SrcName).Op(";").Id("c").Op("!=").Nil()).Block(
+				Id("c").Op(":=").Op("*").Id("c"),
+				Id("c").Dot("Name").Op("=").Lit(f.WireName),
+				cookiesExpr.Clone().Op("=").Append(cookiesExpr.Clone(), Op("&").Id("c")),
+			)
+		} else {
+			errs.Addf(f.Type.ASTExpr().Pos(), "cannot marshal %s to cookie", f.Type)
+		}
+	}
+}
+
 const jsonIterPkg = "github.com/json-iterator/go"
 
 // DecodeBody decodes an io.Reader request body into the given parameters.
-func DecodeBody(g *Group, ioReaderExpr *Statement, paramsExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
+// If strict is true, fields in the body that do not match any parameter are
+// reported as unknown fields instead of being ignored.
+func DecodeBody(g *Group, ioReaderExpr *Statement, paramsExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding, strict bool) {
 	if len(params) == 0 {
 		return
 	}
@@ -92,11 +191,22 @@ func DecodeBody(g *Group, ioReaderExpr *Statement, paramsExpr *Statement, dec *g
 		Func().Params(Id("_").Op("*").Qual(jsonIterPkg, "Iterator"), Id("key").String()).Bool().Block(
 			Switch(Qual("strings", "ToLower").Call(Id("key"))).BlockFunc(func(g *Group) {
 				for _, f := range params {
-					g.Case(Lit(strings.ToLower(f.WireName))).Block(
-						dec.ParseJSON(f.SrcName, Id("iter"), Op("&").Add(paramsExpr.Clone()).Dot(f.SrcName)),
-					)
+					dst := Op("&").Add(paramsExpr.Clone()).Dot(f.SrcName)
+					g.Case(Lit(strings.ToLower(f.WireName))).BlockFunc(func(g *Group) {
+						if f.StringEncoded {
+							g.Add(dec.ParseJSONIntString(f.SrcName, Id("iter"), dst))
+						} else {
+							g.Add(dec.ParseJSON(f.SrcName, Id("iter"), dst))
+						}
+						checkMaxSize(g, paramsExpr, dec, f, f.SrcName)
+					})
 				}
-				g.Default().Block(Id("_").Op("=").Id("iter").Dot("SkipAndReturnBytes").Call())
+				g.Default().BlockFunc(func(g *Group) {
+					if strict {
+						g.Add(dec.UnknownField(Id("key")))
+					}
+					g.Id("_").Op("=").Id("iter").Dot("SkipAndReturnBytes").Call()
+				})
 			}),
 			Return(True()),
 		)).Block(),
@@ -104,6 +214,49 @@ func DecodeBody(g *Group, ioReaderExpr *Statement, paramsExpr *Statement, dec *g
 	g.Line()
 }
 
+// DecodeMultipartBody is like DecodeBody but for multipart/form-data request bodies,
+// which are used for requests containing files. Files are read from the form's files
+// and the other parameters from its values, which are decoded like query strings.
+func DecodeMultipartBody(g *Group, httpReqExpr *Statement, paramsExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding, strict bool) {
+	if len(params) == 0 {
+		return
+	}
+
+	g.Comment("Decode multipart request body")
+	g.Id("form").Op(":=").Add(dec.ReadMultipartForm(httpReqExpr))
+	g.Id("fv").Op(":=").Qual("net/url", "Values").Call(Id("form").Dot("Value"))
+
+	known := make([]string, 0, len(params))
+	for _, f := range params {
+		known = append(known, f.WireName)
+		if apienc.IsFileType(f.Type) {
+			_, isList := f.Type.(schema.ListType)
+			filesExpr := Id("form").Dot("File").Index(Lit(f.WireName))
+			g.Add(paramsExpr.Clone()).Dot(f.SrcName).Op("=").Add(dec.FormFile(filesExpr, isList))
+			continue
+		}
+
+		singleValExpr := Id("fv").Dot("Get").Call(Lit(f.WireName))
+		listValExpr := Id("fv").Index(Lit(f.WireName))
+		decodeExpr := dec.UnmarshalQueryOrHeader(f.Type, f.WireName, singleValExpr, listValExpr)
+		g.Add(paramsExpr.Clone()).Dot(f.SrcName).Op("=").Add(decodeExpr)
+		checkMaxSize(g, paramsExpr, dec, f, f.WireName)
+	}
+	if strict {
+		g.Add(dec.UnknownFormFields(Id("form"), known))
+	}
+	g.Line()
+}
+
+// checkMaxSize generates code for checking the decoded size of the parameter
+// given by paramExpr against its maximum size, if it has one.
+// The field name is only used for error reporting.
+func checkMaxSize(g *Group, paramExpr *Statement, dec *genutil.TypeUnmarshaller, f *apienc.ParameterEncoding, fieldName string) {
+	if f.MaxSize > 0 {
+		g.Add(dec.CheckMaxSize(fieldName, paramExpr.Clone().Dot(f.SrcName), f.MaxSize))
+	}
+}
+
 // EncodeHeaders generates code for encoding HTTP headers into a http.Header map.
 func EncodeHeaders(errs *perr.List, g *Group, httpHeaderExpr, paramExpr *Statement, params []*apienc.ParameterEncoding) {
 	if len(params) == 0 {
@@ -183,7 +336,7 @@ func EncodeBody(gu *genutil.Helper, g *Group, streamExpr, paramExpr *Statement,
 		}
 
 		writeBlock.Add(streamExpr.Clone().Dot("WriteObjectField").Call(Lit(p.WireName)))
-		writeBlock.Add(streamExpr.Clone().Dot("WriteVal").Call(paramExpr.Clone().Dot(p.SrcName)))
+		writeBlock.Add(streamExpr.Clone().Dot("WriteVal").Call(JSONValue(p, paramExpr.Clone().Dot(p.SrcName))))
 		if i+1 < len(params) {
 			// If we're not on the last field, write a comma.
 			// we do this within the writeBlock so that we don't write a comma if we're omitting the field.
@@ -194,6 +347,19 @@ func EncodeBody(gu *genutil.Helper, g *Group, streamExpr, paramExpr *Statement,
 	g.Line()
 }
 
+// JSONValue returns the expression to encode as JSON for the given parameter.
+// Parameters tagged with encore:"string" are wrapped so they're encoded as JSON strings.
+func JSONValue(p *apienc.ParameterEncoding, valueExpr *Statement) *Statement {
+	if !p.StringEncoded {
+		return valueExpr
+	}
+	wrapper := "Int64String"
+	if schemautil.IsBuiltinKind(p.Type, schema.Uint, schema.Uint64) {
+		wrapper = "Uint64String"
+	}
+	return Qual("encore.dev/appruntime/shared/etype", wrapper).Call(valueExpr)
+}
+
 // BuildErr returns an expression for returning an encore.dev/beta/errs.Error with the given code and message.
 func BuildErr(code, msg string) *Statement {
 	p := "encore.dev/beta/errs"
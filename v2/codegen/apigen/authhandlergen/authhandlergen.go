@@ -25,18 +25,23 @@ func Gen(gen *codegen.Generator, appDesc *app.Desc, ah *authhandler.AuthHandler,
 		svcNum = svc.Num
 	}
 
-	desc.Value(Op("&").Add(apiQ("AuthHandlerDesc")).Types(
-		gu.Type(ah.Param),
-	).Values(Dict{
+	fields := Dict{
 		Id("Service"): Lit(svcName),
 		Id("SvcNum"):  Lit(svcNum),
-		Id("DefLoc"):  Lit(gen.TraceNodes.AuthHandler()),
+		Id("DefLoc"):  Lit(gen.TraceNodes.AuthHandler(ah)),
 
 		Id("Endpoint"):    Lit(ah.Name),
 		Id("HasAuthData"): Lit(ah.AuthData.Present()),
 		Id("DecodeAuth"):  renderDecodeAuth(gen, f, ah, enc),
 		Id("AuthHandler"): renderAuthHandler(gen, ah, svcStruct),
-	}))
+	}
+	if order, ok := ah.Order.Get(); ok {
+		fields[Id("Order")] = Lit(order)
+	}
+
+	desc.Value(Op("&").Add(apiQ("AuthHandlerDesc")).Types(
+		gu.Type(ah.Param),
+	).Values(fields))
 
 	f.Add(Func().Id("init").Params().Block(
 		Qual("encore.dev/appruntime/apisdk/api", "RegisterAuthHandler").Call(
@@ -12,7 +12,7 @@ import (
 
 func TestCodegen(t *testing.T) {
 	fn := func(gen *codegen.Generator, desc *app.Desc) {
-		ah := desc.Framework.MustGet().AuthHandler.MustGet()
+		ah := desc.Framework.MustGet().AuthHandler().MustGet()
 
 		var svcStruct option.Option[*codegen.VarDecl]
 		if len(desc.Services) > 0 {
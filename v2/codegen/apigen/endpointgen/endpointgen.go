@@ -3,6 +3,7 @@ package endpointgen
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	. "github.com/dave/jennifer/jen"
 
@@ -73,11 +74,7 @@ func genAPIDesc(
 	}
 
 	pos := ep.Decl.AST.Pos()
-	desc := f.VarDecl("APIDesc", ep.Name)
-	desc.Value(Op("&").Add(apiQ("Desc")).Types(
-		reqDesc.Type(),
-		respDesc.Type(),
-	).Values(Dict{
+	fields := Dict{
 		Id("Service"):        Lit(svc.Name),
 		Id("SvcNum"):         Lit(svc.Num),
 		Id("Endpoint"):       Lit(ep.Name),
@@ -106,7 +103,48 @@ func genAPIDesc(
 
 		Id("ServiceMiddleware"):   serviceMiddleware(ep, fw, svcMiddleware),
 		Id("GlobalMiddlewareIDs"): globalMiddleware(appDesc, ep),
-	}))
+	}
+	if envTypes := svc.EndpointEnvTypes(ep); len(envTypes) > 0 && ep.Access != api.Private {
+		fields[Id("EnvTypes")] = gu.GoToJen(pos, envTypes)
+	}
+	if ep.Version > 0 {
+		fields[Id("Version")] = Lit(ep.Version)
+		fields[Id("UnversionedRawPath")] = Lit(rawPath(ep.UnversionedPath))
+	}
+	if ep.Deprecated.Present() {
+		fields[Id("Deprecated")] = True()
+	}
+	if ep.DisableCompression {
+		fields[Id("DisableCompression")] = True()
+	}
+	if ep.Timeout > 0 {
+		fields[Id("Timeout")] = duration(ep.Timeout)
+	}
+	if ep.MaxBodySize > 0 {
+		fields[Id("MaxBodySize")] = Lit(int(ep.MaxBodySize))
+	}
+	if c := svc.EndpointCORS(ep); c != nil && ep.Access != api.Private {
+		fields[Id("CORS")] = corsPolicy(c)
+	}
+	if cluster, ok := appDesc.CacheCluster(ep); ok {
+		if ep.RateLimit != nil {
+			fields[Id("RateLimit")] = rateLimit(ep.RateLimit, cluster.Name)
+		}
+		if ep.Idempotent {
+			fields[Id("Idempotency")] = Op("&").Add(apiQ("Idempotency")).Values(Dict{
+				Id("Cluster"): Lit(cluster.Name),
+			})
+		}
+		if ep.ResponseCache != nil {
+			fields[Id("ResponseCache")] = responseCache(ep.ResponseCache, cluster.Name)
+		}
+	}
+
+	desc := f.VarDecl("APIDesc", ep.Name)
+	desc.Value(Op("&").Add(apiQ("Desc")).Types(
+		reqDesc.Type(),
+		respDesc.Type(),
+	).Values(fields))
 
 	handler.desc = desc
 	return handler
@@ -130,6 +168,84 @@ func globalMiddleware(appDesc *app.Desc, ep *api.Endpoint) *Statement {
 	})
 }
 
+// rateLimit returns the *api.RateLimit describing the endpoint's rate limit.
+func rateLimit(rl *api.RateLimit, cluster string) *Statement {
+	period := map[time.Duration]string{
+		time.Second: "Second",
+		time.Minute: "Minute",
+		time.Hour:   "Hour",
+	}[rl.Period]
+
+	fields := Dict{
+		Id("Cluster"): Lit(cluster),
+		Id("Rate"):    Lit(rl.Rate),
+		Id("Period"):  Qual("time", period),
+	}
+	if rl.Burst > 0 {
+		fields[Id("Burst")] = Lit(rl.Burst)
+	}
+	if rl.PerUser {
+		fields[Id("PerUser")] = True()
+	}
+	return Op("&").Add(apiQ("RateLimit")).Values(fields)
+}
+
+// responseCache returns the *api.ResponseCache describing how the endpoint's responses are cached.
+func responseCache(rc *api.ResponseCache, cluster string) *Statement {
+	fields := Dict{
+		Id("Cluster"): Lit(cluster),
+		Id("TTL"):     duration(rc.TTL),
+	}
+	if len(rc.VaryHeaders) > 0 {
+		fields[Id("VaryHeaders")] = Index().String().ValuesFunc(func(g *Group) {
+			for _, h := range rc.VaryHeaders {
+				g.Lit(h)
+			}
+		})
+	}
+	if rc.VaryAuth {
+		fields[Id("VaryAuth")] = True()
+	}
+	return Op("&").Add(apiQ("ResponseCache")).Values(fields)
+}
+
+// corsPolicy returns the *api.CORS describing the endpoint's CORS policy.
+func corsPolicy(c *api.CORS) *Statement {
+	fields := Dict{}
+	if len(c.AllowOrigins) > 0 {
+		fields[Id("AllowOrigins")] = Index().String().ValuesFunc(func(g *Group) {
+			for _, o := range c.AllowOrigins {
+				g.Lit(o)
+			}
+		})
+	}
+	if len(c.AllowHeaders) > 0 {
+		fields[Id("AllowHeaders")] = Index().String().ValuesFunc(func(g *Group) {
+			for _, h := range c.AllowHeaders {
+				g.Lit(h)
+			}
+		})
+	}
+	if c.AllowCredentials {
+		fields[Id("AllowCredentials")] = True()
+	}
+	return Op("&").Add(apiQ("CORS")).Values(fields)
+}
+
+// duration returns an expression for d in the largest unit it's a whole number of,
+// such as 5 * time.Minute.
+func duration(d time.Duration) *Statement {
+	for _, unit := range []struct {
+		name string
+		dur  time.Duration
+	}{{"Hour", time.Hour}, {"Minute", time.Minute}, {"Second", time.Second}} {
+		if d%unit.dur == 0 {
+			return Lit(int(d/unit.dur)).Op("*").Qual("time", unit.name)
+		}
+	}
+	return Qual("time", "Duration").Call(Lit(int64(d)))
+}
+
 func registerHandlers(appDesc *app.Desc, file *codegen.File, handlers []*handlerDesc) {
 	f := file.Jen
 	f.Func().Id("init").Params().BlockFunc(func(g *Group) {
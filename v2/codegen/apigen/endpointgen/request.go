@@ -215,6 +215,15 @@ func (d *requestDesc) renderRequestDecoding(g *Group, dec *genutil.TypeUnmarshal
 		return
 	}
 
+	// Record streams are read lazily from the request body by the handler.
+	if d.ep.StreamRecord != nil {
+		g.List(d.reqDataPayloadExpr(), Err()).Op("=").Qual("encore.dev/beta/stream", "NewRequestReader").Types(
+			d.gu.Type(d.ep.StreamRecord),
+		).Call(d.httpReqExpr())
+		g.If(Err().Op("!=").Nil()).Block(Return(Nil(), Nil(), Err()))
+		return
+	}
+
 	if schemautil.IsPointer(d.ep.Request) {
 		g.Id("params").Op(":=").Add(d.gu.Initialize(d.ep.Request))
 		g.Add(d.reqDataPayloadExpr()).Op("=").Id("params")
@@ -248,13 +257,24 @@ func (d *requestDesc) renderRequestDecoding(g *Group, dec *genutil.TypeUnmarshal
 func (d *requestDesc) decodeRequestParameters(g *Group, dec *genutil.TypeUnmarshaller, req *apienc.RequestEncoding) {
 	apigenutil.DecodeHeaders(g, d.httpReqExpr().Dot("Header"), Id("params"), dec, req.HeaderParameters)
 	apigenutil.DecodeQuery(g, d.httpReqExpr().Dot("URL").Dot("Query").Call(), Id("params"), dec, req.QueryParameters)
-	apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters)
+	apigenutil.DecodeCookie(d.gu.Errs, g, d.httpReqExpr(), Id("params"), dec, req.CookieParameters)
+	if req.Multipart() {
+		apigenutil.DecodeMultipartBody(g, d.httpReqExpr(), Id("params"), dec, req.BodyParameters, d.ep.StrictDecoding)
+	} else {
+		apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters, d.ep.StrictDecoding)
+	}
 }
 
 // Clone returns the function literal to clone the request.
 func (d *requestDesc) Clone() *Statement {
 	const recv = "r"
 	return Func().Params(Id(recv).Add(d.Type())).Params(d.Type(), Error()).BlockFunc(func(g *Group) {
+		if d.ep.StreamRecord != nil {
+			// Record streams can only be read once, so there's nothing to clone.
+			g.Return(Id(recv), Nil())
+			return
+		}
+
 		// We could optimize the clone operation if there are no reference types (pointers, maps, slices)
 		// in the struct. For now, simply serialize it as JSON and back.
 		g.Var().Id("clone").Add(d.Type())
@@ -262,10 +282,39 @@ func (d *requestDesc) Clone() *Statement {
 		g.If(Err().Op("==").Nil()).Block(
 			Err().Op("=").Qual(jsonIterPkg, "ConfigDefault").Dot("Unmarshal").Call(Id("bytes"), Op("&").Id("clone")),
 		)
+
+		// Uploaded files can't be serialized, so share them with the clone instead.
+		if files := d.fileParams(); len(files) > 0 {
+			cond := Err().Op("==").Nil()
+			if schemautil.IsPointer(d.ep.Request) {
+				cond = cond.Op("&&").Id(recv).Dot(d.reqDataPayloadName()).Op("!=").Nil()
+			}
+			g.If(cond).BlockFunc(func(g *Group) {
+				for _, f := range files {
+					g.Id("clone").Dot(d.reqDataPayloadName()).Dot(f.SrcName).Op("=").
+						Id(recv).Dot(d.reqDataPayloadName()).Dot(f.SrcName)
+				}
+			})
+		}
 		g.Return(Id("clone"), Err())
 	})
 }
 
+// fileParams returns the request parameters containing uploaded files.
+func (d *requestDesc) fileParams() []*apienc.ParameterEncoding {
+	var params []*apienc.ParameterEncoding
+	seen := make(map[string]bool)
+	for _, enc := range d.ep.RequestEncoding() {
+		for _, p := range enc.BodyParameters {
+			if apienc.IsFileType(p.Type) && !seen[p.SrcName] {
+				seen[p.SrcName] = true
+				params = append(params, p)
+			}
+		}
+	}
+	return params
+}
+
 // ReqPath returns the function literal to compute the request path.
 func (d *requestDesc) ReqPath() *Statement {
 	return Func().Params(
@@ -316,7 +365,7 @@ func (d *requestDesc) UserPayload() *Statement {
 		// output
 		Any(),
 	).BlockFunc(func(g *Group) {
-		if d.ep.Request == nil {
+		if d.ep.Request == nil || d.ep.StreamRecord != nil {
 			g.Return(Nil())
 		} else {
 			g.Return(d.reqDataPayloadExpr())
@@ -333,8 +382,8 @@ func (d *requestDesc) EncodeExternalReq() *Statement {
 		d.queryStringExpr().Add(Qual("net/url", "Values")),
 		Err().Error(),
 	).BlockFunc(func(g *Group) {
-		if d.ep.Request == nil {
-			// Nothing to do.
+		if d.ep.Request == nil || d.ep.StreamRecord != nil {
+			// Nothing to do; endpoints receiving record streams can't be called by other services.
 			g.Return(Nil(), Nil(), Nil())
 			return
 		}
@@ -362,6 +411,7 @@ func (d *requestDesc) EncodeExternalReq() *Statement {
 
 		apigenutil.EncodeHeaders(d.gu.Errs, g, d.httpHeaderExpr(), Id("params"), enc.HeaderParameters)
 		apigenutil.EncodeQuery(d.gu.Errs, g, d.queryStringExpr(), Id("params"), enc.QueryParameters)
+		apigenutil.EncodeCookies(d.gu.Errs, g, d.httpHeaderExpr(), Id("params"), enc.CookieParameters)
 		apigenutil.EncodeBody(d.gu, g, d.jsonStream(), Id("params"), enc.BodyParameters)
 
 		g.Return(d.httpHeaderExpr(), d.queryStringExpr(), Err())
@@ -56,6 +56,10 @@ func (d *responseDesc) EncodeResponse() *Statement {
 		if d.ep.Response == nil {
 			g.Return(Nil())
 			return
+		} else if d.ep.StreamEvent != nil {
+			// Event streams are written by the runtime as the events are produced.
+			g.Return(Nil())
+			return
 		}
 
 		resp := apienc.DescribeResponse(d.gu.Errs, d.ep.Response)
@@ -67,6 +71,9 @@ func (d *responseDesc) EncodeResponse() *Statement {
 		if len(resp.HeaderParameters) > 0 {
 			g.Var().Id("headers").Map(String()).Index().String()
 		}
+		if len(resp.CookieParameters) > 0 {
+			g.Var().Id("cookies").Index().Op("*").Qual("net/http", "Cookie")
+		}
 
 		responseEncoder := CustomFunc(Options{Separator: "\n"}, func(g *Group) {
 			if len(resp.BodyParameters) > 0 {
@@ -78,7 +85,7 @@ func (d *responseDesc) EncodeResponse() *Statement {
 					).BlockFunc(
 						func(g *Group) {
 							for _, f := range resp.BodyParameters {
-								g.Add(Id("ser").Dot("WriteField").Call(Lit(f.WireName), Id("resp").Dot(f.SrcName), Lit(f.OmitEmpty)))
+								g.Add(Id("ser").Dot("WriteField").Call(Lit(f.WireName), apigenutil.JSONValue(f, Id("resp").Dot(f.SrcName)), Lit(f.OmitEmpty)))
 							}
 						}))
 				g.If(Err().Op("!=").Nil()).Block(
@@ -100,6 +107,8 @@ func (d *responseDesc) EncodeResponse() *Statement {
 					}
 				}))
 			}
+
+			apigenutil.EncodeResponseCookies(d.gu.Errs, g, Id("cookies"), Id("resp"), resp.CookieParameters)
 		})
 
 		// If response is a ptr we need to check it's not nil
@@ -118,6 +127,13 @@ func (d *responseDesc) EncodeResponse() *Statement {
 			)
 		}
 
+		if len(resp.CookieParameters) > 0 {
+			g.Line().Comment("Set response cookies")
+			g.For(List(Id("_"), Id("c")).Op(":=").Range().Id("cookies")).Block(
+				Qual("net/http", "SetCookie").Call(Id("w"), Id("c")),
+			)
+		}
+
 		g.Line().Comment("Set HTTP status code")
 		if resp.HTTPStatusParameter != nil {
 			g.Id("statusCode").Op(":=").Id("status")
@@ -164,6 +180,10 @@ func (d *responseDesc) DecodeExternalResp() *Statement {
 		if d.ep.Response == nil {
 			g.Return(d.ZeroType(), Nil())
 			return
+		} else if d.ep.StreamEvent != nil {
+			// Nothing to do; endpoints streaming events can't be called by other services.
+			g.Return(d.ZeroType(), Nil())
+			return
 		}
 
 		g.Id("resp").Op("=").Add(d.gu.Initialize(d.ep.Response))
@@ -179,7 +199,8 @@ func (d *responseDesc) DecodeExternalResp() *Statement {
 		}
 
 		apigenutil.DecodeHeaders(g, Id("httpResp").Dot("Header"), Id("resp"), dec, enc.HeaderParameters)
-		apigenutil.DecodeBody(g, Id("httpResp").Dot("Body"), Id("resp"), dec, enc.BodyParameters)
+		apigenutil.DecodeResponseCookies(d.gu.Errs, g, Id("httpResp"), Id("resp"), dec, enc.CookieParameters)
+		apigenutil.DecodeBody(g, Id("httpResp").Dot("Body"), Id("resp"), dec, enc.BodyParameters, false)
 
 		g.If(Err().Op(":=").Add(dec.Err()), Err().Op("!=").Nil()).Block(
 			Return(d.ZeroType(), Err()),
@@ -218,6 +239,12 @@ func (d *responseDesc) zero() *Statement {
 func (d *responseDesc) Clone() *Statement {
 	const recv = "r"
 	return Func().Params(Id(recv).Add(d.Type())).Params(d.Type(), Error()).BlockFunc(func(g *Group) {
+		if d.ep.StreamEvent != nil {
+			// Event streams can only be served once, so there's nothing to clone.
+			g.Return(Id(recv), Nil())
+			return
+		}
+
 		// We could optimize the clone operation if there are no reference types (pointers, maps, slices)
 		// in the struct. For now, simply serialize it as JSON and back.
 		g.Var().Id("clone").Add(d.Type())
@@ -2,17 +2,21 @@ package maingen
 
 import (
 	"cmp"
+	"go/token"
 	"maps"
 	"net/http"
 	"slices"
 	"sort"
 
 	. "github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/proto"
 
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/experiments"
+	"encr.dev/pkg/clientgen/protobuf"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 	"encr.dev/v2/app"
 	"encr.dev/v2/app/apiframework"
 	"encr.dev/v2/codegen"
@@ -57,9 +61,32 @@ func GenAppConfig(p GenParams, test option.Option[testParams]) *config.Static {
 		maps.Copy(cfg.EmbeddedEnvs, test.EnvsToEmbed)
 	}
 
+	if p.Meta != nil {
+		cfg.GRPCDescriptor = grpcDescriptor(p.Gen, p.Meta)
+	}
+
 	return cfg
 }
 
+// grpcDescriptor returns the serialized protobuf descriptor of the
+// endpoints exposed over gRPC, or nil if there are none.
+func grpcDescriptor(gen *codegen.Generator, md *meta.Data) []byte {
+	desc, err := protobuf.Descriptor(md)
+	if err != nil {
+		gen.Errs.Addf(token.NoPos, "unable to describe the endpoints exposed over gRPC: %v", err)
+		return nil
+	} else if desc == nil {
+		return nil
+	}
+
+	data, err := proto.Marshal(desc)
+	if err != nil {
+		gen.Errs.Addf(token.NoPos, "unable to marshal the gRPC descriptor: %v", err)
+		return nil
+	}
+	return data
+}
+
 func pubsubTopics(gen *codegen.Generator, appDesc *app.Desc) map[string]*config.StaticPubsubTopic {
 	result := make(map[string]*config.StaticPubsubTopic)
 	// Get all the topics and subscriptions
@@ -8,11 +8,13 @@ import (
 	"encore.dev/appruntime/exported/config"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 	"encr.dev/v2/app"
 	"encr.dev/v2/app/apiframework"
 	"encr.dev/v2/codegen"
 	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/parser/apis/api"
+	"encr.dev/v2/parser/apis/authhandler"
 	"encr.dev/v2/parser/apis/middleware"
 )
 
@@ -22,6 +24,10 @@ type GenParams struct {
 	MainModule    *pkginfo.Module
 	RuntimeModule *pkginfo.Module
 
+	// Meta is the app metadata, used to describe the endpoints exposed over gRPC.
+	// If nil, no endpoints are exposed over gRPC.
+	Meta *meta.Data
+
 	// CompilerVersion is the version of the compiler to embed in the generated code.
 	CompilerVersion string
 	// AppRevision is the revision of the app to embed in the generated code.
@@ -31,7 +37,7 @@ type GenParams struct {
 	AppUncommitted bool
 
 	APIHandlers    map[*api.Endpoint]*codegen.VarDecl
-	AuthHandler    option.Option[*codegen.VarDecl]
+	AuthHandlers   map[*authhandler.AuthHandler]*codegen.VarDecl
 	Middleware     map[*middleware.Middleware]*codegen.VarDecl
 	ServiceStructs map[*app.Service]*codegen.VarDecl
 
@@ -72,7 +78,7 @@ func genMain(p GenParams) *config.Static {
 	}
 	// Make sure auth handlers and global middleware are imported as well so they get registered.
 	if fw, ok := p.Desc.Framework.Get(); ok {
-		if ah, ok := fw.AuthHandler.Get(); ok {
+		for _, ah := range fw.AuthHandlers {
 			f.Anon(ah.Decl.File.Pkg.ImportPath.String())
 		}
 
@@ -113,7 +119,7 @@ func genExecScriptMain(p GenParams, mainPkgPath paths.Pkg) *config.Static {
 
 	// Make sure auth handlers and global middleware are imported as well so they get registered.
 	if fw, ok := p.Desc.Framework.Get(); ok {
-		if ah, ok := fw.AuthHandler.Get(); ok {
+		for _, ah := range fw.AuthHandlers {
 			f.Anon(ah.Decl.File.Pkg.ImportPath.String())
 		}
 		for _, mw := range fw.GlobalMiddleware {
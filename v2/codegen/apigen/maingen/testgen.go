@@ -16,7 +16,7 @@ func genTestConfigs(p GenParams, test codegen.TestConfig) *config.Static {
 		//	f.ImportAnon("encore.dev/appruntime/shared/testsupport")
 		//
 		//	if fw, ok := p.Desc.Framework.Get(); ok {
-		//		if ah, ok := fw.AuthHandler.Get(); ok {
+		//		for _, ah := range fw.AuthHandlers {
 		//			f.ImportAnon(ah.Decl.File.Pkg.ImportPath)
 		//		}
 		//		for _, mw := range fw.GlobalMiddleware {
@@ -164,6 +164,32 @@ func (u *TypeUnmarshaller) ReadBody(bodyExpr *Statement) *Statement {
 	return u.unmarshallerExpr.Clone().Dot("ReadBody").Call(bodyExpr.Clone())
 }
 
+// ReadMultipartForm returns an expression to parse the multipart/form-data
+// body of the *http.Request given by reqExpr into a *multipart.Form.
+func (u *TypeUnmarshaller) ReadMultipartForm(reqExpr *Statement) *Statement {
+	return u.unmarshallerExpr.Clone().Dot("ReadMultipartForm").Call(reqExpr.Clone())
+}
+
+// FormFile returns an expression to get the single file, or a list of files if isList is true,
+// from the []*multipart.FileHeader given by filesExpr.
+func (u *TypeUnmarshaller) FormFile(filesExpr *Statement, isList bool) *Statement {
+	if isList {
+		return u.unmarshallerExpr.Clone().Dot("FormFiles").Call(filesExpr)
+	}
+	return u.unmarshallerExpr.Clone().Dot("FormFile").Call(filesExpr)
+}
+
+// UnknownFormFields returns a statement to record the fields in the
+// *multipart.Form given by formExpr that are not among the known ones.
+func (u *TypeUnmarshaller) UnknownFormFields(formExpr *Statement, known []string) *Statement {
+	return u.unmarshallerExpr.Clone().Dot("UnknownFormFields").CallFunc(func(g *Group) {
+		g.Add(formExpr)
+		for _, k := range known {
+			g.Lit(k)
+		}
+	})
+}
+
 // ParseJSON returns an expression to parse json.
 // It uses the iterator accessed through the given iteratorExpr to parse JSON into the given dstExpr.
 // The dstExpr must be a pointer value.
@@ -172,6 +198,29 @@ func (u *TypeUnmarshaller) ParseJSON(fieldName string, iteratorExpr *Statement,
 	return u.unmarshallerExpr.Clone().Dot("ParseJSON").Call(Lit(fieldName), iteratorExpr, dstExpr)
 }
 
+// ParseJSONIntString returns an expression to parse a JSON-string-encoded integer.
+// It uses the iterator accessed through the given iteratorExpr to parse JSON into the given dstExpr,
+// accepting both JSON strings and JSON numbers. The dstExpr must be a pointer to an integer.
+// The field name is only used for error reporting.
+func (u *TypeUnmarshaller) ParseJSONIntString(fieldName string, iteratorExpr *Statement, dstExpr *Statement) *Statement {
+	return Qual("encore.dev/appruntime/shared/etype", "ParseJSONIntString").Call(
+		u.unmarshallerExpr.Clone(), Lit(fieldName), iteratorExpr, dstExpr,
+	)
+}
+
+// UnknownField returns a statement to record that the field at the given path,
+// which must evaluate to a string, is not part of the schema.
+func (u *TypeUnmarshaller) UnknownField(pathExpr *Statement) *Statement {
+	return u.unmarshallerExpr.Clone().Dot("UnknownField").Call(pathExpr)
+}
+
+// CheckMaxSize returns a statement to record an error if the []byte
+// given by valueExpr is larger than max bytes.
+// The field name is only used for error reporting.
+func (u *TypeUnmarshaller) CheckMaxSize(fieldName string, valueExpr *Statement, max int) *Statement {
+	return u.unmarshallerExpr.Clone().Dot("CheckMaxSize").Call(Lit(fieldName), Len(valueExpr), Lit(max))
+}
+
 // builtinToName returns the string name of the builtin.
 //
 // Each kind's name corresponds with the functions in etype.
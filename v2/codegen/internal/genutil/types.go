@@ -143,6 +143,8 @@ func (g *Helper) Builtin(pos gotoken.Pos, kind schema.BuiltinKind) *Statement {
 		return Qual("encore.dev/types/uuid", "UUID")
 	case schema.UserID:
 		return Qual("encore.dev/beta/auth", "UID")
+	case schema.File:
+		return Qual("mime/multipart", "FileHeader")
 	case schema.Error:
 		return Error()
 	default:
@@ -361,6 +363,8 @@ func (g *Helper) builtinZero(builtin schema.BuiltinType) *Statement {
 		return Nil()
 	case schema.UserID:
 		return Qual("encore.dev/beta/auth", "UID").Call(Lit(""))
+	case schema.File:
+		return Parens(Qual("mime/multipart", "FileHeader").Values())
 	case schema.Error:
 		return Parens(Id("error")).Call(nil)
 	default:
@@ -224,13 +224,16 @@ func (r *fileNameResolver) processImports() {
 
 // resolveKnownPkgName returns the known package name for the given import path, if any.
 // It uses the fact that the encore.dev module and the standard library guarantee
-// that the last path segment is the package name.
+// that the last path segment is the package name, except for the encore.dev root package.
 //
 // If the name is not known it reports "".
 func (r *fileNameResolver) resolveKnownPkgName(pkgPath paths.Pkg) (name string) {
 	stdlib := paths.StdlibMod()
 	encoreRuntime := r.l.runtimeModule.Path
-	if stdlib.LexicallyContains(pkgPath) || encoreRuntime.LexicallyContains(pkgPath) {
+	if pkgPath.String() == string(encoreRuntime) {
+		// The root package is the exception, as "encore.dev" is not a valid package name.
+		return "encore"
+	} else if stdlib.LexicallyContains(pkgPath) || encoreRuntime.LexicallyContains(pkgPath) {
 		return path.Base(pkgPath.String())
 	}
 	return ""
@@ -370,6 +370,7 @@ const (
 	uuidImportPath   paths.Pkg = "encore.dev/types/uuid"
 	optionImportPath paths.Pkg = "encore.dev/types/option"
 	authImportPath   paths.Pkg = "encore.dev/beta/auth"
+	encoreImportPath paths.Pkg = "encore.dev"
 )
 
 // parseRecv parses a receiver AST into a Receiver.
@@ -422,6 +423,9 @@ func (r *typeResolver) parseEncoreBuiltin(pkgPath paths.Pkg, name string) (Built
 		return Time, true
 	case pkgPath == "encoding/json" && name == "RawMessage":
 		return JSON, true
+	case pkgPath == "mime/multipart" && name == "FileHeader",
+		pkgPath == encoreImportPath && name == "File":
+		return File, true
 	}
 	return unsupported, false
 }
@@ -106,6 +106,12 @@ func TestParser_ParseType(t *testing.T) {
 			typ:     "json.RawMessage",
 			want:    BuiltinType{Kind: JSON, AST: ast.NewIdent("json.RawMessage")},
 		},
+		{
+			name:    "builtin_file",
+			imports: []string{"mime/multipart"},
+			typ:     "*multipart.FileHeader",
+			want:    PointerType{Elem: BuiltinType{Kind: File, AST: ast.NewIdent("multipart.FileHeader")}},
+		},
 		{
 			name: "builtin_error",
 			typ:  "error",
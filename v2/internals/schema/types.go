@@ -188,6 +188,7 @@ const (
 	UUID
 	JSON
 	UserID
+	File  // *multipart.FileHeader, for file uploads
 	Error // builtin "error" type, for convenience
 
 	// unsupported is a special value used
@@ -8,6 +8,7 @@ import (
 	"go/token"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"runtime"
 	"sync"
@@ -268,5 +269,10 @@ func (l *PackageList) Collector() func(pkg *pkginfo.Package) {
 // ResourceDeepEquals is a quicktest comparator for resource.Resource and resource.Bind
 // that forces the comparison to include unexported fields
 var ResourceDeepEquals = qt.CmpEquals(
-	cmp.AllowUnexported(option.Option[*pkginfo.File]{}),
+	cmp.Exporter(func(t reflect.Type) bool {
+		// Compare the contents of all option.Option types.
+		return t.PkgPath() == optionPkgPath
+	}),
 )
+
+var optionPkgPath = reflect.TypeOf(option.Option[*pkginfo.File]{}).PkgPath()
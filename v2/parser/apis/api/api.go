@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/option"
@@ -45,6 +46,8 @@ type Endpoint struct {
 	HTTPMethodsField option.Option[directive.Field]
 	Request          schema.Type // request data; nil for Raw Endpoints
 	Response         schema.Type // response data; nil for Raw Endpoints
+	StreamRecord     schema.Type // record type if Request is a *stream.Reader[T]; nil otherwise
+	StreamEvent      schema.Type // event type if Response is a *stream.Events[T]; nil otherwise
 	Tags             selector.Set
 	Recv             option.Option[*schema.Receiver] // None if not a method
 
@@ -52,6 +55,70 @@ type Endpoint struct {
 	// meaning all request/response information will be redacted in traces.
 	Sensitive bool
 
+	// StrictDecoding indicates whether requests containing fields
+	// not part of the request schema are rejected.
+	StrictDecoding bool
+
+	// GRPC indicates whether the endpoint is also exposed over gRPC,
+	// using the "grpc" option.
+	GRPC      bool
+	GRPCField option.Option[directive.Field]
+
+	// EnvTypes are the environment types the endpoint is publicly exposed in,
+	// as given by the "env" field. If empty it's exposed in all environments.
+	EnvTypes      []string
+	EnvTypesField option.Option[directive.Field]
+
+	// Version is the version of the endpoint, as given by the "version" field,
+	// or 0 if it's unversioned. Versioned endpoints are served on Path, which is
+	// UnversionedPath prefixed with "/v<version>", and on UnversionedPath with
+	// the version selected by the request's API-Version header.
+	Version         int
+	VersionField    option.Option[directive.Field]
+	UnversionedPath *resourcepaths.Path
+
+	// Deprecated is the deprecation notice of the endpoint, given by
+	// a paragraph of its doc comment beginning with "Deprecated: ".
+	Deprecated option.Option[string]
+
+	// RateLimit is the rate limit of the endpoint, as given by the
+	// "ratelimit" field. It's nil if the endpoint is not rate limited.
+	RateLimit *RateLimit
+
+	// Idempotent indicates whether retries of requests with the same
+	// Idempotency-Key header replay the first response, using "idempotent=true".
+	Idempotent      bool
+	IdempotentField option.Option[directive.Field]
+
+	// ResponseCache describes how the endpoint's responses are cached,
+	// as given by the "cache" and "vary" fields. It's nil if they aren't cached.
+	ResponseCache *ResponseCache
+
+	// DisableCompression indicates whether the endpoint's responses are never
+	// compressed, using "compress=false".
+	DisableCompression bool
+	CompressField      option.Option[directive.Field]
+
+	// Timeout is the maximum duration of a request, after which its context
+	// is canceled, as given by the "timeout" field. It's 0 if unlimited.
+	Timeout      time.Duration
+	TimeoutField option.Option[directive.Field]
+
+	// MaxBodySize is the maximum size of request bodies in bytes,
+	// as given by the "maxBodySize" field. It's 0 if unlimited.
+	MaxBodySize      int64
+	MaxBodySizeField option.Option[directive.Field]
+
+	// CORS is the CORS policy of the endpoint, overriding the global CORS configuration.
+	// It is nil if the endpoint has none of its own.
+	CORS *CORS
+
+	// CacheCluster is the name of the cache cluster storing the endpoint's
+	// rate limiter state, idempotent and cached responses, as given by the "cluster" field.
+	// If None the app's only cache cluster is used.
+	CacheCluster      option.Option[string]
+	CacheClusterField option.Option[directive.Field]
+
 	reqEncOnce  sync.Once
 	reqEncoding []*apienc.RequestEncoding
 
@@ -72,8 +139,15 @@ func (ep *Endpoint) Pos() token.Pos            { return ep.Decl.AST.Pos() }
 func (ep *Endpoint) End() token.Pos            { return ep.Decl.AST.End() }
 func (ep *Endpoint) SortKey() string           { return ep.File.Pkg.ImportPath.String() + "." + ep.Name }
 
+// UsesCacheCluster reports whether the endpoint stores state in a cache cluster,
+// for rate limiting, idempotency or response caching.
+func (ep *Endpoint) UsesCacheCluster() bool {
+	return ep.RateLimit != nil || ep.Idempotent || ep.ResponseCache != nil
+}
+
 func (ep *Endpoint) RequestEncoding() []*apienc.RequestEncoding {
-	if ep.Request == nil {
+	// Record streams are decoded by the runtime, not by the request encoding.
+	if ep.Request == nil || ep.StreamRecord != nil {
 		return nil
 	}
 
@@ -86,6 +160,11 @@ func (ep *Endpoint) RequestEncoding() []*apienc.RequestEncoding {
 
 func (ep *Endpoint) ResponseEncoding() *apienc.ResponseEncoding {
 	ep.respEncOnce.Do(func() {
+		// Event streams are encoded by the runtime, not by the response encoding.
+		if ep.StreamEvent != nil {
+			ep.respEncoding = &apienc.ResponseEncoding{}
+			return
+		}
 		ep.respEncoding = apienc.DescribeResponse(ep.errs, ep.Response)
 	})
 	return ep.respEncoding
@@ -130,6 +209,7 @@ func Parse(d ParseData) *Endpoint {
 
 	rpc.Name = d.Func.Name.Name
 	rpc.Doc = d.Doc
+	rpc.Deprecated = deprecationNotice(d.Doc)
 	rpc.Decl = decl
 	rpc.File = d.File
 	rpc.Recv = decl.Recv
@@ -163,6 +243,30 @@ func Parse(d ParseData) *Endpoint {
 	// ResponseEncoding will validate the response payload.
 	rpc.ResponseEncoding()
 
+	if rpc.Request != nil {
+		validateTags(d.Errs, rpc.Request, make(map[*pkginfo.PkgDeclInfo]bool))
+	}
+
+	if rpc.GRPC {
+		validateGRPC(d.Errs, rpc)
+	}
+
+	if rpc.Idempotent && (rpc.StreamRecord != nil || rpc.StreamEvent != nil) {
+		d.Errs.Add(errStreamingEndpointIdempotent.AtGoNode(rpc.IdempotentField.MustGet(), errors.AsError("idempotent here")))
+	}
+
+	if rc := rpc.ResponseCache; rc != nil {
+		if rpc.StreamRecord != nil || rpc.StreamEvent != nil {
+			d.Errs.Add(errStreamingEndpointCached.AtGoNode(rc.Field, errors.AsError("cached here")))
+		} else if m, ok := nonReadOnlyMethod(rpc.HTTPMethods); ok {
+			err := errCachedEndpointMethod(m).AtGoNode(rc.Field, errors.AsError("cached here"))
+			if methodField, ok := rpc.HTTPMethodsField.Get(); ok {
+				err = err.AtGoNode(methodField, errors.AsError("methods set here"))
+			}
+			d.Errs.Add(err)
+		}
+	}
+
 	return rpc
 }
 
@@ -214,6 +318,7 @@ func initTypedRPC(errs *perr.List, endpoint *Endpoint) {
 				continue
 			}
 			endpoint.Request = param.Type
+			endpoint.StreamRecord = streamRecordType(errs, param)
 		}
 	}
 
@@ -237,6 +342,7 @@ func initTypedRPC(errs *perr.List, endpoint *Endpoint) {
 	if numResults >= 2 {
 		result := sig.Results[0]
 		endpoint.Response = result.Type
+		endpoint.StreamEvent = streamEventType(errs, result)
 	}
 
 	// Make sure the last return is of type error.
@@ -246,6 +352,42 @@ func initTypedRPC(errs *perr.List, endpoint *Endpoint) {
 	}
 }
 
+// streamRecordType returns the record type T if param is a *stream.Reader[T],
+// and nil otherwise.
+func streamRecordType(errs *perr.List, param schema.Param) schema.Type {
+	typ, derefs := schemautil.Deref(param.Type)
+	if !schemautil.IsNamed(typ, "encore.dev/beta/stream", "Reader") {
+		return nil
+	} else if derefs != 1 {
+		errs.Add(errStreamReaderNotPointer.AtGoNode(param.AST))
+		return nil
+	}
+
+	named := typ.(schema.NamedType)
+	if len(named.TypeArgs) != 1 {
+		return nil
+	}
+	return named.TypeArgs[0]
+}
+
+// streamEventType returns the event type T if result is a *stream.Events[T],
+// and nil otherwise.
+func streamEventType(errs *perr.List, result schema.Param) schema.Type {
+	typ, derefs := schemautil.Deref(result.Type)
+	if !schemautil.IsNamed(typ, "encore.dev/beta/stream", "Events") {
+		return nil
+	} else if derefs != 1 {
+		errs.Add(errStreamEventsNotPointer.AtGoNode(result.AST))
+		return nil
+	}
+
+	named := typ.(schema.NamedType)
+	if len(named.TypeArgs) != 1 {
+		return nil
+	}
+	return named.TypeArgs[0]
+}
+
 func initRawRPC(errs *perr.List, endpoint *Endpoint) {
 	decl := endpoint.Decl
 	sig := decl.Type
@@ -306,11 +448,15 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 
 	var accessField directive.Field
 	var rawTag directive.Field
+	var strictTag directive.Field
+	var rateLimitFields []directive.Field
+	var responseCacheFields []directive.Field
+	var corsFields []directive.Field
 
 	accessOptions := []string{"public", "private", "auth"}
 	ok := directive.Validate(errs, dir, directive.ValidateSpec{
-		AllowedOptions: append([]string{"raw", "sensitive"}, accessOptions...),
-		AllowedFields:  []string{"path", "method"},
+		AllowedOptions: append([]string{"raw", "sensitive", "strict", "grpc"}, accessOptions...),
+		AllowedFields:  []string{"path", "method", "env", "ratelimit", "burst", "per", "idempotent", "cache", "vary", "cluster", "version", "compress", "timeout", "maxBodySize", "cors", "corsHeaders", "corsCredentials"},
 
 		ValidateOption: func(errs *perr.List, opt directive.Field) (ok bool) {
 			// If this is an access option, check for duplicates.
@@ -329,6 +475,12 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 				rawTag = opt
 			case "sensitive":
 				endpoint.Sensitive = true
+			case "strict":
+				strictTag = opt
+				endpoint.StrictDecoding = true
+			case "grpc":
+				endpoint.GRPC = true
+				endpoint.GRPCField = option.Some(opt)
 			}
 
 			return true
@@ -350,6 +502,69 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 					return false
 				}
 
+			case "env":
+				endpoint.EnvTypes, ok = ParseEnvTypes(errs, f)
+				if !ok {
+					return false
+				}
+				endpoint.EnvTypesField = option.Some(f)
+
+			case "ratelimit", "burst", "per":
+				rateLimitFields = append(rateLimitFields, f)
+
+			case "cache", "vary":
+				responseCacheFields = append(responseCacheFields, f)
+
+			case "cors", "corsHeaders", "corsCredentials":
+				corsFields = append(corsFields, f)
+
+			case "idempotent":
+				switch f.Value {
+				case "true":
+					endpoint.Idempotent = true
+				case "false":
+				default:
+					errs.Add(errInvalidIdempotent(f.Value).AtGoNode(f))
+					return false
+				}
+				endpoint.IdempotentField = option.Some(f)
+
+			case "compress":
+				switch f.Value {
+				case "true":
+				case "false":
+					endpoint.DisableCompression = true
+				default:
+					errs.Add(errInvalidCompress(fmt.Sprintf("expected compress=true or compress=false, got compress=%s", f.Value)).AtGoNode(f))
+					return false
+				}
+				endpoint.CompressField = option.Some(f)
+
+			case "timeout":
+				endpoint.Timeout, ok = parseTimeout(errs, f)
+				if !ok {
+					return false
+				}
+				endpoint.TimeoutField = option.Some(f)
+
+			case "maxBodySize":
+				endpoint.MaxBodySize, ok = parseByteSize(errs, f)
+				if !ok {
+					return false
+				}
+				endpoint.MaxBodySizeField = option.Some(f)
+
+			case "version":
+				endpoint.Version, ok = parseVersion(errs, f)
+				if !ok {
+					return false
+				}
+				endpoint.VersionField = option.Some(f)
+
+			case "cluster":
+				endpoint.CacheCluster = option.Some(f.Value)
+				endpoint.CacheClusterField = option.Some(f)
+
 			case "method":
 				endpoint.HTTPMethods = f.List()
 				endpoint.HTTPMethodsField = option.Some(f)
@@ -389,6 +604,102 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 		errs.Add(errRawEndpointCantBePrivate.AtGoNode(rawTag, errors.AsError("declared as raw here")).AtGoNode(accessField, errors.AsError("set as private here")))
 		return nil, false
 	}
+	if endpoint.Raw && endpoint.StrictDecoding {
+		errs.Add(errRawEndpointCantBeStrict.AtGoNode(strictTag, errors.AsError("declared as strict here")).AtGoNode(rawTag, errors.AsError("declared as raw here")))
+		return nil, false
+	}
+	if envField, ok := endpoint.EnvTypesField.Get(); ok && endpoint.Access == Private {
+		// Private endpoints are never publicly exposed.
+		errs.Add(errPrivateEndpointWithEnv.AtGoNode(envField, errors.AsError("restricted to environments here")))
+		return nil, false
+	}
+	if versionField, ok := endpoint.VersionField.Get(); ok {
+		if endpoint.Path == nil {
+			// The versions of an endpoint are identified by their path.
+			errs.Add(errVersionWithoutPath.AtGoNode(versionField))
+			return nil, false
+		}
+		endpoint.UnversionedPath = endpoint.Path
+		endpoint.Path = versionedPath(endpoint.Path, endpoint.Version, versionField)
+	}
+	if len(rateLimitFields) > 0 {
+		endpoint.RateLimit, ok = parseRateLimit(errs, rateLimitFields)
+		if !ok {
+			return nil, false
+		}
+		if endpoint.Access == Private {
+			// Only requests from outside the application are rate limited.
+			errs.Add(errPrivateEndpointWithRateLimit.AtGoNode(endpoint.RateLimit.Field, errors.AsError("rate limited here")))
+			return nil, false
+		}
+	}
+	if idemField, ok := endpoint.IdempotentField.Get(); ok && endpoint.Idempotent {
+		if endpoint.Access == Private {
+			// Only requests from outside the application carry idempotency keys.
+			errs.Add(errPrivateEndpointIdempotent.AtGoNode(idemField, errors.AsError("idempotent here")))
+			return nil, false
+		} else if endpoint.Raw {
+			errs.Add(errRawEndpointIdempotent.AtGoNode(idemField, errors.AsError("idempotent here")).AtGoNode(rawTag, errors.AsError("declared as raw here")))
+			return nil, false
+		}
+	}
+	if compressField, ok := endpoint.CompressField.Get(); ok && endpoint.Raw {
+		errs.Add(errInvalidCompress("raw APIs write their own responses, so Encore cannot compress them").AtGoNode(compressField).AtGoNode(rawTag, errors.AsError("declared as raw here")))
+		return nil, false
+	}
+	if len(responseCacheFields) > 0 {
+		endpoint.ResponseCache, ok = parseResponseCache(errs, responseCacheFields)
+		if !ok {
+			return nil, false
+		}
+		if endpoint.Access == Private {
+			// Only requests from outside the application are served from the cache.
+			errs.Add(errPrivateEndpointCached.AtGoNode(endpoint.ResponseCache.Field, errors.AsError("cached here")))
+			return nil, false
+		} else if endpoint.Raw {
+			errs.Add(errRawEndpointCached.AtGoNode(endpoint.ResponseCache.Field, errors.AsError("cached here")).AtGoNode(rawTag, errors.AsError("declared as raw here")))
+			return nil, false
+		}
+		if endpoint.Access == Auth {
+			// Never serve one user's response to another user.
+			endpoint.ResponseCache.VaryAuth = true
+		}
+	}
+	if len(corsFields) > 0 {
+		endpoint.CORS, ok = ParseCORS(errs, corsFields)
+		if !ok {
+			return nil, false
+		}
+		if endpoint.Access == Private {
+			// Private endpoints are never publicly exposed.
+			errs.Add(errPrivateEndpointWithCORS.AtGoNode(endpoint.CORS.Field, errors.AsError("CORS policy defined here")))
+			return nil, false
+		}
+	}
+	if clusterField, ok := endpoint.CacheClusterField.Get(); ok && !endpoint.UsesCacheCluster() {
+		errs.Add(errCacheClusterUnused.AtGoNode(clusterField))
+		return nil, false
+	}
 
 	return endpoint, true
 }
+
+// EnvTypes are the environment types endpoints can be exposed in using the "env" field.
+// "local" matches environments run locally with "encore run" and "encore test".
+var EnvTypes = []string{"production", "development", "ephemeral", "test", "local"}
+
+// ParseEnvTypes parses the environment types in an "env" directive field,
+// such as "env=development,local".
+func ParseEnvTypes(errs *perr.List, f directive.Field) (envTypes []string, ok bool) {
+	for _, env := range f.List() {
+		if !slices.Contains(EnvTypes, env) {
+			errs.Add(errUnknownEnvType(env, strings.Join(EnvTypes, ", ")).AtGoNode(f))
+			return nil, false
+		} else if slices.Contains(envTypes, env) {
+			errs.Add(errDuplicateEnvType(env).AtGoNode(f))
+			return nil, false
+		}
+		envTypes = append(envTypes, env)
+	}
+	return envTypes, true
+}
@@ -5,6 +5,7 @@ import (
 	"go/token"
 	"strconv"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/google/go-cmp/cmp"
@@ -100,6 +101,470 @@ func Foo(ctx context.Context, key int) error {}
 				HTTPMethods: []string{"GET", "POST"},
 			},
 		},
+		{
+			name: "strict",
+			def: `
+//encore:api public strict path=/foo
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo", ValueType: schema.String},
+				}},
+				HTTPMethods:    []string{"GET", "POST"},
+				StrictDecoding: true,
+			},
+		},
+		{
+			name: "grpc",
+			def: `
+type Params struct {
+	Name   string
+	Tags   []string
+	Counts map[string]int
+}
+
+//encore:api public grpc path=/foo/:id
+func Foo(ctx context.Context, id int, p *Params) (*Params, error) {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo", ValueType: schema.String},
+					{Type: resourcepaths.Param, Value: "id", ValueType: schema.Int},
+				}},
+				HTTPMethods: []string{"POST"},
+				Request:     schema.PointerType{Elem: schema.NamedType{}},
+				Response:    schema.PointerType{Elem: schema.NamedType{}},
+				GRPC:        true,
+				GRPCField:   option.Some(directive.Field{Value: "grpc"}),
+			},
+		},
+		{
+			name:    "grpc_raw",
+			imports: []string{"net/http"},
+			def: `
+//encore:api public raw grpc path=/raw
+func Raw(w http.ResponseWriter, req *http.Request) {}
+`,
+			wantErrs: []string{"Raw endpoints cannot be exposed over gRPC*"},
+		},
+		{
+			name: "grpc_private",
+			def: `
+//encore:api private grpc
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{"Private endpoints cannot be exposed over gRPC*"},
+		},
+		{
+			name: "grpc_get",
+			def: `
+type Params struct {
+	Name string
+}
+
+//encore:api public grpc method=GET
+func Foo(ctx context.Context, p *Params) error {}
+`,
+			wantErrs: []string{"Endpoints exposed over gRPC must accept POST requests*"},
+		},
+		{
+			name: "grpc_header",
+			def: `
+type Params struct {
+	Token string ` + "`header:\"X-Token\"`" + `
+}
+
+//encore:api public grpc
+func Foo(ctx context.Context, p *Params) error {}
+`,
+			wantErrs: []string{"The request field Token is sent as a header*"},
+		},
+		{
+			name: "grpc_unsupported_type",
+			def: `
+type Params struct {
+	Weights map[float64]string
+}
+
+//encore:api public grpc
+func Foo(ctx context.Context, p *Params) error {}
+`,
+			wantErrs: []string{"The type map\\[float64\\]string cannot be used by endpoints exposed over gRPC*"},
+		},
+		{
+			name: "validate_unknown_rule",
+			def: `
+type Params struct {
+	Name string ` + "`validate:\"required,maximum=10\"`" + `
+}
+
+//encore:api public
+func Foo(ctx context.Context, p *Params) error {}
+`,
+			wantErrs: []string{`The validate tag on the field Name is invalid: unknown rule "maximum"*`},
+		},
+		{
+			name: "validate_wrong_kind",
+			def: `
+type Address struct {
+	Zip     int ` + "`validate:\"email\"`" + `
+	Country string ` + "`validate:\"len=2\"`" + `
+}
+
+type Params struct {
+	Email     *string ` + "`validate:\"required,email\"`" + `
+	Addresses []Address ` + "`validate:\"max=5\"`" + `
+}
+
+//encore:api public
+func Foo(ctx context.Context, p *Params) error {}
+`,
+			wantErrs: []string{`The validate tag on the field Zip is invalid: rule "email" cannot be used on a integer*`},
+		},
+		{
+			name: "ratelimit",
+			def: `
+//encore:api auth path=/foo ratelimit=100/s burst=20 per=user cluster=limits
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Auth,
+				AccessField: option.Some(directive.Field{Value: "auth"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"GET", "POST"},
+				RateLimit: &RateLimit{
+					Rate:    100,
+					Period:  time.Second,
+					Burst:   20,
+					PerUser: true,
+					Field:   directive.Field{Key: "ratelimit", Value: "100/s"},
+				},
+				CacheCluster:      option.Some("limits"),
+				CacheClusterField: option.Some(directive.Field{Key: "cluster", Value: "limits"}),
+			},
+		},
+		{
+			name: "ratelimit_invalid",
+			def: `
+//encore:api public ratelimit=100/day
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Invalid rate limit "100/day"*`},
+		},
+		{
+			name: "ratelimit_burst_without_rate",
+			def: `
+//encore:api public burst=10
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`The "burst" field can only be used together with the ratelimit field*`},
+		},
+		{
+			name: "ratelimit_private",
+			def: `
+//encore:api private ratelimit=5/m
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Private APIs cannot be rate limited*`},
+		},
+		{
+			name: "idempotent",
+			def: `
+//encore:api public method=POST idempotent=true
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods:      []string{"POST"},
+				HTTPMethodsField: option.Some(directive.Field{Key: "method", Value: "POST"}),
+				Idempotent:       true,
+				IdempotentField:  option.Some(directive.Field{Key: "idempotent", Value: "true"}),
+			},
+		},
+		{
+			name: "idempotent_private",
+			def: `
+//encore:api private idempotent=true
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Private APIs cannot be idempotent*`},
+		},
+		{
+			name: "compress_false",
+			def: `
+//encore:api public compress=false
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods:        []string{"GET", "POST"},
+				DisableCompression: true,
+				CompressField:      option.Some(directive.Field{Key: "compress", Value: "false"}),
+			},
+		},
+		{
+			name: "compress_invalid",
+			def: `
+//encore:api public compress=no
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Invalid compress field: expected compress=true or compress=false, got compress=no*`},
+		},
+		{
+			name: "compress_raw",
+			def: `
+//encore:api public raw compress=false
+func Foo(w http.ResponseWriter, req *http.Request) {}
+`,
+			wantErrs: []string{`Invalid compress field: raw APIs write their own responses*`},
+		},
+		{
+			name: "request_limits",
+			def: `
+//encore:api public timeout=5s maxBodySize=1MB
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods:      []string{"GET", "POST"},
+				Timeout:          5 * time.Second,
+				TimeoutField:     option.Some(directive.Field{Key: "timeout", Value: "5s"}),
+				MaxBodySize:      1 << 20,
+				MaxBodySizeField: option.Some(directive.Field{Key: "maxBodySize", Value: "1MB"}),
+			},
+		},
+		{
+			name: "max_body_size_bytes",
+			def: `
+//encore:api public maxBodySize=4096
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods:      []string{"GET", "POST"},
+				MaxBodySize:      4096,
+				MaxBodySizeField: option.Some(directive.Field{Key: "maxBodySize", Value: "4096"}),
+			},
+		},
+		{
+			name: "cors",
+			def: `
+//encore:api public cors=https://app.example.com,https://*.example.com corsHeaders=X-Custom corsCredentials=true
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"GET", "POST"},
+				CORS: &CORS{
+					AllowOrigins:     []string{"https://app.example.com", "https://*.example.com"},
+					AllowHeaders:     []string{"X-Custom"},
+					AllowCredentials: true,
+					Field:            directive.Field{Key: "cors", Value: "https://app.example.com,https://*.example.com"},
+				},
+			},
+		},
+		{
+			name: "cors_invalid_origin",
+			def: `
+//encore:api public cors=app.example.com
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Invalid origin "app.example.com" in cors field*`},
+		},
+		{
+			name: "cors_credentials_without_origins",
+			def: `
+//encore:api public corsCredentials=true
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`The corsCredentials field can only be used together with the cors field*`},
+		},
+		{
+			name: "cors_credentials_with_wildcard",
+			def: `
+//encore:api public cors=* corsCredentials=true
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Requests with credentials cannot be allowed from all origins*`},
+		},
+		{
+			name: "cors_private",
+			def: `
+//encore:api private cors=https://app.example.com
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Private APIs cannot have a CORS policy*`},
+		},
+		{
+			name: "timeout_invalid",
+			def: `
+//encore:api public timeout=-5s
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Invalid timeout field "-5s": expected a positive duration*`},
+		},
+		{
+			name: "max_body_size_invalid",
+			def: `
+//encore:api public maxBodySize=1TB
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Invalid maxBodySize field "1TB": expected a positive size in bytes*`},
+		},
+		{
+			name: "cluster_unused",
+			def: `
+//encore:api public cluster=limits
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`The cluster field can only be used together with the ratelimit, idempotent or cache fields*`},
+		},
+		{
+			name: "response_cache",
+			def: `
+//encore:api auth method=GET cache=5m vary=accept-language
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Auth,
+				AccessField: option.Some(directive.Field{Value: "auth"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods:      []string{"GET"},
+				HTTPMethodsField: option.Some(directive.Field{Key: "method", Value: "GET"}),
+				ResponseCache: &ResponseCache{
+					TTL:         5 * time.Minute,
+					VaryHeaders: []string{"Accept-Language"},
+					VaryAuth:    true,
+					Field:       directive.Field{Key: "cache", Value: "5m"},
+				},
+			},
+		},
+		{
+			name: "response_cache_invalid_ttl",
+			def: `
+//encore:api public method=GET cache=1500ms
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Invalid value cache=1500ms*`},
+		},
+		{
+			name: "response_cache_vary_without_ttl",
+			def: `
+//encore:api public method=GET vary=auth
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`The "vary" field can only be used together with the cache field*`},
+		},
+		{
+			name: "response_cache_private",
+			def: `
+//encore:api private method=GET cache=1m
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Private APIs cannot cache their responses*`},
+		},
+		{
+			name: "response_cache_default_methods",
+			def: `
+//encore:api public cache=1m
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`APIs caching their responses must be read-only, but the API accepts POST requests*`},
+		},
+		{
+			name: "version",
+			def: `
+// Foo does things.
+//
+// Deprecated: Use FooV3 instead.
+//
+//encore:api public method=GET path=/foo/:id version=2
+func Foo(ctx context.Context, id int) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "Foo does things.\n\nDeprecated: Use FooV3 instead.\n",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "v2", ValueType: schema.String},
+					{Type: resourcepaths.Literal, Value: "foo", ValueType: schema.String},
+					{Type: resourcepaths.Param, Value: "id", ValueType: schema.Int},
+				}},
+				UnversionedPath: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo", ValueType: schema.String},
+					{Type: resourcepaths.Param, Value: "id", ValueType: schema.Int},
+				}},
+				HTTPMethods:      []string{"GET"},
+				HTTPMethodsField: option.Some(directive.Field{Key: "method", Value: "GET"}),
+				Version:          2,
+				VersionField:     option.Some(directive.Field{Key: "version", Value: "2"}),
+				Deprecated:       option.Some("Use FooV3 instead."),
+			},
+		},
+		{
+			name: "version_invalid",
+			def: `
+//encore:api public path=/foo version=v2
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Invalid value version=v2*`},
+		},
+		{
+			name: "version_without_path",
+			def: `
+//encore:api public version=2
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`Versioned APIs must specify their path*`},
+		},
 		{
 			name:    "raw",
 			imports: []string{"net/http"},
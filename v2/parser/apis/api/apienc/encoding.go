@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/idents"
 	"encr.dev/pkg/option"
 	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/internals/schema"
 	"encr.dev/v2/internals/schema/schemautil"
 	"encr.dev/v2/parser/apis/authhandler"
@@ -60,12 +62,14 @@ var requestTags = map[string]tagDescription{
 	"query":  QueryTag,
 	"qs":     QsTag,
 	"header": HeaderTag,
+	"cookie": CookieTag,
 	"json":   JSONTag,
 }
 
 // responseTags is a description of tags used for responses
 var responseTags = map[string]tagDescription{
 	"header": HeaderTag,
+	"cookie": CookieTag,
 	"json":   JSONTag,
 }
 
@@ -129,13 +133,14 @@ func (e *APIEncoding) RequestEncodingForMethod(method string) *RequestEncoding {
 type ResponseEncoding struct {
 	// Contains metadata about how to marshal an HTTP parameter
 	HeaderParameters []*ParameterEncoding `json:"header_parameters"`
+	CookieParameters []*ParameterEncoding `json:"cookie_parameters"`
 	BodyParameters   []*ParameterEncoding `json:"body_parameters"`
 	// HTTPStatusParameter contains encoding info for the HTTP status field, if any
 	HTTPStatusParameter *ParameterEncoding `json:"http_status_parameter,omitempty"`
 }
 
 func (r *ResponseEncoding) AllParameters() []*ParameterEncoding {
-	params := append(r.HeaderParameters, r.BodyParameters...)
+	params := append(append(r.HeaderParameters, r.CookieParameters...), r.BodyParameters...)
 	if r.HTTPStatusParameter != nil {
 		params = append(params, r.HTTPStatusParameter)
 	}
@@ -149,11 +154,20 @@ type RequestEncoding struct {
 	// Contains metadata about how to marshal an HTTP parameter
 	HeaderParameters []*ParameterEncoding `json:"header_parameters"`
 	QueryParameters  []*ParameterEncoding `json:"query_parameters"`
+	CookieParameters []*ParameterEncoding `json:"cookie_parameters"`
 	BodyParameters   []*ParameterEncoding `json:"body_parameters"`
 }
 
 func (r *RequestEncoding) AllParameters() []*ParameterEncoding {
-	return append(append(r.HeaderParameters, r.QueryParameters...), r.BodyParameters...)
+	return append(append(append(r.HeaderParameters, r.QueryParameters...), r.CookieParameters...), r.BodyParameters...)
+}
+
+// Multipart reports whether the request body contains files,
+// in which case it's encoded as multipart/form-data instead of JSON.
+func (r *RequestEncoding) Multipart() bool {
+	return slices.ContainsFunc(r.BodyParameters, func(p *ParameterEncoding) bool {
+		return IsFileType(p.Type)
+	})
 }
 
 // ParameterEncoding expresses how a parameter should be encoded on the wire
@@ -170,6 +184,13 @@ type ParameterEncoding struct {
 	Doc string `json:"doc"`
 	// Type is the field's type description.
 	Type schema.Type `json:"type"`
+	// StringEncoded specifies whether the parameter is encoded as a JSON string,
+	// as set with the encore:"string" tag. It's only valid for 64-bit integers.
+	StringEncoded bool `json:"string_encoded"`
+	// MaxSize is the maximum decoded size of the parameter in bytes,
+	// as set with the encore:"maxsize=N" tag. It's only valid for []byte
+	// and zero means there is no limit.
+	MaxSize int `json:"max_size"`
 }
 
 // DescribeResponse generates a ParameterEncoding per field of the response struct and returns it as
@@ -202,14 +223,34 @@ func DescribeResponse(errs *perr.List, responseSchema schema.Type) *ResponseEnco
 		}
 	}
 
-	// Check for reserved header prefixes
+	// Check for reserved header prefixes and invalid data types
 	for _, field := range fields[Header] {
 		if strings.HasPrefix(strings.ToLower(field.WireName), "x-encore-") {
 			errs.Add(errReservedHeaderPrefix.AtGoNode(field.Type.ASTExpr()))
 		}
+
+		if !schemautil.IsValidHeaderType(field.Type) && !ContainsFile(field.Type) {
+			errs.Add(
+				errInvalidResponseHeaderType(field.Type.String()).
+					AtGoNode(field.Type.ASTExpr(), errors.AsError("unsupported type")),
+			)
+		}
 	}
 
-	if keys := keyDiff(fields, Header, Body, HTTPStatus); len(keys) > 0 {
+	for _, params := range fields {
+		for _, p := range params {
+			if ContainsFile(p.Type) {
+				errs.Add(ErrInvalidFileUpload("Responses cannot contain files.").
+					AtGoNode(p.Type.ASTExpr(), errors.AsError("contains a file")))
+			}
+		}
+	}
+
+	checkCookieParams(errs, fields[Cookie])
+	checkDuplicateWireNames(errs, fields[Header], strings.EqualFold)
+	checkDuplicateWireNames(errs, fields[Cookie], func(a, b string) bool { return a == b })
+
+	if keys := keyDiff(fields, Header, Cookie, Body, HTTPStatus); len(keys) > 0 {
 		err := errResponseTypeMustOnlyBeBodyOrHeaders.AtGoNode(responseSchema.ASTExpr())
 
 		for _, k := range keys {
@@ -224,6 +265,7 @@ func DescribeResponse(errs *perr.List, responseSchema schema.Type) *ResponseEnco
 	return &ResponseEncoding{
 		BodyParameters:      fields[Body],
 		HeaderParameters:    fields[Header],
+		CookieParameters:    fields[Cookie],
 		HTTPStatusParameter: httpStatusParameter,
 	}
 }
@@ -296,7 +338,7 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 				errs.Add(errReservedHeaderPrefix.AtGoNode(field.Type.ASTExpr()))
 			}
 
-			if !schemautil.IsValidHeaderType(field.Type) {
+			if !schemautil.IsValidHeaderType(field.Type) && !ContainsFile(field.Type) {
 				errs.Add(
 					errInvalidHeaderType(field.Type.String()).
 						AtGoNode(field.Type.ASTExpr(), errors.AsError("unsupported type")).
@@ -307,7 +349,7 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 
 		// Check for invalid datatype in query parameters
 		for _, field := range fields[Query] {
-			if !schemautil.IsValidQueryType(field.Type) {
+			if !schemautil.IsValidQueryType(field.Type) && !ContainsFile(field.Type) {
 				err := errInvalidQueryStringType(field.Type.String()).
 					AtGoNode(field.Type.ASTExpr(), errors.AsError("unsupported type")).
 					AtGoNode(requestAST.AST, errors.AsHelp("used here"))
@@ -324,11 +366,16 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 			}
 		}
 
+		checkFileParams(errs, fields, requestAST, methodsField)
+		checkCookieParams(errs, fields[Cookie])
+		checkDuplicateWireNames(errs, fields[Header], strings.EqualFold)
+		checkDuplicateWireNames(errs, fields[Cookie], func(a, b string) bool { return a == b })
+
 		if errs.Len() > 0 {
 			return nil
 		}
 
-		if keys := keyDiff(fields, Query, Header, Body); len(keys) > 0 {
+		if keys := keyDiff(fields, Query, Header, Cookie, Body); len(keys) > 0 {
 			err := errRequestInvalidLocation.AtGoNode(requestSchema.ASTExpr())
 
 			for _, k := range keys {
@@ -343,6 +390,7 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 			HTTPMethods:      methods,
 			QueryParameters:  fields[Query],
 			HeaderParameters: fields[Header],
+			CookieParameters: fields[Cookie],
 			BodyParameters:   fields[Body],
 		})
 	}
@@ -474,6 +522,11 @@ func describeParam(errs *perr.List, encodingHints *encodingHints, field schema.S
 		Doc:       field.Doc,
 		Type:      field.Type,
 		WireName:  defaultWireName,
+
+		StringEncoded: IsStringEncoded(field),
+	}
+	if n, ok := MaxSize(field); ok && n > 0 {
+		param.MaxSize = n
 	}
 
 	// Determine which location we should use for this field.
@@ -538,6 +591,160 @@ func describeParam(errs *perr.List, encodingHints *encodingHints, field schema.S
 	return &param, true
 }
 
+// IsStringEncoded reports whether the field is tagged with encore:"string",
+// meaning it's encoded as a JSON string on the wire.
+func IsStringEncoded(field schema.StructField) bool {
+	tag, err := field.Tag.Get("encore")
+	if err != nil {
+		return false
+	}
+	return tag.Name == "string" || tag.HasOption("string")
+}
+
+// IsValidStringEncodedType reports whether the given type can be tagged with encore:"string".
+func IsValidStringEncodedType(typ schema.Type) bool {
+	return schemautil.IsBuiltinKind(typ, schema.Int, schema.Int64, schema.Uint, schema.Uint64)
+}
+
+// MaxSize returns the maximum decoded size in bytes of the field,
+// as set with the encore:"maxsize=N" tag, and whether the tag is set.
+// If the size is not a valid integer it returns 0, true.
+func MaxSize(field schema.StructField) (n int, ok bool) {
+	tag, err := field.Tag.Get("encore")
+	if err != nil {
+		return 0, false
+	}
+	for _, o := range append([]string{tag.Name}, tag.Options...) {
+		if val, found := strings.CutPrefix(o, "maxsize="); found {
+			n, _ := strconv.Atoi(val)
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// IsValidMaxSizeType reports whether the given type can be tagged with encore:"maxsize=N".
+func IsValidMaxSizeType(typ schema.Type) bool {
+	return schemautil.IsBuiltinKind(typ, schema.Bytes)
+}
+
+// checkFileParams reports an error for each request parameter containing files
+// that cannot be sent in a multipart/form-data body, and for each body parameter
+// that cannot be sent alongside files.
+func checkFileParams(errs *perr.List, fields map[WireLoc][]*ParameterEncoding, requestAST schema.Param, methodsField option.Option[directive.Field]) {
+	hasFiles := false
+	for loc, params := range fields {
+		for _, p := range params {
+			switch {
+			case !ContainsFile(p.Type):
+				continue
+			case loc == Query:
+				err := ErrInvalidFileUpload("Files cannot be sent in query strings.").
+					AtGoNode(p.Type.ASTExpr(), errors.AsError("file parameter")).
+					AtGoNode(requestAST.AST, errors.AsHelp("used here"))
+				if field, ok := methodsField.Get(); ok {
+					err = err.AtGoNode(field, errors.AsHelp("you could change this to a POST or PUT request"))
+				}
+				errs.Add(err)
+			case loc != Body:
+				errs.Add(ErrInvalidFileUpload(fmt.Sprintf("Files cannot be sent as %s parameters.", loc)).
+					AtGoNode(p.Type.ASTExpr(), errors.AsError("file parameter")))
+			case !IsFileType(p.Type):
+				errs.Add(ErrInvalidFileUpload("Files cannot be nested within other types.").
+					AtGoNode(p.Type.ASTExpr(), errors.AsError("contains a file")))
+			default:
+				hasFiles = true
+			}
+		}
+	}
+	if !hasFiles {
+		return
+	}
+
+	for _, p := range fields[Body] {
+		if !ContainsFile(p.Type) && !schemautil.IsValidQueryType(p.Type) {
+			errs.Add(
+				ErrInvalidFileUpload(fmt.Sprintf("Body parameters of type %s cannot be sent alongside files, as they're sent as form values. "+
+					"You can only use built-in types, or slices of built-in types.", p.Type)).
+					AtGoNode(p.Type.ASTExpr(), errors.AsError("unsupported type")),
+			)
+		}
+	}
+}
+
+// IsFileType reports whether typ is *multipart.FileHeader or []*multipart.FileHeader,
+// the types of request fields containing uploaded files.
+func IsFileType(typ schema.Type) bool {
+	if list, ok := typ.(schema.ListType); ok && list.Len == -1 {
+		typ = list.Elem
+	}
+	ptr, ok := typ.(schema.PointerType)
+	return ok && schemautil.IsBuiltinKind(ptr.Elem, schema.File)
+}
+
+// ContainsFile reports whether typ is or contains a *multipart.FileHeader.
+func ContainsFile(typ schema.Type) (found bool) {
+	schemautil.Walk(typ, func(t schema.Type) bool {
+		found = schemautil.IsBuiltinKind(t, schema.File)
+		return !found
+	})
+	return found
+}
+
+// checkCookieParams reports an error for each cookie parameter
+// whose type cannot be represented as a cookie.
+func checkCookieParams(errs *perr.List, params []*ParameterEncoding) {
+	for _, p := range params {
+		// Files are reported separately.
+		if !IsValidCookieType(p.Type) && !ContainsFile(p.Type) {
+			errs.Add(
+				errInvalidCookieType(p.Type.String()).
+					AtGoNode(p.Type.ASTExpr(), errors.AsError("unsupported type")),
+			)
+		}
+	}
+}
+
+// IsValidCookieType reports whether the given type can be used for a cookie parameter.
+// Cookies are either builtin types (encoded as the cookie value) or *http.Cookie,
+// which additionally allows setting Set-Cookie attributes such as Path and Expires.
+func IsValidCookieType(typ schema.Type) bool {
+	if _, ok := typ.(schema.BuiltinType); ok {
+		return true
+	}
+	return IsHTTPCookie(typ)
+}
+
+// IsHTTPCookie reports whether typ is *http.Cookie.
+func IsHTTPCookie(typ schema.Type) bool {
+	ptr, ok := typ.(schema.PointerType)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem.(schema.NamedType)
+	return ok && named.DeclInfo.QualifiedName() == pkginfo.Q("net/http", "Cookie")
+}
+
+// checkDuplicateWireNames reports an error if two parameters in the same location
+// resolve to the same wire name, as determined by equal.
+//
+// HTTP header names are case-insensitive, so "X-Foo" and "x-foo" would otherwise
+// silently overwrite each other.
+func checkDuplicateWireNames(errs *perr.List, params []*ParameterEncoding, equal func(a, b string) bool) {
+	for i, a := range params {
+		for _, b := range params[:i] {
+			if equal(a.WireName, b.WireName) {
+				errs.Add(
+					errDuplicateWireName(a.Location, a.WireName).
+						AtGoNode(b.Type.ASTExpr(), errors.AsHelp("first defined here")).
+						AtGoNode(a.Type.ASTExpr(), errors.AsError("defined again here")),
+				)
+				break
+			}
+		}
+	}
+}
+
 // isValidHTTPStatusType returns true if the given type is valid for HTTP status fields.
 // Valid types are integer types that can hold a http status code
 func isValidHTTPStatusType(typ schema.Type) bool {
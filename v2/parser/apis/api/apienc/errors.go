@@ -61,6 +61,16 @@ var (
 		"Anonymous fields are not supported in API schemas.",
 	)
 
+	ErrInvalidStringEncodedType = errRange.New(
+		"Invalid API schema",
+		"Fields tagged with encore:\"string\" must be of type int, int64, uint or uint64.",
+	)
+
+	ErrInvalidMaxSize = errRange.New(
+		"Invalid API schema",
+		"Fields tagged with encore:\"maxsize=N\" must be of type []byte, and N must be a positive integer.",
+	)
+
 	errInvalidHeaderType = errRange.Newf(
 		"Invalid request type",
 		"API request parameters of type %s are not supported in headers. You can only "+
@@ -88,4 +98,34 @@ var (
 		"Invalid response type",
 		"Fields tagged with encore:\"httpstatus\" must be of an integer type.",
 	)
+
+	errInvalidResponseHeaderType = errRange.Newf(
+		"Invalid response type",
+		"API response parameters of type %s are not supported in headers. You can only "+
+			"use built-in types, or slices of built-in types such as strings, booleans, int, time.Time.",
+
+		errors.WithDetails("See https://encore.dev/docs/develop/api-schemas#supported-types for more information."),
+	)
+
+	errInvalidCookieType = errRange.Newf(
+		"Invalid API schema",
+		"Parameters of type %s are not supported in cookies. You can only "+
+			"use built-in types such as strings, booleans, int, time.Time, or *http.Cookie.",
+	)
+
+	errDuplicateWireName = errRange.Newf(
+		"Invalid API schema",
+		"Multiple %s parameters use the name %q. Parameter names must be unique "+
+			"(header names are compared case-insensitively).",
+	)
+
+	ErrInvalidFileUpload = errRange.Newf(
+		"Invalid file upload",
+		"%s",
+
+		errors.WithDetails("Files can only be sent as top-level request body fields of type *multipart.FileHeader "+
+			"or []*multipart.FileHeader, by endpoints using the POST, PUT or PATCH methods. Such requests are sent as "+
+			"multipart/form-data, with the other body fields as form values. "+
+			"See https://encore.dev/docs/go/primitives/defining-apis#file-uploads for more information."),
+	)
 )
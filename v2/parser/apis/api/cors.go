@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/parser/apis/directive"
+)
+
+// CORS describes the CORS policy of an endpoint or service, such as
+// "cors=https://app.example.com corsCredentials=true" in its directive.
+// It overrides the app's global CORS configuration for requests to the endpoints.
+type CORS struct {
+	// AllowOrigins are the origins allowed to make requests, as given by the "cors" field.
+	// If empty, the origins allowed by the global CORS configuration are allowed.
+	AllowOrigins []string
+
+	// AllowHeaders are the request headers allowed in addition to those allowed
+	// by the global CORS configuration, as given by the "corsHeaders" field.
+	AllowHeaders []string
+
+	// AllowCredentials reports whether AllowOrigins are allowed to make requests
+	// with credentials ("corsCredentials=true").
+	AllowCredentials bool
+
+	// Field is the first CORS directive field.
+	Field directive.Field
+}
+
+// corsFieldKeys are the keys of the directive fields of a CORS policy.
+var corsFieldKeys = []string{"cors", "corsHeaders", "corsCredentials"}
+
+// IsCORSField reports whether f is one of the directive fields of a CORS policy.
+func IsCORSField(f directive.Field) bool {
+	return slices.Contains(corsFieldKeys, f.Key)
+}
+
+// ParseCORS parses the CORS policy fields of an encore:api or encore:service directive.
+func ParseCORS(errs *perr.List, fields []directive.Field) (c *CORS, ok bool) {
+	c = &CORS{Field: fields[0]}
+	var credsField *directive.Field
+	for _, f := range fields {
+		switch f.Key {
+		case "cors":
+			for _, origin := range f.List() {
+				if !isValidCORSOrigin(origin) {
+					errs.Add(errInvalidCORSOrigin(origin).AtGoNode(f))
+					return nil, false
+				}
+				c.AllowOrigins = append(c.AllowOrigins, origin)
+			}
+
+		case "corsHeaders":
+			for _, header := range f.List() {
+				if header == "" || strings.ContainsAny(header, " \t:") {
+					errs.Add(errInvalidCORSHeader(header).AtGoNode(f))
+					return nil, false
+				}
+				c.AllowHeaders = append(c.AllowHeaders, header)
+			}
+
+		case "corsCredentials":
+			switch f.Value {
+			case "true":
+				c.AllowCredentials = true
+			case "false":
+			default:
+				errs.Add(errInvalidCORSCredentials(f.Value).AtGoNode(f))
+				return nil, false
+			}
+			credsField = &f
+		}
+	}
+
+	if credsField != nil {
+		if len(c.AllowOrigins) == 0 {
+			// Credentials are allowed for the origins of the global configuration.
+			errs.Add(errCORSCredentialsWithoutOrigins.AtGoNode(*credsField))
+			return nil, false
+		} else if c.AllowCredentials && slices.Contains(c.AllowOrigins, "*") {
+			// Browsers reject credentialed responses allowing all origins.
+			errs.Add(errCORSCredentialsWithWildcard.AtGoNode(*credsField))
+			return nil, false
+		}
+	}
+	return c, true
+}
+
+// isValidCORSOrigin reports whether origin is "*", or an origin such as "https://app.example.com"
+// where the host may contain wildcards, such as "https://*.example.com".
+func isValidCORSOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	return u.Host != "" && u.User == nil && u.Path == "" && u.RawQuery == "" && u.Fragment == ""
+}
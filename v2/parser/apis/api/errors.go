@@ -10,6 +10,22 @@ For more information on how to use raw APIs see https://encore.dev/docs/primitiv
 
 const baseHint = "For more information on how to use APIs see https://encore.dev/docs/primitives/apis"
 
+const rateLimitHelp = "For more information on rate limiting APIs see https://encore.dev/docs/go/primitives/defining-apis#rate-limiting"
+
+const cacheClusterHelp = "For more information on cache clusters see https://encore.dev/docs/go/primitives/caching"
+
+const idempotencyHelp = "For more information on idempotent APIs see https://encore.dev/docs/go/primitives/defining-apis#idempotency"
+
+const versionHelp = "For more information on versioning APIs see https://encore.dev/docs/go/primitives/defining-apis#api-versioning"
+
+const compressionHelp = "For more information on response compression see https://encore.dev/docs/go/primitives/defining-apis#response-compression"
+
+const responseCacheHelp = "For more information on caching API responses see https://encore.dev/docs/go/primitives/defining-apis#response-caching"
+
+const requestLimitsHelp = "For more information on request limits see https://encore.dev/docs/go/primitives/defining-apis#request-limits"
+
+const corsHelp = "For more information on CORS policies of APIs see https://encore.dev/docs/go/develop/cors#per-service-and-per-api-policies"
+
 var (
 	errRange = errors.Range(
 		"api",
@@ -21,7 +37,7 @@ var (
 
 For more information on how to use APIs, see https://encore.dev/docs/primitives/apis`,
 
-		errors.WithRangeSize(50),
+		errors.WithRangeSize(80),
 	)
 
 	errDuplicateAccessOptions = errRange.Newf(
@@ -44,6 +60,11 @@ For more information on how to use APIs, see https://encore.dev/docs/primitives/
 		"Private APIs cannot be declared as raw endpoints.",
 	)
 
+	errRawEndpointCantBeStrict = errRange.New(
+		"Invalid API Directive",
+		"Raw endpoints cannot use strict decoding, as Encore does not decode their requests.",
+	)
+
 	errWrongNumberParams = errRange.Newf(
 		"Invalid API Function",
 		"API functions must have at least 1 parameter, found %d parameters.",
@@ -64,6 +85,16 @@ For more information on how to use APIs, see https://encore.dev/docs/primitives/
 		"API functions can only have one payload parameter.",
 	)
 
+	errStreamReaderNotPointer = errRange.New(
+		"Invalid API Function",
+		"Record stream payloads must be declared as *stream.Reader[T].",
+	)
+
+	errStreamEventsNotPointer = errRange.New(
+		"Invalid API Function",
+		"Event stream responses must be declared as *stream.Events[T].",
+	)
+
 	errInvalidPathParams = errRange.Newf(
 		"Invalid API Function",
 		"Expected function parameters named '%s' to match Endpoint path params.",
@@ -134,4 +165,280 @@ For more information on how to use APIs, see https://encore.dev/docs/primitives/
 		"Invalid API call",
 		"Raw APIs cannot be called from within an Encore application.",
 	)
+
+	errUnknownEnvType = errRange.Newf(
+		"Invalid API Directive",
+		"Unknown environment type %q. Environment types must be one of %s.",
+	)
+
+	errDuplicateEnvType = errRange.Newf(
+		"Invalid API Directive",
+		"The environment type %q is listed more than once.",
+	)
+
+	errPrivateEndpointWithEnv = errRange.New(
+		"Invalid API Directive",
+		"Private APIs cannot be restricted to environment types, as they are never publicly exposed.",
+	)
+
+	ErrEndpointEnvNotInService = errRange.Newf(
+		"Invalid API Directive",
+		"The API is exposed in environment type %q, but its service is not.",
+	)
+
+	ErrStreamEndpointsCannotBeCalled = errRange.New(
+		"Invalid API call",
+		"APIs receiving a record stream or streaming events cannot be called from within an Encore application.",
+	)
+
+	errGRPCRawEndpoint = errRange.New(
+		"Invalid gRPC API",
+		"Raw endpoints cannot be exposed over gRPC, as Encore does not know their request and response types.",
+	)
+
+	errGRPCPrivateEndpoint = errRange.New(
+		"Invalid gRPC API",
+		"Private endpoints cannot be exposed over gRPC, as gRPC calls are served like API calls from outside the application. Use public or auth access instead.",
+	)
+
+	errGRPCStreamingEndpoint = errRange.New(
+		"Invalid gRPC API",
+		"Endpoints receiving a record stream or streaming events cannot be exposed over gRPC.",
+	)
+
+	errGRPCRequiresPOST = errRange.New(
+		"Invalid gRPC API",
+		"Endpoints exposed over gRPC must accept POST requests, as gRPC calls are served as POST requests to the endpoint.",
+	)
+
+	errGRPCWireField = errRange.Newf(
+		"Invalid gRPC API",
+		"The %s field %s is sent as %s, which is not supported by endpoints exposed over gRPC. Only path parameters and fields sent in the body can be used.",
+	)
+
+	errGRPCStringEncodedField = errRange.Newf(
+		"Invalid gRPC API",
+		"The field %s is encoded as a string using encore:\"string\", which is not supported by endpoints exposed over gRPC.",
+	)
+
+	errGRPCUnsupportedType = errRange.Newf(
+		"Invalid gRPC API",
+		"The type %s cannot be used by endpoints exposed over gRPC: %s.",
+	)
+
+	errInvalidValidateTag = errRange.Newf(
+		"Invalid validation rules",
+		"The validate tag on the field %s is invalid: %v.",
+		errors.WithDetails("See https://encore.dev/docs/go/develop/validation for the supported rules."),
+	)
+
+	errInvalidRateLimit = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid rate limit %q, expected a positive number of requests per second, minute or hour, like ratelimit=100/s.",
+		errors.WithDetails(rateLimitHelp),
+	)
+
+	errInvalidRateLimitBurst = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid rate limit burst %q, expected a positive number of requests.",
+		errors.WithDetails(rateLimitHelp),
+	)
+
+	errInvalidRateLimitPer = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid rate limit scope per=%s, the only supported scope is per=user.",
+		errors.WithDetails(rateLimitHelp),
+	)
+
+	errRateLimitFieldWithoutRate = errRange.Newf(
+		"Invalid API Directive",
+		"The %q field can only be used together with the ratelimit field.",
+		errors.WithDetails(rateLimitHelp),
+	)
+
+	errPrivateEndpointWithRateLimit = errRange.New(
+		"Invalid API Directive",
+		"Private APIs cannot be rate limited, as only requests from outside the application are rate limited.",
+		errors.WithDetails(rateLimitHelp),
+	)
+
+	ErrRateLimitNoCacheCluster = errRange.New(
+		"Invalid API Directive",
+		"Rate limited APIs store their rate limiter state in a cache cluster, but the application does not define one.",
+		errors.WithDetails(rateLimitHelp),
+	)
+
+	ErrRateLimitAmbiguousCacheCluster = errRange.New(
+		"Invalid API Directive",
+		"The application defines multiple cache clusters, so the cache cluster to store the rate limiter state in must be specified using cluster=<name>.",
+		errors.WithDetails(rateLimitHelp),
+	)
+
+	ErrRateLimitUnknownCacheCluster = errRange.Newf(
+		"Invalid API Directive",
+		"The cache cluster %q does not exist.",
+		errors.WithDetails(rateLimitHelp),
+	)
+
+	ErrEndpointNoCacheCluster = errRange.Newf(
+		"Invalid API Directive",
+		"%s APIs store their state in a cache cluster, but the application does not define one.",
+		errors.WithDetails(cacheClusterHelp),
+	)
+
+	ErrEndpointAmbiguousCacheCluster = errRange.New(
+		"Invalid API Directive",
+		"The application defines multiple cache clusters, so the cache cluster to store the API's state in must be specified using cluster=<name>.",
+		errors.WithDetails(cacheClusterHelp),
+	)
+
+	ErrEndpointUnknownCacheCluster = errRange.Newf(
+		"Invalid API Directive",
+		"The cache cluster %q does not exist.",
+		errors.WithDetails(cacheClusterHelp),
+	)
+
+	errCacheClusterUnused = errRange.New(
+		"Invalid API Directive",
+		"The cluster field can only be used together with the ratelimit, idempotent or cache fields.",
+		errors.WithDetails(cacheClusterHelp),
+	)
+
+	errInvalidIdempotent = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid value idempotent=%s, expected idempotent=true or idempotent=false.",
+		errors.WithDetails(idempotencyHelp),
+	)
+
+	errPrivateEndpointIdempotent = errRange.New(
+		"Invalid API Directive",
+		"Private APIs cannot be idempotent, as idempotency keys are only supported for requests from outside the application.",
+		errors.WithDetails(idempotencyHelp),
+	)
+
+	errRawEndpointIdempotent = errRange.New(
+		"Invalid API Directive",
+		"Raw APIs cannot be idempotent, as Encore does not know their request and response types.",
+		errors.WithDetails(idempotencyHelp),
+	)
+
+	errStreamingEndpointIdempotent = errRange.New(
+		"Invalid API Directive",
+		"APIs receiving a record stream or streaming events cannot be idempotent.",
+		errors.WithDetails(idempotencyHelp),
+	)
+
+	errInvalidResponseCacheTTL = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid value cache=%s, expected a whole number of seconds between 1s and 24h, such as cache=30s or cache=5m.",
+		errors.WithDetails(responseCacheHelp),
+	)
+
+	errInvalidResponseCacheVary = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid vary value %q, expected a header name or auth.",
+		errors.WithDetails(responseCacheHelp),
+	)
+
+	errCacheFieldWithoutTTL = errRange.Newf(
+		"Invalid API Directive",
+		"The %q field can only be used together with the cache field.",
+		errors.WithDetails(responseCacheHelp),
+	)
+
+	errPrivateEndpointCached = errRange.New(
+		"Invalid API Directive",
+		"Private APIs cannot cache their responses, as only requests from outside the application are served from the cache.",
+		errors.WithDetails(responseCacheHelp),
+	)
+
+	errRawEndpointCached = errRange.New(
+		"Invalid API Directive",
+		"Raw APIs cannot cache their responses, as Encore does not know their request and response types.",
+		errors.WithDetails(responseCacheHelp),
+	)
+
+	errStreamingEndpointCached = errRange.New(
+		"Invalid API Directive",
+		"APIs receiving a record stream or streaming events cannot cache their responses.",
+		errors.WithDetails(responseCacheHelp),
+	)
+
+	errCachedEndpointMethod = errRange.Newf(
+		"Invalid API Directive",
+		"APIs caching their responses must be read-only, but the API accepts %s requests. Use method=GET to only accept GET requests.",
+		errors.WithDetails(responseCacheHelp),
+	)
+
+	errInvalidVersion = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid value version=%s, expected a positive integer such as version=2.",
+		errors.WithDetails(versionHelp),
+	)
+
+	errVersionWithoutPath = errRange.New(
+		"Invalid API Directive",
+		"Versioned APIs must specify their path, which identifies the versions of the API.",
+		errors.WithDetails(versionHelp),
+	)
+
+	ErrVersionSetUnversioned = errRange.Newf(
+		"Invalid API Directive",
+		"The API %s is versioned, but %s with the same path is not. Specify a version for it as well, such as version=1.",
+		errors.WithDetails(versionHelp),
+	)
+
+	errInvalidCompress = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid compress field: %s.",
+		errors.WithDetails(compressionHelp),
+	)
+
+	errInvalidTimeout = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid timeout field %q: expected a positive duration, such as timeout=5s.",
+		errors.WithDetails(requestLimitsHelp),
+	)
+
+	errInvalidMaxBodySize = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid maxBodySize field %q: expected a positive size in bytes, optionally with a unit of KB, MB or GB, such as maxBodySize=1MB.",
+		errors.WithDetails(requestLimitsHelp),
+	)
+
+	errInvalidCORSOrigin = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid origin %q in cors field: expected an origin such as https://app.example.com, optionally with wildcards such as https://*.example.com, or *.",
+		errors.WithDetails(corsHelp),
+	)
+
+	errInvalidCORSHeader = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid header %q in corsHeaders field: expected a header name such as X-Custom-Header.",
+		errors.WithDetails(corsHelp),
+	)
+
+	errInvalidCORSCredentials = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid value corsCredentials=%s, expected corsCredentials=true or corsCredentials=false.",
+		errors.WithDetails(corsHelp),
+	)
+
+	errCORSCredentialsWithoutOrigins = errRange.New(
+		"Invalid API Directive",
+		"The corsCredentials field can only be used together with the cors field, which specifies the origins allowed to make requests with credentials.",
+		errors.WithDetails(corsHelp),
+	)
+
+	errCORSCredentialsWithWildcard = errRange.New(
+		"Invalid API Directive",
+		"Requests with credentials cannot be allowed from all origins. List the allowed origins in the cors field instead of *.",
+		errors.WithDetails(corsHelp),
+	)
+
+	errPrivateEndpointWithCORS = errRange.New(
+		"Invalid API Directive",
+		"Private APIs cannot have a CORS policy, as they cannot be called from browsers.",
+		errors.WithDetails(corsHelp),
+	)
 )
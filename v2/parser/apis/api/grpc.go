@@ -0,0 +1,189 @@
+package api
+
+import (
+	"go/ast"
+	"slices"
+
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/internals/schema"
+	"encr.dev/v2/parser/apis/api/apienc"
+)
+
+// validateGRPC validates that an endpoint using the "grpc" option
+// can be described as a protobuf service method.
+//
+// gRPC calls are served as POST requests to the endpoint, so the request
+// and response must be fully described by path parameters and the body,
+// and their types must map onto protobuf messages.
+func validateGRPC(errs *perr.List, ep *Endpoint) {
+	field := ep.GRPCField.MustGet()
+	declared := errors.AsError("exposed over gRPC here")
+	help := errors.AsHelp("exposed over gRPC here")
+
+	if ep.Raw {
+		errs.Add(errGRPCRawEndpoint.AtGoNode(field, declared))
+		return
+	}
+	if ep.Access == Private {
+		errs.Add(errGRPCPrivateEndpoint.AtGoNode(field, declared))
+		return
+	}
+	if ep.StreamRecord != nil || ep.StreamEvent != nil {
+		errs.Add(errGRPCStreamingEndpoint.AtGoNode(field, declared))
+		return
+	}
+
+	if ep.Request != nil {
+		var post *apienc.RequestEncoding
+		for _, enc := range ep.RequestEncoding() {
+			if slices.Contains(enc.HTTPMethods, "POST") {
+				post = enc
+				break
+			}
+		}
+		if post == nil {
+			errs.Add(errGRPCRequiresPOST.AtGoNode(field, declared))
+			return
+		}
+
+		wire := [...]struct {
+			loc    string
+			params []*apienc.ParameterEncoding
+		}{
+			{"a header", post.HeaderParameters},
+			{"a query parameter", post.QueryParameters},
+			{"a cookie", post.CookieParameters},
+		}
+		for _, w := range wire {
+			for _, p := range w.params {
+				errs.Add(errGRPCWireField("request", p.SrcName, w.loc).AtGoNode(p.Type.ASTExpr()).AtGoNode(field, help))
+			}
+		}
+		checkGRPCType(errs, ep.Request, make(map[*pkginfo.PkgDeclInfo]bool))
+	} else if !slices.Contains(ep.HTTPMethods, "POST") && !slices.Contains(ep.HTTPMethods, "*") {
+		errs.Add(errGRPCRequiresPOST.AtGoNode(field, declared))
+		return
+	}
+
+	if ep.Response != nil {
+		resp := ep.ResponseEncoding()
+		wire := [...]struct {
+			loc    string
+			params []*apienc.ParameterEncoding
+		}{
+			{"a header", resp.HeaderParameters},
+			{"a cookie", resp.CookieParameters},
+		}
+		for _, w := range wire {
+			for _, p := range w.params {
+				errs.Add(errGRPCWireField("response", p.SrcName, w.loc).AtGoNode(p.Type.ASTExpr()).AtGoNode(field, help))
+			}
+		}
+		if p := resp.HTTPStatusParameter; p != nil {
+			errs.Add(errGRPCWireField("response", p.SrcName, "the HTTP status code").AtGoNode(p.Type.ASTExpr()).AtGoNode(field, help))
+		}
+		checkGRPCType(errs, ep.Response, make(map[*pkginfo.PkgDeclInfo]bool))
+	}
+}
+
+// checkGRPCType reports an error for each part of typ
+// that cannot be represented in a protobuf message.
+// Seen tracks the named types already checked, to handle recursive types.
+func checkGRPCType(errs *perr.List, typ schema.Type, seen map[*pkginfo.PkgDeclInfo]bool) {
+	unsupported := func(node ast.Node, reason string) {
+		errs.Add(errGRPCUnsupportedType(typ.String(), reason).AtGoNode(node))
+	}
+
+	switch t := typ.(type) {
+	case schema.NamedType:
+		if len(t.TypeArgs) > 0 {
+			unsupported(t.AST, "generic types are not supported")
+			return
+		}
+		if seen[t.DeclInfo] {
+			return
+		}
+		seen[t.DeclInfo] = true
+		checkGRPCType(errs, t.Decl().Type, seen)
+
+	case schema.StructType:
+		for _, f := range t.Fields {
+			if apienc.IgnoreField(f) {
+				continue
+			}
+			if apienc.IsStringEncoded(f) {
+				errs.Add(errGRPCStringEncodedField(f.Name.GetOrElse("")).AtGoNode(f.AST))
+				continue
+			}
+			checkGRPCType(errs, f.Type, seen)
+		}
+
+	case schema.PointerType:
+		checkGRPCType(errs, t.Elem, seen)
+
+	case schema.OptionType:
+		checkGRPCType(errs, t.Value, seen)
+
+	case schema.ListType:
+		switch underlyingGRPCType(t.Elem).(type) {
+		case schema.ListType, schema.MapType:
+			unsupported(t.AST, "protobuf does not support nested lists or lists of maps")
+			return
+		}
+		checkGRPCType(errs, t.Elem, seen)
+
+	case schema.MapType:
+		key, ok := underlyingGRPCType(t.Key).(schema.BuiltinType)
+		if !ok || !isGRPCMapKey(key.Kind) {
+			unsupported(t.AST, "protobuf map keys must be strings, integers, or booleans")
+			return
+		}
+		switch underlyingGRPCType(t.Value).(type) {
+		case schema.ListType, schema.MapType:
+			unsupported(t.AST, "protobuf map values cannot be lists or maps")
+			return
+		}
+		checkGRPCType(errs, t.Value, seen)
+
+	case schema.BuiltinType:
+		if t.Kind == schema.Any {
+			unsupported(t.AST, "values of any type are not supported; use json.RawMessage instead")
+		}
+
+	case schema.FuncType, schema.InterfaceType, schema.TypeParamRefType:
+		unsupported(t.ASTExpr(), "only structs, maps, lists, and builtin types are supported")
+	}
+}
+
+// underlyingGRPCType resolves pointers, options and
+// non-generic named types to the type they represent.
+func underlyingGRPCType(typ schema.Type) schema.Type {
+	for {
+		switch t := typ.(type) {
+		case schema.PointerType:
+			typ = t.Elem
+		case schema.OptionType:
+			typ = t.Value
+		case schema.NamedType:
+			if len(t.TypeArgs) > 0 {
+				return t
+			}
+			typ = t.Decl().Type
+		default:
+			return typ
+		}
+	}
+}
+
+func isGRPCMapKey(kind schema.BuiltinKind) bool {
+	switch kind {
+	case schema.String, schema.Bool,
+		schema.Int, schema.Int8, schema.Int16, schema.Int32, schema.Int64,
+		schema.Uint, schema.Uint8, schema.Uint16, schema.Uint32, schema.Uint64,
+		schema.UUID, schema.UserID:
+		return true
+	}
+	return false
+}
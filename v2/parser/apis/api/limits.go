@@ -0,0 +1,44 @@
+package api
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/parser/apis/directive"
+)
+
+// byteSizeUnits are the supported units of the "maxBodySize" field.
+var byteSizeUnits = map[string]int64{
+	"":   1,
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+}
+
+// parseTimeout parses the "timeout" field of an encore:api directive, such as "timeout=5s".
+func parseTimeout(errs *perr.List, f directive.Field) (time.Duration, bool) {
+	d, err := time.ParseDuration(f.Value)
+	if err != nil || d <= 0 {
+		errs.Add(errInvalidTimeout(f.Value).AtGoNode(f))
+		return 0, false
+	}
+	return d, true
+}
+
+// parseByteSize parses the "maxBodySize" field of an encore:api directive, such as "maxBodySize=1MB".
+// The units are multiples of 1024 bytes.
+func parseByteSize(errs *perr.List, f directive.Field) (int64, bool) {
+	num := strings.TrimRightFunc(f.Value, unicode.IsLetter)
+	unit, ok := byteSizeUnits[strings.ToUpper(f.Value[len(num):])]
+	n, err := strconv.ParseInt(num, 10, 64)
+	if !ok || err != nil || n <= 0 || n > math.MaxInt64/unit {
+		errs.Add(errInvalidMaxBodySize(f.Value).AtGoNode(f))
+		return 0, false
+	}
+	return n * unit, true
+}
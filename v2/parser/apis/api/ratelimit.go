@@ -0,0 +1,78 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/parser/apis/directive"
+)
+
+// RateLimit describes the rate limit of an endpoint, such as
+// "ratelimit=100/s burst=20 per=user" in its //encore:api directive.
+type RateLimit struct {
+	Rate   int           // number of requests allowed per Period
+	Period time.Duration // one of time.Second, time.Minute or time.Hour
+	Burst  int           // maximum number of requests at once; 0 means Rate
+
+	// PerUser reports whether each authenticated user is limited separately
+	// ("per=user"). Unauthenticated requests share a single limit.
+	PerUser bool
+
+	// Field is the "ratelimit" directive field.
+	Field directive.Field
+}
+
+// rateLimitPeriods are the supported periods of the "ratelimit" field.
+var rateLimitPeriods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// parseRateLimit parses the rate limiting fields of an encore:api directive.
+func parseRateLimit(errs *perr.List, fields []directive.Field) (rl *RateLimit, ok bool) {
+	rl = &RateLimit{}
+	var hasRate bool
+	for _, f := range fields {
+		if f.Key == "ratelimit" {
+			rl.Field = f
+			hasRate = true
+		}
+	}
+
+	for _, f := range fields {
+		if !hasRate {
+			errs.Add(errRateLimitFieldWithoutRate(f.Key).AtGoNode(f))
+			return nil, false
+		}
+
+		switch f.Key {
+		case "ratelimit":
+			rate, period, found := strings.Cut(f.Value, "/")
+			n, err := strconv.Atoi(rate)
+			if !found || err != nil || n <= 0 || rateLimitPeriods[period] == 0 {
+				errs.Add(errInvalidRateLimit(f.Value).AtGoNode(f))
+				return nil, false
+			}
+			rl.Rate, rl.Period = n, rateLimitPeriods[period]
+
+		case "burst":
+			n, err := strconv.Atoi(f.Value)
+			if err != nil || n <= 0 {
+				errs.Add(errInvalidRateLimitBurst(f.Value).AtGoNode(f))
+				return nil, false
+			}
+			rl.Burst = n
+
+		case "per":
+			if f.Value != "user" {
+				errs.Add(errInvalidRateLimitPer(f.Value).AtGoNode(f))
+				return nil, false
+			}
+			rl.PerUser = true
+		}
+	}
+	return rl, true
+}
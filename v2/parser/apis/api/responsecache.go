@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/textproto"
+	"time"
+
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/parser/apis/directive"
+)
+
+// ResponseCache describes how the responses of an endpoint are cached,
+// such as "cache=5m vary=Accept-Language,auth" in its //encore:api directive.
+type ResponseCache struct {
+	TTL time.Duration // how long responses are cached for, in whole seconds
+
+	// VaryHeaders are the request headers the response depends on,
+	// in canonical form. Responses are cached separately for each
+	// combination of their values.
+	VaryHeaders []string
+
+	// VaryAuth reports whether responses are cached separately for each
+	// authenticated user ("vary=auth"). It's always set for endpoints
+	// requiring authentication.
+	VaryAuth bool
+
+	// Field is the "cache" directive field.
+	Field directive.Field
+}
+
+const (
+	minResponseCacheTTL = time.Second
+	maxResponseCacheTTL = 24 * time.Hour
+)
+
+// parseResponseCache parses the response caching fields of an encore:api directive.
+func parseResponseCache(errs *perr.List, fields []directive.Field) (rc *ResponseCache, ok bool) {
+	rc = &ResponseCache{}
+	var hasTTL bool
+	for _, f := range fields {
+		if f.Key == "cache" {
+			rc.Field = f
+			hasTTL = true
+		}
+	}
+
+	for _, f := range fields {
+		if !hasTTL {
+			errs.Add(errCacheFieldWithoutTTL(f.Key).AtGoNode(f))
+			return nil, false
+		}
+
+		switch f.Key {
+		case "cache":
+			ttl, err := time.ParseDuration(f.Value)
+			if err != nil || ttl < minResponseCacheTTL || ttl > maxResponseCacheTTL || ttl%time.Second != 0 {
+				errs.Add(errInvalidResponseCacheTTL(f.Value).AtGoNode(f))
+				return nil, false
+			}
+			rc.TTL = ttl
+
+		case "vary":
+			for _, v := range f.List() {
+				if v == "auth" {
+					rc.VaryAuth = true
+					continue
+				} else if !isHeaderName(v) {
+					errs.Add(errInvalidResponseCacheVary(v).AtGoNode(f))
+					return nil, false
+				}
+				rc.VaryHeaders = append(rc.VaryHeaders, textproto.CanonicalMIMEHeaderKey(v))
+			}
+		}
+	}
+	return rc, true
+}
+
+// isHeaderName reports whether s is a valid HTTP header name.
+func isHeaderName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// nonReadOnlyMethod returns the first of the given HTTP methods
+// that isn't read-only, if any.
+func nonReadOnlyMethod(methods []string) (method string, ok bool) {
+	for _, m := range methods {
+		if m != "GET" && m != "HEAD" {
+			return m, true
+		}
+	}
+	return "", false
+}
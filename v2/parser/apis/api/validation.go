@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encore.dev/appruntime/exported/validation"
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/internals/schema"
+	"encr.dev/v2/internals/schema/schemautil"
+	"encr.dev/v2/parser/apis/api/apienc"
+)
+
+// validateTags checks the "validate" struct tags of the request type,
+// so the rules the runtime enforces are known to be valid at compile time.
+func validateTags(errs *perr.List, typ schema.Type, seen map[*pkginfo.PkgDeclInfo]bool) {
+	switch t := typ.(type) {
+	case schema.NamedType:
+		if len(t.TypeArgs) > 0 {
+			// Each instantiation may use different type arguments, so check them separately.
+			validateTags(errs, schemautil.ConcretizeWithTypeArgs(errs, t.Decl().Type, t.TypeArgs), seen)
+			return
+		}
+		if seen[t.DeclInfo] {
+			return
+		}
+		seen[t.DeclInfo] = true
+		validateTags(errs, t.Decl().Type, seen)
+
+	case schema.StructType:
+		for _, f := range t.Fields {
+			if !f.IsExported() || apienc.IgnoreField(f) {
+				continue
+			}
+			if tag, err := f.Tag.Get("validate"); err == nil {
+				rules, err := validation.Parse(tag.Value())
+				if err == nil {
+					err = rules.CheckKind(validationKind(f.Type))
+				}
+				if err != nil {
+					errs.Add(errInvalidValidateTag(f.Name.GetOrElse(""), err).AtGoNode(f.AST.Tag))
+				}
+			}
+			validateTags(errs, f.Type, seen)
+		}
+
+	case schema.PointerType:
+		validateTags(errs, t.Elem, seen)
+
+	case schema.OptionType:
+		validateTags(errs, t.Value, seen)
+
+	case schema.ListType:
+		validateTags(errs, t.Elem, seen)
+
+	case schema.MapType:
+		validateTags(errs, t.Value, seen)
+	}
+}
+
+// validationKind returns the kind of values of the given type
+// for the purpose of validation rules. Pointers and options
+// are validated by the value they hold.
+func validationKind(typ schema.Type) validation.Kind {
+	for {
+		switch t := typ.(type) {
+		case schema.PointerType:
+			typ = t.Elem
+		case schema.OptionType:
+			typ = t.Value
+		case schema.NamedType:
+			if len(t.TypeArgs) > 0 {
+				// Generic named types are structs in practice.
+				return validation.Other
+			}
+			typ = t.Decl().Type
+		case schema.ListType:
+			return validation.List
+		case schema.MapType:
+			return validation.Map
+		case schema.BuiltinType:
+			switch t.Kind {
+			case schema.String, schema.UserID:
+				return validation.String
+			case schema.Int, schema.Int8, schema.Int16, schema.Int32, schema.Int64:
+				return validation.Int
+			case schema.Uint, schema.Uint8, schema.Uint16, schema.Uint32, schema.Uint64:
+				return validation.Uint
+			case schema.Float32, schema.Float64:
+				return validation.Float
+			case schema.Bool:
+				return validation.Bool
+			case schema.Bytes:
+				return validation.List
+			default:
+				return validation.Other
+			}
+		default:
+			return validation.Other
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"encr.dev/pkg/option"
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/internals/resourcepaths"
+	"encr.dev/v2/internals/schema"
+	"encr.dev/v2/parser/apis/directive"
+)
+
+// parseVersion parses the "version" field of an encore:api directive.
+func parseVersion(errs *perr.List, f directive.Field) (version int, ok bool) {
+	n, err := strconv.Atoi(f.Value)
+	if err != nil || n <= 0 {
+		errs.Add(errInvalidVersion(f.Value).AtGoNode(f))
+		return 0, false
+	}
+	return n, true
+}
+
+// versionedPath returns path prefixed with the "/v<version>" segment,
+// positioned at the "version" field declaring it.
+func versionedPath(path *resourcepaths.Path, version int, f directive.Field) *resourcepaths.Path {
+	prefix := resourcepaths.Segment{
+		Type:      resourcepaths.Literal,
+		Value:     "v" + strconv.Itoa(version),
+		ValueType: schema.String,
+		StartPos:  f.Pos(),
+		EndPos:    f.End(),
+	}
+	return &resourcepaths.Path{
+		StartPos: path.StartPos,
+		Segments: append([]resourcepaths.Segment{prefix}, path.Segments...),
+	}
+}
+
+// deprecationNotice returns the deprecation notice in the doc comment,
+// following the Go convention of a paragraph beginning with "Deprecated: ".
+func deprecationNotice(doc string) option.Option[string] {
+	for _, para := range strings.Split(doc, "\n\n") {
+		if notice, ok := strings.CutPrefix(strings.TrimSpace(para), "Deprecated: "); ok {
+			return option.Some(strings.Join(strings.Fields(notice), " "))
+		}
+	}
+	return option.None[string]()
+}
+
+// VersionSet is the set of versions of an endpoint: the endpoints
+// with the same unversioned path, sorted by version.
+type VersionSet struct {
+	Path      *resourcepaths.Path
+	Endpoints []*Endpoint
+}
+
+// Default returns the version serving requests that don't specify a version:
+// the oldest one, so that existing callers keep working as versions are added.
+func (vs *VersionSet) Default() *Endpoint {
+	return vs.Endpoints[0]
+}
@@ -3,6 +3,7 @@ package authhandler
 import (
 	"go/ast"
 	"go/token"
+	"strconv"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/option"
@@ -31,6 +32,11 @@ type AuthHandler struct {
 	// AuthData is the custom auth data type the app specifies
 	// as part of the returns from the auth handler, if any.
 	AuthData option.Option[*schema.TypeDeclRef]
+
+	// Order is the position of the auth handler in the resolution order,
+	// as specified with the "order" directive field, if any.
+	// It is only meaningful when the application defines multiple auth handlers.
+	Order option.Option[int]
 }
 
 func (ah *AuthHandler) Kind() resource.Kind       { return resource.AuthHandler }
@@ -64,6 +70,25 @@ func Parse(d ParseData) *AuthHandler {
 		Recv: decl.Recv,
 	}
 
+	ok = directive.Validate(d.Errs, d.Dir, directive.ValidateSpec{
+		AllowedFields: []string{"order"},
+		ValidateField: func(errs *perr.List, f directive.Field) (ok bool) {
+			switch f.Key {
+			case "order":
+				n, err := strconv.Atoi(f.Value)
+				if err != nil || n < 0 {
+					errs.Add(errInvalidOrder(f.Value).AtGoNode(f))
+					return false
+				}
+				ah.Order = option.Some(n)
+			}
+			return true
+		},
+	})
+	if !ok {
+		return nil
+	}
+
 	sig := decl.Type
 	numParams := len(sig.Params)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/rogpeppe/go-internal/txtar"
 
+	"encr.dev/pkg/option"
 	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/internals/schema"
 	. "encr.dev/v2/internals/schema/schematest"
@@ -78,6 +79,46 @@ func Foo(ctx context.Context, p *Params) (auth.UID, error) {}
 				Param: Ptr(Named(TypeInfo("Params"))),
 			},
 		},
+		{
+			name: "with_order",
+			def: `
+//encore:authhandler order=2
+func Foo(ctx context.Context, token string) (auth.UID, error) {}
+`,
+			want: &AuthHandler{
+				Decl: &schema.FuncDecl{
+					Name: "Foo",
+					Type: schema.FuncType{
+						Params: []schema.Param{
+							ctxParam,
+							Param(String()),
+						},
+						Results: []schema.Param{
+							uidResult,
+							Param(Error()),
+						},
+					},
+				},
+				Param: String(),
+				Order: option.Some(2),
+			},
+		},
+		{
+			name: "invalid_order",
+			def: `
+//encore:authhandler order=first
+func Foo(ctx context.Context, token string) (auth.UID, error) {}
+`,
+			wantErrs: []string{`.*The auth handler order must be a non-negative integer, got "first"\.`},
+		},
+		{
+			name: "unknown_field",
+			def: `
+//encore:authhandler target=all
+func Foo(ctx context.Context, token string) (auth.UID, error) {}
+`,
+			wantErrs: []string{`.*Unknown field "target"\. Fields must be one of order\.`},
+		},
 	}
 
 	// testArchive renders the txtar archive to use for a given test.
@@ -74,9 +74,40 @@ note: *Params and *UserData are custom data types you define
 		),
 	)
 
-	ErrMultipleAuthHandlers = errRange.New(
-		"Multiple auth handlers found",
-		"Multiple auth handlers were found in the application. Encore only allows one auth handler to be defined per application.",
+	errInvalidOrder = errRange.Newf(
+		"Invalid auth handler directive",
+		"The auth handler order must be a non-negative integer, got %q.",
+		errors.WithDetails(authLink),
+	)
+
+	ErrMissingOrder = errRange.New(
+		"Missing auth handler order",
+		"When multiple auth handlers are defined, each one must specify its resolution order using the \"order\" field, like //encore:authhandler order=1.",
+		errors.WithDetails(authLink),
+	)
+
+	ErrDuplicateOrder = errRange.New(
+		"Duplicate auth handler order",
+		"Multiple auth handlers were defined with the same resolution order.",
+		errors.WithDetails(authLink),
+	)
+
+	ErrAuthDataMismatch = errRange.New(
+		"Mismatched auth data types",
+		"All auth handlers in the application must return the same auth data type.",
+		errors.WithDetails(authLink),
+	)
+
+	ErrAuthHandlersInDifferentServices = errRange.New(
+		"Auth handlers defined in different services",
+		"All auth handlers in the application must be defined in the same service.",
+		errors.WithDetails(authLink),
+	)
+
+	ErrUnreachableAuthHandler = errRange.New(
+		"Unreachable auth handler",
+		"The auth handler can never be used, since all of its auth parameters are already handled by auth handlers earlier in the resolution order.",
+		errors.WithDetails(authLink),
 	)
 
 	ErrNoAuthHandlerDefined = errRange.New(
@@ -205,8 +205,9 @@ func Parse(errs *perr.List, cg *ast.CommentGroup) (dir *Directive, doc string, o
 }
 
 var (
-	// nameRe is the regexp for validating option names and field names.
-	nameRe = regexp.MustCompile(`^[a-z]+$`)
+	// nameRe is the regexp for validating option names and field names,
+	// which are lowercase or camelCase, like "path" or "maxBodySize".
+	nameRe = regexp.MustCompile(`^[a-z][a-zA-Z]*$`)
 	// tagRe is the regexp for validating tag values.
 	tagRe = regexp.MustCompile(`^[a-z]([-_a-z0-9]*[a-z0-9])?$`)
 )
@@ -63,4 +63,29 @@ var (
 		"Invalid middleware function",
 		"Global middleware cannot be defined in a service.",
 	)
+
+	errInvalidOrder = errRange.Newf(
+		"Invalid middleware order",
+		"The middleware order must be an integer, got %q.",
+	)
+
+	errInvalidDependency = errRange.Newf(
+		"Invalid middleware dependency",
+		"The \"after\" field must be a comma-separated list of middleware names, got %q.",
+	)
+
+	ErrUnknownDependency = errRange.Newf(
+		"Invalid middleware dependency",
+		"Unknown middleware %q. Middleware can only run after global middleware, or middleware defined in the same service.",
+	)
+
+	ErrAmbiguousDependency = errRange.Newf(
+		"Invalid middleware dependency",
+		"Multiple middleware named %q are defined.",
+	)
+
+	ErrDependencyCycle = errRange.Newf(
+		"Middleware dependency cycle",
+		"The middleware must run after each other in a cycle, so they cannot be ordered: %s.",
+	)
 )
@@ -6,6 +6,8 @@ import (
 	"go/ast"
 	"go/token"
 	"slices"
+	"strconv"
+	"strings"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/option"
@@ -33,6 +35,15 @@ type Middleware struct {
 
 	// Recv is the type the middleware is defined as a method on, if any.
 	Recv option.Option[*schema.Receiver]
+
+	// Order is the position of the middleware relative to the other middleware,
+	// as specified with the "order" directive field, if any.
+	// Middleware with a lower order runs first, and the default is 0.
+	Order option.Option[int]
+
+	// After are the names of the middleware this middleware must run after,
+	// as specified with the "after" directive field.
+	After []string
 }
 
 // ID returns a unique id for this specific middleware.
@@ -75,7 +86,7 @@ func Parse(d ParseData) *Middleware {
 	}
 	ok = directive.Validate(d.Errs, d.Dir, directive.ValidateSpec{
 		AllowedOptions: []string{"global"},
-		AllowedFields:  []string{"target"},
+		AllowedFields:  []string{"target", "order", "after"},
 		ValidateOption: nil,
 		ValidateField: func(errs *perr.List, f directive.Field) (ok bool) {
 			switch f.Key {
@@ -95,6 +106,21 @@ func Parse(d ParseData) *Middleware {
 					}
 					mw.Target.Add(sel)
 				}
+			case "order":
+				n, err := strconv.Atoi(f.Value)
+				if err != nil {
+					errs.Add(errInvalidOrder(f.Value).AtGoNode(f))
+					return false
+				}
+				mw.Order = option.Some(n)
+			case "after":
+				for _, name := range f.List() {
+					if !token.IsIdentifier(name) {
+						errs.Add(errInvalidDependency(f.Value).AtGoNode(f))
+						return false
+					}
+					mw.After = append(mw.After, name)
+				}
 			}
 			return true
 		},
@@ -174,3 +200,83 @@ func Sort(mws []*Middleware) {
 
 	slices.SortStableFunc(mws, sortFn)
 }
+
+// Order sorts mws into the order they run in, reporting an error
+// if their "after" dependencies cannot be satisfied.
+//
+// Middleware runs after the middleware it depends on, and otherwise
+// in increasing order and then in the order given by Sort.
+// The middleware in earlier always run before mws, so dependencies
+// on them are satisfied already.
+func Order(errs *perr.List, mws, earlier []*Middleware) []*Middleware {
+	sorted := slices.Clone(mws)
+	Sort(sorted)
+	slices.SortStableFunc(sorted, func(a, b *Middleware) int {
+		return cmp.Compare(a.Order.GetOrElse(0), b.Order.GetOrElse(0))
+	})
+
+	byName := make(map[string][]*Middleware)
+	for _, mw := range sorted {
+		byName[mw.Decl.Name] = append(byName[mw.Decl.Name], mw)
+	}
+	earlierNames := make(map[string]bool)
+	for _, mw := range earlier {
+		earlierNames[mw.Decl.Name] = true
+	}
+
+	// Resolve the dependencies of each middleware.
+	deps := make(map[*Middleware][]*Middleware)
+	for _, mw := range sorted {
+		for _, name := range mw.After {
+			switch matches := byName[name]; {
+			case len(matches) == 1:
+				deps[mw] = append(deps[mw], matches[0])
+			case len(matches) > 1:
+				errs.Add(ErrAmbiguousDependency(name).AtGoNode(mw.Decl.AST.Name))
+			case !earlierNames[name]:
+				errs.Add(ErrUnknownDependency(name).AtGoNode(mw.Decl.AST.Name))
+			}
+		}
+	}
+
+	// Repeatedly add the first middleware whose dependencies have all been added.
+	result := make([]*Middleware, 0, len(sorted))
+	added := make(map[*Middleware]bool)
+	for len(result) < len(sorted) {
+		idx := slices.IndexFunc(sorted, func(mw *Middleware) bool {
+			return !added[mw] && !slices.ContainsFunc(deps[mw], func(dep *Middleware) bool { return !added[dep] })
+		})
+		if idx == -1 {
+			// The remaining middleware depend on each other in a cycle.
+			remaining := slices.DeleteFunc(slices.Clone(sorted), func(mw *Middleware) bool { return added[mw] })
+			cycle := findCycle(remaining[0], deps, added)
+			names := make([]string, len(cycle))
+			for i, mw := range cycle {
+				names[i] = mw.Decl.Name
+			}
+			errs.Add(ErrDependencyCycle(strings.Join(names, " -> ")).AtGoNode(cycle[0].Decl.AST.Name))
+			return append(result, remaining...)
+		}
+		added[sorted[idx]] = true
+		result = append(result, sorted[idx])
+	}
+	return result
+}
+
+// findCycle follows the dependencies that have not been added, starting at mw,
+// and returns the cycle it reaches, beginning and ending with the same middleware.
+func findCycle(mw *Middleware, deps map[*Middleware][]*Middleware, added map[*Middleware]bool) []*Middleware {
+	var path []*Middleware
+	for {
+		if idx := slices.Index(path, mw); idx != -1 {
+			return append(path[idx:], mw)
+		}
+		path = append(path, mw)
+		for _, dep := range deps[mw] {
+			if !added[dep] {
+				mw = dep
+				break
+			}
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/rogpeppe/go-internal/txtar"
 
+	"encr.dev/pkg/option"
 	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/internals/schema"
 	. "encr.dev/v2/internals/schema/schematest"
@@ -57,6 +58,43 @@ func Foo(req middleware.Request, next middleware.Next) middleware.Response {}
 				}),
 			},
 		},
+		{
+			name: "with_order",
+			def: `
+//encore:middleware target=all order=-1 after=Auth,Tracing
+func Foo(req middleware.Request, next middleware.Next) middleware.Response {}
+`,
+			want: &Middleware{
+				Decl: &schema.FuncDecl{
+					Name: "Foo",
+					Type: schema.FuncType{
+						Params:  mwParams,
+						Results: mwResults,
+					},
+				},
+				Target: selector.NewSet(selector.Selector{
+					Type: selector.All,
+				}),
+				Order: option.Some(-1),
+				After: []string{"Auth", "Tracing"},
+			},
+		},
+		{
+			name: "invalid_order",
+			def: `
+//encore:middleware target=all order=first
+func Foo(req middleware.Request, next middleware.Next) middleware.Response {}
+`,
+			wantErrs: []string{`.*The middleware order must be an integer, got "first"\.`},
+		},
+		{
+			name: "invalid_after",
+			def: `
+//encore:middleware target=all after=foo.Bar
+func Foo(req middleware.Request, next middleware.Next) middleware.Response {}
+`,
+			wantErrs: []string{`.*The "after" field must be a comma-separated list of middleware names, got "foo\.Bar"\.`},
+		},
 	}
 
 	// testArchive renders the txtar archive to use for a given test.
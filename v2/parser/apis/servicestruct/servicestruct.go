@@ -11,6 +11,7 @@ import (
 	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/internals/schema"
 	"encr.dev/v2/internals/schema/schemautil"
+	"encr.dev/v2/parser/apis/api"
 	"encr.dev/v2/parser/apis/directive"
 	"encr.dev/v2/parser/internal/utils"
 	"encr.dev/v2/parser/resource"
@@ -24,6 +25,15 @@ type ServiceStruct struct {
 	// Init is the function for initializing this group.
 	// It is nil if there is no initialization function.
 	Init option.Option[*schema.FuncDecl]
+
+	// EnvTypes are the environment types the service's endpoints are publicly exposed in,
+	// as given by the "env" field. If empty they're exposed in all environments.
+	EnvTypes      []string
+	EnvTypesField option.Option[directive.Field]
+
+	// CORS is the CORS policy of the service's endpoints, as given by the "cors",
+	// "corsHeaders" and "corsCredentials" fields. It is nil if the service has none.
+	CORS *api.CORS
 }
 
 func (ss *ServiceStruct) Kind() resource.Kind       { return resource.ServiceStruct }
@@ -46,8 +56,28 @@ type ParseData struct {
 
 // Parse parses the service struct in the provided type declaration.
 func Parse(d ParseData) *ServiceStruct {
-	// We don't allow anything on the directive besides "encore:service".
-	directive.Validate(d.Errs, d.Dir, directive.ValidateSpec{})
+	// We don't allow anything on the directive besides "encore:service",
+	// the "env" field and the CORS policy fields.
+	var envTypes []string
+	var envField option.Option[directive.Field]
+	var corsFields []directive.Field
+	directive.Validate(d.Errs, d.Dir, directive.ValidateSpec{
+		AllowedFields: []string{"env", "cors", "corsHeaders", "corsCredentials"},
+		ValidateField: func(errs *perr.List, f directive.Field) (ok bool) {
+			if api.IsCORSField(f) {
+				corsFields = append(corsFields, f)
+				return true
+			}
+			envTypes, ok = api.ParseEnvTypes(errs, f)
+			envField = option.Some(f)
+			return ok
+		},
+	})
+
+	var corsPolicy *api.CORS
+	if len(corsFields) > 0 {
+		corsPolicy, _ = api.ParseCORS(d.Errs, corsFields)
+	}
 
 	// We only support encore:service directives directly on the type declaration,
 	// not on a group of type declarations.
@@ -64,8 +94,11 @@ func Parse(d ParseData) *ServiceStruct {
 	decl := d.Schema.ParseTypeDecl(declInfo)
 
 	ss := &ServiceStruct{
-		Decl: decl,
-		Doc:  d.Doc,
+		Decl:          decl,
+		Doc:           d.Doc,
+		EnvTypes:      envTypes,
+		EnvTypesField: envField,
+		CORS:          corsPolicy,
 	}
 
 	// Find the init function for this service struct, if any.
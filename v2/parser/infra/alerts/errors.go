@@ -0,0 +1,94 @@
+package alerts
+
+import (
+	"encr.dev/pkg/errors"
+)
+
+var (
+	errRange = errors.Range(
+		"alerts",
+		"For more information, see https://encore.dev/docs/observability/alerts",
+
+		errors.WithRangeSize(20),
+	)
+
+	errExpects2Arguments = errRange.Newf(
+		"Invalid call to alerts.NewRule",
+		"Expected 2 arguments, got %d",
+	)
+
+	errConditionCount = errRange.New(
+		"Invalid call to alerts.NewRule",
+		"Exactly one of ErrorRateAbove, LatencyAbove and QueueLagAbove must be set.",
+	)
+
+	errErrorRateOutOfRange = errRange.Newf(
+		"Invalid call to alerts.NewRule",
+		"ErrorRateAbove must be greater than 0 and less than 1, got %v.",
+	)
+
+	errLatencyOutOfRange = errRange.Newf(
+		"Invalid call to alerts.NewRule",
+		"LatencyAbove must be positive, got %s.",
+	)
+
+	errPercentileOutOfRange = errRange.Newf(
+		"Invalid call to alerts.NewRule",
+		"LatencyPercentile must be greater than 0 and less than 100, got %v.",
+	)
+
+	errQueueLagOutOfRange = errRange.Newf(
+		"Invalid call to alerts.NewRule",
+		"QueueLagAbove must be positive, got %s.",
+	)
+
+	errSubscriptionRequired = errRange.New(
+		"Invalid call to alerts.NewRule",
+		"Queue lag rules must specify the Subscription they apply to.",
+	)
+
+	errSubscriptionNotAllowed = errRange.New(
+		"Invalid call to alerts.NewRule",
+		"Subscription can only be set for queue lag rules.",
+	)
+
+	errEndpointNotAllowed = errRange.New(
+		"Invalid call to alerts.NewRule",
+		"Endpoint can only be set for error rate and latency rules.",
+	)
+
+	errUnableToResolveReference = errRange.New(
+		"Invalid call to alerts.NewRule",
+		"Unable to resolve the reference. It must refer to a package-level declaration.",
+	)
+
+	errWindowOutOfRange = errRange.Newf(
+		"Invalid call to alerts.NewRule",
+		"Window must be between 1 minute and 24 hours, got %s.",
+	)
+
+	errForOutOfRange = errRange.Newf(
+		"Invalid call to alerts.NewRule",
+		"For must be between 0 and 24 hours, got %s.",
+	)
+
+	errInvalidSeverity = errRange.Newf(
+		"Invalid call to alerts.NewRule",
+		"Severity must be alerts.Warning or alerts.Critical, got %q.",
+	)
+
+	ErrDuplicateNames = errRange.New(
+		"Duplicate alert rules",
+		"Multiple alert rules with the same name were found. Rule names must be unique.",
+	)
+
+	ErrEndpointNotInService = errRange.New(
+		"Invalid alert rule endpoint",
+		"The Endpoint of an alert rule must be an API endpoint of the service the rule is declared in.",
+	)
+
+	ErrSubscriptionNotInService = errRange.New(
+		"Invalid alert rule subscription",
+		"The Subscription of an alert rule must be a Pub/Sub subscription of the service the rule is declared in.",
+	)
+)
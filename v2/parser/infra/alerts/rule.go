@@ -0,0 +1,201 @@
+package alerts
+
+import (
+	"go/ast"
+	"go/token"
+	"time"
+
+	"encr.dev/pkg/option"
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/pkginfo"
+	literals "encr.dev/v2/parser/infra/internal/literals"
+	parseutil "encr.dev/v2/parser/infra/internal/parseutil"
+	"encr.dev/v2/parser/resource"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+// Rule is an alert rule, declared with alerts.NewRule.
+type Rule struct {
+	AST   *ast.CallExpr
+	File  *pkginfo.File
+	Name  string // The unique name of the rule
+	Doc   string // The documentation on the rule
+	Title string // The rule title
+
+	// Endpoint is the endpoint an error rate or latency rule applies to.
+	// If None the rule applies to all endpoints of the service.
+	Endpoint    option.Option[pkginfo.QualifiedName]
+	EndpointAST ast.Expr
+
+	// Subscription is the subscription a queue lag rule applies to.
+	Subscription    option.Option[pkginfo.QualifiedName]
+	SubscriptionAST ast.Expr
+
+	// The condition of the rule. Exactly one of these is set.
+	ErrorRateAbove    float64
+	LatencyAbove      time.Duration
+	LatencyPercentile float64
+	QueueLagAbove     time.Duration
+
+	Window   time.Duration // The window the condition is computed over
+	For      time.Duration // How long the condition must hold before firing
+	Severity string        // "warning" or "critical"
+}
+
+func (r *Rule) Kind() resource.Kind       { return resource.AlertRule }
+func (r *Rule) Package() *pkginfo.Package { return r.File.Pkg }
+func (r *Rule) ASTExpr() ast.Expr         { return r.AST }
+func (r *Rule) ResourceName() string      { return r.Name }
+func (r *Rule) Pos() token.Pos            { return r.AST.Pos() }
+func (r *Rule) End() token.Pos            { return r.AST.End() }
+func (r *Rule) SortKey() string           { return r.Name }
+
+var RuleParser = &resourceparser.Parser{
+	Name: "Alert Rule",
+
+	InterestingImports: []paths.Pkg{"encore.dev/alerts"},
+	Run: func(p *resourceparser.Pass) {
+		name := pkginfo.QualifiedName{PkgPath: "encore.dev/alerts", Name: "NewRule"}
+
+		spec := &parseutil.ReferenceSpec{
+			MinTypeArgs: 0,
+			MaxTypeArgs: 0,
+			Parse:       parseRule,
+		}
+
+		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+			parseutil.ParseReference(p, spec, parseutil.ReferenceData{
+				File:         file,
+				Stack:        stack,
+				ResourceFunc: name,
+			})
+		})
+	},
+}
+
+func parseRule(d parseutil.ReferenceInfo) {
+	errs := d.Pass.Errs
+	displayName := d.ResourceFunc.NaiveDisplayName()
+	if len(d.Call.Args) != 2 {
+		errs.Add(errExpects2Arguments(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	}
+
+	ruleName := parseutil.ParseResourceName(errs, displayName, "rule name",
+		d.Call.Args[0], parseutil.KebabName, "")
+	if ruleName == "" {
+		// we already reported the error inside ParseResourceName
+		return
+	}
+
+	cfgLit, ok := literals.ParseStruct(errs, d.File, "alerts.RuleConfig", d.Call.Args[1])
+	if !ok {
+		return // error reported by ParseStruct
+	}
+
+	// Decode the config
+	type decodedConfig struct {
+		Title             string        `literal:",optional"`
+		Endpoint          ast.Expr      `literal:",optional,dynamic"`
+		Subscription      ast.Expr      `literal:",optional,dynamic"`
+		ErrorRateAbove    float64       `literal:",optional"`
+		LatencyAbove      time.Duration `literal:",optional"`
+		LatencyPercentile float64       `literal:",optional,default"`
+		QueueLagAbove     time.Duration `literal:",optional"`
+		Window            time.Duration `literal:",optional,default"`
+		For               time.Duration `literal:",optional,default"`
+		Severity          string        `literal:",optional,default"`
+	}
+	defaults := decodedConfig{
+		LatencyPercentile: 99,
+		Window:            5 * time.Minute,
+		For:               5 * time.Minute,
+		Severity:          "warning",
+	}
+	config := literals.Decode[decodedConfig](errs, cfgLit, &defaults)
+
+	rule := &Rule{
+		AST:               d.Call,
+		File:              d.File,
+		Name:              ruleName,
+		Doc:               d.Doc,
+		Title:             config.Title,
+		ErrorRateAbove:    config.ErrorRateAbove,
+		LatencyAbove:      config.LatencyAbove,
+		LatencyPercentile: config.LatencyPercentile,
+		QueueLagAbove:     config.QueueLagAbove,
+		Window:            config.Window,
+		For:               config.For,
+		Severity:          config.Severity,
+	}
+	if rule.Title == "" {
+		rule.Title = ruleName
+	}
+
+	// Validate the condition.
+	numConditions := 0
+	for _, field := range []string{"ErrorRateAbove", "LatencyAbove", "QueueLagAbove"} {
+		if cfgLit.IsSet(field) {
+			numConditions++
+		}
+	}
+	if numConditions != 1 {
+		errs.Add(errConditionCount.AtGoNode(cfgLit.Lit()))
+		return
+	}
+
+	switch {
+	case cfgLit.IsSet("ErrorRateAbove"):
+		if config.ErrorRateAbove <= 0 || config.ErrorRateAbove >= 1 {
+			errs.Add(errErrorRateOutOfRange(config.ErrorRateAbove).AtGoNode(cfgLit.Expr("ErrorRateAbove")))
+		}
+	case cfgLit.IsSet("LatencyAbove"):
+		if config.LatencyAbove <= 0 {
+			errs.Add(errLatencyOutOfRange(config.LatencyAbove).AtGoNode(cfgLit.Expr("LatencyAbove")))
+		}
+	case cfgLit.IsSet("QueueLagAbove"):
+		if config.QueueLagAbove <= 0 {
+			errs.Add(errQueueLagOutOfRange(config.QueueLagAbove).AtGoNode(cfgLit.Expr("QueueLagAbove")))
+		}
+	}
+	if cfgLit.IsSet("LatencyPercentile") && (config.LatencyPercentile <= 0 || config.LatencyPercentile >= 100) {
+		errs.Add(errPercentileOutOfRange(config.LatencyPercentile).AtGoNode(cfgLit.Expr("LatencyPercentile")))
+	}
+
+	isQueueLag := cfgLit.IsSet("QueueLagAbove")
+	if config.Endpoint != nil {
+		if isQueueLag {
+			errs.Add(errEndpointNotAllowed.AtGoNode(config.Endpoint))
+		} else if qn, ok := d.File.Names().ResolvePkgLevelRef(config.Endpoint); ok {
+			rule.Endpoint = option.Some(qn)
+			rule.EndpointAST = config.Endpoint
+		} else {
+			errs.Add(errUnableToResolveReference.AtGoNode(config.Endpoint))
+		}
+	}
+	if config.Subscription != nil {
+		if !isQueueLag {
+			errs.Add(errSubscriptionNotAllowed.AtGoNode(config.Subscription))
+		} else if qn, ok := d.File.Names().ResolvePkgLevelRef(config.Subscription); ok {
+			rule.Subscription = option.Some(qn)
+			rule.SubscriptionAST = config.Subscription
+		} else {
+			errs.Add(errUnableToResolveReference.AtGoNode(config.Subscription))
+		}
+	} else if isQueueLag {
+		errs.Add(errSubscriptionRequired.AtGoNode(cfgLit.Lit()))
+	}
+
+	if config.Window < time.Minute || config.Window > 24*time.Hour {
+		errs.Add(errWindowOutOfRange(config.Window).AtGoNode(cfgLit.Expr("Window")))
+	}
+	if config.For < 0 || config.For > 24*time.Hour {
+		errs.Add(errForOutOfRange(config.For).AtGoNode(cfgLit.Expr("For")))
+	}
+	if config.Severity != "warning" && config.Severity != "critical" {
+		errs.Add(errInvalidSeverity(config.Severity).AtGoNode(cfgLit.Expr("Severity")))
+	}
+
+	d.Pass.RegisterResource(rule)
+	d.Pass.AddBind(d.File, d.Ident, rule)
+}
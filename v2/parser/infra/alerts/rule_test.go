@@ -0,0 +1,125 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"encr.dev/pkg/option"
+	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []resourcetest.Case[*Rule]{
+		{
+			Name: "error_rate",
+			Code: `
+// Rule docs
+var x = alerts.NewRule("pay-errors", alerts.RuleConfig{
+	Title:          "Payments are failing",
+	Endpoint:       Pay,
+	ErrorRateAbove: 0.05,
+	Severity:       alerts.Critical,
+})
+
+func Pay() {}
+`,
+			Want: &Rule{
+				Name:              "pay-errors",
+				Title:             "Payments are failing",
+				Doc:               "Rule docs\n",
+				Endpoint:          option.Some(pkginfo.Q("example.com", "Pay")),
+				ErrorRateAbove:    0.05,
+				LatencyPercentile: 99,
+				Window:            5 * time.Minute,
+				For:               5 * time.Minute,
+				Severity:          "critical",
+			},
+		},
+		{
+			Name:    "latency",
+			Imports: []string{"time"},
+			Code: `
+var _ = alerts.NewRule("slow", alerts.RuleConfig{
+	LatencyAbove:      500 * time.Millisecond,
+	LatencyPercentile: 95,
+	Window:            10 * time.Minute,
+	For:               0,
+})
+`,
+			Want: &Rule{
+				Name:              "slow",
+				Title:             "slow",
+				LatencyAbove:      500 * time.Millisecond,
+				LatencyPercentile: 95,
+				Window:            10 * time.Minute,
+				Severity:          "warning",
+			},
+		},
+		{
+			Name:    "queue_lag",
+			Imports: []string{"time"},
+			Code: `
+var _ = alerts.NewRule("lagging", alerts.RuleConfig{
+	Subscription:  Sub,
+	QueueLagAbove: time.Minute,
+})
+
+var Sub = 1
+`,
+			Want: &Rule{
+				Name:              "lagging",
+				Title:             "lagging",
+				Subscription:      option.Some(pkginfo.Q("example.com", "Sub")),
+				QueueLagAbove:     time.Minute,
+				LatencyPercentile: 99,
+				Window:            5 * time.Minute,
+				For:               5 * time.Minute,
+				Severity:          "warning",
+			},
+		},
+		{
+			Name:    "no_condition",
+			Imports: []string{"time"},
+			Code: `
+var _ = alerts.NewRule("none", alerts.RuleConfig{
+	Window: time.Minute,
+})
+`,
+			WantErrs: []string{`.*Exactly one of ErrorRateAbove, LatencyAbove and QueueLagAbove must be set.*`},
+		},
+		{
+			Name:    "invalid",
+			Imports: []string{"time"},
+			Code: `
+var _ = alerts.NewRule("invalid", alerts.RuleConfig{
+	Endpoint:      Pay,
+	QueueLagAbove: time.Minute,
+	Window:        time.Second,
+	Severity:      "page",
+})
+
+func Pay() {}
+`,
+			WantErrs: []string{
+				`.*Endpoint can only be set for error rate and latency rules.*`,
+				`.*Queue lag rules must specify the Subscription they apply to.*`,
+				`.*Window must be between 1 minute and 24 hours, got 1s.*`,
+				`.*Severity must be alerts.Warning or alerts.Critical, got "page".*`,
+			},
+		},
+		{
+			Name: "error_rate_out_of_range",
+			Code: `
+var _ = alerts.NewRule("errors", alerts.RuleConfig{
+	ErrorRateAbove: 5,
+})
+`,
+			WantErrs: []string{`.*ErrorRateAbove must be greater than 0 and less than 1, got 5.*`},
+		},
+	}
+
+	resourcetest.Run(t, RuleParser, tests, cmp.AllowUnexported(option.Option[pkginfo.QualifiedName]{}))
+}
@@ -18,6 +18,7 @@ type Cluster struct {
 	Name           string // The unique name of the cache cluster
 	Doc            string // The documentation on the cluster
 	EvictionPolicy string
+	Tags           map[string]string // The tags to apply to the provisioned cluster
 	File           *pkginfo.File
 }
 
@@ -73,8 +74,9 @@ func parseCluster(d parseutil.ReferenceInfo) {
 
 	// Decode the config
 	type decodedConfig struct {
-		EvictionPolicy string   `literal:",optional,default"`
-		DefaultExpiry  ast.Expr `literal:",optional,dynamic"`
+		EvictionPolicy string            `literal:",optional,default"`
+		DefaultExpiry  ast.Expr          `literal:",optional,dynamic"`
+		Tags           map[string]string `literal:",optional"`
 	}
 	defaultValues := decodedConfig{
 		EvictionPolicy: string(cache.AllKeysLRU),
@@ -95,6 +97,7 @@ func parseCluster(d parseutil.ReferenceInfo) {
 		Name:           clusterName,
 		Doc:            d.Doc,
 		EvictionPolicy: config.EvictionPolicy,
+		Tags:           parseutil.ValidateTags(d.Pass.Errs, cfgLit.Expr("Tags"), config.Tags),
 		File:           d.File,
 	}
 
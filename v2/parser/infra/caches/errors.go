@@ -9,7 +9,7 @@ var (
 		"cache",
 		`For more information see https://encore.dev/docs/primitives/caching`,
 
-		errors.WithRangeSize(25),
+		errors.WithRangeSize(30),
 	)
 
 	errExpectsTwoArgs = errRange.Newf("Invalid cache construction", "%s expects two arguments, got %d.)")
@@ -74,6 +74,76 @@ var (
 		"Must be one of the constants defined in the cache package.",
 	)
 
+	errLocalCacheNotSupported = errRange.Newf(
+		"Invalid Cache Local Cache Configuration",
+		"LocalCache is not supported by %s; it's only supported by string, int, float and struct keyspaces.",
+	)
+
+	errLocalCacheMaxEntriesNegative = errRange.New(
+		"Invalid Cache Local Cache Configuration",
+		"LocalCache.MaxEntries must not be negative.",
+	)
+
+	errLocalCacheTTLNegative = errRange.New(
+		"Invalid Cache Local Cache Configuration",
+		"LocalCache.TTL must not be negative.",
+	)
+
+	errLocalCacheMaxBytesNegative = errRange.New(
+		"Invalid Cache Local Cache Configuration",
+		"LocalCache.MaxBytes must not be negative.",
+	)
+
+	errCompressionNotSupported = errRange.Newf(
+		"Invalid Cache Compression Configuration",
+		"Compression is not supported by %s; it's only supported by struct keyspaces.",
+	)
+
+	errCompressionMinSizeNegative = errRange.New(
+		"Invalid Cache Compression Configuration",
+		"Compression.MinSize must not be negative.",
+	)
+
+	errValueSizeLimitNotSupported = errRange.Newf(
+		"Invalid Cache Value Size Limit Configuration",
+		"ValueSizeLimit is not supported by %s; it's only supported by string and struct keyspaces.",
+	)
+
+	errValueSizeLimitMaxSizeNotPositive = errRange.New(
+		"Invalid Cache Value Size Limit Configuration",
+		"ValueSizeLimit.MaxSize must be positive.",
+	)
+
+	errValueSizeLimitTruncateNotSupported = errRange.Newf(
+		"Invalid Cache Value Size Limit Configuration",
+		"ValueSizeLimit.Truncate is not supported by %s; it's only supported by string keyspaces.",
+	)
+
+	errNegativeCacheNotSupported = errRange.Newf(
+		"Invalid Cache Negative Cache Configuration",
+		"NegativeCache is not supported by %s; it's only supported by string, int, float and struct keyspaces.",
+	)
+
+	errNegativeCacheTTLNegative = errRange.New(
+		"Invalid Cache Negative Cache Configuration",
+		"NegativeCache.TTL must not be negative.",
+	)
+
+	errStaleWhileRevalidateNotSupported = errRange.Newf(
+		"Invalid Cache Stale-While-Revalidate Configuration",
+		"StaleWhileRevalidate is not supported by %s; it's only supported by string, int, float and struct keyspaces.",
+	)
+
+	errStaleWhileRevalidateMaxStaleNotPositive = errRange.New(
+		"Invalid Cache Stale-While-Revalidate Configuration",
+		"StaleWhileRevalidate.MaxStale must be positive.",
+	)
+
+	errStaleWhileRevalidateWithoutExpiry = errRange.New(
+		"Invalid Cache Stale-While-Revalidate Configuration",
+		"StaleWhileRevalidate requires DefaultExpiry to be set, since values that never expire never go stale.",
+	)
+
 	ErrDuplicateCacheCluster = errRange.New(
 		"Duplicate Cache Cluster",
 		"Cache clusters must have unique names.",
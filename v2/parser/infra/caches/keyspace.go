@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/token"
 	"strings"
+	"time"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/paths"
@@ -24,9 +25,10 @@ type Keyspace struct {
 	File    *pkginfo.File // File the keyspace is declared in.
 	Cluster pkginfo.QualifiedName
 
-	KeyType   schema.Type
-	ValueType schema.Type
-	Path      *resourcepaths.Path
+	KeyType      schema.Type
+	ValueType    schema.Type
+	Path         *resourcepaths.Path
+	KeyspaceKind KeyspaceKind // The kind of keyspace, based on its constructor.
 
 	// The struct literal for the config. Used to inject additional configuration
 	// at compile-time.
@@ -99,20 +101,55 @@ const (
 	structValue
 )
 
+// KeyspaceKind describes the kind of a cache keyspace.
+type KeyspaceKind int
+
+const (
+	StringKeyspace KeyspaceKind = iota + 1
+	IntKeyspace
+	FloatKeyspace
+	ListKeyspace
+	SetKeyspace
+	SortedSetKeyspace
+	StructKeyspace
+	LockKeyspace
+	RateLimiterKeyspace
+)
+
 // cacheKeyspaceConstructor describes a particular cache keyspace constructor.
 type cacheKeyspaceConstructor struct {
 	FuncName          string
+	KeyspaceKind      KeyspaceKind
 	ValueKind         valueKind
 	ImplicitValueType schema.Type
+
+	// LocalCache reports whether the keyspace supports
+	// the LocalCache configuration option.
+	LocalCache bool
+
+	// Compression reports whether the keyspace supports
+	// the Compression configuration option.
+	Compression bool
+
+	// ValueSizeLimit reports whether the keyspace supports
+	// the ValueSizeLimit configuration option.
+	ValueSizeLimit bool
+
+	// GetOrLoad reports whether the keyspace supports GetOrLoad, and with it
+	// the NegativeCache and StaleWhileRevalidate configuration options.
+	GetOrLoad bool
 }
 
 var keyspaceConstructors = []cacheKeyspaceConstructor{
-	{"NewStringKeyspace", implicitValue, schema.BuiltinType{Kind: schema.String}},
-	{"NewIntKeyspace", implicitValue, schema.BuiltinType{Kind: schema.Int64}},
-	{"NewFloatKeyspace", implicitValue, schema.BuiltinType{Kind: schema.Float64}},
-	{"NewListKeyspace", basicValue, nil},
-	{"NewSetKeyspace", basicValue, nil},
-	{"NewStructKeyspace", structValue, nil},
+	{"NewStringKeyspace", StringKeyspace, implicitValue, schema.BuiltinType{Kind: schema.String}, true, false, true, true},
+	{"NewIntKeyspace", IntKeyspace, implicitValue, schema.BuiltinType{Kind: schema.Int64}, true, false, false, true},
+	{"NewFloatKeyspace", FloatKeyspace, implicitValue, schema.BuiltinType{Kind: schema.Float64}, true, false, false, true},
+	{"NewListKeyspace", ListKeyspace, basicValue, nil, false, false, false, false},
+	{"NewSetKeyspace", SetKeyspace, basicValue, nil, false, false, false, false},
+	{"NewSortedSetKeyspace", SortedSetKeyspace, basicValue, nil, false, false, false, false},
+	{"NewStructKeyspace", StructKeyspace, structValue, nil, true, true, true, true},
+	{"NewLock", LockKeyspace, implicitValue, schema.BuiltinType{Kind: schema.String}, false, false, false, false},
+	{"NewRateLimiter", RateLimiterKeyspace, implicitValue, schema.BuiltinType{Kind: schema.Int64}, false, false, false, false},
 }
 
 func parseKeyspace(c cacheKeyspaceConstructor, d parseutil.ReferenceInfo) {
@@ -138,12 +175,92 @@ func parseKeyspace(c cacheKeyspaceConstructor, d parseutil.ReferenceInfo) {
 	patternNode := cfgLit.Expr("KeyPattern")
 
 	// Decode the config
+	type localCacheConfig struct {
+		MaxEntries int           `literal:",optional"`
+		MaxBytes   int           `literal:",optional"`
+		TTL        time.Duration `literal:",optional"`
+	}
+	type compressionConfig struct {
+		MinSize int `literal:",optional"`
+	}
+	type valueSizeLimitConfig struct {
+		MaxSize  int  `literal:",optional"`
+		Truncate bool `literal:",optional"`
+	}
+	type negativeCacheConfig struct {
+		TTL time.Duration `literal:",optional"`
+	}
+	type staleWhileRevalidateConfig struct {
+		MaxStale time.Duration `literal:",optional"`
+	}
 	type decodedConfig struct {
-		KeyPattern    string   `literal:",required"`
-		DefaultExpiry ast.Expr `literal:",optional,dynamic"`
+		KeyPattern           string                     `literal:",required"`
+		DefaultExpiry        ast.Expr                   `literal:",optional,dynamic"`
+		LocalCache           localCacheConfig           `literal:",optional"`
+		Compression          compressionConfig          `literal:",optional"`
+		ValueSizeLimit       valueSizeLimitConfig       `literal:",optional"`
+		NegativeCache        negativeCacheConfig        `literal:",optional"`
+		StaleWhileRevalidate staleWhileRevalidateConfig `literal:",optional"`
 	}
 	config := literals.Decode[decodedConfig](errs, cfgLit, nil)
 
+	if cfgLit.IsSet("LocalCache") {
+		if !c.LocalCache {
+			errs.Add(errLocalCacheNotSupported(constructorName).AtGoNode(cfgLit.Expr("LocalCache")))
+		}
+		if config.LocalCache.MaxEntries < 0 {
+			errs.Add(errLocalCacheMaxEntriesNegative.AtGoNode(cfgLit.Expr("LocalCache.MaxEntries")))
+		}
+		if config.LocalCache.TTL < 0 {
+			errs.Add(errLocalCacheTTLNegative.AtGoNode(cfgLit.Expr("LocalCache.TTL")))
+		}
+		if config.LocalCache.MaxBytes < 0 {
+			errs.Add(errLocalCacheMaxBytesNegative.AtGoNode(cfgLit.Expr("LocalCache.MaxBytes")))
+		}
+	}
+
+	if cfgLit.IsSet("Compression") {
+		if !c.Compression {
+			errs.Add(errCompressionNotSupported(constructorName).AtGoNode(cfgLit.Expr("Compression")))
+		}
+		if config.Compression.MinSize < 0 {
+			errs.Add(errCompressionMinSizeNegative.AtGoNode(cfgLit.Expr("Compression.MinSize")))
+		}
+	}
+
+	if cfgLit.IsSet("ValueSizeLimit") {
+		if !c.ValueSizeLimit {
+			errs.Add(errValueSizeLimitNotSupported(constructorName).AtGoNode(cfgLit.Expr("ValueSizeLimit")))
+		}
+		if config.ValueSizeLimit.MaxSize <= 0 {
+			errs.Add(errValueSizeLimitMaxSizeNotPositive.AtGoNode(cfgLit.Expr("ValueSizeLimit.MaxSize")))
+		}
+		if config.ValueSizeLimit.Truncate && c.KeyspaceKind != StringKeyspace {
+			errs.Add(errValueSizeLimitTruncateNotSupported(constructorName).AtGoNode(cfgLit.Expr("ValueSizeLimit.Truncate")))
+		}
+	}
+
+	if cfgLit.IsSet("NegativeCache") {
+		if !c.GetOrLoad {
+			errs.Add(errNegativeCacheNotSupported(constructorName).AtGoNode(cfgLit.Expr("NegativeCache")))
+		}
+		if config.NegativeCache.TTL < 0 {
+			errs.Add(errNegativeCacheTTLNegative.AtGoNode(cfgLit.Expr("NegativeCache.TTL")))
+		}
+	}
+
+	if cfgLit.IsSet("StaleWhileRevalidate") {
+		if !c.GetOrLoad {
+			errs.Add(errStaleWhileRevalidateNotSupported(constructorName).AtGoNode(cfgLit.Expr("StaleWhileRevalidate")))
+		}
+		if config.StaleWhileRevalidate.MaxStale <= 0 {
+			errs.Add(errStaleWhileRevalidateMaxStaleNotPositive.AtGoNode(cfgLit.Expr("StaleWhileRevalidate.MaxStale")))
+		}
+		if !cfgLit.IsSet("DefaultExpiry") {
+			errs.Add(errStaleWhileRevalidateWithoutExpiry.AtGoNode(cfgLit.Expr("StaleWhileRevalidate")))
+		}
+	}
+
 	const reservedPrefix = "__encore"
 	if strings.HasPrefix(config.KeyPattern, reservedPrefix) {
 		errs.Add(errPrefixReserved.AtGoNode(patternNode))
@@ -273,6 +390,7 @@ func parseKeyspace(c cacheKeyspaceConstructor, d parseutil.ReferenceInfo) {
 		Path:          path,
 		KeyType:       keyType,
 		ValueType:     valueType,
+		KeyspaceKind:  c.KeyspaceKind,
 	}
 
 	d.Pass.RegisterResource(ks)
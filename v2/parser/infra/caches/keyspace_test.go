@@ -32,6 +32,7 @@ var x = cache.NewStringKeyspace[string](cluster, cache.KeyspaceConfig{
 						{Type: resourcepaths.Param, Value: "key", ValueType: schema.String},
 					},
 				},
+				KeyspaceKind: StringKeyspace,
 			},
 		},
 		{
@@ -52,6 +53,7 @@ var x = cache.NewIntKeyspace[int64](cluster, cache.KeyspaceConfig{
 						{Type: resourcepaths.Literal, Value: "int", ValueType: schema.String},
 					},
 				},
+				KeyspaceKind: IntKeyspace,
 			},
 		}, {
 			Name: "float",
@@ -71,6 +73,7 @@ var x = cache.NewFloatKeyspace[string](cluster, cache.KeyspaceConfig{
 						{Type: resourcepaths.Literal, Value: "float", ValueType: schema.String},
 					},
 				},
+				KeyspaceKind: FloatKeyspace,
 			},
 		},
 		{
@@ -91,6 +94,7 @@ var x = cache.NewListKeyspace[string, bool](cluster, cache.KeyspaceConfig{
 						{Type: resourcepaths.Literal, Value: "list", ValueType: schema.String},
 					},
 				},
+				KeyspaceKind: ListKeyspace,
 			},
 		},
 		{
@@ -111,6 +115,28 @@ var x = cache.NewSetKeyspace[string, bool](cluster, cache.KeyspaceConfig{
 						{Type: resourcepaths.Literal, Value: "set", ValueType: schema.String},
 					},
 				},
+				KeyspaceKind: SetKeyspace,
+			},
+		},
+		{
+			Name: "sorted_set",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewSortedSetKeyspace[string, int64](cluster, cache.KeyspaceConfig{
+	KeyPattern: "sorted-set",
+})
+`,
+			Want: &Keyspace{
+				KeyType:   schematest.String(),
+				ValueType: schematest.Builtin(schema.Int64),
+				Cluster:   pkginfo.Q("example.com", "cluster"),
+				Path: &resourcepaths.Path{
+					Segments: []resourcepaths.Segment{
+						{Type: resourcepaths.Literal, Value: "sorted-set", ValueType: schema.String},
+					},
+				},
+				KeyspaceKind: SortedSetKeyspace,
 			},
 		},
 		{
@@ -135,8 +161,247 @@ var x = cache.NewStructKeyspace[string, Foo](cluster, cache.KeyspaceConfig{
 						{Type: resourcepaths.Literal, Value: "struct", ValueType: schema.String},
 					},
 				},
+				KeyspaceKind: StructKeyspace,
 			},
 		},
+		{
+			Name: "lock",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewLock[string](cluster, cache.KeyspaceConfig{
+	KeyPattern: "lock/:key",
+})
+`,
+			Want: &Keyspace{
+				KeyType:   schematest.String(),
+				ValueType: schematest.String(),
+				Cluster:   pkginfo.Q("example.com", "cluster"),
+				Path: &resourcepaths.Path{
+					Segments: []resourcepaths.Segment{
+						{Type: resourcepaths.Literal, Value: "lock", ValueType: schema.String},
+						{Type: resourcepaths.Param, Value: "key", ValueType: schema.String},
+					},
+				},
+				KeyspaceKind: LockKeyspace,
+			},
+		},
+		{
+			Name: "rate_limiter",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewRateLimiter[int64](cluster, cache.KeyspaceConfig{
+	KeyPattern: "rate-limit/:key",
+})
+`,
+			Want: &Keyspace{
+				KeyType:   schematest.Builtin(schema.Int64),
+				ValueType: schematest.Builtin(schema.Int64),
+				Cluster:   pkginfo.Q("example.com", "cluster"),
+				Path: &resourcepaths.Path{
+					Segments: []resourcepaths.Segment{
+						{Type: resourcepaths.Literal, Value: "rate-limit", ValueType: schema.String},
+						{Type: resourcepaths.Param, Value: "key", ValueType: schema.String},
+					},
+				},
+				KeyspaceKind: RateLimiterKeyspace,
+			},
+		},
+		{
+			Name: "local_cache",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewStringKeyspace[string](cluster, cache.KeyspaceConfig{
+	KeyPattern: "local",
+	LocalCache: &cache.LocalCacheConfig{
+		MaxEntries: 100,
+		MaxBytes:   1 << 20,
+		TTL:        5 * time.Second,
+	},
+})
+`,
+			Imports: []string{"time"},
+			Want: &Keyspace{
+				KeyType:   schematest.String(),
+				ValueType: schematest.String(),
+				Cluster:   pkginfo.Q("example.com", "cluster"),
+				Path: &resourcepaths.Path{
+					Segments: []resourcepaths.Segment{
+						{Type: resourcepaths.Literal, Value: "local", ValueType: schema.String},
+					},
+				},
+				KeyspaceKind: StringKeyspace,
+			},
+		},
+		{
+			Name: "local_cache_negative_ttl",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewStringKeyspace[string](cluster, cache.KeyspaceConfig{
+	KeyPattern: "local",
+	LocalCache: &cache.LocalCacheConfig{TTL: -1},
+})
+`,
+			WantErrs: []string{`.*LocalCache.TTL must not be negative.*`},
+		},
+		{
+			Name: "local_cache_negative_max_bytes",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewStringKeyspace[string](cluster, cache.KeyspaceConfig{
+	KeyPattern: "local",
+	LocalCache: &cache.LocalCacheConfig{MaxBytes: -1},
+})
+`,
+			WantErrs: []string{`.*LocalCache.MaxBytes must not be negative.*`},
+		},
+		{
+			Name: "local_cache_list",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewListKeyspace[string, string](cluster, cache.KeyspaceConfig{
+	KeyPattern: "list",
+	LocalCache: &cache.LocalCacheConfig{},
+})
+`,
+			WantErrs: []string{`.*LocalCache is not supported by cache.NewListKeyspace.*`},
+		},
+		{
+			Name: "value_options",
+			Code: `
+type Foo struct {
+	A int
+}
+
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewStructKeyspace[string, Foo](cluster, cache.KeyspaceConfig{
+	KeyPattern:     "values",
+	Compression:    &cache.CompressionConfig{MinSize: 512},
+	ValueSizeLimit: &cache.ValueSizeLimitConfig{MaxSize: 1 << 20},
+})
+`,
+			Want: &Keyspace{
+				KeyType:   schematest.String(),
+				ValueType: schematest.Named(schematest.TypeInfo("Foo")),
+				Cluster:   pkginfo.Q("example.com", "cluster"),
+				Path: &resourcepaths.Path{
+					Segments: []resourcepaths.Segment{
+						{Type: resourcepaths.Literal, Value: "values", ValueType: schema.String},
+					},
+				},
+				KeyspaceKind: StructKeyspace,
+			},
+		},
+		{
+			Name: "compression_string",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewStringKeyspace[string](cluster, cache.KeyspaceConfig{
+	KeyPattern:  "values",
+	Compression: &cache.CompressionConfig{},
+})
+`,
+			WantErrs: []string{`.*Compression is not supported by cache.NewStringKeyspace.*`},
+		},
+		{
+			Name: "value_size_limit_missing_max_size",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewStringKeyspace[string](cluster, cache.KeyspaceConfig{
+	KeyPattern:     "values",
+	ValueSizeLimit: &cache.ValueSizeLimitConfig{Truncate: true},
+})
+`,
+			WantErrs: []string{`.*ValueSizeLimit.MaxSize must be positive.*`},
+		},
+		{
+			Name: "value_size_limit_truncate_struct",
+			Code: `
+type Foo struct {
+	A int
+}
+
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewStructKeyspace[string, Foo](cluster, cache.KeyspaceConfig{
+	KeyPattern:     "values",
+	ValueSizeLimit: &cache.ValueSizeLimitConfig{MaxSize: 100, Truncate: true},
+})
+`,
+			WantErrs: []string{`.*ValueSizeLimit.Truncate is not supported by cache.NewStructKeyspace.*`},
+		},
+		{
+			Name: "get_or_load_options",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewIntKeyspace[string](cluster, cache.KeyspaceConfig{
+	KeyPattern:           "counts",
+	DefaultExpiry:        cache.ExpireIn(time.Minute),
+	NegativeCache:        &cache.NegativeCacheConfig{TTL: 5 * time.Second},
+	StaleWhileRevalidate: &cache.StaleWhileRevalidateConfig{MaxStale: time.Hour},
+})
+`,
+			Imports: []string{"time"},
+			Want: &Keyspace{
+				KeyType:   schematest.String(),
+				ValueType: schematest.Builtin(schema.Int64),
+				Cluster:   pkginfo.Q("example.com", "cluster"),
+				Path: &resourcepaths.Path{
+					Segments: []resourcepaths.Segment{
+						{Type: resourcepaths.Literal, Value: "counts", ValueType: schema.String},
+					},
+				},
+				KeyspaceKind: IntKeyspace,
+			},
+		},
+		{
+			Name: "negative_cache_list",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewListKeyspace[string, string](cluster, cache.KeyspaceConfig{
+	KeyPattern:    "values",
+	NegativeCache: &cache.NegativeCacheConfig{},
+})
+`,
+			WantErrs: []string{`.*NegativeCache is not supported by cache.NewListKeyspace.*`},
+		},
+		{
+			Name: "stale_while_revalidate_missing_max_stale",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewStringKeyspace[string](cluster, cache.KeyspaceConfig{
+	KeyPattern:           "values",
+	DefaultExpiry:        cache.ExpireIn(time.Minute),
+	StaleWhileRevalidate: &cache.StaleWhileRevalidateConfig{},
+})
+`,
+			Imports:  []string{"time"},
+			WantErrs: []string{`.*StaleWhileRevalidate.MaxStale must be positive.*`},
+		},
+		{
+			Name: "stale_while_revalidate_without_expiry",
+			Code: `
+var cluster = cache.NewCluster("cluster", cache.ClusterConfig{})
+
+var x = cache.NewStringKeyspace[string](cluster, cache.KeyspaceConfig{
+	KeyPattern:           "values",
+	StaleWhileRevalidate: &cache.StaleWhileRevalidateConfig{MaxStale: time.Hour},
+})
+`,
+			Imports:  []string{"time"},
+			WantErrs: []string{`.*StaleWhileRevalidate requires DefaultExpiry to be set.*`},
+		},
 	}
 
 	resourcetest.Run(t, KeyspaceParser, tests)
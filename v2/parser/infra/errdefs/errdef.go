@@ -0,0 +1,123 @@
+package errdefs
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/pkginfo"
+	literals "encr.dev/v2/parser/infra/internal/literals"
+	parseutil "encr.dev/v2/parser/infra/internal/parseutil"
+	"encr.dev/v2/parser/resource"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+// Definition is a reusable error, declared with errs.Define.
+type Definition struct {
+	AST     *ast.CallExpr
+	File    *pkginfo.File
+	ID      string // The unique id of the error, such as "user.not_found"
+	Code    string // The error code, such as "not_found"
+	Message string // The default error message
+	Doc     string // The documentation on the error
+}
+
+func (d *Definition) Kind() resource.Kind       { return resource.ErrorDefinition }
+func (d *Definition) Package() *pkginfo.Package { return d.File.Pkg }
+func (d *Definition) ASTExpr() ast.Expr         { return d.AST }
+func (d *Definition) ResourceName() string      { return d.ID }
+func (d *Definition) Pos() token.Pos            { return d.AST.Pos() }
+func (d *Definition) End() token.Pos            { return d.AST.End() }
+func (d *Definition) SortKey() string           { return d.ID }
+
+var DefinitionParser = &resourceparser.Parser{
+	Name: "Error Definition",
+
+	InterestingImports: []paths.Pkg{"encore.dev/beta/errs"},
+	Run: func(p *resourceparser.Pass) {
+		name := pkginfo.QualifiedName{PkgPath: "encore.dev/beta/errs", Name: "Define"}
+
+		spec := &parseutil.ReferenceSpec{
+			MinTypeArgs: 0,
+			MaxTypeArgs: 0,
+			Parse:       parseDefinition,
+		}
+
+		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+			parseutil.ParseReference(p, spec, parseutil.ReferenceData{
+				File:         file,
+				Stack:        stack,
+				ResourceFunc: name,
+			})
+		})
+	},
+}
+
+// idRegexp matches valid error ids, such as "user.not_found".
+var idRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+
+// codeNames are the string representations of the error codes
+// an error definition can use, keyed by their name in the errs package.
+var codeNames = map[string]string{
+	"Canceled":           "canceled",
+	"Unknown":            "unknown",
+	"InvalidArgument":    "invalid_argument",
+	"DeadlineExceeded":   "deadline_exceeded",
+	"NotFound":           "not_found",
+	"AlreadyExists":      "already_exists",
+	"PermissionDenied":   "permission_denied",
+	"ResourceExhausted":  "resource_exhausted",
+	"FailedPrecondition": "failed_precondition",
+	"Aborted":            "aborted",
+	"OutOfRange":         "out_of_range",
+	"Unimplemented":      "unimplemented",
+	"Internal":           "internal",
+	"Unavailable":        "unavailable",
+	"DataLoss":           "data_loss",
+	"Unauthenticated":    "unauthenticated",
+}
+
+func parseDefinition(d parseutil.ReferenceInfo) {
+	errs := d.Pass.Errs
+	if len(d.Call.Args) != 3 {
+		errs.Add(errExpects3Arguments(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	}
+
+	id, ok := literals.ParseString(d.Call.Args[0])
+	if !ok {
+		errs.Add(errIDMustBeStringLiteral.AtGoNode(d.Call.Args[0]))
+		return
+	} else if !idRegexp.MatchString(id) {
+		errs.Add(errInvalidID(id).AtGoNode(d.Call.Args[0]))
+		return
+	}
+
+	var code string
+	if qn, ok := d.File.Names().ResolvePkgLevelRef(d.Call.Args[1]); ok && qn.PkgPath == "encore.dev/beta/errs" {
+		code = codeNames[qn.Name]
+	}
+	if code == "" {
+		errs.Add(errInvalidCode.AtGoNode(d.Call.Args[1]))
+		return
+	}
+
+	msg, ok := literals.ParseString(d.Call.Args[2])
+	if !ok {
+		errs.Add(errMessageMustBeStringLiteral.AtGoNode(d.Call.Args[2]))
+		return
+	}
+
+	def := &Definition{
+		AST:     d.Call,
+		File:    d.File,
+		ID:      id,
+		Code:    code,
+		Message: msg,
+		Doc:     d.Doc,
+	}
+
+	d.Pass.RegisterResource(def)
+	d.Pass.AddBind(d.File, d.Ident, def)
+}
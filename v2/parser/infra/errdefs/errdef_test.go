@@ -0,0 +1,67 @@
+package errdefs
+
+import (
+	"testing"
+
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseDefinition(t *testing.T) {
+	tests := []resourcetest.Case[*Definition]{
+		{
+			Name: "basic",
+			Code: `
+// Error docs
+var x = errs.Define("user.not_found", errs.NotFound, "user not found")
+`,
+			Want: &Definition{
+				ID:      "user.not_found",
+				Code:    "not_found",
+				Message: "user not found",
+				Doc:     "Error docs\n",
+			},
+		},
+		{
+			Name: "single_segment",
+			Code: `
+var _ = errs.Define("rate_limited", errs.ResourceExhausted, "too many requests")
+`,
+			Want: &Definition{
+				ID:      "rate_limited",
+				Code:    "resource_exhausted",
+				Message: "too many requests",
+			},
+		},
+		{
+			Name: "invalid_id",
+			Code: `
+var _ = errs.Define("User.NotFound", errs.NotFound, "user not found")
+`,
+			WantErrs: []string{`.*The error id "User.NotFound" is invalid.*`},
+		},
+		{
+			Name: "invalid_code",
+			Code: `
+var _ = errs.Define("user.not_found", errs.ErrCode(5), "user not found")
+`,
+			WantErrs: []string{`.*The error code must be one of the error codes in the errs package.*`},
+		},
+		{
+			Name: "ok_code",
+			Code: `
+var _ = errs.Define("user.ok", errs.OK, "user ok")
+`,
+			WantErrs: []string{`.*The error code must be one of the error codes in the errs package.*`},
+		},
+		{
+			Name: "non_literal_message",
+			Code: `
+var msg = "user not found"
+var _ = errs.Define("user.not_found", errs.NotFound, msg)
+`,
+			WantErrs: []string{`.*The error message must be a string literal.*`},
+		},
+	}
+
+	resourcetest.Run(t, DefinitionParser, tests)
+}
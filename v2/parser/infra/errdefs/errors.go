@@ -0,0 +1,44 @@
+package errdefs
+
+import (
+	"encr.dev/pkg/errors"
+)
+
+var (
+	errRange = errors.Range(
+		"errdefs",
+		"For more information, see https://encore.dev/docs/primitives/api-errors",
+
+		errors.WithRangeSize(20),
+	)
+
+	errExpects3Arguments = errRange.Newf(
+		"Invalid call to errs.Define",
+		"Expected 3 arguments, got %d",
+	)
+
+	errIDMustBeStringLiteral = errRange.New(
+		"Invalid call to errs.Define",
+		"The error id must be a string literal.",
+	)
+
+	errInvalidID = errRange.Newf(
+		"Invalid call to errs.Define",
+		"The error id %q is invalid. Error ids must consist of dot-separated segments in \"snake_case\", such as \"user.not_found\".",
+	)
+
+	errInvalidCode = errRange.New(
+		"Invalid call to errs.Define",
+		"The error code must be one of the error codes in the errs package, such as errs.NotFound.",
+	)
+
+	errMessageMustBeStringLiteral = errRange.New(
+		"Invalid call to errs.Define",
+		"The error message must be a string literal.",
+	)
+
+	ErrDuplicateIDs = errRange.New(
+		"Duplicate error definitions",
+		"Multiple error definitions with the same id were found. Error ids must be unique.",
+	)
+)
@@ -0,0 +1,39 @@
+package flags
+
+import (
+	"encr.dev/pkg/errors"
+)
+
+var (
+	errRange = errors.Range(
+		"flags",
+		"For more information, see https://encore.dev/docs/primitives/feature-flags",
+
+		errors.WithRangeSize(20),
+	)
+
+	errExpects2Arguments = errRange.Newf(
+		"Invalid call to flags.New",
+		"Expected 2 arguments, got %d",
+	)
+
+	errRolloutOutOfRange = errRange.Newf(
+		"Invalid call to flags.New",
+		"Rollout must be a percentage between 0 and 100, got %d.",
+	)
+
+	errEmptyUserID = errRange.New(
+		"Invalid call to flags.New",
+		"UserIDs must not contain empty user IDs.",
+	)
+
+	errEmptyAttribute = errRange.New(
+		"Invalid call to flags.New",
+		"Attributes must not contain empty field names.",
+	)
+
+	ErrDuplicateNames = errRange.New(
+		"Duplicate feature flags",
+		"Multiple feature flags with the same name were found. Flag names must be unique.",
+	)
+)
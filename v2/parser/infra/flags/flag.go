@@ -0,0 +1,109 @@
+package flags
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/pkginfo"
+	literals "encr.dev/v2/parser/infra/internal/literals"
+	parseutil "encr.dev/v2/parser/infra/internal/parseutil"
+	"encr.dev/v2/parser/resource"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+// Flag is a feature flag, declared with flags.New.
+type Flag struct {
+	AST  *ast.CallExpr
+	File *pkginfo.File
+	Name string // The unique name of the flag
+	Doc  string // The documentation on the flag
+
+	Rollout    int               // The percentage of users the flag is enabled for
+	UserIDs    []string          // The users the flag is always enabled for
+	Attributes map[string]string // The auth data fields the flag is enabled for
+}
+
+func (f *Flag) Kind() resource.Kind       { return resource.FeatureFlag }
+func (f *Flag) Package() *pkginfo.Package { return f.File.Pkg }
+func (f *Flag) ASTExpr() ast.Expr         { return f.AST }
+func (f *Flag) ResourceName() string      { return f.Name }
+func (f *Flag) Pos() token.Pos            { return f.AST.Pos() }
+func (f *Flag) End() token.Pos            { return f.AST.End() }
+func (f *Flag) SortKey() string           { return f.Name }
+
+var FlagParser = &resourceparser.Parser{
+	Name: "Feature Flag",
+
+	InterestingImports: []paths.Pkg{"encore.dev/flags"},
+	Run: func(p *resourceparser.Pass) {
+		name := pkginfo.QualifiedName{PkgPath: "encore.dev/flags", Name: "New"}
+
+		spec := &parseutil.ReferenceSpec{
+			MinTypeArgs: 0,
+			MaxTypeArgs: 0,
+			Parse:       parseFlag,
+		}
+
+		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+			parseutil.ParseReference(p, spec, parseutil.ReferenceData{
+				File:         file,
+				Stack:        stack,
+				ResourceFunc: name,
+			})
+		})
+	},
+}
+
+func parseFlag(d parseutil.ReferenceInfo) {
+	errs := d.Pass.Errs
+	displayName := d.ResourceFunc.NaiveDisplayName()
+	if len(d.Call.Args) != 2 {
+		errs.Add(errExpects2Arguments(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	}
+
+	flagName := parseutil.ParseResourceName(errs, displayName, "flag name",
+		d.Call.Args[0], parseutil.KebabName, "")
+	if flagName == "" {
+		// we already reported the error inside ParseResourceName
+		return
+	}
+
+	cfgLit, ok := literals.ParseStruct(errs, d.File, "flags.Config", d.Call.Args[1])
+	if !ok {
+		return // error reported by ParseStruct
+	}
+
+	// Decode the config
+	type decodedConfig struct {
+		Rollout    int               `literal:",optional"`
+		UserIDs    []string          `literal:",optional"`
+		Attributes map[string]string `literal:",optional"`
+	}
+	config := literals.Decode[decodedConfig](errs, cfgLit, nil)
+
+	if config.Rollout < 0 || config.Rollout > 100 {
+		errs.Add(errRolloutOutOfRange(config.Rollout).AtGoNode(cfgLit.Expr("Rollout")))
+	}
+	if slices.Contains(config.UserIDs, "") {
+		errs.Add(errEmptyUserID.AtGoNode(cfgLit.Expr("UserIDs")))
+	}
+	if _, ok := config.Attributes[""]; ok {
+		errs.Add(errEmptyAttribute.AtGoNode(cfgLit.Expr("Attributes")))
+	}
+
+	flag := &Flag{
+		AST:        d.Call,
+		File:       d.File,
+		Name:       flagName,
+		Doc:        d.Doc,
+		Rollout:    config.Rollout,
+		UserIDs:    config.UserIDs,
+		Attributes: config.Attributes,
+	}
+
+	d.Pass.RegisterResource(flag)
+	d.Pass.AddBind(d.File, d.Ident, flag)
+}
@@ -0,0 +1,63 @@
+package flags
+
+import (
+	"testing"
+
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseFlag(t *testing.T) {
+	tests := []resourcetest.Case[*Flag]{
+		{
+			Name: "basic",
+			Code: `
+// Flag docs
+var x = flags.New("new-checkout", flags.Config{})
+`,
+			Want: &Flag{
+				Name: "new-checkout",
+				Doc:  "Flag docs\n",
+			},
+		},
+		{
+			Name: "targeting",
+			Code: `
+var _ = flags.New("beta-search", flags.Config{
+	Rollout:    25,
+	UserIDs:    []string{"alice", "bob"},
+	Attributes: map[string]string{"plan": "enterprise"},
+})
+`,
+			Want: &Flag{
+				Name:       "beta-search",
+				Rollout:    25,
+				UserIDs:    []string{"alice", "bob"},
+				Attributes: map[string]string{"plan": "enterprise"},
+			},
+		},
+		{
+			Name: "invalid",
+			Code: `
+var _ = flags.New("invalid", flags.Config{
+	Rollout:    150,
+	UserIDs:    []string{""},
+	Attributes: map[string]string{"": "x"},
+})
+`,
+			WantErrs: []string{
+				`.*Rollout must be a percentage between 0 and 100, got 150.*`,
+				`.*UserIDs must not contain empty user IDs.*`,
+				`.*Attributes must not contain empty field names.*`,
+			},
+		},
+		{
+			Name: "invalid_name",
+			Code: `
+var _ = flags.New("NewCheckout", flags.Config{})
+`,
+			WantErrs: []string{`.*flag name must be defined in "kebab-case".*`},
+		},
+	}
+
+	resourcetest.Run(t, FlagParser, tests)
+}
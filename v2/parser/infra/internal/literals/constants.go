@@ -15,10 +15,19 @@ var constants = map[paths.Pkg]map[string]any{
 		"AtLeastOnce":     1,
 		"ExactlyOnce":     2,
 	},
+	"encore.dev/alerts": {
+		"Warning":  "warning",
+		"Critical": "critical",
+	},
 	"encore.dev/cron": {
 		"Minute": 60,
 		"Hour":   60 * 60,
 	},
+	"encore.dev/metrics": {
+		"DefaultMaxLabelCombinations": 1000,
+		"DropOverflow":                "drop",
+		"AggregateOverflow":           "aggregate",
+	},
 	"encore.dev/storage/cache": {
 		"AllKeysLRU":     string(cache.AllKeysLRU),
 		"AllKeysLFU":     string(cache.AllKeysLFU),
@@ -1,9 +1,11 @@
 package literals
 
 import (
+	"fmt"
 	"go/ast"
 	"go/constant"
 	"reflect"
+	"strconv"
 
 	"github.com/fatih/structtag"
 
@@ -103,6 +105,15 @@ func decodeField(errs *perr.List, literal *Struct, fieldType reflect.StructField
 		}
 	}
 
+	// Decode inline child structs field by field, so they can contain dynamic fields.
+	if child, ok := literal.ChildStruct(fieldPath); ok && fieldType.Type.Kind() == reflect.Struct {
+		childPaths := decodeStruct(errs, child, field, defaultField)
+		for _, p := range childPaths {
+			fieldPaths = append(fieldPaths, fieldPath+"."+p)
+		}
+		return fieldPaths
+	}
+
 	// If the field is not dynamic and we don't allow dynamic fields, return an error.
 	isDynamic := !literal.IsConstant(fieldPath)
 	if isDynamic && !dynamicOK {
@@ -117,6 +128,15 @@ func decodeField(errs *perr.List, literal *Struct, fieldType reflect.StructField
 		return
 	}
 
+	switch fieldType.Type.Kind() {
+	case reflect.Map:
+		decodeMap(errs, literal, fieldPath, field)
+		return fieldPaths
+	case reflect.Slice:
+		decodeSlice(errs, literal, fieldPath, field)
+		return fieldPaths
+	}
+
 	val := literal.ConstantValue(fieldPath)
 	switch fieldType.Type.Kind() {
 	case reflect.String:
@@ -134,6 +154,14 @@ func decodeField(errs *perr.List, literal *Struct, fieldType reflect.StructField
 			errs.Add(errWrongDynamicType(fieldPath, "integer").AtGoNode(literal.Expr(fieldPath)))
 		}
 
+	case reflect.Float64:
+		if val.Kind() == constant.Int || val.Kind() == constant.Float {
+			f, _ := constant.Float64Val(constant.ToFloat(val))
+			field.SetFloat(f)
+		} else {
+			errs.Add(errWrongDynamicType(fieldPath, "number").AtGoNode(literal.Expr(fieldPath)))
+		}
+
 	case reflect.Bool:
 		if val.Kind() == constant.Bool {
 			field.SetBool(constant.BoolVal(val))
@@ -142,16 +170,8 @@ func decodeField(errs *perr.List, literal *Struct, fieldType reflect.StructField
 		}
 
 	case reflect.Struct:
-		child, ok := literal.ChildStruct(fieldPath)
-		if !ok {
-			errs.Add(errWrongDynamicType(fieldPath, "inline struct").AtGoNode(literal.Expr(fieldPath)))
-			return
-		}
-		childPaths := decodeStruct(errs, child, field, defaultField)
-		for _, p := range childPaths {
-			fieldPaths = append(fieldPaths, fieldPath+"."+p)
-		}
-		return fieldPaths
+		errs.Add(errWrongDynamicType(fieldPath, "inline struct").AtGoNode(literal.Expr(fieldPath)))
+		return
 
 	default:
 		errs.Assert(errUnsupportedType(fieldType.Type.Kind()).AtGoNode(literal.Expr(fieldPath)))
@@ -164,3 +184,51 @@ func decodeField(errs *perr.List, literal *Struct, fieldType reflect.StructField
 
 	return fieldPaths
 }
+
+// decodeMap decodes a constant map literal field.
+// Only maps with string keys and string values are supported.
+func decodeMap(errs *perr.List, literal *Struct, fieldPath string, field reflect.Value) {
+	if field.Type().Key().Kind() != reflect.String || field.Type().Elem().Kind() != reflect.String {
+		errs.Assert(errUnsupportedType(field.Type().Kind()).AtGoNode(literal.Expr(fieldPath)))
+	}
+
+	entries, ok := literal.ConstantMap(fieldPath)
+	if !ok {
+		errs.Add(errWrongDynamicType(fieldPath, "map").AtGoNode(literal.Expr(fieldPath)))
+		return
+	}
+
+	m := reflect.MakeMapWithSize(field.Type(), len(entries))
+	for k, v := range entries {
+		if v.Kind() != constant.String {
+			errs.Add(errWrongDynamicType(fieldPath+"["+strconv.Quote(k)+"]", "string").AtGoNode(literal.Expr(fieldPath)))
+			continue
+		}
+		m.SetMapIndex(reflect.ValueOf(k).Convert(field.Type().Key()), reflect.ValueOf(constant.StringVal(v)).Convert(field.Type().Elem()))
+	}
+	field.Set(m)
+}
+
+// decodeSlice decodes a constant slice literal field.
+// Only slices of strings are supported.
+func decodeSlice(errs *perr.List, literal *Struct, fieldPath string, field reflect.Value) {
+	if field.Type().Elem().Kind() != reflect.String {
+		errs.Assert(errUnsupportedType(field.Type().Kind()).AtGoNode(literal.Expr(fieldPath)))
+	}
+
+	elems, ok := literal.ConstantSlice(fieldPath)
+	if !ok {
+		errs.Add(errWrongDynamicType(fieldPath, "slice").AtGoNode(literal.Expr(fieldPath)))
+		return
+	}
+
+	s := reflect.MakeSlice(field.Type(), 0, len(elems))
+	for i, v := range elems {
+		if v.Kind() != constant.String {
+			errs.Add(errWrongDynamicType(fmt.Sprintf("%s[%d]", fieldPath, i), "string").AtGoNode(literal.Expr(fieldPath)))
+			continue
+		}
+		s = reflect.Append(s, reflect.ValueOf(constant.StringVal(v)).Convert(field.Type().Elem()))
+	}
+	field.Set(s)
+}
@@ -70,3 +70,101 @@ var x = pubsub.SubscriptionConfig{
 	})
 
 }
+
+func TestDecode_NestedDynamic(t *testing.T) {
+	c := qt.New(t)
+	tc := testutil.NewContext(c, false, testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- foo.go --
+package foo
+
+type Policy struct {
+	Handler func()
+	MaxDeliveries int
+}
+
+type Config struct {
+	Policy *Policy
+}
+
+func handler() {}
+
+var x = Config{
+	Policy: &Policy{
+		Handler: handler,
+		MaxDeliveries: 3,
+	},
+}
+`))
+	tc.FailTestOnErrors()
+	tc.GoModTidy()
+
+	loader := pkginfo.New(tc.Context)
+	pkg := loader.MustLoadPkg(0, "example.com")
+
+	cfgLit, ok := ParseStruct(tc.Errs, pkg.Files[0], "Config",
+		pkg.Names().PkgDecls["x"].Spec.(*ast.ValueSpec).Values[0])
+	c.Assert(ok, qt.IsTrue)
+
+	type decodedConfig struct {
+		Policy struct {
+			Handler       ast.Expr `literal:",dynamic"`
+			MaxDeliveries int      `literal:",optional"`
+		} `literal:",optional"`
+	}
+
+	cfg := Decode[decodedConfig](tc.Errs, cfgLit, nil)
+	c.Assert(cfg.Policy.MaxDeliveries, qt.Equals, 3)
+	c.Assert(PrettyPrint(cfg.Policy.Handler), qt.Equals, "handler")
+}
+
+func TestDecode_Collections(t *testing.T) {
+	c := qt.New(t)
+	tc := testutil.NewContext(c, false, testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- foo.go --
+package foo
+
+type Config struct {
+	Name    string
+	Tags    map[string]string
+	Regions []string
+}
+
+var x = Config{
+	Name: "foo",
+	Tags: map[string]string{
+		"cost-center": "billing",
+		"data-residency": "eu",
+	},
+	Regions: []string{"eu-*", "europe-" + "west1"},
+}
+`))
+	tc.FailTestOnErrors()
+	tc.GoModTidy()
+
+	loader := pkginfo.New(tc.Context)
+	pkg := loader.MustLoadPkg(0, "example.com")
+
+	cfgLit, ok := ParseStruct(tc.Errs, pkg.Files[0], "Config",
+		pkg.Names().PkgDecls["x"].Spec.(*ast.ValueSpec).Values[0])
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(cfgLit.FullyConstant(), qt.IsTrue)
+
+	type decodedConfig struct {
+		Name    string
+		Tags    map[string]string `literal:",optional"`
+		Regions []string          `literal:",optional"`
+	}
+
+	cfg := Decode[decodedConfig](tc.Errs, cfgLit, nil)
+	c.Assert(cfg, qt.DeepEquals, decodedConfig{
+		Name:    "foo",
+		Tags:    map[string]string{"cost-center": "billing", "data-residency": "eu"},
+		Regions: []string{"eu-*", "europe-west1"},
+	})
+}
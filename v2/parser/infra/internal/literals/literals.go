@@ -44,6 +44,8 @@ func ParseStruct(errs *perr.List, file *pkginfo.File, expectedType string, node
 	lit = &Struct{
 		ast:            cl,
 		constantFields: make(map[string]constant.Value),
+		constantMaps:   make(map[string]map[string]constant.Value),
+		constantSlices: make(map[string][]constant.Value),
 		allFields:      make(map[string]ast.Expr),
 		childStructs:   make(map[string]*Struct),
 	}
@@ -77,7 +79,20 @@ elemLoop:
 				subStruct = value
 			}
 
-			if subStruct != nil {
+			if mapLit, isMap := elem.Value.(*ast.CompositeLit); isMap && isMapType(mapLit.Type) {
+				// Map literals are kept as a single field, which is constant
+				// if all its keys are constant strings and its values constant.
+				lit.allFields[ident.Name] = elem.Value
+				if m, ok := parseConstantMap(errs, file, mapLit); ok {
+					lit.constantMaps[ident.Name] = m
+				}
+			} else if sliceLit, isSlice := elem.Value.(*ast.CompositeLit); isSlice && isSliceType(sliceLit.Type) {
+				// Slice literals are likewise kept as a single field.
+				lit.allFields[ident.Name] = elem.Value
+				if elems, ok := parseConstantSlice(errs, file, sliceLit); ok {
+					lit.constantSlices[ident.Name] = elems
+				}
+			} else if subStruct != nil {
 				subLit, subOk := ParseStruct(errs, file, "struct", subStruct)
 				ok = ok && subOk
 				lit.childStructs[ident.Name] = subLit
@@ -202,6 +217,49 @@ func ParseConstant(errs *perr.List, file *pkginfo.File, value ast.Expr) (rtn con
 	}
 }
 
+func isMapType(typ ast.Expr) bool {
+	_, ok := typ.(*ast.MapType)
+	return ok
+}
+
+func isSliceType(typ ast.Expr) bool {
+	arr, ok := typ.(*ast.ArrayType)
+	return ok && arr.Len == nil
+}
+
+// parseConstantSlice parses a slice literal with constant elements.
+// It reports false if any element is not constant.
+func parseConstantSlice(errs *perr.List, file *pkginfo.File, lit *ast.CompositeLit) (elems []constant.Value, ok bool) {
+	elems = make([]constant.Value, 0, len(lit.Elts))
+	for _, elem := range lit.Elts {
+		val := ParseConstant(errs, file, elem)
+		if val.Kind() == constant.Unknown {
+			return nil, false
+		}
+		elems = append(elems, val)
+	}
+	return elems, true
+}
+
+// parseConstantMap parses a map literal with constant string keys and constant values.
+// It reports false if any key or value is not constant.
+func parseConstantMap(errs *perr.List, file *pkginfo.File, lit *ast.CompositeLit) (m map[string]constant.Value, ok bool) {
+	m = make(map[string]constant.Value, len(lit.Elts))
+	for _, elem := range lit.Elts {
+		kv, isKV := elem.(*ast.KeyValueExpr)
+		if !isKV {
+			return nil, false
+		}
+		key := ParseConstant(errs, file, kv.Key)
+		val := ParseConstant(errs, file, kv.Value)
+		if key.Kind() != constant.String || val.Kind() == constant.Unknown {
+			return nil, false
+		}
+		m[constant.StringVal(key)] = val
+	}
+	return m, true
+}
+
 func basicLit(value *ast.BasicLit) (constant.Value, error) {
 	switch value.Kind {
 	case token.IDENT:
@@ -230,10 +288,12 @@ func basicLit(value *ast.BasicLit) (constant.Value, error) {
 
 // Struct represents a struct literal at compile time
 type Struct struct {
-	ast            *ast.CompositeLit         // The AST node which presents the literal
-	constantFields map[string]constant.Value // All found constant expressions
-	allFields      map[string]ast.Expr       // All field expressions (constant or otherwise)
-	childStructs   map[string]*Struct        // Any child struct literals
+	ast            *ast.CompositeLit                    // The AST node which presents the literal
+	constantFields map[string]constant.Value            // All found constant expressions
+	constantMaps   map[string]map[string]constant.Value // All found constant map literals
+	constantSlices map[string][]constant.Value          // All found constant slice literals
+	allFields      map[string]ast.Expr                  // All field expressions (constant or otherwise)
+	childStructs   map[string]*Struct                   // Any child struct literals
 }
 
 func (l *Struct) Lit() *ast.CompositeLit {
@@ -248,7 +308,7 @@ func (l *Struct) FullyConstant() bool {
 			return false
 		}
 	}
-	return len(l.constantFields) == len(l.allFields)
+	return len(l.constantFields)+len(l.constantMaps)+len(l.constantSlices) == len(l.allFields)
 }
 
 // DynamicFields returns the names of the fields and ast.Expr that are not constant
@@ -259,7 +319,11 @@ func (l *Struct) DynamicFields() map[string]ast.Expr {
 	fields := make(map[string]ast.Expr)
 	for name, expr := range l.allFields {
 		if _, found := l.constantFields[name]; !found {
-			fields[name] = expr
+			_, isMap := l.constantMaps[name]
+			_, isSlice := l.constantSlices[name]
+			if !isMap && !isSlice {
+				fields[name] = expr
+			}
 		}
 	}
 
@@ -305,10 +369,47 @@ func (l *Struct) IsConstant(fieldName string) bool {
 		return child.FullyConstant()
 	}
 
+	if _, found = l.constantMaps[fieldName]; found {
+		return true
+	} else if _, found = l.constantSlices[fieldName]; found {
+		return true
+	}
 	_, found = l.constantFields[fieldName]
 	return found
 }
 
+// ConstantMap returns the entries of the map literal field as constant values.
+// If the field is not a constant map literal or does not exist, it reports false.
+//
+// You can reference a child struct field with `.`; i.e. `parent.child`
+func (l *Struct) ConstantMap(fieldName string) (m map[string]constant.Value, ok bool) {
+	before, after, found := strings.Cut(fieldName, ".")
+	if found {
+		if child, found := l.childStructs[before]; found {
+			return child.ConstantMap(after)
+		}
+		return nil, false
+	}
+	m, ok = l.constantMaps[fieldName]
+	return m, ok
+}
+
+// ConstantSlice returns the elements of the slice literal field as constant values.
+// If the field is not a constant slice literal or does not exist, it reports false.
+//
+// You can reference a child struct field with `.`; i.e. `parent.child`
+func (l *Struct) ConstantSlice(fieldName string) (elems []constant.Value, ok bool) {
+	before, after, found := strings.Cut(fieldName, ".")
+	if found {
+		if child, found := l.childStructs[before]; found {
+			return child.ConstantSlice(after)
+		}
+		return nil, false
+	}
+	elems, ok = l.constantSlices[fieldName]
+	return elems, ok
+}
+
 func (l *Struct) ChildStruct(fieldName string) (st *Struct, ok bool) {
 	st, ok = l.childStructs[fieldName]
 	return
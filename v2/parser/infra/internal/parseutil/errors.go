@@ -49,4 +49,19 @@ var (
 		"Invalid resource name",
 		"The %s %s %q used the reserved prefix %q.",
 	)
+
+	errInvalidTagKey = errRange.Newf(
+		"Invalid resource tag",
+		"The tag key %q is invalid.",
+	)
+
+	errInvalidTagValue = errRange.Newf(
+		"Invalid resource tag",
+		"The value %q of tag %q is invalid.",
+	)
+
+	errInvalidRegion = errRange.Newf(
+		"Invalid region",
+		"The region %q is invalid.",
+	)
 )
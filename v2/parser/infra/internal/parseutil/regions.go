@@ -0,0 +1,29 @@
+package parseutil
+
+import (
+	"go/ast"
+	"regexp"
+
+	"encr.dev/v2/internals/perr"
+)
+
+// regionRegexp matches a cloud region name like "eu-west-1" or "europe-west1",
+// optionally ending with a "*" to match all regions with that prefix, like "eu-*".
+var regionRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*\*?$`)
+
+const regionHelp = "Regions are given as cloud region names, such as \"eu-west-1\" or \"europe-west1\", " +
+	"optionally ending with \"*\" to allow all regions with that prefix, such as \"eu-*\"."
+
+// ValidateRegions checks that the regions a resource is pinned to, given in node, are valid,
+// reporting any errors and returning the valid regions.
+func ValidateRegions(errs *perr.List, node ast.Expr, regions []string) []string {
+	var valid []string
+	for _, r := range regions {
+		if !regionRegexp.MatchString(r) {
+			errs.Add(errInvalidRegion(r).WithDetails(regionHelp).AtGoNode(node))
+			continue
+		}
+		valid = append(valid, r)
+	}
+	return valid
+}
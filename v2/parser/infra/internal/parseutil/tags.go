@@ -0,0 +1,41 @@
+package parseutil
+
+import (
+	"go/ast"
+	"maps"
+	"regexp"
+	"slices"
+
+	"encr.dev/v2/internals/perr"
+)
+
+// Tag keys and values follow the strictest rules of the supported clouds (GCP labels),
+// so the same tags can be applied to the provisioned infrastructure everywhere.
+var (
+	tagKeyRegexp   = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	tagValueRegexp = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+)
+
+const tagHelp = "Tag keys must start with a lowercase letter, and tag keys and values may only contain " +
+	"lowercase letters, numbers, dashes and underscores and be at most 63 characters long."
+
+// ValidateTags checks that the resource tags given in node are valid,
+// reporting any errors and returning the valid tags.
+func ValidateTags(errs *perr.List, node ast.Expr, tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	valid := make(map[string]string, len(tags))
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		v := tags[k]
+		if !tagKeyRegexp.MatchString(k) {
+			errs.Add(errInvalidTagKey(k).WithDetails(tagHelp).AtGoNode(node))
+		} else if !tagValueRegexp.MatchString(v) {
+			errs.Add(errInvalidTagValue(v, k).WithDetails(tagHelp).AtGoNode(node))
+		} else {
+			valid[k] = v
+		}
+	}
+	return valid
+}
@@ -45,4 +45,14 @@ var (
 		"Invalid metric label name",
 		"Metric labels cannot be named 'service' as this is reserved by Encore.",
 	)
+
+	errLabelLimitWithoutLabels = errRange.Newf(
+		"Invalid metric configuration",
+		"%s can only be set for metric groups.",
+	)
+
+	errInvalidOverflow = errRange.Newf(
+		"Invalid metric configuration",
+		"Overflow must be metrics.DropOverflow or metrics.AggregateOverflow, got %q.",
+	)
 )
@@ -218,15 +218,34 @@ func parseMetric(c metricConstructor, d parseutil.ReferenceInfo) {
 type configParseFunc func(c metricConstructor, d parseutil.ReferenceInfo, cfgLit *literals.Struct, dst *Metric)
 
 func parseCounterConfig(c metricConstructor, d parseutil.ReferenceInfo, cfgLit *literals.Struct, dst *Metric) {
-	// We don't have any actual configuration yet.
-	// Parse anyway to make sure we don't have any fields we don't expect.
-	type decodedConfig struct{}
-	_ = literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	parseLabelLimits(c, d, cfgLit)
 }
 
 func parseGaugeConfig(c metricConstructor, d parseutil.ReferenceInfo, cfgLit *literals.Struct, dst *Metric) {
-	// We don't have any actual configuration yet.
-	// Parse anyway to make sure we don't have any fields we don't expect.
-	type decodedConfig struct{}
-	_ = literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	parseLabelLimits(c, d, cfgLit)
+}
+
+// parseLabelLimits parses and validates the label cardinality limits
+// shared by the counter and gauge configs.
+func parseLabelLimits(c metricConstructor, d parseutil.ReferenceInfo, cfgLit *literals.Struct) {
+	type decodedConfig struct {
+		MaxLabelCombinations int    `literal:",optional"`
+		Overflow             string `literal:",optional"`
+	}
+	config := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+
+	if !c.HasLabels {
+		for _, field := range []string{"MaxLabelCombinations", "Overflow"} {
+			if cfgLit.IsSet(field) {
+				d.Pass.Errs.Add(errLabelLimitWithoutLabels(field).AtGoNode(cfgLit.Expr(field)))
+			}
+		}
+		return
+	}
+
+	switch config.Overflow {
+	case "", "drop", "aggregate":
+	default:
+		d.Pass.Errs.Add(errInvalidOverflow(config.Overflow).AtGoNode(cfgLit.Expr("Overflow")))
+	}
 }
@@ -73,6 +73,49 @@ type Labels struct {
 				Type:      Gauge,
 			},
 		},
+		{
+			Name: "label_limits",
+			Code: `
+// Metric docs
+var x = metrics.NewCounterGroup[Labels, int]("name", metrics.CounterConfig{
+	MaxLabelCombinations: 100,
+	Overflow:             metrics.AggregateOverflow,
+})
+
+type Labels struct {
+	ID string
+}
+`,
+			Want: &Metric{
+				Name:      "name",
+				Doc:       "Metric docs\n",
+				Type:      Counter,
+				Labels:    []Label{{Key: "id", Type: schematest.String()}},
+				ValueType: schematest.Int(),
+			},
+		},
+		{
+			Name: "invalid_overflow",
+			Code: `
+var x = metrics.NewGaugeGroup[Labels, int]("name", metrics.GaugeConfig{
+	Overflow: "ignore",
+})
+
+type Labels struct {
+	ID string
+}
+`,
+			WantErrs: []string{`.*Overflow must be metrics.DropOverflow or metrics.AggregateOverflow, got "ignore".*`},
+		},
+		{
+			Name: "label_limits_without_labels",
+			Code: `
+var x = metrics.NewCounter[int]("name", metrics.CounterConfig{
+	MaxLabelCombinations: 10,
+})
+`,
+			WantErrs: []string{`.*MaxLabelCombinations can only be set for metric groups.*`},
+		},
 	}
 
 	resourcetest.Run(t, MetricParser, tests, cmpopts.IgnoreFields(Metric{}, "LabelType"))
@@ -0,0 +1,148 @@
+package monitors
+
+import (
+	"go/ast"
+	"go/token"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/pkginfo"
+	literals "encr.dev/v2/parser/infra/internal/literals"
+	parseutil "encr.dev/v2/parser/infra/internal/parseutil"
+	"encr.dev/v2/parser/resource"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+// Check is a synthetic monitoring check, declared with monitor.NewCheck.
+type Check struct {
+	AST   *ast.CallExpr
+	File  *pkginfo.File
+	Name  string // The unique name of the check
+	Doc   string // The documentation on the check
+	Title string // The check title
+
+	Method   string        // The HTTP method to use
+	Path     string        // The request path to probe
+	Interval time.Duration // How often to run the check
+	Timeout  time.Duration // The request timeout
+
+	ExpectStatus       int           // The expected response status code
+	ExpectBodyContains string        // A string the response body must contain, if non-empty
+	MaxLatency         time.Duration // The maximum response time, if non-zero
+}
+
+func (c *Check) Kind() resource.Kind       { return resource.MonitorCheck }
+func (c *Check) Package() *pkginfo.Package { return c.File.Pkg }
+func (c *Check) ASTExpr() ast.Expr         { return c.AST }
+func (c *Check) ResourceName() string      { return c.Name }
+func (c *Check) Pos() token.Pos            { return c.AST.Pos() }
+func (c *Check) End() token.Pos            { return c.AST.End() }
+func (c *Check) SortKey() string           { return c.Name }
+
+var CheckParser = &resourceparser.Parser{
+	Name: "Monitoring Check",
+
+	InterestingImports: []paths.Pkg{"encore.dev/monitor"},
+	Run: func(p *resourceparser.Pass) {
+		name := pkginfo.QualifiedName{PkgPath: "encore.dev/monitor", Name: "NewCheck"}
+
+		spec := &parseutil.ReferenceSpec{
+			MinTypeArgs: 0,
+			MaxTypeArgs: 0,
+			Parse:       parseCheck,
+		}
+
+		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+			parseutil.ParseReference(p, spec, parseutil.ReferenceData{
+				File:         file,
+				Stack:        stack,
+				ResourceFunc: name,
+			})
+		})
+	},
+}
+
+var allowedMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+func parseCheck(d parseutil.ReferenceInfo) {
+	errs := d.Pass.Errs
+	displayName := d.ResourceFunc.NaiveDisplayName()
+	if len(d.Call.Args) != 2 {
+		errs.Add(errExpects2Arguments(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	}
+
+	checkName := parseutil.ParseResourceName(errs, displayName, "check name",
+		d.Call.Args[0], parseutil.KebabName, "")
+	if checkName == "" {
+		// we already reported the error inside ParseResourceName
+		return
+	}
+
+	cfgLit, ok := literals.ParseStruct(errs, d.File, "monitor.CheckConfig", d.Call.Args[1])
+	if !ok {
+		return // error reported by ParseStruct
+	}
+
+	// Decode the config
+	type decodedConfig struct {
+		Title              string        `literal:",optional"`
+		Method             string        `literal:",optional,default"`
+		Path               string        `literal:",required"`
+		Interval           time.Duration `literal:",optional,default"`
+		Timeout            time.Duration `literal:",optional,default"`
+		ExpectStatus       int           `literal:",optional,default"`
+		ExpectBodyContains string        `literal:",optional"`
+		MaxLatency         time.Duration `literal:",optional"`
+	}
+	defaults := decodedConfig{
+		Method:       "GET",
+		Interval:     time.Minute,
+		Timeout:      10 * time.Second,
+		ExpectStatus: http.StatusOK,
+	}
+	config := literals.Decode[decodedConfig](errs, cfgLit, &defaults)
+
+	if !strings.HasPrefix(config.Path, "/") {
+		errs.Add(errInvalidPath.AtGoNode(cfgLit.Expr("Path")))
+	}
+	if !slices.Contains(allowedMethods, config.Method) {
+		errs.Add(errInvalidMethod(config.Method).AtGoNode(cfgLit.Expr("Method")))
+	}
+	if config.Interval < 10*time.Second || config.Interval > 24*time.Hour {
+		errs.Add(errIntervalOutOfRange(config.Interval).AtGoNode(cfgLit.Expr("Interval")))
+	}
+	if config.Timeout <= 0 || config.Timeout > config.Interval {
+		errs.Add(errTimeoutOutOfRange(config.Timeout).AtGoNode(cfgLit.Expr("Timeout")))
+	}
+	if config.ExpectStatus < 100 || config.ExpectStatus > 599 {
+		errs.Add(errInvalidExpectStatus(config.ExpectStatus).AtGoNode(cfgLit.Expr("ExpectStatus")))
+	}
+	if cfgLit.IsSet("MaxLatency") && (config.MaxLatency <= 0 || config.MaxLatency > config.Timeout) {
+		errs.Add(errMaxLatencyOutOfRange(config.MaxLatency).AtGoNode(cfgLit.Expr("MaxLatency")))
+	}
+
+	check := &Check{
+		AST:                d.Call,
+		File:               d.File,
+		Name:               checkName,
+		Doc:                d.Doc,
+		Title:              config.Title,
+		Method:             config.Method,
+		Path:               config.Path,
+		Interval:           config.Interval,
+		Timeout:            config.Timeout,
+		ExpectStatus:       config.ExpectStatus,
+		ExpectBodyContains: config.ExpectBodyContains,
+		MaxLatency:         config.MaxLatency,
+	}
+	if check.Title == "" {
+		check.Title = checkName
+	}
+
+	d.Pass.RegisterResource(check)
+	d.Pass.AddBind(d.File, d.Ident, check)
+}
@@ -0,0 +1,80 @@
+package monitors
+
+import (
+	"testing"
+	"time"
+
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseCheck(t *testing.T) {
+	tests := []resourcetest.Case[*Check]{
+		{
+			Name: "defaults",
+			Code: `
+// Check docs
+var x = monitor.NewCheck("api-health", monitor.CheckConfig{
+	Path: "/healthz",
+})
+`,
+			Want: &Check{
+				Name:         "api-health",
+				Doc:          "Check docs\n",
+				Title:        "api-health",
+				Method:       "GET",
+				Path:         "/healthz",
+				Interval:     time.Minute,
+				Timeout:      10 * time.Second,
+				ExpectStatus: 200,
+			},
+		},
+		{
+			Name:    "full",
+			Imports: []string{"time"},
+			Code: `
+var _ = monitor.NewCheck("checkout", monitor.CheckConfig{
+	Title:              "Checkout is reachable",
+	Method:             "HEAD",
+	Path:               "/checkout",
+	Interval:           30 * time.Second,
+	Timeout:            5 * time.Second,
+	ExpectStatus:       204,
+	ExpectBodyContains: "ok",
+	MaxLatency:         500 * time.Millisecond,
+})
+`,
+			Want: &Check{
+				Name:               "checkout",
+				Title:              "Checkout is reachable",
+				Method:             "HEAD",
+				Path:               "/checkout",
+				Interval:           30 * time.Second,
+				Timeout:            5 * time.Second,
+				ExpectStatus:       204,
+				ExpectBodyContains: "ok",
+				MaxLatency:         500 * time.Millisecond,
+			},
+		},
+		{
+			Name:    "invalid",
+			Imports: []string{"time"},
+			Code: `
+var _ = monitor.NewCheck("invalid", monitor.CheckConfig{
+	Path:       "healthz",
+	Method:     "FETCH",
+	Interval:   5 * time.Second,
+	MaxLatency: time.Minute,
+})
+`,
+			WantErrs: []string{
+				`.*Path must be a request path starting with "/".*`,
+				`.*Method must be one of GET, HEAD, POST, PUT, PATCH, DELETE or OPTIONS, got "FETCH".*`,
+				`.*Interval must be between 10 seconds and 24 hours, got 5s.*`,
+				`.*Timeout must be positive and no longer than the Interval, got 10s.*`,
+				`.*MaxLatency must be positive and no longer than the Timeout, got 1m0s.*`,
+			},
+		},
+	}
+
+	resourcetest.Run(t, CheckParser, tests)
+}
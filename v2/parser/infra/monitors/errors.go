@@ -0,0 +1,54 @@
+package monitors
+
+import (
+	"encr.dev/pkg/errors"
+)
+
+var (
+	errRange = errors.Range(
+		"monitor",
+		"For more information, see https://encore.dev/docs/observability/synthetic-monitoring",
+
+		errors.WithRangeSize(20),
+	)
+
+	errExpects2Arguments = errRange.Newf(
+		"Invalid call to monitor.NewCheck",
+		"Expected 2 arguments, got %d",
+	)
+
+	errInvalidPath = errRange.New(
+		"Invalid call to monitor.NewCheck",
+		"Path must be a request path starting with \"/\", such as \"/healthz\".",
+	)
+
+	errInvalidMethod = errRange.Newf(
+		"Invalid call to monitor.NewCheck",
+		"Method must be one of GET, HEAD, POST, PUT, PATCH, DELETE or OPTIONS, got %q.",
+	)
+
+	errIntervalOutOfRange = errRange.Newf(
+		"Invalid call to monitor.NewCheck",
+		"Interval must be between 10 seconds and 24 hours, got %s.",
+	)
+
+	errTimeoutOutOfRange = errRange.Newf(
+		"Invalid call to monitor.NewCheck",
+		"Timeout must be positive and no longer than the Interval, got %s.",
+	)
+
+	errInvalidExpectStatus = errRange.Newf(
+		"Invalid call to monitor.NewCheck",
+		"ExpectStatus must be a valid HTTP status code, got %d.",
+	)
+
+	errMaxLatencyOutOfRange = errRange.Newf(
+		"Invalid call to monitor.NewCheck",
+		"MaxLatency must be positive and no longer than the Timeout, got %s.",
+	)
+
+	ErrDuplicateNames = errRange.New(
+		"Duplicate monitoring checks",
+		"Multiple monitoring checks with the same name were found. Check names must be unique.",
+	)
+)
@@ -4,6 +4,7 @@ import (
 	"go/ast"
 	"go/token"
 
+	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
 	"encr.dev/v2/internals/pkginfo"
 	literals "encr.dev/v2/parser/infra/internal/literals"
@@ -19,6 +20,24 @@ type Bucket struct {
 	Doc       string // The documentation on the bucket
 	Versioned bool
 	Public    bool
+	Tags      map[string]string // The tags to apply to the provisioned bucket
+	Regions   []string          // The regions the bucket must be provisioned in, if restricted
+	Lifecycle *Lifecycle        // The lifecycle rules for objects in the bucket, if any
+
+	// ClientSideEncryption is whether objects are encrypted by the runtime before being uploaded.
+	ClientSideEncryption bool
+
+	// Events is the pubsub topic object change notifications are published to, if any.
+	Events option.Option[pkginfo.QualifiedName]
+	// EventsExpr is the AST expression referencing the events topic, if any.
+	EventsExpr ast.Expr
+}
+
+// Lifecycle describes when objects in a bucket are moved to cold storage or deleted.
+// Zero values mean the rule is not set.
+type Lifecycle struct {
+	ExpireAfterDays      int // delete objects this many days after creation
+	ColdStorageAfterDays int // move objects to cold storage this many days after creation
 }
 
 func (t *Bucket) Kind() resource.Kind       { return resource.Bucket }
@@ -73,9 +92,19 @@ func parseBucket(d parseutil.ReferenceInfo) {
 	}
 
 	// Decode the config
+	type lifecycleConfig struct {
+		ExpireAfterDays      int `literal:",optional"`
+		ColdStorageAfterDays int `literal:",optional"`
+	}
 	type decodedConfig struct {
-		Versioned bool `literal:",optional"`
-		Public    bool `literal:",optional"`
+		Versioned bool              `literal:",optional"`
+		Public    bool              `literal:",optional"`
+		Tags      map[string]string `literal:",optional"`
+		Regions   []string          `literal:",optional"`
+		Lifecycle lifecycleConfig   `literal:",optional"`
+		Events    ast.Expr          `literal:",optional,dynamic"`
+
+		ClientSideEncryption bool `literal:",optional"`
 	}
 	config := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
 
@@ -86,7 +115,46 @@ func parseBucket(d parseutil.ReferenceInfo) {
 		Doc:       d.Doc,
 		Versioned: config.Versioned,
 		Public:    config.Public,
+		Tags:      parseutil.ValidateTags(d.Pass.Errs, cfgLit.Expr("Tags"), config.Tags),
+		Regions:   parseutil.ValidateRegions(d.Pass.Errs, cfgLit.Expr("Regions"), config.Regions),
+
+		ClientSideEncryption: config.ClientSideEncryption,
 	}
+
+	if bkt.Public && bkt.ClientSideEncryption {
+		errs.Add(errPublicEncryptedBucket.AtGoNode(cfgLit.Expr("ClientSideEncryption")))
+	}
+
+	if cfgLit.IsSet("Lifecycle") {
+		lc := config.Lifecycle
+		if lc.ExpireAfterDays < 0 {
+			errs.Add(errLifecycleNegativeDays("ExpireAfterDays", lc.ExpireAfterDays).
+				AtGoNode(cfgLit.Expr("Lifecycle.ExpireAfterDays")))
+		}
+		if lc.ColdStorageAfterDays < 0 {
+			errs.Add(errLifecycleNegativeDays("ColdStorageAfterDays", lc.ColdStorageAfterDays).
+				AtGoNode(cfgLit.Expr("Lifecycle.ColdStorageAfterDays")))
+		}
+		if lc.ExpireAfterDays > 0 && lc.ColdStorageAfterDays >= lc.ExpireAfterDays {
+			errs.Add(errLifecycleColdStorageAfterExpiry.AtGoNode(cfgLit.Expr("Lifecycle.ColdStorageAfterDays")))
+		}
+		if lc.ExpireAfterDays > 0 || lc.ColdStorageAfterDays > 0 {
+			bkt.Lifecycle = &Lifecycle{
+				ExpireAfterDays:      lc.ExpireAfterDays,
+				ColdStorageAfterDays: lc.ColdStorageAfterDays,
+			}
+		}
+	}
+
+	if topicExpr := config.Events; topicExpr != nil {
+		if topic, ok := d.File.Names().ResolvePkgLevelRef(topicExpr); ok {
+			bkt.Events = option.Some(topic)
+			bkt.EventsExpr = topicExpr
+		} else {
+			errs.Add(ErrBucketEventsNotTopic.AtGoNode(topicExpr))
+		}
+	}
+
 	d.Pass.RegisterResource(bkt)
 	d.Pass.AddBind(d.File, d.Ident, bkt)
 }
@@ -47,7 +47,7 @@ var (
 
 	errBucketRefInvalidPerms = errRange.New(
 		"Unrecognized permissions in call to objects.BucketRef",
-		"The supported permissions are objects.{Uploader,Downloader,Attrser,Lister,Remover,PublicURLer,ReadWriter}.",
+		"The supported permissions are objects.{Uploader,SignedUploader,Downloader,SignedDownloader,Attrser,AttrsUpdater,Lister,Remover,Copier,Mover,PublicURLer,ReadWriter}.",
 	)
 
 	ErrBucketRefOutsideService = errRange.New(
@@ -55,6 +55,36 @@ var (
 		"objects.BucketRef can only be called from within a service.",
 	)
 
+	errLifecycleNegativeDays = errRange.Newf(
+		"Invalid bucket lifecycle",
+		"Lifecycle.%s must not be negative, got %d.",
+	)
+
+	errLifecycleColdStorageAfterExpiry = errRange.New(
+		"Invalid bucket lifecycle",
+		"Lifecycle.ColdStorageAfterDays must be less than Lifecycle.ExpireAfterDays, as objects are deleted before they would be moved to cold storage.",
+	)
+
+	ErrBucketEventsNotTopic = errRange.New(
+		"Invalid bucket events topic",
+		"BucketConfig.Events must reference a package-level pubsub topic declared with pubsub.NewTopic.",
+	)
+
+	ErrBucketEventsMessageType = errRange.New(
+		"Invalid bucket events topic",
+		"The topic referenced by BucketConfig.Events must have *objects.BucketEvent as its message type.",
+	)
+
+	errPublicEncryptedBucket = errRange.New(
+		"Invalid bucket config",
+		"A bucket using client-side encryption cannot be public, since its objects can only be decrypted by the application.",
+	)
+
+	ErrBucketSignedURLEncrypted = errRange.Newf(
+		"Signed URL for objects.Bucket using client-side encryption",
+		"The %s method cannot be called on a bucket using client-side encryption, since its objects can only be decrypted by the application.",
+	)
+
 	ErrBucketNotPublic = errRange.New(
 		"Call to PublicURL for non-public objects.Bucket",
 		"The PublicURL method can only be called on a public bucket.",
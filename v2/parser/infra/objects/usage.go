@@ -16,7 +16,11 @@ import (
 type MethodUsage struct {
 	usage.Base
 	Method string
-	Perm   Perm
+	Perms  []Perm
+}
+
+func (u *MethodUsage) HasPerm(perm Perm) bool {
+	return slices.Contains(u.Perms, perm)
 }
 
 type RefUsage struct {
@@ -75,24 +79,30 @@ func (p Perm) ToMeta() (meta.BucketUsage_Operation, bool) {
 func ResolveBucketUsage(data usage.ResolveData, bkt *Bucket) usage.Usage {
 	switch expr := data.Expr.(type) {
 	case *usage.MethodCall:
-		var perm Perm
+		var perms []Perm
 		switch expr.Method {
 		case "Upload":
-			perm = WriteObject
-		case "Download":
-			perm = ReadObjectContents
+			perms = []Perm{WriteObject}
+		case "Download", "DownloadRange":
+			perms = []Perm{ReadObjectContents}
 		case "List":
-			perm = ListObjects
+			perms = []Perm{ListObjects}
 		case "Remove":
-			perm = DeleteObject
+			perms = []Perm{DeleteObject}
 		case "PublicURL":
-			perm = GetPublicURL
+			perms = []Perm{GetPublicURL}
 		case "SignedUploadURL":
-			perm = SignedUploadURL
-		case "SignedDownloadURL":
-			perm = SignedDownloadURL
+			perms = []Perm{SignedUploadURL}
+		case "SignedDownloadURL", "SignedPrefixAccess", "ServeSignedAccess":
+			perms = []Perm{SignedDownloadURL}
 		case "Attrs", "Exists":
-			perm = GetObjectMetadata
+			perms = []Perm{GetObjectMetadata}
+		case "UpdateAttrs":
+			perms = []Perm{UpdateObjectMetadata}
+		case "Copy":
+			perms = []Perm{ReadObjectContents, WriteObject}
+		case "Move":
+			perms = []Perm{ReadObjectContents, WriteObject, DeleteObject}
 		default:
 			return nil
 		}
@@ -104,7 +114,7 @@ func ResolveBucketUsage(data usage.ResolveData, bkt *Bucket) usage.Usage {
 				Expr: expr,
 			},
 			Method: expr.Method,
-			Perm:   perm,
+			Perms:  perms,
 		}
 
 	case *usage.FuncArg:
@@ -146,8 +156,14 @@ func parseBucketRef(errs *perr.List, expr *usage.FuncArg) usage.Usage {
 				perms = append(perms, DeleteObject)
 			case isNamed(typ, "Attrser"):
 				perms = append(perms, GetObjectMetadata)
+			case isNamed(typ, "AttrsUpdater"):
+				perms = append(perms, UpdateObjectMetadata)
 			case isNamed(typ, "PublicURLer"):
 				perms = append(perms, GetPublicURL)
+			case isNamed(typ, "Copier"):
+				perms = append(perms, ReadObjectContents, WriteObject)
+			case isNamed(typ, "Mover"):
+				perms = append(perms, ReadObjectContents, WriteObject, DeleteObject)
 			case isNamed(typ, "ReadWriter"):
 				perms = append(perms,
 					WriteObject, ReadObjectContents, ListObjects, DeleteObject,
@@ -26,7 +26,7 @@ var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
 func Foo() { bkt.Upload(context.Background(), "key") }
 
 `,
-			Want: []usage.Usage{&objects.MethodUsage{Method: "Upload", Perm: objects.WriteObject}},
+			Want: []usage.Usage{&objects.MethodUsage{Method: "Upload", Perms: []objects.Perm{objects.WriteObject}}},
 		},
 		{
 			Name: "sign_upload_url",
@@ -36,7 +36,7 @@ var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
 func Foo() { bkt.SignedUploadURL(context.Background(), "key") }
 
 `,
-			Want: []usage.Usage{&objects.MethodUsage{Method: "SignedUploadURL", Perm: objects.SignedUploadURL}},
+			Want: []usage.Usage{&objects.MethodUsage{Method: "SignedUploadURL", Perms: []objects.Perm{objects.SignedUploadURL}}},
 		},
 		{
 			Name: "sign_download_url",
@@ -46,7 +46,26 @@ var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
 func Foo() { bkt.SignedDownloadURL(context.Background(), "key") }
 
 `,
-			Want: []usage.Usage{&objects.MethodUsage{Method: "SignedDownloadURL", Perm: objects.SignedDownloadURL}},
+			Want: []usage.Usage{&objects.MethodUsage{Method: "SignedDownloadURL", Perms: []objects.Perm{objects.SignedDownloadURL}}},
+		},
+		{
+			Name: "signed_prefix_access",
+			Code: `
+var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
+
+func Foo() { bkt.SignedPrefixAccess(context.Background(), "users/1/") }
+
+`,
+			Want: []usage.Usage{&objects.MethodUsage{Method: "SignedPrefixAccess", Perms: []objects.Perm{objects.SignedDownloadURL}}},
+		},
+		{
+			Name: "download_range",
+			Code: `
+var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
+
+func Foo() { bkt.DownloadRange(context.Background(), "key", 0, 1024) }
+`,
+			Want: []usage.Usage{&objects.MethodUsage{Method: "DownloadRange", Perms: []objects.Perm{objects.ReadObjectContents}}},
 		},
 		{
 			Name: "attrs",
@@ -55,7 +74,7 @@ var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
 
 func Foo() { bkt.Attrs(context.Background(), "key") }
 `,
-			Want: []usage.Usage{&objects.MethodUsage{Method: "Attrs", Perm: objects.GetObjectMetadata}},
+			Want: []usage.Usage{&objects.MethodUsage{Method: "Attrs", Perms: []objects.Perm{objects.GetObjectMetadata}}},
 		},
 		{
 			Name: "exists",
@@ -64,7 +83,25 @@ var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
 
 func Foo() { bkt.Exists(context.Background(), "key") }
 `,
-			Want: []usage.Usage{&objects.MethodUsage{Method: "Exists", Perm: objects.GetObjectMetadata}},
+			Want: []usage.Usage{&objects.MethodUsage{Method: "Exists", Perms: []objects.Perm{objects.GetObjectMetadata}}},
+		},
+		{
+			Name: "copy",
+			Code: `
+var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
+
+func Foo() { bkt.Copy(context.Background(), "src", "dst") }
+`,
+			Want: []usage.Usage{&objects.MethodUsage{Method: "Copy", Perms: []objects.Perm{objects.ReadObjectContents, objects.WriteObject}}},
+		},
+		{
+			Name: "move",
+			Code: `
+var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
+
+func Foo() { bkt.Move(context.Background(), "src", "dst") }
+`,
+			Want: []usage.Usage{&objects.MethodUsage{Method: "Move", Perms: []objects.Perm{objects.ReadObjectContents, objects.WriteObject, objects.DeleteObject}}},
 		},
 		{
 			Name: "ref",
@@ -97,6 +134,37 @@ var ref = objects.BucketRef[objects.ReadWriter](bkt)
 				},
 			}},
 		},
+		{
+			Name: "update_attrs",
+			Code: `
+var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
+
+func Foo() { bkt.UpdateAttrs(context.Background(), "key", objects.ObjectAttrsToUpdate{}) }
+`,
+			Want: []usage.Usage{&objects.MethodUsage{Method: "UpdateAttrs", Perms: []objects.Perm{objects.UpdateObjectMetadata}}},
+		},
+		{
+			Name: "ref_attrs_updater",
+			Code: `
+var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
+
+var ref = objects.BucketRef[objects.AttrsUpdater](bkt)
+`,
+			Want: []usage.Usage{&objects.RefUsage{
+				Perms: []objects.Perm{objects.UpdateObjectMetadata},
+			}},
+		},
+		{
+			Name: "ref_mover",
+			Code: `
+var bkt = objects.NewBucket("bucket", objects.BucketConfig{})
+
+var ref = objects.BucketRef[objects.Mover](bkt)
+`,
+			Want: []usage.Usage{&objects.RefUsage{
+				Perms: []objects.Perm{objects.DeleteObject, objects.ReadObjectContents, objects.WriteObject},
+			}},
+		},
 		{
 			Name: "custom_ref_alias",
 			Code: `
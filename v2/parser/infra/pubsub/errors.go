@@ -48,6 +48,18 @@ var (
 		errors.PrependDetails(pubsubNewTopicHelp),
 	)
 
+	errOrderedTopicDeliveryGuarantee = errRange.New(
+		"Invalid PubSub topic config",
+		"Ordered topics must use the pubsub.ExactlyOnce delivery guarantee, "+
+			"since ordering keys are implemented using the FIFO message groups of exactly-once topics.",
+	)
+
+	errPublishOrderingKeyUnorderedTopic = errRange.New(
+		"Invalid call to Topic.Publish",
+		"pubsub.WithOrderingKey can only be used when publishing to ordered topics. "+
+			"Set \"Ordered\" to true in the topic config to enable ordering keys.",
+	)
+
 	errInvalidTopicUsage = errRange.New(
 		"Invalid reference to pubsub.Topic",
 		"A reference to pubsub.Topic is not permissible here.",
@@ -100,6 +112,23 @@ var (
 		"The ack deadline must be at least 1 second.",
 	)
 
+	errSubscriptionDeliveryDelayNegative = errRange.New(
+		"Invalid PubSub subscription config",
+		"The delivery delay cannot be negative.",
+	)
+
+	errSubscriptionInvalidFilter = errRange.Newf(
+		"Invalid PubSub subscription config",
+		"The subscription filter is invalid: %s.",
+		errors.WithDetails(`Filters are conditions on message attributes joined with "&&", like: region IN ("eu", "us") && kind != "test"`),
+	)
+
+	ErrSubscriptionFilterUnknownAttr = errRange.Newf(
+		"Invalid PubSub subscription config",
+		"The subscription filter references the attribute %q, which is not defined on the topic's message type.",
+		errors.WithDetails("Attributes are defined using the `pubsub-attr` struct tag on the message type's fields."),
+	)
+
 	errSubscriptionMessageRetentionTooShort = errRange.New(
 		"Invalid PubSub subscription config",
 		"The message retention must be at least 1 minute.",
@@ -120,6 +149,27 @@ var (
 		"The max number of retries must be a positive number or the constants `pubsub.InfiniteRetries` or `pubsub.NoRetries`.",
 	)
 
+	ErrSubscriptionDeadLetterTopicNotResource = errRange.New(
+		"Invalid PubSub subscription config",
+		"The dead-letter topic must be a package-level variable declared with pubsub.NewTopic.",
+	)
+
+	ErrSubscriptionDeadLetterTopicIsSubscribedTopic = errRange.New(
+		"Invalid PubSub subscription config",
+		"The dead-letter topic cannot be the same topic the subscription is subscribing to.",
+	)
+
+	errSubscriptionMaxDeliveriesTooSmall = errRange.New(
+		"Invalid PubSub subscription config",
+		"The max number of deliveries before a message is dead-lettered must be at least 1.",
+	)
+
+	errSubscriptionDeadLetterWithMaxRetries = errRange.New(
+		"Invalid PubSub subscription config",
+		"RetryPolicy.MaxRetries cannot be set when a dead-letter topic is configured, since messages are retried until they're dead-lettered.",
+		errors.PrependDetails("Use DeadLetter.MaxDeliveries to configure how many times a message is delivered before it's dead-lettered."),
+	)
+
 	errTopicRefNoTypeArgs = errRange.New(
 		"Invalid call to pubsub.TopicRef",
 		"A type argument indicating the requested permissions must be provided.",
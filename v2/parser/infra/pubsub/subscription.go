@@ -8,6 +8,8 @@ import (
 
 	"golang.org/x/tools/go/ast/astutil"
 
+	"encore.dev/appruntime/exported/pubsubfilter"
+
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
@@ -49,6 +51,25 @@ type SubscriptionConfig struct {
 	MaxRetryBackoff  time.Duration
 	MaxRetries       int
 	MaxConcurrency   int
+	DeliveryDelay    time.Duration
+
+	// Filter is the subscription's attribute filter, if any.
+	Filter option.Option[*pubsubfilter.Filter]
+	// FilterExpr is the AST expression defining the filter, if any.
+	FilterExpr ast.Expr
+
+	// DeadLetter is the dead-letter configuration, if any.
+	DeadLetter option.Option[DeadLetterConfig]
+}
+
+// DeadLetterConfig describes where messages are sent after failing to be processed.
+type DeadLetterConfig struct {
+	// Topic is the dead-letter topic.
+	Topic pkginfo.QualifiedName
+	// TopicExpr is the AST expression referencing the dead-letter topic.
+	TopicExpr ast.Expr
+	// MaxDeliveries is the number of deliveries before a message is dead-lettered.
+	MaxDeliveries int
 }
 
 func (s *Subscription) Kind() resource.Kind       { return resource.PubSubSubscription }
@@ -118,14 +139,22 @@ func parsePubSubSubscription(d parseutil.ReferenceInfo) {
 		MaxRetryBackoff time.Duration `literal:"MaxBackoff,optional,default"`
 		MaxRetries      int           `literal:"MaxRetries,optional,default"`
 	}
+	type deadLetterConfig struct {
+		Topic         ast.Expr `literal:",dynamic,required"`
+		MaxDeliveries int      `literal:",optional,default"`
+	}
 	type decodedConfig struct {
 		Handler ast.Expr `literal:",dynamic,required"`
 
 		// Optional configuration
-		MaxConcurrency   int           `literal:",optional,default"`
-		AckDeadline      time.Duration `literal:",optional,default"`
-		MessageRetention time.Duration `literal:",optional,default"`
-		RetryPolicy      retryConfig   `literal:",optional,default"`
+		MaxConcurrency   int              `literal:",optional,default"`
+		AckDeadline      time.Duration    `literal:",optional,default"`
+		MessageRetention time.Duration    `literal:",optional,default"`
+		DeliveryDelay    time.Duration    `literal:",optional,default"`
+		Filter           string           `literal:",optional"`
+		RetryPolicy      retryConfig      `literal:",optional,default"`
+		DeadLetter       deadLetterConfig `literal:",optional,default"`
+		Idempotency      ast.Expr         `literal:",optional,dynamic"`
 	}
 	defaults := decodedConfig{
 		MaxConcurrency:   100,
@@ -136,6 +165,9 @@ func parsePubSubSubscription(d parseutil.ReferenceInfo) {
 			MaxRetryBackoff: 10 * time.Minute,
 			MaxRetries:      100,
 		},
+		DeadLetter: deadLetterConfig{
+			MaxDeliveries: 5,
+		},
 	}
 
 	cfg := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, &defaults)
@@ -149,6 +181,23 @@ func parsePubSubSubscription(d parseutil.ReferenceInfo) {
 		errs.Add(errSubscriptionMessageRetentionTooShort.AtGoNode(cfgLit.Expr("MessageRetention"), errors.AsError(fmt.Sprintf("got %s", cfg.MessageRetention))))
 	}
 
+	if cfg.DeliveryDelay < 0 {
+		errs.Add(errSubscriptionDeliveryDelayNegative.AtGoNode(cfgLit.Expr("DeliveryDelay"), errors.AsError(fmt.Sprintf("got %s", cfg.DeliveryDelay))))
+	}
+
+	var filter option.Option[*pubsubfilter.Filter]
+	if cfg.Filter != "" {
+		if f, err := pubsubfilter.Parse(cfg.Filter); err != nil {
+			msg := err.Error()
+			if ferr, ok := err.(*pubsubfilter.Error); ok {
+				msg = fmt.Sprintf("%s (at offset %d)", ferr.Msg, ferr.Offset)
+			}
+			errs.Add(errSubscriptionInvalidFilter(msg).AtGoNode(cfgLit.Expr("Filter")))
+		} else {
+			filter = option.Some(f)
+		}
+	}
+
 	if cfg.RetryPolicy.MinRetryBackoff < 1*time.Second {
 		errs.Add(errSubscriptionMinRetryBackoffTooShort.AtGoNode(cfgLit.Expr("RetryPolicy.MinBackoff"), errors.AsError(fmt.Sprintf("got %s", cfg.RetryPolicy.MinRetryBackoff))))
 	}
@@ -161,6 +210,22 @@ func parsePubSubSubscription(d parseutil.ReferenceInfo) {
 		errs.Add(errSubscriptionMaxRetriesTooSmall.AtGoNode(cfgLit.Expr("RetryPolicy.MaxRetries"), errors.AsError(fmt.Sprintf("got %d", cfg.RetryPolicy.MaxRetries))))
 	}
 
+	if cfgLit.IsSet("DeadLetter") {
+		if cfg.DeadLetter.MaxDeliveries < 1 {
+			errs.Add(errSubscriptionMaxDeliveriesTooSmall.AtGoNode(cfgLit.Expr("DeadLetter.MaxDeliveries"), errors.AsError(fmt.Sprintf("got %d", cfg.DeadLetter.MaxDeliveries))))
+		}
+
+		// Messages are retried until they're dead-lettered.
+		if cfgLit.IsSet("RetryPolicy.MaxRetries") {
+			errs.Add(errSubscriptionDeadLetterWithMaxRetries.AtGoNode(cfgLit.Expr("RetryPolicy.MaxRetries")))
+		}
+		if cfg.DeadLetter.MaxDeliveries == 1 {
+			cfg.RetryPolicy.MaxRetries = -2 // pubsub.NoRetries
+		} else {
+			cfg.RetryPolicy.MaxRetries = cfg.DeadLetter.MaxDeliveries - 1
+		}
+	}
+
 	subCfg := SubscriptionConfig{
 		AckDeadline:      cfg.AckDeadline,
 		MessageRetention: cfg.MessageRetention,
@@ -168,6 +233,23 @@ func parsePubSubSubscription(d parseutil.ReferenceInfo) {
 		MaxRetryBackoff:  cfg.RetryPolicy.MaxRetryBackoff,
 		MaxRetries:       cfg.RetryPolicy.MaxRetries,
 		MaxConcurrency:   cfg.MaxConcurrency,
+		DeliveryDelay:    cfg.DeliveryDelay,
+		Filter:           filter,
+	}
+	if filter.Present() {
+		subCfg.FilterExpr = cfgLit.Expr("Filter")
+	}
+
+	if topicExpr := cfg.DeadLetter.Topic; topicExpr != nil {
+		if dlTopic, ok := d.File.Names().ResolvePkgLevelRef(topicExpr); ok {
+			subCfg.DeadLetter = option.Some(DeadLetterConfig{
+				Topic:         dlTopic,
+				TopicExpr:     topicExpr,
+				MaxDeliveries: cfg.DeadLetter.MaxDeliveries,
+			})
+		} else {
+			errs.Add(ErrSubscriptionDeadLetterTopicNotResource.AtGoNode(topicExpr))
+		}
 	}
 
 	if cfg.Handler == nil {
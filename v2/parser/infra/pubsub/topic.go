@@ -31,7 +31,15 @@ type Topic struct {
 	Doc               string              // The documentation on the pub sub topic
 	DeliveryGuarantee DeliveryGuarantee   // What guarantees does the pub sub topic have?
 	OrderingAttribute string              // What field in the message type should be used to ensure First-In-First-Out (FIFO) for messages with the same key
+	Ordered           bool                // Whether messages are ordered by ordering keys given when publishing
 	MessageType       *schema.TypeDeclRef // The message type of the pub sub topic
+	Tags              map[string]string   // The tags to apply to the provisioned topic
+	Regions           []string            // The regions the topic must be provisioned in, if restricted
+}
+
+// IsOrdered reports whether messages published to the topic are delivered in order.
+func (t *Topic) IsOrdered() bool {
+	return t.Ordered || t.OrderingAttribute != ""
 }
 
 func (t *Topic) Kind() resource.Kind       { return resource.PubSubTopic }
@@ -93,8 +101,11 @@ func parsePubSubTopic(d parseutil.ReferenceInfo) {
 
 	// Decode the config
 	type decodedConfig struct {
-		DeliveryGuarantee int    `literal:",optional"` // optional rather than required because we check for a zero value below
-		OrderingAttribute string `literal:",optional"`
+		DeliveryGuarantee int               `literal:",optional"` // optional rather than required because we check for a zero value below
+		OrderingAttribute string            `literal:",optional"`
+		Ordered           bool              `literal:",optional"`
+		Tags              map[string]string `literal:",optional"`
+		Regions           []string          `literal:",optional"`
 	}
 	config := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
 
@@ -123,6 +134,10 @@ func parsePubSubTopic(d parseutil.ReferenceInfo) {
 	if deliveryGuarantee != AtLeastOnce && deliveryGuarantee != ExactlyOnce {
 		pos := cfgLit.Pos("DeliveryGuarantee")
 		errs.Add(errInvalidDeliveryGuarantee.AtGoPos(pos, pos))
+	} else if config.Ordered && deliveryGuarantee != ExactlyOnce {
+		// Ordering keys are implemented using FIFO message groups,
+		// which are only provisioned for exactly-once topics.
+		errs.Add(errOrderedTopicDeliveryGuarantee.AtGoNode(cfgLit.Expr("Ordered")))
 	}
 
 	// Validate the message attributes are not using the reserved prefix
@@ -153,7 +168,10 @@ func parsePubSubTopic(d parseutil.ReferenceInfo) {
 		Doc:               d.Doc,
 		DeliveryGuarantee: deliveryGuarantee,
 		OrderingAttribute: config.OrderingAttribute,
+		Ordered:           config.Ordered,
 		MessageType:       messageType,
+		Tags:              parseutil.ValidateTags(errs, cfgLit.Expr("Tags"), config.Tags),
+		Regions:           parseutil.ValidateRegions(errs, cfgLit.Expr("Regions"), config.Regions),
 	}
 	d.Pass.RegisterResource(topic)
 	d.Pass.AddBind(d.File, d.Ident, topic)
@@ -1,6 +1,8 @@
 package pubsub
 
 import (
+	"go/ast"
+
 	"encr.dev/pkg/option"
 	"encr.dev/v2/internals/perr"
 	"encr.dev/v2/internals/pkginfo"
@@ -36,7 +38,16 @@ const (
 func ResolveTopicUsage(data usage.ResolveData, topic *Topic) usage.Usage {
 	switch expr := data.Expr.(type) {
 	case *usage.MethodCall:
-		if expr.Method == "Publish" {
+		if expr.Method == "Publish" || expr.Method == "PublishBatch" {
+			if !topic.IsOrdered() {
+				for _, arg := range expr.Args {
+					if call, ok := arg.(*ast.CallExpr); ok {
+						if qn, ok := expr.File.Names().ResolvePkgLevelRef(call.Fun); ok && qn == pkginfo.Q("encore.dev/pubsub", "WithOrderingKey") {
+							data.Errs.Add(errPublishOrderingKeyUnorderedTopic.AtGoNode(call))
+						}
+					}
+				}
+			}
 			return &PublishUsage{
 				Base: usage.Base{
 					File: expr.File,
@@ -59,6 +70,19 @@ func ResolveTopicUsage(data usage.ResolveData, topic *Topic) usage.Usage {
 		case option.Contains(expr.PkgFunc, pkginfo.Q("encore.dev/pubsub", "TopicRef")):
 			return parseTopicRef(data.Errs, expr)
 		}
+
+	case *usage.Other:
+		// Allow the topic to be referenced as a dead-letter topic
+		// within the config passed to pubsub.NewSubscription,
+		// and as the events topic in the config passed to objects.NewBucket.
+		if call, ok := expr.Expr.(*ast.CallExpr); ok {
+			if qn, ok := expr.File.Names().ResolvePkgLevelRef(call.Fun); ok {
+				switch qn {
+				case pkginfo.Q("encore.dev/pubsub", "NewSubscription"), pkginfo.Q("encore.dev/storage/objects", "NewBucket"):
+					return nil
+				}
+			}
+		}
 	}
 
 	data.Errs.Add(errInvalidTopicUsage.AtGoNode(data.Expr))
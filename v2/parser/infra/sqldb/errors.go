@@ -59,4 +59,39 @@ var (
 		"Unknown sqldb database",
 		"No database named %q was found in the application. Ensure it is created somewhere using sqldb.NewDatabase to be able to reference it.",
 	)
+	errGrantArgCount = errRange.Newf(
+		"Invalid call to sqldb.Grant",
+		"sqldb.Grant requires a database and at least one service name to be passed, got %d arguments.",
+	)
+	errGrantRequiresDatabase = errRange.New(
+		"Invalid call to sqldb.Grant",
+		"sqldb.Grant requires the first argument to be a database declared with sqldb.NewDatabase in the same package.",
+	)
+	errGrantRequiresServiceNameString = errRange.New(
+		"Invalid call to sqldb.Grant",
+		"sqldb.Grant requires the service names to be passed as string literals.",
+	)
+	ErrGrantUnknownService = errRange.Newf(
+		"Invalid call to sqldb.Grant",
+		"No service named %q was found in the application.",
+	)
+	ErrAccessNotGranted = errRange.Newf(
+		"Database access not granted",
+		"The service %q is not allowed to access the database %q.",
+		errors.WithDetails("The database restricts access using sqldb.Grant. To access it from this service, "+
+			"grant the service access with sqldb.Grant in the package declaring the database."),
+	)
+	errNewDatabaseSeedPath = errRange.New(
+		"Invalid sqldb.NewDatabase call",
+		"The seed path must be a relative path rooted within the package directory.",
+	)
+	errNewDatabaseSeedDirNotFound = errRange.New(
+		"Invalid sqldb.NewDatabase call",
+		"The seed directory does not exist.",
+	)
+	errUnableToParseSeeds = errRange.New(
+		"Unable to parse database seeds",
+		"Encore was unable to parse the database seed directory. It must contain SQL files "+
+			"and optionally a Go program (package main) for seeding the database.",
+	)
 )
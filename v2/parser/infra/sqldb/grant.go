@@ -0,0 +1,75 @@
+package sqldb
+
+import (
+	"fmt"
+	"go/ast"
+
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/parser/infra/internal/literals"
+	"encr.dev/v2/parser/infra/internal/parseutil"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+// Grant describes a service granted access to a database using sqldb.Grant.
+type Grant struct {
+	AST     ast.Expr // the service name argument
+	Service string   // the name of the service granted access
+}
+
+// parseGrants parses the sqldb.Grant calls in the package.
+// Grants must reference a database declared in the same package,
+// found in dbsByIdent by the name of the variable it's bound to.
+func parseGrants(p *resourceparser.Pass, dbsByIdent map[string]*Database) {
+	name := pkginfo.QualifiedName{PkgPath: "encore.dev/storage/sqldb", Name: "Grant"}
+
+	spec := &parseutil.ReferenceSpec{
+		MinTypeArgs: 0,
+		MaxTypeArgs: 0,
+		Parse: func(d parseutil.ReferenceInfo) {
+			parseGrant(d, dbsByIdent)
+		},
+	}
+
+	parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+		parseutil.ParseReference(p, spec, parseutil.ReferenceData{
+			File:         file,
+			Stack:        stack,
+			ResourceFunc: name,
+		})
+	})
+}
+
+func parseGrant(d parseutil.ReferenceInfo, dbsByIdent map[string]*Database) {
+	errs := d.Pass.Errs
+
+	if len(d.Call.Args) < 2 {
+		errs.Add(errGrantArgCount(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	} else if d.Call.Ellipsis.IsValid() {
+		errs.Add(errGrantRequiresServiceNameString.AtGoNode(d.Call.Args[len(d.Call.Args)-1]))
+		return
+	}
+
+	var db *Database
+	if id, ok := d.Call.Args[0].(*ast.Ident); ok {
+		db = dbsByIdent[id.Name]
+	}
+	if db == nil {
+		errs.Add(errGrantRequiresDatabase.AtGoNode(d.Call.Args[0]))
+		return
+	}
+
+	for _, arg := range d.Call.Args[1:] {
+		svcName, ok := literals.ParseString(arg)
+		if !ok {
+			errs.Add(errGrantRequiresServiceNameString.
+				AtGoNode(arg, errors.AsError(fmt.Sprintf("got %v", parseutil.NodeType(arg)))))
+			continue
+		} else if svcName == "" {
+			errs.Add(errGrantRequiresServiceNameString.AtGoNode(arg, errors.AsError("got an empty string")))
+			continue
+		}
+		db.Grants = append(db.Grants, Grant{AST: arg, Service: svcName})
+	}
+}
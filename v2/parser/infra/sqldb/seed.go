@@ -0,0 +1,129 @@
+package sqldb
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	perrors "encr.dev/pkg/errors"
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/perr"
+)
+
+// defaultSeedDir is the seed directory used when none is configured,
+// relative to the package directory.
+const defaultSeedDir = "seed"
+
+// Seeds describes the seed data for a database, used to populate
+// the database after applying migrations in development environments.
+type Seeds struct {
+	// Dir is the seed directory, relative to the main module.
+	Dir paths.MainModuleRelSlash
+
+	// Files are the SQL seed files, in the order to apply them.
+	Files []string
+
+	// Program is true if the seed directory contains
+	// a Go program for seeding the database.
+	Program bool
+}
+
+// parseSeeds parses the seed directory at dir.
+//
+// If strict is false, Go files that are not part of a main package are ignored,
+// so that an existing package named "seed" is not mistaken for a seed program.
+func parseSeeds(dir paths.FS, strict bool) (*Seeds, error) {
+	entries, err := os.ReadDir(dir.ToIO())
+	if err != nil {
+		return nil, fmt.Errorf("could not read seed directory: %v", err)
+	}
+
+	seeds := &Seeds{}
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		switch ext := strings.ToLower(filepath.Ext(name)); {
+		case ext == ".sql":
+			if strings.HasSuffix(strings.ToLower(name), ".down.sql") {
+				return nil, fmt.Errorf("seed file %s: seeds are only applied and cannot be reverted", name)
+			}
+			seeds.Files = append(seeds.Files, name)
+
+		case ext == ".go" && !strings.HasSuffix(name, "_test.go"):
+			f, err := parser.ParseFile(fset, dir.Join(name).ToIO(), nil, parser.PackageClauseOnly)
+			if err != nil {
+				return nil, fmt.Errorf("seed program %s: %v", name, err)
+			} else if f.Name.Name == "main" {
+				seeds.Program = true
+			} else if strict {
+				return nil, fmt.Errorf("seed program %s: must be package main, got package %s", name, f.Name.Name)
+			}
+		}
+	}
+	sort.Strings(seeds.Files)
+	return seeds, nil
+}
+
+// parseSeedDir resolves and parses the seed directory for a database
+// declared in the package at pkgDir. If seedPath is empty the default
+// seed directory is used, if it exists. Errors are reported at node, if non-nil.
+//
+// It reports nil if the database has no seeds or the seeds are invalid.
+func parseSeedDir(errs *perr.List, node ast.Node, mainModuleDir, pkgDir paths.FS, seedPath string) *Seeds {
+	at := func(t perrors.Template) perrors.Template {
+		if node != nil {
+			return t.AtGoNode(node)
+		}
+		return t
+	}
+
+	explicit := seedPath != ""
+	if !explicit {
+		seedPath = defaultSeedDir
+	} else if path.IsAbs(seedPath) || !filepath.IsLocal(filepath.FromSlash(seedPath)) {
+		errs.Add(at(errNewDatabaseSeedPath))
+		return nil
+	}
+
+	dir := pkgDir.Join(filepath.FromSlash(seedPath))
+	if fi, err := os.Stat(dir.ToIO()); errors.Is(err, fs.ErrNotExist) || (err == nil && !fi.IsDir()) {
+		if explicit {
+			errs.Add(at(errNewDatabaseSeedDirNotFound))
+		}
+		return nil
+	} else if err != nil {
+		errs.AddStd(err)
+		return nil
+	}
+
+	rel, err := filepath.Rel(mainModuleDir.ToIO(), dir.ToIO())
+	if err != nil || !filepath.IsLocal(rel) {
+		errs.Add(at(errNewDatabaseSeedPath))
+		return nil
+	}
+
+	seeds, err := parseSeeds(dir, explicit)
+	if err != nil {
+		errs.Add(at(errUnableToParseSeeds.Wrapping(err)))
+		return nil
+	} else if len(seeds.Files) == 0 && !seeds.Program {
+		if explicit {
+			errs.Add(at(errUnableToParseSeeds.Wrapping(errors.New("the seed directory contains no seed files"))))
+		}
+		return nil
+	}
+	seeds.Dir = paths.MainModuleRelSlash(filepath.ToSlash(rel))
+	return seeds
+}
@@ -32,6 +32,20 @@ type Database struct {
 	File         option.Option[*pkginfo.File]
 	MigrationDir paths.MainModuleRelSlash
 	Migrations   []MigrationFile
+
+	// Grants are the services granted access to the database using sqldb.Grant.
+	// If empty, the database can be accessed by any service.
+	Grants []Grant
+
+	// Seeds is the seed data for the database, if any.
+	Seeds option.Option[*Seeds]
+
+	// Tags are the tags to apply to the provisioned database.
+	Tags map[string]string
+
+	// Regions are the regions the database must be provisioned in.
+	// If empty, the database can be provisioned in any region.
+	Regions []string
 }
 
 func (d *Database) Kind() resource.Kind       { return resource.SQLDatabase }
@@ -54,10 +68,19 @@ var DatabaseParser = &resourceparser.Parser{
 	Run: func(p *resourceparser.Pass) {
 		name := pkginfo.QualifiedName{PkgPath: "encore.dev/storage/sqldb", Name: "NewDatabase"}
 
+		// Track the databases by the name of the variable they're bound to,
+		// so that grants within the package can reference them.
+		dbsByIdent := make(map[string]*Database)
+
 		spec := &parseutil.ReferenceSpec{
 			MinTypeArgs: 0,
 			MaxTypeArgs: 0,
-			Parse:       parseDatabase,
+			Parse: func(d parseutil.ReferenceInfo) {
+				db := parseDatabase(d)
+				if id, ok := d.Ident.Get(); ok && db != nil {
+					dbsByIdent[id.Name] = db
+				}
+			},
 		}
 
 		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
@@ -67,66 +90,77 @@ var DatabaseParser = &resourceparser.Parser{
 				ResourceFunc: name,
 			})
 		})
+
+		parseGrants(p, dbsByIdent)
 	},
 }
 
-func parseDatabase(d parseutil.ReferenceInfo) {
+func parseDatabase(d parseutil.ReferenceInfo) *Database {
 	errs := d.Pass.Errs
 
 	if len(d.Call.Args) != 2 {
 		errs.Add(errNewDatabaseArgCount(len(d.Call.Args)).AtGoNode(d.Call))
-		return
+		return nil
 	}
 
 	databaseName := parseutil.ParseResourceName(d.Pass.Errs, "sqldb.NewDatabase", "database name",
 		d.Call.Args[0], parseutil.SnakeName, "")
 	if databaseName == "" {
 		// we already reported the error inside ParseResourceName
-		return
+		return nil
 	}
 
 	cfgLit, ok := literals.ParseStruct(d.Pass.Errs, d.File, "sqldb.DatabaseConfig", d.Call.Args[1])
 	if !ok {
-		return // error reported by ParseStruct
+		return nil // error reported by ParseStruct
 	}
 
 	// Decode the config
 	type decodedConfig struct {
 		Migrations string `literal:",required"`
+		Seed       string
+		Tags       map[string]string `literal:",optional"`
+		Regions    []string          `literal:",optional"`
 	}
 	config := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
 
 	if path.IsAbs(config.Migrations) {
 		errs.Add(errNewDatabaseAbsPath.AtGoNode(cfgLit.Expr("Migrations")))
-		return
+		return nil
 	}
 	migDir := filepath.FromSlash(config.Migrations)
 	if !filepath.IsLocal(migDir) {
 		errs.Add(errNewDatabaseNonLocalPath.AtGoNode(cfgLit.Expr("Migrations")))
-		return
+		return nil
 	}
 
 	migrationDir := d.Pass.Pkg.FSPath.Join(migDir)
 	if fi, err := os.Stat(migrationDir.ToIO()); errors.Is(err, fs.ErrNotExist) || (err == nil && !fi.IsDir()) {
 		errs.Add(errNewDatabaseMigrationDirNotFound.AtGoNode(cfgLit.Expr("Migrations")))
-		return
+		return nil
 	} else if err != nil {
 		errs.AddStd(err)
-		return
+		return nil
 	}
 
 	// Compute the relative path to the migration directory from the main module.
 	relMigrationDir, err := filepath.Rel(d.Pass.MainModuleDir.ToIO(), migrationDir.ToIO())
 	if err != nil || !filepath.IsLocal(relMigrationDir) {
 		errs.Add(errMigrationsNotInMainModule)
-		return
+		return nil
 	}
 
 	migrations, err := parseMigrations(migrationDir)
 	if err != nil {
 		errs.Add(errUnableToParseMigrations.AtGoNode(cfgLit.Expr("Migrations")).Wrapping(err))
-		return
+		return nil
+	}
+
+	var seedNode ast.Node = cfgLit.Expr("Seed")
+	if !cfgLit.IsSet("Seed") {
+		seedNode = d.Call
 	}
+	seeds := parseSeedDir(errs, seedNode, d.Pass.MainModuleDir, d.Pass.Pkg.FSPath, config.Seed)
 
 	db := &Database{
 		AST:          d.Call,
@@ -135,9 +169,13 @@ func parseDatabase(d parseutil.ReferenceInfo) {
 		Doc:          d.Doc,
 		MigrationDir: paths.MainModuleRelSlash(filepath.ToSlash(relMigrationDir)),
 		Migrations:   migrations,
+		Seeds:        option.AsOptional(seeds),
+		Tags:         parseutil.ValidateTags(errs, cfgLit.Expr("Tags"), config.Tags),
+		Regions:      parseutil.ValidateRegions(errs, cfgLit.Expr("Regions"), config.Regions),
 	}
 	d.Pass.RegisterResource(db)
 	d.Pass.AddBind(d.File, d.Ident, db)
+	return db
 }
 
 var MigrationParser = &resourceparser.Parser{
@@ -180,6 +218,7 @@ var MigrationParser = &resourceparser.Parser{
 			Name:         p.Pkg.Name,
 			MigrationDir: paths.MainModuleRelSlash(filepath.ToSlash(relMigrationDir)),
 			Migrations:   migrations,
+			Seeds:        option.AsOptional(parseSeedDir(p.Errs, nil, p.MainModuleDir, p.Pkg.FSPath, "")),
 		}
 		p.RegisterResource(res)
 		p.AddImplicitBind(res)
@@ -3,6 +3,9 @@ package sqldb
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
+	"encr.dev/pkg/option"
 	"encr.dev/v2/parser/resource/resourcetest"
 )
 
@@ -58,7 +61,86 @@ var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
 `,
 			WantErrs: []string{`.*The migration path must be a relative path.*`},
 		},
+		{
+			Name: "seed_default",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+})
+-- migrations/foo.txt --
+-- seed/2_orders.sql --
+INSERT INTO orders (id) VALUES (1);
+-- seed/1_users.sql --
+INSERT INTO users (id) VALUES (1);
+-- seed/README.md --
+`,
+			Want: &Database{
+				Name:         "name",
+				MigrationDir: "migrations",
+				Seeds: option.Some(&Seeds{
+					Dir:   "seed",
+					Files: []string{"1_users.sql", "2_orders.sql"},
+				}),
+			},
+		},
+		{
+			Name: "seed_explicit",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+	Seed:       "testdata/seed",
+})
+-- migrations/foo.txt --
+-- testdata/seed/main.go --
+package main
+
+func main() {}
+`,
+			Want: &Database{
+				Name:         "name",
+				MigrationDir: "migrations",
+				Seeds: option.Some(&Seeds{
+					Dir:     "testdata/seed",
+					Program: true,
+				}),
+			},
+		},
+		{
+			Name: "seed_dir_not_found",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+	Seed:       "seed",
+})
+-- migrations/foo.txt --
+`,
+			WantErrs: []string{`.*The seed directory does not exist.*`},
+		},
+		{
+			Name: "seed_program_not_main",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+	Seed:       "seed",
+})
+-- migrations/foo.txt --
+-- seed/seed.go --
+package seed
+`,
+			WantErrs: []string{`.*must be package main.*`},
+		},
+		{
+			Name: "seed_down_file",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+})
+-- migrations/foo.txt --
+-- seed/1_users.down.sql --
+`,
+			WantErrs: []string{`.*cannot be reverted.*`},
+		},
 	}
 
-	resourcetest.Run(t, DatabaseParser, tests)
+	resourcetest.Run(t, DatabaseParser, tests, cmp.AllowUnexported(option.Option[*Seeds]{}))
 }
@@ -1,6 +1,8 @@
 package sqldb
 
 import (
+	"encr.dev/pkg/option"
+	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/parser/resource/usage"
 )
 
@@ -9,6 +11,12 @@ type DatabaseUsage struct {
 }
 
 func ResolveDatabaseUsage(data usage.ResolveData, db *Database) usage.Usage {
+	if arg, ok := data.Expr.(*usage.FuncArg); ok &&
+		option.Contains(arg.PkgFunc, pkginfo.Q("encore.dev/storage/sqldb", "Grant")) {
+		// Granting access is not a usage of the database.
+		return nil
+	}
+
 	return &DatabaseUsage{
 		Base: usage.Base{
 			File: data.Expr.DeclaredIn(),
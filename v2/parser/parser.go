@@ -14,10 +14,14 @@ import (
 	"encr.dev/v2/parser/apis/api"
 	"encr.dev/v2/parser/apis/authhandler"
 	"encr.dev/v2/parser/apis/servicestruct"
+	"encr.dev/v2/parser/infra/alerts"
 	"encr.dev/v2/parser/infra/caches"
 	"encr.dev/v2/parser/infra/config"
 	"encr.dev/v2/parser/infra/crons"
+	"encr.dev/v2/parser/infra/errdefs"
+	"encr.dev/v2/parser/infra/flags"
 	"encr.dev/v2/parser/infra/metrics"
+	"encr.dev/v2/parser/infra/monitors"
 	"encr.dev/v2/parser/infra/objects"
 	"encr.dev/v2/parser/infra/pubsub"
 	"encr.dev/v2/parser/infra/secrets"
@@ -137,12 +141,16 @@ func (p *Parser) Parse() *Result {
 
 // allParsers are all the resource parsers we support.
 var allParsers = []*resourceparser.Parser{
+	alerts.RuleParser,
 	apis.Parser,
 	caches.ClusterParser,
 	caches.KeyspaceParser,
 	config.LoadParser,
 	crons.JobParser,
+	errdefs.DefinitionParser,
+	flags.FlagParser,
 	metrics.MetricParser,
+	monitors.CheckParser,
 	pubsub.TopicParser,
 	pubsub.SubscriptionParser,
 	secrets.SecretsParser,
@@ -22,6 +22,10 @@ const (
 	ConfigLoad
 	Secrets
 	Bucket
+	MonitorCheck
+	AlertRule
+	FeatureFlag
+	ErrorDefinition
 
 	// API Framework Resources
 	APIEndpoint
@@ -19,15 +19,19 @@ func _() {
 	_ = x[ConfigLoad-8]
 	_ = x[Secrets-9]
 	_ = x[Bucket-10]
-	_ = x[APIEndpoint-11]
-	_ = x[AuthHandler-12]
-	_ = x[Middleware-13]
-	_ = x[ServiceStruct-14]
+	_ = x[MonitorCheck-11]
+	_ = x[AlertRule-12]
+	_ = x[FeatureFlag-13]
+	_ = x[ErrorDefinition-14]
+	_ = x[APIEndpoint-15]
+	_ = x[AuthHandler-16]
+	_ = x[Middleware-17]
+	_ = x[ServiceStruct-18]
 }
 
-const _Kind_name = "UnknownPubSubTopicPubSubSubscriptionSQLDatabaseMetricCronJobCacheClusterCacheKeyspaceConfigLoadSecretsBucketAPIEndpointAuthHandlerMiddlewareServiceStruct"
+const _Kind_name = "UnknownPubSubTopicPubSubSubscriptionSQLDatabaseMetricCronJobCacheClusterCacheKeyspaceConfigLoadSecretsBucketMonitorCheckAlertRuleFeatureFlagErrorDefinitionAPIEndpointAuthHandlerMiddlewareServiceStruct"
 
-var _Kind_index = [...]uint8{0, 7, 18, 36, 47, 53, 60, 72, 85, 95, 102, 108, 119, 130, 140, 153}
+var _Kind_index = [...]uint8{0, 7, 18, 36, 47, 53, 60, 72, 85, 95, 102, 108, 120, 129, 140, 155, 166, 177, 187, 200}
 
 func (i Kind) String() string {
 	if i < 0 || i >= Kind(len(_Kind_index)-1) {
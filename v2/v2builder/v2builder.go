@@ -139,6 +139,7 @@ func (*BuilderImpl) Compile(ctx context.Context, p builder.CompileParams) (*buil
 			Desc:              pd.appDesc,
 			MainModule:        pd.mainModule,
 			RuntimeModule:     pd.runtimeModule,
+			Meta:              p.Parse.Meta,
 			CompilerVersion:   p.EncoreVersion.GetOrElse(fmt.Sprintf("EncoreCLI/%s", version.Version)),
 			AppRevision:       p.Build.Revision,
 			AppUncommitted:    p.Build.UncommittedChanges,
@@ -225,8 +226,9 @@ func (*BuilderImpl) ServiceConfigs(ctx context.Context, p builder.ServiceConfigs
 		return nil, err
 	}
 	return &builder.ServiceConfigsResult{
-		Configs:     cfg.configs,
-		ConfigFiles: cfg.files,
+		Configs:      cfg.configs,
+		ConfigFiles:  cfg.files,
+		ConfigLayers: cfg.layers,
 	}, nil
 }
 
@@ -316,6 +318,7 @@ func (i *BuilderImpl) generateTestSpec(ctx context.Context, p builder.TestSpecPa
 				Desc:            pd.appDesc,
 				MainModule:      pd.mainModule,
 				RuntimeModule:   pd.runtimeModule,
+				Meta:            p.Compile.Parse.Meta,
 				CompilerVersion: p.Compile.EncoreVersion.GetOrElse(fmt.Sprintf("EncoreCLI/%s", version.Version)),
 				AppRevision:     p.Compile.Build.Revision,
 				AppUncommitted:  p.Compile.Build.UncommittedChanges,
@@ -362,6 +365,7 @@ func (i *BuilderImpl) testEnvVarsToEmbed(args, envs []string) map[string]string
 type configResult struct {
 	configs map[string]string
 	files   fs.FS
+	layers  map[string][]cueutil.Layer
 }
 
 func computeConfigs(errs *perr.List, desc *app.Desc, mainModule *pkginfo.Module, cueMeta *cueutil.Meta) configResult {
@@ -383,6 +387,7 @@ func computeConfigs(errs *perr.List, desc *app.Desc, mainModule *pkginfo.Module,
 	}
 
 	configs := make(map[string]string, len(desc.Services))
+	layers := make(map[string][]cueutil.Layer, len(desc.Services))
 	for _, svc := range desc.Services {
 		resourceNode, ok := serviceUsesConfig[svc.Name]
 		if !ok {
@@ -408,8 +413,11 @@ func computeConfigs(errs *perr.List, desc *app.Desc, mainModule *pkginfo.Module,
 		}
 
 		configs[svc.Name] = string(cfgData)
+		if layers[svc.Name], err = cueutil.Layers(files, rel, cueMeta); err != nil {
+			errs.AddStdNode(err, resourceNode)
+		}
 	}
-	return configResult{configs, files}
+	return configResult{configs, files, layers}
 }
 
 func pickupConfigFiles(errs *perr.List, mainModule *pkginfo.Module) fs.FS {